@@ -128,9 +128,10 @@ func init() {
 
 	var err error
 	env, err = vtenv.New(vtenv.Options{
-		MySQLServerVersion: servenv.MySQLServerVersion(),
-		TruncateUILen:      servenv.TruncateUILen,
-		TruncateErrLen:     servenv.TruncateErrLen,
+		MySQLServerVersion:  servenv.MySQLServerVersion(),
+		TruncateUILen:       servenv.TruncateUILen,
+		TruncateErrLen:      servenv.TruncateErrLen,
+		RedactQueryLiterals: servenv.RedactQueryLiterals,
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize env: %v", err)
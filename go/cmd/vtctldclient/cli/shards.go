@@ -121,3 +121,28 @@ func SortedReplicatingTablets(tabletMap map[string]*topodatapb.Tablet, replicati
 
 	return rtablets
 }
+
+// MarshalMissingTransactionsAWK returns, in an AWK-friendly format, the
+// transactions of the primary position that are missing from the position of
+// the given replication status. It returns "<null>" if no transactions are
+// missing, "<unknown>" if the position of the primary is not known, and "<err>"
+// if the positions cannot be compared.
+func MarshalMissingTransactionsAWK(primary *replication.Position, status *replicationdatapb.Status) string {
+	if primary == nil {
+		return "<unknown>"
+	}
+	pos, err := replication.DecodePosition(status.Position)
+	if err != nil {
+		return "<err>"
+	}
+
+	missing, err := primary.Diff(pos)
+	switch {
+	case err != nil:
+		return "<err>"
+	case missing == nil:
+		return "<null>"
+	}
+
+	return missing.String()
+}
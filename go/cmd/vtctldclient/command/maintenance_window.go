@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// DeleteMaintenanceWindow makes a DeleteMaintenanceWindow gRPC call to a vtctld.
+	DeleteMaintenanceWindow = &cobra.Command{
+		Use:                   "DeleteMaintenanceWindow <name>",
+		Short:                 "Deletes a maintenance window of the cluster.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDeleteMaintenanceWindow,
+	}
+	// GetMaintenanceWindows makes a GetMaintenanceWindows gRPC call to a vtctld.
+	GetMaintenanceWindows = &cobra.Command{
+		Use:                   "GetMaintenanceWindows",
+		Short:                 "Displays the maintenance windows of the cluster, and whether one of them is currently open.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetMaintenanceWindows,
+	}
+	// SetMaintenanceWindow makes a SetMaintenanceWindow gRPC call to a vtctld.
+	SetMaintenanceWindow = &cobra.Command{
+		Use:   "SetMaintenanceWindow --schedule <schedule> --duration <duration> <name>",
+		Short: "Creates or updates a maintenance window of the cluster, during which the Online DDL cut-overs and the VReplication copy phases are allowed to run.",
+		Long: `Creates or updates a maintenance window of the cluster. Once the cluster has maintenance windows,
+the Online DDL cut-overs and the VReplication copy phases only run while one of them is open. Forced
+cut-overs are not gated.
+
+The schedule is a cron-like spec of the starts of the window, as "minute hour day-of-month month day-of-week",
+in UTC. Each field is "*", a value, a range "a-b", or a list of them, optionally with a step as in "*/15".
+Days of week go from 0 (Sunday) to 7 (Sunday).`,
+		Example: `SetMaintenanceWindow --schedule "0 2 * * *" --duration 3h nightly
+SetMaintenanceWindow --schedule "0 0 * * 6" --duration 48h weekend`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetMaintenanceWindow,
+	}
+)
+
+func commandDeleteMaintenanceWindow(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	_, err := client.DeleteMaintenanceWindow(commandCtx, &vtctldatapb.DeleteMaintenanceWindowRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully deleted maintenance window %s\n", cmd.Flags().Arg(0))
+
+	return nil
+}
+
+func commandGetMaintenanceWindows(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetMaintenanceWindows(commandCtx, &vtctldatapb.GetMaintenanceWindowsRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var setMaintenanceWindowOptions = struct {
+	Schedule string
+	Duration time.Duration
+}{}
+
+func commandSetMaintenanceWindow(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	window := &topodatapb.MaintenanceWindow{
+		Name:     cmd.Flags().Arg(0),
+		Schedule: setMaintenanceWindowOptions.Schedule,
+		Duration: protoutil.DurationToProto(setMaintenanceWindowOptions.Duration),
+	}
+	_, err := client.SetMaintenanceWindow(commandCtx, &vtctldatapb.SetMaintenanceWindowRequest{
+		Window: window,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(window)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Maintenance window:\n%s\n", data)
+
+	return nil
+}
+
+func init() {
+	Root.AddCommand(DeleteMaintenanceWindow)
+
+	Root.AddCommand(GetMaintenanceWindows)
+
+	SetMaintenanceWindow.Flags().StringVar(&setMaintenanceWindowOptions.Schedule, "schedule", "", "Cron-like schedule of the starts of the window, in UTC.")
+	SetMaintenanceWindow.Flags().DurationVar(&setMaintenanceWindowOptions.Duration, "duration", 0, "How long the window stays open after each start.")
+	SetMaintenanceWindow.MarkFlagRequired("schedule")
+	SetMaintenanceWindow.MarkFlagRequired("duration")
+	Root.AddCommand(SetMaintenanceWindow)
+}
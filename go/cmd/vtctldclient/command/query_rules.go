@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyQueryRules makes an ApplyQueryRules gRPC call to a vtctld.
+	ApplyQueryRules = &cobra.Command{
+		Use:   "ApplyQueryRules {--rules RULES | --rules-file RULES_FILE} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run]",
+		Short: "Applies the provided query rules, which vtgate enforces on the queries it executes.",
+		Long: `Applies the provided query rules, which vtgate enforces on the queries it executes.
+
+The rules are evaluated in order, and the first rule matching a query decides its action:
+ALLOW executes the query, DENY fails it, WARN executes it with a warning, and REDIRECT
+sends it to the tablets of the redirect_tablet_type. A rule matches the queries meeting
+all of its non-empty conditions: query (a regular expression on the normalized query),
+users, tables, plan_types and route_types.
+
+Example:
+{"rules": [{"name": "no_scatter_deletes", "plan_types": ["DELETE"], "route_types": ["Scatter"], "action": "DENY"}]}`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyQueryRules,
+	}
+	// GetQueryRules makes a GetQueryRules gRPC call to a vtctld.
+	GetQueryRules = &cobra.Command{
+		Use:                   "GetQueryRules",
+		Short:                 "Displays the query rules enforced by vtgate as a JSON document.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetQueryRules,
+	}
+)
+
+var applyQueryRulesOptions = struct {
+	Rules         string
+	RulesFilePath string
+	Cells         []string
+	SkipRebuild   bool
+	DryRun        bool
+}{}
+
+func commandApplyQueryRules(cmd *cobra.Command, args []string) error {
+	if applyQueryRulesOptions.Rules != "" && applyQueryRulesOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", applyQueryRulesOptions.Rules, applyQueryRulesOptions.RulesFilePath)
+	}
+
+	if applyQueryRulesOptions.Rules == "" && applyQueryRulesOptions.RulesFilePath == "" {
+		return errors.New("must pass exactly one of --rules or --rules-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var rulesBytes []byte
+	if applyQueryRulesOptions.RulesFilePath != "" {
+		data, err := os.ReadFile(applyQueryRulesOptions.RulesFilePath)
+		if err != nil {
+			return err
+		}
+
+		rulesBytes = data
+	} else {
+		rulesBytes = []byte(applyQueryRulesOptions.Rules)
+	}
+
+	qr := &vschemapb.QueryRules{}
+	if err := json2.Unmarshal(rulesBytes, &qr); err != nil {
+		return err
+	}
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(qr)
+	if err != nil {
+		return err
+	}
+
+	if applyQueryRulesOptions.DryRun {
+		fmt.Printf("[DRY RUN] Would have saved new QueryRules object:\n%s\n", data)
+
+		if applyQueryRulesOptions.SkipRebuild {
+			fmt.Println("[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
+		} else {
+			fmt.Print("[DRY RUN] Would have rebuilt the VSchema graph")
+			if len(applyQueryRulesOptions.Cells) == 0 {
+				fmt.Print(" in all cells\n")
+			} else {
+				fmt.Printf(" in the following cells: %s.\n", strings.Join(applyQueryRulesOptions.Cells, ", "))
+			}
+		}
+
+		return nil
+	}
+
+	_, err = client.ApplyQueryRules(commandCtx, &vtctldatapb.ApplyQueryRulesRequest{
+		QueryRules:   qr,
+		SkipRebuild:  applyQueryRulesOptions.SkipRebuild,
+		RebuildCells: applyQueryRulesOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New QueryRules object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	if applyQueryRulesOptions.SkipRebuild {
+		fmt.Println("Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+func commandGetQueryRules(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetQueryRules(commandCtx, &vtctldatapb.GetQueryRulesRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.QueryRules)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyQueryRules.Flags().StringVarP(&applyQueryRulesOptions.Rules, "rules", "r", "", "Query rules, specified as a string")
+	ApplyQueryRules.Flags().StringVarP(&applyQueryRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing query rules specified as JSON")
+	ApplyQueryRules.Flags().StringSliceVarP(&applyQueryRulesOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyQueryRules.Flags().BoolVar(&applyQueryRulesOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	ApplyQueryRules.Flags().BoolVarP(&applyQueryRulesOptions.DryRun, "dry-run", "d", false, "Note actions that would be taken, but do not actually apply the rules to the topo.")
+	Root.AddCommand(ApplyQueryRules)
+
+	Root.AddCommand(GetQueryRules)
+}
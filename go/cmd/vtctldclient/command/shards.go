@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -164,6 +165,7 @@ useful after a MoveTables has finished to remove serving restrictions.`,
 		Use: "ShardReplicationPositions <keyspace/shard>",
 		Long: `Shows the replication status of each tablet in the shard graph.
 Output is sorted by tablet type, then replication position.
+The last column lists the GTIDs of the primary that each tablet is missing.
 Use ctrl-C to interrupt the command and see partial results if needed.`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
@@ -494,14 +496,28 @@ func commandShardReplicationPositions(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	for _, rt := range cli.SortedReplicatingTablets(resp.TabletMap, resp.ReplicationStatuses) {
+	rtablets := cli.SortedReplicatingTablets(resp.TabletMap, resp.ReplicationStatuses)
+
+	// The missing transactions of each tablet are computed against the
+	// position of the primary, if there is one.
+	var primaryPosition *replication.Position
+	for _, rt := range rtablets {
+		if rt.Status != nil && rt.Tablet.Type == topodatapb.TabletType_PRIMARY {
+			if pos, err := replication.DecodePosition(rt.Status.Position); err == nil {
+				primaryPosition = &pos
+			}
+			break
+		}
+	}
+
+	for _, rt := range rtablets {
 		var line string
 
 		switch rt.Status {
 		case nil:
-			line = cli.MarshalTabletAWK(rt.Tablet) + "<err> <err> <err>"
+			line = cli.MarshalTabletAWK(rt.Tablet) + " <err> <err> <err>"
 		default:
-			line = cli.MarshalTabletAWK(rt.Tablet) + fmt.Sprintf(" %v %v", rt.Status.Position, rt.Status.ReplicationLagSeconds)
+			line = cli.MarshalTabletAWK(rt.Tablet) + fmt.Sprintf(" %v %v %v", rt.Status.Position, rt.Status.ReplicationLagSeconds, cli.MarshalMissingTransactionsAWK(primaryPosition, rt.Status))
 		}
 
 		fmt.Println(line)
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyTableACL makes an ApplyTableACL gRPC call to a vtctld.
+	ApplyTableACL = &cobra.Command{
+		Use:   "ApplyTableACL {--acl ACL | --acl-file ACL_FILE} [--dry-run]",
+		Short: "Validates and applies the table ACL of the cluster, loaded by the tablets started with --table-acl-from-topo.",
+		Long: `Validates and applies the table ACL of the cluster, loaded by the tablets started with
+--table-acl-from-topo, which reload it whenever it changes.
+
+The names of the table groups may end with '%' to match a prefix, or contain '%' anywhere else to
+match any characters. The readers, writers and admins may refer to the user groups with
+"group:NAME". A table group with keyspaces only applies to the tablets of these keyspaces.
+
+Example:
+{"table_groups": [{"name": "logs", "table_names_or_prefixes": ["%_log"], "readers": ["group:analysts"], "keyspaces": ["commerce"]}],
+ "user_groups": [{"name": "analysts", "users": ["alice", "bob"]}]}`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyTableACL,
+	}
+	// GetTableACL makes a GetTableACL gRPC call to a vtctld.
+	GetTableACL = &cobra.Command{
+		Use:                   "GetTableACL",
+		Short:                 "Displays the table ACL of the cluster.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetTableACL,
+	}
+)
+
+var applyTableACLOptions = struct {
+	ACL         string
+	ACLFilePath string
+	DryRun      bool
+}{}
+
+func commandApplyTableACL(cmd *cobra.Command, args []string) error {
+	if applyTableACLOptions.ACL != "" && applyTableACLOptions.ACLFilePath != "" {
+		return fmt.Errorf("cannot pass both --acl (=%s) and --acl-file (=%s)", applyTableACLOptions.ACL, applyTableACLOptions.ACLFilePath)
+	}
+
+	if applyTableACLOptions.ACL == "" && applyTableACLOptions.ACLFilePath == "" {
+		return errors.New("must pass exactly one of --acl or --acl-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var aclBytes []byte
+	if applyTableACLOptions.ACLFilePath != "" {
+		data, err := os.ReadFile(applyTableACLOptions.ACLFilePath)
+		if err != nil {
+			return err
+		}
+
+		aclBytes = data
+	} else {
+		aclBytes = []byte(applyTableACLOptions.ACL)
+	}
+
+	config := &tableaclpb.Config{}
+	if err := json2.Unmarshal(aclBytes, config); err != nil {
+		return err
+	}
+
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(config)
+	if err != nil {
+		return err
+	}
+
+	// The vtctld validates the table ACL even in dry run mode.
+	_, err = client.ApplyTableACL(commandCtx, &vtctldatapb.ApplyTableACLRequest{
+		TableAcl: config,
+		DryRun:   applyTableACLOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if applyTableACLOptions.DryRun {
+		fmt.Printf("[DRY RUN] Would have saved new table ACL:\n%s\n", data)
+		return nil
+	}
+
+	fmt.Printf("New table ACL:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	return nil
+}
+
+func commandGetTableACL(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTableACL(commandCtx, &vtctldatapb.GetTableACLRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.TableAcl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyTableACL.Flags().StringVarP(&applyTableACLOptions.ACL, "acl", "a", "", "Table ACL, specified as a string")
+	ApplyTableACL.Flags().StringVarP(&applyTableACLOptions.ACLFilePath, "acl-file", "f", "", "Path to a file containing the table ACL specified as JSON")
+	ApplyTableACL.Flags().BoolVarP(&applyTableACLOptions.DryRun, "dry-run", "d", false, "Validate the table ACL, but do not actually save it to the topo.")
+	Root.AddCommand(ApplyTableACL)
+
+	Root.AddCommand(GetTableACL)
+}
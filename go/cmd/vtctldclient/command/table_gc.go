@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// UpdateTableGCConfig makes a UpdateTableGCConfig gRPC call to a vtctld.
+	UpdateTableGCConfig = &cobra.Command{
+		Use:   "UpdateTableGCConfig [--retention=<duration>] [--purge-partitions|--no-purge-partitions] [--purge-now] <keyspace>",
+		Short: "Update the table lifecycle (table GC) configuration for all tablets in the given keyspace (across all cells)",
+		Long: `Update the table lifecycle (table GC) configuration for all tablets in the given keyspace (across all cells).
+
+--retention sets how long tables are held in HOLD state before being purged and dropped. A zero value resets to the tablets' --retain-online-ddl-tables.
+--purge-partitions purges partitioned tables by truncating their partitions, rather than by deleting rows in chunks.
+--purge-now transitions all tables currently in the table lifecycle straight to DROP state, disregarding retention. Use with care.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandUpdateTableGCConfig,
+	}
+)
+
+var updateTableGCConfigOptions = struct {
+	Retention              time.Duration
+	EnablePurgePartitions  bool
+	DisablePurgePartitions bool
+	PurgeNow               bool
+}{}
+
+func commandUpdateTableGCConfig(cmd *cobra.Command, args []string) error {
+	keyspace := cmd.Flags().Arg(0)
+	cli.FinishedParsing(cmd)
+
+	if updateTableGCConfigOptions.EnablePurgePartitions && updateTableGCConfigOptions.DisablePurgePartitions {
+		return fmt.Errorf("--purge-partitions and --no-purge-partitions are mutually exclusive")
+	}
+
+	resp, err := client.UpdateTableGCConfig(commandCtx, &vtctldatapb.UpdateTableGCConfigRequest{
+		Keyspace:               keyspace,
+		Retention:              protoutil.DurationToProto(updateTableGCConfigOptions.Retention),
+		RetentionSet:           cmd.Flags().Changed("retention"),
+		EnablePurgePartitions:  updateTableGCConfigOptions.EnablePurgePartitions,
+		DisablePurgePartitions: updateTableGCConfigOptions.DisablePurgePartitions,
+		PurgeNow:               updateTableGCConfigOptions.PurgeNow,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.TableGcConfig)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func init() {
+	UpdateTableGCConfig.Flags().DurationVar(&updateTableGCConfigOptions.Retention, "retention", 0, "How long to hold tables before purging and dropping them. Zero resets to the tablets' --retain-online-ddl-tables.")
+	UpdateTableGCConfig.Flags().BoolVar(&updateTableGCConfigOptions.EnablePurgePartitions, "purge-partitions", false, "Purge partitioned tables by truncating their partitions rather than by deleting rows.")
+	UpdateTableGCConfig.Flags().BoolVar(&updateTableGCConfigOptions.DisablePurgePartitions, "no-purge-partitions", false, "Purge partitioned tables by deleting rows, like any other table.")
+	UpdateTableGCConfig.Flags().BoolVar(&updateTableGCConfigOptions.PurgeNow, "purge-now", false, "Transition all tables in the table lifecycle straight to DROP state, disregarding retention.")
+
+	Root.AddCommand(UpdateTableGCConfig)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyTabletQueryRules makes an ApplyTabletQueryRules gRPC call to a vtctld.
+	ApplyTabletQueryRules = &cobra.Command{
+		Use:   "ApplyTabletQueryRules --path PATH {--rules RULES | --rules-file RULES_FILE} [--cell CELL] [--dry-run]",
+		Short: "Applies the provided query rules to the topo file watched by the tablets started with --topocustomrule_path.",
+		Long: `Applies the provided query rules to the topo file watched by the tablets started with
+--topocustomrule_cell=CELL and --topocustomrule_path=PATH.
+
+Besides the actions of the tablet query rules (FAIL, FAIL_RETRY), the LIMIT action bounds the
+queries matching a rule with a QueryTimeout (a duration), a MaxRows and a MaxMemory (in bytes).
+When several LIMIT rules match a query, the lowest of each limit applies.
+
+Example:
+[{"Name": "bound_analytics", "TableNames": ["events"], "Plans": ["Select"], "Action": "LIMIT", "QueryTimeout": "30s", "MaxRows": 100000}]`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyTabletQueryRules,
+	}
+	// GetTabletQueryRules makes a GetTabletQueryRules gRPC call to a vtctld.
+	GetTabletQueryRules = &cobra.Command{
+		Use:                   "GetTabletQueryRules --path PATH [--cell CELL]",
+		Short:                 "Displays the tablet query rules stored in the given topo file.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetTabletQueryRules,
+	}
+)
+
+var applyTabletQueryRulesOptions = struct {
+	Cell          string
+	Path          string
+	Rules         string
+	RulesFilePath string
+	DryRun        bool
+}{}
+
+func commandApplyTabletQueryRules(cmd *cobra.Command, args []string) error {
+	if applyTabletQueryRulesOptions.Rules != "" && applyTabletQueryRulesOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", applyTabletQueryRulesOptions.Rules, applyTabletQueryRulesOptions.RulesFilePath)
+	}
+
+	if applyTabletQueryRulesOptions.Rules == "" && applyTabletQueryRulesOptions.RulesFilePath == "" {
+		return errors.New("must pass exactly one of --rules or --rules-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var rulesBytes []byte
+	if applyTabletQueryRulesOptions.RulesFilePath != "" {
+		data, err := os.ReadFile(applyTabletQueryRulesOptions.RulesFilePath)
+		if err != nil {
+			return err
+		}
+
+		rulesBytes = data
+	} else {
+		rulesBytes = []byte(applyTabletQueryRulesOptions.Rules)
+	}
+
+	// Round-trip so when we display the result it's readable, and so invalid
+	// rules are rejected before reaching the vtctld.
+	qrs := rules.New()
+	if err := qrs.UnmarshalJSON(rulesBytes); err != nil {
+		return err
+	}
+	data, err := qrs.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	if applyTabletQueryRulesOptions.DryRun {
+		fmt.Printf("[DRY RUN] Would have saved new tablet query rules to %s:\n%s\n", applyTabletQueryRulesOptions.Path, data)
+		return nil
+	}
+
+	_, err = client.ApplyTabletQueryRules(commandCtx, &vtctldatapb.ApplyTabletQueryRulesRequest{
+		Cell:  applyTabletQueryRulesOptions.Cell,
+		Path:  applyTabletQueryRulesOptions.Path,
+		Rules: string(rulesBytes),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New tablet query rules:\n%s\n", data)
+
+	return nil
+}
+
+var getTabletQueryRulesOptions = struct {
+	Cell string
+	Path string
+}{}
+
+func commandGetTabletQueryRules(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTabletQueryRules(commandCtx, &vtctldatapb.GetTabletQueryRulesRequest{
+		Cell: getTabletQueryRulesOptions.Cell,
+		Path: getTabletQueryRulesOptions.Path,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", resp.Rules)
+
+	return nil
+}
+
+func init() {
+	ApplyTabletQueryRules.Flags().StringVar(&applyTabletQueryRulesOptions.Cell, "cell", "", "Cell of the topo server storing the rules. Defaults to the global topo.")
+	ApplyTabletQueryRules.Flags().StringVar(&applyTabletQueryRulesOptions.Path, "path", "", "Path of the rules file in the topo, as passed to the tablets with --topocustomrule_path.")
+	ApplyTabletQueryRules.MarkFlagRequired("path")
+	ApplyTabletQueryRules.Flags().StringVarP(&applyTabletQueryRulesOptions.Rules, "rules", "r", "", "Tablet query rules, specified as a string")
+	ApplyTabletQueryRules.Flags().StringVarP(&applyTabletQueryRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing tablet query rules specified as JSON")
+	ApplyTabletQueryRules.Flags().BoolVarP(&applyTabletQueryRulesOptions.DryRun, "dry-run", "d", false, "Note actions that would be taken, but do not actually apply the rules to the topo.")
+	Root.AddCommand(ApplyTabletQueryRules)
+
+	GetTabletQueryRules.Flags().StringVar(&getTabletQueryRulesOptions.Cell, "cell", "", "Cell of the topo server storing the rules. Defaults to the global topo.")
+	GetTabletQueryRules.Flags().StringVar(&getTabletQueryRulesOptions.Path, "path", "", "Path of the rules file in the topo, as passed to the tablets with --topocustomrule_path.")
+	GetTabletQueryRules.MarkFlagRequired("path")
+	Root.AddCommand(GetTabletQueryRules)
+}
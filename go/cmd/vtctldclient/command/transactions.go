@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// DistributedTransaction is the parent command for the distributed transaction
+	// (two-phase commit) related commands.
+	DistributedTransaction = &cobra.Command{
+		Use:                   "DistributedTransaction <cmd>",
+		Short:                 "Perform commands on distributed transactions.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+	}
+
+	// DistributedTransactionList makes a GetUnresolvedTransactions gRPC call to a vtctld.
+	DistributedTransactionList = &cobra.Command{
+		Use:   "list <keyspace>",
+		Short: "Lists the unresolved distributed transactions of a keyspace.",
+		Example: `DistributedTransaction list commerce
+DistributedTransaction list --abandon-age 5m commerce`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDistributedTransactionList,
+	}
+
+	// DistributedTransactionRead makes a GetTransactionInfo gRPC call to a vtctld.
+	DistributedTransactionRead = &cobra.Command{
+		Use:                   "read <dtid>",
+		Short:                 "Displays the metadata of a distributed transaction: its state and participants.",
+		Example:               `DistributedTransaction read commerce:0:1234`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDistributedTransactionRead,
+	}
+
+	// DistributedTransactionConclude makes a ConcludeTransaction gRPC call to a vtctld.
+	DistributedTransactionConclude = &cobra.Command{
+		Use:   "conclude <dtid>",
+		Short: "Force-resolves an unresolved distributed transaction.",
+		Long: `Force-resolves an unresolved distributed transaction.
+
+The participants are committed if the metadata manager recorded the decision to commit the
+transaction, and rolled back otherwise. Its metadata is deleted once all of them are resolved.`,
+		Example:               `DistributedTransaction conclude commerce:0:1234`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDistributedTransactionConclude,
+	}
+)
+
+var distributedTransactionListOptions = struct {
+	AbandonAge time.Duration
+}{}
+
+func commandDistributedTransactionList(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetUnresolvedTransactions(commandCtx, &vtctldatapb.GetUnresolvedTransactionsRequest{
+		Keyspace:   cmd.Flags().Arg(0),
+		AbandonAge: int64(distributedTransactionListOptions.AbandonAge.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func commandDistributedTransactionRead(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTransactionInfo(commandCtx, &vtctldatapb.GetTransactionInfoRequest{
+		Dtid: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func commandDistributedTransactionConclude(cmd *cobra.Command, args []string) error {
+	dtid := cmd.Flags().Arg(0)
+
+	cli.FinishedParsing(cmd)
+
+	_, err := client.ConcludeTransaction(commandCtx, &vtctldatapb.ConcludeTransactionRequest{
+		Dtid: dtid,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully concluded the distributed transaction %s.\n", dtid)
+	return nil
+}
+
+func init() {
+	DistributedTransactionList.Flags().DurationVar(&distributedTransactionListOptions.AbandonAge, "abandon-age", 0, "Only list the transactions older than this age. All the unresolved transactions are listed by default.")
+	DistributedTransaction.AddCommand(DistributedTransactionList)
+	DistributedTransaction.AddCommand(DistributedTransactionRead)
+	DistributedTransaction.AddCommand(DistributedTransactionConclude)
+
+	Root.AddCommand(DistributedTransaction)
+}
@@ -178,6 +178,18 @@ var (
 		Args:                  cobra.NoArgs,
 		RunE:                  commandShow,
 	}
+
+	// status makes a LookupVindexStatus call to a vtctld.
+	status = &cobra.Command{
+		Use:                   "status",
+		Short:                 "Show the progress of the VReplication workflow that backfills the Lookup Vindex: the rows copied, the lag, and the estimated time left.",
+		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer status`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Status", "progress", "Progress"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandStatus,
+	}
 )
 
 func commandCancel(cmd *cobra.Command, args []string) error {
@@ -273,6 +285,27 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandStatus(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().LookupVindexStatus(common.GetCommandCtx(), &vtctldatapb.LookupVindexStatusRequest{
+		Name:          baseOptions.Name,
+		TableKeyspace: baseOptions.TableKeyspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func registerCommands(root *cobra.Command) {
 	base.PersistentFlags().StringVar(&baseOptions.Name, "name", "", "The name of the Lookup Vindex to create. This will also be the name of the VReplication workflow created to backfill the Lookup Vindex.")
 	base.MarkPersistentFlagRequired("name")
@@ -304,6 +337,10 @@ func registerCommands(root *cobra.Command) {
 	// for the VReplication workflow used.
 	base.AddCommand(show)
 
+	// This will show the backfill progress of the VReplication
+	// workflow used, from a LookupVindexStatus client call.
+	base.AddCommand(status)
+
 	// This will also delete the VReplication workflow if the
 	// vindex has an owner as the lookup vindex will then be
 	// managed by VTGate.
@@ -30,16 +30,17 @@ import (
 
 var (
 	createOptions = struct {
-		SourceKeyspace      string
-		SourceShards        []string
-		ExternalClusterName string
-		AllTables           bool
-		IncludeTables       []string
-		ExcludeTables       []string
-		SourceTimeZone      string
-		NoRoutingRules      bool
-		AtomicCopy          bool
-		WorkflowOptions     vtctldatapb.WorkflowOptions
+		SourceKeyspace            string
+		AdditionalSourceKeyspaces []string
+		SourceShards              []string
+		ExternalClusterName       string
+		AllTables                 bool
+		IncludeTables             []string
+		ExcludeTables             []string
+		SourceTimeZone            string
+		NoRoutingRules            bool
+		AtomicCopy                bool
+		WorkflowOptions           vtctldatapb.WorkflowOptions
 	}{}
 
 	// create makes a MoveTablesCreate gRPC call to a vtctld.
@@ -86,6 +87,14 @@ var (
 			if tenantId != "" && len(createOptions.SourceShards) > 0 {
 				return fmt.Errorf("cannot specify both --tenant-id (i.e. a multi-tenant migration) and --source-shards (i.e. a shard-by-shard migration)")
 			}
+			if len(createOptions.AdditionalSourceKeyspaces) > 0 {
+				if tenantId != "" || len(createOptions.SourceShards) > 0 {
+					return fmt.Errorf("cannot specify --additional-source-keyspaces (i.e. a multi-source migration) along with --tenant-id or --source-shards")
+				}
+				if createOptions.AtomicCopy {
+					return fmt.Errorf("cannot specify --additional-source-keyspaces (i.e. a multi-source migration) along with --atomic-copy")
+				}
+			}
 
 			return nil
 		},
@@ -105,6 +114,7 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		Workflow:                  common.BaseOptions.Workflow,
 		TargetKeyspace:            common.BaseOptions.TargetKeyspace,
 		SourceKeyspace:            createOptions.SourceKeyspace,
+		AdditionalSourceKeyspaces: createOptions.AdditionalSourceKeyspaces,
 		SourceShards:              createOptions.SourceShards,
 		SourceTimeZone:            createOptions.SourceTimeZone,
 		Cells:                     common.CreateOptions.Cells,
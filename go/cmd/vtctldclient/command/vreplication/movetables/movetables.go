@@ -40,6 +40,7 @@ func registerCommands(root *cobra.Command) {
 	common.AddCommonCreateFlags(create)
 	create.PersistentFlags().StringVar(&createOptions.SourceKeyspace, "source-keyspace", "", "Keyspace where the tables are being moved from.")
 	create.MarkPersistentFlagRequired("source-keyspace")
+	create.Flags().StringSliceVar(&createOptions.AdditionalSourceKeyspaces, "additional-source-keyspaces", nil, "(EXPERIMENTAL) Additional keyspaces to move tables from, merging them along with --source-keyspace into the target keyspace. Each table is moved from the source keyspace which has it, by a workflow per source keyspace, and traffic is switched for all of them together.")
 	create.Flags().StringSliceVar(&createOptions.SourceShards, "source-shards", nil, "Source shards to copy data from when performing a partial MoveTables (experimental).")
 	create.Flags().StringVar(&createOptions.SourceTimeZone, "source-time-zone", "", "Specifying this causes any DATETIME fields to be converted from the given time zone into UTC.")
 	create.Flags().BoolVar(&createOptions.AllTables, "all-tables", false, "Copy all tables from the source.")
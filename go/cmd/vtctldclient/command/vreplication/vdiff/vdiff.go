@@ -68,6 +68,9 @@ var (
 		WaitUpdateInterval          time.Duration
 		AutoRetry                   bool
 		MaxDiffDuration             time.Duration
+		IncrementalFromPosition     string
+		Repair                      bool
+		RepairDryRun                bool
 	}{}
 
 	deleteOptions = struct {
@@ -293,6 +296,9 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		AutoRetry:                   createOptions.AutoRetry,
 		MaxReportSampleRows:         createOptions.MaxReportSampleRows,
 		MaxDiffDuration:             protoutil.DurationToProto(createOptions.MaxDiffDuration),
+		IncrementalFromPosition:     createOptions.IncrementalFromPosition,
+		Repair:                      createOptions.Repair,
+		RepairDryRun:                createOptions.RepairDryRun,
 	})
 
 	if err != nil {
@@ -396,6 +402,8 @@ type tableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
+	RowsToRepair    int64  `json:"RowsToRepair,omitempty"`
+	RepairedRows    int64  `json:"RepairedRows,omitempty"`
 	LastUpdated     string `json:"LastUpdated,omitempty"`
 }
 
@@ -436,6 +444,8 @@ Table {{$table.TableName}}:
 {{if $table.MismatchedRows}}	MismatchedRows:   {{$table.MismatchedRows}}{{end}}
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
+{{if $table.RowsToRepair}}	RowsToRepair:     {{$table.RowsToRepair}}{{end}}
+{{if $table.RepairedRows}}	RepairedRows:     {{$table.RepairedRows}}{{end}}
 {{end}}
  
 Use "--format=json" for more detailed output.
@@ -735,6 +745,8 @@ func buildSingleSummary(keyspace, workflow, uuid string, resp *vtctldatapb.VDiff
 						ts.MatchingRows += dr.MatchingRows
 						ts.ExtraRowsTarget += dr.ExtraRowsTarget
 						ts.ExtraRowsSource += dr.ExtraRowsSource
+						ts.RowsToRepair += dr.RowsToRepair
+						ts.RepairedRows += dr.RepairedRows
 					}
 					if _, ok := reports[table]; !ok {
 						reports[table] = make(map[string]vdiff.DiffReport)
@@ -886,6 +898,9 @@ func registerCommands(root *cobra.Command) {
 	create.Flags().BoolVar(&createOptions.AutoRetry, "auto-retry", true, "Should this vdiff automatically retry and continue in case of recoverable errors.")
 	create.Flags().BoolVar(&createOptions.UpdateTableStats, "update-table-stats", false, "Update the table statistics, using ANALYZE TABLE, on each table involved in the VDiff during initialization. This will ensure that progress estimates are as accurate as possible -- but it does involve locks and can potentially impact query processing on the target keyspace.")
 	create.Flags().DurationVar(&createOptions.MaxDiffDuration, "max-diff-duration", 0, "How long should an individual table diff run before being stopped and restarted in order to lessen the impact on tablets due to holding open database snapshots for long periods of time (0 is the default and means no time limit).")
+	create.Flags().StringVar(&createOptions.IncrementalFromPosition, "incremental-from-position", "", "Only diff the rows modified on the source since this GTID position (e.g. MySQL56/<uuid>:1-100), using the row images of the binlog events. This requires binlog_row_image=full on the source.")
+	create.Flags().BoolVar(&createOptions.Repair, "repair", false, "Repair the rows found to be different on the target: insert the missing rows, delete the extra rows and update the mismatched rows. The workflow is stopped on the target while each table is diffed.")
+	create.Flags().BoolVar(&createOptions.RepairDryRun, "repair-dry-run", false, "Report the statements which would repair the rows found to be different on the target, without applying them. The workflow is stopped on the target while each table is diffed.")
 	base.AddCommand(create)
 
 	base.AddCommand(delete)
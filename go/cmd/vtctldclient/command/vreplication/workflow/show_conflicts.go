@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// showConflicts makes a GetWorkflows gRPC call to a vtctld, including the
+	// conflicts detected by the streams of the workflow.
+	showConflicts = &cobra.Command{
+		Use:   "show-conflicts",
+		Short: "Show the conflicts detected by a reverse VReplication workflow.",
+		Long: `Show the conflicts detected by a reverse VReplication workflow.
+
+After traffic is switched, the reverse workflow replicates the writes of the target keyspace back to the original source
+keyspace. Row events which do not apply cleanly there, i.e. inserts of rows which already exist (duplicate_key) and
+updates or deletes of rows which do not exist (missing_row), are recorded as conflicts with the image of the row, the
+GTID position of the stream and the error.`,
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace commerce show-conflicts --workflow commerce2customer_reverse`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"ShowConflicts"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandShowConflicts,
+	}
+)
+
+// workflowConflict is a conflict of a stream of the workflow, as output by
+// show-conflicts.
+type workflowConflict struct {
+	Shard     string          `json:"shard"`
+	Tablet    string          `json:"tablet"`
+	StreamID  int64           `json:"stream_id"`
+	Table     string          `json:"table"`
+	Type      string          `json:"type"`
+	RowImage  json.RawMessage `json:"row_image"`
+	GTID      string          `json:"gtid"`
+	Error     string          `json:"error,omitempty"`
+	Count     int64           `json:"count"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+}
+
+func commandShowConflicts(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.GetWorkflowsRequest{
+		Keyspace:         baseOptions.Keyspace,
+		Workflow:         baseOptions.Workflow,
+		IncludeConflicts: true,
+		Shards:           baseOptions.Shards,
+	}
+	resp, err := common.GetClient().GetWorkflows(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+	if len(resp.Workflows) == 0 {
+		return fmt.Errorf("workflow %s not found in keyspace %s", baseOptions.Workflow, baseOptions.Keyspace)
+	}
+
+	conflicts := make([]*workflowConflict, 0)
+	for _, shardStreams := range resp.Workflows[0].ShardStreams {
+		for _, stream := range shardStreams.Streams {
+			for _, conflict := range stream.Conflicts {
+				rowImage := json.RawMessage(conflict.RowImage)
+				if !json.Valid(rowImage) {
+					rowImage, _ = json.Marshal(conflict.RowImage)
+				}
+				conflicts = append(conflicts, &workflowConflict{
+					Shard:     stream.Shard,
+					Tablet:    topoproto.TabletAliasString(stream.Tablet),
+					StreamID:  stream.Id,
+					Table:     conflict.TableName,
+					Type:      conflict.Type,
+					RowImage:  rowImage,
+					GTID:      conflict.Gtid,
+					Error:     conflict.Error,
+					Count:     conflict.Count,
+					CreatedAt: protoutil.TimeFromProto(conflict.CreatedAt).UTC().String(),
+					UpdatedAt: protoutil.TimeFromProto(conflict.UpdatedAt).UTC().String(),
+				})
+			}
+		}
+	}
+	// The conflicts of a stream are already in the order they were detected.
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].Shard != conflicts[j].Shard {
+			return conflicts[i].Shard < conflicts[j].Shard
+		}
+		return conflicts[i].StreamID < conflicts[j].StreamID
+	})
+
+	data, err := cli.MarshalJSONPretty(conflicts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+
+	return nil
+}
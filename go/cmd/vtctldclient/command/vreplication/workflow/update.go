@@ -18,6 +18,7 @@ package workflow
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -39,8 +40,16 @@ var (
 		TabletTypes                  []topodatapb.TabletType
 		TabletTypesInPreferenceOrder bool
 		OnDDL                        string
+		CopyParallelInsertWorkers    int64
+		CopyBatchRows                int64
+		CopyBatchBytes               int64
+		CopyMaxRowsPerSecond         int64
+		CopyMaxBytesPerSecond        int64
 	}{}
 
+	// copySettingsFlags are the flags of the copy settings of the workflow.
+	copySettingsFlags = []string{"copy-parallel-insert-workers", "copy-batch-rows", "copy-batch-bytes", "copy-max-rows-per-second", "copy-max-bytes-per-second"}
+
 	// update makes a WorkflowUpdate gRPC call to a vtctld.
 	update = &cobra.Command{
 		Use:                   "update",
@@ -73,6 +82,15 @@ var (
 					return fmt.Errorf("invalid on-ddl value: %s", updateOptions.OnDDL)
 				}
 			} // Simulated NULL will need to be handled in command
+			for _, name := range copySettingsFlags {
+				if !cmd.Flags().Lookup(name).Changed {
+					continue
+				}
+				changes = true
+				if val, _ := cmd.Flags().GetInt64(name); val < 0 {
+					return fmt.Errorf("invalid %s value: %d", name, val)
+				}
+			}
 			if !changes {
 				return fmt.Errorf("no configuration options specified to update")
 			}
@@ -103,6 +121,24 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var copySettings *tabletmanagerdatapb.VReplicationCopySettings
+	if slices.ContainsFunc(copySettingsFlags, func(name string) bool { return cmd.Flags().Lookup(name).Changed }) {
+		// Simulated NULL for the copy settings which are not provided.
+		copySetting := func(name string, val int64) int64 {
+			if !cmd.Flags().Lookup(name).Changed {
+				return int64(textutil.SimulatedNullInt)
+			}
+			return val
+		}
+		copySettings = &tabletmanagerdatapb.VReplicationCopySettings{
+			ParallelInsertWorkers: copySetting("copy-parallel-insert-workers", updateOptions.CopyParallelInsertWorkers),
+			BatchRows:             copySetting("copy-batch-rows", updateOptions.CopyBatchRows),
+			BatchBytes:            copySetting("copy-batch-bytes", updateOptions.CopyBatchBytes),
+			MaxRowsPerSecond:      copySetting("copy-max-rows-per-second", updateOptions.CopyMaxRowsPerSecond),
+			MaxBytesPerSecond:     copySetting("copy-max-bytes-per-second", updateOptions.CopyMaxBytesPerSecond),
+		}
+	}
+
 	req := &vtctldatapb.WorkflowUpdateRequest{
 		Keyspace: baseOptions.Keyspace,
 		TabletRequest: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
@@ -112,6 +148,7 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 			TabletSelectionPreference: tsp,
 			OnDdl:                     binlogdatapb.OnDDLAction(onddl),
 			State:                     binlogdatapb.VReplicationWorkflowState(textutil.SimulatedNullInt), // We don't allow changing this in the client command
+			CopySettings:              copySettings,
 		},
 	}
 
@@ -72,6 +72,11 @@ func registerCommands(root *cobra.Command) {
 	common.AddShardSubsetFlag(show, &baseOptions.Shards)
 	base.AddCommand(show)
 
+	showConflicts.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The reverse workflow you want the conflicts for.")
+	showConflicts.MarkFlagRequired("workflow")
+	common.AddShardSubsetFlag(showConflicts, &baseOptions.Shards)
+	base.AddCommand(showConflicts)
+
 	start.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to start.")
 	start.MarkFlagRequired("workflow")
 	common.AddShardSubsetFlag(start, &baseOptions.Shards)
@@ -88,6 +93,11 @@ func registerCommands(root *cobra.Command) {
 	update.Flags().VarP((*topoproto.TabletTypeListFlag)(&updateOptions.TabletTypes), "tablet-types", "t", "New source tablet types to replicate from (e.g. PRIMARY,REPLICA,RDONLY).")
 	update.Flags().BoolVar(&updateOptions.TabletTypesInPreferenceOrder, "tablet-types-in-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
 	update.Flags().StringVar(&updateOptions.OnDDL, "on-ddl", "", "New instruction on what to do when DDL is encountered in the VReplication stream. Possible values are IGNORE, STOP, EXEC, and EXEC_IGNORE.")
+	update.Flags().Int64Var(&updateOptions.CopyParallelInsertWorkers, "copy-parallel-insert-workers", 0, "New number of parallel workers inserting the rows of the table being copied in the copy phase. Set to 0 to use the --vreplication-parallel-insert-workers value of the target tablets.")
+	update.Flags().Int64Var(&updateOptions.CopyBatchRows, "copy-batch-rows", 0, "New minimum number of rows inserted and committed at once in the copy phase, combining the rows streamed from the source. Set to 0 to commit the rows as they are streamed.")
+	update.Flags().Int64Var(&updateOptions.CopyBatchBytes, "copy-batch-bytes", 0, "New minimum number of bytes inserted and committed at once in the copy phase, combining the rows streamed from the source. Set to 0 to commit the rows as they are streamed.")
+	update.Flags().Int64Var(&updateOptions.CopyMaxRowsPerSecond, "copy-max-rows-per-second", 0, "New maximum number of rows copied per second, per stream, in the copy phase. Set to 0 for no limit.")
+	update.Flags().Int64Var(&updateOptions.CopyMaxBytesPerSecond, "copy-max-bytes-per-second", 0, "New maximum number of bytes copied per second, per stream, in the copy phase. Set to 0 for no limit.")
 	common.AddShardSubsetFlag(update, &baseOptions.Shards)
 	base.AddCommand(update)
 }
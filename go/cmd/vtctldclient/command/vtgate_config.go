@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyVTGateConfig makes an ApplyVTGateConfig gRPC call to a vtctld.
+	ApplyVTGateConfig = &cobra.Command{
+		Use:   "ApplyVTGateConfig {--config CONFIG | --config-file CONFIG_FILE} [--expected-version=VERSION] [--cells=c1,c2,...] [--skip-rebuild]",
+		Short: "Applies a new version of the dynamic configuration of the vtgates, which overrides their flags without restarting them.",
+		Long: `Applies a new version of the dynamic configuration of the vtgates, which overrides their flags without restarting them.
+
+The vtgates apply the configuration when they receive the rebuilt VSchema. The fields of the
+configuration which are not set keep the values of the flags of the vtgates:
+query_timeout_ms (--query-timeout), max_memory_rows (--max_memory_rows),
+warn_memory_rows (--warn_memory_rows), buffer_window_ms (--buffer_window),
+buffer_max_failover_duration_ms (--buffer_max_failover_duration) and
+scatter_max_concurrency (--scatter-max-concurrency).
+
+The previous versions of the configuration are kept, see GetVTGateConfig and RollbackVTGateConfig.
+
+Example:
+{"query_timeout_ms": 30000, "max_memory_rows": 500000}`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyVTGateConfig,
+	}
+	// GetVTGateConfig makes a GetVTGateConfig gRPC call to a vtctld.
+	GetVTGateConfig = &cobra.Command{
+		Use:                   "GetVTGateConfig [--history]",
+		Short:                 "Displays the dynamic configuration of the vtgates as a JSON document.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetVTGateConfig,
+	}
+	// RollbackVTGateConfig makes a RollbackVTGateConfig gRPC call to a vtctld.
+	RollbackVTGateConfig = &cobra.Command{
+		Use:   "RollbackVTGateConfig [--cells=c1,c2,...] [--skip-rebuild] <version>",
+		Short: "Rolls the dynamic configuration of the vtgates back to a previous version.",
+		Long: `Rolls the dynamic configuration of the vtgates back to a previous version, by applying it as a new version.
+
+The version 0 rolls the vtgates back to their flags.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandRollbackVTGateConfig,
+	}
+)
+
+var applyVTGateConfigOptions = struct {
+	Config          string
+	ConfigFilePath  string
+	ExpectedVersion int64
+	Cells           []string
+	SkipRebuild     bool
+}{}
+
+func commandApplyVTGateConfig(cmd *cobra.Command, args []string) error {
+	if applyVTGateConfigOptions.Config != "" && applyVTGateConfigOptions.ConfigFilePath != "" {
+		return fmt.Errorf("cannot pass both --config (=%s) and --config-file (=%s)", applyVTGateConfigOptions.Config, applyVTGateConfigOptions.ConfigFilePath)
+	}
+
+	if applyVTGateConfigOptions.Config == "" && applyVTGateConfigOptions.ConfigFilePath == "" {
+		return errors.New("must pass exactly one of --config or --config-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var configBytes []byte
+	if applyVTGateConfigOptions.ConfigFilePath != "" {
+		data, err := os.ReadFile(applyVTGateConfigOptions.ConfigFilePath)
+		if err != nil {
+			return err
+		}
+
+		configBytes = data
+	} else {
+		configBytes = []byte(applyVTGateConfigOptions.Config)
+	}
+
+	config := &vschemapb.VTGateConfig{}
+	if err := json2.Unmarshal(configBytes, &config); err != nil {
+		return err
+	}
+
+	resp, err := client.ApplyVTGateConfig(commandCtx, &vtctldatapb.ApplyVTGateConfigRequest{
+		VtgateConfig:    config,
+		ExpectedVersion: applyVTGateConfigOptions.ExpectedVersion,
+		SkipRebuild:     applyVTGateConfigOptions.SkipRebuild,
+		RebuildCells:    applyVTGateConfigOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.VtgateConfig)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New VTGateConfig object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	if applyVTGateConfigOptions.SkipRebuild {
+		fmt.Println("Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+var getVTGateConfigOptions = struct {
+	History bool
+}{}
+
+func commandGetVTGateConfig(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetVTGateConfig(commandCtx, &vtctldatapb.GetVTGateConfigRequest{})
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if getVTGateConfigOptions.History {
+		data, err = cli.MarshalJSON(resp)
+	} else {
+		data, err = cli.MarshalJSON(resp.VtgateConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+var rollbackVTGateConfigOptions = struct {
+	Cells       []string
+	SkipRebuild bool
+}{}
+
+func commandRollbackVTGateConfig(cmd *cobra.Command, args []string) error {
+	version, err := strconv.ParseInt(cmd.Flags().Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid version %s: %w", cmd.Flags().Arg(0), err)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RollbackVTGateConfig(commandCtx, &vtctldatapb.RollbackVTGateConfigRequest{
+		Version:      version,
+		SkipRebuild:  rollbackVTGateConfigOptions.SkipRebuild,
+		RebuildCells: rollbackVTGateConfigOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.VtgateConfig)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New VTGateConfig object:\n%s\n", data)
+
+	if rollbackVTGateConfigOptions.SkipRebuild {
+		fmt.Println("Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+func init() {
+	ApplyVTGateConfig.Flags().StringVar(&applyVTGateConfigOptions.Config, "config", "", "VTGate config, specified as a string")
+	ApplyVTGateConfig.Flags().StringVar(&applyVTGateConfigOptions.ConfigFilePath, "config-file", "", "Path to a file containing the vtgate config specified as JSON")
+	ApplyVTGateConfig.Flags().Int64Var(&applyVTGateConfigOptions.ExpectedVersion, "expected-version", 0, "Fail if the version of the current vtgate config differs. Not checked if 0.")
+	ApplyVTGateConfig.Flags().StringSliceVarP(&applyVTGateConfigOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyVTGateConfig.Flags().BoolVar(&applyVTGateConfigOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	Root.AddCommand(ApplyVTGateConfig)
+
+	GetVTGateConfig.Flags().BoolVar(&getVTGateConfigOptions.History, "history", false, "Also display the previous versions of the vtgate config.")
+	Root.AddCommand(GetVTGateConfig)
+
+	RollbackVTGateConfig.Flags().StringSliceVarP(&rollbackVTGateConfigOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	RollbackVTGateConfig.Flags().BoolVar(&rollbackVTGateConfigOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	Root.AddCommand(RollbackVTGateConfig)
+}
@@ -167,9 +167,10 @@ func run(cmd *cobra.Command, args []string) error {
 	plannerVersion, _ := plancontext.PlannerNameToVersion(plannerName)
 
 	env, err := vtenv.New(vtenv.Options{
-		MySQLServerVersion: servenv.MySQLServerVersion(),
-		TruncateUILen:      servenv.TruncateUILen,
-		TruncateErrLen:     servenv.TruncateErrLen,
+		MySQLServerVersion:  servenv.MySQLServerVersion(),
+		TruncateUILen:       servenv.TruncateUILen,
+		TruncateErrLen:      servenv.TruncateErrLen,
+		RedactQueryLiterals: servenv.RedactQueryLiterals,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to initialize env: %v", err)
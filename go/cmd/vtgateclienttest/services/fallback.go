@@ -60,6 +60,18 @@ func (c fallbackClient) CloseSession(ctx context.Context, session *vtgatepb.Sess
 	return c.fallback.CloseSession(ctx, session)
 }
 
+func (c fallbackClient) OpenCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	return c.fallback.OpenCursor(ctx, session, sql, bindVariables, keysetColumns)
+}
+
+func (c fallbackClient) FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	return c.fallback.FetchCursor(ctx, cursorID, maxRows)
+}
+
+func (c fallbackClient) CloseCursor(ctx context.Context, cursorID string) error {
+	return c.fallback.CloseCursor(ctx, cursorID)
+}
+
 func (c fallbackClient) ResolveTransaction(ctx context.Context, dtid string) error {
 	return c.fallback.ResolveTransaction(ctx, dtid)
 }
@@ -68,6 +80,10 @@ func (c fallbackClient) VStream(ctx context.Context, tabletType topodatapb.Table
 	return c.fallback.VStream(ctx, tabletType, vgtid, filter, flags, send)
 }
 
+func (c fallbackClient) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return c.fallback.VStreamAck(ctx, consumerGroup, vgtid)
+}
+
 func (c fallbackClient) HandlePanic(err *error) {
 	c.fallback.HandlePanic(err)
 }
@@ -71,6 +71,18 @@ func (c *terminalClient) CloseSession(ctx context.Context, session *vtgatepb.Ses
 	return errTerminal
 }
 
+func (c *terminalClient) OpenCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	return "", errTerminal
+}
+
+func (c *terminalClient) FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	return nil, false, errTerminal
+}
+
+func (c *terminalClient) CloseCursor(ctx context.Context, cursorID string) error {
+	return errTerminal
+}
+
 func (c *terminalClient) ResolveTransaction(ctx context.Context, dtid string) error {
 	return errTerminal
 }
@@ -79,6 +91,10 @@ func (c *terminalClient) VStream(ctx context.Context, tabletType topodatapb.Tabl
 	return errTerminal
 }
 
+func (c *terminalClient) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return errTerminal
+}
+
 func (c *terminalClient) HandlePanic(err *error) {
 	if x := recover(); x != nil {
 		log.Errorf("Uncaught panic:\n%v\n%s", x, tb.Stack(4))
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/grpccommon"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+	"vitess.io/vitess/go/vt/vtstreamer"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	server             string
+	keyspace           string
+	tables             []string
+	tabletType         = topodatapb.TabletType_PRIMARY
+	consumerGroup      string
+	copyTables         bool
+	publisher          = "stdout"
+	topicTemplate      = vtstreamer.DefaultTopicTemplate
+	topicMap           map[string]string
+	keyColumns         []string
+	checkpointInterval = 10 * time.Second
+
+	Main = &cobra.Command{
+		Use:   "vtstreamer",
+		Short: "vtstreamer publishes the change events of a keyspace, streamed from vtgate, to a message broker.",
+		Long: `vtstreamer publishes the change events of a keyspace, streamed from vtgate, to a message broker.
+
+The changes of the rows are published as JSON change events, to a topic per table.
+The position of the stream is checkpointed in vtgate as a VStream consumer group,
+which requires vtgate to run with --vstream-checkpoint-keyspace. When vtstreamer is
+restarted, it resumes from the last checkpoint of its group, so the events are
+delivered at least once.
+
+The publishers of the message brokers are registered by the plugins of the binary.
+The stdout publisher writes the messages to the standard output as JSON lines.`,
+		Example: `vtstreamer --server vtgate:15991 --keyspace customer --consumer-group customer-cdc
+
+vtstreamer --server vtgate:15991 --keyspace customer --tables customer,corder --consumer-group customer-cdc \
+	--copy --topic-template 'cdc.{keyspace}.{table}' --topic-map corder=orders --key-columns corder=order_id`,
+		Args:    cobra.NoArgs,
+		Version: servenv.AppVersion.String(),
+		PreRunE: servenv.CobraPreRunE,
+		RunE:    run,
+	}
+)
+
+func init() {
+	servenv.MoveFlagsToCobraCommand(Main)
+
+	Main.Flags().StringVar(&server, "server", server, "vtgate server to connect to")
+	Main.Flags().StringVar(&keyspace, "keyspace", keyspace, "Keyspace whose change events are published.")
+	Main.Flags().StringSliceVar(&tables, "tables", tables, "Tables whose change events are published. All the tables of the keyspace if empty.")
+	Main.Flags().Var((*topoproto.TabletTypeFlag)(&tabletType), "tablet-type", "Type of the tablets to stream from.")
+	Main.Flags().StringVar(&consumerGroup, "consumer-group", consumerGroup, "VStream consumer group in which the position of the stream is checkpointed.")
+	Main.Flags().BoolVar(&copyTables, "copy", copyTables, "If the consumer group has no checkpoint yet, publish the rows already in the tables before their changes. Otherwise the stream starts from the current position.")
+	Main.Flags().StringVar(&publisher, "publisher", publisher, "Publisher of the change events.")
+	Main.Flags().StringVar(&topicTemplate, "topic-template", topicTemplate, "Topic of the tables which aren't in --topic-map, where {keyspace} and {table} are replaced by the keyspace and the name of the table.")
+	Main.Flags().StringToStringVar(&topicMap, "topic-map", topicMap, "Topics of tables, as table=topic or keyspace.table=topic.")
+	Main.Flags().StringArrayVar(&keyColumns, "key-columns", keyColumns, "Key columns of the messages of a table, as table=col1,col2. The key columns of the other tables are their primary key columns, if the stream flags them.")
+	Main.Flags().DurationVar(&checkpointInterval, "checkpoint-interval", checkpointInterval, "Minimum interval between two checkpoints of the position of the stream.")
+
+	Main.MarkFlagRequired("keyspace")
+	Main.MarkFlagRequired("consumer-group")
+
+	acl.RegisterFlags(Main.Flags())
+	grpccommon.RegisterFlags(Main.Flags())
+}
+
+func parseKeyColumns(values []string) (map[string][]string, error) {
+	keyCols := make(map[string][]string, len(values))
+	for _, value := range values {
+		table, cols, ok := strings.Cut(value, "=")
+		if !ok || table == "" || cols == "" {
+			return nil, fmt.Errorf("invalid key columns %q, must be table=col1,col2", value)
+		}
+		keyCols[table] = strings.Split(cols, ",")
+	}
+	return keyCols, nil
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	defer logutil.Flush()
+
+	keyCols, err := parseKeyColumns(keyColumns)
+	if err != nil {
+		return err
+	}
+	topics, err := vtstreamer.NewTopicMapper(topicTemplate, topicMap)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	conn, err := vtgateconn.Dial(ctx, server)
+	if err != nil {
+		return fmt.Errorf("failed to connect to vtgate %s: %w", server, err)
+	}
+	defer conn.Close()
+
+	pub, err := vtstreamer.NewPublisher(publisher)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := pub.Close(); err != nil {
+			log.Errorf("Failed to close publisher %s: %v", publisher, err)
+		}
+	}()
+
+	s, err := vtstreamer.NewStreamer(&vtstreamer.Config{
+		Keyspace:           keyspace,
+		Tables:             tables,
+		TabletType:         tabletType,
+		ConsumerGroup:      consumerGroup,
+		Copy:               copyTables,
+		Topics:             topics,
+		KeyColumns:         keyCols,
+		CheckpointInterval: checkpointInterval,
+	}, conn, pub)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Publishing the change events of keyspace %s as consumer group %s", keyspace, consumerGroup)
+	err = s.Run(ctx)
+	if errors.Is(err, io.EOF) || ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
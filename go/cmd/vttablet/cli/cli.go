@@ -51,6 +51,7 @@ var (
 	enforceTableACLConfig        bool
 	tableACLConfig               string
 	tableACLConfigReloadInterval time.Duration
+	tableACLFromTopo             bool
 	tabletPath                   string
 	tabletConfig                 string
 
@@ -117,9 +118,10 @@ func run(cmd *cobra.Command, args []string) error {
 
 	mysqlVersion := servenv.MySQLServerVersion()
 	env, err := vtenv.New(vtenv.Options{
-		MySQLServerVersion: mysqlVersion,
-		TruncateUILen:      servenv.TruncateUILen,
-		TruncateErrLen:     servenv.TruncateErrLen,
+		MySQLServerVersion:  mysqlVersion,
+		TruncateUILen:       servenv.TruncateUILen,
+		TruncateErrLen:      servenv.TruncateErrLen,
+		RedactQueryLiterals: servenv.RedactQueryLiterals,
 	})
 	if err != nil {
 		return fmt.Errorf("cannot initialize vtenv: %w", err)
@@ -150,6 +152,13 @@ func run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse --tablet-path: %w", err)
 	}
+	// The table groups of the ACL may be scoped to keyspaces.
+	tableacl.SetKeyspace(tablet.Keyspace)
+	if tableACLFromTopo {
+		qsc.InitTopoACL(enforceTableACLConfig)
+	} else {
+		qsc.InitACL(tableACLConfig, enforceTableACLConfig, tableACLConfigReloadInterval)
+	}
 	tm = &tabletmanager.TabletManager{
 		BatchCtx:            context.Background(),
 		Env:                 env,
@@ -230,11 +239,14 @@ func initConfig(tabletAlias *topodatapb.TabletAlias, collationEnv *collations.En
 }
 
 func createTabletServer(ctx context.Context, env *vtenv.Environment, config *tabletenv.TabletConfig, ts *topo.Server, tabletAlias *topodatapb.TabletAlias, srvTopoCounts *stats.CountersWithSingleLabel) (*tabletserver.TabletServer, error) {
-	if tableACLConfig != "" {
+	if tableACLConfig != "" && tableACLFromTopo {
+		return nil, fmt.Errorf("table-acl-config and table-acl-from-topo cannot be both set.")
+	}
+	if tableACLConfig != "" || tableACLFromTopo {
 		// To override default simpleacl, other ACL plugins must set themselves to be default ACL factory
 		tableacl.Register("simpleacl", &simpleacl.Factory{})
 	} else if enforceTableACLConfig {
-		return nil, fmt.Errorf("table acl config has to be specified with table-acl-config flag because enforce-tableacl-config is set.")
+		return nil, fmt.Errorf("table acl config has to be specified with table-acl-config or table-acl-from-topo flag because enforce-tableacl-config is set.")
 	}
 
 	// creates and registers the query service
@@ -244,7 +256,6 @@ func createTabletServer(ctx context.Context, env *vtenv.Environment, config *tab
 		addStatusParts(qsc)
 	})
 	servenv.OnClose(qsc.StopService)
-	qsc.InitACL(tableACLConfig, enforceTableACLConfig, tableACLConfigReloadInterval)
 	return qsc, nil
 }
 
@@ -264,6 +275,7 @@ func init() {
 	Main.Flags().BoolVar(&enforceTableACLConfig, "enforce-tableacl-config", enforceTableACLConfig, "if this flag is true, vttablet will fail to start if a valid tableacl config does not exist")
 	Main.Flags().StringVar(&tableACLConfig, "table-acl-config", tableACLConfig, "path to table access checker config file; send SIGHUP to reload this file")
 	Main.Flags().DurationVar(&tableACLConfigReloadInterval, "table-acl-config-reload-interval", tableACLConfigReloadInterval, "Ticker to reload ACLs. Duration flag, format e.g.: 30s. Default: do not reload")
+	Main.Flags().BoolVar(&tableACLFromTopo, "table-acl-from-topo", tableACLFromTopo, "if this flag is true, vttablet loads the table access checker config from the global topo and reloads it whenever it changes")
 	Main.Flags().StringVar(&tabletPath, "tablet-path", tabletPath, "tablet alias")
 	Main.Flags().StringVar(&tabletConfig, "tablet_config", tabletConfig, "YAML file config for tablet")
 }
@@ -77,6 +77,9 @@ var (
 	//go:embed vtgateclienttest.txt
 	vtgateclienttestTxt string
 
+	//go:embed vtstreamer.txt
+	vtstreamerTxt string
+
 	//go:embed vttestserver.txt
 	vttestserverTxt string
 
@@ -106,6 +109,7 @@ var (
 		"vtgate":           vtgateTxt,
 		"vtgateclienttest": vtgateclienttestTxt,
 		"vtorc":            vtorcTxt,
+		"vtstreamer":       vtstreamerTxt,
 		"vttablet":         vttabletTxt,
 		"vttestserver":     vttestserverTxt,
 		"vttlstest":        vttlstestTxt,
@@ -594,6 +594,33 @@ func GetAuthServer(name string) AuthServer {
 	return authServer
 }
 
+// AuthenticateClearText checks the clear text password of a user with an
+// AuthServer, for the clients of another protocol than MySQL, like the HTTP
+// query API of vtgate. remoteAddr is the address of the client, and
+// tlsEnabled tells whether the password was received over TLS.
+func AuthenticateClearText(authServer AuthServer, user string, password []byte, remoteAddr net.Addr, tlsEnabled bool) (Getter, error) {
+	l := &Listener{authServer: authServer}
+	c := &Conn{
+		conn:     &remoteAddrConn{addr: remoteAddr},
+		listener: l,
+	}
+	if tlsEnabled {
+		c.Capabilities |= CapabilityClientSSL
+	}
+	return l.authenticateClearText(c, user, password)
+}
+
+// remoteAddrConn is a placeholder net.Conn which only has a remote address,
+// for the auth methods authenticating a client without a MySQL connection.
+type remoteAddrConn struct {
+	net.Conn
+	addr net.Addr
+}
+
+func (c *remoteAddrConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
 func newSalt() ([]byte, error) {
 	salt := make([]byte, 20)
 	if _, err := rand.Read(salt); err != nil {
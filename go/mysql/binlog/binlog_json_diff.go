@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+/*
+References:
+
+* Docs for the binary format of partial JSON updates (Json_diff_vector::write_binary):
+https://dev.mysql.com/doc/dev/mysql-server/latest/classJson__diff__vector.html
+*/
+
+// JSONDiffPlaceholder is the placeholder in the expression returned by
+// ParseBinaryJSONDiff that stands for the value of the column before the
+// partial update was applied.
+const JSONDiffPlaceholder = "%s"
+
+// jsonDiffOperation is the operation of a single diff in a partial JSON update.
+type jsonDiffOperation byte
+
+const (
+	jsonDiffOperationReplace = jsonDiffOperation(iota)
+	jsonDiffOperationInsert
+	jsonDiffOperationRemove
+)
+
+// ParseBinaryJSONDiff parses the diffs of a partial JSON update, as logged in
+// a PARTIAL_UPDATE_ROWS_EVENT when binlog_row_value_options=PARTIAL_JSON, and
+// returns them as a single SQL expression which applies them in order, e.g.
+//
+//	JSON_INSERT(JSON_REMOVE(%s, _utf8mb4'$.a'), _utf8mb4'$.b', CAST(1 as JSON))
+//
+// The JSONDiffPlaceholder must be replaced by the value of the column before
+// the update to get its new value.
+//
+// Each diff is encoded as:
+//
+//	# bytes   field
+//	1         operation (0: REPLACE, 1: INSERT, 2: REMOVE)
+//	<var>     path length (packed integer)
+//	<var>     path
+//	-- if operation != REMOVE
+//	<var>     value length (packed integer)
+//	<var>     value, in the MySQL binary JSON format
+//	-- endif
+func ParseBinaryJSONDiff(data []byte) (sqltypes.Value, error) {
+	expr := JSONDiffPlaceholder
+	pos := 0
+	for pos < len(data) {
+		op := jsonDiffOperation(data[pos])
+		pos++
+
+		pathLen, pos2, err := readPackedInteger(data, pos)
+		if err != nil {
+			return sqltypes.Value{}, err
+		}
+		pos = pos2
+		if pos+pathLen > len(data) {
+			return sqltypes.Value{}, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "truncated JSON diff path at position %d", pos)
+		}
+		path := sqltypes.EncodeStringSQL(string(data[pos : pos+pathLen]))
+		pos += pathLen
+
+		var fn string
+		switch op {
+		case jsonDiffOperationReplace:
+			fn = "JSON_REPLACE"
+		case jsonDiffOperationInsert:
+			fn = "JSON_INSERT"
+			// JSON_INSERT does not shift the existing elements of an array,
+			// so inserting at an array index needs JSON_ARRAY_INSERT.
+			if strings.HasSuffix(path, "]'") {
+				fn = "JSON_ARRAY_INSERT"
+			}
+		case jsonDiffOperationRemove:
+			expr = "JSON_REMOVE(" + expr + ", _utf8mb4" + path + ")"
+			continue
+		default:
+			return sqltypes.Value{}, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unknown JSON diff operation %d", op)
+		}
+
+		valueLen, pos2, err := readPackedInteger(data, pos)
+		if err != nil {
+			return sqltypes.Value{}, err
+		}
+		pos = pos2
+		if pos+valueLen > len(data) {
+			return sqltypes.Value{}, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "truncated JSON diff value at position %d", pos)
+		}
+		value, err := ParseBinaryJSON(data[pos : pos+valueLen])
+		if err != nil {
+			return sqltypes.Value{}, err
+		}
+		pos += valueLen
+		expr = fn + "(" + expr + ", _utf8mb4" + path + ", " + string(value.MarshalSQLTo(nil)) + ")"
+	}
+	return sqltypes.MakeTrusted(sqltypes.Expression, []byte(expr)), nil
+}
+
+// JSONDiffCellValue returns the partial JSON update of a JSON column in a
+// row event as the expression returned by ParseBinaryJSONDiff, and the
+// number of bytes it takes.
+func JSONDiffCellValue(data []byte, pos int, metadata uint16) (sqltypes.Value, int, error) {
+	l, err := CellLength(data, pos, TypeJSON, metadata)
+	if err != nil {
+		return sqltypes.NULL, 0, err
+	}
+	if pos+l > len(data) {
+		return sqltypes.NULL, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "truncated JSON diff at position %d", pos)
+	}
+	val, err := ParseBinaryJSONDiff(data[pos+int(metadata) : pos+l])
+	if err != nil {
+		return sqltypes.NULL, 0, err
+	}
+	return val, l, nil
+}
+
+// readPackedInteger reads an integer in the packed format used by MySQL
+// (net_field_length), and returns it and the new position.
+func readPackedInteger(data []byte, pos int) (int, int, error) {
+	if pos >= len(data) {
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "truncated packed integer at position %d", pos)
+	}
+	size := 0
+	switch data[pos] {
+	case 0xfc:
+		size = 2
+	case 0xfd:
+		size = 3
+	case 0xfe:
+		size = 8
+	default:
+		return int(data[pos]), pos + 1, nil
+	}
+	pos++
+	if pos+size > len(data) {
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "truncated packed integer at position %d", pos)
+	}
+	val := 0
+	for i := size - 1; i >= 0; i-- {
+		val = val<<8 | int(data[pos+i])
+	}
+	return val, pos + size, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestParseBinaryJSONDiff(t *testing.T) {
+	testcases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{{
+		name:     "no diff",
+		data:     []byte{},
+		expected: "%s",
+	}, {
+		name:     "replace",
+		data:     []byte{0, 3, '$', '.', 'a', 3, 5, 1, 0},
+		expected: "JSON_REPLACE(%s, _utf8mb4'$.a', CAST(1 as JSON))",
+	}, {
+		name:     "insert object member",
+		data:     []byte{1, 3, '$', '.', 'b', 4, 12, 2, 'h', 'i'},
+		expected: "JSON_INSERT(%s, _utf8mb4'$.b', CAST(JSON_QUOTE(_utf8mb4'hi') as JSON))",
+	}, {
+		name:     "insert array element",
+		data:     []byte{1, 6, '$', '.', 'c', '[', '1', ']', 2, 4, 1},
+		expected: "JSON_ARRAY_INSERT(%s, _utf8mb4'$.c[1]', CAST(_utf8mb4'true' as JSON))",
+	}, {
+		name:     "remove",
+		data:     []byte{2, 3, '$', '.', 'a'},
+		expected: "JSON_REMOVE(%s, _utf8mb4'$.a')",
+	}, {
+		name: "multiple diffs",
+		data: []byte{
+			2, 3, '$', '.', 'a',
+			0, 3, '$', '.', 'b', 2, 4, 0,
+			1, 5, '$', '.', '\'', '%', 's', 3, 5, 2, 0,
+		},
+		expected: "JSON_INSERT(JSON_REPLACE(JSON_REMOVE(%s, _utf8mb4'$.a'), _utf8mb4'$.b', CAST(_utf8mb4'null' as JSON)), _utf8mb4'$.\\'%s', CAST(2 as JSON))",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := ParseBinaryJSONDiff(tc.data)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.Expression, val.Type())
+			require.Equal(t, tc.expected, val.RawStr())
+		})
+	}
+}
+
+func TestParseBinaryJSONDiffErrors(t *testing.T) {
+	testcases := []struct {
+		name string
+		data []byte
+		err  string
+	}{{
+		name: "unknown operation",
+		data: []byte{3, 3, '$', '.', 'a'},
+		err:  "unknown JSON diff operation 3",
+	}, {
+		name: "truncated path length",
+		data: []byte{0},
+		err:  "truncated packed integer at position 1",
+	}, {
+		name: "truncated path",
+		data: []byte{0, 3, '$', '.'},
+		err:  "truncated JSON diff path at position 2",
+	}, {
+		name: "truncated value",
+		data: []byte{0, 3, '$', '.', 'a', 3, 5, 1},
+		err:  "truncated JSON diff value at position 6",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseBinaryJSONDiff(tc.data)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestJSONDiffCellValue(t *testing.T) {
+	data := []byte{0xff, 5, 0, 0, 0, 2, 3, '$', '.', 'a', 0xff}
+	val, l, err := JSONDiffCellValue(data, 1, 4)
+	require.NoError(t, err)
+	require.Equal(t, 9, l)
+	require.Equal(t, "JSON_REMOVE(%s, _utf8mb4'$.a')", val.RawStr())
+
+	_, _, err = JSONDiffCellValue(data[:9], 1, 4)
+	require.ErrorContains(t, err, "truncated JSON diff at position 1")
+}
@@ -111,7 +111,7 @@ func CellLength(data []byte, pos int, typ byte, metadata uint16) (int, error) {
 		return intg0*4 + dig2bytes[intg0x] + frac0*4 + dig2bytes[frac0x], nil
 	case TypeEnum, TypeSet:
 		return int(metadata & 0xff), nil
-	case TypeJSON, TypeTinyBlob, TypeMediumBlob, TypeLongBlob, TypeBlob, TypeGeometry:
+	case TypeJSON, TypeTinyBlob, TypeMediumBlob, TypeLongBlob, TypeBlob, TypeGeometry, TypeVector:
 		// Of the Blobs, only TypeBlob is used in binary logs,
 		// but supports others just in case.
 		switch metadata {
@@ -758,7 +758,9 @@ func CellValue(data []byte, pos int, typ byte, metadata uint16, field *querypb.F
 		}
 		return sqltypes.MakeTrusted(querypb.Type_VARCHAR, mdata), l + 1, nil
 
-	case TypeGeometry:
+	case TypeGeometry, TypeVector:
+		// A VECTOR is stored like a GEOMETRY, as the little-endian
+		// float32 values of its dimensions.
 		l := 0
 		switch metadata {
 		case 1:
@@ -776,10 +778,14 @@ func CellValue(data []byte, pos int, typ byte, metadata uint16, field *querypb.F
 				uint32(data[pos+2])<<16 |
 				uint32(data[pos+3])<<24)
 		default:
-			return sqltypes.NULL, 0, vterrors.Errorf(vtrpc.Code_INTERNAL, "unsupported geometry metadata value %v (data: %v pos: %v)", metadata, data, pos)
+			return sqltypes.NULL, 0, vterrors.Errorf(vtrpc.Code_INTERNAL, "unsupported geometry/vector metadata value %v (data: %v pos: %v)", metadata, data, pos)
 		}
 		pos += int(metadata)
-		return sqltypes.MakeTrusted(querypb.Type_GEOMETRY,
+		resultType := querypb.Type_GEOMETRY
+		if typ == TypeVector {
+			resultType = querypb.Type_VECTOR
+		}
+		return sqltypes.MakeTrusted(resultType,
 			data[pos:pos+l]), l + int(metadata), nil
 
 	default:
@@ -534,6 +534,13 @@ func TestCellLengthAndData(t *testing.T) {
 		data:     []byte{0x3, 0x00, 0x00, 0x00, 'a', 'b', 'c'},
 		out: sqltypes.MakeTrusted(querypb.Type_GEOMETRY,
 			[]byte("abc")),
+	}, {
+		// VECTOR(2) of [1, -2].
+		typ:      TypeVector,
+		metadata: 4,
+		data:     []byte{0x8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x3f, 0x00, 0x00, 0x00, 0xc0},
+		out: sqltypes.MakeTrusted(querypb.Type_VECTOR,
+			[]byte{0x00, 0x00, 0x80, 0x3f, 0x00, 0x00, 0x00, 0xc0}),
 	}}
 
 	for _, tcase := range testcases {
@@ -91,6 +91,9 @@ type BinlogEvent interface {
 
 	// Timestamp returns the timestamp from the event header.
 	Timestamp() uint32
+	// ServerID returns the server_id of the server the event originated
+	// on, from the event header.
+	ServerID() uint32
 
 	// Format returns a BinlogFormat struct based on the event data.
 	// This is only valid if IsFormatDescription() returns true.
@@ -124,9 +127,9 @@ type BinlogEvent interface {
 	// IsWriteRows(), IsUpdateRows(), or IsDeleteRows() returns
 	// true.
 	Rows(BinlogFormat, *TableMap) (Rows, error)
-	// TransactionPayload returns a list of BinlogEvents contained
-	// within the compressed transaction.
-	TransactionPayload(BinlogFormat) ([]BinlogEvent, error)
+	// TransactionPayload returns the compressed transaction, from
+	// which the BinlogEvents it contains can be read.
+	TransactionPayload(BinlogFormat) (*TransactionPayload, error)
 	// NextLogFile returns the name of the next binary log file & pos.
 	// This is only valid if IsRotate() returns true
 	NextLogFile(BinlogFormat) (string, uint64, error)
@@ -225,6 +228,49 @@ type TableMap struct {
 	// array position needs to be mapped to the ordered list of
 	// text based columns in the table.
 	ColumnCollationIDs []collations.ID
+
+	// EnumSetCollationIDs is like ColumnCollationIDs, for the ordered
+	// list of ENUM and SET columns in the table.
+	EnumSetCollationIDs []collations.ID
+
+	// UnsignedColumns' bits are set if the column is an unsigned
+	// numeric column. It is empty if the signedness was not logged.
+	UnsignedColumns Bitmap
+
+	// ColumnNames contains the name of each column. It is only set
+	// with binlog_row_metadata=FULL.
+	ColumnNames []string
+
+	// EnumSetValues contains the string values of each ENUM and SET
+	// column, and is nil for the other columns. It is only set with
+	// binlog_row_metadata=FULL.
+	EnumSetValues [][]string
+}
+
+// ColumnCollationID returns the collation of a column, if it is a text
+// based, ENUM or SET column and its collation was logged.
+func (tm *TableMap) ColumnCollationID(column int) (collations.ID, bool) {
+	charIndex, enumSetIndex := 0, 0
+	for c := 0; c < column; c++ {
+		switch {
+		case isCharacterColumn(tm.Types[c], tm.Metadata[c]):
+			charIndex++
+		case isEnumOrSetColumn(tm.Types[c], tm.Metadata[c]):
+			enumSetIndex++
+		}
+	}
+	switch {
+	case isCharacterColumn(tm.Types[column], tm.Metadata[column]) && charIndex < len(tm.ColumnCollationIDs):
+		return tm.ColumnCollationIDs[charIndex], true
+	case isEnumOrSetColumn(tm.Types[column], tm.Metadata[column]) && enumSetIndex < len(tm.EnumSetCollationIDs):
+		return tm.EnumSetCollationIDs[enumSetIndex], true
+	}
+	return collations.Unknown, false
+}
+
+// IsUnsigned returns true if a column is an unsigned numeric column.
+func (tm *TableMap) IsUnsigned(column int) bool {
+	return column < tm.UnsignedColumns.Count() && tm.UnsignedColumns.Bit(column)
 }
 
 // Rows contains data from a {WRITE,UPDATE,DELETE}_ROWS_EVENT.
@@ -257,6 +303,11 @@ type Row struct {
 	// It is only set for WRITE and UPDATE events.
 	NullColumns Bitmap
 
+	// JSONPartialValues describes which of the present JSON columns
+	// hold a partial JSON update rather than a full JSON document.
+	// It is only set for PARTIAL_UPDATE events.
+	JSONPartialValues Bitmap
+
 	// Identify is the raw data for the columns used to identify a row.
 	// It is only set for UPDATE and DELETE events.
 	Identify []byte
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	// binlogEventFlagsOffset is the offset of the flags in the event header.
+	binlogEventFlagsOffset = 17
+	// binlogEventInUseFlag is LOG_EVENT_BINLOG_IN_USE_F, which is set in the
+	// FORMAT_DESCRIPTION_EVENT of a binlog file that is still being written.
+	binlogEventInUseFlag = 0x1
+)
+
+// binlogChecksumAlgorithm describes how binlog events are checksummed with
+// a checksum algorithm.
+type binlogChecksumAlgorithm struct {
+	// length is the number of bytes of the checksum suffix.
+	length int
+	// sum computes the checksum of the event bytes without the suffix.
+	sum func(data []byte) []byte
+}
+
+// binlogChecksumAlgorithms are the supported checksum algorithms, by ID.
+// BinlogChecksumAlgOff and BinlogChecksumAlgUndef mean there is no checksum.
+var binlogChecksumAlgorithms = map[byte]binlogChecksumAlgorithm{
+	BinlogChecksumAlgCRC32: {
+		length: BinlogCRC32ChecksumLen,
+		sum: func(data []byte) []byte {
+			return binary.LittleEndian.AppendUint32(nil, crc32.ChecksumIEEE(data))
+		},
+	},
+}
+
+// RegisterBinlogChecksumAlgorithm adds support for a binlog checksum
+// algorithm, so that the events of a server that uses it, as negotiated
+// with @master_binlog_checksum, can be stripped and verified. sum computes
+// the checksum of the event bytes without the length bytes of the checksum
+// suffix. It must be called at init time.
+func RegisterBinlogChecksumAlgorithm(alg byte, length int, sum func(data []byte) []byte) {
+	binlogChecksumAlgorithms[alg] = binlogChecksumAlgorithm{
+		length: length,
+		sum:    sum,
+	}
+}
+
+// splitChecksum returns the bytes of an event without its checksum, and
+// the checksum, which is nil if the events have no checksum.
+func splitChecksum(f BinlogFormat, data []byte) ([]byte, []byte, error) {
+	switch f.ChecksumAlgorithm {
+	case BinlogChecksumAlgOff, BinlogChecksumAlgUndef:
+		// There is no checksum.
+		return data, nil, nil
+	}
+	alg, ok := binlogChecksumAlgorithms[f.ChecksumAlgorithm]
+	if !ok {
+		// MySQL 5.6 does not guarantee that future checksum algorithms will be
+		// 4 bytes, so we can't support them a priori.
+		return data, nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "unsupported checksum algorithm: %v", f.ChecksumAlgorithm)
+	}
+	length := len(data)
+	if length < alg.length {
+		return data, nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "event of %d bytes is too short to hold a checksum", length)
+	}
+	return data[:length-alg.length], data[length-alg.length:], nil
+}
+
+// VerifyBinlogEventChecksum verifies the checksum of an event before it is
+// stripped by StripChecksum, to detect events that were corrupted on the
+// network. It returns a DATA_LOSS error if the checksum doesn't match.
+// Events without a checksum, and pseudo events, are always valid.
+func VerifyBinlogEventChecksum(f BinlogFormat, ev BinlogEvent) error {
+	if ev.IsPseudo() {
+		return nil
+	}
+	data, checksum, err := splitChecksum(f, ev.Bytes())
+	if err != nil || checksum == nil {
+		return err
+	}
+	if ev.IsFormatDescription() && len(data) > binlogEventFlagsOffset && data[binlogEventFlagsOffset]&binlogEventInUseFlag != 0 {
+		// The server computes the checksum of a FORMAT_DESCRIPTION_EVENT
+		// before setting the flag that marks its binlog file as in use.
+		data = append([]byte(nil), data...)
+		data[binlogEventFlagsOffset] &^= binlogEventInUseFlag
+	}
+	if expected := binlogChecksumAlgorithms[f.ChecksumAlgorithm].sum(data); !bytes.Equal(checksum, expected) {
+		return vterrors.Errorf(vtrpc.Code_DATA_LOSS, "binlog event checksum mismatch: got %x, expected %x", checksum, expected)
+	}
+	return nil
+}
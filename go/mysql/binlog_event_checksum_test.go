@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+func TestVerifyBinlogEventChecksum(t *testing.T) {
+	format, err := mysql56FormatEvent.Format()
+	require.NoError(t, err)
+	require.Equal(t, byte(BinlogChecksumAlgCRC32), format.ChecksumAlgorithm)
+
+	require.NoError(t, VerifyBinlogEventChecksum(format, mysql56FormatEvent))
+	require.NoError(t, VerifyBinlogEventChecksum(format, mysql56QueryEvent))
+
+	corrupted := append([]byte(nil), mysql56QueryEvent.Bytes()...)
+	corrupted[len(corrupted)-10] ^= 0xff
+	err = VerifyBinlogEventChecksum(format, NewMysql56BinlogEvent(corrupted))
+	require.ErrorContains(t, err, "binlog event checksum mismatch")
+	require.Equal(t, vtrpc.Code_DATA_LOSS, vterrors.Code(err))
+
+	// Without checksums, nothing is verified.
+	format.ChecksumAlgorithm = BinlogChecksumAlgOff
+	require.NoError(t, VerifyBinlogEventChecksum(format, NewMysql56BinlogEvent(corrupted)))
+
+	// Unknown algorithms can't be verified.
+	format.ChecksumAlgorithm = 2
+	require.ErrorContains(t, VerifyBinlogEventChecksum(format, mysql56QueryEvent), "unsupported checksum algorithm: 2")
+}
+
+func TestMariadbVerifyBinlogEventChecksum(t *testing.T) {
+	format, err := (mariadbBinlogEvent{binlogEvent: binlogEvent(mariadbChecksumFormatEvent)}).Format()
+	require.NoError(t, err)
+
+	ev := mariadbBinlogEvent{binlogEvent: binlogEvent(mariadbChecksumQueryEvent)}
+	require.NoError(t, VerifyBinlogEventChecksum(format, ev))
+
+	corrupted := append([]byte(nil), mariadbChecksumQueryEvent...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	err = VerifyBinlogEventChecksum(format, mariadbBinlogEvent{binlogEvent: binlogEvent(corrupted)})
+	require.ErrorContains(t, err, "binlog event checksum mismatch")
+}
+
+func TestRegisterBinlogChecksumAlgorithm(t *testing.T) {
+	const alg = 0x42
+	defer delete(binlogChecksumAlgorithms, alg)
+	RegisterBinlogChecksumAlgorithm(alg, 2, func(data []byte) []byte {
+		var sum byte
+		for _, b := range data {
+			sum += b
+		}
+		return []byte{sum, ^sum}
+	})
+
+	format := NewMySQL56BinlogFormat()
+	format.ChecksumAlgorithm = alg
+	data := []byte{0x01, 0x02, 0x03, 0x06, 0xf9}
+
+	ev := NewMysql56BinlogEvent(data)
+	require.NoError(t, VerifyBinlogEventChecksum(format, ev))
+	stripped, checksum, err := ev.StripChecksum(format)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x03}, stripped.Bytes())
+	require.Equal(t, []byte{0x06, 0xf9}, checksum)
+
+	data[0] = 0x00
+	require.ErrorContains(t, VerifyBinlogEventChecksum(format, ev), "binlog event checksum mismatch")
+
+	_, _, err = NewMysql56BinlogEvent([]byte{0x01}).StripChecksum(format)
+	require.ErrorContains(t, err, "too short to hold a checksum")
+}
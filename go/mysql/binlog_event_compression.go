@@ -61,15 +61,25 @@ var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
 
 // At what size should we switch from the in-memory buffer
 // decoding to streaming mode -- which is slower, but does not
-// require everything be done in memory.
-const zstdInMemoryDecompressorMaxSize = 128 << (10 * 2) // 128MiB
+// require everything be done in memory. It is a variable so that
+// tests can change it.
+var zstdInMemoryDecompressorMaxSize uint64 = 128 << (10 * 2) // 128MiB
 
+// TransactionPayload is a decoded Transaction_payload_event. The events
+// it contains are decompressed lazily, one at a time, with GetNextEvent.
 type TransactionPayload struct {
 	Size             uint64
 	CompressionType  uint64
 	UncompressedSize uint64
 	Payload          []byte
-	Events           []BinlogEvent
+
+	// reader returns the decompressed payload.
+	reader io.Reader
+	// streamDecoder is set when the payload is decompressed in
+	// streaming mode, and must be closed when done.
+	streamDecoder *zstd.Decoder
+	// decompressedSize is the number of bytes read from reader so far.
+	decompressedSize uint64
 }
 
 // IsTransactionPayload returns true if a compressed transaction
@@ -127,17 +137,18 @@ func (ev binlogEvent) IsTransactionPayload() bool {
 // +-----------------------------------------+
 //
 // We need to extract the compressed transaction payload from the GTID
-// event, decompress it with zstd, and then process the internal events
-// (e.g. Query and Row events) that make up the transaction.
-func (ev binlogEvent) TransactionPayload(format BinlogFormat) ([]BinlogEvent, error) {
+// event, and then process the internal events (e.g. Query and Row events)
+// that make up the transaction as they are decompressed with zstd.
+// The caller must Close the returned TransactionPayload.
+func (ev binlogEvent) TransactionPayload(format BinlogFormat) (*TransactionPayload, error) {
 	tp := &TransactionPayload{}
 	if err := tp.Decode(ev.Bytes()[format.HeaderLength:]); err != nil {
 		return nil, vterrors.Wrapf(err, "error decoding transaction payload event")
 	}
-	return tp.Events, nil
+	return tp, nil
 }
 
-// Decode decodes and decompresses the payload.
+// Decode reads the payload and prepares its decompression.
 func (tp *TransactionPayload) Decode(data []byte) error {
 	if err := tp.read(data); err != nil {
 		return err
@@ -192,78 +203,94 @@ func (tp *TransactionPayload) read(data []byte) error {
 	}
 }
 
-// decode decompresses the payload and extracts the internal binlog
-// events.
+// decode prepares the decompression of the payload.
 func (tp *TransactionPayload) decode() error {
 	if tp.CompressionType != TransactionPayloadCompressionZstd {
 		return vterrors.New(vtrpcpb.Code_INTERNAL,
 			fmt.Sprintf("TransactionPayload has unsupported compression type of %d", tp.CompressionType))
 	}
-
-	decompressedPayload, err := tp.decompress()
-	decompressedPayloadLen := uint64(len(decompressedPayload))
-	if err != nil {
+	if err := tp.decompress(); err != nil {
 		return vterrors.Wrapf(err, "error decompressing transaction payload")
 	}
-
-	pos := uint64(0)
-
-	for {
-		eventLenPosEnd := pos + BinlogEventLenOffset + 4
-		if eventLenPosEnd > decompressedPayloadLen { // No more events in the payload
-			break
-		}
-		eventLen := uint64(binary.LittleEndian.Uint32(decompressedPayload[pos+BinlogEventLenOffset : eventLenPosEnd]))
-		if pos+eventLen > decompressedPayloadLen {
-			return vterrors.New(vtrpcpb.Code_INTERNAL,
-				fmt.Sprintf("[BUG] event length of %d at pos %d in decompressed transaction payload is beyond the expected payload length of %d",
-					eventLen, pos, decompressedPayloadLen))
-		}
-		eventData := decompressedPayload[pos : pos+eventLen]
-		ble := NewMysql56BinlogEvent(eventData)
-		tp.Events = append(tp.Events, ble)
-
-		pos += eventLen
-	}
-
 	return nil
 }
 
-// Decompress the payload.
-func (tp *TransactionPayload) decompress() ([]byte, error) {
+// decompress sets up the reader of the decompressed payload.
+func (tp *TransactionPayload) decompress() error {
 	if len(tp.Payload) == 0 {
-		return []byte{}, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "cannot decompress empty payload")
+		return vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "cannot decompress empty payload")
 	}
-	var (
-		decompressedBytes []byte
-		err               error
-	)
 
-	// Switch to slower but less memory intensive stream mode for larger payloads.
+	// Switch to slower but less memory intensive stream mode for larger
+	// payloads: the events are then decompressed one at a time, as they
+	// are read.
 	if tp.UncompressedSize > zstdInMemoryDecompressorMaxSize {
-		in := bytes.NewReader(tp.Payload)
-		streamDecoder, err := zstd.NewReader(in)
-		if err != nil {
-			return nil, err
-		}
-		defer streamDecoder.Close()
-		out := io.Writer(&bytes.Buffer{})
-		_, err = io.Copy(out, streamDecoder)
-		if err != nil {
-			return nil, err
-		}
-		decompressedBytes = out.(*bytes.Buffer).Bytes()
-	} else { // Process smaller payloads using in-memory buffers.
-		decompressedBytes, err = zstdDecoder.DecodeAll(tp.Payload, nil)
+		streamDecoder, err := zstd.NewReader(bytes.NewReader(tp.Payload), zstd.WithDecoderConcurrency(1))
 		if err != nil {
-			return nil, err
+			return err
 		}
+		tp.streamDecoder = streamDecoder
+		tp.reader = streamDecoder
+		return nil
 	}
 
+	// Process smaller payloads using in-memory buffers.
+	decompressedBytes, err := zstdDecoder.DecodeAll(tp.Payload, nil)
+	if err != nil {
+		return err
+	}
 	if uint64(len(decompressedBytes)) != tp.UncompressedSize {
-		return []byte{}, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT,
+		return vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT,
 			fmt.Sprintf("decompressed size %d does not match expected size %d", len(decompressedBytes), tp.UncompressedSize))
 	}
+	tp.reader = bytes.NewReader(decompressedBytes)
+	return nil
+}
 
-	return decompressedBytes, nil
+// GetNextEvent returns the next internal binlog event of the payload. It
+// returns io.EOF when there are no more events.
+func (tp *TransactionPayload) GetNextEvent() (BinlogEvent, error) {
+	if tp.reader == nil {
+		return nil, io.EOF
+	}
+	header := make([]byte, BinlogEventLenOffset+4)
+	n, err := io.ReadFull(tp.reader, header)
+	tp.decompressedSize += uint64(n)
+	switch {
+	case err == io.EOF:
+		// No more events in the payload.
+		if tp.decompressedSize != tp.UncompressedSize {
+			return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT,
+				fmt.Sprintf("decompressed size %d does not match expected size %d", tp.decompressedSize, tp.UncompressedSize))
+		}
+		return nil, io.EOF
+	case err == io.ErrUnexpectedEOF:
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL,
+			fmt.Sprintf("[BUG] truncated event header at pos %d in decompressed transaction payload", tp.decompressedSize-uint64(n)))
+	case err != nil:
+		return nil, vterrors.Wrapf(err, "error decompressing transaction payload")
+	}
+
+	eventLen := uint64(binary.LittleEndian.Uint32(header[BinlogEventLenOffset:]))
+	if eventLen < uint64(len(header)) || tp.decompressedSize-uint64(n)+eventLen > tp.UncompressedSize {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL,
+			fmt.Sprintf("[BUG] event length of %d at pos %d in decompressed transaction payload is beyond the expected payload length of %d",
+				eventLen, tp.decompressedSize-uint64(n), tp.UncompressedSize))
+	}
+	eventData := make([]byte, eventLen)
+	copy(eventData, header)
+	n, err = io.ReadFull(tp.reader, eventData[len(header):])
+	tp.decompressedSize += uint64(n)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "error reading event of length %d from transaction payload", eventLen)
+	}
+	return NewMysql56BinlogEvent(eventData), nil
+}
+
+// Close releases the resources used to decompress the payload.
+func (tp *TransactionPayload) Close() {
+	if tp.streamDecoder != nil {
+		tp.streamDecoder.Close()
+		tp.streamDecoder = nil
+	}
 }
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransactionPayloadData returns the data of a Transaction_payload_event
+// containing the given events, and whose uncompressed size is announced to
+// be uncompressedSize.
+func newTransactionPayloadData(t *testing.T, events []BinlogEvent, uncompressedSize uint64) []byte {
+	var uncompressed []byte
+	for _, ev := range events {
+		uncompressed = append(uncompressed, ev.Bytes()...)
+	}
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	payload := encoder.EncodeAll(uncompressed, nil)
+	require.NoError(t, encoder.Close())
+
+	var data []byte
+	for _, field := range []struct {
+		typ   uint64
+		value uint64
+	}{
+		{payloadSizeField, uint64(len(payload))},
+		{payloadCompressionTypeField, TransactionPayloadCompressionZstd},
+		{payloadUncompressedSizeField, uncompressedSize},
+	} {
+		value := make([]byte, lenEncIntSize(field.value))
+		writeLenEncInt(value, 0, field.value)
+		data = append(data, byte(field.typ), byte(len(value)))
+		data = append(data, value...)
+	}
+	data = append(data, payloadHeaderEndMark)
+	return append(data, payload...)
+}
+
+func TestTransactionPayload(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	f.ChecksumAlgorithm = BinlogChecksumAlgOff
+	s := NewFakeBinlogStream()
+
+	var events []BinlogEvent
+	var size uint64
+	for i := range 20 {
+		ev := NewQueryEvent(f, s, Query{
+			Database: "vt_commerce",
+			SQL:      fmt.Sprintf("insert into customer values (%d)", i),
+		})
+		events = append(events, ev)
+		size += uint64(len(ev.Bytes()))
+	}
+	data := newTransactionPayloadData(t, events, size)
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			if streaming {
+				defer func(maxSize uint64) { zstdInMemoryDecompressorMaxSize = maxSize }(zstdInMemoryDecompressorMaxSize)
+				zstdInMemoryDecompressorMaxSize = 16
+			}
+
+			tp := &TransactionPayload{}
+			require.NoError(t, tp.Decode(data))
+			defer tp.Close()
+			assert.Equal(t, streaming, tp.streamDecoder != nil)
+			assert.Equal(t, size, tp.UncompressedSize)
+
+			for i := range events {
+				ev, err := tp.GetNextEvent()
+				require.NoError(t, err)
+				require.True(t, ev.IsQuery())
+				q, err := ev.Query(f)
+				require.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("insert into customer values (%d)", i), q.SQL)
+			}
+			_, err := tp.GetNextEvent()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestTransactionPayloadErrors(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	f.ChecksumAlgorithm = BinlogChecksumAlgOff
+	s := NewFakeBinlogStream()
+	ev := NewQueryEvent(f, s, Query{SQL: "insert into customer values (1)"})
+	size := uint64(len(ev.Bytes()))
+
+	defer func(maxSize uint64) { zstdInMemoryDecompressorMaxSize = maxSize }(zstdInMemoryDecompressorMaxSize)
+	zstdInMemoryDecompressorMaxSize = 16
+
+	// The payload is smaller than announced.
+	tp := &TransactionPayload{}
+	require.NoError(t, tp.Decode(newTransactionPayloadData(t, []BinlogEvent{ev}, size+10)))
+	defer tp.Close()
+	_, err := tp.GetNextEvent()
+	require.NoError(t, err)
+	_, err = tp.GetNextEvent()
+	assert.ErrorContains(t, err, fmt.Sprintf("decompressed size %d does not match expected size %d", size, size+10))
+
+	// The payload is larger than announced.
+	tp = &TransactionPayload{}
+	require.NoError(t, tp.Decode(newTransactionPayloadData(t, []BinlogEvent{ev, ev}, size+10)))
+	defer tp.Close()
+	_, err = tp.GetNextEvent()
+	require.NoError(t, err)
+	_, err = tp.GetNextEvent()
+	assert.ErrorContains(t, err, "is beyond the expected payload length")
+
+	// The payload ends in the middle of an event header.
+	tp = &TransactionPayload{}
+	truncated := NewMysql56BinlogEvent(ev.Bytes()[:5])
+	require.NoError(t, tp.Decode(newTransactionPayloadData(t, []BinlogEvent{ev, truncated}, size+5)))
+	defer tp.Close()
+	_, err = tp.GetNextEvent()
+	require.NoError(t, err)
+	_, err = tp.GetNextEvent()
+	assert.ErrorContains(t, err, "truncated event header")
+
+	// The in-memory decoding checks the size upfront.
+	zstdInMemoryDecompressorMaxSize = 1 << 20
+	tp = &TransactionPayload{}
+	err = tp.Decode(newTransactionPayloadData(t, []BinlogEvent{ev}, size+10))
+	assert.ErrorContains(t, err, "does not match expected size")
+}
@@ -105,11 +105,12 @@ type filePosQueryEvent struct {
 	filePosFakeEvent
 }
 
-func newFilePosQueryEvent(query string, ts uint32) filePosQueryEvent {
+func newFilePosQueryEvent(query string, ts, serverID uint32) filePosQueryEvent {
 	return filePosQueryEvent{
 		query: query,
 		filePosFakeEvent: filePosFakeEvent{
 			timestamp: ts,
+			serverID:  serverID,
 		},
 	}
 }
@@ -137,6 +138,7 @@ func (ev filePosQueryEvent) Bytes() []byte {
 // filePosFakeEvent is the base class for fake events.
 type filePosFakeEvent struct {
 	timestamp uint32
+	serverID  uint32
 }
 
 func (ev filePosFakeEvent) NextPosition() uint32 {
@@ -211,6 +213,10 @@ func (ev filePosFakeEvent) Timestamp() uint32 {
 	return ev.timestamp
 }
 
+func (ev filePosFakeEvent) ServerID() uint32 {
+	return ev.serverID
+}
+
 func (ev filePosFakeEvent) Format() (BinlogFormat, error) {
 	return BinlogFormat{}, nil
 }
@@ -247,8 +253,8 @@ func (ev filePosFakeEvent) Rows(BinlogFormat, *TableMap) (Rows, error) {
 	return Rows{}, nil
 }
 
-func (ev filePosFakeEvent) TransactionPayload(BinlogFormat) ([]BinlogEvent, error) {
-	return []BinlogEvent{}, nil
+func (ev filePosFakeEvent) TransactionPayload(BinlogFormat) (*TransactionPayload, error) {
+	return &TransactionPayload{}, nil
 }
 
 func (ev filePosFakeEvent) NextLogFile(BinlogFormat) (string, uint64, error) {
@@ -275,10 +281,11 @@ type filePosGTIDEvent struct {
 	gtid replication.FilePosGTID
 }
 
-func newFilePosGTIDEvent(file string, pos uint32, timestamp uint32) filePosGTIDEvent {
+func newFilePosGTIDEvent(file string, pos uint32, timestamp, serverID uint32) filePosGTIDEvent {
 	return filePosGTIDEvent{
 		filePosFakeEvent: filePosFakeEvent{
 			timestamp: timestamp,
+			serverID:  serverID,
 		},
 		gtid: replication.FilePosGTID{
 			File: file,
@@ -392,6 +392,13 @@ func NewUpdateRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, row
 	return newRowsEvent(f, s, eUpdateRowsEventV2, tableID, rows)
 }
 
+// NewPartialUpdateRowsEvent returns a PartialUpdateRows event, as logged
+// when binlog_row_value_options=PARTIAL_JSON. The rows that have a
+// JSONPartialValues bitmap are logged with the partial JSON value option.
+func NewPartialUpdateRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, rows Rows) BinlogEvent {
+	return newRowsEvent(f, s, ePartialUpdateRowsEvent, tableID, rows)
+}
+
 // NewDeleteRowsEvent returns an DeleteRows event. Uses v2.
 func NewDeleteRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, rows Rows) BinlogEvent {
 	return newRowsEvent(f, s, eDeleteRowsEventV2, tableID, rows)
@@ -400,16 +407,19 @@ func NewDeleteRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, row
 // newRowsEvent can create an event of type:
 // eWriteRowsEventV1, eWriteRowsEventV2,
 // eUpdateRowsEventV1, eUpdateRowsEventV2,
-// eDeleteRowsEventV1, eDeleteRowsEventV2.
+// eDeleteRowsEventV1, eDeleteRowsEventV2,
+// ePartialUpdateRowsEvent.
 func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64, rows Rows) BinlogEvent {
 	if f.HeaderSize(typ) == 6 {
 		panic("Not implemented, post_header_length==6")
 	}
 
 	hasIdentify := typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 ||
-		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2
+		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2 ||
+		typ == ePartialUpdateRowsEvent
 	hasData := typ == eWriteRowsEventV1 || typ == eWriteRowsEventV2 ||
-		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2
+		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 ||
+		typ == ePartialUpdateRowsEvent
 
 	rowLen := rows.DataColumns.Count()
 	if hasIdentify {
@@ -427,6 +437,10 @@ func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64,
 			len(row.NullColumns.data) +
 			len(row.Identify) +
 			len(row.Data)
+		if typ == ePartialUpdateRowsEvent {
+			length += 1 + // value options
+				len(row.JSONPartialValues.data)
+		}
 	}
 	data := make([]byte, length)
 
@@ -456,6 +470,13 @@ func newRowsEvent(f BinlogFormat, s *FakeBinlogStream, typ byte, tableID uint64,
 			pos += copy(data[pos:], row.Identify)
 		}
 		if hasData {
+			if typ == ePartialUpdateRowsEvent {
+				if row.JSONPartialValues.Count() > 0 {
+					data[pos] = rowsValueOptionPartialJSON
+				}
+				pos++
+				pos += copy(data[pos:], row.JSONPartialValues.data)
+			}
 			pos += copy(data[pos:], row.NullColumns.data)
 			pos += copy(data[pos:], row.Data)
 		}
@@ -246,6 +246,72 @@ func TestTableMapEvent(t *testing.T) {
 
 }
 
+func TestTableMapOptionalMetadata(t *testing.T) {
+	tm := &TableMap{
+		Types: []byte{
+			binlog.TypeLong,
+			binlog.TypeLongLong,
+			binlog.TypeVarchar,
+			binlog.TypeString,
+			binlog.TypeString,
+			binlog.TypeBlob,
+			binlog.TypeTiny,
+		},
+		Metadata: []uint16{
+			0,
+			0,
+			384,
+			uint16(binlog.TypeEnum)<<8 | 1,
+			uint16(binlog.TypeSet)<<8 | 1,
+			2,
+			0,
+		},
+	}
+	data := []byte{
+		// Signedness of the 3 numeric columns, most significant bit first.
+		1, 1, 0xa0,
+		// Default collation of the text columns, and the ones that differ.
+		2, 3, 45, 1, 63,
+		// Column names.
+		4, 14, 1, 'a', 1, 'b', 1, 'c', 1, 'd', 1, 'e', 1, 'f', 1, 'g',
+		// Set values.
+		5, 5, 2, 1, 'x', 1, 'y',
+		// Enum values.
+		6, 5, 2, 1, 'r', 1, 'g',
+		// Default collation of the enum and set columns.
+		10, 1, 8,
+		// Column visibility, which is skipped.
+		12, 1, 0xfe,
+	}
+	require.NoError(t, readOptionalMetadata(data, 0, tm))
+
+	assert.Equal(t, []collations.ID{45, 63}, tm.ColumnCollationIDs)
+	assert.Equal(t, []collations.ID{8, 8}, tm.EnumSetCollationIDs)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g"}, tm.ColumnNames)
+	assert.Equal(t, [][]string{nil, nil, nil, {"r", "g"}, {"x", "y"}, nil, nil}, tm.EnumSetValues)
+
+	var unsigned []bool
+	var collationIDs []collations.ID
+	for c := range tm.Types {
+		unsigned = append(unsigned, tm.IsUnsigned(c))
+		id, _ := tm.ColumnCollationID(c)
+		collationIDs = append(collationIDs, id)
+	}
+	assert.Equal(t, []bool{true, false, false, false, false, false, true}, unsigned)
+	assert.Equal(t, []collations.ID{collations.Unknown, collations.Unknown, 45, 8, 8, 63, collations.Unknown}, collationIDs)
+
+	// Without optional metadata, nothing is known about the columns.
+	tm.ColumnCollationIDs, tm.EnumSetCollationIDs, tm.UnsignedColumns = nil, nil, Bitmap{}
+	require.NoError(t, readOptionalMetadata(nil, 0, tm))
+	assert.False(t, tm.IsUnsigned(0))
+	_, ok := tm.ColumnCollationID(2)
+	assert.False(t, ok)
+
+	// Truncated fields are errors.
+	require.Error(t, readOptionalMetadata([]byte{4, 14, 1, 'a'}, 0, tm))
+	require.Error(t, readOptionalMetadata([]byte{4, 2, 5, 'a'}, 0, tm))
+}
+
 func TestLargeTableMapEvent(t *testing.T) {
 	f := NewMySQL56BinlogFormat()
 	s := NewFakeBinlogStream()
@@ -376,6 +442,70 @@ func TestRowsEvent(t *testing.T) {
 	assert.NotZero(t, event.Timestamp())
 }
 
+func TestPartialUpdateRowsEvent(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	// The MySQL 5.6 format doesn't know about PARTIAL_UPDATE_ROWS_EVENT.
+	f.HeaderSizes = append(f.HeaderSizes, 0, 0, 0, 10)
+	s := NewFakeBinlogStream()
+
+	tm := &TableMap{
+		Database: "my_database",
+		Name:     "my_table",
+		Types: []byte{
+			binlog.TypeLong,
+			binlog.TypeJSON,
+			binlog.TypeJSON,
+		},
+		CanBeNull: NewServerBitmap(3),
+		Metadata: []uint16{
+			0,
+			4,
+			4,
+		},
+	}
+
+	rows := Rows{
+		IdentifyColumns: NewServerBitmap(3),
+		DataColumns:     NewServerBitmap(3),
+		Rows: []Row{
+			{
+				NullIdentifyColumns: NewServerBitmap(3),
+				NullColumns:         NewServerBitmap(3),
+				JSONPartialValues:   NewServerBitmap(2),
+				Identify: []byte{
+					0x01, 0x00, 0x00, 0x00, // long
+					0x02, 0x00, 0x00, 0x00, 0x04, 0x00, // JSON null
+					0x02, 0x00, 0x00, 0x00, 0x04, 0x00, // JSON null
+				},
+				Data: []byte{
+					0x01, 0x00, 0x00, 0x00, // long
+					0x02, 0x00, 0x00, 0x00, 0x04, 0x01, // JSON true
+					0x05, 0x00, 0x00, 0x00, 0x02, 0x03, '$', '.', 'a', // JSON_REMOVE(%s, '$.a')
+				},
+			},
+		},
+	}
+	for c := 0; c < 3; c++ {
+		rows.IdentifyColumns.Set(c, true)
+		rows.DataColumns.Set(c, true)
+	}
+	// Only the second JSON column holds a partial value.
+	rows.Rows[0].JSONPartialValues.Set(1, true)
+
+	event := NewPartialUpdateRowsEvent(f, s, 0x102030405060, rows)
+	require.True(t, event.IsValid(), "NewPartialUpdateRowsEvent().IsValid() is false")
+	require.True(t, event.IsUpdateRows(), "NewPartialUpdateRowsEvent().IsUpdateRows() is false")
+
+	event, _, err := event.StripChecksum(f)
+	require.NoError(t, err)
+
+	gotRows, err := event.Rows(f, tm)
+	require.NoError(t, err)
+	require.Equal(t, rows, gotRows)
+	require.False(t, gotRows.Rows[0].JSONPartialValues.Bit(0))
+	require.True(t, gotRows.Rows[0].JSONPartialValues.Bit(1))
+}
+
 func TestHeartbeatEvent(t *testing.T) {
 	// MySQL 5.6
 	f := NewMySQL56BinlogFormat()
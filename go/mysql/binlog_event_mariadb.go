@@ -17,7 +17,10 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
+	"compress/zlib"
 	"encoding/binary"
+	"io"
 
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
@@ -73,23 +76,238 @@ func (ev mariadbBinlogEvent) GTID(f BinlogFormat) (replication.GTID, bool, error
 	}, flags2&FLStandalone == 0, nil
 }
 
+// IsPreviousGTIDs implements BinlogEvent.IsPreviousGTIDs().
+//
+// MariaDB logs a GTID_LIST_EVENT instead of a PREVIOUS_GTIDS_EVENT at the
+// start of each binlog file.
+func (ev mariadbBinlogEvent) IsPreviousGTIDs() bool {
+	return ev.Type() == eMariaGTIDListEvent
+}
+
 // PreviousGTIDs implements BinlogEvent.PreviousGTIDs().
+//
+// It returns the position of a GTID_LIST_EVENT, which holds the last GTID
+// of each domain and server ID that was logged in the previous binlog files.
+//
+// Expected format:
+//
+//	# bytes   field
+//	4         number of GTIDs (low 28 bits) and flags (high 4 bits)
+//	-- for each GTID
+//	4         domain ID
+//	4         server ID
+//	8         sequence number
+//	-- endfor
 func (ev mariadbBinlogEvent) PreviousGTIDs(f BinlogFormat) (replication.Position, error) {
-	return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "MariaDB should not provide PREVIOUS_GTIDS_EVENT events")
+	if ev.Type() != eMariaGTIDListEvent {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "MariaDB should not provide PREVIOUS_GTIDS_EVENT events")
+	}
+	const gtidLen = 4 + 4 + 8
+
+	data := ev.Bytes()[f.HeaderLength:]
+	if len(data) < 4 {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "GTID_LIST_EVENT is too short: %d bytes", len(data))
+	}
+	count := int(binary.LittleEndian.Uint32(data[:4]) & 0x0fffffff)
+	data = data[4:]
+	if len(data) < count*gtidLen {
+		return replication.Position{}, vterrors.Errorf(vtrpc.Code_INTERNAL, "GTID_LIST_EVENT of %d GTIDs is too short: %d bytes", count, len(data))
+	}
+
+	var set replication.GTIDSet = replication.MariadbGTIDSet{}
+	for i := 0; i < count; i++ {
+		gtid := data[i*gtidLen : (i+1)*gtidLen]
+		set = set.AddGTID(replication.MariadbGTID{
+			Domain:   binary.LittleEndian.Uint32(gtid[:4]),
+			Server:   binary.LittleEndian.Uint32(gtid[4:8]),
+			Sequence: binary.LittleEndian.Uint64(gtid[8:]),
+		})
+	}
+	return replication.Position{GTIDSet: set}, nil
+}
+
+// IsStartEncryption returns true if this is a START_ENCRYPTION_EVENT. It
+// is logged at the start of the binlog files of a server with
+// encrypt_binlog=ON, and the events that follow it in the file are
+// encrypted. The server decrypts them before sending them to replicas, so
+// it only appears when reading binlog files directly.
+func (ev mariadbBinlogEvent) IsStartEncryption() bool {
+	return ev.Type() == eMariaStartEncryptionEvent
 }
 
 // StripChecksum implements BinlogEvent.StripChecksum().
 func (ev mariadbBinlogEvent) StripChecksum(f BinlogFormat) (BinlogEvent, []byte, error) {
-	switch f.ChecksumAlgorithm {
-	case BinlogChecksumAlgOff, BinlogChecksumAlgUndef:
-		// There is no checksum.
-		return ev, nil, nil
-	default:
-		// Checksum is the last 4 bytes of the event buffer.
-		data := ev.Bytes()
-		length := len(data)
-		checksum := data[length-BinlogCRC32ChecksumLen:]
-		data = data[:length-BinlogCRC32ChecksumLen]
-		return mariadbBinlogEvent{binlogEvent: binlogEvent(data)}, checksum, nil
+	data, checksum, err := splitChecksum(f, ev.Bytes())
+	if err != nil || checksum == nil {
+		return ev, nil, err
+	}
+	return mariadbBinlogEvent{binlogEvent: binlogEvent(data)}, checksum, nil
+}
+
+// mariadbUncompressedTypes maps the types of the events MariaDB logs when
+// log_bin_compress=ON to the types of their uncompressed equivalents.
+var mariadbUncompressedTypes = map[byte]byte{
+	eMariaQueryCompressedEvent:        eQueryEvent,
+	eMariaWriteRowsCompressedEventV1:  eWriteRowsEventV1,
+	eMariaUpdateRowsCompressedEventV1: eUpdateRowsEventV1,
+	eMariaDeleteRowsCompressedEventV1: eDeleteRowsEventV1,
+	eMariaWriteRowsCompressedEventV2:  eWriteRowsEventV2,
+	eMariaUpdateRowsCompressedEventV2: eUpdateRowsEventV2,
+	eMariaDeleteRowsCompressedEventV2: eDeleteRowsEventV2,
+}
+
+// IsQuery implements BinlogEvent.IsQuery().
+func (ev mariadbBinlogEvent) IsQuery() bool {
+	return ev.binlogEvent.IsQuery() || ev.Type() == eMariaQueryCompressedEvent
+}
+
+// IsWriteRows implements BinlogEvent.IsWriteRows().
+func (ev mariadbBinlogEvent) IsWriteRows() bool {
+	return ev.binlogEvent.IsWriteRows() ||
+		ev.Type() == eMariaWriteRowsCompressedEventV1 ||
+		ev.Type() == eMariaWriteRowsCompressedEventV2
+}
+
+// IsUpdateRows implements BinlogEvent.IsUpdateRows().
+func (ev mariadbBinlogEvent) IsUpdateRows() bool {
+	return ev.binlogEvent.IsUpdateRows() ||
+		ev.Type() == eMariaUpdateRowsCompressedEventV1 ||
+		ev.Type() == eMariaUpdateRowsCompressedEventV2
+}
+
+// IsDeleteRows implements BinlogEvent.IsDeleteRows().
+func (ev mariadbBinlogEvent) IsDeleteRows() bool {
+	return ev.binlogEvent.IsDeleteRows() ||
+		ev.Type() == eMariaDeleteRowsCompressedEventV1 ||
+		ev.Type() == eMariaDeleteRowsCompressedEventV2
+}
+
+// Query implements BinlogEvent.Query().
+//
+// The SQL text of a QUERY_COMPRESSED_EVENT is compressed, the rest of the
+// event is the same as a QUERY_EVENT.
+func (ev mariadbBinlogEvent) Query(f BinlogFormat) (Query, error) {
+	query, err := ev.binlogEvent.Query(f)
+	if err != nil || ev.Type() != eMariaQueryCompressedEvent {
+		return query, err
+	}
+	sql, err := mariadbUncompress([]byte(query.SQL))
+	if err != nil {
+		return query, vterrors.Wrapf(err, "can't decompress QUERY_COMPRESSED_EVENT")
+	}
+	query.SQL = string(sql)
+	return query, nil
+}
+
+// Rows implements BinlogEvent.Rows().
+//
+// The rows of a compressed rows event are compressed, the table id, flags,
+// extra data and column bitmaps are the same as in the uncompressed event.
+func (ev mariadbBinlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
+	typ, ok := mariadbUncompressedTypes[ev.Type()]
+	if !ok || typ == eQueryEvent {
+		return ev.binlogEvent.Rows(f, tm)
+	}
+
+	data := ev.Bytes()
+	pos, err := mariadbRowsDataPos(f, typ, data[f.HeaderLength:])
+	if err != nil {
+		return Rows{}, err
+	}
+	pos += int(f.HeaderLength)
+	rows, err := mariadbUncompress(data[pos:])
+	if err != nil {
+		return Rows{}, vterrors.Wrapf(err, "can't decompress rows event")
+	}
+
+	// Rebuild the uncompressed event, and parse it.
+	buf := make([]byte, 0, pos+len(rows))
+	buf = append(buf, data[:pos]...)
+	buf = append(buf, rows...)
+	buf[4] = typ
+	binary.LittleEndian.PutUint32(buf[9:9+4], uint32(len(buf)))
+	return binlogEvent(buf).Rows(f, tm)
+}
+
+// mariadbRowsDataPos returns the position of the rows in the data of a
+// rows event of the given uncompressed type.
+func mariadbRowsDataPos(f BinlogFormat, typ byte, data []byte) (int, error) {
+	// Table id and flags.
+	pos := 6 + 2
+	if f.HeaderSize(typ) == 6 {
+		pos = 4 + 2
+	}
+	if typ == eWriteRowsEventV2 || typ == eUpdateRowsEventV2 || typ == eDeleteRowsEventV2 {
+		if pos+2 > len(data) {
+			return 0, vterrors.Errorf(vtrpc.Code_INTERNAL, "expected extra data length at position %v (data=%v)", pos, data)
+		}
+		// This extraDataLength contains the 2 bytes length.
+		pos += int(binary.LittleEndian.Uint16(data[pos : pos+2]))
+	}
+	columnCount, pos, ok := readLenEncInt(data, pos)
+	if !ok {
+		return 0, vterrors.Errorf(vtrpc.Code_INTERNAL, "expected column count at position %v (data=%v)", pos, data)
+	}
+	bitmapSize := (int(columnCount) + 7) / 8
+	if typ != eWriteRowsEventV1 && typ != eWriteRowsEventV2 {
+		// Bitmap of the columns used for identify.
+		pos += bitmapSize
+	}
+	if typ != eDeleteRowsEventV1 && typ != eDeleteRowsEventV2 {
+		// Bitmap of columns that are present.
+		pos += bitmapSize
+	}
+	if pos > len(data) {
+		return 0, vterrors.Errorf(vtrpc.Code_INTERNAL, "column bitmaps overflow buffer (%v > %v)", pos, len(data))
+	}
+	return pos, nil
+}
+
+// mariadbMaxUncompressedSize is the maximum size of the uncompressed data of
+// an event. Events can't be larger than the maximum max_allowed_packet, 1GiB.
+var mariadbMaxUncompressedSize uint32 = 1 << 30
+
+// mariadbUncompress decompresses the data of a compressed event, as
+// written by binlog_buf_compress() in MariaDB. The uncompressed length
+// comes from the event, so it is checked against the maximum size of an
+// event, and the data is read without trusting it for the allocation.
+//
+// Expected format:
+//
+//	# bytes   field
+//	1         0x80 | algorithm << 4 | number of bytes of the length
+//	1-4       uncompressed length, big endian
+//	rest      zlib compressed data
+func mariadbUncompress(data []byte) ([]byte, error) {
+	if len(data) < 1 || data[0]&0x80 == 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid compressed data header")
+	}
+	if algorithm := data[0] >> 4 & 0x07; algorithm != 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "unsupported compression algorithm %d", algorithm)
+	}
+	lenLen := int(data[0] & 0x07)
+	if lenLen < 1 || lenLen > 4 || 1+lenLen > len(data) {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid compressed data length size %d", lenLen)
+	}
+	var length uint32
+	for _, b := range data[1 : 1+lenLen] {
+		length = length<<8 | uint32(b)
+	}
+	if length > mariadbMaxUncompressedSize {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "uncompressed data length %d exceeds the maximum of %d", length, mariadbMaxUncompressedSize)
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(data[1+lenLen:]))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, int64(length)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != int(length) {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "uncompressed data doesn't have the expected length %d", length)
 	}
+	return out, nil
 }
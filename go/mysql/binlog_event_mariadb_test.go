@@ -17,12 +17,17 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/mysql/binlog"
 	"vitess.io/vitess/go/mysql/replication"
 )
 
@@ -213,3 +218,185 @@ func TestMariaDBSemiSyncAck(t *testing.T) {
 		assert.True(t, e.IsQuery())
 	}
 }
+
+// mariadbCompress compresses data the way binlog_buf_compress() does in
+// MariaDB.
+func mariadbCompress(t *testing.T, data []byte) []byte {
+	lenLen := 1
+	for l := len(data) >> 8; l > 0; l >>= 8 {
+		lenLen++
+	}
+	out := []byte{0x80 | byte(lenLen)}
+	for i := lenLen - 1; i >= 0; i-- {
+		out = append(out, byte(len(data)>>(8*i)))
+	}
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return append(out, buf.Bytes()...)
+}
+
+// compressMariadbEvent returns the compressed version of an event, with the
+// given type, whose data starting at pos is compressed.
+func compressMariadbEvent(t *testing.T, ev BinlogEvent, typ byte, pos int) BinlogEvent {
+	data := ev.Bytes()
+	buf := append([]byte{}, data[:pos]...)
+	buf = append(buf, mariadbCompress(t, data[pos:])...)
+	buf[4] = typ
+	binary.LittleEndian.PutUint32(buf[9:9+4], uint32(len(buf)))
+	return NewMariadbBinlogEvent(buf)
+}
+
+func TestMariadbQueryCompressedEvent(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+
+	for _, sql := range []string{
+		"insert into vt_insert_test(msg) values ('test 0')",
+		"insert into vt_insert_test(msg) values ('" + strings.Repeat("x", 70000) + "')",
+	} {
+		q := Query{
+			Database: "vt_test_keyspace",
+			SQL:      sql,
+		}
+		ev := NewQueryEvent(f, s, q)
+		ev = compressMariadbEvent(t, ev, eMariaQueryCompressedEvent, len(ev.Bytes())-len(sql))
+		require.True(t, ev.IsValid())
+		require.True(t, ev.IsQuery())
+
+		got, err := ev.Query(f)
+		require.NoError(t, err)
+		assert.Equal(t, q, got)
+	}
+}
+
+func TestMariadbRowsCompressedEvent(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+	// MariaDB 10.2+ also has the post-header lengths of the compressed
+	// events in its FORMAT_DESCRIPTION_EVENT.
+	f.HeaderSizes = append(f.HeaderSizes[:eMariaGTIDListEvent:eMariaGTIDListEvent], 0, 13, 8, 8, 8, 10, 10, 10)
+
+	tm := &TableMap{
+		Types: []byte{
+			binlog.TypeLong,
+			binlog.TypeVarchar,
+		},
+		CanBeNull: NewServerBitmap(2),
+		Metadata: []uint16{
+			0,
+			384,
+		},
+	}
+	rows := Rows{
+		Flags:           0x1234,
+		IdentifyColumns: NewServerBitmap(2),
+		DataColumns:     NewServerBitmap(2),
+		Rows: []Row{
+			{
+				NullIdentifyColumns: NewServerBitmap(2),
+				NullColumns:         NewServerBitmap(2),
+				Identify: []byte{
+					0x10, 0x20, 0x30, 0x40, // long
+					0x03, 0x00, // len('abc')
+					'a', 'b', 'c', // 'abc'
+				},
+				Data: []byte{
+					0x10, 0x20, 0x30, 0x40, // long
+					0x04, 0x00, // len('abcd')
+					'a', 'b', 'c', 'd', // 'abcd'
+				},
+			},
+		},
+	}
+	rows.IdentifyColumns.Set(0, true)
+	rows.IdentifyColumns.Set(1, true)
+	rows.DataColumns.Set(0, true)
+	rows.DataColumns.Set(1, true)
+
+	ev := NewUpdateRowsEvent(f, s, 0x102030405060, rows)
+	pos, err := mariadbRowsDataPos(f, eUpdateRowsEventV2, ev.Bytes()[f.HeaderLength:])
+	require.NoError(t, err)
+	ev = compressMariadbEvent(t, ev, eMariaUpdateRowsCompressedEventV2, int(f.HeaderLength)+pos)
+	require.True(t, ev.IsValid())
+	require.True(t, ev.IsUpdateRows())
+	require.False(t, ev.IsWriteRows())
+	require.Equal(t, uint64(0x102030405060), ev.TableID(f))
+
+	got, err := ev.Rows(f, tm)
+	require.NoError(t, err)
+	assert.Equal(t, rows, got)
+}
+
+func TestMariadbUncompress(t *testing.T) {
+	data := []byte("some data to compress")
+	got, err := mariadbUncompress(mariadbCompress(t, data))
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	_, err = mariadbUncompress(nil)
+	assert.ErrorContains(t, err, "invalid compressed data header")
+	_, err = mariadbUncompress([]byte{0x91, 0x01})
+	assert.ErrorContains(t, err, "unsupported compression algorithm 1")
+	_, err = mariadbUncompress([]byte{0x85, 0x01})
+	assert.ErrorContains(t, err, "invalid compressed data length size 5")
+	_, err = mariadbUncompress([]byte{0x81, 0x10, 0x01, 0x02})
+	assert.Error(t, err)
+
+	// The length of the header is checked against the maximum size of an
+	// event, and against the actual length of the data.
+	_, err = mariadbUncompress([]byte{0x84, 0xff, 0xff, 0xff, 0xff})
+	assert.ErrorContains(t, err, "uncompressed data length 4294967295 exceeds the maximum of 1073741824")
+	compressed := mariadbCompress(t, data)
+	compressed[1]--
+	_, err = mariadbUncompress(compressed)
+	assert.ErrorContains(t, err, "uncompressed data doesn't have the expected length 20")
+	compressed[1] += 2
+	_, err = mariadbUncompress(compressed)
+	assert.ErrorContains(t, err, "uncompressed data doesn't have the expected length 22")
+}
+
+func TestMariadbGTIDListEvent(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+
+	data := []byte{
+		0x03, 0x00, 0x00, 0x00, // 3 GTIDs, no flags
+		0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0-1-10
+		0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 0-2-12
+		0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 1-1-5
+	}
+	ev := NewMariadbBinlogEvent(s.Packetize(f, eMariaGTIDListEvent, 0, data))
+	require.True(t, ev.IsValid())
+	require.True(t, ev.IsPreviousGTIDs())
+
+	pos, err := ev.PreviousGTIDs(f)
+	require.NoError(t, err)
+	assert.Equal(t, "0-2-12,1-1-5", pos.GTIDSet.String())
+
+	ev = NewMariadbBinlogEvent(s.Packetize(f, eMariaGTIDListEvent, 0, data[:20]))
+	_, err = ev.PreviousGTIDs(f)
+	assert.ErrorContains(t, err, "GTID_LIST_EVENT of 3 GTIDs is too short")
+
+	ev = NewMariadbBinlogEvent(s.Packetize(f, eQueryEvent, 0, data))
+	assert.False(t, ev.IsPreviousGTIDs())
+}
+
+func TestMariadbStartEncryptionEvent(t *testing.T) {
+	f := NewMariaDBBinlogFormat()
+	s := NewFakeBinlogStream()
+
+	// scheme, key version and nonce.
+	data := append([]byte{0x01, 0x01, 0x00, 0x00, 0x00}, make([]byte, 12)...)
+	bfr, err := NewBinlogFileReader(bytes.NewReader(makeBinlogFile(
+		NewFormatDescriptionEvent(f, s),
+		NewMariadbBinlogEvent(s.Packetize(f, eMariaStartEncryptionEvent, 0, data)),
+	)))
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	assert.ErrorContains(t, err, "encrypted binlogs are not supported")
+}
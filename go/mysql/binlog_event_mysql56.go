@@ -18,6 +18,8 @@ package mysql
 
 import (
 	"encoding/binary"
+	"math/bits"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
@@ -49,7 +51,8 @@ func (ev mysql56BinlogEvent) IsSemiSyncAckRequested() bool {
 
 // IsGTID implements BinlogEvent.IsGTID().
 func (ev mysql56BinlogEvent) IsGTID() bool {
-	return ev.Type() == eGTIDEvent
+	typ := ev.Type()
+	return typ == eGTIDEvent || typ == eGTIDTaggedEvent
 }
 
 // GTID implements BinlogEvent.GTID().
@@ -60,8 +63,14 @@ func (ev mysql56BinlogEvent) IsGTID() bool {
 //	1         flags
 //	16        SID (server UUID)
 //	8         GNO (sequence number, signed int)
+//
+// Tagged GTIDs use a different format, see taggedGTID.
 func (ev mysql56BinlogEvent) GTID(f BinlogFormat) (replication.GTID, bool, error) {
 	data := ev.Bytes()[f.HeaderLength:]
+	if ev.Type() == eGTIDTaggedEvent {
+		gtid, err := taggedGTID(data)
+		return gtid, false /* hasBegin */, err
+	}
 	var sid replication.SID
 	copy(sid[:], data[1:1+16])
 	gno := int64(binary.LittleEndian.Uint64(data[1+16 : 1+16+8]))
@@ -82,20 +91,91 @@ func (ev mysql56BinlogEvent) PreviousGTIDs(f BinlogFormat) (replication.Position
 
 // StripChecksum implements BinlogEvent.StripChecksum().
 func (ev mysql56BinlogEvent) StripChecksum(f BinlogFormat) (BinlogEvent, []byte, error) {
-	switch f.ChecksumAlgorithm {
-	case BinlogChecksumAlgOff, BinlogChecksumAlgUndef:
-		// There is no checksum.
-		return ev, nil, nil
-	case BinlogChecksumAlgCRC32:
-		// Checksum is the last 4 bytes of the event buffer.
-		data := ev.Bytes()
-		length := len(data)
-		checksum := data[length-BinlogCRC32ChecksumLen:]
-		data = data[:length-BinlogCRC32ChecksumLen]
-		return mysql56BinlogEvent{binlogEvent: binlogEvent(data)}, checksum, nil
-	default:
-		// MySQL 5.6 does not guarantee that future checksum algorithms will be
-		// 4 bytes, so we can't support them a priori.
-		return ev, nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "unsupported checksum algorithm: %v", f.ChecksumAlgorithm)
+	data, checksum, err := splitChecksum(f, ev.Bytes())
+	if err != nil || checksum == nil {
+		return ev, nil, err
+	}
+	return mysql56BinlogEvent{binlogEvent: binlogEvent(data)}, checksum, nil
+}
+
+// taggedGTID parses the body of a Gtid_tagged_log_event (MySQL 8.4+). Unlike
+// the other events, it is encoded with the MySQL serialization library, where
+// integers have a variable length (see readVarlenUint):
+//
+//	# bytes   field
+//	varlen    size of the event
+//	varlen    ID of the last field that must be understood
+//
+// followed by the fields, each of them prefixed by its varlen ID:
+//
+//	# id  bytes   field
+//	0     varlen  flags
+//	1     16      SID (server UUID)
+//	2     varlen  GNO (sequence number, signed int)
+//	3     varlen  tag length, followed by the tag
+//
+// The fields that follow are not needed to get the GTID.
+func taggedGTID(data []byte) (replication.GTID, error) {
+	var gtid replication.Mysql56GTID
+	var ok bool
+	pos := 0
+	for i := 0; i < 2; i++ {
+		if _, pos, ok = readVarlenUint(data, pos); !ok {
+			return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read tagged GTID event header")
+		}
+	}
+	for field := uint64(0); field <= 3; field++ {
+		var id uint64
+		if id, pos, ok = readVarlenUint(data, pos); !ok || id != field {
+			return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read field %v of tagged GTID event", field)
+		}
+		switch field {
+		case 0:
+			_, pos, ok = readVarlenUint(data, pos)
+		case 1:
+			var sid []byte
+			if sid, pos, ok = readBytes(data, pos, len(gtid.Server)); ok {
+				copy(gtid.Server[:], sid)
+			}
+		case 2:
+			var gno uint64
+			if gno, pos, ok = readVarlenUint(data, pos); ok {
+				// Signed integers are zigzag encoded.
+				gtid.Sequence = int64(gno>>1) ^ -int64(gno&1)
+			}
+		case 3:
+			var length uint64
+			var tag []byte
+			if length, pos, ok = readVarlenUint(data, pos); ok {
+				if tag, pos, ok = readBytes(data, pos, int(length)); ok {
+					gtid.Tag = strings.ToLower(string(tag))
+				}
+			}
+		}
+		if !ok {
+			return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read field %v of tagged GTID event", field)
+		}
+	}
+	return gtid, nil
+}
+
+// readVarlenUint reads an unsigned integer in the variable-length encoding of
+// the MySQL serialization library: the number of trailing one bits of the
+// first byte is the number of bytes that follow it, and the value is stored
+// little-endian in the remaining bits. A first byte of 0xff is followed by the
+// full 8 bytes of the value.
+func readVarlenUint(data []byte, pos int) (uint64, int, bool) {
+	if pos >= len(data) {
+		return 0, 0, false
+	}
+	size := bits.TrailingZeros8(^data[pos]) + 1
+	if pos+size > len(data) {
+		return 0, 0, false
+	}
+	if size == 9 {
+		return binary.LittleEndian.Uint64(data[pos+1:]), pos + size, true
 	}
+	var buf [8]byte
+	copy(buf[:], data[pos:pos+size])
+	return binary.LittleEndian.Uint64(buf[:]) >> size, pos + size, true
 }
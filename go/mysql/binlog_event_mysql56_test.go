@@ -17,7 +17,11 @@ limitations under the License.
 package mysql
 
 import (
+	"encoding/binary"
 	"fmt"
+	"io"
+	"math"
+	"math/bits"
 	"reflect"
 	"testing"
 
@@ -91,6 +95,71 @@ func TestMysql56GTID(t *testing.T) {
 	assert.Equal(t, want, got, "GTID() = %#v, want %#v", got, want)
 }
 
+// appendVarlenUint appends v in the variable-length encoding of the MySQL
+// serialization library.
+func appendVarlenUint(buf []byte, v uint64) []byte {
+	size := (bits.Len64(v|1)-1)/7 + 1
+	if size > 8 {
+		return binary.LittleEndian.AppendUint64(append(buf, 0xff), v)
+	}
+	encoded := binary.LittleEndian.AppendUint64(nil, v<<size|(1<<(size-1)-1))
+	return append(buf, encoded[:size]...)
+}
+
+func TestReadVarlenUint(t *testing.T) {
+	for _, v := range []uint64{0, 1, 127, 128, 1 << 20, 1<<56 - 1, 1 << 56, math.MaxUint64} {
+		data := appendVarlenUint([]byte{0xaa}, v)
+		got, pos, ok := readVarlenUint(data, 1)
+		require.True(t, ok, "%v", v)
+		assert.Equal(t, v, got)
+		assert.Equal(t, len(data), pos, "%v", v)
+
+		_, _, ok = readVarlenUint(data[:len(data)-1], 1)
+		assert.False(t, ok, "%v", v)
+	}
+}
+
+func TestMysql56TaggedGTID(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	s := NewFakeBinlogStream()
+	sid := replication.SID{0x43, 0x91, 0x92, 0xbd, 0xf3, 0x7c, 0x11, 0xe4, 0xbb, 0xeb, 0x2, 0x42, 0xac, 0x11, 0x3, 0x5a}
+
+	var fields []byte
+	fields = appendVarlenUint(fields, 0)
+	fields = appendVarlenUint(fields, 0) // flags
+	fields = appendVarlenUint(fields, 1)
+	fields = append(fields, sid[:]...)
+	fields = appendVarlenUint(fields, 2)
+	fields = appendVarlenUint(fields, 12345<<1) // gno, zigzag encoded
+	fields = appendVarlenUint(fields, 3)
+	fields = appendVarlenUint(fields, uint64(len("Domain_1")))
+	fields = append(fields, "Domain_1"...)
+	fields = appendVarlenUint(fields, 4)
+	fields = appendVarlenUint(fields, 7) // last_committed
+	data := appendVarlenUint(nil, uint64(len(fields)+2))
+	data = appendVarlenUint(data, 4)
+	data = append(data, fields...)
+
+	ev := NewMysql56BinlogEvent(s.Packetize(f, eGTIDTaggedEvent, 0, data))
+	require.True(t, ev.IsGTID())
+	ev, _, err := ev.StripChecksum(f)
+	require.NoError(t, err)
+
+	got, hasBegin, err := ev.GTID(f)
+	require.NoError(t, err)
+	assert.False(t, hasBegin)
+	want := replication.Mysql56GTID{Server: sid, Tag: "domain_1", Sequence: 12345}
+	assert.Equal(t, want, got)
+	assert.Equal(t, "439192bd-f37c-11e4-bbeb-0242ac11035a:domain_1:12345", got.String())
+
+	// Truncated events are rejected.
+	ev = NewMysql56BinlogEvent(s.Packetize(f, eGTIDTaggedEvent, 0, data[:10]))
+	ev, _, err = ev.StripChecksum(f)
+	require.NoError(t, err)
+	_, _, err = ev.GTID(f)
+	assert.ErrorContains(t, err, "cannot read field 1 of tagged GTID event")
+}
+
 func TestMysql56DecodeTransactionPayload(t *testing.T) {
 	format := NewMySQL56BinlogFormat()
 	tableMap := &TableMap{}
@@ -108,10 +177,16 @@ func TestMysql56DecodeTransactionPayload(t *testing.T) {
 		"[2 sup@planetscale.com]",   // WriteRows event
 		"COMMIT",                    // XID event
 	}
-	internalEvents, err := mysql56TransactionPayloadEvent.TransactionPayload(format)
+	tp, err := mysql56TransactionPayloadEvent.TransactionPayload(format)
 	require.NoError(t, err)
+	defer tp.Close()
 	eventStrs := []string{}
-	for _, ev := range internalEvents {
+	for {
+		ev, err := tp.GetNextEvent()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
 		switch {
 		case ev.IsTableMap():
 			tableMap, err = ev.TableMap(format)
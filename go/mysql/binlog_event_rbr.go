@@ -45,10 +45,6 @@ const (
 	tableMapColumnVisibility
 )
 
-// This byte in the optional metadata indicates that we should
-// read the next 2 bytes as a collation ID.
-const readTwoByteCollationID = 252
-
 // TableMap implements BinlogEvent.TableMap().
 //
 // Expected format (L = total length of event data):
@@ -119,10 +115,9 @@ func (ev binlogEvent) TableMap(f BinlogFormat) (*TableMap, error) {
 	result.CanBeNull, read = newBitmap(data, pos, int(columnCount))
 	pos = read
 
-	// Read any text based column collation values provided in the optional metadata.
-	// The binlog_row_metadata only contains this info for text based columns.
-	var err error
-	if result.ColumnCollationIDs, err = readColumnCollationIDs(data, pos, int(columnCount)); err != nil {
+	// Read the optional metadata, which describes the columns beyond their
+	// type depending on the binlog_row_metadata setting.
+	if err := readOptionalMetadata(data, pos, result); err != nil {
 		return nil, err
 	}
 
@@ -136,7 +131,7 @@ func metadataLength(typ byte) int {
 		// No data here.
 		return 0
 
-	case binlog.TypeFloat, binlog.TypeDouble, binlog.TypeTimestamp2, binlog.TypeDateTime2, binlog.TypeTime2, binlog.TypeJSON, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob, binlog.TypeGeometry:
+	case binlog.TypeFloat, binlog.TypeDouble, binlog.TypeTimestamp2, binlog.TypeDateTime2, binlog.TypeTime2, binlog.TypeJSON, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob, binlog.TypeGeometry, binlog.TypeVector:
 		// One byte.
 		return 1
 
@@ -172,7 +167,7 @@ func metadataRead(data []byte, pos int, typ byte) (uint16, int, error) {
 		// No data here.
 		return 0, pos, nil
 
-	case binlog.TypeFloat, binlog.TypeDouble, binlog.TypeTimestamp2, binlog.TypeDateTime2, binlog.TypeTime2, binlog.TypeJSON, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob, binlog.TypeGeometry:
+	case binlog.TypeFloat, binlog.TypeDouble, binlog.TypeTimestamp2, binlog.TypeDateTime2, binlog.TypeTime2, binlog.TypeJSON, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob, binlog.TypeGeometry, binlog.TypeVector:
 		// One byte.
 		return uint16(data[pos]), pos + 1, nil
 
@@ -198,7 +193,7 @@ func metadataWrite(data []byte, pos int, typ byte, value uint16) int {
 		// No data here.
 		return pos
 
-	case binlog.TypeFloat, binlog.TypeDouble, binlog.TypeTimestamp2, binlog.TypeDateTime2, binlog.TypeTime2, binlog.TypeJSON, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob, binlog.TypeGeometry:
+	case binlog.TypeFloat, binlog.TypeDouble, binlog.TypeTimestamp2, binlog.TypeDateTime2, binlog.TypeTime2, binlog.TypeJSON, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob, binlog.TypeGeometry, binlog.TypeVector:
 		// One byte.
 		data[pos] = byte(value)
 		return pos + 1
@@ -221,43 +216,237 @@ func metadataWrite(data []byte, pos int, typ byte, value uint16) int {
 	}
 }
 
-// readColumnCollationIDs reads from the optional metadata that exists.
+// readOptionalMetadata reads the optional metadata that exists at the end
+// of a TABLE_MAP_EVENT into the TableMap.
 // See: https://github.com/mysql/mysql-server/blob/8.0/libbinlogevents/include/rows_event.h
 // What's included depends on the server configuration:
 // https://dev.mysql.com/doc/refman/en/replication-options-binary-log.html#sysvar_binlog_row_metadata
-// and the table definition.
-// We only care about any collation IDs in the optional metadata and
-// this info is provided in all binlog_row_metadata formats. Note that
-// this info is only provided for text based columns.
-func readColumnCollationIDs(data []byte, pos, count int) ([]collations.ID, error) {
-	collationIDs := make([]collations.ID, 0, count)
+// and the table definition. The signedness and the collations are provided
+// in all binlog_row_metadata formats, the column names and the enum and set
+// values only with binlog_row_metadata=FULL.
+//
+// Each field is encoded as:
+//
+//	# bytes   field
+//	1         field type
+//	<var>     field length (var-len encoded)
+//	<var>     field value
+func readOptionalMetadata(data []byte, pos int, tm *TableMap) error {
+	columnCount := len(tm.Types)
+	numCharColumns := 0
+	numEnumSetColumns := 0
+	for c := 0; c < columnCount; c++ {
+		switch {
+		case isCharacterColumn(tm.Types[c], tm.Metadata[c]):
+			numCharColumns++
+		case isEnumOrSetColumn(tm.Types[c], tm.Metadata[c]):
+			numEnumSetColumns++
+		}
+	}
+
+	tm.ColumnCollationIDs = make([]collations.ID, 0, numCharColumns)
 	for pos < len(data) {
 		fieldType := uint8(data[pos])
 		pos++
 
 		fieldLen, read, ok := readLenEncInt(data, pos)
-		if !ok {
-			return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "error reading optional metadata field length")
+		if !ok || read+int(fieldLen) > len(data) {
+			return vterrors.New(vtrpcpb.Code_INTERNAL, "error reading optional metadata field length")
 		}
 		pos = read
 
 		fieldVal := data[pos : pos+int(fieldLen)]
 		pos += int(fieldLen)
 
-		if fieldType == tableMapDefaultCharset || fieldType == tableMapColumnCharset { // It's one or the other
-			for i := uint64(0); i < fieldLen; i++ {
-				v := uint16(fieldVal[i])
-				if v == readTwoByteCollationID { // The ID is the subsequent 2 bytes
-					v = binary.LittleEndian.Uint16(fieldVal[i+1 : i+3])
-					i += 2
-				}
-				collationIDs = append(collationIDs, collations.ID(v))
-			}
+		var err error
+		switch fieldType {
+		case tableMapSignedness:
+			tm.UnsignedColumns = readSignedness(fieldVal, tm.Types)
+		case tableMapDefaultCharset:
+			tm.ColumnCollationIDs, err = readDefaultCollationIDs(fieldVal, numCharColumns)
+		case tableMapColumnCharset:
+			tm.ColumnCollationIDs, err = readColumnCollationIDs(fieldVal)
+		case tableMapEnumAndSetDefaultCharset:
+			tm.EnumSetCollationIDs, err = readDefaultCollationIDs(fieldVal, numEnumSetColumns)
+		case tableMapEnumAndSetColumnCharset:
+			tm.EnumSetCollationIDs, err = readColumnCollationIDs(fieldVal)
+		case tableMapColumnName:
+			tm.ColumnNames, err = readColumnNames(fieldVal, columnCount)
+		case tableMapEnumStrValue:
+			err = readEnumSetValues(fieldVal, tm, binlog.TypeEnum)
+		case tableMapSetStrValue:
+			err = readEnumSetValues(fieldVal, tm, binlog.TypeSet)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isCharacterColumn returns true if the column has a collation in the
+// optional metadata, i.e. it is a CHAR, VARCHAR, TEXT or BLOB column.
+func isCharacterColumn(typ byte, metadata uint16) bool {
+	switch typ {
+	case binlog.TypeString:
+		realType := byte(metadata >> 8)
+		return realType != binlog.TypeEnum && realType != binlog.TypeSet
+	case binlog.TypeVarchar, binlog.TypeVarString, binlog.TypeTinyBlob, binlog.TypeMediumBlob, binlog.TypeLongBlob, binlog.TypeBlob:
+		return true
+	}
+	return false
+}
+
+// isEnumOrSetColumn returns true if the column is an ENUM or a SET. Those
+// are logged with the TypeString type and their real type in the metadata.
+func isEnumOrSetColumn(typ byte, metadata uint16) bool {
+	switch typ {
+	case binlog.TypeString:
+		realType := byte(metadata >> 8)
+		return realType == binlog.TypeEnum || realType == binlog.TypeSet
+	case binlog.TypeEnum, binlog.TypeSet:
+		return true
+	}
+	return false
+}
+
+// enumOrSetType returns TypeEnum or TypeSet for an ENUM or SET column.
+func enumOrSetType(typ byte, metadata uint16) byte {
+	if typ == binlog.TypeString {
+		return byte(metadata >> 8)
+	}
+	return typ
+}
+
+// isNumericColumn returns true if the column has a signedness in the
+// optional metadata.
+func isNumericColumn(typ byte) bool {
+	switch typ {
+	case binlog.TypeTiny, binlog.TypeShort, binlog.TypeInt24, binlog.TypeLong, binlog.TypeLongLong,
+		binlog.TypeNewDecimal, binlog.TypeFloat, binlog.TypeDouble:
+		return true
+	}
+	return false
+}
+
+// readSignedness reads the signedness of the numeric columns, a bitmap with
+// the most significant bit first where a set bit means unsigned, into a
+// bitmap indexed by column.
+func readSignedness(data []byte, types []byte) Bitmap {
+	unsigned := NewServerBitmap(len(types))
+	numericIndex := 0
+	for c, typ := range types {
+		if !isNumericColumn(typ) {
+			continue
+		}
+		byteIndex := numericIndex / 8
+		if byteIndex >= len(data) {
+			break
+		}
+		if data[byteIndex]&(0x80>>(numericIndex%8)) != 0 {
+			unsigned.Set(c, true)
+		}
+		numericIndex++
+	}
+	return unsigned
+}
+
+// readCollationID reads a single var-len encoded collation ID.
+func readCollationID(data []byte, pos int) (collations.ID, int, error) {
+	v, read, ok := readLenEncInt(data, pos)
+	if !ok {
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error reading collation ID at position %v", pos)
+	}
+	return collations.ID(v), read, nil
+}
+
+// readDefaultCollationIDs reads the collation IDs of count columns from a
+// default collation followed by the (column index, collation) pairs of the
+// columns that don't use it.
+func readDefaultCollationIDs(data []byte, count int) ([]collations.ID, error) {
+	defaultID, pos, err := readCollationID(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	collationIDs := make([]collations.ID, count)
+	for i := range collationIDs {
+		collationIDs[i] = defaultID
+	}
+	for pos < len(data) {
+		index, read, ok := readLenEncInt(data, pos)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error reading column index at position %v", pos)
+		}
+		var id collations.ID
+		id, pos, err = readCollationID(data, read)
+		if err != nil {
+			return nil, err
+		}
+		if int(index) < count {
+			collationIDs[index] = id
 		}
 	}
 	return collationIDs, nil
 }
 
+// readColumnCollationIDs reads the collation ID of each column.
+func readColumnCollationIDs(data []byte) ([]collations.ID, error) {
+	var collationIDs []collations.ID
+	for pos := 0; pos < len(data); {
+		id, read, err := readCollationID(data, pos)
+		if err != nil {
+			return nil, err
+		}
+		collationIDs = append(collationIDs, id)
+		pos = read
+	}
+	return collationIDs, nil
+}
+
+// readLenEncStrings reads count var-len encoded strings.
+func readLenEncStrings(data []byte, pos, count int) ([]string, int, error) {
+	values := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		l, read, ok := readLenEncInt(data, pos)
+		if !ok || read+int(l) > len(data) {
+			return nil, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error reading string at position %v", pos)
+		}
+		values = append(values, string(data[read:read+int(l)]))
+		pos = read + int(l)
+	}
+	return values, pos, nil
+}
+
+// readColumnNames reads the name of each column.
+func readColumnNames(data []byte, count int) ([]string, error) {
+	names, _, err := readLenEncStrings(data, 0, count)
+	return names, err
+}
+
+// readEnumSetValues reads the string values of the ENUM or SET columns,
+// depending on typ, into tm.EnumSetValues.
+func readEnumSetValues(data []byte, tm *TableMap, typ byte) error {
+	if tm.EnumSetValues == nil {
+		tm.EnumSetValues = make([][]string, len(tm.Types))
+	}
+	pos := 0
+	for c := range tm.Types {
+		if !isEnumOrSetColumn(tm.Types[c], tm.Metadata[c]) || enumOrSetType(tm.Types[c], tm.Metadata[c]) != typ {
+			continue
+		}
+		count, read, ok := readLenEncInt(data, pos)
+		if !ok {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error reading number of values at position %v", pos)
+		}
+		var err error
+		tm.EnumSetValues[c], pos, err = readLenEncStrings(data, read, int(count))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Rows implements BinlogEvent.TableMap().
 //
 // Expected format (L = total length of event data):
@@ -276,6 +465,10 @@ func readColumnCollationIDs(data []byte, pos, count int) ([]collations.ID, error
 // -- for each row
 // <var>      null bitmap for identify for present rows
 // <var>      values for each identify field
+// -- if PARTIAL_UPDATE_ROWS_EVENT
+// <var>      value options (var-len encoded)
+// <var>      partial JSON bitmap for present JSON fields, if partial JSON is set in the value options
+// -- endif
 // <var>      null bitmap for data for present rows
 // <var>      values for each data field
 // --
@@ -283,9 +476,11 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 	typ := ev.Type()
 	data := ev.Bytes()[f.HeaderLength:]
 	hasIdentify := typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 ||
-		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2
+		typ == eDeleteRowsEventV1 || typ == eDeleteRowsEventV2 ||
+		typ == ePartialUpdateRowsEvent
 	hasData := typ == eWriteRowsEventV1 || typ == eWriteRowsEventV2 ||
-		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2
+		typ == eUpdateRowsEventV1 || typ == eUpdateRowsEventV2 ||
+		typ == ePartialUpdateRowsEvent
 
 	result := Rows{}
 	pos := 6
@@ -296,7 +491,8 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 	pos += 2
 
 	// version=2 have extra data here.
-	if typ == eWriteRowsEventV2 || typ == eUpdateRowsEventV2 || typ == eDeleteRowsEventV2 {
+	if typ == eWriteRowsEventV2 || typ == eUpdateRowsEventV2 || typ == eDeleteRowsEventV2 ||
+		typ == ePartialUpdateRowsEvent {
 		// This extraDataLength contains the 2 bytes length.
 		extraDataLength := binary.LittleEndian.Uint16(data[pos : pos+2])
 		pos += int(extraDataLength)
@@ -310,6 +506,7 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 
 	numIdentifyColumns := 0
 	numDataColumns := 0
+	numJSONColumns := 0
 
 	if hasIdentify {
 		// Bitmap of the columns used for identify.
@@ -321,6 +518,11 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 		// Bitmap of columns that are present.
 		result.DataColumns, pos = newBitmap(data, pos, int(columnCount))
 		numDataColumns = result.DataColumns.BitCount()
+		for c := 0; c < int(columnCount); c++ {
+			if result.DataColumns.Bit(c) && tm.Types[c] == binlog.TypeJSON {
+				numJSONColumns++
+			}
+		}
 	}
 
 	// One row at a time.
@@ -358,6 +560,18 @@ func (ev binlogEvent) Rows(f BinlogFormat, tm *TableMap) (Rows, error) {
 		}
 
 		if hasData {
+			if typ == ePartialUpdateRowsEvent {
+				valueOptions, read, ok := readLenEncInt(data, pos)
+				if !ok {
+					return result, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "expected value options at position %v (data=%v)", pos, data)
+				}
+				pos = read
+				if valueOptions&rowsValueOptionPartialJSON != 0 {
+					// Bitmap of JSON columns that hold a partial update (amongst the ones that are present).
+					row.JSONPartialValues, pos = newBitmap(data, pos, numJSONColumns)
+				}
+			}
+
 			// Bitmap of columns that are null (amongst the ones that are present).
 			row.NullColumns, pos = newBitmap(data, pos, numDataColumns)
 
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// BinlogFileReader reads the events of a binary log file from disk, e.g. one
+// that was copied from a backup, without a connection to the server that
+// wrote it. The file starts with BinglogMagicNumber, followed by a
+// FORMAT_DESCRIPTION_EVENT which tells how to parse the rest of the events,
+// and usually ends with a ROTATE_EVENT naming the next file.
+type BinlogFileReader struct {
+	r      *bufio.Reader
+	closer io.Closer
+
+	// mariadb is true if the file was written by MariaDB, as told by the
+	// server version of its FORMAT_DESCRIPTION_EVENT.
+	mariadb bool
+	format  BinlogFormat
+
+	// pos is the offset in the file of the next event.
+	pos uint64
+
+	// nextLogFile and nextLogPos are set by the ROTATE_EVENT, if any.
+	nextLogFile string
+	nextLogPos  uint64
+}
+
+// OpenBinlogFile opens the binary log file at path for reading.
+// The returned reader must be closed.
+func OpenBinlogFile(path string) (*BinlogFileReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	bfr, err := NewBinlogFileReader(f)
+	if err != nil {
+		f.Close()
+		return nil, vterrors.Wrapf(err, "can't read binlog file %v", path)
+	}
+	bfr.closer = f
+	return bfr, nil
+}
+
+// NewBinlogFileReader returns a reader of the binary log file read from r,
+// after checking that it starts with the binlog magic number.
+func NewBinlogFileReader(r io.Reader) (*BinlogFileReader, error) {
+	bfr := &BinlogFileReader{
+		r: bufio.NewReader(r),
+	}
+	magic := make([]byte, len(BinglogMagicNumber))
+	if _, err := io.ReadFull(bfr.r, magic); err != nil {
+		return nil, vterrors.Wrapf(err, "can't read binlog magic number")
+	}
+	if !bytes.Equal(magic, BinglogMagicNumber) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "not a binlog file, magic number is %x", magic)
+	}
+	bfr.pos = uint64(len(magic))
+	return bfr, nil
+}
+
+// ReadEvent returns the next event of the file, with its checksum verified
+// and stripped, so that it can be parsed with Format(). It returns io.EOF
+// at the end of the file, and an error if the last event is truncated,
+// e.g. because the file was still being written when it was copied.
+func (bfr *BinlogFileReader) ReadEvent() (BinlogEvent, error) {
+	header := make([]byte, BinlogFixedHeaderLen)
+	if _, err := io.ReadFull(bfr.r, header); err != nil {
+		return nil, bfr.readError(err)
+	}
+	length := binary.LittleEndian.Uint32(header[BinlogEventLenOffset : BinlogEventLenOffset+4])
+	if length < BinlogFixedHeaderLen {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid binlog event length %d at position %d", length, bfr.pos)
+	}
+	buf := make([]byte, length)
+	copy(buf, header)
+	if _, err := io.ReadFull(bfr.r, buf[BinlogFixedHeaderLen:]); err != nil {
+		return nil, bfr.readError(err)
+	}
+
+	if bfr.format.IsZero() {
+		if buf[BinlogEventTypeOffset] != eFormatDescriptionEvent {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "got a real event before FORMAT_DESCRIPTION_EVENT at position %d", bfr.pos)
+		}
+		format, err := binlogEvent(buf).Format()
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "can't parse FORMAT_DESCRIPTION_EVENT")
+		}
+		bfr.format = format
+		bfr.mariadb = bytes.Contains([]byte(format.ServerVersion), []byte("MariaDB"))
+	}
+
+	var ev BinlogEvent
+	if bfr.mariadb {
+		ev = NewMariadbBinlogEvent(buf)
+	} else {
+		ev = NewMysql56BinlogEvent(buf)
+	}
+	if !ev.IsValid() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid binlog event at position %d", bfr.pos)
+	}
+	if mev, ok := ev.(mariadbBinlogEvent); ok && mev.IsStartEncryption() {
+		// We don't have the key to decrypt the rest of the file.
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "encrypted binlogs are not supported: got a START_ENCRYPTION_EVENT at position %d, the binlog file was written with encrypt_binlog=ON", bfr.pos)
+	}
+	if err := VerifyBinlogEventChecksum(bfr.format, ev); err != nil {
+		return nil, vterrors.Wrapf(err, "binlog event at position %d", bfr.pos)
+	}
+	ev, _, err := ev.StripChecksum(bfr.format)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "can't strip checksum from binlog event at position %d", bfr.pos)
+	}
+	bfr.pos += uint64(length)
+
+	if ev.IsRotate() {
+		// NextLogFile expects the checksum to be still there, so we parse
+		// the stripped event ourselves:
+		//
+		//	# bytes  field
+		//	8        position
+		//	8:L      file
+		data := ev.Bytes()[bfr.format.HeaderLength:]
+		if len(data) < 8 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid ROTATE_EVENT at position %d", bfr.pos-uint64(length))
+		}
+		bfr.nextLogPos = binary.LittleEndian.Uint64(data[:8])
+		bfr.nextLogFile = string(data[8:])
+	}
+	return ev, nil
+}
+
+// readError returns the error to return for a failed read of an event.
+func (bfr *BinlogFileReader) readError(err error) error {
+	switch {
+	case errors.Is(err, io.EOF):
+		// We are exactly at the end of the file.
+		return io.EOF
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return vterrors.Wrapf(err, "truncated binlog event at position %d", bfr.pos)
+	default:
+		return vterrors.Wrapf(err, "can't read binlog event at position %d", bfr.pos)
+	}
+}
+
+// Format returns the format of the file, as read from its
+// FORMAT_DESCRIPTION_EVENT. It is zero until the first event is read.
+func (bfr *BinlogFileReader) Format() BinlogFormat {
+	return bfr.format
+}
+
+// Position returns the offset in the file of the next event to be read.
+func (bfr *BinlogFileReader) Position() uint64 {
+	return bfr.pos
+}
+
+// NextLogFile returns the name of the next binary log file and the position
+// of its first event, as read from the ROTATE_EVENT of the file. The name is
+// empty until the ROTATE_EVENT is read, and stays empty if the file has
+// none, e.g. because it is the last one written by the server.
+func (bfr *BinlogFileReader) NextLogFile() (string, uint64) {
+	return bfr.nextLogFile, bfr.nextLogPos
+}
+
+// Close closes the file opened by OpenBinlogFile.
+func (bfr *BinlogFileReader) Close() error {
+	if bfr.closer == nil {
+		return nil
+	}
+	return bfr.closer.Close()
+}
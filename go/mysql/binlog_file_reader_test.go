@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeBinlogFile returns the contents of a binlog file with the given events.
+func makeBinlogFile(events ...BinlogEvent) []byte {
+	var buf bytes.Buffer
+	buf.Write(BinglogMagicNumber)
+	for _, ev := range events {
+		buf.Write(ev.Bytes())
+	}
+	return buf.Bytes()
+}
+
+func TestBinlogFileReader(t *testing.T) {
+	for _, f := range []BinlogFormat{NewMySQL56BinlogFormat(), NewMariaDBBinlogFormat()} {
+		t.Run(f.ServerVersion, func(t *testing.T) {
+			s := NewFakeBinlogStream()
+			data := makeBinlogFile(
+				NewFormatDescriptionEvent(f, s),
+				NewQueryEvent(f, s, Query{Database: "vt_test", SQL: "insert into t values (1)"}),
+				NewXIDEvent(f, s),
+				NewRotateEvent(f, s, 4, "binlog.000002"),
+			)
+			path := filepath.Join(t.TempDir(), "binlog.000001")
+			require.NoError(t, os.WriteFile(path, data, 0o600))
+
+			bfr, err := OpenBinlogFile(path)
+			require.NoError(t, err)
+			defer bfr.Close()
+			assert.EqualValues(t, 4, bfr.Position())
+
+			ev, err := bfr.ReadEvent()
+			require.NoError(t, err)
+			require.True(t, ev.IsFormatDescription())
+			assert.Equal(t, f.ServerVersion, bfr.Format().ServerVersion)
+			assert.Equal(t, f.ChecksumAlgorithm, bfr.Format().ChecksumAlgorithm)
+
+			ev, err = bfr.ReadEvent()
+			require.NoError(t, err)
+			require.True(t, ev.IsQuery())
+			q, err := ev.Query(bfr.Format())
+			require.NoError(t, err)
+			assert.Equal(t, "insert into t values (1)", q.SQL)
+
+			ev, err = bfr.ReadEvent()
+			require.NoError(t, err)
+			require.True(t, ev.IsXID())
+
+			file, _ := bfr.NextLogFile()
+			assert.Empty(t, file)
+			ev, err = bfr.ReadEvent()
+			require.NoError(t, err)
+			require.True(t, ev.IsRotate())
+			file, pos := bfr.NextLogFile()
+			assert.Equal(t, "binlog.000002", file)
+			assert.EqualValues(t, 4, pos)
+			assert.EqualValues(t, len(data), bfr.Position())
+
+			_, err = bfr.ReadEvent()
+			require.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestBinlogFileReaderErrors(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	s := NewFakeBinlogStream()
+	fde := NewFormatDescriptionEvent(f, s)
+	query := NewQueryEvent(f, s, Query{Database: "vt_test", SQL: "insert into t values (1)"})
+
+	_, err := NewBinlogFileReader(bytes.NewReader([]byte{0xfe, 'b'}))
+	assert.ErrorContains(t, err, "can't read binlog magic number")
+
+	_, err = NewBinlogFileReader(bytes.NewReader([]byte("binlog")))
+	assert.ErrorContains(t, err, "not a binlog file")
+
+	bfr, err := NewBinlogFileReader(bytes.NewReader(makeBinlogFile(query)))
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	assert.ErrorContains(t, err, "got a real event before FORMAT_DESCRIPTION_EVENT at position 4")
+
+	data := makeBinlogFile(fde, query)
+	bfr, err = NewBinlogFileReader(bytes.NewReader(data[:len(data)-1]))
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	assert.ErrorContains(t, err, "truncated binlog event")
+
+	data[len(data)-10] ^= 0xff
+	bfr, err = NewBinlogFileReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	require.NoError(t, err)
+	_, err = bfr.ReadEvent()
+	assert.ErrorContains(t, err, "binlog event checksum mismatch")
+}
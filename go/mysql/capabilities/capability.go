@@ -47,7 +47,7 @@ const (
 	CheckConstraintsCapability                                          // supported in MySQL 8.0.16 and above: https://dev.mysql.com/doc/relnotes/mysql/8.0/en/news-8-0-16.html
 	PerformanceSchemaDataLocksTableCapability                           // supported in MySQL 8.0.1 and above: https://dev.mysql.com/doc/relnotes/mysql/8.0/en/news-8-0-1.html
 	InstantDDLXtrabackupCapability                                      // Supported in 8.0.32 and above, solving a MySQL-vs-Xtrabackup bug starting 8.0.29
-	ReplicaTerminologyCapability                                        // Supported in 8.0.26 and above, using SHOW REPLICA STATUS and all variations.
+	ReplicaTerminologyCapability                                        // Supported in 8.0.26 and above, and MariaDB 10.5.1 and above, using SHOW REPLICA STATUS and all variations.
 )
 
 type CapableOf func(capability FlavorCapability) (bool, error)
@@ -124,6 +124,35 @@ func MySQLVersionHasCapability(serverVersion string, capability FlavorCapability
 	}
 }
 
+// MariaDBVersionHasCapability is specific to MariaDB flavors and answers whether
+// the given server version has the requested capability.
+func MariaDBVersionHasCapability(serverVersion string, capability FlavorCapability) (bool, error) {
+	atLeast := func(parts ...int) (bool, error) {
+		return ServerVersionAtLeast(serverVersion, parts...)
+	}
+	switch capability {
+	case ReplicaTerminologyCapability:
+		// MariaDB 10.5.1 introduced SHOW REPLICA STATUS and the REPLICA aliases
+		// of the other SLAVE statements, which are deprecated since.
+		return atLeast(10, 5, 1)
+	case InstantDDLFlavorCapability,
+		InstantAddLastColumnFlavorCapability,
+		InstantAddDropVirtualColumnFlavorCapability,
+		InstantChangeColumnDefaultFlavorCapability,
+		InstantExpandEnumCapability:
+		// MariaDB 10.3.7 introduced ALGORITHM=INSTANT, with instant ADD COLUMN
+		// as the last column, instant changes of DEFAULT and of virtual columns, and
+		// appending values to an ENUM/SET.
+		// reference: https://mariadb.com/kb/en/innodb-online-ddl-operations-with-the-instant-alter-algorithm/
+		return atLeast(10, 3, 7)
+	case InstantAddDropColumnFlavorCapability:
+		// MariaDB 10.4 added instant DROP COLUMN and instant ADD COLUMN in any position.
+		return atLeast(10, 4, 0)
+	default:
+		return false, nil
+	}
+}
+
 // MySQLVersionCapableOf returns a CapableOf function specific to MySQL flavors
 func MySQLVersionCapableOf(serverVersion string) CapableOf {
 	if serverVersion == "" {
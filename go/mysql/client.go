@@ -263,6 +263,7 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		if err != nil {
 			return sqlerror.NewSQLError(sqlerror.CRSSLConnectionError, sqlerror.SSUnknownSQLState, "error loading client cert and ca: %v", err)
 		}
+		clientConfig.ClientSessionCache = params.TLSSessionCache
 
 		// Send the SSLRequest packet.
 		if err := c.writeSSLRequest(capabilities, uint8(params.Charset), params); err != nil {
@@ -295,6 +296,14 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		return sqlerror.NewSQLError(sqlerror.CRSSLConnectionError, sqlerror.SSUnknownSQLState, "server doesn't support ClientSessionTrack but client asked for it")
 	}
 
+	// Query Attributes Capability. They change the COM_QUERY format,
+	// so they are only used if the client asked for them and the server
+	// supports them. Otherwise, the queries are sent without attributes.
+	if params.Flags&CapabilityClientQueryAttributes == CapabilityClientQueryAttributes &&
+		capabilities&CapabilityClientQueryAttributes == CapabilityClientQueryAttributes {
+		c.Capabilities |= CapabilityClientQueryAttributes
+	}
+
 	// Build and send our handshake response 41.
 	// Note this one will never have SSL flag on.
 	if err := c.writeHandshakeResponse41(capabilities, scrambledPassword, uint8(params.Charset), params); err != nil {
@@ -537,7 +546,10 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		CapabilityClientFoundRows&uint32(params.Flags) |
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
-		c.Capabilities&CapabilityClientSessionTrack
+		c.Capabilities&CapabilityClientSessionTrack |
+		// If requested and supported by the server,
+		// CapabilityClientQueryAttributes.
+		c.Capabilities&CapabilityClientQueryAttributes
 
 	// FIXME(alainjobart) add multi statement.
 
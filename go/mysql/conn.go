@@ -163,6 +163,10 @@ type Conn struct {
 	// and CapabilityClientFoundRows.
 	Capabilities uint32
 
+	// ConnAttrs are the connection attributes sent by the client
+	// during the initial handshake. Only set on the server side.
+	ConnAttrs map[string]string
+
 	// closed is set to true when Close() is called on the connection.
 	closed atomic.Bool
 
@@ -203,6 +207,23 @@ type Conn struct {
 	// See: ConnParams.EnableQueryInfo
 	enableQueryInfo bool
 
+	// queryAttributes are the query attributes (see
+	// CapabilityClientQueryAttributes) that go along with a COM_QUERY.
+	// On the server side, they are the attributes sent with the query
+	// currently being executed. On the client side, they are the
+	// attributes that will be sent with the next query.
+	queryAttributes []QueryAttribute
+
+	// trackedSystemVariables are the system variables changed by the
+	// statement being executed, to be reported to the client in the
+	// next OK packet (see CapabilityClientSessionTrack).
+	// Server side only.
+	trackedSystemVariables []TrackedSystemVariable
+
+	// sqlMode is the sql_mode of the session (see SQLMode).
+	// Client side only.
+	sqlMode SQLMode
+
 	// keepAliveOn marks when keep alive is active on the connection.
 	// This is currently used for testing.
 	keepAliveOn bool
@@ -227,6 +248,31 @@ type PrepareData struct {
 	BindVars    map[string]*querypb.BindVariable
 	StatementID uint32
 	ParamsCount uint16
+	// CursorType is the cursor type requested by the current
+	// COM_STMT_EXECUTE. See CursorTypeReadOnly.
+	CursorType byte
+
+	// cursor holds the rows of the read-only cursor opened by the last
+	// execution, until they are fetched with COM_STMT_FETCH.
+	cursor *stmtCursor
+}
+
+// stmtCursor is a materialized read-only cursor: the result of the
+// statement is buffered when it is executed, and the rows are sent to
+// the client in batches when it asks for them with COM_STMT_FETCH.
+// The number of buffered rows is bounded by Listener.MaxCursorRows.
+type stmtCursor struct {
+	fields []*querypb.Field
+	rows   [][]sqltypes.Value
+}
+
+// maxCursorRows returns the maximum number of rows of a cursor, or 0
+// if they are not limited.
+func (c *Conn) maxCursorRows() int {
+	if c.listener == nil {
+		return 0
+	}
+	return c.listener.MaxCursorRows
 }
 
 // execResult is an enum signifying the result of executing a query
@@ -345,6 +391,17 @@ func (c *Conn) endWriterBuffering() error {
 	return c.bufferedWriter.Flush()
 }
 
+// flush writes out any buffered data without ending write buffering.
+func (c *Conn) flush() error {
+	c.bufMu.Lock()
+	defer c.bufMu.Unlock()
+
+	if c.bufferedWriter == nil {
+		return nil
+	}
+	return c.bufferedWriter.Flush()
+}
+
 func (c *Conn) returnReader() {
 	if c.bufferedReader == nil {
 		return
@@ -787,16 +844,23 @@ func (c *Conn) writeOKPacketWithHeader(packetOk *PacketOK, headerType byte) erro
 	// assuming CapabilityClientProtocol41
 	length += 4 // status_flags + warnings
 
-	var gtidData []byte
+	var sessionState []byte
 	if c.Capabilities&CapabilityClientSessionTrack == CapabilityClientSessionTrack {
 		length += lenEncStringSize(packetOk.info) // info
 		if packetOk.statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
-			gtidData = getLenEncString([]byte(packetOk.sessionStateData))
-			gtidData = append([]byte{0x00}, gtidData...)
-			gtidData = getLenEncString(gtidData)
-			gtidData = append([]byte{0x03}, gtidData...)
-			gtidData = append(getLenEncInt(uint64(len(gtidData))), gtidData...)
-			length += len(gtidData)
+			for _, v := range packetOk.trackedSystemVariables {
+				varData := append(getLenEncString([]byte(v.Name)), getLenEncString([]byte(v.Value))...)
+				sessionState = append(sessionState, SessionTrackSystemVariables)
+				sessionState = append(sessionState, getLenEncString(varData)...)
+			}
+			if packetOk.sessionStateData != "" || len(packetOk.trackedSystemVariables) == 0 {
+				gtidEntry := getLenEncString([]byte(packetOk.sessionStateData))
+				gtidEntry = append([]byte{0x00}, gtidEntry...)
+				sessionState = append(sessionState, SessionTrackGtids)
+				sessionState = append(sessionState, getLenEncString(gtidEntry)...)
+			}
+			sessionState = append(getLenEncInt(uint64(len(sessionState))), sessionState...)
+			length += len(sessionState)
 		}
 	} else {
 		length += len(packetOk.info) // info
@@ -812,7 +876,7 @@ func (c *Conn) writeOKPacketWithHeader(packetOk *PacketOK, headerType byte) erro
 	if c.Capabilities&CapabilityClientSessionTrack == CapabilityClientSessionTrack {
 		data.writeLenEncString(packetOk.info)
 		if packetOk.statusFlags&ServerSessionStateChanged == ServerSessionStateChanged {
-			data.writeEOFString(string(gtidData))
+			data.writeEOFString(string(sessionState))
 		}
 	} else {
 		data.writeEOFString(packetOk.info)
@@ -967,6 +1031,8 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		}
 	case ComStmtReset:
 		return c.handleComStmtReset(data)
+	case ComStmtFetch:
+		return c.handleComStmtFetch(data)
 	case ComResetConnection:
 		c.handleComResetConnection(handler)
 		return true
@@ -1093,6 +1159,7 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 			prepare.BindVars[k] = nil
 		}
 	}
+	prepare.cursor = nil
 
 	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
 		log.Error("Error writing ComStmtReset OK packet to client %v: %v", c.ConnectionID, err)
@@ -1101,6 +1168,49 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 	return true
 }
 
+func (c *Conn) handleComStmtFetch(data []byte) (kontinue bool) {
+	c.startWriterBuffering()
+	defer func() {
+		if err := c.endWriterBuffering(); err != nil {
+			log.Errorf("conn %v: flush() failed: %v", c.ID(), err)
+			kontinue = false
+		}
+	}()
+
+	stmtID, numRows, ok := c.parseComStmtFetch(data)
+	c.recycleReadPacket()
+	if !ok {
+		return c.writeErrorAndLog(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "error parsing statement fetch packet")
+	}
+
+	prepare, ok := c.PrepareData[stmtID]
+	if !ok {
+		return c.writeErrorAndLog(sqlerror.ERUnknownStmtHandler, sqlerror.SSUnknownSQLState, "Unknown prepared statement handler (%d) given to mysqld_stmt_fetch", stmtID)
+	}
+	cursor := prepare.cursor
+	if cursor == nil {
+		return c.writeErrorAndLog(sqlerror.ERStmtHasNoOpenCursor, sqlerror.SSUnknownSQLState, "The statement (%d) has no open cursor.", stmtID)
+	}
+
+	n := min(int(numRows), len(cursor.rows))
+	if err := c.writeBinaryRows(&sqltypes.Result{Fields: cursor.fields, Rows: cursor.rows[:n]}); err != nil {
+		log.Errorf("Error writing fetched rows to %s: %v", c, err)
+		return false
+	}
+	cursor.rows = cursor.rows[n:]
+
+	flags := c.StatusFlags | ServerStatusCursorExists
+	if len(cursor.rows) == 0 {
+		flags |= ServerStatusLastRowSent
+		prepare.cursor = nil
+	}
+	if err := c.writeEndResultWithFlags(flags, 0); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
 func (c *Conn) handleComStmtSendLongData(data []byte) bool {
 	stmtID, paramID, chunk, ok := c.parseComStmtSendLongData(data)
 	c.recycleReadPacket()
@@ -1123,7 +1233,8 @@ func (c *Conn) handleComStmtSendLongData(data []byte) bool {
 	}
 
 	key := fmt.Sprintf("v%d", paramID+1)
-	if val, ok := prepare.BindVars[key]; ok {
+	// The value may have been cleared by COM_STMT_RESET.
+	if val := prepare.BindVars[key]; val != nil {
 		val.Value = append(val.Value, chunk...)
 	} else {
 		prepare.BindVars[key] = sqltypes.BytesBindVariable(chunk)
@@ -1140,7 +1251,7 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		}
 	}()
 	queryStart := time.Now()
-	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
+	stmtID, cursorType, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
 
 	if stmtID != uint32(0) {
@@ -1159,6 +1270,13 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
 	prepare := c.PrepareData[stmtID]
+	// Executing the statement again closes the cursor it had opened.
+	prepare.cursor = nil
+	prepare.CursorType = cursorType
+	// cursor buffers the rows of a read-only cursor. It is only opened
+	// once the statement succeeded, so a failed execution doesn't leave
+	// a partial result behind for COM_STMT_FETCH.
+	var cursor *stmtCursor
 	err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
@@ -1181,16 +1299,27 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 				}
 				return c.writeOKPacket(&ok)
 			}
-			if err := c.writeFields(qr); err != nil {
+			if cursorType&CursorTypeReadOnly != 0 {
+				// The rows are buffered and sent by COM_STMT_FETCH.
+				cursor = &stmtCursor{fields: qr.Fields}
+			} else if err := c.writeFields(qr); err != nil {
 				return err
 			}
 		}
 
+		if cursor != nil {
+			if maxRows := c.maxCursorRows(); maxRows > 0 && len(cursor.rows)+len(qr.Rows) > maxRows {
+				return sqlerror.NewSQLError(sqlerror.EROutOfResources, sqlerror.SSUnknownSQLState, "cursor row count exceeded allowed limit of %d", maxRows)
+			}
+			cursor.rows = append(cursor.rows, qr.Rows...)
+			return nil
+		}
 		return c.writeBinaryRows(qr)
 	})
 
-	// If no field was sent, we expect an error.
-	if !fieldSent {
+	// If no field was sent, we expect an error. Nothing was sent either
+	// for a cursor, so its errors are returned as is.
+	if !fieldSent || (cursor != nil && err != nil) {
 		// This is just a failsafe. Should never happen.
 		if err == nil || err == io.EOF {
 			err = sqlerror.NewSQLErrorFromError(errors.New("unexpected: query ended without no results and no error"))
@@ -1208,8 +1337,15 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 
 		// Send the end packet only sendFinished is false (results were streamed).
 		// In this case the affectedRows and lastInsertID are always 0 since it
-		// was a read operation.
-		if !sendFinished {
+		// was a read operation. With a cursor, the fields are only sent along
+		// with the end packet once all the rows are buffered.
+		if cursor != nil {
+			if err := c.writeCursorFields(&sqltypes.Result{Fields: cursor.fields}); err != nil {
+				log.Errorf("Error writing result to %s: %v", c, err)
+				return false
+			}
+			prepare.cursor = cursor
+		} else if !sendFinished {
 			if err := c.writeEndResult(false, 0, 0, handler.WarningCount(c)); err != nil {
 				log.Errorf("Error writing result to %s: %v", c, err)
 				return false
@@ -1308,6 +1444,9 @@ func (c *Conn) handleComSetOption(data []byte) bool {
 	if ok {
 		switch operation {
 		case 0:
+			if c.listener != nil && c.listener.DisableMultiStatements {
+				return c.writeErrorAndLog(sqlerror.ERNotSupportedYet, sqlerror.SSUnknownSQLState, "multi statements are disabled on this server")
+			}
 			c.Capabilities |= CapabilityClientMultiStatements
 		case 1:
 			c.Capabilities &^= CapabilityClientMultiStatements
@@ -1358,11 +1497,18 @@ func (c *Conn) handleComQuery(handler Handler, data []byte) (kontinue bool) {
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, attributes, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+	c.queryAttributes = attributes
+	defer func() {
+		c.queryAttributes = nil
+		c.trackedSystemVariables = nil
+	}()
 
 	var queries []string
-	var err error
 	if c.Capabilities&CapabilityClientMultiStatements != 0 {
 		queries, err = handler.Env().Parser().SplitStatementToPieces(query)
 		if err != nil {
@@ -1420,12 +1566,17 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 				// to extract the affected rows and last insert id from the result
 				// struct here since clients expect it.
 				ok := PacketOK{
-					affectedRows:     qr.RowsAffected,
-					lastInsertID:     qr.InsertID,
-					statusFlags:      flag,
-					warnings:         handler.WarningCount(c),
-					info:             "",
-					sessionStateData: qr.SessionStateChanges,
+					affectedRows:           qr.RowsAffected,
+					lastInsertID:           qr.InsertID,
+					statusFlags:            flag,
+					warnings:               handler.WarningCount(c),
+					info:                   "",
+					sessionStateData:       qr.SessionStateChanges,
+					trackedSystemVariables: c.trackedSystemVariables,
+				}
+				c.trackedSystemVariables = nil
+				if c.Capabilities&CapabilityClientSessionTrack != 0 && (ok.sessionStateData != "" || len(ok.trackedSystemVariables) > 0) {
+					ok.statusFlags |= ServerSessionStateChanged
 				}
 				return c.writeOKPacket(&ok)
 			}
@@ -1523,6 +1674,10 @@ type PacketOK struct {
 
 	// at the moment, we only store GTID information in this field
 	sessionStateData string
+
+	// trackedSystemVariables are sent along with the GTID information
+	// when the session state changed.
+	trackedSystemVariables []TrackedSystemVariable
 }
 
 func (c *Conn) parseOKPacket(packetOK *PacketOK, in []byte) error {
@@ -1588,6 +1743,21 @@ func (c *Conn) parseOKPacket(packetOK *PacketOK, in []byte) error {
 					return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid OK packet session state change length for type %v", sscType)
 				}
 
+				if sscType == SessionTrackSystemVariables {
+					name, ok := data.readLenEncString()
+					if !ok {
+						return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid OK packet system variable name: %v", data.data)
+					}
+					value, ok := data.readLenEncString()
+					if !ok {
+						return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid OK packet system variable value: %v", data.data)
+					}
+					packetOK.trackedSystemVariables = append(packetOK.trackedSystemVariables, TrackedSystemVariable{Name: name, Value: value})
+					if strings.EqualFold(name, "sql_mode") {
+						c.sqlMode = SQLMode(value)
+					}
+					continue
+				}
 				if sscType != SessionTrackGtids {
 					// Still need to increase the pointer here to indicate we're consuming
 					// but otherwise ignoring the rest of this packet
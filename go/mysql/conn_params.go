@@ -17,6 +17,7 @@ limitations under the License.
 package mysql
 
 import (
+	"crypto/tls"
 	"time"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -65,6 +66,11 @@ type ConnParams struct {
 	FlushDelay time.Duration
 
 	TruncateErrLen int
+
+	// TLSSessionCache, if set, is used to resume TLS sessions when
+	// connecting again to the same server, which avoids a full TLS
+	// handshake.
+	TLSSessionCache tls.ClientSessionCache
 }
 
 // EnableSSL will set the right flag on the parameters.
@@ -281,6 +281,30 @@ func TestBasicPackets(t *testing.T) {
 	assert.EqualValues(89, packetOk.warnings)
 	assert.EqualValues("foo-bar", packetOk.sessionStateData)
 
+	// Write OK packet with tracked system variables and GTIDs, read it, compare.
+	ok = PacketOK{
+		affectedRows:     1,
+		statusFlags:      ServerSessionStateChanged,
+		sessionStateData: "uuid:1-5",
+		trackedSystemVariables: []TrackedSystemVariable{
+			{Name: "autocommit", Value: "OFF"},
+			{Name: "time_zone", Value: "+01:00"},
+			{Name: "sql_mode", Value: "ANSI_QUOTES"},
+		},
+	}
+	err = sConn.writeOKPacket(&ok)
+	require.NoError(err)
+
+	data, err = cConn.ReadPacket()
+	require.NoError(err)
+	packetOk = PacketOK{}
+	err = cConn.parseOKPacket(&packetOk, data)
+	require.NoError(err)
+	assert.EqualValues(1, packetOk.affectedRows)
+	assert.EqualValues("uuid:1-5", packetOk.sessionStateData)
+	assert.Equal(ok.trackedSystemVariables, packetOk.trackedSystemVariables)
+	assert.EqualValues("ANSI_QUOTES", cConn.SQLMode())
+
 	// Write OK packet with EOF header, read it, compare.
 	ok = PacketOK{
 		affectedRows: 12,
@@ -379,7 +403,8 @@ func TestOkPackets(t *testing.T) {
 00000000  00 00 00 00 40 00 00 00  14 00 0f 0a 61 75 74 6f  |....@.......auto|
 00000010  63 6f 6d 6d 69 74 03 4f  46 46 02 01 31           |commit.OFF..1|`,
 		dataOut: `
-00000000  00 00 00 00 40 00 00 00  04 03 02 00 00           |....@........|`,
+00000000  00 00 00 00 40 00 00 00  11 00 0f 0a 61 75 74 6f  |....@.......auto|
+00000010  63 6f 6d 6d 69 74 03 4f  46 46                    |commit.OFF|`,
 		cc: CapabilityClientProtocol41 | CapabilityClientTransactions | CapabilityClientSessionTrack,
 	}, {
 		dataIn: `
@@ -902,6 +927,170 @@ func TestMultiStatementOnSplitError(t *testing.T) {
 	require.EqualValues(t, data[0], ErrPacket) // we should see the error here
 }
 
+func TestMultiStatementDisabled(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	sConn.listener = &Listener{DisableMultiStatements: true}
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Without CapabilityClientMultiStatements, the query is not split,
+	// and only one result is sent.
+	err := cConn.WriteComQuery("select 1;select 2")
+	require.NoError(t, err)
+	handler := &testRun{t: t, err: fmt.Errorf("execution failed")}
+	res := sConn.handleNextCommand(handler)
+	require.True(t, res, "we should not break the connection in case of no errors")
+	data, more, _, err := cConn.ReadQueryResult(100, true)
+	require.NoError(t, err)
+	require.False(t, more)
+	require.True(t, data.Equal(selectRowsResult))
+
+	// Multi statements cannot be turned on with COM_SET_OPTION either.
+	cConn.sequence = 0
+	err = cConn.writeComSetOption(0)
+	require.NoError(t, err)
+	res = sConn.handleNextCommand(handler)
+	require.True(t, res, "we should not break the connection because of a rejected option")
+	require.Zero(t, sConn.Capabilities&CapabilityClientMultiStatements)
+	pkt, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, ErrPacket, pkt[0])
+}
+
+type cursorTestRun struct {
+	testRun
+}
+
+func (t cursorTestRun) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	// Stream the rows in two results.
+	if err := callback(&sqltypes.Result{Fields: selectRowsResult.Fields, Rows: selectRowsResult.Rows[:1]}); err != nil {
+		return err
+	}
+	if t.err != nil {
+		return t.err
+	}
+	return callback(&sqltypes.Result{Rows: selectRowsResult.Rows[1:]})
+}
+
+func TestComStmtFetch(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select * from t"}
+	handler := &cursorTestRun{testRun{t: t}}
+
+	writeCommand := func(data ...byte) {
+		cConn.sequence = 0
+		err := cConn.writePacket(append(make([]byte, packetHeaderSize), data...))
+		require.NoError(t, err)
+		require.True(t, sConn.handleNextCommand(handler))
+	}
+	readStatus := func() uint16 {
+		pkt, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.EqualValues(t, EOFPacket, pkt[0])
+		status, _, ok := readUint16(pkt, 3)
+		require.True(t, ok)
+		return status
+	}
+	readRows := func(count int) {
+		for i := 0; i < count; i++ {
+			pkt, err := cConn.ReadPacket()
+			require.NoError(t, err)
+			require.EqualValues(t, 0, pkt[0], "binary row expected")
+		}
+	}
+
+	readError := func() error {
+		pkt, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.EqualValues(t, ErrPacket, pkt[0])
+		return ParseErrorPacket(pkt)
+	}
+
+	// Fetching without an open cursor fails.
+	writeCommand(ComStmtFetch, 1, 0, 0, 0, 1, 0, 0, 0)
+	require.ErrorContains(t, readError(), "The statement (1) has no open cursor. (errno 1421)")
+
+	// Executing with a read-only cursor only sends the fields.
+	writeCommand(ComStmtExecute, 1, 0, 0, 0, CursorTypeReadOnly, 1, 0, 0, 0)
+	pkt, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, []byte{2}, pkt)
+	for range selectRowsResult.Fields {
+		_, err := cConn.ReadPacket()
+		require.NoError(t, err)
+	}
+	assert.NotZero(t, readStatus()&ServerStatusCursorExists)
+
+	// The rows are fetched one at a time.
+	writeCommand(ComStmtFetch, 1, 0, 0, 0, 1, 0, 0, 0)
+	readRows(1)
+	status := readStatus()
+	assert.NotZero(t, status&ServerStatusCursorExists)
+	assert.Zero(t, status&ServerStatusLastRowSent)
+
+	writeCommand(ComStmtFetch, 1, 0, 0, 0, 1, 0, 0, 0)
+	readRows(1)
+	assert.NotZero(t, readStatus()&ServerStatusLastRowSent)
+
+	// The cursor is closed once all rows were sent.
+	writeCommand(ComStmtFetch, 1, 0, 0, 0, 1, 0, 0, 0)
+	require.ErrorContains(t, readError(), "errno 1421")
+
+	// A failed execution returns its error and doesn't open a cursor.
+	handler.err = sqlerror.NewSQLError(sqlerror.ERQueryInterrupted, sqlerror.SSUnknownSQLState, "interrupted")
+	writeCommand(ComStmtExecute, 1, 0, 0, 0, CursorTypeReadOnly, 1, 0, 0, 0)
+	require.ErrorContains(t, readError(), "interrupted (errno 1317)")
+	writeCommand(ComStmtFetch, 1, 0, 0, 0, 1, 0, 0, 0)
+	require.ErrorContains(t, readError(), "errno 1421")
+	handler.err = nil
+
+	// The number of buffered rows is limited.
+	sConn.listener = &Listener{MaxCursorRows: 1}
+	writeCommand(ComStmtExecute, 1, 0, 0, 0, CursorTypeReadOnly, 1, 0, 0, 0)
+	require.ErrorContains(t, readError(), "cursor row count exceeded allowed limit of 1 (errno 1041)")
+	writeCommand(ComStmtFetch, 1, 0, 0, 0, 1, 0, 0, 0)
+	require.ErrorContains(t, readError(), "errno 1421")
+}
+
+func TestComStmtSendLongDataAfterReset(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.PrepareData[1] = &PrepareData{
+		StatementID: 1,
+		ParamsCount: 1,
+		BindVars:    map[string]*querypb.BindVariable{"v1": sqltypes.BytesBindVariable([]byte("ab"))},
+	}
+	handler := &testRun{t: t}
+
+	cConn.sequence = 0
+	err := cConn.writePacket([]byte{0, 0, 0, 0, ComStmtReset, 1, 0, 0, 0})
+	require.NoError(t, err)
+	require.True(t, sConn.handleNextCommand(handler))
+	pkt, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, OKPacket, pkt[0])
+
+	for _, chunk := range []string{"cd", "ef"} {
+		cConn.sequence = 0
+		err = cConn.writePacket(createSendLongDataPacket(1, 0, []byte(chunk)))
+		require.NoError(t, err)
+		require.True(t, sConn.handleNextCommand(handler))
+	}
+	assert.Equal(t, []byte("cdef"), sConn.PrepareData[1].BindVars["v1"].Value)
+}
+
 func TestInitDbAgainstWrongDbDoesNotDropConnection(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	sConn.Capabilities |= CapabilityClientMultiStatements
@@ -82,9 +82,10 @@ const (
 	// CLIENT_ODBC 1 << 6
 	// No special behavior since 3.22.
 
-	// CLIENT_LOCAL_FILES 1 << 7
+	// CapabilityClientLocalFiles is CLIENT_LOCAL_FILES.
 	// Client can use LOCAL INFILE request of LOAD DATA|XML.
-	// We do not set it.
+	// The server only sets it if local infile is enabled.
+	CapabilityClientLocalFiles = 1 << 7
 
 	// CLIENT_IGNORE_SPACE 1 << 8
 	// Parser can ignore spaces before '('.
@@ -148,6 +149,17 @@ const (
 	// CapabilityClientDeprecateEOF is CLIENT_DEPRECATE_EOF
 	// Expects an OK (instead of EOF) after the resultset rows of a Text Resultset.
 	CapabilityClientDeprecateEOF = 1 << 24
+
+	// CLIENT_OPTIONAL_RESULTSET_METADATA 1 << 25
+	// Not supported.
+
+	// CLIENT_ZSTD_COMPRESSION_ALGORITHM 1 << 26
+	// Not supported.
+
+	// CapabilityClientQueryAttributes is CLIENT_QUERY_ATTRIBUTES
+	// Can send optional named parameters (query attributes) in
+	// COM_QUERY and COM_STMT_EXECUTE.
+	CapabilityClientQueryAttributes = 1 << 27
 )
 
 // Status flags. They are returned by the server in a few cases.
@@ -257,6 +269,9 @@ const (
 
 	// NullValue is the encoded value of NULL.
 	NullValue = 0xfb
+
+	// LocalInfilePacket is the header of the LOCAL INFILE request packet.
+	LocalInfilePacket = 0xfb
 )
 
 // Auth packet types
@@ -274,6 +289,16 @@ const (
 	AuthSwitchRequestPacket = 0xfe
 )
 
+// Cursor types of COM_STMT_EXECUTE.
+const (
+	// CursorTypeNoCursor means the rows are sent with the result set.
+	CursorTypeNoCursor = 0x00
+
+	// CursorTypeReadOnly opens a read-only cursor, and the rows are
+	// fetched with COM_STMT_FETCH.
+	CursorTypeReadOnly = 0x01
+)
+
 var typeInt24, _ = sqltypes.TypeToMySQL(sqltypes.Int24)
 var typeTimestamp, _ = sqltypes.TypeToMySQL(sqltypes.Timestamp)
 var typeYear, _ = sqltypes.TypeToMySQL(sqltypes.Year)
@@ -390,10 +390,11 @@ func (db *DB) HandleQuery(c *mysql.Conn, query string, callback func(*sqltypes.R
 		return nil
 	}
 
-	// Using special handling for setting the charset and connection collation.
+	// Using special handling for setting the charset and connection collation,
+	// and the tracking of the GTIDs by the tablet's connections.
 	// The driver may send this at connection time, and we don't want it to
 	// interfere.
-	if key == "set names utf8" || strings.HasPrefix(key, "set collation_connection = ") {
+	if key == "set names utf8" || strings.HasPrefix(key, "set collation_connection = ") || strings.HasPrefix(key, "set @@session.session_track_gtids = ") {
 		defer db.mu.Unlock()
 
 		// log error
@@ -19,15 +19,17 @@ package mysql
 import (
 	"context"
 	"errors"
-	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"vitess.io/vitess/go/constants/sidecar"
 	"vitess.io/vitess/go/mysql/capabilities"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 )
 
@@ -49,6 +51,9 @@ const (
 	// mysql8VersionPrefix is the prefix for 8.x mysql version, such as 8.0.19,
 	// but also newer ones like 8.4.0.
 	mysql8VersionPrefix = "8."
+	// readHeartbeatQuery reads the timestamp, in nanoseconds, of the most
+	// recent heartbeat replicated from the primary.
+	readHeartbeatQuery = "SELECT MAX(ts) FROM %s.heartbeat"
 )
 
 // flavor is the abstract interface for a flavor.
@@ -120,7 +125,7 @@ type flavor interface {
 
 	// setReplicationSourceCommand returns the command to use the provided host/port
 	// as the new replication source (without changing any GTID position).
-	setReplicationSourceCommand(params *ConnParams, host string, port int32, connectRetry int) string
+	setReplicationSourceCommand(c *Conn, params *ConnParams, host string, port int32, connectRetry int) string
 
 	// status returns the result of the appropriate status command,
 	// with parsed replication position.
@@ -135,7 +140,7 @@ type flavor interface {
 	// succeed.
 	waitUntilPosition(ctx context.Context, c *Conn, pos replication.Position) error
 	// catchupToGTIDCommands returns the command to catch up to a given GTID.
-	catchupToGTIDCommands(params *ConnParams, pos replication.Position) []string
+	catchupToGTIDCommands(c *Conn, params *ConnParams, pos replication.Position) []string
 
 	// binlogReplicatedUpdates returns the field to use to check replica updates.
 	binlogReplicatedUpdates() string
@@ -174,9 +179,9 @@ func GetFlavor(serverVersion string, flavorFunc func() flavor) (f flavor, capabl
 	case strings.Contains(serverVersion, mariaDBVersionString):
 		mariadbVersion, err := strconv.ParseFloat(serverVersion[:4], 64)
 		if err != nil || mariadbVersion < 10.2 {
-			f = mariadbFlavor101{mariadbFlavor{serverVersion: fmt.Sprintf("%f", mariadbVersion)}}
+			f = mariadbFlavor101{mariadbFlavor{serverVersion: serverVersion}}
 		} else {
-			f = mariadbFlavor102{mariadbFlavor{serverVersion: fmt.Sprintf("%f", mariadbVersion)}}
+			f = mariadbFlavor102{mariadbFlavor{serverVersion: serverVersion}}
 		}
 	case strings.HasPrefix(serverVersion, mysql8VersionPrefix):
 		recent, _ := capabilities.MySQLVersionHasCapability(serverVersion, capabilities.ReplicaTerminologyCapability)
@@ -365,7 +370,7 @@ func (c *Conn) SetReplicationPositionCommands(pos replication.Position) []string
 // It is guaranteed to be called with replication stopped.
 // It should not start or stop replication.
 func (c *Conn) SetReplicationSourceCommand(params *ConnParams, host string, port int32, connectRetry int) string {
-	return c.flavor.setReplicationSourceCommand(params, host, port, connectRetry)
+	return c.flavor.setReplicationSourceCommand(c, params, host, port, connectRetry)
 }
 
 // resultToMap is a helper function used by ShowReplicationStatus.
@@ -388,6 +393,29 @@ func resultToMap(qr *sqltypes.Result) (map[string]string, error) {
 	return result, nil
 }
 
+// readHeartbeatLag sets the lag of the replica computed from the most
+// recent heartbeat written by the primary in the heartbeat table of the
+// sidecar database. The lag is left unknown if the table can't be read,
+// like on the servers which Vitess doesn't manage, or is empty.
+func (c *Conn) readHeartbeatLag(status *replication.ReplicationStatus) {
+	qr, err := c.ExecuteFetch(sqlparser.BuildParsedQuery(readHeartbeatQuery, sidecar.GetIdentifier()).Query, 1, false)
+	if err != nil || len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 || qr.Rows[0][0].IsNull() {
+		return
+	}
+	ts, err := qr.Rows[0][0].ToInt64()
+	if err != nil {
+		return
+	}
+	lag := time.Since(time.Unix(0, ts))
+	if lag < 0 {
+		// The clocks of the servers are not in sync.
+		lag = 0
+	}
+	status.HeartbeatLagSeconds = uint32(lag / time.Second)
+	status.HeartbeatLagKnown = true
+	status.HeartbeatTime = time.Unix(0, ts)
+}
+
 // ShowReplicationStatus executes the right command to fetch replication status,
 // and returns a parsed Position with other fields.
 func (c *Conn) ShowReplicationStatus() (replication.ReplicationStatus, error) {
@@ -401,13 +429,40 @@ func (c *Conn) ShowPrimaryStatus() (replication.PrimaryStatus, error) {
 }
 
 // WaitUntilPosition waits until the given position is reached or until the
-// context expires. It returns an error if we did not succeed.
+// context expires. It returns an error if we did not succeed. File based
+// positions are waited on with MASTER_POS_WAIT, whatever the flavor.
 func (c *Conn) WaitUntilPosition(ctx context.Context, pos replication.Position) error {
+	if pos.MatchesFlavor(replication.FilePosFlavorID) {
+		return c.WaitUntilFilePosition(ctx, pos)
+	}
 	return c.flavor.waitUntilPosition(ctx, c, pos)
 }
 
+// WaitUntilPrimaryStatus waits until the server has applied everything up to
+// the given primary status, or until the context expires. The GTID position
+// is used when GTIDs are enabled on the server, and the file position
+// otherwise, so that sources without GTIDs can be waited on as well.
+func (c *Conn) WaitUntilPrimaryStatus(ctx context.Context, status replication.PrimaryStatus) error {
+	if status.Position.IsZero() {
+		return c.WaitUntilPosition(ctx, status.FilePosition)
+	}
+	gtidMode, err := c.flavor.gtidMode(c)
+	if err != nil {
+		return err
+	}
+	switch strings.ToUpper(gtidMode) {
+	case "OFF", "OFF_PERMISSIVE":
+		// Transactions are anonymous, so only the file position is reliable.
+		if status.FilePosition.IsZero() {
+			return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "gtid_mode is %v and no file position was given to wait for", gtidMode)
+		}
+		return c.WaitUntilPosition(ctx, status.FilePosition)
+	}
+	return c.WaitUntilPosition(ctx, status.Position)
+}
+
 func (c *Conn) CatchupToGTIDCommands(params *ConnParams, pos replication.Position) []string {
-	return c.flavor.catchupToGTIDCommands(params, pos)
+	return c.flavor.catchupToGTIDCommands(c, params, pos)
 }
 
 // WaitUntilFilePosition waits until the given position is reached or until
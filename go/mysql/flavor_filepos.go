@@ -152,7 +152,7 @@ func (flv *filePosFlavor) readBinlogEvent(c *Conn) (BinlogEvent, error) {
 		}
 		event := newFilePosBinlogEventWithSemiSyncInfo(buf, semiSyncAckRequested)
 		switch event.Type() {
-		case eGTIDEvent, eAnonymousGTIDEvent, ePreviousGTIDsEvent, eMariaGTIDListEvent:
+		case eGTIDEvent, eGTIDTaggedEvent, eAnonymousGTIDEvent, ePreviousGTIDsEvent, eMariaGTIDListEvent:
 			// Don't transmit fake or irrelevant events because we should not
 			// resume replication at these positions.
 			continue
@@ -162,7 +162,7 @@ func (flv *filePosFlavor) readBinlogEvent(c *Conn) (BinlogEvent, error) {
 			flags2 := result[8+4]
 			// This means that it's also a BEGIN event.
 			if flags2&FLStandalone == 0 {
-				return newFilePosQueryEvent("begin", event.Timestamp()), nil
+				return newFilePosQueryEvent("begin", event.Timestamp(), event.ServerID()), nil
 			}
 			// Otherwise, don't send this event.
 			continue
@@ -184,21 +184,21 @@ func (flv *filePosFlavor) readBinlogEvent(c *Conn) (BinlogEvent, error) {
 			eDeleteRowsEventV0, eDeleteRowsEventV1, eDeleteRowsEventV2,
 			eUpdateRowsEventV0, eUpdateRowsEventV1, eUpdateRowsEventV2:
 			flv.savedEvent = event
-			return newFilePosGTIDEvent(flv.file, event.nextPosition(flv.format), event.Timestamp()), nil
+			return newFilePosGTIDEvent(flv.file, event.nextPosition(flv.format), event.Timestamp(), event.ServerID()), nil
 		case eQueryEvent:
 			q, err := event.Query(flv.format)
 			if err == nil && strings.HasPrefix(q.SQL, "#") {
 				continue
 			}
 			flv.savedEvent = event
-			return newFilePosGTIDEvent(flv.file, event.nextPosition(flv.format), event.Timestamp()), nil
+			return newFilePosGTIDEvent(flv.file, event.nextPosition(flv.format), event.Timestamp(), event.ServerID()), nil
 		default:
 			// For unrecognized events, send a fake "repair" event so that
 			// the position gets transmitted.
 			if !flv.format.IsZero() {
 				if v := event.nextPosition(flv.format); v != 0 {
-					flv.savedEvent = newFilePosQueryEvent("repair", event.Timestamp())
-					return newFilePosGTIDEvent(flv.file, v, event.Timestamp()), nil
+					flv.savedEvent = newFilePosQueryEvent("repair", event.Timestamp(), event.ServerID())
+					return newFilePosGTIDEvent(flv.file, v, event.Timestamp(), event.ServerID()), nil
 				}
 			}
 		}
@@ -228,7 +228,7 @@ func (flv *filePosFlavor) setReplicationPositionCommands(pos replication.Positio
 }
 
 // setReplicationSourceCommand is part of the Flavor interface.
-func (flv *filePosFlavor) setReplicationSourceCommand(params *ConnParams, host string, port int32, connectRetry int) string {
+func (flv *filePosFlavor) setReplicationSourceCommand(c *Conn, params *ConnParams, host string, port int32, connectRetry int) string {
 	return "unsupported"
 }
 
@@ -249,7 +249,12 @@ func (flv *filePosFlavor) status(c *Conn) (replication.ReplicationStatus, error)
 		return replication.ReplicationStatus{}, err
 	}
 
-	return replication.ParseFilePosReplicationStatus(resultMap)
+	status, err := replication.ParseFilePosReplicationStatus(resultMap)
+	if err != nil {
+		return replication.ReplicationStatus{}, err
+	}
+	c.readHeartbeatLag(&status)
+	return status, nil
 }
 
 // primaryStatus is part of the Flavor interface.
@@ -347,7 +352,7 @@ func (*filePosFlavor) supportsCapability(capability capabilities.FlavorCapabilit
 	}
 }
 
-func (*filePosFlavor) catchupToGTIDCommands(_ *ConnParams, _ replication.Position) []string {
+func (*filePosFlavor) catchupToGTIDCommands(_ *Conn, _ *ConnParams, _ replication.Position) []string {
 	return []string{"unsupported"}
 }
 
@@ -74,44 +74,54 @@ func (mariadbFlavor) gtidMode(c *Conn) (string, error) {
 	return "", nil
 }
 
-func (mariadbFlavor) startReplicationUntilAfter(pos replication.Position) string {
-	return fmt.Sprintf("START SLAVE UNTIL master_gtid_pos = \"%s\"", pos)
+// replica returns the keyword of the replication statements: REPLICA if
+// the server supports it, or the deprecated SLAVE otherwise, as the older
+// servers don't know the REPLICA aliases.
+func (f mariadbFlavor) replica() string {
+	if ok, _ := f.supportsCapability(capabilities.ReplicaTerminologyCapability); ok {
+		return "REPLICA"
+	}
+	return "SLAVE"
+}
+
+func (f mariadbFlavor) startReplicationUntilAfter(pos replication.Position) string {
+	return fmt.Sprintf("START %s UNTIL master_gtid_pos = '%s'", f.replica(), pos)
 }
 
-func (mariadbFlavor) startSQLThreadUntilAfter(pos replication.Position) string {
-	return fmt.Sprintf("START SLAVE SQL_THREAD UNTIL master_gtid_pos = \"%s\"", pos)
+func (f mariadbFlavor) startSQLThreadUntilAfter(pos replication.Position) string {
+	return fmt.Sprintf("START %s SQL_THREAD UNTIL master_gtid_pos = '%s'", f.replica(), pos)
 }
 
-func (mariadbFlavor) startReplicationCommand() string {
-	return "START SLAVE"
+func (f mariadbFlavor) startReplicationCommand() string {
+	return "START " + f.replica()
 }
 
-func (mariadbFlavor) restartReplicationCommands() []string {
+func (f mariadbFlavor) restartReplicationCommands() []string {
 	return []string{
-		"STOP SLAVE",
-		"RESET SLAVE",
-		"START SLAVE",
+		"STOP " + f.replica(),
+		"RESET " + f.replica(),
+		"START " + f.replica(),
 	}
 }
 
-func (mariadbFlavor) stopReplicationCommand() string {
-	return "STOP SLAVE"
+func (f mariadbFlavor) stopReplicationCommand() string {
+	return "STOP " + f.replica()
 }
 
-func (mariadbFlavor) resetReplicationCommand() string {
-	return "RESET SLAVE ALL"
+func (f mariadbFlavor) resetReplicationCommand() string {
+	return "RESET " + f.replica() + " ALL"
 }
 
-func (mariadbFlavor) stopIOThreadCommand() string {
-	return "STOP SLAVE IO_THREAD"
+func (f mariadbFlavor) stopIOThreadCommand() string {
+	return "STOP " + f.replica() + " IO_THREAD"
 }
 
-func (mariadbFlavor) stopSQLThreadCommand() string {
-	return "STOP SLAVE SQL_THREAD"
+func (f mariadbFlavor) stopSQLThreadCommand() string {
+	return "STOP " + f.replica() + " SQL_THREAD"
 }
 
-func (mariadbFlavor) startSQLThreadCommand() string {
-	return "START SLAVE SQL_THREAD"
+func (f mariadbFlavor) startSQLThreadCommand() string {
+	return "START " + f.replica() + " SQL_THREAD"
 }
 
 // sendBinlogDumpCommand is part of the Flavor interface.
@@ -142,10 +152,10 @@ func (mariadbFlavor) sendBinlogDumpCommand(c *Conn, serverID uint32, binlogFilen
 }
 
 // resetReplicationCommands is part of the Flavor interface.
-func (mariadbFlavor) resetReplicationCommands(c *Conn) []string {
+func (f mariadbFlavor) resetReplicationCommands(c *Conn) []string {
 	resetCommands := []string{
-		"STOP SLAVE",
-		"RESET SLAVE ALL", // "ALL" makes it forget source host:port.
+		"STOP " + f.replica(),
+		"RESET " + f.replica() + " ALL", // "ALL" makes it forget source host:port.
 		"RESET MASTER",
 		"SET GLOBAL gtid_slave_pos = ''",
 	}
@@ -157,9 +167,9 @@ func (mariadbFlavor) resetReplicationCommands(c *Conn) []string {
 }
 
 // resetReplicationParametersCommands is part of the Flavor interface.
-func (mariadbFlavor) resetReplicationParametersCommands(c *Conn) []string {
+func (f mariadbFlavor) resetReplicationParametersCommands(c *Conn) []string {
 	resetCommands := []string{
-		"RESET SLAVE ALL", // "ALL" makes it forget source host:port.
+		"RESET " + f.replica() + " ALL", // "ALL" makes it forget source host:port.
 	}
 	return resetCommands
 }
@@ -187,36 +197,37 @@ func (mariadbFlavor) setReplicationPositionCommands(pos replication.Position) []
 	}
 }
 
-func (mariadbFlavor) setReplicationSourceCommand(params *ConnParams, host string, port int32, connectRetry int) string {
+func (mariadbFlavor) setReplicationSourceCommand(c *Conn, params *ConnParams, host string, port int32, connectRetry int) string {
+	sqlMode := c.SQLMode()
 	args := []string{
-		fmt.Sprintf("MASTER_HOST = '%s'", host),
+		fmt.Sprintf("MASTER_HOST = %s", sqlMode.EncodeString(host)),
 		fmt.Sprintf("MASTER_PORT = %d", port),
-		fmt.Sprintf("MASTER_USER = '%s'", params.Uname),
-		fmt.Sprintf("MASTER_PASSWORD = '%s'", params.Pass),
+		fmt.Sprintf("MASTER_USER = %s", sqlMode.EncodeString(params.Uname)),
+		fmt.Sprintf("MASTER_PASSWORD = %s", sqlMode.EncodeString(params.Pass)),
 		fmt.Sprintf("MASTER_CONNECT_RETRY = %d", connectRetry),
 	}
 	if params.SslEnabled() {
 		args = append(args, "MASTER_SSL = 1")
 	}
 	if params.SslCa != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_CA = '%s'", params.SslCa))
+		args = append(args, fmt.Sprintf("MASTER_SSL_CA = %s", sqlMode.EncodeString(params.SslCa)))
 	}
 	if params.SslCaPath != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_CAPATH = '%s'", params.SslCaPath))
+		args = append(args, fmt.Sprintf("MASTER_SSL_CAPATH = %s", sqlMode.EncodeString(params.SslCaPath)))
 	}
 	if params.SslCert != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_CERT = '%s'", params.SslCert))
+		args = append(args, fmt.Sprintf("MASTER_SSL_CERT = %s", sqlMode.EncodeString(params.SslCert)))
 	}
 	if params.SslKey != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_KEY = '%s'", params.SslKey))
+		args = append(args, fmt.Sprintf("MASTER_SSL_KEY = %s", sqlMode.EncodeString(params.SslKey)))
 	}
 	args = append(args, "MASTER_USE_GTID = current_pos")
 	return "CHANGE MASTER TO\n  " + strings.Join(args, ",\n  ")
 }
 
 // status is part of the Flavor interface.
-func (mariadbFlavor) status(c *Conn) (replication.ReplicationStatus, error) {
-	qr, err := c.ExecuteFetch("SHOW ALL SLAVES STATUS", 100, true /* wantfields */)
+func (f mariadbFlavor) status(c *Conn) (replication.ReplicationStatus, error) {
+	qr, err := c.ExecuteFetch("SHOW ALL "+f.replica()+"S STATUS", 100, true /* wantfields */)
 	if err != nil {
 		return replication.ReplicationStatus{}, err
 	}
@@ -231,7 +242,12 @@ func (mariadbFlavor) status(c *Conn) (replication.ReplicationStatus, error) {
 		return replication.ReplicationStatus{}, err
 	}
 
-	return replication.ParseMariadbReplicationStatus(resultMap)
+	status, err := replication.ParseMariadbReplicationStatus(resultMap)
+	if err != nil {
+		return replication.ReplicationStatus{}, err
+	}
+	c.readHeartbeatLag(&status)
+	return status, nil
 }
 
 // primaryStatus is part of the Flavor interface.
@@ -317,14 +333,11 @@ func (mariadbFlavor) readBinlogEvent(c *Conn) (BinlogEvent, error) {
 }
 
 // supportsCapability is part of the Flavor interface.
-func (mariadbFlavor) supportsCapability(capability capabilities.FlavorCapability) (bool, error) {
-	switch capability {
-	default:
-		return false, nil
-	}
+func (f mariadbFlavor) supportsCapability(capability capabilities.FlavorCapability) (bool, error) {
+	return capabilities.MariaDBVersionHasCapability(f.serverVersion, capability)
 }
 
-func (mariadbFlavor) catchupToGTIDCommands(_ *ConnParams, _ replication.Position) []string {
+func (mariadbFlavor) catchupToGTIDCommands(_ *Conn, _ *ConnParams, _ replication.Position) []string {
 	return []string{"unsupported"}
 }
 
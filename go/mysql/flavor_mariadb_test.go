@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql/replication"
 )
 
 func TestMariadbSetReplicationSourceCommand(t *testing.T) {
@@ -75,3 +77,20 @@ func TestMariadbSetReplicationSourceCommandSSL(t *testing.T) {
 	assert.Equal(t, want, got, "mariadbFlavor.SetReplicationSourceCommand(%#v, %#v, %#v, %#v) = %#v, want %#v", params, host, port, connectRetry, got, want)
 
 }
+
+func TestMariadbReplicaTerminology(t *testing.T) {
+	pos := replication.Position{GTIDSet: replication.MariadbGTIDSet{0: replication.MariadbGTID{Domain: 0, Server: 1, Sequence: 2}}}
+
+	f, _, _ := GetFlavor("10.4.31-MariaDB", nil)
+	assert.Equal(t, "START SLAVE", f.startReplicationCommand())
+	assert.Equal(t, "STOP SLAVE IO_THREAD", f.stopIOThreadCommand())
+	assert.Equal(t, "RESET SLAVE ALL", f.resetReplicationCommand())
+	assert.Equal(t, "START SLAVE UNTIL master_gtid_pos = '0-1-2'", f.startReplicationUntilAfter(pos))
+
+	f, _, _ = GetFlavor("10.5.1-MariaDB-log", nil)
+	assert.Equal(t, "START REPLICA", f.startReplicationCommand())
+	assert.Equal(t, "STOP REPLICA IO_THREAD", f.stopIOThreadCommand())
+	assert.Equal(t, "RESET REPLICA ALL", f.resetReplicationCommand())
+	assert.Equal(t, "START REPLICA UNTIL master_gtid_pos = '0-1-2'", f.startReplicationUntilAfter(pos))
+	assert.Equal(t, []string{"STOP REPLICA", "RESET REPLICA", "START REPLICA"}, f.restartReplicationCommands())
+}
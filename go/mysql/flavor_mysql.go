@@ -278,7 +278,12 @@ func (mysqlFlavor) status(c *Conn) (replication.ReplicationStatus, error) {
 		return replication.ReplicationStatus{}, err
 	}
 
-	return replication.ParseMysqlReplicationStatus(resultMap, false)
+	status, err := replication.ParseMysqlReplicationStatus(resultMap, false)
+	if err != nil {
+		return replication.ReplicationStatus{}, err
+	}
+	c.readHeartbeatLag(&status)
+	return status, nil
 }
 
 // primaryStatus is part of the Flavor interface.
@@ -317,7 +322,12 @@ func (mysqlFlavor8) status(c *Conn) (replication.ReplicationStatus, error) {
 		return replication.ReplicationStatus{}, err
 	}
 
-	return replication.ParseMysqlReplicationStatus(resultMap, true)
+	status, err := replication.ParseMysqlReplicationStatus(resultMap, true)
+	if err != nil {
+		return replication.ReplicationStatus{}, err
+	}
+	c.readHeartbeatLag(&status)
+	return status, nil
 }
 
 // waitUntilPosition is part of the Flavor interface.
@@ -482,61 +492,64 @@ func (f mysqlFlavor8) supportsCapability(capability capabilities.FlavorCapabilit
 	return capabilities.MySQLVersionHasCapability(f.serverVersion, capability)
 }
 
-func (mysqlFlavor) setReplicationSourceCommand(params *ConnParams, host string, port int32, connectRetry int) string {
+func (mysqlFlavor) setReplicationSourceCommand(c *Conn, params *ConnParams, host string, port int32, connectRetry int) string {
+	sqlMode := c.SQLMode()
 	args := []string{
-		fmt.Sprintf("MASTER_HOST = '%s'", host),
+		fmt.Sprintf("MASTER_HOST = %s", sqlMode.EncodeString(host)),
 		fmt.Sprintf("MASTER_PORT = %d", port),
-		fmt.Sprintf("MASTER_USER = '%s'", params.Uname),
-		fmt.Sprintf("MASTER_PASSWORD = '%s'", params.Pass),
+		fmt.Sprintf("MASTER_USER = %s", sqlMode.EncodeString(params.Uname)),
+		fmt.Sprintf("MASTER_PASSWORD = %s", sqlMode.EncodeString(params.Pass)),
 		fmt.Sprintf("MASTER_CONNECT_RETRY = %d", connectRetry),
 	}
 	if params.SslEnabled() {
 		args = append(args, "MASTER_SSL = 1")
 	}
 	if params.SslCa != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_CA = '%s'", params.SslCa))
+		args = append(args, fmt.Sprintf("MASTER_SSL_CA = %s", sqlMode.EncodeString(params.SslCa)))
 	}
 	if params.SslCaPath != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_CAPATH = '%s'", params.SslCaPath))
+		args = append(args, fmt.Sprintf("MASTER_SSL_CAPATH = %s", sqlMode.EncodeString(params.SslCaPath)))
 	}
 	if params.SslCert != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_CERT = '%s'", params.SslCert))
+		args = append(args, fmt.Sprintf("MASTER_SSL_CERT = %s", sqlMode.EncodeString(params.SslCert)))
 	}
 	if params.SslKey != "" {
-		args = append(args, fmt.Sprintf("MASTER_SSL_KEY = '%s'", params.SslKey))
+		args = append(args, fmt.Sprintf("MASTER_SSL_KEY = %s", sqlMode.EncodeString(params.SslKey)))
 	}
 	args = append(args, "MASTER_AUTO_POSITION = 1")
 	return "CHANGE MASTER TO\n  " + strings.Join(args, ",\n  ")
 }
 
-func (mysqlFlavor8) setReplicationSourceCommand(params *ConnParams, host string, port int32, connectRetry int) string {
+func (mysqlFlavor8) setReplicationSourceCommand(c *Conn, params *ConnParams, host string, port int32, connectRetry int) string {
+	sqlMode := c.SQLMode()
 	args := []string{
-		fmt.Sprintf("SOURCE_HOST = '%s'", host),
+		fmt.Sprintf("SOURCE_HOST = %s", sqlMode.EncodeString(host)),
 		fmt.Sprintf("SOURCE_PORT = %d", port),
-		fmt.Sprintf("SOURCE_USER = '%s'", params.Uname),
-		fmt.Sprintf("SOURCE_PASSWORD = '%s'", params.Pass),
+		fmt.Sprintf("SOURCE_USER = %s", sqlMode.EncodeString(params.Uname)),
+		fmt.Sprintf("SOURCE_PASSWORD = %s", sqlMode.EncodeString(params.Pass)),
 		fmt.Sprintf("SOURCE_CONNECT_RETRY = %d", connectRetry),
 	}
 	if params.SslEnabled() {
 		args = append(args, "SOURCE_SSL = 1")
 	}
 	if params.SslCa != "" {
-		args = append(args, fmt.Sprintf("SOURCE_SSL_CA = '%s'", params.SslCa))
+		args = append(args, fmt.Sprintf("SOURCE_SSL_CA = %s", sqlMode.EncodeString(params.SslCa)))
 	}
 	if params.SslCaPath != "" {
-		args = append(args, fmt.Sprintf("SOURCE_SSL_CAPATH = '%s'", params.SslCaPath))
+		args = append(args, fmt.Sprintf("SOURCE_SSL_CAPATH = %s", sqlMode.EncodeString(params.SslCaPath)))
 	}
 	if params.SslCert != "" {
-		args = append(args, fmt.Sprintf("SOURCE_SSL_CERT = '%s'", params.SslCert))
+		args = append(args, fmt.Sprintf("SOURCE_SSL_CERT = %s", sqlMode.EncodeString(params.SslCert)))
 	}
 	if params.SslKey != "" {
-		args = append(args, fmt.Sprintf("SOURCE_SSL_KEY = '%s'", params.SslKey))
+		args = append(args, fmt.Sprintf("SOURCE_SSL_KEY = %s", sqlMode.EncodeString(params.SslKey)))
 	}
 	args = append(args, "SOURCE_AUTO_POSITION = 1")
 	return "CHANGE REPLICATION SOURCE TO\n  " + strings.Join(args, ",\n  ")
 }
 
-func (mysqlFlavor) catchupToGTIDCommands(params *ConnParams, replPos replication.Position) []string {
+func (mysqlFlavor) catchupToGTIDCommands(c *Conn, params *ConnParams, replPos replication.Position) []string {
+	sqlMode := c.SQLMode()
 	cmds := []string{
 		"STOP SLAVE FOR CHANNEL '' ",
 		"STOP SLAVE IO_THREAD FOR CHANNEL ''",
@@ -544,20 +557,20 @@ func (mysqlFlavor) catchupToGTIDCommands(params *ConnParams, replPos replication
 
 	if params.SslCa != "" || params.SslCert != "" {
 		// We need to use TLS
-		cmd := fmt.Sprintf("CHANGE MASTER TO MASTER_HOST='%s', MASTER_PORT=%d, MASTER_USER='%s', MASTER_PASSWORD='%s', MASTER_AUTO_POSITION=1, MASTER_SSL=1", params.Host, params.Port, params.Uname, params.Pass)
+		cmd := fmt.Sprintf("CHANGE MASTER TO MASTER_HOST=%s, MASTER_PORT=%d, MASTER_USER=%s, MASTER_PASSWORD=%s, MASTER_AUTO_POSITION=1, MASTER_SSL=1", sqlMode.EncodeString(params.Host), params.Port, sqlMode.EncodeString(params.Uname), sqlMode.EncodeString(params.Pass))
 		if params.SslCa != "" {
-			cmd += fmt.Sprintf(", MASTER_SSL_CA='%s'", params.SslCa)
+			cmd += fmt.Sprintf(", MASTER_SSL_CA=%s", sqlMode.EncodeString(params.SslCa))
 		}
 		if params.SslCert != "" {
-			cmd += fmt.Sprintf(", MASTER_SSL_CERT='%s'", params.SslCert)
+			cmd += fmt.Sprintf(", MASTER_SSL_CERT=%s", sqlMode.EncodeString(params.SslCert))
 		}
 		if params.SslKey != "" {
-			cmd += fmt.Sprintf(", MASTER_SSL_KEY='%s'", params.SslKey)
+			cmd += fmt.Sprintf(", MASTER_SSL_KEY=%s", sqlMode.EncodeString(params.SslKey))
 		}
 		cmds = append(cmds, cmd+";")
 	} else {
 		// No TLS
-		cmds = append(cmds, fmt.Sprintf("CHANGE MASTER TO MASTER_HOST='%s', MASTER_PORT=%d, MASTER_USER='%s', MASTER_PASSWORD='%s', MASTER_AUTO_POSITION=1;", params.Host, params.Port, params.Uname, params.Pass))
+		cmds = append(cmds, fmt.Sprintf("CHANGE MASTER TO MASTER_HOST=%s, MASTER_PORT=%d, MASTER_USER=%s, MASTER_PASSWORD=%s, MASTER_AUTO_POSITION=1;", sqlMode.EncodeString(params.Host), params.Port, sqlMode.EncodeString(params.Uname), sqlMode.EncodeString(params.Pass)))
 	}
 
 	if replPos.IsZero() { // when the there is no afterPos, that means need to replicate completely
@@ -568,7 +581,8 @@ func (mysqlFlavor) catchupToGTIDCommands(params *ConnParams, replPos replication
 	return cmds
 }
 
-func (mysqlFlavor8) catchupToGTIDCommands(params *ConnParams, replPos replication.Position) []string {
+func (mysqlFlavor8) catchupToGTIDCommands(c *Conn, params *ConnParams, replPos replication.Position) []string {
+	sqlMode := c.SQLMode()
 	cmds := []string{
 		"STOP REPLICA FOR CHANNEL '' ",
 		"STOP REPLICA IO_THREAD FOR CHANNEL ''",
@@ -576,20 +590,20 @@ func (mysqlFlavor8) catchupToGTIDCommands(params *ConnParams, replPos replicatio
 
 	if params.SslCa != "" || params.SslCert != "" {
 		// We need to use TLS
-		cmd := fmt.Sprintf("CHANGE REPLICATION SOURCE TO SOURCE_HOST='%s', SOURCE_PORT=%d, SOURCE_USER='%s', SOURCE_PASSWORD='%s', SOURCE_AUTO_POSITION=1, SOURCE_SSL=1", params.Host, params.Port, params.Uname, params.Pass)
+		cmd := fmt.Sprintf("CHANGE REPLICATION SOURCE TO SOURCE_HOST=%s, SOURCE_PORT=%d, SOURCE_USER=%s, SOURCE_PASSWORD=%s, SOURCE_AUTO_POSITION=1, SOURCE_SSL=1", sqlMode.EncodeString(params.Host), params.Port, sqlMode.EncodeString(params.Uname), sqlMode.EncodeString(params.Pass))
 		if params.SslCa != "" {
-			cmd += fmt.Sprintf(", SOURCE_SSL_CA='%s'", params.SslCa)
+			cmd += fmt.Sprintf(", SOURCE_SSL_CA=%s", sqlMode.EncodeString(params.SslCa))
 		}
 		if params.SslCert != "" {
-			cmd += fmt.Sprintf(", SOURCE_SSL_CERT='%s'", params.SslCert)
+			cmd += fmt.Sprintf(", SOURCE_SSL_CERT=%s", sqlMode.EncodeString(params.SslCert))
 		}
 		if params.SslKey != "" {
-			cmd += fmt.Sprintf(", SOURCE_SSL_KEY='%s'", params.SslKey)
+			cmd += fmt.Sprintf(", SOURCE_SSL_KEY=%s", sqlMode.EncodeString(params.SslKey))
 		}
 		cmds = append(cmds, cmd+";")
 	} else {
 		// No TLS
-		cmds = append(cmds, fmt.Sprintf("CHANGE REPLICATION SOURCE TO SOURCE_HOST='%s', SOURCE_PORT=%d, SOURCE_USER='%s', SOURCE_PASSWORD='%s', SOURCE_AUTO_POSITION=1;", params.Host, params.Port, params.Uname, params.Pass))
+		cmds = append(cmds, fmt.Sprintf("CHANGE REPLICATION SOURCE TO SOURCE_HOST=%s, SOURCE_PORT=%d, SOURCE_USER=%s, SOURCE_PASSWORD=%s, SOURCE_AUTO_POSITION=1;", sqlMode.EncodeString(params.Host), params.Port, sqlMode.EncodeString(params.Uname), sqlMode.EncodeString(params.Pass)))
 	}
 
 	if replPos.IsZero() { // when the there is no afterPos, that means need to replicate completely
@@ -126,3 +126,38 @@ func TestMysql8SetReplicationSourceCommandSSL(t *testing.T) {
 	got := conn.SetReplicationSourceCommand(params, host, port, connectRetry)
 	assert.Equal(t, want, got, "mysqlFlavor.SetReplicationSourceCommand(%#v, %#v, %#v, %#v) = %#v, want %#v", params, host, port, connectRetry, got, want)
 }
+
+func TestMysql8SetReplicationSourceCommandSQLMode(t *testing.T) {
+	params := &ConnParams{
+		Uname:  "username",
+		Pass:   `pass'wo\rd`,
+		SslKey: `C:\ssl\key.pem`,
+	}
+	host := "localhost"
+	port := int32(123)
+	connectRetry := 1234
+
+	conn := &Conn{flavor: mysqlFlavor8{}}
+	want := `CHANGE REPLICATION SOURCE TO
+  SOURCE_HOST = 'localhost',
+  SOURCE_PORT = 123,
+  SOURCE_USER = 'username',
+  SOURCE_PASSWORD = 'pass\'wo\\rd',
+  SOURCE_CONNECT_RETRY = 1234,
+  SOURCE_SSL_KEY = 'C:\\ssl\\key.pem',
+  SOURCE_AUTO_POSITION = 1`
+	got := conn.SetReplicationSourceCommand(params, host, port, connectRetry)
+	assert.Equal(t, want, got)
+
+	conn.sqlMode = "ANSI_QUOTES,NO_BACKSLASH_ESCAPES"
+	want = `CHANGE REPLICATION SOURCE TO
+  SOURCE_HOST = 'localhost',
+  SOURCE_PORT = 123,
+  SOURCE_USER = 'username',
+  SOURCE_PASSWORD = 'pass''wo\rd',
+  SOURCE_CONNECT_RETRY = 1234,
+  SOURCE_SSL_KEY = 'C:\ssl\key.pem',
+  SOURCE_AUTO_POSITION = 1`
+	got = conn.SetReplicationSourceCommand(params, host, port, connectRetry)
+	assert.Equal(t, want, got)
+}
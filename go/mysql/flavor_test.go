@@ -14,12 +14,17 @@ limitations under the License.
 package mysql
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/capabilities"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
 )
 
 func TestServerVersionCapableOf(t *testing.T) {
@@ -108,6 +113,51 @@ func TestServerVersionCapableOf(t *testing.T) {
 			capability: capabilities.PerformanceSchemaDataLocksTableCapability,
 			isCapable:  true,
 		},
+		{
+			version:    "8.0.26",
+			capability: capabilities.ReplicaTerminologyCapability,
+			isCapable:  true,
+		},
+		{
+			version:    "10.4.31-MariaDB",
+			capability: capabilities.ReplicaTerminologyCapability,
+			isCapable:  false,
+		},
+		{
+			version:    "10.5.1-MariaDB-log",
+			capability: capabilities.ReplicaTerminologyCapability,
+			isCapable:  true,
+		},
+		{
+			version:    "5.5.5-10.11.6-MariaDB",
+			capability: capabilities.ReplicaTerminologyCapability,
+			isCapable:  true,
+		},
+		{
+			version:    "10.2.44-MariaDB",
+			capability: capabilities.InstantDDLFlavorCapability,
+			isCapable:  false,
+		},
+		{
+			version:    "10.3.7-MariaDB",
+			capability: capabilities.InstantAddLastColumnFlavorCapability,
+			isCapable:  true,
+		},
+		{
+			version:    "10.3.39-MariaDB",
+			capability: capabilities.InstantAddDropColumnFlavorCapability,
+			isCapable:  false,
+		},
+		{
+			version:    "5.5.5-10.4.31-MariaDB",
+			capability: capabilities.InstantAddDropColumnFlavorCapability,
+			isCapable:  true,
+		},
+		{
+			version:    "10.11.6-MariaDB",
+			capability: capabilities.InstantDDLXtrabackupCapability,
+			isCapable:  false,
+		},
 		{
 			// Some ridiculous version
 			version:    "5914.234.17",
@@ -125,3 +175,100 @@ func TestServerVersionCapableOf(t *testing.T) {
 		})
 	}
 }
+
+// queryHandler answers the queries it knows with their result, and logs them.
+type queryHandler struct {
+	testHandler
+	results map[string]*sqltypes.Result
+	queries []string
+}
+
+func (th *queryHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	th.mu.Lock()
+	th.queries = append(th.queries, query)
+	result, ok := th.results[query]
+	th.mu.Unlock()
+	if !ok {
+		return sqlerror.NewSQLError(sqlerror.ERUnknownError, sqlerror.SSUnknownSQLState, "unexpected query: %v", query)
+	}
+	return callback(result)
+}
+
+func (th *queryHandler) setResult(query string, result *sqltypes.Result) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	th.results[query] = result
+	th.queries = nil
+}
+
+func (th *queryHandler) queryLog() []string {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	return th.queries
+}
+
+func TestWaitUntilPrimaryStatus(t *testing.T) {
+	ctx := context.Background()
+	th := &queryHandler{results: map[string]*sqltypes.Result{
+		"SELECT WAIT_FOR_EXECUTED_GTID_SET('8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-8', 0)": sqltypes.MakeTestResult(sqltypes.MakeTestFields("result", "int64"), "0"),
+		"SELECT MASTER_POS_WAIT('binlog.000002', 200)":                                     sqltypes.MakeTestResult(sqltypes.MakeTestFields("result", "int64"), "1"),
+	}}
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+	}}
+	defer authServer.close()
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	conn, err := Connect(ctx, &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	status := replication.PrimaryStatus{
+		Position:     replication.MustParsePosition(replication.Mysql56FlavorID, "8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-8"),
+		FilePosition: replication.Position{GTIDSet: replication.FilePosGTID{File: "binlog.000002", Pos: 200}},
+	}
+	testcases := []struct {
+		gtidMode string
+		query    string
+	}{{
+		gtidMode: "ON",
+		query:    "SELECT WAIT_FOR_EXECUTED_GTID_SET('8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-8', 0)",
+	}, {
+		gtidMode: "ON_PERMISSIVE",
+		query:    "SELECT WAIT_FOR_EXECUTED_GTID_SET('8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-8', 0)",
+	}, {
+		gtidMode: "OFF_PERMISSIVE",
+		query:    "SELECT MASTER_POS_WAIT('binlog.000002', 200)",
+	}, {
+		gtidMode: "OFF",
+		query:    "SELECT MASTER_POS_WAIT('binlog.000002', 200)",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.gtidMode, func(t *testing.T) {
+			th.setResult("select @@global.gtid_mode", sqltypes.MakeTestResult(sqltypes.MakeTestFields("gtid_mode", "varchar"), tc.gtidMode))
+			err := conn.WaitUntilPrimaryStatus(ctx, status)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"select @@global.gtid_mode", tc.query}, th.queryLog())
+		})
+	}
+
+	// Without a GTID position, the file position is waited on directly.
+	th.setResult("select @@global.gtid_mode", sqltypes.MakeTestResult(sqltypes.MakeTestFields("gtid_mode", "varchar"), "ON"))
+	err = conn.WaitUntilPrimaryStatus(ctx, replication.PrimaryStatus{FilePosition: status.FilePosition})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SELECT MASTER_POS_WAIT('binlog.000002', 200)"}, th.queryLog())
+
+	th.setResult("select @@global.gtid_mode", sqltypes.MakeTestResult(sqltypes.MakeTestFields("gtid_mode", "varchar"), "OFF"))
+	err = conn.WaitUntilPrimaryStatus(ctx, replication.PrimaryStatus{Position: status.Position})
+	assert.ErrorContains(t, err, "no file position was given")
+}
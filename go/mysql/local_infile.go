@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"vitess.io/vitess/go/mysql/sqlerror"
+)
+
+// This file contains the methods related to the LOAD DATA LOCAL INFILE
+// sub-protocol, where the server asks the client to send the contents
+// of a file as part of a COM_QUERY.
+
+// LocalInfileEnabled returns true if the client can send local files
+// with LOAD DATA LOCAL INFILE.
+// Server side only.
+func (c *Conn) LocalInfileEnabled() bool {
+	return c.Capabilities&CapabilityClientLocalFiles != 0
+}
+
+// RequestLocalInfile asks the client to send the contents of fileName,
+// and streams them to callback as they are received. It must only be
+// called from a Handler's ComQuery. The query result, or the error,
+// still has to be sent back to the client once this returns.
+//
+// If callback returns an error, the remaining contents of the file are
+// read and dropped, so the connection stays usable, and the error is
+// returned.
+// Server side only.
+func (c *Conn) RequestLocalInfile(fileName string, callback func([]byte) error) error {
+	if !c.LocalInfileEnabled() {
+		return sqlerror.NewSQLError(sqlerror.ERNotAllowedCommand, sqlerror.SSClientError, "The used command is not allowed with this MySQL version")
+	}
+
+	data, pos := c.startEphemeralPacketWithHeader(len(fileName) + 1)
+	data[pos] = LocalInfilePacket
+	pos++
+	copy(data[pos:], fileName)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	if err := c.flush(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+
+	// The client sends the file in as many packets as needed,
+	// and signals the end of it with an empty packet.
+	var callbackErr error
+	for {
+		data, err := c.readEphemeralPacket()
+		if err != nil {
+			return sqlerror.NewSQLError(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+		}
+		if len(data) == 0 {
+			c.recycleReadPacket()
+			return callbackErr
+		}
+		if callbackErr == nil {
+			callbackErr = callback(data)
+		}
+		c.recycleReadPacket()
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+)
+
+// sendLocalInfile plays the client side of the LOCAL INFILE exchange:
+// it reads the request and sends back the given chunks.
+func sendLocalInfile(t *testing.T, cConn *Conn, fileName string, chunks ...string) {
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.Equal(t, byte(LocalInfilePacket), data[0])
+	require.Equal(t, fileName, string(data[1:]))
+
+	for _, chunk := range chunks {
+		require.NoError(t, cConn.writePacket(append(make([]byte, packetHeaderSize), chunk...)))
+	}
+	require.NoError(t, cConn.writePacket(make([]byte, packetHeaderSize)))
+}
+
+func TestRequestLocalInfile(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Not negotiated.
+	err := sConn.RequestLocalInfile("/tmp/data.csv", func([]byte) error { return nil })
+	var sqlErr *sqlerror.SQLError
+	require.ErrorAs(t, err, &sqlErr)
+	assert.Equal(t, sqlerror.ERNotAllowedCommand, sqlErr.Number())
+
+	sConn.Capabilities |= CapabilityClientLocalFiles
+	var received []string
+	done := make(chan error)
+	go func() {
+		done <- sConn.RequestLocalInfile("/tmp/data.csv", func(data []byte) error {
+			received = append(received, string(data))
+			return nil
+		})
+	}()
+	sendLocalInfile(t, cConn, "/tmp/data.csv", "1,a\n", "2,b\n")
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"1,a\n", "2,b\n"}, received)
+
+	// A callback error drains the rest of the file and is returned.
+	sConn.sequence = 0
+	cConn.sequence = 0
+	received = nil
+	go func() {
+		done <- sConn.RequestLocalInfile("data.csv", func(data []byte) error {
+			received = append(received, string(data))
+			return errors.New("too many rows")
+		})
+	}()
+	sendLocalInfile(t, cConn, "data.csv", "1,a\n", "2,b\n", "3,c\n")
+	assert.EqualError(t, <-done, "too many rows")
+	assert.Equal(t, []string{"1,a\n"}, received)
+}
@@ -0,0 +1,1057 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/tb"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+/*
+References:
+
+* The PostgreSQL frontend/backend protocol, version 3.0:
+https://www.postgresql.org/docs/current/protocol.html
+
+The startup messages are framed as:
+
+	# bytes   field
+	4         length of the length and the payload, big endian
+	<var>     payload, starting with the protocol version or a request code
+
+The other messages are framed as:
+
+	# bytes   field
+	1         message type
+	4         length of the length and the payload, big endian
+	<var>     payload
+
+The support is experimental: the queries are executed as MySQL queries, after
+a translation of the quoted identifiers, the string literals and the $n
+parameters of the PostgreSQL dialect, and the results are described with the
+PostgreSQL types closest to their MySQL types. This is enough for the clients
+that read data, like psql or some BI tools, but the catalog of PostgreSQL
+(pg_catalog) is not emulated, and queries can't be canceled.
+*/
+
+// The protocol version and the request codes of the startup messages.
+const (
+	pgProtocolVersion3  = 196608
+	pgSSLRequestCode    = 80877103
+	pgGSSENCRequestCode = 80877104
+	pgCancelRequestCode = 80877102
+)
+
+// The types of the messages sent by the client.
+const (
+	pgClientQuery     = 'Q'
+	pgClientParse     = 'P'
+	pgClientBind      = 'B'
+	pgClientDescribe  = 'D'
+	pgClientExecute   = 'E'
+	pgClientClose     = 'C'
+	pgClientSync      = 'S'
+	pgClientFlush     = 'H'
+	pgClientTerminate = 'X'
+	pgClientPassword  = 'p'
+)
+
+// The types of the messages sent by the server.
+const (
+	pgServerAuthentication       = 'R'
+	pgServerParameterStatus      = 'S'
+	pgServerBackendKeyData       = 'K'
+	pgServerReadyForQuery        = 'Z'
+	pgServerRowDescription       = 'T'
+	pgServerDataRow              = 'D'
+	pgServerCommandComplete      = 'C'
+	pgServerEmptyQueryResponse   = 'I'
+	pgServerErrorResponse        = 'E'
+	pgServerParseComplete        = '1'
+	pgServerBindComplete         = '2'
+	pgServerCloseComplete        = '3'
+	pgServerNoData               = 'n'
+	pgServerParameterDescription = 't'
+	pgServerPortalSuspended      = 's'
+)
+
+// The authentication requests, and the transaction status of ReadyForQuery.
+const (
+	pgAuthOk                = 0
+	pgAuthCleartextPassword = 3
+
+	pgTxIdle          = 'I'
+	pgTxInTransaction = 'T'
+)
+
+// The targets of the Describe and Close messages.
+const (
+	pgTargetStatement = 'S'
+	pgTargetPortal    = 'P'
+)
+
+const (
+	// pgMaxStartupMessageSize is the largest startup message we accept,
+	// like PostgreSQL.
+	pgMaxStartupMessageSize = 10000
+	// pgMaxMessageSize is the largest message we accept.
+	pgMaxMessageSize = 64 * 1024 * 1024
+	// pgFirstConnectionID is the first connection ID of a PostgreSQL
+	// listener, so that its connections don't share the IDs of the classic
+	// protocol listener, which counts from 1, nor the ones of the X Protocol
+	// listener.
+	pgFirstConnectionID = 3 << 30
+	// pgResultFlushThreshold is the number of buffered bytes of a result
+	// after which they are flushed to the client.
+	pgResultFlushThreshold = 16 * 1024
+	// pgServerVersion is the version of PostgreSQL reported to the clients,
+	// which adapt their queries to it.
+	pgServerVersion = "14.0 (Vitess)"
+)
+
+// pgLocalParameters are the parameters of PostgreSQL that a SET statement
+// changes locally, without executing it, because they have no MySQL
+// equivalent. The clients commonly set them when they connect.
+var pgLocalParameters = map[string]bool{
+	"application_name":    true,
+	"bytea_output":        true,
+	"client_encoding":     true,
+	"client_min_messages": true,
+	"datestyle":           true,
+	"extra_float_digits":  true,
+	"intervalstyle":       true,
+}
+
+// pgStatement is a statement prepared with a Parse message.
+type pgStatement struct {
+	// query is the query translated to the MySQL dialect, with :vN bind
+	// variables in place of the $N parameters.
+	query string
+	// paramCount is the number of parameters of the query.
+	paramCount int
+	// paramTypes are the OIDs of the types of the parameters specified by
+	// the client. They may be fewer than the parameters, and 0 means that
+	// the type is not specified.
+	paramTypes []uint32
+}
+
+// paramType returns the OID of the type of the i-th parameter.
+func (s *pgStatement) paramType(i int) uint32 {
+	if i < len(s.paramTypes) {
+		return s.paramTypes[i]
+	}
+	return 0
+}
+
+// pgPortal is a prepared statement bound to its parameters with a Bind
+// message.
+type pgPortal struct {
+	stmt          *pgStatement
+	bindVars      map[string]*querypb.BindVariable
+	resultFormats []int16
+
+	// result is the result of the portal once it was executed: the portal is
+	// executed at once, even when the client fetches its rows with several
+	// Execute messages. sent is the number of rows sent to the client.
+	result *sqltypes.Result
+	sent   int
+}
+
+// pgConn is the state of a connection using the PostgreSQL protocol.
+type pgConn struct {
+	c *Conn
+	l *Listener
+	w *bufio.Writer
+
+	statements map[string]*pgStatement
+	portals    map[string]*pgPortal
+	// skipTillSync is set after an error of the extended query protocol:
+	// the messages are then skipped until the next Sync.
+	skipTillSync bool
+}
+
+// handlePostgresProtocol is called in a go routine for each client
+// connection of a listener that speaks the PostgreSQL protocol. It
+// authenticates the client, and then executes its queries with the same
+// handler as the classic protocol.
+func (l *Listener) handlePostgresProtocol(conn net.Conn, connectionID uint32, acceptTime time.Time) {
+	if l.connReadTimeout != 0 || l.connWriteTimeout != 0 {
+		conn = netutil.NewConnWithTimeouts(conn, l.connReadTimeout, l.connWriteTimeout)
+	}
+	c := newServerConn(conn, l)
+	c.ConnectionID = connectionID
+	p := &pgConn{
+		c:          c,
+		l:          l,
+		w:          bufio.NewWriter(conn),
+		statements: make(map[string]*pgStatement),
+		portals:    make(map[string]*pgPortal),
+	}
+
+	// Catch panics, and close the connection in any case.
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("pgwire_server caught panic:\n%v\n%s", x, tb.Stack(4))
+		}
+		if l.connBufferPooling {
+			c.returnReader()
+		}
+		c.conn.Close()
+	}()
+
+	// Tell the handler about the connection coming and going.
+	l.handler.NewConnection(c)
+	defer l.handler.ConnectionClosed(c)
+
+	// Adjust the count of open connections
+	defer connCount.Add(-1)
+
+	if !p.startup() {
+		return
+	}
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+		defer connCountPerUser.Add(c.User, -1)
+	}
+
+	// Record how long we took to establish the connection
+	timings.Record(connectTimingKey, acceptTime)
+
+	// Log a warning if it took too long to connect
+	connectTime := time.Since(acceptTime).Nanoseconds()
+	if threshold := l.SlowConnectWarnThreshold.Load(); threshold != 0 && connectTime > threshold {
+		connSlow.Add(1)
+		log.Warningf("Slow connection from %s: %v", c, connectTime)
+	}
+
+	l.handler.ConnectionReady(c)
+
+	for {
+		kontinue := p.handleNextMessage()
+		if !kontinue || c.IsMarkedForClose() {
+			return
+		}
+	}
+}
+
+// readStartupMessage reads the next startup message from the client.
+func (p *pgConn) readStartupMessage() ([]byte, error) {
+	var header [4]byte
+	r := p.c.getReader()
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length < 8 || length > pgMaxStartupMessageSize {
+		return nil, newPgError(pgSSProtocolViolation, "invalid length of startup packet: %d", length)
+	}
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// readMessage reads the next message from the client.
+func (p *pgConn) readMessage() (byte, []byte, error) {
+	var header [5]byte
+	r := p.c.getReader()
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length < 4 || length > pgMaxMessageSize {
+		return 0, nil, newPgError(pgSSProtocolViolation, "invalid message length %d", length)
+	}
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0], payload, nil
+}
+
+// writeMessage buffers a message for the client, until the buffer is
+// flushed.
+func (p *pgConn) writeMessage(typ byte, payload []byte) error {
+	var header [5]byte
+	header[0] = typ
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)+4))
+	if _, err := p.w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := p.w.Write(payload)
+	return err
+}
+
+// writeError writes an ErrorResponse.
+func (p *pgConn) writeError(err error) error {
+	return p.writeMessage(pgServerErrorResponse, pgErrorResponseBytes("ERROR", pgErrorFromError(err)))
+}
+
+// writeFatal writes an ErrorResponse ending the connection, and flushes it.
+func (p *pgConn) writeFatal(err error) {
+	if werr := p.writeMessage(pgServerErrorResponse, pgErrorResponseBytes("FATAL", pgErrorFromError(err))); werr != nil {
+		return
+	}
+	p.w.Flush()
+}
+
+// writeReadyForQuery writes a ReadyForQuery, with the transaction status of
+// the connection, and flushes it.
+func (p *pgConn) writeReadyForQuery() error {
+	status := byte(pgTxIdle)
+	if p.c.StatusFlags&ServerStatusInTrans != 0 {
+		status = pgTxInTransaction
+	}
+	if err := p.writeMessage(pgServerReadyForQuery, []byte{status}); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+// writeParameterStatus writes a ParameterStatus.
+func (p *pgConn) writeParameterStatus(name, value string) error {
+	return p.writeMessage(pgServerParameterStatus, pgAppendString(pgAppendString(nil, name), value))
+}
+
+// startup handles the startup messages and authenticates the client. It
+// returns false if the connection should be closed.
+func (p *pgConn) startup() bool {
+	for {
+		payload, err := p.readStartupMessage()
+		if err != nil {
+			if err != io.EOF {
+				log.Infof("Cannot read PostgreSQL startup message from %s: %v, it may not be a valid PostgreSQL client", p.c, err)
+			}
+			return false
+		}
+
+		switch code := binary.BigEndian.Uint32(payload); code {
+		case pgSSLRequestCode:
+			config, _ := p.l.TLSConfig.Load().(*tls.Config)
+			if config == nil || p.c.TLSEnabled() {
+				err = p.writeByte('N')
+				break
+			}
+			if err = p.writeByte('S'); err == nil {
+				p.upgradeTLS(config)
+			}
+		case pgGSSENCRequestCode:
+			err = p.writeByte('N')
+		case pgCancelRequestCode:
+			// Canceling a query is not supported. The client doesn't expect
+			// an answer.
+			return false
+		case pgProtocolVersion3:
+			return p.authenticate(payload[4:])
+		default:
+			p.writeFatal(newPgError(pgSSFeatureNotSupported, "unsupported frontend protocol %d.%d: server supports 3.0", code>>16, code&0xffff))
+			return false
+		}
+		if err != nil {
+			log.Errorf("Error negotiating PostgreSQL session with %s: %v", p.c, err)
+			return false
+		}
+	}
+}
+
+// writeByte writes a single byte, as the answer to an SSLRequest or a
+// GSSENCRequest, and flushes it.
+func (p *pgConn) writeByte(b byte) error {
+	if err := p.w.WriteByte(b); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+// upgradeTLS upgrades the connection to TLS.
+func (p *pgConn) upgradeTLS(config *tls.Config) {
+	conn := tls.Server(p.c.conn, config)
+	p.c.conn = conn
+	if p.c.bufferedReader != nil {
+		p.c.bufferedReader.Reset(conn)
+	}
+	p.w.Reset(conn)
+	p.c.Capabilities |= CapabilityClientSSL
+}
+
+// authenticate handles the StartupMessage, whose parameters are given, and
+// authenticates the client with a clear text password. It returns false if
+// the connection should be closed.
+func (p *pgConn) authenticate(payload []byte) bool {
+	r := &pgReader{buf: payload}
+	params := make(map[string]string)
+	for {
+		name := r.readString()
+		if name == "" || r.err != nil {
+			break
+		}
+		params[name] = r.readString()
+	}
+	user := params["user"]
+	if r.err != nil || user == "" {
+		p.writeFatal(newPgError(pgSSProtocolViolation, "no PostgreSQL user name specified in startup packet"))
+		return false
+	}
+
+	if p.l.RequireSecureTransport && !p.c.TLSEnabled() {
+		p.writeFatal(newPgError(pgSSInvalidAuthorization, "server does not allow insecure connections, client must use SSL/TLS"))
+		return false
+	}
+	if !p.c.TLSEnabled() && !p.l.AllowClearTextWithoutTLS.Load() {
+		p.writeFatal(newPgError(pgSSInvalidAuthorization, "Cannot use clear text authentication over non-SSL connections."))
+		return false
+	}
+
+	// The password is sent in clear text, and checked with the auth server.
+	if err := p.writeMessage(pgServerAuthentication, binary.BigEndian.AppendUint32(nil, pgAuthCleartextPassword)); err != nil {
+		return false
+	}
+	if err := p.w.Flush(); err != nil {
+		return false
+	}
+	typ, payload, err := p.readMessage()
+	if err != nil {
+		if err != io.EOF {
+			log.Infof("Cannot read PostgreSQL password message from %s: %v", p.c, err)
+		}
+		return false
+	}
+	if typ != pgClientPassword {
+		p.writeFatal(newPgError(pgSSProtocolViolation, "expected password response, got message type %d", typ))
+		return false
+	}
+	password, _, _ := bytes.Cut(payload, []byte{0})
+	getter, err := p.l.authenticateClearText(p.c, user, password)
+	if err != nil {
+		log.Warningf("Error authenticating user %s over the PostgreSQL protocol: %v", user, err)
+		p.l.authenticationFailed(p.c, user, err)
+		p.writeFatal(newPgError(pgSSInvalidPassword, "password authentication failed for user %q", user))
+		return false
+	}
+	p.c.User = user
+	p.c.UserData = getter
+
+	// Set initial db name.
+	if database := params["database"]; database != "" {
+		p.c.schemaName = database
+		err = p.l.handler.ComQuery(p.c, "use "+sqlescape.EscapeID(database), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			p.writeFatal(err)
+			return false
+		}
+	}
+
+	if err := p.writeMessage(pgServerAuthentication, binary.BigEndian.AppendUint32(nil, pgAuthOk)); err != nil {
+		return false
+	}
+	parameters := []struct{ name, value string }{
+		{"server_version", pgServerVersion},
+		{"server_encoding", "UTF8"},
+		{"client_encoding", "UTF8"},
+		{"DateStyle", "ISO, MDY"},
+		{"IntervalStyle", "postgres"},
+		{"integer_datetimes", "on"},
+		{"standard_conforming_strings", "on"},
+		{"is_superuser", "off"},
+		{"session_authorization", user},
+		{"application_name", params["application_name"]},
+	}
+	for _, param := range parameters {
+		if err := p.writeParameterStatus(param.name, param.value); err != nil {
+			return false
+		}
+	}
+	// Canceling a query is not supported, so the key is never checked.
+	keyData := binary.BigEndian.AppendUint32(nil, p.c.ConnectionID)
+	keyData = binary.BigEndian.AppendUint32(keyData, rand.Uint32())
+	if err := p.writeMessage(pgServerBackendKeyData, keyData); err != nil {
+		return false
+	}
+	return p.writeReadyForQuery() == nil
+}
+
+// handleNextMessage handles the next message of an authenticated client.
+// It returns false if the connection should be closed.
+func (p *pgConn) handleNextMessage() bool {
+	typ, payload, err := p.readMessage()
+	if err != nil {
+		if err != io.EOF {
+			log.Errorf("Error reading PostgreSQL message from %s: %v", p.c, err)
+		}
+		return false
+	}
+
+	if p.skipTillSync && typ != pgClientSync && typ != pgClientTerminate {
+		return true
+	}
+	switch typ {
+	case pgClientQuery:
+		err = p.simpleQuery(payload)
+	case pgClientParse:
+		err = p.parse(payload)
+	case pgClientBind:
+		err = p.bind(payload)
+	case pgClientDescribe:
+		err = p.describe(payload)
+	case pgClientExecute:
+		err = p.execute(payload)
+	case pgClientClose:
+		err = p.close(payload)
+	case pgClientSync:
+		p.skipTillSync = false
+		err = p.writeReadyForQuery()
+	case pgClientFlush:
+		err = p.w.Flush()
+	case pgClientTerminate:
+		return false
+	default:
+		p.writeFatal(newPgError(pgSSProtocolViolation, "invalid frontend message type %d", typ))
+		return false
+	}
+	if err != nil {
+		log.Errorf("Error writing PostgreSQL message to %s: %v", p.c, err)
+		return false
+	}
+	return true
+}
+
+// extendedError reports an error of the extended query protocol, and skips
+// the next messages until a Sync.
+func (p *pgConn) extendedError(err error) error {
+	p.skipTillSync = true
+	return p.writeError(err)
+}
+
+// simpleQuery handles a Query message: its statements are executed in order
+// until one fails, and their results are streamed to the client.
+func (p *pgConn) simpleQuery(payload []byte) error {
+	// A Query message closes the unnamed statement and portal.
+	delete(p.statements, "")
+	delete(p.portals, "")
+
+	query, _, _ := bytes.Cut(payload, []byte{0})
+	queries, err := p.l.handler.Env().Parser().SplitStatementToPieces(string(query))
+	if err != nil {
+		if err := p.writeError(err); err != nil {
+			return err
+		}
+		return p.writeReadyForQuery()
+	}
+	if len(queries) == 0 || len(queries) == 1 && strings.TrimSpace(queries[0]) == "" {
+		if err := p.writeMessage(pgServerEmptyQueryResponse, nil); err != nil {
+			return err
+		}
+		return p.writeReadyForQuery()
+	}
+
+	for _, query := range queries {
+		translated, paramCount := pgTranslateQuery(query)
+		if paramCount > 0 {
+			err = newPgError(pgSSUndefinedParameter, "there is no parameter $1")
+		} else {
+			err = p.streamQuery(translated)
+		}
+		if err != nil {
+			if err, ok := err.(*pgWriteError); ok {
+				return err.err
+			}
+			if err := p.writeError(err); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return p.writeReadyForQuery()
+}
+
+// pgWriteError wraps the errors writing to the client while a query is
+// executed, which close the connection, unlike the errors of the query.
+type pgWriteError struct {
+	err error
+}
+
+func (e *pgWriteError) Error() string {
+	return e.err.Error()
+}
+
+// streamQuery executes a query of a Query message, and streams its result
+// to the client, followed by a CommandComplete.
+func (p *pgConn) streamQuery(query string) error {
+	if name, value, ok := pgLocalSet(query); ok {
+		return p.setLocal(name, value)
+	}
+
+	var fields []*querypb.Field
+	var rows, rowsAffected uint64
+	err := p.l.handler.ComQuery(p.c, query, func(qr *sqltypes.Result) error {
+		if fields == nil && len(qr.Fields) > 0 {
+			fields = qr.Fields
+			if err := p.writeMessage(pgServerRowDescription, pgRowDescriptionBytes(fields, nil)); err != nil {
+				return &pgWriteError{err}
+			}
+		}
+		for _, row := range qr.Rows {
+			b, err := pgDataRowBytes(fields, nil, row)
+			if err != nil {
+				return err
+			}
+			if err := p.writeMessage(pgServerDataRow, b); err != nil {
+				return &pgWriteError{err}
+			}
+			if p.w.Buffered() > pgResultFlushThreshold {
+				if err := p.w.Flush(); err != nil {
+					return &pgWriteError{err}
+				}
+			}
+		}
+		rows += uint64(len(qr.Rows))
+		rowsAffected += qr.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := p.writeMessage(pgServerCommandComplete, pgAppendString(nil, pgCommandTag(query, fields != nil, rows, rowsAffected))); err != nil {
+		return &pgWriteError{err}
+	}
+	return nil
+}
+
+// setLocal handles a SET statement of a parameter in pgLocalParameters.
+func (p *pgConn) setLocal(name, value string) error {
+	if name == "client_encoding" && !strings.EqualFold(value, "UTF8") && !strings.EqualFold(value, "UNICODE") {
+		return newPgError(pgSSFeatureNotSupported, "client encoding %q is not supported, only UTF8 is", value)
+	}
+	if name == "application_name" || name == "client_encoding" {
+		if name == "client_encoding" {
+			value = "UTF8"
+		}
+		if err := p.writeParameterStatus(name, value); err != nil {
+			return &pgWriteError{err}
+		}
+	}
+	if err := p.writeMessage(pgServerCommandComplete, pgAppendString(nil, "SET")); err != nil {
+		return &pgWriteError{err}
+	}
+	return nil
+}
+
+// parse handles a Parse message, which prepares a statement.
+func (p *pgConn) parse(payload []byte) error {
+	r := &pgReader{buf: payload}
+	name := r.readString()
+	query := r.readString()
+	paramTypes := make([]uint32, r.readInt16())
+	for i := range paramTypes {
+		paramTypes[i] = r.readUint32()
+	}
+	if r.err != nil {
+		return p.extendedError(r.err)
+	}
+	if _, ok := p.statements[name]; ok && name != "" {
+		return p.extendedError(newPgError(pgSSDuplicatePreparedStatement, "prepared statement %q already exists", name))
+	}
+
+	queries, err := p.l.handler.Env().Parser().SplitStatementToPieces(query)
+	if err != nil {
+		return p.extendedError(err)
+	}
+	if len(queries) > 1 {
+		return p.extendedError(newPgError(pgSSSyntaxError, "cannot insert multiple commands into a prepared statement"))
+	}
+	stmt := &pgStatement{paramTypes: paramTypes}
+	if len(queries) == 1 {
+		stmt.query, stmt.paramCount = pgTranslateQuery(queries[0])
+	}
+	stmt.paramCount = max(stmt.paramCount, len(paramTypes))
+	p.statements[name] = stmt
+	return p.writeMessage(pgServerParseComplete, nil)
+}
+
+// bind handles a Bind message, which binds a prepared statement to its
+// parameters in a portal.
+func (p *pgConn) bind(payload []byte) error {
+	r := &pgReader{buf: payload}
+	portalName := r.readString()
+	stmtName := r.readString()
+	paramFormats := make([]int16, r.readInt16())
+	for i := range paramFormats {
+		paramFormats[i] = r.readInt16()
+	}
+	params := make([][]byte, r.readInt16())
+	for i := range params {
+		if length := int32(r.readUint32()); length >= 0 {
+			params[i] = r.readBytes(int(length))
+		}
+	}
+	resultFormats := make([]int16, r.readInt16())
+	for i := range resultFormats {
+		resultFormats[i] = r.readInt16()
+	}
+	if r.err != nil {
+		return p.extendedError(r.err)
+	}
+
+	stmt, ok := p.statements[stmtName]
+	if !ok {
+		return p.extendedError(newPgError(pgSSInvalidStatementName, "prepared statement %q does not exist", stmtName))
+	}
+	if len(params) != stmt.paramCount {
+		return p.extendedError(newPgError(pgSSProtocolViolation, "bind message supplies %d parameters, but prepared statement %q requires %d", len(params), stmtName, stmt.paramCount))
+	}
+	if len(paramFormats) > 1 && len(paramFormats) != len(params) {
+		return p.extendedError(newPgError(pgSSProtocolViolation, "bind message has %d parameter formats but %d parameters", len(paramFormats), len(params)))
+	}
+
+	bindVars := make(map[string]*querypb.BindVariable, len(params))
+	for i, param := range params {
+		bindVar, err := pgDecodeParam(param, stmt.paramType(i), pgFormat(paramFormats, i))
+		if err != nil {
+			return p.extendedError(err)
+		}
+		bindVars[fmt.Sprintf("v%d", i+1)] = bindVar
+	}
+	p.portals[portalName] = &pgPortal{
+		stmt:          stmt,
+		bindVars:      bindVars,
+		resultFormats: resultFormats,
+	}
+	return p.writeMessage(pgServerBindComplete, nil)
+}
+
+// describe handles a Describe message, which describes the parameters and
+// the columns of a prepared statement, or the columns of a portal.
+func (p *pgConn) describe(payload []byte) error {
+	r := &pgReader{buf: payload}
+	target := r.readByte()
+	name := r.readString()
+	if r.err != nil {
+		return p.extendedError(r.err)
+	}
+
+	switch target {
+	case pgTargetStatement:
+		stmt, ok := p.statements[name]
+		if !ok {
+			return p.extendedError(newPgError(pgSSInvalidStatementName, "prepared statement %q does not exist", name))
+		}
+		var fields []*querypb.Field
+		if stmt.query != "" {
+			bindVars := make(map[string]*querypb.BindVariable, stmt.paramCount)
+			for i := 0; i < stmt.paramCount; i++ {
+				bindVars[fmt.Sprintf("v%d", i+1)] = &querypb.BindVariable{}
+			}
+			var err error
+			if fields, err = p.l.handler.ComPrepare(p.c, stmt.query, bindVars); err != nil {
+				return p.extendedError(err)
+			}
+		}
+		description := binary.BigEndian.AppendUint16(nil, uint16(stmt.paramCount))
+		for i := 0; i < stmt.paramCount; i++ {
+			typ := stmt.paramType(i)
+			if typ == 0 {
+				typ = pgTypeText
+			}
+			description = binary.BigEndian.AppendUint32(description, typ)
+		}
+		if err := p.writeMessage(pgServerParameterDescription, description); err != nil {
+			return err
+		}
+		return p.writeFields(fields, nil)
+	case pgTargetPortal:
+		portal, ok := p.portals[name]
+		if !ok {
+			return p.extendedError(newPgError(pgSSInvalidCursorName, "portal %q does not exist", name))
+		}
+		// The columns of a portal are only known once it is executed.
+		if err := p.executePortal(portal); err != nil {
+			return p.extendedError(err)
+		}
+		return p.writeFields(portal.result.Fields, portal.resultFormats)
+	}
+	return p.extendedError(newPgError(pgSSProtocolViolation, "invalid DESCRIBE message subtype %d", target))
+}
+
+// writeFields writes the RowDescription of the fields, or NoData if there
+// are none.
+func (p *pgConn) writeFields(fields []*querypb.Field, formats []int16) error {
+	if len(fields) == 0 {
+		return p.writeMessage(pgServerNoData, nil)
+	}
+	return p.writeMessage(pgServerRowDescription, pgRowDescriptionBytes(fields, formats))
+}
+
+// executePortal executes the statement of a portal, if it wasn't yet.
+func (p *pgConn) executePortal(portal *pgPortal) error {
+	if portal.result != nil {
+		return nil
+	}
+	if portal.stmt.query == "" {
+		portal.result = &sqltypes.Result{}
+		return nil
+	}
+	if name, value, ok := pgLocalSet(portal.stmt.query); ok {
+		if name == "client_encoding" && !strings.EqualFold(value, "UTF8") && !strings.EqualFold(value, "UNICODE") {
+			return newPgError(pgSSFeatureNotSupported, "client encoding %q is not supported, only UTF8 is", value)
+		}
+		portal.result = &sqltypes.Result{}
+		return nil
+	}
+
+	result := &sqltypes.Result{}
+	prepare := &PrepareData{
+		PrepareStmt: portal.stmt.query,
+		BindVars:    portal.bindVars,
+		ParamsCount: uint16(portal.stmt.paramCount),
+	}
+	err := p.l.handler.ComStmtExecute(p.c, prepare, func(qr *sqltypes.Result) error {
+		if result.Fields == nil {
+			result.Fields = qr.Fields
+		}
+		result.Rows = append(result.Rows, qr.Rows...)
+		result.RowsAffected += qr.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	portal.result = result
+	return nil
+}
+
+// execute handles an Execute message, which sends the rows of a portal, up
+// to a maximum number of rows.
+func (p *pgConn) execute(payload []byte) error {
+	r := &pgReader{buf: payload}
+	name := r.readString()
+	maxRows := int(int32(r.readUint32()))
+	if r.err != nil {
+		return p.extendedError(r.err)
+	}
+	portal, ok := p.portals[name]
+	if !ok {
+		return p.extendedError(newPgError(pgSSInvalidCursorName, "portal %q does not exist", name))
+	}
+	if portal.stmt.query == "" {
+		return p.writeMessage(pgServerEmptyQueryResponse, nil)
+	}
+	if err := p.executePortal(portal); err != nil {
+		return p.extendedError(err)
+	}
+
+	result := portal.result
+	end := len(result.Rows)
+	if maxRows > 0 {
+		end = min(end, portal.sent+maxRows)
+	}
+	for _, row := range result.Rows[portal.sent:end] {
+		b, err := pgDataRowBytes(result.Fields, portal.resultFormats, row)
+		if err != nil {
+			return p.extendedError(err)
+		}
+		if err := p.writeMessage(pgServerDataRow, b); err != nil {
+			return err
+		}
+		if p.w.Buffered() > pgResultFlushThreshold {
+			if err := p.w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	portal.sent = end
+	if end < len(result.Rows) {
+		return p.writeMessage(pgServerPortalSuspended, nil)
+	}
+	if _, _, ok := pgLocalSet(portal.stmt.query); ok {
+		return p.writeMessage(pgServerCommandComplete, pgAppendString(nil, "SET"))
+	}
+	tag := pgCommandTag(portal.stmt.query, result.Fields != nil, uint64(len(result.Rows)), result.RowsAffected)
+	return p.writeMessage(pgServerCommandComplete, pgAppendString(nil, tag))
+}
+
+// close handles a Close message, which closes a prepared statement or a
+// portal.
+func (p *pgConn) close(payload []byte) error {
+	r := &pgReader{buf: payload}
+	target := r.readByte()
+	name := r.readString()
+	if r.err != nil {
+		return p.extendedError(r.err)
+	}
+	switch target {
+	case pgTargetStatement:
+		delete(p.statements, name)
+	case pgTargetPortal:
+		delete(p.portals, name)
+	default:
+		return p.extendedError(newPgError(pgSSProtocolViolation, "invalid CLOSE message subtype %d", target))
+	}
+	return p.writeMessage(pgServerCloseComplete, nil)
+}
+
+// pgReader reads the fields of a message. After an error, it returns zero
+// values and keeps the error.
+type pgReader struct {
+	buf []byte
+	err error
+}
+
+func (r *pgReader) fail() {
+	if r.err == nil {
+		r.err = newPgError(pgSSProtocolViolation, "invalid message format")
+	}
+	r.buf = nil
+}
+
+func (r *pgReader) readByte() byte {
+	if len(r.buf) < 1 {
+		r.fail()
+		return 0
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *pgReader) readInt16() int16 {
+	if len(r.buf) < 2 {
+		r.fail()
+		return 0
+	}
+	v := int16(binary.BigEndian.Uint16(r.buf))
+	r.buf = r.buf[2:]
+	return v
+}
+
+func (r *pgReader) readUint32() uint32 {
+	if len(r.buf) < 4 {
+		r.fail()
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.buf)
+	r.buf = r.buf[4:]
+	return v
+}
+
+func (r *pgReader) readBytes(n int) []byte {
+	if len(r.buf) < n {
+		r.fail()
+		return nil
+	}
+	b := r.buf[:n:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+// readString reads a null terminated string.
+func (r *pgReader) readString() string {
+	s, rest, ok := bytes.Cut(r.buf, []byte{0})
+	if !ok {
+		r.fail()
+		return ""
+	}
+	r.buf = rest
+	return string(s)
+}
+
+// pgAppendString appends a null terminated string.
+func pgAppendString(b []byte, s string) []byte {
+	return append(append(b, s...), 0)
+}
+
+// The SQLSTATE codes of the errors reported by the PostgreSQL protocol.
+const (
+	pgSSProtocolViolation           = "08P01"
+	pgSSFeatureNotSupported         = "0A000"
+	pgSSInvalidAuthorization        = "28000"
+	pgSSInvalidPassword             = "28P01"
+	pgSSInvalidStatementName        = "26000"
+	pgSSInvalidCursorName           = "34000"
+	pgSSInvalidTextRepresentation   = "22P02"
+	pgSSInvalidBinaryRepresentation = "22P03"
+	pgSSSyntaxError                 = "42601"
+	pgSSUndefinedParameter          = "42P02"
+	pgSSDuplicatePreparedStatement  = "42P05"
+	pgSSInternalError               = "XX000"
+)
+
+// pgError is an error reported to a PostgreSQL client.
+type pgError struct {
+	state   string
+	message string
+}
+
+func newPgError(state string, format string, args ...any) *pgError {
+	return &pgError{
+		state:   state,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implements the error interface
+func (e *pgError) Error() string {
+	return e.message
+}
+
+// pgErrorFromError converts an error to a pgError. The SQLSTATE of the MySQL
+// errors is kept, since it follows the same standard, except for the general
+// error of MySQL.
+func pgErrorFromError(err error) *pgError {
+	var pgErr *pgError
+	if errors.As(err, &pgErr) {
+		return pgErr
+	}
+	serr, ok := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+	if !ok {
+		return newPgError(pgSSInternalError, "%v", err)
+	}
+	state := serr.SQLState()
+	if state == "" || state == sqlerror.SSUnknownSQLState {
+		state = pgSSInternalError
+	}
+	return &pgError{state: state, message: serr.Message}
+}
+
+// pgErrorResponseBytes returns the payload of an ErrorResponse.
+func pgErrorResponseBytes(severity string, e *pgError) []byte {
+	var b []byte
+	b = pgAppendString(append(b, 'S'), severity)
+	b = pgAppendString(append(b, 'V'), severity)
+	b = pgAppendString(append(b, 'C'), e.state)
+	b = pgAppendString(append(b, 'M'), e.message)
+	return append(b, 0)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// pgTranslateQuery translates the lexical differences between the PostgreSQL
+// and the MySQL dialects, and returns the translated query with the highest
+// $N parameter of the query:
+//   - the backslashes of the string literals are escaped, since they are not
+//     escape characters in PostgreSQL, except in E'...' literals;
+//   - the "quoted" identifiers are backticked;
+//   - the $N parameters become :vN bind variables.
+//
+// The comments are kept as they are. Other differences of the dialects, like
+// the :: casts, are left to the parser, which rejects what it doesn't know.
+func pgTranslateQuery(query string) (string, int) {
+	var buf strings.Builder
+	buf.Grow(len(query))
+	maxParam := 0
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == '\'' || (ch == 'E' || ch == 'e') && i+1 < len(query) && query[i+1] == '\'' && (i == 0 || !pgIsIdentChar(query[i-1])):
+			escapes := ch != '\''
+			if escapes {
+				i++
+			}
+			buf.WriteByte('\'')
+			i++
+			for i < len(query) {
+				c := query[i]
+				if c == '\\' {
+					if escapes && i+1 < len(query) {
+						buf.WriteString(query[i : i+2])
+						i += 2
+						continue
+					}
+					buf.WriteString(`\\`)
+					i++
+					continue
+				}
+				buf.WriteByte(c)
+				i++
+				if c == '\'' {
+					if i < len(query) && query[i] == '\'' {
+						buf.WriteByte('\'')
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case ch == '"':
+			var ident strings.Builder
+			i++
+			for i < len(query) {
+				if query[i] == '"' {
+					if i+1 < len(query) && query[i+1] == '"' {
+						ident.WriteByte('"')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				ident.WriteByte(query[i])
+				i++
+			}
+			buf.WriteString(sqlescape.EscapeID(ident.String()))
+		case ch == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query) - i
+			}
+			buf.WriteString(query[i : i+end])
+			i += end
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			buf.WriteString(query[i : i+end])
+			i += end
+		case ch == '$' && i+1 < len(query) && isDigit(query[i+1]) && (i == 0 || !pgIsIdentChar(query[i-1])):
+			end := i + 1
+			for end < len(query) && isDigit(query[end]) {
+				end++
+			}
+			n, err := strconv.Atoi(query[i+1 : end])
+			if err != nil {
+				// Too large to be a parameter: keep it as it is.
+				buf.WriteString(query[i:end])
+			} else {
+				fmt.Fprintf(&buf, ":v%d", n)
+				maxParam = max(maxParam, n)
+			}
+			i = end
+		default:
+			buf.WriteByte(ch)
+			i++
+		}
+	}
+	return buf.String(), maxParam
+}
+
+func pgIsIdentChar(ch byte) bool {
+	return ch == '_' || ch == '$' || isDigit(ch) || 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch >= 0x80
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+// pgLocalSet returns the parameter and the value of a SET statement of one of
+// the pgLocalParameters, which is not executed. ok is false for the other
+// statements.
+func pgLocalSet(query string) (name, value string, ok bool) {
+	query = strings.TrimSpace(sqlparser.StripLeadingComments(query))
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	keyword, rest, found := strings.Cut(query, " ")
+	if !found || !strings.EqualFold(keyword, "set") {
+		return "", "", false
+	}
+	rest = strings.TrimSpace(rest)
+	for _, scope := range []string{"session ", "local "} {
+		if len(rest) > len(scope) && strings.EqualFold(rest[:len(scope)], scope) {
+			rest = strings.TrimSpace(rest[len(scope):])
+		}
+	}
+
+	end := strings.IndexFunc(rest, func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+	if end == -1 {
+		return "", "", false
+	}
+	name = strings.ToLower(rest[:end])
+	if !pgLocalParameters[name] {
+		return "", "", false
+	}
+	rest = strings.TrimSpace(rest[end:])
+	switch {
+	case strings.HasPrefix(rest, "="):
+		rest = rest[1:]
+	case len(rest) > 3 && strings.EqualFold(rest[:3], "to") && unicode.IsSpace(rune(rest[2])):
+		rest = rest[2:]
+	default:
+		return "", "", false
+	}
+	value = strings.TrimSpace(rest)
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return name, value, true
+}
+
+// pgCommandTag returns the tag of the CommandComplete of a query, which
+// depends on the kind of the query.
+func pgCommandTag(query string, hasFields bool, rows, rowsAffected uint64) string {
+	if hasFields {
+		return fmt.Sprintf("SELECT %d", rows)
+	}
+	switch sqlparser.Preview(query) {
+	case sqlparser.StmtInsert, sqlparser.StmtReplace:
+		// The OID of the inserted row is always 0.
+		return fmt.Sprintf("INSERT 0 %d", rowsAffected)
+	case sqlparser.StmtUpdate:
+		return fmt.Sprintf("UPDATE %d", rowsAffected)
+	case sqlparser.StmtDelete:
+		return fmt.Sprintf("DELETE %d", rowsAffected)
+	}
+	words := strings.Fields(sqlparser.StripLeadingComments(query))
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSuffix(words[0], ";"))
+}
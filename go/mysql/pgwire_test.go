@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// pgTestClient is a minimal PostgreSQL protocol client.
+type pgTestClient struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func (tc *pgTestClient) write(typ byte, payload []byte) {
+	header := binary.BigEndian.AppendUint32([]byte{typ}, uint32(len(payload)+4))
+	_, err := tc.conn.Write(append(header, payload...))
+	require.NoError(tc.t, err)
+}
+
+func (tc *pgTestClient) read() (byte, []byte) {
+	var header [5]byte
+	_, err := io.ReadFull(tc.conn, header[:])
+	require.NoError(tc.t, err)
+	payload := make([]byte, binary.BigEndian.Uint32(header[1:])-4)
+	_, err = io.ReadFull(tc.conn, payload)
+	require.NoError(tc.t, err)
+	return header[0], payload
+}
+
+// expect reads a message of the given type and returns its payload.
+func (tc *pgTestClient) expect(typ byte) []byte {
+	actual, payload := tc.read()
+	require.EqualValues(tc.t, string(typ), string(actual), "payload: %q", payload)
+	return payload
+}
+
+// expectError reads an ErrorResponse and returns its SQLSTATE and message.
+func (tc *pgTestClient) expectError() (string, string) {
+	fields := map[byte]string{}
+	r := &pgReader{buf: tc.expect(pgServerErrorResponse)}
+	for typ := r.readByte(); typ != 0; typ = r.readByte() {
+		fields[typ] = r.readString()
+	}
+	require.NoError(tc.t, r.err)
+	return fields['C'], fields['M']
+}
+
+// startup sends a StartupMessage and the password, and returns the
+// parameters sent by the server.
+func (tc *pgTestClient) startup(user, password string) map[string]string {
+	payload := binary.BigEndian.AppendUint32(nil, pgProtocolVersion3)
+	payload = pgAppendString(pgAppendString(payload, "user"), user)
+	payload = append(payload, 0)
+	_, err := tc.conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(payload)+4)), payload...))
+	require.NoError(tc.t, err)
+
+	assert.Equal(tc.t, binary.BigEndian.AppendUint32(nil, pgAuthCleartextPassword), tc.expect(pgServerAuthentication))
+	tc.write(pgClientPassword, pgAppendString(nil, password))
+	typ, payload := tc.read()
+	if typ == pgServerErrorResponse {
+		return nil
+	}
+	require.EqualValues(tc.t, pgServerAuthentication, typ)
+	assert.Equal(tc.t, binary.BigEndian.AppendUint32(nil, pgAuthOk), payload)
+
+	params := map[string]string{}
+	for {
+		typ, payload := tc.read()
+		switch typ {
+		case pgServerParameterStatus:
+			r := &pgReader{buf: payload}
+			params[r.readString()] = r.readString()
+		case pgServerBackendKeyData:
+		case pgServerReadyForQuery:
+			assert.Equal(tc.t, []byte{pgTxIdle}, payload)
+			return params
+		default:
+			require.FailNow(tc.t, "unexpected message", "type %c", typ)
+		}
+	}
+}
+
+// readRows reads the DataRows until a CommandComplete or a PortalSuspended,
+// and returns their text values and the message type and payload that ended
+// them.
+func (tc *pgTestClient) readRows() ([][]string, byte, string) {
+	var rows [][]string
+	for {
+		typ, payload := tc.read()
+		switch typ {
+		case pgServerDataRow:
+			r := &pgReader{buf: payload}
+			row := make([]string, r.readInt16())
+			for i := range row {
+				row[i] = string(r.readBytes(int(r.readUint32())))
+			}
+			rows = append(rows, row)
+		case pgServerCommandComplete, pgServerPortalSuspended:
+			return rows, typ, string(payload)
+		default:
+			require.FailNow(tc.t, "unexpected message", "type %c", typ)
+		}
+	}
+}
+
+// columnTypes returns the OIDs of the columns of a RowDescription.
+func columnTypes(payload []byte) []uint32 {
+	r := &pgReader{buf: payload}
+	oids := make([]uint32, r.readInt16())
+	for i := range oids {
+		r.readString()
+		r.readUint32()
+		r.readInt16()
+		oids[i] = r.readUint32()
+		r.readInt16()
+		r.readUint32()
+		r.readInt16()
+	}
+	return oids
+}
+
+func newPgTestListener(t *testing.T, th Handler) *Listener {
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	t.Cleanup(authServer.close)
+	l, err := NewListenerWithConfig(ListenerConfig{
+		Protocol:         "tcp",
+		Address:          "127.0.0.1:",
+		AuthServer:       authServer,
+		Handler:          th,
+		PostgresProtocol: true,
+	})
+	require.NoError(t, err)
+	l.AllowClearTextWithoutTLS.Store(true)
+	t.Cleanup(l.Close)
+	go l.Accept()
+	return l
+}
+
+func newPgTestClient(t *testing.T, l *Listener) *pgTestClient {
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &pgTestClient{t: t, conn: conn}
+}
+
+func TestPostgresProtocol(t *testing.T) {
+	th := &testHandler{}
+	l := newPgTestListener(t, th)
+
+	tc := newPgTestClient(t, l)
+	require.Nil(t, tc.startup("user1", "bad password"))
+	_, err := tc.conn.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+
+	tc = newPgTestClient(t, l)
+	params := tc.startup("user1", "password1")
+	assert.Equal(t, pgServerVersion, params["server_version"])
+	assert.Equal(t, "UTF8", params["client_encoding"])
+	assert.Equal(t, "user1", th.LastConn().User)
+	// The first connection failed to authenticate.
+	assert.EqualValues(t, pgFirstConnectionID+1, th.LastConn().ConnectionID)
+
+	tc.write(pgClientQuery, pgAppendString(nil, "select rows"))
+	assert.Equal(t, []uint32{pgTypeInt4, pgTypeVarchar}, columnTypes(tc.expect(pgServerRowDescription)))
+	rows, _, tag := tc.readRows()
+	assert.Equal(t, [][]string{{"10", "nice name"}, {"20", "nicer name"}}, rows)
+	assert.Equal(t, "SELECT 2\x00", tag)
+	tc.expect(pgServerReadyForQuery)
+
+	tc.write(pgClientQuery, pgAppendString(nil, "insert"))
+	rows, _, tag = tc.readRows()
+	assert.Empty(t, rows)
+	assert.Equal(t, "INSERT 0 123\x00", tag)
+	tc.expect(pgServerReadyForQuery)
+
+	th.SetErr(sqlerror.NewSQLError(sqlerror.ERUnknownError, sqlerror.SSUnknownSQLState, "forced query error"))
+	tc.write(pgClientQuery, pgAppendString(nil, "error"))
+	state, msg := tc.expectError()
+	assert.Equal(t, pgSSInternalError, state)
+	assert.Equal(t, "forced query error", msg)
+	tc.expect(pgServerReadyForQuery)
+
+	tc.write(pgClientQuery, pgAppendString(nil, " "))
+	tc.expect(pgServerEmptyQueryResponse)
+	tc.expect(pgServerReadyForQuery)
+
+	tc.write(pgClientQuery, pgAppendString(nil, "SET application_name = 'psql'"))
+	assert.Equal(t, pgAppendString(pgAppendString(nil, "application_name"), "psql"), tc.expect(pgServerParameterStatus))
+	assert.Equal(t, pgAppendString(nil, "SET"), tc.expect(pgServerCommandComplete))
+	tc.expect(pgServerReadyForQuery)
+
+	tc.write(pgClientTerminate, nil)
+	_, err = tc.conn.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestPostgresProtocolAuthenticationFailed(t *testing.T) {
+	h := &authFailedHandler{testHandler: &testHandler{}}
+	l := newPgTestListener(t, h)
+
+	tc := newPgTestClient(t, l)
+	require.Nil(t, tc.startup("user1", "bad password"))
+	_, err := tc.conn.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []string{"user1"}, h.failedUsers())
+
+	tc = newPgTestClient(t, l)
+	require.NotNil(t, tc.startup("user1", "password1"))
+	assert.Equal(t, []string{"user1"}, h.failedUsers())
+}
+
+// pgRecordingHandler records the prepared statements it executes.
+type pgRecordingHandler struct {
+	*testHandler
+	mu       sync.Mutex
+	prepares []*PrepareData
+}
+
+func (h *pgRecordingHandler) ComPrepare(c *Conn, query string, bindVars map[string]*querypb.BindVariable) ([]*querypb.Field, error) {
+	return selectRowsResult.Fields, nil
+}
+
+func (h *pgRecordingHandler) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	h.mu.Lock()
+	h.prepares = append(h.prepares, prepare)
+	h.mu.Unlock()
+	return callback(selectRowsResult)
+}
+
+func (h *pgRecordingHandler) lastPrepare() *PrepareData {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.prepares[len(h.prepares)-1]
+}
+
+func TestPostgresProtocolExtended(t *testing.T) {
+	h := &pgRecordingHandler{testHandler: &testHandler{}}
+	l := newPgTestListener(t, h)
+	tc := newPgTestClient(t, l)
+	require.NotNil(t, tc.startup("user1", "password1"))
+
+	// Parse the statement, with the type of the second parameter only.
+	parse := pgAppendString(pgAppendString(nil, "stmt"), `select $1, "name" from t where id = $2`)
+	parse = binary.BigEndian.AppendUint16(parse, 2)
+	parse = binary.BigEndian.AppendUint32(parse, 0)
+	parse = binary.BigEndian.AppendUint32(parse, pgTypeInt4)
+	tc.write(pgClientParse, parse)
+	tc.write(pgClientDescribe, pgAppendString([]byte{pgTargetStatement}, "stmt"))
+	tc.write(pgClientSync, nil)
+	tc.expect(pgServerParseComplete)
+	description := tc.expect(pgServerParameterDescription)
+	assert.Equal(t, binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32([]byte{0, 2}, pgTypeText), pgTypeInt4), description)
+	assert.Equal(t, []uint32{pgTypeInt4, pgTypeVarchar}, columnTypes(tc.expect(pgServerRowDescription)))
+	tc.expect(pgServerReadyForQuery)
+
+	// Bind it in text format, and fetch the rows one at a time.
+	bind := pgAppendString(pgAppendString(nil, ""), "stmt")
+	bind = binary.BigEndian.AppendUint16(bind, 0)
+	bind = binary.BigEndian.AppendUint16(bind, 2)
+	bind = append(binary.BigEndian.AppendUint32(bind, 3), "abc"...)
+	bind = append(binary.BigEndian.AppendUint32(bind, 2), "42"...)
+	bind = binary.BigEndian.AppendUint16(bind, 0)
+	tc.write(pgClientBind, bind)
+	tc.write(pgClientExecute, binary.BigEndian.AppendUint32(pgAppendString(nil, ""), 1))
+	tc.write(pgClientExecute, binary.BigEndian.AppendUint32(pgAppendString(nil, ""), 0))
+	tc.write(pgClientSync, nil)
+	tc.expect(pgServerBindComplete)
+	rows, typ, _ := tc.readRows()
+	assert.Equal(t, [][]string{{"10", "nice name"}}, rows)
+	assert.EqualValues(t, pgServerPortalSuspended, typ)
+	rows, typ, tag := tc.readRows()
+	assert.Equal(t, [][]string{{"20", "nicer name"}}, rows)
+	assert.EqualValues(t, pgServerCommandComplete, typ)
+	assert.Equal(t, "SELECT 2\x00", tag)
+	tc.expect(pgServerReadyForQuery)
+
+	prepare := h.lastPrepare()
+	assert.Equal(t, "select :v1, `name` from t where id = :v2", prepare.PrepareStmt)
+	assert.Equal(t, map[string]*querypb.BindVariable{
+		"v1": sqltypes.StringBindVariable("abc"),
+		"v2": sqltypes.Int64BindVariable(42),
+	}, prepare.BindVars)
+
+	// After an error, the messages are skipped until the Sync.
+	tc.write(pgClientBind, pgAppendString(pgAppendString(nil, ""), "unknown"))
+	tc.write(pgClientExecute, binary.BigEndian.AppendUint32(pgAppendString(nil, ""), 0))
+	tc.write(pgClientSync, nil)
+	state, _ := tc.expectError()
+	assert.Equal(t, pgSSProtocolViolation, state)
+	tc.expect(pgServerReadyForQuery)
+
+	bind = pgAppendString(pgAppendString(nil, ""), "stmt")
+	bind = binary.BigEndian.AppendUint16(bind, 0)
+	bind = binary.BigEndian.AppendUint16(bind, 2)
+	bind = append(binary.BigEndian.AppendUint32(bind, 3), "abc"...)
+	bind = append(binary.BigEndian.AppendUint32(bind, 3), "abc"...)
+	bind = binary.BigEndian.AppendUint16(bind, 0)
+	tc.write(pgClientBind, bind)
+	tc.write(pgClientSync, nil)
+	state, _ = tc.expectError()
+	assert.Equal(t, pgSSInvalidTextRepresentation, state)
+	tc.expect(pgServerReadyForQuery)
+
+	tc.write(pgClientClose, pgAppendString([]byte{pgTargetStatement}, "stmt"))
+	tc.write(pgClientSync, nil)
+	tc.expect(pgServerCloseComplete)
+	tc.expect(pgServerReadyForQuery)
+}
+
+func TestPgTranslateQuery(t *testing.T) {
+	testcases := []struct {
+		query      string
+		translated string
+		params     int
+	}{{
+		query:      "select 1",
+		translated: "select 1",
+	}, {
+		query:      `select "id", "a""b" from "t"`,
+		translated: "select `id`, `a\"b` from `t`",
+	}, {
+		query:      `select 'C:\dir', 'it''s'`,
+		translated: `select 'C:\\dir', 'it''s'`,
+	}, {
+		query:      `select E'it\'s\n'`,
+		translated: `select 'it\'s\n'`,
+	}, {
+		query:      "select $1 from t where a = $2 and b = $1",
+		translated: "select :v1 from t where a = :v2 and b = :v1",
+		params:     2,
+	}, {
+		query:      "select '$1', \"$2\", a$1 -- $3\n/* $4 */",
+		translated: "select '$1', `$2`, a$1 -- $3\n/* $4 */",
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.query, func(t *testing.T) {
+			translated, params := pgTranslateQuery(tcase.query)
+			assert.Equal(t, tcase.translated, translated)
+			assert.Equal(t, tcase.params, params)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// The OIDs of the PostgreSQL types, from pg_type.
+const (
+	pgTypeBool      = 16
+	pgTypeBytea     = 17
+	pgTypeInt8      = 20
+	pgTypeInt2      = 21
+	pgTypeInt4      = 23
+	pgTypeText      = 25
+	pgTypeJSON      = 114
+	pgTypeFloat4    = 700
+	pgTypeFloat8    = 701
+	pgTypeBpchar    = 1042
+	pgTypeVarchar   = 1043
+	pgTypeDate      = 1082
+	pgTypeTime      = 1083
+	pgTypeTimestamp = 1114
+	pgTypeNumeric   = 1700
+)
+
+// The formats of the parameters and of the columns.
+const (
+	pgFormatText   = 0
+	pgFormatBinary = 1
+)
+
+// pgTypeOf returns the OID and the size of the PostgreSQL type closest to a
+// MySQL type. The size is -1 for the types of variable size.
+func pgTypeOf(typ querypb.Type) (uint32, int16) {
+	switch typ {
+	case sqltypes.Int8, sqltypes.Uint8, sqltypes.Int16, sqltypes.Year:
+		return pgTypeInt2, 2
+	case sqltypes.Uint16, sqltypes.Int24, sqltypes.Uint24, sqltypes.Int32:
+		return pgTypeInt4, 4
+	case sqltypes.Uint32, sqltypes.Int64:
+		return pgTypeInt8, 8
+	case sqltypes.Uint64, sqltypes.Decimal:
+		// An unsigned 64 bit integer may not fit in an int8.
+		return pgTypeNumeric, -1
+	case sqltypes.Float32:
+		return pgTypeFloat4, 4
+	case sqltypes.Float64:
+		return pgTypeFloat8, 8
+	case sqltypes.Datetime, sqltypes.Timestamp:
+		return pgTypeTimestamp, 8
+	case sqltypes.Date:
+		return pgTypeDate, 4
+	case sqltypes.Time:
+		return pgTypeTime, 8
+	case sqltypes.VarChar:
+		return pgTypeVarchar, -1
+	case sqltypes.Char:
+		return pgTypeBpchar, -1
+	case sqltypes.TypeJSON:
+		return pgTypeJSON, -1
+	case sqltypes.Blob, sqltypes.VarBinary, sqltypes.Binary, sqltypes.Bit, sqltypes.Geometry:
+		return pgTypeBytea, -1
+	}
+	return pgTypeText, -1
+}
+
+// pgFormat returns the format of the i-th parameter or column: no format
+// means text, and a single format applies to all of them.
+func pgFormat(formats []int16, i int) int16 {
+	switch {
+	case len(formats) == 0:
+		return pgFormatText
+	case len(formats) == 1:
+		return formats[0]
+	case i < len(formats):
+		return formats[i]
+	}
+	return pgFormatText
+}
+
+// pgRowDescriptionBytes returns the payload of a RowDescription of the
+// fields, sent in the given formats.
+func pgRowDescriptionBytes(fields []*querypb.Field, formats []int16) []byte {
+	b := binary.BigEndian.AppendUint16(nil, uint16(len(fields)))
+	for i, field := range fields {
+		oid, size := pgTypeOf(field.Type)
+		b = pgAppendString(b, field.Name)
+		// The table OID and the column number are unknown.
+		b = binary.BigEndian.AppendUint32(b, 0)
+		b = binary.BigEndian.AppendUint16(b, 0)
+		b = binary.BigEndian.AppendUint32(b, oid)
+		b = binary.BigEndian.AppendUint16(b, uint16(size))
+		// No type modifier.
+		b = binary.BigEndian.AppendUint32(b, math.MaxUint32)
+		b = binary.BigEndian.AppendUint16(b, uint16(pgFormat(formats, i)))
+	}
+	return b
+}
+
+// pgDataRowBytes returns the payload of a DataRow of a row, sent in the given
+// formats.
+func pgDataRowBytes(fields []*querypb.Field, formats []int16, row []sqltypes.Value) ([]byte, error) {
+	b := binary.BigEndian.AppendUint16(nil, uint16(len(row)))
+	for i, value := range row {
+		if value.IsNull() {
+			b = binary.BigEndian.AppendUint32(b, math.MaxUint32)
+			continue
+		}
+		typ := value.Type()
+		if i < len(fields) {
+			typ = fields[i].Type
+		}
+		encoded, err := pgEncodeValue(typ, value, pgFormat(formats, i))
+		if err != nil {
+			return nil, err
+		}
+		b = binary.BigEndian.AppendUint32(b, uint32(len(encoded)))
+		b = append(b, encoded...)
+	}
+	return b, nil
+}
+
+// pgEncodeValue encodes a value of a column of a MySQL type in a format.
+func pgEncodeValue(typ querypb.Type, value sqltypes.Value, format int16) ([]byte, error) {
+	oid, _ := pgTypeOf(typ)
+	if format == pgFormatText {
+		if oid == pgTypeBytea {
+			raw := value.Raw()
+			b := make([]byte, 2+hex.EncodedLen(len(raw)))
+			copy(b, `\x`)
+			hex.Encode(b[2:], raw)
+			return b, nil
+		}
+		return value.Raw(), nil
+	}
+
+	switch oid {
+	case pgTypeInt2, pgTypeInt4, pgTypeInt8:
+		i, err := value.ToInt64()
+		if err != nil {
+			return nil, err
+		}
+		switch oid {
+		case pgTypeInt2:
+			return binary.BigEndian.AppendUint16(nil, uint16(i)), nil
+		case pgTypeInt4:
+			return binary.BigEndian.AppendUint32(nil, uint32(i)), nil
+		}
+		return binary.BigEndian.AppendUint64(nil, uint64(i)), nil
+	case pgTypeFloat4, pgTypeFloat8:
+		f, err := value.ToFloat64()
+		if err != nil {
+			return nil, err
+		}
+		if oid == pgTypeFloat4 {
+			return binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(f))), nil
+		}
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(f)), nil
+	case pgTypeText, pgTypeVarchar, pgTypeBpchar, pgTypeBytea:
+		// The binary format of the text types is their text.
+		return value.Raw(), nil
+	}
+	return nil, newPgError(pgSSFeatureNotSupported, "binary format of type %d is not supported", oid)
+}
+
+// pgDecodeParam decodes the value of a parameter of a Bind message, of a type
+// and in a format, into a bind variable. A nil value is a NULL.
+func pgDecodeParam(value []byte, oid uint32, format int16) (*querypb.BindVariable, error) {
+	if value == nil {
+		return sqltypes.NullBindVariable, nil
+	}
+
+	if format == pgFormatBinary {
+		switch oid {
+		case pgTypeBool:
+			if len(value) == 1 {
+				return sqltypes.Int64BindVariable(int64(value[0])), nil
+			}
+		case pgTypeInt2:
+			if len(value) == 2 {
+				return sqltypes.Int64BindVariable(int64(int16(binary.BigEndian.Uint16(value)))), nil
+			}
+		case pgTypeInt4:
+			if len(value) == 4 {
+				return sqltypes.Int64BindVariable(int64(int32(binary.BigEndian.Uint32(value)))), nil
+			}
+		case pgTypeInt8:
+			if len(value) == 8 {
+				return sqltypes.Int64BindVariable(int64(binary.BigEndian.Uint64(value))), nil
+			}
+		case pgTypeFloat4:
+			if len(value) == 4 {
+				return sqltypes.Float64BindVariable(float64(math.Float32frombits(binary.BigEndian.Uint32(value)))), nil
+			}
+		case pgTypeFloat8:
+			if len(value) == 8 {
+				return sqltypes.Float64BindVariable(math.Float64frombits(binary.BigEndian.Uint64(value))), nil
+			}
+		case pgTypeText, pgTypeVarchar, pgTypeBpchar, pgTypeJSON:
+			return sqltypes.StringBindVariable(string(value)), nil
+		case pgTypeBytea, 0:
+			return sqltypes.BytesBindVariable(value), nil
+		default:
+			return nil, newPgError(pgSSFeatureNotSupported, "binary format of parameter type %d is not supported", oid)
+		}
+		return nil, newPgError(pgSSInvalidBinaryRepresentation, "incorrect binary data format in bind parameter of type %d", oid)
+	}
+
+	var typ querypb.Type
+	switch oid {
+	case pgTypeInt2, pgTypeInt4, pgTypeInt8:
+		typ = sqltypes.Int64
+	case pgTypeFloat4, pgTypeFloat8:
+		typ = sqltypes.Float64
+	case pgTypeNumeric:
+		typ = sqltypes.Decimal
+	case pgTypeBool:
+		switch strings.ToLower(strings.TrimSpace(string(value))) {
+		case "t", "true", "y", "yes", "on", "1":
+			return sqltypes.Int64BindVariable(1), nil
+		case "f", "false", "n", "no", "off", "0":
+			return sqltypes.Int64BindVariable(0), nil
+		}
+		return nil, newPgError(pgSSInvalidTextRepresentation, "invalid input syntax for type boolean: %q", value)
+	case pgTypeBytea:
+		if hexValue, ok := strings.CutPrefix(string(value), `\x`); ok {
+			decoded, err := hex.DecodeString(hexValue)
+			if err != nil {
+				return nil, newPgError(pgSSInvalidTextRepresentation, "invalid hexadecimal data in bytea parameter")
+			}
+			return sqltypes.BytesBindVariable(decoded), nil
+		}
+		return sqltypes.BytesBindVariable(value), nil
+	default:
+		return sqltypes.StringBindVariable(string(value)), nil
+	}
+	v, err := sqltypes.NewValue(typ, value)
+	if err != nil {
+		return nil, newPgError(pgSSInvalidTextRepresentation, "invalid input syntax for parameter of type %d: %q", oid, value)
+	}
+	return sqltypes.ValueBindVariable(v), nil
+}
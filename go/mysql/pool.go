@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"time"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/pools/smartconnpool"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// PoolConfig is the configuration of a Pool.
+type PoolConfig struct {
+	// Capacity is the maximum number of connections the pool opens.
+	Capacity int64
+
+	// MaxLifetime is the maximum time a connection is kept open.
+	// Zero means no limit.
+	MaxLifetime time.Duration
+
+	// MaxIdleTime is the maximum time a connection stays idle in the
+	// pool before it is closed. Zero means no limit.
+	MaxIdleTime time.Duration
+
+	// PingInterval is the time after which an unused connection is
+	// pinged before it is handed out, to make sure it is still alive.
+	// Zero disables the health pings.
+	PingInterval time.Duration
+}
+
+// Pool is a pool of client connections to a MySQL server, for the
+// components that need a few connections to a single server without
+// the machinery of the tablet server pools. It does not replace the
+// pools of vttablet, mysqlctl or VReplication, which rely on connection
+// settings, query killing and stats that it does not provide.
+//
+// Connections are only opened when they are needed. When TLS is used,
+// the TLS sessions are cached so that reconnecting resumes them
+// instead of doing a full handshake.
+type Pool struct {
+	params       ConnParams
+	pingInterval time.Duration
+	pool         *smartconnpool.ConnPool[*PoolConn]
+}
+
+// PooledConn is a connection borrowed from a Pool. It must be returned
+// to the pool with Recycle.
+type PooledConn = smartconnpool.Pooled[*PoolConn]
+
+// PoolConn is a connection of a Pool.
+type PoolConn struct {
+	*Conn
+	pool     *Pool
+	lastUsed time.Time
+}
+
+var errPoolSettingNotSupported = errors.New("mysql.Pool does not support connection settings")
+
+// NewPool creates a pool of connections to the server described by
+// params, and opens it.
+func NewPool(params *ConnParams, config PoolConfig) *Pool {
+	p := &Pool{
+		params:       *params,
+		pingInterval: config.PingInterval,
+	}
+	if p.params.SslEnabled() && p.params.TLSSessionCache == nil {
+		p.params.TLSSessionCache = tls.NewLRUClientSessionCache(int(config.Capacity))
+	}
+	p.pool = smartconnpool.NewPool(&smartconnpool.Config[*PoolConn]{
+		Capacity:    config.Capacity,
+		IdleTimeout: config.MaxIdleTime,
+		MaxLifetime: config.MaxLifetime,
+	}).Open(p.connect, nil)
+	return p
+}
+
+func (p *Pool) connect(ctx context.Context) (*PoolConn, error) {
+	c, err := Connect(ctx, &p.params)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolConn{Conn: c, pool: p, lastUsed: time.Now()}, nil
+}
+
+// Get returns a connection from the pool, blocking until one is
+// available or ctx is done.
+func (p *Pool) Get(ctx context.Context) (*PooledConn, error) {
+	conn, err := p.pool.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Conn.checkHealth(ctx); err != nil {
+		// The connection is broken: drop it instead of returning it to
+		// the pool.
+		conn.Close()
+		conn.Taint()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// ExecuteFetch executes a query on a connection of the pool. If the
+// connection turns out to be broken and the query only reads data, so
+// it can be safely replayed, it is retried once on a new connection.
+func (p *Pool) ExecuteFetch(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	for retried := false; ; retried = true {
+		conn, err := p.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		qr, err := conn.Conn.ExecuteFetch(query, maxrows, wantfields)
+		lost := isConnLost(err)
+		if lost {
+			// Make sure the connection is not reused.
+			conn.Close()
+			conn.Taint()
+		} else {
+			conn.Recycle()
+		}
+
+		if !lost || retried || !isReplaySafe(query) || ctx.Err() != nil {
+			return qr, err
+		}
+	}
+}
+
+// Close closes the pool. It waits for all the borrowed connections to
+// be returned.
+func (p *Pool) Close() {
+	p.pool.Close()
+}
+
+// Active returns the number of connections the pool has open.
+func (p *Pool) Active() int64 {
+	return p.pool.Active()
+}
+
+// InUse returns the number of connections borrowed from the pool.
+func (p *Pool) InUse() int64 {
+	return p.pool.InUse()
+}
+
+// checkHealth pings the connection if it was not used for longer than
+// the ping interval, and reconnects if the ping fails.
+func (pc *PoolConn) checkHealth(ctx context.Context) error {
+	now := time.Now()
+	if interval := pc.pool.pingInterval; interval > 0 && now.Sub(pc.lastUsed) > interval {
+		if err := pc.Ping(); err != nil {
+			if err := pc.reconnect(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	pc.lastUsed = now
+	return nil
+}
+
+// reconnect replaces the connection by a new one.
+func (pc *PoolConn) reconnect(ctx context.Context) error {
+	pc.Conn.Close()
+	c, err := Connect(ctx, &pc.pool.params)
+	if err != nil {
+		return err
+	}
+	pc.Conn = c
+	return nil
+}
+
+// ApplySetting is part of the smartconnpool.Connection interface.
+func (pc *PoolConn) ApplySetting(ctx context.Context, setting *smartconnpool.Setting) error {
+	return errPoolSettingNotSupported
+}
+
+// ResetSetting is part of the smartconnpool.Connection interface.
+func (pc *PoolConn) ResetSetting(ctx context.Context) error {
+	return errPoolSettingNotSupported
+}
+
+// Setting is part of the smartconnpool.Connection interface.
+func (pc *PoolConn) Setting() *smartconnpool.Setting {
+	return nil
+}
+
+// isConnLost returns true if the error means the connection to the
+// server is gone. Unlike sqlerror.IsConnErr, interrupted queries are
+// not included: they were killed on purpose.
+func isConnLost(err error) bool {
+	var sqlErr *sqlerror.SQLError
+	if !errors.As(err, &sqlErr) {
+		return false
+	}
+	switch sqlErr.Number() {
+	case sqlerror.CRServerGone, sqlerror.CRServerLost:
+		return true
+	}
+	return false
+}
+
+// isReplaySafe returns true if executing the query twice has the same
+// effect as executing it once.
+func isReplaySafe(query string) bool {
+	switch sqlparser.Preview(query) {
+	case sqlparser.StmtSelect, sqlparser.StmtShow, sqlparser.StmtExplain:
+		return true
+	}
+	return false
+}
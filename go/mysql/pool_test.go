@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPool(t *testing.T, th *testHandler, config PoolConfig) (*Pool, *Listener) {
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+	}}
+	t.Cleanup(authServer.close)
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	t.Cleanup(l.Close)
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	pool := NewPool(&ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}, config)
+	t.Cleanup(pool.Close)
+	return pool, l
+}
+
+func TestPoolExecuteFetch(t *testing.T) {
+	ctx := context.Background()
+	th := &testHandler{}
+	pool, _ := newTestPool(t, th, PoolConfig{Capacity: 1})
+
+	qr, err := pool.ExecuteFetch(ctx, "select rows", 10, true)
+	require.NoError(t, err)
+	assert.True(t, qr.Equal(selectRowsResult))
+	assert.EqualValues(t, 1, pool.Active())
+	assert.EqualValues(t, 0, pool.InUse())
+
+	// Writes are not replayed when the connection is lost.
+	th.LastConn().Close()
+	_, err = pool.ExecuteFetch(ctx, "insert", 10, false)
+	require.True(t, isConnLost(err), "unexpected error: %v", err)
+
+	// Reads are.
+	_, err = pool.ExecuteFetch(ctx, "insert", 10, false)
+	require.NoError(t, err)
+	th.LastConn().Close()
+	qr, err = pool.ExecuteFetch(ctx, "select rows", 10, true)
+	require.NoError(t, err)
+	assert.True(t, qr.Equal(selectRowsResult))
+}
+
+func TestPoolHealthPing(t *testing.T) {
+	ctx := context.Background()
+	th := &testHandler{}
+	pool, l := newTestPool(t, th, PoolConfig{Capacity: 1, PingInterval: time.Millisecond})
+
+	conn, err := pool.Get(ctx)
+	require.NoError(t, err)
+	conn.Recycle()
+
+	// The broken connection is replaced when it is handed out again.
+	th.LastConn().Close()
+	time.Sleep(2 * time.Millisecond)
+	conn, err = pool.Get(ctx)
+	require.NoError(t, err)
+	_, err = conn.Conn.ExecuteFetch("select rows", 10, true)
+	require.NoError(t, err)
+	conn.Recycle()
+
+	// The broken connection is dropped if it cannot be replaced.
+	l.Close()
+	th.LastConn().Close()
+	time.Sleep(2 * time.Millisecond)
+	_, err = pool.Get(ctx)
+	require.Error(t, err)
+	assert.EqualValues(t, 0, pool.Active())
+	assert.EqualValues(t, 0, pool.InUse())
+}
+
+func TestIsReplaySafe(t *testing.T) {
+	assert.True(t, isReplaySafe("select 1"))
+	assert.True(t, isReplaySafe("/* comment */ show tables"))
+	assert.False(t, isReplaySafe("insert into t values (1)"))
+	assert.False(t, isReplaySafe("set @a = 1"))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pires/go-proxyproto"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// NewProxyProtocolListener wraps l so that the connections accepted from
+// a load balancer, like HAProxy or an AWS NLB, which start with a PROXY
+// protocol v1 or v2 header report the address of the client as their
+// RemoteAddr, instead of the address of the load balancer.
+//
+// The header is only used for the connections coming from trustedCIDRs.
+// The connections from other addresses that send one are rejected, so
+// that clients which connect directly can't spoof their address. All the
+// connections are trusted if trustedCIDRs is empty.
+func NewProxyProtocolListener(l net.Listener, trustedCIDRs []*net.IPNet) net.Listener {
+	return &proxyproto.Listener{
+		Listener: l,
+		Policy:   proxyProtocolPolicy(trustedCIDRs),
+	}
+}
+
+// proxyProtocolPolicy returns the policy of the PROXY protocol listener
+// for trustedCIDRs. It never returns an error, as that would make Accept
+// fail and stop the accept loop of the Listener.
+func proxyProtocolPolicy(trustedCIDRs []*net.IPNet) proxyproto.PolicyFunc {
+	return func(upstream net.Addr) (proxyproto.Policy, error) {
+		if len(trustedCIDRs) == 0 {
+			return proxyproto.USE, nil
+		}
+		tcpAddr, ok := upstream.(*net.TCPAddr)
+		if !ok {
+			return proxyproto.REJECT, nil
+		}
+		for _, cidr := range trustedCIDRs {
+			if cidr.Contains(tcpAddr.IP) {
+				return proxyproto.USE, nil
+			}
+		}
+		return proxyproto.REJECT, nil
+	}
+}
+
+// ParseTrustedCIDRs parses a list of networks in CIDR notation, like
+// 10.0.0.0/8, for NewProxyProtocolListener. A single address, without a
+// prefix length, is accepted as the network of that address only.
+func ParseTrustedCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid trusted address: %q", cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid trusted CIDR: %q", cidr)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"net"
+	"testing"
+
+	"github.com/pires/go-proxyproto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// proxyProtocolRoundTrip accepts a connection on a PROXY protocol listener
+// trusting trustedCIDRs, from a client which sends the given header and a
+// ping, and returns the address reported by the connection and the error
+// of reading the ping.
+func proxyProtocolRoundTrip(t *testing.T, trustedCIDRs []string, header []byte) (net.Addr, error) {
+	cidrs, err := ParseTrustedCIDRs(trustedCIDRs)
+	require.NoError(t, err)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	pl := NewProxyProtocolListener(l, cidrs)
+
+	client, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write(append(header, []byte("ping")...))
+	require.NoError(t, err)
+
+	conn, err := pl.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	buf := make([]byte, 4)
+	_, err = conn.Read(buf)
+	if err == nil {
+		assert.Equal(t, "ping", string(buf))
+	}
+	return conn.RemoteAddr(), err
+}
+
+func TestProxyProtocolListener(t *testing.T) {
+	v1 := []byte("PROXY TCP4 192.0.2.10 192.0.2.20 54321 3306\r\n")
+	v2Header := proxyproto.HeaderProxyFromAddrs(2,
+		&net.TCPAddr{IP: net.ParseIP("2001:db8::10"), Port: 54321},
+		&net.TCPAddr{IP: net.ParseIP("2001:db8::20"), Port: 3306},
+	)
+	v2, err := v2Header.Format()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		trustedCIDRs []string
+		header       []byte
+		wantAddr     string
+		wantErr      bool
+	}{{
+		name:     "v1, all trusted",
+		header:   v1,
+		wantAddr: "192.0.2.10:54321",
+	}, {
+		name:     "v2, all trusted",
+		header:   v2,
+		wantAddr: "[2001:db8::10]:54321",
+	}, {
+		name:         "v1, trusted network",
+		trustedCIDRs: []string{"10.0.0.0/8", "127.0.0.0/8"},
+		header:       v1,
+		wantAddr:     "192.0.2.10:54321",
+	}, {
+		name:         "v2, trusted address",
+		trustedCIDRs: []string{"127.0.0.1"},
+		header:       v2,
+		wantAddr:     "[2001:db8::10]:54321",
+	}, {
+		name:         "untrusted with header",
+		trustedCIDRs: []string{"10.0.0.0/8"},
+		header:       v1,
+		wantErr:      true,
+	}, {
+		name:         "untrusted without header",
+		trustedCIDRs: []string{"10.0.0.0/8"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := proxyProtocolRoundTrip(t, tt.trustedCIDRs, tt.header)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantAddr == "" {
+				assert.Equal(t, "127.0.0.1", addr.(*net.TCPAddr).IP.String())
+				return
+			}
+			assert.Equal(t, tt.wantAddr, addr.String())
+		})
+	}
+}
+
+func TestParseTrustedCIDRs(t *testing.T) {
+	nets, err := ParseTrustedCIDRs([]string{"10.0.0.0/8", " 192.0.2.1 ", "", "2001:db8::/32", "::1"})
+	require.NoError(t, err)
+	var got []string
+	for _, n := range nets {
+		got = append(got, n.String())
+	}
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.1/32", "2001:db8::/32", "::1/128"}, got)
+
+	_, err = ParseTrustedCIDRs([]string{"10.0.0.0/33"})
+	assert.ErrorContains(t, err, "invalid trusted CIDR")
+	_, err = ParseTrustedCIDRs([]string{"not-an-ip"})
+	assert.ErrorContains(t, err, "invalid trusted address")
+}
@@ -50,6 +50,57 @@ const (
 // Client side methods.
 //
 
+// QueryAttribute is a named value sent along with a query when
+// CapabilityClientQueryAttributes is in use. MySQL exposes them to
+// the query through the mysql_query_attribute_string() function.
+type QueryAttribute struct {
+	Name  string
+	Value sqltypes.Value
+}
+
+// SetQueryAttributes sets the query attributes to send with the next
+// query written by WriteComQuery. They are ignored if the connection
+// did not negotiate CapabilityClientQueryAttributes.
+// Client side only.
+func (c *Conn) SetQueryAttributes(attributes []QueryAttribute) {
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return
+	}
+	c.queryAttributes = attributes
+}
+
+// QueryAttributes returns the query attributes sent by the client with
+// the query currently being executed.
+// Server side only.
+func (c *Conn) QueryAttributes() []QueryAttribute {
+	return c.queryAttributes
+}
+
+// TrackedSystemVariable is a system variable reported to the client
+// in the session state information of an OK packet.
+type TrackedSystemVariable struct {
+	Name  string
+	Value string
+}
+
+// TrackSystemVariable records that the statement being executed changed
+// the given system variable, so that the client is notified in the OK
+// packet of the statement. It is a no-op if the client did not negotiate
+// CapabilityClientSessionTrack.
+// Server side only.
+func (c *Conn) TrackSystemVariable(name, value string) {
+	if c.Capabilities&CapabilityClientSessionTrack == 0 {
+		return
+	}
+	c.trackedSystemVariables = append(c.trackedSystemVariables, TrackedSystemVariable{Name: name, Value: value})
+}
+
+// SessionTrackEnabled returns true if the client negotiated
+// CapabilityClientSessionTrack.
+func (c *Conn) SessionTrackEnabled() bool {
+	return c.Capabilities&CapabilityClientSessionTrack != 0
+}
+
 // WriteComQuery writes a query for the server to execute.
 // Client -> Server.
 // Returns SQLError(CRServerGone) if it can't.
@@ -57,6 +108,10 @@ func (c *Conn) WriteComQuery(query string) error {
 	// This is a new command, need to reset the sequence.
 	c.sequence = 0
 
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+		return c.writeComQueryWithAttributes(query)
+	}
+
 	data, pos := c.startEphemeralPacketWithHeader(len(query) + 1)
 	data[pos] = ComQuery
 	pos++
@@ -67,6 +122,62 @@ func (c *Conn) WriteComQuery(query string) error {
 	return nil
 }
 
+// writeComQueryWithAttributes writes a COM_QUERY packet prefixed with
+// the pending query attributes, as expected by a server that negotiated
+// CapabilityClientQueryAttributes. All attribute values are sent as
+// strings. The pending attributes are cleared once written.
+func (c *Conn) writeComQueryWithAttributes(query string) error {
+	attributes := c.queryAttributes
+	c.queryAttributes = nil
+
+	count := uint64(len(attributes))
+	length := 1 + // ComQuery
+		lenEncIntSize(count) + // parameter_count
+		lenEncIntSize(1) + // parameter_set_count
+		len(query)
+	if count > 0 {
+		length += (len(attributes)+7)/8 + // null_bitmap
+			1 // new_params_bind_flag
+		for _, attr := range attributes {
+			length += 2 + lenEncStringSize(attr.Name)
+			if !attr.Value.IsNull() {
+				length += lenEncStringSize(attr.Value.ToString())
+			}
+		}
+	}
+
+	data, pos := c.startEphemeralPacketWithHeader(length)
+	pos = writeByte(data, pos, ComQuery)
+	pos = writeLenEncInt(data, pos, count)
+	pos = writeLenEncInt(data, pos, 1)
+	if count > 0 {
+		bitmap := pos
+		pos = writeZeroes(data, pos, (len(attributes)+7)/8)
+		for i, attr := range attributes {
+			if attr.Value.IsNull() {
+				data[bitmap+i/8] |= 1 << uint(i%8)
+			}
+		}
+		pos = writeByte(data, pos, 0x01)
+		mysqlType, _ := sqltypes.TypeToMySQL(sqltypes.VarChar)
+		for _, attr := range attributes {
+			pos = writeByte(data, pos, mysqlType)
+			pos = writeByte(data, pos, 0)
+			pos = writeLenEncString(data, pos, attr.Name)
+		}
+		for _, attr := range attributes {
+			if !attr.Value.IsNull() {
+				pos = writeLenEncString(data, pos, attr.Value.ToString())
+			}
+		}
+	}
+	copy(data[pos:], query)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+	return nil
+}
+
 // writeComInitDB changes the default database to use.
 // Client -> Server.
 // Returns SQLError(CRServerGone) if it can't.
@@ -556,7 +667,7 @@ func (c *Conn) readComQueryResponse(packetOk *PacketOK) (int, error) {
 	case ErrPacket:
 		// Error
 		return 0, ParseErrorPacket(data)
-	case 0xfb:
+	case LocalInfilePacket:
 		// Local infile
 		return 0, vterrors.Errorf(vtrpc.Code_UNIMPLEMENTED, "not implemented")
 	}
@@ -574,8 +685,71 @@ func (c *Conn) readComQueryResponse(packetOk *PacketOK) (int, error) {
 // Server side methods.
 //
 
-func (c *Conn) parseComQuery(data []byte) string {
-	return string(data[1:])
+func (c *Conn) parseComQuery(data []byte) (string, []QueryAttribute, error) {
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return string(data[1:]), nil, nil
+	}
+
+	count, pos, ok := readLenEncInt(data, 1)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute count failed")
+	}
+	// parameter_set_count is always 1.
+	_, pos, ok = readLenEncInt(data, pos)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute set count failed")
+	}
+	if count == 0 {
+		return string(data[pos:]), nil, nil
+	}
+	if count > uint64(len(data)) {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "invalid query attribute count: %d", count)
+	}
+
+	bitMap, pos, ok := readBytes(data, pos, (int(count)+7)/8)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute NULL-bitmap failed")
+	}
+	newParamsBoundFlag, pos, ok := readByte(data, pos)
+	if !ok || newParamsBoundFlag != 0x01 {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes sent without types")
+	}
+
+	attributes := make([]QueryAttribute, count)
+	types := make([]querypb.Type, count)
+	for i := range attributes {
+		var mysqlType, flags byte
+		mysqlType, pos, ok = readByte(data, pos)
+		if !ok {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute type failed")
+		}
+		flags, pos, ok = readByte(data, pos)
+		if !ok {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute flags failed")
+		}
+		typ, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+		if err != nil {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+		}
+		types[i] = typ
+		attributes[i].Name, pos, ok = readLenEncString(data, pos)
+		if !ok {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute name failed")
+		}
+	}
+
+	for i := range attributes {
+		if (bitMap[i/8] & (1 << uint(i%8))) > 0 {
+			attributes[i].Value, pos, ok = c.parseStmtArgs(nil, sqltypes.Null, pos)
+		} else {
+			attributes[i].Value, pos, ok = c.parseStmtArgs(data, types[i], pos)
+		}
+		if !ok {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", types[i])
+		}
+	}
+
+	return string(data[pos:]), attributes, nil
 }
 
 func (c *Conn) parseComSetOption(data []byte) (uint16, bool) {
@@ -891,7 +1065,7 @@ func (c *Conn) parseStmtArgs(data []byte, typ querypb.Type, pos int) (sqltypes.V
 			return sqltypes.NULL, 0, false
 		}
 	case sqltypes.Decimal, sqltypes.Text, sqltypes.Blob, sqltypes.VarChar, sqltypes.VarBinary, sqltypes.Char,
-		sqltypes.Bit, sqltypes.Enum, sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON:
+		sqltypes.Bit, sqltypes.Enum, sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON, sqltypes.Vector:
 		val, pos, ok := readLenEncStringAsBytesCopy(data, pos)
 		return sqltypes.MakeTrusted(sqltypes.VarBinary, val), pos, ok
 	default:
@@ -928,6 +1102,15 @@ func (c *Conn) parseComStmtReset(data []byte) (uint32, bool) {
 	return val, ok
 }
 
+func (c *Conn) parseComStmtFetch(data []byte) (uint32, uint32, bool) {
+	stmtID, pos, ok := readUint32(data, 1)
+	if !ok {
+		return 0, 0, false
+	}
+	numRows, _, ok := readUint32(data, pos)
+	return stmtID, numRows, ok
+}
+
 func (c *Conn) parseComInitDB(data []byte) string {
 	return string(data[1:])
 }
@@ -1035,6 +1218,21 @@ func (c *Conn) writeFields(result *sqltypes.Result) error {
 	return nil
 }
 
+// writeCursorFields sends the fields of a Result whose rows are fetched
+// with COM_STMT_FETCH. The fields are always followed by an end packet
+// that has the ServerStatusCursorExists flag set.
+func (c *Conn) writeCursorFields(result *sqltypes.Result) error {
+	if err := c.sendColumnCount(uint64(len(result.Fields))); err != nil {
+		return err
+	}
+	for _, field := range result.Fields {
+		if err := c.writeColumnDefinition(field); err != nil {
+			return err
+		}
+	}
+	return c.writeEndResultWithFlags(c.StatusFlags|ServerStatusCursorExists, 0)
+}
+
 // writeRows sends the rows of a Result.
 func (c *Conn) writeRows(result *sqltypes.Result) error {
 	for _, row := range result.Rows {
@@ -1073,6 +1271,18 @@ func (c *Conn) writeEndResult(more bool, affectedRows, lastInsertID uint64, warn
 	return nil
 }
 
+// writeEndResultWithFlags sends an EOF packet, or an OK packet with the
+// EOF header, with the given status flags.
+func (c *Conn) writeEndResultWithFlags(flags, warnings uint16) error {
+	if c.Capabilities&CapabilityClientDeprecateEOF == 0 {
+		return c.writeEOFPacket(flags, warnings)
+	}
+	return c.writeOKPacketWithEOFHeader(&PacketOK{
+		statusFlags: flags,
+		warnings:    warnings,
+	})
+}
+
 // PacketComStmtPrepareOK contains the COM_STMT_PREPARE_OK packet details
 type PacketComStmtPrepareOK struct {
 	status       uint8
@@ -1503,7 +1713,7 @@ func val2MySQL(v sqltypes.Value) ([]byte, error) {
 		}
 	case sqltypes.Decimal, sqltypes.Text, sqltypes.Blob, sqltypes.VarChar,
 		sqltypes.VarBinary, sqltypes.Char, sqltypes.Bit, sqltypes.Enum,
-		sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON:
+		sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON, sqltypes.Vector:
 		l := len(v.Raw())
 		length := lenEncIntSize(uint64(l)) + l
 		out = make([]byte, length)
@@ -1553,7 +1763,7 @@ func val2MySQLLen(v sqltypes.Value) (int, error) {
 		}
 	case sqltypes.Decimal, sqltypes.Text, sqltypes.Blob, sqltypes.VarChar,
 		sqltypes.VarBinary, sqltypes.Char, sqltypes.Bit, sqltypes.Enum,
-		sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON:
+		sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON, sqltypes.Vector:
 		l := len(v.Raw())
 		length = lenEncIntSize(uint64(l)) + l
 	default:
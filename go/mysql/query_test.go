@@ -123,6 +123,97 @@ func TestComSetOption(t *testing.T) {
 
 }
 
+func TestComQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	cConn.Capabilities |= CapabilityClientQueryAttributes
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	// Write a ComQuery packet with attributes, read it, compare.
+	cConn.SetQueryAttributes([]QueryAttribute{
+		{Name: "trace_id", Value: sqltypes.NewVarChar("abc123")},
+		{Name: "empty", Value: sqltypes.NULL},
+		{Name: "priority", Value: sqltypes.NewInt64(10)},
+	})
+	err := cConn.WriteComQuery("select 1")
+	require.NoError(t, err)
+	data, err := sConn.ReadPacket()
+	require.NoError(t, err)
+	require.Equal(t, byte(ComQuery), data[0])
+
+	query, attributes, err := sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	require.Len(t, attributes, 3)
+	assert.Equal(t, "trace_id", attributes[0].Name)
+	assert.Equal(t, "abc123", attributes[0].Value.ToString())
+	assert.Equal(t, "empty", attributes[1].Name)
+	assert.True(t, attributes[1].Value.IsNull())
+	assert.Equal(t, "priority", attributes[2].Name)
+	assert.Equal(t, "10", attributes[2].Value.ToString())
+
+	// The attributes are only sent once.
+	sConn.sequence = 0
+	err = cConn.WriteComQuery("select 2")
+	require.NoError(t, err)
+	data, err = sConn.ReadPacket()
+	require.NoError(t, err)
+	query, attributes, err = sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 2", query)
+	assert.Empty(t, attributes)
+
+	// Malformed attribute header.
+	_, _, err = sConn.parseComQuery([]byte{ComQuery, 0x01, 0x01})
+	assert.ErrorContains(t, err, "NULL-bitmap")
+}
+
+func TestComQueryAttributesPacket(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	cConn.Capabilities |= CapabilityClientQueryAttributes
+
+	cConn.SetQueryAttributes([]QueryAttribute{
+		{Name: "a", Value: sqltypes.NewVarChar("xy")},
+		{Name: "n", Value: sqltypes.NULL},
+	})
+	err := cConn.WriteComQuery("select 1")
+	require.NoError(t, err)
+	data, err := sConn.ReadPacket()
+	require.NoError(t, err)
+
+	want := []byte{
+		ComQuery,
+		0x02,      // parameter_count
+		0x01,      // parameter_set_count
+		0x02,      // null_bitmap: the second attribute is NULL
+		0x01,      // new_params_bind_flag
+		253, 0x00, // type and flags of the first attribute
+		0x01, 'a', // name of the first attribute
+		253, 0x00, // type and flags of the second attribute
+		0x01, 'n', // name of the second attribute
+		0x02, 'x', 'y', // value of the first attribute
+	}
+	want = append(want, "select 1"...)
+	assert.Equal(t, want, data)
+
+	// Without attributes, the counts are still sent.
+	sConn.sequence = 0
+	err = cConn.WriteComQuery("select 1")
+	require.NoError(t, err)
+	data, err = sConn.ReadPacket()
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{ComQuery, 0x00, 0x01}, "select 1"...), data)
+}
+
 func TestComStmtPrepare(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	defer func() {
@@ -463,6 +554,7 @@ func TestQueries(t *testing.T) {
 			// Skip TUPLE, not possible in Result.
 			{Name: "Type_GEOMETRY ", Type: querypb.Type_GEOMETRY, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_BINARY_FLAG | querypb.MySqlFlag_BLOB_FLAG)},
 			{Name: "Type_JSON     ", Type: querypb.Type_JSON, Charset: collations.CollationUtf8mb4ID},
+			{Name: "Type_VECTOR   ", Type: querypb.Type_VECTOR, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_BINARY_FLAG)},
 		},
 		Rows: [][]sqltypes.Value{
 			{
@@ -495,6 +587,7 @@ func TestQueries(t *testing.T) {
 				sqltypes.MakeTrusted(querypb.Type_SET, []byte("Type_SET")),
 				sqltypes.MakeTrusted(querypb.Type_GEOMETRY, []byte("Type_GEOMETRY")),
 				sqltypes.MakeTrusted(querypb.Type_JSON, []byte("Type_JSON")),
+				sqltypes.MakeTrusted(querypb.Type_VECTOR, []byte{0x00, 0x00, 0x80, 0x3f}),
 			},
 			{
 				sqltypes.NULL,
@@ -526,6 +619,7 @@ func TestQueries(t *testing.T) {
 				sqltypes.NULL,
 				sqltypes.NULL,
 				sqltypes.NULL,
+				sqltypes.NULL,
 			},
 		},
 	})
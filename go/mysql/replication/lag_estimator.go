@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import "time"
+
+// LagEstimator estimates the replication lag of a replica from the time at
+// which the last binlog event it applied was committed on the source.
+//
+// Seconds_Behind_Source drops to 0 as soon as the applier has executed all
+// the events in the relay log, even if the replica is still behind and more
+// events are about to be received. MariaDB in particular makes the lag
+// oscillate between 0 and its real value when there are gaps in the relay
+// log. The estimator only reports no lag once the replica has stayed caught
+// up for GracePeriod, and keeps reporting the last known lag until then.
+//
+// A LagEstimator is not safe for concurrent use.
+type LagEstimator struct {
+	// GracePeriod is how long the replica must stay caught up before it is
+	// considered to have no lag.
+	GracePeriod time.Duration
+
+	// eventTime is the time at which the last applied event was committed
+	// on the source, and eventLag the lag when it was recorded.
+	eventTime time.Time
+	eventLag  time.Duration
+
+	// caughtUpSince is the first time the replica was seen caught up since
+	// the last event was recorded.
+	caughtUpSince time.Time
+}
+
+// RecordEvent records that, as of now, the last binlog event applied by the
+// replica was committed on the source at eventTime.
+func (e *LagEstimator) RecordEvent(eventTime, now time.Time) {
+	e.eventTime = eventTime
+	e.eventLag = max(now.Sub(eventTime), 0)
+	e.caughtUpSince = time.Time{}
+}
+
+// RecordCaughtUp records that, as of now, the replica has applied all the
+// events it received from the source.
+func (e *LagEstimator) RecordCaughtUp(now time.Time) {
+	if e.caughtUpSince.IsZero() {
+		e.caughtUpSince = now
+	}
+}
+
+// RecordStatus records a replication status that was fetched at now. A lag
+// of 0 means the replica is caught up. Otherwise, the last applied event is
+// the most recent heartbeat applied by the replica, which the primary writes
+// at a regular interval. Without heartbeats, the time of the last applied
+// event can only be derived from the lag reported by the replica.
+func (e *LagEstimator) RecordStatus(status ReplicationStatus, now time.Time) {
+	switch {
+	case status.ReplicationLagSeconds == 0:
+		e.RecordCaughtUp(now)
+	case status.HeartbeatLagKnown:
+		e.RecordEvent(status.HeartbeatTime, now)
+	default:
+		e.RecordEvent(now.Add(-time.Duration(status.ReplicationLagSeconds)*time.Second), now)
+	}
+}
+
+// Lag returns the estimated replication lag at now.
+func (e *LagEstimator) Lag(now time.Time) time.Duration {
+	switch {
+	case e.eventTime.IsZero():
+		// No event was recorded, so the replica never lagged behind.
+		return 0
+	case e.caughtUpSince.IsZero():
+		// The replica is still applying events.
+		return max(now.Sub(e.eventTime), 0)
+	case now.Sub(e.caughtUpSince) >= e.GracePeriod:
+		return 0
+	}
+	return e.eventLag
+}
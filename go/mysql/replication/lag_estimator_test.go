@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLagEstimator(t *testing.T) {
+	start := time.Now()
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	e := &LagEstimator{GracePeriod: 10 * time.Second}
+
+	// Nothing recorded yet.
+	assert.Zero(t, e.Lag(at(0)))
+	e.RecordCaughtUp(at(0))
+	assert.Zero(t, e.Lag(at(1)))
+
+	// The lag is measured from the time of the last applied event.
+	e.RecordEvent(at(-5), at(0))
+	assert.Equal(t, 5*time.Second, e.Lag(at(0)))
+	assert.Equal(t, 7*time.Second, e.Lag(at(2)))
+
+	// Catching up with the relay log doesn't drop the lag to 0 right away.
+	e.RecordCaughtUp(at(3))
+	assert.Equal(t, 5*time.Second, e.Lag(at(3)))
+	e.RecordCaughtUp(at(8))
+	assert.Equal(t, 5*time.Second, e.Lag(at(8)))
+	assert.Zero(t, e.Lag(at(13)))
+
+	// The lag oscillates between 0 and its real value.
+	e.RecordStatus(ReplicationStatus{ReplicationLagSeconds: 30}, at(20))
+	assert.Equal(t, 30*time.Second, e.Lag(at(20)))
+	e.RecordStatus(ReplicationStatus{ReplicationLagSeconds: 0}, at(25))
+	assert.Equal(t, 30*time.Second, e.Lag(at(25)))
+	e.RecordStatus(ReplicationStatus{ReplicationLagSeconds: 28}, at(30))
+	assert.Equal(t, 28*time.Second, e.Lag(at(30)))
+	e.RecordStatus(ReplicationStatus{ReplicationLagSeconds: 0}, at(35))
+	assert.Equal(t, 28*time.Second, e.Lag(at(35)))
+	e.RecordStatus(ReplicationStatus{ReplicationLagSeconds: 0}, at(45))
+	assert.Zero(t, e.Lag(at(45)))
+
+	// The time of the last applied event is the time of the last heartbeat,
+	// rather than the rounded lag reported by the replica.
+	e.RecordStatus(ReplicationStatus{ReplicationLagSeconds: 30, HeartbeatLagKnown: true, HeartbeatTime: at(38).Add(-500 * time.Millisecond)}, at(50))
+	assert.Equal(t, 12500*time.Millisecond, e.Lag(at(50)))
+	assert.Equal(t, 14500*time.Millisecond, e.Lag(at(52)))
+}
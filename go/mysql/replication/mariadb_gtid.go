@@ -63,6 +63,11 @@ func parseMariadbGTID(s string) (GTID, error) {
 }
 
 // ParseMariadbGTIDSet is registered as a GTIDSet parser.
+//
+// The set holds the last GTID of each replication domain. If the input lists
+// more than one GTID for a domain, as gtid_binlog_state does when several
+// servers wrote to the same domain, the one with the highest sequence number
+// is kept.
 func ParseMariadbGTIDSet(s string) (GTIDSet, error) {
 	gtidStrings := strings.Split(s, ",")
 	gtidSet := make(MariadbGTIDSet, len(gtidStrings))
@@ -75,8 +80,7 @@ func ParseMariadbGTIDSet(s string) (GTIDSet, error) {
 		if err != nil {
 			return nil, err
 		}
-		mdbGTID := gtid.(MariadbGTID)
-		gtidSet[mdbGTID.Domain] = mdbGTID
+		gtidSet.addGTID(gtid.(MariadbGTID))
 	}
 	return gtidSet, nil
 }
@@ -122,6 +126,11 @@ func (gtid MariadbGTID) GTIDSet() GTIDSet {
 }
 
 // MariadbGTIDSet implements GTIDSet.
+//
+// MariaDB sequence numbers are only ordered within a replication domain, so
+// the set holds the last GTID of each domain, and sets are compared domain by
+// domain: a set contains another one if, for every domain of the other set,
+// it has reached at least the same sequence number.
 type MariadbGTIDSet map[uint32]MariadbGTID
 
 // String implements GTIDSet.String()
@@ -237,8 +246,13 @@ func (gtidSet MariadbGTIDSet) Union(other GTIDSet) GTIDSet {
 	return newSet
 }
 
-// Last returns the last gtid
+// Last returns the GTID of the highest domain of the set, or an empty
+// string if the set is empty.
 func (gtidSet MariadbGTIDSet) Last() string {
+	if len(gtidSet) == 0 {
+		return ""
+	}
+
 	// Sort domains so the string format is deterministic.
 	domains := make([]uint32, 0, len(gtidSet))
 	for domain := range gtidSet {
@@ -87,6 +87,19 @@ func TestParseMariaGTIDSet(t *testing.T) {
 
 }
 
+func TestParseMariaGTIDSetDuplicateDomain(t *testing.T) {
+	// gtid_binlog_state lists the last GTID of each server in a domain.
+	input := "1-101-50,1-201-40,2-102-30,2-202-35"
+	want := MariadbGTIDSet{
+		1: MariadbGTID{Domain: 1, Server: 101, Sequence: 50},
+		2: MariadbGTID{Domain: 2, Server: 202, Sequence: 35},
+	}
+
+	got, err := ParseMariadbGTIDSet(input)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(want), "ParseMariadbGTIDSet(%#v) = %#v, want %#v", input, got, want)
+}
+
 func TestParseInvalidMariaGTIDSet(t *testing.T) {
 	input := "12-34-5678,11-22-33e33"
 	want := "invalid MariaDB GTID Sequence number"
@@ -626,3 +639,61 @@ func TestMariaGTIDSetLast(t *testing.T) {
 		assert.Equal(t, want, got.Last())
 	}
 }
+
+func TestMariaGTIDSetLastEmpty(t *testing.T) {
+	got, err := ParseMariadbGTIDSet("")
+	require.NoError(t, err)
+	assert.Equal(t, "", got.Last())
+}
+
+func TestMariaGTIDSetMultiSource(t *testing.T) {
+	// A replica applying the changes of two primaries, each of them writing
+	// to its own domain.
+	replica := MustParsePosition(MariadbFlavorID, "1-101-50,2-102-30")
+
+	testcases := []struct {
+		name    string
+		other   string
+		atLeast bool
+		union   string
+	}{{
+		name:    "behind on one domain",
+		other:   "1-101-60",
+		atLeast: false,
+		union:   "1-101-60,2-102-30",
+	}, {
+		name:    "caught up on one domain",
+		other:   "2-102-30",
+		atLeast: true,
+		union:   "1-101-50,2-102-30",
+	}, {
+		name:    "ahead on one domain, behind on the other",
+		other:   "1-101-40,2-102-31",
+		atLeast: false,
+		union:   "1-101-50,2-102-31",
+	}, {
+		name:    "caught up on both domains",
+		other:   "1-101-50,2-102-30",
+		atLeast: true,
+		union:   "1-101-50,2-102-30",
+	}, {
+		name:    "unknown domain",
+		other:   "3-103-1",
+		atLeast: false,
+		union:   "1-101-50,2-102-30,3-103-1",
+	}, {
+		name:    "failover within a domain",
+		other:   "1-201-45,2-102-10",
+		atLeast: true,
+		union:   "1-101-50,2-102-30",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			other := MustParsePosition(MariadbFlavorID, tc.other)
+			assert.Equal(t, tc.atLeast, replica.AtLeast(other))
+			assert.Equal(t, tc.union, replica.GTIDSet.Union(other.GTIDSet).String())
+			assert.True(t, MustParsePosition(MariadbFlavorID, tc.union).AtLeast(replica))
+			assert.True(t, MustParsePosition(MariadbFlavorID, tc.union).AtLeast(other))
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package replication
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"strconv"
@@ -37,8 +38,8 @@ var (
 func parseMysql56GTID(s string) (GTID, error) {
 	// Split into parts.
 	parts := strings.Split(s, ":")
-	if len(parts) != 2 {
-		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid MySQL 5.6 GTID (%v): expecting UUID:Sequence", s)
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid MySQL 5.6 GTID (%v): expecting UUID[:Tag]:Sequence", s)
 	}
 
 	// Parse Server ID.
@@ -47,13 +48,50 @@ func parseMysql56GTID(s string) (GTID, error) {
 		return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID Server ID (%v)", parts[0])
 	}
 
+	// Parse Tag, if any.
+	var tag string
+	if len(parts) == 3 {
+		if tag, err = ParseGTIDTag(parts[1]); err != nil {
+			return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID Tag (%v)", parts[1])
+		}
+	}
+
 	// Parse Sequence number.
-	seq, err := strconv.ParseInt(parts[1], 10, 64)
+	seq, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
 	if err != nil {
-		return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID Sequence number (%v)", parts[1])
+		return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID Sequence number (%v)", parts[len(parts)-1])
+	}
+
+	return Mysql56GTID{Server: sid, Tag: tag, Sequence: seq}, nil
+}
+
+// maxGTIDTagLength is the maximum length of a GTID tag.
+const maxGTIDTagLength = 32
+
+// ParseGTIDTag parses a GTID tag, as introduced by MySQL 8.4. Tags are made
+// of up to 32 letters, digits and underscores, and must not start with a
+// digit. They are case insensitive, so the tag is returned in lowercase,
+// which is how MySQL prints them.
+func ParseGTIDTag(s string) (string, error) {
+	if s == "" || len(s) > maxGTIDTagLength {
+		return "", vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid GTID tag %q: must be 1 to %d characters long", s, maxGTIDTagLength)
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return "", vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid GTID tag %q: unexpected character %q", s, c)
+		}
 	}
+	return strings.ToLower(s), nil
+}
 
-	return Mysql56GTID{Server: sid, Sequence: seq}, nil
+// isGTIDTag returns true if s is a GTID tag rather than an interval.
+// Intervals start with a digit, which tags can't.
+func isGTIDTag(s string) bool {
+	return s != "" && (s[0] < '0' || s[0] > '9')
 }
 
 // SID is the 16-byte unique ID of a MySQL 5.6 server.
@@ -90,18 +128,50 @@ func ParseSID(s string) (sid SID, err error) {
 	return sid, nil
 }
 
+// TSID identifies a sequence of MySQL 5.6 GTIDs: the SID of the server that
+// generated them, and their tag. Untagged GTIDs have an empty tag.
+type TSID struct {
+	SID SID
+	Tag string
+}
+
+// String prints a TSID in the form used by MySQL.
+func (tsid TSID) String() string {
+	if tsid.Tag == "" {
+		return tsid.SID.String()
+	}
+	return tsid.SID.String() + ":" + tsid.Tag
+}
+
+// compareTSIDs orders TSIDs by SID, and then by tag. Untagged GTIDs come
+// first, like in the sets printed by MySQL.
+func compareTSIDs(a, b TSID) int {
+	if c := bytes.Compare(a.SID[:], b.SID[:]); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Tag, b.Tag)
+}
+
 // Mysql56GTID implements GTID
 type Mysql56GTID struct {
 	// Server is the SID of the server that originally committed the transaction.
 	Server SID
+	// Tag is the tag of the transaction, or empty if it was not tagged.
+	// Tagged GTIDs were introduced in MySQL 8.4.
+	Tag string
 	// Sequence is the sequence number of the transaction within a given Server's
 	// scope.
 	Sequence int64
 }
 
+// TSID returns the TSID the GTID belongs to.
+func (gtid Mysql56GTID) TSID() TSID {
+	return TSID{SID: gtid.Server, Tag: gtid.Tag}
+}
+
 // String implements GTID.String().
 func (gtid Mysql56GTID) String() string {
-	return fmt.Sprintf("%s:%d", gtid.Server, gtid.Sequence)
+	return fmt.Sprintf("%s:%d", gtid.TSID(), gtid.Sequence)
 }
 
 // Flavor implements GTID.Flavor().
@@ -18,6 +18,7 @@ package replication
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/binary"
 	"slices"
 	"strconv"
@@ -79,7 +80,7 @@ func ParseMysql56GTIDSet(s string) (Mysql56GTIDSet, error) {
 			continue
 		}
 
-		// uuid_set: uuid:interval[:interval]...
+		// uuid_set: uuid[:tag]:interval[:[tag:]interval]...
 		head, tail, ok := strings.Cut(uuidSet, ":")
 		if !ok {
 			return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "invalid MySQL 5.6 GTID set (%q): expected uuid:interval", s)
@@ -91,6 +92,7 @@ func ParseMysql56GTIDSet(s string) (Mysql56GTIDSet, error) {
 			return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID set (%q)", s)
 		}
 
+		tsid := TSID{SID: sid}
 		intervals := make([]interval, 0, strings.Count(tail, ":")+1)
 		for len(tail) > 0 {
 			if idx := strings.IndexByte(tail, ':'); idx >= 0 {
@@ -101,6 +103,16 @@ func ParseMysql56GTIDSet(s string) (Mysql56GTIDSet, error) {
 				tail = ""
 			}
 
+			if isGTIDTag(head) {
+				// The tag applies to the intervals that follow it.
+				set.addIntervals(tsid, intervals)
+				if tsid.Tag, err = ParseGTIDTag(head); err != nil {
+					return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID set (%q)", s)
+				}
+				intervals = make([]interval, 0, strings.Count(tail, ":")+1)
+				continue
+			}
+
 			iv, err := parseInterval(head)
 			if err != nil {
 				return nil, vterrors.Wrapf(err, "invalid MySQL 5.6 GTID set (%q)", s)
@@ -113,48 +125,107 @@ func ParseMysql56GTIDSet(s string) (Mysql56GTIDSet, error) {
 			}
 			intervals = append(intervals, iv)
 		}
-		if len(intervals) == 0 {
-			// We might have discarded all the intervals.
+		set.addIntervals(tsid, intervals)
+	}
+
+	return set, nil
+}
+
+// addIntervals adds parsed intervals to the set.
+// Unlike the exported methods, this mutates the receiver.
+func (set Mysql56GTIDSet) addIntervals(tsid TSID, intervals []interval) {
+	if len(intervals) == 0 {
+		// We might have discarded all the intervals.
+		return
+	}
+
+	if tsidIntervals, ok := set[tsid]; ok {
+		// TSID already exists, we append
+		// Example:  "00010203-0405-0607-0809-0a0b0c0d0e0f:1-5,00010203-0405-0607-0809-0a0b0c0d0e0f:10-20"
+		// turns to: "00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20"
+		intervals = append(tsidIntervals, intervals...)
+	}
+	// Internally we expect intervals to be stored in order, without
+	// overlaps, so they can be binary searched.
+	set[tsid] = normalizeIntervals(intervals)
+}
+
+// normalizeIntervals sorts the intervals, and merges the ones that
+// overlap or are adjacent, like MySQL does.
+func normalizeIntervals(intervals []interval) []interval {
+	if len(intervals) == 0 {
+		return intervals
+	}
+	slices.SortFunc(intervals, func(a, b interval) int {
+		return cmp.Compare(a.start, b.start)
+	})
+	merged := intervals[:1]
+	for _, iv := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if iv.start > last.end+1 {
+			merged = append(merged, iv)
 			continue
 		}
+		last.end = max(last.end, iv.end)
+	}
+	return merged
+}
 
-		if sidIntervals, ok := set[sid]; ok {
-			// SID already exists, we append
-			// Example:  "00010203-0405-0607-0809-0a0b0c0d0e0f:1-5,00010203-0405-0607-0809-0a0b0c0d0e0f:10-20"
-			// turns to: "00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20"
-			intervals = append(sidIntervals, intervals...)
+// seekIntervals is like searchIntervals, but it is faster when the
+// result is close to the beginning of the intervals, which is the case
+// when walking two sets of intervals side by side.
+func seekIntervals(intervals []interval, seq int64) int {
+	const scan = 8
+	for i := 0; i < len(intervals) && i < scan; i++ {
+		if intervals[i].end >= seq {
+			return i
 		}
-		// Internally we expect intervals to be stored in order.
-		slices.SortFunc(intervals, func(a, b interval) int {
-			// TODO: switch to cmp.Compare for Go 1.21+.
-			//
-			// https://pkg.go.dev/cmp@master#Compare.
-			switch {
-			case a.start < b.start:
-				return -1
-			case a.start > b.start:
-				return 1
-			default:
-				return 0
-			}
-		})
-		set[sid] = intervals
 	}
+	if len(intervals) <= scan {
+		return len(intervals)
+	}
+	return scan + searchIntervals(intervals[scan:], seq)
+}
 
-	return set, nil
+// searchIntervals returns the index of the first interval that ends at
+// or after seq, or len(intervals) if there is none.
+func searchIntervals(intervals []interval, seq int64) int {
+	lo, hi := 0, len(intervals)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if intervals[mid].end < seq {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
 }
 
 // Mysql56GTIDSet implements GTIDSet for MySQL 5.6.
-type Mysql56GTIDSet map[SID][]interval
+//
+// The intervals are grouped by TSID: tagged GTIDs (MySQL 8.4+) are kept
+// apart from the untagged GTIDs of the same server.
+type Mysql56GTIDSet map[TSID][]interval
+
+// TSIDs returns a sorted list of TSIDs in the set.
+func (set Mysql56GTIDSet) TSIDs() []TSID {
+	tsids := make([]TSID, 0, len(set))
+	for tsid := range set {
+		tsids = append(tsids, tsid)
+	}
+	slices.SortFunc(tsids, compareTSIDs)
+	return tsids
+}
 
-// SIDs returns a sorted list of SIDs in the set.
-func (set Mysql56GTIDSet) SIDs() []SID {
-	sids := make([]SID, 0, len(set))
-	for sid := range set {
-		sids = append(sids, sid)
+// tagged returns true if the set contains tagged GTIDs.
+func (set Mysql56GTIDSet) tagged() bool {
+	for tsid := range set {
+		if tsid.Tag != "" {
+			return true
+		}
 	}
-	sortSIDs(sids)
-	return sids
+	return false
 }
 
 func sortSIDs(sids []SID) {
@@ -166,13 +237,22 @@ func sortSIDs(sids []SID) {
 // String implements GTIDSet.
 func (set Mysql56GTIDSet) String() string {
 	var buf strings.Builder
-	for i, sid := range set.SIDs() {
-		if i != 0 {
-			buf.WriteByte(',')
+	tsids := set.TSIDs()
+	for i, tsid := range tsids {
+		// The intervals of all the tags of a server are printed together,
+		// each tag followed by its intervals, e.g. "uuid:1-5:tag:1-3".
+		if i == 0 || tsid.SID != tsids[i-1].SID {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(tsid.SID.String())
+		}
+		if tsid.Tag != "" {
+			buf.WriteByte(':')
+			buf.WriteString(tsid.Tag)
 		}
-		buf.WriteString(sid.String())
 
-		for _, interval := range set[sid] {
+		for _, interval := range set[tsid] {
 			buf.WriteByte(':')
 			buf.WriteString(strconv.FormatInt(interval.start, 10))
 
@@ -190,10 +270,10 @@ func (set Mysql56GTIDSet) String() string {
 // it just returns the last SID with last interval
 func (set Mysql56GTIDSet) Last() string {
 	var buf strings.Builder
-	if len(set.SIDs()) > 0 {
-		sid := set.SIDs()[len(set.SIDs())-1]
-		buf.WriteString(sid.String())
-		sequences := set[sid]
+	if tsids := set.TSIDs(); len(tsids) > 0 {
+		tsid := tsids[len(tsids)-1]
+		buf.WriteString(tsid.String())
+		sequences := set[tsid]
 		if len(sequences) > 0 {
 			buf.WriteByte(':')
 			lastInterval := sequences[len(sequences)-1]
@@ -213,18 +293,9 @@ func (set Mysql56GTIDSet) ContainsGTID(gtid GTID) bool {
 		return false
 	}
 
-	for _, iv := range set[gtid56.Server] {
-		if iv.start > gtid56.Sequence {
-			// We assume intervals are sorted, so we can skip the rest.
-			return false
-		}
-		if gtid56.Sequence <= iv.end {
-			// Now we know that: start <= Sequence <= end.
-			return true
-		}
-	}
-	// Server wasn't in the set, or no interval contained gtid.
-	return false
+	intervals := set[gtid56.TSID()]
+	i := searchIntervals(intervals, gtid56.Sequence)
+	return i < len(intervals) && intervals[i].start <= gtid56.Sequence
 }
 
 // Contains implements GTIDSet.
@@ -240,26 +311,19 @@ func (set Mysql56GTIDSet) Contains(other GTIDSet) bool {
 
 	// Check each SID in the other set.
 	for sid, otherIntervals := range other56 {
-		i := 0
 		intervals := set[sid]
-		count := len(intervals)
 
-		// Check each interval for this SID in the other set.
+		// Check each interval for this SID in the other set. Intervals
+		// are sorted and don't overlap, so each one can only be covered
+		// by the first of our intervals that ends after its start.
+		// Intervals are monotonically increasing, so we don't need to
+		// search from the beginning each time.
 		for _, iv := range otherIntervals {
-			// Check that interval against each of our intervals.
-			// Intervals are monotonically increasing,
-			// so we don't need to reset the index each time.
-			for {
-				if i >= count {
-					// We ran out of intervals to check against.
-					return false
-				}
-				if intervals[i].contains(iv) {
-					// Yes it's covered. Go on to the next one.
-					break
-				}
-				i++
+			i := seekIntervals(intervals, iv.start)
+			if i >= len(intervals) || !intervals[i].contains(iv) {
+				return false
 			}
+			intervals = intervals[i:]
 		}
 	}
 
@@ -315,57 +379,40 @@ func (set Mysql56GTIDSet) AddGTID(gtid GTID) GTIDSet {
 	}
 
 	// Make a copy and add the new GTID in the proper place.
-	// This function is not supposed to modify the original set.
-	newSet := make(Mysql56GTIDSet)
-
-	added := false
-
+	// This function is not supposed to modify the original set, but the
+	// intervals of the other SIDs are immutable, so they can be shared.
+	newSet := make(Mysql56GTIDSet, len(set)+1)
 	for sid, intervals := range set {
-		newIntervals := make([]interval, 0, len(intervals))
-
-		if sid == gtid56.Server {
-			// Look for the right place to add this GTID.
-			for _, iv := range intervals {
-				if !added {
-					switch {
-					case gtid56.Sequence == iv.start-1:
-						// Expand the interval at the beginning.
-						iv.start = gtid56.Sequence
-						added = true
-					case gtid56.Sequence == iv.end+1:
-						// Expand the interval at the end.
-						iv.end = gtid56.Sequence
-						added = true
-					case gtid56.Sequence < iv.start-1:
-						// The next interval is beyond the new GTID, but it can't
-						// be expanded, so we have to insert a new interval.
-						newIntervals = append(newIntervals, interval{start: gtid56.Sequence, end: gtid56.Sequence})
-						added = true
-					}
-				}
-				// Check if this interval can be merged with the previous one.
-				count := len(newIntervals)
-				if count != 0 && iv.start == newIntervals[count-1].end+1 {
-					// Merge instead of appending.
-					newIntervals[count-1].end = iv.end
-				} else {
-					// Can't be merged.
-					newIntervals = append(newIntervals, iv)
-				}
-			}
-		} else {
-			// Just copy everything.
-			newIntervals = append(newIntervals, intervals...)
-		}
-
-		newSet[sid] = newIntervals
-	}
-
-	if !added {
-		// There wasn't any place to insert the new GTID, so just append it
-		// as a new interval.
-		newSet[gtid56.Server] = append(newSet[gtid56.Server], interval{start: gtid56.Sequence, end: gtid56.Sequence})
-	}
+		newSet[sid] = intervals
+	}
+
+	seq := gtid56.Sequence
+	tsid := gtid56.TSID()
+	intervals := set[tsid]
+	// i is the first interval that ends after the new GTID. The interval
+	// before it, if any, ends before the new GTID.
+	i := searchIntervals(intervals, seq)
+	newIntervals := make([]interval, 0, len(intervals)+1)
+	newIntervals = append(newIntervals, intervals[:i]...)
+
+	switch {
+	case i > 0 && newIntervals[i-1].end+1 == seq:
+		// Expand the previous interval at the end, and merge it with
+		// the next one if the gap is now closed.
+		newIntervals[i-1].end = seq
+		if i < len(intervals) && intervals[i].start == seq+1 {
+			newIntervals[i-1].end = intervals[i].end
+			i++
+		}
+	case i < len(intervals) && intervals[i].start == seq+1:
+		// Expand the next interval at the beginning.
+		newIntervals = append(newIntervals, interval{start: seq, end: intervals[i].end})
+		i++
+	default:
+		// The GTID can't be merged, so insert a new interval.
+		newIntervals = append(newIntervals, interval{start: seq, end: seq})
+	}
+	newSet[tsid] = append(newIntervals, intervals[i:]...)
 
 	return newSet
 }
@@ -383,9 +430,15 @@ func (set Mysql56GTIDSet) Union(other GTIDSet) GTIDSet {
 		return set
 	}
 
+	// If the other set doesn't add anything, we can return the same
+	// instance. This is cheap compared to merging large sets.
+	if set.Contains(mydbOther) {
+		return set
+	}
+
 	// Make a copy and add the new GTID in the proper place.
 	// This function is not supposed to modify the original set.
-	newSet := make(Mysql56GTIDSet)
+	newSet := make(Mysql56GTIDSet, len(set)+len(mydbOther))
 
 	for otherSID, otherIntervals := range mydbOther {
 		intervals, ok := set[otherSID]
@@ -394,12 +447,17 @@ func (set Mysql56GTIDSet) Union(other GTIDSet) GTIDSet {
 			newSet[otherSID] = otherIntervals
 			continue
 		}
+		if mydbOther.Contains(Mysql56GTIDSet{otherSID: intervals}) {
+			// The other set is a superset for this server id.
+			newSet[otherSID] = otherIntervals
+			continue
+		}
 
 		// Found server id match between sets, so now we need to add each interval.
 		s1 := intervals
 		s2 := otherIntervals
 		var nextInterval interval
-		var newIntervals []interval
+		newIntervals := make([]interval, 0, len(s1)+len(s2))
 
 		// While our stacks have intervals to process, do work.
 		for popInterval(&nextInterval, &s1, &s2) {
@@ -441,19 +499,31 @@ func (set Mysql56GTIDSet) Union(other GTIDSet) GTIDSet {
 
 // SIDBlock returns the binary encoding of a MySQL 5.6 GTID set as expected
 // by internal commands that refer to an "SID block".
+// Sets that contain tagged GTIDs use the tagged format of MySQL 8.4.
 //
 // e.g. https://dev.mysql.com/doc/internals/en/com-binlog-dump-gtid.html
 func (set Mysql56GTIDSet) SIDBlock() []byte {
 	buf := &bytes.Buffer{}
 
 	// Number of SIDs.
-	binary.Write(buf, binary.LittleEndian, uint64(len(set)))
+	tagged := set.tagged()
+	if tagged {
+		binary.Write(buf, binary.LittleEndian, sidBlockTaggedFormat<<56|uint64(len(set))<<8|sidBlockTaggedFormat)
+	} else {
+		binary.Write(buf, binary.LittleEndian, uint64(len(set)))
+	}
 
-	for _, sid := range set.SIDs() {
-		buf.Write(sid[:])
+	for _, tsid := range set.TSIDs() {
+		buf.Write(tsid.SID[:])
+		if tagged {
+			// The length of the tag is encoded as a variable-length
+			// integer, which takes a single byte for tags.
+			buf.WriteByte(byte(len(tsid.Tag)) << 1)
+			buf.WriteString(tsid.Tag)
+		}
 
 		// Number of intervals.
-		intervals := set[sid]
+		intervals := set[tsid]
 		binary.Write(buf, binary.LittleEndian, uint64(len(intervals)))
 
 		for _, iv := range intervals {
@@ -487,7 +557,7 @@ func (set Mysql56GTIDSet) Difference(other Mysql56GTIDSet) Mysql56GTIDSet {
 		}
 
 		// Found server id match between sets, so now we need to subtract each interval.
-		var diffIntervals []interval
+		diffIntervals := make([]interval, 0, len(intervals)+len(otherIntervals))
 		advance := func() bool {
 			if len(intervals) == 0 {
 				return false
@@ -595,6 +665,11 @@ func (set Mysql56GTIDSet) Difference(other Mysql56GTIDSet) Mysql56GTIDSet {
 	return differenceSet
 }
 
+// sidBlockTaggedFormat marks SID blocks in the tagged format. It is stored
+// in the first and last bytes of the number of SIDs, which can't both be set
+// in the untagged format.
+const sidBlockTaggedFormat = 1
+
 // NewMysql56GTIDSetFromSIDBlock builds a Mysql56GTIDSet from parsing a SID Block.
 // This is the reverse of the SIDBlock method.
 //
@@ -606,6 +681,7 @@ func (set Mysql56GTIDSet) Difference(other Mysql56GTIDSet) Mysql56GTIDSet {
 // (nSIDs times)
 //
 //	16      SID
+//	1+n     tag (tagged format only: length << 1, then the tag)
 //	8       nIntervals
 //
 // (nIntervals times)
@@ -614,16 +690,31 @@ func (set Mysql56GTIDSet) Difference(other Mysql56GTIDSet) Mysql56GTIDSet {
 //	8       end
 func NewMysql56GTIDSetFromSIDBlock(data []byte) (Mysql56GTIDSet, error) {
 	buf := bytes.NewReader(data)
-	var set Mysql56GTIDSet = make(map[SID][]interval)
+	var set Mysql56GTIDSet = make(map[TSID][]interval)
 	var nSIDs uint64
 	if err := binary.Read(buf, binary.LittleEndian, &nSIDs); err != nil {
 		return nil, vterrors.Wrapf(err, "cannot read nSIDs")
 	}
+	tagged := nSIDs>>56 == sidBlockTaggedFormat && nSIDs&0xff == sidBlockTaggedFormat
+	if tagged {
+		nSIDs = nSIDs >> 8 & (1<<48 - 1)
+	}
 	for i := uint64(0); i < nSIDs; i++ {
-		var sid SID
-		if c, err := buf.Read(sid[:]); err != nil || c != 16 {
+		var tsid TSID
+		if c, err := buf.Read(tsid.SID[:]); err != nil || c != 16 {
 			return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read SID %v: %v %v", i, err, c)
 		}
+		if tagged {
+			tagLen, err := buf.ReadByte()
+			if err != nil || tagLen&1 != 0 || int(tagLen>>1) > maxGTIDTagLength {
+				return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read tag length %v: %v %v", i, err, tagLen)
+			}
+			tag := make([]byte, tagLen>>1)
+			if c, err := buf.Read(tag); len(tag) > 0 && (err != nil || c != len(tag)) {
+				return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot read tag %v: %v %v", i, err, c)
+			}
+			tsid.Tag = string(tag)
+		}
 		var nIntervals uint64
 		if err := binary.Read(buf, binary.LittleEndian, &nIntervals); err != nil {
 			return nil, vterrors.Wrapf(err, "cannot read nIntervals %v", i)
@@ -636,7 +727,7 @@ func NewMysql56GTIDSetFromSIDBlock(data []byte) (Mysql56GTIDSet, error) {
 			if err := binary.Read(buf, binary.LittleEndian, &end); err != nil {
 				return nil, vterrors.Wrapf(err, "cannot read end %v/%v", i, j)
 			}
-			set[sid] = append(set[sid], interval{
+			set[tsid] = append(set[tsid], interval{
 				start: int64(start),
 				end:   int64(end - 1),
 			})
@@ -56,46 +56,50 @@ func TestParseMysql56GTIDSet(t *testing.T) {
 		"": {},
 		// Simple case
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5": {
-			sid1: []interval{{1, 5}},
+			{SID: sid1}: []interval{{1, 5}},
 		},
 		// Capital hex chars
 		"00010203-0405-0607-0809-0A0B0C0D0E0F:1-5": {
-			sid1: []interval{{1, 5}},
+			{SID: sid1}: []interval{{1, 5}},
 		},
 		// Interval with same start and end
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:12": {
-			sid1: []interval{{12, 12}},
+			{SID: sid1}: []interval{{12, 12}},
 		},
 		// Multiple intervals
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
 		},
 		// Multiple intervals, out of order
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:10-20:1-5": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
+		},
+		// Overlapping and adjacent intervals are merged
+		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:3-10:11-12:20:6-7": {
+			{SID: sid1}: []interval{{1, 12}, {20, 20}},
 		},
 		// Intervals with end < start are discarded by MySQL 5.6
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:8-7": {},
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:8-7:10-20": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
 		},
 		// Same repeating SIDs
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5,00010203-0405-0607-0809-0a0b0c0d0e0f:10-20": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
 		},
 		// Same repeating SIDs, backwards order
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:10-20,00010203-0405-0607-0809-0a0b0c0d0e0f:1-5": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
 		},
 		// Multiple SIDs
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20,00010203-0405-0607-0809-0a0b0c0d0eff:1-5:50": {
-			sid1: []interval{{1, 5}, {10, 20}},
-			sid2: []interval{{1, 5}, {50, 50}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}},
 		},
 		// Multiple SIDs with space around the comma
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20, 00010203-0405-0607-0809-0a0b0c0d0eff:1-5:50": {
-			sid1: []interval{{1, 5}, {10, 20}},
-			sid2: []interval{{1, 5}, {50, 50}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}},
 		},
 	}
 
@@ -119,6 +123,9 @@ func TestParseMysql56GTIDSetInvalid(t *testing.T) {
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:-5",
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-2-3",
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-",
+		// Invalid tags
+		"00010203-0405-0607-0809-0a0b0c0d0e0f:tag-1:1-5",
+		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:" + strings.Repeat("x", 33) + ":1",
 	}
 
 	for _, input := range table {
@@ -134,20 +141,20 @@ func TestMysql56GTIDSetString(t *testing.T) {
 	table := map[string]Mysql56GTIDSet{
 		// Simple case
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5": {
-			sid1: []interval{{1, 5}},
+			{SID: sid1}: []interval{{1, 5}},
 		},
 		// Interval with same start and end
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:12": {
-			sid1: []interval{{12, 12}},
+			{SID: sid1}: []interval{{12, 12}},
 		},
 		// Multiple intervals
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
 		},
 		// Multiple SIDs
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:10-20,00010203-0405-0607-0809-0a0b0c0d0eff:1-5:50": {
-			sid1: []interval{{1, 5}, {10, 20}},
-			sid2: []interval{{1, 5}, {50, 50}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}},
 		},
 	}
 
@@ -171,26 +178,26 @@ func TestMysql56GTIDSetContainsGTID(t *testing.T) {
 	sid3 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 17}
 
 	set := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 40}},
-		sid2: []interval{{1, 5}, {50, 50}},
+		{SID: sid1}: []interval{{20, 30}, {35, 40}},
+		{SID: sid2}: []interval{{1, 5}, {50, 50}},
 	}
 
 	table := map[GTID]bool{
 		fakeGTID{}: false,
 
-		Mysql56GTID{sid1, 1}:  false,
-		Mysql56GTID{sid1, 19}: false,
-		Mysql56GTID{sid1, 20}: true,
-		Mysql56GTID{sid1, 23}: true,
-		Mysql56GTID{sid1, 30}: true,
-		Mysql56GTID{sid1, 31}: false,
+		Mysql56GTID{Server: sid1, Sequence: 1}:  false,
+		Mysql56GTID{Server: sid1, Sequence: 19}: false,
+		Mysql56GTID{Server: sid1, Sequence: 20}: true,
+		Mysql56GTID{Server: sid1, Sequence: 23}: true,
+		Mysql56GTID{Server: sid1, Sequence: 30}: true,
+		Mysql56GTID{Server: sid1, Sequence: 31}: false,
 
-		Mysql56GTID{sid2, 1}:  true,
-		Mysql56GTID{sid2, 10}: false,
-		Mysql56GTID{sid2, 50}: true,
-		Mysql56GTID{sid2, 51}: false,
+		Mysql56GTID{Server: sid2, Sequence: 1}:  true,
+		Mysql56GTID{Server: sid2, Sequence: 10}: false,
+		Mysql56GTID{Server: sid2, Sequence: 50}: true,
+		Mysql56GTID{Server: sid2, Sequence: 51}: false,
 
-		Mysql56GTID{sid3, 1}: false,
+		Mysql56GTID{Server: sid3, Sequence: 1}: false,
 	}
 
 	for input, want := range table {
@@ -207,8 +214,8 @@ func TestMysql56GTIDSetContains(t *testing.T) {
 
 	// The set to test against.
 	set := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 40}},
-		sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+		{SID: sid1}: []interval{{20, 30}, {35, 40}},
+		{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 	}
 
 	// Test cases that should return Contains() = true.
@@ -219,13 +226,13 @@ func TestMysql56GTIDSetContains(t *testing.T) {
 		{},
 
 		// Simple case
-		{sid1: []interval{{25, 30}}},
+		{{SID: sid1}: []interval{{25, 30}}},
 		// Multiple intervals
-		{sid2: []interval{{1, 2}, {4, 5}, {60, 70}}},
+		{{SID: sid2}: []interval{{1, 2}, {4, 5}, {60, 70}}},
 		// Multiple SIDs
 		{
-			sid1: []interval{{25, 30}, {35, 37}},
-			sid2: []interval{{1, 5}},
+			{SID: sid1}: []interval{{25, 30}, {35, 37}},
+			{SID: sid2}: []interval{{1, 5}},
 		},
 	}
 
@@ -240,22 +247,22 @@ func TestMysql56GTIDSetContains(t *testing.T) {
 		fakeGTID{},
 
 		// Simple cases
-		Mysql56GTIDSet{sid1: []interval{{1, 5}}},
-		Mysql56GTIDSet{sid1: []interval{{10, 19}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{1, 5}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{10, 19}}},
 		// Overlapping intervals
-		Mysql56GTIDSet{sid1: []interval{{10, 20}}},
-		Mysql56GTIDSet{sid1: []interval{{10, 25}}},
-		Mysql56GTIDSet{sid1: []interval{{25, 31}}},
-		Mysql56GTIDSet{sid1: []interval{{30, 31}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{10, 20}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{10, 25}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{25, 31}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{30, 31}}},
 		// Multiple intervals
-		Mysql56GTIDSet{sid1: []interval{{20, 30}, {34, 34}}},
+		Mysql56GTIDSet{{SID: sid1}: []interval{{20, 30}, {34, 34}}},
 		// Multiple SIDs
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 30}, {36, 36}},
-			sid2: []interval{{3, 5}, {55, 60}},
+			{SID: sid1}: []interval{{20, 30}, {36, 36}},
+			{SID: sid2}: []interval{{3, 5}, {55, 60}},
 		},
 		// SID is missing entirely
-		Mysql56GTIDSet{sid3: []interval{{1, 5}}},
+		Mysql56GTIDSet{{SID: sid3}: []interval{{1, 5}}},
 	}
 
 	for _, other := range notContained {
@@ -294,8 +301,8 @@ func TestMysql56GTIDSetEqual(t *testing.T) {
 
 	// The set to test against.
 	set := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 40}},
-		sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+		{SID: sid1}: []interval{{20, 30}, {35, 40}},
+		{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 	}
 
 	// Test cases that should return Equal() = true.
@@ -304,8 +311,8 @@ func TestMysql56GTIDSetEqual(t *testing.T) {
 		set,
 		// Different instance, same data
 		{
-			sid1: []interval{{20, 30}, {35, 40}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 	}
 
@@ -324,33 +331,33 @@ func TestMysql56GTIDSetEqual(t *testing.T) {
 		Mysql56GTIDSet{},
 		// Interval changed
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 31}, {35, 40}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 31}, {35, 40}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// Interval added
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 30}, {32, 33}, {35, 40}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {32, 33}, {35, 40}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// Interval removed
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 30}, {35, 40}},
-			sid2: []interval{{1, 5}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}},
+			{SID: sid2}: []interval{{1, 5}, {60, 70}},
 		},
 		// Different SID, same intervals
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 30}, {35, 40}},
-			sid3: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}},
+			{SID: sid3}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// SID added
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 30}, {35, 40}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
-			sid3: []interval{{1, 5}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid3}: []interval{{1, 5}},
 		},
 		// SID removed
 		Mysql56GTIDSet{
-			sid1: []interval{{20, 30}, {35, 40}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}},
 		},
 	}
 
@@ -372,8 +379,8 @@ func TestMysql56GTIDSetAddGTID(t *testing.T) {
 
 	// The set to test against.
 	set := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-		sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+		{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+		{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 	}
 
 	table := map[GTID]Mysql56GTIDSet{
@@ -382,52 +389,52 @@ func TestMysql56GTIDSetAddGTID(t *testing.T) {
 
 		// Adding GTIDs that are already in the set
 		Mysql56GTID{Server: sid1, Sequence: 20}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		Mysql56GTID{Server: sid1, Sequence: 30}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		Mysql56GTID{Server: sid1, Sequence: 25}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// New interval beginning
 		Mysql56GTID{Server: sid1, Sequence: 1}: {
-			sid1: []interval{{1, 1}, {20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{1, 1}, {20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// New interval middle
 		Mysql56GTID{Server: sid1, Sequence: 32}: {
-			sid1: []interval{{20, 30}, {32, 32}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {32, 32}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// New interval end
 		Mysql56GTID{Server: sid1, Sequence: 50}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}, {50, 50}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}, {50, 50}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// Extend interval start
 		Mysql56GTID{Server: sid2, Sequence: 49}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {49, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {49, 50}, {60, 70}},
 		},
 		// Extend interval end
 		Mysql56GTID{Server: sid2, Sequence: 51}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 51}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 51}, {60, 70}},
 		},
 		// Merge intervals
 		Mysql56GTID{Server: sid1, Sequence: 41}: {
-			sid1: []interval{{20, 30}, {35, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid1}: []interval{{20, 30}, {35, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
 		},
 		// Different SID
 		Mysql56GTID{Server: sid3, Sequence: 1}: {
-			sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-			sid2: []interval{{1, 5}, {50, 50}, {60, 70}},
-			sid3: []interval{{1, 1}},
+			{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}, {60, 70}},
+			{SID: sid3}: []interval{{1, 1}},
 		},
 	}
 
@@ -444,22 +451,22 @@ func TestMysql56GTIDSetUnion(t *testing.T) {
 	sid3 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 17}
 
 	set1 := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 40}, {42, 45}},
-		sid2: []interval{{1, 5}, {20, 50}, {60, 70}},
+		{SID: sid1}: []interval{{20, 30}, {35, 40}, {42, 45}},
+		{SID: sid2}: []interval{{1, 5}, {20, 50}, {60, 70}},
 	}
 
 	set2 := Mysql56GTIDSet{
-		sid1: []interval{{20, 31}, {35, 37}, {41, 46}},
-		sid2: []interval{{3, 6}, {22, 49}, {67, 72}},
-		sid3: []interval{{1, 45}},
+		{SID: sid1}: []interval{{20, 31}, {35, 37}, {41, 46}},
+		{SID: sid2}: []interval{{3, 6}, {22, 49}, {67, 72}},
+		{SID: sid3}: []interval{{1, 45}},
 	}
 
 	got := set1.Union(set2)
 
 	want := Mysql56GTIDSet{
-		sid1: []interval{{20, 31}, {35, 46}},
-		sid2: []interval{{1, 6}, {20, 50}, {60, 72}},
-		sid3: []interval{{1, 45}},
+		{SID: sid1}: []interval{{20, 31}, {35, 46}},
+		{SID: sid2}: []interval{{1, 6}, {20, 50}, {60, 72}},
+		{SID: sid3}: []interval{{1, 45}},
 	}
 	assert.True(t, got.Equal(want), "set1: %#v, set1.Union(%#v) = %#v, want %#v", set1, set2, got, want)
 
@@ -473,36 +480,36 @@ func TestMysql56GTIDSetDifference(t *testing.T) {
 	sid5 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 19}
 
 	set1 := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 39}, {40, 53}, {55, 75}},
-		sid2: []interval{{1, 7}, {20, 50}, {60, 70}},
-		sid4: []interval{{1, 30}},
-		sid5: []interval{{1, 7}, {20, 30}},
+		{SID: sid1}: []interval{{20, 30}, {35, 39}, {40, 53}, {55, 75}},
+		{SID: sid2}: []interval{{1, 7}, {20, 50}, {60, 70}},
+		{SID: sid4}: []interval{{1, 30}},
+		{SID: sid5}: []interval{{1, 7}, {20, 30}},
 	}
 
 	set2 := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 37}, {50, 60}},
-		sid2: []interval{{3, 5}, {22, 25}, {32, 37}, {67, 70}},
-		sid3: []interval{{1, 45}},
-		sid5: []interval{{2, 6}, {15, 40}},
+		{SID: sid1}: []interval{{20, 30}, {35, 37}, {50, 60}},
+		{SID: sid2}: []interval{{3, 5}, {22, 25}, {32, 37}, {67, 70}},
+		{SID: sid3}: []interval{{1, 45}},
+		{SID: sid5}: []interval{{2, 6}, {15, 40}},
 	}
 
 	got := set1.Difference(set2)
 
 	want := Mysql56GTIDSet{
-		sid1: []interval{{38, 39}, {40, 49}, {61, 75}},
-		sid2: []interval{{1, 2}, {6, 7}, {20, 21}, {26, 31}, {38, 50}, {60, 66}},
-		sid4: []interval{{1, 30}},
-		sid5: []interval{{1, 1}, {7, 7}},
+		{SID: sid1}: []interval{{38, 39}, {40, 49}, {61, 75}},
+		{SID: sid2}: []interval{{1, 2}, {6, 7}, {20, 21}, {26, 31}, {38, 50}, {60, 66}},
+		{SID: sid4}: []interval{{1, 30}},
+		{SID: sid5}: []interval{{1, 1}, {7, 7}},
 	}
 	assert.True(t, got.Equal(want), "got %#v; want %#v", got, want)
 
 	sid10 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	sid11 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	set10 := Mysql56GTIDSet{
-		sid10: []interval{{1, 30}},
+		{SID: sid10}: []interval{{1, 30}},
 	}
 	set11 := Mysql56GTIDSet{
-		sid11: []interval{{1, 30}},
+		{SID: sid11}: []interval{{1, 30}},
 	}
 	got = set10.Difference(set11)
 	want = Mysql56GTIDSet{}
@@ -515,8 +522,8 @@ func TestMysql56GTIDSetSIDBlock(t *testing.T) {
 	sid2 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 16}
 
 	input := Mysql56GTIDSet{
-		sid1: []interval{{20, 30}, {35, 40}},
-		sid2: []interval{{1, 5}},
+		{SID: sid1}: []interval{{20, 30}, {35, 40}},
+		{SID: sid2}: []interval{{1, 5}},
 	}
 	want := []byte{
 		// n_sids
@@ -552,6 +559,75 @@ func TestMysql56GTIDSetSIDBlock(t *testing.T) {
 
 }
 
+func TestMysql56GTIDSetSIDBlockTagged(t *testing.T) {
+	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+	input := Mysql56GTIDSet{
+		{SID: sid1}:             []interval{{1, 5}},
+		{SID: sid1, Tag: "abc"}: []interval{{3, 3}},
+	}
+	want := []byte{
+		// format, n_sids, format
+		1, 2, 0, 0, 0, 0, 0, 1,
+		// sid1
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		// sid1: no tag
+		0,
+		// sid1: n_intervals
+		1, 0, 0, 0, 0, 0, 0, 0,
+		// sid1: interval 1 start
+		1, 0, 0, 0, 0, 0, 0, 0,
+		// sid1: interval 1 end
+		6, 0, 0, 0, 0, 0, 0, 0,
+		// sid1
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		// sid1: tag
+		6, 'a', 'b', 'c',
+		// sid1:abc: n_intervals
+		1, 0, 0, 0, 0, 0, 0, 0,
+		// sid1:abc: interval 1 start
+		3, 0, 0, 0, 0, 0, 0, 0,
+		// sid1:abc: interval 1 end
+		4, 0, 0, 0, 0, 0, 0, 0,
+	}
+	got := input.SIDBlock()
+	assert.Equal(t, want, got)
+
+	set, err := NewMysql56GTIDSetFromSIDBlock(want)
+	require.NoError(t, err)
+	assert.Equal(t, input, set)
+}
+
+func TestMysql56GTIDSetTagged(t *testing.T) {
+	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	sid2 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 255}
+
+	set, err := ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0e0f:1-5:Tag_B:3:tag_a:1-2:7, 00010203-0405-0607-0809-0a0b0c0d0eff:tag_a:4,00010203-0405-0607-0809-0a0b0c0d0e0f:6:tag_b:4")
+	require.NoError(t, err)
+	assert.Equal(t, Mysql56GTIDSet{
+		{SID: sid1}:               []interval{{1, 6}},
+		{SID: sid1, Tag: "tag_a"}: []interval{{1, 2}, {7, 7}},
+		{SID: sid1, Tag: "tag_b"}: []interval{{3, 4}},
+		{SID: sid2, Tag: "tag_a"}: []interval{{4, 4}},
+	}, set)
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-6:tag_a:1-2:7:tag_b:3-4,00010203-0405-0607-0809-0a0b0c0d0eff:tag_a:4", set.String())
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0eff:tag_a:4", set.Last())
+
+	// Tagged and untagged GTIDs of a server are different transactions.
+	assert.True(t, set.ContainsGTID(Mysql56GTID{Server: sid1, Tag: "tag_a", Sequence: 7}))
+	assert.False(t, set.ContainsGTID(Mysql56GTID{Server: sid1, Sequence: 7}))
+	assert.False(t, set.ContainsGTID(Mysql56GTID{Server: sid2, Sequence: 4}))
+
+	untagged, err := ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0e0f:1-6")
+	require.NoError(t, err)
+	assert.True(t, set.Contains(untagged))
+	assert.False(t, untagged.Contains(set))
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f:tag_a:1-2:7:tag_b:3-4,00010203-0405-0607-0809-0a0b0c0d0eff:tag_a:4", set.Difference(untagged).String())
+
+	union := untagged.AddGTID(Mysql56GTID{Server: sid1, Tag: "tag_b", Sequence: 5}).Union(set)
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-6:tag_a:1-2:7:tag_b:3-5,00010203-0405-0607-0809-0a0b0c0d0eff:tag_a:4", union.String())
+}
+
 func TestMySQL56GTIDSetLast(t *testing.T) {
 	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	sid2 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 255}
@@ -559,23 +635,23 @@ func TestMySQL56GTIDSetLast(t *testing.T) {
 	table := map[string]Mysql56GTIDSet{
 		// Simple case
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:5": {
-			sid1: []interval{{1, 5}},
+			{SID: sid1}: []interval{{1, 5}},
 		},
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:3": {
-			sid1: []interval{{end: 3}},
+			{SID: sid1}: []interval{{end: 3}},
 		},
 		// Interval with same start and end
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:12": {
-			sid1: []interval{{12, 12}},
+			{SID: sid1}: []interval{{12, 12}},
 		},
 		// Multiple intervals
 		"00010203-0405-0607-0809-0a0b0c0d0e0f:20": {
-			sid1: []interval{{1, 5}, {10, 20}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
 		},
 		// Multiple SIDs
 		"00010203-0405-0607-0809-0a0b0c0d0eff:50": {
-			sid1: []interval{{1, 5}, {10, 20}},
-			sid2: []interval{{1, 5}, {50, 50}},
+			{SID: sid1}: []interval{{1, 5}, {10, 20}},
+			{SID: sid2}: []interval{{1, 5}, {50, 50}},
 		},
 	}
 
@@ -704,3 +780,96 @@ func BenchmarkMySQL56GTIDParsing(b *testing.B) {
 		}
 	}
 }
+
+// newBenchmarkMysql56GTIDSet returns a set with the given number of
+// intervals for a single SID, and a few other SIDs.
+func newBenchmarkMysql56GTIDSet(intervals int, offset int64) Mysql56GTIDSet {
+	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	sid2 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 16}
+	set := Mysql56GTIDSet{{SID: sid2}: []interval{{1, 1000}}}
+	tsid1 := TSID{SID: sid1}
+	for i := int64(0); i < int64(intervals); i++ {
+		set[tsid1] = append(set[tsid1], interval{start: 10*i + offset + 1, end: 10*i + offset + 5})
+	}
+	return set
+}
+
+func BenchmarkMySQL56GTIDSetContainsGTID(b *testing.B) {
+	set := newBenchmarkMysql56GTIDSet(100_000, 0)
+	gtid := Mysql56GTID{Server: SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Sequence: 567_893}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if !set.ContainsGTID(gtid) {
+			b.Fatal("gtid not found")
+		}
+	}
+}
+
+func BenchmarkMySQL56GTIDSetContains(b *testing.B) {
+	set := newBenchmarkMysql56GTIDSet(100_000, 0)
+	small := newBenchmarkMysql56GTIDSet(10, 0)
+	other := newBenchmarkMysql56GTIDSet(100_000, 0)
+
+	b.Run("small", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			if !set.Contains(small) {
+				b.Fatal("set not contained")
+			}
+		}
+	})
+	b.Run("large", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			if !set.Contains(other) {
+				b.Fatal("set not contained")
+			}
+		}
+	})
+}
+
+func BenchmarkMySQL56GTIDSetUnion(b *testing.B) {
+	set := newBenchmarkMysql56GTIDSet(100_000, 0)
+	contained := newBenchmarkMysql56GTIDSet(10, 0)
+	shifted := newBenchmarkMysql56GTIDSet(100_000, 3)
+
+	b.Run("contained", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = set.Union(contained)
+		}
+	})
+	b.Run("overlapping", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = set.Union(shifted)
+		}
+	})
+}
+
+func BenchmarkMySQL56GTIDSetAddGTID(b *testing.B) {
+	set := newBenchmarkMysql56GTIDSet(100_000, 0)
+	gtid := Mysql56GTID{Server: SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Sequence: 567_897}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = set.AddGTID(gtid)
+	}
+}
+
+func BenchmarkMySQL56GTIDSetDifference(b *testing.B) {
+	set := newBenchmarkMysql56GTIDSet(100_000, 0)
+	shifted := newBenchmarkMysql56GTIDSet(100_000, 3)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = set.Difference(shifted)
+	}
+}
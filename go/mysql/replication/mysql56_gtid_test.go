@@ -53,6 +53,32 @@ func TestParseMysql56GTIDInvalid(t *testing.T) {
 	}
 }
 
+func TestParseMysql56GTIDTagged(t *testing.T) {
+	input := "00010203-0405-0607-0809-0A0B0C0D0E0F:My_Tag1:56789"
+	want := Mysql56GTID{
+		Server:   SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		Tag:      "my_tag1",
+		Sequence: 56789,
+	}
+
+	got, err := parseMysql56GTID(input)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f:my_tag1:56789", got.String())
+}
+
+func TestParseGTIDTag(t *testing.T) {
+	for _, input := range []string{"a", "_", "Tag_1", strings.Repeat("x", 32)} {
+		tag, err := ParseGTIDTag(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, strings.ToLower(input), tag)
+	}
+	for _, input := range []string{"", "1tag", "tag-1", "tag 1", strings.Repeat("x", 33)} {
+		_, err := ParseGTIDTag(input)
+		assert.Error(t, err, input)
+	}
+}
+
 func TestSIDString(t *testing.T) {
 	input := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	want := "00010203-0405-0607-0809-0a0b0c0d0e0f"
@@ -136,7 +162,7 @@ func TestMysql56SequenceNumber(t *testing.T) {
 func TestMysql56GTIDGTIDSet(t *testing.T) {
 	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	input := Mysql56GTID{Server: sid1, Sequence: 5432}
-	want := Mysql56GTIDSet{sid1: []interval{{5432, 5432}}}
+	want := Mysql56GTIDSet{{SID: sid1}: []interval{{5432, 5432}}}
 	if got := input.GTIDSet(); !got.Equal(want) {
 		t.Errorf("%#v.GTIDSet() = %#v, want %#v", input, got, want)
 	}
@@ -167,9 +193,9 @@ func TestDecodePositionMySQL56(t *testing.T) {
 		assert.False(t, pos.IsZero())
 		assert.NotNil(t, gtidSet)
 		expectGTID := Mysql56GTIDSet{
-			SID{
+			{SID: SID{
 				0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf,
-			}: []interval{{start: 1, end: 615}}}
+			}}: []interval{{start: 1, end: 615}}}
 		assert.Equal(t, expectGTID, gtidSet)
 	}
 	{
@@ -178,9 +204,9 @@ func TestDecodePositionMySQL56(t *testing.T) {
 		assert.False(t, pos.IsZero())
 		assert.NotNil(t, gtidSet)
 		expectGTID := Mysql56GTIDSet{
-			SID{
+			{SID: SID{
 				0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf,
-			}: []interval{{start: 1, end: 615}}}
+			}}: []interval{{start: 1, end: 615}}}
 		assert.Equal(t, expectGTID, gtidSet)
 	}
 	{
@@ -83,6 +83,41 @@ func (rp Position) AtLeast(other Position) bool {
 	return rp.GTIDSet.Contains(other.GTIDSet)
 }
 
+// Diff returns the transactions that are in this position but not in
+// another, i.e. the transactions a server at the other position is missing
+// to catch up with this one. It returns nil if nothing is missing.
+// Only MySQL 5.6+ GTID positions can be diffed: MariaDB positions only hold
+// the last GTID of each replication domain, and file positions don't identify
+// transactions, so an error is returned for them.
+func (rp Position) Diff(other Position) (GTIDSet, error) {
+	if rp.GTIDSet == nil {
+		return nil, nil
+	}
+	var set Mysql56GTIDSet
+	switch gtidSet := rp.GTIDSet.(type) {
+	case Mysql56GTIDSet:
+		set = gtidSet
+	case MariadbGTIDSet:
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot diff MariaDB positions: they only hold the last GTID of each replication domain")
+	case FilePosGTID:
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot diff file positions: they don't identify transactions")
+	default:
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot diff positions of flavor %v", rp.GTIDSet.Flavor())
+	}
+	var otherSet Mysql56GTIDSet
+	var ok bool
+	if other.GTIDSet != nil {
+		if otherSet, ok = other.GTIDSet.(Mysql56GTIDSet); !ok {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot diff a position of flavor %v with a position of flavor %v", rp.GTIDSet.Flavor(), other.GTIDSet.Flavor())
+		}
+	}
+	diff := set.Difference(otherSet)
+	if len(diff) == 0 {
+		return nil, nil
+	}
+	return diff, nil
+}
+
 // String returns a string representation of the underlying GTIDSet.
 // If the set is nil, it returns "<nil>" in the style of Sprintf("%v", nil).
 func (rp Position) String() string {
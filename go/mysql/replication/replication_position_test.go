@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPositionEqual(t *testing.T) {
@@ -210,6 +211,41 @@ func TestPositionAppendToZero(t *testing.T) {
 	}
 }
 
+func TestPositionDiff(t *testing.T) {
+	primary := MustParsePosition(Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-100,00010203-0405-0607-0809-0a0b0c0d0eff:1-5")
+	replica := MustParsePosition(Mysql56FlavorID, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-20:30-90")
+
+	diff, err := primary.Diff(replica)
+	require.NoError(t, err)
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f:21-29:91-100,00010203-0405-0607-0809-0a0b0c0d0eff:1-5", diff.String())
+
+	diff, err = replica.Diff(primary)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	diff, err = primary.Diff(Position{})
+	require.NoError(t, err)
+	assert.Equal(t, primary.GTIDSet, diff)
+
+	diff, err = Position{}.Diff(primary)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	mariadb := Position{GTIDSet: MariadbGTIDSet{3: MariadbGTID{Domain: 3, Server: 5555, Sequence: 1234}}}
+	_, err = primary.Diff(mariadb)
+	assert.ErrorContains(t, err, "cannot diff a position of flavor MySQL56 with a position of flavor MariaDB")
+	_, err = mariadb.Diff(primary)
+	assert.ErrorContains(t, err, "cannot diff MariaDB positions")
+	_, err = mariadb.Diff(mariadb)
+	assert.ErrorContains(t, err, "cannot diff MariaDB positions")
+
+	filePos := MustParsePosition(FilePosFlavorID, "binlog.000001:1234")
+	_, err = filePos.Diff(filePos)
+	assert.ErrorContains(t, err, "cannot diff file positions")
+	_, err = primary.Diff(filePos)
+	assert.ErrorContains(t, err, "cannot diff a position of flavor MySQL56 with a position of flavor FilePos")
+}
+
 func TestMustParsePosition(t *testing.T) {
 	flavor := "fake flavor"
 	gtidSetParsers[flavor] = func(s string) (GTIDSet, error) {
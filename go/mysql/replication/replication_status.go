@@ -19,6 +19,7 @@ package replication
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"vitess.io/vitess/go/vt/log"
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
@@ -56,6 +57,14 @@ type ReplicationStatus struct {
 	LastSQLError          string
 	ReplicationLagSeconds uint32
 	ReplicationLagUnknown bool
+	// HeartbeatLagSeconds is the lag computed from the most recent
+	// heartbeat written by the primary in the heartbeat table of the
+	// sidecar database. It is only set if HeartbeatLagKnown is true, as
+	// the table doesn't exist on the servers which Vitess doesn't manage.
+	// HeartbeatTime is the time at which the primary wrote this heartbeat.
+	HeartbeatLagSeconds   uint32
+	HeartbeatLagKnown     bool
+	HeartbeatTime         time.Time
 	SourceHost            string
 	SourcePort            int32
 	SourceUser            string
@@ -68,6 +77,16 @@ type ReplicationStatus struct {
 	SSLAllowed            bool
 }
 
+// LagSeconds returns the replication lag, preferably the one computed from
+// the heartbeats, or the one reported by the server otherwise. It returns
+// false if neither is known.
+func (s *ReplicationStatus) LagSeconds() (uint32, bool) {
+	if s.HeartbeatLagKnown {
+		return s.HeartbeatLagSeconds, true
+	}
+	return s.ReplicationLagSeconds, !s.ReplicationLagUnknown
+}
+
 // Running returns true if both the IO and SQL threads are running.
 func (s *ReplicationStatus) Running() bool {
 	return s.IOState == ReplicationStateRunning && s.SQLState == ReplicationStateRunning
@@ -102,6 +121,8 @@ func ReplicationStatusToProto(s ReplicationStatus) *replicationdatapb.Status {
 		SourceServerId:                         s.SourceServerID,
 		ReplicationLagSeconds:                  s.ReplicationLagSeconds,
 		ReplicationLagUnknown:                  s.ReplicationLagUnknown,
+		HeartbeatLagSeconds:                    s.HeartbeatLagSeconds,
+		HeartbeatLagKnown:                      s.HeartbeatLagKnown,
 		SqlDelay:                               s.SQLDelay,
 		RelayLogFilePosition:                   EncodePosition(s.RelayLogFilePosition),
 		SourceHost:                             s.SourceHost,
@@ -159,6 +180,8 @@ func ProtoToReplicationStatus(s *replicationdatapb.Status) ReplicationStatus {
 		SourceServerID:                         s.SourceServerId,
 		ReplicationLagSeconds:                  s.ReplicationLagSeconds,
 		ReplicationLagUnknown:                  s.ReplicationLagUnknown,
+		HeartbeatLagSeconds:                    s.HeartbeatLagSeconds,
+		HeartbeatLagKnown:                      s.HeartbeatLagKnown,
 		SQLDelay:                               s.SqlDelay,
 		SourceHost:                             s.SourceHost,
 		SourceUser:                             s.SourceUser,
@@ -204,7 +227,7 @@ func (s *ReplicationStatus) FindErrantGTIDs(otherReplicaStatuses []*ReplicationS
 	// Copy set for final diffSet so we don't mutate receiver.
 	diffSet := make(Mysql56GTIDSet, len(relayLogSet))
 	for sid, intervals := range relayLogSet {
-		if sid == s.SourceUUID {
+		if sid.SID == s.SourceUUID {
 			continue
 		}
 		diffSet[sid] = intervals
@@ -255,8 +278,17 @@ func ParseMysqlReplicationStatus(resultMap map[string]string, replicaTerminology
 	return status, nil
 }
 
+// ParseMariadbReplicationStatus parses the result of SHOW ALL SLAVES STATUS,
+// or of SHOW ALL REPLICAS STATUS, whose columns may use the replica
+// terminology, like Replica_IO_Running instead of Slave_IO_Running.
 func ParseMariadbReplicationStatus(resultMap map[string]string) (ReplicationStatus, error) {
-	status := ParseReplicationStatus(resultMap, false)
+	replicaTerminology := false
+	for _, field := range []string{"Replica_IO_Running", "Source_Server_Id"} {
+		if _, ok := resultMap[field]; ok {
+			replicaTerminology = true
+		}
+	}
+	status := ParseReplicationStatus(resultMap, replicaTerminology)
 
 	var err error
 	status.Position.GTIDSet, err = ParseMariadbGTIDSet(resultMap["Gtid_Slave_Pos"])
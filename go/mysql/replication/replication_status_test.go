@@ -57,6 +57,27 @@ func TestStatusSQLThreadNotRunning(t *testing.T) {
 	}
 }
 
+func TestStatusLagSeconds(t *testing.T) {
+	status := &ReplicationStatus{ReplicationLagSeconds: 10}
+	lag, ok := status.LagSeconds()
+	assert.True(t, ok)
+	assert.EqualValues(t, 10, lag)
+
+	status.HeartbeatLagSeconds, status.HeartbeatLagKnown = 3, true
+	lag, ok = status.LagSeconds()
+	assert.True(t, ok)
+	assert.EqualValues(t, 3, lag)
+
+	status = &ReplicationStatus{ReplicationLagUnknown: true}
+	_, ok = status.LagSeconds()
+	assert.False(t, ok)
+
+	status.HeartbeatLagKnown = true
+	lag, ok = status.LagSeconds()
+	assert.True(t, ok)
+	assert.EqualValues(t, 0, lag)
+}
+
 func TestFindErrantGTIDs(t *testing.T) {
 	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	sid2 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 16}
@@ -65,24 +86,24 @@ func TestFindErrantGTIDs(t *testing.T) {
 	sourceSID := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 19}
 
 	set1 := Mysql56GTIDSet{
-		sid1:      []interval{{20, 30}, {35, 39}, {40, 53}, {55, 75}},
-		sid2:      []interval{{1, 7}, {20, 50}, {60, 70}},
-		sid4:      []interval{{1, 30}},
-		sourceSID: []interval{{1, 7}, {20, 30}},
+		{SID: sid1}:      []interval{{20, 30}, {35, 39}, {40, 53}, {55, 75}},
+		{SID: sid2}:      []interval{{1, 7}, {20, 50}, {60, 70}},
+		{SID: sid4}:      []interval{{1, 30}},
+		{SID: sourceSID}: []interval{{1, 7}, {20, 30}},
 	}
 
 	set2 := Mysql56GTIDSet{
-		sid1:      []interval{{20, 30}, {35, 37}, {50, 60}},
-		sid2:      []interval{{3, 5}, {22, 25}, {32, 37}, {67, 70}},
-		sid3:      []interval{{1, 45}},
-		sourceSID: []interval{{2, 6}, {15, 40}},
+		{SID: sid1}:      []interval{{20, 30}, {35, 37}, {50, 60}},
+		{SID: sid2}:      []interval{{3, 5}, {22, 25}, {32, 37}, {67, 70}},
+		{SID: sid3}:      []interval{{1, 45}},
+		{SID: sourceSID}: []interval{{2, 6}, {15, 40}},
 	}
 
 	set3 := Mysql56GTIDSet{
-		sid1:      []interval{{20, 30}, {35, 38}, {50, 70}},
-		sid2:      []interval{{3, 5}, {22, 25}, {32, 37}, {67, 70}},
-		sid3:      []interval{{1, 45}},
-		sourceSID: []interval{{2, 6}, {15, 45}},
+		{SID: sid1}:      []interval{{20, 30}, {35, 38}, {50, 70}},
+		{SID: sid2}:      []interval{{3, 5}, {22, 25}, {32, 37}, {67, 70}},
+		{SID: sid3}:      []interval{{1, 45}},
+		{SID: sourceSID}: []interval{{2, 6}, {15, 45}},
 	}
 
 	testcases := []struct {
@@ -96,9 +117,9 @@ func TestFindErrantGTIDs(t *testing.T) {
 			{SourceUUID: sourceSID, RelayLogPosition: Position{GTIDSet: set3}},
 		},
 		want: Mysql56GTIDSet{
-			sid1: []interval{{39, 39}, {40, 49}, {71, 75}},
-			sid2: []interval{{1, 2}, {6, 7}, {20, 21}, {26, 31}, {38, 50}, {60, 66}},
-			sid4: []interval{{1, 30}},
+			{SID: sid1}: []interval{{39, 39}, {40, 49}, {71, 75}},
+			{SID: sid2}: []interval{{1, 2}, {6, 7}, {20, 21}, {26, 31}, {38, 50}, {60, 66}},
+			{SID: sid4}: []interval{{1, 30}},
 		},
 	}, {
 		mainRepStatus:    &ReplicationStatus{SourceUUID: sourceSID, RelayLogPosition: Position{GTIDSet: set1}},
@@ -125,7 +146,7 @@ func TestMysqlShouldGetPosition(t *testing.T) {
 
 	sid, _ := ParseSID("3e11fa47-71ca-11e1-9e33-c80aa9429562")
 	want := PrimaryStatus{
-		Position:     Position{GTIDSet: Mysql56GTIDSet{sid: []interval{{start: 1, end: 5}}}},
+		Position:     Position{GTIDSet: Mysql56GTIDSet{{SID: sid}: []interval{{start: 1, end: 5}}}},
 		FilePosition: Position{GTIDSet: FilePosGTID{File: "source-bin.000003", Pos: 1307}},
 	}
 	got, err := ParseMysqlPrimaryStatus(resultMap)
@@ -190,8 +211,8 @@ func TestMysqlShouldGetLegacyRelayLogPosition(t *testing.T) {
 
 	sid, _ := ParseSID("3e11fa47-71ca-11e1-9e33-c80aa9429562")
 	want := ReplicationStatus{
-		Position:         Position{GTIDSet: Mysql56GTIDSet{sid: []interval{{start: 1, end: 5}}}},
-		RelayLogPosition: Position{GTIDSet: Mysql56GTIDSet{sid: []interval{{start: 1, end: 9}}}},
+		Position:         Position{GTIDSet: Mysql56GTIDSet{{SID: sid}: []interval{{start: 1, end: 5}}}},
+		RelayLogPosition: Position{GTIDSet: Mysql56GTIDSet{{SID: sid}: []interval{{start: 1, end: 9}}}},
 	}
 	got, err := ParseMysqlReplicationStatus(resultMap, false)
 	require.NoError(t, err)
@@ -210,8 +231,8 @@ func TestMysqlShouldGetRelayLogPosition(t *testing.T) {
 
 	sid, _ := ParseSID("3e11fa47-71ca-11e1-9e33-c80aa9429562")
 	want := ReplicationStatus{
-		Position:         Position{GTIDSet: Mysql56GTIDSet{sid: []interval{{start: 1, end: 5}}}},
-		RelayLogPosition: Position{GTIDSet: Mysql56GTIDSet{sid: []interval{{start: 1, end: 9}}}},
+		Position:         Position{GTIDSet: Mysql56GTIDSet{{SID: sid}: []interval{{start: 1, end: 5}}}},
+		RelayLogPosition: Position{GTIDSet: Mysql56GTIDSet{{SID: sid}: []interval{{start: 1, end: 9}}}},
 	}
 	got, err := ParseMysqlReplicationStatus(resultMap, true)
 	require.NoError(t, err)
@@ -230,6 +251,31 @@ func TestMariadbRetrieveSourceServerId(t *testing.T) {
 	assert.Equal(t, got.SourceServerID, want.SourceServerID, fmt.Sprintf("got SourceServerID: %v; want SourceServerID: %v", got.SourceServerID, want.SourceServerID))
 }
 
+func TestMariadbReplicaTerminology(t *testing.T) {
+	resultMap := map[string]string{
+		"Source_Host":           "primary",
+		"Source_Port":           "3306",
+		"Replica_IO_Running":    "Yes",
+		"Replica_SQL_Running":   "No",
+		"Source_Server_Id":      "1",
+		"Seconds_Behind_Source": "2",
+		"Exec_Source_Log_Pos":   "1307",
+		"Relay_Source_Log_File": "master-bin.000002",
+		"Gtid_Slave_Pos":        "0-101-2320",
+	}
+
+	got, err := ParseMariadbReplicationStatus(resultMap)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", got.SourceHost)
+	assert.EqualValues(t, 3306, got.SourcePort)
+	assert.Equal(t, ReplicationStateRunning, got.IOState)
+	assert.Equal(t, ReplicationStateStopped, got.SQLState)
+	assert.EqualValues(t, 1, got.SourceServerID)
+	assert.EqualValues(t, 2, got.ReplicationLagSeconds)
+	assert.Equal(t, FilePosGTID{File: "master-bin.000002", Pos: 1307}, got.FilePosition.GTIDSet)
+	assert.Equal(t, "0-101-2320", got.Position.GTIDSet.String())
+}
+
 func TestMariadbRetrieveFileBasedPositions(t *testing.T) {
 	resultMap := map[string]string{
 		"Exec_Master_Log_Pos":   "1307",
@@ -52,6 +52,13 @@ const (
 	BinlogChecksumAlgUndef = 255
 )
 
+// Flags of the value_options of the rows in a PARTIAL_UPDATE_ROWS_EVENT.
+const (
+	// rowsValueOptionPartialJSON indicates that the JSON columns of the
+	// after image may hold partial JSON updates.
+	rowsValueOptionPartialJSON = 1
+)
+
 // These constants describe the event types.
 // See: http://dev.mysql.com/doc/internals/en/binlog-event-type.html
 const (
@@ -110,17 +117,34 @@ const (
 	//eViewChangeEvent         = 37
 	//eXAPrepareLogEvent       = 38
 
+	// Partial_update_rows_log_event when binlog_row_value_options=PARTIAL_JSON.
+	ePartialUpdateRowsEvent = 39
+
 	// Transaction_payload_event when binlog_transaction_compression=ON.
 	eTransactionPayloadEvent = 40
 
+	// Gtid_tagged_log_event, used instead of Gtid_log_event for tagged
+	// GTIDs since MySQL 8.4.
+	eGTIDTaggedEvent = 42
+
 	// MariaDB specific values. They start at 160.
 	//eMariaAnnotateRowsEvent = 160
 	// Unused
 	//eMariaBinlogCheckpointEvent = 161
 	eMariaGTIDEvent     = 162
 	eMariaGTIDListEvent = 163
-	// Unused
-	//eMariaStartEncryptionEvent  = 164
+	// Start_encryption_log_event, after which the events of a binlog file
+	// are encrypted when encrypt_binlog=ON.
+	eMariaStartEncryptionEvent = 164
+
+	// MariaDB compressed events, logged when log_bin_compress=ON.
+	eMariaQueryCompressedEvent        = 165
+	eMariaWriteRowsCompressedEventV1  = 166
+	eMariaUpdateRowsCompressedEventV1 = 167
+	eMariaDeleteRowsCompressedEventV1 = 168
+	eMariaWriteRowsCompressedEventV2  = 169
+	eMariaUpdateRowsCompressedEventV2 = 170
+	eMariaDeleteRowsCompressedEventV2 = 171
 )
 
 // These constants describe the type of status variables in q Query packet.
@@ -25,8 +25,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/pires/go-proxyproto"
-
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -147,6 +145,15 @@ func (UnimplementedHandler) ConnectionReady(*Conn)    {}
 func (UnimplementedHandler) ConnectionClosed(*Conn)   {}
 func (UnimplementedHandler) ComResetConnection(*Conn) {}
 
+// AuthenticationFailedHandler can be implemented by a Handler to be
+// told about the users failing to authenticate, on any of the protocols
+// of the Listener.
+type AuthenticationFailedHandler interface {
+	// AuthenticationFailed is called when the user of a connection
+	// fails to authenticate, before the connection is closed.
+	AuthenticationFailed(c *Conn, user string, err error)
+}
+
 // Listener is the MySQL server protocol listener.
 type Listener struct {
 	// Construction parameters, set by NewListener.
@@ -207,6 +214,33 @@ type Listener struct {
 	// RequireSecureTransport configures the server to reject connections from insecure clients
 	RequireSecureTransport bool
 
+	// DisableMultiStatements configures the server to not advertise
+	// CapabilityClientMultiStatements, so every COM_QUERY is executed
+	// as a single statement.
+	DisableMultiStatements bool
+
+	// EnableLocalInfile configures the server to advertise
+	// CapabilityClientLocalFiles, so clients can send files with
+	// LOAD DATA LOCAL INFILE.
+	EnableLocalInfile bool
+
+	// EnableSessionTrack configures the server to advertise
+	// CapabilityClientSessionTrack, so that clients receive session
+	// state changes, such as tracked system variables and GTIDs, in
+	// OK packets.
+	EnableSessionTrack bool
+
+	// EnableQueryAttributes configures the server to advertise
+	// CapabilityClientQueryAttributes, so clients can send query
+	// attributes along with their queries.
+	EnableQueryAttributes bool
+
+	// MaxCursorRows is the maximum number of rows of a read-only cursor
+	// that are buffered until the client fetches them with COM_STMT_FETCH.
+	// Executing a statement with a cursor fails if its result has more
+	// rows. The rows are not limited if it's <= 0.
+	MaxCursorRows int
+
 	// PreHandleFunc is called for each incoming connection, immediately after
 	// accepting a new connection. By default it's no-op. Useful for custom
 	// connection inspection or TLS termination. The returned connection is
@@ -217,6 +251,15 @@ type Listener struct {
 	// flushDelay is the delay after which buffered response will be flushed to the client.
 	flushDelay time.Duration
 
+	// xProtocol is true if the listener speaks the X Protocol instead of
+	// the classic protocol, see handleXProtocol.
+	xProtocol bool
+
+	// postgresProtocol is true if the listener speaks the PostgreSQL
+	// frontend/backend protocol instead of the classic protocol, see
+	// handlePostgresProtocol.
+	postgresProtocol bool
+
 	// charset is the default server side character set to use for the connection
 	charset collations.ID
 	// parser to use for this listener, configured with the correct version.
@@ -265,8 +308,7 @@ func NewListener(
 		return nil, err
 	}
 	if proxyProtocol {
-		proxyListener := &proxyproto.Listener{Listener: listener}
-		return NewFromListener(proxyListener, authServer, handler, connReadTimeout, connWriteTimeout, connBufferPooling, keepAlivePeriod, flushDelay)
+		listener = NewProxyProtocolListener(listener, nil)
 	}
 
 	return NewFromListener(listener, authServer, handler, connReadTimeout, connWriteTimeout, connBufferPooling, keepAlivePeriod, flushDelay)
@@ -286,6 +328,13 @@ type ListenerConfig struct {
 	ConnBufferPooling   bool
 	ConnKeepAlivePeriod time.Duration
 	FlushDelay          time.Duration
+	// XProtocol makes the listener speak the X Protocol, which is used
+	// by the X DevAPI connectors, instead of the classic protocol.
+	XProtocol bool
+	// PostgresProtocol makes the listener speak the PostgreSQL
+	// frontend/backend protocol, for the PostgreSQL clients, instead of
+	// the classic protocol.
+	PostgresProtocol bool
 }
 
 // NewListenerWithConfig creates new listener using provided config. There are
@@ -302,12 +351,19 @@ func NewListenerWithConfig(cfg ListenerConfig) (*Listener, error) {
 		l = listener
 	}
 
+	connectionID := uint32(1)
+	if cfg.XProtocol {
+		connectionID = xFirstConnectionID
+	} else if cfg.PostgresProtocol {
+		connectionID = pgFirstConnectionID
+	}
+
 	return &Listener{
 		authServer:          cfg.AuthServer,
 		handler:             cfg.Handler,
 		listener:            l,
 		ServerVersion:       cfg.Handler.Env().MySQLVersion(),
-		connectionID:        1,
+		connectionID:        connectionID,
 		connReadTimeout:     cfg.ConnReadTimeout,
 		connWriteTimeout:    cfg.ConnWriteTimeout,
 		connReadBufferSize:  cfg.ConnReadBufferSize,
@@ -316,6 +372,8 @@ func NewListenerWithConfig(cfg ListenerConfig) (*Listener, error) {
 		flushDelay:          cfg.FlushDelay,
 		truncateErrLen:      cfg.Handler.Env().TruncateErrLen(),
 		charset:             cfg.Handler.Env().CollationEnv().DefaultConnectionCharset(),
+		xProtocol:           cfg.XProtocol,
+		postgresProtocol:    cfg.PostgresProtocol,
 	}, nil
 }
 
@@ -353,11 +411,28 @@ func (l *Listener) Accept() {
 				}
 			}
 
+			if l.xProtocol {
+				l.handleXProtocol(conn, connectionID, acceptTime)
+				return
+			}
+			if l.postgresProtocol {
+				l.handlePostgresProtocol(conn, connectionID, acceptTime)
+				return
+			}
 			l.handle(conn, connectionID, acceptTime)
 		}()
 	}
 }
 
+// authenticationFailed tells the handler, if it implements
+// AuthenticationFailedHandler, that the user of a connection failed to
+// authenticate.
+func (l *Listener) authenticationFailed(c *Conn, user string, err error) {
+	if h, ok := l.handler.(AuthenticationFailedHandler); ok {
+		h.AuthenticationFailed(c, user, err)
+	}
+}
+
 // handle is called in a go routine for each client connection.
 // FIXME(alainjobart) handle per-connection logs in a way that makes sense.
 func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Time) {
@@ -391,7 +466,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	defer connCount.Add(-1)
 
 	// First build and send the server handshake packet.
-	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil)
+	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil, l.optionalCapabilities())
 	if err != nil {
 		if err != io.EOF {
 			log.Errorf("Cannot send HandshakeV10 packet to %s: %v", c, err)
@@ -505,6 +580,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, conn.RemoteAddr())
 	if err != nil {
 		log.Warningf("Error authenticating user %s using: %s", user, negotiatedAuthMethod.Name())
+		l.authenticationFailed(c, user, err)
 		c.writeErrorPacketFromError(err)
 		return
 	}
@@ -570,9 +646,29 @@ func (l *Listener) Shutdown() {
 	}
 }
 
+// optionalCapabilities returns the capabilities that are only
+// advertised, and used, if the listener is configured for them.
+func (l *Listener) optionalCapabilities() uint32 {
+	var capabilities uint32
+	if !l.DisableMultiStatements {
+		capabilities |= CapabilityClientMultiStatements
+	}
+	if l.EnableLocalInfile {
+		capabilities |= CapabilityClientLocalFiles
+	}
+	if l.EnableSessionTrack {
+		capabilities |= CapabilityClientSessionTrack
+	}
+	if l.EnableQueryAttributes {
+		capabilities |= CapabilityClientQueryAttributes
+	}
+	return capabilities
+}
+
 // writeHandshakeV10 writes the Initial Handshake Packet, server side.
+// optionalCapabilities are advertised on top of the ones we always support.
 // It returns the salt data.
-func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool) ([]byte, error) {
+func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool, optionalCapabilities uint32) ([]byte, error) {
 	capabilities := CapabilityClientLongPassword |
 		CapabilityClientFoundRows |
 		CapabilityClientLongFlag |
@@ -580,12 +676,12 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, ch
 		CapabilityClientProtocol41 |
 		CapabilityClientTransactions |
 		CapabilityClientSecureConnection |
-		CapabilityClientMultiStatements |
 		CapabilityClientMultiResults |
 		CapabilityClientPluginAuth |
 		CapabilityClientPluginAuthLenencClientData |
 		CapabilityClientDeprecateEOF |
-		CapabilityClientConnAttr
+		CapabilityClientConnAttr |
+		optionalCapabilities
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
@@ -709,10 +805,9 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows)
 	}
 
-	// set connection capability for executing multi statements
-	if clientFlags&CapabilityClientMultiStatements > 0 {
-		c.Capabilities |= CapabilityClientMultiStatements
-	}
+	// Set the optional capabilities, such as executing multi statements,
+	// if we advertised them and the client supports them.
+	c.Capabilities |= clientFlags & l.optionalCapabilities()
 
 	// Max packet size. Don't do anything with this now.
 	// See doc.go for more information.
@@ -806,8 +901,11 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 
 	// Decode connection attributes send by the client
 	if clientFlags&CapabilityClientConnAttr != 0 {
-		if _, _, err := parseConnAttrs(data, pos); err != nil {
+		attrs, _, err := parseConnAttrs(data, pos)
+		if err != nil {
 			log.Warningf("Decode connection attributes send by the client: %v", err)
+		} else {
+			c.ConnAttrs = attrs
 		}
 	}
 
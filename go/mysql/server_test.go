@@ -19,11 +19,13 @@ package mysql
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/exec"
 	"path"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -395,6 +397,58 @@ func TestConnectionUseMysqlNativePasswordWithSourceHost(t *testing.T) {
 	require.EqualError(t, err, "Access denied for user 'user1' (errno 1045) (sqlstate 28000)", "Should not be able to connect to server")
 }
 
+// authFailedHandler records the users failing to authenticate.
+type authFailedHandler struct {
+	*testHandler
+	mu    sync.Mutex
+	users []string
+}
+
+func (h *authFailedHandler) AuthenticationFailed(c *Conn, user string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.users = append(h.users, user)
+}
+
+func (h *authFailedHandler) failedUsers() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.users)
+}
+
+func TestConnectionAuthenticationFailed(t *testing.T) {
+	h := &authFailedHandler{testHandler: &testHandler{}}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, h, 0, 0, false, false, 0, 0)
+	require.NoError(t, err, "NewListener failed")
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "bad password",
+	}
+	_, err = Connect(context.Background(), params)
+	require.EqualError(t, err, "Access denied for user 'user1' (errno 1045) (sqlstate 28000)")
+	assert.Equal(t, []string{"user1"}, h.failedUsers())
+
+	params.Pass = "password1"
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err)
+	c.Close()
+	assert.Equal(t, []string{"user1"}, h.failedUsers())
+}
+
 func TestConnectionUnixSocket(t *testing.T) {
 	th := &testHandler{}
 
@@ -471,6 +525,46 @@ func TestClientFoundRows(t *testing.T) {
 	c.Close()
 }
 
+func TestClientQueryAttributes(t *testing.T) {
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	defer authServer.close()
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err, "NewListener failed")
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+		Flags: CapabilityClientQueryAttributes,
+	}
+
+	// The server doesn't support them: they are not used.
+	c, err := Connect(context.Background(), params)
+	require.NoError(t, err, "Connect failed")
+	assert.Zero(t, c.Capabilities&CapabilityClientQueryAttributes)
+	assert.Zero(t, th.LastConn().Capabilities&CapabilityClientQueryAttributes)
+	c.Close()
+
+	// The server supports them: they are negotiated.
+	l.EnableQueryAttributes = true
+	c, err = Connect(context.Background(), params)
+	require.NoError(t, err, "Connect failed")
+	assert.NotZero(t, c.Capabilities&CapabilityClientQueryAttributes)
+	assert.NotZero(t, th.LastConn().Capabilities&CapabilityClientQueryAttributes)
+	c.Close()
+}
+
 func TestConnCounts(t *testing.T) {
 	th := &testHandler{}
 
@@ -1538,3 +1632,87 @@ func TestTcpKeepAlive(t *testing.T) {
 	err = setTcpConnProperties(th.lastConn.conn.(*net.TCPConn), 0)
 	require.ErrorContains(t, err, "unable to enable keepalive on tcp connection")
 }
+
+func TestExecuteStreamFetchWithCallback(t *testing.T) {
+	result := &sqltypes.Result{Fields: selectRowsResult.Fields}
+	for i := 0; i < 5; i++ {
+		result.Rows = append(result.Rows, selectRowsResult.Rows[i%2])
+	}
+	th := &testHandler{result: result}
+
+	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer l.Close()
+	go l.Accept()
+
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host: host,
+		Port: port,
+	}
+	ctx := context.Background()
+
+	t.Run("batches", func(t *testing.T) {
+		c, err := Connect(ctx, params)
+		require.NoError(t, err)
+		defer c.Close()
+
+		var batches []*sqltypes.Result
+		err = c.ExecuteStreamFetchWithCallback(ctx, "select rows", 2, func(qr *sqltypes.Result) error {
+			batches = append(batches, qr)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, batches, 4)
+		assert.True(t, sqltypes.FieldsEqual(result.Fields, batches[0].Fields))
+		assert.Empty(t, batches[0].Rows)
+		assert.Equal(t, result.Rows[0:2], batches[1].Rows)
+		assert.Equal(t, result.Rows[2:4], batches[2].Rows)
+		assert.Equal(t, result.Rows[4:5], batches[3].Rows)
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		c, err := Connect(ctx, params)
+		require.NoError(t, err)
+		defer c.Close()
+
+		err = c.ExecuteStreamFetchWithCallback(ctx, "select rows", 2, func(qr *sqltypes.Result) error {
+			if len(qr.Rows) > 0 {
+				return errors.New("callback failed")
+			}
+			return nil
+		})
+		require.ErrorContains(t, err, "callback failed")
+
+		// The rest of the result was drained, so the connection can be reused.
+		qr, err := c.ExecuteFetch("select rows", 10, false)
+		require.NoError(t, err)
+		assert.Len(t, qr.Rows, 5)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		c, err := Connect(ctx, params)
+		require.NoError(t, err)
+		defer c.Close()
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		err = c.ExecuteStreamFetchWithCallback(cancelCtx, "select rows", 2, func(qr *sqltypes.Result) error {
+			cancel()
+			return nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.True(t, c.IsClosed())
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		c, err := Connect(ctx, params)
+		require.NoError(t, err)
+		defer c.Close()
+
+		err = c.ExecuteStreamFetchWithCallback(ctx, "select rows", 0, func(qr *sqltypes.Result) error {
+			return nil
+		})
+		require.ErrorContains(t, err, "invalid batch size 0")
+	})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	// SQLModeANSIQuotes makes double quotes delimit identifiers instead
+	// of strings.
+	SQLModeANSIQuotes = "ANSI_QUOTES"
+	// SQLModeNoBackslashEscapes makes the backslash an ordinary character
+	// in string literals, instead of an escape character.
+	SQLModeNoBackslashEscapes = "NO_BACKSLASH_ESCAPES"
+	// SQLModeANSI is a combination mode which implies ANSI_QUOTES.
+	SQLModeANSI = "ANSI"
+)
+
+// SQLMode is the value of the sql_mode system variable of a session,
+// a comma separated list of modes, as returned by SELECT @@sql_mode.
+type SQLMode string
+
+// Has returns true if mode is one of the modes of the sql_mode.
+func (m SQLMode) Has(mode string) bool {
+	for _, name := range strings.Split(string(m), ",") {
+		if strings.EqualFold(strings.TrimSpace(name), mode) {
+			return true
+		}
+	}
+	return false
+}
+
+// ANSIQuotes returns true if double quotes delimit identifiers.
+func (m SQLMode) ANSIQuotes() bool {
+	return m.Has(SQLModeANSIQuotes) || m.Has(SQLModeANSI)
+}
+
+// NoBackslashEscapes returns true if backslashes are not escape
+// characters in string literals.
+func (m SQLMode) NoBackslashEscapes() bool {
+	return m.Has(SQLModeNoBackslashEscapes)
+}
+
+// WithDefaultQuoting returns the sql_mode without the modes that change
+// how strings and identifiers are quoted, so that the queries built with
+// the encoding of sqltypes and sqlparser are parsed as intended.
+func (m SQLMode) WithDefaultQuoting() SQLMode {
+	var modes []string
+	for _, name := range strings.Split(string(m), ",") {
+		name = strings.TrimSpace(name)
+		switch strings.ToUpper(name) {
+		case "", SQLModeANSIQuotes, SQLModeNoBackslashEscapes, SQLModeANSI:
+			continue
+		}
+		modes = append(modes, name)
+	}
+	return SQLMode(strings.Join(modes, ","))
+}
+
+// EncodeString returns s as a quoted string literal that is parsed back
+// to s under the sql_mode. Strings are always quoted with single quotes,
+// which delimit strings whether ANSI_QUOTES is set or not.
+func (m SQLMode) EncodeString(s string) string {
+	if m.NoBackslashEscapes() {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return sqltypes.EncodeStringSQL(s)
+}
+
+// SQLMode returns the sql_mode of the session, as last read by
+// ReadSQLMode or reported in the session state information of an OK
+// packet. It is empty until then, which is handled like the default
+// sql_mode of the server by the query builders of the flavors.
+// Client side only.
+func (c *Conn) SQLMode() SQLMode {
+	return c.sqlMode
+}
+
+// ReadSQLMode reads the sql_mode of the session from the server, so that
+// the queries built by the flavor, like CHANGE REPLICATION SOURCE TO, are
+// valid under it.
+// Client side only.
+func (c *Conn) ReadSQLMode() error {
+	qr, err := c.ExecuteFetch("SELECT @@session.sql_mode", 1, false)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+		return vterrors.Errorf(vtrpc.Code_INTERNAL, "unexpected result for SELECT @@session.sql_mode: %v", qr.Rows)
+	}
+	c.sqlMode = SQLMode(qr.Rows[0][0].ToString())
+	return nil
+}
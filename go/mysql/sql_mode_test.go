@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLMode(t *testing.T) {
+	testcases := []struct {
+		mode               SQLMode
+		ansiQuotes         bool
+		noBackslashEscapes bool
+		defaultQuoting     SQLMode
+		encoded            string
+	}{{
+		mode:           "",
+		defaultQuoting: "",
+		encoded:        `'it\'s a \\ test'`,
+	}, {
+		mode:           "STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION",
+		defaultQuoting: "STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION",
+		encoded:        `'it\'s a \\ test'`,
+	}, {
+		mode:           "STRICT_TRANS_TABLES,ANSI_QUOTES",
+		ansiQuotes:     true,
+		defaultQuoting: "STRICT_TRANS_TABLES",
+		encoded:        `'it\'s a \\ test'`,
+	}, {
+		mode:               "no_backslash_escapes,STRICT_TRANS_TABLES",
+		noBackslashEscapes: true,
+		defaultQuoting:     "STRICT_TRANS_TABLES",
+		encoded:            `'it''s a \ test'`,
+	}, {
+		mode:           "REAL_AS_FLOAT,PIPES_AS_CONCAT,ANSI_QUOTES,IGNORE_SPACE,ONLY_FULL_GROUP_BY,ANSI",
+		ansiQuotes:     true,
+		defaultQuoting: "REAL_AS_FLOAT,PIPES_AS_CONCAT,IGNORE_SPACE,ONLY_FULL_GROUP_BY",
+		encoded:        `'it\'s a \\ test'`,
+	}}
+	for _, tc := range testcases {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			assert.Equal(t, tc.ansiQuotes, tc.mode.ANSIQuotes())
+			assert.Equal(t, tc.noBackslashEscapes, tc.mode.NoBackslashEscapes())
+			assert.Equal(t, tc.defaultQuoting, tc.mode.WithDefaultQuoting())
+			assert.Equal(t, tc.encoded, tc.mode.EncodeString(`it's a \ test`))
+		})
+	}
+}
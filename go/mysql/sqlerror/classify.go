@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlerror
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// ErrorClass tells whether, and how, a statement that failed with a
+// given error can be retried.
+type ErrorClass int8
+
+const (
+	// ErrorClassUnknown is used for errors that can't be classified.
+	ErrorClassUnknown ErrorClass = iota
+
+	// ErrorClassTransient is used for errors caused by a temporary
+	// condition of the server or of the connection to it. The
+	// statement may succeed if retried later, possibly on a new
+	// connection.
+	ErrorClassTransient
+
+	// ErrorClassFailover is used for errors caused by a failover, such
+	// as a write to a primary that was demoted and made read-only. The
+	// statement can be retried once the new primary is serving.
+	ErrorClassFailover
+
+	// ErrorClassConflict is used for errors caused by concurrent
+	// transactions, such as deadlocks. The transaction must be rolled
+	// back, and can then be retried from the start.
+	ErrorClassConflict
+
+	// ErrorClassSchemaChanged is used for errors caused by a schema
+	// change that happened while the statement was prepared or running.
+	// The statement can be retried once the schema is reloaded.
+	ErrorClassSchemaChanged
+
+	// ErrorClassFatal is used for errors that will happen again if the
+	// statement is retried, such as syntax errors or duplicate keys.
+	ErrorClassFatal
+)
+
+// codeClass is the class of an error code, and whether IsEphemeralError
+// reports it.
+type codeClass struct {
+	class     ErrorClass
+	ephemeral bool
+}
+
+// errorClasses maps the error codes to their class. Codes that are not
+// listed here are classified by their SQLSTATE, and are not ephemeral.
+var errorClasses = map[ErrorCode]codeClass{
+	// in case-insensitive alphabetical order
+	CRConnectionError:        {ErrorClassTransient, true},
+	CRConnHostError:          {ErrorClassTransient, true},
+	CRMalformedPacket:        {ErrorClassTransient, true},
+	CRNamedPipeStateError:    {ErrorClassTransient, true},
+	CRServerGone:             {ErrorClassTransient, true},
+	CRServerHandshakeErr:     {ErrorClassTransient, true},
+	CRServerLost:             {ErrorClassTransient, true},
+	CRSSLConnectionError:     {ErrorClassTransient, true},
+	CRUnknownError:           {ErrorClassTransient, true},
+	CRUnknownHost:            {ErrorClassTransient, true},
+	ERCantCreateThread:       {ErrorClassTransient, true},
+	ERConCount:               {ErrorClassTransient, false},
+	ERConnectionKilled:       {ErrorClassTransient, false},
+	ERDiskFull:               {ErrorClassTransient, true},
+	ERForcingClose:           {ErrorClassTransient, true},
+	ERGotSignal:              {ErrorClassTransient, true},
+	ERHostIsBlocked:          {ErrorClassTransient, true},
+	ERInnodbReadOnly:         {ErrorClassTransient, true},
+	ERInternalError:          {ErrorClassTransient, true},
+	ERLockTableFull:          {ErrorClassTransient, true},
+	EROutOfMemory:            {ErrorClassTransient, true},
+	EROutOfResources:         {ErrorClassTransient, true},
+	EROutOfSortMemory:        {ErrorClassTransient, true},
+	ERQueryInterrupted:       {ErrorClassTransient, true},
+	ERQueryTimeout:           {ErrorClassTransient, true},
+	ERServerIsntAvailable:    {ErrorClassTransient, true},
+	ERServerShutdown:         {ErrorClassTransient, true},
+	ERTooManyUserConnections: {ErrorClassTransient, true},
+	ERUnknownError:           {ErrorClassTransient, true},
+	ERUserLimitReached:       {ErrorClassTransient, true},
+
+	EROptionPreventsStatement: {ErrorClassFailover, false},
+
+	ERLockDeadlock:    {ErrorClassConflict, true},
+	ERLockNowait:      {ErrorClassConflict, false},
+	ERLockWaitTimeout: {ErrorClassConflict, true},
+
+	ERNeedReprepare:   {ErrorClassSchemaChanged, false},
+	ERTableDefChanged: {ErrorClassSchemaChanged, false},
+
+	ERAccessDeniedError:           {ErrorClassFatal, false},
+	ERBadFieldError:               {ErrorClassFatal, false},
+	ERBadNullError:                {ErrorClassFatal, false},
+	ERDataOutOfRange:              {ErrorClassFatal, false},
+	ERDataTooLong:                 {ErrorClassFatal, false},
+	ERDBAccessDenied:              {ErrorClassFatal, false},
+	ERDupEntry:                    {ErrorClassFatal, false},
+	ERDupUnique:                   {ErrorClassFatal, false},
+	ERNoReferencedRow:             {ErrorClassFatal, false},
+	ErNoReferencedRow2:            {ErrorClassFatal, false},
+	ERNoSuchTable:                 {ErrorClassFatal, false},
+	ERNotSupportedYet:             {ErrorClassFatal, false},
+	ERParseError:                  {ErrorClassFatal, false},
+	ERRowIsReferenced:             {ErrorClassFatal, false},
+	ERRowIsReferenced2:            {ErrorClassFatal, false},
+	ERSpecifiedAccessDenied:       {ErrorClassFatal, false},
+	ERSyntaxError:                 {ErrorClassFatal, false},
+	ERTruncatedWrongValueForField: {ErrorClassFatal, false},
+	ERWrongValueCountOnRow:        {ErrorClassFatal, false},
+}
+
+// sqlStateClasses maps the classes of SQLSTATE values (their first two
+// characters) to an error class.
+var sqlStateClasses = map[string]ErrorClass{
+	"08": ErrorClassTransient, // connection exception
+	"0A": ErrorClassFatal,     // feature not supported
+	"21": ErrorClassFatal,     // cardinality violation
+	"22": ErrorClassFatal,     // data exception
+	"23": ErrorClassFatal,     // integrity constraint violation
+	"28": ErrorClassFatal,     // invalid authorization specification
+	"40": ErrorClassConflict,  // transaction rollback
+	"42": ErrorClassFatal,     // syntax error or access rule violation
+}
+
+// String returns the name of the class.
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassTransient:
+		return "transient"
+	case ErrorClassFailover:
+		return "failover"
+	case ErrorClassConflict:
+		return "conflict"
+	case ErrorClassSchemaChanged:
+		return "schema-changed"
+	case ErrorClassFatal:
+		return "fatal"
+	}
+	return "unknown"
+}
+
+// Retryable returns true if a statement that failed with an error of
+// this class can succeed when retried.
+func (c ErrorClass) Retryable() bool {
+	switch c {
+	case ErrorClassTransient, ErrorClassFailover, ErrorClassConflict, ErrorClassSchemaChanged:
+		return true
+	}
+	return false
+}
+
+// ClassifyError returns the class of the error. MySQL errors are
+// classified by their error code, or by their SQLSTATE if the code is
+// not known. Errors coming from other layers are converted with
+// NewSQLErrorFromError first, and the ones that carry neither a MySQL
+// error nor a Vitess error code are unknown.
+func ClassifyError(err error) ErrorClass {
+	sqlErr, ok := asSQLError(err)
+	if !ok {
+		return ErrorClassUnknown
+	}
+	if cc, ok := errorClasses[sqlErr.Num]; ok {
+		return cc.class
+	}
+	if len(sqlErr.State) >= 2 {
+		return sqlStateClasses[strings.ToUpper(sqlErr.State[:2])]
+	}
+	return ErrorClassUnknown
+}
+
+// asSQLError returns the MySQL error of err, converting the errors coming
+// from other layers. ok is false if err is nil, or if it has neither a
+// MySQL error number in its message nor a Vitess error code, since such an
+// error would otherwise be converted to ERUnknownError.
+func asSQLError(err error) (sqlErr *SQLError, ok bool) {
+	if err == nil {
+		return nil, false
+	}
+	if sqlErr, ok := err.(*SQLError); ok {
+		return sqlErr, true
+	}
+	if vterrors.Code(err) == vtrpcpb.Code_UNKNOWN && !errExtract.MatchString(err.Error()) {
+		return nil, false
+	}
+	sqlErr, ok = NewSQLErrorFromError(err).(*SQLError)
+	return sqlErr, ok
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestClassifyError(t *testing.T) {
+	testcases := []struct {
+		err   error
+		class ErrorClass
+	}{{
+		err:   nil,
+		class: ErrorClassUnknown,
+	}, {
+		err:   NewSQLError(CRServerLost, SSUnknownSQLState, "lost connection"),
+		class: ErrorClassTransient,
+	}, {
+		err:   NewSQLError(EROptionPreventsStatement, SSUnknownSQLState, "read-only"),
+		class: ErrorClassFailover,
+	}, {
+		err:   NewSQLError(ERLockDeadlock, SSLockDeadlock, "deadlock"),
+		class: ErrorClassConflict,
+	}, {
+		err:   NewSQLError(ERTableDefChanged, SSUnknownSQLState, "table definition has changed"),
+		class: ErrorClassSchemaChanged,
+	}, {
+		err:   NewSQLError(ERDupEntry, SSConstraintViolation, "duplicate entry"),
+		class: ErrorClassFatal,
+	}, {
+		// Unknown codes are classified by their SQLSTATE.
+		err:   NewSQLError(ErrorCode(3101), "40000", "rollback"),
+		class: ErrorClassConflict,
+	}, {
+		err:   NewSQLError(ErrorCode(1064), "42000", "syntax"),
+		class: ErrorClassFatal,
+	}, {
+		err:   NewSQLError(ERWrongValue, SSUnknownSQLState, "wrong value"),
+		class: ErrorClassUnknown,
+	}, {
+		// Errors from other layers are parsed.
+		err:   fmt.Errorf("target: ks.0.primary: Lock wait timeout exceeded (errno 1205) (sqlstate HY000)"),
+		class: ErrorClassConflict,
+	}, {
+		err:   vterrors.Errorf(vtrpcpb.Code_DEADLINE_EXCEEDED, "deadline exceeded"),
+		class: ErrorClassTransient,
+	}, {
+		// Errors that aren't MySQL errors are not retried.
+		err:   errors.New("something went wrong"),
+		class: ErrorClassUnknown,
+	}, {
+		err:   vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "something went wrong"),
+		class: ErrorClassUnknown,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v", tc.err), func(t *testing.T) {
+			class := ClassifyError(tc.err)
+			assert.Equal(t, tc.class, class, "got %v, want %v", class, tc.class)
+		})
+	}
+}
+
+func TestErrorClassRetryable(t *testing.T) {
+	assert.False(t, ErrorClassUnknown.Retryable())
+	assert.True(t, ErrorClassTransient.Retryable())
+	assert.True(t, ErrorClassFailover.Retryable())
+	assert.True(t, ErrorClassConflict.Retryable())
+	assert.True(t, ErrorClassSchemaChanged.Retryable())
+	assert.False(t, ErrorClassFatal.Retryable())
+	assert.Equal(t, "schema-changed", ErrorClassSchemaChanged.String())
+}
+
+func TestIsEphemeralError(t *testing.T) {
+	assert.True(t, IsEphemeralError(NewSQLError(CRServerGone, SSUnknownSQLState, "gone")))
+	assert.True(t, IsEphemeralError(NewSQLError(ERLockWaitTimeout, SSUnknownSQLState, "lock wait timeout")))
+	assert.False(t, IsEphemeralError(NewSQLError(ERDupEntry, SSConstraintViolation, "duplicate entry")))
+	// Only the listed codes are ephemeral, whatever their class.
+	assert.False(t, IsEphemeralError(NewSQLError(EROptionPreventsStatement, SSUnknownSQLState, "read-only")))
+	assert.False(t, IsEphemeralError(NewSQLError(ERLockNowait, SSUnknownSQLState, "nowait")))
+	assert.False(t, IsEphemeralError(NewSQLError(ErrorCode(3101), "40000", "rollback")))
+	assert.False(t, IsEphemeralError(NewSQLError(ErrorCode(2047), "08S01", "wrong protocol")))
+	assert.True(t, IsEphemeralError(fmt.Errorf("target: ks.0.primary: Lock wait timeout exceeded (errno 1205) (sqlstate HY000)")))
+	assert.False(t, IsEphemeralError(errors.New("not a mysql error")))
+	assert.False(t, IsEphemeralError(nil))
+
+	// The ephemeral codes are the ones IsEphemeralError always reported.
+	var ephemeral []ErrorCode
+	for code, cc := range errorClasses {
+		if cc.ephemeral {
+			ephemeral = append(ephemeral, code)
+		}
+	}
+	assert.ElementsMatch(t, []ErrorCode{
+		CRConnectionError, CRConnHostError, CRMalformedPacket, CRNamedPipeStateError, CRServerHandshakeErr,
+		CRServerGone, CRServerLost, CRSSLConnectionError, CRUnknownError, CRUnknownHost, ERCantCreateThread,
+		ERDiskFull, ERForcingClose, ERGotSignal, ERHostIsBlocked, ERLockTableFull, ERInnodbReadOnly,
+		ERInternalError, ERLockDeadlock, ERLockWaitTimeout, ERQueryTimeout, EROutOfMemory, EROutOfResources,
+		EROutOfSortMemory, ERQueryInterrupted, ERServerIsntAvailable, ERServerShutdown, ERTooManyUserConnections,
+		ERUnknownError, ERUserLimitReached,
+	}, ephemeral)
+}
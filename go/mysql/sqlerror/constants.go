@@ -66,7 +66,8 @@ const (
 	ERLockWaitTimeout = ErrorCode(1205)
 
 	// unavailable
-	ERServerShutdown = ErrorCode(1053)
+	ERServerShutdown   = ErrorCode(1053)
+	ERConnectionKilled = ErrorCode(1927)
 
 	// not found
 	ERDbDropExists          = ErrorCode(1008)
@@ -120,6 +121,7 @@ const (
 	ERSPDoesNotExist                = ErrorCode(1305)
 	ERNoDefaultForField             = ErrorCode(1364)
 	ErSPNotVarArg                   = ErrorCode(1414)
+	ERStmtHasNoOpenCursor           = ErrorCode(1421)
 	ERRowIsReferenced2              = ErrorCode(1451)
 	ErNoReferencedRow2              = ErrorCode(1452)
 	ERDupIndex                      = ErrorCode(1831)
@@ -240,9 +242,11 @@ const (
 	ERIllegalValueForType          = ErrorCode(1367)
 	ERDataTooLong                  = ErrorCode(1406)
 	ErrWrongValueForType           = ErrorCode(1411)
+	ERTableDefChanged              = ErrorCode(1412)
 	ERNoSuchUser                   = ErrorCode(1449)
 	ERForbidSchemaChange           = ErrorCode(1450)
 	ERWrongValue                   = ErrorCode(1525)
+	ERNeedReprepare                = ErrorCode(1615)
 	ERDataOutOfRange               = ErrorCode(1690)
 	ERInvalidJSONText              = ErrorCode(3140)
 	ERInvalidJSONTextInParams      = ErrorCode(3141)
@@ -278,6 +282,10 @@ const (
 
 	ERWrongParametersToNativeFct = ErrorCode(1583)
 
+	ERCTEMaxRecursionDepth = ErrorCode(3636)
+
+	ERNonDefaultValueForGeneratedColumn = ErrorCode(3105)
+
 	// max execution time exceeded
 	ERQueryTimeout = ErrorCode(3024)
 
@@ -370,49 +378,13 @@ func IsConnLostDuringQuery(err error) bool {
 }
 
 // IsEphemeralError returns true if the error is ephemeral and the caller should
-// retry if possible. Note: non-SQL errors are always treated as ephemeral.
+// retry if possible. Unlike ClassifyError, it only considers the error codes
+// marked as ephemeral in errorClasses. Errors coming from other layers are
+// converted like in ClassifyError, and the ones that aren't MySQL errors are
+// not ephemeral.
 func IsEphemeralError(err error) bool {
-	if sqlErr, ok := err.(*SQLError); ok {
-		en := sqlErr.Number()
-		switch en {
-		case
-			CRConnectionError,
-			CRConnHostError,
-			CRMalformedPacket,
-			CRNamedPipeStateError,
-			CRServerHandshakeErr,
-			CRServerGone,
-			CRServerLost,
-			CRSSLConnectionError,
-			CRUnknownError,
-			CRUnknownHost,
-			ERCantCreateThread,
-			ERDiskFull,
-			ERForcingClose,
-			ERGotSignal,
-			ERHostIsBlocked,
-			ERLockTableFull,
-			ERInnodbReadOnly,
-			ERInternalError,
-			ERLockDeadlock,
-			ERLockWaitTimeout,
-			ERQueryTimeout,
-			EROutOfMemory,
-			EROutOfResources,
-			EROutOfSortMemory,
-			ERQueryInterrupted,
-			ERServerIsntAvailable,
-			ERServerShutdown,
-			ERTooManyUserConnections,
-			ERUnknownError,
-			ERUserLimitReached:
-			return true
-		default:
-			return false
-		}
-	}
-	// If it's not an sqlError then we assume it's ephemeral
-	return true
+	sqlErr, ok := asSQLError(err)
+	return ok && errorClasses[sqlErr.Num].ephemeral
 }
 
 // IsTooManyConnectionsErr returns true if the error is due to too many connections.
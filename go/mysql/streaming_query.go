@@ -17,6 +17,8 @@ limitations under the License.
 package mysql
 
 import (
+	"context"
+
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -151,3 +153,72 @@ func (c *Conn) CloseResult() {
 		}
 	}
 }
+
+// ExecuteStreamFetchWithCallback executes a streaming query, and calls
+// callback first with the fields of the result, then with its rows in
+// batches of at most batchSize rows. The next batch is only read once
+// callback returns, so a slow callback makes the server wait through the
+// flow control of the connection, instead of the whole result being
+// buffered in memory as with ExecuteFetch.
+//
+// If callback returns an error, the rest of the result is drained and the
+// error is returned. If ctx is done before the end of the result, the
+// connection is closed, as draining a large result could take as long as
+// reading it, and the error of ctx is returned.
+func (c *Conn) ExecuteStreamFetchWithCallback(ctx context.Context, query string, batchSize int, callback func(*sqltypes.Result) error) (err error) {
+	if batchSize <= 0 {
+		return sqlerror.NewSQLError(sqlerror.CRUnknownError, sqlerror.SSUnknownSQLState, "invalid batch size %d", batchSize)
+	}
+
+	// Closing the connection interrupts a blocked read.
+	stop := context.AfterFunc(ctx, c.Close)
+	defer func() {
+		stop()
+		if ctxErr := ctx.Err(); ctxErr != nil && err != nil {
+			err = ctxErr
+		}
+	}()
+
+	if err := c.ExecuteStreamFetch(query); err != nil {
+		return err
+	}
+	defer func() {
+		if ctx.Err() != nil {
+			c.Close()
+		}
+		c.CloseResult()
+	}()
+
+	fields, err := c.Fields()
+	if err != nil {
+		return err
+	}
+	if err := callback(&sqltypes.Result{Fields: fields}); err != nil {
+		return err
+	}
+
+	qr := &sqltypes.Result{Rows: make([][]sqltypes.Value, 0, batchSize)}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		row, err := c.FetchNext(nil)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			break
+		}
+		qr.Rows = append(qr.Rows, row)
+		if len(qr.Rows) == batchSize {
+			if err := callback(qr); err != nil {
+				return err
+			}
+			qr = &sqltypes.Result{Rows: make([][]sqltypes.Value, 0, batchSize)}
+		}
+	}
+	if len(qr.Rows) > 0 {
+		return callback(qr)
+	}
+	return nil
+}
@@ -0,0 +1,814 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/tb"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+/*
+References:
+
+* The X Protocol, as used by the X DevAPI connectors:
+https://dev.mysql.com/doc/dev/mysql-server/latest/page_mysqlx_protocol.html
+
+Each message is framed as:
+
+	# bytes   field
+	4         length of the type and the payload, little endian
+	1         message type
+	<var>     payload, an encoded protocol buffer
+*/
+
+// Mysqlx.ClientMessages.Type
+const (
+	xClientConCapabilitiesGet = 1
+	xClientConCapabilitiesSet = 2
+	xClientConClose           = 3
+	xClientSessAuthStart      = 4
+	xClientSessAuthContinue   = 5
+	xClientSessReset          = 6
+	xClientSessClose          = 7
+	xClientSQLStmtExecute     = 12
+	xClientCrudFind           = 17
+	xClientCrudInsert         = 18
+	xClientCrudUpdate         = 19
+	xClientCrudDelete         = 20
+	xClientExpectOpen         = 24
+	xClientExpectClose        = 25
+)
+
+// Mysqlx.ServerMessages.Type
+const (
+	xServerOk               = 0
+	xServerError            = 1
+	xServerConCapabilities  = 2
+	xServerSessAuthContinue = 3
+	xServerSessAuthOk       = 4
+	xServerNotice           = 11
+	xServerColumnMetaData   = 12
+	xServerRow              = 13
+	xServerFetchDone        = 14
+	xServerSQLStmtExecuteOk = 17
+)
+
+// The capabilities and their values, the authentication mechanisms and
+// the namespaces of Mysqlx.Sql.StmtExecute.
+const (
+	xCapabilityTLS            = "tls"
+	xCapabilityAuthMechanisms = "authentication.mechanisms"
+	xCapabilityDocFormats     = "doc.formats"
+	xCapabilityNodeType       = "node_type"
+	xCapabilityPwdExpireOk    = "client.pwd_expire_ok"
+	xCapabilityInteractive    = "client.interactive"
+	xCapabilityConnectAttrs   = "session_connect_attrs"
+
+	xAuthMechanismMySQL41 = "MYSQL41"
+	xAuthMechanismPlain   = "PLAIN"
+
+	xNamespaceSQL     = "sql"
+	xNamespaceMysqlx  = "mysqlx"
+	xNamespaceXPlugin = "xplugin"
+)
+
+const (
+	// xMaxMessageSize is the largest message we accept, like the default
+	// mysqlx_max_allowed_packet.
+	xMaxMessageSize = 64 * 1024 * 1024
+	// xFirstConnectionID is the first connection ID of an X Protocol
+	// listener, so that its connections don't share the IDs of the
+	// classic protocol listener, which counts from 1.
+	xFirstConnectionID = 1 << 31
+	// xResultFlushThreshold is the number of buffered bytes of a result
+	// after which they are flushed to the client.
+	xResultFlushThreshold = 16 * 1024
+)
+
+// The error codes of the X Plugin, for the errors that have no
+// equivalent in the classic protocol.
+const (
+	xErrBadMessage              = sqlerror.ErrorCode(5000)
+	xErrCapabilitiesPrepare     = sqlerror.ErrorCode(5001)
+	xErrCapabilityNotFound      = sqlerror.ErrorCode(5002)
+	xErrInvalidArgument         = sqlerror.ErrorCode(5012)
+	xErrUnknownCommand          = sqlerror.ErrorCode(5157)
+	xErrBadOperator             = sqlerror.ErrorCode(5150)
+	xErrBadNumArgs              = sqlerror.ErrorCode(5151)
+	xErrInvalidNamespace        = sqlerror.ErrorCode(5162)
+	xErrAuthMechanismNotSupport = sqlerror.ErrorCode(1251)
+)
+
+var (
+	// xDocumentIDPrefix, xDocumentIDStart and xDocumentIDSeq make the _id
+	// of the documents inserted without one, like the X Plugin does with
+	// mysqlx_document_id_unique_prefix, the start time of the server and
+	// a sequence number.
+	xDocumentIDPrefix = uint16(rand.Uint32())
+	xDocumentIDStart  = uint32(time.Now().Unix())
+	xDocumentIDSeq    atomic.Uint64
+)
+
+// xNewDocumentID returns a new _id for a document.
+func xNewDocumentID() string {
+	return fmt.Sprintf("%04x%08x%016x", xDocumentIDPrefix, xDocumentIDStart, xDocumentIDSeq.Add(1))
+}
+
+// xConn is the state of a connection using the X Protocol.
+type xConn struct {
+	c *Conn
+	l *Listener
+	w *bufio.Writer
+}
+
+// handleXProtocol is called in a go routine for each client connection
+// of a listener that speaks the X Protocol. It authenticates the client,
+// and then executes its messages with the same handler as the classic
+// protocol, by translating them to SQL queries.
+func (l *Listener) handleXProtocol(conn net.Conn, connectionID uint32, acceptTime time.Time) {
+	if l.connReadTimeout != 0 || l.connWriteTimeout != 0 {
+		conn = netutil.NewConnWithTimeouts(conn, l.connReadTimeout, l.connWriteTimeout)
+	}
+	c := newServerConn(conn, l)
+	c.ConnectionID = connectionID
+	x := &xConn{
+		c: c,
+		l: l,
+		w: bufio.NewWriter(conn),
+	}
+
+	// Catch panics, and close the connection in any case.
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("mysqlx_server caught panic:\n%v\n%s", x, tb.Stack(4))
+		}
+		if l.connBufferPooling {
+			c.returnReader()
+		}
+		c.conn.Close()
+	}()
+
+	// Tell the handler about the connection coming and going.
+	l.handler.NewConnection(c)
+	defer l.handler.ConnectionClosed(c)
+
+	// Adjust the count of open connections
+	defer connCount.Add(-1)
+
+	if !x.authenticate() {
+		return
+	}
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+		defer connCountPerUser.Add(c.User, -1)
+	}
+
+	// Record how long we took to establish the connection
+	timings.Record(connectTimingKey, acceptTime)
+
+	// Log a warning if it took too long to connect
+	connectTime := time.Since(acceptTime).Nanoseconds()
+	if threshold := l.SlowConnectWarnThreshold.Load(); threshold != 0 && connectTime > threshold {
+		connSlow.Add(1)
+		log.Warningf("Slow connection from %s: %v", c, connectTime)
+	}
+
+	l.handler.ConnectionReady(c)
+
+	for {
+		kontinue := x.handleNextMessage()
+		if !kontinue || c.IsMarkedForClose() {
+			return
+		}
+	}
+}
+
+// readMessage reads the next message from the client.
+func (x *xConn) readMessage() (byte, []byte, error) {
+	var header [5]byte
+	r := x.c.getReader()
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.LittleEndian.Uint32(header[:4])
+	if length == 0 || length > xMaxMessageSize {
+		return 0, nil, sqlerror.NewSQLError(xErrBadMessage, sqlerror.SSUnknownSQLState, "Invalid message length %d", length)
+	}
+	payload := make([]byte, length-1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[4], payload, nil
+}
+
+// writeMessage buffers a message for the client, until flush is called.
+func (x *xConn) writeMessage(typ byte, payload []byte) error {
+	var header [5]byte
+	binary.LittleEndian.PutUint32(header[:4], uint32(len(payload)+1))
+	header[4] = typ
+	if _, err := x.w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := x.w.Write(payload)
+	return err
+}
+
+// writeError writes a Mysqlx.Error and flushes it.
+func (x *xConn) writeError(err error) error {
+	if werr := x.writeMessage(xServerError, xErrorBytes(err)); werr != nil {
+		return werr
+	}
+	return x.w.Flush()
+}
+
+// writeOk writes a Mysqlx.Ok and flushes it.
+func (x *xConn) writeOk() error {
+	if err := x.writeMessage(xServerOk, nil); err != nil {
+		return err
+	}
+	return x.w.Flush()
+}
+
+// writeCapabilities writes the Mysqlx.Connection.Capabilities of the server.
+func (x *xConn) writeCapabilities() error {
+	var capabilities []byte
+	add := func(name string, value []byte) {
+		capability := xAppendBytes(nil, 1, []byte(name))
+		capability = xAppendBytes(capability, 2, value)
+		capabilities = xAppendBytes(capabilities, 1, capability)
+	}
+	if x.l.TLSConfig.Load() != nil {
+		add(xCapabilityTLS, xAnyScalarBytes(xAppendScalarBool(nil, x.c.TLSEnabled())))
+	}
+	mechanisms := []string{xAuthMechanismMySQL41}
+	if x.c.TLSEnabled() || x.l.AllowClearTextWithoutTLS.Load() {
+		mechanisms = append(mechanisms, xAuthMechanismPlain)
+	}
+	add(xCapabilityAuthMechanisms, xAnyStringArrayBytes(mechanisms...))
+	add(xCapabilityDocFormats, xAnyScalarBytes(xAppendScalarString(nil, "text")))
+	add(xCapabilityNodeType, xAnyScalarBytes(xAppendScalarString(nil, "mysql")))
+	add(xCapabilityPwdExpireOk, xAnyScalarBytes(xAppendScalarBool(nil, false)))
+	add(xCapabilityInteractive, xAnyScalarBytes(xAppendScalarBool(nil, false)))
+	if err := x.writeMessage(xServerConCapabilities, capabilities); err != nil {
+		return err
+	}
+	return x.w.Flush()
+}
+
+// setCapabilities handles a Mysqlx.Connection.CapabilitiesSet. Enabling
+// TLS upgrades the connection right after the Ok is sent.
+func (x *xConn) setCapabilities(payload []byte) error {
+	var capabilities []*xAnyField
+	err := xDecodeFields(payload, func(num protowire.Number, b []byte, v uint64) error {
+		if num != 1 {
+			return nil
+		}
+		return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+			if num != 1 {
+				return nil
+			}
+			capability := &xAnyField{}
+			capabilities = append(capabilities, capability)
+			return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				var err error
+				switch num {
+				case 1:
+					capability.key = string(b)
+				case 2:
+					capability.value, err = xDecodeAny(b)
+				}
+				return err
+			})
+		})
+	})
+	if err != nil {
+		return x.writeError(err)
+	}
+
+	var tlsConfig *tls.Config
+	for _, capability := range capabilities {
+		switch capability.key {
+		case xCapabilityTLS:
+			value := capability.value
+			if value == nil || value.typ != xAnyScalar || value.scalar.typ != xScalarBool || !value.scalar.boolean {
+				return x.writeError(sqlerror.NewSQLError(xErrCapabilitiesPrepare, sqlerror.SSUnknownSQLState, "Capability prepare failed for 'tls'"))
+			}
+			if config, ok := x.l.TLSConfig.Load().(*tls.Config); ok && !x.c.TLSEnabled() {
+				tlsConfig = config
+			} else if !x.c.TLSEnabled() {
+				return x.writeError(sqlerror.NewSQLError(xErrCapabilitiesPrepare, sqlerror.SSUnknownSQLState, "Capability prepare failed for 'tls'"))
+			}
+		case xCapabilityPwdExpireOk, xCapabilityInteractive, xCapabilityConnectAttrs:
+			// We don't need them.
+		default:
+			return x.writeError(sqlerror.NewSQLError(xErrCapabilityNotFound, sqlerror.SSUnknownSQLState, "Capability '%s' doesn't exist", capability.key))
+		}
+	}
+	if err := x.writeOk(); err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		conn := tls.Server(x.c.conn, tlsConfig)
+		x.c.conn = conn
+		if x.c.bufferedReader != nil {
+			x.c.bufferedReader.Reset(conn)
+		}
+		x.w.Reset(conn)
+		x.c.Capabilities |= CapabilityClientSSL
+	}
+	return nil
+}
+
+// authenticate negotiates the capabilities and authenticates the client.
+// It returns false if the connection should be closed.
+func (x *xConn) authenticate() bool {
+	for {
+		typ, payload, err := x.readMessage()
+		if err != nil {
+			if err != io.EOF {
+				log.Infof("Cannot read X Protocol message from %s: %v, it may not be a valid X Protocol client", x.c, err)
+			}
+			return false
+		}
+
+		switch typ {
+		case xClientConCapabilitiesGet:
+			err = x.writeCapabilities()
+		case xClientConCapabilitiesSet:
+			err = x.setCapabilities(payload)
+		case xClientSessAuthStart:
+			var ok bool
+			ok, err = x.authenticateStart(payload)
+			if ok && err == nil {
+				return true
+			}
+		case xClientConClose:
+			x.writeOk()
+			return false
+		default:
+			x.writeError(sqlerror.NewSQLError(sqlerror.ERUnknownComError, sqlerror.SSNetError, "Unexpected message received"))
+			return false
+		}
+		if err != nil {
+			log.Errorf("Error negotiating X Protocol session with %s: %v", x.c, err)
+			return false
+		}
+	}
+}
+
+// authenticateStart handles a Mysqlx.Session.AuthenticateStart, and the
+// rest of the authentication exchange. It returns true if the client is
+// authenticated. A failed authentication is reported to the client, which
+// can try again.
+func (x *xConn) authenticateStart(payload []byte) (bool, error) {
+	var mechanism string
+	var authData []byte
+	err := xDecodeFields(payload, func(num protowire.Number, b []byte, v uint64) error {
+		switch num {
+		case 1:
+			mechanism = string(b)
+		case 2:
+			authData = b
+		}
+		return nil
+	})
+	if err != nil {
+		return false, x.writeError(err)
+	}
+	if x.l.RequireSecureTransport && !x.c.TLSEnabled() {
+		return false, x.writeError(sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "server does not allow insecure connections, client must use SSL/TLS"))
+	}
+
+	var schema, user string
+	var getter Getter
+	switch mechanism {
+	case xAuthMechanismMySQL41:
+		// The server sends a salt, and the client replies with
+		// "schema\0user\0*<hex of the mysql_native_password scramble>".
+		method := x.l.authMethod(MysqlNativePassword)
+		if method == nil {
+			break
+		}
+		salt, err := method.AuthPluginData()
+		if err != nil {
+			return false, err
+		}
+		if err := x.writeMessage(xServerSessAuthContinue, xAppendBytes(nil, 1, salt[:len(salt)-1])); err != nil {
+			return false, err
+		}
+		if err := x.w.Flush(); err != nil {
+			return false, err
+		}
+		typ, payload, err := x.readMessage()
+		if err != nil {
+			return false, err
+		}
+		if typ != xClientSessAuthContinue {
+			return false, x.writeError(sqlerror.NewSQLError(sqlerror.ERUnknownComError, sqlerror.SSNetError, "Unexpected message received"))
+		}
+		err = xDecodeFields(payload, func(num protowire.Number, b []byte, v uint64) error {
+			if num == 1 {
+				authData = b
+			}
+			return nil
+		})
+		if err != nil {
+			return false, x.writeError(err)
+		}
+		var response []byte
+		schema, user, response = xSplitAuthData(authData)
+		var scramble []byte
+		if len(response) > 0 {
+			scramble, err = hex.DecodeString(string(bytes.TrimPrefix(response, []byte("*"))))
+		}
+		if err == nil && method.HandleUser(x.c, user) {
+			getter, err = method.HandleAuthPluginData(x.c, user, salt, scramble, x.c.conn.RemoteAddr())
+		} else {
+			err = sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+		}
+		if err != nil {
+			log.Warningf("Error authenticating user %s using: %s", user, mechanism)
+			x.l.authenticationFailed(x.c, user, err)
+			return false, x.writeError(err)
+		}
+	case xAuthMechanismPlain:
+		// The client sends "schema\0user\0password" right away, so it's only
+		// allowed over TLS.
+		if !x.c.TLSEnabled() && !x.l.AllowClearTextWithoutTLS.Load() {
+			return false, x.writeError(sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Cannot use clear text authentication over non-SSL connections."))
+		}
+		var password []byte
+		schema, user, password = xSplitAuthData(authData)
+		getter, err = x.l.authenticateClearText(x.c, user, password)
+		if err != nil {
+			log.Warningf("Error authenticating user %s using: %s", user, mechanism)
+			x.l.authenticationFailed(x.c, user, err)
+			return false, x.writeError(err)
+		}
+	default:
+		return false, x.writeError(sqlerror.NewSQLError(xErrAuthMechanismNotSupport, sqlerror.SSClientError, "Invalid authentication method %s", mechanism))
+	}
+	if getter == nil {
+		return false, x.writeError(sqlerror.NewSQLError(xErrAuthMechanismNotSupport, sqlerror.SSClientError, "Invalid authentication method %s", mechanism))
+	}
+
+	x.c.User = user
+	x.c.UserData = getter
+
+	// Set initial db name.
+	if schema != "" {
+		x.c.schemaName = schema
+		err = x.l.handler.ComQuery(x.c, "use "+sqlescape.EscapeID(schema), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			return false, x.writeError(err)
+		}
+	}
+
+	notice := xSessionStateChangedBytes(xStateClientIDAssigned, xAppendScalarUint(nil, uint64(x.c.ConnectionID)))
+	if err := x.writeMessage(xServerNotice, notice); err != nil {
+		return false, err
+	}
+	if err := x.writeMessage(xServerSessAuthOk, nil); err != nil {
+		return false, err
+	}
+	return true, x.w.Flush()
+}
+
+// authMethod returns the auth method of the auth server with that name.
+func (l *Listener) authMethod(name AuthMethodDescription) AuthMethod {
+	for _, method := range l.authServer.AuthMethods() {
+		if method.Name() == name {
+			return method
+		}
+	}
+	return nil
+}
+
+// authenticateClearText checks a clear text password, as sent with the
+// PLAIN mechanism of the X Protocol or by PostgreSQL clients, with
+// mysql_clear_password if the auth server has it, or else by computing the
+// mysql_native_password scramble of the password.
+func (l *Listener) authenticateClearText(c *Conn, user string, password []byte) (Getter, error) {
+	if method := l.authMethod(MysqlClearPassword); method != nil && method.HandleUser(c, user) {
+		return method.HandleAuthPluginData(c, user, nil, append(password, 0), c.conn.RemoteAddr())
+	}
+	if method := l.authMethod(MysqlNativePassword); method != nil && method.HandleUser(c, user) {
+		salt, err := method.AuthPluginData()
+		if err != nil {
+			return nil, err
+		}
+		var scramble []byte
+		if len(password) > 0 {
+			scramble = ScrambleMysqlNativePassword(salt[:len(salt)-1], password)
+		}
+		return method.HandleAuthPluginData(c, user, salt, scramble, c.conn.RemoteAddr())
+	}
+	return nil, sqlerror.NewSQLError(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+}
+
+// xSplitAuthData splits "schema\0user\0response".
+func xSplitAuthData(data []byte) (string, string, []byte) {
+	parts := bytes.SplitN(data, []byte{0}, 3)
+	for len(parts) < 3 {
+		parts = append(parts, nil)
+	}
+	return string(parts[0]), string(parts[1]), parts[2]
+}
+
+// handleNextMessage handles the next message of an authenticated client.
+// It returns false if the connection should be closed.
+func (x *xConn) handleNextMessage() bool {
+	typ, payload, err := x.readMessage()
+	if err != nil {
+		if err != io.EOF {
+			log.Errorf("Error reading X Protocol message from %s: %v", x.c, err)
+		}
+		return false
+	}
+
+	switch typ {
+	case xClientConCapabilitiesGet:
+		err = x.writeCapabilities()
+	case xClientSQLStmtExecute:
+		err = x.execStmt(payload)
+	case xClientCrudFind, xClientCrudInsert, xClientCrudUpdate, xClientCrudDelete:
+		err = x.execCrud(typ, payload)
+	case xClientExpectOpen, xClientExpectClose:
+		// We execute all messages in order and stop at the first error
+		// anyway, so there is no expectation to check.
+		err = x.writeOk()
+	case xClientSessReset:
+		x.l.handler.ComResetConnection(x.c)
+		err = x.writeOk()
+	case xClientSessClose, xClientConClose:
+		x.writeOk()
+		return false
+	default:
+		err = x.writeError(sqlerror.NewSQLError(sqlerror.ERUnknownComError, sqlerror.SSNetError, "Unexpected message received"))
+	}
+	if err != nil {
+		log.Errorf("Error writing X Protocol message to %s: %v", x.c, err)
+		return false
+	}
+	return true
+}
+
+// execStmt handles a Mysqlx.Sql.StmtExecute.
+func (x *xConn) execStmt(payload []byte) error {
+	namespace := xNamespaceSQL
+	var stmt string
+	var args []*xAny
+	err := xDecodeFields(payload, func(num protowire.Number, b []byte, v uint64) error {
+		switch num {
+		case 1:
+			stmt = string(b)
+		case 2:
+			arg, err := xDecodeAny(b)
+			args = append(args, arg)
+			return err
+		case 3:
+			namespace = string(b)
+		}
+		return nil
+	})
+	if err != nil {
+		return x.writeError(err)
+	}
+
+	switch namespace {
+	case xNamespaceSQL:
+		query, err := x.bindArgs(stmt, args)
+		if err != nil {
+			return x.writeError(err)
+		}
+		return x.execQuery(query, nil)
+	case xNamespaceMysqlx, xNamespaceXPlugin:
+		return x.execAdminCommand(stmt, args)
+	}
+	return x.writeError(sqlerror.NewSQLError(xErrInvalidNamespace, sqlerror.SSUnknownSQLState, "Unknown namespace %s", namespace))
+}
+
+// bindArgs returns the query with its ? placeholders replaced by the
+// scalar arguments of the statement.
+func (x *xConn) bindArgs(stmt string, args []*xAny) (string, error) {
+	if len(args) == 0 {
+		return stmt, nil
+	}
+	bindVars := make(map[string]*querypb.BindVariable, len(args))
+	for i, arg := range args {
+		if arg.typ != xAnyScalar || arg.scalar == nil {
+			return "", xInvalidArgument("Invalid argument %d: only scalars are supported", i)
+		}
+		v, err := arg.scalar.value()
+		if err != nil {
+			return "", err
+		}
+		bindVars[fmt.Sprintf("v%d", i+1)] = sqltypes.ValueBindVariable(v)
+	}
+	parsed, err := x.l.handler.Env().Parser().Parse(stmt)
+	if err != nil {
+		return "", err
+	}
+	return sqlparser.NewParsedQuery(parsed).GenerateQuery(bindVars, nil)
+}
+
+// execCrud handles the Find, Insert, Update and Delete messages.
+func (x *xConn) execCrud(typ byte, payload []byte) error {
+	var query string
+	var ids []string
+	var crud *xCrud
+	var err error
+	switch typ {
+	case xClientCrudFind:
+		if crud, err = xDecodeFind(payload); err == nil {
+			query, err = xFindQuery(crud)
+		}
+	case xClientCrudInsert:
+		if crud, err = xDecodeInsert(payload); err == nil {
+			query, ids, err = xInsertQuery(crud, xNewDocumentID)
+		}
+	case xClientCrudUpdate:
+		if crud, err = xDecodeUpdate(payload); err == nil {
+			query, err = xUpdateQuery(crud)
+		}
+	case xClientCrudDelete:
+		if crud, err = xDecodeDelete(payload); err == nil {
+			query, err = xDeleteQuery(crud)
+		}
+	}
+	if err != nil {
+		return x.writeError(err)
+	}
+	return x.execQuery(query, ids)
+}
+
+// execAdminCommand handles the statements of the mysqlx namespace, which
+// manage the collections of documents.
+func (x *xConn) execAdminCommand(command string, args []*xAny) error {
+	arg := func(name string) (string, bool) {
+		if len(args) == 0 || args[0].typ != xAnyObject {
+			return "", false
+		}
+		return args[0].field(name).stringValue()
+	}
+	table := func() (string, error) {
+		schema, ok := arg("schema")
+		if !ok || schema == "" {
+			return "", xInvalidArgument("Invalid value for argument 'schema'")
+		}
+		name, ok := arg("name")
+		if !ok || name == "" {
+			return "", xInvalidArgument("Invalid value for argument 'name'")
+		}
+		return sqlescape.EscapeID(schema) + "." + sqlescape.EscapeID(name), nil
+	}
+
+	var query string
+	switch command {
+	case "ping":
+		if err := x.writeMessage(xServerSQLStmtExecuteOk, nil); err != nil {
+			return err
+		}
+		return x.w.Flush()
+	case "create_collection", "ensure_collection":
+		name, err := table()
+		if err != nil {
+			return x.writeError(err)
+		}
+		query = "CREATE TABLE "
+		if command == "ensure_collection" {
+			query += "IF NOT EXISTS "
+		}
+		query += name + " (doc JSON, _id VARBINARY(32) GENERATED ALWAYS AS (JSON_UNQUOTE(JSON_EXTRACT(doc, '$._id'))) STORED NOT NULL, PRIMARY KEY (_id))"
+	case "drop_collection":
+		name, err := table()
+		if err != nil {
+			return x.writeError(err)
+		}
+		query = "DROP TABLE " + name
+	case "list_objects":
+		schema, ok := arg("schema")
+		if !ok || schema == "" {
+			schema = x.c.schemaName
+		}
+		if schema == "" {
+			return x.writeError(sqlerror.NewSQLError(sqlerror.ERNoDb, sqlerror.SSNoDB, "No database selected"))
+		}
+		// Collections are the tables that only have the doc and _id
+		// columns.
+		query = "SELECT t.table_name AS name, IF(t.table_type = 'VIEW', 'VIEW', IF((SELECT COUNT(*) FROM information_schema.columns AS c WHERE c.table_schema = t.table_schema AND c.table_name = t.table_name AND c.column_name NOT IN ('doc', '_id')) = 0, 'COLLECTION', 'TABLE')) AS type FROM information_schema.tables AS t WHERE t.table_schema = " + sqltypes.EncodeStringSQL(schema)
+		if pattern, ok := arg("pattern"); ok && pattern != "" {
+			query += " AND t.table_name LIKE " + sqltypes.EncodeStringSQL(pattern)
+		}
+		query += " ORDER BY t.table_name"
+	default:
+		return x.writeError(sqlerror.NewSQLError(xErrUnknownCommand, sqlerror.SSUnknownSQLState, "Invalid mysqlx command %s", command))
+	}
+	return x.execQuery(query, nil)
+}
+
+// execQuery executes the query with the handler, and streams its result
+// to the client, followed by the notices of the rows affected, the last
+// insert id and the generated document ids, and a StmtExecuteOk.
+func (x *xConn) execQuery(query string, ids []string) error {
+	var fields []*querypb.Field
+	var rowsAffected, insertID uint64
+	err := x.l.handler.ComQuery(x.c, query, func(qr *sqltypes.Result) error {
+		if fields == nil && len(qr.Fields) > 0 {
+			fields = qr.Fields
+			for _, field := range fields {
+				if err := x.writeMessage(xServerColumnMetaData, xColumnMetaDataBytes(field)); err != nil {
+					return err
+				}
+			}
+		}
+		for _, row := range qr.Rows {
+			b, err := xRowBytes(fields, row)
+			if err != nil {
+				return err
+			}
+			if err := x.writeMessage(xServerRow, b); err != nil {
+				return err
+			}
+			if x.w.Buffered() > xResultFlushThreshold {
+				if err := x.w.Flush(); err != nil {
+					return err
+				}
+			}
+		}
+		rowsAffected += qr.RowsAffected
+		if qr.InsertID != 0 {
+			insertID = qr.InsertID
+		}
+		return nil
+	})
+	if err != nil {
+		return x.writeError(err)
+	}
+
+	if fields != nil {
+		if err := x.writeMessage(xServerFetchDone, nil); err != nil {
+			return err
+		}
+	} else {
+		notice := xSessionStateChangedBytes(xStateRowsAffected, xAppendScalarUint(nil, rowsAffected))
+		if err := x.writeMessage(xServerNotice, notice); err != nil {
+			return err
+		}
+	}
+	if insertID != 0 {
+		notice := xSessionStateChangedBytes(xStateGeneratedInsertID, xAppendScalarUint(nil, insertID))
+		if err := x.writeMessage(xServerNotice, notice); err != nil {
+			return err
+		}
+	}
+	if len(ids) > 0 {
+		values := make([][]byte, 0, len(ids))
+		for _, id := range ids {
+			values = append(values, xAppendScalarString(nil, id))
+		}
+		if err := x.writeMessage(xServerNotice, xSessionStateChangedBytes(xStateGeneratedDocumentID, values...)); err != nil {
+			return err
+		}
+	}
+	if err := x.writeMessage(xServerSQLStmtExecuteOk, nil); err != nil {
+		return err
+	}
+	return x.w.Flush()
+}
@@ -0,0 +1,1278 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+// The CRUD messages of the X Protocol are translated to SQL, which is then
+// executed like the statements of Mysqlx.Sql.StmtExecute. A collection of
+// documents is a table with a JSON doc column and an _id column generated
+// from the _id member of the document, as created by the create_collection
+// admin command.
+
+// Mysqlx.Crud.DataModel
+const (
+	xDataModelDocument = 1
+	xDataModelTable    = 2
+)
+
+// Mysqlx.Expr.Expr.Type
+const (
+	xExprIdent       = 1
+	xExprLiteral     = 2
+	xExprVariable    = 3
+	xExprFuncCall    = 4
+	xExprOperator    = 5
+	xExprPlaceholder = 6
+	xExprObject      = 7
+	xExprArray       = 8
+)
+
+// Mysqlx.Expr.DocumentPathItem.Type
+const (
+	xPathMember             = 1
+	xPathMemberAsterisk     = 2
+	xPathArrayIndex         = 3
+	xPathArrayIndexAsterisk = 4
+	xPathDoubleAsterisk     = 5
+)
+
+// Mysqlx.Crud.UpdateOperation.UpdateType
+const (
+	xUpdateSet         = 1
+	xUpdateItemRemove  = 2
+	xUpdateItemSet     = 3
+	xUpdateItemReplace = 4
+	xUpdateItemMerge   = 5
+	xUpdateArrayInsert = 6
+	xUpdateArrayAppend = 7
+	xUpdateMergePatch  = 8
+)
+
+// Mysqlx.Crud.Order.Direction and Mysqlx.Crud.Find.RowLock(Options)
+const (
+	xOrderDesc = 2
+
+	xRowLockShared    = 1
+	xRowLockExclusive = 2
+	xRowLockNoWait    = 1
+	xRowLockSkipLock  = 2
+)
+
+// xExpr is a Mysqlx.Expr.Expr.
+type xExpr struct {
+	typ      uint64
+	ident    *xColumnIdent
+	literal  *xScalar
+	name     string
+	schema   string
+	params   []*xExpr
+	position uint64
+	object   []xExprField
+	array    []*xExpr
+}
+
+// xExprField is a Mysqlx.Expr.Object.ObjectField.
+type xExprField struct {
+	key   string
+	value *xExpr
+}
+
+// xColumnIdent is a Mysqlx.Expr.ColumnIdentifier.
+type xColumnIdent struct {
+	path   []xPathItem
+	name   string
+	table  string
+	schema string
+}
+
+// xPathItem is a Mysqlx.Expr.DocumentPathItem.
+type xPathItem struct {
+	typ   uint64
+	value string
+	index uint64
+}
+
+func xDecodeExpr(data []byte) (*xExpr, error) {
+	e := &xExpr{}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 1:
+			e.typ = v
+		case 2:
+			e.ident, err = xDecodeColumnIdent(b)
+		case 3:
+			e.name = string(b)
+		case 4:
+			e.literal, err = xDecodeScalar(b)
+		case 5:
+			// FunctionCall has the Identifier of the function and its
+			// parameters.
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				switch num {
+				case 1:
+					return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+						switch num {
+						case 1:
+							e.name = string(b)
+						case 2:
+							e.schema = string(b)
+						}
+						return nil
+					})
+				case 2:
+					param, err := xDecodeExpr(b)
+					e.params = append(e.params, param)
+					return err
+				}
+				return nil
+			})
+		case 6:
+			// Operator has the name of the operator and its parameters.
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				switch num {
+				case 1:
+					e.name = string(b)
+				case 2:
+					param, err := xDecodeExpr(b)
+					e.params = append(e.params, param)
+					return err
+				}
+				return nil
+			})
+		case 7:
+			e.position = v
+		case 8:
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				if num != 1 {
+					return nil
+				}
+				var field xExprField
+				err := xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+					var err error
+					switch num {
+					case 1:
+						field.key = string(b)
+					case 2:
+						field.value, err = xDecodeExpr(b)
+					}
+					return err
+				})
+				e.object = append(e.object, field)
+				return err
+			})
+		case 9:
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				if num != 1 {
+					return nil
+				}
+				value, err := xDecodeExpr(b)
+				e.array = append(e.array, value)
+				return err
+			})
+		}
+		return err
+	})
+	return e, err
+}
+
+func xDecodeColumnIdent(data []byte) (*xColumnIdent, error) {
+	ident := &xColumnIdent{}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		switch num {
+		case 1:
+			item, err := xDecodePathItem(b)
+			ident.path = append(ident.path, item)
+			return err
+		case 2:
+			ident.name = string(b)
+		case 3:
+			ident.table = string(b)
+		case 4:
+			ident.schema = string(b)
+		}
+		return nil
+	})
+	return ident, err
+}
+
+func xDecodePathItem(data []byte) (xPathItem, error) {
+	var item xPathItem
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		switch num {
+		case 1:
+			item.typ = v
+		case 2:
+			item.value = string(b)
+		case 3:
+			item.index = v
+		}
+		return nil
+	})
+	return item, err
+}
+
+// xCrud holds the fields of the Find, Insert, Update and Delete messages.
+// They are numbered differently in each message, so they are decoded by
+// the functions below.
+type xCrud struct {
+	schema    string
+	table     string
+	dataModel uint64
+	args      []*xScalar
+	criteria  *xExpr
+
+	limitRowCount *xExpr
+	limitOffset   *xExpr
+
+	order     []xOrder
+	columns   []xProjection
+	grouping  []*xExpr
+	having    *xExpr
+	locking   uint64
+	lockingOp uint64
+
+	rows       [][]*xExpr
+	upsert     bool
+	operations []xUpdateOperation
+}
+
+// xOrder is a Mysqlx.Crud.Order.
+type xOrder struct {
+	expr *xExpr
+	desc bool
+}
+
+// xProjection is a Mysqlx.Crud.Projection of Find, or a Mysqlx.Crud.Column
+// of Insert.
+type xProjection struct {
+	source *xExpr
+	name   string
+	alias  string
+}
+
+// xUpdateOperation is a Mysqlx.Crud.UpdateOperation.
+type xUpdateOperation struct {
+	source *xColumnIdent
+	op     uint64
+	value  *xExpr
+}
+
+// decodeCollection decodes a Mysqlx.Crud.Collection.
+func (crud *xCrud) decodeCollection(b []byte) error {
+	return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+		switch num {
+		case 1:
+			crud.table = string(b)
+		case 2:
+			crud.schema = string(b)
+		}
+		return nil
+	})
+}
+
+// decodeArg decodes a Scalar argument of the placeholders.
+func (crud *xCrud) decodeArg(b []byte) error {
+	arg, err := xDecodeScalar(b)
+	crud.args = append(crud.args, arg)
+	return err
+}
+
+// decodeLimit decodes a Mysqlx.Crud.Limit.
+func (crud *xCrud) decodeLimit(b []byte) error {
+	return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+		limit := &xExpr{typ: xExprLiteral, literal: &xScalar{typ: xScalarUint, unsigned: v}}
+		switch num {
+		case 1:
+			crud.limitRowCount = limit
+		case 2:
+			crud.limitOffset = limit
+		}
+		return nil
+	})
+}
+
+// decodeLimitExpr decodes a Mysqlx.Crud.LimitExpr.
+func (crud *xCrud) decodeLimitExpr(b []byte) error {
+	return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 1:
+			crud.limitRowCount, err = xDecodeExpr(b)
+		case 2:
+			crud.limitOffset, err = xDecodeExpr(b)
+		}
+		return err
+	})
+}
+
+// decodeOrder decodes a Mysqlx.Crud.Order.
+func (crud *xCrud) decodeOrder(b []byte) error {
+	var order xOrder
+	err := xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 1:
+			order.expr, err = xDecodeExpr(b)
+		case 2:
+			order.desc = v == xOrderDesc
+		}
+		return err
+	})
+	crud.order = append(crud.order, order)
+	return err
+}
+
+// xDecodeFind decodes a Mysqlx.Crud.Find.
+func xDecodeFind(data []byte) (*xCrud, error) {
+	crud := &xCrud{dataModel: xDataModelDocument}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 2:
+			err = crud.decodeCollection(b)
+		case 3:
+			crud.dataModel = v
+		case 4:
+			var projection xProjection
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				var err error
+				switch num {
+				case 1:
+					projection.source, err = xDecodeExpr(b)
+				case 2:
+					projection.alias = string(b)
+				}
+				return err
+			})
+			crud.columns = append(crud.columns, projection)
+		case 5:
+			crud.criteria, err = xDecodeExpr(b)
+		case 6:
+			err = crud.decodeLimit(b)
+		case 7:
+			err = crud.decodeOrder(b)
+		case 8:
+			var grouping *xExpr
+			grouping, err = xDecodeExpr(b)
+			crud.grouping = append(crud.grouping, grouping)
+		case 9:
+			crud.having, err = xDecodeExpr(b)
+		case 11:
+			err = crud.decodeArg(b)
+		case 12:
+			crud.locking = v
+		case 13:
+			crud.lockingOp = v
+		case 14:
+			err = crud.decodeLimitExpr(b)
+		}
+		return err
+	})
+	return crud, err
+}
+
+// xDecodeInsert decodes a Mysqlx.Crud.Insert.
+func xDecodeInsert(data []byte) (*xCrud, error) {
+	crud := &xCrud{dataModel: xDataModelDocument}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 1:
+			err = crud.decodeCollection(b)
+		case 2:
+			crud.dataModel = v
+		case 3:
+			var column xProjection
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				switch num {
+				case 1:
+					column.name = string(b)
+				case 2:
+					column.alias = string(b)
+				}
+				return nil
+			})
+			crud.columns = append(crud.columns, column)
+		case 4:
+			var row []*xExpr
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				if num != 1 {
+					return nil
+				}
+				field, err := xDecodeExpr(b)
+				row = append(row, field)
+				return err
+			})
+			crud.rows = append(crud.rows, row)
+		case 5:
+			err = crud.decodeArg(b)
+		case 6:
+			crud.upsert = v != 0
+		}
+		return err
+	})
+	return crud, err
+}
+
+// xDecodeUpdate decodes a Mysqlx.Crud.Update.
+func xDecodeUpdate(data []byte) (*xCrud, error) {
+	crud := &xCrud{dataModel: xDataModelDocument}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 2:
+			err = crud.decodeCollection(b)
+		case 3:
+			crud.dataModel = v
+		case 4:
+			crud.criteria, err = xDecodeExpr(b)
+		case 5:
+			err = crud.decodeLimit(b)
+		case 6:
+			err = crud.decodeOrder(b)
+		case 7:
+			var operation xUpdateOperation
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				var err error
+				switch num {
+				case 1:
+					operation.source, err = xDecodeColumnIdent(b)
+				case 2:
+					operation.op = v
+				case 3:
+					operation.value, err = xDecodeExpr(b)
+				}
+				return err
+			})
+			crud.operations = append(crud.operations, operation)
+		case 8:
+			err = crud.decodeArg(b)
+		case 9:
+			err = crud.decodeLimitExpr(b)
+		}
+		return err
+	})
+	return crud, err
+}
+
+// xDecodeDelete decodes a Mysqlx.Crud.Delete.
+func xDecodeDelete(data []byte) (*xCrud, error) {
+	crud := &xCrud{dataModel: xDataModelDocument}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 1:
+			err = crud.decodeCollection(b)
+		case 2:
+			crud.dataModel = v
+		case 3:
+			crud.criteria, err = xDecodeExpr(b)
+		case 4:
+			err = crud.decodeLimit(b)
+		case 5:
+			err = crud.decodeOrder(b)
+		case 6:
+			err = crud.decodeArg(b)
+		case 7:
+			err = crud.decodeLimitExpr(b)
+		}
+		return err
+	})
+	return crud, err
+}
+
+// xInvalidArgument returns an error for an invalid CRUD message.
+func xInvalidArgument(format string, args ...any) error {
+	return sqlerror.NewSQLError(xErrInvalidArgument, sqlerror.SSUnknownSQLState, format, args...)
+}
+
+// xBinaryOperators are the operators of Mysqlx.Expr.Operator that
+// translate to a binary operator of SQL.
+var xBinaryOperators = map[string]string{
+	"==":         "=",
+	"!=":         "!=",
+	"<":          "<",
+	">":          ">",
+	"<=":         "<=",
+	">=":         ">=",
+	"&&":         "AND",
+	"||":         "OR",
+	"xor":        "XOR",
+	"+":          "+",
+	"-":          "-",
+	"*":          "*",
+	"/":          "/",
+	"div":        "DIV",
+	"%":          "%",
+	"&":          "&",
+	"|":          "|",
+	"^":          "^",
+	"<<":         "<<",
+	">>":         ">>",
+	"is":         "IS",
+	"is_not":     "IS NOT",
+	"regexp":     "REGEXP",
+	"not_regexp": "NOT REGEXP",
+	"like":       "LIKE",
+	"not_like":   "NOT LIKE",
+}
+
+// xUnaryOperators are the operators of Mysqlx.Expr.Operator that translate
+// to a unary operator of SQL.
+var xUnaryOperators = map[string]string{
+	"!":          "NOT ",
+	"not":        "NOT ",
+	"~":          "~",
+	"sign_plus":  "+",
+	"sign_minus": "-",
+}
+
+var (
+	// xFunctionName matches the names of functions that can be called.
+	xFunctionName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// xCastType matches the types that values can be cast to.
+	xCastType = regexp.MustCompile(`^[A-Za-z]+( ?\([0-9]+( ?, ?[0-9]+)?\))?( [A-Za-z]+)?$`)
+	// xIntervalUnit matches the units of DATE_ADD and DATE_SUB.
+	xIntervalUnit = regexp.MustCompile(`^[A-Za-z]+(_[A-Za-z]+)?$`)
+	// xPathMemberName matches the members of a document path that don't
+	// need to be quoted.
+	xPathMemberName = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// xQueryBuilder builds the SQL query of a CRUD message.
+type xQueryBuilder struct {
+	buf  strings.Builder
+	crud *xCrud
+}
+
+func (qb *xQueryBuilder) WriteString(s string) {
+	qb.buf.WriteString(s)
+}
+
+// document returns true if the message is for a collection of documents.
+func (qb *xQueryBuilder) document() bool {
+	return qb.crud.dataModel != xDataModelTable
+}
+
+// table writes the qualified name of the collection or table.
+func (qb *xQueryBuilder) table() {
+	if qb.crud.schema != "" {
+		qb.WriteString(sqlescape.EscapeID(qb.crud.schema))
+		qb.WriteString(".")
+	}
+	qb.WriteString(sqlescape.EscapeID(qb.crud.table))
+}
+
+// list writes the expressions separated by commas.
+func (qb *xQueryBuilder) list(exprs []*xExpr) error {
+	for i, e := range exprs {
+		if i > 0 {
+			qb.WriteString(", ")
+		}
+		if err := qb.expr(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// path writes a document path as a string literal.
+func (qb *xQueryBuilder) path(items []xPathItem) error {
+	var path strings.Builder
+	path.WriteString("$")
+	for _, item := range items {
+		switch item.typ {
+		case xPathMember:
+			path.WriteString(".")
+			if xPathMemberName.MatchString(item.value) {
+				path.WriteString(item.value)
+			} else {
+				path.WriteString(strconv.Quote(item.value))
+			}
+		case xPathMemberAsterisk:
+			path.WriteString(".*")
+		case xPathArrayIndex:
+			path.WriteString("[" + strconv.FormatUint(item.index, 10) + "]")
+		case xPathArrayIndexAsterisk:
+			path.WriteString("[*]")
+		case xPathDoubleAsterisk:
+			path.WriteString("**")
+		default:
+			return xInvalidArgument("Invalid document path item type %d", item.typ)
+		}
+	}
+	qb.WriteString(sqltypes.EncodeStringSQL(path.String()))
+	return nil
+}
+
+// column writes the column of an identifier, which is the doc column of
+// a collection if it has no name.
+func (qb *xQueryBuilder) column(ident *xColumnIdent) error {
+	if ident.name == "" {
+		if !qb.document() {
+			return xInvalidArgument("Column name is required for tables")
+		}
+		qb.WriteString("doc")
+		return nil
+	}
+	if ident.table != "" {
+		if ident.schema != "" {
+			qb.WriteString(sqlescape.EscapeID(ident.schema))
+			qb.WriteString(".")
+		}
+		qb.WriteString(sqlescape.EscapeID(ident.table))
+		qb.WriteString(".")
+	}
+	qb.WriteString(sqlescape.EscapeID(ident.name))
+	return nil
+}
+
+// ident writes an identifier, which extracts the value at its document path
+// if it has one.
+func (qb *xQueryBuilder) ident(ident *xColumnIdent) error {
+	if len(ident.path) == 0 {
+		return qb.column(ident)
+	}
+	qb.WriteString("JSON_EXTRACT(")
+	if err := qb.column(ident); err != nil {
+		return err
+	}
+	qb.WriteString(", ")
+	if err := qb.path(ident.path); err != nil {
+		return err
+	}
+	qb.WriteString(")")
+	return nil
+}
+
+// literal writes a scalar as a literal.
+func (qb *xQueryBuilder) literal(s *xScalar) error {
+	v, err := s.value()
+	if err != nil {
+		return err
+	}
+	if s.typ == xScalarOctets && s.contentType == xContentTypeJSON {
+		qb.WriteString("CAST(")
+		v.EncodeSQLStringBuilder(&qb.buf)
+		qb.WriteString(" AS JSON)")
+		return nil
+	}
+	if s.typ == xScalarBool {
+		qb.WriteString(strings.ToUpper(strconv.FormatBool(s.boolean)))
+		return nil
+	}
+	v.EncodeSQLStringBuilder(&qb.buf)
+	return nil
+}
+
+// resolve returns the expression with its placeholder replaced by the
+// literal of its argument.
+func (qb *xQueryBuilder) resolve(e *xExpr) (*xExpr, error) {
+	if e.typ != xExprPlaceholder {
+		return e, nil
+	}
+	if e.position >= uint64(len(qb.crud.args)) {
+		return nil, xInvalidArgument("Invalid value of placeholder %d", e.position)
+	}
+	return &xExpr{typ: xExprLiteral, literal: qb.crud.args[e.position]}, nil
+}
+
+// jsonExpr writes an expression as a JSON value.
+func (qb *xQueryBuilder) jsonExpr(e *xExpr) error {
+	e, err := qb.resolve(e)
+	if err != nil {
+		return err
+	}
+	switch {
+	case e.typ == xExprObject, e.typ == xExprArray,
+		e.typ == xExprIdent && len(e.ident.path) > 0,
+		e.typ == xExprLiteral && e.literal.typ == xScalarOctets && e.literal.contentType == xContentTypeJSON:
+		return qb.expr(e)
+	case e.typ == xExprLiteral && e.literal.isString():
+		qb.WriteString("JSON_QUOTE(")
+		err = qb.expr(e)
+		qb.WriteString(")")
+	default:
+		qb.WriteString("CAST(")
+		err = qb.expr(e)
+		qb.WriteString(" AS JSON)")
+	}
+	return err
+}
+
+// unquotedExpr writes an expression, with the JSON string values of
+// document paths unquoted, for the operators that compare strings.
+func (qb *xQueryBuilder) unquotedExpr(e *xExpr) error {
+	if e.typ != xExprIdent || len(e.ident.path) == 0 {
+		return qb.expr(e)
+	}
+	qb.WriteString("JSON_UNQUOTE(")
+	err := qb.expr(e)
+	qb.WriteString(")")
+	return err
+}
+
+// expr writes an expression.
+func (qb *xQueryBuilder) expr(e *xExpr) error {
+	switch e.typ {
+	case xExprIdent:
+		return qb.ident(e.ident)
+	case xExprLiteral:
+		return qb.literal(e.literal)
+	case xExprPlaceholder:
+		e, err := qb.resolve(e)
+		if err != nil {
+			return err
+		}
+		return qb.expr(e)
+	case xExprFuncCall:
+		if !xFunctionName.MatchString(e.name) {
+			return xInvalidArgument("Invalid function name %q", e.name)
+		}
+		if e.schema != "" {
+			qb.WriteString(sqlescape.EscapeID(e.schema))
+			qb.WriteString(".")
+			qb.WriteString(sqlescape.EscapeID(e.name))
+		} else {
+			qb.WriteString(e.name)
+		}
+		qb.WriteString("(")
+		if err := qb.list(e.params); err != nil {
+			return err
+		}
+		qb.WriteString(")")
+		return nil
+	case xExprOperator:
+		return qb.operator(e)
+	case xExprObject:
+		qb.WriteString("JSON_OBJECT(")
+		for i, field := range e.object {
+			if i > 0 {
+				qb.WriteString(", ")
+			}
+			qb.WriteString(sqltypes.EncodeStringSQL(field.key))
+			qb.WriteString(", ")
+			if err := qb.expr(field.value); err != nil {
+				return err
+			}
+		}
+		qb.WriteString(")")
+		return nil
+	case xExprArray:
+		qb.WriteString("JSON_ARRAY(")
+		if err := qb.list(e.array); err != nil {
+			return err
+		}
+		qb.WriteString(")")
+		return nil
+	}
+	return xInvalidArgument("Invalid expression type %d", e.typ)
+}
+
+// operator writes an operator expression.
+func (qb *xQueryBuilder) operator(e *xExpr) error {
+	params := e.params
+	wantParams := func(counts ...int) error {
+		for _, count := range counts {
+			if len(params) == count {
+				return nil
+			}
+		}
+		return sqlerror.NewSQLError(xErrBadNumArgs, sqlerror.SSUnknownSQLState, "Invalid number of arguments for operator '%s'", e.name)
+	}
+
+	if op, ok := xBinaryOperators[e.name]; ok {
+		switch {
+		case e.name == "*" && len(params) == 0:
+			qb.WriteString("*")
+			return nil
+		case (e.name == "like" || e.name == "not_like") && len(params) == 3:
+		default:
+			if err := wantParams(2); err != nil {
+				return err
+			}
+		}
+		operand := qb.expr
+		switch e.name {
+		case "like", "not_like", "regexp", "not_regexp":
+			operand = qb.unquotedExpr
+		}
+		qb.WriteString("(")
+		if err := operand(params[0]); err != nil {
+			return err
+		}
+		qb.WriteString(" " + op + " ")
+		if err := operand(params[1]); err != nil {
+			return err
+		}
+		if len(params) == 3 {
+			qb.WriteString(" ESCAPE ")
+			if err := qb.expr(params[2]); err != nil {
+				return err
+			}
+		}
+		qb.WriteString(")")
+		return nil
+	}
+	if op, ok := xUnaryOperators[e.name]; ok {
+		if err := wantParams(1); err != nil {
+			return err
+		}
+		qb.WriteString("(" + op)
+		if err := qb.expr(params[0]); err != nil {
+			return err
+		}
+		qb.WriteString(")")
+		return nil
+	}
+
+	switch e.name {
+	case "in", "not_in":
+		if len(params) < 2 {
+			return wantParams(2)
+		}
+		qb.WriteString("(")
+		if err := qb.expr(params[0]); err != nil {
+			return err
+		}
+		if e.name == "not_in" {
+			qb.WriteString(" NOT")
+		}
+		qb.WriteString(" IN (")
+		if err := qb.list(params[1:]); err != nil {
+			return err
+		}
+		qb.WriteString("))")
+		return nil
+	case "between", "not_between":
+		if err := wantParams(3); err != nil {
+			return err
+		}
+		qb.WriteString("(")
+		if err := qb.expr(params[0]); err != nil {
+			return err
+		}
+		if e.name == "not_between" {
+			qb.WriteString(" NOT")
+		}
+		qb.WriteString(" BETWEEN ")
+		if err := qb.expr(params[1]); err != nil {
+			return err
+		}
+		qb.WriteString(" AND ")
+		if err := qb.expr(params[2]); err != nil {
+			return err
+		}
+		qb.WriteString(")")
+		return nil
+	case "cont_in", "not_cont_in", "overlaps", "not_overlaps":
+		if err := wantParams(2); err != nil {
+			return err
+		}
+		if strings.HasPrefix(e.name, "not_") {
+			qb.WriteString("NOT ")
+		}
+		// a IN b is true if the JSON value b contains a.
+		first, second := params[1], params[0]
+		if strings.HasSuffix(e.name, "overlaps") {
+			qb.WriteString("JSON_OVERLAPS(")
+		} else {
+			qb.WriteString("JSON_CONTAINS(")
+		}
+		if err := qb.jsonExpr(first); err != nil {
+			return err
+		}
+		qb.WriteString(", ")
+		if err := qb.jsonExpr(second); err != nil {
+			return err
+		}
+		qb.WriteString(")")
+		return nil
+	case "cast":
+		if err := wantParams(2); err != nil {
+			return err
+		}
+		typ, err := qb.resolve(params[1])
+		if err != nil {
+			return err
+		}
+		if typ.typ != xExprLiteral || !typ.literal.isString() || !xCastType.Match(typ.literal.bytes) {
+			return xInvalidArgument("Invalid type of CAST")
+		}
+		qb.WriteString("CAST(")
+		if err := qb.expr(params[0]); err != nil {
+			return err
+		}
+		qb.WriteString(" AS " + strings.ToUpper(string(typ.literal.bytes)) + ")")
+		return nil
+	case "date_add", "date_sub":
+		if err := wantParams(3); err != nil {
+			return err
+		}
+		unit, err := qb.resolve(params[2])
+		if err != nil {
+			return err
+		}
+		if unit.typ != xExprLiteral || !unit.literal.isString() || !xIntervalUnit.Match(unit.literal.bytes) {
+			return xInvalidArgument("Invalid unit of %s", strings.ToUpper(e.name))
+		}
+		qb.WriteString(strings.ToUpper(e.name) + "(")
+		if err := qb.expr(params[0]); err != nil {
+			return err
+		}
+		qb.WriteString(", INTERVAL ")
+		if err := qb.expr(params[1]); err != nil {
+			return err
+		}
+		qb.WriteString(" " + strings.ToUpper(string(unit.literal.bytes)) + ")")
+		return nil
+	case "default":
+		if err := wantParams(0); err != nil {
+			return err
+		}
+		qb.WriteString("DEFAULT")
+		return nil
+	}
+	return sqlerror.NewSQLError(xErrBadOperator, sqlerror.SSUnknownSQLState, "Invalid operator %s", e.name)
+}
+
+// where writes the WHERE, ORDER BY and LIMIT clauses of the message.
+// Offsets are only allowed for Find.
+func (qb *xQueryBuilder) where(allowOffset bool) error {
+	crud := qb.crud
+	if crud.criteria != nil {
+		qb.WriteString(" WHERE ")
+		if err := qb.expr(crud.criteria); err != nil {
+			return err
+		}
+	}
+	if allowOffset && len(crud.grouping) > 0 {
+		qb.WriteString(" GROUP BY ")
+		if err := qb.list(crud.grouping); err != nil {
+			return err
+		}
+		if crud.having != nil {
+			qb.WriteString(" HAVING ")
+			if err := qb.expr(crud.having); err != nil {
+				return err
+			}
+		}
+	}
+	for i, order := range crud.order {
+		if i == 0 {
+			qb.WriteString(" ORDER BY ")
+		} else {
+			qb.WriteString(", ")
+		}
+		if err := qb.expr(order.expr); err != nil {
+			return err
+		}
+		if order.desc {
+			qb.WriteString(" DESC")
+		}
+	}
+	if crud.limitRowCount != nil {
+		qb.WriteString(" LIMIT ")
+		if crud.limitOffset != nil {
+			if !allowOffset {
+				return xInvalidArgument("Invalid parameter: non-zero offset value not allowed for this operation")
+			}
+			if err := qb.expr(crud.limitOffset); err != nil {
+				return err
+			}
+			qb.WriteString(", ")
+		}
+		if err := qb.expr(crud.limitRowCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xFindQuery returns the SELECT query of a Mysqlx.Crud.Find message.
+func xFindQuery(crud *xCrud) (string, error) {
+	qb := &xQueryBuilder{crud: crud}
+	qb.WriteString("SELECT ")
+	switch {
+	case len(crud.columns) == 0 && qb.document():
+		qb.WriteString("doc")
+	case len(crud.columns) == 0:
+		qb.WriteString("*")
+	case qb.document():
+		// The projection of documents builds a new document.
+		qb.WriteString("JSON_OBJECT(")
+		for i, column := range crud.columns {
+			if i > 0 {
+				qb.WriteString(", ")
+			}
+			alias := column.alias
+			if alias == "" && column.source.typ == xExprIdent && len(column.source.ident.path) > 0 {
+				alias = column.source.ident.path[len(column.source.ident.path)-1].value
+			}
+			if alias == "" {
+				return "", xInvalidArgument("Invalid projection target name")
+			}
+			qb.WriteString(sqltypes.EncodeStringSQL(alias))
+			qb.WriteString(", ")
+			if err := qb.expr(column.source); err != nil {
+				return "", err
+			}
+		}
+		qb.WriteString(") AS doc")
+	default:
+		for i, column := range crud.columns {
+			if i > 0 {
+				qb.WriteString(", ")
+			}
+			if err := qb.expr(column.source); err != nil {
+				return "", err
+			}
+			if column.alias != "" {
+				qb.WriteString(" AS ")
+				qb.WriteString(sqlescape.EscapeID(column.alias))
+			}
+		}
+	}
+	qb.WriteString(" FROM ")
+	qb.table()
+	if err := qb.where(true); err != nil {
+		return "", err
+	}
+	switch crud.locking {
+	case xRowLockShared:
+		qb.WriteString(" FOR SHARE")
+	case xRowLockExclusive:
+		qb.WriteString(" FOR UPDATE")
+	}
+	if crud.locking != 0 {
+		switch crud.lockingOp {
+		case xRowLockNoWait:
+			qb.WriteString(" NOWAIT")
+		case xRowLockSkipLock:
+			qb.WriteString(" SKIP LOCKED")
+		}
+	}
+	return qb.buf.String(), nil
+}
+
+// xInsertQuery returns the INSERT query of a Mysqlx.Crud.Insert message.
+// Documents without an _id get one from newID, and the generated ids are
+// returned.
+func xInsertQuery(crud *xCrud, newID func() string) (string, []string, error) {
+	qb := &xQueryBuilder{crud: crud}
+	if len(crud.rows) == 0 {
+		return "", nil, xInvalidArgument("Missing row data for Insert")
+	}
+	if crud.upsert && !qb.document() {
+		return "", nil, xInvalidArgument("Invalid parameter: upsert is only supported for collections")
+	}
+	qb.WriteString("INSERT INTO ")
+	qb.table()
+	if qb.document() {
+		qb.WriteString(" (doc)")
+	} else if len(crud.columns) > 0 {
+		qb.WriteString(" (")
+		for i, column := range crud.columns {
+			if i > 0 {
+				qb.WriteString(", ")
+			}
+			qb.WriteString(sqlescape.EscapeID(column.name))
+		}
+		qb.WriteString(")")
+	}
+	qb.WriteString(" VALUES ")
+
+	var ids []string
+	for i, row := range crud.rows {
+		if i > 0 {
+			qb.WriteString(", ")
+		}
+		qb.WriteString("(")
+		if !qb.document() {
+			if err := qb.list(row); err != nil {
+				return "", nil, err
+			}
+			qb.WriteString(")")
+			continue
+		}
+
+		if len(row) != 1 {
+			return "", nil, xInvalidArgument("Invalid number of fields for a document: %d", len(row))
+		}
+		doc, err := qb.resolve(row[0])
+		if err != nil {
+			return "", nil, err
+		}
+		if doc.typ == xExprLiteral && doc.literal.isString() {
+			// Documents can be sent as JSON strings.
+			doc = &xExpr{typ: xExprLiteral, literal: &xScalar{typ: xScalarOctets, bytes: doc.literal.bytes, contentType: xContentTypeJSON}}
+		}
+		if xDocumentNeedsID(doc) {
+			id := newID()
+			ids = append(ids, id)
+			qb.WriteString("JSON_INSERT(")
+			if err := qb.jsonExpr(doc); err != nil {
+				return "", nil, err
+			}
+			qb.WriteString(", '$._id', " + sqltypes.EncodeStringSQL(id) + ")")
+		} else if err := qb.jsonExpr(doc); err != nil {
+			return "", nil, err
+		}
+		qb.WriteString(")")
+	}
+	if crud.upsert {
+		qb.WriteString(" ON DUPLICATE KEY UPDATE doc = VALUES(doc)")
+	}
+	return qb.buf.String(), ids, nil
+}
+
+// xDocumentNeedsID returns true if the document to insert is an object
+// without an _id member.
+func xDocumentNeedsID(doc *xExpr) bool {
+	switch doc.typ {
+	case xExprObject:
+		for _, field := range doc.object {
+			if field.key == "_id" {
+				return false
+			}
+		}
+		return true
+	case xExprLiteral:
+		if !doc.literal.isString() {
+			return false
+		}
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(doc.literal.bytes, &members); err != nil {
+			// Not an object, let the server complain about it.
+			return false
+		}
+		_, ok := members["_id"]
+		return !ok
+	}
+	return false
+}
+
+// xUpdateFunctions are the JSON functions of the update operations.
+var xUpdateFunctions = map[uint64]string{
+	xUpdateItemRemove:  "JSON_REMOVE",
+	xUpdateItemSet:     "JSON_SET",
+	xUpdateItemReplace: "JSON_REPLACE",
+	xUpdateItemMerge:   "JSON_MERGE_PRESERVE",
+	xUpdateArrayInsert: "JSON_ARRAY_INSERT",
+	xUpdateArrayAppend: "JSON_ARRAY_APPEND",
+	xUpdateMergePatch:  "JSON_MERGE_PATCH",
+}
+
+// xUpdateQuery returns the UPDATE query of a Mysqlx.Crud.Update message.
+func xUpdateQuery(crud *xCrud) (string, error) {
+	qb := &xQueryBuilder{crud: crud}
+	if len(crud.operations) == 0 {
+		return "", xInvalidArgument("Invalid parameter: list of update operations is empty")
+	}
+	qb.WriteString("UPDATE ")
+	qb.table()
+	qb.WriteString(" SET ")
+
+	// The operations on the same column are nested in a single assignment,
+	// which is the only one for documents.
+	var assignments []string
+	values := map[string]string{}
+	for _, operation := range crud.operations {
+		if operation.source == nil {
+			return "", xInvalidArgument("Invalid update operation: missing source")
+		}
+		colqb := &xQueryBuilder{crud: crud}
+		if err := colqb.column(&xColumnIdent{name: operation.source.name}); err != nil {
+			return "", err
+		}
+		column := colqb.buf.String()
+		value, ok := values[column]
+		if !ok {
+			assignments = append(assignments, column)
+			value = column
+		}
+
+		opqb := &xQueryBuilder{crud: crud}
+		switch fn, ok := xUpdateFunctions[operation.op]; {
+		case operation.op == xUpdateSet:
+			if qb.document() || len(operation.source.path) > 0 {
+				return "", xInvalidArgument("Invalid type of update operation for document")
+			}
+			if err := opqb.expr(operation.value); err != nil {
+				return "", err
+			}
+		case operation.op == xUpdateItemMerge, operation.op == xUpdateMergePatch:
+			opqb.WriteString(fn + "(" + value + ", ")
+			if err := opqb.jsonExpr(operation.value); err != nil {
+				return "", err
+			}
+			opqb.WriteString(")")
+		case ok:
+			if len(operation.source.path) == 0 {
+				return "", xInvalidArgument("Invalid document path of update operation")
+			}
+			opqb.WriteString(fn + "(" + value + ", ")
+			if err := opqb.path(operation.source.path); err != nil {
+				return "", err
+			}
+			if operation.op != xUpdateItemRemove {
+				opqb.WriteString(", ")
+				if err := opqb.expr(operation.value); err != nil {
+					return "", err
+				}
+			}
+			opqb.WriteString(")")
+		default:
+			return "", xInvalidArgument("Invalid type of update operation %d", operation.op)
+		}
+		values[column] = opqb.buf.String()
+	}
+	for i, column := range assignments {
+		if i > 0 {
+			qb.WriteString(", ")
+		}
+		qb.WriteString(column + " = " + values[column])
+	}
+	if err := qb.where(false); err != nil {
+		return "", err
+	}
+	return qb.buf.String(), nil
+}
+
+// xDeleteQuery returns the DELETE query of a Mysqlx.Crud.Delete message.
+func xDeleteQuery(crud *xCrud) (string, error) {
+	qb := &xQueryBuilder{crud: crud}
+	qb.WriteString("DELETE FROM ")
+	qb.table()
+	if err := qb.where(false); err != nil {
+		return "", err
+	}
+	return qb.buf.String(), nil
+}
@@ -0,0 +1,593 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+/*
+The messages of the X Protocol are protocol buffers, defined in the
+mysqlx*.proto files of the MySQL server:
+https://dev.mysql.com/doc/dev/mysql-server/latest/page_mysqlx_protocol_messages.html
+
+We only need a small subset of them, so they are encoded and decoded by hand
+with protowire, instead of generating code for all of them.
+*/
+
+// Mysqlx.Datatypes.Scalar.Type
+const (
+	xScalarSint   = 1
+	xScalarUint   = 2
+	xScalarNull   = 3
+	xScalarOctets = 4
+	xScalarDouble = 5
+	xScalarFloat  = 6
+	xScalarBool   = 7
+	xScalarString = 8
+)
+
+// Mysqlx.Datatypes.Any.Type
+const (
+	xAnyScalar = 1
+	xAnyObject = 2
+	xAnyArray  = 3
+)
+
+// Mysqlx.Resultset.ColumnMetaData.FieldType
+const (
+	xColumnSint     = 1
+	xColumnUint     = 2
+	xColumnDouble   = 5
+	xColumnFloat    = 6
+	xColumnBytes    = 7
+	xColumnTime     = 10
+	xColumnDatetime = 12
+	xColumnSet      = 15
+	xColumnEnum     = 16
+	xColumnBit      = 17
+	xColumnDecimal  = 18
+)
+
+// Mysqlx.Resultset.ContentType_BYTES and ContentType_DATETIME
+const (
+	xContentTypeGeometry = 1
+	xContentTypeJSON     = 2
+	xContentTypeDate     = 1
+	xContentTypeDatetime = 2
+)
+
+// Mysqlx.Notice.Frame.Type, Scope and SessionStateChanged.Parameter
+const (
+	xNoticeSessionStateChanged = 3
+	xNoticeScopeLocal          = 2
+
+	xStateGeneratedInsertID   = 3
+	xStateRowsAffected        = 4
+	xStateClientIDAssigned    = 11
+	xStateGeneratedDocumentID = 12
+)
+
+// xMalformedMessage returns the error for a message we can't decode.
+func xMalformedMessage(n int) error {
+	return sqlerror.NewSQLError(xErrBadMessage, sqlerror.SSUnknownSQLState, "Invalid message: %v", protowire.ParseError(n))
+}
+
+// xDecodeFields calls fn for each field of the encoded protobuf message
+// data. Only one of b and v is set, depending on the wire type: b for
+// length delimited fields, v for the others.
+func xDecodeFields(data []byte, fn func(num protowire.Number, b []byte, v uint64) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return xMalformedMessage(n)
+		}
+		data = data[n:]
+
+		var b []byte
+		var v uint64
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed32Type:
+			var v32 uint32
+			v32, n = protowire.ConsumeFixed32(data)
+			v = uint64(v32)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(data)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return xMalformedMessage(n)
+		}
+		data = data[n:]
+
+		if err := fn(num, b, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xAppendBytes appends a length delimited field to b.
+func xAppendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// xAppendVarint appends a varint field to b.
+func xAppendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// xScalar is a Mysqlx.Datatypes.Scalar.
+type xScalar struct {
+	typ         uint64
+	signed      int64
+	unsigned    uint64
+	double      float64
+	float       float32
+	boolean     bool
+	bytes       []byte
+	contentType uint64
+}
+
+func xDecodeScalar(data []byte) (*xScalar, error) {
+	s := &xScalar{}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		switch num {
+		case 1:
+			s.typ = v
+		case 2:
+			s.signed = protowire.DecodeZigZag(v)
+		case 3:
+			s.unsigned = v
+		case 5, 9:
+			// Octets and String both have the value as their first field,
+			// followed by the content type or the collation.
+			return xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				switch num {
+				case 1:
+					s.bytes = b
+				case 2:
+					s.contentType = v
+				}
+				return nil
+			})
+		case 6:
+			s.double = math.Float64frombits(v)
+		case 7:
+			s.float = math.Float32frombits(uint32(v))
+		case 8:
+			s.boolean = v != 0
+		}
+		return nil
+	})
+	return s, err
+}
+
+// value returns the scalar as a value that can be used as a bind variable
+// or a literal of a query.
+func (s *xScalar) value() (sqltypes.Value, error) {
+	switch s.typ {
+	case xScalarSint:
+		return sqltypes.NewInt64(s.signed), nil
+	case xScalarUint:
+		return sqltypes.NewUint64(s.unsigned), nil
+	case xScalarNull:
+		return sqltypes.NULL, nil
+	case xScalarOctets:
+		return sqltypes.NewVarBinary(string(s.bytes)), nil
+	case xScalarDouble:
+		return sqltypes.NewFloat64(s.double), nil
+	case xScalarFloat:
+		return sqltypes.MakeTrusted(sqltypes.Float64, strconv.AppendFloat(nil, float64(s.float), 'g', -1, 32)), nil
+	case xScalarBool:
+		if s.boolean {
+			return sqltypes.NewInt64(1), nil
+		}
+		return sqltypes.NewInt64(0), nil
+	case xScalarString:
+		return sqltypes.NewVarChar(string(s.bytes)), nil
+	}
+	return sqltypes.Value{}, sqlerror.NewSQLError(xErrBadMessage, sqlerror.SSUnknownSQLState, "Invalid scalar type %d", s.typ)
+}
+
+// isString returns true if the scalar is a string or octets.
+func (s *xScalar) isString() bool {
+	return s.typ == xScalarString || s.typ == xScalarOctets
+}
+
+// xAny is a Mysqlx.Datatypes.Any.
+type xAny struct {
+	typ    uint64
+	scalar *xScalar
+	object []xAnyField
+	array  []*xAny
+}
+
+// xAnyField is a Mysqlx.Datatypes.Object.ObjectField.
+type xAnyField struct {
+	key   string
+	value *xAny
+}
+
+func xDecodeAny(data []byte) (*xAny, error) {
+	a := &xAny{}
+	err := xDecodeFields(data, func(num protowire.Number, b []byte, v uint64) error {
+		var err error
+		switch num {
+		case 1:
+			a.typ = v
+		case 2:
+			a.scalar, err = xDecodeScalar(b)
+		case 3:
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				if num != 1 {
+					return nil
+				}
+				var field xAnyField
+				err := xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+					var err error
+					switch num {
+					case 1:
+						field.key = string(b)
+					case 2:
+						field.value, err = xDecodeAny(b)
+					}
+					return err
+				})
+				a.object = append(a.object, field)
+				return err
+			})
+		case 4:
+			err = xDecodeFields(b, func(num protowire.Number, b []byte, v uint64) error {
+				if num != 1 {
+					return nil
+				}
+				value, err := xDecodeAny(b)
+				a.array = append(a.array, value)
+				return err
+			})
+		}
+		return err
+	})
+	return a, err
+}
+
+// field returns the value of the field named key of an object, or nil.
+func (a *xAny) field(key string) *xAny {
+	for _, f := range a.object {
+		if f.key == key {
+			return f.value
+		}
+	}
+	return nil
+}
+
+// stringValue returns the value of a string scalar, and false if the value
+// is not a string.
+func (a *xAny) stringValue() (string, bool) {
+	if a == nil || a.typ != xAnyScalar || a.scalar == nil || !a.scalar.isString() {
+		return "", false
+	}
+	return string(a.scalar.bytes), true
+}
+
+// xAppendScalarUint appends an unsigned integer Scalar to b.
+func xAppendScalarUint(b []byte, v uint64) []byte {
+	b = xAppendVarint(b, 1, xScalarUint)
+	return xAppendVarint(b, 3, v)
+}
+
+// xAppendScalarString appends a string Scalar to b.
+func xAppendScalarString(b []byte, v string) []byte {
+	b = xAppendVarint(b, 1, xScalarString)
+	return xAppendBytes(b, 9, xAppendBytes(nil, 1, []byte(v)))
+}
+
+// xAppendScalarBool appends a boolean Scalar to b.
+func xAppendScalarBool(b []byte, v bool) []byte {
+	b = xAppendVarint(b, 1, xScalarBool)
+	return xAppendVarint(b, 8, protowire.EncodeBool(v))
+}
+
+// xAnyScalarBytes returns an Any that wraps the encoded Scalar.
+func xAnyScalarBytes(scalar []byte) []byte {
+	b := xAppendVarint(nil, 1, xAnyScalar)
+	return xAppendBytes(b, 2, scalar)
+}
+
+// xAnyStringArrayBytes returns an Any holding an array of strings.
+func xAnyStringArrayBytes(values ...string) []byte {
+	var array []byte
+	for _, v := range values {
+		array = xAppendBytes(array, 1, xAnyScalarBytes(xAppendScalarString(nil, v)))
+	}
+	b := xAppendVarint(nil, 1, xAnyArray)
+	return xAppendBytes(b, 4, array)
+}
+
+// xErrorBytes returns a Mysqlx.Error for err.
+func xErrorBytes(err error) []byte {
+	serr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+	b := xAppendVarint(nil, 2, uint64(serr.Number()))
+	b = xAppendBytes(b, 3, []byte(serr.Message))
+	return xAppendBytes(b, 4, []byte(serr.SQLState()))
+}
+
+// xSessionStateChangedBytes returns a local Mysqlx.Notice.Frame that holds
+// a SessionStateChanged notice for the encoded scalar values.
+func xSessionStateChangedBytes(param uint64, values ...[]byte) []byte {
+	payload := xAppendVarint(nil, 1, param)
+	for _, v := range values {
+		payload = xAppendBytes(payload, 2, v)
+	}
+	b := xAppendVarint(nil, 1, xNoticeSessionStateChanged)
+	b = xAppendVarint(b, 2, xNoticeScopeLocal)
+	return xAppendBytes(b, 3, payload)
+}
+
+// xColumnType returns the X Protocol type and content type of a field.
+func xColumnType(field *querypb.Field) (uint64, uint64) {
+	switch typ := field.Type; {
+	case sqltypes.IsSigned(typ):
+		return xColumnSint, 0
+	case sqltypes.IsUnsigned(typ), typ == sqltypes.Year:
+		return xColumnUint, 0
+	case typ == sqltypes.Float32:
+		return xColumnFloat, 0
+	case typ == sqltypes.Float64:
+		return xColumnDouble, 0
+	case typ == sqltypes.Decimal:
+		return xColumnDecimal, 0
+	case typ == sqltypes.Time:
+		return xColumnTime, 0
+	case typ == sqltypes.Date:
+		return xColumnDatetime, xContentTypeDate
+	case typ == sqltypes.Datetime, typ == sqltypes.Timestamp:
+		return xColumnDatetime, xContentTypeDatetime
+	case typ == sqltypes.Bit:
+		return xColumnBit, 0
+	case typ == sqltypes.Enum:
+		return xColumnEnum, 0
+	case typ == sqltypes.Set:
+		return xColumnSet, 0
+	case typ == sqltypes.TypeJSON:
+		return xColumnBytes, xContentTypeJSON
+	case typ == sqltypes.Geometry:
+		return xColumnBytes, xContentTypeGeometry
+	}
+	return xColumnBytes, 0
+}
+
+// xColumnMetaDataBytes returns a Mysqlx.Resultset.ColumnMetaData for a field.
+func xColumnMetaDataBytes(field *querypb.Field) []byte {
+	typ, contentType := xColumnType(field)
+	b := xAppendVarint(nil, 1, typ)
+	b = xAppendBytes(b, 2, []byte(field.Name))
+	b = xAppendBytes(b, 3, []byte(field.OrgName))
+	b = xAppendBytes(b, 4, []byte(field.Table))
+	b = xAppendBytes(b, 5, []byte(field.OrgTable))
+	b = xAppendBytes(b, 6, []byte(field.Database))
+	b = xAppendBytes(b, 7, []byte("def"))
+	if typ == xColumnBytes || typ == xColumnEnum || typ == xColumnSet {
+		b = xAppendVarint(b, 8, uint64(field.Charset))
+	}
+	if typ == xColumnDouble || typ == xColumnFloat || typ == xColumnDecimal {
+		b = xAppendVarint(b, 9, uint64(field.Decimals))
+	}
+	b = xAppendVarint(b, 10, uint64(field.ColumnLength))
+	b = xAppendVarint(b, 11, uint64(field.Flags))
+	if contentType != 0 {
+		b = xAppendVarint(b, 12, contentType)
+	}
+	return b
+}
+
+// xRowBytes returns a Mysqlx.Resultset.Row for a row of a result.
+func xRowBytes(fields []*querypb.Field, row []sqltypes.Value) ([]byte, error) {
+	var b []byte
+	for i, v := range row {
+		value, err := xEncodeValue(fields[i], v)
+		if err != nil {
+			return nil, err
+		}
+		b = xAppendBytes(b, 1, value)
+	}
+	return b, nil
+}
+
+// xEncodeValue encodes a value of a row in the binary format of its
+// X Protocol type. NULL is the only value encoded as empty bytes.
+func xEncodeValue(field *querypb.Field, v sqltypes.Value) ([]byte, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	raw := v.Raw()
+	typ, contentType := xColumnType(field)
+	switch typ {
+	case xColumnSint:
+		i, err := v.ToInt64()
+		if err != nil {
+			return nil, err
+		}
+		return protowire.AppendVarint(nil, protowire.EncodeZigZag(i)), nil
+	case xColumnUint:
+		u, err := v.ToUint64()
+		if err != nil {
+			return nil, err
+		}
+		return protowire.AppendVarint(nil, u), nil
+	case xColumnDouble:
+		f, err := v.ToFloat64()
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)), nil
+	case xColumnFloat:
+		f, err := strconv.ParseFloat(string(raw), 32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(f))), nil
+	case xColumnBit:
+		// BIT values are sent as big-endian bytes by the MySQL protocol.
+		var u uint64
+		for _, c := range raw {
+			u = u<<8 | uint64(c)
+		}
+		return protowire.AppendVarint(nil, u), nil
+	case xColumnDecimal:
+		return xEncodeDecimal(string(raw))
+	case xColumnTime:
+		return xEncodeTime(string(raw))
+	case xColumnDatetime:
+		return xEncodeDatetime(string(raw), contentType == xContentTypeDate)
+	case xColumnSet:
+		if len(raw) == 0 {
+			// The empty set is a single 0x01 byte, as empty bytes are NULL.
+			return []byte{0x01}, nil
+		}
+		var b []byte
+		for _, member := range strings.Split(string(raw), ",") {
+			b = protowire.AppendString(b, member)
+		}
+		return b, nil
+	}
+	// Strings, enums and everything else are followed by a 0x00 byte, to
+	// tell the empty string from NULL.
+	return append(append(make([]byte, 0, len(raw)+1), raw...), 0x00), nil
+}
+
+// xEncodeDecimal encodes a DECIMAL as its scale, followed by its digits in
+// packed BCD and a sign nibble: 0xc for positive and 0xd for negative
+// numbers. The sign takes a whole byte if the number of digits is even.
+func xEncodeDecimal(s string) ([]byte, error) {
+	sign := byte(0xc)
+	if strings.HasPrefix(s, "-") {
+		sign = 0xd
+		s = s[1:]
+	}
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = len(s) - i - 1
+		s = s[:i] + s[i+1:]
+	}
+	if scale > math.MaxUint8 || len(s) == 0 || strings.Trim(s, "0123456789") != "" {
+		return nil, sqlerror.NewSQLError(sqlerror.ERUnknownError, sqlerror.SSUnknownSQLState, "invalid DECIMAL value %q", s)
+	}
+	b := []byte{byte(scale)}
+	for i := 0; i < len(s); i += 2 {
+		lo := sign
+		if i+1 < len(s) {
+			lo = s[i+1] - '0'
+		}
+		b = append(b, (s[i]-'0')<<4|lo)
+	}
+	if len(s)%2 == 0 {
+		b = append(b, sign<<4)
+	}
+	return b, nil
+}
+
+// xEncodeTime encodes a TIME as a sign byte, 0x01 for negative values,
+// followed by the hours, minutes, seconds and microseconds as varints.
+// The microseconds are omitted if they are zero.
+func xEncodeTime(s string) ([]byte, error) {
+	var negative byte
+	if strings.HasPrefix(s, "-") {
+		negative = 1
+		s = s[1:]
+	}
+	parts, err := xParseTimeParts(s)
+	if err != nil {
+		return nil, err
+	}
+	b := []byte{negative}
+	for _, part := range parts {
+		b = protowire.AppendVarint(b, part)
+	}
+	return b, nil
+}
+
+// xEncodeDatetime encodes a DATE, DATETIME or TIMESTAMP as the year,
+// month, day, hours, minutes, seconds and microseconds as varints. The time
+// is omitted for dates, and the microseconds are omitted if they are zero.
+func xEncodeDatetime(s string, dateOnly bool) ([]byte, error) {
+	date, clock, _ := strings.Cut(s, " ")
+	var b []byte
+	for _, part := range strings.Split(date, "-") {
+		u, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendVarint(b, u)
+	}
+	if dateOnly || clock == "" {
+		return b, nil
+	}
+	parts, err := xParseTimeParts(clock)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		b = protowire.AppendVarint(b, part)
+	}
+	return b, nil
+}
+
+// xParseTimeParts parses the hours, minutes, seconds and microseconds of
+// "hh:mm:ss[.ffffff]", without the microseconds if they are zero.
+func xParseTimeParts(s string) ([]uint64, error) {
+	clock, frac, _ := strings.Cut(s, ".")
+	var parts []uint64
+	for _, part := range strings.Split(clock, ":") {
+		u, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, u)
+	}
+	if frac != "" {
+		// Scale the fraction to microseconds.
+		for len(frac) < 6 {
+			frac += "0"
+		}
+		us, err := strconv.ParseUint(frac[:6], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if us != 0 {
+			parts = append(parts, us)
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,514 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// xTestClient is a minimal X Protocol client.
+type xTestClient struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func (tc *xTestClient) write(typ byte, payload []byte) {
+	header := binary.LittleEndian.AppendUint32(nil, uint32(len(payload)+1))
+	_, err := tc.conn.Write(append(append(header, typ), payload...))
+	require.NoError(tc.t, err)
+}
+
+func (tc *xTestClient) read() (byte, []byte) {
+	var header [5]byte
+	_, err := io.ReadFull(tc.conn, header[:])
+	require.NoError(tc.t, err)
+	payload := make([]byte, binary.LittleEndian.Uint32(header[:4])-1)
+	_, err = io.ReadFull(tc.conn, payload)
+	require.NoError(tc.t, err)
+	return header[4], payload
+}
+
+// fields returns the values of the fields of a message by field number.
+func (tc *xTestClient) fields(payload []byte) map[protowire.Number][][]byte {
+	fields := map[protowire.Number][][]byte{}
+	err := xDecodeFields(payload, func(num protowire.Number, b []byte, v uint64) error {
+		if b == nil {
+			b = protowire.AppendVarint(nil, v)
+		}
+		fields[num] = append(fields[num], b)
+		return nil
+	})
+	require.NoError(tc.t, err)
+	return fields
+}
+
+// expectError reads a Mysqlx.Error and returns its code and message.
+func (tc *xTestClient) expectError() (uint64, string) {
+	typ, payload := tc.read()
+	require.EqualValues(tc.t, xServerError, typ)
+	fields := tc.fields(payload)
+	code, _ := protowire.ConsumeVarint(fields[2][0])
+	return code, string(fields[3][0])
+}
+
+// authenticate authenticates with MYSQL41, and returns the error message
+// of the server if it fails.
+func (tc *xTestClient) authenticate(schema, user, password string) string {
+	tc.write(xClientSessAuthStart, xAppendBytes(nil, 1, []byte(xAuthMechanismMySQL41)))
+	typ, payload := tc.read()
+	require.EqualValues(tc.t, xServerSessAuthContinue, typ)
+	salt := tc.fields(payload)[1][0]
+	require.Len(tc.t, salt, 20)
+
+	response := schema + "\x00" + user + "\x00*" + strings.ToUpper(hex.EncodeToString(ScrambleMysqlNativePassword(salt, []byte(password))))
+	tc.write(xClientSessAuthContinue, xAppendBytes(nil, 1, []byte(response)))
+	typ, payload = tc.read()
+	if typ == xServerError {
+		return string(tc.fields(payload)[3][0])
+	}
+	require.EqualValues(tc.t, xServerNotice, typ)
+	typ, _ = tc.read()
+	require.EqualValues(tc.t, xServerSessAuthOk, typ)
+	return ""
+}
+
+// execute executes a statement and returns the rows of its result as
+// the encoded fields, and the notices.
+func (tc *xTestClient) execute(stmt string, args ...[]byte) ([][][]byte, [][]byte) {
+	payload := xAppendBytes(nil, 1, []byte(stmt))
+	for _, arg := range args {
+		payload = xAppendBytes(payload, 2, xAnyScalarBytes(arg))
+	}
+	tc.write(xClientSQLStmtExecute, payload)
+	return tc.readResult()
+}
+
+func (tc *xTestClient) readResult() ([][][]byte, [][]byte) {
+	var rows [][][]byte
+	var notices [][]byte
+	for {
+		typ, payload := tc.read()
+		switch typ {
+		case xServerColumnMetaData, xServerFetchDone:
+		case xServerRow:
+			rows = append(rows, tc.fields(payload)[1])
+		case xServerNotice:
+			notices = append(notices, tc.fields(payload)[3][0])
+		case xServerSQLStmtExecuteOk:
+			return rows, notices
+		default:
+			require.FailNow(tc.t, "unexpected message", "type %d", typ)
+		}
+	}
+}
+
+func newXTestListener(t *testing.T, th Handler) *Listener {
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	t.Cleanup(authServer.close)
+
+	l, err := NewListenerWithConfig(ListenerConfig{
+		Protocol:   "tcp",
+		Address:    "127.0.0.1:",
+		AuthServer: authServer,
+		Handler:    th,
+		XProtocol:  true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(l.Close)
+	go l.Accept()
+	return l
+}
+
+func newXTestClient(t *testing.T, l *Listener) *xTestClient {
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &xTestClient{t: t, conn: conn}
+}
+
+func TestXProtocol(t *testing.T) {
+	th := &testHandler{}
+	l := newXTestListener(t, th)
+	tc := newXTestClient(t, l)
+
+	tc.write(xClientConCapabilitiesGet, nil)
+	typ, payload := tc.read()
+	require.EqualValues(t, xServerConCapabilities, typ)
+	var names []string
+	for _, capability := range tc.fields(payload)[1] {
+		names = append(names, string(tc.fields(capability)[1][0]))
+	}
+	assert.Contains(t, names, xCapabilityAuthMechanisms)
+	assert.NotContains(t, names, xCapabilityTLS)
+
+	tc.write(xClientConCapabilitiesSet, xAppendBytes(nil, 1, xAppendBytes(nil, 1, xAppendBytes(xAppendBytes(nil, 1, []byte("unknown")), 2, xAnyScalarBytes(xAppendScalarBool(nil, true))))))
+	code, msg := tc.expectError()
+	assert.EqualValues(t, xErrCapabilityNotFound, code)
+	assert.Equal(t, "Capability 'unknown' doesn't exist", msg)
+
+	// A failed authentication can be retried.
+	assert.Equal(t, "Access denied for user 'user1'", tc.authenticate("", "user1", "bad password"))
+	require.Empty(t, tc.authenticate("", "user1", "password1"))
+	assert.Equal(t, "user1", th.LastConn().User)
+	assert.EqualValues(t, xFirstConnectionID, th.LastConn().ConnectionID)
+
+	rows, notices := tc.execute("select rows")
+	require.Len(t, rows, 2)
+	assert.Equal(t, protowire.AppendVarint(nil, protowire.EncodeZigZag(10)), rows[0][0])
+	assert.Equal(t, []byte("nice name\x00"), rows[0][1])
+	assert.Equal(t, []byte("nicer name\x00"), rows[1][1])
+	assert.Empty(t, notices)
+
+	rows, notices = tc.execute("insert")
+	assert.Empty(t, rows)
+	require.Len(t, notices, 2)
+	assert.Equal(t, xAppendBytes(xAppendVarint(nil, 1, xStateRowsAffected), 2, xAppendScalarUint(nil, 123)), notices[0])
+	assert.Equal(t, xAppendBytes(xAppendVarint(nil, 1, xStateGeneratedInsertID), 2, xAppendScalarUint(nil, 123456789)), notices[1])
+
+	th.SetErr(sqlerror.NewSQLError(sqlerror.ERUnknownError, sqlerror.SSUnknownSQLState, "forced query error"))
+	tc.write(xClientSQLStmtExecute, xAppendBytes(nil, 1, []byte("error")))
+	code, msg = tc.expectError()
+	assert.EqualValues(t, sqlerror.ERUnknownError, code)
+	assert.Equal(t, "forced query error", msg)
+
+	tc.write(xClientSQLStmtExecute, xAppendBytes(xAppendBytes(nil, 1, []byte("ping")), 3, []byte(xNamespaceMysqlx)))
+	typ, _ = tc.read()
+	assert.EqualValues(t, xServerSQLStmtExecuteOk, typ)
+
+	tc.write(xClientSQLStmtExecute, xAppendBytes(xAppendBytes(nil, 1, []byte("select rows")), 3, []byte("unknown")))
+	code, _ = tc.expectError()
+	assert.EqualValues(t, xErrInvalidNamespace, code)
+
+	tc.write(xClientSessClose, nil)
+	typ, _ = tc.read()
+	assert.EqualValues(t, xServerOk, typ)
+}
+
+func TestXProtocolAuthenticationFailed(t *testing.T) {
+	h := &authFailedHandler{testHandler: &testHandler{}}
+	l := newXTestListener(t, h)
+	tc := newXTestClient(t, l)
+	assert.Equal(t, "Access denied for user 'user1'", tc.authenticate("", "user1", "bad password"))
+	assert.Equal(t, "Access denied for user 'user2'", tc.authenticate("", "user2", "password1"))
+	require.Empty(t, tc.authenticate("", "user1", "password1"))
+	assert.Equal(t, []string{"user1", "user2"}, h.failedUsers())
+}
+
+// xRecordingHandler records the queries it executes.
+type xRecordingHandler struct {
+	*testHandler
+	mu      sync.Mutex
+	queries []string
+}
+
+func (h *xRecordingHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	h.mu.Lock()
+	h.queries = append(h.queries, query)
+	h.mu.Unlock()
+	return h.testHandler.ComQuery(c, query, callback)
+}
+
+func (h *xRecordingHandler) lastQuery() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.queries[len(h.queries)-1]
+}
+
+func TestXProtocolQueries(t *testing.T) {
+	h := &xRecordingHandler{testHandler: &testHandler{}}
+	l := newXTestListener(t, h)
+	tc := newXTestClient(t, l)
+	require.Empty(t, tc.authenticate("ks", "user1", "password1"))
+	assert.Equal(t, "use `ks`", h.lastQuery())
+
+	_, _ = tc.execute("select ? from t where name = ?", xAppendScalarUint(nil, 1), xAppendScalarString(nil, "it's"))
+	assert.Equal(t, "select 1 from t where `name` = 'it\\'s'", h.lastQuery())
+
+	tc.write(xClientSQLStmtExecute, xAppendBytes(xAppendBytes(nil, 1, []byte("select ?, ?")), 2, xAnyScalarBytes(xAppendScalarUint(nil, 1))))
+	code, msg := tc.expectError()
+	assert.EqualValues(t, sqlerror.ERUnknownError, code)
+	assert.Contains(t, msg, "missing bind var v2")
+
+	// collection.find("age > 21")
+	criteria := xAppendVarint(nil, 1, xExprOperator)
+	criteria = xAppendBytes(criteria, 6, xAppendBytes(xAppendBytes(xAppendBytes(nil, 1, []byte(">")),
+		2, xAppendBytes(xAppendVarint(nil, 1, xExprIdent), 2, xAppendBytes(nil, 1, xAppendBytes(xAppendVarint(nil, 1, xPathMember), 2, []byte("age"))))),
+		2, xAppendBytes(xAppendVarint(nil, 1, xExprLiteral), 4, xAppendScalarUint(nil, 21))))
+	find := xAppendBytes(nil, 2, xAppendBytes(nil, 1, []byte("people")))
+	find = xAppendVarint(find, 3, xDataModelDocument)
+	find = xAppendBytes(find, 5, criteria)
+	tc.write(xClientCrudFind, find)
+	_, _ = tc.readResult()
+	assert.Equal(t, "SELECT doc FROM `people` WHERE (JSON_EXTRACT(doc, '$.age') > 21)", h.lastQuery())
+
+	// collection.add({"name": "Jo"})
+	doc := xAppendVarint(nil, 1, xExprObject)
+	doc = xAppendBytes(doc, 8, xAppendBytes(nil, 1, xAppendBytes(xAppendBytes(nil, 1, []byte("name")), 2, xAppendBytes(xAppendVarint(nil, 1, xExprLiteral), 4, xAppendScalarString(nil, "Jo")))))
+	insert := xAppendBytes(nil, 1, xAppendBytes(nil, 1, []byte("people")))
+	insert = xAppendVarint(insert, 2, xDataModelDocument)
+	insert = xAppendBytes(insert, 4, xAppendBytes(nil, 1, doc))
+	tc.write(xClientCrudInsert, insert)
+	_, notices := tc.readResult()
+	require.Len(t, notices, 2)
+	ids := tc.fields(notices[1])
+	assert.Equal(t, protowire.AppendVarint(nil, xStateGeneratedDocumentID), ids[1][0])
+	id := string(tc.fields(tc.fields(ids[2][0])[9][0])[1][0])
+	assert.Len(t, id, 28)
+	assert.Equal(t, "INSERT INTO `people` (doc) VALUES (JSON_INSERT(JSON_OBJECT('name', 'Jo'), '$._id', '"+id+"'))", h.lastQuery())
+
+	tc.write(xClientSQLStmtExecute, xAppendBytes(xAppendBytes(xAppendBytes(nil, 1, []byte("create_collection")), 2,
+		xAppendBytes(xAppendVarint(nil, 1, xAnyObject), 3,
+			xAppendBytes(xAppendBytes(nil, 1, xAppendBytes(xAppendBytes(nil, 1, []byte("schema")), 2, xAnyScalarBytes(xAppendScalarString(nil, "ks")))),
+				1, xAppendBytes(xAppendBytes(nil, 1, []byte("name")), 2, xAnyScalarBytes(xAppendScalarString(nil, "people")))))),
+		3, []byte(xNamespaceMysqlx)))
+	_, _ = tc.readResult()
+	assert.Equal(t, "CREATE TABLE `ks`.`people` (doc JSON, _id VARBINARY(32) GENERATED ALWAYS AS (JSON_UNQUOTE(JSON_EXTRACT(doc, '$._id'))) STORED NOT NULL, PRIMARY KEY (_id))", h.lastQuery())
+}
+
+func TestXProtocolPlain(t *testing.T) {
+	th := &testHandler{}
+	l := newXTestListener(t, th)
+	tc := newXTestClient(t, l)
+
+	start := xAppendBytes(xAppendBytes(nil, 1, []byte(xAuthMechanismPlain)), 2, []byte("\x00user1\x00password1"))
+	tc.write(xClientSessAuthStart, start)
+	_, msg := tc.expectError()
+	assert.Equal(t, "Cannot use clear text authentication over non-SSL connections.", msg)
+
+	l.AllowClearTextWithoutTLS.Store(true)
+	tc.write(xClientSessAuthStart, start)
+	typ, _ := tc.read()
+	require.EqualValues(t, xServerNotice, typ)
+	typ, _ = tc.read()
+	require.EqualValues(t, xServerSessAuthOk, typ)
+}
+
+func TestXProtocolCrudQueries(t *testing.T) {
+	member := func(names ...string) *xExpr {
+		ident := &xColumnIdent{}
+		for _, name := range names {
+			ident.path = append(ident.path, xPathItem{typ: xPathMember, value: name})
+		}
+		return &xExpr{typ: xExprIdent, ident: ident}
+	}
+	column := func(name string) *xExpr {
+		return &xExpr{typ: xExprIdent, ident: &xColumnIdent{name: name}}
+	}
+	str := func(s string) *xExpr {
+		return &xExpr{typ: xExprLiteral, literal: &xScalar{typ: xScalarString, bytes: []byte(s)}}
+	}
+	uint := func(u uint64) *xExpr {
+		return &xExpr{typ: xExprLiteral, literal: &xScalar{typ: xScalarUint, unsigned: u}}
+	}
+	op := func(name string, params ...*xExpr) *xExpr {
+		return &xExpr{typ: xExprOperator, name: name, params: params}
+	}
+	placeholder := &xExpr{typ: xExprPlaceholder, position: 0}
+
+	testcases := []struct {
+		name  string
+		query func() (string, error)
+		want  string
+		err   string
+	}{{
+		name: "find documents",
+		query: func() (string, error) {
+			return xFindQuery(&xCrud{
+				schema:        "ks",
+				table:         "people",
+				dataModel:     xDataModelDocument,
+				criteria:      op("&&", op(">", member("age"), placeholder), op("like", member("name"), str("J%"))),
+				args:          []*xScalar{{typ: xScalarSint, signed: 21}},
+				order:         []xOrder{{expr: member("age"), desc: true}},
+				limitRowCount: uint(10),
+				limitOffset:   uint(5),
+			})
+		},
+		want: "SELECT doc FROM `ks`.`people` WHERE ((JSON_EXTRACT(doc, '$.age') > 21) AND (JSON_UNQUOTE(JSON_EXTRACT(doc, '$.name')) LIKE 'J%')) ORDER BY JSON_EXTRACT(doc, '$.age') DESC LIMIT 5, 10",
+	}, {
+		name: "find documents with projection",
+		query: func() (string, error) {
+			return xFindQuery(&xCrud{
+				table:     "people",
+				dataModel: xDataModelDocument,
+				columns:   []xProjection{{source: member("name")}, {source: member("address", "home town"), alias: "town"}},
+				criteria:  op("in", member("_id"), str("a"), str("b")),
+				locking:   xRowLockExclusive,
+				lockingOp: xRowLockNoWait,
+			})
+		},
+		want: "SELECT JSON_OBJECT('name', JSON_EXTRACT(doc, '$.name'), 'town', JSON_EXTRACT(doc, '$.address.\\\"home town\\\"')) AS doc FROM `people` WHERE (JSON_EXTRACT(doc, '$._id') IN ('a', 'b')) FOR UPDATE NOWAIT",
+	}, {
+		name: "find rows",
+		query: func() (string, error) {
+			return xFindQuery(&xCrud{
+				table:     "t",
+				dataModel: xDataModelTable,
+				columns:   []xProjection{{source: column("a")}, {source: op("+", column("b"), uint(1)), alias: "c"}},
+				criteria:  op("not_between", column("a"), uint(1), uint(2)),
+				grouping:  []*xExpr{column("a")},
+			})
+		},
+		want: "SELECT `a`, (`b` + 1) AS `c` FROM `t` WHERE (`a` NOT BETWEEN 1 AND 2) GROUP BY `a`",
+	}, {
+		name: "invalid operator",
+		query: func() (string, error) {
+			return xFindQuery(&xCrud{table: "t", criteria: op("drop table")})
+		},
+		err: "Invalid operator drop table",
+	}, {
+		name: "missing placeholder",
+		query: func() (string, error) {
+			return xFindQuery(&xCrud{table: "t", criteria: op("==", member("a"), placeholder)})
+		},
+		err: "Invalid value of placeholder 0",
+	}, {
+		name: "insert documents",
+		query: func() (string, error) {
+			query, ids, err := xInsertQuery(&xCrud{
+				table:     "people",
+				dataModel: xDataModelDocument,
+				rows: [][]*xExpr{
+					{{typ: xExprObject, object: []xExprField{{key: "name", value: str("Jo")}}}},
+					{placeholder},
+				},
+				args:   []*xScalar{{typ: xScalarString, bytes: []byte(`{"_id": "1", "name": "Al"}`)}},
+				upsert: true,
+			}, func() string { return "id1" })
+			if err == nil {
+				assert.Equal(t, []string{"id1"}, ids)
+			}
+			return query, err
+		},
+		want: "INSERT INTO `people` (doc) VALUES (JSON_INSERT(JSON_OBJECT('name', 'Jo'), '$._id', 'id1')), (CAST('{\\\"_id\\\": \\\"1\\\", \\\"name\\\": \\\"Al\\\"}' AS JSON)) ON DUPLICATE KEY UPDATE doc = VALUES(doc)",
+	}, {
+		name: "insert rows",
+		query: func() (string, error) {
+			query, _, err := xInsertQuery(&xCrud{
+				table:     "t",
+				dataModel: xDataModelTable,
+				columns:   []xProjection{{name: "a"}, {name: "b"}},
+				rows:      [][]*xExpr{{uint(1), str("x")}, {uint(2), str("y")}},
+			}, nil)
+			return query, err
+		},
+		want: "INSERT INTO `t` (`a`, `b`) VALUES (1, 'x'), (2, 'y')",
+	}, {
+		name: "update documents",
+		query: func() (string, error) {
+			return xUpdateQuery(&xCrud{
+				table:     "people",
+				dataModel: xDataModelDocument,
+				criteria:  op("==", member("_id"), str("id1")),
+				operations: []xUpdateOperation{
+					{source: member("name").ident, op: xUpdateItemSet, value: str("Joe")},
+					{source: member("age").ident, op: xUpdateItemRemove},
+				},
+				limitRowCount: uint(1),
+			})
+		},
+		want: "UPDATE `people` SET doc = JSON_REMOVE(JSON_SET(doc, '$.name', 'Joe'), '$.age') WHERE (JSON_EXTRACT(doc, '$._id') = 'id1') LIMIT 1",
+	}, {
+		name: "update rows",
+		query: func() (string, error) {
+			return xUpdateQuery(&xCrud{
+				table:     "t",
+				dataModel: xDataModelTable,
+				operations: []xUpdateOperation{
+					{source: &xColumnIdent{name: "a"}, op: xUpdateSet, value: uint(1)},
+					{source: &xColumnIdent{name: "j", path: []xPathItem{{typ: xPathArrayIndex, index: 2}}}, op: xUpdateArrayInsert, value: str("x")},
+				},
+			})
+		},
+		want: "UPDATE `t` SET `a` = 1, `j` = JSON_ARRAY_INSERT(`j`, '$[2]', 'x')",
+	}, {
+		name: "delete with offset",
+		query: func() (string, error) {
+			return xDeleteQuery(&xCrud{table: "t", limitRowCount: uint(1), limitOffset: uint(1)})
+		},
+		err: "non-zero offset value not allowed",
+	}, {
+		name: "delete",
+		query: func() (string, error) {
+			return xDeleteQuery(&xCrud{schema: "ks", table: "t", dataModel: xDataModelTable, criteria: op("is_not", column("a"), &xExpr{typ: xExprLiteral, literal: &xScalar{typ: xScalarNull}})})
+		},
+		want: "DELETE FROM `ks`.`t` WHERE (`a` IS NOT null)",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := tc.query()
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, query)
+		})
+	}
+}
+
+func TestXEncodeValue(t *testing.T) {
+	testcases := []struct {
+		typ   querypb.Type
+		value string
+		want  []byte
+	}{
+		{typ: sqltypes.Int64, value: "-1", want: []byte{0x01}},
+		{typ: sqltypes.Uint64, value: "300", want: []byte{0xac, 0x02}},
+		{typ: sqltypes.Year, value: "2024", want: []byte{0xe8, 0x0f}},
+		{typ: sqltypes.Float64, value: "1", want: []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}},
+		{typ: sqltypes.Decimal, value: "-12.3", want: []byte{0x01, 0x12, 0x3d}},
+		{typ: sqltypes.Decimal, value: "1.0", want: []byte{0x01, 0x10, 0xc0}},
+		{typ: sqltypes.Time, value: "-01:02:03.5", want: []byte{0x01, 0x01, 0x02, 0x03, 0xa0, 0xc2, 0x1e}},
+		{typ: sqltypes.Date, value: "2024-01-02", want: []byte{0xe8, 0x0f, 0x01, 0x02}},
+		{typ: sqltypes.Datetime, value: "2024-01-02 03:04:05", want: []byte{0xe8, 0x0f, 0x01, 0x02, 0x03, 0x04, 0x05}},
+		{typ: sqltypes.Bit, value: "\x01\x00", want: []byte{0x80, 0x02}},
+		{typ: sqltypes.Set, value: "", want: []byte{0x01}},
+		{typ: sqltypes.Set, value: "a,bc", want: []byte{0x01, 'a', 0x02, 'b', 'c'}},
+		{typ: sqltypes.VarChar, value: "", want: []byte{0x00}},
+		{typ: sqltypes.TypeJSON, value: `{"a": 1}`, want: []byte("{\"a\": 1}\x00")},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.typ.String()+" "+tc.value, func(t *testing.T) {
+			got, err := xEncodeValue(&querypb.Field{Type: tc.typ}, sqltypes.MakeTrusted(tc.typ, []byte(tc.value)))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	got, err := xEncodeValue(&querypb.Field{Type: sqltypes.VarChar}, sqltypes.NULL)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -335,6 +335,15 @@ func (result *Result) AppendResult(src *Result) {
 		result.InsertID = src.InsertID
 	}
 	result.Rows = append(result.Rows, src.Rows...)
+	if src.SessionStateChanges != "" {
+		// The session state changes hold GTIDs, the ones from
+		// multiple results form a GTID set.
+		if result.SessionStateChanges == "" {
+			result.SessionStateChanges = src.SessionStateChanges
+		} else {
+			result.SessionStateChanges += "," + src.SessionStateChanges
+		}
+	}
 }
 
 // Named returns a NamedResult based on this struct
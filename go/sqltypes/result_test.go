@@ -344,4 +344,11 @@ func TestAppendResult(t *testing.T) {
 	if !result.Equal(want) {
 		t.Errorf("Got:\n%#v, want:\n%#v", result, want)
 	}
+
+	// GTIDs of the appended results are merged into a GTID set.
+	result.AppendResult(&Result{RowsAffected: 1, SessionStateChanges: "uuid1:5"})
+	result.AppendResult(&Result{RowsAffected: 1, SessionStateChanges: "uuid2:7"})
+	if got, want := result.SessionStateChanges, "uuid1:5,uuid2:7"; got != want {
+		t.Errorf("SessionStateChanges: %q, want %q", got, want)
+	}
 }
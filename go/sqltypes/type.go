@@ -140,7 +140,7 @@ func IsSet(t querypb.Type) bool {
 //	IsIntegral(): INT8, UINT8, INT16, UINT16, INT24, UINT24, INT32, UINT32, INT64, UINT64, YEAR
 //	IsText(): TEXT, VARCHAR, CHAR, HEXNUM, HEXVAL, BITNUM
 //	IsNumber(): INT8, UINT8, INT16, UINT16, INT24, UINT24, INT32, UINT32, INT64, UINT64, FLOAT32, FLOAT64, YEAR, DECIMAL
-//	IsQuoted(): TIMESTAMP, DATE, TIME, DATETIME, TEXT, BLOB, VARCHAR, VARBINARY, CHAR, BINARY, ENUM, SET, GEOMETRY, JSON
+//	IsQuoted(): TIMESTAMP, DATE, TIME, DATETIME, TEXT, BLOB, VARCHAR, VARBINARY, CHAR, BINARY, ENUM, SET, GEOMETRY, JSON, VECTOR
 //	IsBinary(): BLOB, VARBINARY, BINARY
 //	IsDate(): TIMESTAMP, DATE, TIME, DATETIME
 //	IsNull(): NULL_TYPE
@@ -186,6 +186,7 @@ const (
 	HexVal     = querypb.Type_HEXVAL
 	Tuple      = querypb.Type_TUPLE
 	BitNum     = querypb.Type_BITNUM
+	Vector     = querypb.Type_VECTOR
 )
 
 // bit-shift the mysql flags by two byte so we
@@ -219,6 +220,7 @@ var mysqlToType = map[byte]querypb.Type{
 	17:  Timestamp,
 	18:  Datetime,
 	19:  Time,
+	242: Vector,
 	245: TypeJSON,
 	246: Decimal,
 	247: Enum,
@@ -335,6 +337,7 @@ var typeToMySQL = map[querypb.Type]struct {
 	Datetime:  {typ: 12, flags: mysqlBinary},
 	Year:      {typ: 13, flags: mysqlUnsigned},
 	Bit:       {typ: 16, flags: mysqlUnsigned},
+	Vector:    {typ: 242, flags: mysqlBinary},
 	TypeJSON:  {typ: 245},
 	Decimal:   {typ: 246},
 	Text:      {typ: 252},
@@ -131,6 +131,9 @@ func TestTypeValues(t *testing.T) {
 	}, {
 		defined:  BitNum,
 		expected: 34 | flagIsText,
+	}, {
+		defined:  Vector,
+		expected: 35 | flagIsQuoted,
 	}}
 	for _, tcase := range testcases {
 		if int(tcase.defined) != tcase.expected {
@@ -177,6 +180,7 @@ func TestCategory(t *testing.T) {
 		HexNum,
 		HexVal,
 		BitNum,
+		Vector,
 	}
 	for _, typ := range alltypes {
 		matched := false
@@ -352,6 +356,9 @@ func TestMySQLToType(t *testing.T) {
 	}, {
 		intype:  16,
 		outtype: Bit,
+	}, {
+		intype:  242,
+		outtype: Vector,
 	}, {
 		intype:  245,
 		outtype: TypeJSON,
@@ -502,6 +509,7 @@ func TestPrintTypeChecks(t *testing.T) {
 		HexVal,
 		Tuple,
 		BitNum,
+		Vector,
 	}
 
 	for _, f := range funcs {
@@ -53,8 +53,10 @@ var (
 	queryLogFormat       = "text"
 )
 
+// GetRedactDebugUIQueries returns true if the queries and bind variables are redacted
+// from the debug UIs, which --redact-query-literals implies.
 func GetRedactDebugUIQueries() bool {
-	return redactDebugUIQueries
+	return redactDebugUIQueries || servenv.RedactQueryLiterals
 }
 
 func SetRedactDebugUIQueries(newRedactDebugUIQueries bool) {
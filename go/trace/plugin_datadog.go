@@ -11,6 +11,7 @@ import (
 	ddtracer "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 
 	"vitess.io/vitess/go/viperutil"
+	"vitess.io/vitess/go/vt/log"
 )
 
 var (
@@ -42,6 +43,7 @@ func init() {
 }
 
 func newDatadogTracer(serviceName string) (tracingService, io.Closer, error) {
+	log.Warningf("The opentracing-datadog tracer is deprecated, use the opentelemetry tracer instead")
 	host, port := dataDogHost.Get(), dataDogPort.Get()
 	if host == "" || port == "" {
 		return nil, nil, fmt.Errorf("need host and port to datadog agent to use datadog tracing")
@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"vitess.io/vitess/go/viperutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+/*
+This file implements a tracing service with OpenTelemetry, exporting the spans
+with OTLP over gRPC. The span contexts are propagated with the W3C trace context,
+through the gRPC metadata between the Vitess components and through the
+traceparent of the query comments from the clients.
+*/
+
+var (
+	otelConfigKey = viperutil.KeyPrefixFunc(configKey("opentelemetry"))
+
+	otelEndpoint = viperutil.Configure(
+		otelConfigKey("endpoint"),
+		viperutil.Options[string]{
+			FlagName: "otel-exporter-endpoint",
+		},
+	)
+	otelInsecure = viperutil.Configure(
+		otelConfigKey("insecure"),
+		viperutil.Options[bool]{
+			FlagName: "otel-exporter-insecure",
+		},
+	)
+)
+
+func init() {
+	// If compiled with plugin_opentelemetry, ensure that trace.RegisterFlags
+	// includes the opentelemetry tracing flags.
+	pluginFlags = append(pluginFlags, func(fs *pflag.FlagSet) {
+		fs.String("otel-exporter-endpoint", "", "host and port of the OTLP gRPC endpoint to send spans to. if empty, the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost:4317 is used")
+		fs.Bool("otel-exporter-insecure", false, "send the spans to the OTLP endpoint without TLS")
+
+		viperutil.BindFlags(fs, otelEndpoint, otelInsecure)
+	})
+
+	tracingBackendFactories["opentelemetry"] = newOpenTelemetryTracer
+}
+
+// newOpenTelemetryTracer creates an OpenTelemetry tracing service, sampling the
+// traces started by the service with --tracing-sampling-rate and the others as
+// their parent.
+func newOpenTelemetryTracer(serviceName string) (tracingService, io.Closer, error) {
+	var opts []otlptracegrpc.Option
+	if endpoint := otelEndpoint.Get(); endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+	}
+	if otelInsecure.Get() {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := resource.NewSchemaless(attribute.String("service.name", serviceName))
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRate.Get()))),
+	)
+	log.Infof("Tracing with OpenTelemetry as %v (sampling rate: %v)", serviceName, samplingRate.Get())
+
+	return &openTelemetryService{
+		tracer:     provider.Tracer("vitess.io/vitess/go/trace"),
+		propagator: propagation.TraceContext{},
+	}, &otelCloser{provider: provider}, nil
+}
+
+var _ io.Closer = (*otelCloser)(nil)
+
+type otelCloser struct {
+	provider *sdktrace.TracerProvider
+}
+
+// Close flushes the pending spans and stops the tracer provider.
+func (c *otelCloser) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return c.provider.Shutdown(ctx)
+}
+
+var _ Span = (*otelSpan)(nil)
+
+type otelSpan struct {
+	span oteltrace.Span
+}
+
+// Finish will mark a span as finished
+func (s otelSpan) Finish() {
+	s.span.End()
+}
+
+// Annotate will add information to an existing span
+func (s otelSpan) Annotate(key string, value any) {
+	s.span.SetAttributes(otelAttribute(key, value))
+}
+
+func otelAttribute(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
+
+var _ tracingService = (*openTelemetryService)(nil)
+
+type openTelemetryService struct {
+	tracer     oteltrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// New is part of an interface implementation
+func (s *openTelemetryService) New(parent Span, label string) Span {
+	ctx := context.Background()
+	if p, ok := parent.(otelSpan); ok {
+		ctx = oteltrace.ContextWithSpan(ctx, p.span)
+	}
+	_, span := s.tracer.Start(ctx, label)
+	return otelSpan{span: span}
+}
+
+// NewFromString is part of an interface implementation. The parent is either
+// a W3C traceparent, or the base64 encoded JSON of the trace context fields.
+func (s *openTelemetryService) NewFromString(parent, label string) (Span, error) {
+	var carrier propagation.MapCarrier
+	if strings.HasPrefix(parent, "00-") {
+		carrier = propagation.MapCarrier{"traceparent": parent}
+	} else {
+		fields, err := extractMapFromString(parent)
+		if err != nil {
+			return nil, err
+		}
+		carrier = propagation.MapCarrier(fields)
+	}
+	ctx := s.propagator.Extract(context.Background(), carrier)
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "failed to deserialize span context %q", parent)
+	}
+	_, span := s.tracer.Start(ctx, label)
+	return otelSpan{span: span}, nil
+}
+
+// FromContext is part of an interface implementation
+func (s *openTelemetryService) FromContext(ctx context.Context) (Span, bool) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return nil, false
+	}
+	return otelSpan{span: span}, true
+}
+
+// NewContext is part of an interface implementation
+func (s *openTelemetryService) NewContext(parent context.Context, span Span) context.Context {
+	otSpan, ok := span.(otelSpan)
+	if !ok {
+		return nil
+	}
+	return oteltrace.ContextWithSpan(parent, otSpan.span)
+}
+
+// Traceparent is part of the traceContextService interface.
+func (s *openTelemetryService) Traceparent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	s.propagator.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// AddGrpcServerOptions is part of an interface implementation
+func (s *openTelemetryService) AddGrpcServerOptions(addInterceptors func(s grpc.StreamServerInterceptor, u grpc.UnaryServerInterceptor)) {
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := s.startServerSpan(ss.Context(), info.FullMethod)
+		defer span.End()
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+		recordError(span, err)
+		return err
+	}
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := s.startServerSpan(ctx, info.FullMethod)
+		defer span.End()
+		resp, err := handler(ctx, req)
+		recordError(span, err)
+		return resp, err
+	}
+	addInterceptors(stream, unary)
+}
+
+// AddGrpcClientOptions is part of an interface implementation
+func (s *openTelemetryService) AddGrpcClientOptions(addInterceptors func(s grpc.StreamClientInterceptor, u grpc.UnaryClientInterceptor)) {
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := s.startClientSpan(ctx, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		recordError(span, err)
+		// The stream outlives the call, so its span only covers its creation.
+		span.End()
+		return cs, err
+	}
+	unary := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := s.startClientSpan(ctx, method)
+		defer span.End()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		recordError(span, err)
+		return err
+	}
+	addInterceptors(stream, unary)
+}
+
+func (s *openTelemetryService) startServerSpan(ctx context.Context, method string) (context.Context, oteltrace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = s.propagator.Extract(ctx, metadataCarrier(md))
+	return s.tracer.Start(ctx, method, oteltrace.WithSpanKind(oteltrace.SpanKindServer))
+}
+
+func (s *openTelemetryService) startClientSpan(ctx context.Context, method string) (context.Context, oteltrace.Span) {
+	ctx, span := s.tracer.Start(ctx, method, oteltrace.WithSpanKind(oteltrace.SpanKindClient))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	s.propagator.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func recordError(span oteltrace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+var _ propagation.TextMapCarrier = (metadataCarrier)(nil)
+
+// metadataCarrier carries the trace context in the gRPC metadata.
+type metadataCarrier metadata.MD
+
+// Get is part of the propagation.TextMapCarrier interface.
+func (mc metadataCarrier) Get(key string) string {
+	values := metadata.MD(mc).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set is part of the propagation.TextMapCarrier interface.
+func (mc metadataCarrier) Set(key, value string) {
+	metadata.MD(mc).Set(key, value)
+}
+
+// Keys is part of the propagation.TextMapCarrier interface.
+func (mc metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for key := range mc {
+		keys = append(keys, key)
+	}
+	return keys
+}
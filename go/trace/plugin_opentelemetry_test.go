@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestOpenTelemetryService() (*openTelemetryService, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return &openTelemetryService{
+		tracer:     provider.Tracer("test"),
+		propagator: propagation.TraceContext{},
+	}, recorder
+}
+
+func TestOpenTelemetrySpans(t *testing.T) {
+	svc, recorder := newTestOpenTelemetryService()
+
+	parent := svc.New(nil, "parent")
+	ctx := svc.NewContext(context.Background(), parent)
+	fromCtx, ok := svc.FromContext(ctx)
+	require.True(t, ok)
+	child := svc.New(fromCtx, "child")
+	child.Annotate("keyspace", "ks")
+	child.Annotate("rows", 10)
+	child.Finish()
+	parent.Finish()
+
+	_, ok = svc.FromContext(context.Background())
+	require.False(t, ok)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("keyspace", "ks"), attribute.Int("rows", 10)}, spans[0].Attributes())
+}
+
+func TestOpenTelemetryNewFromString(t *testing.T) {
+	svc, recorder := newTestOpenTelemetryService()
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	span, err := svc.NewFromString(traceparent, "from-traceparent")
+	require.NoError(t, err)
+	span.Finish()
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(`{"traceparent": "` + traceparent + `"}`))
+	span, err = svc.NewFromString(encoded, "from-vt-span-context")
+	require.NoError(t, err)
+	ctx := svc.NewContext(context.Background(), span)
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-"+span.(otelSpan).span.SpanContext().SpanID().String()+"-01", svc.Traceparent(ctx))
+	span.Finish()
+
+	for _, ended := range recorder.Ended() {
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", ended.SpanContext().TraceID().String())
+		assert.Equal(t, "b7ad6b7169203331", ended.Parent().SpanID().String())
+	}
+
+	_, err = svc.NewFromString("00-invalid", "label")
+	require.ErrorContains(t, err, "failed to deserialize span context")
+}
+
+func TestOpenTelemetryGrpcPropagation(t *testing.T) {
+	svc, recorder := newTestOpenTelemetryService()
+
+	var clientInterceptor grpc.UnaryClientInterceptor
+	svc.AddGrpcClientOptions(func(_ grpc.StreamClientInterceptor, u grpc.UnaryClientInterceptor) {
+		clientInterceptor = u
+	})
+	var serverInterceptor grpc.UnaryServerInterceptor
+	svc.AddGrpcServerOptions(func(_ grpc.StreamServerInterceptor, u grpc.UnaryServerInterceptor) {
+		serverInterceptor = u
+	})
+
+	parent := svc.New(nil, "parent")
+	ctx := svc.NewContext(context.Background(), parent)
+	err := clientInterceptor(ctx, "/test/Method", nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		serverCtx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := serverInterceptor(serverCtx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			span, ok := svc.FromContext(ctx)
+			require.True(t, ok)
+			span.Annotate("handled", true)
+			return nil, nil
+		})
+		return err
+	})
+	require.NoError(t, err)
+	parent.Finish()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	server, client := spans[0], spans[1]
+	assert.Equal(t, "/test/Method", server.Name())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, spans[2].SpanContext().TraceID(), server.SpanContext().TraceID())
+}
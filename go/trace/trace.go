@@ -65,6 +65,15 @@ func NewFromString(inCtx context.Context, parent, label string) (Span, context.C
 	return span, outCtx, nil
 }
 
+// Traceparent returns the W3C trace context of the span of the context, to propagate
+// it outside of Vitess, or an empty string if the tracing service does not support it.
+func Traceparent(ctx context.Context) string {
+	if svc, ok := currentTracer.(traceContextService); ok {
+		return svc.Traceparent(ctx)
+	}
+	return ""
+}
+
 // AnnotateSQL annotates information about a sql query in the span. This is done in a way
 // so as to not leak personally identifying information (PII), or sensitive personal information (SPI)
 func AnnotateSQL(span Span, strippedSQL fmt.Stringer) {
@@ -122,6 +131,12 @@ type tracingService interface {
 	AddGrpcClientOptions(addInterceptors func(s grpc.StreamClientInterceptor, u grpc.UnaryClientInterceptor))
 }
 
+// traceContextService is implemented by the tracing services propagating the W3C trace context.
+type traceContextService interface {
+	// Traceparent returns the traceparent of the span of the context
+	Traceparent(ctx context.Context) string
+}
+
 // TracerFactory creates a tracing service for the service provided. It's important to close the provided io.Closer
 // object to make sure that all spans are sent to the backend before the process exits.
 type TracerFactory func(serviceName string) (tracingService, io.Closer, error)
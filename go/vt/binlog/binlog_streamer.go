@@ -41,7 +41,8 @@ import (
 )
 
 var (
-	binlogStreamerErrors = stats.NewCountersWithSingleLabel("BinlogStreamerErrors", "error count when streaming binlog", "state")
+	binlogStreamerErrors         = stats.NewCountersWithSingleLabel("BinlogStreamerErrors", "error count when streaming binlog", "state")
+	binlogStreamerChecksumErrors = stats.NewCounter("BinlogStreamerChecksumErrors", "count of binlog events received with an invalid checksum")
 
 	// ErrClientEOF is returned by Streamer if the stream ended because the
 	// consumer of the stream indicated it doesn't want any more events.
@@ -147,6 +148,7 @@ type Streamer struct {
 	timestamp        int64
 	sendTransaction  sendTransactionFunc
 	usePreviousGTIDs bool
+	sourceFilter     *SourceFilter
 
 	conn *BinlogConnection
 }
@@ -170,6 +172,12 @@ func NewStreamer(cp dbconfigs.Connector, se *schema.Engine, clientCharset *binlo
 	}
 }
 
+// SetSourceFilter restricts the transactions that are sent to the ones
+// matching the filter. It must be called before Stream().
+func (bls *Streamer) SetSourceFilter(filter *SourceFilter) {
+	bls.sourceFilter = filter
+}
+
 // Stream starts streaming binlog events using the settings from NewStreamer().
 func (bls *Streamer) Stream(ctx context.Context) (err error) {
 	// Ensure se is Open. If vttablet came up in a non_serving role,
@@ -251,6 +259,7 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 	var statements []FullBinlogStatement
 	var format mysql.BinlogFormat
 	var gtid replication.GTID
+	var serverID uint32
 	pos := bls.startPos
 	autocommit := true
 	var err error
@@ -273,6 +282,11 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 	// Statements that aren't wrapped in BEGIN/COMMIT are committed immediately.
 	commit := func(timestamp uint32) error {
 		if int64(timestamp) >= bls.timestamp {
+			if !bls.sourceFilter.Matches(serverID, gtid) {
+				// Send an empty transaction, so the client can
+				// still update its position.
+				statements = nil
+			}
 			eventToken := &querypb.EventToken{
 				Timestamp: int64(timestamp),
 				Position:  replication.EncodePosition(pos),
@@ -336,7 +350,11 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 			return pos, fmt.Errorf("got a real event before FORMAT_DESCRIPTION_EVENT: %#v", ev)
 		}
 
-		// Strip the checksum, if any. We don't actually verify the checksum, so discard it.
+		// Verify and strip the checksum, if any.
+		if err := mysql.VerifyBinlogEventChecksum(format, ev); err != nil {
+			binlogStreamerChecksumErrors.Add(1)
+			return pos, fmt.Errorf("invalid binlog event: %v, event data: %#v", err, ev)
+		}
 		ev, _, err = ev.StripChecksum(format)
 		if err != nil {
 			return pos, fmt.Errorf("can't strip checksum from binlog event: %v, event data: %#v", err, ev)
@@ -364,6 +382,7 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 			if err != nil {
 				return pos, fmt.Errorf("can't get GTID from binlog event: %v, event data: %#v", err, ev)
 			}
+			serverID = ev.ServerID()
 			pos = replication.AppendGTID(pos, gtid)
 			if hasBegin {
 				begin()
@@ -400,6 +419,7 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 			if err != nil {
 				return pos, fmt.Errorf("can't get query from binlog event: %v, event data: %#v", err, ev)
 			}
+			serverID = ev.ServerID()
 			switch cat := getStatementCategory(q.SQL); cat {
 			case binlogdatapb.BinlogTransaction_Statement_BL_BEGIN:
 				begin()
@@ -445,8 +465,8 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 					}
 				}
 			}
-		case ev.IsPreviousGTIDs(): // PREVIOUS_GTIDS_EVENT
-			// MySQL 5.6 only: The Binlogs contain an
+		case ev.IsPreviousGTIDs(): // PREVIOUS_GTIDS_EVENT, or GTID_LIST_EVENT on MariaDB
+			// The Binlogs contain an
 			// event that gives us all the previously
 			// applied commits. It is *not* an
 			// authoritative value, unless we started from
@@ -1237,6 +1237,76 @@ func TestStreamerParseEventsMariadbStandaloneGTID(t *testing.T) {
 	}
 }
 
+func TestStreamerParseEventsSourceFilter(t *testing.T) {
+	f := mysql.NewMariaDBBinlogFormat()
+	s := mysql.NewFakeBinlogStream()
+	s.Timestamp = 1409892744
+
+	input := []mysql.BinlogEvent{
+		mysql.NewRotateEvent(f, s, 4, "filename.0001"),
+		mysql.NewFormatDescriptionEvent(f, s),
+	}
+	for _, source := range []struct {
+		serverID uint32
+		domain   uint32
+	}{{62344, 0}, {62345, 1}} {
+		s.ServerID = source.serverID
+		input = append(input,
+			mysql.NewMariaDBGTIDEvent(f, s, replication.MariadbGTID{Domain: source.domain, Sequence: 10}, true /* hasBegin */),
+			mysql.NewQueryEvent(f, s, mysql.Query{
+				SQL: "insert into vt_insert_test(msg) values ('test 0') /* _stream vt_insert_test (id ) (null ); */",
+			}),
+			mysql.NewXIDEvent(f, s),
+		)
+	}
+
+	testcases := []struct {
+		name   string
+		filter *SourceFilter
+		sent   []bool
+	}{{
+		name: "no filter",
+		sent: []bool{true, true},
+	}, {
+		name:   "server ids",
+		filter: &SourceFilter{ServerIDs: []uint32{62345}},
+		sent:   []bool{false, true},
+	}, {
+		name:   "domains",
+		filter: &SourceFilter{Domains: []uint32{0}},
+		sent:   []bool{true, false},
+	}, {
+		name:   "server ids and domains",
+		filter: &SourceFilter{ServerIDs: []uint32{62344}, Domains: []uint32{1}},
+		sent:   []bool{false, false},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := make(chan mysql.BinlogEvent)
+			errs := make(chan error)
+
+			var got binlogStatements
+			dbcfgs := dbconfigs.New(&mysql.ConnParams{
+				DbName: "vt_test_keyspace",
+			})
+			bls := NewStreamer(dbcfgs, nil, nil, replication.Position{}, 0, (&got).sendTransaction)
+			bls.SetSourceFilter(tc.filter)
+
+			go sendTestEvents(events, input)
+			_, err := bls.parseEvents(context.Background(), events, errs)
+			require.Equal(t, ErrServerEOF, err)
+
+			// Filtered out transactions are sent without statements, so
+			// that the position still moves forward.
+			require.Len(t, got, len(tc.sent))
+			for i, sent := range tc.sent {
+				require.Equal(t, sent, len(got[i].Statements) > 0, "transaction %d", i)
+				require.NotEmpty(t, got[i].EventToken.Position)
+			}
+		})
+	}
+}
+
 func TestGetStatementCategory(t *testing.T) {
 	table := map[string]binlogdatapb.BinlogTransaction_Statement_Category{
 		"":  binlogdatapb.BinlogTransaction_Statement_BL_UNRECOGNIZED,
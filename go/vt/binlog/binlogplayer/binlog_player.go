@@ -116,7 +116,12 @@ type Stats struct {
 
 	ThrottledCounts *stats.CountersWithMultiLabels // By throttler and component
 
+	ConflictCounts *stats.CountersWithMultiLabels // By table and conflict type
+
 	DDLEventActions *stats.CountersWithSingleLabel
+
+	MinMaxRecalculationCount *stats.CountersWithSingleLabel // By table
+	MinMaxRecalculationRows  *stats.CountersWithSingleLabel // By table
 }
 
 // RecordHeartbeat updates the time the last heartbeat from vstreamer was seen
@@ -187,7 +192,10 @@ func NewStats() *Stats {
 	bps.PartialQueryCacheSize = stats.NewCountersWithMultiLabels("", "", []string{"type"})
 	bps.PartialQueryCount = stats.NewCountersWithMultiLabels("", "", []string{"type"})
 	bps.ThrottledCounts = stats.NewCountersWithMultiLabels("", "", []string{"throttler", "component"})
+	bps.ConflictCounts = stats.NewCountersWithMultiLabels("", "", []string{"table", "type"})
 	bps.DDLEventActions = stats.NewCountersWithSingleLabel("", "", "action")
+	bps.MinMaxRecalculationCount = stats.NewCountersWithSingleLabel("", "", "Table")
+	bps.MinMaxRecalculationRows = stats.NewCountersWithSingleLabel("", "", "Table")
 	return bps
 }
 
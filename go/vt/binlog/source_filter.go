@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"slices"
+
+	"vitess.io/vitess/go/mysql/replication"
+)
+
+// SourceFilter restricts the transactions sent by a Streamer to the ones
+// that originated on a given set of servers or MariaDB GTID domains. This
+// is used for instance to exclude the writes applied by an external
+// migration tool that uses its own server_id or domain.
+//
+// Transactions that don't match are still sent, but without any statement,
+// so that the consumer can keep track of its position. The vstreamer applies
+// it the same way to the row events, from the source server ids and domains
+// of its binlogdata.Filter.
+type SourceFilter struct {
+	// ServerIDs are the server_ids of the servers whose transactions are
+	// sent. If empty, transactions from all servers are sent.
+	ServerIDs []uint32
+
+	// Domains are the MariaDB GTID domains whose transactions are sent.
+	// If empty, transactions from all domains are sent. When set,
+	// transactions without a MariaDB GTID never match.
+	Domains []uint32
+}
+
+// Matches returns true if a transaction with the given originating
+// server_id and GTID passes the filter. A nil filter matches everything.
+func (f *SourceFilter) Matches(serverID uint32, gtid replication.GTID) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.ServerIDs) > 0 && !slices.Contains(f.ServerIDs, serverID) {
+		return false
+	}
+	if len(f.Domains) > 0 {
+		mariadbGTID, ok := gtid.(replication.MariadbGTID)
+		if !ok || !slices.Contains(f.Domains, mariadbGTID.Domain) {
+			return false
+		}
+	}
+	return true
+}
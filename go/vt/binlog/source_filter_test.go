@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql/replication"
+)
+
+func TestSourceFilterMatches(t *testing.T) {
+	mariadbGTID := replication.MariadbGTID{Domain: 1, Server: 100, Sequence: 5}
+	mysqlGTID := replication.Mysql56GTID{Server: replication.SID{1}, Sequence: 5}
+
+	var nilFilter *SourceFilter
+	assert.True(t, nilFilter.Matches(100, mariadbGTID))
+	assert.True(t, (&SourceFilter{}).Matches(100, mysqlGTID))
+
+	byServer := &SourceFilter{ServerIDs: []uint32{100, 200}}
+	assert.True(t, byServer.Matches(100, mysqlGTID))
+	assert.True(t, byServer.Matches(200, nil))
+	assert.False(t, byServer.Matches(300, mysqlGTID))
+
+	byDomain := &SourceFilter{Domains: []uint32{1}}
+	assert.True(t, byDomain.Matches(300, mariadbGTID))
+	assert.False(t, byDomain.Matches(300, replication.MariadbGTID{Domain: 2, Server: 300, Sequence: 1}))
+	// Only MariaDB GTIDs have a domain.
+	assert.False(t, byDomain.Matches(300, mysqlGTID))
+	assert.False(t, byDomain.Matches(300, nil))
+}
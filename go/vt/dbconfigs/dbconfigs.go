@@ -89,6 +89,7 @@ type DBConfigs struct {
 	ConnectTimeoutMilliseconds int           `json:"connectTimeoutMilliseconds,omitempty"`
 	DBName                     string        `json:"dbName,omitempty"`
 	EnableQueryInfo            bool          `json:"enableQueryInfo,omitempty"`
+	EnableQueryAttributes      bool          `json:"enableQueryAttributes,omitempty"`
 
 	App          UserConfig `json:"app,omitempty"`
 	Dba          UserConfig `json:"dba,omitempty"`
@@ -145,6 +146,7 @@ func registerBaseFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&GlobalDBConfigs.ServerName, "db_server_name", "", "server name of the DB we are connecting to.")
 	fs.IntVar(&GlobalDBConfigs.ConnectTimeoutMilliseconds, "db_connect_timeout_ms", 0, "connection timeout to mysqld in milliseconds (0 for no timeout)")
 	fs.BoolVar(&GlobalDBConfigs.EnableQueryInfo, "db_conn_query_info", false, "enable parsing and processing of QUERY_OK info fields")
+	fs.BoolVar(&GlobalDBConfigs.EnableQueryAttributes, "db_query_attributes", false, "Send the query attributes forwarded by vtgate to MySQL, if the server supports them.")
 }
 
 // The flags will change the global singleton
@@ -350,6 +352,11 @@ func (dbcfgs *DBConfigs) InitWithSocket(defaultSocketFile string, collationEnv *
 		if dbcfgs.Flags != 0 {
 			cp.Flags = dbcfgs.Flags
 		}
+		if dbcfgs.EnableQueryAttributes && (userKey == App || userKey == Dba) {
+			// The query attributes forwarded by vtgate are sent with the
+			// queries if MySQL supports them.
+			cp.Flags |= mysql.CapabilityClientQueryAttributes
+		}
 		if userKey != ExternalRepl {
 			cp.Flavor = dbcfgs.Flavor
 		}
@@ -222,6 +222,27 @@ func TestUseTCP(t *testing.T) {
 	assert.Equal(t, want, dbConfigs.dbaParams)
 }
 
+func TestQueryAttributes(t *testing.T) {
+	dbConfigs := DBConfigs{
+		Socket:                "a",
+		Charset:               "utf8",
+		EnableQueryAttributes: true,
+	}
+	dbConfigs.InitWithSocket("default", collations.MySQL8())
+	assert.EqualValues(t, mysql.CapabilityClientQueryAttributes, dbConfigs.appParams.Flags)
+	assert.EqualValues(t, mysql.CapabilityClientQueryAttributes, dbConfigs.dbaParams.Flags)
+	assert.Zero(t, dbConfigs.filteredParams.Flags)
+	assert.Zero(t, dbConfigs.replParams.Flags)
+
+	dbConfigs = DBConfigs{
+		Socket:  "a",
+		Charset: "utf8",
+	}
+	dbConfigs.InitWithSocket("default", collations.MySQL8())
+	assert.Zero(t, dbConfigs.appParams.Flags)
+	assert.Zero(t, dbConfigs.dbaParams.Flags)
+}
+
 func TestAccessors(t *testing.T) {
 	dbc := &DBConfigs{
 		appParams:      mysql.ConnParams{},
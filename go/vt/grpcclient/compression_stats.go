@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"io"
+
+	"google.golang.org/grpc/encoding"
+
+	"vitess.io/vitess/go/stats"
+)
+
+var (
+	compressionInputBytes  = stats.NewCountersWithSingleLabel("GRPCCompressionInputBytes", "Size of the gRPC messages compressed by this process, before compression", "Compressor")
+	compressionOutputBytes = stats.NewCountersWithSingleLabel("GRPCCompressionOutputBytes", "Size of the gRPC messages compressed by this process, after compression", "Compressor")
+)
+
+// countingCompressor records the size of the messages compressed by its
+// Compressor, before and after compression, so that the compression ratio
+// of each compressor can be monitored.
+type countingCompressor struct {
+	encoding.Compressor
+}
+
+// Compress wraps the writer of the Compressor with counters.
+func (c countingCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	out := &countingWriter{Writer: w}
+	wc, err := c.Compressor.Compress(out)
+	if err != nil {
+		return nil, err
+	}
+	return &countingWriteCloser{WriteCloser: wc, name: c.Name(), out: out}, nil
+}
+
+type countingWriter struct {
+	io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// countingWriteCloser updates the counters once the message is compressed.
+type countingWriteCloser struct {
+	io.WriteCloser
+	name string
+	in   int64
+	out  *countingWriter
+}
+
+func (w *countingWriteCloser) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	w.in += int64(n)
+	return n, err
+}
+
+func (w *countingWriteCloser) Close() error {
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+	compressionInputBytes.Add(w.name, w.in)
+	compressionOutputBytes.Add(w.name, w.out.n)
+	return nil
+}
@@ -46,8 +46,9 @@ func (s SnappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
 }
 
 func appendCompression(opts []grpc.DialOption) ([]grpc.DialOption, error) {
-	if compression == "snappy" {
-		compression := grpc.WithDefaultCallOptions(grpc.UseCompressor("snappy"))
+	switch compression {
+	case "snappy", "zstd":
+		compression := grpc.WithDefaultCallOptions(grpc.UseCompressor(compression))
 		opts = append(opts, compression)
 	}
 
@@ -55,6 +56,7 @@ func appendCompression(opts []grpc.DialOption) ([]grpc.DialOption, error) {
 }
 
 func init() {
-	encoding.RegisterCompressor(SnappyCompressor{})
+	encoding.RegisterCompressor(countingCompressor{SnappyCompressor{}})
+	encoding.RegisterCompressor(countingCompressor{ZstdCompressor{}})
 	RegisterGRPCDialOptions(appendCompression)
 }
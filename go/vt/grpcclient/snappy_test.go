@@ -53,10 +53,17 @@ func TestAppendCompression(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(dialOpts))
 
+	// Change the compression to zstd
+	compression = "zstd"
+
+	dialOpts, err = appendCompression(dialOpts)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dialOpts))
+
 	// Change the compression to some unknown value
 	compression = "unknown"
 
 	dialOpts, err = appendCompression(dialOpts)
 	require.NoError(t, err)
-	require.Equal(t, 1, len(dialOpts))
+	require.Equal(t, 2, len(dialOpts))
 }
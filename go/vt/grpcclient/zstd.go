@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ZstdCompressor is a gRPC compressor using the Zstandard algorithm.
+// Its encoders and decoders are pooled, as they are expensive to create.
+type ZstdCompressor struct{}
+
+var (
+	zstdEncoders sync.Pool
+	zstdDecoders sync.Pool
+)
+
+// Name is "zstd"
+func (z ZstdCompressor) Name() string {
+	return "zstd"
+}
+
+// Compress wraps with a zstd Encoder
+func (z ZstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := zstdEncoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		enc.Reset(w)
+	}
+	return &zstdWriter{Encoder: enc}, nil
+}
+
+// Decompress wraps with a zstd Decoder
+func (z ZstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := zstdDecoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else if err := dec.Reset(r); err != nil {
+		zstdDecoders.Put(dec)
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec}, nil
+}
+
+// zstdWriter returns its Encoder to the pool once closed.
+type zstdWriter struct {
+	*zstd.Encoder
+}
+
+func (w *zstdWriter) Close() error {
+	defer zstdEncoders.Put(w.Encoder)
+	return w.Encoder.Close()
+}
+
+// zstdReader returns its Decoder to the pool once the message is read.
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		_ = r.Decoder.Reset(nil)
+		zstdDecoders.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestZstdCompressDecompress(t *testing.T) {
+	zstdComp := encoding.GetCompressor("zstd")
+	require.NotNil(t, zstdComp)
+
+	msg := []byte(strings.Repeat("select * from t where id = 1;", 100))
+	for i := 0; i < 2; i++ {
+		// The second iteration uses the pooled encoder and decoder.
+		in, out := compressionInputBytes.Counts()["zstd"], compressionOutputBytes.Counts()["zstd"]
+
+		var compressed bytes.Buffer
+		writer, err := zstdComp.Compress(&compressed)
+		require.NoError(t, err)
+		_, err = writer.Write(msg)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		assert.Less(t, compressed.Len(), len(msg))
+
+		assert.EqualValues(t, len(msg), compressionInputBytes.Counts()["zstd"]-in)
+		assert.EqualValues(t, compressed.Len(), compressionOutputBytes.Counts()["zstd"]-out)
+
+		reader, err := zstdComp.Decompress(&compressed)
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, msg, decompressed)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenancewindow evaluates the maintenance windows of the cluster,
+// which gate its heavy operations, such as the Online DDL cut-overs and the
+// VReplication copy phases. The windows are stored in the global topo. When
+// there are none, the heavy operations are always allowed to run.
+package maintenancewindow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// MaxDuration is the maximum duration of a maintenance window.
+const MaxDuration = 7 * 24 * time.Hour
+
+// Schedule is the parsed cron-like schedule of the starts of a maintenance
+// window: "minute hour day-of-month month day-of-week", in UTC. Each field
+// is "*", a value, a range "a-b", or a list of them, optionally with a step
+// as in "*/15" or "1-5/2". Days of week go from 0 (Sunday) to 7 (Sunday).
+// As with cron, when both the day of month and the day of week are
+// restricted, a start matches either of them.
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseSchedule parses the schedule of a maintenance window.
+func ParseSchedule(spec string) (*Schedule, error) {
+	tokens := strings.Fields(spec)
+	if len(tokens) != len(scheduleFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", spec, len(tokens))
+	}
+	var bits [5]uint64
+	for i, token := range tokens {
+		var err error
+		if bits[i], err = parseScheduleField(token, scheduleFields[i]); err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+	}
+	s := &Schedule{
+		minutes:       bits[0],
+		hours:         bits[1],
+		daysOfMonth:   bits[2],
+		months:        bits[3],
+		daysOfWeek:    bits[4],
+		anyDayOfMonth: strings.HasPrefix(tokens[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(tokens[4], "*"),
+	}
+	if s.daysOfWeek&(1<<7) != 0 {
+		// 7 is Sunday too.
+		s.daysOfWeek |= 1
+	}
+	return s, nil
+}
+
+func parseScheduleField(token string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(token, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepSpec); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepSpec, field.name)
+			}
+		}
+		low, high := field.min, field.max
+		if rangeSpec != "*" {
+			lowSpec, highSpec, isRange := strings.Cut(rangeSpec, "-")
+			var err error
+			if low, err = strconv.Atoi(lowSpec); err != nil {
+				return 0, fmt.Errorf("invalid value %q in %s field", lowSpec, field.name)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highSpec); err != nil {
+					return 0, fmt.Errorf("invalid value %q in %s field", highSpec, field.name)
+				}
+			} else if hasStep {
+				// As with cron, "a/n" means from a to the max.
+				high = field.max
+			}
+			if low < field.min || high > field.max || low > high {
+				return 0, fmt.Errorf("%s out of range %d-%d in %s field", rangeSpec, field.min, field.max, field.name)
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Matches returns whether the schedule matches the minute of the given time.
+func (s *Schedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	if s.minutes&(1<<t.Minute()) == 0 || s.hours&(1<<t.Hour()) == 0 || s.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.daysOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.daysOfWeek&(1<<int(t.Weekday())) != 0
+	if !s.anyDayOfMonth && !s.anyDayOfWeek {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// Validate validates a maintenance window.
+func Validate(window *topodatapb.MaintenanceWindow) error {
+	if window.GetName() == "" {
+		return fmt.Errorf("maintenance window has no name")
+	}
+	if _, err := ParseSchedule(window.Schedule); err != nil {
+		return fmt.Errorf("maintenance window %s: %w", window.Name, err)
+	}
+	duration, ok, err := protoutil.DurationFromProto(window.Duration)
+	if err != nil {
+		return fmt.Errorf("maintenance window %s: %w", window.Name, err)
+	}
+	if !ok || duration < time.Minute || duration > MaxDuration {
+		return fmt.Errorf("maintenance window %s: duration must be between %v and %v", window.Name, time.Minute, MaxDuration)
+	}
+	return nil
+}
+
+// IsOpen returns whether one of the maintenance windows is open at the given
+// time. It's always true when there are no windows.
+func IsOpen(windows []*topodatapb.MaintenanceWindow, now time.Time) (bool, error) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+	for _, window := range windows {
+		if err := Validate(window); err != nil {
+			return false, err
+		}
+		schedule, _ := ParseSchedule(window.Schedule)
+		duration, _, _ := protoutil.DurationFromProto(window.Duration)
+		// The window is open if it started less than its duration ago.
+		for start := now.Truncate(time.Minute); now.Sub(start) < duration; start = start.Add(-time.Minute) {
+			if schedule.Matches(start) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Check returns whether one of the maintenance windows of the cluster is
+// currently open. It's always true when there are no windows.
+func Check(ctx context.Context, ts *topo.Server) (bool, error) {
+	windows, err := ts.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return false, err
+	}
+	return IsOpen(windows, time.Now())
+}
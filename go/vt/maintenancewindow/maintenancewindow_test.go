@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// 2024-03-04 is a Monday.
+	monday := time.Date(2024, 3, 4, 2, 30, 0, 0, time.UTC)
+	tcases := []struct {
+		spec      string
+		matches   []time.Time
+		mismatch  []time.Time
+		expectErr string
+	}{
+		{
+			spec:    "* * * * *",
+			matches: []time.Time{monday, monday.Add(time.Minute)},
+		},
+		{
+			spec:     "30 2 * * *",
+			matches:  []time.Time{monday, monday.Add(24 * time.Hour)},
+			mismatch: []time.Time{monday.Add(time.Minute), monday.Add(time.Hour)},
+		},
+		{
+			spec:     "*/15 1-3 * * 1-5",
+			matches:  []time.Time{monday, monday.Add(-15 * time.Minute)},
+			mismatch: []time.Time{monday.Add(time.Minute), monday.Add(5 * 24 * time.Hour)},
+		},
+		{
+			spec:     "30 2 * * 0,7",
+			matches:  []time.Time{monday.Add(6 * 24 * time.Hour)},
+			mismatch: []time.Time{monday},
+		},
+		{
+			// Either the day of month or the day of week.
+			spec:     "30 2 1 * 1",
+			matches:  []time.Time{monday, time.Date(2024, 3, 1, 2, 30, 0, 0, time.UTC)},
+			mismatch: []time.Time{monday.Add(24 * time.Hour)},
+		},
+		{
+			spec:     "30 2 4 3 *",
+			matches:  []time.Time{monday},
+			mismatch: []time.Time{monday.AddDate(0, 1, 0)},
+		},
+		{
+			spec:      "30 2 * *",
+			expectErr: "expected 5 fields",
+		},
+		{
+			spec:      "60 2 * * *",
+			expectErr: "out of range 0-59 in minute field",
+		},
+		{
+			spec:      "0 2 * * 1-8",
+			expectErr: "out of range 0-7 in day of week field",
+		},
+		{
+			spec:      "*/0 2 * * *",
+			expectErr: "invalid step",
+		},
+		{
+			spec:      "0 two * * *",
+			expectErr: "invalid value \"two\" in hour field",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tcase.spec)
+			if tcase.expectErr != "" {
+				assert.ErrorContains(t, err, tcase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, m := range tcase.matches {
+				assert.True(t, schedule.Matches(m), "%v", m)
+			}
+			for _, m := range tcase.mismatch {
+				assert.False(t, schedule.Matches(m), "%v", m)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	window := &topodatapb.MaintenanceWindow{Name: "nightly", Schedule: "0 2 * * *", Duration: protoutil.DurationToProto(time.Hour)}
+	assert.NoError(t, Validate(window))
+
+	window.Duration = protoutil.DurationToProto(8 * 24 * time.Hour)
+	assert.ErrorContains(t, Validate(window), "duration must be between")
+	window.Duration = nil
+	assert.ErrorContains(t, Validate(window), "duration must be between")
+	window.Duration = protoutil.DurationToProto(time.Hour)
+	window.Schedule = "0 2 * *"
+	assert.ErrorContains(t, Validate(window), "maintenance window nightly: invalid schedule")
+	window.Name = ""
+	assert.ErrorContains(t, Validate(window), "has no name")
+}
+
+func TestIsOpen(t *testing.T) {
+	open, err := IsOpen(nil, time.Now())
+	require.NoError(t, err)
+	assert.True(t, open, "no windows")
+
+	windows := []*topodatapb.MaintenanceWindow{
+		{Name: "nightly", Schedule: "0 2 * * *", Duration: protoutil.DurationToProto(2 * time.Hour)},
+		{Name: "weekend", Schedule: "0 0 * * 6", Duration: protoutil.DurationToProto(48 * time.Hour)},
+	}
+	tcases := []struct {
+		now  time.Time
+		open bool
+	}{
+		{time.Date(2024, 3, 4, 1, 59, 0, 0, time.UTC), false},
+		{time.Date(2024, 3, 4, 2, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 3, 4, 3, 59, 59, 0, time.UTC), true},
+		{time.Date(2024, 3, 4, 4, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 3, 10, 23, 59, 0, 0, time.UTC), true},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), false},
+		// In another time zone.
+		{time.Date(2024, 3, 4, 3, 0, 0, 0, time.FixedZone("UTC+1", 3600)), true},
+	}
+	for _, tcase := range tcases {
+		open, err := IsOpen(windows, tcase.now)
+		require.NoError(t, err)
+		assert.Equal(t, tcase.open, open, "%v", tcase.now)
+	}
+
+	_, err = IsOpen([]*topodatapb.MaintenanceWindow{{Name: "invalid"}}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	open, err := Check(ctx, ts)
+	require.NoError(t, err)
+	assert.True(t, open, "no windows")
+
+	// A window which is never open: February 30th.
+	require.NoError(t, ts.SaveMaintenanceWindow(ctx, &topodatapb.MaintenanceWindow{Name: "never", Schedule: "0 0 30 2 *", Duration: protoutil.DurationToProto(time.Hour)}))
+	open, err = Check(ctx, ts)
+	require.NoError(t, err)
+	assert.False(t, open)
+
+	require.NoError(t, ts.SaveMaintenanceWindow(ctx, &topodatapb.MaintenanceWindow{Name: "always", Schedule: "* * * * *", Duration: protoutil.DurationToProto(time.Hour)}))
+	open, err = Check(ctx, ts)
+	require.NoError(t, err)
+	assert.True(t, open)
+}
@@ -104,6 +104,9 @@ type FakeMysqlDaemon struct {
 	// ReplicationLagSeconds is returned by ReplicationStatus.
 	ReplicationLagSeconds uint32
 
+	// HeartbeatTime is returned by ReplicationStatus, if it isn't zero.
+	HeartbeatTime time.Time
+
 	// ReadOnly is the current value of the flag.
 	ReadOnly bool
 
@@ -310,6 +313,8 @@ func (fmd *FakeMysqlDaemon) ReplicationStatus(ctx context.Context) (replication.
 		FilePosition:                           fmd.CurrentSourceFilePosition,
 		RelayLogSourceBinlogEquivalentPosition: fmd.CurrentSourceFilePosition,
 		ReplicationLagSeconds:                  fmd.ReplicationLagSeconds,
+		HeartbeatLagKnown:                      !fmd.HeartbeatTime.IsZero(),
+		HeartbeatTime:                          fmd.HeartbeatTime,
 		// Implemented as AND to avoid changing all tests that were
 		// previously using Replicating = false.
 		IOState:    replication.ReplicationStatusToState(fmt.Sprintf("%v", fmd.Replicating && fmd.IOThreadRunning)),
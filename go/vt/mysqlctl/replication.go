@@ -329,9 +329,12 @@ func (mysqld *Mysqld) WaitSourcePos(ctx context.Context, targetPos replication.P
 	defer conn.Recycle()
 
 	// First check if filePos flavored Position was passed in. If so, we
-	// can't defer to the flavor in the connection, unless that flavor is
-	// also filePos.
+	// have to compare it with the file position of the server, and the
+	// wait uses MASTER_POS_WAIT whatever the flavor of the connection.
+	status := replication.PrimaryStatus{Position: targetPos}
 	if targetPos.MatchesFlavor(replication.FilePosFlavorID) {
+		status = replication.PrimaryStatus{FilePosition: targetPos}
+
 		// If we are the primary, WaitUntilFilePosition will fail. But
 		// position is most likely reached. So, check the position first.
 		mpos, err := conn.Conn.PrimaryFilePosition()
@@ -353,7 +356,9 @@ func (mysqld *Mysqld) WaitSourcePos(ctx context.Context, targetPos replication.P
 		}
 	}
 
-	if err := conn.Conn.WaitUntilPosition(ctx, targetPos); err != nil {
+	// The GTID position can't be waited on if GTIDs are disabled on the
+	// server, in which case WaitUntilPrimaryStatus fails explicitly.
+	if err := conn.Conn.WaitUntilPrimaryStatus(ctx, status); err != nil {
 		return vterrors.Wrapf(err, "WaitSourcePos failed")
 	}
 	return nil
@@ -370,6 +375,10 @@ func (mysqld *Mysqld) CatchupToGTID(ctx context.Context, targetPos replication.P
 	}
 	defer conn.Recycle()
 
+	// The commands quote strings according to the sql_mode of the session.
+	if err := conn.Conn.ReadSQLMode(); err != nil {
+		return err
+	}
 	cmds := conn.Conn.CatchupToGTIDCommands(params, targetPos)
 	return mysqld.executeSuperQueryListConn(ctx, conn, cmds)
 }
@@ -449,6 +458,10 @@ func (mysqld *Mysqld) SetReplicationSource(ctx context.Context, host string, por
 	if stopReplicationBefore {
 		cmds = append(cmds, conn.Conn.StopReplicationCommand())
 	}
+	// The command quotes strings according to the sql_mode of the session.
+	if err := conn.Conn.ReadSQLMode(); err != nil {
+		return err
+	}
 	smc := conn.Conn.SetReplicationSourceCommand(params, host, port, int(replicationConnectRetry.Seconds()))
 	cmds = append(cmds, smc)
 	if startReplicationAfter {
@@ -250,6 +250,39 @@ func TestWaitSourcePos(t *testing.T) {
 	db.AddQuery("SELECT @@global.gtid_executed", sqltypes.MakeTestResult(sqltypes.MakeTestFields("test_field", "varchar"), "invalid_id"))
 	err = testMysqld.WaitSourcePos(ctx, replication.Position{GTIDSet: replication.Mysql56GTIDSet{}})
 	assert.ErrorContains(t, err, "invalid MySQL 5.6 GTID set")
+
+	// A GTID position can't be waited on if GTIDs are disabled on the server.
+	db.AddQuery("SELECT @@global.gtid_executed", sqltypes.MakeTestResult(sqltypes.MakeTestFields("test_field", "varchar"), "8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-8"))
+	db.AddQuery("select @@global.gtid_mode", sqltypes.MakeTestResult(sqltypes.MakeTestFields("gtid_mode", "varchar"), "OFF"))
+	err = testMysqld.WaitSourcePos(ctx, replication.MustParsePosition(replication.Mysql56FlavorID, "8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-10"))
+	assert.ErrorContains(t, err, "gtid_mode is OFF and no file position was given to wait for")
+}
+
+func TestWaitSourcePosFilePos(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+
+	params := db.ConnParams()
+	cp := *params
+	dbc := dbconfigs.NewTestDBConfigs(cp, cp, "fakesqldb")
+
+	db.AddQuery("SELECT 1", &sqltypes.Result{})
+	db.AddQuery("SHOW MASTER STATUS", sqltypes.MakeTestResult(sqltypes.MakeTestFields("File|Position", "varchar|int64"), "binlog.000002|100"))
+	db.AddQuery("SELECT MASTER_POS_WAIT('binlog.000002', 200)", sqltypes.MakeTestResult(sqltypes.MakeTestFields("result", "int64"), "1"))
+
+	testMysqld := NewMysqld(dbc)
+	defer testMysqld.Close()
+
+	ctx := context.Background()
+	err := testMysqld.WaitSourcePos(ctx, replication.Position{GTIDSet: replication.FilePosGTID{File: "binlog.000002", Pos: 50}})
+	assert.NoError(t, err)
+
+	err = testMysqld.WaitSourcePos(ctx, replication.Position{GTIDSet: replication.FilePosGTID{File: "binlog.000002", Pos: 200}})
+	assert.NoError(t, err)
+
+	db.AddQuery("SELECT MASTER_POS_WAIT('binlog.000002', 200)", sqltypes.MakeTestResult(sqltypes.MakeTestFields("result", "int64"), "NULL"))
+	err = testMysqld.WaitSourcePos(ctx, replication.Position{GTIDSet: replication.FilePosGTID{File: "binlog.000002", Pos: 200}})
+	assert.ErrorContains(t, err, "replication is not running")
 }
 
 func TestReplicationStatus(t *testing.T) {
@@ -269,6 +302,18 @@ func TestReplicationStatus(t *testing.T) {
 	res, err := testMysqld.ReplicationStatus(context.Background())
 	assert.NoError(t, err)
 	assert.True(t, res.ReplicationLagUnknown)
+	assert.False(t, res.HeartbeatLagKnown)
+
+	// The lag is also computed from the heartbeats, if any.
+	db.AddQuery("SELECT MAX(ts) FROM _vt.heartbeat", sqltypes.MakeTestResult(sqltypes.MakeTestFields("MAX(ts)", "int64"), fmt.Sprint(time.Now().Add(-5*time.Second).UnixNano())))
+	res, err = testMysqld.ReplicationStatus(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, res.HeartbeatLagKnown)
+	assert.InDelta(t, 5, res.HeartbeatLagSeconds, 1)
+	assert.WithinDuration(t, time.Now().Add(-5*time.Second), res.HeartbeatTime, time.Second)
+	lag, ok := res.LagSeconds()
+	assert.True(t, ok)
+	assert.Equal(t, res.HeartbeatLagSeconds, lag)
 
 	db.AddQuery("SHOW REPLICA STATUS", &sqltypes.Result{})
 	res, err = testMysqld.ReplicationStatus(context.Background())
@@ -380,6 +425,7 @@ func TestSetReplicationSource(t *testing.T) {
 	db.AddQuery("SELECT 1", &sqltypes.Result{})
 	db.AddQuery("RESET MASTER", &sqltypes.Result{})
 	db.AddQuery("STOP REPLICA", &sqltypes.Result{})
+	db.AddQuery("SELECT @@session.sql_mode", sqltypes.MakeTestResult(sqltypes.MakeTestFields("@@session.sql_mode", "varchar"), "NO_BACKSLASH_ESCAPES"))
 
 	testMysqld := NewMysqld(dbc)
 	defer testMysqld.Close()
@@ -9,6 +9,8 @@ import (
 
 // alterOptionAvailableViaInstantDDL checks if the specific alter option is eligible to run via ALGORITHM=INSTANT
 // reference: https://dev.mysql.com/doc/refman/8.0/en/innodb-online-ddl-operations.html
+// MariaDB has its own rules, which its flavor reflects via capableOf:
+// reference: https://mariadb.com/kb/en/innodb-online-ddl-operations-with-the-instant-alter-algorithm/
 func alterOptionCapableOfInstantDDL(alterOption sqlparser.AlterOption, createTable *sqlparser.CreateTable, capableOf capabilities.CapableOf) (bool, error) {
 	// A table with FULLTEXT index won't support adding/removing columns instantly.
 	tableHasFulltextIndex := false
@@ -111,10 +113,10 @@ func alterOptionCapableOfInstantDDL(alterOption sqlparser.AlterOption, createTab
 			return false, nil
 		}
 		if opt.First || opt.After != nil {
-			// not a "last" column. Only supported as of 8.0.29
+			// not a "last" column. Only supported as of 8.0.29, or MariaDB 10.4
 			return capableOf(capabilities.InstantAddDropColumnFlavorCapability)
 		}
-		// Adding a *last* column is supported in 8.0
+		// Adding a *last* column is supported in 8.0, or MariaDB 10.3.7
 		return capableOf(capabilities.InstantAddLastColumnFlavorCapability)
 	case *sqlparser.DropColumn:
 		if tableHasFulltextIndex {
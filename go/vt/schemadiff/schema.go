@@ -18,6 +18,7 @@ package schemadiff
 
 import (
 	"errors"
+	"slices"
 	"sort"
 	"strings"
 
@@ -151,6 +152,44 @@ func getViewDependentTableNames(createView *sqlparser.CreateView) (names []strin
 	return names
 }
 
+// getViewColumnReferenceNames analyzes a CREATE VIEW definition and extracts the lowercased names of all columns it references
+func getViewColumnReferenceNames(createView *sqlparser.CreateView) map[string]bool {
+	names := map[string]bool{}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			names[col.Name.Lowered()] = true
+		}
+		return true, nil
+	}, createView)
+	return names
+}
+
+// getAlterTableColumnChanges returns the lowercased names of columns removed by an ALTER TABLE diff, i.e. dropped or
+// renamed away, and of columns added by the diff, i.e. added or renamed into.
+func getAlterTableColumnChanges(diff *AlterTableEntityDiff) (removed map[string]bool, added map[string]bool) {
+	fromColumns := map[string]bool{}
+	for _, col := range diff.from.CreateTable.TableSpec.Columns {
+		fromColumns[col.Name.Lowered()] = true
+	}
+	toColumns := map[string]bool{}
+	for _, col := range diff.to.CreateTable.TableSpec.Columns {
+		toColumns[col.Name.Lowered()] = true
+	}
+	removed = map[string]bool{}
+	for name := range fromColumns {
+		if !toColumns[name] {
+			removed[name] = true
+		}
+	}
+	added = map[string]bool{}
+	for name := range toColumns {
+		if !fromColumns[name] {
+			added[name] = true
+		}
+	}
+	return removed, added
+}
+
 // normalize is called as part of Schema creation process. The user may only get a hold of normalized schema.
 // It validates some cross-entity constraints, and orders entity based on dependencies (e.g. tables, views that read from tables, 2nd level views, etc.)
 func (s *Schema) normalize(hints *DiffHints) error {
@@ -970,6 +1009,47 @@ func (s *Schema) SchemaDiff(other *Schema, hints *DiffHints) (*SchemaDiff, error
 		}
 	}
 
+	// A table diff that drops or renames columns breaks the views that read those columns. MySQL permits this,
+	// and the views are then expected to be dropped before the table diff, or altered after it. Likewise,
+	// views reading newly added columns may only be created or altered after the table diff.
+	viewReadsColumns := func(createView *sqlparser.CreateView, tableName string, columns map[string]bool) bool {
+		if !slices.Contains(getViewDependentTableNames(createView), tableName) {
+			return false
+		}
+		for name := range getViewColumnReferenceNames(createView) {
+			if columns[name] {
+				return true
+			}
+		}
+		return false
+	}
+	for _, diff := range schemaDiff.UnorderedDiffs() {
+		alterTableDiff, ok := diff.(*AlterTableEntityDiff)
+		if !ok {
+			continue
+		}
+		removed, added := getAlterTableColumnChanges(alterTableDiff)
+		if len(removed) == 0 && len(added) == 0 {
+			continue
+		}
+		for _, viewDiff := range schemaDiff.UnorderedDiffs() {
+			switch viewDiff := viewDiff.(type) {
+			case *CreateViewEntityDiff:
+				if viewReadsColumns(viewDiff.createView, alterTableDiff.EntityName(), added) {
+					schemaDiff.addDep(alterTableDiff, viewDiff, DiffDependencyInOrderCompletion)
+				}
+			case *AlterViewEntityDiff:
+				if viewReadsColumns(viewDiff.to.CreateView, alterTableDiff.EntityName(), added) {
+					schemaDiff.addDep(alterTableDiff, viewDiff, DiffDependencyInOrderCompletion)
+				}
+			case *DropViewEntityDiff:
+				if viewReadsColumns(viewDiff.from.CreateView, alterTableDiff.EntityName(), removed) {
+					schemaDiff.addDep(viewDiff, alterTableDiff, DiffDependencyInOrderCompletion)
+				}
+			}
+		}
+	}
+
 	// Check and assign capabilities:
 	// Reminder: schemadiff assumes a MySQL flavor, so we only check for MySQL capabilities.
 	if capableOf := capabilities.MySQLVersionCapableOf(s.env.MySQLVersion()); capableOf != nil {
@@ -212,6 +212,13 @@ func (d *SchemaDiff) loadDiffs(diffs []EntityDiff) {
 func (d *SchemaDiff) addDep(diff EntityDiff, dependentDiff EntityDiff, typ DiffDependencyType) *DiffDependency {
 	_, _ = d.r.Relate(diff.CanonicalStatementString(), dependentDiff.CanonicalStatementString())
 	diffDep := NewDiffDependency(diff, dependentDiff, typ)
+	if typ > DiffDependencyOrderUnknown {
+		// An "order unknown" dependency in the reverse direction is superseded by this stricter dependency.
+		reverseHashKey := NewDiffDependency(dependentDiff, diff, typ).hashKey()
+		if reverseDep, ok := d.dependencies[reverseHashKey]; ok && reverseDep.typ == DiffDependencyOrderUnknown {
+			delete(d.dependencies, reverseHashKey)
+		}
+	}
 	if existingDep, ok := d.dependencies[diffDep.hashKey()]; ok {
 		if existingDep.typ >= diffDep.typ {
 			// nothing new here, the new dependency is weaker or equals to an existing dependency
@@ -321,6 +328,11 @@ func (d *SchemaDiff) OrderedDiffs(ctx context.Context) ([]EntityDiff, error) {
 			for i := range permutatedDiffs {
 				// apply inline
 				if err := permutationSchema.apply(permutatedDiffs[i:i+1], d.hints); err != nil {
+					if isPendingViewsReferenceError(err, permutatedDiffs[i+1:]) {
+						// The diff breaks the column references of views, and those views are yet to be
+						// altered or dropped. MySQL allows this, so the permutation is still valid.
+						continue
+					}
 					// permutation is invalid
 					return false // continue searching
 				}
@@ -358,6 +370,32 @@ func (d *SchemaDiff) OrderedDiffs(ctx context.Context) ([]EntityDiff, error) {
 	return orderedDiffs, nil
 }
 
+// isPendingViewsReferenceError returns true when the given error, returned by applying a diff to a schema,
+// only indicates invalid column references in views which the given pending diffs alter or drop.
+func isPendingViewsReferenceError(err error, pendingDiffs []EntityDiff) bool {
+	pendingViews := map[string]bool{}
+	for _, diff := range pendingDiffs {
+		switch diff.(type) {
+		case *AlterViewEntityDiff, *DropViewEntityDiff:
+			pendingViews[diff.EntityName()] = true
+		}
+	}
+	var isPendingViewError func(err error) bool
+	isPendingViewError = func(err error) bool {
+		if joinedErr, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joinedErr.Unwrap() {
+				if !isPendingViewError(err) {
+					return false
+				}
+			}
+			return true
+		}
+		viewErr, ok := err.(*InvalidColumnReferencedInViewError)
+		return ok && pendingViews[viewErr.View]
+	}
+	return isPendingViewError(err)
+}
+
 // InstantDDLCapability returns an overall summary of the ability of the diffs to run with ALGORITHM=INSTANT.
 // It is a convenience method, whose logic anyone can reimplement.
 func (d *SchemaDiff) InstantDDLCapability() InstantDDLCapability {
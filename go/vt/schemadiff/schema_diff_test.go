@@ -425,7 +425,6 @@ func TestSchemaDiff(t *testing.T) {
 			expectDiffs:       2,
 			expectDeps:        1,
 			entityOrder:       []string{"t1", "v1"},
-			conflictingDiffs:  2,
 			instantCapability: InstantDDLCapabilityImpossible,
 		},
 		{
@@ -588,7 +587,7 @@ func TestSchemaDiff(t *testing.T) {
 			instantCapability: InstantDDLCapabilityPossible,
 		},
 		{
-			name: "alter table, alter view, impossible sequence",
+			name: "alter table, alter view, replaced column",
 			fromQueries: []string{
 				"create table t1 (id int primary key, info int not null);",
 				"create view v1 as select id, info from t1",
@@ -599,7 +598,38 @@ func TestSchemaDiff(t *testing.T) {
 			},
 			expectDiffs:       2,
 			expectDeps:        1,
-			conflictingDiffs:  2,
+			entityOrder:       []string{"t1", "v1"},
+			instantCapability: InstantDDLCapabilityPossible,
+		},
+		{
+			name: "alter view, alter table, view reads from view",
+			fromQueries: []string{
+				"create table t1 (id int primary key, info int not null);",
+				"create view v1 as select id, info from t1",
+				"create view v2 as select info from v1",
+			},
+			toQueries: []string{
+				"create table t1 (id int primary key, newcol int not null);",
+				"create view v1 as select id, newcol as info from t1",
+				"create view v2 as select info from v1",
+			},
+			expectDiffs:       2,
+			expectDeps:        1,
+			entityOrder:       []string{"t1", "v1"},
+			instantCapability: InstantDDLCapabilityPossible,
+		},
+		{
+			name: "drop view, drop column",
+			fromQueries: []string{
+				"create table t1 (id int primary key, info int not null);",
+				"create view v1 as select id, info from t1",
+			},
+			toQueries: []string{
+				"create table t1 (id int primary key);",
+			},
+			expectDiffs:       2,
+			expectDeps:        1,
+			entityOrder:       []string{"v1", "t1"},
 			instantCapability: InstantDDLCapabilityPossible,
 		},
 
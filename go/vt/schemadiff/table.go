@@ -918,6 +918,17 @@ func (c *CreateTableEntity) TableDiff(other *CreateTableEntity, hints *DiffHints
 		if algorithmValue != "" {
 			alterTable.AlterOptions = append(alterTable.AlterOptions, algorithmValue)
 		}
+
+		switch hints.AlterTableLockStrategy {
+		case AlterTableLockStrategyDefault:
+			alterTable.AlterOptions = append(alterTable.AlterOptions, &sqlparser.LockOption{Type: sqlparser.DefaultType})
+		case AlterTableLockStrategyNoLock:
+			alterTable.AlterOptions = append(alterTable.AlterOptions, &sqlparser.LockOption{Type: sqlparser.NoneType})
+		case AlterTableLockStrategyShared:
+			alterTable.AlterOptions = append(alterTable.AlterOptions, &sqlparser.LockOption{Type: sqlparser.SharedType})
+		case AlterTableLockStrategyExclusive:
+			alterTable.AlterOptions = append(alterTable.AlterOptions, &sqlparser.LockOption{Type: sqlparser.ExclusiveType})
+		}
 		return d
 	}
 	if tableSpecHasChanged {
@@ -1273,6 +1284,173 @@ func (c *CreateTableEntity) isRangePartitionsRotation(
 	return true, partitionSpecs, nil
 }
 
+// partitionChangeSpecs analyzes a change of partitions between two tables which share the same RANGE or LIST
+// partitioning scheme, and returns the partition management statements which transform the partitions of
+// the first into the partitions of the second, without a complete re-partitioning:
+// - a single DROP PARTITION for all partitions which are gone
+// - a REORGANIZE PARTITION for each sequence of partitions replaced by others, which cover the same range or values
+// - an ADD PARTITION for each partition added at the end
+// The partitions shared by both tables (same names, same definitions, in same order) are left untouched.
+// It returns false when the change cannot be expressed with such statements.
+func (c *CreateTableEntity) partitionChangeSpecs(
+	annotations *TextualAnnotations,
+	t1Partitions *sqlparser.PartitionOption,
+	t2Partitions *sqlparser.PartitionOption,
+) (bool, []*sqlparser.PartitionSpec) {
+	if t1Partitions.Type != sqlparser.RangeType && t1Partitions.Type != sqlparser.ListType {
+		return false, nil
+	}
+	if t1Partitions.SubPartition != nil {
+		return false, nil
+	}
+	{
+		// Validate that only the partition definitions differ
+		scheme1 := sqlparser.CloneRefOfPartitionOption(t1Partitions)
+		scheme1.Definitions = nil
+		scheme2 := sqlparser.CloneRefOfPartitionOption(t2Partitions)
+		scheme2.Definitions = nil
+		if !sqlparser.Equals.RefOfPartitionOption(scheme1, scheme2) {
+			return false, nil
+		}
+	}
+	definitions1 := t1Partitions.Definitions
+	definitions2 := t2Partitions.Definitions
+	if len(definitions1) == 0 || len(definitions2) == 0 {
+		return false, nil
+	}
+	for _, definition := range append(slices.Clone(definitions1), definitions2...) {
+		if definition.Options == nil || definition.Options.ValueRange == nil {
+			return false, nil
+		}
+	}
+	isRange := t1Partitions.Type == sqlparser.RangeType
+	// coverSameValues checks if two sequences of partitions can be reorganized into one another.
+	coverSameValues := func(defs1, defs2 []*sqlparser.PartitionDefinition) bool {
+		if len(defs1) == 0 || len(defs2) == 0 {
+			return false
+		}
+		if isRange {
+			// Both sequences follow the same partition (or none), and so they cover the same range if they end with the same value
+			return sqlparser.Equals.RefOfPartitionValueRange(defs1[len(defs1)-1].Options.ValueRange, defs2[len(defs2)-1].Options.ValueRange)
+		}
+		values := func(defs []*sqlparser.PartitionDefinition) (values []string) {
+			for _, def := range defs {
+				for _, expr := range def.Options.ValueRange.Range {
+					values = append(values, sqlparser.CanonicalString(expr))
+				}
+			}
+			slices.Sort(values)
+			return values
+		}
+		return slices.Equal(values(defs1), values(defs2))
+	}
+
+	// Find the shared partitions, which anchor the changes in between them:
+	definitions2map := make(map[string]int, len(definitions2))
+	for i, definition := range definitions2 {
+		definitions2map[sqlparser.CanonicalString(definition)] = i
+	}
+	type anchor struct{ i1, i2 int }
+	var anchors []anchor
+	for i, definition := range definitions1 {
+		if j, ok := definitions2map[sqlparser.CanonicalString(definition)]; ok {
+			if len(anchors) > 0 && j < anchors[len(anchors)-1].i2 {
+				// Shared partitions were reordered
+				return false, nil
+			}
+			anchors = append(anchors, anchor{i1: i, i2: j})
+		}
+	}
+	// The end of both lists is a final anchor
+	anchors = append(anchors, anchor{i1: len(definitions1), i2: len(definitions2)})
+
+	var droppedPartitions []*sqlparser.PartitionDefinition
+	var reorganizeSpecs []*sqlparser.PartitionSpec
+	var addedPartitions []*sqlparser.PartitionDefinition
+	reorganize := func(defs1, defs2 []*sqlparser.PartitionDefinition) {
+		spec := &sqlparser.PartitionSpec{
+			Action:      sqlparser.ReorganizeAction,
+			Definitions: defs2,
+		}
+		for _, def := range defs1 {
+			spec.Names = append(spec.Names, def.Name)
+		}
+		reorganizeSpecs = append(reorganizeSpecs, spec)
+	}
+	i1, i2 := 0, 0
+	for k, a := range anchors {
+		gap1 := definitions1[i1:a.i1]
+		gap2 := definitions2[i2:a.i2]
+		isLast := k == len(anchors)-1
+		switch {
+		case len(gap1) == 0 && len(gap2) == 0:
+			// Nothing changed
+		case len(gap2) == 0:
+			// Partitions were dropped. Any partition may be dropped, in RANGE and in LIST partitioning.
+			droppedPartitions = append(droppedPartitions, gap1...)
+		case coverSameValues(gap1, gap2):
+			reorganize(gap1, gap2)
+		case isLast && len(gap1) == 0:
+			// Partitions were added. Partitions may only be added at the end.
+			addedPartitions = append(addedPartitions, gap2...)
+		case isLast:
+			// The last partitions were replaced with others, covering different values.
+			droppedPartitions = append(droppedPartitions, gap1...)
+			addedPartitions = append(addedPartitions, gap2...)
+		case isRange:
+			// RANGE partitions were added, or replaced, before a shared partition, which ends the range they
+			// cover in both tables. We reorganize that shared partition along with them.
+			reorganize(definitions1[i1:a.i1+1], definitions2[i2:a.i2+1])
+		default:
+			return false, nil
+		}
+		i1, i2 = a.i1+1, a.i2+1
+	}
+	if len(droppedPartitions) == len(definitions1) {
+		// MySQL won't drop all partitions of a table
+		return false, nil
+	}
+
+	var partitionSpecs []*sqlparser.PartitionSpec
+	if len(droppedPartitions) > 0 {
+		// A single DROP PARTITION clause can specify multiple partition names
+		partitionSpec := &sqlparser.PartitionSpec{
+			Action: sqlparser.DropAction,
+		}
+		for _, p := range droppedPartitions {
+			partitionSpec.Names = append(partitionSpec.Names, p.Name)
+			annotations.MarkRemoved(sqlparser.CanonicalString(p))
+		}
+		partitionSpecs = append(partitionSpecs, partitionSpec)
+	}
+	for _, partitionSpec := range reorganizeSpecs {
+		// A shared partition, reorganized along with others, is unchanged
+		for _, p := range definitions1 {
+			_, isShared := definitions2map[sqlparser.CanonicalString(p)]
+			if !isShared && slices.ContainsFunc(partitionSpec.Names, p.Name.Equal) {
+				annotations.MarkRemoved(sqlparser.CanonicalString(p))
+			}
+		}
+		for _, p := range partitionSpec.Definitions {
+			if !slices.ContainsFunc(definitions1, func(def *sqlparser.PartitionDefinition) bool {
+				return sqlparser.Equals.RefOfPartitionDefinition(def, p)
+			}) {
+				annotations.MarkAdded(sqlparser.CanonicalString(p))
+			}
+		}
+		partitionSpecs = append(partitionSpecs, partitionSpec)
+	}
+	for _, p := range addedPartitions {
+		partitionSpec := &sqlparser.PartitionSpec{
+			Action:      sqlparser.AddAction,
+			Definitions: []*sqlparser.PartitionDefinition{p},
+		}
+		partitionSpecs = append(partitionSpecs, partitionSpec)
+		annotations.MarkAdded(sqlparser.CanonicalString(p))
+	}
+	return true, partitionSpecs
+}
+
 func (c *CreateTableEntity) diffPartitions(alterTable *sqlparser.AlterTable,
 	annotations *TextualAnnotations,
 	t1Partitions *sqlparser.PartitionOption,
@@ -1320,7 +1498,19 @@ func (c *CreateTableEntity) diffPartitions(alterTable *sqlparser.AlterTable,
 			case RangeRotationDistinctStatements:
 				return partitionSpecs, nil
 			case RangeRotationFullSpec:
-				// proceed to return a full rebuild
+				// proceed to return a full rebuild, unless partition changes are expressed as distinct statements
+			}
+		}
+		if hints.PartitionChangeStrategy == PartitionChangeDistinctStatements {
+			// We analyze partitions added, dropped or reorganized under the same partitioning scheme, which
+			// MySQL applies to the affected partitions only, rather than rebuild the table.
+			changeAnnotations := annotations
+			if isRotation {
+				// Already annotated
+				changeAnnotations = NewTextualAnnotations()
+			}
+			if isChange, partitionSpecs := c.partitionChangeSpecs(changeAnnotations, t1Partitions, t2Partitions); isChange {
+				return partitionSpecs, nil
 			}
 		}
 		alterTable.PartitionOption = t2Partitions
@@ -1897,6 +2087,28 @@ func (c *CreateTableEntity) apply(diff *AlterTableEntityDiff) error {
 					return &ApplyPartitionNotFoundError{Table: c.Name(), Partition: dropPartitionName.String()}
 				}
 			}
+		case spec.Action == sqlparser.ReorganizeAction && len(spec.Names) > 0 && len(spec.Definitions) > 0:
+			// Reorganize consecutive partitions into new partitions
+			if c.TableSpec.PartitionOption == nil {
+				return &ApplyNoPartitionsError{Table: c.Name()}
+			}
+			definitions := c.TableSpec.PartitionOption.Definitions
+			first := slices.IndexFunc(definitions, func(p *sqlparser.PartitionDefinition) bool {
+				return strings.EqualFold(p.Name.String(), spec.Names[0].String())
+			})
+			if first < 0 {
+				return &ApplyPartitionNotFoundError{Table: c.Name(), Partition: spec.Names[0].String()}
+			}
+			for i, name := range spec.Names {
+				if first+i >= len(definitions) || !strings.EqualFold(definitions[first+i].Name.String(), name.String()) {
+					return &ApplyPartitionNotFoundError{Table: c.Name(), Partition: name.String()}
+				}
+			}
+			c.TableSpec.PartitionOption.Definitions = slices.Concat(
+				definitions[:first],
+				spec.Definitions,
+				definitions[first+len(spec.Names):],
+			)
 		case spec.Action == sqlparser.AddAction && len(spec.Definitions) == 1:
 			// Add one partition
 			if c.TableSpec.PartitionOption == nil {
@@ -2214,7 +2426,7 @@ func (c *CreateTableEntity) apply(diff *AlterTableEntityDiff) error {
 					c.TableSpec.Options = append(c.TableSpec.Options, option)
 				}()
 			}
-		case sqlparser.AlgorithmValue:
+		case sqlparser.AlgorithmValue, *sqlparser.LockOption:
 			// silently ignore. This has an operational effect on the MySQL engine, but has no semantical effect.
 		default:
 			return &UnsupportedApplyOperationError{Statement: sqlparser.CanonicalString(opt)}
@@ -40,11 +40,13 @@ func TestCreateTableDiff(t *testing.T) {
 		errorMsg    string
 		autoinc     int
 		rotation    int
+		partitions  int
 		fulltext    int
 		colrename   int
 		constraint  int
 		charset     int
 		algorithm   int
+		lock        int
 		enumreorder int
 		subsequent  int
 		textdiffs   []string
@@ -1596,6 +1598,132 @@ func TestCreateTableDiff(t *testing.T) {
 				"+ PARTITION `pC` VALUES LESS THAN (40))",
 			},
 		},
+		{
+			name:       "change partitioning range: partition statements, split maxvalue",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition pmax values less than maxvalue)",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition p3 values less than (30), partition pmax values less than maxvalue)",
+			partitions: PartitionChangeDistinctStatements,
+			diff:       "alter table t1 reorganize partition pmax into (partition p3 values less than (30), partition pmax values less than maxvalue)",
+			cdiff:      "ALTER TABLE `t1` REORGANIZE PARTITION `pmax` INTO (PARTITION `p3` VALUES LESS THAN (30), PARTITION `pmax` VALUES LESS THAN MAXVALUE)",
+			textdiffs: []string{
+				"+ PARTITION `p3` VALUES LESS THAN (30),",
+			},
+		},
+		{
+			name:       "change partitioning range: partition statements, split last partition",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20))",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2a values less than (15), partition p2b values less than (20))",
+			partitions: PartitionChangeDistinctStatements,
+			diff:       "alter table t1 reorganize partition p2 into (partition p2a values less than (15), partition p2b values less than (20))",
+			cdiff:      "ALTER TABLE `t1` REORGANIZE PARTITION `p2` INTO (PARTITION `p2a` VALUES LESS THAN (15), PARTITION `p2b` VALUES LESS THAN (20))",
+			textdiffs: []string{
+				"- PARTITION `p2` VALUES LESS THAN (20))",
+				"+ PARTITION `p2a` VALUES LESS THAN (15),",
+				"+ PARTITION `p2b` VALUES LESS THAN (20))",
+			},
+		},
+		{
+			name:       "change partitioning range: partition statements, change range",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition p3 values less than (30))",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (25), partition p3 values less than (30))",
+			partitions: PartitionChangeDistinctStatements,
+			diff:       "alter table t1 reorganize partition p2, p3 into (partition p2 values less than (25), partition p3 values less than (30))",
+			cdiff:      "ALTER TABLE `t1` REORGANIZE PARTITION `p2`, `p3` INTO (PARTITION `p2` VALUES LESS THAN (25), PARTITION `p3` VALUES LESS THAN (30))",
+			textdiffs: []string{
+				"- PARTITION `p2` VALUES LESS THAN (20),",
+				"+ PARTITION `p2` VALUES LESS THAN (25),",
+			},
+		},
+		{
+			name:       "change partitioning range: partition statements, rotate",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition p3 values less than (30))",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p2 values less than (20), partition p3 values less than (30), partition p4 values less than (40))",
+			partitions: PartitionChangeDistinctStatements,
+			diffs:      []string{"alter table t1 drop partition p1", "alter table t1 add partition (partition p4 values less than (40))"},
+			cdiffs:     []string{"ALTER TABLE `t1` DROP PARTITION `p1`", "ALTER TABLE `t1` ADD PARTITION (PARTITION `p4` VALUES LESS THAN (40))"},
+			textdiffs: []string{
+				"-(PARTITION `p1` VALUES LESS THAN (10),",
+				"+ PARTITION `p4` VALUES LESS THAN (40))",
+			},
+		},
+		{
+			name:       "change partitioning range: partition statements, ignore rotate",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition p3 values less than (30))",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p2 values less than (20), partition p3 values less than (30), partition p4 values less than (40))",
+			rotation:   RangeRotationIgnore,
+			partitions: PartitionChangeDistinctStatements,
+		},
+		{
+			name:       "change partitioning range: partition statements, multiple, assorted",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition p3 values less than (30))",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p2a values less than (15), partition p2b values less than (20), partition p3 values less than (30), partition p4 values less than (40))",
+			partitions: PartitionChangeDistinctStatements,
+			diffs:      []string{"alter table t1 reorganize partition p1, p2 into (partition p2a values less than (15), partition p2b values less than (20))", "alter table t1 add partition (partition p4 values less than (40))"},
+			cdiffs:     []string{"ALTER TABLE `t1` REORGANIZE PARTITION `p1`, `p2` INTO (PARTITION `p2a` VALUES LESS THAN (15), PARTITION `p2b` VALUES LESS THAN (20))", "ALTER TABLE `t1` ADD PARTITION (PARTITION `p4` VALUES LESS THAN (40))"},
+			textdiffs: []string{
+				"-(PARTITION `p1` VALUES LESS THAN (10),",
+				"- PARTITION `p2` VALUES LESS THAN (20),",
+				"+(PARTITION `p2a` VALUES LESS THAN (15),",
+				"+ PARTITION `p2b` VALUES LESS THAN (20),",
+				"+ PARTITION `p4` VALUES LESS THAN (40))",
+			},
+		},
+		{
+			name:       "change partitioning range: partition statements, all partitions replaced",
+			from:       "create table t1 (id int primary key) partition by range (id) (partition p1 values less than (10))",
+			to:         "create table t1 (id int primary key) partition by range (id) (partition p2 values less than (20))",
+			partitions: PartitionChangeDistinctStatements,
+			diff:       "alter table t1 \npartition by range (id)\n(partition p2 values less than (20))",
+			cdiff:      "ALTER TABLE `t1` \nPARTITION BY RANGE (`id`)\n(PARTITION `p2` VALUES LESS THAN (20))",
+			textdiffs: []string{
+				"-PARTITION BY RANGE (`id`)",
+				"-(PARTITION `p1` VALUES LESS THAN (10))",
+				"+PARTITION BY RANGE (`id`)",
+				"+(PARTITION `p2` VALUES LESS THAN (20))",
+			},
+		},
+		{
+			name:       "change partitioning list: partition statements, add and drop",
+			from:       "create table t1 (id int primary key) partition by list (id) (partition p1 values in (1, 2), partition p2 values in (3, 4))",
+			to:         "create table t1 (id int primary key) partition by list (id) (partition p2 values in (3, 4), partition p3 values in (5, 6))",
+			partitions: PartitionChangeDistinctStatements,
+			diffs:      []string{"alter table t1 drop partition p1", "alter table t1 add partition (partition p3 values in (5, 6))"},
+			cdiffs:     []string{"ALTER TABLE `t1` DROP PARTITION `p1`", "ALTER TABLE `t1` ADD PARTITION (PARTITION `p3` VALUES IN (5, 6))"},
+			textdiffs: []string{
+				"-(PARTITION `p1` VALUES IN (1, 2),",
+				"+ PARTITION `p3` VALUES IN (5, 6))",
+			},
+		},
+		{
+			name:       "change partitioning list: partition statements, split",
+			from:       "create table t1 (id int primary key) partition by list (id) (partition p1 values in (1, 2), partition p2 values in (3, 4))",
+			to:         "create table t1 (id int primary key) partition by list (id) (partition p1 values in (1), partition p12 values in (2), partition p2 values in (3, 4))",
+			partitions: PartitionChangeDistinctStatements,
+			diff:       "alter table t1 reorganize partition p1 into (partition p1 values in (1), partition p12 values in (2))",
+			cdiff:      "ALTER TABLE `t1` REORGANIZE PARTITION `p1` INTO (PARTITION `p1` VALUES IN (1), PARTITION `p12` VALUES IN (2))",
+			textdiffs: []string{
+				"-(PARTITION `p1` VALUES IN (1, 2),",
+				"+(PARTITION `p1` VALUES IN (1),",
+				"+ PARTITION `p12` VALUES IN (2),",
+			},
+		},
+		{
+			name:       "change partitioning list: partition statements, insert",
+			from:       "create table t1 (id int primary key) partition by list (id) (partition p1 values in (1), partition p3 values in (3))",
+			to:         "create table t1 (id int primary key) partition by list (id) (partition p1 values in (1), partition p2 values in (2), partition p3 values in (3))",
+			partitions: PartitionChangeDistinctStatements,
+			diff:       "alter table t1 \npartition by list (id)\n(partition p1 values in (1),\n partition p2 values in (2),\n partition p3 values in (3))",
+			cdiff:      "ALTER TABLE `t1` \nPARTITION BY LIST (`id`)\n(PARTITION `p1` VALUES IN (1),\n PARTITION `p2` VALUES IN (2),\n PARTITION `p3` VALUES IN (3))",
+			textdiffs: []string{
+				"-PARTITION BY LIST (`id`)",
+				"-(PARTITION `p1` VALUES IN (1),",
+				"- PARTITION `p3` VALUES IN (3))",
+				"+PARTITION BY LIST (`id`)",
+				"+(PARTITION `p1` VALUES IN (1),",
+				"+ PARTITION `p2` VALUES IN (2),",
+				"+ PARTITION `p3` VALUES IN (3))",
+			},
+		},
 
 		//
 		// table options
@@ -2012,6 +2140,32 @@ func TestCreateTableDiff(t *testing.T) {
 			cdiff:     "ALTER TABLE `t1` ADD COLUMN `i` int NOT NULL DEFAULT 0, ALGORITHM = INSTANT",
 			algorithm: AlterTableAlgorithmStrategyInstant,
 		},
+		// lock
+		{
+			name:  "lock: NONE",
+			from:  "create table t1 (`id` int primary key)",
+			to:    "create table t2 (id int primary key, `i` int not null default 0)",
+			diff:  "alter table t1 add column i int not null default 0, lock none",
+			cdiff: "ALTER TABLE `t1` ADD COLUMN `i` int NOT NULL DEFAULT 0, LOCK NONE",
+			lock:  AlterTableLockStrategyNoLock,
+		},
+		{
+			name:  "lock: SHARED",
+			from:  "create table t1 (`id` int primary key)",
+			to:    "create table t2 (id int primary key, `i` int not null default 0)",
+			diff:  "alter table t1 add column i int not null default 0, lock shared",
+			cdiff: "ALTER TABLE `t1` ADD COLUMN `i` int NOT NULL DEFAULT 0, LOCK SHARED",
+			lock:  AlterTableLockStrategyShared,
+		},
+		{
+			name:      "algorithm and lock",
+			from:      "create table t1 (`id` int primary key)",
+			to:        "create table t2 (id int primary key, `i` int not null default 0)",
+			diff:      "alter table t1 add column i int not null default 0, algorithm = INPLACE, lock none",
+			cdiff:     "ALTER TABLE `t1` ADD COLUMN `i` int NOT NULL DEFAULT 0, ALGORITHM = INPLACE, LOCK NONE",
+			algorithm: AlterTableAlgorithmStrategyInplace,
+			lock:      AlterTableLockStrategyNoLock,
+		},
 	}
 	standardHints := DiffHints{}
 	env := NewTestEnv()
@@ -2035,11 +2189,13 @@ func TestCreateTableDiff(t *testing.T) {
 			hints := standardHints
 			hints.AutoIncrementStrategy = ts.autoinc
 			hints.RangeRotationStrategy = ts.rotation
+			hints.PartitionChangeStrategy = ts.partitions
 			hints.ConstraintNamesStrategy = ts.constraint
 			hints.ColumnRenameStrategy = ts.colrename
 			hints.FullTextKeyStrategy = ts.fulltext
 			hints.TableCharsetCollateStrategy = ts.charset
 			hints.AlterTableAlgorithmStrategy = ts.algorithm
+			hints.AlterTableLockStrategy = ts.lock
 			hints.EnumReorderStrategy = ts.enumreorder
 			hints.SubsequentDiffStrategy = ts.subsequent
 			alter, err := c.Diff(other, &hints)
@@ -85,6 +85,11 @@ const (
 	RangeRotationIgnore
 )
 
+const (
+	PartitionChangeFullSpec int = iota
+	PartitionChangeDistinctStatements
+)
+
 const (
 	ConstraintNamesIgnoreVitess = iota
 	ConstraintNamesIgnoreAll
@@ -124,6 +129,14 @@ const (
 	AlterTableAlgorithmStrategyCopy
 )
 
+const (
+	AlterTableLockStrategyNone int = iota
+	AlterTableLockStrategyDefault
+	AlterTableLockStrategyNoLock
+	AlterTableLockStrategyShared
+	AlterTableLockStrategyExclusive
+)
+
 const (
 	EnumReorderStrategyAllow int = iota
 	EnumReorderStrategyReject
@@ -144,6 +157,7 @@ type DiffHints struct {
 	StrictIndexOrdering         bool
 	AutoIncrementStrategy       int
 	RangeRotationStrategy       int
+	PartitionChangeStrategy     int
 	ConstraintNamesStrategy     int
 	ColumnRenameStrategy        int
 	TableRenameStrategy         int
@@ -151,6 +165,7 @@ type DiffHints struct {
 	TableCharsetCollateStrategy int
 	TableQualifierHint          int
 	AlterTableAlgorithmStrategy int
+	AlterTableLockStrategy      int
 	EnumReorderStrategy         int
 	ForeignKeyCheckStrategy     int
 	SubsequentDiffStrategy      int
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// RedactQueryLiterals redacts the literals and the bind variables of the queries
+// from the errors, query logs and log messages.
+var RedactQueryLiterals bool
+
+func registerQueryRedactionFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&RedactQueryLiterals, "redact-query-literals", RedactQueryLiterals, "redact the literals and bind variables of the queries from errors, query logs, log messages and debug UIs, so that they never contain user data. Implies --redact-debug-ui-queries, and the terse errors and sanitized log messages of vtgate and vttablet")
+}
+
+func init() {
+	for _, cmd := range []string{
+		"vtgate",
+		"vttablet",
+		"vtcombo",
+	} {
+		OnParseFor(cmd, registerQueryRedactionFlags)
+	}
+}
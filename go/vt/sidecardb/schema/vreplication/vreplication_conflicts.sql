@@ -0,0 +1,31 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+CREATE TABLE IF NOT EXISTS vreplication_conflicts
+(
+    `id`         bigint         NOT NULL AUTO_INCREMENT,
+    `vrepl_id`   int            NOT NULL,
+    `table_name` varbinary(128) NOT NULL,
+    `type`       varbinary(64)  NOT NULL,
+    `row_image`  longblob       NOT NULL,
+    `gtid`       longblob       NOT NULL,
+    `error`      text           NOT NULL,
+    `created_at` timestamp      NULL     DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp      NULL     DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `count`      bigint         NOT NULL DEFAULT '1',
+    PRIMARY KEY (`id`),
+    KEY `vrepl_id_idx` (`vrepl_id`)
+) ENGINE = InnoDB
@@ -81,7 +81,7 @@ func ASTToStatementType(stmt Statement) StatementType {
 		return StmtSet
 	case *Show:
 		return StmtShow
-	case DDLStatement, DBDDLStatement, *AlterVschema:
+	case DDLStatement, DBDDLStatement, *AlterVschema, *CreateSequence:
 		return StmtDDL
 	case *RevertMigration:
 		return StmtRevert
@@ -295,11 +295,15 @@ type (
 	// Lock is an enum for the type of lock in the statement
 	Lock int8
 
-	// Union represents a UNION statement.
+	// SetOpType is an enum for the set operator used by a Union
+	SetOpType int8
+
+	// Union represents a UNION, INTERSECT or EXCEPT statement.
 	Union struct {
 		With     *With
 		Left     SelectStatement
 		Right    SelectStatement
+		Type     SetOpType
 		Distinct bool
 		OrderBy  OrderBy
 		Limit    *Limit
@@ -343,6 +347,9 @@ type (
 		Rows       InsertRows
 		RowAlias   *RowAlias
 		OnDup      OnDup
+		// Returning is the MariaDB RETURNING clause, which makes the insert
+		// return the given expressions of the inserted rows.
+		Returning SelectExprs
 	}
 
 	// Ignore represents whether ignore was specified or not
@@ -376,6 +383,9 @@ type (
 		Where      *Where
 		OrderBy    OrderBy
 		Limit      *Limit
+		// Returning is the MariaDB RETURNING clause, which makes the delete
+		// return the given expressions of the deleted rows.
+		Returning SelectExprs
 	}
 
 	// Set represents a SET statement.
@@ -458,6 +468,24 @@ type (
 		AutoIncSpec *AutoIncSpec
 	}
 
+	// CreateSequence represents a CREATE SEQUENCE statement.
+	CreateSequence struct {
+		Comments    *ParsedComments
+		IfNotExists bool
+		Name        TableName
+		Options     []*SequenceOption
+	}
+
+	// SequenceOptionType is an enum for the different options of a CREATE SEQUENCE statement.
+	SequenceOptionType int8
+
+	// SequenceOption represents one of the options of a CREATE SEQUENCE statement.
+	// Value is nil for the options that do not take a value, like NOCACHE or NO MAXVALUE.
+	SequenceOption struct {
+		Type  SequenceOptionType
+		Value *Literal
+	}
+
 	// ShowMigrationLogs represents a SHOW VITESS_MIGRATION '<uuid>' LOGS statement
 	ShowMigrationLogs struct {
 		UUID     string
@@ -563,8 +591,26 @@ type (
 	// DDLAction is an enum for DDL.Action
 	DDLAction int8
 
-	// Load represents a LOAD statement
+	// Load represents a LOAD statement.
+	// Only LOAD DATA [LOCAL] INFILE is fully parsed. The other
+	// variants of the statement result in a Load without a FileName.
 	Load struct {
+		Local    bool
+		FileName string
+		Replace  bool
+		Ignore   Ignore
+		Table    TableName
+		Charset  ColumnCharset
+
+		FieldsTerminatedBy       *string
+		FieldsEnclosedBy         *string
+		FieldsOptionallyEnclosed bool
+		FieldsEscapedBy          *string
+		LinesStartingBy          *string
+		LinesTerminatedBy        *string
+		IgnoreLines              int
+
+		Columns Columns
 	}
 
 	// PurgeBinaryLogs represents a PURGE BINARY LOGS statement
@@ -754,6 +800,7 @@ func (*LockTables) iStatement()          {}
 func (*UnlockTables) iStatement()        {}
 func (*AlterTable) iStatement()          {}
 func (*AlterVschema) iStatement()        {}
+func (*CreateSequence) iStatement()      {}
 func (*AlterMigration) iStatement()      {}
 func (*RevertMigration) iStatement()     {}
 func (*ShowMigrationLogs) iStatement()   {}
@@ -1293,6 +1340,11 @@ func (node *AlterView) SetFromTables(tables TableNames) {
 	// irrelevant
 }
 
+// SetComments implements Commented interface.
+func (node *CreateSequence) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
 // SetComments implements Commented interface.
 func (node *RenameTable) SetComments(comments Comments) {
 	// irrelevant
@@ -1418,6 +1470,11 @@ func (node *CreateView) GetParsedComments() *ParsedComments {
 	return node.Comments
 }
 
+// GetParsedComments implements Commented interface.
+func (node *CreateSequence) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
 // GetParsedComments implements Commented interface.
 func (node *DropTable) GetParsedComments() *ParsedComments {
 	return node.Comments
@@ -3163,6 +3220,11 @@ type (
 		Timeout Expr
 		Channel Expr
 	}
+
+	// NextValueExpr represents the NEXT VALUE FOR and NEXTVAL() expressions of MariaDB sequences.
+	NextValueExpr struct {
+		Sequence TableName
+	}
 )
 
 // IsExpr ensures that only expressions nodes can be assigned to a Expr
@@ -3280,6 +3342,7 @@ func (*GeoHashFromLatLongExpr) IsExpr()             {}
 func (*GeoHashFromPointExpr) IsExpr()               {}
 func (*GeomFromGeoHashExpr) IsExpr()                {}
 func (*GeoJSONFromGeomExpr) IsExpr()                {}
+func (*NextValueExpr) IsExpr()                      {}
 func (*GeomFromGeoJSONExpr) IsExpr()                {}
 
 // iCallable marks all expressions that represent function calls
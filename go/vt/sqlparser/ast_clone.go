@@ -127,6 +127,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfCountStar(in)
 	case *CreateDatabase:
 		return CloneRefOfCreateDatabase(in)
+	case *CreateSequence:
+		return CloneRefOfCreateSequence(in)
 	case *CreateTable:
 		return CloneRefOfCreateTable(in)
 	case *CreateView:
@@ -335,6 +337,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfNamedWindow(in)
 	case NamedWindows:
 		return CloneNamedWindows(in)
+	case *NextValueExpr:
+		return CloneRefOfNextValueExpr(in)
 	case *Nextval:
 		return CloneRefOfNextval(in)
 	case *NotExpr:
@@ -1109,6 +1113,18 @@ func CloneRefOfCreateDatabase(n *CreateDatabase) *CreateDatabase {
 	return &out
 }
 
+// CloneRefOfCreateSequence creates a deep clone of the input.
+func CloneRefOfCreateSequence(n *CreateSequence) *CreateSequence {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Name = CloneTableName(n.Name)
+	out.Options = CloneSliceOfRefOfSequenceOption(n.Options)
+	return &out
+}
+
 // CloneRefOfCreateTable creates a deep clone of the input.
 func CloneRefOfCreateTable(n *CreateTable) *CreateTable {
 	if n == nil {
@@ -1189,6 +1205,7 @@ func CloneRefOfDelete(n *Delete) *Delete {
 	out.Where = CloneRefOfWhere(n.Where)
 	out.OrderBy = CloneOrderBy(n.OrderBy)
 	out.Limit = CloneRefOfLimit(n.Limit)
+	out.Returning = CloneSelectExprs(n.Returning)
 	return &out
 }
 
@@ -1636,6 +1653,7 @@ func CloneRefOfInsert(n *Insert) *Insert {
 	out.Rows = CloneInsertRows(n.Rows)
 	out.RowAlias = CloneRefOfRowAlias(n.RowAlias)
 	out.OnDup = CloneOnDup(n.OnDup)
+	out.Returning = CloneSelectExprs(n.Returning)
 	return &out
 }
 
@@ -2063,6 +2081,14 @@ func CloneRefOfLoad(n *Load) *Load {
 		return nil
 	}
 	out := *n
+	out.Table = CloneTableName(n.Table)
+	out.Charset = CloneColumnCharset(n.Charset)
+	out.FieldsTerminatedBy = CloneRefOfString(n.FieldsTerminatedBy)
+	out.FieldsEnclosedBy = CloneRefOfString(n.FieldsEnclosedBy)
+	out.FieldsEscapedBy = CloneRefOfString(n.FieldsEscapedBy)
+	out.LinesStartingBy = CloneRefOfString(n.LinesStartingBy)
+	out.LinesTerminatedBy = CloneRefOfString(n.LinesTerminatedBy)
+	out.Columns = CloneColumns(n.Columns)
 	return &out
 }
 
@@ -2229,6 +2255,16 @@ func CloneNamedWindows(n NamedWindows) NamedWindows {
 	return res
 }
 
+// CloneRefOfNextValueExpr creates a deep clone of the input.
+func CloneRefOfNextValueExpr(n *NextValueExpr) *NextValueExpr {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Sequence = CloneTableName(n.Sequence)
+	return &out
+}
+
 // CloneRefOfNextval creates a deep clone of the input.
 func CloneRefOfNextval(n *Nextval) *Nextval {
 	if n == nil {
@@ -3978,6 +4014,8 @@ func CloneExpr(in Expr) Expr {
 		return CloneRefOfNTHValueExpr(in)
 	case *NamedWindow:
 		return CloneRefOfNamedWindow(in)
+	case *NextValueExpr:
+		return CloneRefOfNextValueExpr(in)
 	case *NotExpr:
 		return CloneRefOfNotExpr(in)
 	case *NtileExpr:
@@ -4164,6 +4202,8 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfCommit(in)
 	case *CreateDatabase:
 		return CloneRefOfCreateDatabase(in)
+	case *CreateSequence:
+		return CloneRefOfCreateSequence(in)
 	case *CreateTable:
 		return CloneRefOfCreateTable(in)
 	case *CreateView:
@@ -4388,6 +4428,18 @@ func CloneSliceOfString(n []string) []string {
 	return res
 }
 
+// CloneSliceOfRefOfSequenceOption creates a deep clone of the input.
+func CloneSliceOfRefOfSequenceOption(n []*SequenceOption) []*SequenceOption {
+	if n == nil {
+		return nil
+	}
+	res := make([]*SequenceOption, len(n))
+	for i, x := range n {
+		res[i] = CloneRefOfSequenceOption(x)
+	}
+	return res
+}
+
 // CloneSliceOfTableExpr creates a deep clone of the input.
 func CloneSliceOfTableExpr(n []TableExpr) []TableExpr {
 	if n == nil {
@@ -4525,6 +4577,15 @@ func CloneRefOfJtNestedPathColDef(n *JtNestedPathColDef) *JtNestedPathColDef {
 	return &out
 }
 
+// CloneRefOfString creates a deep clone of the input.
+func CloneRefOfString(n *string) *string {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	return &out
+}
+
 // CloneTableAndLockTypes creates a deep clone of the input.
 func CloneTableAndLockTypes(n TableAndLockTypes) TableAndLockTypes {
 	if n == nil {
@@ -4689,6 +4750,16 @@ func CloneRefOfColumnCharset(n *ColumnCharset) *ColumnCharset {
 	return &out
 }
 
+// CloneRefOfSequenceOption creates a deep clone of the input.
+func CloneRefOfSequenceOption(n *SequenceOption) *SequenceOption {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Value = CloneRefOfLiteral(n.Value)
+	return &out
+}
+
 // CloneRefOfIndexColumn creates a deep clone of the input.
 func CloneRefOfIndexColumn(n *IndexColumn) *IndexColumn {
 	if n == nil {
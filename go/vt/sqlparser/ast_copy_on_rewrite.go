@@ -126,6 +126,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfCountStar(n, parent)
 	case *CreateDatabase:
 		return c.copyOnRewriteRefOfCreateDatabase(n, parent)
+	case *CreateSequence:
+		return c.copyOnRewriteRefOfCreateSequence(n, parent)
 	case *CreateTable:
 		return c.copyOnRewriteRefOfCreateTable(n, parent)
 	case *CreateView:
@@ -334,6 +336,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfNamedWindow(n, parent)
 	case NamedWindows:
 		return c.copyOnRewriteNamedWindows(n, parent)
+	case *NextValueExpr:
+		return c.copyOnRewriteRefOfNextValueExpr(n, parent)
 	case *Nextval:
 		return c.copyOnRewriteRefOfNextval(n, parent)
 	case *NotExpr:
@@ -1712,6 +1716,30 @@ func (c *cow) copyOnRewriteRefOfCreateDatabase(n *CreateDatabase, parent SQLNode
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfCreateSequence(n *CreateSequence, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		if changedComments || changedName {
+			res := *n
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Name, _ = _Name.(TableName)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfCreateTable(n *CreateTable, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -1862,7 +1890,8 @@ func (c *cow) copyOnRewriteRefOfDelete(n *Delete, parent SQLNode) (out SQLNode,
 		_Where, changedWhere := c.copyOnRewriteRefOfWhere(n.Where, n)
 		_OrderBy, changedOrderBy := c.copyOnRewriteOrderBy(n.OrderBy, n)
 		_Limit, changedLimit := c.copyOnRewriteRefOfLimit(n.Limit, n)
-		if changedWith || changedComments || changedTableExprs || changedTargets || changedPartitions || changedWhere || changedOrderBy || changedLimit {
+		_Returning, changedReturning := c.copyOnRewriteSelectExprs(n.Returning, n)
+		if changedWith || changedComments || changedTableExprs || changedTargets || changedPartitions || changedWhere || changedOrderBy || changedLimit || changedReturning {
 			res := *n
 			res.With, _ = _With.(*With)
 			res.Comments, _ = _Comments.(*ParsedComments)
@@ -1872,6 +1901,7 @@ func (c *cow) copyOnRewriteRefOfDelete(n *Delete, parent SQLNode) (out SQLNode,
 			res.Where, _ = _Where.(*Where)
 			res.OrderBy, _ = _OrderBy.(OrderBy)
 			res.Limit, _ = _Limit.(*Limit)
+			res.Returning, _ = _Returning.(SelectExprs)
 			out = &res
 			if c.cloned != nil {
 				c.cloned(n, out)
@@ -2818,7 +2848,8 @@ func (c *cow) copyOnRewriteRefOfInsert(n *Insert, parent SQLNode) (out SQLNode,
 		_Rows, changedRows := c.copyOnRewriteInsertRows(n.Rows, n)
 		_RowAlias, changedRowAlias := c.copyOnRewriteRefOfRowAlias(n.RowAlias, n)
 		_OnDup, changedOnDup := c.copyOnRewriteOnDup(n.OnDup, n)
-		if changedComments || changedTable || changedPartitions || changedColumns || changedRows || changedRowAlias || changedOnDup {
+		_Returning, changedReturning := c.copyOnRewriteSelectExprs(n.Returning, n)
+		if changedComments || changedTable || changedPartitions || changedColumns || changedRows || changedRowAlias || changedOnDup || changedReturning {
 			res := *n
 			res.Comments, _ = _Comments.(*ParsedComments)
 			res.Table, _ = _Table.(*AliasedTableExpr)
@@ -2827,6 +2858,7 @@ func (c *cow) copyOnRewriteRefOfInsert(n *Insert, parent SQLNode) (out SQLNode,
 			res.Rows, _ = _Rows.(InsertRows)
 			res.RowAlias, _ = _RowAlias.(*RowAlias)
 			res.OnDup, _ = _OnDup.(OnDup)
+			res.Returning, _ = _Returning.(SelectExprs)
 			out = &res
 			if c.cloned != nil {
 				c.cloned(n, out)
@@ -3775,6 +3807,18 @@ func (c *cow) copyOnRewriteRefOfLoad(n *Load, parent SQLNode) (out SQLNode, chan
 	}
 	out = n
 	if c.pre == nil || c.pre(n, parent) {
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_Columns, changedColumns := c.copyOnRewriteColumns(n.Columns, n)
+		if changedTable || changedColumns {
+			res := *n
+			res.Table, _ = _Table.(TableName)
+			res.Columns, _ = _Columns.(Columns)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
 	}
 	if c.post != nil {
 		out, changed = c.postVisit(out, parent, changed)
@@ -4124,6 +4168,28 @@ func (c *cow) copyOnRewriteNamedWindows(n NamedWindows, parent SQLNode) (out SQL
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfNextValueExpr(n *NextValueExpr, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Sequence, changedSequence := c.copyOnRewriteTableName(n.Sequence, n)
+		if changedSequence {
+			res := *n
+			res.Sequence, _ = _Sequence.(TableName)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfNextval(n *Nextval, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -7199,6 +7265,8 @@ func (c *cow) copyOnRewriteExpr(n Expr, parent SQLNode) (out SQLNode, changed bo
 		return c.copyOnRewriteRefOfNTHValueExpr(n, parent)
 	case *NamedWindow:
 		return c.copyOnRewriteRefOfNamedWindow(n, parent)
+	case *NextValueExpr:
+		return c.copyOnRewriteRefOfNextValueExpr(n, parent)
 	case *NotExpr:
 		return c.copyOnRewriteRefOfNotExpr(n, parent)
 	case *NtileExpr:
@@ -7373,6 +7441,8 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfCommit(n, parent)
 	case *CreateDatabase:
 		return c.copyOnRewriteRefOfCreateDatabase(n, parent)
+	case *CreateSequence:
+		return c.copyOnRewriteRefOfCreateSequence(n, parent)
 	case *CreateTable:
 		return c.copyOnRewriteRefOfCreateTable(n, parent)
 	case *CreateView:
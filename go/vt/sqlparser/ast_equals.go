@@ -338,6 +338,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfCreateDatabase(a, b)
+	case *CreateSequence:
+		b, ok := inB.(*CreateSequence)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateSequence(a, b)
 	case *CreateTable:
 		b, ok := inB.(*CreateTable)
 		if !ok {
@@ -962,6 +968,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.NamedWindows(a, b)
+	case *NextValueExpr:
+		b, ok := inB.(*NextValueExpr)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfNextValueExpr(a, b)
 	case *Nextval:
 		b, ok := inB.(*Nextval)
 		if !ok {
@@ -2281,6 +2293,20 @@ func (cmp *Comparator) RefOfCreateDatabase(a, b *CreateDatabase) bool {
 		cmp.SliceOfDatabaseOption(a.CreateOptions, b.CreateOptions)
 }
 
+// RefOfCreateSequence does deep equals between the two objects.
+func (cmp *Comparator) RefOfCreateSequence(a, b *CreateSequence) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfNotExists == b.IfNotExists &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.TableName(a.Name, b.Name) &&
+		cmp.SliceOfRefOfSequenceOption(a.Options, b.Options)
+}
+
 // RefOfCreateTable does deep equals between the two objects.
 func (cmp *Comparator) RefOfCreateTable(a, b *CreateTable) bool {
 	if a == b {
@@ -2380,7 +2406,8 @@ func (cmp *Comparator) RefOfDelete(a, b *Delete) bool {
 		cmp.Partitions(a.Partitions, b.Partitions) &&
 		cmp.RefOfWhere(a.Where, b.Where) &&
 		cmp.OrderBy(a.OrderBy, b.OrderBy) &&
-		cmp.RefOfLimit(a.Limit, b.Limit)
+		cmp.RefOfLimit(a.Limit, b.Limit) &&
+		cmp.SelectExprs(a.Returning, b.Returning)
 }
 
 // RefOfDerivedTable does deep equals between the two objects.
@@ -2899,7 +2926,8 @@ func (cmp *Comparator) RefOfInsert(a, b *Insert) bool {
 		cmp.Columns(a.Columns, b.Columns) &&
 		cmp.InsertRows(a.Rows, b.Rows) &&
 		cmp.RefOfRowAlias(a.RowAlias, b.RowAlias) &&
-		cmp.OnDup(a.OnDup, b.OnDup)
+		cmp.OnDup(a.OnDup, b.OnDup) &&
+		cmp.SelectExprs(a.Returning, b.Returning)
 }
 
 // RefOfInsertExpr does deep equals between the two objects.
@@ -3382,7 +3410,20 @@ func (cmp *Comparator) RefOfLoad(a, b *Load) bool {
 	if a == nil || b == nil {
 		return false
 	}
-	return true
+	return a.Local == b.Local &&
+		a.FileName == b.FileName &&
+		a.Replace == b.Replace &&
+		a.FieldsOptionallyEnclosed == b.FieldsOptionallyEnclosed &&
+		a.IgnoreLines == b.IgnoreLines &&
+		a.Ignore == b.Ignore &&
+		cmp.TableName(a.Table, b.Table) &&
+		cmp.ColumnCharset(a.Charset, b.Charset) &&
+		cmp.RefOfString(a.FieldsTerminatedBy, b.FieldsTerminatedBy) &&
+		cmp.RefOfString(a.FieldsEnclosedBy, b.FieldsEnclosedBy) &&
+		cmp.RefOfString(a.FieldsEscapedBy, b.FieldsEscapedBy) &&
+		cmp.RefOfString(a.LinesStartingBy, b.LinesStartingBy) &&
+		cmp.RefOfString(a.LinesTerminatedBy, b.LinesTerminatedBy) &&
+		cmp.Columns(a.Columns, b.Columns)
 }
 
 // RefOfLocateExpr does deep equals between the two objects.
@@ -3569,6 +3610,17 @@ func (cmp *Comparator) NamedWindows(a, b NamedWindows) bool {
 	return true
 }
 
+// RefOfNextValueExpr does deep equals between the two objects.
+func (cmp *Comparator) RefOfNextValueExpr(a, b *NextValueExpr) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.TableName(a.Sequence, b.Sequence)
+}
+
 // RefOfNextval does deep equals between the two objects.
 func (cmp *Comparator) RefOfNextval(a, b *Nextval) bool {
 	if a == b {
@@ -4626,6 +4678,7 @@ func (cmp *Comparator) RefOfUnion(a, b *Union) bool {
 		cmp.RefOfWith(a.With, b.With) &&
 		cmp.SelectStatement(a.Left, b.Left) &&
 		cmp.SelectStatement(a.Right, b.Right) &&
+		a.Type == b.Type &&
 		cmp.OrderBy(a.OrderBy, b.OrderBy) &&
 		cmp.RefOfLimit(a.Limit, b.Limit) &&
 		a.Lock == b.Lock &&
@@ -6389,6 +6442,12 @@ func (cmp *Comparator) Expr(inA, inB Expr) bool {
 			return false
 		}
 		return cmp.RefOfNamedWindow(a, b)
+	case *NextValueExpr:
+		b, ok := inB.(*NextValueExpr)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfNextValueExpr(a, b)
 	case *NotExpr:
 		b, ok := inB.(*NotExpr)
 		if !ok {
@@ -6821,6 +6880,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfCreateDatabase(a, b)
+	case *CreateSequence:
+		b, ok := inB.(*CreateSequence)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateSequence(a, b)
 	case *CreateTable:
 		b, ok := inB.(*CreateTable)
 		if !ok {
@@ -7239,6 +7304,19 @@ func (cmp *Comparator) SliceOfString(a, b []string) bool {
 	return true
 }
 
+// SliceOfRefOfSequenceOption does deep equals between the two objects.
+func (cmp *Comparator) SliceOfRefOfSequenceOption(a, b []*SequenceOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if !cmp.RefOfSequenceOption(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // SliceOfTableExpr does deep equals between the two objects.
 func (cmp *Comparator) SliceOfTableExpr(a, b []TableExpr) bool {
 	if len(a) != len(b) {
@@ -7392,6 +7470,17 @@ func (cmp *Comparator) RefOfJtNestedPathColDef(a, b *JtNestedPathColDef) bool {
 		cmp.SliceOfRefOfJtColumnDefinition(a.Columns, b.Columns)
 }
 
+// RefOfString does deep equals between the two objects.
+func (cmp *Comparator) RefOfString(a, b *string) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
 // TableAndLockTypes does deep equals between the two objects.
 func (cmp *Comparator) TableAndLockTypes(a, b TableAndLockTypes) bool {
 	if len(a) != len(b) {
@@ -7578,6 +7667,18 @@ func (cmp *Comparator) RefOfColumnCharset(a, b *ColumnCharset) bool {
 		a.Binary == b.Binary
 }
 
+// RefOfSequenceOption does deep equals between the two objects.
+func (cmp *Comparator) RefOfSequenceOption(a, b *SequenceOption) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Type == b.Type &&
+		cmp.RefOfLiteral(a.Value, b.Value)
+}
+
 // RefOfIndexColumn does deep equals between the two objects.
 func (cmp *Comparator) RefOfIndexColumn(a, b *IndexColumn) bool {
 	if a == b {
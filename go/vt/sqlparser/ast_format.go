@@ -78,21 +78,17 @@ func (node *Union) Format(buf *TrackedBuffer) {
 		buf.astPrintf(node, "%v", node.With)
 	}
 
-	if requiresParen(node.Left) {
+	if requiresParen(node.Left) || node.Type.precedence() > setOpPrecedence(node.Left) {
 		buf.astPrintf(node, "(%v)", node.Left)
 	} else {
 		buf.astPrintf(node, "%v", node.Left)
 	}
 
 	buf.WriteByte(' ')
-	if node.Distinct {
-		buf.literal(UnionStr)
-	} else {
-		buf.literal(UnionAllStr)
-	}
+	buf.literal(node.SetOpString())
 	buf.WriteByte(' ')
 
-	if requiresParen(node.Right) {
+	if requiresParen(node.Right) || requiresSetOpParen(node, node.Right) {
 		buf.astPrintf(node, "(%v)", node.Right)
 	} else {
 		buf.astPrintf(node, "%v", node.Right)
@@ -132,7 +128,9 @@ func (node *Insert) Format(buf *TrackedBuffer) {
 			node.Comments, node.Ignore.ToString(),
 			node.Table.Expr, node.Partitions, node.Columns, node.Rows, node.RowAlias, node.OnDup)
 	}
-
+	if len(node.Returning) > 0 {
+		buf.astPrintf(node, " returning %v", node.Returning)
+	}
 }
 
 // Format formats the node.
@@ -190,6 +188,9 @@ func (node *Delete) Format(buf *TrackedBuffer) {
 		prefix = ", "
 	}
 	buf.astPrintf(node, "%v%v%v%v", node.Partitions, node.Where, node.OrderBy, node.Limit)
+	if len(node.Returning) > 0 {
+		buf.astPrintf(node, " returning %v", node.Returning)
+	}
 }
 
 // Format formats the node.
@@ -2045,7 +2046,55 @@ func (node IdentifierCS) Format(buf *TrackedBuffer) {
 
 // Format formats the node.
 func (node *Load) Format(buf *TrackedBuffer) {
-	buf.literal("AST node missing for Load type")
+	if node.FileName == "" {
+		buf.literal("AST node missing for Load type")
+		return
+	}
+	buf.literal("load data ")
+	if node.Local {
+		buf.literal("local ")
+	}
+	buf.astPrintf(node, "infile %#s", encodeSQLString(node.FileName))
+	if node.Replace {
+		buf.literal(" replace")
+	}
+	if node.Ignore {
+		buf.literal(" ignore")
+	}
+	buf.astPrintf(node, " into table %v", node.Table)
+	if node.Charset.Name != "" {
+		buf.astPrintf(node, " character set %#s", node.Charset.Name)
+	}
+	if node.FieldsTerminatedBy != nil || node.FieldsEnclosedBy != nil || node.FieldsEscapedBy != nil {
+		buf.literal(" fields")
+		if node.FieldsTerminatedBy != nil {
+			buf.astPrintf(node, " terminated by %#s", encodeSQLString(*node.FieldsTerminatedBy))
+		}
+		if node.FieldsEnclosedBy != nil {
+			if node.FieldsOptionallyEnclosed {
+				buf.literal(" optionally")
+			}
+			buf.astPrintf(node, " enclosed by %#s", encodeSQLString(*node.FieldsEnclosedBy))
+		}
+		if node.FieldsEscapedBy != nil {
+			buf.astPrintf(node, " escaped by %#s", encodeSQLString(*node.FieldsEscapedBy))
+		}
+	}
+	if node.LinesStartingBy != nil || node.LinesTerminatedBy != nil {
+		buf.literal(" lines")
+		if node.LinesStartingBy != nil {
+			buf.astPrintf(node, " starting by %#s", encodeSQLString(*node.LinesStartingBy))
+		}
+		if node.LinesTerminatedBy != nil {
+			buf.astPrintf(node, " terminated by %#s", encodeSQLString(*node.LinesTerminatedBy))
+		}
+	}
+	if node.IgnoreLines > 0 {
+		buf.astPrintf(node, " ignore %d lines", node.IgnoreLines)
+	}
+	if node.Columns != nil {
+		buf.astPrintf(node, " %v", node.Columns)
+	}
 }
 
 // Format formats the node.
@@ -2169,6 +2218,26 @@ func (node *CreateView) Format(buf *TrackedBuffer) {
 	}
 }
 
+// Format formats the node.
+func (node *CreateSequence) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %vsequence ", node.Comments)
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v", node.Name)
+	for _, opt := range node.Options {
+		buf.astPrintf(node, " %s", opt.Type.ToString())
+		if opt.Value != nil {
+			buf.astPrintf(node, " %v", opt.Value)
+		}
+	}
+}
+
+// Format formats the node.
+func (node *NextValueExpr) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "next value for %v", node.Sequence)
+}
+
 // Format formats the LockTables node.
 func (node *LockTables) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "lock tables %v %s", node.Tables[0].Table, node.Tables[0].Lock.ToString())
@@ -90,7 +90,7 @@ func (node *Union) FormatFast(buf *TrackedBuffer) {
 		node.With.FormatFast(buf)
 	}
 
-	if requiresParen(node.Left) {
+	if requiresParen(node.Left) || node.Type.precedence() > setOpPrecedence(node.Left) {
 		buf.WriteByte('(')
 		node.Left.FormatFast(buf)
 		buf.WriteByte(')')
@@ -99,14 +99,10 @@ func (node *Union) FormatFast(buf *TrackedBuffer) {
 	}
 
 	buf.WriteByte(' ')
-	if node.Distinct {
-		buf.WriteString(UnionStr)
-	} else {
-		buf.WriteString(UnionAllStr)
-	}
+	buf.WriteString(node.SetOpString())
 	buf.WriteByte(' ')
 
-	if requiresParen(node.Right) {
+	if requiresParen(node.Right) || requiresSetOpParen(node, node.Right) {
 		buf.WriteByte('(')
 		node.Right.FormatFast(buf)
 		buf.WriteByte(')')
@@ -206,7 +202,10 @@ func (node *Insert) FormatFast(buf *TrackedBuffer) {
 		node.OnDup.FormatFast(buf)
 
 	}
-
+	if len(node.Returning) > 0 {
+		buf.WriteString(" returning ")
+		node.Returning.FormatFast(buf)
+	}
 }
 
 // FormatFast formats the node.
@@ -281,6 +280,10 @@ func (node *Delete) FormatFast(buf *TrackedBuffer) {
 	node.Where.FormatFast(buf)
 	node.OrderBy.FormatFast(buf)
 	node.Limit.FormatFast(buf)
+	if len(node.Returning) > 0 {
+		buf.WriteString(" returning ")
+		node.Returning.FormatFast(buf)
+	}
 }
 
 // FormatFast formats the node.
@@ -2717,7 +2720,66 @@ func (node IdentifierCS) FormatFast(buf *TrackedBuffer) {
 
 // FormatFast formats the node.
 func (node *Load) FormatFast(buf *TrackedBuffer) {
-	buf.WriteString("AST node missing for Load type")
+	if node.FileName == "" {
+		buf.WriteString("AST node missing for Load type")
+		return
+	}
+	buf.WriteString("load data ")
+	if node.Local {
+		buf.WriteString("local ")
+	}
+	buf.WriteString("infile ")
+	buf.WriteString(encodeSQLString(node.FileName))
+	if node.Replace {
+		buf.WriteString(" replace")
+	}
+	if node.Ignore {
+		buf.WriteString(" ignore")
+	}
+	buf.WriteString(" into table ")
+	node.Table.FormatFast(buf)
+	if node.Charset.Name != "" {
+		buf.WriteString(" character set ")
+		buf.WriteString(node.Charset.Name)
+	}
+	if node.FieldsTerminatedBy != nil || node.FieldsEnclosedBy != nil || node.FieldsEscapedBy != nil {
+		buf.WriteString(" fields")
+		if node.FieldsTerminatedBy != nil {
+			buf.WriteString(" terminated by ")
+			buf.WriteString(encodeSQLString(*node.FieldsTerminatedBy))
+		}
+		if node.FieldsEnclosedBy != nil {
+			if node.FieldsOptionallyEnclosed {
+				buf.WriteString(" optionally")
+			}
+			buf.WriteString(" enclosed by ")
+			buf.WriteString(encodeSQLString(*node.FieldsEnclosedBy))
+		}
+		if node.FieldsEscapedBy != nil {
+			buf.WriteString(" escaped by ")
+			buf.WriteString(encodeSQLString(*node.FieldsEscapedBy))
+		}
+	}
+	if node.LinesStartingBy != nil || node.LinesTerminatedBy != nil {
+		buf.WriteString(" lines")
+		if node.LinesStartingBy != nil {
+			buf.WriteString(" starting by ")
+			buf.WriteString(encodeSQLString(*node.LinesStartingBy))
+		}
+		if node.LinesTerminatedBy != nil {
+			buf.WriteString(" terminated by ")
+			buf.WriteString(encodeSQLString(*node.LinesTerminatedBy))
+		}
+	}
+	if node.IgnoreLines > 0 {
+		buf.WriteString(" ignore ")
+		buf.WriteString(fmt.Sprintf("%d", node.IgnoreLines))
+		buf.WriteString(" lines")
+	}
+	if node.Columns != nil {
+		buf.WriteByte(' ')
+		node.Columns.FormatFast(buf)
+	}
 }
 
 // FormatFast formats the node.
@@ -2869,6 +2931,31 @@ func (node *CreateView) FormatFast(buf *TrackedBuffer) {
 	}
 }
 
+// FormatFast formats the node.
+func (node *CreateSequence) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	buf.WriteString("sequence ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	for _, opt := range node.Options {
+		buf.WriteByte(' ')
+		buf.WriteString(opt.Type.ToString())
+		if opt.Value != nil {
+			buf.WriteByte(' ')
+			opt.Value.FormatFast(buf)
+		}
+	}
+}
+
+// FormatFast formats the node.
+func (node *NextValueExpr) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("next value for ")
+	node.Sequence.FormatFast(buf)
+}
+
 // FormatFast formats the LockTables node.
 func (node *LockTables) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("lock tables ")
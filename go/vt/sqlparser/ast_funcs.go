@@ -293,6 +293,8 @@ func SQLTypeToQueryType(typeName string, unsigned bool) querypb.Type {
 		return sqltypes.Set
 	case JSON:
 		return sqltypes.TypeJSON
+	case VECTOR:
+		return sqltypes.Vector
 	case GEOMETRY:
 		return sqltypes.Geometry
 	case POINT:
@@ -1289,6 +1291,59 @@ func requiresParen(stmt SelectStatement) bool {
 	return false
 }
 
+// SetOpString returns the set operator of the Union, as it is written in the query
+func (node *Union) SetOpString() string {
+	switch node.Type {
+	case IntersectType:
+		if node.Distinct {
+			return IntersectStr
+		}
+		return IntersectAllStr
+	case ExceptType:
+		if node.Distinct {
+			return ExceptStr
+		}
+		return ExceptAllStr
+	default:
+		if node.Distinct {
+			return UnionStr
+		}
+		return UnionAllStr
+	}
+}
+
+// precedence returns the precedence of the set operator. INTERSECT binds tighter than UNION and EXCEPT
+func (ty SetOpType) precedence() int {
+	if ty == IntersectType {
+		return 2
+	}
+	return 1
+}
+
+// setOpPrecedence returns the precedence of the set operator of the statement,
+// or the highest precedence if the statement is not a set operation
+func setOpPrecedence(stmt SelectStatement) int {
+	union, ok := stmt.(*Union)
+	if !ok {
+		return 3
+	}
+	return union.Type.precedence()
+}
+
+// requiresSetOpParen returns true if the right side of a set operation needs parenthesis
+// for the query to be parsed back into the same tree. A UNION to the right of a UNION
+// is printed without parenthesis, like it always has been.
+func requiresSetOpParen(node *Union, right SelectStatement) bool {
+	rightUnion, ok := right.(*Union)
+	if !ok {
+		return false
+	}
+	if node.Type == UnionType && rightUnion.Type == UnionType {
+		return false
+	}
+	return rightUnion.Type.precedence() <= node.Type.precedence()
+}
+
 func setLockInSelect(stmt SelectStatement, lock Lock) {
 	stmt.SetLock(lock)
 }
@@ -1815,6 +1870,34 @@ func (ty LockingFuncType) ToString() string {
 	}
 }
 
+// ToString returns the type as a string
+func (ty SequenceOptionType) ToString() string {
+	switch ty {
+	case SequenceIncrementType:
+		return SequenceIncrementStr
+	case SequenceMinValueType:
+		return SequenceMinValueStr
+	case SequenceNoMinValueType:
+		return SequenceNoMinValueStr
+	case SequenceMaxValueType:
+		return SequenceMaxValueStr
+	case SequenceNoMaxValueType:
+		return SequenceNoMaxValueStr
+	case SequenceStartType:
+		return SequenceStartStr
+	case SequenceCacheType:
+		return SequenceCacheStr
+	case SequenceNoCacheType:
+		return SequenceNoCacheStr
+	case SequenceCycleType:
+		return SequenceCycleStr
+	case SequenceNoCycleType:
+		return SequenceNoCycleStr
+	default:
+		return "Unknown SequenceOptionType"
+	}
+}
+
 // ToString returns the type as a string
 func (ty PerformanceSchemaType) ToString() string {
 	switch ty {
@@ -1874,6 +1957,8 @@ func (ty VExplainType) ToString() string {
 		return QueriesStr
 	case AllVExplainType:
 		return AllVExplainStr
+	case TraceVExplainType:
+		return TraceStr
 	default:
 		return "Unknown VExplainType"
 	}
@@ -2163,6 +2248,86 @@ func ContainsAggregation(e SQLNode) bool {
 	return hasAggregates
 }
 
+// ContainsNextValue returns true if the node contains a NEXT VALUE FOR expression
+func ContainsNextValue(e SQLNode) bool {
+	hasNextValue := false
+	_ = Walk(func(node SQLNode) (kontinue bool, err error) {
+		if _, ok := node.(*NextValueExpr); ok {
+			hasNextValue = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, e)
+	return hasNextValue
+}
+
+// GetOverClause returns the OVER clause of a window function, or of an
+// aggregation used as a window function. It returns nil for any other node.
+func GetOverClause(node SQLNode) *OverClause {
+	switch node := node.(type) {
+	case *ArgumentLessWindowExpr:
+		return node.OverClause
+	case *FirstOrLastValueExpr:
+		return node.OverClause
+	case *NtileExpr:
+		return node.OverClause
+	case *NTHValueExpr:
+		return node.OverClause
+	case *LagLeadExpr:
+		return node.OverClause
+	case *Count:
+		return node.OverClause
+	case *CountStar:
+		return node.OverClause
+	case *Avg:
+		return node.OverClause
+	case *Max:
+		return node.OverClause
+	case *Min:
+		return node.OverClause
+	case *Sum:
+		return node.OverClause
+	case *BitAnd:
+		return node.OverClause
+	case *BitOr:
+		return node.OverClause
+	case *BitXor:
+		return node.OverClause
+	case *Std:
+		return node.OverClause
+	case *StdDev:
+		return node.OverClause
+	case *StdPop:
+		return node.OverClause
+	case *StdSamp:
+		return node.OverClause
+	case *VarPop:
+		return node.OverClause
+	case *VarSamp:
+		return node.OverClause
+	case *Variance:
+		return node.OverClause
+	}
+	return nil
+}
+
+// ContainsWindowFunction returns true if the expression contains a window function
+func ContainsWindowFunction(e SQLNode) bool {
+	hasWindow := false
+	_ = Walk(func(node SQLNode) (kontinue bool, err error) {
+		switch node.(type) {
+		case *Offset, *Subquery:
+			return false, nil
+		}
+		if GetOverClause(node) != nil {
+			hasWindow = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, e)
+	return hasWindow
+}
+
 // GetFirstSelect gets the first select statement
 func GetFirstSelect(selStmt SelectStatement) *Select {
 	if selStmt == nil {
@@ -155,6 +155,9 @@ const (
 
 	// UserDefinedVariableName is what we prepend bind var names for user defined variables
 	UserDefinedVariableName = "__vtudv"
+
+	// QueryAttributeName is what we prepend bind var names for query attributes
+	QueryAttributeName = "__vtqa"
 )
 
 func (er *astRewriter) rewriteAliasedExpr(node *AliasedExpr) (*BindVarNeeds, error) {
@@ -172,6 +175,7 @@ func (er *astRewriter) rewriteAliasedExpr(node *AliasedExpr) (*BindVarNeeds, err
 func (er *astRewriter) rewriteDown(node SQLNode, _ SQLNode) bool {
 	switch node := node.(type) {
 	case *Select:
+		rewriteNextValue(node)
 		er.visitSelect(node)
 	case *PrepareStmt, *ExecuteStmt:
 		return false // nothing to rewrite here.
@@ -226,6 +230,37 @@ func (er *astRewriter) rewriteUnion(node *Union) {
 	}
 }
 
+// rewriteNextValue turns a query only fetching the next value of a sequence into
+// the NEXT VALUES query that is understood by the sequence tables:
+//
+//	select next value for seq -> select next 1 values from seq
+func rewriteNextValue(node *Select) {
+	if len(node.SelectExprs) != 1 || node.With != nil || node.Where != nil || node.GroupBy != nil ||
+		node.Having != nil || node.OrderBy != nil || node.Limit != nil || node.Into != nil || node.Lock != NoLock {
+		return
+	}
+	ae, ok := node.SelectExprs[0].(*AliasedExpr)
+	if !ok {
+		return
+	}
+	nextVal, ok := ae.Expr.(*NextValueExpr)
+	if !ok {
+		return
+	}
+	if len(node.From) != 1 {
+		return
+	}
+	from, ok := node.From[0].(*AliasedTableExpr)
+	if !ok || !from.As.IsEmpty() {
+		return
+	}
+	if tbl, ok := from.Expr.(TableName); !ok || !tbl.Qualifier.IsEmpty() || tbl.Name.String() != "dual" {
+		return
+	}
+	node.SelectExprs = SelectExprs{&Nextval{Expr: NewIntLiteral("1")}}
+	node.From = TableExprs{&AliasedTableExpr{Expr: nextVal.Sequence}}
+}
+
 func (er *astRewriter) rewriteAliasedTable(cursor *Cursor, node *AliasedTableExpr) {
 	aliasTableName, ok := node.Expr.(TableName)
 	if !ok {
@@ -301,6 +336,10 @@ func (er *astRewriter) rewriteVariable(cursor *Cursor, node *Variable) {
 	if v, isSet := cursor.Parent().(*SetExpr); isSet && v.Var == node {
 		return
 	}
+	// the variable assigned by `@var := expr` is not read, so it is not replaced by its value either
+	if a, isAssignment := cursor.Parent().(*AssignmentExpr); isAssignment && a.Left == node {
+		return
+	}
 	switch node.Scope {
 	case VariableScope:
 		er.udvRewrite(cursor, node)
@@ -389,15 +428,18 @@ func (er *astRewriter) sysVarRewrite(cursor *Cursor, node *Variable) {
 		sysvars.TransactionMode.Name,
 		sysvars.ReadAfterWriteGTID.Name,
 		sysvars.ReadAfterWriteTimeOut.Name,
+		sysvars.ReadAfterWriteConsistency.Name,
 		sysvars.SessionEnableSystemSettings.Name,
 		sysvars.SessionTrackGTIDs.Name,
 		sysvars.SessionUUID.Name,
 		sysvars.SkipQueryPlanCache.Name,
+		sysvars.SnapshotGTIDs.Name,
 		sysvars.Socket.Name,
 		sysvars.SQLSelectLimit.Name,
 		sysvars.Version.Name,
 		sysvars.VersionComment.Name,
 		sysvars.QueryTimeout.Name,
+		sysvars.ScatterErrorsAsWarnings.Name,
 		sysvars.Workload.Name:
 		found = true
 	}
@@ -74,6 +74,15 @@ func TestRewrites(in *testing.T) {
 		in:       "SELECT database()",
 		expected: "SELECT :__vtdbname as `database()`",
 		db:       true,
+	}, {
+		in:       "select next value for seq",
+		expected: "select next 1 values from seq",
+	}, {
+		in:       "select nextval(ks.seq) from dual",
+		expected: "select next 1 values from ks.seq",
+	}, {
+		in:       "select nextval(seq), 1",
+		expected: "select next value for seq, 1 from dual",
 	}, {
 		in:       "SELECT database() from test",
 		expected: "SELECT database() from test",
@@ -128,6 +137,10 @@ func TestRewrites(in *testing.T) {
 		in:       "select id from t where id = @x and val = @y",
 		expected: "select id from t where id = :__vtudvx and val = :__vtudvy",
 		db:       false, udv: 2,
+	}, {
+		in:       "select @x := id + @y from t",
+		expected: "select @x := id + :__vtudvy as `@x := id + @y` from t",
+		db:       false, udv: 1,
 	}, {
 		in:       "insert into t(id) values(@xyx)",
 		expected: "insert into t(id) values(:__vtudvxyx)",
@@ -126,6 +126,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfCountStar(in, f)
 	case *CreateDatabase:
 		return VisitRefOfCreateDatabase(in, f)
+	case *CreateSequence:
+		return VisitRefOfCreateSequence(in, f)
 	case *CreateTable:
 		return VisitRefOfCreateTable(in, f)
 	case *CreateView:
@@ -334,6 +336,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfNamedWindow(in, f)
 	case NamedWindows:
 		return VisitNamedWindows(in, f)
+	case *NextValueExpr:
+		return VisitRefOfNextValueExpr(in, f)
 	case *Nextval:
 		return VisitRefOfNextval(in, f)
 	case *NotExpr:
@@ -1282,6 +1286,21 @@ func VisitRefOfCreateDatabase(in *CreateDatabase, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfCreateSequence(in *CreateSequence, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfCreateTable(in *CreateTable, f Visit) error {
 	if in == nil {
 		return nil
@@ -1405,6 +1424,9 @@ func VisitRefOfDelete(in *Delete, f Visit) error {
 	if err := VisitRefOfLimit(in.Limit, f); err != nil {
 		return err
 	}
+	if err := VisitSelectExprs(in.Returning, f); err != nil {
+		return err
+	}
 	return nil
 }
 func VisitRefOfDerivedTable(in *DerivedTable, f Visit) error {
@@ -2009,6 +2031,9 @@ func VisitRefOfInsert(in *Insert, f Visit) error {
 	if err := VisitOnDup(in.OnDup, f); err != nil {
 		return err
 	}
+	if err := VisitSelectExprs(in.Returning, f); err != nil {
+		return err
+	}
 	return nil
 }
 func VisitRefOfInsertExpr(in *InsertExpr, f Visit) error {
@@ -2593,6 +2618,12 @@ func VisitRefOfLoad(in *Load, f Visit) error {
 	if cont, err := f(in); err != nil || !cont {
 		return err
 	}
+	if err := VisitTableName(in.Table, f); err != nil {
+		return err
+	}
+	if err := VisitColumns(in.Columns, f); err != nil {
+		return err
+	}
 	return nil
 }
 func VisitRefOfLocateExpr(in *LocateExpr, f Visit) error {
@@ -2809,6 +2840,18 @@ func VisitNamedWindows(in NamedWindows, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfNextValueExpr(in *NextValueExpr, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Sequence, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfNextval(in *Nextval, f Visit) error {
 	if in == nil {
 		return nil
@@ -4957,6 +5000,8 @@ func VisitExpr(in Expr, f Visit) error {
 		return VisitRefOfNTHValueExpr(in, f)
 	case *NamedWindow:
 		return VisitRefOfNamedWindow(in, f)
+	case *NextValueExpr:
+		return VisitRefOfNextValueExpr(in, f)
 	case *NotExpr:
 		return VisitRefOfNotExpr(in, f)
 	case *NtileExpr:
@@ -5131,6 +5176,8 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfCommit(in, f)
 	case *CreateDatabase:
 		return VisitRefOfCreateDatabase(in, f)
+	case *CreateSequence:
+		return VisitRefOfCreateSequence(in, f)
 	case *CreateTable:
 		return VisitRefOfCreateTable(in, f)
 	case *CreateView:
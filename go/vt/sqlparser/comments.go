@@ -58,6 +58,20 @@ const (
 	// DirectivePriority specifies the priority of a workload. It should be an integer between 0 and MaxPriorityValue,
 	// where 0 is the highest priority, and MaxPriorityValue is the lowest one.
 	DirectivePriority = "PRIORITY"
+	// DirectiveResultCache lets a query opt in (ON) or out (OFF) of the vtgate query result cache.
+	DirectiveResultCache = "RESULT_CACHE"
+	// DirectiveForceVindex makes the planner route the sharded tables of the query with the given vindexes,
+	// like a `USE VINDEX` hint on every table that has one of them. Several vindexes are separated by commas:
+	// /*vt+ FORCE_VINDEX=name_user_map,user_index */
+	DirectiveForceVindex = "FORCE_VINDEX"
+	// DirectiveJoinOrder makes the planner join the tables in the given order, instead of searching for the
+	// cheapest one. The tables are named by their alias, or their name when they have none, separated by commas:
+	// /*vt+ JOIN_ORDER=u,ue */
+	DirectiveJoinOrder = "JOIN_ORDER"
+	// DirectiveScatterOK is a synonym of DirectiveAllowScatter.
+	DirectiveScatterOK = "SCATTER_OK"
+	// DirectiveNoScatter fails the query if its plan has to scatter, even when `no-scatter` is not set.
+	DirectiveNoScatter = "NO_SCATTER"
 
 	// MaxPriorityValue specifies the maximum value allowed for the priority query directive. Valid priority values are
 	// between zero and MaxPriorityValue.
@@ -551,7 +565,43 @@ func IgnoreMaxMaxMemoryRowsDirective(stmt Statement) bool {
 
 // AllowScatterDirective returns true if the allow scatter override is set to true
 func AllowScatterDirective(stmt Statement) bool {
-	return checkDirective(stmt, DirectiveAllowScatter)
+	return checkDirective(stmt, DirectiveAllowScatter) || checkDirective(stmt, DirectiveScatterOK)
+}
+
+// NoScatterDirective returns true if the query must fail instead of scattering.
+func NoScatterDirective(stmt Statement) bool {
+	return checkDirective(stmt, DirectiveNoScatter)
+}
+
+// ForceVindexDirective returns the vindexes the query has to be routed with, if any.
+func ForceVindexDirective(stmt Statement) []string {
+	return listDirective(stmt, DirectiveForceVindex)
+}
+
+// JoinOrderDirective returns the order in which the tables of the query have to be joined, if any.
+func JoinOrderDirective(stmt Statement) []string {
+	return listDirective(stmt, DirectiveJoinOrder)
+}
+
+// PlannerHints returns the directives of the query that change its plan, as they are written in the query.
+func PlannerHints(stmt Statement) []string {
+	cmt, ok := stmt.(Commented)
+	if !ok {
+		return nil
+	}
+	directives := cmt.GetParsedComments().Directives()
+	var hints []string
+	for _, key := range []string{DirectiveForceVindex, DirectiveJoinOrder} {
+		if val, ok := directives.GetString(key, ""); ok {
+			hints = append(hints, key+"="+val)
+		}
+	}
+	for _, key := range []string{DirectiveScatterOK, DirectiveAllowScatter, DirectiveNoScatter} {
+		if directives.IsSet(key) {
+			hints = append(hints, key)
+		}
+	}
+	return hints
 }
 
 // ForeignKeyChecksState returns the state of foreign_key_checks variable if it is part of a SET_VAR optimizer hint in the comments.
@@ -575,6 +625,41 @@ func ForeignKeyChecksState(stmt Statement) *bool {
 	return nil
 }
 
+// ResultCacheDirective returns whether the query opted in or out of the result cache with
+// the RESULT_CACHE directive, or nil if the directive is not specified.
+func ResultCacheDirective(stmt Statement) *bool {
+	cmt, ok := stmt.(Commented)
+	if !ok {
+		return nil
+	}
+	val, ok := cmt.GetParsedComments().Directives().GetString(DirectiveResultCache, "")
+	if !ok {
+		return nil
+	}
+	switch strings.ToLower(val) {
+	case "on", "1", "true":
+		enabled := true
+		return &enabled
+	case "off", "0", "false":
+		enabled := false
+		return &enabled
+	}
+	return nil
+}
+
+// listDirective returns the comma separated values of the directive.
+func listDirective(stmt Statement, key string) []string {
+	cmt, ok := stmt.(Commented)
+	if !ok {
+		return nil
+	}
+	val, ok := cmt.GetParsedComments().Directives().GetString(key, "")
+	if !ok || val == "" {
+		return nil
+	}
+	return strings.Split(val, ",")
+}
+
 func checkDirective(stmt Statement, key string) bool {
 	cmt, ok := stmt.(Commented)
 	if ok {
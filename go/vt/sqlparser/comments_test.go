@@ -480,6 +480,80 @@ func TestConsolidator(t *testing.T) {
 	}
 }
 
+func TestResultCacheDirective(t *testing.T) {
+	enabled, disabled := true, false
+	testCases := []struct {
+		query    string
+		expected *bool
+	}{
+		{"select /*vt+ RESULT_CACHE=on */ * from users", &enabled},
+		{"select /*vt+ RESULT_CACHE=1 */ * from users", &enabled},
+		{"select /*vt+ RESULT_CACHE=OFF */ * from users", &disabled},
+		{"select /*vt+ RESULT_CACHE=false */ * from users union select * from admins", &disabled},
+		{"select /*vt+ RESULT_CACHE=maybe */ * from users", nil},
+		{"select * from users", nil},
+		{"show /*vt+ RESULT_CACHE=on */ create table users", nil},
+	}
+
+	parser := NewTestParser()
+	for _, test := range testCases {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := parser.Parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ResultCacheDirective(stmt))
+		})
+	}
+}
+
+func TestPlannerHintDirectives(t *testing.T) {
+	testCases := []struct {
+		query        string
+		forceVindex  []string
+		joinOrder    []string
+		allowScatter bool
+		noScatter    bool
+		hints        []string
+	}{
+		{
+			query: "select * from users",
+		},
+		{
+			query:       "select /*vt+ FORCE_VINDEX=name_user_map,user_index */ * from users",
+			forceVindex: []string{"name_user_map", "user_index"},
+			hints:       []string{"FORCE_VINDEX=name_user_map,user_index"},
+		},
+		{
+			query:        "select /*vt+ JOIN_ORDER=u,ue SCATTER_OK */ * from users u join user_extra ue",
+			joinOrder:    []string{"u", "ue"},
+			allowScatter: true,
+			hints:        []string{"JOIN_ORDER=u,ue", "SCATTER_OK"},
+		},
+		{
+			query:        "delete /*vt+ ALLOW_SCATTER NO_SCATTER */ from users",
+			allowScatter: true,
+			noScatter:    true,
+			hints:        []string{"ALLOW_SCATTER", "NO_SCATTER"},
+		},
+		{
+			query: "select /*vt+ FORCE_VINDEX= */ * from users",
+			hints: []string{"FORCE_VINDEX="},
+		},
+	}
+
+	parser := NewTestParser()
+	for _, test := range testCases {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := parser.Parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.forceVindex, ForceVindexDirective(stmt))
+			assert.Equal(t, test.joinOrder, JoinOrderDirective(stmt))
+			assert.Equal(t, test.allowScatter, AllowScatterDirective(stmt))
+			assert.Equal(t, test.noScatter, NoScatterDirective(stmt))
+			assert.Equal(t, test.hints, PlannerHints(stmt))
+		})
+	}
+}
+
 func TestGetPriorityFromStatement(t *testing.T) {
 	testCases := []struct {
 		query            string
@@ -44,6 +44,10 @@ const (
 	UnionStr         = "union"
 	UnionAllStr      = "union all"
 	UnionDistinctStr = "union distinct"
+	IntersectStr     = "intersect"
+	IntersectAllStr  = "intersect all"
+	ExceptStr        = "except"
+	ExceptAllStr     = "except all"
 
 	// DDL strings.
 	InsertStr  = "insert"
@@ -271,6 +275,7 @@ const (
 	AnalyzeStr     = "analyze"
 	QueriesStr     = "queries"
 	AllVExplainStr = "all"
+	TraceStr       = "trace"
 	PlanStr        = "plan"
 
 	// Lock Types
@@ -396,6 +401,18 @@ const (
 	ReleaseAllLocksStr = "release_all_locks"
 	ReleaseLockStr     = "release_lock"
 
+	// SequenceOptionType strings
+	SequenceIncrementStr  = "increment by"
+	SequenceMinValueStr   = "minvalue"
+	SequenceNoMinValueStr = "no minvalue"
+	SequenceMaxValueStr   = "maxvalue"
+	SequenceNoMaxValueStr = "no maxvalue"
+	SequenceStartStr      = "start with"
+	SequenceCacheStr      = "cache"
+	SequenceNoCacheStr    = "nocache"
+	SequenceCycleStr      = "cycle"
+	SequenceNoCycleStr    = "nocycle"
+
 	// PerformanceSchemaType strings
 	FormatBytesStr       = "format_bytes"
 	FormatPicoTimeStr    = "format_pico_time"
@@ -531,6 +548,13 @@ const (
 	ForUpdateLockSkipLocked
 )
 
+// Constants for Enum Type - SetOpType
+const (
+	UnionType SetOpType = iota
+	IntersectType
+	ExceptType
+)
+
 // Constants for Enum Type - TrimType
 const (
 	NoTrimType TrimType = iota
@@ -627,6 +651,20 @@ const (
 	ReleaseLock
 )
 
+// Constants for Enum Type - SequenceOptionType
+const (
+	SequenceIncrementType SequenceOptionType = iota
+	SequenceMinValueType
+	SequenceNoMinValueType
+	SequenceMaxValueType
+	SequenceNoMaxValueType
+	SequenceStartType
+	SequenceCacheType
+	SequenceNoCacheType
+	SequenceCycleType
+	SequenceNoCycleType
+)
+
 // Constants for Enum Type - PerformanceSchemaType
 const (
 	FormatBytesType PerformanceSchemaType = iota
@@ -824,6 +862,7 @@ const (
 	QueriesVExplainType VExplainType = iota
 	PlanVExplainType
 	AllVExplainType
+	TraceVExplainType
 )
 
 // Constant for Enum Type - SelectIntoType
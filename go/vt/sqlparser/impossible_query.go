@@ -41,21 +41,17 @@ func FormatImpossibleQuery(buf *TrackedBuffer, node SQLNode) {
 			node.GroupBy.Format(buf)
 		}
 	case *Union:
-		if requiresParen(node.Left) {
+		if requiresParen(node.Left) || node.Type.precedence() > setOpPrecedence(node.Left) {
 			buf.astPrintf(node, "(%v)", node.Left)
 		} else {
 			buf.astPrintf(node, "%v", node.Left)
 		}
 
 		buf.WriteString(" ")
-		if node.Distinct {
-			buf.WriteString(UnionStr)
-		} else {
-			buf.WriteString(UnionAllStr)
-		}
+		buf.WriteString(node.SetOpString())
 		buf.WriteString(" ")
 
-		if requiresParen(node.Right) {
+		if requiresParen(node.Right) || requiresSetOpParen(node, node.Right) {
 			buf.astPrintf(node, "(%v)", node.Right)
 		} else {
 			buf.astPrintf(node, "%v", node.Right)
@@ -151,6 +151,7 @@ var keywords = []keyword{
 	{"both", BOTH},
 	{"by", BY},
 	{"byte", BYTE},
+	{"cache", CACHE},
 	{"call", CALL},
 	{"cancel", CANCEL},
 	{"cascade", CASCADE},
@@ -188,6 +189,14 @@ var keywords = []keyword{
 	{"copy", COPY},
 	{"count", COUNT},
 	{"cume_dist", CUME_DIST},
+	{"cycle", CYCLE},
+	{"increment", INCREMENT},
+	{"minvalue", MINVALUE},
+	{"nextval", NEXTVAL},
+	{"nocache", NOCACHE},
+	{"nocycle", NOCYCLE},
+	{"nomaxvalue", NOMAXVALUE},
+	{"nominvalue", NOMINVALUE},
 	{"substr", SUBSTRING},
 	{"subpartition", SUBPARTITION},
 	{"subpartitions", SUBPARTITIONS},
@@ -260,6 +269,7 @@ var keywords = []keyword{
 	{"escape", ESCAPE},
 	{"escaped", ESCAPED},
 	{"event", EVENT},
+	{"except", EXCEPT},
 	{"exchange", EXCHANGE},
 	{"exclusive", EXCLUSIVE},
 	{"execute", EXECUTE},
@@ -326,7 +336,7 @@ var keywords = []keyword{
 	{"in", IN},
 	{"index", INDEX},
 	{"indexes", INDEXES},
-	{"infile", UNUSED},
+	{"infile", INFILE},
 	{"inout", UNUSED},
 	{"inner", INNER},
 	{"inplace", INPLACE},
@@ -342,6 +352,7 @@ var keywords = []keyword{
 	{"int4", UNUSED},
 	{"int8", UNUSED},
 	{"integer", INTEGER},
+	{"intersect", INTERSECT},
 	{"interval", INTERVAL},
 	{"into", INTO},
 	{"io_after_gtids", UNUSED},
@@ -705,6 +716,7 @@ var keywords = []keyword{
 	{"tinyint", TINYINT},
 	{"tinytext", TINYTEXT},
 	{"to", TO},
+	{"trace", TRACE},
 	{"trailing", TRAILING},
 	{"transaction", TRANSACTION},
 	{"tree", TREE},
@@ -746,6 +758,7 @@ var keywords = []keyword{
 	{"varcharacter", UNUSED},
 	{"variance", VARIANCE},
 	{"varying", UNUSED},
+	{"vector", VECTOR},
 	{"vexplain", VEXPLAIN},
 	{"vgtid_executed", VGTID_EXECUTED},
 	{"virtual", VIRTUAL},
@@ -147,6 +147,11 @@ func (nz *normalizer) walkUpSelect(cursor *Cursor) bool {
 		return true
 	}
 	parent := cursor.Parent()
+	if isWindowFunctionN(parent, node) {
+		// MySQL only accepts a constant there, and the vtgate needs
+		// to know it when it evaluates the window function itself
+		return true
+	}
 	switch parent.(type) {
 	case *Order, GroupBy:
 		return true
@@ -158,6 +163,20 @@ func (nz *normalizer) walkUpSelect(cursor *Cursor) bool {
 	return nz.err == nil // only continue if we haven't found any errors
 }
 
+// isWindowFunctionN returns true if the literal is the N argument of NTILE,
+// LAG, LEAD or NTH_VALUE
+func isWindowFunctionN(parent SQLNode, node *Literal) bool {
+	switch parent := parent.(type) {
+	case *NtileExpr:
+		return parent.N == node
+	case *LagLeadExpr:
+		return parent.N == node
+	case *NTHValueExpr:
+		return parent.N == node
+	}
+	return false
+}
+
 func validateLiteral(node *Literal) error {
 	switch node.Type {
 	case DateVal:
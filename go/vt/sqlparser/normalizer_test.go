@@ -402,6 +402,13 @@ func TestNormalize(t *testing.T) {
 			"bv2": sqltypes.Int64BindVariable(2),
 			"bv3": sqltypes.Int64BindVariable(3),
 		},
+	}, {
+		// the N of window functions is not a bind variable
+		in:      "select ntile(4) over w, lag(a, 2, 0) over w, nth_value(a, 3) over w from t window w as (order by a)",
+		outstmt: "select ntile(4) over w, lag(a, 2, :bv1 /* INT64 */) over w, nth_value(a, 3) over w from t window w AS ( order by a asc)",
+		outbv: map[string]*querypb.BindVariable{
+			"bv1": sqltypes.Int64BindVariable(0),
+		},
 	}}
 	parser := NewTestParser()
 	for _, tc := range testcases {
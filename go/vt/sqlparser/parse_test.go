@@ -43,6 +43,14 @@ var (
 		ignoreNormalizerTest bool
 	}{{
 		input: "select * from foo limit 5 + 5",
+	}, {
+		input:  "select id from t order by vec_distance_euclidean(v, vec_fromtext('[0.1, 0.2, 0.3]')) limit 10",
+		output: "select id from t order by vec_distance_euclidean(v, vec_fromtext('[0.1, 0.2, 0.3]')) asc limit 10",
+	}, {
+		input: "select vector_to_string(v), vector_dim(v) from t where v = string_to_vector('[1,2]')",
+	}, {
+		input:  "select vector from t",
+		output: "select `vector` from t",
 	}, {
 		input:  "create table x(location GEOMETRYCOLLECTION DEFAULT (POINT(7.0, 3.0)))",
 		output: "create table x (\n\tlocation GEOMETRYCOLLECTION default (point(7.0, 3.0))\n)",
@@ -639,6 +647,28 @@ var (
 	}, {
 		input:  "select /* union distinct */ 1 from t union distinct select 1 from t",
 		output: "select /* union distinct */ 1 from t union select 1 from t",
+	}, {
+		input: "select /* intersect */ 1 from t intersect select 1 from t",
+	}, {
+		input:  "select /* intersect distinct */ 1 from t intersect distinct select 1 from t",
+		output: "select /* intersect distinct */ 1 from t intersect select 1 from t",
+	}, {
+		input: "select /* intersect all */ 1 from t intersect all select 1 from t",
+	}, {
+		input: "select /* except */ 1 from t except select 1 from t",
+	}, {
+		input: "select /* except all */ 1 from t except all select 1 from t except select 1 from t",
+	}, {
+		input: "select /* intersect precedence */ 1 from t union select 1 from t intersect select 1 from t",
+	}, {
+		input: "select /* intersect precedence 2 */ 1 from t intersect select 1 from t except select 1 from t",
+	}, {
+		input: "(select /* parenthesized union */ 1 from t union select 1 from t) intersect select 1 from t",
+	}, {
+		input: "select /* parenthesized except */ 1 from t except (select 1 from t except select 1 from t)",
+	}, {
+		input:  "(select /* intersect parenthesized selects */ 1 from t) intersect (select 1 from t) order by 1",
+		output: "select /* intersect parenthesized selects */ 1 from t intersect select 1 from t order by 1 asc",
 	}, {
 		input:  "(select /* union parenthesized select */ 1 from t order by a) union select 1 from t",
 		output: "(select /* union parenthesized select */ 1 from t order by a asc) union select 1 from t",
@@ -736,7 +766,7 @@ var (
 		input: "select /* a.* */ a.* from t",
 	}, {
 		input:  "select next value for t",
-		output: "select next 1 values from t",
+		output: "select next value for t from dual",
 	}, {
 		input:  "select next value from t",
 		output: "select next 1 values from t",
@@ -744,6 +774,17 @@ var (
 		input: "select next 10 values from t",
 	}, {
 		input: "select next :a values from t",
+	}, {
+		input:  "select nextval(t)",
+		output: "select next value for t from dual",
+	}, {
+		input: "select next value for ks.t, a from dual",
+	}, {
+		input:  "insert into t(id, a) values (next value for s, 1), (nextval(ks.s), 2)",
+		output: "insert into t(id, a) values (next value for s, 1), (next value for ks.s, 2)",
+	}, {
+		input:  "select cache, cycle, increment, nocache from nextval",
+		output: "select `cache`, `cycle`, `increment`, `nocache` from `nextval`",
 	}, {
 		input: "select /* `By`.* */ `By`.* from t",
 	}, {
@@ -1292,6 +1333,18 @@ var (
 		input: "insert /* on duplicate */ into a values (1, 2) on duplicate key update b = func(a), c = d",
 	}, {
 		input: "insert /* bool in insert value */ into a values (1, true, false)",
+	}, {
+		input: "insert /* returning */ into a(b, c) values (1, 2) returning id, b",
+	}, {
+		input:  "insert into a set b = 1 returning *",
+		output: "insert into a(b) values (1) returning *",
+	}, {
+		input: "insert into a values (1, 2) on duplicate key update b = 3 returning a.id as x, b + 1",
+	}, {
+		input:  "replace into a select b from c returning d",
+		output: "replace into a select b from c returning d",
+	}, {
+		input: "insert into a select b as `returning` from c as `returning` returning `returning`",
 	}, {
 		input: "insert /* bool in on duplicate */ into a values (1, 2) on duplicate key update b = false, c = d",
 	}, {
@@ -1384,6 +1437,12 @@ var (
 		input: "delete ignore from a",
 	}, {
 		input: "delete /* limit */ ignore from a",
+	}, {
+		input: "delete /* returning */ from a where b = 1 returning id, b",
+	}, {
+		input: "delete from a limit 1 returning *",
+	}, {
+		input: "delete from a as b returning b.id",
 	}, {
 		input:  "delete from a1, a2 using t1 as a1 inner join t2 as a2 where a1.id=a2.id",
 		output: "delete a1, a2 from t1 as a1 join t2 as a2 where a1.id = a2.id",
@@ -1946,6 +2005,13 @@ var (
 		// Alter Vschema does not reach the vttablets, so we don't need to run the normalizer test
 		input:                "alter vschema add table ks.a",
 		ignoreNormalizerTest: true,
+	}, {
+		input: "create sequence s",
+	}, {
+		input: "create /* comment */ sequence if not exists ks.s start with 100 increment by 1 minvalue 1 no maxvalue cache 1000 nocycle",
+	}, {
+		input:  "create sequence s start = 5 increment = -1 maxvalue = 10 nominvalue nomaxvalue nocache cycle",
+		output: "create sequence s start with 5 increment by -1 maxvalue 10 no minvalue no maxvalue nocache cycle",
 	}, {
 		// Alter Vschema does not reach the vttablets, so we don't need to run the normalizer test
 		input:                "alter vschema add sequence a_seq",
@@ -2541,6 +2607,8 @@ var (
 		input: "vexplain all select * from t",
 	}, {
 		input: "vexplain plan select * from t",
+	}, {
+		input: "vexplain trace select * from t",
 	}, {
 		input:  "vexplain select * from t",
 		output: "vexplain plan select * from t",
@@ -3111,6 +3179,12 @@ var (
 	}, {
 		input:  "select b from v1 vq1, lateral (select count(*) from v1 vq2 having vq1.b = 3) dt",
 		output: "select b from v1 as vq1, lateral (select count(*) from v1 as vq2 having vq1.b = 3) as dt",
+	}, {
+		input:  "select t1.a, dt.c from t1 join lateral (select count(*) as c from t2 where t2.a = t1.a) dt on true",
+		output: "select t1.a, dt.c from t1 join lateral (select count(*) as c from t2 where t2.a = t1.a) as dt on true",
+	}, {
+		input:  "select t1.a, dt.b from t1 left join lateral (select t2.b from t2 where t2.a = t1.a limit 1) as dt on dt.b > 0",
+		output: "select t1.a, dt.b from t1 left join lateral (select t2.b from t2 where t2.a = t1.a limit 1) as dt on dt.b > 0",
 	}, {
 		input:  `SELECT JSON_SCHEMA_VALID('{"type":"string","pattern":"("}', '"abc"')`,
 		output: `select json_schema_valid('{\"type\":\"string\",\"pattern\":\"(\"}', '\"abc\"') from dual`,
@@ -4679,7 +4753,7 @@ func TestLoadData(t *testing.T) {
 		"load data from s3 'x.txt'",
 		"load data from s3 manifest 'x.txt'",
 		"load data from s3 file 'x.txt'",
-		"load data infile 'x.txt' into table 'c'",
+		"load data infile 'x.txt' into table c",
 		"load data from s3 'x.txt' into table x"}
 
 	parser := NewTestParser()
@@ -4689,6 +4763,51 @@ func TestLoadData(t *testing.T) {
 	}
 }
 
+func TestLoadDataInfile(t *testing.T) {
+	testcases := []struct {
+		input  string
+		output string
+	}{{
+		input: "load data infile 'x.txt' into table t",
+	}, {
+		input: "load data local infile '/tmp/x.csv' replace into table ks.t",
+	}, {
+		input:  `LOAD DATA LOCAL INFILE 'x.csv' IGNORE INTO TABLE t CHARACTER SET utf8mb4 COLUMNS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' LINES TERMINATED BY '\r\n' IGNORE 1 ROWS (a, b, c)`,
+		output: `load data local infile 'x.csv' ignore into table t character set utf8mb4 fields terminated by ',' optionally enclosed by '\"' lines terminated by '\r\n' ignore 1 lines (a, b, c)`,
+	}, {
+		input:  `load data local infile 'x.txt' into table t fields escaped by '' terminated by '\t' lines starting by 'xxx'`,
+		output: `load data local infile 'x.txt' into table t fields terminated by '\t' escaped by '' lines starting by 'xxx'`,
+	}}
+
+	parser := NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.input, func(t *testing.T) {
+			if tcase.output == "" {
+				tcase.output = tcase.input
+			}
+			tree, err := parser.Parse(tcase.input)
+			require.NoError(t, err)
+			require.Equal(t, tcase.output, String(tree))
+		})
+	}
+
+	tree, err := parser.Parse(`load data local infile 'x.csv' into table t fields terminated by ',' enclosed by '"' escaped by '\\' lines starting by '>' terminated by '\n' ignore 2 lines (a, b)`)
+	require.NoError(t, err)
+	load, ok := tree.(*Load)
+	require.True(t, ok)
+	require.True(t, load.Local)
+	require.Equal(t, "x.csv", load.FileName)
+	require.Equal(t, "t", load.Table.Name.String())
+	require.Equal(t, ",", *load.FieldsTerminatedBy)
+	require.Equal(t, `"`, *load.FieldsEnclosedBy)
+	require.False(t, load.FieldsOptionallyEnclosed)
+	require.Equal(t, `\`, *load.FieldsEscapedBy)
+	require.Equal(t, ">", *load.LinesStartingBy)
+	require.Equal(t, "\n", *load.LinesTerminatedBy)
+	require.Equal(t, 2, load.IgnoreLines)
+	require.Equal(t, Columns{NewIdentifierCI("a"), NewIdentifierCI("b")}, load.Columns)
+}
+
 func TestCreateTable(t *testing.T) {
 	createTableQueries := []struct {
 		input, output string
@@ -4744,6 +4863,8 @@ func TestCreateTable(t *testing.T) {
 	col_longtext longtext,
 	col_text text character set ascii collate ascii_bin,
 	col_json json,
+	col_vector vector,
+	col_vector2 vector(3) not null,
 	col_enum enum('a', 'b', 'c', 'd'),
 	col_enum2 enum('a', 'b', 'c', 'd') character set ascii,
 	col_enum3 enum('a', 'b', 'c', 'd') collate ascii_bin,
@@ -5974,6 +6095,9 @@ var (
 	}{{
 		input:  "select : from t",
 		output: "syntax error at position 9 near ':'",
+	}, {
+		input:  "delete a from a join b returning a.id",
+		output: "syntax error at position 33 near 'returning'",
 	}, {
 		input:  "execute stmt using 1;",
 		output: "syntax error at position 21 near '1'",
@@ -311,12 +311,15 @@ type Options struct {
 	MySQLServerVersion string
 	TruncateUILen      int
 	TruncateErrLen     int
+	// RedactQueryLiterals redacts the literals of the queries logged by TruncateForLog.
+	RedactQueryLiterals bool
 }
 
 type Parser struct {
-	version        string
-	truncateUILen  int
-	truncateErrLen int
+	version             string
+	truncateUILen       int
+	truncateErrLen      int
+	redactQueryLiterals bool
 }
 
 func New(opts Options) (*Parser, error) {
@@ -328,9 +331,10 @@ func New(opts Options) (*Parser, error) {
 		return nil, err
 	}
 	return &Parser{
-		version:        convVersion,
-		truncateUILen:  opts.TruncateUILen,
-		truncateErrLen: opts.TruncateErrLen,
+		version:             convVersion,
+		truncateUILen:       opts.TruncateUILen,
+		truncateErrLen:      opts.TruncateErrLen,
+		redactQueryLiterals: opts.RedactQueryLiterals,
 	}, nil
 }
 
@@ -35,3 +35,24 @@ func (p *Parser) RedactSQLQuery(sql string) (string, error) {
 
 	return comments.Leading + String(stmt) + comments.Trailing, nil
 }
+
+// RedactsQueryLiterals returns true if the literals of the queries are
+// redacted from the errors and logs.
+func (p *Parser) RedactsQueryLiterals() bool {
+	return p.redactQueryLiterals
+}
+
+// RedactForLog returns the query with its literals redacted if the parser
+// redacts the query literals, and the query untouched otherwise. A query that
+// cannot be parsed is replaced by its statement type, as its literals cannot
+// be told apart.
+func (p *Parser) RedactForLog(query string) string {
+	if !p.redactQueryLiterals || query == "" {
+		return query
+	}
+	redacted, err := p.RedactSQLQuery(query)
+	if err != nil {
+		return Preview(query).String()
+	}
+	return redacted
+}
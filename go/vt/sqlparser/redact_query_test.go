@@ -32,3 +32,19 @@ func TestRedactSQLStatements(t *testing.T) {
 
 	require.Equal(t, "select a, b, c from t where x = :x /* INT64 */ and y = :x /* INT64 */ and z = :z /* VARCHAR */", redactedSQL)
 }
+
+func TestRedactForLog(t *testing.T) {
+	sql := "select a from t where x = 1234 and z = 'apple'"
+	parser := NewTestParser()
+	require.False(t, parser.RedactsQueryLiterals())
+	require.Equal(t, sql, parser.RedactForLog(sql))
+
+	parser, err := New(Options{RedactQueryLiterals: true, TruncateErrLen: 40})
+	require.NoError(t, err)
+	require.True(t, parser.RedactsQueryLiterals())
+	require.Equal(t, "select a from t where x = :x /* INT64 */ and z = :z /* VARCHAR */", parser.RedactForLog(sql))
+	require.Equal(t, "select a from t where x = :x [TRUNCATED] /* VARCHAR */", parser.TruncateForLog(sql))
+	// A query that cannot be parsed is replaced by its type.
+	require.Equal(t, "SELECT", parser.RedactForLog("select 'apple' frm t"))
+	require.Equal(t, "", parser.RedactForLog(""))
+}
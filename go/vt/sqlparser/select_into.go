@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// ExportOptions is the format of the file written by SELECT ... INTO OUTFILE.
+// The options that are not part of the query have the default values of MySQL.
+type ExportOptions struct {
+	FieldsTerminatedBy       string
+	FieldsEnclosedBy         string
+	FieldsOptionallyEnclosed bool
+	FieldsEscapedBy          string
+	LinesStartingBy          string
+	LinesTerminatedBy        string
+
+	// Header writes the names of the columns as the first line of the file, for FORMAT CSV HEADER.
+	Header bool
+}
+
+// ParseSelectInto parses the file name and the export options of a SELECT ... INTO,
+// which are kept in the AST as they are formatted.
+func (p *Parser) ParseSelectInto(into *SelectInto) (fileName string, opts *ExportOptions, err error) {
+	invalid := func() error {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid INTO clause: %s", String(into))
+	}
+
+	tokenizer := p.NewStringTokenizer(into.FileName)
+	token, value := tokenizer.Scan()
+	if token != STRING {
+		return "", nil, invalid()
+	}
+	fileName = value
+	if token, _ = tokenizer.Scan(); token != 0 {
+		return "", nil, invalid()
+	}
+
+	opts = &ExportOptions{
+		FieldsTerminatedBy: "\t",
+		FieldsEscapedBy:    "\\",
+		LinesTerminatedBy:  "\n",
+	}
+	section, optionally := FIELDS, false
+	tokenizer = p.NewStringTokenizer(into.FormatOption + into.ExportOption)
+	for {
+		token, _ = tokenizer.Scan()
+		switch token {
+		case 0:
+			return fileName, opts, nil
+		case FORMAT:
+			switch token, _ = tokenizer.Scan(); token {
+			case CSV:
+				opts.FieldsTerminatedBy = ","
+				opts.FieldsEnclosedBy = "\""
+				opts.FieldsOptionallyEnclosed = true
+			case TEXT:
+			default:
+				return "", nil, invalid()
+			}
+		case HEADER:
+			opts.Header = true
+		case FIELDS, COLUMNS, LINES:
+			section = token
+		case OPTIONALLY:
+			optionally = true
+		case TERMINATED, ENCLOSED, ESCAPED, STARTING:
+			if by, _ := tokenizer.Scan(); by != BY {
+				return "", nil, invalid()
+			}
+			str, value := tokenizer.Scan()
+			if str != STRING {
+				return "", nil, invalid()
+			}
+			switch {
+			case token == TERMINATED && section == LINES:
+				opts.LinesTerminatedBy = value
+			case token == TERMINATED:
+				opts.FieldsTerminatedBy = value
+			case token == ENCLOSED:
+				opts.FieldsEnclosedBy = value
+				opts.FieldsOptionallyEnclosed = optionally
+				optionally = false
+			case token == ESCAPED:
+				opts.FieldsEscapedBy = value
+			case token == STARTING:
+				opts.LinesStartingBy = value
+			}
+			if (token == ENCLOSED || token == ESCAPED) && len(value) > 1 {
+				return "", nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Field separator argument is not what is expected; check the manual")
+			}
+		default:
+			return "", nil, invalid()
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelectInto(t *testing.T) {
+	defaults := ExportOptions{
+		FieldsTerminatedBy: "\t",
+		FieldsEscapedBy:    "\\",
+		LinesTerminatedBy:  "\n",
+	}
+	testcases := []struct {
+		query    string
+		fileName string
+		opts     ExportOptions
+	}{{
+		query:    "select * from t into outfile 'x.txt'",
+		fileName: "x.txt",
+		opts:     defaults,
+	}, {
+		query:    "select * from t into dumpfile '/tmp/it''s.bin'",
+		fileName: "/tmp/it's.bin",
+		opts:     defaults,
+	}, {
+		query:    `select * from t into outfile 'x.csv' fields terminated by ',' optionally enclosed by '"' escaped by '' lines starting by '>' terminated by '\r\n'`,
+		fileName: "x.csv",
+		opts: ExportOptions{
+			FieldsTerminatedBy:       ",",
+			FieldsEnclosedBy:         `"`,
+			FieldsOptionallyEnclosed: true,
+			LinesStartingBy:          ">",
+			LinesTerminatedBy:        "\r\n",
+		},
+	}, {
+		query:    "select * from t into outfile 'x.txt' columns enclosed by '|' lines terminated by ';'",
+		fileName: "x.txt",
+		opts: ExportOptions{
+			FieldsTerminatedBy: "\t",
+			FieldsEnclosedBy:   "|",
+			FieldsEscapedBy:    "\\",
+			LinesTerminatedBy:  ";",
+		},
+	}, {
+		query:    "select * from t into outfile s3 's3://bucket/x.csv' format csv header",
+		fileName: "s3://bucket/x.csv",
+		opts: ExportOptions{
+			FieldsTerminatedBy:       ",",
+			FieldsEnclosedBy:         `"`,
+			FieldsOptionallyEnclosed: true,
+			FieldsEscapedBy:          "\\",
+			LinesTerminatedBy:        "\n",
+			Header:                   true,
+		},
+	}, {
+		query:    "select * from t into outfile s3 'gs://bucket/x.csv' format csv fields terminated by ';' enclosed by '\\'' overwrite on",
+		fileName: "gs://bucket/x.csv",
+		opts: ExportOptions{
+			FieldsTerminatedBy: ";",
+			FieldsEnclosedBy:   "'",
+			FieldsEscapedBy:    "\\",
+			LinesTerminatedBy:  "\n",
+		},
+	}, {
+		query:    "select * from t into outfile s3 'x.txt' format text",
+		fileName: "x.txt",
+		opts:     defaults,
+	}}
+
+	parser := NewTestParser()
+	for _, tcase := range testcases {
+		t.Run(tcase.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tcase.query)
+			require.NoError(t, err)
+			fileName, opts, err := parser.ParseSelectInto(stmt.(*Select).Into)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.fileName, fileName)
+			assert.Equal(t, tcase.opts, *opts)
+		})
+	}
+}
+
+func TestParseSelectIntoErrors(t *testing.T) {
+	parser := NewTestParser()
+
+	stmt, err := parser.Parse("select * from t into outfile 'x.txt' fields enclosed by 'ab'")
+	require.NoError(t, err)
+	_, _, err = parser.ParseSelectInto(stmt.(*Select).Into)
+	require.EqualError(t, err, "Field separator argument is not what is expected; check the manual")
+
+	_, _, err = parser.ParseSelectInto(&SelectInto{Type: IntoOutfile, FileName: "x.txt"})
+	require.EqualError(t, err, "invalid INTO clause:  into outfile x.txt")
+}
@@ -141,6 +141,8 @@ func markBindVariable(yylex yyLexer, bvar string) {
   databaseOptions []DatabaseOption
   tableAndLockTypes TableAndLockTypes
   renameTablePairs []*RenameTablePair
+  sequenceOption *SequenceOption
+  sequenceOptions []*SequenceOption
   alterOptions	   []AlterOption
   vindexParams  []VindexParam
   jsonObjectParams []*JSONObjectParam
@@ -202,6 +204,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
   jtOnResponse	*JtOnResponse
   variables      []*Variable
   variable       *Variable
+  load           *Load
 }
 
 // These precedence rules are there to handle shift-reduce conflicts.
@@ -237,9 +240,16 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // In order to ensure lower precedence of reduction, this rule has to come before the precedence declaration of STRING.
 // This precedence should not be used anywhere else other than with non-reserved-keywords that are also used for type-casting a STRING.
 %nonassoc <str> STRING_TYPE_PREFIX_NON_KEYWORD
+// RETURNING and NO_ALIAS_BEFORE_RETURNING are used to resolve shift-reduce conflicts occuring due to RETURNING being a non-reserved
+// keyword that can follow a table name or a select expression, like in DELETE FROM t RETURNING id. After seeing the table name or the
+// expression, if we see RETURNING, then we can either shift to use it as an alias or reduce the missing alias and start the RETURNING clause.
+// The way to fix this conflict is to give reducing the missing alias higher precedence than shifting RETURNING, so it can only be used
+// as an alias with AS, like in MariaDB.
+%nonassoc <str> RETURNING
+%nonassoc <str> NO_ALIAS_BEFORE_RETURNING
 
 %token LEX_ERROR
-%left <str> UNION
+%left <str> UNION EXCEPT INTERSECT
 %token <str> SELECT STREAM VSTREAM INSERT UPDATE DELETE FROM WHERE GROUP HAVING ORDER BY LIMIT OFFSET FOR
 %token <str> ALL DISTINCT AS EXISTS ASC DESC INTO DUPLICATE DEFAULT SET LOCK UNLOCK KEYS DO CALL
 %token <str> DISTINCTROW PARSER GENERATED ALWAYS
@@ -306,7 +316,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 
 // DDL Tokens
 %token <str> CREATE ALTER DROP RENAME ANALYZE ADD FLUSH CHANGE MODIFY DEALLOCATE
-%token <str> REVERT QUERIES
+%token <str> REVERT QUERIES TRACE
 %token <str> SCHEMA TABLE INDEX VIEW TO IGNORE IF PRIMARY COLUMN SPATIAL FULLTEXT KEY_BLOCK_SIZE CHECK INDEXES
 %token <str> ACTION CASCADE CONSTRAINT FOREIGN NO REFERENCES RESTRICT
 %token <str> SHOW DESCRIBE EXPLAIN DATE ESCAPE REPAIR OPTIMIZE TRUNCATE COALESCE EXCHANGE REBUILD PARTITIONING REMOVE PREPARE EXECUTE
@@ -315,6 +325,9 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> STATUS VARIABLES WARNINGS CASCADED DEFINER OPTION SQL UNDEFINED
 %token <str> SEQUENCE MERGE TEMPORARY TEMPTABLE INVOKER SECURITY FIRST AFTER LAST
 
+// Sequence tokens
+%token <str> INCREMENT MINVALUE NOMINVALUE NOMAXVALUE CACHE NOCACHE CYCLE NOCYCLE NEXTVAL
+
 // Migration tokens
 %token <str> VITESS_MIGRATION CANCEL RETRY LAUNCH COMPLETE CLEANUP THROTTLE UNTHROTTLE FORCE_CUTOVER EXPIRE RATIO
 // Throttler tokens
@@ -330,7 +343,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> TIME TIMESTAMP DATETIME YEAR
 %token <str> CHAR VARCHAR BOOL CHARACTER VARBINARY NCHAR
 %token <str> TEXT TINYTEXT MEDIUMTEXT LONGTEXT
-%token <str> BLOB TINYBLOB MEDIUMBLOB LONGBLOB JSON JSON_SCHEMA_VALID JSON_SCHEMA_VALIDATION_REPORT ENUM
+%token <str> BLOB TINYBLOB MEDIUMBLOB LONGBLOB JSON JSON_SCHEMA_VALID JSON_SCHEMA_VALIDATION_REPORT ENUM VECTOR
 %token <str> GEOMETRY POINT LINESTRING POLYGON GEOMCOLLECTION GEOMETRYCOLLECTION MULTIPOINT MULTILINESTRING MULTIPOLYGON
 %token <str> ASCII UNICODE // used in CONVERT/CAST types
 
@@ -378,13 +391,13 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> MATCH AGAINST BOOLEAN LANGUAGE WITH QUERY EXPANSION WITHOUT VALIDATION
 
 // MySQL reserved words that are unused by this grammar will map to this token.
-%token <str> UNUSED ARRAY BYTE CUME_DIST DESCRIPTION DENSE_RANK EMPTY EXCEPT FIRST_VALUE GROUPING GROUPS JSON_TABLE LAG LAST_VALUE LATERAL LEAD
+%token <str> UNUSED ARRAY BYTE CUME_DIST DESCRIPTION DENSE_RANK EMPTY FIRST_VALUE GROUPING GROUPS JSON_TABLE LAG LAST_VALUE LATERAL LEAD
 %token <str> NTH_VALUE NTILE OF OVER PERCENT_RANK RANK RECURSIVE ROW_NUMBER SYSTEM WINDOW
 %token <str> ACTIVE ADMIN AUTOEXTEND_SIZE BUCKETS CLONE COLUMN_FORMAT COMPONENT DEFINITION ENFORCED ENGINE_ATTRIBUTE EXCLUDE FOLLOWING GET_MASTER_PUBLIC_KEY HISTOGRAM HISTORY
 %token <str> INACTIVE INVISIBLE LOCKED MASTER_COMPRESSION_ALGORITHMS MASTER_PUBLIC_KEY_PATH MASTER_TLS_CIPHERSUITES MASTER_ZSTD_COMPRESSION_LEVEL
 %token <str> NESTED NETWORK_NAMESPACE NOWAIT NULLS OJ OLD OPTIONAL ORDINALITY ORGANIZATION OTHERS PARTIAL PATH PERSIST PERSIST_ONLY PRECEDING PRIVILEGE_CHECKS_USER PROCESS
 %token <str> RANDOM REFERENCE REQUIRE_ROW_FORMAT RESOURCE RESPECT RESTART RETAIN REUSE ROLE SECONDARY SECONDARY_ENGINE SECONDARY_ENGINE_ATTRIBUTE SECONDARY_LOAD SECONDARY_UNLOAD SIMPLE SKIP SRID
-%token <str> THREAD_PRIORITY TIES UNBOUNDED VCPU VISIBLE RETURNING
+%token <str> THREAD_PRIORITY TIES UNBOUNDED VCPU VISIBLE
 
 // Performance Schema Functions
 %token <str> FORMAT_BYTES FORMAT_PICO_TIME PS_CURRENT_THREAD_ID PS_THREAD_ID
@@ -398,6 +411,9 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // Lock type tokens
 %token <str> LOCAL LOW_PRIORITY
 
+// Load data tokens
+%token <str> INFILE
+
 // Flush tokens
 %token <str> NO_WRITE_TO_BINLOG LOGS ERROR GENERAL HOSTS OPTIMIZER_COSTS USER_RESOURCES SLOW CHANNEL RELAY EXPORT
 
@@ -418,11 +434,14 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <statement> prepare_statement execute_statement deallocate_statement
 %type <statement> stream_statement vstream_statement insert_statement update_statement delete_statement set_statement set_transaction_statement
 %type <statement> create_statement alter_statement rename_statement drop_statement truncate_statement flush_statement do_statement
-%type <selStmt> select_statement select_stmt_with_into query_expression_parens query_expression query_expression_body query_primary
+%type <selStmt> select_statement select_stmt_with_into query_expression_parens query_expression query_expression_body query_term query_primary
 %type <with> with_clause_opt with_clause
 %type <cte> common_table_expr
 %type <ctes> with_list
 %type <renameTablePairs> rename_list
+%type <sequenceOptions> sequence_option_list_opt sequence_option_list
+%type <sequenceOption> sequence_option
+%type <literal> sequence_value
 %type <createTable> create_table_prefix
 %type <alterTable> alter_table_prefix
 %type <alterOption> alter_option alter_commands_modifier lock_index algorithm_index
@@ -468,10 +487,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <subPartitionDefinitions> subpartition_definition_list subpartition_definition_list_with_brackets
 %type <subPartitionDefinitionOptions> subpartition_definition_attribute_list_opt
 %type <intervalType> interval timestampadd_interval
-%type <str> cache_opt separator_opt flush_option for_channel_opt maxvalue
+%type <str> separator_opt flush_option for_channel_opt maxvalue
 %type <matchExprOption> match_option
-%type <boolean> distinct_opt union_op replace_opt local_opt
-%type <selectExprs> select_expression_list
+%type <boolean> distinct_opt union_op except_op intersect_op replace_opt local_opt
+%type <selectExprs> select_expression_list returning_opt
 %type <selectExpr> select_expression
 %type <strs> select_options select_options_opt flush_option_list
 %type <str> select_option algorithm_view security_view security_view_opt
@@ -535,6 +554,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <str> for_from from_or_on
 %type <str> default_opt
 %type <ignore> ignore_opt
+%type <boolean> load_local_opt
+%type <str> load_duplicate_opt
+%type <load> load_fields_opt load_fields_list load_lines_opt load_lines_list
+%type <integer> load_ignore_lines_opt
 %type <str> columns_or_fields extended_opt storage_opt
 %type <showFilter> like_or_where_opt like_opt
 %type <boolean> exists_opt not_exists_opt enforced enforced_opt temp_opt full_opt
@@ -702,10 +725,132 @@ do_statement:
   }
 
 load_statement:
-  LOAD DATA skip_to_end
+  LOAD DATA load_local_opt INFILE STRING load_duplicate_opt INTO TABLE table_name charset_opt load_fields_opt load_lines_opt load_ignore_lines_opt column_list_opt
+  {
+    load := $11
+    load.Local = $3
+    load.FileName = $5
+    load.Replace = $6 == ReplaceStr
+    load.Ignore = $6 == IgnoreStr
+    load.Table = $9
+    load.Charset = $10
+    load.LinesStartingBy = $12.LinesStartingBy
+    load.LinesTerminatedBy = $12.LinesTerminatedBy
+    load.IgnoreLines = $13
+    load.Columns = $14
+    $$ = load
+  }
+| LOAD DATA FROM skip_to_end
   {
     $$ = &Load{}
   }
+| LOAD DATA LOW_PRIORITY skip_to_end
+  {
+    $$ = &Load{}
+  }
+
+load_local_opt:
+  {
+    $$ = false
+  }
+| LOCAL
+  {
+    $$ = true
+  }
+
+load_duplicate_opt:
+  {
+    $$ = ""
+  }
+| REPLACE
+  {
+    $$ = ReplaceStr
+  }
+| IGNORE
+  {
+    $$ = IgnoreStr
+  }
+
+load_fields_opt:
+  {
+    $$ = &Load{}
+  }
+| columns_or_fields load_fields_list
+  {
+    $$ = $2
+  }
+
+load_fields_list:
+  TERMINATED BY STRING
+  {
+    $$ = &Load{FieldsTerminatedBy: ptr.Of($3)}
+  }
+| optionally_opt ENCLOSED BY STRING
+  {
+    $$ = &Load{FieldsEnclosedBy: ptr.Of($4), FieldsOptionallyEnclosed: $1 != ""}
+  }
+| ESCAPED BY STRING
+  {
+    $$ = &Load{FieldsEscapedBy: ptr.Of($3)}
+  }
+| load_fields_list TERMINATED BY STRING
+  {
+    $1.FieldsTerminatedBy = ptr.Of($4)
+    $$ = $1
+  }
+| load_fields_list optionally_opt ENCLOSED BY STRING
+  {
+    $1.FieldsEnclosedBy = ptr.Of($5)
+    $1.FieldsOptionallyEnclosed = $2 != ""
+    $$ = $1
+  }
+| load_fields_list ESCAPED BY STRING
+  {
+    $1.FieldsEscapedBy = ptr.Of($4)
+    $$ = $1
+  }
+
+load_lines_opt:
+  {
+    $$ = &Load{}
+  }
+| LINES load_lines_list
+  {
+    $$ = $2
+  }
+
+load_lines_list:
+  STARTING BY STRING
+  {
+    $$ = &Load{LinesStartingBy: ptr.Of($3)}
+  }
+| TERMINATED BY STRING
+  {
+    $$ = &Load{LinesTerminatedBy: ptr.Of($3)}
+  }
+| load_lines_list STARTING BY STRING
+  {
+    $1.LinesStartingBy = ptr.Of($4)
+    $$ = $1
+  }
+| load_lines_list TERMINATED BY STRING
+  {
+    $1.LinesTerminatedBy = ptr.Of($4)
+    $$ = $1
+  }
+
+load_ignore_lines_opt:
+  {
+    $$ = 0
+  }
+| IGNORE INTEGRAL LINES
+  {
+    $$ = convertStringToInt($2)
+  }
+| IGNORE INTEGRAL ROWS
+  {
+    $$ = convertStringToInt($2)
+  }
 
 with_clause:
   WITH with_list
@@ -815,21 +960,25 @@ query_expression:
   {
 	$2.SetWith($1)
   }
-| SELECT comment_opt cache_opt NEXT num_val for_from table_name
+| SELECT comment_opt select_options_opt NEXT num_val for_from table_name
   {
-	$$ = NewSelect(Comments($2), SelectExprs{&Nextval{Expr: $5}}, []string{$3}/*options*/, nil, TableExprs{&AliasedTableExpr{Expr: $7}}, nil/*where*/, nil/*groupBy*/, nil/*having*/, nil)
+	$$ = NewSelect(Comments($2), SelectExprs{&Nextval{Expr: $5}}, $3/*options*/, nil, TableExprs{&AliasedTableExpr{Expr: $7}}, nil/*where*/, nil/*groupBy*/, nil/*having*/, nil)
+  }
+| SELECT comment_opt select_options_opt NEXT next_value FROM table_name
+  {
+	$$ = NewSelect(Comments($2), SelectExprs{&Nextval{Expr: NewIntLiteral("1")}}, $3/*options*/, nil, TableExprs{&AliasedTableExpr{Expr: $7}}, nil/*where*/, nil/*groupBy*/, nil/*having*/, nil)
   }
 
 query_expression_body:
- query_primary
+ query_term
   {
 	$$ = $1
   }
-| query_expression_body union_op query_primary
+| query_expression_body union_op query_term
   {
  	$$ = &Union{Left: $1, Distinct: $2, Right: $3}
   }
-| query_expression_parens union_op query_primary
+| query_expression_parens union_op query_term
   {
 	$$ = &Union{Left: $1, Distinct: $2, Right: $3}
   }
@@ -841,6 +990,45 @@ query_expression_body:
   {
 	$$ = &Union{Left: $1, Distinct: $2, Right: $3}
   }
+| query_expression_body except_op query_term
+  {
+ 	$$ = &Union{Left: $1, Type: ExceptType, Distinct: $2, Right: $3}
+  }
+| query_expression_parens except_op query_term
+  {
+	$$ = &Union{Left: $1, Type: ExceptType, Distinct: $2, Right: $3}
+  }
+| query_expression_body except_op query_expression_parens
+  {
+  	$$ = &Union{Left: $1, Type: ExceptType, Distinct: $2, Right: $3}
+  }
+| query_expression_parens except_op query_expression_parens
+  {
+	$$ = &Union{Left: $1, Type: ExceptType, Distinct: $2, Right: $3}
+  }
+
+// query_term binds INTERSECT tighter than UNION and EXCEPT, like MySQL does
+query_term:
+ query_primary
+  {
+	$$ = $1
+  }
+| query_term intersect_op query_primary
+  {
+ 	$$ = &Union{Left: $1, Type: IntersectType, Distinct: $2, Right: $3}
+  }
+| query_expression_parens intersect_op query_primary
+  {
+	$$ = &Union{Left: $1, Type: IntersectType, Distinct: $2, Right: $3}
+  }
+| query_term intersect_op query_expression_parens
+  {
+  	$$ = &Union{Left: $1, Type: IntersectType, Distinct: $2, Right: $3}
+  }
+| query_expression_parens intersect_op query_expression_parens
+  {
+	$$ = &Union{Left: $1, Type: IntersectType, Distinct: $2, Right: $3}
+  }
 
 select_statement:
 query_expression
@@ -914,7 +1102,7 @@ query_primary:
   }
 
 insert_statement:
-  insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause insert_data on_dup_opt
+  insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause insert_data on_dup_opt returning_opt
   {
     // insert_data returns a *Insert pre-filled with Columns & Values
     ins := $6
@@ -924,9 +1112,10 @@ insert_statement:
     ins.Table = getAliasedTableExprFromTableName($4)
     ins.Partitions = $5
     ins.OnDup = OnDup($7)
+    ins.Returning = $8
     $$ = ins
   }
-| insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause SET update_list on_dup_opt
+| insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause SET update_list on_dup_opt returning_opt
   {
     cols := make(Columns, 0, len($7))
     vals := make(ValTuple, 0, len($8))
@@ -934,7 +1123,16 @@ insert_statement:
       cols = append(cols, updateList.Name.Name)
       vals = append(vals, updateList.Expr)
     }
-    $$ = &Insert{Action: $1, Comments: Comments($2).Parsed(), Ignore: $3, Table: getAliasedTableExprFromTableName($4), Partitions: $5, Columns: cols, Rows: Values{vals}, OnDup: OnDup($8)}
+    $$ = &Insert{Action: $1, Comments: Comments($2).Parsed(), Ignore: $3, Table: getAliasedTableExprFromTableName($4), Partitions: $5, Columns: cols, Rows: Values{vals}, OnDup: OnDup($8), Returning: $9}
+  }
+
+returning_opt:
+  {
+    $$ = nil
+  }
+| RETURNING select_expression_list
+  {
+    $$ = $2
   }
 
 insert_or_replace:
@@ -954,9 +1152,9 @@ update_statement:
   }
 
 delete_statement:
-  with_clause_opt DELETE comment_opt ignore_opt FROM table_name as_opt_id opt_partition_clause where_expression_opt order_by_opt limit_opt
+  with_clause_opt DELETE comment_opt ignore_opt FROM table_name as_opt_id opt_partition_clause where_expression_opt order_by_opt limit_opt returning_opt
   {
-    $$ = &Delete{With: $1, Comments: Comments($3).Parsed(), Ignore: $4, TableExprs: TableExprs{&AliasedTableExpr{Expr:$6, As: $7}}, Partitions: $8, Where: NewWhere(WhereClause, $9), OrderBy: $10, Limit: $11}
+    $$ = &Delete{With: $1, Comments: Comments($3).Parsed(), Ignore: $4, TableExprs: TableExprs{&AliasedTableExpr{Expr:$6, As: $7}}, Partitions: $8, Where: NewWhere(WhereClause, $9), OrderBy: $10, Limit: $11, Returning: $12}
   }
 | with_clause_opt DELETE comment_opt ignore_opt FROM table_name_list USING table_references where_expression_opt
   {
@@ -1161,6 +1359,97 @@ create_statement:
     $1.CreateOptions = $2
     $$ = $1
   }
+| CREATE comment_opt SEQUENCE not_exists_opt table_name sequence_option_list_opt
+  {
+    $$ = &CreateSequence{Comments: Comments($2).Parsed(), IfNotExists: $4, Name: $5, Options: $6}
+  }
+
+sequence_option_list_opt:
+  {
+    $$ = nil
+  }
+| sequence_option_list
+  {
+    $$ = $1
+  }
+
+sequence_option_list:
+  sequence_option
+  {
+    $$ = []*SequenceOption{$1}
+  }
+| sequence_option_list sequence_option
+  {
+    $$ = append($1, $2)
+  }
+
+sequence_option:
+  INCREMENT BY sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceIncrementType, Value: $3}
+  }
+| INCREMENT equal_opt sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceIncrementType, Value: $3}
+  }
+| MINVALUE equal_opt sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceMinValueType, Value: $3}
+  }
+| NO MINVALUE
+  {
+    $$ = &SequenceOption{Type: SequenceNoMinValueType}
+  }
+| NOMINVALUE
+  {
+    $$ = &SequenceOption{Type: SequenceNoMinValueType}
+  }
+| MAXVALUE equal_opt sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceMaxValueType, Value: $3}
+  }
+| NO MAXVALUE
+  {
+    $$ = &SequenceOption{Type: SequenceNoMaxValueType}
+  }
+| NOMAXVALUE
+  {
+    $$ = &SequenceOption{Type: SequenceNoMaxValueType}
+  }
+| START WITH sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceStartType, Value: $3}
+  }
+| START equal_opt sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceStartType, Value: $3}
+  }
+| CACHE equal_opt sequence_value
+  {
+    $$ = &SequenceOption{Type: SequenceCacheType, Value: $3}
+  }
+| NOCACHE
+  {
+    $$ = &SequenceOption{Type: SequenceNoCacheType}
+  }
+| CYCLE
+  {
+    $$ = &SequenceOption{Type: SequenceCycleType}
+  }
+| NOCYCLE
+  {
+    $$ = &SequenceOption{Type: SequenceNoCycleType}
+  }
+
+sequence_value:
+  INTEGRAL
+  {
+    $$ = NewIntLiteral($1)
+  }
+| '-' INTEGRAL
+  {
+    $$ = NewIntLiteral("-" + $2)
+  }
 
 replace_opt:
   {
@@ -2161,6 +2450,10 @@ char_type:
   {
     $$ = &ColumnType{Type: string($1)}
   }
+| VECTOR length_opt
+  {
+    $$ = &ColumnType{Type: string($1), Length: $2}
+  }
 | ENUM '(' enum_values ')' charset_opt
   {
     $$ = &ColumnType{Type: string($1), EnumValues: $3, Charset: $5}
@@ -4457,6 +4750,10 @@ vexplain_type_opt:
   {
     $$ = QueriesVExplainType
   }
+| TRACE
+  {
+    $$ = TraceVExplainType
+  }
 
 explain_synonyms:
   EXPLAIN
@@ -4722,18 +5019,33 @@ union_op:
     $$ = true
   }
 
-cache_opt:
-{
-  $$ = ""
-}
-| SQL_NO_CACHE
-{
-  $$ = SQLNoCacheStr
-}
-| SQL_CACHE
-{
-  $$ = SQLCacheStr
-}
+except_op:
+  EXCEPT
+  {
+    $$ = true
+  }
+| EXCEPT ALL
+  {
+    $$ = false
+  }
+| EXCEPT DISTINCT
+  {
+    $$ = true
+  }
+
+intersect_op:
+  INTERSECT
+  {
+    $$ = true
+  }
+| INTERSECT ALL
+  {
+    $$ = false
+  }
+| INTERSECT DISTINCT
+  {
+    $$ = true
+  }
 
 distinct_opt:
   {
@@ -4865,6 +5177,7 @@ select_expression:
   }
 
 as_ci_opt:
+  %prec NO_ALIAS_BEFORE_RETURNING
   {
     $$ = IdentifierCI{}
   }
@@ -5066,6 +5379,7 @@ as_opt:
   { $$ = struct{}{} }
 
 as_opt_id:
+  %prec NO_ALIAS_BEFORE_RETURNING
   {
     $$ = NewIdentifierCS("")
   }
@@ -6101,6 +6415,14 @@ UTC_DATE func_paren_opt
   {
     $$ = &LocateExpr{SubStr: $3, Str: $5}
   }
+| NEXT next_value FOR table_name
+  {
+    $$ = &NextValueExpr{Sequence: $4}
+  }
+| NEXTVAL openb table_name closeb
+  {
+    $$ = &NextValueExpr{Sequence: $3}
+  }
 | GET_LOCK openb expression ',' expression closeb
   {
     $$ = &LockingFunc{Type: GetLock, Name:$3, Timeout:$5}
@@ -7241,6 +7563,16 @@ column_name_or_offset:
   }
 
 num_val:
+  INTEGRAL VALUES
+  {
+    $$ = NewIntLiteral($1)
+  }
+| VALUE_ARG VALUES
+  {
+    $$ = parseBindVariable(yylex, $1[1:])
+  }
+
+next_value:
   sql_id
   {
     // TODO(sougou): Deprecate this construct.
@@ -7248,15 +7580,6 @@ num_val:
       yylex.Error("expecting value after next")
       return 1
     }
-    $$ = NewIntLiteral("1")
-  }
-| INTEGRAL VALUES
-  {
-    $$ = NewIntLiteral($1)
-  }
-| VALUE_ARG VALUES
-  {
-    $$ = parseBindVariable(yylex, $1[1:])
   }
 
 group_by_opt:
@@ -8025,6 +8348,7 @@ reserved_keyword:
 | ELSE
 | EMPTY
 | ESCAPE
+| EXCEPT
 | EXISTS
 | EXPLAIN
 | EXTRACT
@@ -8046,6 +8370,7 @@ reserved_keyword:
 | INDEX
 | INNER
 | INSERT
+| INTERSECT
 | INTERVAL
 | INTO
 | IS
@@ -8170,6 +8495,7 @@ non_reserved_keyword:
 | BOOLEAN
 | BUCKETS
 | BYTE
+| CACHE
 | CANCEL
 | CASCADE
 | CASCADED
@@ -8198,6 +8524,7 @@ non_reserved_keyword:
 | COUNT %prec FUNCTION_CALL_NON_KEYWORD
 | CSV
 | CURRENT
+| CYCLE
 | DATA
 | DATE %prec STRING_TYPE_PREFIX_NON_KEYWORD
 | DATE_ADD %prec FUNCTION_CALL_NON_KEYWORD
@@ -8269,6 +8596,7 @@ non_reserved_keyword:
 | HOSTS
 | IMPORT
 | INACTIVE
+| INCREMENT
 | INPLACE
 | INSERT_METHOD
 | INSTANT
@@ -8344,6 +8672,7 @@ non_reserved_keyword:
 | MERGE
 | MID %prec FUNCTION_CALL_NON_KEYWORD
 | MIN %prec FUNCTION_CALL_NON_KEYWORD
+| MINVALUE
 | MIN_ROWS
 | MODE
 | MODIFY
@@ -8355,6 +8684,11 @@ non_reserved_keyword:
 | NCHAR
 | NESTED
 | NETWORK_NAMESPACE
+| NEXTVAL %prec FUNCTION_CALL_NON_KEYWORD
+| NOCACHE
+| NOCYCLE
+| NOMAXVALUE
+| NOMINVALUE
 | NOWAIT
 | NO
 | NONE
@@ -8537,6 +8871,7 @@ non_reserved_keyword:
 | TINYBLOB
 | TINYINT
 | TINYTEXT
+| TRACE
 | TRADITIONAL
 | TRANSACTION
 | TREE
@@ -8564,6 +8899,7 @@ non_reserved_keyword:
 | VARIABLES
 | VARIANCE %prec FUNCTION_CALL_NON_KEYWORD
 | VCPU
+| VECTOR
 | VEXPLAIN
 | VGTID_EXECUTED
 | VIEW
@@ -45,7 +45,8 @@ func (p *Parser) TruncateForUI(query string) string {
 
 // TruncateForLog is used when displaying queries as part of error logs
 // to avoid overwhelming logging systems with potentially long queries and
-// bind value data.
+// bind value data. The literals of the query are redacted if the parser
+// redacts the query literals.
 func (p *Parser) TruncateForLog(query string) string {
-	return TruncateQuery(query, p.truncateErrLen)
+	return TruncateQuery(p.RedactForLog(query), p.truncateErrLen)
 }
@@ -73,6 +73,7 @@ var (
 	TxReadOnly                  = SystemVariable{Name: "tx_read_only", IsBoolean: true, Default: off}
 	Workload                    = SystemVariable{Name: "workload", IdentifierAsString: true}
 	QueryTimeout                = SystemVariable{Name: "query_timeout"}
+	ScatterErrorsAsWarnings     = SystemVariable{Name: "scatter_errors_as_warnings", IsBoolean: true, Default: off}
 
 	// Online DDL
 	DDLStrategy      = SystemVariable{Name: "ddl_strategy", IdentifierAsString: true}
@@ -86,6 +87,13 @@ var (
 	ReadAfterWriteGTID    = SystemVariable{Name: "read_after_write_gtid"}
 	ReadAfterWriteTimeOut = SystemVariable{Name: "read_after_write_timeout"}
 	SessionTrackGTIDs     = SystemVariable{Name: "session_track_gtids", IdentifierAsString: true}
+	// ReadAfterWriteConsistency is either EVENTUAL or SESSION. With SESSION, the reads
+	// of the session only go to the replicas that applied its writes.
+	ReadAfterWriteConsistency = SystemVariable{Name: "read_after_write_consistency", IdentifierAsString: true}
+
+	// SnapshotGTIDs are the GTID sets of the snapshots of a transaction started
+	// WITH CONSISTENT SNAPSHOT, READ ONLY, as a JSON object by keyspace/shard.
+	SnapshotGTIDs = SystemVariable{Name: "snapshot_gtids"}
 
 	VitessAware = []SystemVariable{
 		Autocommit,
@@ -105,13 +113,16 @@ var (
 		ReadAfterWriteGTID,
 		ReadAfterWriteTimeOut,
 		SessionTrackGTIDs,
+		ReadAfterWriteConsistency,
 		QueryTimeout,
+		ScatterErrorsAsWarnings,
 	}
 
 	ReadOnly = []SystemVariable{
 		Socket,
 		Version,
 		VersionComment,
+		SnapshotGTIDs,
 	}
 
 	IgnoreThese = []SystemVariable{
@@ -17,10 +17,10 @@ limitations under the License.
 package tableacl
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -47,6 +47,10 @@ type aclEntry struct {
 
 type aclEntries []aclEntry
 
+// UserGroupPrefix prefixes the names of the user groups in the readers,
+// writers and admins of the table groups.
+const UserGroupPrefix = "group:"
+
 func (aes aclEntries) Len() int {
 	return len(aes)
 }
@@ -68,10 +72,16 @@ var acls = make(map[string]acl.Factory)
 var defaultACL string
 
 type tableACL struct {
-	// mutex protects entries, config, and callback
+	// mutex protects entries, wildcards, config, keyspace and callback
 	sync.RWMutex
 	entries aclEntries
-	config  *tableaclpb.Config
+	// wildcards are the entries of the table name wildcards, in the order of
+	// the config. They are only checked when no entry matches.
+	wildcards aclEntries
+	config    *tableaclpb.Config
+	// keyspace is the keyspace of the tablet, which selects the table groups
+	// scoped to keyspaces which apply.
+	keyspace string
 	// callback is executed on successful reload.
 	callback func()
 	// ACL Factory override for testing
@@ -89,10 +99,17 @@ var currentTableACL tableACL
 //	{
 //	  "table_groups": [
 //	    {
-//	      "table_names_or_prefixes": ["name1"],
-//	      "readers": ["client1"],
+//	      "table_names_or_prefixes": ["name1", "prefix%", "wild%card"],
+//	      "readers": ["client1", "group:group1"],
 //	      "writers": ["client1"],
-//	      "admins": ["client1"]
+//	      "admins": ["client1"],
+//	      "keyspaces": ["keyspace1"]
+//	    }
+//	  ],
+//	  "user_groups": [
+//	    {
+//	      "name": "group1",
+//	      "users": ["client2", "client3"]
 //	    }
 //	  ]
 //	}
@@ -132,28 +149,49 @@ func InitFromProto(config *tableaclpb.Config) error {
 	return currentTableACL.Set(config)
 }
 
-// load loads configurations from a proto-defined Config
+// SetKeyspace sets the keyspace of the tablet. The table groups scoped to
+// other keyspaces don't apply to its tables. It must be called before the
+// table ACLs are initialized.
+func SetKeyspace(keyspace string) {
+	currentTableACL.SetKeyspace(keyspace)
+}
+
+func (tacl *tableACL) SetKeyspace(keyspace string) {
+	tacl.Lock()
+	defer tacl.Unlock()
+	tacl.keyspace = keyspace
+}
+
+// load loads configurations from a proto-defined Config, keeping the table
+// groups which apply to the keyspace.
 // If err is nil, then entries is guaranteed to be non-nil (though possibly empty).
-func load(config *tableaclpb.Config, newACL func([]string) (acl.ACL, error)) (entries aclEntries, err error) {
+func load(config *tableaclpb.Config, keyspace string, newACL func([]string) (acl.ACL, error)) (entries, wildcards aclEntries, err error) {
 	if err := ValidateProto(config); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	userGroups := make(map[string][]string, len(config.UserGroups))
+	for _, group := range config.UserGroups {
+		userGroups[group.Name] = group.Users
 	}
 	entries = aclEntries{}
 	for _, group := range config.TableGroups {
-		readers, err := newACL(group.Readers)
+		if len(group.Keyspaces) > 0 && !slices.Contains(group.Keyspaces, keyspace) {
+			continue
+		}
+		readers, err := newACL(expandUserGroups(group.Readers, userGroups))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		writers, err := newACL(group.Writers)
+		writers, err := newACL(expandUserGroups(group.Writers, userGroups))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		admins, err := newACL(group.Admins)
+		admins, err := newACL(expandUserGroups(group.Admins, userGroups))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		for _, tableNameOrPrefix := range group.TableNamesOrPrefixes {
-			entries = append(entries, aclEntry{
+			entry := aclEntry{
 				tableNameOrPrefix: tableNameOrPrefix,
 				groupName:         group.Name,
 				acl: map[Role]acl.ACL{
@@ -161,11 +199,54 @@ func load(config *tableaclpb.Config, newACL func([]string) (acl.ACL, error)) (en
 					WRITER: writers,
 					ADMIN:  admins,
 				},
-			})
+			}
+			if isWildcard(tableNameOrPrefix) {
+				wildcards = append(wildcards, entry)
+			} else {
+				entries = append(entries, entry)
+			}
 		}
 	}
 	sort.Sort(entries)
-	return entries, nil
+	return entries, wildcards, nil
+}
+
+// expandUserGroups replaces the user groups in entries with their users.
+func expandUserGroups(entries []string, userGroups map[string][]string) []string {
+	var expanded []string
+	for _, entry := range entries {
+		if name, ok := strings.CutPrefix(entry, UserGroupPrefix); ok {
+			expanded = append(expanded, userGroups[name]...)
+			continue
+		}
+		expanded = append(expanded, entry)
+	}
+	return expanded
+}
+
+// isWildcard returns whether a table name or prefix of a table group is a
+// wildcard, i.e. has a '%' elsewhere than at its end.
+func isWildcard(tableNameOrPrefix string) bool {
+	return strings.Contains(strings.TrimSuffix(tableNameOrPrefix, "%"), "%")
+}
+
+// matchWildcard returns whether a table name matches a wildcard, whose '%'
+// match any sequence of characters.
+func matchWildcard(wildcard, table string) bool {
+	parts := strings.Split(wildcard, "%")
+	if !strings.HasPrefix(table, parts[0]) {
+		return false
+	}
+	table = table[len(parts[0]):]
+	last := len(parts) - 1
+	for _, part := range parts[1:last] {
+		i := strings.Index(table, part)
+		if i < 0 {
+			return false
+		}
+		table = table[i+len(part):]
+	}
+	return strings.HasSuffix(table, parts[last])
 }
 
 func (tacl *tableACL) aclFactory() (acl.Factory, error) {
@@ -180,12 +261,16 @@ func (tacl *tableACL) Set(config *tableaclpb.Config) error {
 	if err != nil {
 		return err
 	}
-	entries, err := load(config, factory.New)
+	tacl.RLock()
+	keyspace := tacl.keyspace
+	tacl.RUnlock()
+	entries, wildcards, err := load(config, keyspace, factory.New)
 	if err != nil {
 		return err
 	}
 	tacl.Lock()
 	tacl.entries = entries
+	tacl.wildcards = wildcards
 	tacl.config = config.CloneVT()
 	callback := tacl.callback
 	tacl.Unlock()
@@ -206,20 +291,51 @@ func (tacl *tableACL) Valid() bool {
 // ValidateProto returns an error if the given proto has problems
 // that would cause InitFromProto to fail.
 func ValidateProto(config *tableaclpb.Config) (err error) {
+	userGroups := make(map[string]bool, len(config.UserGroups))
+	for _, group := range config.UserGroups {
+		if group.Name == "" {
+			return errors.New("user groups must have a name")
+		}
+		if userGroups[group.Name] {
+			return fmt.Errorf("duplicate user group: %s", group.Name)
+		}
+		userGroups[group.Name] = true
+	}
+	// entries are the table names and prefixes of the table groups, along
+	// with their keyspaces, stored in the trie.
+	type entry struct {
+		name      string
+		keyspaces []string
+	}
 	t := patricia.NewTrie()
 	for _, group := range config.TableGroups {
+		for _, users := range [][]string{group.Readers, group.Writers, group.Admins} {
+			for _, user := range users {
+				if name, ok := strings.CutPrefix(user, UserGroupPrefix); ok && !userGroups[name] {
+					return fmt.Errorf("table group %s refers to unknown user group: %s", group.Name, name)
+				}
+			}
+		}
 		for _, name := range group.TableNamesOrPrefixes {
+			if isWildcard(name) {
+				if strings.Contains(name, "%%") {
+					return fmt.Errorf("got: %s, '%%%%' is not a valid wildcard", name)
+				}
+				continue
+			}
 			var prefix patricia.Prefix
 			if strings.HasSuffix(name, "%") {
 				prefix = []byte(strings.TrimSuffix(name, "%"))
 			} else {
 				prefix = []byte(name + "\000")
 			}
-			if bytes.Contains(prefix, []byte("%")) {
-				return fmt.Errorf("got: %s, '%%' means this entry is a prefix and should not appear in the middle of name or prefix", name)
-			}
 			overlapVisitor := func(_ patricia.Prefix, item patricia.Item) error {
-				return fmt.Errorf("conflicting entries: %q overlaps with %q", name, item)
+				for _, e := range item.([]entry) {
+					if keyspacesOverlap(e.keyspaces, group.Keyspaces) {
+						return fmt.Errorf("conflicting entries: %q overlaps with %q", name, e.name)
+					}
+				}
+				return nil
 			}
 			if err := t.VisitSubtree(prefix, overlapVisitor); err != nil {
 				return err
@@ -227,12 +343,31 @@ func ValidateProto(config *tableaclpb.Config) (err error) {
 			if err := t.VisitPrefixes(prefix, overlapVisitor); err != nil {
 				return err
 			}
-			t.Insert(prefix, name)
+			e := entry{name: name, keyspaces: group.Keyspaces}
+			if item := t.Get(prefix); item != nil {
+				t.Set(prefix, append(item.([]entry), e))
+			} else {
+				t.Insert(prefix, []entry{e})
+			}
 		}
 	}
 	return nil
 }
 
+// keyspacesOverlap returns whether two table groups scoped to keyspaces
+// apply to the same keyspaces, the empty scope being all the keyspaces.
+func keyspacesOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, keyspace := range a {
+		if slices.Contains(b, keyspace) {
+			return true
+		}
+	}
+	return false
+}
+
 // Authorized returns the list of entities who have the specified role on a tablel.
 func Authorized(table string, role Role) *ACLResult {
 	return currentTableACL.Authorized(table, role)
@@ -261,6 +396,17 @@ func (tacl *tableACL) Authorized(table string, role Role) *ACLResult {
 			start = mid + 1
 		}
 	}
+	for _, entry := range tacl.wildcards {
+		if matchWildcard(entry.tableNameOrPrefix, table) {
+			if acl, ok := entry.acl[role]; ok {
+				return &ACLResult{
+					ACL:       acl,
+					GroupName: entry.groupName,
+				}
+			}
+			break
+		}
+	}
 	return &ACLResult{
 		ACL:       acl.DenyAllACL{},
 		GroupName: "",
@@ -113,7 +113,8 @@ func TestTableACLValidateConfig(t *testing.T) {
 		{[]string{}, true},
 		{[]string{"b"}, true},
 		{[]string{"b", "a"}, true},
-		{[]string{"b%c"}, false},                    // invalid entry
+		{[]string{"b%c"}, true},                     // wildcard
+		{[]string{"b%c", "bc"}, true},               // wildcards don't overlap
 		{[]string{"aaa", "aaab%", "aaabb"}, false},  // overlapping
 		{[]string{"aaa", "aaab", "aaab%"}, false},   // overlapping
 		{[]string{"a", "aa%", "aaab%"}, false},      // overlapping
@@ -138,6 +139,120 @@ func TestTableACLValidateConfig(t *testing.T) {
 	}
 }
 
+func TestTableACLValidateUserGroups(t *testing.T) {
+	tests := []struct {
+		userGroups []string
+		readers    []string
+		valid      bool
+	}{
+		{nil, []string{"u1"}, true},
+		{[]string{"g1"}, []string{"u1", "group:g1"}, true},
+		{nil, []string{"group:g1"}, false}, // unknown user group
+		{[]string{"g1", "g1"}, nil, false}, // duplicate user group
+		{[]string{""}, nil, false},         // unnamed user group
+	}
+	for _, test := range tests {
+		config := &tableaclpb.Config{
+			TableGroups: []*tableaclpb.TableGroupSpec{{
+				Name:                 "group01",
+				TableNamesOrPrefixes: []string{"test_table"},
+				Readers:              test.readers,
+			}},
+		}
+		for _, name := range test.userGroups {
+			config.UserGroups = append(config.UserGroups, &tableaclpb.UserGroupSpec{Name: name})
+		}
+		err := ValidateProto(config)
+		if test.valid && err != nil {
+			t.Fatalf("ValidateProto(%v) = %v, want nil", config, err)
+		} else if !test.valid && err == nil {
+			t.Fatalf("ValidateProto(%v) = nil, want error", config)
+		}
+	}
+}
+
+func TestTableACLValidateKeyspaces(t *testing.T) {
+	tests := []struct {
+		keyspaces [][]string
+		valid     bool
+	}{
+		{[][]string{{"ks1"}, {"ks2"}}, true},
+		{[][]string{{"ks1"}, {"ks1", "ks2"}}, false}, // overlapping
+		{[][]string{{"ks1"}, nil}, false},            // overlapping
+	}
+	for _, test := range tests {
+		var groups []*tableaclpb.TableGroupSpec
+		for _, keyspaces := range test.keyspaces {
+			groups = append(groups, &tableaclpb.TableGroupSpec{
+				TableNamesOrPrefixes: []string{"test_table"},
+				Keyspaces:            keyspaces,
+			})
+		}
+		config := &tableaclpb.Config{TableGroups: groups}
+		err := ValidateProto(config)
+		if test.valid && err != nil {
+			t.Fatalf("ValidateProto(%v) = %v, want nil", config, err)
+		} else if !test.valid && err == nil {
+			t.Fatalf("ValidateProto(%v) = nil, want error", config)
+		}
+	}
+}
+
+func TestTableACLAuthorizeGroupsAndWildcards(t *testing.T) {
+	tacl := tableACL{factory: &simpleacl.Factory{}, keyspace: "ks1"}
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{
+			{
+				Name:                 "group01",
+				TableNamesOrPrefixes: []string{"test_%_log"},
+				Readers:              []string{"group:analysts"},
+				Writers:              []string{"u1"},
+			},
+			{
+				Name:                 "group02",
+				TableNamesOrPrefixes: []string{"test_music"},
+				Readers:              []string{"u1"},
+				Keyspaces:            []string{"ks1"},
+			},
+			{
+				Name:                 "group03",
+				TableNamesOrPrefixes: []string{"test_music"},
+				Readers:              []string{"u2"},
+				Keyspaces:            []string{"ks2"},
+			},
+		},
+		UserGroups: []*tableaclpb.UserGroupSpec{{
+			Name:  "analysts",
+			Users: []string{"u2", "u3"},
+		}},
+	}
+	if err := tacl.Set(config); err != nil {
+		t.Fatalf("InitFromProto(<data>) = %v, want: nil", err)
+	}
+
+	readerACL := tacl.Authorized("test_music_log", READER)
+	if readerACL.GroupName != "group01" {
+		t.Fatalf("table test_music_log should match group01, got: %s", readerACL.GroupName)
+	}
+	if !readerACL.IsMember(&querypb.VTGateCallerID{Username: "u3"}) {
+		t.Fatalf("user u3 should have reader permission to table test_music_log")
+	}
+	if readerACL.IsMember(&querypb.VTGateCallerID{Username: "u1"}) {
+		t.Fatalf("user u1 should not have reader permission to table test_music_log")
+	}
+	if readerACL = tacl.Authorized("test_music_logs", READER); readerACL.GroupName != "" {
+		t.Fatalf("table test_music_logs should not match any group, got: %s", readerACL.GroupName)
+	}
+
+	readerACL = tacl.Authorized("test_music", READER)
+	if readerACL.GroupName != "group02" {
+		t.Fatalf("table test_music should match group02 in keyspace ks1, got: %s", readerACL.GroupName)
+	}
+	if readerACL.IsMember(&querypb.VTGateCallerID{Username: "u2"}) {
+		t.Fatalf("user u2 should not have reader permission to table test_music in keyspace ks1")
+	}
+}
+
 func TestTableACLAuthorize(t *testing.T) {
 	tacl := tableACL{factory: &simpleacl.Factory{}}
 	config := &tableaclpb.Config{
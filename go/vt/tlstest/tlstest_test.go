@@ -17,11 +17,14 @@ limitations under the License.
 package tlstest
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"path"
 	"strings"
 	"sync"
 	"testing"
@@ -468,3 +471,82 @@ func TestClientServerWithRevokedClientCert(t *testing.T) {
 
 	assertTLSHandshakeFails(t, serverConfig, clientConfig)
 }
+
+// servedCommonName returns the common name of the certificate served by
+// serverConfig to a client which requests serverName with SNI.
+func servedCommonName(t *testing.T, serverConfig *tls.Config, serverName string) string {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	var serverEG errgroup.Group
+	serverEG.Go(func() error {
+		return tls.Server(serverConn, serverConfig).Handshake()
+	})
+
+	client := tls.Client(clientConn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	if err := client.Handshake(); err != nil {
+		t.Fatalf("Handshake failed: %v", err)
+	}
+	if err := serverEG.Wait(); err != nil {
+		t.Fatalf("Server handshake failed: %v", err)
+	}
+	return client.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestReloadableCertificates(t *testing.T) {
+	root := t.TempDir()
+	CreateCA(root)
+	CreateSignedCert(root, CA, "01", "server", "server.example.com")
+	CreateSignedCert(root, CA, "02", "sni", "sni.example.com")
+
+	certs, err := vttls.NewReloadableCertificates(
+		vttls.CertificateFiles{Cert: path.Join(root, "server-cert.pem"), Key: path.Join(root, "server-key.pem")},
+		vttls.CertificateFiles{Cert: path.Join(root, "sni-cert.pem"), Key: path.Join(root, "sni-key.pem"), CA: path.Join(root, "ca-cert.pem")},
+	)
+	if err != nil {
+		t.Fatalf("NewReloadableCertificates failed: %v", err)
+	}
+	serverConfig, err := vttls.ReloadableServerConfig(certs, "", "", tls.VersionTLS12)
+	if err != nil {
+		t.Fatalf("ReloadableServerConfig failed: %v", err)
+	}
+
+	// The certificate is selected by the requested server name, and the
+	// first one is the default.
+	assert.Equal(t, "server.example.com", servedCommonName(t, serverConfig, "server.example.com"))
+	assert.Equal(t, "sni.example.com", servedCommonName(t, serverConfig, "sni.example.com"))
+	assert.Equal(t, "server.example.com", servedCommonName(t, serverConfig, "other.example.com"))
+	assert.Equal(t, "server.example.com", servedCommonName(t, serverConfig, ""))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan error, 10)
+	if err := certs.Watch(ctx, func(err error) { reloaded <- err }); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	// Rotating the certificate reloads it.
+	CreateSignedCert(root, CA, "03", "server", "rotated.example.com")
+	assert.Eventually(t, func() bool {
+		return servedCommonName(t, serverConfig, "") == "rotated.example.com"
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// A broken certificate keeps the previous one.
+	if err := os.WriteFile(path.Join(root, "server-cert.pem"), []byte("broken"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	timeout := time.After(10 * time.Second)
+	for failed := false; !failed; {
+		select {
+		case err := <-reloaded:
+			failed = err != nil
+		case <-timeout:
+			t.Fatal("Reload of the broken certificate did not fail")
+		}
+	}
+	assert.Equal(t, "rotated.example.com", servedCommonName(t, serverConfig, ""))
+}
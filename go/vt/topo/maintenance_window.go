@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"fmt"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the utility methods to manage the maintenance windows
+// of the cluster, which are stored in the global cell.
+
+// GetMaintenanceWindows fetches the maintenance windows from the topo. It
+// returns an empty list if there are none.
+func (ts *Server) GetMaintenanceWindows(ctx context.Context) ([]*topodatapb.MaintenanceWindow, error) {
+	windows, _, err := ts.getMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return windows.Windows, nil
+}
+
+// SaveMaintenanceWindow creates or replaces the maintenance window of the
+// same name in the topo.
+func (ts *Server) SaveMaintenanceWindow(ctx context.Context, window *topodatapb.MaintenanceWindow) error {
+	return ts.updateMaintenanceWindows(ctx, func(windows *topodatapb.MaintenanceWindows) error {
+		for i, w := range windows.Windows {
+			if w.Name == window.Name {
+				windows.Windows[i] = window
+				return nil
+			}
+		}
+		windows.Windows = append(windows.Windows, window)
+		return nil
+	})
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window from the topo. It
+// returns a NoNode error if there is no window of that name.
+func (ts *Server) DeleteMaintenanceWindow(ctx context.Context, name string) error {
+	return ts.updateMaintenanceWindows(ctx, func(windows *topodatapb.MaintenanceWindows) error {
+		for i, w := range windows.Windows {
+			if w.Name == name {
+				windows.Windows = append(windows.Windows[:i], windows.Windows[i+1:]...)
+				return nil
+			}
+		}
+		return NewError(NoNode, fmt.Sprintf("%s/%s", MaintenanceWindowsFile, name))
+	})
+}
+
+// updateMaintenanceWindows applies an update to the maintenance windows,
+// retrying it if they were concurrently modified.
+func (ts *Server) updateMaintenanceWindows(ctx context.Context, update func(*topodatapb.MaintenanceWindows) error) error {
+	for {
+		windows, version, err := ts.getMaintenanceWindows(ctx)
+		if err != nil {
+			return err
+		}
+		if err := update(windows); err != nil {
+			return err
+		}
+		if len(windows.Windows) == 0 {
+			// No windows, remove them.
+			err = ts.globalCell.Delete(ctx, MaintenanceWindowsFile, version)
+		} else {
+			var data []byte
+			if data, err = windows.MarshalVT(); err != nil {
+				return err
+			}
+			if version == nil {
+				_, err = ts.globalCell.Create(ctx, MaintenanceWindowsFile, data)
+			} else {
+				_, err = ts.globalCell.Update(ctx, MaintenanceWindowsFile, data, version)
+			}
+		}
+		if IsErrType(err, BadVersion) || IsErrType(err, NodeExists) {
+			// The windows were concurrently modified.
+			continue
+		}
+		return err
+	}
+}
+
+func (ts *Server) getMaintenanceWindows(ctx context.Context) (*topodatapb.MaintenanceWindows, Version, error) {
+	windows := &topodatapb.MaintenanceWindows{}
+	data, version, err := ts.globalCell.Get(ctx, MaintenanceWindowsFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return windows, nil, nil
+		}
+		return nil, nil, err
+	}
+	if err := windows.UnmarshalVT(data); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "invalid maintenance windows: %q", data)
+	}
+	return windows, version, nil
+}
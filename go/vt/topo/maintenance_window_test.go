@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	windows, err := ts.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, windows)
+
+	nightly := &topodatapb.MaintenanceWindow{Name: "nightly", Schedule: "0 2 * * *", Duration: protoutil.DurationToProto(time.Hour)}
+	weekend := &topodatapb.MaintenanceWindow{Name: "weekend", Schedule: "0 0 * * 6", Duration: protoutil.DurationToProto(48 * time.Hour)}
+	require.NoError(t, ts.SaveMaintenanceWindow(ctx, nightly))
+	require.NoError(t, ts.SaveMaintenanceWindow(ctx, weekend))
+	windows, err = ts.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.MaintenanceWindow{nightly, weekend}, windows)
+
+	// Saving a window of the same name replaces it.
+	nightly.Duration = protoutil.DurationToProto(2 * time.Hour)
+	require.NoError(t, ts.SaveMaintenanceWindow(ctx, nightly))
+	windows, err = ts.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.MaintenanceWindow{nightly, weekend}, windows)
+
+	require.NoError(t, ts.DeleteMaintenanceWindow(ctx, "nightly"))
+	err = ts.DeleteMaintenanceWindow(ctx, "nightly")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "got: %v", err)
+	windows, err = ts.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.MaintenanceWindow{weekend}, windows)
+
+	require.NoError(t, ts.DeleteMaintenanceWindow(ctx, "weekend"))
+	windows, err = ts.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, windows)
+}
@@ -80,6 +80,10 @@ const (
 	ExternalClustersFile     = "ExternalClusters"
 	ShardRoutingRulesFile    = "ShardRoutingRules"
 	KeyspaceRoutingRulesFile = "KeyspaceRoutingRules"
+	QueryRulesFile           = "QueryRules"
+	VTGateConfigFile         = "VTGateConfig"
+	TableACLFile             = "TableACL"
+	MaintenanceWindowsFile   = "MaintenanceWindows"
 )
 
 // Path for all object types.
@@ -750,3 +750,51 @@ func (ts *Server) ValidateSrvKeyspace(ctx context.Context, keyspace, cells strin
 	}
 	return nil
 }
+
+// UpdateSrvKeyspaceTableGCConfig updates existing table GC configuration
+func (ts *Server) UpdateSrvKeyspaceTableGCConfig(ctx context.Context, keyspace string, cells []string, update func(tableGCConfig *topodatapb.TableGCConfig) *topodatapb.TableGCConfig) (updatedCells []string, err error) {
+	if err = CheckKeyspaceLocked(ctx, keyspace); err != nil {
+		return updatedCells, err
+	}
+
+	// The caller intends to update all cells in this case
+	if len(cells) == 0 {
+		cells, err = ts.GetCellInfoNames(ctx)
+		if err != nil {
+			return updatedCells, err
+		}
+	}
+
+	var mu sync.Mutex
+	wg := sync.WaitGroup{}
+	rec := concurrency.AllErrorRecorder{}
+	for _, cell := range cells {
+		wg.Add(1)
+		go func(cell string) {
+			defer wg.Done()
+			srvKeyspace, err := ts.GetSrvKeyspace(ctx, cell, keyspace)
+			switch {
+			case err == nil:
+				srvKeyspace.TableGcConfig = update(srvKeyspace.TableGcConfig)
+				if err := ts.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace); err != nil {
+					rec.RecordError(err)
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				updatedCells = append(updatedCells, cell)
+				return
+			case IsErrType(err, NoNode):
+				// NOOP as not every cell will contain a serving tablet in the keyspace
+			default:
+				rec.RecordError(err)
+				return
+			}
+		}(cell)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		return updatedCells, NewError(PartialResult, rec.Error().Error())
+	}
+	return updatedCells, nil
+}
@@ -210,6 +210,18 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 	}
 	srvVSchema.KeyspaceRoutingRules = krr
 
+	qr, err := ts.GetQueryRules(ctx)
+	if err != nil {
+		return fmt.Errorf("GetQueryRules failed: %v", err)
+	}
+	srvVSchema.QueryRules = qr
+
+	vc, err := ts.GetVTGateConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("GetVTGateConfig failed: %v", err)
+	}
+	srvVSchema.VtgateConfig = vc
+
 	// now save the SrvVSchema in all cells in parallel
 	for _, cell := range cells {
 		wg.Add(1)
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+)
+
+// This file contains the utility methods to manage the table ACL of the
+// cluster, which is stored in the global cell.
+
+// WatchTableACLData is returned / streamed by WatchTableACL.
+// The WatchTableACL API guarantees exactly one of Value or Err will be set.
+type WatchTableACLData struct {
+	Value *tableaclpb.Config
+	Err   error
+}
+
+// SaveTableACL saves the table ACL into the topo.
+func (ts *Server) SaveTableACL(ctx context.Context, config *tableaclpb.Config) error {
+	data, err := config.MarshalVT()
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		// No table ACL, remove it.
+		if err := ts.globalCell.Delete(ctx, TableACLFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+	_, err = ts.globalCell.Update(ctx, TableACLFile, data, nil)
+	return err
+}
+
+// GetTableACL fetches the table ACL from the topo. It returns nil if there
+// is none.
+func (ts *Server) GetTableACL(ctx context.Context) (*tableaclpb.Config, error) {
+	data, _, err := ts.globalCell.Get(ctx, TableACLFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	config := &tableaclpb.Config{}
+	if err := config.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid table ACL: %q", data)
+	}
+	return config, nil
+}
+
+// WatchTableACL will set a watch on the table ACL.
+// It has the same contract as Conn.Watch, but it also unpacks the
+// contents into a Config object.
+func (ts *Server) WatchTableACL(ctx context.Context) (*WatchTableACLData, <-chan *WatchTableACLData, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	current, wdChannel, err := ts.globalCell.Watch(ctx, TableACLFile)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	value := &tableaclpb.Config{}
+	if err := value.UnmarshalVT(current.Contents); err != nil {
+		// Cancel the watch, drain channel.
+		cancel()
+		for range wdChannel {
+		}
+		return nil, nil, vterrors.Wrapf(err, "error unpacking initial table ACL")
+	}
+
+	changes := make(chan *WatchTableACLData, 10)
+
+	// The background routine reads any event from the watch channel,
+	// translates it, and sends it to the caller.
+	// If cancel() is called, the underlying Watch() code will
+	// send an ErrInterrupted and then close the channel. We'll
+	// just propagate that back to our caller.
+	go func() {
+		defer cancel()
+		defer close(changes)
+
+		for wd := range wdChannel {
+			if wd.Err != nil {
+				// Last error value, we're done.
+				// wdChannel will be closed right after
+				// this, no need to do anything.
+				changes <- &WatchTableACLData{Err: wd.Err}
+				return
+			}
+
+			value := &tableaclpb.Config{}
+			if err := value.UnmarshalVT(wd.Contents); err != nil {
+				cancel()
+				for range wdChannel {
+				}
+				changes <- &WatchTableACLData{Err: vterrors.Wrapf(err, "error unpacking table ACL")}
+				return
+			}
+			changes <- &WatchTableACLData{Value: value}
+		}
+	}()
+
+	return &WatchTableACLData{Value: value}, changes, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+)
+
+func TestTableACL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	config, err := ts.GetTableACL(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, _, err = ts.WatchTableACL(ctx)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "got: %v", err)
+
+	want := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "group01",
+			TableNamesOrPrefixes: []string{"t1"},
+			Readers:              []string{"group:readers"},
+			Keyspaces:            []string{"ks1"},
+		}},
+		UserGroups: []*tableaclpb.UserGroupSpec{{
+			Name:  "readers",
+			Users: []string{"u1"},
+		}},
+	}
+	require.NoError(t, ts.SaveTableACL(ctx, want))
+	config, err = ts.GetTableACL(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, want, config)
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+	current, changes, err := ts.WatchTableACL(watchCtx)
+	require.NoError(t, err)
+	utils.MustMatch(t, want, current.Value)
+
+	want.TableGroups[0].Readers = append(want.TableGroups[0].Readers, "u2")
+	require.NoError(t, ts.SaveTableACL(ctx, want))
+	change := <-changes
+	require.NoError(t, change.Err)
+	utils.MustMatch(t, want, change.Value)
+
+	// Saving an empty table ACL deletes it.
+	require.NoError(t, ts.SaveTableACL(ctx, &tableaclpb.Config{}))
+	config, err = ts.GetTableACL(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, config)
+	change = <-changes
+	assert.True(t, topo.IsErrType(change.Err, topo.NoNode), "got: %v", change.Err)
+}
@@ -18,6 +18,7 @@ package topo
 
 import (
 	"context"
+	"fmt"
 	"path"
 
 	"google.golang.org/protobuf/proto"
@@ -190,3 +191,113 @@ func (ts *Server) GetKeyspaceRoutingRules(ctx context.Context) (*vschemapb.Keysp
 	}
 	return rules, nil
 }
+
+// SaveQueryRules saves the vtgate query rules into the topo.
+func (ts *Server) SaveQueryRules(ctx context.Context, queryRules *vschemapb.QueryRules) error {
+	data, err := queryRules.MarshalVT()
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		// No rules, remove it.
+		if err := ts.globalCell.Delete(ctx, QueryRulesFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+	_, err = ts.globalCell.Update(ctx, QueryRulesFile, data, nil)
+	return err
+}
+
+// GetQueryRules fetches the vtgate query rules from the topo.
+func (ts *Server) GetQueryRules(ctx context.Context) (*vschemapb.QueryRules, error) {
+	qr := &vschemapb.QueryRules{}
+	data, _, err := ts.globalCell.Get(ctx, QueryRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	err = qr.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid query rules: %q", data)
+	}
+	return qr, nil
+}
+
+// maxVTGateConfigHistory is the number of versions of the vtgate
+// configuration kept in the topo.
+const maxVTGateConfigHistory = 20
+
+// SaveVTGateConfig saves a new version of the dynamic vtgate configuration
+// into the topo, keeping the previous versions in its history, and returns it.
+// If expectedVersion isn't 0, it fails with a BadVersion error if the version
+// of the current configuration differs.
+func (ts *Server) SaveVTGateConfig(ctx context.Context, config *vschemapb.VTGateConfig, expectedVersion int64) (*vschemapb.VTGateConfig, error) {
+	history, version, err := ts.getVTGateConfigHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var current int64
+	if len(history.Versions) > 0 {
+		current = history.Versions[len(history.Versions)-1].Version
+	}
+	if expectedVersion != 0 && expectedVersion != current {
+		return nil, NewError(BadVersion, fmt.Sprintf("%s: expected version %d, got %d", VTGateConfigFile, expectedVersion, current))
+	}
+
+	config = config.CloneVT()
+	config.Version = current + 1
+	history.Versions = append(history.Versions, config)
+	if len(history.Versions) > maxVTGateConfigHistory {
+		history.Versions = history.Versions[len(history.Versions)-maxVTGateConfigHistory:]
+	}
+
+	data, err := history.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		_, err = ts.globalCell.Create(ctx, VTGateConfigFile, data)
+	} else {
+		_, err = ts.globalCell.Update(ctx, VTGateConfigFile, data, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// GetVTGateConfig fetches the current dynamic vtgate configuration from the
+// topo. It returns nil if there is none.
+func (ts *Server) GetVTGateConfig(ctx context.Context) (*vschemapb.VTGateConfig, error) {
+	history, err := ts.GetVTGateConfigHistory(ctx)
+	if err != nil || len(history.Versions) == 0 {
+		return nil, err
+	}
+	return history.Versions[len(history.Versions)-1], nil
+}
+
+// GetVTGateConfigHistory fetches the versions of the dynamic vtgate
+// configuration from the topo, from the oldest one to the current one.
+func (ts *Server) GetVTGateConfigHistory(ctx context.Context) (*vschemapb.VTGateConfigHistory, error) {
+	history, _, err := ts.getVTGateConfigHistory(ctx)
+	return history, err
+}
+
+func (ts *Server) getVTGateConfigHistory(ctx context.Context) (*vschemapb.VTGateConfigHistory, Version, error) {
+	history := &vschemapb.VTGateConfigHistory{}
+	data, version, err := ts.globalCell.Get(ctx, VTGateConfigFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return history, nil, nil
+		}
+		return nil, nil, err
+	}
+	if err := history.UnmarshalVT(data); err != nil {
+		return nil, nil, vterrors.Wrapf(err, "invalid vtgate config: %q", data)
+	}
+	return history, version, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestSaveVTGateConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	config, err := ts.GetVTGateConfig(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{QueryTimeoutMs: 1000}, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, config.Version)
+
+	config, err = ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{MaxMemoryRows: 100}, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, config.Version)
+
+	// The version of the current configuration is now 2.
+	_, err = ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{MaxMemoryRows: 200}, 1)
+	assert.True(t, topo.IsErrType(err, topo.BadVersion), "%v", err)
+
+	config, err = ts.GetVTGateConfig(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, config.Version)
+	assert.EqualValues(t, 100, config.MaxMemoryRows)
+	assert.Zero(t, config.QueryTimeoutMs)
+
+	for i := 0; i < 30; i++ {
+		_, err = ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{}, 0)
+		require.NoError(t, err)
+	}
+	history, err := ts.GetVTGateConfigHistory(ctx)
+	require.NoError(t, err)
+	require.Len(t, history.Versions, 20)
+	assert.EqualValues(t, 13, history.Versions[0].Version)
+	assert.EqualValues(t, 32, history.Versions[19].Version)
+}
@@ -156,6 +156,21 @@ func (f *fakeVTGateService) CloseSession(ctx context.Context, session *vtgatepb.
 	return nil
 }
 
+// OpenCursor is part of the VTGateService interface
+func (f *fakeVTGateService) OpenCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// FetchCursor is part of the VTGateService interface
+func (f *fakeVTGateService) FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	return nil, false, errors.New("not implemented")
+}
+
+// CloseCursor is part of the VTGateService interface
+func (f *fakeVTGateService) CloseCursor(ctx context.Context, cursorID string) error {
+	return errors.New("not implemented")
+}
+
 // ResolveTransaction is part of the VTGateService interface
 func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string) error {
 	if dtid != dtid2 {
@@ -168,6 +183,11 @@ func (f *fakeVTGateService) VStream(ctx context.Context, tabletType topodatapb.T
 	return nil
 }
 
+// VStreamAck is part of the VTGateService interface
+func (f *fakeVTGateService) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return errors.New("not implemented")
+}
+
 // HandlePanic is part of the VTGateService interface
 func (f *fakeVTGateService) HandlePanic(err *error) {
 	if x := recover(); x != nil {
@@ -411,15 +411,20 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/tablet/{tablet}/start_replication", httpAPI.Adapt(vtadminhttp.StartReplication)).Name("API.StartReplication").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tablet/{tablet}/stop_replication", httpAPI.Adapt(vtadminhttp.StopReplication)).Name("API.StopReplication").Methods("PUT", "OPTIONS")
 	router.HandleFunc("/tablet/{tablet}/externally_promoted", httpAPI.Adapt(vtadminhttp.TabletExternallyPromoted)).Name("API.TabletExternallyPromoted").Methods("POST")
+	router.HandleFunc("/transaction/{cluster_id}/{dtid}", httpAPI.Adapt(vtadminhttp.GetTransactionInfo)).Name("API.GetTransactionInfo")
+	router.HandleFunc("/transaction/{cluster_id}/{dtid}/conclude", httpAPI.Adapt(vtadminhttp.ConcludeTransaction)).Name("API.ConcludeTransaction").Methods("PUT", "OPTIONS")
+	router.HandleFunc("/transactions/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.GetUnresolvedTransactions)).Name("API.GetUnresolvedTransactions")
 	router.HandleFunc("/vschema/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.GetVSchema)).Name("API.GetVSchema")
 	router.HandleFunc("/vschemas", httpAPI.Adapt(vtadminhttp.GetVSchemas)).Name("API.GetVSchemas")
 	router.HandleFunc("/vtctlds", httpAPI.Adapt(vtadminhttp.GetVtctlds)).Name("API.GetVtctlds")
 	router.HandleFunc("/vtexplain", httpAPI.Adapt(vtadminhttp.VTExplain)).Name("API.VTExplain")
 	router.HandleFunc("/workflow/{cluster_id}/{keyspace}/{name}", httpAPI.Adapt(vtadminhttp.GetWorkflow)).Name("API.GetWorkflow")
+	router.HandleFunc("/workflow/{cluster_id}/{keyspace}/{name}/lookup_vindex_status", httpAPI.Adapt(vtadminhttp.LookupVindexStatus)).Name("API.LookupVindexStatus")
 	router.HandleFunc("/workflows", httpAPI.Adapt(vtadminhttp.GetWorkflows)).Name("API.GetWorkflows")
 
 	experimentalRouter := router.PathPrefix("/experimental").Subrouter()
 	experimentalRouter.HandleFunc("/tablet/{tablet}/debug/vars", httpAPI.Adapt(experimental.TabletDebugVarsPassthrough)).Name("API.TabletDebugVarsPassthrough")
+	experimentalRouter.HandleFunc("/vtgates/slow_queries", httpAPI.Adapt(experimental.VTGateSlowQueriesPassthrough)).Name("API.VTGateSlowQueriesPassthrough")
 	experimentalRouter.HandleFunc("/whoami", httpAPI.Adapt(experimental.WhoAmI))
 
 	return router
@@ -523,6 +528,26 @@ func (api *API) CompleteSchemaMigration(ctx context.Context, req *vtadminpb.Comp
 	return c.CompleteSchemaMigration(ctx, req.Request)
 }
 
+// ConcludeTransaction is part of the vtadminpb.VTAdminServer interface.
+func (api *API) ConcludeTransaction(ctx context.Context, req *vtadminpb.ConcludeTransactionRequest) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.ConcludeTransaction")
+	defer span.Finish()
+
+	span.Annotate("cluster_id", req.ClusterId)
+	span.Annotate("dtid", req.Dtid)
+
+	if !api.authz.IsAuthorized(ctx, req.ClusterId, rbac.TransactionResource, rbac.ConcludeTransactionAction) {
+		return nil, fmt.Errorf("%w: cannot conclude transaction in %s", errors.ErrUnauthorized, req.ClusterId)
+	}
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Vtctld.ConcludeTransaction(ctx, &vtctldatapb.ConcludeTransactionRequest{Dtid: req.Dtid})
+}
+
 // CreateKeyspace is part of the vtadminpb.VTAdminServer interface.
 func (api *API) CreateKeyspace(ctx context.Context, req *vtadminpb.CreateKeyspaceRequest) (*vtadminpb.CreateKeyspaceResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.CreateKeyspace")
@@ -1476,6 +1501,50 @@ func (api *API) GetTopologyPath(ctx context.Context, req *vtadminpb.GetTopologyP
 	return c.Vtctld.GetTopologyPath(ctx, &vtctldatapb.GetTopologyPathRequest{Path: req.Path})
 }
 
+// GetTransactionInfo is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetTransactionInfo(ctx context.Context, req *vtadminpb.GetTransactionInfoRequest) (*vtctldatapb.GetTransactionInfoResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetTransactionInfo")
+	defer span.Finish()
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.AnnotateSpan(c, span)
+	span.Annotate("dtid", req.Dtid)
+
+	if !api.authz.IsAuthorized(ctx, c.ID, rbac.TransactionResource, rbac.GetAction) {
+		return nil, nil
+	}
+
+	return c.Vtctld.GetTransactionInfo(ctx, &vtctldatapb.GetTransactionInfoRequest{Dtid: req.Dtid})
+}
+
+// GetUnresolvedTransactions is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetUnresolvedTransactions(ctx context.Context, req *vtadminpb.GetUnresolvedTransactionsRequest) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetUnresolvedTransactions")
+	defer span.Finish()
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.AnnotateSpan(c, span)
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("abandon_age", req.AbandonAge)
+
+	if !api.authz.IsAuthorized(ctx, c.ID, rbac.TransactionResource, rbac.GetAction) {
+		return nil, nil
+	}
+
+	return c.Vtctld.GetUnresolvedTransactions(ctx, &vtctldatapb.GetUnresolvedTransactionsRequest{
+		Keyspace:   req.Keyspace,
+		AbandonAge: req.AbandonAge,
+	})
+}
+
 // GetVSchema is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetVSchema(ctx context.Context, req *vtadminpb.GetVSchemaRequest) (*vtadminpb.VSchema, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetVSchema")
@@ -1732,6 +1801,30 @@ func (api *API) LaunchSchemaMigration(ctx context.Context, req *vtadminpb.Launch
 	return c.LaunchSchemaMigration(ctx, req.Request)
 }
 
+// LookupVindexStatus is part of the vtadminpb.VTAdminServer interface.
+func (api *API) LookupVindexStatus(ctx context.Context, req *vtadminpb.LookupVindexStatusRequest) (*vtctldatapb.LookupVindexStatusResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.LookupVindexStatus")
+	defer span.Finish()
+
+	c, err := api.getClusterForRequest(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.AnnotateSpan(c, span)
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	if !api.authz.IsAuthorized(ctx, c.ID, rbac.WorkflowResource, rbac.GetAction) {
+		return nil, nil
+	}
+
+	return c.Vtctld.LookupVindexStatus(ctx, &vtctldatapb.LookupVindexStatusRequest{
+		Name:          req.Name,
+		TableKeyspace: req.TableKeyspace,
+	})
+}
+
 // PingTablet is part of the vtadminpb.VTAdminServer interface.
 func (api *API) PingTablet(ctx context.Context, req *vtadminpb.PingTabletRequest) (*vtadminpb.PingTabletResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.PingTablet")
@@ -304,6 +304,67 @@ func TestCompleteSchemaMigration(t *testing.T) {
 	})
 }
 
+func TestConcludeTransaction(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Transaction",
+					Actions:  []string{"conclude_transaction"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(vtenv.NewTestEnv(), testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.ConcludeTransaction(ctx, &vtadminpb.ConcludeTransactionRequest{
+			ClusterId: "test",
+			Dtid:      "test:-:1",
+		})
+		assert.Error(t, err, "actor %+v should not be permitted to ConcludeTransaction", actor)
+		assert.Nil(t, resp, "actor %+v should not be permitted to ConcludeTransaction", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.ConcludeTransaction(ctx, &vtadminpb.ConcludeTransactionRequest{
+			ClusterId: "test",
+			Dtid:      "test:-:1",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to ConcludeTransaction", actor)
+	})
+}
+
 func TestCreateKeyspace(t *testing.T) {
 	t.Parallel()
 
@@ -1961,6 +2022,128 @@ func TestGetTablets(t *testing.T) {
 	})
 }
 
+func TestGetTransactionInfo(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Transaction",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(vtenv.NewTestEnv(), testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.GetTransactionInfo(ctx, &vtadminpb.GetTransactionInfoRequest{
+			ClusterId: "test",
+			Dtid:      "test:-:1",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetTransactionInfo", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.GetTransactionInfo(ctx, &vtadminpb.GetTransactionInfoRequest{
+			ClusterId: "test",
+			Dtid:      "test:-:1",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetTransactionInfo", actor)
+	})
+}
+
+func TestGetUnresolvedTransactions(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Transaction",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(vtenv.NewTestEnv(), testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.GetUnresolvedTransactions(ctx, &vtadminpb.GetUnresolvedTransactionsRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to GetUnresolvedTransactions", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.GetUnresolvedTransactions(ctx, &vtadminpb.GetUnresolvedTransactionsRequest{
+			ClusterId: "test",
+			Keyspace:  "test",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to GetUnresolvedTransactions", actor)
+	})
+}
+
 func TestGetVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -2399,6 +2582,69 @@ func TestLaunchSchemaMigration(t *testing.T) {
 	})
 }
 
+func TestLookupVindexStatus(t *testing.T) {
+	t.Parallel()
+
+	opts := vtadmin.Options{
+		RBAC: &rbac.Config{
+			Rules: []*struct {
+				Resource string
+				Actions  []string
+				Subjects []string
+				Clusters []string
+			}{
+				{
+					Resource: "Workflow",
+					Actions:  []string{"get"},
+					Subjects: []string{"user:allowed"},
+					Clusters: []string{"*"},
+				},
+			},
+		},
+	}
+	err := opts.RBAC.Reify()
+	require.NoError(t, err, "failed to reify authorization rules: %+v", opts.RBAC.Rules)
+
+	api := vtadmin.NewAPI(vtenv.NewTestEnv(), testClusters(t), opts)
+	t.Cleanup(func() {
+		if err := api.Close(); err != nil {
+			t.Logf("api did not close cleanly: %s", err.Error())
+		}
+	})
+
+	t.Run("unauthorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "other"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.LookupVindexStatus(ctx, &vtadminpb.LookupVindexStatusRequest{
+			ClusterId:     "test",
+			Name:          "testworkflow",
+			TableKeyspace: "test",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp, "actor %+v should not be permitted to LookupVindexStatus", actor)
+	})
+
+	t.Run("authorized actor", func(t *testing.T) {
+		t.Parallel()
+
+		actor := &rbac.Actor{Name: "allowed"}
+		ctx := context.Background()
+		ctx = rbac.NewContext(ctx, actor)
+
+		resp, err := api.LookupVindexStatus(ctx, &vtadminpb.LookupVindexStatusRequest{
+			ClusterId:     "test",
+			Name:          "testworkflow",
+			TableKeyspace: "test",
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp, "actor %+v should be permitted to LookupVindexStatus", actor)
+	})
+}
+
 func TestPingTablet(t *testing.T) {
 	t.Parallel()
 
@@ -3493,6 +3739,14 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 						Response: &vtctldatapb.CompleteSchemaMigrationResponse{},
 					},
 				},
+				ConcludeTransactionResults: map[string]struct {
+					Response *vtctldatapb.ConcludeTransactionResponse
+					Error    error
+				}{
+					"test:-:1": {
+						Response: &vtctldatapb.ConcludeTransactionResponse{},
+					},
+				},
 				DeleteShardsResults: map[string]error{
 					"test/-": nil,
 				},
@@ -3639,6 +3893,22 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 						},
 					},
 				},
+				GetTransactionInfoResults: map[string]struct {
+					Response *vtctldatapb.GetTransactionInfoResponse
+					Error    error
+				}{
+					"test:-:1": {
+						Response: &vtctldatapb.GetTransactionInfoResponse{},
+					},
+				},
+				GetUnresolvedTransactionsResults: map[string]struct {
+					Response *vtctldatapb.GetUnresolvedTransactionsResponse
+					Error    error
+				}{
+					"test": {
+						Response: &vtctldatapb.GetUnresolvedTransactionsResponse{},
+					},
+				},
 				GetVSchemaResults: map[string]struct {
 					Response *vtctldatapb.GetVSchemaResponse
 					Error    error
@@ -3670,6 +3940,14 @@ func testClusters(t testing.TB) []*cluster.Cluster {
 						Response: &vtctldatapb.LaunchSchemaMigrationResponse{},
 					},
 				},
+				LookupVindexStatusResults: map[string]struct {
+					Response *vtctldatapb.LookupVindexStatusResponse
+					Error    error
+				}{
+					"test/testworkflow": {
+						Response: &vtctldatapb.LookupVindexStatusResponse{},
+					},
+				},
 				PingTabletResults: map[string]error{
 					"zone1-0000000100": nil,
 				},
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package experimental
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/vt/concurrency"
+	vtadminhttp "vitess.io/vitess/go/vt/vtadmin/http"
+	"vitess.io/vitess/go/vt/vtgate/slowlog"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// VTGateSlowQuery is a slow query recorded by a vtgate.
+type VTGateSlowQuery struct {
+	ClusterID string `json:"cluster_id"`
+	VTGate    string `json:"vtgate"`
+	*slowlog.Entry
+}
+
+// VTGateSlowQueriesPassthrough makes passthrough requests to the /debug/slow_queries
+// route of the vtgates, after looking them up via VTAdmin's GetGates rpc, and
+// returns their slow queries from the most recent one.
+//
+// The vtgates are reached through their FQDN, so the clusters need a vtgate FQDN
+// template in their discovery.
+func VTGateSlowQueriesPassthrough(ctx context.Context, r vtadminhttp.Request, api *vtadminhttp.API) *vtadminhttp.JSONResponse {
+	query := r.URL.Query()
+	gates, err := api.Server().GetGates(ctx, &vtadminpb.GetGatesRequest{
+		ClusterIds: query["cluster_id"],
+	})
+	if err != nil {
+		return vtadminhttp.NewJSONResponse(nil, err)
+	}
+
+	var (
+		m       sync.Mutex
+		wg      sync.WaitGroup
+		rec     concurrency.AllErrorRecorder
+		queries []*VTGateSlowQuery
+	)
+	for _, gate := range gates.Gates {
+		if gate.FQDN == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(gate *vtadminpb.VTGate) {
+			defer wg.Done()
+
+			entries, err := getSlowQueries(ctx, gate, query.Get("limit"))
+			if err != nil {
+				rec.RecordError(fmt.Errorf("GetSlowQueries(%s): %w", gate.Hostname, err))
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+			for _, entry := range entries {
+				queries = append(queries, &VTGateSlowQuery{
+					ClusterID: gate.Cluster.GetId(),
+					VTGate:    gate.Hostname,
+					Entry:     entry,
+				})
+			}
+		}(gate)
+	}
+	wg.Wait()
+
+	if rec.HasErrors() {
+		return vtadminhttp.NewJSONResponse(nil, rec.Error())
+	}
+
+	sort.SliceStable(queries, func(i, j int) bool {
+		return queries[i].Time.After(queries[j].Time)
+	})
+	return vtadminhttp.NewJSONResponse(queries, nil)
+}
+
+func getSlowQueries(ctx context.Context, gate *vtadminpb.VTGate, limit string) ([]*slowlog.Entry, error) {
+	addr := strings.TrimSuffix(gate.FQDN, "/") + slowlog.DebugPath
+	if limit != "" {
+		addr += "?" + url.Values{"limit": []string{limit}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", addr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// The slow query log of the vtgate is disabled.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var entries []*slowlog.Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
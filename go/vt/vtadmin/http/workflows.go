@@ -69,3 +69,21 @@ func GetWorkflows(ctx context.Context, r Request, api *API) *JSONResponse {
 
 	return NewJSONResponse(workflows, err)
 }
+
+// LookupVindexStatus implements the http wrapper for the
+// VTAdminServer.LookupVindexStatus method.
+//
+// Its route is /workflow/{cluster_id}/{keyspace}/{name}/lookup_vindex_status,
+// where keyspace is the keyspace of the lookup table and name the name of the
+// lookup vindex.
+func LookupVindexStatus(ctx context.Context, r Request, api *API) *JSONResponse {
+	vars := r.Vars()
+
+	status, err := api.server.LookupVindexStatus(ctx, &vtadminpb.LookupVindexStatusRequest{
+		ClusterId:     vars["cluster_id"],
+		Name:          vars["name"],
+		TableKeyspace: vars["keyspace"],
+	})
+
+	return NewJSONResponse(status, err)
+}
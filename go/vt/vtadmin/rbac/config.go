@@ -181,6 +181,7 @@ func DefaultConfig() *Config {
 		string(ManageTabletReplicationAction),
 		string(ManageTabletWritabilityAction),
 		string(RefreshTabletReplicationSourceAction),
+		string(ConcludeTransactionAction),
 	}
 	subjects := []string{"*"}
 	clusters := []string{"*"}
@@ -88,6 +88,10 @@ const (
 	PlannedFailoverShardAction     Action = "planned_failover_shard"
 	TabletExternallyPromotedAction Action = "tablet_externally_promoted" // NOTE: even though "tablet" is in the name, this actually operates on the tablet's shard.
 
+	/* transaction-specific actions */
+
+	ConcludeTransactionAction Action = "conclude_transaction"
+
 	/* tablet-specific actions */
 
 	ManageTabletReplicationAction        Action = "manage_tablet_replication" // Start/Stop Replication
@@ -127,6 +131,10 @@ const (
 	SchemaResource          Resource = "Schema"
 	SchemaMigrationResource Resource = "SchemaMigration"
 
+	/* distributed transaction resources */
+
+	TransactionResource Resource = "Transaction"
+
 	/* misc resources */
 
 	BackupResource                   Resource = "Backup"
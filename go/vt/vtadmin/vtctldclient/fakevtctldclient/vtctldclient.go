@@ -54,6 +54,11 @@ type VtctldClient struct {
 		Response *vtctldatapb.CompleteSchemaMigrationResponse
 		Error    error
 	}
+	// Keyed by dtid.
+	ConcludeTransactionResults map[string]struct {
+		Response *vtctldatapb.ConcludeTransactionResponse
+		Error    error
+	}
 	CreateKeyspaceShouldErr bool
 	CreateShardShouldErr    bool
 	DeleteKeyspaceShouldErr bool
@@ -105,6 +110,15 @@ type VtctldClient struct {
 		Response *vtctldatapb.GetSrvVSchemaResponse
 		Error    error
 	}
+	// Keyed by dtid.
+	GetTransactionInfoResults map[string]struct {
+		Response *vtctldatapb.GetTransactionInfoResponse
+		Error    error
+	}
+	GetUnresolvedTransactionsResults map[string]struct {
+		Response *vtctldatapb.GetUnresolvedTransactionsResponse
+		Error    error
+	}
 	GetVSchemaResults map[string]struct {
 		Response *vtctldatapb.GetVSchemaResponse
 		Error    error
@@ -117,6 +131,11 @@ type VtctldClient struct {
 		Response *vtctldatapb.LaunchSchemaMigrationResponse
 		Error    error
 	}
+	// Keyed by <table_keyspace>/<name>.
+	LookupVindexStatusResults map[string]struct {
+		Response *vtctldatapb.LookupVindexStatusResponse
+		Error    error
+	}
 	PingTabletResults           map[string]error
 	PlannedReparentShardResults map[string]struct {
 		Response *vtctldatapb.PlannedReparentShardResponse
@@ -240,6 +259,20 @@ func (fake *VtctldClient) CompleteSchemaMigration(ctx context.Context, req *vtct
 	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
 }
 
+// ConcludeTransaction is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) ConcludeTransaction(ctx context.Context, req *vtctldatapb.ConcludeTransactionRequest, opts ...grpc.CallOption) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	if fake.ConcludeTransactionResults == nil {
+		return nil, fmt.Errorf("%w: ConcludeTransactionResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Dtid
+	if result, ok := fake.ConcludeTransactionResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
 // CreateKeyspace is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) CreateKeyspace(ctx context.Context, req *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	if fake.CreateKeyspaceShouldErr {
@@ -504,6 +537,34 @@ func (fake *VtctldClient) GetSrvVSchemas(ctx context.Context, req *vtctldatapb.G
 	return resp, nil
 }
 
+// GetTransactionInfo is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) GetTransactionInfo(ctx context.Context, req *vtctldatapb.GetTransactionInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTransactionInfoResponse, error) {
+	if fake.GetTransactionInfoResults == nil {
+		return nil, fmt.Errorf("%w: GetTransactionInfoResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Dtid
+	if result, ok := fake.GetTransactionInfoResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
+// GetUnresolvedTransactions is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) GetUnresolvedTransactions(ctx context.Context, req *vtctldatapb.GetUnresolvedTransactionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	if fake.GetUnresolvedTransactionsResults == nil {
+		return nil, fmt.Errorf("%w: GetUnresolvedTransactionsResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := req.Keyspace
+	if result, ok := fake.GetUnresolvedTransactionsResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
 // GetVSchema is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) GetVSchema(ctx context.Context, req *vtctldatapb.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVSchemaResponse, error) {
 	if fake.GetVSchemaResults == nil {
@@ -545,6 +606,20 @@ func (fake *VtctldClient) LaunchSchemaMigration(ctx context.Context, req *vtctld
 	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
 }
 
+// LookupVindexStatus is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) LookupVindexStatus(ctx context.Context, req *vtctldatapb.LookupVindexStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexStatusResponse, error) {
+	if fake.LookupVindexStatusResults == nil {
+		return nil, fmt.Errorf("%w: LookupVindexStatusResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	key := fmt.Sprintf("%s/%s", req.TableKeyspace, req.Name)
+	if result, ok := fake.LookupVindexStatusResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result set for %s", assert.AnError, key)
+}
+
 // PingTablet is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) PingTablet(ctx context.Context, req *vtctldatapb.PingTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.PingTabletResponse, error) {
 	if fake.PingTabletResults == nil {
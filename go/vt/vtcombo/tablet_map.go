@@ -949,6 +949,18 @@ func (itmc *internalTabletManagerClient) CheckThrottler(context.Context, *topoda
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) GetUnresolvedTransactions(context.Context, *topodatapb.Tablet, int64) ([]*querypb.TransactionMetadata, error) {
+	return nil, fmt.Errorf("not implemented in vtcombo")
+}
+
+func (itmc *internalTabletManagerClient) ReadTransaction(context.Context, *topodatapb.Tablet, string) (*querypb.TransactionMetadata, error) {
+	return nil, fmt.Errorf("not implemented in vtcombo")
+}
+
+func (itmc *internalTabletManagerClient) ConcludeTransaction(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.ConcludeTransactionRequest) error {
+	return fmt.Errorf("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) Close() {
 }
 
@@ -56,6 +56,15 @@ func (client *gRPCVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, i
 	return client.c.ApplyKeyspaceRoutingRules(ctx, in, opts...)
 }
 
+// ApplyQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyQueryRules(ctx context.Context, in *vtctldatapb.ApplyQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyQueryRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyQueryRules(ctx, in, opts...)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -83,6 +92,24 @@ func (client *gRPCVtctldClient) ApplyShardRoutingRules(ctx context.Context, in *
 	return client.c.ApplyShardRoutingRules(ctx, in, opts...)
 }
 
+// ApplyTableACL is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyTableACL(ctx context.Context, in *vtctldatapb.ApplyTableACLRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTableACLResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyTableACL(ctx, in, opts...)
+}
+
+// ApplyTabletQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyTabletQueryRules(ctx context.Context, in *vtctldatapb.ApplyTabletQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTabletQueryRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyTabletQueryRules(ctx, in, opts...)
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatapb.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVSchemaResponse, error) {
 	if client.c == nil {
@@ -92,6 +119,15 @@ func (client *gRPCVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatap
 	return client.c.ApplyVSchema(ctx, in, opts...)
 }
 
+// ApplyVTGateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyVTGateConfig(ctx context.Context, in *vtctldatapb.ApplyVTGateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVTGateConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyVTGateConfig(ctx, in, opts...)
+}
+
 // Backup is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) Backup(ctx context.Context, in *vtctldatapb.BackupRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_BackupClient, error) {
 	if client.c == nil {
@@ -146,6 +182,15 @@ func (client *gRPCVtctldClient) CompleteSchemaMigration(ctx context.Context, in
 	return client.c.CompleteSchemaMigration(ctx, in, opts...)
 }
 
+// ConcludeTransaction is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ConcludeTransaction(ctx context.Context, in *vtctldatapb.ConcludeTransactionRequest, opts ...grpc.CallOption) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ConcludeTransaction(ctx, in, opts...)
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CreateKeyspace(ctx context.Context, in *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	if client.c == nil {
@@ -191,6 +236,15 @@ func (client *gRPCVtctldClient) DeleteKeyspace(ctx context.Context, in *vtctldat
 	return client.c.DeleteKeyspace(ctx, in, opts...)
 }
 
+// DeleteMaintenanceWindow is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DeleteMaintenanceWindow(ctx context.Context, in *vtctldatapb.DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteMaintenanceWindowResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DeleteMaintenanceWindow(ctx, in, opts...)
+}
+
 // DeleteShards is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) DeleteShards(ctx context.Context, in *vtctldatapb.DeleteShardsRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteShardsResponse, error) {
 	if client.c == nil {
@@ -353,6 +407,15 @@ func (client *gRPCVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatap
 	return client.c.GetKeyspaces(ctx, in, opts...)
 }
 
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetMaintenanceWindows(ctx context.Context, in *vtctldatapb.GetMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenanceWindowsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetMaintenanceWindows(ctx, in, opts...)
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
 	if client.c == nil {
@@ -362,6 +425,15 @@ func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldat
 	return client.c.GetPermissions(ctx, in, opts...)
 }
 
+// GetQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetQueryRules(ctx context.Context, in *vtctldatapb.GetQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetQueryRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetQueryRules(ctx, in, opts...)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -434,6 +506,15 @@ func (client *gRPCVtctldClient) GetSrvKeyspaces(ctx context.Context, in *vtctlda
 	return client.c.GetSrvKeyspaces(ctx, in, opts...)
 }
 
+// UpdateTableGCConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) UpdateTableGCConfig(ctx context.Context, in *vtctldatapb.UpdateTableGCConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateTableGCConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.UpdateTableGCConfig(ctx, in, opts...)
+}
+
 // GetSrvVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetSrvVSchema(ctx context.Context, in *vtctldatapb.GetSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvVSchemaResponse, error) {
 	if client.c == nil {
@@ -452,6 +533,24 @@ func (client *gRPCVtctldClient) GetSrvVSchemas(ctx context.Context, in *vtctldat
 	return client.c.GetSrvVSchemas(ctx, in, opts...)
 }
 
+// GetTableACL is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTableACL(ctx context.Context, in *vtctldatapb.GetTableACLRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTableACLResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTableACL(ctx, in, opts...)
+}
+
+// GetTabletQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTabletQueryRules(ctx context.Context, in *vtctldatapb.GetTabletQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletQueryRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTabletQueryRules(ctx, in, opts...)
+}
+
 // GetTablet is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetTablet(ctx context.Context, in *vtctldatapb.GetTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletResponse, error) {
 	if client.c == nil {
@@ -479,6 +578,24 @@ func (client *gRPCVtctldClient) GetTopologyPath(ctx context.Context, in *vtctlda
 	return client.c.GetTopologyPath(ctx, in, opts...)
 }
 
+// GetTransactionInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTransactionInfo(ctx context.Context, in *vtctldatapb.GetTransactionInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTransactionInfoResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTransactionInfo(ctx, in, opts...)
+}
+
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetUnresolvedTransactions(ctx context.Context, in *vtctldatapb.GetUnresolvedTransactionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetUnresolvedTransactions(ctx, in, opts...)
+}
+
 // GetVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVSchemaResponse, error) {
 	if client.c == nil {
@@ -488,6 +605,15 @@ func (client *gRPCVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.
 	return client.c.GetVSchema(ctx, in, opts...)
 }
 
+// GetVTGateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetVTGateConfig(ctx context.Context, in *vtctldatapb.GetVTGateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVTGateConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetVTGateConfig(ctx, in, opts...)
+}
+
 // GetVersion is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetVersion(ctx context.Context, in *vtctldatapb.GetVersionRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVersionResponse, error) {
 	if client.c == nil {
@@ -542,6 +668,15 @@ func (client *gRPCVtctldClient) LookupVindexExternalize(ctx context.Context, in
 	return client.c.LookupVindexExternalize(ctx, in, opts...)
 }
 
+// LookupVindexStatus is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) LookupVindexStatus(ctx context.Context, in *vtctldatapb.LookupVindexStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexStatusResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.LookupVindexStatus(ctx, in, opts...)
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) MaterializeCreate(ctx context.Context, in *vtctldatapb.MaterializeCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.MaterializeCreateResponse, error) {
 	if client.c == nil {
@@ -758,6 +893,15 @@ func (client *gRPCVtctldClient) RetrySchemaMigration(ctx context.Context, in *vt
 	return client.c.RetrySchemaMigration(ctx, in, opts...)
 }
 
+// RollbackVTGateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RollbackVTGateConfig(ctx context.Context, in *vtctldatapb.RollbackVTGateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.RollbackVTGateConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RollbackVTGateConfig(ctx, in, opts...)
+}
+
 // RunHealthCheck is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldatapb.RunHealthCheckRequest, opts ...grpc.CallOption) (*vtctldatapb.RunHealthCheckResponse, error) {
 	if client.c == nil {
@@ -776,6 +920,15 @@ func (client *gRPCVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context,
 	return client.c.SetKeyspaceDurabilityPolicy(ctx, in, opts...)
 }
 
+// SetMaintenanceWindow is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetMaintenanceWindow(ctx context.Context, in *vtctldatapb.SetMaintenanceWindowRequest, opts ...grpc.CallOption) (*vtctldatapb.SetMaintenanceWindowResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetMaintenanceWindow(ctx, in, opts...)
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) SetShardIsPrimaryServing(ctx context.Context, in *vtctldatapb.SetShardIsPrimaryServingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardIsPrimaryServingResponse, error) {
 	if client.c == nil {
@@ -42,10 +42,12 @@ import (
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/dtids"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/maintenancewindow"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 	"vitess.io/vitess/go/vt/mysqlctl/mysqlctlproto"
@@ -53,6 +55,7 @@ import (
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/schemamanager"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/tableacl"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
@@ -63,12 +66,14 @@ import (
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
@@ -165,6 +170,36 @@ func (s *VtctldServer) AddCellsAlias(ctx context.Context, req *vtctldatapb.AddCe
 	return &vtctldatapb.AddCellsAliasResponse{}, nil
 }
 
+// ApplyQueryRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyQueryRules(ctx context.Context, req *vtctldatapb.ApplyQueryRulesRequest) (*vtctldatapb.ApplyQueryRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyQueryRules")
+	defer span.Finish()
+
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	if _, err := vindexes.BuildQueryRules(req.QueryRules); err != nil {
+		return nil, err
+	}
+
+	if err := s.ts.SaveQueryRules(ctx, req.QueryRules); err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ApplyQueryRulesResponse{}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+	}
+
+	return resp, nil
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.ApplyRoutingRulesRequest) (resp *vtctldatapb.ApplyRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRoutingRules")
@@ -307,6 +342,73 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 	return resp, err
 }
 
+// ApplyTableACL is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyTableACL(ctx context.Context, req *vtctldatapb.ApplyTableACLRequest) (*vtctldatapb.ApplyTableACLResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyTableACL")
+	defer span.Finish()
+
+	span.Annotate("dry_run", req.DryRun)
+
+	config := req.TableAcl
+	if config == nil {
+		config = &tableaclpb.Config{}
+	}
+	if err := tableacl.ValidateProto(config); err != nil {
+		return nil, vterrors.Wrap(err, "invalid table ACL")
+	}
+	for _, group := range config.TableGroups {
+		for _, keyspace := range group.Keyspaces {
+			if _, err := s.ts.GetKeyspace(ctx, keyspace); err != nil {
+				if topo.IsErrType(err, topo.NoNode) {
+					err = vterrors.Wrapf(err, "table group %s refers to keyspace(%s) which doesn't exist", group.Name, keyspace)
+				} else {
+					err = vterrors.Wrapf(err, "GetKeyspace(%s)", keyspace)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	if req.DryRun {
+		return &vtctldatapb.ApplyTableACLResponse{}, nil
+	}
+	if err := s.ts.SaveTableACL(ctx, config); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ApplyTableACLResponse{}, nil
+}
+
+// ApplyTabletQueryRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyTabletQueryRules(ctx context.Context, req *vtctldatapb.ApplyTabletQueryRulesRequest) (*vtctldatapb.ApplyTabletQueryRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyTabletQueryRules")
+	defer span.Finish()
+
+	cell := req.Cell
+	if cell == "" {
+		cell = topo.GlobalCell
+	}
+	span.Annotate("cell", cell)
+	span.Annotate("path", req.Path)
+
+	if req.Path == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the path of the query rules is required")
+	}
+	if err := rules.New().UnmarshalJSON([]byte(req.Rules)); err != nil {
+		return nil, vterrors.Wrap(err, "invalid query rules")
+	}
+
+	conn, err := s.ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Update(ctx, req.Path, []byte(req.Rules), nil); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ApplyTabletQueryRulesResponse{}, nil
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyVSchemaRequest) (resp *vtctldatapb.ApplyVSchemaResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyVSchema")
@@ -425,6 +527,55 @@ func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyV
 	return response, nil
 }
 
+// ApplyVTGateConfig is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyVTGateConfig(ctx context.Context, req *vtctldatapb.ApplyVTGateConfigRequest) (*vtctldatapb.ApplyVTGateConfigResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyVTGateConfig")
+	defer span.Finish()
+
+	span.Annotate("expected_version", req.ExpectedVersion)
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	config, err := s.saveVTGateConfig(ctx, req.VtgateConfig, req.ExpectedVersion, req.SkipRebuild, req.RebuildCells)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ApplyVTGateConfigResponse{
+		VtgateConfig: config,
+	}, nil
+}
+
+// saveVTGateConfig validates and saves a new version of the vtgate config,
+// then rebuilds the SrvVSchema unless skipRebuild is set.
+func (s *VtctldServer) saveVTGateConfig(ctx context.Context, config *vschemapb.VTGateConfig, expectedVersion int64, skipRebuild bool, rebuildCells []string) (*vschemapb.VTGateConfig, error) {
+	if config == nil {
+		config = &vschemapb.VTGateConfig{}
+	}
+	if err := vindexes.ValidateVTGateConfig(config); err != nil {
+		return nil, err
+	}
+
+	config, err := s.ts.SaveVTGateConfig(ctx, config, expectedVersion)
+	if err != nil {
+		if topo.IsErrType(err, topo.BadVersion) {
+			return nil, vterrors.Wrapf(err, "the vtgate config was changed concurrently")
+		}
+		return nil, err
+	}
+
+	if skipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
+		return config, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, rebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", rebuildCells, err)
+	}
+
+	return config, nil
+}
+
 // Backup is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) Backup(req *vtctldatapb.BackupRequest, stream vtctlservicepb.Vtctld_BackupServer) (err error) {
 	span, ctx := trace.NewSpan(stream.Context(), "VtctldServer.Backup")
@@ -773,6 +924,75 @@ func (s *VtctldServer) CompleteSchemaMigration(ctx context.Context, req *vtctlda
 	return resp, nil
 }
 
+// ConcludeTransaction is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ConcludeTransaction(ctx context.Context, req *vtctldatapb.ConcludeTransactionRequest) (resp *vtctldatapb.ConcludeTransactionResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ConcludeTransaction")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("dtid", req.Dtid)
+
+	mmShard, err := dtids.ShardSession(req.Dtid)
+	if err != nil {
+		return nil, err
+	}
+	mm, err := s.shardPrimary(ctx, mmShard.Target.Keyspace, mmShard.Target.Shard)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.tmc.ReadTransaction(ctx, mm, req.Dtid)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil || metadata.Dtid == "" {
+		// It was already resolved.
+		return &vtctldatapb.ConcludeTransactionResponse{}, nil
+	}
+
+	// Only the transactions that reached the commit decision are committed. The
+	// others are rolled back, but the coordinator may still be committing the
+	// ones in the PREPARE state: like the vtgates, the decision to roll them back
+	// is first recorded by the metadata manager, which fails if the coordinator
+	// already recorded the decision to commit them.
+	commit := metadata.State == querypb.TransactionState_COMMIT
+	log.Infof("Concluding distributed transaction %s in state %v", req.Dtid, metadata.State)
+	if metadata.State == querypb.TransactionState_PREPARE {
+		err = s.tmc.ConcludeTransaction(ctx, mm, &tabletmanagerdatapb.ConcludeTransactionRequest{
+			Dtid:        req.Dtid,
+			SetRollback: true,
+		})
+		if err != nil {
+			err = fmt.Errorf("cannot roll back transaction %s, which may have been committed meanwhile: %w", req.Dtid, err)
+			return nil, err
+		}
+	}
+	for _, participant := range metadata.Participants {
+		var tablet *topodatapb.Tablet
+		tablet, err = s.shardPrimary(ctx, participant.Keyspace, participant.Shard)
+		if err != nil {
+			return nil, err
+		}
+		err = s.tmc.ConcludeTransaction(ctx, tablet, &tabletmanagerdatapb.ConcludeTransactionRequest{
+			Dtid:   req.Dtid,
+			Commit: commit,
+		})
+		if err != nil {
+			err = fmt.Errorf("cannot conclude transaction %s on %v/%v: %w", req.Dtid, participant.Keyspace, participant.Shard, err)
+			return nil, err
+		}
+	}
+
+	err = s.tmc.ConcludeTransaction(ctx, mm, &tabletmanagerdatapb.ConcludeTransactionRequest{
+		Dtid: req.Dtid,
+		Mm:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.ConcludeTransactionResponse{}, nil
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) CreateKeyspace(ctx context.Context, req *vtctldatapb.CreateKeyspaceRequest) (resp *vtctldatapb.CreateKeyspaceResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.CreateKeyspace")
@@ -1065,6 +1285,20 @@ func (s *VtctldServer) DeleteKeyspace(ctx context.Context, req *vtctldatapb.Dele
 	return &vtctldatapb.DeleteKeyspaceResponse{}, nil
 }
 
+// DeleteMaintenanceWindow is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DeleteMaintenanceWindow(ctx context.Context, req *vtctldatapb.DeleteMaintenanceWindowRequest) (*vtctldatapb.DeleteMaintenanceWindowResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteMaintenanceWindow")
+	defer span.Finish()
+
+	span.Annotate("name", req.Name)
+
+	if err := s.ts.DeleteMaintenanceWindow(ctx, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.DeleteMaintenanceWindowResponse{}, nil
+}
+
 // DeleteShards is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) DeleteShards(ctx context.Context, req *vtctldatapb.DeleteShardsRequest) (resp *vtctldatapb.DeleteShardsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteShards")
@@ -1548,6 +1782,26 @@ func (s *VtctldServer) GetKeyspaces(ctx context.Context, req *vtctldatapb.GetKey
 	return &vtctldatapb.GetKeyspacesResponse{Keyspaces: keyspaces}, nil
 }
 
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetMaintenanceWindows(ctx context.Context, req *vtctldatapb.GetMaintenanceWindowsRequest) (*vtctldatapb.GetMaintenanceWindowsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetMaintenanceWindows")
+	defer span.Finish()
+
+	windows, err := s.ts.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	open, err := maintenancewindow.IsOpen(windows, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetMaintenanceWindowsResponse{
+		Windows: windows,
+		Open:    open,
+	}, nil
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetPermissionsRequest) (resp *vtctldatapb.GetPermissionsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetPermissions")
@@ -1572,6 +1826,21 @@ func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetP
 	}, nil
 }
 
+// GetQueryRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetQueryRules(ctx context.Context, req *vtctldatapb.GetQueryRulesRequest) (*vtctldatapb.GetQueryRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetQueryRules")
+	defer span.Finish()
+
+	qr, err := s.ts.GetQueryRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetQueryRulesResponse{
+		QueryRules: qr,
+	}, nil
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetRoutingRules(ctx context.Context, req *vtctldatapb.GetRoutingRulesRequest) (resp *vtctldatapb.GetRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRoutingRules")
@@ -1988,6 +2257,75 @@ func (s *VtctldServer) UpdateThrottlerConfig(ctx context.Context, req *vtctldata
 	return &vtctldatapb.UpdateThrottlerConfigResponse{}, err
 }
 
+// UpdateTableGCConfig updates the table GC config of the given keyspace, for all cells
+func (s *VtctldServer) UpdateTableGCConfig(ctx context.Context, req *vtctldatapb.UpdateTableGCConfigRequest) (resp *vtctldatapb.UpdateTableGCConfigResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.UpdateTableGCConfig")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if req.EnablePurgePartitions && req.DisablePurgePartitions {
+		return nil, fmt.Errorf("--purge-partitions and --no-purge-partitions are mutually exclusive")
+	}
+	retention, _, err := protoutil.DurationFromProto(req.Retention)
+	if err != nil {
+		return nil, err
+	}
+	if retention < 0 {
+		return nil, fmt.Errorf("--retention must not be negative: %v", retention)
+	}
+	now := time.Now()
+
+	update := func(tableGCConfig *topodatapb.TableGCConfig) *topodatapb.TableGCConfig {
+		if tableGCConfig == nil {
+			tableGCConfig = &topodatapb.TableGCConfig{}
+		}
+		if req.RetentionSet {
+			// A zero retention resets to the tablets' default, i.e. --retain-online-ddl-tables
+			tableGCConfig.Retention = nil
+			if retention > 0 {
+				tableGCConfig.Retention = protoutil.DurationToProto(retention)
+			}
+		}
+		if req.EnablePurgePartitions {
+			tableGCConfig.PurgePartitions = true
+		}
+		if req.DisablePurgePartitions {
+			tableGCConfig.PurgePartitions = false
+		}
+		if req.PurgeNow {
+			tableGCConfig.PurgeRequestedAt = protoutil.TimeToProto(now)
+		}
+		return tableGCConfig
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "UpdateTableGCConfig")
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.TableGcConfig = update(ki.TableGcConfig)
+
+	err = s.ts.UpdateKeyspace(ctx, ki)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.ts.UpdateSrvKeyspaceTableGCConfig(ctx, req.Keyspace, []string{}, update)
+
+	return &vtctldatapb.UpdateTableGCConfigResponse{
+		TableGcConfig: ki.TableGcConfig,
+	}, err
+}
+
 // GetSrvVSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetSrvVSchema(ctx context.Context, req *vtctldatapb.GetSrvVSchemaRequest) (resp *vtctldatapb.GetSrvVSchemaResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSrvVSchema")
@@ -2053,6 +2391,51 @@ func (s *VtctldServer) GetSrvVSchemas(ctx context.Context, req *vtctldatapb.GetS
 	}, nil
 }
 
+// GetTableACL is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTableACL(ctx context.Context, req *vtctldatapb.GetTableACLRequest) (*vtctldatapb.GetTableACLResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTableACL")
+	defer span.Finish()
+
+	config, err := s.ts.GetTableACL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetTableACLResponse{
+		TableAcl: config,
+	}, nil
+}
+
+// GetTabletQueryRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTabletQueryRules(ctx context.Context, req *vtctldatapb.GetTabletQueryRulesRequest) (*vtctldatapb.GetTabletQueryRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTabletQueryRules")
+	defer span.Finish()
+
+	cell := req.Cell
+	if cell == "" {
+		cell = topo.GlobalCell
+	}
+	span.Annotate("cell", cell)
+	span.Annotate("path", req.Path)
+
+	if req.Path == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the path of the query rules is required")
+	}
+
+	conn, err := s.ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := conn.Get(ctx, req.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetTabletQueryRulesResponse{
+		Rules: string(data),
+	}, nil
+}
+
 // GetTablet is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetTablet(ctx context.Context, req *vtctldatapb.GetTabletRequest) (resp *vtctldatapb.GetTabletResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTablet")
@@ -2287,6 +2670,85 @@ func (s *VtctldServer) GetTopologyPath(ctx context.Context, req *vtctldatapb.Get
 	}, nil
 }
 
+// GetTransactionInfo is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTransactionInfo(ctx context.Context, req *vtctldatapb.GetTransactionInfoRequest) (resp *vtctldatapb.GetTransactionInfoResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTransactionInfo")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("dtid", req.Dtid)
+
+	mmShard, err := dtids.ShardSession(req.Dtid)
+	if err != nil {
+		return nil, err
+	}
+	mm, err := s.shardPrimary(ctx, mmShard.Target.Keyspace, mmShard.Target.Shard)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.tmc.ReadTransaction(ctx, mm, req.Dtid)
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.GetTransactionInfoResponse{Metadata: metadata}, nil
+}
+
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetUnresolvedTransactions(ctx context.Context, req *vtctldatapb.GetUnresolvedTransactionsRequest) (resp *vtctldatapb.GetUnresolvedTransactionsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetUnresolvedTransactions")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("abandon_age", req.AbandonAge)
+
+	shards, err := s.ts.GetShardNames(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		m            sync.Mutex
+		wg           sync.WaitGroup
+		rec          concurrency.AllErrorRecorder
+		transactions []*querypb.TransactionMetadata
+	)
+	for _, shard := range shards {
+		wg.Add(1)
+		go func(shard string) {
+			defer wg.Done()
+			primary, err := s.shardPrimary(ctx, req.Keyspace, shard)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			shardTransactions, err := s.tmc.GetUnresolvedTransactions(ctx, primary, req.AbandonAge)
+			if err != nil {
+				rec.RecordError(fmt.Errorf("GetUnresolvedTransactions(%v/%v) failed: %w", req.Keyspace, shard, err))
+				return
+			}
+			m.Lock()
+			defer m.Unlock()
+			transactions = append(transactions, shardTransactions...)
+		}(shard)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		err = rec.Error()
+		return nil, err
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].TimeCreated != transactions[j].TimeCreated {
+			return transactions[i].TimeCreated < transactions[j].TimeCreated
+		}
+		return transactions[i].Dtid < transactions[j].Dtid
+	})
+	return &vtctldatapb.GetUnresolvedTransactionsResponse{Transactions: transactions}, nil
+}
+
 // GetVersion returns the version of a tablet from its debug vars
 func (s *VtctldServer) GetVersion(ctx context.Context, req *vtctldatapb.GetVersionRequest) (resp *vtctldatapb.GetVersionResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetVersion")
@@ -2327,6 +2789,25 @@ func (s *VtctldServer) GetVSchema(ctx context.Context, req *vtctldatapb.GetVSche
 	}, nil
 }
 
+// GetVTGateConfig is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetVTGateConfig(ctx context.Context, req *vtctldatapb.GetVTGateConfigRequest) (*vtctldatapb.GetVTGateConfigResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetVTGateConfig")
+	defer span.Finish()
+
+	history, err := s.ts.GetVTGateConfigHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.GetVTGateConfigResponse{
+		History: history.Versions,
+	}
+	if len(history.Versions) > 0 {
+		resp.VtgateConfig = history.Versions[len(history.Versions)-1]
+	}
+	return resp, nil
+}
+
 // GetWorkflows is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest) (resp *vtctldatapb.GetWorkflowsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetWorkflows")
@@ -2683,6 +3164,20 @@ func (s *VtctldServer) LookupVindexExternalize(ctx context.Context, req *vtctlda
 	return resp, err
 }
 
+// LookupVindexStatus is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) LookupVindexStatus(ctx context.Context, req *vtctldatapb.LookupVindexStatusRequest) (resp *vtctldatapb.LookupVindexStatusResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexStatus")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	resp, err = s.ws.LookupVindexStatus(ctx, req)
+	return resp, err
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) MaterializeCreate(ctx context.Context, req *vtctldatapb.MaterializeCreateRequest) (resp *vtctldatapb.MaterializeCreateResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.MaterializeCreate")
@@ -3395,6 +3890,49 @@ func (s *VtctldServer) RetrySchemaMigration(ctx context.Context, req *vtctldatap
 	return resp, nil
 }
 
+// RollbackVTGateConfig is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RollbackVTGateConfig(ctx context.Context, req *vtctldatapb.RollbackVTGateConfigRequest) (*vtctldatapb.RollbackVTGateConfigResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RollbackVTGateConfig")
+	defer span.Finish()
+
+	span.Annotate("version", req.Version)
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	history, err := s.ts.GetVTGateConfigHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		current  int64
+		previous *vschemapb.VTGateConfig
+	)
+	if len(history.Versions) > 0 {
+		current = history.Versions[len(history.Versions)-1].Version
+	}
+	if req.Version == 0 {
+		previous = &vschemapb.VTGateConfig{}
+	}
+	for _, config := range history.Versions {
+		if config.Version == req.Version {
+			previous = config
+		}
+	}
+	if previous == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "version %d of the vtgate config not found", req.Version)
+	}
+
+	config, err := s.saveVTGateConfig(ctx, previous, current, req.SkipRebuild, req.RebuildCells)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RollbackVTGateConfigResponse{
+		VtgateConfig: config,
+	}, nil
+}
+
 // RunHealthCheck is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RunHealthCheck(ctx context.Context, req *vtctldatapb.RunHealthCheckRequest) (resp *vtctldatapb.RunHealthCheckResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RunHealthCheck")
@@ -3458,6 +3996,24 @@ func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtc
 	}, nil
 }
 
+// SetMaintenanceWindow is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetMaintenanceWindow(ctx context.Context, req *vtctldatapb.SetMaintenanceWindowRequest) (*vtctldatapb.SetMaintenanceWindowResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetMaintenanceWindow")
+	defer span.Finish()
+
+	span.Annotate("name", req.Window.GetName())
+	span.Annotate("schedule", req.Window.GetSchedule())
+
+	if err := maintenancewindow.Validate(req.Window); err != nil {
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, err.Error())
+	}
+	if err := s.ts.SaveMaintenanceWindow(ctx, req.Window); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetMaintenanceWindowResponse{}, nil
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) SetShardIsPrimaryServing(ctx context.Context, req *vtctldatapb.SetShardIsPrimaryServingRequest) (resp *vtctldatapb.SetShardIsPrimaryServingResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetShardIsPrimaryServing")
@@ -5149,6 +5705,22 @@ func GetVersionFunc() func(string) (string, error) {
 	return getVersionFromTablet
 }
 
+// shardPrimary returns the primary tablet of a shard.
+func (s *VtctldServer) shardPrimary(ctx context.Context, keyspace string, shard string) (*topodatapb.Tablet, error) {
+	si, err := s.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	if !si.HasPrimary() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no primary tablet for shard %v/%v", keyspace, shard)
+	}
+	primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+	if err != nil {
+		return nil, fmt.Errorf("cannot lookup primary tablet %v for shard %v/%v: %w", topoproto.TabletAliasString(si.PrimaryAlias), keyspace, shard, err)
+	}
+	return primary.Tablet, nil
+}
+
 // helper method to asynchronously get and diff a version
 func (s *VtctldServer) diffVersion(ctx context.Context, primaryVersion string, primaryAlias *topodatapb.TabletAlias, alias *topodatapb.TabletAlias, wg *sync.WaitGroup, er concurrency.ErrorRecorder) {
 	defer wg.Done()
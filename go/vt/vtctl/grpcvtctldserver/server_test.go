@@ -54,6 +54,7 @@ import (
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
@@ -344,6 +345,242 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplyQueryRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rules := &vschemapb.QueryRules{
+		Rules: []*vschemapb.QueryRule{
+			{
+				Name:       "no_scatter_deletes",
+				PlanTypes:  []string{"DELETE"},
+				RouteTypes: []string{"Scatter"},
+				Action:     vschemapb.QueryRule_DENY,
+			},
+			{
+				Name:               "reports_on_rdonly",
+				Tables:             []string{"reports"},
+				Action:             vschemapb.QueryRule_REDIRECT,
+				RedirectTabletType: "rdonly",
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		req           *vtctldatapb.ApplyQueryRulesRequest
+		expectedRules *vschemapb.QueryRules
+		shouldErr     string
+	}{
+		{
+			name:          "success",
+			req:           &vtctldatapb.ApplyQueryRulesRequest{QueryRules: rules},
+			expectedRules: rules,
+		},
+		{
+			name: "invalid query",
+			req: &vtctldatapb.ApplyQueryRulesRequest{QueryRules: &vschemapb.QueryRules{
+				Rules: []*vschemapb.QueryRule{{Name: "bad", Query: "select (", Action: vschemapb.QueryRule_DENY}},
+			}},
+			shouldErr: "invalid query of query rule bad",
+		},
+		{
+			name: "invalid redirect tablet type",
+			req: &vtctldatapb.ApplyQueryRulesRequest{QueryRules: &vschemapb.QueryRules{
+				Rules: []*vschemapb.QueryRule{{Name: "bad", Action: vschemapb.QueryRule_REDIRECT, RedirectTabletType: "nope"}},
+			}},
+			shouldErr: "invalid redirect tablet type of query rule bad",
+		},
+		{
+			name: "duplicate rules",
+			req: &vtctldatapb.ApplyQueryRulesRequest{QueryRules: &vschemapb.QueryRules{
+				Rules: []*vschemapb.QueryRule{{Name: "r1"}, {Name: "r1"}},
+			}},
+			shouldErr: "duplicate query rule r1",
+		},
+		{
+			name: "rebuild failed (bad cell)",
+			req: &vtctldatapb.ApplyQueryRulesRequest{
+				QueryRules:   rules,
+				RebuildCells: []string{"zone1", "zone2"},
+			},
+			shouldErr: "RebuildSrvVSchema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := vtctld.ApplyQueryRules(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, err := vtctld.GetQueryRules(ctx, &vtctldatapb.GetQueryRulesRequest{})
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedRules, resp.QueryRules)
+
+			srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedRules, srvVSchema.QueryRules)
+		})
+	}
+}
+
+func TestApplyTableACL(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newConfig := func(keyspaces ...string) *tableaclpb.Config {
+		return &tableaclpb.Config{
+			TableGroups: []*tableaclpb.TableGroupSpec{{
+				Name:                 "group01",
+				TableNamesOrPrefixes: []string{"test_%_log"},
+				Readers:              []string{"group:readers"},
+				Keyspaces:            keyspaces,
+			}},
+			UserGroups: []*tableaclpb.UserGroupSpec{{
+				Name:  "readers",
+				Users: []string{"u1"},
+			}},
+		}
+	}
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ApplyTableACLRequest
+		saved     bool
+		shouldErr string
+	}{
+		{
+			name:  "all keyspaces",
+			req:   &vtctldatapb.ApplyTableACLRequest{TableAcl: newConfig()},
+			saved: true,
+		},
+		{
+			name:  "scoped to a keyspace",
+			req:   &vtctldatapb.ApplyTableACLRequest{TableAcl: newConfig("ks1")},
+			saved: true,
+		},
+		{
+			name: "dry run",
+			req:  &vtctldatapb.ApplyTableACLRequest{TableAcl: newConfig(), DryRun: true},
+		},
+		{
+			name:      "unknown keyspace",
+			req:       &vtctldatapb.ApplyTableACLRequest{TableAcl: newConfig("ks2")},
+			shouldErr: "keyspace(ks2) which doesn't exist",
+		},
+		{
+			name: "unknown user group",
+			req: &vtctldatapb.ApplyTableACLRequest{TableAcl: &tableaclpb.Config{
+				TableGroups: []*tableaclpb.TableGroupSpec{{
+					Name:                 "group01",
+					TableNamesOrPrefixes: []string{"t1"},
+					Readers:              []string{"group:writers"},
+				}},
+			}},
+			shouldErr: "invalid table ACL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}))
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := vtctld.ApplyTableACL(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, err := vtctld.GetTableACL(ctx, &vtctldatapb.GetTableACLRequest{})
+			require.NoError(t, err)
+			if !tt.saved {
+				assert.Nil(t, resp.TableAcl)
+				return
+			}
+			utils.MustMatch(t, tt.req.TableAcl, resp.TableAcl)
+		})
+	}
+}
+
+func TestApplyTabletQueryRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rules := `[{"Name": "bound_events", "TableNames": ["events"], "Action": "LIMIT", "QueryTimeout": "30s", "MaxRows": 1000}]`
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ApplyTabletQueryRulesRequest
+		shouldErr string
+	}{
+		{
+			name: "global cell",
+			req:  &vtctldatapb.ApplyTabletQueryRulesRequest{Path: "/vt/rules", Rules: rules},
+		},
+		{
+			name: "local cell",
+			req:  &vtctldatapb.ApplyTabletQueryRulesRequest{Cell: "zone1", Path: "/vt/rules", Rules: rules},
+		},
+		{
+			name:      "missing path",
+			req:       &vtctldatapb.ApplyTabletQueryRulesRequest{Rules: rules},
+			shouldErr: "the path of the query rules is required",
+		},
+		{
+			name:      "invalid rules",
+			req:       &vtctldatapb.ApplyTabletQueryRulesRequest{Path: "/vt/rules", Rules: `[{"Name": "r1", "Action": "LIMIT"}]`},
+			shouldErr: "invalid query rules",
+		},
+		{
+			name:      "unknown cell",
+			req:       &vtctldatapb.ApplyTabletQueryRulesRequest{Cell: "zone2", Path: "/vt/rules", Rules: rules},
+			shouldErr: "zone2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := vtctld.ApplyTabletQueryRules(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, err := vtctld.GetTabletQueryRules(ctx, &vtctldatapb.GetTabletQueryRulesRequest{Cell: tt.req.Cell, Path: tt.req.Path})
+			require.NoError(t, err)
+			assert.Equal(t, rules, resp.Rules)
+
+			// The tablets read the rules straight from the topo.
+			cell := tt.req.Cell
+			if cell == "" {
+				cell = topo.GlobalCell
+			}
+			conn, err := ts.ConnForCell(ctx, cell)
+			require.NoError(t, err)
+			data, _, err := conn.Get(ctx, tt.req.Path)
+			require.NoError(t, err)
+			assert.Equal(t, rules, string(data))
+		})
+	}
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -677,6 +914,91 @@ func TestApplyVSchema(t *testing.T) {
 	}
 }
 
+func TestApplyVTGateConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := &vschemapb.VTGateConfig{
+		QueryTimeoutMs:        1000,
+		MaxMemoryRows:         1000,
+		ScatterMaxConcurrency: 8,
+	}
+	tests := []struct {
+		name           string
+		req            *vtctldatapb.ApplyVTGateConfigRequest
+		expectedConfig *vschemapb.VTGateConfig
+		shouldErr      string
+	}{
+		{
+			name: "success",
+			req:  &vtctldatapb.ApplyVTGateConfigRequest{VtgateConfig: config},
+			expectedConfig: &vschemapb.VTGateConfig{
+				Version:               2,
+				QueryTimeoutMs:        1000,
+				MaxMemoryRows:         1000,
+				ScatterMaxConcurrency: 8,
+			},
+		},
+		{
+			name: "expected version",
+			req:  &vtctldatapb.ApplyVTGateConfigRequest{VtgateConfig: config, ExpectedVersion: 1},
+			expectedConfig: &vschemapb.VTGateConfig{
+				Version:               2,
+				QueryTimeoutMs:        1000,
+				MaxMemoryRows:         1000,
+				ScatterMaxConcurrency: 8,
+			},
+		},
+		{
+			name:      "unexpected version",
+			req:       &vtctldatapb.ApplyVTGateConfigRequest{VtgateConfig: config, ExpectedVersion: 2},
+			shouldErr: "the vtgate config was changed concurrently",
+		},
+		{
+			name:      "invalid config",
+			req:       &vtctldatapb.ApplyVTGateConfigRequest{VtgateConfig: &vschemapb.VTGateConfig{BufferWindowMs: 10}},
+			shouldErr: "buffer_window_ms must be >= 1000",
+		},
+		{
+			name: "rebuild failed (bad cell)",
+			req: &vtctldatapb.ApplyVTGateConfigRequest{
+				VtgateConfig: config,
+				RebuildCells: []string{"zone1", "zone2"},
+			},
+			shouldErr: "RebuildSrvVSchema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{QueryTimeoutMs: 500}, 0)
+			require.NoError(t, err)
+
+			resp, err := vtctld.ApplyVTGateConfig(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedConfig, resp.VtgateConfig)
+
+			getResp, err := vtctld.GetVTGateConfig(ctx, &vtctldatapb.GetVTGateConfigRequest{})
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedConfig, getResp.VtgateConfig)
+			assert.Len(t, getResp.History, 2)
+
+			srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedConfig, srvVSchema.VtgateConfig)
+		})
+	}
+}
+
 func TestBackup(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -2364,6 +2686,123 @@ func TestCompleteSchemaMigration(t *testing.T) {
 	}
 }
 
+func TestConcludeTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "ks",
+		Shard:    "-80",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+		Keyspace: "ks",
+		Shard:    "80-",
+		Type:     topodatapb.TabletType_PRIMARY,
+	})
+
+	setRollback := &tabletmanagerdatapb.ConcludeTransactionRequest{Dtid: "ks:-80:1234", SetRollback: true}
+	conclude := &tabletmanagerdatapb.ConcludeTransactionRequest{Dtid: "ks:-80:1234", Mm: true}
+	tests := []struct {
+		name     string
+		state    querypb.TransactionState
+		resolved bool
+		// mmErr is the error of the metadata manager.
+		mmErr      error
+		wantMM     []*tabletmanagerdatapb.ConcludeTransactionRequest
+		wantCommit bool
+		wantErr    string
+	}{
+		{
+			name:       "commit decision",
+			state:      querypb.TransactionState_COMMIT,
+			wantMM:     []*tabletmanagerdatapb.ConcludeTransactionRequest{conclude},
+			wantCommit: true,
+		},
+		{
+			name:   "abandoned while preparing",
+			state:  querypb.TransactionState_PREPARE,
+			wantMM: []*tabletmanagerdatapb.ConcludeTransactionRequest{setRollback, conclude},
+		},
+		{
+			// The coordinator recorded the decision to commit the transaction
+			// after it was read: the participants must not be rolled back.
+			name:    "committed while preparing",
+			state:   querypb.TransactionState_PREPARE,
+			mmErr:   errors.New("could not transition to ROLLBACK: ks:-80:1234"),
+			wantMM:  []*tabletmanagerdatapb.ConcludeTransactionRequest{setRollback},
+			wantErr: "cannot roll back transaction ks:-80:1234, which may have been committed meanwhile: could not transition to ROLLBACK",
+		},
+		{
+			name:   "rollback decision",
+			state:  querypb.TransactionState_ROLLBACK,
+			wantMM: []*tabletmanagerdatapb.ConcludeTransactionRequest{conclude},
+		},
+		{
+			name:     "already resolved",
+			resolved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := &querypb.TransactionMetadata{}
+			if !tt.resolved {
+				metadata = &querypb.TransactionMetadata{
+					Dtid:  "ks:-80:1234",
+					State: tt.state,
+					Participants: []*querypb.Target{{
+						Keyspace:   "ks",
+						Shard:      "80-",
+						TabletType: topodatapb.TabletType_PRIMARY,
+					}},
+				}
+			}
+			tmc := &testutil.TabletManagerClient{
+				ReadTransactionResults: map[string]struct {
+					Metadata *querypb.TransactionMetadata
+					Error    error
+				}{
+					"zone1-0000000100": {Metadata: metadata},
+				},
+				ConcludeTransactionResults: map[string]error{
+					"zone1-0000000100": tt.mmErr,
+					"zone1-0000000200": nil,
+				},
+			}
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+
+			_, err := vtctld.ConcludeTransaction(ctx, &vtctldatapb.ConcludeTransactionRequest{Dtid: "ks:-80:1234"})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.resolved {
+				assert.Empty(t, tmc.ConcludeTransactionRequests)
+				return
+			}
+			if tt.wantErr != "" {
+				assert.Empty(t, tmc.ConcludeTransactionRequests["zone1-0000000200"])
+			} else {
+				utils.MustMatch(t, []*tabletmanagerdatapb.ConcludeTransactionRequest{{Dtid: "ks:-80:1234", Commit: tt.wantCommit}}, tmc.ConcludeTransactionRequests["zone1-0000000200"])
+			}
+			utils.MustMatch(t, tt.wantMM, tmc.ConcludeTransactionRequests["zone1-0000000100"])
+		})
+	}
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	_, err := vtctld.ConcludeTransaction(ctx, &vtctldatapb.ConcludeTransactionRequest{Dtid: "invalid"})
+	assert.ErrorContains(t, err, "invalid parts in dtid")
+}
+
 func TestCreateKeyspace(t *testing.T) {
 	t.Parallel()
 
@@ -7775,6 +8214,51 @@ func TestGetTopologyPath(t *testing.T) {
 	}
 }
 
+func TestGetUnresolvedTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "ks",
+		Shard:    "-80",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+		Keyspace: "ks",
+		Shard:    "80-",
+		Type:     topodatapb.TabletType_PRIMARY,
+	})
+
+	tx1 := &querypb.TransactionMetadata{Dtid: "ks:-80:1", State: querypb.TransactionState_COMMIT, TimeCreated: 2}
+	tx2 := &querypb.TransactionMetadata{Dtid: "ks:80-:2", State: querypb.TransactionState_PREPARE, TimeCreated: 1}
+	tmc := &testutil.TabletManagerClient{
+		GetUnresolvedTransactionsResults: map[string]struct {
+			Transactions []*querypb.TransactionMetadata
+			Error        error
+		}{
+			"zone1-0000000100": {Transactions: []*querypb.TransactionMetadata{tx1}},
+			"zone1-0000000200": {Transactions: []*querypb.TransactionMetadata{tx2}},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	resp, err := vtctld.GetUnresolvedTransactions(ctx, &vtctldatapb.GetUnresolvedTransactionsRequest{Keyspace: "ks", AbandonAge: 60})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*querypb.TransactionMetadata{tx2, tx1}, resp.Transactions)
+
+	tmc.GetUnresolvedTransactionsResults["zone1-0000000200"] = struct {
+		Transactions []*querypb.TransactionMetadata
+		Error        error
+	}{Error: assert.AnError}
+	_, err = vtctld.GetUnresolvedTransactions(ctx, &vtctldatapb.GetUnresolvedTransactionsRequest{Keyspace: "ks"})
+	assert.ErrorContains(t, err, "GetUnresolvedTransactions(ks/80-) failed")
+}
+
 func TestGetVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -10607,6 +11091,60 @@ func TestRetrySchemaMigration(t *testing.T) {
 	}
 }
 
+func TestRollbackVTGateConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tests := []struct {
+		name           string
+		version        int64
+		expectedConfig *vschemapb.VTGateConfig
+		shouldErr      string
+	}{
+		{
+			name:           "previous version",
+			version:        1,
+			expectedConfig: &vschemapb.VTGateConfig{Version: 3, QueryTimeoutMs: 500},
+		},
+		{
+			name:           "flags",
+			version:        0,
+			expectedConfig: &vschemapb.VTGateConfig{Version: 3},
+		},
+		{
+			name:      "unknown version",
+			version:   5,
+			shouldErr: "version 5 of the vtgate config not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{QueryTimeoutMs: 500}, 0)
+			require.NoError(t, err)
+			_, err = ts.SaveVTGateConfig(ctx, &vschemapb.VTGateConfig{QueryTimeoutMs: 1000}, 0)
+			require.NoError(t, err)
+
+			resp, err := vtctld.RollbackVTGateConfig(ctx, &vtctldatapb.RollbackVTGateConfigRequest{Version: tt.version})
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedConfig, resp.VtgateConfig)
+
+			srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expectedConfig, srvVSchema.VtgateConfig)
+		})
+	}
+}
+
 func TestRunHealthCheck(t *testing.T) {
 	t.Parallel()
 
@@ -10791,6 +11329,43 @@ func TestSetKeyspaceDurabilityPolicy(t *testing.T) {
 	}
 }
 
+func TestSetMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	resp, err := vtctld.GetMaintenanceWindows(ctx, &vtctldatapb.GetMaintenanceWindowsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Windows)
+	assert.True(t, resp.Open, "no windows")
+
+	// February 30th never comes.
+	window := &topodatapb.MaintenanceWindow{Name: "never", Schedule: "0 0 30 2 *", Duration: protoutil.DurationToProto(time.Hour)}
+	_, err = vtctld.SetMaintenanceWindow(ctx, &vtctldatapb.SetMaintenanceWindowRequest{Window: window})
+	require.NoError(t, err)
+	resp, err = vtctld.GetMaintenanceWindows(ctx, &vtctldatapb.GetMaintenanceWindowsRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*topodatapb.MaintenanceWindow{window}, resp.Windows)
+	assert.False(t, resp.Open)
+
+	_, err = vtctld.SetMaintenanceWindow(ctx, &vtctldatapb.SetMaintenanceWindowRequest{Window: &topodatapb.MaintenanceWindow{Name: "invalid", Schedule: "0 0 30 2"}})
+	assert.ErrorContains(t, err, "maintenance window invalid: invalid schedule")
+
+	_, err = vtctld.DeleteMaintenanceWindow(ctx, &vtctldatapb.DeleteMaintenanceWindowRequest{Name: "never"})
+	require.NoError(t, err)
+	_, err = vtctld.DeleteMaintenanceWindow(ctx, &vtctldatapb.DeleteMaintenanceWindowRequest{Name: "never"})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "got: %v", err)
+	resp, err = vtctld.GetMaintenanceWindows(ctx, &vtctldatapb.GetMaintenanceWindowsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Windows)
+	assert.True(t, resp.Open)
+}
+
 func TestSetShardIsPrimaryServing(t *testing.T) {
 	t.Parallel()
 
@@ -13026,6 +13601,62 @@ func TestUpdateCellsAlias(t *testing.T) {
 	}
 }
 
+func TestUpdateTableGCConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "ks",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "zone1", "ks", &topodatapb.SrvKeyspace{}))
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	_, err := vtctld.UpdateTableGCConfig(ctx, &vtctldatapb.UpdateTableGCConfigRequest{Keyspace: "ks", EnablePurgePartitions: true, DisablePurgePartitions: true})
+	assert.ErrorContains(t, err, "mutually exclusive")
+	_, err = vtctld.UpdateTableGCConfig(ctx, &vtctldatapb.UpdateTableGCConfigRequest{Keyspace: "ks", Retention: protoutil.DurationToProto(-time.Hour), RetentionSet: true})
+	assert.ErrorContains(t, err, "must not be negative")
+
+	resp, err := vtctld.UpdateTableGCConfig(ctx, &vtctldatapb.UpdateTableGCConfigRequest{
+		Keyspace:              "ks",
+		Retention:             protoutil.DurationToProto(6 * time.Hour),
+		RetentionSet:          true,
+		EnablePurgePartitions: true,
+	})
+	require.NoError(t, err)
+	expected := &topodatapb.TableGCConfig{
+		Retention:       protoutil.DurationToProto(6 * time.Hour),
+		PurgePartitions: true,
+	}
+	utils.MustMatch(t, expected, resp.TableGcConfig)
+
+	ki, err := ts.GetKeyspace(ctx, "ks")
+	require.NoError(t, err)
+	utils.MustMatch(t, expected, ki.TableGcConfig)
+	srvKeyspace, err := ts.GetSrvKeyspace(ctx, "zone1", "ks")
+	require.NoError(t, err)
+	utils.MustMatch(t, expected, srvKeyspace.TableGcConfig)
+
+	// Request an immediate purge, and reset the retention: other settings are unchanged.
+	resp, err = vtctld.UpdateTableGCConfig(ctx, &vtctldatapb.UpdateTableGCConfigRequest{
+		Keyspace:     "ks",
+		RetentionSet: true,
+		PurgeNow:     true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.TableGcConfig.Retention)
+	assert.True(t, resp.TableGcConfig.PurgePartitions)
+	assert.NotNil(t, resp.TableGcConfig.PurgeRequestedAt)
+
+	srvKeyspace, err = ts.GetSrvKeyspace(ctx, "zone1", "ks")
+	require.NoError(t, err)
+	utils.MustMatch(t, resp.TableGcConfig, srvKeyspace.TableGcConfig)
+}
+
 func TestValidate(t *testing.T) {
 	t.Parallel()
 
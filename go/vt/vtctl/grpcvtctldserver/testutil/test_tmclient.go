@@ -186,6 +186,10 @@ type TabletManagerClient struct {
 	}
 	// keyed by tablet alias.
 	ChangeTabletTypeResult map[string]error
+	// keyed by tablet alias. ConcludeTransactionRequests records the requests
+	// received for the tablets.
+	ConcludeTransactionResults  map[string]error
+	ConcludeTransactionRequests map[string][]*tabletmanagerdatapb.ConcludeTransactionRequest
 	// keyed by tablet alias.
 	DemotePrimaryDelays map[string]time.Duration
 	// keyed by tablet alias.
@@ -250,6 +254,11 @@ type TabletManagerClient struct {
 		Error  error
 	}
 	// keyed by tablet alias.
+	GetUnresolvedTransactionsResults map[string]struct {
+		Transactions []*querypb.TransactionMetadata
+		Error        error
+	}
+	// keyed by tablet alias.
 	InitPrimaryDelays map[string]time.Duration
 	// keyed by tablet alias. injects a sleep to the end of the function
 	// regardless of parent context timeout or error result.
@@ -285,6 +294,11 @@ type TabletManagerClient struct {
 		Error  error
 	}
 	// keyed by tablet alias.
+	ReadTransactionResults map[string]struct {
+		Metadata *querypb.TransactionMetadata
+		Error    error
+	}
+	// keyed by tablet alias.
 	RefreshStateResults map[string]error
 	// keyed by `<tablet_alias>/<wait_pos>`.
 	ReloadSchemaDelays map[string]time.Duration
@@ -480,6 +494,25 @@ func (fake *TabletManagerClient) ChangeType(ctx context.Context, tablet *topodat
 	return err
 }
 
+// ConcludeTransaction is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ConcludeTransactionRequest) error {
+	if fake.ConcludeTransactionResults == nil {
+		return fmt.Errorf("%w: no ConcludeTransaction results on fake TabletManagerClient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if fake.ConcludeTransactionRequests == nil {
+		fake.ConcludeTransactionRequests = map[string][]*tabletmanagerdatapb.ConcludeTransactionRequest{}
+	}
+	fake.ConcludeTransactionRequests[key] = append(fake.ConcludeTransactionRequests[key], req)
+
+	if err, ok := fake.ConcludeTransactionResults[key]; ok {
+		return err
+	}
+
+	return fmt.Errorf("%w: no ConcludeTransaction result set for tablet %s", assert.AnError, key)
+}
+
 // DemotePrimary is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) DemotePrimary(ctx context.Context, tablet *topodatapb.Tablet) (*replicationdatapb.PrimaryStatus, error) {
 	if fake.DemotePrimaryResults == nil {
@@ -717,6 +750,20 @@ func (fake *TabletManagerClient) GetSchema(ctx context.Context, tablet *topodata
 	return nil, fmt.Errorf("%w: no schemas for %s", assert.AnError, key)
 }
 
+// GetUnresolvedTransactions is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, abandonAge int64) ([]*querypb.TransactionMetadata, error) {
+	if fake.GetUnresolvedTransactionsResults == nil {
+		return nil, fmt.Errorf("%w: no GetUnresolvedTransactions results on fake TabletManagerClient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.GetUnresolvedTransactionsResults[key]; ok {
+		return result.Transactions, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no GetUnresolvedTransactions result set for tablet %s", assert.AnError, key)
+}
+
 // InitPrimary is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) InitPrimary(ctx context.Context, tablet *topodatapb.Tablet, semiSync bool) (string, error) {
 	if fake.InitPrimaryResults == nil {
@@ -875,6 +922,20 @@ func (fake *TabletManagerClient) PromoteReplica(ctx context.Context, tablet *top
 	return "", assert.AnError
 }
 
+// ReadTransaction is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ReadTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string) (*querypb.TransactionMetadata, error) {
+	if fake.ReadTransactionResults == nil {
+		return nil, fmt.Errorf("%w: no ReadTransaction results on fake TabletManagerClient", assert.AnError)
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.ReadTransactionResults[key]; ok {
+		return result.Metadata, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no ReadTransaction result set for tablet %s", assert.AnError, key)
+}
+
 // RefreshState is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) RefreshState(ctx context.Context, tablet *topodatapb.Tablet) error {
 	if fake.RefreshStateResults == nil {
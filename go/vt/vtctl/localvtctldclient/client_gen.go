@@ -44,6 +44,11 @@ func (client *localVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context,
 	return client.s.ApplyKeyspaceRoutingRules(ctx, in)
 }
 
+// ApplyQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyQueryRules(ctx context.Context, in *vtctldatapb.ApplyQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyQueryRulesResponse, error) {
+	return client.s.ApplyQueryRules(ctx, in)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	return client.s.ApplyRoutingRules(ctx, in)
@@ -59,11 +64,26 @@ func (client *localVtctldClient) ApplyShardRoutingRules(ctx context.Context, in
 	return client.s.ApplyShardRoutingRules(ctx, in)
 }
 
+// ApplyTableACL is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyTableACL(ctx context.Context, in *vtctldatapb.ApplyTableACLRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTableACLResponse, error) {
+	return client.s.ApplyTableACL(ctx, in)
+}
+
+// ApplyTabletQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyTabletQueryRules(ctx context.Context, in *vtctldatapb.ApplyTabletQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTabletQueryRulesResponse, error) {
+	return client.s.ApplyTabletQueryRules(ctx, in)
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatapb.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVSchemaResponse, error) {
 	return client.s.ApplyVSchema(ctx, in)
 }
 
+// ApplyVTGateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyVTGateConfig(ctx context.Context, in *vtctldatapb.ApplyVTGateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVTGateConfigResponse, error) {
+	return client.s.ApplyVTGateConfig(ctx, in)
+}
+
 type backupStreamAdapter struct {
 	*grpcshim.BidiStream
 	ch chan *vtctldatapb.BackupResponse
@@ -186,6 +206,11 @@ func (client *localVtctldClient) CompleteSchemaMigration(ctx context.Context, in
 	return client.s.CompleteSchemaMigration(ctx, in)
 }
 
+// ConcludeTransaction is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ConcludeTransaction(ctx context.Context, in *vtctldatapb.ConcludeTransactionRequest, opts ...grpc.CallOption) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	return client.s.ConcludeTransaction(ctx, in)
+}
+
 // CreateKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) CreateKeyspace(ctx context.Context, in *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
 	return client.s.CreateKeyspace(ctx, in)
@@ -211,6 +236,11 @@ func (client *localVtctldClient) DeleteKeyspace(ctx context.Context, in *vtctlda
 	return client.s.DeleteKeyspace(ctx, in)
 }
 
+// DeleteMaintenanceWindow is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) DeleteMaintenanceWindow(ctx context.Context, in *vtctldatapb.DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteMaintenanceWindowResponse, error) {
+	return client.s.DeleteMaintenanceWindow(ctx, in)
+}
+
 // DeleteShards is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) DeleteShards(ctx context.Context, in *vtctldatapb.DeleteShardsRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteShardsResponse, error) {
 	return client.s.DeleteShards(ctx, in)
@@ -301,11 +331,21 @@ func (client *localVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldata
 	return client.s.GetKeyspaces(ctx, in)
 }
 
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetMaintenanceWindows(ctx context.Context, in *vtctldatapb.GetMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenanceWindowsResponse, error) {
+	return client.s.GetMaintenanceWindows(ctx, in)
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
 	return client.s.GetPermissions(ctx, in)
 }
 
+// GetQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetQueryRules(ctx context.Context, in *vtctldatapb.GetQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetQueryRulesResponse, error) {
+	return client.s.GetQueryRules(ctx, in)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	return client.s.GetRoutingRules(ctx, in)
@@ -346,6 +386,11 @@ func (client *localVtctldClient) GetSrvKeyspaces(ctx context.Context, in *vtctld
 	return client.s.GetSrvKeyspaces(ctx, in)
 }
 
+// UpdateTableGCConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) UpdateTableGCConfig(ctx context.Context, in *vtctldatapb.UpdateTableGCConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateTableGCConfigResponse, error) {
+	return client.s.UpdateTableGCConfig(ctx, in)
+}
+
 // GetSrvVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetSrvVSchema(ctx context.Context, in *vtctldatapb.GetSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvVSchemaResponse, error) {
 	return client.s.GetSrvVSchema(ctx, in)
@@ -356,6 +401,16 @@ func (client *localVtctldClient) GetSrvVSchemas(ctx context.Context, in *vtctlda
 	return client.s.GetSrvVSchemas(ctx, in)
 }
 
+// GetTableACL is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetTableACL(ctx context.Context, in *vtctldatapb.GetTableACLRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTableACLResponse, error) {
+	return client.s.GetTableACL(ctx, in)
+}
+
+// GetTabletQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetTabletQueryRules(ctx context.Context, in *vtctldatapb.GetTabletQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletQueryRulesResponse, error) {
+	return client.s.GetTabletQueryRules(ctx, in)
+}
+
 // GetTablet is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetTablet(ctx context.Context, in *vtctldatapb.GetTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletResponse, error) {
 	return client.s.GetTablet(ctx, in)
@@ -371,11 +426,26 @@ func (client *localVtctldClient) GetTopologyPath(ctx context.Context, in *vtctld
 	return client.s.GetTopologyPath(ctx, in)
 }
 
+// GetTransactionInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetTransactionInfo(ctx context.Context, in *vtctldatapb.GetTransactionInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTransactionInfoResponse, error) {
+	return client.s.GetTransactionInfo(ctx, in)
+}
+
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetUnresolvedTransactions(ctx context.Context, in *vtctldatapb.GetUnresolvedTransactionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	return client.s.GetUnresolvedTransactions(ctx, in)
+}
+
 // GetVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVSchemaResponse, error) {
 	return client.s.GetVSchema(ctx, in)
 }
 
+// GetVTGateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetVTGateConfig(ctx context.Context, in *vtctldatapb.GetVTGateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVTGateConfigResponse, error) {
+	return client.s.GetVTGateConfig(ctx, in)
+}
+
 // GetVersion is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetVersion(ctx context.Context, in *vtctldatapb.GetVersionRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVersionResponse, error) {
 	return client.s.GetVersion(ctx, in)
@@ -406,6 +476,11 @@ func (client *localVtctldClient) LookupVindexExternalize(ctx context.Context, in
 	return client.s.LookupVindexExternalize(ctx, in)
 }
 
+// LookupVindexStatus is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) LookupVindexStatus(ctx context.Context, in *vtctldatapb.LookupVindexStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexStatusResponse, error) {
+	return client.s.LookupVindexStatus(ctx, in)
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) MaterializeCreate(ctx context.Context, in *vtctldatapb.MaterializeCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.MaterializeCreateResponse, error) {
 	return client.s.MaterializeCreate(ctx, in)
@@ -572,6 +647,11 @@ func (client *localVtctldClient) RetrySchemaMigration(ctx context.Context, in *v
 	return client.s.RetrySchemaMigration(ctx, in)
 }
 
+// RollbackVTGateConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RollbackVTGateConfig(ctx context.Context, in *vtctldatapb.RollbackVTGateConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.RollbackVTGateConfigResponse, error) {
+	return client.s.RollbackVTGateConfig(ctx, in)
+}
+
 // RunHealthCheck is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldatapb.RunHealthCheckRequest, opts ...grpc.CallOption) (*vtctldatapb.RunHealthCheckResponse, error) {
 	return client.s.RunHealthCheck(ctx, in)
@@ -582,6 +662,11 @@ func (client *localVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context
 	return client.s.SetKeyspaceDurabilityPolicy(ctx, in)
 }
 
+// SetMaintenanceWindow is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetMaintenanceWindow(ctx context.Context, in *vtctldatapb.SetMaintenanceWindowRequest, opts ...grpc.CallOption) (*vtctldatapb.SetMaintenanceWindowResponse, error) {
+	return client.s.SetMaintenanceWindow(ctx, in)
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetShardIsPrimaryServing(ctx context.Context, in *vtctldatapb.SetShardIsPrimaryServingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardIsPrimaryServingResponse, error) {
 	return client.s.SetShardIsPrimaryServing(ctx, in)
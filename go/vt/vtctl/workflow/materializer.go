@@ -210,6 +210,9 @@ func (mz *materializer) generateBinlogSources(ctx context.Context, targetShard *
 			if !ok {
 				return nil, fmt.Errorf("unrecognized statement: %s", ts.SourceExpression)
 			}
+			if err := vreplication.ValidateFilterTransformations(mz.env, sel); err != nil {
+				return nil, err
+			}
 			if !keyRangesEqual && mz.targetVSchema.Keyspace.Sharded && mz.targetVSchema.Tables[ts.TargetTable].Type != vindexes.TypeReference {
 				cv, err := vindexes.FindBestColVindex(mz.targetVSchema.Tables[ts.TargetTable])
 				if err != nil {
@@ -480,6 +483,9 @@ func (mz *materializer) buildMaterializer() error {
 		return fmt.Errorf("failed to get source keyspace vschema: %v", err)
 	}
 	differentPVs = primaryVindexesDiffer(ms, sourceVSchema, vschema)
+	if err := validateMinMaxGroupBy(ms, sourceVSchema, mz.env.Parser()); err != nil {
+		return err
+	}
 
 	mz.targetVSchema = targetVSchema
 	mz.sourceShards = sourceShards
@@ -671,6 +677,90 @@ func primaryVindexesDiffer(ms *vtctldatapb.MaterializeSettings, source, target *
 	return false
 }
 
+// validateMinMaxGroupBy validates that all the rows of each group of the min
+// and max expressions of the tables are on a single source shard. When the min
+// or max value of a group is removed from it, vreplication recalculates it from
+// the rows of the group on the source shard of the stream. So if the source
+// keyspace is sharded, the group by must include the primary vindex columns
+// of the source table.
+func validateMinMaxGroupBy(ms *vtctldatapb.MaterializeSettings, source *vschemapb.Keyspace, parser *sqlparser.Parser) error {
+	if !source.Sharded {
+		return nil
+	}
+	var sourceVSchema *vindexes.KeyspaceSchema
+	for _, ts := range ms.TableSettings {
+		if ts.SourceExpression == "" {
+			continue
+		}
+		// Invalid expressions are reported when the streams are created.
+		stmt, err := parser.Parse(ts.SourceExpression)
+		if err != nil {
+			continue
+		}
+		sel, ok := stmt.(*sqlparser.Select)
+		if !ok || len(sel.From) != 1 || !hasMinMax(sel) {
+			continue
+		}
+		from, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+		if !ok {
+			continue
+		}
+		if sourceVSchema == nil {
+			if sourceVSchema, err = vindexes.BuildKeyspaceSchema(source, ms.SourceKeyspace, parser); err != nil {
+				return err
+			}
+		}
+		tableName := sqlparser.GetTableName(from.Expr).String()
+		table := sourceVSchema.Tables[tableName]
+		if table == nil {
+			return fmt.Errorf("table %s not found in vschema for keyspace %s", tableName, ms.SourceKeyspace)
+		}
+		if table.Type == vindexes.TypeReference {
+			continue
+		}
+		cv, err := vindexes.FindBestColVindex(table)
+		if err != nil {
+			return err
+		}
+		for _, col := range cv.Columns {
+			if !isGroupedBy(col, sel) {
+				return fmt.Errorf("the min and max expressions of table %s must be grouped by the primary vindex column %s of source table %s",
+					ts.TargetTable, col.String(), tableName)
+			}
+		}
+	}
+	return nil
+}
+
+// hasMinMax returns true if the select expressions contain min or max.
+func hasMinMax(sel *sqlparser.Select) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		switch node.(type) {
+		case *sqlparser.Min, *sqlparser.Max:
+			found = true
+		}
+		return !found, nil
+	}, sel.SelectExprs)
+	return found
+}
+
+// isGroupedBy returns true if the select is grouped by the column of the
+// source table.
+func isGroupedBy(col sqlparser.IdentifierCI, sel *sqlparser.Select) bool {
+	for _, expr := range sel.GroupBy {
+		alias, ok := expr.(*sqlparser.ColName)
+		if !ok {
+			continue
+		}
+		colName, err := matchColInSelect(alias.Name, sel)
+		if err == nil && colName.Name.Equal(col) {
+			return true
+		}
+	}
+	return false
+}
+
 func (mz *materializer) IsMultiTenantMigration() bool {
 	if mz.ms.WorkflowOptions != nil && mz.ms.WorkflowOptions.TenantId != "" {
 		return true
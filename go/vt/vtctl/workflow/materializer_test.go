@@ -1931,6 +1931,12 @@ func TestStopAfterCopyFlag(t *testing.T) {
 	ms2, _, _, err := env.ws.prepareCreateLookup(ctx, "workflow", ms.TargetKeyspace, specs, true)
 	require.NoError(t, err)
 	require.Equal(t, ms2.StopAfterCopy, false)
+
+	// The workflow maintains the lookup table of an async vindex.
+	specs.Vindexes["v"].Params["async"] = "true"
+	ms3, _, _, err := env.ws.prepareCreateLookup(ctx, "workflow", ms.TargetKeyspace, specs, false)
+	require.NoError(t, err)
+	require.Equal(t, ms3.StopAfterCopy, false)
 }
 
 func TestCreateLookupVindexFailures(t *testing.T) {
@@ -2259,6 +2265,58 @@ func TestCreateLookupVindexFailures(t *testing.T) {
 // its keyrange is equal to the target shard for the stream. This
 // means that even if the target keyspace is sharded, the source
 // does not need to perform the in_keyrange filtering.
+func TestValidateMinMaxGroupBy(t *testing.T) {
+	source := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "c1", Name: "hash"}},
+			},
+		},
+	}
+	testcases := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{{
+		name:       "grouped by the primary vindex column",
+		expression: "select c1, min(c2) as mn, max(c2) as mx from t1 group by c1",
+	}, {
+		name:       "grouped by an alias of the primary vindex column",
+		expression: "select c1 as g, c3, max(c2) as mx from t1 group by g, c3",
+	}, {
+		name:       "no min or max",
+		expression: "select c3, count(*) as cnt, sum(c2) as total from t1 group by c3",
+	}, {
+		name:       "not grouped by the primary vindex column",
+		expression: "select c3, min(c2) as mn from t1 group by c3",
+		wantErr:    "the min and max expressions of table mv must be grouped by the primary vindex column c1 of source table t1",
+	}, {
+		name:       "table not in vschema",
+		expression: "select c3, max(c2) as mx from t2 group by c3",
+		wantErr:    "table t2 not found in vschema for keyspace sourceks",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ms := &vtctldatapb.MaterializeSettings{
+				SourceKeyspace: "sourceks",
+				TableSettings:  []*vtctldatapb.TableMaterializeSettings{{TargetTable: "mv", SourceExpression: tc.expression}},
+			}
+			err := validateMinMaxGroupBy(ms, source, sqlparser.NewTestParser())
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			// Any group is on a single shard of an unsharded keyspace.
+			require.NoError(t, validateMinMaxGroupBy(ms, &vschemapb.Keyspace{}, sqlparser.NewTestParser()))
+		})
+	}
+}
+
 func TestKeyRangesEqualOptimization(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
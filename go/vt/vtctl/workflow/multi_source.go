@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// A multi-source MoveTables merges several source keyspaces into a single target keyspace. It is made of one
+// MoveTables workflow per source keyspace: the workflow of the first source keyspace has the requested name,
+// and the workflow of any other source keyspace is named <workflow>_<keyspace>. Each of the workflows lists all
+// of them in its WorkflowOptions, so that traffic is switched for all of them together.
+
+// multiSourceWorkflowName returns the name of the workflow which moves tables from the i-th source keyspace.
+func multiSourceWorkflowName(workflow string, sourceKeyspace string, i int) string {
+	if i == 0 {
+		return workflow
+	}
+	return fmt.Sprintf("%s_%s", workflow, sourceKeyspace)
+}
+
+// mapMultiSourceTables maps each of the tables to move onto the source keyspace which has it. The tables to move
+// are either includeTables or, with allTables, all tables of all source keyspaces; minus excludeTables. A table to
+// move must exist in exactly one of the source keyspaces, and each source keyspace must have tables to move.
+func mapMultiSourceTables(sourceKeyspaces []string, ksTables map[string][]string, includeTables, excludeTables []string, allTables bool) (map[string][]string, error) {
+	tableKeyspaces := make(map[string][]string)
+	var tables []string
+	for _, keyspace := range sourceKeyspaces {
+		for _, table := range ksTables[keyspace] {
+			if _, ok := tableKeyspaces[table]; !ok && allTables {
+				tables = append(tables, table)
+			}
+			tableKeyspaces[table] = append(tableKeyspaces[table], keyspace)
+		}
+	}
+	if len(includeTables) > 0 {
+		tables = includeTables
+	}
+	if len(tables) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tables to move")
+	}
+
+	var missingTables, ambiguousTables []string
+	keyspaceTables := make(map[string][]string, len(sourceKeyspaces))
+	for _, table := range tables {
+		if !shouldInclude(table, excludeTables) {
+			continue
+		}
+		keyspaces := tableKeyspaces[table]
+		switch len(keyspaces) {
+		case 0:
+			if !schema.IsInternalOperationTableName(table) {
+				missingTables = append(missingTables, table)
+			}
+		case 1:
+			keyspaceTables[keyspaces[0]] = append(keyspaceTables[keyspaces[0]], table)
+		default:
+			ambiguousTables = append(ambiguousTables, fmt.Sprintf("%s (%s)", table, strings.Join(keyspaces, ",")))
+		}
+	}
+	if len(missingTables) > 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table(s) not found in any of the source keyspaces %s: %s",
+			strings.Join(sourceKeyspaces, ","), strings.Join(missingTables, ","))
+	}
+	if len(ambiguousTables) > 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table(s) found in multiple source keyspaces: %s",
+			strings.Join(ambiguousTables, ", "))
+	}
+	for _, keyspace := range sourceKeyspaces {
+		if len(keyspaceTables[keyspace]) == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tables to move from source keyspace %s", keyspace)
+		}
+		sort.Strings(keyspaceTables[keyspace])
+	}
+	return keyspaceTables, nil
+}
+
+// validateMultiSourceMoveTablesCreate validates the options of a multi-source MoveTables, and returns its
+// source keyspaces.
+func validateMultiSourceMoveTablesCreate(req *vtctldatapb.MoveTablesCreateRequest) ([]string, error) {
+	switch {
+	case req.ExternalClusterName != "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot use an external cluster in a multi-source MoveTables")
+	case len(req.SourceShards) > 0:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot run partial shard migration along with multi-source MoveTables")
+	case req.GetWorkflowOptions().GetTenantId() != "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot run multi-tenant migration along with multi-source MoveTables")
+	case req.AtomicCopy:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot use atomic copy in a multi-source MoveTables")
+	}
+	sourceKeyspaces := append([]string{req.SourceKeyspace}, req.AdditionalSourceKeyspaces...)
+	seen := make(map[string]bool, len(sourceKeyspaces))
+	for _, keyspace := range sourceKeyspaces {
+		switch {
+		case keyspace == "":
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "empty source keyspace name")
+		case keyspace == req.TargetKeyspace:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "target keyspace %s cannot also be a source keyspace", keyspace)
+		case seen[keyspace]:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "source keyspace %s is listed more than once", keyspace)
+		}
+		seen[keyspace] = true
+	}
+	return sourceKeyspaces, nil
+}
+
+// multiSourceMoveTablesCreate creates a MoveTables workflow for each of the source keyspaces, moving the tables
+// it has into the target keyspace. If creating any of the workflows fails, the workflows already created are
+// deleted.
+func (s *Server) multiSourceMoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (res *vtctldatapb.WorkflowStatusResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.multiSourceMoveTablesCreate")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.TargetKeyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("additional_source_keyspaces", req.AdditionalSourceKeyspaces)
+
+	sourceKeyspaces, err := validateMultiSourceMoveTablesCreate(req)
+	if err != nil {
+		return nil, err
+	}
+	ksTables := make(map[string][]string, len(sourceKeyspaces))
+	for _, keyspace := range sourceKeyspaces {
+		if ksTables[keyspace], err = getTablesInKeyspace(ctx, s.ts, s.tmc, keyspace); err != nil {
+			return nil, err
+		}
+	}
+	keyspaceTables, err := mapMultiSourceTables(sourceKeyspaces, ksTables, req.IncludeTables, req.ExcludeTables, req.AllTables)
+	if err != nil {
+		return nil, err
+	}
+	workflows := make([]string, len(sourceKeyspaces))
+	for i, keyspace := range sourceKeyspaces {
+		workflows[i] = multiSourceWorkflowName(req.Workflow, keyspace, i)
+	}
+
+	var createdWorkflows []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, workflow := range createdWorkflows {
+			if _, derr := s.WorkflowDelete(ctx, &vtctldatapb.WorkflowDeleteRequest{Keyspace: req.TargetKeyspace, Workflow: workflow}); derr != nil {
+				err = vterrors.Wrapf(err, "failed to delete workflow %s.%s: %v", req.TargetKeyspace, workflow, derr)
+			}
+		}
+	}()
+
+	res = &vtctldatapb.WorkflowStatusResponse{
+		TableCopyState: make(map[string]*vtctldatapb.WorkflowStatusResponse_TableCopyState),
+		ShardStreams:   make(map[string]*vtctldatapb.WorkflowStatusResponse_ShardStreams),
+	}
+	var trafficStates []string
+	for i, keyspace := range sourceKeyspaces {
+		workflowReq := req.CloneVT()
+		workflowReq.Workflow = workflows[i]
+		workflowReq.SourceKeyspace = keyspace
+		workflowReq.AdditionalSourceKeyspaces = nil
+		workflowReq.IncludeTables = keyspaceTables[keyspace]
+		workflowReq.ExcludeTables = nil
+		workflowReq.AllTables = false
+		if workflowReq.WorkflowOptions == nil {
+			workflowReq.WorkflowOptions = &vtctldatapb.WorkflowOptions{}
+		}
+		workflowReq.WorkflowOptions.MultiSourceWorkflows = workflows
+
+		log.Infof("Creating workflow %s.%s of multi-source MoveTables, moving tables %s from keyspace %s",
+			req.TargetKeyspace, workflows[i], strings.Join(keyspaceTables[keyspace], ","), keyspace)
+		workflowRes, err := s.moveTablesCreate(ctx, workflowReq, binlogdatapb.VReplicationWorkflowType_MoveTables)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to create workflow %s.%s", req.TargetKeyspace, workflows[i])
+		}
+		createdWorkflows = append(createdWorkflows, workflows[i])
+
+		for table, state := range workflowRes.TableCopyState {
+			res.TableCopyState[table] = state
+		}
+		for shard, shardStreams := range workflowRes.ShardStreams {
+			if res.ShardStreams[shard] == nil {
+				res.ShardStreams[shard] = &vtctldatapb.WorkflowStatusResponse_ShardStreams{}
+			}
+			res.ShardStreams[shard].Streams = append(res.ShardStreams[shard].Streams, shardStreams.Streams...)
+		}
+		trafficStates = append(trafficStates, fmt.Sprintf("%s: %s", workflows[i], workflowRes.TrafficState))
+	}
+	res.TrafficState = strings.Join(trafficStates, "; ")
+	return res, nil
+}
+
+// multiSourceSwitchTraffic switches traffic for all the workflows of a multi-source MoveTables. Traffic is only
+// switched once all of the workflows are able to switch, as validated by a dry run of each. Should switching fail
+// for one of the workflows, traffic is switched back for the workflows already switched.
+func (s *Server) multiSourceSwitchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, workflows []string) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
+	direction := TrafficSwitchDirection(req.Direction)
+	cmd := "SwitchTraffic"
+	if direction == DirectionBackward {
+		cmd = "ReverseTraffic"
+	}
+	workflowRequest := func(workflow string, dryRun bool) *vtctldatapb.WorkflowSwitchTrafficRequest {
+		workflowReq := req.CloneVT()
+		workflowReq.Workflow = workflow
+		workflowReq.DryRun = dryRun
+		return workflowReq
+	}
+
+	var dryRunResults []string
+	for _, workflow := range workflows {
+		res, err := s.switchTraffic(ctx, workflowRequest(workflow, true), true)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot %s for multi-source workflow %s.%s", cmd, req.Keyspace, workflow)
+		}
+		for _, result := range res.DryRunResults {
+			dryRunResults = append(dryRunResults, fmt.Sprintf("%s: %s", workflow, result))
+		}
+	}
+	resp := &vtctldatapb.WorkflowSwitchTrafficResponse{}
+	if req.DryRun {
+		resp.Summary = fmt.Sprintf("%s dry run results for multi-source workflows %s in keyspace %s at %v",
+			cmd, strings.Join(workflows, ","), req.Keyspace, time.Now().UTC().Format(time.RFC822))
+		resp.DryRunResults = dryRunResults
+		return resp, nil
+	}
+
+	var switchedWorkflows, startStates, currentStates []string
+	for _, workflow := range workflows {
+		res, err := s.switchTraffic(ctx, workflowRequest(workflow, false), true)
+		if err != nil {
+			err = vterrors.Wrapf(err, "%s failed for multi-source workflow %s.%s", cmd, req.Keyspace, workflow)
+			for _, switchedWorkflow := range switchedWorkflows {
+				revertReq := workflowRequest(switchedWorkflow, false)
+				revertReq.Direction = int32(DirectionBackward)
+				if direction == DirectionBackward {
+					revertReq.Direction = int32(DirectionForward)
+				}
+				if _, rerr := s.switchTraffic(ctx, revertReq, true); rerr != nil {
+					err = vterrors.Wrapf(err, "failed to switch traffic back for workflow %s.%s: %v", req.Keyspace, switchedWorkflow, rerr)
+				}
+			}
+			return nil, err
+		}
+		switchedWorkflows = append(switchedWorkflows, workflow)
+		startStates = append(startStates, fmt.Sprintf("%s: %s", workflow, res.StartState))
+		currentStates = append(currentStates, fmt.Sprintf("%s: %s", workflow, res.CurrentState))
+	}
+	resp.Summary = fmt.Sprintf("%s was successful for multi-source workflows %s in keyspace %s", cmd, strings.Join(workflows, ","), req.Keyspace)
+	resp.StartState = strings.Join(startStates, "\n")
+	resp.CurrentState = strings.Join(currentStates, "\n")
+	return resp, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestMultiSourceWorkflowName(t *testing.T) {
+	assert.Equal(t, "wf", multiSourceWorkflowName("wf", "ks1", 0))
+	assert.Equal(t, "wf_ks2", multiSourceWorkflowName("wf", "ks2", 1))
+}
+
+func TestMapMultiSourceTables(t *testing.T) {
+	sourceKeyspaces := []string{"ks1", "ks2"}
+	ksTables := map[string][]string{
+		"ks1": {"t1", "t2", "dup"},
+		"ks2": {"t3", "dup"},
+	}
+	tcases := []struct {
+		name          string
+		includeTables []string
+		excludeTables []string
+		allTables     bool
+		expected      map[string][]string
+		expectedErr   string
+	}{
+		{
+			name:          "include tables",
+			includeTables: []string{"t3", "t1"},
+			expected:      map[string][]string{"ks1": {"t1"}, "ks2": {"t3"}},
+		},
+		{
+			name:          "all tables, excluding ambiguous table",
+			allTables:     true,
+			excludeTables: []string{"dup"},
+			expected:      map[string][]string{"ks1": {"t1", "t2"}, "ks2": {"t3"}},
+		},
+		{
+			name:        "all tables, with ambiguous table",
+			allTables:   true,
+			expectedErr: "table(s) found in multiple source keyspaces: dup (ks1,ks2)",
+		},
+		{
+			name:          "missing table",
+			includeTables: []string{"t1", "t3", "t4"},
+			expectedErr:   "table(s) not found in any of the source keyspaces ks1,ks2: t4",
+		},
+		{
+			name:          "no tables from a source keyspace",
+			includeTables: []string{"t1", "t2"},
+			expectedErr:   "no tables to move from source keyspace ks2",
+		},
+		{
+			name:        "no tables",
+			expectedErr: "no tables to move",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			keyspaceTables, err := mapMultiSourceTables(sourceKeyspaces, ksTables, tcase.includeTables, tcase.excludeTables, tcase.allTables)
+			if tcase.expectedErr != "" {
+				assert.EqualError(t, err, tcase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expected, keyspaceTables)
+		})
+	}
+}
+
+func TestValidateMultiSourceMoveTablesCreate(t *testing.T) {
+	tcases := []struct {
+		name        string
+		req         *vtctldatapb.MoveTablesCreateRequest
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace:            "ks1",
+				AdditionalSourceKeyspaces: []string{"ks2", "ks3"},
+				TargetKeyspace:            "target",
+			},
+			expected: []string{"ks1", "ks2", "ks3"},
+		},
+		{
+			name: "duplicate source keyspace",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace:            "ks1",
+				AdditionalSourceKeyspaces: []string{"ks2", "ks1"},
+				TargetKeyspace:            "target",
+			},
+			expectedErr: "source keyspace ks1 is listed more than once",
+		},
+		{
+			name: "target is a source",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace:            "ks1",
+				AdditionalSourceKeyspaces: []string{"target"},
+				TargetKeyspace:            "target",
+			},
+			expectedErr: "target keyspace target cannot also be a source keyspace",
+		},
+		{
+			name: "partial migration",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace:            "ks1",
+				AdditionalSourceKeyspaces: []string{"ks2"},
+				TargetKeyspace:            "target",
+				SourceShards:              []string{"-80"},
+			},
+			expectedErr: "cannot run partial shard migration along with multi-source MoveTables",
+		},
+		{
+			name: "external cluster",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace:            "ks1",
+				AdditionalSourceKeyspaces: []string{"ks2"},
+				TargetKeyspace:            "target",
+				ExternalClusterName:       "ext",
+			},
+			expectedErr: "cannot use an external cluster in a multi-source MoveTables",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			sourceKeyspaces, err := validateMultiSourceMoveTablesCreate(tcase.req)
+			if tcase.expectedErr != "" {
+				assert.EqualError(t, err, tcase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expected, sourceKeyspaces)
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sets"
@@ -393,6 +394,7 @@ func (s *Server) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflows
 	span.Annotate("workflow", req.Workflow)
 	span.Annotate("active_only", req.ActiveOnly)
 	span.Annotate("include_logs", req.IncludeLogs)
+	span.Annotate("include_conflicts", req.IncludeConflicts)
 	span.Annotate("shards", req.Shards)
 
 	readReq := &tabletmanagerdatapb.ReadVReplicationWorkflowsRequest{}
@@ -725,6 +727,25 @@ SELECT
 FROM
 	_vt.vreplication_log
 WHERE vrepl_id IN %a
+ORDER BY
+	vrepl_id ASC,
+	id ASC
+`)
+		vrepConflictsQuery = strings.TrimSpace(`
+SELECT
+	id,
+	vrepl_id,
+	table_name,
+	type,
+	row_image,
+	gtid,
+	error,
+	created_at,
+	updated_at,
+	count
+FROM
+	_vt.vreplication_conflicts
+WHERE vrepl_id IN %a
 ORDER BY
 	vrepl_id ASC,
 	id ASC
@@ -866,6 +887,73 @@ ORDER BY
 		}
 	}
 
+	// fetchStreamConflicts fetches the conflicts detected by the streams of a
+	// reverse workflow. Like the logs, the conflicts are fetched on a
+	// best-effort basis: a failure to fetch them doesn't fail GetWorkflows.
+	fetchStreamConflicts := func(ctx context.Context, workflow *vtctldatapb.Workflow) {
+		span, ctx := trace.NewSpan(ctx, "workflow.Server.fetchStreamConflicts")
+		defer span.Finish()
+
+		span.Annotate("keyspace", req.Keyspace)
+		span.Annotate("workflow", workflow.Name)
+
+		vreplIDs := make([]int64, 0, len(workflow.ShardStreams))
+		for _, shardStream := range maps.Values(workflow.ShardStreams) {
+			for _, stream := range shardStream.Streams {
+				vreplIDs = append(vreplIDs, stream.Id)
+			}
+		}
+		idsBV, err := sqltypes.BuildBindVariable(vreplIDs)
+		if err != nil {
+			return
+		}
+
+		query, err := sqlparser.ParseAndBind(vrepConflictsQuery, idsBV)
+		if err != nil {
+			return
+		}
+
+		vx := vexec.NewVExec(req.Keyspace, workflow.Name, s.ts, s.tmc, s.SQLParser())
+		results, err := vx.QueryContext(ctx, query)
+		if err != nil {
+			// As with the logs, we still read the conflicts of the tablets
+			// which returned successfully.
+			log.Warningf("Failed to fetch the conflicts of workflow %s.%s: %v", req.Keyspace, workflow.Name, err)
+		}
+
+		for target, p3qr := range results {
+			qr := sqltypes.Proto3ToResult(p3qr)
+			shardStreamKey := fmt.Sprintf("%s/%s", target.Shard, target.AliasString())
+
+			ss, ok := workflow.ShardStreams[shardStreamKey]
+			if !ok || ss == nil {
+				continue
+			}
+
+			streams := make(map[int64]*vtctldatapb.Workflow_Stream, len(ss.Streams))
+			for _, stream := range ss.Streams {
+				streams[stream.Id] = stream
+			}
+
+			for _, row := range qr.Rows {
+				conflict, err := scanStreamConflict(row)
+				if err != nil {
+					log.Warningf("Failed to read a conflict of workflow %s.%s on %s: %v", req.Keyspace, workflow.Name, shardStreamKey, err)
+					continue
+				}
+
+				stream, ok := streams[conflict.StreamId]
+				if !ok {
+					// This can happen on manual/failed workflow cleanup so keep going.
+					log.Warningf("Found stream conflict for nonexistent stream: %+v", conflict)
+					continue
+				}
+
+				stream.Conflicts = append(stream.Conflicts, conflict)
+			}
+		}
+	}
+
 	workflows := make([]*vtctldatapb.Workflow, 0, len(workflowsMap))
 
 	for name, workflow := range workflowsMap {
@@ -930,9 +1018,18 @@ ORDER BY
 				fetchStreamLogs(ctx, workflow)
 			}(ctx, workflow)
 		}
+
+		if req.IncludeConflicts {
+			// Fetch conflicts for all streams associated with this workflow in the background.
+			fetchLogsWG.Add(1)
+			go func(ctx context.Context, workflow *vtctldatapb.Workflow) {
+				defer fetchLogsWG.Done()
+				fetchStreamConflicts(ctx, workflow)
+			}(ctx, workflow)
+		}
 	}
 
-	// Wait for all the log fetchers to finish.
+	// Wait for all the log and conflict fetchers to finish.
 	fetchLogsWG.Wait()
 
 	return &vtctldatapb.GetWorkflowsResponse{
@@ -1150,7 +1247,8 @@ func (s *Server) LookupVindexCreate(ctx context.Context, req *vtctldatapb.Lookup
 
 // LookupVindexExternalize externalizes a lookup vindex that's
 // finished backfilling or has caught up. If the vindex has an
-// owner then the workflow will also be deleted.
+// owner then the workflow will also be deleted, unless the vindex
+// is async: its lookup table is then maintained by the workflow.
 func (s *Server) LookupVindexExternalize(ctx context.Context, req *vtctldatapb.LookupVindexExternalizeRequest) (*vtctldatapb.LookupVindexExternalizeResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "workflow.Server.LookupVindexExternalize")
 	defer span.Finish()
@@ -1231,10 +1329,10 @@ func (s *Server) LookupVindexExternalize(ctx context.Context, req *vtctldatapb.L
 
 	resp := &vtctldatapb.LookupVindexExternalizeResponse{}
 
-	if vindex.Owner != "" {
+	if vindex.Owner != "" && vindex.Params["async"] != "true" {
 		// If there is an owner, we have to delete the streams. Once we externalize it
 		// the VTGate will now be responsible for keeping the lookup table up to date
-		// with the owner table.
+		// with the owner table. An async vindex keeps relying on the streams for that.
 		if _, derr := s.WorkflowDelete(ctx, &vtctldatapb.WorkflowDeleteRequest{
 			Keyspace:         req.TableKeyspace,
 			Workflow:         req.Name,
@@ -1254,6 +1352,121 @@ func (s *Server) LookupVindexExternalize(ctx context.Context, req *vtctldatapb.L
 	return resp, s.ts.RebuildSrvVSchema(ctx, nil)
 }
 
+// LookupVindexStatus returns the progress of the workflow which backfills the
+// lookup table of a lookup vindex: the rows copied so far out of the estimated
+// rows of the owner table, the lag of the workflow, an estimate of the time
+// left before the copy completes, and the last time it was throttled.
+func (s *Server) LookupVindexStatus(ctx context.Context, req *vtctldatapb.LookupVindexStatusRequest) (*vtctldatapb.LookupVindexStatusResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.LookupVindexStatus")
+	defer span.Finish()
+
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	// The logs are used to find when the workflow was created.
+	wf, err := s.GetWorkflow(ctx, req.TableKeyspace, req.Name, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	if wf.WorkflowType != binlogdatapb.VReplicationWorkflowType_CreateLookupIndex.String() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "workflow %s.%s is not a LookupVindex workflow", req.TableKeyspace, req.Name)
+	}
+
+	resp := &vtctldatapb.LookupVindexStatusResponse{
+		MaxVReplicationTransactionLag: wf.MaxVReplicationTransactionLag,
+	}
+	var (
+		created    time.Time
+		ownerTable string
+		states     = make(map[string]bool)
+		// Every source shard is streamed to every target shard.
+		sourceShards = make(map[string]*binlogdatapb.BinlogSource)
+	)
+	for _, shardStreams := range wf.ShardStreams {
+		for _, stream := range shardStreams.Streams {
+			states[stream.State] = true
+			resp.RowsCopied += stream.RowsCopied
+			if throttled := stream.ThrottlerStatus; throttled.GetTimeThrottled().GetSeconds() > resp.TimeThrottled.GetSeconds() {
+				resp.ComponentThrottled = throttled.ComponentThrottled
+				resp.TimeThrottled = throttled.TimeThrottled
+			}
+			for _, entry := range stream.Logs {
+				if t := protoutil.TimeFromProto(entry.CreatedAt); !t.IsZero() && (created.IsZero() || t.Before(created)) {
+					created = t
+				}
+			}
+			bls := stream.BinlogSource
+			if bls == nil || bls.Filter == nil || len(bls.Filter.Rules) != 1 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid binlog source for stream %d of workflow %s.%s", stream.Id, req.TableKeyspace, req.Name)
+			}
+			ownerTable = bls.Filter.Rules[0].Match
+			sourceShards[topoproto.KeyspaceShardString(bls.Keyspace, bls.Shard)] = bls
+		}
+	}
+	// The workflow is in the state of its most troubled stream.
+	resp.State = binlogdatapb.VReplicationWorkflowState_Unknown.String()
+	for _, state := range []binlogdatapb.VReplicationWorkflowState{
+		binlogdatapb.VReplicationWorkflowState_Error,
+		binlogdatapb.VReplicationWorkflowState_Stopped,
+		binlogdatapb.VReplicationWorkflowState_Lagging,
+		binlogdatapb.VReplicationWorkflowState_Copying,
+		binlogdatapb.VReplicationWorkflowState_Init,
+		binlogdatapb.VReplicationWorkflowState_Running,
+	} {
+		if states[state.String()] {
+			resp.State = state.String()
+			break
+		}
+	}
+
+	for _, bls := range sourceShards {
+		rows, err := s.getTableRowsEstimate(ctx, bls.Keyspace, bls.Shard, ownerTable)
+		if err != nil {
+			return nil, err
+		}
+		resp.RowsTotal += rows
+	}
+
+	// The copy rate so far gives the time left to copy the remaining rows.
+	if resp.State == binlogdatapb.VReplicationWorkflowState_Copying.String() && !created.IsZero() &&
+		resp.RowsCopied > 0 && resp.RowsTotal > resp.RowsCopied {
+		elapsed := time.Since(created)
+		eta := time.Duration(float64(elapsed) * float64(resp.RowsTotal-resp.RowsCopied) / float64(resp.RowsCopied))
+		resp.EtaSeconds = int64(eta.Seconds())
+	}
+	return resp, nil
+}
+
+// getTableRowsEstimate returns the estimated number of rows of a table on the
+// primary of a shard, from the statistics of information_schema.
+func (s *Server) getTableRowsEstimate(ctx context.Context, keyspace, shard, table string) (int64, error) {
+	si, err := s.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return 0, err
+	}
+	if si.PrimaryAlias == nil {
+		return 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary", keyspace, shard)
+	}
+	primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+	if err != nil {
+		return 0, err
+	}
+	query := fmt.Sprintf("select table_rows from information_schema.tables where table_schema = %s and table_name = %s",
+		encodeString(primary.DbName()), encodeString(table))
+	p3qr, err := s.tmc.ExecuteFetchAsDba(ctx, primary.Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(query),
+		MaxRows: 1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	qr := sqltypes.Proto3ToResult(p3qr)
+	if len(qr.Rows) == 0 {
+		return 0, nil
+	}
+	return qr.Rows[0][0].ToCastInt64()
+}
+
 // Materialize performs the steps needed to materialize a list of
 // tables based on the materialization specs.
 func (s *Server) Materialize(ctx context.Context, ms *vtctldatapb.MaterializeSettings) error {
@@ -1296,6 +1509,9 @@ func (s *Server) Materialize(ctx context.Context, ms *vtctldatapb.MaterializeSet
 // It passes the embedded TabletRequest object to the given keyspace's
 // target primary tablets that will be executing the workflow.
 func (s *Server) MoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (res *vtctldatapb.WorkflowStatusResponse, err error) {
+	if len(req.AdditionalSourceKeyspaces) > 0 {
+		return s.multiSourceMoveTablesCreate(ctx, req)
+	}
 	return s.moveTablesCreate(ctx, req, binlogdatapb.VReplicationWorkflowType_MoveTables)
 }
 
@@ -1735,6 +1951,18 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 	span.Annotate("tables", req.Tables)
 	span.Annotate("auto_retry", req.AutoRetry)
 	span.Annotate("max_diff_duration", req.MaxDiffDuration)
+	span.Annotate("incremental_from_position", req.IncrementalFromPosition)
+	span.Annotate("repair", req.Repair)
+	span.Annotate("repair_dry_run", req.RepairDryRun)
+
+	if req.IncrementalFromPosition != "" {
+		if _, err := replication.DecodePosition(req.IncrementalFromPosition); err != nil {
+			return nil, vterrors.Wrapf(err, "invalid incremental from position %q", req.IncrementalFromPosition)
+		}
+		if req.Repair || req.RepairDryRun {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "repair is not supported with an incremental vdiff")
+		}
+	}
 
 	tabletTypesStr := discovery.BuildTabletTypesString(req.TabletTypes, req.TabletSelectionPreference)
 
@@ -1760,13 +1988,16 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 			TargetCell:  strings.Join(req.TargetCells, ","),
 		},
 		CoreOptions: &tabletmanagerdatapb.VDiffCoreOptions{
-			Tables:                strings.Join(req.Tables, ","),
-			AutoRetry:             req.AutoRetry,
-			MaxRows:               req.Limit,
-			TimeoutSeconds:        req.FilteredReplicationWaitTime.Seconds,
-			MaxExtraRowsToCompare: req.MaxExtraRowsToCompare,
-			UpdateTableStats:      req.UpdateTableStats,
-			MaxDiffSeconds:        req.MaxDiffDuration.Seconds,
+			Tables:                  strings.Join(req.Tables, ","),
+			AutoRetry:               req.AutoRetry,
+			MaxRows:                 req.Limit,
+			TimeoutSeconds:          req.FilteredReplicationWaitTime.Seconds,
+			MaxExtraRowsToCompare:   req.MaxExtraRowsToCompare,
+			UpdateTableStats:        req.UpdateTableStats,
+			MaxDiffSeconds:          req.MaxDiffDuration.Seconds,
+			IncrementalFromPosition: req.IncrementalFromPosition,
+			Repair:                  req.Repair,
+			RepairDryRun:            req.RepairDryRun,
 		},
 		ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{
 			OnlyPks:       req.OnlyPKs,
@@ -2259,6 +2490,9 @@ func (s *Server) WorkflowUpdate(ctx context.Context, req *vtctldatapb.WorkflowUp
 	span.Annotate("tablet_types", req.TabletRequest.TabletTypes)
 	span.Annotate("on_ddl", req.TabletRequest.OnDdl)
 	span.Annotate("state", req.TabletRequest.State)
+	if req.TabletRequest.CopySettings != nil {
+		span.Annotate("copy_settings", req.TabletRequest.CopySettings.String())
+	}
 
 	vx := vexec.NewVExec(req.Keyspace, req.TabletRequest.Workflow, s.ts, s.tmc, s.env.Parser())
 	callback := func(ctx context.Context, tablet *topo.TabletInfo) (*querypb.QueryResult, error) {
@@ -2980,6 +3214,13 @@ func (s *Server) finalizeMigrateWorkflow(ctx context.Context, targetKeyspace, wo
 
 // WorkflowSwitchTraffic switches traffic in the direction passed for specified tablet types.
 func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
+	return s.switchTraffic(ctx, req, false)
+}
+
+// switchTraffic switches traffic for the given workflow. If the workflow is one of the workflows of a
+// multi-source MoveTables, traffic is switched for all of them, unless multiSourceMember is set: in which
+// case traffic is switched for this one workflow only.
+func (s *Server) switchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, multiSourceMember bool) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
 	var (
 		dryRunResults                     []string
 		rdDryRunResults, wrDryRunResults  *[]string
@@ -3001,6 +3242,9 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	if startState.WorkflowType == TypeMigrate {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid action for Migrate workflow: SwitchTraffic")
 	}
+	if workflows := ts.options.GetMultiSourceWorkflows(); len(workflows) > 1 && !multiSourceMember {
+		return s.multiSourceSwitchTraffic(ctx, req, workflows)
+	}
 
 	maxReplicationLagAllowed, set, err := protoutil.DurationFromProto(req.MaxReplicationLagAllowed)
 	if err != nil {
@@ -3928,7 +4172,7 @@ func (s *Server) prepareCreateLookup(ctx context.Context, workflow, keyspace str
 		MaterializationIntent: vtctldatapb.MaterializationIntent_CREATELOOKUPINDEX,
 		SourceKeyspace:        keyspace,
 		TargetKeyspace:        targetKeyspace,
-		StopAfterCopy:         vindex.Owner != "" && !continueAfterCopyWithOwner,
+		StopAfterCopy:         vindex.Owner != "" && !continueAfterCopyWithOwner && vindex.Params["async"] != "true",
 		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
 			TargetTable:      targetTableName,
 			SourceExpression: materializeQuery,
@@ -20,11 +20,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/prototext"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
@@ -34,6 +36,7 @@ import (
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
@@ -199,3 +202,143 @@ func TestVDiffCreate(t *testing.T) {
 		})
 	}
 }
+
+// lookupVindexStatusTMClient returns a single stream for any workflow.
+type lookupVindexStatusTMClient struct {
+	*testMaterializerTMClient
+	stream *tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream
+}
+
+func (tmc *lookupVindexStatusTMClient) ReadVReplicationWorkflows(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReadVReplicationWorkflowsRequest) (*tabletmanagerdatapb.ReadVReplicationWorkflowsResponse, error) {
+	return &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{
+		Workflows: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{{
+			Workflow:     req.IncludeWorkflows[0],
+			WorkflowType: binlogdatapb.VReplicationWorkflowType_CreateLookupIndex,
+			Streams:      []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{tmc.stream},
+		}},
+	}, nil
+}
+
+func TestLookupVindexStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ms := &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "ks",
+		TargetKeyspace: "lookupks",
+	}
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	throttled := protoutil.TimeToProto(time.Now().Add(-time.Second))
+	tmc := &lookupVindexStatusTMClient{
+		testMaterializerTMClient: env.tmc,
+		stream: &tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+			Id: 1,
+			Bls: &binlogdatapb.BinlogSource{
+				Keyspace: "ks",
+				Shard:    "0",
+				Filter: &binlogdatapb.Filter{
+					Rules: []*binlogdatapb.Rule{{
+						Match:  "t1",
+						Filter: "select c1, keyspace_id() from t1",
+					}},
+				},
+			},
+			State:              binlogdatapb.VReplicationWorkflowState_Copying,
+			RowsCopied:         250,
+			TimeUpdated:        protoutil.TimeToProto(time.Now()),
+			TimeThrottled:      throttled,
+			ComponentThrottled: "vcopier",
+		},
+	}
+	ws := NewServer(vtenv.NewTestEnv(), env.topoServ, tmc)
+
+	// The workflow was created 100 seconds ago.
+	created := time.Now().UTC().Add(-100 * time.Second).Format("2006-01-02 15:04:05")
+	env.tmc.expectVRQuery(200, "/select vrepl_id, table_name, lastpk from _vt.copy_state", &sqltypes.Result{})
+	env.tmc.expectVRQuery(200, "select id from _vt.vreplication where db_name = 'vt_lookupks' and workflow = 't1_lookup'",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1"))
+	env.tmc.expectVRQuery(200, "/_vt.vreplication_log", sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"id|vrepl_id|type|state|message|created_at|updated_at|count",
+		"int64|int64|varchar|varchar|varchar|varchar|varchar|int64"),
+		fmt.Sprintf("1|1|Stream Created||created|%s|%s|1", created, created),
+	))
+	env.tmc.expectVRQuery(100, "select table_rows from information_schema.tables where table_schema = 'vt_ks' and table_name = 't1'",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("table_rows", "int64"), "1000"))
+
+	resp, err := ws.LookupVindexStatus(ctx, &vtctldatapb.LookupVindexStatusRequest{
+		Name:          "t1_lookup",
+		TableKeyspace: "lookupks",
+	})
+	require.NoError(t, err)
+	env.tmc.verifyQueries(t)
+
+	assert.Equal(t, binlogdatapb.VReplicationWorkflowState_Copying.String(), resp.State)
+	assert.EqualValues(t, 250, resp.RowsCopied)
+	assert.EqualValues(t, 1000, resp.RowsTotal)
+	// 250 rows were copied in 100 seconds, so the 750 remaining ones need 300 more seconds.
+	assert.InDelta(t, 300, resp.EtaSeconds, 10)
+	assert.Equal(t, "vcopier", resp.ComponentThrottled)
+	utils.MustMatch(t, throttled, resp.TimeThrottled)
+}
+
+func TestGetWorkflowsConflicts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ms := &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "targetks",
+		TargetKeyspace: "sourceks",
+	}
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	tmc := &lookupVindexStatusTMClient{
+		testMaterializerTMClient: env.tmc,
+		stream: &tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+			Id: 1,
+			Bls: &binlogdatapb.BinlogSource{
+				Keyspace: "targetks",
+				Shard:    "0",
+			},
+			State: binlogdatapb.VReplicationWorkflowState_Running,
+		},
+	}
+	ws := NewServer(vtenv.NewTestEnv(), env.topoServ, tmc)
+
+	created := time.Now().UTC().Add(-100 * time.Second).Format("2006-01-02 15:04:05")
+	env.tmc.expectVRQuery(200, "/select vrepl_id, table_name, lastpk from _vt.copy_state", &sqltypes.Result{})
+	env.tmc.expectVRQuery(200, "select id from _vt.vreplication where db_name = 'vt_sourceks' and workflow = 'wf1_reverse'",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1"))
+	env.tmc.expectVRQuery(200, "/_vt.vreplication_conflicts", sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"id|vrepl_id|table_name|type|row_image|gtid|error|created_at|updated_at|count",
+		"int64|int64|varchar|varchar|varchar|varchar|varchar|varchar|varchar|int64"),
+		fmt.Sprintf(`1|1|t1|missing_row|{"before":{"id":"1"},"after":null}|MySQL56/00000000-0000-0000-0000-000000000001:1-10||%s|%s|1`, created, created),
+		// Conflicts of streams which don't exist anymore are skipped.
+		fmt.Sprintf("2|3|t1|duplicate_key|{}|MySQL56/00000000-0000-0000-0000-000000000001:1-12|Duplicate entry|%s|%s|2", created, created),
+	))
+
+	resp, err := ws.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{
+		Keyspace:         "sourceks",
+		Workflow:         "wf1_reverse",
+		IncludeConflicts: true,
+	})
+	require.NoError(t, err)
+	env.tmc.verifyQueries(t)
+
+	require.Len(t, resp.Workflows, 1)
+	var conflicts []*vtctldatapb.Workflow_Stream_Conflict
+	for _, shardStreams := range resp.Workflows[0].ShardStreams {
+		for _, stream := range shardStreams.Streams {
+			conflicts = append(conflicts, stream.Conflicts...)
+		}
+	}
+	require.Len(t, conflicts, 1)
+	assert.EqualValues(t, 1, conflicts[0].StreamId)
+	assert.Equal(t, "t1", conflicts[0].TableName)
+	assert.Equal(t, "missing_row", conflicts[0].Type)
+	assert.Equal(t, `{"before":{"id":"1"},"after":null}`, conflicts[0].RowImage)
+	assert.Equal(t, "MySQL56/00000000-0000-0000-0000-000000000001:1-10", conflicts[0].Gtid)
+	assert.EqualValues(t, 1, conflicts[0].Count)
+}
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 
@@ -53,6 +54,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 )
 
 const reverseSuffix = "_reverse"
@@ -943,3 +945,44 @@ func getTabletTypeSuffix(tabletType topodatapb.TabletType) string {
 	}
 	return ""
 }
+
+// scanStreamConflict reads a row of the _vt.vreplication_conflicts table, as
+// selected by GetWorkflows.
+func scanStreamConflict(row []sqltypes.Value) (*vtctldatapb.Workflow_Stream_Conflict, error) {
+	id, err := row[0].ToCastInt64()
+	if err != nil {
+		return nil, err
+	}
+	streamID, err := row[1].ToCastInt64()
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse("2006-01-02 15:04:05", row[7].ToString())
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := time.Parse("2006-01-02 15:04:05", row[8].ToString())
+	if err != nil {
+		return nil, err
+	}
+	count, err := row[9].ToCastInt64()
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.Workflow_Stream_Conflict{
+		Id:        id,
+		StreamId:  streamID,
+		TableName: row[2].ToString(),
+		Type:      row[3].ToString(),
+		RowImage:  row[4].ToString(),
+		Gtid:      row[5].ToString(),
+		Error:     row[6].ToString(),
+		CreatedAt: &vttimepb.Time{
+			Seconds: createdAt.Unix(),
+		},
+		UpdatedAt: &vttimepb.Time{
+			Seconds: updatedAt.Unix(),
+		},
+		Count: count,
+	}, nil
+}
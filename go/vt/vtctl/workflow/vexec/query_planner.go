@@ -243,7 +243,8 @@ func (planner *VReplicationQueryPlanner) planUpdate(upd *sqlparser.Update) (*Fix
 }
 
 // VReplicationLogQueryPlanner implements the QueryPlanner interface for queries
-// on the _vt.vreplication_log table.
+// on the _vt.vreplication_log and _vt.vreplication_conflicts tables, whose rows
+// belong to a stream by their vrepl_id.
 type VReplicationLogQueryPlanner struct {
 	tmc             tmclient.TabletManagerClient
 	tabletStreamIDs map[string][]int64
@@ -340,6 +340,24 @@ func TestVReplicationLogQueryPlanner(t *testing.T) {
 					assertQueryMapsMatch(t, expected, qp.ParsedQueries)
 				},
 			},
+			{
+				targetStreamIDs: map[string][]int64{
+					"a": {1, 2},
+				},
+				query: "select * from _vt.vreplication_conflicts where type = 'duplicate_key'",
+				assertion: func(t *testing.T, plan QueryPlan) {
+					t.Helper()
+					qp, ok := plan.(*PerTargetQueryPlan)
+					if !ok {
+						require.FailNow(t, "failed type check", "expected plan to be PerTargetQueryPlan, got %T: %v", plan, plan)
+					}
+
+					expected := map[string]string{
+						"a": "select * from _vt.vreplication_conflicts where vrepl_id in (1, 2) and type = 'duplicate_key'",
+					}
+					assertQueryMapsMatch(t, expected, qp.ParsedQueries)
+				},
+			},
 		}
 
 		for _, tt := range tests {
@@ -43,6 +43,9 @@ const (
 	// VReplicationLogTableName is the unqualified name of the vreplication_log
 	// table supported by vexec.
 	VReplicationLogTableName = "vreplication_log"
+	// VReplicationConflictsTableName is the unqualified name of the
+	// vreplication_conflicts table supported by vexec.
+	VReplicationConflictsTableName = "vreplication_conflicts"
 	// VReplicationTableName is the unqualified name of the vreplication table
 	// supported by vexec.
 	VReplicationTableName = "vreplication"
@@ -271,7 +274,7 @@ func (vx *VExec) getPlanner(ctx context.Context, table string) (QueryPlanner, er
 	switch table {
 	case qualifiedTableName(VReplicationTableName):
 		return NewVReplicationQueryPlanner(vx.tmc, vx.workflow, vx.primaries[0].DbName()), nil
-	case qualifiedTableName(VReplicationLogTableName):
+	case qualifiedTableName(VReplicationLogTableName), qualifiedTableName(VReplicationConflictsTableName):
 		results, err := vx.QueryContext(ctx, "select id from _vt.vreplication")
 		if err != nil {
 			return nil, err
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/textutil"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	lookupVindexRepairInterval time.Duration
+	lookupVindexMaxLag         = time.Minute
+
+	lookupVindexRestarts = stats.NewCountersWithSingleLabel("LookupVindexRestarts", "Workflows of async lookup vindexes restarted by vtctld, by vindex", "Vindex")
+	lookupVindexDrifts   = stats.NewCountersWithSingleLabel("LookupVindexDrifts", "Checks of vtctld which found an async lookup vindex drifting from its owner table further than --lookup-vindex-max-lag, by vindex", "Vindex")
+	lookupVindexLag      = stats.NewGaugesWithSingleLabel("LookupVindexLagSeconds", "Lag of the lookup table of the async lookup vindexes behind their owner table, by vindex", "Vindex")
+)
+
+// lookupVindexWorkflows is the part of the vtctld server used by the repairer.
+type lookupVindexWorkflows interface {
+	GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest) (*vtctldatapb.GetWorkflowsResponse, error)
+	WorkflowUpdate(ctx context.Context, req *vtctldatapb.WorkflowUpdateRequest) (*vtctldatapb.WorkflowUpdateResponse, error)
+}
+
+// lookupVindexRepairer periodically checks the workflows which maintain the lookup
+// tables of the async lookup vindexes. It restarts the stopped ones, and reports
+// the vindexes drifting from their owner table further than the max lag.
+type lookupVindexRepairer struct {
+	ts        *topo.Server
+	vtctld    lookupVindexWorkflows
+	maxLag    time.Duration
+	parseName func(string) (string, string, error)
+}
+
+// startLookupVindexRepairer starts the repairer, if it is enabled by --lookup-vindex-repair-interval.
+func startLookupVindexRepairer(ts *topo.Server, parser *sqlparser.Parser, vtctld lookupVindexWorkflows) {
+	if lookupVindexRepairInterval <= 0 {
+		return
+	}
+	r := &lookupVindexRepairer{
+		ts:        ts,
+		vtctld:    vtctld,
+		maxLag:    lookupVindexMaxLag,
+		parseName: parser.ParseTable,
+	}
+	ticks := timer.NewTimer(lookupVindexRepairInterval)
+	ticks.Start(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), lookupVindexRepairInterval)
+		defer cancel()
+		r.repairAll(ctx)
+	})
+	servenv.OnTerm(ticks.Stop)
+}
+
+// repairAll checks the async lookup vindexes of all the keyspaces.
+func (r *lookupVindexRepairer) repairAll(ctx context.Context) {
+	keyspaces, err := r.ts.GetKeyspaces(ctx)
+	if err != nil {
+		log.Errorf("Cannot list the keyspaces to check their async lookup vindexes: %v", err)
+		return
+	}
+	for _, keyspace := range keyspaces {
+		vschema, err := r.ts.GetVSchema(ctx, keyspace)
+		if err != nil {
+			if !topo.IsErrType(err, topo.NoNode) {
+				log.Errorf("Cannot read the vschema of keyspace %s: %v", keyspace, err)
+			}
+			continue
+		}
+		for name, vindex := range vschema.Vindexes {
+			if vindex.Params["async"] != "true" {
+				continue
+			}
+			tableKeyspace, _, err := r.parseName(vindex.Params["table"])
+			if err != nil || tableKeyspace == "" {
+				tableKeyspace = keyspace
+			}
+			r.repair(ctx, keyspace+"."+name, tableKeyspace, name)
+		}
+	}
+}
+
+// repair checks the workflow of an async lookup vindex, which is named after the vindex.
+func (r *lookupVindexRepairer) repair(ctx context.Context, vindex, keyspace, workflow string) {
+	resp, err := r.vtctld.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{
+		Keyspace: keyspace,
+		Workflow: workflow,
+	})
+	if err != nil {
+		log.Errorf("Cannot read the workflow %s.%s of the async lookup vindex %s: %v", keyspace, workflow, vindex, err)
+		return
+	}
+	if len(resp.Workflows) == 0 {
+		lookupVindexDrifts.Add(vindex, 1)
+		log.Errorf("The async lookup vindex %s has no workflow %s.%s to maintain its lookup table", vindex, keyspace, workflow)
+		return
+	}
+
+	wf := resp.Workflows[0]
+	stopped, copying := false, false
+	for _, shardStreams := range wf.ShardStreams {
+		for _, stream := range shardStreams.Streams {
+			switch stream.State {
+			case binlogdatapb.VReplicationWorkflowState_Stopped.String():
+				stopped = true
+			case binlogdatapb.VReplicationWorkflowState_Copying.String():
+				copying = true
+			}
+		}
+	}
+	if stopped {
+		if _, err := r.vtctld.WorkflowUpdate(ctx, &vtctldatapb.WorkflowUpdateRequest{
+			Keyspace: keyspace,
+			TabletRequest: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+				Workflow:    workflow,
+				Cells:       textutil.SimulatedNullStringSlice,
+				TabletTypes: []topodatapb.TabletType{topodatapb.TabletType(textutil.SimulatedNullInt)},
+				OnDdl:       binlogdatapb.OnDDLAction(textutil.SimulatedNullInt),
+				State:       binlogdatapb.VReplicationWorkflowState_Running,
+			},
+		}); err != nil {
+			log.Errorf("Cannot restart the workflow %s.%s of the async lookup vindex %s: %v", keyspace, workflow, vindex, err)
+			return
+		}
+		lookupVindexRestarts.Add(vindex, 1)
+		log.Infof("Restarted the stopped workflow %s.%s of the async lookup vindex %s", keyspace, workflow, vindex)
+	}
+	// The lookup table is still being backfilled during the copy phase.
+	if copying {
+		return
+	}
+
+	lag := time.Duration(wf.MaxVReplicationTransactionLag) * time.Second
+	lookupVindexLag.Set(vindex, int64(lag.Seconds()))
+	if lag > r.maxLag {
+		lookupVindexDrifts.Add(vindex, 1)
+		log.Warningf("The lookup table of the async lookup vindex %s lags %v behind its owner table", vindex, lag)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+type fakeLookupVindexWorkflows struct {
+	workflows map[string]*vtctldatapb.Workflow
+	restarted []string
+}
+
+func (f *fakeLookupVindexWorkflows) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest) (*vtctldatapb.GetWorkflowsResponse, error) {
+	resp := &vtctldatapb.GetWorkflowsResponse{}
+	if wf, ok := f.workflows[req.Keyspace+"."+req.Workflow]; ok {
+		resp.Workflows = append(resp.Workflows, wf)
+	}
+	return resp, nil
+}
+
+func (f *fakeLookupVindexWorkflows) WorkflowUpdate(ctx context.Context, req *vtctldatapb.WorkflowUpdateRequest) (*vtctldatapb.WorkflowUpdateResponse, error) {
+	if req.TabletRequest.State == binlogdatapb.VReplicationWorkflowState_Running {
+		f.restarted = append(f.restarted, req.Keyspace+"."+req.TabletRequest.Workflow)
+	}
+	return &vtctldatapb.WorkflowUpdateResponse{}, nil
+}
+
+func lookupVindexWorkflow(state binlogdatapb.VReplicationWorkflowState, lag int64) *vtctldatapb.Workflow {
+	return &vtctldatapb.Workflow{
+		ShardStreams: map[string]*vtctldatapb.Workflow_ShardStream{
+			"-80/zone1-100": {Streams: []*vtctldatapb.Workflow_Stream{{Id: 1, State: binlogdatapb.VReplicationWorkflowState_Running.String()}}},
+			"80-/zone1-200": {Streams: []*vtctldatapb.Workflow_Stream{{Id: 1, State: state.String()}}},
+		},
+		MaxVReplicationTransactionLag: lag,
+	}
+}
+
+func TestLookupVindexRepairer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "lookupks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.SaveVSchema(ctx, "ks", &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+			// a synchronous lookup vindex is not checked.
+			"sync_lookup": {Type: "lookup_unique", Params: map[string]string{"table": "lookupks.sync_lookup", "from": "c1", "to": "keyspace_id"}, Owner: "t1"},
+			"stopped":     {Type: "lookup_unique", Params: map[string]string{"table": "lookupks.stopped", "from": "c2", "to": "keyspace_id", "async": "true"}, Owner: "t1"},
+			"lagging":     {Type: "lookup_unique", Params: map[string]string{"table": "lookupks.lagging", "from": "c3", "to": "keyspace_id", "async": "true"}, Owner: "t1"},
+			"copying":     {Type: "lookup_unique", Params: map[string]string{"table": "copying", "from": "c4", "to": "keyspace_id", "async": "true"}, Owner: "t1"},
+			"missing":     {Type: "lookup_unique", Params: map[string]string{"table": "lookupks.missing", "from": "c5", "to": "keyspace_id", "async": "true"}, Owner: "t1"},
+		},
+	}))
+
+	fake := &fakeLookupVindexWorkflows{
+		workflows: map[string]*vtctldatapb.Workflow{
+			"lookupks.sync_lookup": lookupVindexWorkflow(binlogdatapb.VReplicationWorkflowState_Stopped, 0),
+			"lookupks.stopped":     lookupVindexWorkflow(binlogdatapb.VReplicationWorkflowState_Stopped, 1),
+			"lookupks.lagging":     lookupVindexWorkflow(binlogdatapb.VReplicationWorkflowState_Running, 600),
+			// the lookup table is in the keyspace of the vindex when it is not qualified.
+			"ks.copying": lookupVindexWorkflow(binlogdatapb.VReplicationWorkflowState_Copying, 1<<62),
+		},
+	}
+	r := &lookupVindexRepairer{
+		ts:        ts,
+		vtctld:    fake,
+		maxLag:    time.Minute,
+		parseName: sqlparser.NewTestParser().ParseTable,
+	}
+	restartsBefore := lookupVindexRestarts.Counts()
+	driftsBefore := lookupVindexDrifts.Counts()
+
+	r.repairAll(ctx)
+	assert.Equal(t, []string{"lookupks.stopped"}, fake.restarted)
+	assert.EqualValues(t, 1, lookupVindexRestarts.Counts()["ks.stopped"]-restartsBefore["ks.stopped"])
+
+	drifts := lookupVindexDrifts.Counts()
+	assert.EqualValues(t, 0, drifts["ks.stopped"]-driftsBefore["ks.stopped"])
+	assert.EqualValues(t, 1, drifts["ks.lagging"]-driftsBefore["ks.lagging"])
+	assert.EqualValues(t, 0, drifts["ks.copying"]-driftsBefore["ks.copying"])
+	assert.EqualValues(t, 1, drifts["ks.missing"]-driftsBefore["ks.missing"])
+
+	lag := lookupVindexLag.Counts()
+	assert.EqualValues(t, 1, lag["ks.stopped"])
+	assert.EqualValues(t, 600, lag["ks.lagging"])
+	assert.NotContains(t, lag, "ks.copying")
+}
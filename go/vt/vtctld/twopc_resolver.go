@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	twoPCResolverInterval   time.Duration
+	twoPCResolverAbandonAge = 15 * time.Minute
+
+	twoPCResolverResolved = stats.NewCountersWithSingleLabel("TwoPCResolverResolved", "Abandoned distributed transactions resolved by vtctld, by keyspace", "Keyspace")
+	twoPCResolverErrors   = stats.NewCountersWithSingleLabel("TwoPCResolverErrors", "Errors of vtctld while resolving the abandoned distributed transactions, by keyspace", "Keyspace")
+)
+
+// transactionResolver is the part of the vtctld server used by the resolver.
+type transactionResolver interface {
+	GetUnresolvedTransactions(ctx context.Context, req *vtctldatapb.GetUnresolvedTransactionsRequest) (*vtctldatapb.GetUnresolvedTransactionsResponse, error)
+	ConcludeTransaction(ctx context.Context, req *vtctldatapb.ConcludeTransactionRequest) (*vtctldatapb.ConcludeTransactionResponse, error)
+}
+
+// twoPCResolver periodically resolves the distributed transactions that stayed
+// unresolved for longer than the abandon age, in all the keyspaces. It backs up
+// the vtgates, which only resolve the transactions that the tablets report to them.
+type twoPCResolver struct {
+	ts         *topo.Server
+	vtctld     transactionResolver
+	abandonAge time.Duration
+}
+
+// startTwoPCResolver starts the resolver, if it is enabled by --twopc-resolver-interval.
+func startTwoPCResolver(ts *topo.Server, vtctld transactionResolver) {
+	if twoPCResolverInterval <= 0 {
+		return
+	}
+	r := &twoPCResolver{
+		ts:         ts,
+		vtctld:     vtctld,
+		abandonAge: twoPCResolverAbandonAge,
+	}
+	ticks := timer.NewTimer(twoPCResolverInterval)
+	ticks.Start(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), twoPCResolverInterval)
+		defer cancel()
+		r.resolveAll(ctx)
+	})
+	servenv.OnTerm(ticks.Stop)
+}
+
+// resolveAll resolves the abandoned transactions of all the keyspaces.
+func (r *twoPCResolver) resolveAll(ctx context.Context) {
+	keyspaces, err := r.ts.GetKeyspaces(ctx)
+	if err != nil {
+		log.Errorf("Cannot list the keyspaces to resolve their abandoned transactions: %v", err)
+		return
+	}
+	for _, keyspace := range keyspaces {
+		r.resolveKeyspace(ctx, keyspace)
+	}
+}
+
+func (r *twoPCResolver) resolveKeyspace(ctx context.Context, keyspace string) {
+	resp, err := r.vtctld.GetUnresolvedTransactions(ctx, &vtctldatapb.GetUnresolvedTransactionsRequest{
+		Keyspace:   keyspace,
+		AbandonAge: int64(r.abandonAge.Seconds()),
+	})
+	if err != nil {
+		twoPCResolverErrors.Add(keyspace, 1)
+		log.Errorf("Cannot read the abandoned transactions of keyspace %s: %v", keyspace, err)
+		return
+	}
+	for _, transaction := range resp.Transactions {
+		if _, err := r.vtctld.ConcludeTransaction(ctx, &vtctldatapb.ConcludeTransactionRequest{Dtid: transaction.Dtid}); err != nil {
+			twoPCResolverErrors.Add(keyspace, 1)
+			log.Errorf("Cannot resolve the abandoned transaction %s: %v", transaction.Dtid, err)
+			continue
+		}
+		twoPCResolverResolved.Add(keyspace, 1)
+		log.Infof("Resolved the abandoned transaction %s in state %v", transaction.Dtid, transaction.State)
+	}
+}
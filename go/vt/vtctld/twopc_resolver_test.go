@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+type fakeTransactionResolver struct {
+	unresolved  map[string][]*querypb.TransactionMetadata
+	failing     map[string]bool
+	abandonAges []int64
+	concluded   []string
+}
+
+func (f *fakeTransactionResolver) GetUnresolvedTransactions(ctx context.Context, req *vtctldatapb.GetUnresolvedTransactionsRequest) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	f.abandonAges = append(f.abandonAges, req.AbandonAge)
+	return &vtctldatapb.GetUnresolvedTransactionsResponse{Transactions: f.unresolved[req.Keyspace]}, nil
+}
+
+func (f *fakeTransactionResolver) ConcludeTransaction(ctx context.Context, req *vtctldatapb.ConcludeTransactionRequest) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	if f.failing[req.Dtid] {
+		return nil, fmt.Errorf("cannot conclude %s", req.Dtid)
+	}
+	f.concluded = append(f.concluded, req.Dtid)
+	return &vtctldatapb.ConcludeTransactionResponse{}, nil
+}
+
+func TestTwoPCResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks2", &topodatapb.Keyspace{}))
+
+	fake := &fakeTransactionResolver{
+		unresolved: map[string][]*querypb.TransactionMetadata{
+			"ks1": {{Dtid: "ks1:-80:1"}, {Dtid: "ks1:80-:2"}},
+			"ks2": {{Dtid: "ks2:0:3"}},
+		},
+		failing: map[string]bool{"ks1:80-:2": true},
+	}
+	r := &twoPCResolver{
+		ts:         ts,
+		vtctld:     fake,
+		abandonAge: 10 * time.Minute,
+	}
+	resolvedBefore := twoPCResolverResolved.Counts()
+	errorsBefore := twoPCResolverErrors.Counts()
+
+	r.resolveAll(ctx)
+	assert.Equal(t, []int64{600, 600}, fake.abandonAges)
+	assert.Equal(t, []string{"ks1:-80:1", "ks2:0:3"}, fake.concluded)
+	assert.EqualValues(t, 1, twoPCResolverResolved.Counts()["ks1"]-resolvedBefore["ks1"])
+	assert.EqualValues(t, 1, twoPCResolverResolved.Counts()["ks2"]-resolvedBefore["ks2"])
+	assert.EqualValues(t, 1, twoPCResolverErrors.Counts()["ks1"]-errorsBefore["ks1"])
+}
@@ -29,6 +29,7 @@ import (
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 	"vitess.io/vitess/go/vt/wrangler"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -47,6 +48,10 @@ func init() {
 
 func registerVtctldFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&sanitizeLogMessages, "vtctld_sanitize_log_messages", sanitizeLogMessages, "When true, vtctld sanitizes logging.")
+	fs.DurationVar(&twoPCResolverInterval, "twopc-resolver-interval", twoPCResolverInterval, "Interval at which vtctld looks for the abandoned distributed transactions and resolves them. The resolver is disabled when 0.")
+	fs.DurationVar(&twoPCResolverAbandonAge, "twopc-resolver-abandon-age", twoPCResolverAbandonAge, "Age after which an unresolved distributed transaction is considered abandoned and resolved by the vtctld resolver.")
+	fs.DurationVar(&lookupVindexRepairInterval, "lookup-vindex-repair-interval", lookupVindexRepairInterval, "Interval at which vtctld checks the workflows maintaining the async lookup vindexes, and restarts the stopped ones. The repairer is disabled when 0.")
+	fs.DurationVar(&lookupVindexMaxLag, "lookup-vindex-max-lag", lookupVindexMaxLag, "Consistency window of the async lookup vindexes: the vtctld repairer reports the lookup tables lagging further behind their owner table.")
 }
 
 // InitVtctld initializes all the vtctld functionality.
@@ -133,5 +138,13 @@ func InitVtctld(env *vtenv.Environment, ts *topo.Server) error {
 	// Serve the topology endpoint in the REST API at /topodata
 	initExplorer(ts)
 
+	vtctldServer := grpcvtctldserver.NewVtctldServer(env, ts)
+
+	// Resolve the abandoned distributed transactions in the background
+	startTwoPCResolver(ts, vtctldServer)
+
+	// Keep the lookup tables of the async lookup vindexes up to date in the background
+	startLookupVindexRepairer(ts, env.Parser(), vtctldServer)
+
 	return nil
 }
@@ -34,6 +34,8 @@ type Options struct {
 	MySQLServerVersion string
 	TruncateUILen      int
 	TruncateErrLen     int
+	// RedactQueryLiterals redacts the literals of the queries logged by TruncateForLog.
+	RedactQueryLiterals bool
 }
 
 func New(cfg Options) (*Environment, error) {
@@ -41,9 +43,10 @@ func New(cfg Options) (*Environment, error) {
 		cfg.MySQLServerVersion = config.DefaultMySQLVersion
 	}
 	parser, err := sqlparser.New(sqlparser.Options{
-		MySQLServerVersion: cfg.MySQLServerVersion,
-		TruncateErrLen:     cfg.TruncateErrLen,
-		TruncateUILen:      cfg.TruncateUILen,
+		MySQLServerVersion:  cfg.MySQLServerVersion,
+		TruncateErrLen:      cfg.TruncateErrLen,
+		TruncateUILen:       cfg.TruncateUILen,
+		RedactQueryLiterals: cfg.RedactQueryLiterals,
 	})
 	if err != nil {
 		return nil, err
@@ -89,7 +92,7 @@ func (e *Environment) TruncateForUI(query string) string {
 // to avoid overwhelming logging systems with potentially long queries and
 // bind value data.
 func (e *Environment) TruncateForLog(query string) string {
-	return sqlparser.TruncateQuery(query, e.truncateErrLen)
+	return e.parser.TruncateForLog(query)
 }
 
 func (e *Environment) TruncateErrLen() int {
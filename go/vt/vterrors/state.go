@@ -50,6 +50,7 @@ const (
 	BadNullError
 	InvalidGroupFuncUse
 	ViewWrongList
+	NonDefaultValueForGeneratedColumn
 
 	// failed precondition
 	NoDB
@@ -119,6 +120,7 @@ const (
 
 	CharacterSetMismatch
 	WrongParametersToNativeFct
+	CTEMaxRecursionDepth
 
 	// No state should be added below NumOfStates
 	NumOfStates
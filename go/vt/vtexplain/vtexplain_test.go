@@ -108,14 +108,16 @@ func runTestCase(testcase, mode string, opts *Options, topts *testopts, t *testi
 		require.NoError(t, err, "vtexplain error")
 		require.NotNil(t, explains, "vtexplain error running %s: no explain", string(sql))
 
-		// We want to remove the additional `set collation_connection` queries that happen
-		// when the tablet connects to MySQL to set the default collation.
+		// We want to remove the additional `set collation_connection` and
+		// `set @@session.session_track_gtids` queries that happen when the tablet
+		// connects to MySQL to set the default collation and track the GTIDs.
 		// Removing them lets us keep simpler expected output files.
 		for _, e := range explains {
 			for i, action := range e.TabletActions {
 				var mysqlQueries []*MysqlQuery
 				for _, query := range action.MysqlQueries {
-					if !strings.Contains(strings.ToLower(query.SQL), "set collation_connection") {
+					sql := strings.ToLower(query.SQL)
+					if !strings.Contains(sql, "set collation_connection") && !strings.Contains(sql, "session_track_gtids") {
 						mysqlQueries = append(mysqlQueries, query)
 					}
 				}
@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditlog writes the audit events of vtgate, the connections and
+// authentications of its clients and optionally their statements, to sinks.
+//
+// The sinks are configured with --audit-log-sinks as a list of name:target,
+// like file:/var/log/vtgate/audit.log or syslog:vtgate. Only the file and
+// syslog sinks are built in: vitess doesn't depend on a Kafka client, so the
+// sinks writing to Kafka, or to any other system, are added by plugins
+// calling RegisterSink from an init function.
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+const (
+	// EventConnect is the type of the events of the clients connecting and authenticating successfully.
+	EventConnect = "connect"
+	// EventDisconnect is the type of the events of the authenticated clients disconnecting.
+	EventDisconnect = "disconnect"
+	// EventAuthFailure is the type of the events of the clients failing to authenticate.
+	EventAuthFailure = "auth_failure"
+	// EventStatement is the type of the events of the statements executed by the clients.
+	EventStatement = "statement"
+)
+
+var (
+	sinks            []string
+	auditStatements  bool
+	redactStatements = true
+	auditedUsers     []string
+	auditedKeyspaces []string
+	bufferSize       = 10000
+
+	eventCount      = stats.NewCountersWithSingleLabel("AuditLogEvents", "Audit events written, by type", "Type")
+	eventDropCount  = stats.NewCounter("AuditLogEventsDropped", "Audit events dropped because the audit log was full")
+	sinkErrorCounts = stats.NewCountersWithSingleLabel("AuditLogSinkErrors", "Errors writing audit events, by sink", "Sink")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&sinks, "audit-log-sinks", sinks, "Sinks of the audit log, as a list of name:target, like file:/var/log/vtgate/audit.log or syslog:vtgate. The audit log is disabled if empty.")
+	fs.BoolVar(&auditStatements, "audit-log-statements", auditStatements, "Write the statements executed by the clients to the audit log, on top of their connections and authentications.")
+	fs.BoolVar(&redactStatements, "audit-log-redact-statements", redactStatements, "Redact the literals of the statements written to the audit log.")
+	fs.StringSliceVar(&auditedUsers, "audit-log-users", auditedUsers, "Users whose events are written to the audit log. All the users are audited if empty.")
+	fs.StringSliceVar(&auditedKeyspaces, "audit-log-keyspaces", auditedKeyspaces, "Keyspaces whose statements are written to the audit log. All the keyspaces are audited if empty.")
+	fs.IntVar(&bufferSize, "audit-log-buffer-size", bufferSize, "Number of audit events buffered before being written to the sinks. The events are dropped when the buffer is full.")
+	fs.Int64Var(&fileMaxSize, "audit-log-file-max-size", fileMaxSize, "Size in bytes at which the file sinks of the audit log are rotated, 0 to never rotate them.")
+	fs.IntVar(&fileMaxBackups, "audit-log-file-max-backups", fileMaxBackups, "Number of rotated files kept by the file sinks of the audit log.")
+}
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtgate"} {
+		servenv.OnParseFor(cmd, registerFlags)
+	}
+}
+
+// Event is an audit event.
+type Event struct {
+	Time          time.Time       `json:"time"`
+	Type          string          `json:"type"`
+	ConnectionID  uint32          `json:"connection_id,omitempty"`
+	SessionUUID   string          `json:"session_uuid,omitempty"`
+	User          string          `json:"user,omitempty"`
+	RemoteAddr    string          `json:"remote_addr,omitempty"`
+	Keyspace      string          `json:"keyspace,omitempty"`
+	StatementType string          `json:"statement_type,omitempty"`
+	Statement     string          `json:"statement,omitempty"`
+	BindVariables json.RawMessage `json:"bind_variables,omitempty"`
+	Tables        []string        `json:"tables,omitempty"`
+	RowsAffected  uint64          `json:"rows_affected,omitempty"`
+	RowsReturned  uint64          `json:"rows_returned,omitempty"`
+	Duration      time.Duration   `json:"duration,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// Sink receives the audit events.
+type Sink interface {
+	// Write writes an event. It is never called concurrently.
+	Write(event *Event) error
+	// Close flushes and closes the sink.
+	Close() error
+}
+
+// SinkFactory creates a sink from its target.
+type SinkFactory func(target string) (Sink, error)
+
+var sinkFactories = make(map[string]SinkFactory)
+
+// RegisterSink registers a sink under the given name.
+func RegisterSink(name string, factory SinkFactory) {
+	if _, ok := sinkFactories[name]; ok {
+		log.Fatalf("audit log sink %s is already registered", name)
+	}
+	sinkFactories[name] = factory
+}
+
+// Config configures the events written by a Logger.
+type Config struct {
+	// Statements is true if the statements are audited.
+	Statements bool
+	// RedactStatements is true if the literals of the statements are redacted.
+	RedactStatements bool
+	// Users are the audited users, all the users being audited if empty.
+	Users []string
+	// Keyspaces are the keyspaces whose statements are audited, all of them being audited if empty.
+	Keyspaces []string
+	// BufferSize is the number of events buffered before being written.
+	BufferSize int
+}
+
+// Logger writes the audit events to sinks.
+type Logger struct {
+	config Config
+	sinks  map[string]Sink
+	parser *sqlparser.Parser
+
+	mu     sync.RWMutex
+	closed bool
+	events chan *Event
+	done   chan struct{}
+}
+
+// NewLogger returns a Logger writing to the given sinks, by name, until it is closed.
+func NewLogger(sinks map[string]Sink, config Config, parser *sqlparser.Parser) *Logger {
+	l := &Logger{
+		config: config,
+		sinks:  sinks,
+		parser: parser,
+		events: make(chan *Event, config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+func (l *Logger) run() {
+	defer close(l.done)
+	for event := range l.events {
+		for name, sink := range l.sinks {
+			if err := sink.Write(event); err != nil {
+				sinkErrorCounts.Add(name, 1)
+				log.Errorf("Failed to write audit event to %s: %v", name, err)
+			}
+		}
+		eventCount.Add(event.Type, 1)
+	}
+}
+
+// Close writes the buffered events and closes the sinks.
+func (l *Logger) Close() {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.closed = true
+	close(l.events)
+	l.mu.Unlock()
+
+	<-l.done
+	for name, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			log.Errorf("Failed to close audit log sink %s: %v", name, err)
+		}
+	}
+}
+
+// Log writes the event if its user is audited. It never blocks, the event
+// being dropped if the buffer is full.
+func (l *Logger) Log(event *Event) {
+	if l == nil || !l.auditsUser(event.User) {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return
+	}
+	select {
+	case l.events <- event:
+	default:
+		eventDropCount.Add(1)
+	}
+}
+
+func (l *Logger) auditsUser(user string) bool {
+	return len(l.config.Users) == 0 || slices.Contains(l.config.Users, user)
+}
+
+// auditsKeyspaces returns true if the statement of the given keyspace, using the given tables, is audited.
+func (l *Logger) auditsKeyspaces(keyspace string, tables []string) bool {
+	if len(l.config.Keyspaces) == 0 {
+		return true
+	}
+	if len(tables) == 0 {
+		return slices.Contains(l.config.Keyspaces, keyspace)
+	}
+	return slices.ContainsFunc(tables, func(table string) bool {
+		ks, _, _ := strings.Cut(table, ".")
+		return slices.Contains(l.config.Keyspaces, ks)
+	})
+}
+
+// LogStatement writes the event of a statement, if the statements of its user and keyspace are audited.
+func (l *Logger) LogStatement(stats *logstats.LogStats) {
+	if l == nil || !l.config.Statements || !l.auditsKeyspaces(stats.ActiveKeyspace, stats.TablesUsed) {
+		return
+	}
+	remoteAddr, _ := stats.RemoteAddrUsername()
+	event := &Event{
+		Time:          stats.EndTime,
+		Type:          EventStatement,
+		SessionUUID:   stats.SessionUUID,
+		User:          stats.ImmediateCaller(),
+		RemoteAddr:    remoteAddr,
+		Keyspace:      stats.ActiveKeyspace,
+		StatementType: stats.StmtType,
+		Tables:        stats.TablesUsed,
+		RowsAffected:  stats.RowsAffected,
+		RowsReturned:  stats.RowsReturned,
+		Duration:      stats.TotalTime(),
+		Error:         stats.ErrorStr(),
+	}
+	if l.config.RedactStatements {
+		redacted, err := l.parser.RedactSQLQuery(stats.SQL)
+		if err != nil {
+			redacted = "[statement could not be redacted]"
+		}
+		event.Statement = redacted
+	} else {
+		event.Statement = stats.SQL
+		if len(stats.BindVariables) > 0 {
+			event.BindVariables = json.RawMessage(sqltypes.FormatBindVariables(stats.BindVariables, true, true))
+		}
+	}
+	l.Log(event)
+}
+
+// subscribe writes the statements logged by the query logger.
+func (l *Logger) subscribe(queryLogger *streamlog.StreamLogger[*logstats.LogStats]) {
+	ch := queryLogger.Subscribe("AuditLog")
+	go func() {
+		for stats := range ch {
+			l.LogStatement(stats)
+		}
+	}()
+}
+
+// logger is the audit logger of vtgate, nil if the audit log is disabled.
+var logger *Logger
+
+// Init creates the audit logger of vtgate from the flags, subscribing it to the statements
+// of the query logger if they are audited. The audit log is disabled if it has no sinks.
+func Init(queryLogger *streamlog.StreamLogger[*logstats.LogStats], parser *sqlparser.Parser) error {
+	if len(sinks) == 0 {
+		return nil
+	}
+	created := make(map[string]Sink, len(sinks))
+	for _, spec := range sinks {
+		name, target, _ := strings.Cut(spec, ":")
+		factory, ok := sinkFactories[name]
+		if !ok {
+			return fmt.Errorf("unknown audit log sink %s", name)
+		}
+		sink, err := factory(target)
+		if err != nil {
+			return fmt.Errorf("cannot create audit log sink %s: %w", spec, err)
+		}
+		created[spec] = sink
+	}
+	logger = NewLogger(created, Config{
+		Statements:       auditStatements,
+		RedactStatements: redactStatements,
+		Users:            auditedUsers,
+		Keyspaces:        auditedKeyspaces,
+		BufferSize:       bufferSize,
+	}, parser)
+	if auditStatements {
+		logger.subscribe(queryLogger)
+	}
+	servenv.OnTerm(logger.Close)
+	return nil
+}
+
+// Log writes an event to the audit log of vtgate, if it is enabled.
+func Log(event *Event) {
+	logger.Log(event)
+}
+
+// SetLoggerForTests replaces the audit logger of vtgate, and returns a
+// function restoring the previous one.
+func SetLoggerForTests(l *Logger) func() {
+	old := logger
+	logger = l
+	return func() { logger = old }
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+type fakeSink struct {
+	events []*Event
+	closed bool
+}
+
+func (fs *fakeSink) Write(event *Event) error {
+	fs.events = append(fs.events, event)
+	return nil
+}
+
+func (fs *fakeSink) Close() error {
+	fs.closed = true
+	return nil
+}
+
+func newTestStats(user, keyspace, sql string, tables ...string) *logstats.LogStats {
+	ctx := callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID(user))
+	stats := logstats.NewLogStats(ctx, "Execute", sql, "suuid", map[string]*querypb.BindVariable{
+		"v1": sqltypes.Int64BindVariable(1),
+	})
+	stats.ActiveKeyspace = keyspace
+	stats.StmtType = "SELECT"
+	stats.TablesUsed = tables
+	stats.EndTime = stats.StartTime.Add(time.Millisecond)
+	return stats
+}
+
+func TestLoggerFilters(t *testing.T) {
+	sink := &fakeSink{}
+	l := NewLogger(map[string]Sink{"fake": sink}, Config{
+		Statements:       true,
+		RedactStatements: true,
+		Users:            []string{"alice"},
+		Keyspaces:        []string{"ks"},
+		BufferSize:       10,
+	}, sqlparser.NewTestParser())
+
+	l.Log(&Event{Type: EventConnect, User: "alice"})
+	l.Log(&Event{Type: EventConnect, User: "bob"})
+	l.LogStatement(newTestStats("alice", "ks", "select * from t where id = 1", "ks.t"))
+	l.LogStatement(newTestStats("alice", "other", "select * from t where id = 2", "other.t"))
+	l.LogStatement(newTestStats("bob", "ks", "select * from t where id = 3", "ks.t"))
+	l.Close()
+	// Closing twice and logging after the close are no-ops.
+	l.Close()
+	l.Log(&Event{Type: EventDisconnect, User: "alice"})
+
+	require.True(t, sink.closed)
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, EventConnect, sink.events[0].Type)
+	assert.False(t, sink.events[0].Time.IsZero())
+
+	stmt := sink.events[1]
+	assert.Equal(t, EventStatement, stmt.Type)
+	assert.Equal(t, "alice", stmt.User)
+	assert.Equal(t, "ks", stmt.Keyspace)
+	assert.Equal(t, "suuid", stmt.SessionUUID)
+	assert.Equal(t, "select * from t where id = :id /* INT64 */", stmt.Statement)
+	assert.Nil(t, stmt.BindVariables)
+	assert.Equal(t, time.Millisecond, stmt.Duration)
+}
+
+func TestLoggerStatementsNotRedacted(t *testing.T) {
+	sink := &fakeSink{}
+	l := NewLogger(map[string]Sink{"fake": sink}, Config{Statements: true, BufferSize: 10}, sqlparser.NewTestParser())
+	l.LogStatement(newTestStats("alice", "ks", "select * from t where id = :v1"))
+	l.Close()
+
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, "select * from t where id = :v1", sink.events[0].Statement)
+	assert.JSONEq(t, `{"v1": {"type": "INT64", "value": 1}}`, string(sink.events[0].BindVariables))
+}
+
+func TestLoggerWithoutStatements(t *testing.T) {
+	sink := &fakeSink{}
+	l := NewLogger(map[string]Sink{"fake": sink}, Config{BufferSize: 10}, sqlparser.NewTestParser())
+	l.LogStatement(newTestStats("alice", "ks", "select 1"))
+	l.Close()
+	assert.Empty(t, sink.events)
+
+	// A nil logger, the audit log being disabled, ignores the events.
+	var disabled *Logger
+	disabled.Log(&Event{Type: EventConnect})
+	disabled.LogStatement(newTestStats("alice", "ks", "select 1"))
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	fs, err := newFileSink(path, 200, 2)
+	require.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		require.NoError(t, fs.Write(&Event{Type: EventConnect, User: "alice", ConnectionID: uint32(i)}))
+	}
+	require.NoError(t, fs.Close())
+
+	readIDs := func(path string) []uint32 {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		var ids []uint32
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var event Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			ids = append(ids, event.ConnectionID)
+		}
+		return ids
+	}
+	// Each event is about 80 bytes long, so each file holds two of them.
+	assert.Equal(t, []uint32{4, 5}, readIDs(path))
+	assert.Equal(t, []uint32{2, 3}, readIDs(path+".1"))
+	assert.Equal(t, []uint32{0, 1}, readIDs(path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestInitWithRegisteredSink(t *testing.T) {
+	sink := &fakeSink{}
+	var target string
+	RegisterSink("fake", func(t string) (Sink, error) {
+		target = t
+		return sink, nil
+	})
+	defer delete(sinkFactories, "fake")
+	defer SetLoggerForTests(nil)()
+
+	oldSinks := sinks
+	defer func() { sinks = oldSinks }()
+
+	// There is no built-in Kafka sink.
+	sinks = []string{"kafka:broker:9092"}
+	assert.EqualError(t, Init(nil, sqlparser.NewTestParser()), "unknown audit log sink kafka")
+
+	sinks = []string{"fake:broker:9092"}
+	require.NoError(t, Init(nil, sqlparser.NewTestParser()))
+	assert.Equal(t, "broker:9092", target)
+	Log(&Event{Type: EventConnect, User: "user1"})
+	logger.Close()
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, "user1", sink.events[0].User)
+	assert.True(t, sink.closed)
+}
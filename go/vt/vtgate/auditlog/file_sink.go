@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	fileMaxSize    int64 = 100 * 1024 * 1024
+	fileMaxBackups       = 5
+)
+
+func init() {
+	RegisterSink("file", func(target string) (Sink, error) {
+		if target == "" {
+			return nil, errors.New("the file sink needs the path of the file")
+		}
+		return newFileSink(target, fileMaxSize, fileMaxBackups)
+	})
+}
+
+// fileSink writes the events as JSON lines to a file. The file is rotated
+// when it reaches its maximum size, the rotated files being suffixed with
+// .1, .2, etc from the most recent to the oldest one.
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+func newFileSink(path string, maxSize int64, maxBackups int) (*fileSink, error) {
+	fs := &fileSink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := fs.open(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *fileSink) open() error {
+	file, err := os.OpenFile(fs.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	fs.file = file
+	fs.size = info.Size()
+	return nil
+}
+
+// Write is part of the Sink interface.
+func (fs *fileSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if fs.maxSize > 0 && fs.size > 0 && fs.size+int64(len(data)) > fs.maxSize {
+		if err := fs.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := fs.file.Write(data)
+	fs.size += int64(n)
+	return err
+}
+
+func (fs *fileSink) rotate() error {
+	if err := fs.file.Close(); err != nil {
+		return err
+	}
+	if fs.maxBackups > 0 {
+		for i := fs.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(fs.backup(i), fs.backup(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		if err := os.Rename(fs.path, fs.backup(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(fs.path); err != nil {
+		return err
+	}
+	return fs.open()
+}
+
+func (fs *fileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", fs.path, i)
+}
+
+// Close is part of the Sink interface.
+func (fs *fileSink) Close() error {
+	return fs.file.Close()
+}
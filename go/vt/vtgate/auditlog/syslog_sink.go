@@ -0,0 +1,60 @@
+//go:build !windows
+
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+func init() {
+	RegisterSink("syslog", func(target string) (Sink, error) {
+		if target == "" {
+			target = "vtgate"
+		}
+		writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, target)
+		if err != nil {
+			return nil, err
+		}
+		return &syslogSink{writer: writer}, nil
+	})
+}
+
+// syslogSink writes the events as JSON messages to the local syslog daemon,
+// with the auth facility and the target as tag.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// Write is part of the Sink interface.
+func (ss *syslogSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.Error != "" || event.Type == EventAuthFailure {
+		return ss.writer.Warning(string(data))
+	}
+	return ss.writer.Info(string(data))
+}
+
+// Close is part of the Sink interface.
+func (ss *syslogSink) Close() error {
+	return ss.writer.Close()
+}
@@ -30,9 +30,12 @@ import (
 	"context"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/semaphore"
 
+	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -105,7 +108,8 @@ func getReason(err error) string {
 }
 
 // isFailoverError looks at the error returned by the sql query execution to check if there is a cluster event
-// (caused by resharding or reparenting) or a denied tables error seen during switch writes in MoveTables
+// (caused by resharding or reparenting), a denied tables error seen during switch writes in MoveTables, or a
+// MySQL error classified as caused by a failover
 func isFailoverError(err error) (string, bool) {
 	var reason string
 	var isFailover bool
@@ -117,6 +121,9 @@ func isFailoverError(err error) (string, bool) {
 			isFailover = true
 		}
 	}
+	if !isFailover && sqlerror.ClassifyError(err) == sqlerror.ErrorClassFailover {
+		return sqlerror.ErrorClassFailover.String(), true
+	}
 	if isFailover {
 		reason = getReason(err)
 	}
@@ -139,6 +146,16 @@ type Buffer struct {
 	bufferSizeSema *semaphore.Weighted
 	bufferSize     int
 
+	// window and maxFailoverDuration override the durations of the
+	// configuration when they are not 0. They are set at runtime from the
+	// dynamic configuration of vtgate.
+	window              atomic.Int64
+	maxFailoverDuration atomic.Int64
+
+	// movedTables holds the tables moved by the MoveTables workflows, per
+	// keyspace. See SetMovedTables.
+	movedTables atomic.Pointer[map[string]map[string]bool]
+
 	// mu guards all fields in this group.
 	// In particular, it is used to serialize the following Go routines:
 	// - 1. Requests which may buffer (RLock, can be run in parallel)
@@ -168,6 +185,30 @@ func (b *Buffer) GetConfig() *Config {
 	return b.config
 }
 
+// SetDurations overrides the buffering window and the maximum failover
+// duration of the configuration. A duration of 0 restores the one of the
+// configuration. It only affects the failovers starting afterwards.
+func (b *Buffer) SetDurations(window, maxFailoverDuration time.Duration) {
+	b.window.Store(int64(window))
+	b.maxFailoverDuration.Store(int64(maxFailoverDuration))
+}
+
+// Window returns how long a request is buffered at most.
+func (b *Buffer) Window() time.Duration {
+	if window := b.window.Load(); window != 0 {
+		return time.Duration(window)
+	}
+	return b.config.Window
+}
+
+// MaxFailoverDuration returns how long buffering lasts at most.
+func (b *Buffer) MaxFailoverDuration() time.Duration {
+	if maxFailoverDuration := b.maxFailoverDuration.Load(); maxFailoverDuration != 0 {
+		return time.Duration(maxFailoverDuration)
+	}
+	return b.config.MaxFailoverDuration
+}
+
 // WaitForFailoverEnd blocks until a pending buffering due to a failover for
 // keyspace/shard is over.
 // If there is no ongoing failover, "err" is checked. If it's caused by a
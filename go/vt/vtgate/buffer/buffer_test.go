@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -72,6 +73,18 @@ var (
 	}
 )
 
+func TestCausedByFailover(t *testing.T) {
+	assert.True(t, CausedByFailover(failoverErr))
+	assert.False(t, CausedByFailover(nonFailoverErr))
+	assert.True(t, CausedByFailover(vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: enforce denied tables")))
+
+	// MySQL errors are classified by sqlerror.
+	assert.True(t, CausedByFailover(vterrors.New(vtrpcpb.Code_UNKNOWN,
+		"The MySQL server is running with the --read-only option so it cannot execute this statement (errno 1290) (sqlstate HY000)")))
+	assert.False(t, CausedByFailover(vterrors.New(vtrpcpb.Code_UNKNOWN,
+		"Lock wait timeout exceeded; try restarting transaction (errno 1205) (sqlstate HY000)")))
+}
+
 func TestBuffering(t *testing.T) {
 	testAllImplementations(t, func(t *testing.T, fail failover) {
 		testBuffering1WithOptions(t, fail, 1)
@@ -763,6 +776,123 @@ func testWindow1(t *testing.T, fail failover) {
 	}
 }
 
+func TestSetDurations(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Window = 10 * time.Second
+	cfg.MaxFailoverDuration = 20 * time.Second
+	b := New(cfg)
+
+	b.SetDurations(5*time.Second, 0)
+	assert.Equal(t, 5*time.Second, b.Window())
+	assert.Equal(t, 20*time.Second, b.MaxFailoverDuration())
+
+	b.SetDurations(0, time.Minute)
+	assert.Equal(t, 10*time.Second, b.Window())
+	assert.Equal(t, time.Minute, b.MaxFailoverDuration())
+}
+
+// TestMovedTablesOnly tests that with --buffer_moved_tables_only, a MoveTables
+// traffic switch only buffers the requests using the moved tables.
+func TestMovedTablesOnly(t *testing.T) {
+	testAllImplementations(t, testMovedTablesOnly1)
+}
+
+func testMovedTablesOnly1(t *testing.T, fail failover) {
+	resetVariables()
+	defer checkVariables(t)
+
+	cfg := NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.MovedTablesOnly = true
+	b := New(cfg)
+	b.SetMovedTables(map[string][]string{keyspace: {"t1"}, "ks2": {"t2"}})
+
+	moveTablesErr := vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION,
+		"vttablet: rpc error: code = FailedPrecondition desc = disallowed due to rule: enforce denied tables")
+	issue := func(err error, tables ...string) chan error {
+		ctx := context.Background()
+		if tables != nil {
+			ctx = WithTables(ctx, tables)
+		}
+		stopped := make(chan error, 1)
+		go func() {
+			retryDone, err := b.WaitForFailoverEnd(ctx, keyspace, shard, err)
+			if retryDone != nil {
+				retryDone()
+			}
+			stopped <- err
+		}()
+		return stopped
+	}
+
+	// The request which detects the traffic switch starts buffering, and its
+	// tables are affected as well.
+	stopped1 := issue(moveTablesErr, keyspace+".t3")
+	require.NoError(t, waitForRequestsInFlight(b, 1))
+
+	// The requests using the affected tables, or unknown tables, are buffered.
+	stopped2 := issue(nil, keyspace+".t1")
+	stopped3 := issue(nil, "t3", keyspace+".t4")
+	stopped4 := issue(nil)
+	require.NoError(t, waitForRequestsInFlight(b, 4))
+
+	// The requests using other tables are not.
+	retryDone, err := b.WaitForFailoverEnd(WithTables(context.Background(), []string{keyspace + ".t2", keyspace + ".t4"}), keyspace, shard, nil)
+	require.NoError(t, err)
+	require.Nil(t, retryDone)
+	assert.EqualValues(t, 1, requestsSkipped.Counts()[statsKeyJoined+"."+skippedUnaffectedTables])
+
+	// Unless they detect the traffic switch themselves.
+	stopped5 := issue(moveTablesErr, keyspace+".t4")
+	require.NoError(t, waitForRequestsInFlight(b, 5))
+	stopped6 := issue(nil, keyspace+".t4")
+	require.NoError(t, waitForRequestsInFlight(b, 6))
+
+	fail(b, newPrimary, keyspace, shard, time.Unix(1, 0))
+	for _, stopped := range []chan error{stopped1, stopped2, stopped3, stopped4, stopped5, stopped6} {
+		require.NoError(t, <-stopped)
+	}
+	require.NoError(t, waitForState(b, stateIdle))
+	require.NoError(t, waitForPoolSlots(b, cfg.Size))
+	assert.Nil(t, b.getOrCreateBuffer(keyspace, shard).tables)
+}
+
+// TestMovedTablesOnlyFailover tests that with --buffer_moved_tables_only, the
+// other failovers still buffer all the requests.
+func TestMovedTablesOnlyFailover(t *testing.T) {
+	testAllImplementations(t, testMovedTablesOnlyFailover1)
+}
+
+func testMovedTablesOnlyFailover1(t *testing.T, fail failover) {
+	resetVariables()
+	defer checkVariables(t)
+
+	cfg := NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.MovedTablesOnly = true
+	b := New(cfg)
+	b.SetMovedTables(map[string][]string{keyspace: {"t1"}})
+
+	stopped1 := issueRequest(context.Background(), t, b, failoverErr)
+	require.NoError(t, waitForRequestsInFlight(b, 1))
+
+	stopped2 := make(chan error, 1)
+	go func() {
+		retryDone, err := b.WaitForFailoverEnd(WithTables(context.Background(), []string{keyspace + ".t2"}), keyspace, shard, nil)
+		if retryDone != nil {
+			retryDone()
+		}
+		stopped2 <- err
+	}()
+	require.NoError(t, waitForRequestsInFlight(b, 2))
+
+	fail(b, newPrimary, keyspace, shard, time.Unix(1, 0))
+	require.NoError(t, <-stopped1)
+	require.NoError(t, <-stopped2)
+	require.NoError(t, waitForState(b, stateIdle))
+	require.NoError(t, waitForPoolSlots(b, cfg.Size))
+}
+
 // TestShutdown tests that Buffer.Shutdown() unblocks any pending bufferings
 // immediately.
 func TestShutdown(t *testing.T) {
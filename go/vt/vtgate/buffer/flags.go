@@ -40,6 +40,8 @@ var (
 
 	bufferDrainConcurrency = 1
 	bufferKeyspaceShards   string
+
+	bufferMovedTablesOnly bool
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -53,6 +55,7 @@ func registerFlags(fs *pflag.FlagSet) {
 
 	fs.IntVar(&bufferDrainConcurrency, "buffer_drain_concurrency", 1, "Maximum number of requests retried simultaneously. More concurrency will increase the load on the PRIMARY vttablet when draining the buffer.")
 	fs.StringVar(&bufferKeyspaceShards, "buffer_keyspace_shards", "", "If not empty, limit buffering to these entries (comma separated). Entry format: keyspace or keyspace/shard. Requires --enable_buffer=true.")
+	fs.BoolVar(&bufferMovedTablesOnly, "buffer_moved_tables_only", false, "During a MoveTables traffic switch, only buffer the requests using the moved tables, and let the requests using other tables of the same shards through.")
 }
 
 func init() {
@@ -148,6 +151,10 @@ type Config struct {
 	// If empty (and *enabled==true), buffering is enabled for all shards.
 	Shards map[string]bool
 
+	// MovedTablesOnly limits the buffering during a MoveTables traffic switch
+	// to the requests using the moved tables.
+	MovedTablesOnly bool
+
 	// internal: used for testing
 	now func() time.Time
 }
@@ -219,6 +226,8 @@ func NewConfigFromFlags() *Config {
 		Keyspaces: keyspaces,
 		Shards:    shards,
 
+		MovedTablesOnly: bufferMovedTablesOnly,
+
 		now: time.Now,
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -104,6 +105,10 @@ type shardBuffer struct {
 	lastReparent time.Time
 	// currentPrimary is tracked to determine when to update "lastReparent".
 	currentPrimary *topodatapb.TabletAlias
+	// tables is the set of tables affected by the ongoing failover, if it is
+	// limited to some tables (see --buffer_moved_tables_only). While it is nil,
+	// all the requests are buffered.
+	tables map[string]bool
 	// timeoutThread will be set while a failover is in progress and the object is
 	// in the BUFFERING state.
 	timeoutThread *timeoutThread
@@ -149,6 +154,12 @@ func (sb *shardBuffer) waitForFailoverEnd(ctx context.Context, keyspace, shard s
 		sb.mu.RUnlock()
 		return nil, nil
 	}
+	if sb.unaffectedLocked(ctx, failoverDetected) {
+		sb.mu.RUnlock()
+		statsKeyWithReason := append(sb.statsKey, string(skippedUnaffectedTables))
+		requestsSkipped.Add(statsKeyWithReason, 1)
+		return nil, nil
+	}
 	sb.mu.RUnlock()
 
 	// Buffering required. Acquire write lock.
@@ -159,6 +170,12 @@ func (sb *shardBuffer) waitForFailoverEnd(ctx context.Context, keyspace, shard s
 		sb.mu.Unlock()
 		return nil, nil
 	}
+	if sb.unaffectedLocked(ctx, failoverDetected) {
+		sb.mu.Unlock()
+		statsKeyWithReason := append(sb.statsKey, string(skippedUnaffectedTables))
+		requestsSkipped.Add(statsKeyWithReason, 1)
+		return nil, nil
+	}
 
 	// Start buffering if failover is not detected yet.
 	if sb.state == stateIdle {
@@ -211,7 +228,13 @@ func (sb *shardBuffer) waitForFailoverEnd(ctx context.Context, keyspace, shard s
 			return nil, nil
 		}
 
-		sb.startBufferingLocked(err)
+		sb.startBufferingLocked(ctx, err)
+	} else if failoverDetected && sb.tables != nil {
+		// The request failed because of the failover, hence its tables are
+		// affected as well.
+		for _, table := range tablesFromContext(ctx) {
+			sb.tables[tableName(table)] = true
+		}
 	}
 
 	if sb.mode == bufferModeDryRun {
@@ -255,7 +278,26 @@ func (sb *shardBuffer) shouldBufferLocked(failoverDetected bool) bool {
 	panic("BUG: All possible states must be covered by the switch expression above.")
 }
 
-func (sb *shardBuffer) startBufferingLocked(err error) {
+// unaffectedLocked returns true if the current request, which did not detect
+// the failover itself, uses none of the tables affected by the ongoing
+// failover. Requests whose tables are unknown are always buffered.
+func (sb *shardBuffer) unaffectedLocked(ctx context.Context, failoverDetected bool) bool {
+	if sb.state != stateBuffering || failoverDetected || sb.tables == nil {
+		return false
+	}
+	tables := tablesFromContext(ctx)
+	if len(tables) == 0 {
+		return false
+	}
+	for _, table := range tables {
+		if sb.tables[tableName(table)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (sb *shardBuffer) startBufferingLocked(ctx context.Context, err error) {
 	// Reset monitoring data from previous failover.
 	lastRequestsInFlightMax.Set(sb.statsKey, 0)
 	lastRequestsDryRunMax.Set(sb.statsKey, 0)
@@ -265,8 +307,16 @@ func (sb *shardBuffer) startBufferingLocked(err error) {
 	sb.logErrorIfStateNotLocked(stateIdle)
 	sb.state = stateBuffering
 	sb.queue = make([]*entry, 0)
+	// A MoveTables traffic switch only affects the moved tables, and the ones
+	// of the requests which detected it.
+	if sb.buf.config.MovedTablesOnly && strings.Contains(err.Error(), ClusterEventMoveTables) {
+		sb.tables = sb.buf.movedTablesOf(sb.keyspace)
+		for _, table := range tablesFromContext(ctx) {
+			sb.tables[tableName(table)] = true
+		}
+	}
 
-	sb.timeoutThread = newTimeoutThread(sb, sb.buf.config.MaxFailoverDuration)
+	sb.timeoutThread = newTimeoutThread(sb, sb.buf.MaxFailoverDuration())
 	sb.timeoutThread.start()
 	msg := "Starting buffering"
 	if sb.mode == bufferModeDryRun {
@@ -276,11 +326,14 @@ func (sb *shardBuffer) startBufferingLocked(err error) {
 	log.Infof("%v for shard: %s (window: %v, size: %v, max failover duration: %v) (A failover was detected by this seen error: %v.)",
 		msg,
 		topoproto.KeyspaceShardString(sb.keyspace, sb.shard),
-		sb.buf.config.Window,
+		sb.buf.Window(),
 		sb.buf.config.Size,
-		sb.buf.config.MaxFailoverDuration,
+		sb.buf.MaxFailoverDuration(),
 		errorsanitizer.NormalizeError(err.Error()),
 	)
+	if sb.tables != nil {
+		log.Infof("Buffering limited for shard: %s to the requests using the moved tables: %v", topoproto.KeyspaceShardString(sb.keyspace, sb.shard), setToString(sb.tables))
+	}
 }
 
 // logErrorIfStateNotLocked logs an error if the current state is not "state".
@@ -327,7 +380,7 @@ func (sb *shardBuffer) bufferRequestLocked(ctx context.Context) (*entry, error)
 
 	e := &entry{
 		done:     make(chan struct{}),
-		deadline: sb.timeNow().Add(sb.buf.config.Window),
+		deadline: sb.timeNow().Add(sb.buf.Window()),
 	}
 	e.bufferCtx, e.bufferCancel = context.WithCancel(ctx)
 	sb.queue = append(sb.queue, e)
@@ -510,7 +563,7 @@ func (sb *shardBuffer) stopBufferingDueToMaxDuration() {
 	defer sb.mu.Unlock()
 
 	sb.stopBufferingLocked(stopMaxFailoverDurationExceeded,
-		fmt.Sprintf("stopping buffering because failover did not finish in time (%v)", sb.buf.config.MaxFailoverDuration))
+		fmt.Sprintf("stopping buffering because failover did not finish in time (%v)", sb.buf.MaxFailoverDuration()))
 }
 
 func (sb *shardBuffer) stopBufferingLocked(reason stopReason, details string) {
@@ -539,6 +592,7 @@ func (sb *shardBuffer) stopBufferingLocked(reason stopReason, details string) {
 
 	sb.logErrorIfStateNotLocked(stateBuffering)
 	sb.state = stateDraining
+	sb.tables = nil
 	q := sb.queue
 	// Clear the queue such that remove(), oldestEntry() and evictOldestEntry()
 	// will not work on obsolete data.
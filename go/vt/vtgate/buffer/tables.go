@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"strings"
+)
+
+// With --buffer_moved_tables_only, a MoveTables traffic switch only buffers the
+// requests using the tables which are moved, i.e. the tables of the routing
+// rules. The requests using other tables of the same shards keep flowing.
+// Other failovers, including Reshard, still buffer all the requests of the
+// shard because all of its tables are affected.
+
+type tablesKey struct{}
+
+// WithTables returns a context recording the tables used by the request, which
+// are optionally qualified by their keyspace. Without them, the request is
+// buffered whatever the tables affected by the failover.
+func WithTables(ctx context.Context, tables []string) context.Context {
+	return context.WithValue(ctx, tablesKey{}, tables)
+}
+
+// tablesFromContext returns the tables recorded by WithTables, if any.
+func tablesFromContext(ctx context.Context) []string {
+	tables, _ := ctx.Value(tablesKey{}).([]string)
+	return tables
+}
+
+// SetMovedTables sets the tables which are moved by the MoveTables workflows,
+// per keyspace. It must be called each time the routing rules change.
+func (b *Buffer) SetMovedTables(movedTables map[string][]string) {
+	sets := make(map[string]map[string]bool, len(movedTables))
+	for keyspace, tables := range movedTables {
+		set := make(map[string]bool, len(tables))
+		for _, table := range tables {
+			set[table] = true
+		}
+		sets[keyspace] = set
+	}
+	b.movedTables.Store(&sets)
+}
+
+// movedTablesOf returns a copy of the set of the moved tables of the keyspace.
+func (b *Buffer) movedTablesOf(keyspace string) map[string]bool {
+	set := make(map[string]bool)
+	if movedTables := b.movedTables.Load(); movedTables != nil {
+		for table := range (*movedTables)[keyspace] {
+			set[table] = true
+		}
+	}
+	return set
+}
+
+// tableName strips the keyspace qualifying the table, if any.
+func tableName(table string) string {
+	if _, name, ok := strings.Cut(table, "."); ok {
+		return name
+	}
+	return table
+}
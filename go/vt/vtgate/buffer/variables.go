@@ -134,7 +134,7 @@ const (
 // skippedReason is used in "requestsSkipped" as "Reason" label.
 type skippedReason string
 
-var skippedReasons = []skippedReason{skippedBufferFull, skippedDisabled, skippedShutdown, skippedLastReparentTooRecent, skippedLastFailoverTooRecent}
+var skippedReasons = []skippedReason{skippedBufferFull, skippedDisabled, skippedShutdown, skippedLastReparentTooRecent, skippedLastFailoverTooRecent, skippedUnaffectedTables}
 
 const (
 	// skippedBufferFull occurs when all slots in the buffer are occupied by one
@@ -147,6 +147,9 @@ const (
 	skippedShutdown              = "Shutdown"
 	skippedLastReparentTooRecent = "LastReparentTooRecent"
 	skippedLastFailoverTooRecent = "LastFailoverTooRecent"
+	// skippedUnaffectedTables is used when the request does not use the tables
+	// affected by the ongoing MoveTables traffic switch.
+	skippedUnaffectedTables = "UnaffectedTables"
 )
 
 // initVariablesForShard is used to initialize all shard variables to 0.
@@ -0,0 +1,450 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// A cursor returns the rows of a single table SELECT in pages, which lets
+// the exporters read very large tables without holding a stream open for
+// hours. The rows are paginated by keyset: each shard is queried with
+//
+//	select ..., <keyset> from t where ... and (<keyset>) > (<last keyset>) order by <keyset> limit n
+//
+// and the rows of the shards are merged by keyset. Each fetch is a short
+// autocommit query, so a cursor doesn't see a consistent snapshot of the
+// table: the rows changed between two fetches may or may not be returned.
+// The keyset columns must be unique and not null, like a primary key.
+//
+// The cursors are kept in the memory of the vtgate which opened them, and
+// are closed after --cursor-idle-timeout without a fetch.
+
+var (
+	cursorIdleTimeout = 10 * time.Minute
+	cursorMaxOpen     = 1000
+
+	cursorsOpen = stats.NewGauge("CursorsOpen", "Number of cursors opened with the OpenCursor API and not closed yet")
+)
+
+func registerCursorFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&cursorIdleTimeout, "cursor-idle-timeout", cursorIdleTimeout, "Time after which a cursor of the OpenCursor API which isn't fetched is closed")
+	fs.IntVar(&cursorMaxOpen, "cursor-max-open", cursorMaxOpen, "Maximum number of cursors of the OpenCursor API open at the same time")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerCursorFlags)
+	servenv.OnParseFor("vtcombo", registerCursorFlags)
+}
+
+// cursorShard is the state of a shard of a cursor.
+type cursorShard struct {
+	target string
+	// rows are the rows fetched from the shard and not returned yet, with
+	// the keyset columns at the end.
+	rows []sqltypes.Row
+	// last is the keyset of the last row fetched from the shard, nil
+	// before the first fetch.
+	last []sqltypes.Value
+	// exhausted is set when the shard has no more rows to fetch.
+	exhausted bool
+}
+
+type cursor struct {
+	id       string
+	owner    string
+	lastUsed time.Time
+
+	// mu serializes the fetches of the cursor.
+	mu sync.Mutex
+
+	options  *querypb.ExecuteOptions
+	sel      *sqlparser.Select
+	bindVars map[string]*querypb.BindVariable
+	keyset   sqlparser.Exprs
+	shards   []*cursorShard
+	// fields are the fields of the rows, including the keyset columns.
+	fields []*querypb.Field
+}
+
+// cursorManager keeps the open cursors of a vtgate.
+type cursorManager struct {
+	mu      sync.Mutex
+	cursors map[string]*cursor
+}
+
+func newCursorManager() *cursorManager {
+	return &cursorManager{cursors: make(map[string]*cursor)}
+}
+
+// cursorOwner returns the caller a cursor belongs to: only the caller which
+// opened a cursor can fetch it.
+func cursorOwner(ctx context.Context) string {
+	return fmt.Sprintf("%s/%s", callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)), callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx)))
+}
+
+func (cm *cursorManager) add(c *cursor) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.expireLocked(time.Now())
+	if len(cm.cursors) >= cursorMaxOpen {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "too many open cursors (%d), close some of them or wait for --cursor-idle-timeout", len(cm.cursors))
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return vterrors.Wrap(err, "cannot generate the cursor ID")
+	}
+	c.id = hex.EncodeToString(id)
+	c.lastUsed = time.Now()
+	cm.cursors[c.id] = c
+	cursorsOpen.Set(int64(len(cm.cursors)))
+	return nil
+}
+
+// get returns the cursor of the caller with the ID.
+func (cm *cursorManager) get(ctx context.Context, id string) (*cursor, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	now := time.Now()
+	cm.expireLocked(now)
+	c, ok := cm.cursors[id]
+	if !ok || c.owner != cursorOwner(ctx) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "cursor %s not found, it may have been closed after --cursor-idle-timeout", id)
+	}
+	c.lastUsed = now
+	return c, nil
+}
+
+func (cm *cursorManager) remove(id string) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	delete(cm.cursors, id)
+	cursorsOpen.Set(int64(len(cm.cursors)))
+}
+
+func (cm *cursorManager) expireLocked(now time.Time) {
+	for id, c := range cm.cursors {
+		if now.Sub(c.lastUsed) > cursorIdleTimeout {
+			delete(cm.cursors, id)
+		}
+	}
+	cursorsOpen.Set(int64(len(cm.cursors)))
+}
+
+// OpenCursor opens a cursor on the rows of a single table SELECT, and returns
+// its ID. The rows are paginated by the keyset columns, or by the primary key
+// of the table in the vschema.
+func (vtg *VTGate) OpenCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	destKeyspace, destTabletType, dest, err := vtg.executor.ParseDestinationTarget(session.TargetString)
+	statsKey := []string{"OpenCursor", destKeyspace, topoproto.TabletTypeLString(destTabletType)}
+	defer vtg.timings.Record(statsKey, time.Now())
+
+	var c *cursor
+	if err == nil {
+		c, err = vtg.newCursor(ctx, session, sql, bindVariables, keysetColumns, destKeyspace, destTabletType, dest)
+	}
+	if err == nil {
+		err = vtg.cursors.add(c)
+	}
+	if err != nil {
+		query := map[string]any{
+			"Sql":           sql,
+			"BindVariables": bindVariables,
+			"Session":       session,
+		}
+		return "", recordAndAnnotateError(err, statsKey, query, vtg.logExecute, vtg.executor.vm.parser)
+	}
+	return c.id, nil
+}
+
+func (vtg *VTGate) newCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string, keyspace string, tabletType topodatapb.TabletType, dest key.Destination) (*cursor, error) {
+	if session.InTransaction {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot open a cursor in a transaction")
+	}
+	if err := sqltypes.ValidateBindVariables(bindVariables); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", err)
+	}
+	stmt, err := vtg.executor.vm.parser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a cursor can only be opened on a SELECT")
+	}
+	if len(sel.From) != 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a cursor can only be opened on a SELECT of a single table")
+	}
+	ate, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a cursor can only be opened on a SELECT of a single table")
+	}
+	tableName, ok := ate.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a cursor can only be opened on a SELECT of a single table")
+	}
+	switch {
+	case sel.With != nil, sel.Distinct, len(sel.GroupBy) > 0, sel.Having != nil, len(sel.OrderBy) > 0, sel.Limit != nil,
+		sel.Lock != sqlparser.NoLock, sel.Into != nil, sqlparser.ContainsAggregation(sel.SelectExprs):
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the SELECT of a cursor can't have a WITH, a DISTINCT, a GROUP BY, a HAVING, an ORDER BY, a LIMIT, a lock, an INTO nor aggregations")
+	}
+
+	if !tableName.Qualifier.IsEmpty() {
+		keyspace = tableName.Qualifier.String()
+	}
+	if keyspace == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no keyspace in the target nor the table of the cursor")
+	}
+	if len(keysetColumns) == 0 {
+		table, err := vtg.executor.VSchema().FindTable(keyspace, tableName.Name.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range table.PrimaryKey {
+			keysetColumns = append(keysetColumns, col.String())
+		}
+		if len(keysetColumns) == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "table %s has no primary key in the vschema, the keyset columns of the cursor must be set", sqlparser.String(tableName))
+		}
+	}
+
+	// The queries are sent to the shards, whose database is the keyspace.
+	ate.Expr = sqlparser.TableName{Name: tableName.Name}
+	qualifier := sqlparser.TableName{Name: tableName.Name}
+	if !ate.As.IsEmpty() {
+		qualifier = sqlparser.TableName{Name: ate.As}
+	}
+	c := &cursor{
+		owner:    cursorOwner(ctx),
+		options:  session.Options,
+		sel:      sel,
+		bindVars: bindVariables,
+	}
+	// The keyset columns are qualified by the table, so that they can't be
+	// mistaken with the aliases of the select expressions.
+	for _, col := range keysetColumns {
+		expr := sqlparser.NewColNameWithQualifier(col, qualifier)
+		c.keyset = append(c.keyset, expr)
+		sel.SelectExprs = append(sel.SelectExprs, &sqlparser.AliasedExpr{Expr: expr})
+	}
+
+	if dest == nil {
+		dest = key.DestinationAllShards{}
+	}
+	rss, err := vtg.resolver.resolver.ResolveDestination(ctx, keyspace, tabletType, dest)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range rss {
+		c.shards = append(c.shards, &cursorShard{
+			target: fmt.Sprintf("%s:%s@%s", rs.Target.Keyspace, rs.Target.Shard, topoproto.TabletTypeLString(rs.Target.TabletType)),
+		})
+	}
+	return c, nil
+}
+
+// FetchCursor returns the next rows of a cursor, at most maxRows. done is set
+// when the cursor has no more rows, and it is closed then.
+func (vtg *VTGate) FetchCursor(ctx context.Context, cursorID string, maxRows int) (qr *sqltypes.Result, done bool, err error) {
+	statsKey := []string{"FetchCursor", "", ""}
+	defer vtg.timings.Record(statsKey, time.Now())
+
+	qr, done, err = vtg.fetchCursor(ctx, cursorID, maxRows)
+	if err != nil {
+		query := map[string]any{
+			"CursorID": cursorID,
+			"MaxRows":  maxRows,
+		}
+		return nil, false, recordAndAnnotateError(err, statsKey, query, vtg.logExecute, vtg.executor.vm.parser)
+	}
+	vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+	return qr, done, nil
+}
+
+func (vtg *VTGate) fetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	if maxRows <= 0 || maxRows > maxMemoryRows {
+		return nil, false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the rows fetched from a cursor must be between 1 and --max_memory_rows (%d), got %d", maxMemoryRows, maxRows)
+	}
+	c, err := vtg.cursors.get(ctx, cursorID)
+	if err != nil {
+		return nil, false, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Each shard which isn't exhausted is filled with at least maxRows rows,
+	// so that none of them can run out of rows while the next maxRows rows
+	// are merged.
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		rec concurrency.AllErrorRecorder
+	)
+	for _, shard := range c.shards {
+		if shard.exhausted || len(shard.rows) >= maxRows {
+			continue
+		}
+		wg.Add(1)
+		go func(shard *cursorShard) {
+			defer wg.Done()
+			fields, err := vtg.fetchCursorShard(ctx, c, shard, maxRows-len(shard.rows))
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if c.fields == nil && len(fields) > 0 {
+				c.fields = fields
+			}
+		}(shard)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, false, rec.AggrError(vterrors.Aggregate)
+	}
+
+	qr := &sqltypes.Result{}
+	if c.fields != nil {
+		qr.Fields = c.fields[:len(c.fields)-len(c.keyset)]
+	}
+	for len(qr.Rows) < maxRows {
+		var next *cursorShard
+		for _, shard := range c.shards {
+			if len(shard.rows) == 0 {
+				continue
+			}
+			if next == nil {
+				next = shard
+				continue
+			}
+			cmp, err := c.compareKeyset(vtg.executor.env.CollationEnv(), shard.rows[0], next.rows[0])
+			if err != nil {
+				return nil, false, err
+			}
+			if cmp < 0 {
+				next = shard
+			}
+		}
+		if next == nil {
+			break
+		}
+		qr.Rows = append(qr.Rows, next.rows[0][:len(next.rows[0])-len(c.keyset)])
+		next.rows = next.rows[1:]
+	}
+
+	for _, shard := range c.shards {
+		if !shard.exhausted || len(shard.rows) > 0 {
+			return qr, false, nil
+		}
+	}
+	vtg.cursors.remove(c.id)
+	return qr, true, nil
+}
+
+// fetchCursorShard fetches the next rows of a shard of a cursor, and returns
+// their fields.
+func (vtg *VTGate) fetchCursorShard(ctx context.Context, c *cursor, shard *cursorShard, limit int) ([]*querypb.Field, error) {
+	sel := sqlparser.CloneRefOfSelect(c.sel)
+	bindVars := make(map[string]*querypb.BindVariable, len(c.bindVars)+len(c.keyset))
+	for k, v := range c.bindVars {
+		bindVars[k] = v
+	}
+	if shard.last != nil {
+		var args sqlparser.ValTuple
+		for i, v := range shard.last {
+			name := "vtg_cursor" + strconv.Itoa(i)
+			bindVars[name] = sqltypes.ValueBindVariable(v)
+			args = append(args, sqlparser.NewArgument(name))
+		}
+		var left, right sqlparser.Expr = sqlparser.ValTuple(c.keyset), args
+		if len(c.keyset) == 1 {
+			left, right = c.keyset[0], args[0]
+		}
+		sel.AddWhere(&sqlparser.ComparisonExpr{Operator: sqlparser.GreaterThanOp, Left: left, Right: right})
+	}
+	for _, expr := range c.keyset {
+		sel.OrderBy = append(sel.OrderBy, &sqlparser.Order{Expr: expr, Direction: sqlparser.AscOrder})
+	}
+	sel.Limit = &sqlparser.Limit{Rowcount: sqlparser.NewIntLiteral(strconv.Itoa(limit))}
+
+	session := NewSafeSession(&vtgatepb.Session{
+		TargetString: shard.target,
+		Autocommit:   true,
+		Options:      c.options,
+	})
+	qr, err := vtg.executor.Execute(ctx, nil, "FetchCursor", session, sqlparser.String(sel), bindVars)
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) > 0 {
+		last := qr.Rows[len(qr.Rows)-1]
+		shard.last = last[len(last)-len(c.keyset):]
+	}
+	shard.rows = append(shard.rows, qr.Rows...)
+	shard.exhausted = len(qr.Rows) < limit
+	return qr.Fields, nil
+}
+
+// compareKeyset compares the keysets of two rows of a cursor, with the
+// collations of the keyset columns.
+func (c *cursor) compareKeyset(collationEnv *collations.Environment, a, b sqltypes.Row) (int, error) {
+	first := len(c.fields) - len(c.keyset)
+	for i := range c.keyset {
+		col := first + i
+		cmp, err := evalengine.NullsafeCompare(a[col], b[col], collationEnv, collations.ID(c.fields[col].Charset), nil)
+		if err != nil || cmp != 0 {
+			return cmp, err
+		}
+	}
+	return 0, nil
+}
+
+// CloseCursor closes a cursor before all its rows are fetched.
+func (vtg *VTGate) CloseCursor(ctx context.Context, cursorID string) error {
+	c, err := vtg.cursors.get(ctx, cursorID)
+	if err != nil {
+		return err
+	}
+	vtg.cursors.remove(c.id)
+	return nil
+}
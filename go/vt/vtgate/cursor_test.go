@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestCursor(t *testing.T) {
+	conns := map[string]*sandboxconn.SandboxConn{}
+	executor, ctx := createExecutorEnvCallback(t, func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		if ks == KsTestSharded {
+			conns[shard] = conn
+		}
+	})
+	vtg := newVTGate(executor, executor.resolver, nil, nil, nil)
+	ctx = callerid.NewContext(ctx, callerid.NewEffectiveCallerID("exporter", "", ""), nil)
+
+	fields := sqltypes.MakeTestFields("id|name|id", "int64|varchar|int64")
+	rows := func(values ...string) *sqltypes.Result {
+		return sqltypes.MakeTestResult(fields, values...)
+	}
+	for shard, conn := range conns {
+		switch shard {
+		case "-20":
+			conn.SetResults([]*sqltypes.Result{rows("1|a|1", "4|d|4"), rows()})
+		case "20-40":
+			conn.SetResults([]*sqltypes.Result{rows("2|b|2", "3|c|3"), rows()})
+		default:
+			conn.SetResults([]*sqltypes.Result{rows()})
+		}
+	}
+
+	session := &vtgatepb.Session{TargetString: KsTestSharded, Autocommit: true}
+	cursorID, err := vtg.OpenCursor(ctx, session, "select id, name from user where name != 'x'", nil, []string{"id"})
+	require.NoError(t, err)
+
+	qr, done, err := vtg.FetchCursor(ctx, cursorID, 2)
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, `[[INT64(1) VARCHAR("a")] [INT64(2) VARCHAR("b")]]`, fmt.Sprintf("%v", qr.Rows))
+	assert.Len(t, qr.Fields, 2)
+	assert.Equal(t, "select id, `name`, `user`.id from `user` where `name` != 'x' order by `user`.id asc limit 2", conns["-20"].Queries[0].Sql)
+
+	// Only the shards which aren't exhausted are queried again, after the
+	// last row they returned.
+	qr, done, err = vtg.FetchCursor(ctx, cursorID, 2)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, `[[INT64(3) VARCHAR("c")] [INT64(4) VARCHAR("d")]]`, fmt.Sprintf("%v", qr.Rows))
+	require.Len(t, conns["-20"].Queries, 2)
+	assert.Equal(t, "select id, `name`, `user`.id from `user` where `name` != 'x' and `user`.id > :vtg_cursor0 order by `user`.id asc limit 1", conns["-20"].Queries[1].Sql)
+	assert.Equal(t, sqltypes.Int64BindVariable(4), conns["-20"].Queries[1].BindVariables["vtg_cursor0"])
+	assert.Len(t, conns["40-60"].Queries, 1)
+
+	// The cursor is closed once all its rows are returned.
+	_, _, err = vtg.FetchCursor(ctx, cursorID, 2)
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(err))
+}
+
+func TestCursorErrors(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	vtg := newVTGate(executor, executor.resolver, nil, nil, nil)
+	ctx = callerid.NewContext(ctx, callerid.NewEffectiveCallerID("exporter", "", ""), nil)
+	session := &vtgatepb.Session{TargetString: KsTestSharded, Autocommit: true}
+
+	for _, sql := range []string{
+		"update user set name = 'x'",
+		"select id from user join music",
+		"select id from user order by id",
+		"select id from user limit 10",
+		"select count(*) from user",
+		"select distinct name from user",
+	} {
+		_, err := vtg.OpenCursor(ctx, session, sql, nil, []string{"id"})
+		assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err), sql)
+	}
+
+	_, err := vtg.OpenCursor(ctx, session, "select id from user", nil, nil)
+	assert.ErrorContains(t, err, "has no primary key in the vschema")
+
+	cursorID, err := vtg.OpenCursor(ctx, session, "select id from user", nil, []string{"id"})
+	require.NoError(t, err)
+	_, _, err = vtg.FetchCursor(ctx, cursorID, 0)
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+
+	// Only the caller which opened the cursor can use it.
+	otherCtx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("other", "", ""), nil)
+	_, _, err = vtg.FetchCursor(otherCtx, cursorID, 1)
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(err))
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(vtg.CloseCursor(otherCtx, cursorID)))
+
+	oldMaxOpen := cursorMaxOpen
+	cursorMaxOpen = 1
+	defer func() { cursorMaxOpen = oldMaxOpen }()
+	_, err = vtg.OpenCursor(ctx, session, "select id from user", nil, []string{"id"})
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+
+	require.NoError(t, vtg.CloseCursor(ctx, cursorID))
+	_, _, err = vtg.FetchCursor(ctx, cursorID, 1)
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(err))
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// The dynamic configuration of vtgate, received with the SrvVSchema, overrides
+// the values of some of its flags at runtime. A value of 0 keeps the value of
+// the flag.
+var (
+	queryTimeoutOverride   atomic.Int64
+	maxMemoryRowsOverride  atomic.Int64
+	warnMemoryRowsOverride atomic.Int64
+
+	vtgateConfigVersion = stats.NewGauge("VTGateConfigVersion", "Version of the dynamic vtgate config applied by vtgate")
+)
+
+// getQueryTimeout returns the default query timeout, in milliseconds.
+func getQueryTimeout() int {
+	if timeout := queryTimeoutOverride.Load(); timeout != 0 {
+		return int(timeout)
+	}
+	return queryTimeout
+}
+
+// getMaxMemoryRows returns the maximum number of rows held in memory.
+func getMaxMemoryRows() int {
+	if rows := maxMemoryRowsOverride.Load(); rows != 0 {
+		return int(rows)
+	}
+	return maxMemoryRows
+}
+
+// getWarnMemoryRows returns the number of rows held in memory above which
+// a warning is returned.
+func getWarnMemoryRows() int {
+	if rows := warnMemoryRowsOverride.Load(); rows != 0 {
+		return int(rows)
+	}
+	return warnMemoryRows
+}
+
+// applyVTGateConfig applies the dynamic configuration of vtgate, each time the
+// VSchema is reloaded. A nil configuration restores the values of the flags.
+func (e *Executor) applyVTGateConfig(config *vschemapb.VTGateConfig) {
+	queryTimeoutOverride.Store(config.GetQueryTimeoutMs())
+	maxMemoryRowsOverride.Store(config.GetMaxMemoryRows())
+	warnMemoryRowsOverride.Store(config.GetWarnMemoryRows())
+
+	if gw := e.scatterConn.gateway; gw != nil && gw.buffer != nil {
+		gw.buffer.SetDurations(time.Duration(config.GetBufferWindowMs())*time.Millisecond, time.Duration(config.GetBufferMaxFailoverDurationMs())*time.Millisecond)
+	}
+
+	if sc := e.scatterConn.concurrency; sc != nil {
+		max := int(config.GetScatterMaxConcurrency())
+		if max == 0 {
+			max = scatterMaxConcurrency
+		}
+		sc.setMax(max)
+	} else if config.GetScatterMaxConcurrency() != 0 {
+		log.Warningf("Ignoring the scatter_max_concurrency of the vtgate config, --scatter-max-concurrency must be set to limit the concurrency of the scatter queries")
+	}
+
+	if version := config.GetVersion(); version != vtgateConfigVersion.Get() {
+		vtgateConfigVersion.Set(version)
+		log.Infof("Applied the version %d of the vtgate config: %v", version, config)
+	}
+}
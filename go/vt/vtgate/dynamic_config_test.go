@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestExecutorVTGateConfig(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	saveVTGateConfig := func(config *vschemapb.VTGateConfig) {
+		vschema := *executor.VSchema()
+		vschema.VTGateConfig = config
+		executor.SaveVSchema(&vschema, executor.VSchemaStats())
+	}
+	defer saveVTGateConfig(nil)
+
+	saveVTGateConfig(&vschemapb.VTGateConfig{
+		Version:        3,
+		QueryTimeoutMs: 1500,
+		MaxMemoryRows:  3,
+		WarnMemoryRows: 2,
+	})
+	assert.Equal(t, 1500, getQueryTimeout())
+	assert.Equal(t, 3, getMaxMemoryRows())
+	assert.Equal(t, 2, getWarnMemoryRows())
+	assert.EqualValues(t, 3, vtgateConfigVersion.Get())
+
+	session := NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "int64"), "1", "2", "3", "4")})
+	_, err := executor.Execute(ctx, nil, "TestExecutorVTGateConfig", session, "select * from main1", nil)
+	require.EqualError(t, err, "in-memory row count exceeded allowed limit of 3")
+
+	// The fields which aren't set keep the values of the flags.
+	saveVTGateConfig(&vschemapb.VTGateConfig{Version: 4, MaxMemoryRows: 5})
+	assert.Equal(t, queryTimeout, getQueryTimeout())
+	assert.Equal(t, 5, getMaxMemoryRows())
+	assert.Equal(t, warnMemoryRows, getWarnMemoryRows())
+
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "int64"), "1", "2", "3", "4")})
+	_, err = executor.Execute(ctx, nil, "TestExecutorVTGateConfig", session, "select * from main1", nil)
+	require.NoError(t, err)
+
+	saveVTGateConfig(nil)
+	assert.Equal(t, maxMemoryRows, getMaxMemoryRows())
+	assert.Zero(t, vtgateConfigVersion.Get())
+}
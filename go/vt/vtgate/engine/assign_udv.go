@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var _ Primitive = (*AssignUDV)(nil)
+
+// AssignUDV is a primitive that assigns the user defined variables of `@var := expr`
+// select expressions. The results of its input are passed through unchanged, and each
+// variable is set to its column value in the last row, like MySQL does.
+type AssignUDV struct {
+	Names   []string
+	Offsets []int
+	Input   Primitive
+}
+
+// RouteType implements the Primitive interface
+func (a *AssignUDV) RouteType() string {
+	return a.Input.RouteType()
+}
+
+// GetKeyspaceName implements the Primitive interface
+func (a *AssignUDV) GetKeyspaceName() string {
+	return a.Input.GetKeyspaceName()
+}
+
+// GetTableName implements the Primitive interface
+func (a *AssignUDV) GetTableName() string {
+	return a.Input.GetTableName()
+}
+
+// NeedsTransaction implements the Primitive interface
+func (a *AssignUDV) NeedsTransaction() bool {
+	return a.Input.NeedsTransaction()
+}
+
+// TryExecute implements the Primitive interface
+func (a *AssignUDV) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	qr, err := vcursor.ExecutePrimitive(ctx, a.Input, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) > 0 {
+		if err := a.assign(vcursor, qr.Rows[len(qr.Rows)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return qr, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (a *AssignUDV) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	var lastRow sqltypes.Row
+	err := vcursor.StreamExecutePrimitive(ctx, a.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
+		if len(qr.Rows) > 0 {
+			lastRow = qr.Rows[len(qr.Rows)-1]
+		}
+		return callback(qr)
+	})
+	if err != nil {
+		return err
+	}
+	if lastRow == nil {
+		return nil
+	}
+	return a.assign(vcursor, lastRow)
+}
+
+func (a *AssignUDV) assign(vcursor VCursor, row sqltypes.Row) error {
+	for i, name := range a.Names {
+		if err := vcursor.Session().SetUDV(name, row[a.Offsets[i]]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetFields implements the Primitive interface
+func (a *AssignUDV) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return a.Input.GetFields(ctx, vcursor, bindVars)
+}
+
+// Inputs implements the Primitive interface
+func (a *AssignUDV) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{a.Input}, nil
+}
+
+// description implements the Primitive interface
+func (a *AssignUDV) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "AssignUDV",
+		Other: map[string]any{
+			"Variables": a.Names,
+			"Offsets":   a.Offsets,
+		},
+	}
+}
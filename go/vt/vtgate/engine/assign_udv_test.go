@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestAssignUDV(t *testing.T) {
+	input := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|@x := name", "int64|varchar"),
+		"1|a",
+		"2|b",
+	)
+	fp := &fakePrimitive{results: []*sqltypes.Result{input}}
+	assign := &AssignUDV{
+		Names:   []string{"x"},
+		Offsets: []int{1},
+		Input:   fp,
+	}
+
+	vc := &loggingVCursor{}
+	qr, err := assign.TryExecute(context.Background(), vc, nil, true)
+	require.NoError(t, err)
+	expectResult(t, qr, input)
+	vc.ExpectLog(t, []string{`UDV set with (x,VARCHAR("b"))`})
+
+	vc.Rewind()
+	fp.rewind()
+	qr, err = wrapStreamExecute(assign, vc, nil, true)
+	require.NoError(t, err)
+	expectResult(t, qr, input)
+	vc.ExpectLog(t, []string{`UDV set with (x,VARCHAR("b"))`})
+
+	// no rows: the variables keep their value
+	vc.Rewind()
+	fp.results = []*sqltypes.Result{sqltypes.MakeTestResult(input.Fields)}
+	fp.rewind()
+	_, err = assign.TryExecute(context.Background(), vc, nil, true)
+	require.NoError(t, err)
+	vc.ExpectLog(t, nil)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+var _ Primitive = (*CreateSequence)(nil)
+
+// CreateSequence creates a Vitess sequence table for a CREATE SEQUENCE statement.
+// The table is created first, then its single row is initialized, and
+// finally the table is added to the VSchema as a sequence.
+type CreateSequence struct {
+	noTxNeeded
+
+	Keyspace *vindexes.Keyspace
+
+	// Name is the name of the sequence table.
+	Name string
+
+	// Table creates the sequence table.
+	Table *Send
+
+	// Init inserts the row of the sequence table.
+	Init *Send
+
+	// VSchema adds the sequence to the VSchema.
+	// It is nil when the sequence is already part of the VSchema.
+	VSchema *AlterVSchema
+}
+
+// RouteType implements the Primitive interface
+func (c *CreateSequence) RouteType() string {
+	return "CreateSequence"
+}
+
+// GetKeyspaceName implements the Primitive interface
+func (c *CreateSequence) GetKeyspaceName() string {
+	return c.Keyspace.Name
+}
+
+// GetTableName implements the Primitive interface
+func (c *CreateSequence) GetTableName() string {
+	return c.Name
+}
+
+// TryExecute implements the Primitive interface
+func (c *CreateSequence) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	// Commit any open transaction before creating the sequence, like any other ddl query.
+	if err := vcursor.Session().Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := vcursor.ExecutePrimitive(ctx, c.Table, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := vcursor.ExecutePrimitive(ctx, c.Init, bindVars, false); err != nil {
+		return nil, err
+	}
+	if c.VSchema != nil {
+		if _, err := vcursor.ExecutePrimitive(ctx, c.VSchema, bindVars, false); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (c *CreateSequence) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	result, err := c.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(result)
+}
+
+// GetFields implements the Primitive interface
+func (c *CreateSequence) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return nil, vterrors.VT13001("GetFields is not supported for CreateSequence")
+}
+
+// Inputs implements the Primitive interface
+func (c *CreateSequence) Inputs() ([]Primitive, []map[string]any) {
+	inputs := []Primitive{c.Table, c.Init}
+	if c.VSchema != nil {
+		inputs = append(inputs, c.VSchema)
+	}
+	return inputs, nil
+}
+
+func (c *CreateSequence) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "CreateSequence",
+		Keyspace:     c.Keyspace,
+	}
+}
@@ -30,6 +30,10 @@ import (
 
 var _ Primitive = (*DDL)(nil)
 
+// TempTablePin is the keyspace id the temporary tables of sharded keyspaces are pinned to.
+// They are all created on the shard owning it, on the reserved connection of the session.
+var TempTablePin = []byte{0}
+
 // DDL represents a DDL statement, either normal or online DDL
 type DDL struct {
 	noTxNeeded
@@ -91,7 +95,20 @@ func (ddl *DDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[st
 	if ddl.CreateTempTable {
 		vcursor.Session().HasCreatedTempTable()
 		vcursor.Session().NeedsReservedConn()
-		return vcursor.ExecutePrimitive(ctx, ddl.NormalDDL, bindVars, wantfields)
+		qr, err := vcursor.ExecutePrimitive(ctx, ddl.NormalDDL, bindVars, wantfields)
+		ks := ddl.NormalDDL.Keyspace
+		if err != nil || !ks.Sharded {
+			return qr, err
+		}
+		switch ddl.DDL.GetAction() {
+		case sqlparser.CreateDDLAction:
+			vcursor.Session().SetTempTable(ks.Name, ddl.DDL.GetTable().Name.String(), true)
+		case sqlparser.DropDDLAction:
+			for _, table := range ddl.DDL.GetFromTables() {
+				vcursor.Session().SetTempTable(ks.Name, table.Name.String(), false)
+			}
+		}
+		return qr, nil
 	}
 
 	// Commit any open transaction before executing the ddl query.
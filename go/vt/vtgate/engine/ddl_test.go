@@ -67,7 +67,7 @@ func TestDDLTempTable(t *testing.T) {
 				Name:    "ks",
 				Sharded: true,
 			},
-			TargetDestination: key.DestinationAllShards{},
+			TargetDestination: key.DestinationKeyspaceID(TempTablePin),
 			Query:             "ddl query",
 		},
 	}
@@ -79,7 +79,8 @@ func TestDDLTempTable(t *testing.T) {
 	vc.ExpectLog(t, []string{
 		"temp table getting created",
 		"Needs Reserved Conn",
-		"ResolveDestinations ks [] Destinations:DestinationAllShards()",
-		"ExecuteMultiShard false false",
+		"ResolveDestinations ks [] Destinations:DestinationKeyspaceID(00)",
+		"ExecuteMultiShard ks.-20: ddl query {} false false",
+		"temp table ks.a exists: true",
 	})
 }
@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"sync"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var _ Primitive = (*Export)(nil)
+
+// Export writes the result of its input to a file, for a SELECT ... INTO OUTFILE or DUMPFILE
+// that cannot be sent to a single unsharded keyspace. The rows are streamed from the
+// shards and formatted by vtgate, and the file is written by an export sink.
+type Export struct {
+	// FileName is the name of the file, whose scheme chooses the sink it is written to.
+	FileName string
+
+	// Overwrite replaces an existing file, for INTO OUTFILE S3 ... OVERWRITE ON.
+	Overwrite bool
+
+	// Dumpfile writes a single row without any formatting, for INTO DUMPFILE.
+	Dumpfile bool
+
+	// Options is the format of the file for INTO OUTFILE.
+	Options *sqlparser.ExportOptions
+
+	Input Primitive
+}
+
+// RouteType implements the Primitive interface
+func (e *Export) RouteType() string {
+	return e.Input.RouteType()
+}
+
+// GetKeyspaceName implements the Primitive interface
+func (e *Export) GetKeyspaceName() string {
+	return e.Input.GetKeyspaceName()
+}
+
+// GetTableName implements the Primitive interface
+func (e *Export) GetTableName() string {
+	return e.Input.GetTableName()
+}
+
+// TryExecute implements the Primitive interface
+func (e *Export) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	file, err := vcursor.CreateExportFile(ctx, e.FileName, e.Overwrite)
+	if err != nil {
+		return nil, err
+	}
+	maxRows, maxBytes := vcursor.ExportLimits()
+	ew := &exportWriter{
+		Export:   e,
+		w:        bufio.NewWriter(file),
+		maxRows:  maxRows,
+		maxBytes: maxBytes,
+	}
+
+	var mu sync.Mutex
+	err = vcursor.StreamExecutePrimitive(ctx, e.Input, bindVars, true, func(qr *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return ew.write(qr)
+	})
+	if err == nil {
+		err = ew.flush()
+	}
+	if err != nil {
+		_ = file.Abort()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{RowsAffected: ew.rows}, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (e *Export) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	result, err := e.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(result)
+}
+
+// GetFields implements the Primitive interface
+func (e *Export) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{}, nil
+}
+
+// Inputs implements the Primitive interface
+func (e *Export) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{e.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (e *Export) NeedsTransaction() bool {
+	return e.Input.NeedsTransaction()
+}
+
+func (e *Export) description() PrimitiveDescription {
+	variant := "Outfile"
+	if e.Dumpfile {
+		variant = "Dumpfile"
+	}
+	other := map[string]any{
+		"FileName": e.FileName,
+	}
+	if e.Overwrite {
+		other["Overwrite"] = true
+	}
+	return PrimitiveDescription{
+		OperatorType: "Export",
+		Variant:      variant,
+		Other:        other,
+	}
+}
+
+// exportWriter formats the rows of an export the way MySQL does for INTO OUTFILE and DUMPFILE.
+type exportWriter struct {
+	*Export
+	w *bufio.Writer
+
+	fields        []*querypb.Field
+	headerWritten bool
+	rows, bytes   uint64
+
+	maxRows, maxBytes int64
+
+	buf []byte
+}
+
+func (ew *exportWriter) write(qr *sqltypes.Result) error {
+	if ew.fields == nil && len(qr.Fields) != 0 {
+		ew.fields = qr.Fields
+	}
+	if err := ew.writeHeader(); err != nil {
+		return err
+	}
+	for _, row := range qr.Rows {
+		ew.rows++
+		if ew.maxRows > 0 && ew.rows > uint64(ew.maxRows) {
+			return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "SELECT ... INTO OUTFILE exceeded the limit of %d rows, see --select-into-outfile-max-rows", ew.maxRows)
+		}
+		if ew.Dumpfile && ew.rows > 1 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Result consisted of more than one row")
+		}
+		if err := ew.writeBuf(ew.formatRow(ew.buf[:0], row, false)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHeader writes the names of the columns, once they are known, for FORMAT CSV HEADER.
+func (ew *exportWriter) writeHeader() error {
+	if ew.Dumpfile || !ew.Options.Header || ew.headerWritten || ew.fields == nil {
+		return nil
+	}
+	ew.headerWritten = true
+	row := make(sqltypes.Row, 0, len(ew.fields))
+	for _, field := range ew.fields {
+		row = append(row, sqltypes.NewVarChar(field.Name))
+	}
+	return ew.writeBuf(ew.formatRow(ew.buf[:0], row, true))
+}
+
+func (ew *exportWriter) writeBuf(buf []byte) error {
+	ew.buf = buf
+	ew.bytes += uint64(len(buf))
+	if ew.maxBytes > 0 && ew.bytes > uint64(ew.maxBytes) {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "SELECT ... INTO OUTFILE exceeded the limit of %d bytes, see --select-into-outfile-max-bytes", ew.maxBytes)
+	}
+	_, err := ew.w.Write(buf)
+	return err
+}
+
+func (ew *exportWriter) flush() error {
+	if err := ew.writeHeader(); err != nil {
+		return err
+	}
+	return ew.w.Flush()
+}
+
+func (ew *exportWriter) formatRow(buf []byte, row sqltypes.Row, header bool) []byte {
+	if ew.Dumpfile {
+		for _, v := range row {
+			buf = append(buf, v.Raw()...)
+		}
+		return buf
+	}
+
+	opts := ew.Options
+	buf = append(buf, opts.LinesStartingBy...)
+	for i, v := range row {
+		if i > 0 {
+			buf = append(buf, opts.FieldsTerminatedBy...)
+		}
+		if v.IsNull() {
+			if opts.FieldsEscapedBy != "" {
+				buf = append(buf, opts.FieldsEscapedBy...)
+				buf = append(buf, 'N')
+			} else {
+				buf = append(buf, "NULL"...)
+			}
+			continue
+		}
+		enclose := opts.FieldsEnclosedBy != "" && (!opts.FieldsOptionallyEnclosed || header || ew.isStringColumn(i, v))
+		if enclose {
+			buf = append(buf, opts.FieldsEnclosedBy...)
+		}
+		buf = ew.escape(buf, v.Raw())
+		if enclose {
+			buf = append(buf, opts.FieldsEnclosedBy...)
+		}
+	}
+	return append(buf, opts.LinesTerminatedBy...)
+}
+
+// isStringColumn returns true if the column is enclosed with FIELDS OPTIONALLY ENCLOSED BY.
+func (ew *exportWriter) isStringColumn(col int, v sqltypes.Value) bool {
+	typ := v.Type()
+	if col < len(ew.fields) {
+		typ = ew.fields[col].Type
+	}
+	return sqltypes.IsTextOrBinary(typ) || typ == sqltypes.Enum || typ == sqltypes.Set
+}
+
+// escape writes the value with the escape character in front of the escape character, the enclosing
+// character, and, if there is no enclosing character, the first characters of the terminators.
+// A NUL byte is written as the escape character followed by 0.
+func (ew *exportWriter) escape(buf []byte, raw []byte) []byte {
+	opts := ew.Options
+	if opts.FieldsEscapedBy == "" {
+		return append(buf, raw...)
+	}
+	special := []byte{opts.FieldsEscapedBy[0]}
+	if opts.FieldsEnclosedBy != "" {
+		special = append(special, opts.FieldsEnclosedBy[0])
+	} else {
+		if opts.FieldsTerminatedBy != "" {
+			special = append(special, opts.FieldsTerminatedBy[0])
+		}
+		if opts.LinesTerminatedBy != "" {
+			special = append(special, opts.LinesTerminatedBy[0])
+		}
+	}
+	for _, c := range raw {
+		switch {
+		case c == 0:
+			buf = append(buf, opts.FieldsEscapedBy[0], '0')
+		case bytes.IndexByte(special, c) >= 0:
+			buf = append(buf, opts.FieldsEscapedBy[0], c)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
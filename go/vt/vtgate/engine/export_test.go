@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestExport(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	defaults := &sqlparser.ExportOptions{
+		FieldsTerminatedBy: "\t",
+		FieldsEscapedBy:    "\\",
+		LinesTerminatedBy:  "\n",
+	}
+	csv := &sqlparser.ExportOptions{
+		FieldsTerminatedBy:       ",",
+		FieldsEnclosedBy:         `"`,
+		FieldsOptionallyEnclosed: true,
+		FieldsEscapedBy:          "\\",
+		LinesTerminatedBy:        "\n",
+		Header:                   true,
+	}
+
+	tcases := []struct {
+		name     string
+		export   *Export
+		results  []*sqltypes.Result
+		maxRows  int64
+		maxBytes int64
+		expected string
+		err      string
+	}{{
+		name:   "default options",
+		export: &Export{FileName: "x.txt", Options: defaults},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|a\tb", "null|c\\d"),
+			sqltypes.MakeTestResult(fields, "3|\x00"),
+		},
+		expected: "1\ta\\\tb\n\\N\tc\\\\d\n3\t\\0\n",
+	}, {
+		name:   "csv with header",
+		export: &Export{FileName: "x.csv", Options: csv},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, `1|a"b`, "2|c,d"),
+		},
+		expected: "\"id\",\"name\"\n1,\"a\\\"b\"\n2,\"c,d\"\n",
+	}, {
+		name:   "header without rows",
+		export: &Export{FileName: "x.csv", Options: csv},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields),
+		},
+		expected: "\"id\",\"name\"\n",
+	}, {
+		name: "enclosed without escaping",
+		export: &Export{FileName: "x.txt", Options: &sqlparser.ExportOptions{
+			FieldsTerminatedBy: "|",
+			FieldsEnclosedBy:   "'",
+			LinesStartingBy:    "> ",
+			LinesTerminatedBy:  ";",
+		}},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|a", "null|b"),
+		},
+		expected: "> '1'|'a';> NULL|'b';",
+	}, {
+		name:   "dumpfile",
+		export: &Export{FileName: "x.bin", Dumpfile: true},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|a\tb"),
+		},
+		expected: "1a\tb",
+	}, {
+		name:   "dumpfile with more than one row",
+		export: &Export{FileName: "x.bin", Dumpfile: true},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|a", "2|b"),
+		},
+		err: "Result consisted of more than one row",
+	}, {
+		name:   "too many rows",
+		export: &Export{FileName: "x.txt", Options: defaults},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|a", "2|b"),
+			sqltypes.MakeTestResult(fields, "3|c"),
+		},
+		maxRows: 2,
+		err:     "SELECT ... INTO OUTFILE exceeded the limit of 2 rows, see --select-into-outfile-max-rows",
+	}, {
+		name:   "too many bytes",
+		export: &Export{FileName: "x.txt", Options: defaults},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|a", "2|b"),
+		},
+		maxBytes: 6,
+		err:      "SELECT ... INTO OUTFILE exceeded the limit of 6 bytes, see --select-into-outfile-max-bytes",
+	}}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			vc := &loggingVCursor{exportMaxRows: tc.maxRows, exportMaxBytes: tc.maxBytes}
+			tc.export.Input = &fakePrimitive{results: tc.results, allResultsInOneCall: true}
+
+			r, err := tc.export.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+			vc.ExpectLog(t, []string{"CreateExportFile " + tc.export.FileName + " overwrite: false"})
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				assert.True(t, vc.exportFile.aborted)
+				assert.False(t, vc.exportFile.closed)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, vc.exportFile.closed)
+			assert.Equal(t, tc.expected, vc.exportFile.String())
+			rows := 0
+			for _, result := range tc.results {
+				rows += len(result.Rows)
+			}
+			assert.EqualValues(t, rows, r.RowsAffected)
+		})
+	}
+}
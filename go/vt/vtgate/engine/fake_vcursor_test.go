@@ -38,6 +38,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/exportsink"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -48,6 +49,10 @@ import (
 
 var testMaxMemoryRows = 100
 var testIgnoreMaxMemoryRows = false
+var testCTEMaxRecursionDepth = 1000
+var testForeignKeyCascadeBatchSize = 1000
+var testSpillDir string
+var testSpillMaxBytes int64
 
 var _ VCursor = (*noopVCursor)(nil)
 var _ SessionActions = (*noopVCursor)(nil)
@@ -215,6 +220,14 @@ func (t *noopVCursor) GetSessionEnableSystemSettings() bool {
 	panic("implement me")
 }
 
+func (t *noopVCursor) SetScatterErrorsAsWarnings(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (t *noopVCursor) GetScatterErrorsAsWarnings() bool {
+	return false
+}
+
 func (t *noopVCursor) CanUseSetVar() bool {
 	panic("implement me")
 }
@@ -227,10 +240,18 @@ func (t *noopVCursor) SetSessionTrackGTIDs(b bool) {
 	panic("implement me")
 }
 
+func (t *noopVCursor) SetReadYourWrites(b bool) {
+	panic("implement me")
+}
+
 func (t *noopVCursor) HasCreatedTempTable() {
 	panic("implement me")
 }
 
+func (t *noopVCursor) SetTempTable(keyspace, table string, exists bool) {
+	panic("implement me")
+}
+
 func (t *noopVCursor) LookupRowLockShardSession() vtgatepb.CommitOrder {
 	panic("implement me")
 }
@@ -337,6 +358,26 @@ func (t *noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t *noopVCursor) CTEMaxRecursionDepth() int {
+	return testCTEMaxRecursionDepth
+}
+
+func (t *noopVCursor) ForeignKeyCascadeBatchSize() int {
+	return testForeignKeyCascadeBatchSize
+}
+
+func (t *noopVCursor) ExportLimits() (int64, int64) {
+	return 0, 0
+}
+
+func (t *noopVCursor) SpillLimits() (string, int64) {
+	return testSpillDir, testSpillMaxBytes
+}
+
+func (t *noopVCursor) CreateExportFile(ctx context.Context, fileName string, overwrite bool) (exportsink.File, error) {
+	panic("implement me")
+}
+
 func (t *noopVCursor) GetKeyspace() string {
 	return ""
 }
@@ -421,12 +462,39 @@ type loggingVCursor struct {
 	shardSession []*srvtopo.ResolvedShard
 
 	parser *sqlparser.Parser
+
+	exportFile                    *fakeExportFile
+	exportMaxRows, exportMaxBytes int64
+
+	scatterErrorsAsWarnings bool
+
+	memoryUsage map[Primitive]*MemoryUsage
+}
+
+// fakeExportFile keeps the content of an export file in memory.
+type fakeExportFile struct {
+	bytes.Buffer
+	closed, aborted bool
+}
+
+func (f *fakeExportFile) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeExportFile) Abort() error {
+	f.aborted = true
+	return nil
 }
 
 func (f *loggingVCursor) HasCreatedTempTable() {
 	f.log = append(f.log, "temp table getting created")
 }
 
+func (f *loggingVCursor) SetTempTable(keyspace, table string, exists bool) {
+	f.log = append(f.log, fmt.Sprintf("temp table %s.%s exists: %v", keyspace, table, exists))
+}
+
 func (f *loggingVCursor) Commit(_ context.Context) error {
 	f.log = append(f.log, "commit")
 	return nil
@@ -449,6 +517,32 @@ func (f *loggingVCursor) ExecutePrimitiveStandalone(ctx context.Context, primiti
 	return primitive.TryExecute(ctx, f, bindVars, wantfields)
 }
 
+func (f *loggingVCursor) ExportLimits() (int64, int64) {
+	return f.exportMaxRows, f.exportMaxBytes
+}
+
+func (f *loggingVCursor) CreateExportFile(ctx context.Context, fileName string, overwrite bool) (exportsink.File, error) {
+	f.log = append(f.log, fmt.Sprintf("CreateExportFile %s overwrite: %v", fileName, overwrite))
+	if f.exportFile == nil {
+		f.exportFile = &fakeExportFile{}
+	}
+	return f.exportFile, nil
+}
+
+func (f *loggingVCursor) RecordMemoryUsage(primitive Primitive, usage MemoryUsage) {
+	if f.memoryUsage == nil {
+		f.memoryUsage = make(map[Primitive]*MemoryUsage)
+	}
+	if f.memoryUsage[primitive] == nil {
+		f.memoryUsage[primitive] = &MemoryUsage{}
+	}
+	f.memoryUsage[primitive].Merge(usage)
+}
+
+func (f *loggingVCursor) GetVExplainMemoryUsage() map[Primitive]*MemoryUsage {
+	return f.memoryUsage
+}
+
 func (f *loggingVCursor) StreamExecutePrimitive(ctx context.Context, primitive Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	return primitive.TryStreamExecute(ctx, f, bindVars, wantfields, callback)
 }
@@ -760,6 +854,15 @@ func (f *loggingVCursor) SetSkipQueryPlanCache(context.Context, bool) error {
 	panic("implement me")
 }
 
+func (f *loggingVCursor) SetScatterErrorsAsWarnings(_ context.Context, enabled bool) error {
+	f.scatterErrorsAsWarnings = enabled
+	return nil
+}
+
+func (f *loggingVCursor) GetScatterErrorsAsWarnings() bool {
+	return f.scatterErrorsAsWarnings
+}
+
 func (f *loggingVCursor) SetSQLSelectLimit(int64) error {
 	panic("implement me")
 }
@@ -830,6 +933,14 @@ func (t *noopVCursor) GetVExplainLogs() []ExecuteEntry {
 func (t *noopVCursor) GetLogs() ([]ExecuteEntry, error) {
 	return nil, nil
 }
+func (t *noopVCursor) RecordMemoryUsage(Primitive, MemoryUsage) {}
+func (t *noopVCursor) GetVExplainMemoryUsage() map[Primitive]*MemoryUsage {
+	return nil
+}
+func (t *noopVCursor) VExplainTracing() {}
+func (t *noopVCursor) GetVExplainTrace() map[Primitive]*PrimitiveStats {
+	return nil
+}
 
 func expectResult(t *testing.T, result, want *sqltypes.Result) {
 	t.Helper()
@@ -115,31 +115,40 @@ func (fkc *FkCascade) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 }
 
 func (fkc *FkCascade) executeLiteralExprFkChild(ctx context.Context, vcursor VCursor, in map[string]*querypb.BindVariable, wantfields bool, selectionRes *sqltypes.Result, child *FkChild, isStreaming bool) error {
-	bindVars := maps.Clone(in)
-	// We create a bindVariable that stores the tuple of columns involved in the fk constraint.
-	bv := &querypb.BindVariable{
-		Type: querypb.Type_TUPLE,
+	// The rows are cascaded to the child in batches, so that the size
+	// of the child query stays bounded for large parent modifications.
+	batchSize := vcursor.ForeignKeyCascadeBatchSize()
+	if batchSize <= 0 {
+		batchSize = len(selectionRes.Rows)
 	}
-	for _, row := range selectionRes.Rows {
-		var tupleValues []sqltypes.Value
+	for start := 0; start < len(selectionRes.Rows); start += batchSize {
+		end := min(start+batchSize, len(selectionRes.Rows))
+		bindVars := maps.Clone(in)
+		// We create a bindVariable that stores the tuple of columns involved in the fk constraint.
+		bv := &querypb.BindVariable{
+			Type: querypb.Type_TUPLE,
+		}
+		for _, row := range selectionRes.Rows[start:end] {
+			var tupleValues []sqltypes.Value
 
-		for _, colIdx := range child.Cols {
-			tupleValues = append(tupleValues, row[colIdx])
+			for _, colIdx := range child.Cols {
+				tupleValues = append(tupleValues, row[colIdx])
+			}
+			bv.Values = append(bv.Values, sqltypes.TupleToProto(tupleValues))
+		}
+		// Execute the child primitive, and bail out incase of failure.
+		// Since this Primitive is always executed in a transaction, the changes should
+		// be rolled back incase of an error.
+		bindVars[child.BVName] = bv
+		var err error
+		if isStreaming {
+			err = vcursor.StreamExecutePrimitive(ctx, child.Exec, bindVars, wantfields, func(result *sqltypes.Result) error { return nil })
+		} else {
+			_, err = vcursor.ExecutePrimitive(ctx, child.Exec, bindVars, wantfields)
+		}
+		if err != nil {
+			return err
 		}
-		bv.Values = append(bv.Values, sqltypes.TupleToProto(tupleValues))
-	}
-	// Execute the child primitive, and bail out incase of failure.
-	// Since this Primitive is always executed in a transaction, the changes should
-	// be rolled back incase of an error.
-	bindVars[child.BVName] = bv
-	var err error
-	if isStreaming {
-		err = vcursor.StreamExecutePrimitive(ctx, child.Exec, bindVars, wantfields, func(result *sqltypes.Result) error { return nil })
-	} else {
-		_, err = vcursor.ExecutePrimitive(ctx, child.Exec, bindVars, wantfields)
-	}
-	if err != nil {
-		return err
 	}
 	return nil
 }
@@ -18,7 +18,9 @@ package engine
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,6 +35,10 @@ import (
 
 var _ Primitive = (*HashJoin)(nil)
 
+// hashJoinPartitionCount is the number of partitions to which the rows of a hash
+// join are spilled.
+const hashJoinPartitionCount = 16
+
 type (
 	// HashJoin specifies the parameters for a join primitive
 	// Hash joins work by fetch all the input from the LHS, and building a hash map, known as the probe table, for this input.
@@ -82,6 +88,18 @@ type (
 		hasher         vthash.Hasher
 		sqlmode        evalengine.SQLMode
 		values         *evalengine.EnumSetValues
+
+		// rows and bytes are the number and size of the rows of the LHS in the table
+		rows  int
+		bytes int64
+	}
+
+	// hashJoinPartitions are the temporary files to which the rows of both sides of
+	// a hash join are spilled, partitioned by the hash of their join column, so
+	// that the partitions can be joined one by one.
+	hashJoinPartitions struct {
+		pt          *hashJoinProbeTable
+		left, right []*spillFile
 	}
 
 	probeTableEntry struct {
@@ -107,6 +125,8 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 		}
 	}
 
+	vcursor.Session().RecordMemoryUsage(hj, MemoryUsage{Rows: pt.rows, Bytes: pt.bytes})
+
 	rresult, err := vcursor.ExecutePrimitive(ctx, hj.Right, bindVars, wantfields)
 	if err != nil {
 		return nil, err
@@ -135,6 +155,13 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	// build the probe table from the LHS result
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+
+	// When the rows of the LHS exceed the maximum number of in-memory rows, the rows
+	// of both sides are spilled to partitions, which are joined at the end.
+	spill := newSpiller(vcursor)
+	defer spill.close(vcursor, hj)
+	var parts *hashJoinPartitions
+
 	var lfields []*querypb.Field
 	var mu sync.Mutex
 	err := vcursor.StreamExecutePrimitive(ctx, hj.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
@@ -144,16 +171,30 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			lfields = result.Fields
 		}
 		for _, current := range result.Rows {
-			err := pt.addLeftRow(current)
+			var err error
+			if parts != nil {
+				err = parts.addLeftRow(current)
+			} else {
+				err = pt.addLeftRow(current)
+			}
 			if err != nil {
 				return err
 			}
 		}
+		spill.usage.track(pt.rows, pt.bytes)
+		if parts == nil && spill.enabled() && vcursor.ExceedsMaxMemoryRows(pt.rows) {
+			var err error
+			parts, err = newHashJoinPartitions(spill, pt)
+			return err
+		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+	if parts != nil {
+		return hj.streamPartitions(ctx, vcursor, bindVars, wantfields, lfields, parts, callback)
+	}
 
 	var sendFields atomic.Bool
 	sendFields.Store(wantfields)
@@ -201,6 +242,107 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 	return nil
 }
 
+// streamPartitions spills the rows of the RHS to the partitions of the rows of the
+// LHS, and then joins the partitions one by one.
+func (hj *HashJoin) streamPartitions(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, lfields []*querypb.Field, parts *hashJoinPartitions, callback func(*sqltypes.Result) error) error {
+	var rfields []*querypb.Field
+	var mu sync.Mutex
+	err := vcursor.StreamExecutePrimitive(ctx, hj.Right, bindVars, wantfields, func(result *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(rfields) == 0 && len(result.Fields) != 0 {
+			rfields = result.Fields
+		}
+		for _, current := range result.Rows {
+			if err := parts.addRightRow(current); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if wantfields {
+		if len(rfields) == 0 {
+			rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
+			if err != nil {
+				return err
+			}
+			rfields = rres.Fields
+		}
+		if err := callback(&sqltypes.Result{Fields: joinFields(lfields, rfields, hj.Cols)}); err != nil {
+			return err
+		}
+	}
+	for i := range parts.left {
+		if err := hj.joinPartition(vcursor, parts, i, callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinPartition joins the rows of both sides of a partition, using a probe table
+// built from the rows of its LHS.
+func (hj *HashJoin) joinPartition(vcursor VCursor, parts *hashJoinPartitions, i int, callback func(*sqltypes.Result) error) error {
+	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	lr, err := parts.left[i].reader()
+	if err != nil {
+		return err
+	}
+	for {
+		row, err := lr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := pt.addLeftRow(row); err != nil {
+			return err
+		}
+	}
+	parts.left[i].s.usage.track(pt.rows, pt.bytes)
+	if vcursor.ExceedsMaxMemoryRows(pt.rows) {
+		return fmt.Errorf("in-memory row count of a partition of the hash join exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+	}
+
+	rr, err := parts.right[i].reader()
+	if err != nil {
+		return err
+	}
+	res := &sqltypes.Result{}
+	for {
+		row, err := rr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		matches, err := pt.get(row)
+		if err != nil {
+			return err
+		}
+		res.Rows = append(res.Rows, matches...)
+		if len(res.Rows) >= spillBatchSize {
+			if err := callback(res); err != nil {
+				return err
+			}
+			res = &sqltypes.Result{}
+		}
+	}
+	if hj.Opcode == LeftJoin {
+		res.Rows = append(res.Rows, pt.notFetched()...)
+	}
+	if len(res.Rows) != 0 {
+		return callback(res)
+	}
+	return nil
+}
+
 // RouteType implements the Primitive interface
 func (hj *HashJoin) RouteType() string {
 	return "HashJoin"
@@ -286,6 +428,8 @@ func (pt *hashJoinProbeTable) addLeftRow(r sqltypes.Row) error {
 		row:  r,
 		next: pt.innerMap[hash],
 	}
+	pt.rows++
+	pt.bytes += rowSize(r)
 
 	return nil
 }
@@ -330,3 +474,62 @@ func (pt *hashJoinProbeTable) notFetched() (rows []sqltypes.Row) {
 	}
 	return
 }
+
+// partition returns the partition of a value of the join column.
+func (pt *hashJoinProbeTable) partition(val sqltypes.Value, partitions int) (int, error) {
+	hash, err := pt.hash(val)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.LittleEndian.Uint64(hash[:8]) % uint64(partitions)), nil
+}
+
+// newHashJoinPartitions creates the partitions of a hash join, and moves the rows
+// of the probe table to them.
+func newHashJoinPartitions(spill *spiller, pt *hashJoinProbeTable) (*hashJoinPartitions, error) {
+	parts := &hashJoinPartitions{pt: pt}
+	for i := 0; i < hashJoinPartitionCount; i++ {
+		left, err := spill.create()
+		if err != nil {
+			return nil, err
+		}
+		right, err := spill.create()
+		if err != nil {
+			return nil, err
+		}
+		parts.left = append(parts.left, left)
+		parts.right = append(parts.right, right)
+	}
+	for _, e := range pt.innerMap {
+		for ; e != nil; e = e.next {
+			if err := parts.addLeftRow(e.row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	pt.innerMap = map[vthash.Hash]*probeTableEntry{}
+	pt.rows = 0
+	pt.bytes = 0
+	return parts, nil
+}
+
+func (parts *hashJoinPartitions) addLeftRow(r sqltypes.Row) error {
+	i, err := parts.pt.partition(r[parts.pt.lhsKey], len(parts.left))
+	if err != nil {
+		return err
+	}
+	return parts.left[i].write(r)
+}
+
+func (parts *hashJoinPartitions) addRightRow(r sqltypes.Row) error {
+	val := r[parts.pt.rhsKey]
+	// NULL values never match, so the rows aren't needed.
+	if val.IsNull() {
+		return nil
+	}
+	i, err := parts.pt.partition(val, len(parts.right))
+	if err != nil {
+		return err
+	}
+	return parts.right[i].write(r)
+}
@@ -159,6 +159,17 @@ func TestHashJoinVariations(t *testing.T) {
 			require.NoError(t, err)
 			expectResultAnyOrder(t, r, expected)
 		})
+		t.Run("Spilling "+tc.name, func(t *testing.T) {
+			dir := enableTestSpill(t, 2, 1024)
+			jn.Left = first()
+			jn.Right = last()
+			vc := &loggingVCursor{}
+			r, err := wrapStreamExecute(jn, vc, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResultAnyOrder(t, r, expected)
+			require.Equal(t, 2*hashJoinPartitionCount, vc.memoryUsage[jn].SpillFiles)
+			requireNoSpillFiles(t, dir)
+		})
 	}
 }
 
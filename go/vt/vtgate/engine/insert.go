@@ -58,6 +58,10 @@ type Insert struct {
 
 	// Alias represents the row alias with columns if specified in the query.
 	Alias string
+
+	// Returning is the RETURNING clause of the sharded insert plans,
+	// if specified in the query.
+	Returning string
 }
 
 // newQueryInsert creates an Insert with a query string.
@@ -290,7 +294,7 @@ func (ins *Insert) getInsertShardedQueries(
 				}
 			}
 		}
-		rewritten := ins.Prefix + strings.Join(mids, ",") + ins.Alias + sqlparser.String(ins.Suffix)
+		rewritten := ins.Prefix + strings.Join(mids, ",") + ins.Alias + sqlparser.String(ins.Suffix) + ins.Returning
 		queries[i] = &querypb.BoundQuery{
 			Sql:           rewritten,
 			BindVariables: shardBindVars,
@@ -373,7 +377,7 @@ func (ins *Insert) description() PrimitiveDescription {
 		for _, n := range ins.Mid {
 			mids = append(mids, sqlparser.String(n))
 		}
-		shardedQuery := ins.Prefix + strings.Join(mids, ", ") + ins.Alias + sqlparser.String(ins.Suffix)
+		shardedQuery := ins.Prefix + strings.Join(mids, ", ") + ins.Alias + sqlparser.String(ins.Suffix) + ins.Returning
 		if shardedQuery != ins.Query {
 			other["ActualQuery"] = shardedQuery
 		}
@@ -77,6 +77,10 @@ type (
 		// ColVindexes are the vindexes that will use the VindexValues
 		ColVindexes []*vindexes.ColumnVindex
 
+		// Pinned is the keyspace id of the pinned table of a sharded keyspace the rows
+		// are inserted into. The query is sent as is to the shard owning it.
+		Pinned []byte
+
 		// Prefix, Suffix are for sharded insert plans.
 		Prefix string
 		Suffix sqlparser.OnDup
@@ -148,7 +152,11 @@ func (ic *InsertCommon) GetFields(context.Context, VCursor, map[string]*querypb.
 }
 
 func (ins *InsertCommon) executeUnshardedTableQuery(ctx context.Context, vcursor VCursor, loggingPrimitive Primitive, bindVars map[string]*querypb.BindVariable, query string, insertID uint64) (*sqltypes.Result, error) {
-	rss, _, err := vcursor.ResolveDestinations(ctx, ins.Keyspace.Name, nil, []key.Destination{key.DestinationAllShards{}})
+	var dest key.Destination = key.DestinationAllShards{}
+	if ins.Pinned != nil {
+		dest = key.DestinationKeyspaceID(ins.Pinned)
+	}
+	rss, _, err := vcursor.ResolveDestinations(ctx, ins.Keyspace.Name, nil, []key.Destination{dest})
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,7 @@ package engine
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -96,7 +97,7 @@ func (ins *InsertSelect) TryExecute(ctx context.Context, vcursor VCursor, bindVa
 	ctx, cancelFunc := addQueryTimeout(ctx, vcursor, ins.QueryTimeout)
 	defer cancelFunc()
 
-	if ins.Keyspace.Sharded {
+	if ins.Keyspace.Sharded && ins.Pinned == nil {
 		return ins.execInsertSharded(ctx, vcursor, bindVars)
 	}
 	return ins.execInsertUnsharded(ctx, vcursor, bindVars)
@@ -351,6 +352,9 @@ func (ic *InsertCommon) commonDesc() map[string]any {
 		"InputAsNonStreaming":  ic.ForceNonStreaming,
 		"NoAutoCommit":         ic.PreventAutoCommit,
 	}
+	if ic.Pinned != nil {
+		other["Pinned"] = hex.EncodeToString(ic.Pinned)
+	}
 
 	if ic.Generate != nil {
 		if ic.Generate.Values == nil {
@@ -79,6 +79,11 @@ func (ms *MemorySort) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 	if err = ms.OrderBy.SortResult(result); err != nil {
 		return nil, err
 	}
+	usage := MemoryUsage{Rows: len(result.Rows)}
+	for _, row := range result.Rows {
+		usage.Bytes += rowSize(row)
+	}
+	vcursor.Session().RecordMemoryUsage(ms, usage)
 	if len(result.Rows) > count {
 		result.Rows = result.Rows[:count]
 	}
@@ -98,10 +103,20 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 		return callback(qr.Truncate(ms.TruncateColumnCount))
 	}
 
-	sorter := &evalengine.Sorter{
-		Compare: ms.OrderBy,
-		Limit:   count,
+	newSorter := func() *evalengine.Sorter {
+		return &evalengine.Sorter{
+			Compare: ms.OrderBy,
+			Limit:   count,
+		}
 	}
+	sorter := newSorter()
+	var sorterBytes int64
+
+	// When the sorted rows exceed the maximum number of in-memory rows, they are
+	// spilled as a sorted run, and the runs are merged at the end.
+	spill := newSpiller(vcursor)
+	defer spill.close(vcursor, ms)
+	var runs []*spillFile
 
 	var mu sync.Mutex
 	err = vcursor.StreamExecutePrimitive(ctx, ms.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
@@ -113,17 +128,36 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 			}
 		}
 		for _, row := range qr.Rows {
+			n := sorter.Len()
 			sorter.Push(row)
+			if sorter.Len() > n {
+				sorterBytes += rowSize(row)
+			}
 		}
+		spill.usage.track(sorter.Len(), sorterBytes)
 		if vcursor.ExceedsMaxMemoryRows(sorter.Len()) {
-			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			if !spill.enabled() {
+				return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			}
+			run, err := spill.writeRun(sorter.Sorted())
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+			sorter = newSorter()
+			sorterBytes = 0
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
-	return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	if len(runs) == 0 {
+		return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	}
+	return mergeRuns(ms.OrderBy, runs, sorter.Sorted(), count, func(rows []sqltypes.Row) error {
+		return cb(&sqltypes.Result{Rows: rows})
+	})
 }
 
 // GetFields satisfies the Primitive interface.
@@ -26,6 +26,8 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 )
 
@@ -611,6 +613,117 @@ func TestMemorySortMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestMemorySortSpill(t *testing.T) {
+	dir := enableTestSpill(t, 2, 1024)
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|int64",
+	)
+	newMemorySort := func() *MemorySort {
+		return &MemorySort{
+			OrderBy: []evalengine.OrderByParams{{
+				WeightStringCol: -1,
+				Col:             1,
+			}},
+			Input: &fakePrimitive{
+				results: []*sqltypes.Result{sqltypes.MakeTestResult(
+					fields,
+					"a|5",
+					"b|2",
+					"c|7",
+					"d|1",
+					"e|null",
+					"f|4",
+					"g|3",
+					"h|6",
+				)},
+			},
+		}
+	}
+
+	vc := &loggingVCursor{}
+	ms := newMemorySort()
+	result, err := wrapStreamExecute(ms, vc, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields,
+		"e|null",
+		"d|1",
+		"b|2",
+		"g|3",
+		"f|4",
+		"a|5",
+		"h|6",
+		"c|7",
+	), result)
+	requireNoSpillFiles(t, dir)
+	// The rows are spilled by runs of 4.
+	usage := vc.memoryUsage[ms]
+	require.Equal(t, 4, usage.Rows)
+	require.Equal(t, 8, usage.SpilledRows)
+	require.Equal(t, 2, usage.SpillFiles)
+
+	ms = newMemorySort()
+	ms.UpperLimit = evalengine.NewLiteralInt(5)
+	result, err = wrapStreamExecute(ms, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields,
+		"e|null",
+		"d|1",
+		"b|2",
+		"g|3",
+		"f|4",
+	), result)
+
+	// An ordered aggregation of the rows sorted by vtgate.
+	oa := &OrderedAggregate{
+		Aggregates:  []*AggregateParams{NewAggregateParam(opcode.AggregateCount, 0, "", collations.MySQL8())},
+		GroupByKeys: []*GroupByParams{{KeyCol: 1, WeightStringCol: -1, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)}},
+		Input:       newMemorySort(),
+	}
+	result, err = wrapStreamExecute(oa, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 8)
+
+	testSpillMaxBytes = 10
+	_, err = wrapStreamExecute(newMemorySort(), &noopVCursor{}, nil, true)
+	require.EqualError(t, err, "spilled rows exceeded allowed limit of 10 bytes")
+	requireNoSpillFiles(t, dir)
+}
+
+func TestMemorySortVExplainMemoryUsage(t *testing.T) {
+	enableTestSpill(t, 2, 1024)
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|int64",
+	)
+	ms := &MemorySort{
+		OrderBy: []evalengine.OrderByParams{{
+			WeightStringCol: -1,
+			Col:             1,
+		}},
+		Input: &fakePrimitive{
+			results: []*sqltypes.Result{sqltypes.MakeTestResult(
+				fields,
+				"a|3",
+				"b|2",
+				"c|1",
+			)},
+		},
+	}
+	vexplain := &VExplain{Input: ms, Type: sqlparser.AllVExplainType}
+	result, err := wrapStreamExecute(vexplain, &loggingVCursor{}, nil, true)
+	require.NoError(t, err)
+	require.Contains(t, result.Rows[0][0].ToString(), `"MemoryUsage": {
+		"Rows": 3,
+		"Bytes": 6,
+		"SpilledRows": 3,
+		"SpilledBytes": 27,
+		"SpillFiles": 1
+	}`)
+}
+
 func TestMemorySortExecuteNoVarChar(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"c1|c2",
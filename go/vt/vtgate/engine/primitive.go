@@ -26,6 +26,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/exportsink"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -56,6 +57,27 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// CTEMaxRecursionDepth returns the maximum number of iterations
+		// of the recursive part of a recursive common table expression.
+		CTEMaxRecursionDepth() int
+
+		// ForeignKeyCascadeBatchSize returns the maximum number of parent rows
+		// whose values are cascaded to a child table in a single query.
+		ForeignKeyCascadeBatchSize() int
+
+		// ExportLimits returns the maximum number of rows and bytes
+		// of the file written by SELECT ... INTO OUTFILE or DUMPFILE.
+		ExportLimits() (maxRows, maxBytes int64)
+
+		// SpillLimits returns the directory of the temporary files to which the sorts,
+		// hash joins and window functions spill the rows exceeding the maximum number
+		// of in-memory rows, and the maximum size of the files of a primitive. The rows
+		// are not spilled if maxBytes is 0.
+		SpillLimits() (dir string, maxBytes int64)
+
+		// CreateExportFile creates the file written by SELECT ... INTO OUTFILE or DUMPFILE.
+		CreateExportFile(ctx context.Context, fileName string, overwrite bool) (exportsink.File, error)
+
 		Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		AutocommitApproval() bool
 
@@ -176,6 +198,9 @@ type (
 		SetSessionEnableSystemSettings(context.Context, bool) error
 		GetSessionEnableSystemSettings() bool
 
+		SetScatterErrorsAsWarnings(context.Context, bool) error
+		GetScatterErrorsAsWarnings() bool
+
 		GetSystemVariables(func(k string, v string))
 		HasSystemVariables() bool
 
@@ -183,9 +208,13 @@ type (
 		SetReadAfterWriteGTID(string)
 		SetReadAfterWriteTimeout(float64)
 		SetSessionTrackGTIDs(bool)
+		// SetReadYourWrites makes the reads of the session only go to the replicas that applied its writes.
+		SetReadYourWrites(bool)
 
 		// HasCreatedTempTable will mark the session as having created temp tables
 		HasCreatedTempTable()
+		// SetTempTable records that the temporary table exists, or not anymore, in the sharded keyspace
+		SetTempTable(keyspace, table string, exists bool)
 		GetWarnings() []*querypb.QueryWarning
 
 		// AnyAdvisoryLockTaken returns true of any advisory lock is taken
@@ -202,6 +231,20 @@ type (
 		// GetVExplainLogs retrieves the vttablet interaction logs
 		GetVExplainLogs() []ExecuteEntry
 
+		// RecordMemoryUsage records the memory used by an execution of a primitive,
+		// when VEXPLAIN logging is enabled
+		RecordMemoryUsage(primitive Primitive, usage MemoryUsage)
+
+		// GetVExplainMemoryUsage retrieves the memory used by the primitives
+		GetVExplainMemoryUsage() map[Primitive]*MemoryUsage
+
+		// VExplainTracing enables the collection of the execution statistics
+		// of the primitives so VEXPLAIN TRACE can report them
+		VExplainTracing()
+
+		// GetVExplainTrace retrieves the execution statistics of the primitives
+		GetVExplainTrace() map[Primitive]*PrimitiveStats
+
 		// SetCommitOrder sets the commit order for the shard session in respect of the type of vindex lookup.
 		// This is used to select the right shard session to perform the vindex lookup query.
 		SetCommitOrder(co vtgatepb.CommitOrder)
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var _ Primitive = (*RecurseCTE)(nil)
+
+// RecurseCTE evaluates a recursive common table expression.
+// The Seed is executed once, and its rows are the first rows of the result.
+// The Term is then executed once for every row produced by the previous
+// iteration, with the values of that row sent as bind variables, until an
+// iteration produces no rows.
+type RecurseCTE struct {
+	// Seed is the non-recursive part of the CTE, which produces the initial rows.
+	Seed Primitive
+
+	// Term is the recursive part of the CTE, which is executed for every row
+	// produced by the previous iteration.
+	Term Primitive
+
+	// Vars defines the bind variables that are sent to the Term,
+	// and the columns of the previous rows that they are built from.
+	Vars map[string]int
+}
+
+// RouteType returns a description of the query routing type used by the primitive
+func (r *RecurseCTE) RouteType() string {
+	return "RecurseCTE"
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (r *RecurseCTE) GetKeyspaceName() string {
+	if r.Seed.GetKeyspaceName() == r.Term.GetKeyspaceName() {
+		return r.Seed.GetKeyspaceName()
+	}
+	return r.Seed.GetKeyspaceName() + "_" + r.Term.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (r *RecurseCTE) GetTableName() string {
+	return r.Seed.GetTableName()
+}
+
+// TryExecute implements the Primitive interface
+func (r *RecurseCTE) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	seedRes, err := vcursor.ExecutePrimitive(ctx, r.Seed, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	res := &sqltypes.Result{Fields: seedRes.Fields, Rows: slices.Clone(seedRes.Rows)}
+
+	// recurseRows contains the rows used in the next recursion
+	recurseRows := seedRes.Rows
+	joinVars := make(map[string]*querypb.BindVariable)
+	for iteration := 1; len(recurseRows) > 0; iteration++ {
+		if err := checkRecursionDepth(vcursor, iteration); err != nil {
+			return nil, err
+		}
+
+		var newRows []sqltypes.Row
+		for _, row := range recurseRows {
+			for k, col := range r.Vars {
+				joinVars[k] = sqltypes.ValueBindVariable(row[col])
+			}
+			rresult, err := vcursor.ExecutePrimitive(ctx, r.Term, combineVars(bindVars, joinVars), false)
+			if err != nil {
+				return nil, err
+			}
+			newRows = append(newRows, rresult.Rows...)
+		}
+
+		res.Rows = append(res.Rows, newRows...)
+		if vcursor.ExceedsMaxMemoryRows(len(res.Rows)) {
+			return nil, fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		}
+		recurseRows = newRows
+	}
+
+	return res, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (r *RecurseCTE) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	// recurseRows contains the rows used in the next recursion
+	var recurseRows []sqltypes.Row
+	err := vcursor.StreamExecutePrimitive(ctx, r.Seed, bindVars, wantfields, func(result *sqltypes.Result) error {
+		recurseRows = append(recurseRows, result.Rows...)
+		return callback(result)
+	})
+	if err != nil {
+		return err
+	}
+
+	joinVars := make(map[string]*querypb.BindVariable)
+	for iteration := 1; len(recurseRows) > 0; iteration++ {
+		if err := checkRecursionDepth(vcursor, iteration); err != nil {
+			return err
+		}
+
+		var newRows []sqltypes.Row
+		for _, row := range recurseRows {
+			for k, col := range r.Vars {
+				joinVars[k] = sqltypes.ValueBindVariable(row[col])
+			}
+			err := vcursor.StreamExecutePrimitive(ctx, r.Term, combineVars(bindVars, joinVars), false, func(result *sqltypes.Result) error {
+				if len(result.Rows) == 0 {
+					return nil
+				}
+				newRows = append(newRows, result.Rows...)
+				return callback(&sqltypes.Result{Rows: result.Rows})
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if vcursor.ExceedsMaxMemoryRows(len(newRows)) {
+			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		}
+		recurseRows = newRows
+	}
+	return nil
+}
+
+// checkRecursionDepth returns an error if the recursion has done more
+// iterations than allowed
+func checkRecursionDepth(vcursor VCursor, iteration int) error {
+	if maxDepth := vcursor.CTEMaxRecursionDepth(); iteration > maxDepth {
+		return vterrors.NewErrorf(vtrpcpb.Code_ABORTED, vterrors.CTEMaxRecursionDepth, "Recursive query aborted after %d iterations. Try increasing the cte-max-recursion-depth flag to a larger value.", maxDepth)
+	}
+	return nil
+}
+
+// GetFields implements the Primitive interface
+func (r *RecurseCTE) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return r.Seed.GetFields(ctx, vcursor, bindVars)
+}
+
+// NeedsTransaction implements the Primitive interface
+func (r *RecurseCTE) NeedsTransaction() bool {
+	return r.Seed.NeedsTransaction() || r.Term.NeedsTransaction()
+}
+
+// Inputs implements the Primitive interface
+func (r *RecurseCTE) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{r.Seed, r.Term}, nil
+}
+
+func (r *RecurseCTE) description() PrimitiveDescription {
+	other := map[string]any{}
+	if len(r.Vars) > 0 {
+		other["JoinVars"] = orderedStringIntMap(r.Vars)
+	}
+
+	return PrimitiveDescription{
+		OperatorType: "RecurseCTE",
+		Other:        other,
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func recurseCTETestInputs() (*fakePrimitive, *fakePrimitive) {
+	fields := sqltypes.MakeTestFields("id|parent", "int64|int64")
+	seed := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "1|null")},
+	}
+	term := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "2|1", "3|1"),
+			sqltypes.MakeTestResult(fields, "4|2"),
+			sqltypes.MakeTestResult(fields),
+			sqltypes.MakeTestResult(fields),
+		},
+	}
+	return seed, term
+}
+
+func TestRecurseCTEExecute(t *testing.T) {
+	seed, term := recurseCTETestInputs()
+	cte := &RecurseCTE{
+		Seed: seed,
+		Term: term,
+		Vars: map[string]int{"id": 0},
+	}
+
+	bv := map[string]*querypb.BindVariable{
+		"a": sqltypes.Int64BindVariable(10),
+	}
+	r, err := cte.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+
+	seed.ExpectLog(t, []string{
+		`Execute a: type:INT64 value:"10" true`,
+	})
+	term.ExpectLog(t, []string{
+		`Execute a: type:INT64 value:"10" id: type:INT64 value:"1" false`,
+		`Execute a: type:INT64 value:"10" id: type:INT64 value:"2" false`,
+		`Execute a: type:INT64 value:"10" id: type:INT64 value:"3" false`,
+		`Execute a: type:INT64 value:"10" id: type:INT64 value:"4" false`,
+	})
+
+	wantRes := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|parent", "int64|int64"),
+		"1|null",
+		"2|1",
+		"3|1",
+		"4|2",
+	)
+	expectResult(t, r, wantRes)
+}
+
+func TestRecurseCTEStreamExecute(t *testing.T) {
+	seed, term := recurseCTETestInputs()
+	cte := &RecurseCTE{
+		Seed: seed,
+		Term: term,
+		Vars: map[string]int{"id": 0},
+	}
+
+	r, err := wrapStreamExecute(cte, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+
+	wantRes := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|parent", "int64|int64"),
+		"1|null",
+		"2|1",
+		"3|1",
+		"4|2",
+	)
+	expectResult(t, r, wantRes)
+}
+
+func TestRecurseCTEMaxRecursionDepth(t *testing.T) {
+	saveMax := testCTEMaxRecursionDepth
+	testCTEMaxRecursionDepth = 2
+	defer func() {
+		testCTEMaxRecursionDepth = saveMax
+	}()
+
+	fields := sqltypes.MakeTestFields("n", "int64")
+	newCTE := func() *RecurseCTE {
+		return &RecurseCTE{
+			Seed: &fakePrimitive{
+				results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "1")},
+			},
+			Term: &fakePrimitive{
+				results: []*sqltypes.Result{
+					sqltypes.MakeTestResult(fields, "2"),
+					sqltypes.MakeTestResult(fields, "3"),
+					sqltypes.MakeTestResult(fields, "4"),
+				},
+			},
+			Vars: map[string]int{"n": 0},
+		}
+	}
+
+	_, err := newCTE().TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.EqualError(t, err, "Recursive query aborted after 2 iterations. Try increasing the cte-max-recursion-depth flag to a larger value.")
+
+	_, err = wrapStreamExecute(newCTE(), &noopVCursor{}, nil, false)
+	require.EqualError(t, err, "Recursive query aborted after 2 iterations. Try increasing the cte-max-recursion-depth flag to a larger value.")
+}
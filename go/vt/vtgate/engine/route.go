@@ -95,6 +95,10 @@ type Route struct {
 	// select count(*) from tbl where lookupColumn = 'not there'
 	// select exists(<subq>)
 	NoRoutesSpecialHandling bool
+
+	// ExpressionIndexes are the expression indexes that the predicates of the query match.
+	// They are only used to describe the plan.
+	ExpressionIndexes []string
 }
 
 // NewRoute creates a Route.
@@ -216,7 +220,7 @@ func (route *Route) executeShards(
 
 	if errs != nil {
 		errs = filterOutNilErrors(errs)
-		if !route.ScatterErrorsAsWarnings || len(errs) == len(rss) {
+		if !route.scatterErrorsAsWarnings(vcursor) || len(errs) == len(rss) {
 			return nil, vterrors.Aggregate(errs)
 		}
 
@@ -307,7 +311,7 @@ func (route *Route) streamExecuteShards(
 			return callback(qr.Truncate(route.TruncateColumnCount))
 		})
 		if len(errs) > 0 {
-			if !route.ScatterErrorsAsWarnings || len(errs) == len(rss) {
+			if !route.scatterErrorsAsWarnings(vcursor) || len(errs) == len(rss) {
 				return vterrors.Aggregate(errs)
 			}
 			partialSuccessScatterQueries.Add(1)
@@ -323,6 +327,12 @@ func (route *Route) streamExecuteShards(
 	return route.mergeSort(ctx, vcursor, bindVars, wantfields, callback, rss, bvs)
 }
 
+// scatterErrorsAsWarnings returns true if the results of the shards that succeeded are returned when
+// other shards fail, as asked by the query comment directive or by the session.
+func (route *Route) scatterErrorsAsWarnings(vcursor VCursor) bool {
+	return route.ScatterErrorsAsWarnings || vcursor.Session().GetScatterErrorsAsWarnings()
+}
+
 func (route *Route) mergeSort(
 	ctx context.Context,
 	vcursor VCursor,
@@ -344,7 +354,7 @@ func (route *Route) mergeSort(
 	ms := MergeSort{
 		Primitives:              prims,
 		OrderBy:                 route.OrderBy,
-		ScatterErrorsAsWarnings: route.ScatterErrorsAsWarnings,
+		ScatterErrorsAsWarnings: route.scatterErrorsAsWarnings(vcursor),
 	}
 	return vcursor.StreamExecutePrimitive(ctx, &ms, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		return callback(qr.Truncate(route.TruncateColumnCount))
@@ -443,6 +453,9 @@ func (route *Route) description() PrimitiveDescription {
 	if route.QueryTimeout > 0 {
 		other["QueryTimeout"] = route.QueryTimeout
 	}
+	if len(route.ExpressionIndexes) > 0 {
+		other["ExpressionIndexes"] = route.ExpressionIndexes
+	}
 	return PrimitiveDescription{
 		OperatorType:      "Route",
 		Variant:           route.Opcode.String(),
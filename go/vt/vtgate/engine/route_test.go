@@ -1436,6 +1436,39 @@ func TestExecFail(t *testing.T) {
 		require.NoError(t, err, "unexpected ScatterErrorsAsWarnings error %v", err)
 		vc.ExpectWarnings(t, []*querypb.QueryWarning{{Code: uint32(sqlerror.ERQueryInterrupted), Message: "query timeout -20 (errno 1317) (sqlstate HY000)"}})
 	})
+
+	t.Run("session ScatterErrorsAsWarnings", func(t *testing.T) {
+		// Scatter succeeds if one of N fails when the session asks for partial results
+		sel := NewRoute(
+			Scatter,
+			&vindexes.Keyspace{
+				Name:    "ks",
+				Sharded: true,
+			},
+			"dummy_select",
+			"dummy_select_field",
+		)
+
+		vc := &loggingVCursor{
+			shards:  []string{"-20", "20-"},
+			results: []*sqltypes.Result{defaultSelectResult},
+			multiShardErrs: []error{
+				sqlerror.NewSQLError(sqlerror.ERQueryInterrupted, "", "query timeout -20"),
+				nil,
+			},
+			scatterErrorsAsWarnings: true,
+		}
+		result, err := sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		expectResult(t, result, defaultSelectResult)
+		vc.ExpectWarnings(t, []*querypb.QueryWarning{{Code: uint32(sqlerror.ERQueryInterrupted), Message: "query timeout -20 (errno 1317) (sqlstate HY000)"}})
+
+		// all the shards failing is still an error
+		vc.Rewind()
+		vc.multiShardErrs = []error{errors.New("result error -20"), errors.New("result error 20-")}
+		_, err = sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.ErrorContains(t, err, "result error -20")
+	})
 }
 
 func TestSelectEqualUniqueMultiColumnVindex(t *testing.T) {
@@ -1477,6 +1510,56 @@ func TestSelectEqualUniqueMultiColumnVindex(t *testing.T) {
 	expectResult(t, result, defaultSelectResult)
 }
 
+func TestSelectRangeMultiColumnVindex(t *testing.T) {
+	vindex, _ := vindexes.CreateVindex("multicol", "", map[string]string{
+		"column_count":  "2",
+		"column_bytes":  "1,7",
+		"column_vindex": "numeric,hash",
+	})
+	sel := NewRoute(
+		Range,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex
+	sel.Values = []evalengine.Expr{
+		evalengine.NewLiteralUint(0x1000000000000000),
+		evalengine.NewLiteralUint(0x1fffffffffffffff),
+	}
+
+	vc := &loggingVCursor{
+		shards:       []string{"-20", "20-"},
+		shardForKsid: []string{"-20"},
+		results:      []*sqltypes.Result{defaultSelectResult},
+	}
+	result, err := sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(10-20)`,
+		`ExecuteMultiShard ks.-20: dummy_select {} false false`,
+	})
+	expectResult(t, result, defaultSelectResult)
+
+	// an open range is sent to all the shards covering it.
+	sel.Values = []evalengine.Expr{
+		evalengine.NewLiteralUint(0x1000000000000000),
+		evalengine.NullExpr,
+	}
+	vc.Rewind()
+	vc.shardForKsid = []string{"-20", "20-"}
+	result, err = wrapStreamExecute(sel, vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationKeyRange(10-)`,
+		`StreamExecuteMulti dummy_select ks.-20: {} ks.20-: {} `,
+	})
+	expectResult(t, result, defaultSelectResult)
+}
+
 func TestSelectEqualMultiColumnVindex(t *testing.T) {
 	vindex, _ := vindexes.CreateVindex("region_experimental", "", map[string]string{"region_bytes": "1"})
 	vc := &loggingVCursor{
@@ -71,6 +71,9 @@ const (
 	// Is used when the query explicitly sets a target destination:
 	// in the clause e.g: UPDATE `keyspace[-]`.x1 SET foo=1
 	ByDestination
+	// Range is for routing a query with a range predicate on the leading column of a vindex.
+	// Requires: A RangeMappable Vindex, and two Values: the start and the end of the range.
+	Range
 )
 
 var opName = map[Opcode]string{
@@ -86,6 +89,7 @@ var opName = map[Opcode]string{
 	None:          "None",
 	ByDestination: "ByDestination",
 	SubShard:      "SubShard",
+	Range:         "Range",
 }
 
 // MarshalJSON serializes the Opcode as a JSON string.
@@ -164,6 +168,8 @@ func (rp *RoutingParameters) findRoute(ctx context.Context, vcursor VCursor, bin
 		default:
 			return rp.multiEqual(ctx, vcursor, bindVars)
 		}
+	case Range:
+		return rp.valueRange(ctx, vcursor, bindVars)
 	default:
 		// Unreachable.
 		return nil, nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unsupported opcode: %v", rp.Opcode)
@@ -371,6 +377,27 @@ func (rp *RoutingParameters) equalMultiCol(ctx context.Context, vcursor VCursor,
 	return rss, multiBindVars, nil
 }
 
+func (rp *RoutingParameters) valueRange(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	rangeMappable, ok := rp.Vindex.(vindexes.RangeMappable)
+	if !ok {
+		return nil, nil, vterrors.VT13001("vindex " + rp.Vindex.String() + " cannot map a range of values")
+	}
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	start, err := env.Evaluate(rp.Values[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	end, err := env.Evaluate(rp.Values[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	destination, err := rangeMappable.MapRange(ctx, vcursor, start.Value(vcursor.ConnCollation()), end.Value(vcursor.ConnCollation()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return rp.byDestination(ctx, vcursor, bindVars, destination)
+}
+
 func (rp *RoutingParameters) in(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
 	value, err := env.Evaluate(rp.Values[0])
@@ -502,6 +502,8 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 		vcursor.Session().SetQueryTimeout(queryTimeout)
 	case sysvars.SessionEnableSystemSettings.Name:
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetSessionEnableSystemSettings)
+	case sysvars.ScatterErrorsAsWarnings.Name:
+		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetScatterErrorsAsWarnings)
 	case sysvars.Charset.Name, sysvars.Names.Name:
 		str, err := svss.evalAsString(env, vcursor)
 		if err != nil {
@@ -539,6 +541,19 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 		default:
 			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "variable 'session_track_gtids' can't be set to the value of '%s'", str)
 		}
+	case sysvars.ReadAfterWriteConsistency.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(str) {
+		case "eventual":
+			vcursor.Session().SetReadYourWrites(false)
+		case "session":
+			vcursor.Session().SetReadYourWrites(true)
+		default:
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "variable 'read_after_write_consistency' can't be set to the value of '%s'", str)
+		}
 	default:
 		return vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.UnknownSystemVariable, "unknown system variable '%s'", svss.Name)
 	}
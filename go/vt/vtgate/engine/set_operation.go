@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vthash"
+)
+
+var _ Primitive = (*SetOperation)(nil)
+
+// SetOperation evaluates an INTERSECT or an EXCEPT between two inputs that
+// could not be sent to the same shard.
+// All the rows of the Right input are fetched and hashed first, and the rows of
+// the Left input are then streamed through and compared against them.
+type SetOperation struct {
+	Opcode SetOpcode
+
+	// Distinct is false for INTERSECT ALL and EXCEPT ALL
+	Distinct bool
+
+	Left, Right Primitive
+
+	// CheckCols are the columns that are compared between the rows of the two inputs
+	CheckCols []CheckCol
+}
+
+// SetOpcode is a number representing the opcode
+// for the SetOperation primitive.
+type SetOpcode int
+
+// This is the list of SetOpcode values.
+const (
+	Intersect = SetOpcode(iota)
+	Except
+)
+
+func (code SetOpcode) String() string {
+	if code == Intersect {
+		return "Intersect"
+	}
+	return "Except"
+}
+
+// MarshalJSON serializes the SetOpcode as a JSON string.
+// It's used for testing and diagnostics.
+func (code SetOpcode) MarshalJSON() ([]byte, error) {
+	return ([]byte)(fmt.Sprintf("\"%s\"", code.String())), nil
+}
+
+// setOpProbe keeps track of the rows seen on the right-hand side,
+// and decides which rows of the left-hand side should be returned
+type setOpProbe struct {
+	pt        *probeTable
+	rightRows map[vthash.Hash]int
+	opcode    SetOpcode
+	distinct  bool
+}
+
+func (s *SetOperation) newProbe(vcursor VCursor) *setOpProbe {
+	return &setOpProbe{
+		pt:        newProbeTable(s.CheckCols, vcursor.Environment().CollationEnv()),
+		rightRows: make(map[vthash.Hash]int),
+		opcode:    s.Opcode,
+		distinct:  s.Distinct,
+	}
+}
+
+func (p *setOpProbe) addRight(rows []sqltypes.Row) error {
+	for _, row := range rows {
+		code, err := p.pt.hashCodeForRow(row)
+		if err != nil {
+			return err
+		}
+		p.rightRows[code]++
+	}
+	return nil
+}
+
+func (p *setOpProbe) filterLeft(rows []sqltypes.Row) ([]sqltypes.Row, error) {
+	var result []sqltypes.Row
+	for _, row := range rows {
+		code, err := p.pt.hashCodeForRow(row)
+		if err != nil {
+			return nil, err
+		}
+		if p.distinct {
+			if _, seen := p.pt.seenRows[code]; seen {
+				continue
+			}
+			p.pt.seenRows[code] = struct{}{}
+		}
+
+		count := p.rightRows[code]
+		if count > 0 && !p.distinct {
+			// with ALL, every row on the right-hand side can only be matched once
+			p.rightRows[code] = count - 1
+		}
+
+		matched := count > 0
+		if matched == (p.opcode == Intersect) {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
+// RouteType returns a description of the query routing type used by the primitive
+func (s *SetOperation) RouteType() string {
+	return s.Opcode.String()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (s *SetOperation) GetKeyspaceName() string {
+	return formatTwoOptionsNicely(s.Left.GetKeyspaceName(), s.Right.GetKeyspaceName())
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (s *SetOperation) GetTableName() string {
+	return formatTwoOptionsNicely(s.Left.GetTableName(), s.Right.GetTableName())
+}
+
+// TryExecute implements the Primitive interface
+func (s *SetOperation) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	rresult, err := vcursor.ExecutePrimitive(ctx, s.Right, bindVars, false)
+	if err != nil {
+		return nil, err
+	}
+	probe := s.newProbe(vcursor)
+	if err := probe.addRight(rresult.Rows); err != nil {
+		return nil, err
+	}
+
+	lresult, err := vcursor.ExecutePrimitive(ctx, s.Left, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := probe.filterLeft(lresult.Rows)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: lresult.Fields, Rows: rows}, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (s *SetOperation) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	var mu sync.Mutex
+	probe := s.newProbe(vcursor)
+
+	err := vcursor.StreamExecutePrimitive(ctx, s.Right, bindVars, false, func(result *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return probe.addRight(result.Rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	return vcursor.StreamExecutePrimitive(ctx, s.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		rows, err := probe.filterLeft(result.Rows)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 && result.Fields == nil {
+			return nil
+		}
+		return callback(&sqltypes.Result{Fields: result.Fields, Rows: rows})
+	})
+}
+
+// GetFields implements the Primitive interface.
+// Only rows from the left-hand side are returned, so the fields are the ones of the left-hand side.
+func (s *SetOperation) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return s.Left.GetFields(ctx, vcursor, bindVars)
+}
+
+// NeedsTransaction implements the Primitive interface
+func (s *SetOperation) NeedsTransaction() bool {
+	return s.Left.NeedsTransaction() || s.Right.NeedsTransaction()
+}
+
+// Inputs implements the Primitive interface
+func (s *SetOperation) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{s.Left, s.Right}, nil
+}
+
+func (s *SetOperation) description() PrimitiveDescription {
+	other := map[string]any{}
+
+	var colls []string
+	for _, checkCol := range s.CheckCols {
+		colls = append(colls, checkCol.String())
+	}
+	if colls != nil {
+		other["Collations"] = colls
+	}
+	if !s.Distinct {
+		other["All"] = true
+	}
+
+	return PrimitiveDescription{
+		OperatorType: s.RouteType(),
+		Other:        other,
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func TestSetOperation(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	left := []string{"1|a", "1|a", "1|a", "2|b", "3|c", "null|d"}
+	right := []string{"1|a", "1|a", "3|C", "4|d", "null|d"}
+
+	tcases := []struct {
+		name     string
+		opcode   SetOpcode
+		distinct bool
+		expected []string
+	}{{
+		name:     "intersect distinct",
+		opcode:   Intersect,
+		distinct: true,
+		expected: []string{"1|a", "3|c", "null|d"},
+	}, {
+		name:     "intersect all",
+		opcode:   Intersect,
+		expected: []string{"1|a", "1|a", "3|c", "null|d"},
+	}, {
+		name:     "except distinct",
+		opcode:   Except,
+		distinct: true,
+		expected: []string{"2|b"},
+	}, {
+		name:     "except all",
+		opcode:   Except,
+		expected: []string{"1|a", "2|b"},
+	}}
+
+	for _, tc := range tcases {
+		newSetOp := func() *SetOperation {
+			return &SetOperation{
+				Opcode:   tc.opcode,
+				Distinct: tc.distinct,
+				Left: &fakePrimitive{
+					results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, left...)},
+				},
+				Right: &fakePrimitive{
+					results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, right...)},
+				},
+				CheckCols: []CheckCol{
+					{Col: 0, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID), CollationEnv: collations.MySQL8()},
+					{Col: 1, Type: evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID), CollationEnv: collations.MySQL8()},
+				},
+			}
+		}
+		want := sqltypes.MakeTestResult(fields, tc.expected...)
+
+		t.Run("Execute "+tc.name, func(t *testing.T) {
+			r, err := newSetOp().TryExecute(context.Background(), &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResult(t, r, want)
+		})
+
+		t.Run("StreamExecute "+tc.name, func(t *testing.T) {
+			r, err := wrapStreamExecute(newSetOp(), &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResult(t, r, want)
+		})
+	}
+}
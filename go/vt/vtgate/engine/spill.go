@@ -0,0 +1,310 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"slices"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+// When the rows held in memory by a sort, a hash join or a window exceed the
+// maximum number of in-memory rows, they are spilled to temporary files instead
+// of failing the query, if spilling is enabled: the sort writes sorted runs of
+// rows which are merged at the end, the hash join partitions the rows of both its
+// inputs by the hash of their join column and joins the partitions one by one,
+// and the window writes the rows of a large partition and reads them back to
+// evaluate its functions. Spilling only applies to streaming executions, since
+// the inputs of the other ones are already fully held in memory.
+
+// spillBatchSize is the number of rows of the results sent after reading them back
+// from temporary files.
+const spillBatchSize = 1000
+
+// MemoryUsage is the memory used by a primitive to hold its intermediate results,
+// as reported by VEXPLAIN ALL.
+type MemoryUsage struct {
+	// Rows and Bytes are the maximum number and size of the rows held in memory.
+	Rows  int
+	Bytes int64
+	// SpilledRows and SpilledBytes are the number and size of the rows written
+	// to temporary files, and SpillFiles is the number of these files.
+	SpilledRows  int   `json:",omitempty"`
+	SpilledBytes int64 `json:",omitempty"`
+	SpillFiles   int   `json:",omitempty"`
+}
+
+// Merge adds the memory used by another execution of the same primitive.
+func (mu *MemoryUsage) Merge(other MemoryUsage) {
+	mu.Rows = max(mu.Rows, other.Rows)
+	mu.Bytes = max(mu.Bytes, other.Bytes)
+	mu.SpilledRows += other.SpilledRows
+	mu.SpilledBytes += other.SpilledBytes
+	mu.SpillFiles += other.SpillFiles
+}
+
+// track updates the maximum number and size of the rows held in memory.
+func (mu *MemoryUsage) track(rows int, bytes int64) {
+	mu.Rows = max(mu.Rows, rows)
+	mu.Bytes = max(mu.Bytes, bytes)
+}
+
+// rowSize returns the size of the values of a row.
+func rowSize(row sqltypes.Row) int64 {
+	var size int64
+	for _, value := range row {
+		size += int64(value.Len())
+	}
+	return size
+}
+
+// spiller creates the temporary files to which a primitive spills its rows, and
+// enforces the maximum size of these files.
+type spiller struct {
+	dir      string
+	maxBytes int64
+	files    []*spillFile
+	usage    MemoryUsage
+}
+
+func newSpiller(vcursor VCursor) *spiller {
+	dir, maxBytes := vcursor.SpillLimits()
+	return &spiller{dir: dir, maxBytes: maxBytes}
+}
+
+// enabled returns true if the rows can be spilled.
+func (s *spiller) enabled() bool {
+	return s.maxBytes > 0
+}
+
+// create creates a temporary file.
+func (s *spiller) create() (*spillFile, error) {
+	f, err := os.CreateTemp(s.dir, "vtgate-spill-")
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to create a temporary file to spill the rows")
+	}
+	sf := &spillFile{s: s, f: f, w: bufio.NewWriter(f)}
+	s.files = append(s.files, sf)
+	s.usage.SpillFiles++
+	return sf, nil
+}
+
+// writeRun writes rows to a new temporary file.
+func (s *spiller) writeRun(rows []sqltypes.Row) (*spillFile, error) {
+	sf, err := s.create()
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := sf.write(row); err != nil {
+			return nil, err
+		}
+	}
+	return sf, nil
+}
+
+// remove removes a temporary file before the spiller is closed.
+func (s *spiller) remove(sf *spillFile) {
+	sf.f.Close()
+	os.Remove(sf.f.Name())
+	s.files = slices.DeleteFunc(s.files, func(other *spillFile) bool { return other == sf })
+}
+
+// close removes the temporary files, and records the memory used by the primitive.
+func (s *spiller) close(vcursor VCursor, primitive Primitive) {
+	for _, sf := range s.files {
+		sf.f.Close()
+		os.Remove(sf.f.Name())
+	}
+	s.files = nil
+	vcursor.Session().RecordMemoryUsage(primitive, s.usage)
+}
+
+// spillFile is a temporary file of spilled rows. Each value is written as its
+// type, its length and its raw bytes.
+type spillFile struct {
+	s   *spiller
+	f   *os.File
+	w   *bufio.Writer
+	buf []byte
+}
+
+func (sf *spillFile) write(row sqltypes.Row) error {
+	sf.buf = binary.AppendUvarint(sf.buf[:0], uint64(len(row)))
+	for _, value := range row {
+		sf.buf = binary.AppendUvarint(sf.buf, uint64(value.Type()))
+		sf.buf = binary.AppendUvarint(sf.buf, uint64(len(value.Raw())))
+		sf.buf = append(sf.buf, value.Raw()...)
+	}
+	if sf.s.usage.SpilledBytes+int64(len(sf.buf)) > sf.s.maxBytes {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "spilled rows exceeded allowed limit of %d bytes", sf.s.maxBytes)
+	}
+	if _, err := sf.w.Write(sf.buf); err != nil {
+		return vterrors.Wrapf(err, "failed to spill the rows")
+	}
+	sf.s.usage.SpilledRows++
+	sf.s.usage.SpilledBytes += int64(len(sf.buf))
+	return nil
+}
+
+// reader returns a reader of the rows written to the file. The readers of a
+// file read it independently of each other.
+func (sf *spillFile) reader() (*spillReader, error) {
+	if err := sf.w.Flush(); err != nil {
+		return nil, vterrors.Wrapf(err, "failed to spill the rows")
+	}
+	return &spillReader{r: bufio.NewReader(io.NewSectionReader(sf.f, 0, math.MaxInt64))}, nil
+}
+
+// spillReader reads the rows of a spillFile.
+type spillReader struct {
+	r *bufio.Reader
+}
+
+// next returns the next row, or io.EOF after the last one.
+func (sr *spillReader) next() (sqltypes.Row, error) {
+	cols, err := binary.ReadUvarint(sr.r)
+	if err != nil {
+		return nil, err
+	}
+	row := make(sqltypes.Row, cols)
+	for i := range row {
+		typ, err := binary.ReadUvarint(sr.r)
+		if err != nil {
+			return nil, sr.unexpectedEOF(err)
+		}
+		size, err := binary.ReadUvarint(sr.r)
+		if err != nil {
+			return nil, sr.unexpectedEOF(err)
+		}
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(sr.r, raw); err != nil {
+			return nil, sr.unexpectedEOF(err)
+		}
+		row[i] = sqltypes.MakeTrusted(querypb.Type(typ), raw)
+	}
+	return row, nil
+}
+
+func (sr *spillReader) unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// mergeRuns merges the sorted runs of rows written to temporary files and a last
+// sorted run held in memory, and sends at most limit rows.
+func mergeRuns(cmp evalengine.Comparison, runs []*spillFile, last []sqltypes.Row, limit int, callback func([]sqltypes.Row) error) error {
+	h := &runHeap{cmp: cmp}
+	for _, run := range runs {
+		sr, err := run.reader()
+		if err != nil {
+			return err
+		}
+		if err := h.add(sr.next); err != nil {
+			return err
+		}
+	}
+	if len(last) > 0 {
+		if err := h.add(func() (sqltypes.Row, error) {
+			if len(last) == 0 {
+				return nil, io.EOF
+			}
+			row := last[0]
+			last = last[1:]
+			return row, nil
+		}); err != nil {
+			return err
+		}
+	}
+	heap.Init(h)
+
+	var batch []sqltypes.Row
+	for sent := 0; h.Len() > 0 && sent < limit; sent++ {
+		rc := h.cursors[0]
+		batch = append(batch, rc.row)
+		if len(batch) == spillBatchSize {
+			if err := callback(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+		row, err := rc.next()
+		switch {
+		case err == io.EOF:
+			heap.Pop(h)
+		case err != nil:
+			return err
+		default:
+			rc.row = row
+			heap.Fix(h, 0)
+		}
+	}
+	if len(batch) > 0 {
+		return callback(batch)
+	}
+	return nil
+}
+
+// runCursor is the current row of a sorted run.
+type runCursor struct {
+	row  sqltypes.Row
+	next func() (sqltypes.Row, error)
+}
+
+// runHeap orders the cursors of the sorted runs by their current row.
+type runHeap struct {
+	cmp     evalengine.Comparison
+	cursors []*runCursor
+}
+
+// add adds the cursor of a run, if it isn't empty.
+func (h *runHeap) add(next func() (sqltypes.Row, error)) error {
+	row, err := next()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	h.cursors = append(h.cursors, &runCursor{row: row, next: next})
+	return nil
+}
+
+func (h *runHeap) Len() int           { return len(h.cursors) }
+func (h *runHeap) Less(i, j int) bool { return h.cmp.Less(h.cursors[i].row, h.cursors[j].row) }
+func (h *runHeap) Swap(i, j int)      { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+func (h *runHeap) Push(x any)         { h.cursors = append(h.cursors, x.(*runCursor)) }
+
+func (h *runHeap) Pop() any {
+	n := len(h.cursors)
+	rc := h.cursors[n-1]
+	h.cursors = h.cursors[:n-1]
+	return rc
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+// enableTestSpill lowers the maximum number of in-memory rows and enables the
+// spilling of the rows to a temporary directory, which is returned.
+func enableTestSpill(t *testing.T, maxMemoryRows int, maxBytes int64) string {
+	saveMax, saveDir, saveBytes := testMaxMemoryRows, testSpillDir, testSpillMaxBytes
+	t.Cleanup(func() {
+		testMaxMemoryRows, testSpillDir, testSpillMaxBytes = saveMax, saveDir, saveBytes
+	})
+	testMaxMemoryRows = maxMemoryRows
+	testSpillDir = t.TempDir()
+	testSpillMaxBytes = maxBytes
+	return testSpillDir
+}
+
+// requireNoSpillFiles checks that the temporary files were removed.
+func requireNoSpillFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestSpillFile(t *testing.T) {
+	dir := enableTestSpill(t, 1, 1024)
+	rows := []sqltypes.Row{
+		{sqltypes.NewInt64(-1), sqltypes.NewVarChar("a"), sqltypes.NULL},
+		{sqltypes.NewUint64(2), sqltypes.NewVarChar(""), sqltypes.NewDecimal("1.5")},
+		{sqltypes.NewFloat64(3.25), sqltypes.NewVarBinary("\x00\x01"), sqltypes.TestValue(sqltypes.TypeJSON, `{"a": 1}`)},
+		{},
+	}
+
+	spill := newSpiller(&noopVCursor{})
+	sf, err := spill.writeRun(rows)
+	require.NoError(t, err)
+	sr, err := sf.reader()
+	require.NoError(t, err)
+	for _, want := range rows {
+		got, err := sr.next()
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+	_, err = sr.next()
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, len(rows), spill.usage.SpilledRows)
+	require.Equal(t, 1, spill.usage.SpillFiles)
+
+	spill.close(&noopVCursor{}, nil)
+	requireNoSpillFiles(t, dir)
+
+	testSpillMaxBytes = 10
+	spill = newSpiller(&noopVCursor{})
+	defer spill.close(&noopVCursor{}, nil)
+	_, err = spill.writeRun(rows)
+	require.EqualError(t, err, "spilled rows exceeded allowed limit of 10 bytes")
+}
+
+func TestMergeRuns(t *testing.T) {
+	enableTestSpill(t, 1, 1024)
+	cmp := evalengine.Comparison{{Col: 0, WeightStringCol: -1, Type: evalengine.NewType(sqltypes.Int64, 0)}}
+	row := func(i int64) sqltypes.Row { return sqltypes.Row{sqltypes.NewInt64(i)} }
+
+	spill := newSpiller(&noopVCursor{})
+	defer spill.close(&noopVCursor{}, nil)
+	run1, err := spill.writeRun([]sqltypes.Row{row(1), row(4), row(7)})
+	require.NoError(t, err)
+	run2, err := spill.writeRun([]sqltypes.Row{row(2), row(3)})
+	require.NoError(t, err)
+	empty, err := spill.writeRun(nil)
+	require.NoError(t, err)
+
+	var got []sqltypes.Row
+	err = mergeRuns(cmp, []*spillFile{run1, run2, empty}, []sqltypes.Row{row(0), row(5)}, 6, func(rows []sqltypes.Row) error {
+		got = append(got, rows...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []sqltypes.Row{row(0), row(1), row(2), row(3), row(4), row(5)}, got)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -42,6 +43,18 @@ type (
 		Input Primitive
 		Type  sqlparser.VExplainType
 	}
+
+	// PrimitiveStats are the execution statistics of a primitive collected for VEXPLAIN TRACE.
+	PrimitiveStats struct {
+		// Calls is the number of times the primitive was executed
+		Calls int
+		// Rows is the number of rows returned by the primitive
+		Rows int
+		// Bytes is the size of the values of the rows returned by the primitive
+		Bytes int64
+		// Time is the time spent executing the primitive, including its inputs
+		Time time.Duration
+	}
 )
 
 var _ Primitive = (*VExplain)(nil)
@@ -73,7 +86,7 @@ func (v *VExplain) NeedsTransaction() bool {
 
 // TryExecute implements the Primitive interface
 func (v *VExplain) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
-	vcursor.Session().VExplainLogging()
+	v.enableLogging(vcursor)
 	_, err := vcursor.ExecutePrimitive(ctx, v.Input, bindVars, wantfields)
 	if err != nil {
 		return nil, err
@@ -83,7 +96,7 @@ func (v *VExplain) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 
 // TryStreamExecute implements the Primitive interface
 func (v *VExplain) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
-	vcursor.Session().VExplainLogging()
+	v.enableLogging(vcursor)
 	err := vcursor.StreamExecutePrimitive(ctx, v.Input, bindVars, wantfields, func(result *sqltypes.Result) error {
 		return nil
 	})
@@ -97,6 +110,13 @@ func (v *VExplain) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 	return callback(result)
 }
 
+func (v *VExplain) enableLogging(vcursor VCursor) {
+	vcursor.Session().VExplainLogging()
+	if v.Type == sqlparser.TraceVExplainType {
+		vcursor.Session().VExplainTracing()
+	}
+}
+
 func (v *VExplain) convertToResult(ctx context.Context, vcursor VCursor) (*sqltypes.Result, error) {
 	switch v.Type {
 	case sqlparser.QueriesVExplainType:
@@ -104,6 +124,8 @@ func (v *VExplain) convertToResult(ctx context.Context, vcursor VCursor) (*sqlty
 		return result, nil
 	case sqlparser.AllVExplainType:
 		return v.convertToVExplainAllResult(ctx, vcursor)
+	case sqlparser.TraceVExplainType:
+		return v.convertToVExplainTraceResult(vcursor)
 	default:
 		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Unknown type of VExplain plan")
 	}
@@ -137,7 +159,27 @@ func (v *VExplain) convertToVExplainAllResult(ctx context.Context, vcursor VCurs
 		explainResults[entry.FiredFrom] = res.Rows[0][0].ToString()
 	}
 
-	planDescription := primitiveToPlanDescriptionWithSQLResults(v.Input, explainResults)
+	planDescription := primitiveToPlanDescriptionWithSQLResults(v.Input, explainResults, vcursor.Session().GetVExplainMemoryUsage())
+	return convertToVExplainJSONResult(planDescription)
+}
+
+func (v *VExplain) convertToVExplainTraceResult(vcursor VCursor) (*sqltypes.Result, error) {
+	shards := make(map[Primitive]map[string]bool)
+	for _, entry := range vcursor.Session().GetVExplainLogs() {
+		if entry.Target == nil || entry.FiredFrom == nil {
+			continue
+		}
+		if shards[entry.FiredFrom] == nil {
+			shards[entry.FiredFrom] = make(map[string]bool)
+		}
+		shards[entry.FiredFrom][entry.Target.Keyspace+"/"+entry.Target.Shard] = true
+	}
+
+	planDescription := primitiveToPlanDescriptionWithStats(v.Input, vcursor.Session().GetVExplainTrace(), shards)
+	return convertToVExplainJSONResult(planDescription)
+}
+
+func convertToVExplainJSONResult(planDescription PrimitiveDescription) (*sqltypes.Result, error) {
 	resultBytes, err := json.MarshalIndent(planDescription, "", "\t")
 	if err != nil {
 		return nil, err
@@ -162,17 +204,54 @@ func (v *VExplain) convertToVExplainAllResult(ctx context.Context, vcursor VCurs
 }
 
 // primitiveToPlanDescriptionWithSQLResults transforms a primitive tree into a corresponding PlanDescription tree
-// and adds the given res ...
-func primitiveToPlanDescriptionWithSQLResults(in Primitive, res map[Primitive]string) PrimitiveDescription {
+// and adds the given res ... and the memory used by the primitives to hold their intermediate results.
+func primitiveToPlanDescriptionWithSQLResults(in Primitive, res map[Primitive]string, memory map[Primitive]*MemoryUsage) PrimitiveDescription {
 	this := in.description()
 
 	if v, found := res[in]; found {
 		this.Other["mysql_explain_json"] = json.RawMessage(v)
 	}
+	if usage, found := memory[in]; found {
+		if this.Other == nil {
+			this.Other = map[string]any{}
+		}
+		this.Other["MemoryUsage"] = *usage
+	}
+
+	inputs, infos := in.Inputs()
+	for idx, input := range inputs {
+		pd := primitiveToPlanDescriptionWithSQLResults(input, res, memory)
+		if infos != nil {
+			for k, v := range infos[idx] {
+				if k == inputName {
+					pd.InputName = v.(string)
+					continue
+				}
+				pd.Other[k] = v
+			}
+		}
+		this.Inputs = append(this.Inputs, pd)
+	}
+
+	if len(inputs) == 0 {
+		this.Inputs = []PrimitiveDescription{}
+	}
+
+	return this
+}
+
+// primitiveToPlanDescriptionWithStats transforms a primitive tree into a corresponding PlanDescription tree,
+// annotating every primitive that was executed with its execution statistics and the shards it queried.
+func primitiveToPlanDescriptionWithStats(in Primitive, stats map[Primitive]*PrimitiveStats, shards map[Primitive]map[string]bool) PrimitiveDescription {
+	this := in.description()
+	if this.Other == nil {
+		this.Other = map[string]any{}
+	}
 
 	inputs, infos := in.Inputs()
+	rowsIn := 0
 	for idx, input := range inputs {
-		pd := primitiveToPlanDescriptionWithSQLResults(input, res)
+		pd := primitiveToPlanDescriptionWithStats(input, stats, shards)
 		if infos != nil {
 			for k, v := range infos[idx] {
 				if k == inputName {
@@ -182,9 +261,25 @@ func primitiveToPlanDescriptionWithSQLResults(in Primitive, res map[Primitive]st
 				pd.Other[k] = v
 			}
 		}
+		if s, found := stats[input]; found {
+			rowsIn += s.Rows
+		}
 		this.Inputs = append(this.Inputs, pd)
 	}
 
+	if s, found := stats[in]; found {
+		this.Other["Calls"] = s.Calls
+		if len(inputs) > 0 {
+			this.Other["RowsIn"] = rowsIn
+		}
+		this.Other["RowsOut"] = s.Rows
+		this.Other["MemoryUsed"] = s.Bytes
+		this.Other["Time"] = s.Time.String()
+	}
+	if queried, found := shards[in]; found {
+		this.Other["ShardsQueried"] = len(queried)
+	}
+
 	if len(inputs) == 0 {
 		this.Inputs = []PrimitiveDescription{}
 	}
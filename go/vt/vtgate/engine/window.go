@@ -0,0 +1,781 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/decimal"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*Window)(nil)
+
+// divPrecisionIncrement is the number of digits that are added to the scale
+// of the result of a division, like the default of div_precision_increment
+// in MySQL.
+const divPrecisionIncrement = 4
+
+// Window is a primitive that evaluates window functions over the rows of
+// its input. It expects the underlying primitive to feed the rows sorted
+// by the PartitionBy and then the OrderBy columns. The rows of a partition
+// are kept in memory until the whole partition has been read, so a single
+// partition may not have more rows than the in-memory row limit, unless
+// the execution is streaming and spilling is enabled: the rows of a larger
+// partition are then spilled to a temporary file.
+//
+// The window functions are evaluated with the default frame of MySQL: from
+// the start of the partition up to the last peer of the current row when
+// the window is ordered, and the whole partition when it is not.
+//
+// The values of the window functions are the first columns of the output,
+// followed by all the columns of the input.
+type Window struct {
+	// PartitionBy specifies the input values that the rows of a
+	// partition have in common.
+	PartitionBy []*GroupByParams
+
+	// OrderBy specifies the input values of the ORDER BY of the window.
+	// Rows that are equal on all of them are peers.
+	OrderBy []*GroupByParams
+
+	// Functions are the window functions to evaluate.
+	Functions []*WindowFunctionParams
+
+	// Input is the primitive that will feed into this Primitive.
+	Input Primitive
+
+	CollationEnv *collations.Environment
+}
+
+// WindowOpcode is the window function evaluated by a WindowFunctionParams.
+type WindowOpcode int
+
+// These constants list the window functions that can be evaluated by the
+// Window primitive.
+const (
+	WindowRowNumber = WindowOpcode(iota)
+	WindowRank
+	WindowDenseRank
+	WindowPercentRank
+	WindowCumeDist
+	WindowNtile
+	WindowLag
+	WindowLead
+	WindowFirstValue
+	WindowLastValue
+	WindowNthValue
+	WindowCount
+	WindowCountStar
+	WindowSum
+	WindowAvg
+	WindowMin
+	WindowMax
+)
+
+var windowOpcodeName = map[WindowOpcode]string{
+	WindowRowNumber:   "row_number",
+	WindowRank:        "rank",
+	WindowDenseRank:   "dense_rank",
+	WindowPercentRank: "percent_rank",
+	WindowCumeDist:    "cume_dist",
+	WindowNtile:       "ntile",
+	WindowLag:         "lag",
+	WindowLead:        "lead",
+	WindowFirstValue:  "first_value",
+	WindowLastValue:   "last_value",
+	WindowNthValue:    "nth_value",
+	WindowCount:       "count",
+	WindowCountStar:   "count_star",
+	WindowSum:         "sum",
+	WindowAvg:         "avg",
+	WindowMin:         "min",
+	WindowMax:         "max",
+}
+
+func (code WindowOpcode) String() string {
+	name := windowOpcodeName[code]
+	if name == "" {
+		name = "ERROR"
+	}
+	return name
+}
+
+// WindowFunctionParams specify the parameters of a window function.
+type WindowFunctionParams struct {
+	Opcode WindowOpcode
+
+	// Col is the input column of the argument of the function,
+	// or -1 if the function has none.
+	Col int
+
+	// N is the number of buckets of NTILE, the offset of LAG and LEAD
+	// and the row of NTH_VALUE.
+	N int64
+
+	// DefaultCol is the input column of the default value of LAG and
+	// LEAD, or -1 if the function has none.
+	DefaultCol int
+
+	// Type is the type of the argument.
+	Type evalengine.Type
+
+	Alias string `json:",omitempty"`
+}
+
+func (wf *WindowFunctionParams) String() string {
+	var args []string
+	if wf.Col >= 0 {
+		args = append(args, strconv.Itoa(wf.Col))
+	}
+	switch wf.Opcode {
+	case WindowNtile, WindowLag, WindowLead, WindowNthValue:
+		args = append(args, strconv.FormatInt(wf.N, 10))
+	}
+	if wf.DefaultCol >= 0 {
+		args = append(args, strconv.Itoa(wf.DefaultCol))
+	}
+	out := fmt.Sprintf("%s(%s)", wf.Opcode.String(), strings.Join(args, ", "))
+	if wf.Alias != "" {
+		out += " AS " + wf.Alias
+	}
+	return out
+}
+
+// field returns the field of the values of the function
+func (wf *WindowFunctionParams) field(input []*querypb.Field) *querypb.Field {
+	name := wf.Alias
+	if name == "" {
+		name = wf.String()
+	}
+
+	var typ querypb.Type
+	switch wf.Opcode {
+	case WindowRowNumber, WindowRank, WindowDenseRank, WindowNtile:
+		typ = sqltypes.Uint64
+	case WindowPercentRank, WindowCumeDist:
+		typ = sqltypes.Float64
+	case WindowCount, WindowCountStar:
+		typ = sqltypes.Int64
+	case WindowSum:
+		typ = opcode.AggregateSum.SQLType(input[wf.Col].Type)
+	case WindowAvg:
+		typ = opcode.AggregateAvg.SQLType(input[wf.Col].Type)
+	default:
+		field := input[wf.Col].CloneVT()
+		field.Name = name
+		return field
+	}
+	return &querypb.Field{Name: name, Type: typ, Charset: collations.CollationBinaryID}
+}
+
+// newAggregator returns the aggregator computing the function, for the
+// functions which are aggregations
+func (wf *WindowFunctionParams) newAggregator(input []*querypb.Field, env *collations.Environment) aggregator {
+	noDistinct := aggregatorDistinct{column: -1}
+	switch wf.Opcode {
+	case WindowCount:
+		return &aggregatorCount{from: wf.Col, distinct: noDistinct}
+	case WindowCountStar:
+		return &aggregatorCountStar{}
+	case WindowSum:
+		return &aggregatorSum{
+			from:     wf.Col,
+			sum:      evalengine.NewAggregationSum(input[wf.Col].Type),
+			distinct: noDistinct,
+		}
+	case WindowAvg:
+		return &aggregatorAvg{
+			aggregatorSum: aggregatorSum{
+				from:     wf.Col,
+				sum:      evalengine.NewAggregationSum(input[wf.Col].Type),
+				distinct: noDistinct,
+			},
+		}
+	case WindowMin:
+		return &aggregatorMin{
+			aggregatorMinMax{
+				from:   wf.Col,
+				minmax: evalengine.NewAggregationMinMax(input[wf.Col].Type, env, wf.Type.Collation(), wf.Type.Values()),
+			},
+		}
+	case WindowMax:
+		return &aggregatorMax{
+			aggregatorMinMax{
+				from:   wf.Col,
+				minmax: evalengine.NewAggregationMinMax(input[wf.Col].Type, env, wf.Type.Collation(), wf.Type.Values()),
+			},
+		}
+	}
+	return nil
+}
+
+// RouteType returns a description of the query routing type used by the primitive
+func (w *Window) RouteType() string {
+	return w.Input.RouteType()
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (w *Window) GetKeyspaceName() string {
+	return w.Input.GetKeyspaceName()
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (w *Window) GetTableName() string {
+	return w.Input.GetTableName()
+}
+
+// TryExecute is a Primitive function.
+func (w *Window) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	result, err := vcursor.ExecutePrimitive(
+		ctx,
+		w.Input,
+		bindVars,
+		true, /*wantFields - we need the input fields types to calculate the output types*/
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows are already in memory, so they are never spilled
+	state := &windowState{w: w, vcursor: vcursor, fields: result.Fields, spill: &spiller{}}
+	defer state.spill.close(vcursor, w)
+	out := &sqltypes.Result{
+		Fields: w.fields(result.Fields),
+		Rows:   make([]sqltypes.Row, 0, len(result.Rows)),
+	}
+	emit := func(rows []sqltypes.Row) error {
+		out.Rows = append(out.Rows, rows...)
+		return nil
+	}
+	for _, row := range result.Rows {
+		if err := state.add(row, emit); err != nil {
+			return nil, err
+		}
+	}
+	if err := state.flush(emit); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TryStreamExecute is a Primitive function.
+func (w *Window) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	spill := newSpiller(vcursor)
+	defer spill.close(vcursor, w)
+	var state *windowState
+
+	var out []sqltypes.Row
+	send := func() error {
+		if len(out) == 0 {
+			return nil
+		}
+		rows := out
+		out = nil
+		return callback(&sqltypes.Result{Rows: rows})
+	}
+	emit := func(rows []sqltypes.Row) error {
+		out = append(out, rows...)
+		if len(out) < spillBatchSize {
+			return nil
+		}
+		return send()
+	}
+
+	visitor := func(qr *sqltypes.Result) error {
+		if state == nil && len(qr.Fields) != 0 {
+			state = &windowState{w: w, vcursor: vcursor, fields: qr.Fields, spill: spill}
+			if err := callback(&sqltypes.Result{Fields: w.fields(qr.Fields)}); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range qr.Rows {
+			if err := state.add(row, emit); err != nil {
+				return err
+			}
+		}
+		return send()
+	}
+
+	/* we need the input fields types to calculate the output types */
+	err := vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, true, visitor)
+	if err != nil || state == nil {
+		return err
+	}
+
+	if err := state.flush(emit); err != nil {
+		return err
+	}
+	return send()
+}
+
+// GetFields is a Primitive function.
+func (w *Window) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := w.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: w.fields(qr.Fields)}, nil
+}
+
+// Inputs returns the Primitive input for this window
+func (w *Window) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (w *Window) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+func (w *Window) fields(input []*querypb.Field) []*querypb.Field {
+	fields := make([]*querypb.Field, 0, len(w.Functions)+len(input))
+	for _, fn := range w.Functions {
+		fields = append(fields, fn.field(input))
+	}
+	return append(fields, input...)
+}
+
+// equalKeys returns true if the two rows have the same values for all the keys
+func (w *Window) equalKeys(keys []*GroupByParams, row1, row2 sqltypes.Row) (bool, error) {
+	for _, key := range keys {
+		v1 := row1[key.KeyCol]
+		v2 := row2[key.KeyCol]
+		if v1.TinyWeightCmp(v2) != 0 {
+			return false, nil
+		}
+
+		cmp, err := evalengine.NullsafeCompare(v1, v2, w.CollationEnv, key.Type.Collation(), key.Type.Values())
+		if err != nil {
+			_, isCollationErr := err.(evalengine.UnsupportedCollationError)
+			if !isCollationErr || key.WeightStringCol == -1 {
+				return false, err
+			}
+			cmp, err = evalengine.NullsafeCompare(row1[key.WeightStringCol], row2[key.WeightStringCol], w.CollationEnv, key.Type.Collation(), key.Type.Values())
+			if err != nil {
+				return false, err
+			}
+		}
+		if cmp != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (w *Window) description() PrimitiveDescription {
+	other := map[string]any{
+		"Functions": GenericJoin(w.Functions, func(in any) string { return in.(*WindowFunctionParams).String() }),
+	}
+	if len(w.PartitionBy) > 0 {
+		other["PartitionBy"] = GenericJoin(w.PartitionBy, groupByParamsToString)
+	}
+	if len(w.OrderBy) > 0 {
+		other["OrderBy"] = GenericJoin(w.OrderBy, groupByParamsToString)
+	}
+	return PrimitiveDescription{
+		OperatorType: "Window",
+		Other:        other,
+	}
+}
+
+// windowState spools the rows of the current partition. When they exceed
+// the in-memory row limit and spilling is enabled, the rows of the partition
+// are written to a temporary file instead, see flushSpilled.
+type windowState struct {
+	w         *Window
+	vcursor   VCursor
+	fields    []*querypb.Field
+	partition []sqltypes.Row
+	bytes     int64
+
+	// first is the first row of the current partition.
+	first sqltypes.Row
+
+	spill *spiller
+	// spilled holds the count rows of the current partition, if they were
+	// spilled.
+	spilled *spillFile
+	count   int
+}
+
+// add adds a row to the current partition. If the row starts a new
+// partition, the rows of the previous one are emitted.
+func (ws *windowState) add(row sqltypes.Row, emit func([]sqltypes.Row) error) error {
+	if ws.first != nil {
+		same, err := ws.w.equalKeys(ws.w.PartitionBy, ws.first, row)
+		if err != nil {
+			return err
+		}
+		if !same {
+			if err := ws.flush(emit); err != nil {
+				return err
+			}
+		}
+	}
+	if ws.first == nil {
+		ws.first = row
+	}
+
+	if ws.spilled != nil {
+		ws.count++
+		return ws.spilled.write(row)
+	}
+	ws.partition = append(ws.partition, row)
+	ws.bytes += rowSize(row)
+	ws.spill.usage.track(len(ws.partition), ws.bytes)
+	if !ws.vcursor.ExceedsMaxMemoryRows(len(ws.partition)) {
+		return nil
+	}
+	if !ws.spill.enabled() {
+		return fmt.Errorf("in-memory row count exceeded allowed limit of %d", ws.vcursor.MaxMemoryRows())
+	}
+	sf, err := ws.spill.writeRun(ws.partition)
+	if err != nil {
+		return err
+	}
+	ws.spilled, ws.count = sf, len(ws.partition)
+	ws.partition, ws.bytes = nil, 0
+	return nil
+}
+
+// flush evaluates the window functions over the current partition and
+// emits its rows.
+func (ws *windowState) flush(emit func([]sqltypes.Row) error) error {
+	ws.first = nil
+	if sf := ws.spilled; sf != nil {
+		ws.spilled = nil
+		defer ws.spill.remove(sf)
+		return ws.flushSpilled(sf, ws.count, emit)
+	}
+
+	rows := ws.partition
+	ws.partition, ws.bytes = nil, 0
+	if len(rows) == 0 {
+		return nil
+	}
+
+	// peerStart and peerEnd are the bounds of the peers of each row, and
+	// groups the rank of its peers among the peers of the partition
+	n := len(rows)
+	peerStart := make([]int, n)
+	peerEnd := make([]int, n)
+	groups := make([]int, n)
+	start := 0
+	for i := 1; i <= n; i++ {
+		if i < n {
+			same, err := ws.w.equalKeys(ws.w.OrderBy, rows[i-1], rows[i])
+			if err != nil {
+				return err
+			}
+			if same {
+				continue
+			}
+		}
+		for j := start; j < i; j++ {
+			peerStart[j] = start
+			peerEnd[j] = i
+			if start > 0 {
+				groups[j] = groups[start-1] + 1
+			}
+		}
+		start = i
+	}
+
+	fnCount := len(ws.w.Functions)
+	out := make([]sqltypes.Row, n)
+	for i, row := range rows {
+		out[i] = make(sqltypes.Row, fnCount, fnCount+len(row))
+		out[i] = append(out[i], row...)
+	}
+
+	at := func(j int64) sqltypes.Row { return rows[j] }
+	for idx, fn := range ws.w.Functions {
+		if agg := fn.newAggregator(ws.fields, ws.w.CollationEnv); agg != nil {
+			for i := 0; i < n; i = peerEnd[i] {
+				for j := i; j < peerEnd[i]; j++ {
+					if err := agg.add(rows[j]); err != nil {
+						return err
+					}
+				}
+				val := agg.finish()
+				for j := i; j < peerEnd[i]; j++ {
+					out[j][idx] = val
+				}
+			}
+			continue
+		}
+
+		for i, row := range rows {
+			out[i][idx] = fn.evaluate(at, row, n, i, peerStart[i], peerEnd[i], groups[i])
+		}
+	}
+	return emit(out)
+}
+
+// flushSpilled evaluates the window functions over the n rows of a partition
+// spilled to a temporary file, and emits its rows in batches. The file is read
+// sequentially by several cursors: one for the current row, one ahead of it up
+// to its last peer, which feeds the aggregations, and one for each LAG and LEAD
+// at its offset. This way, only the rows the functions refer to are held in
+// memory.
+func (ws *windowState) flushSpilled(sf *spillFile, n int, emit func([]sqltypes.Row) error) error {
+	fns := ws.w.Functions
+	current, err := sf.reader()
+	if err != nil {
+		return err
+	}
+	peers, err := sf.reader()
+	if err != nil {
+		return err
+	}
+	aggs := make([]aggregator, len(fns))
+	aggValues := make([]sqltypes.Value, len(fns))
+	offsets := make([]*spillCursor, len(fns))
+	// refs are the rows referred to by the functions which aren't aggregations
+	refs := make([]sqltypes.Row, len(fns))
+	for idx, fn := range fns {
+		aggs[idx] = fn.newAggregator(ws.fields, ws.w.CollationEnv)
+		if fn.Opcode == WindowLag || fn.Opcode == WindowLead {
+			r, err := sf.reader()
+			if err != nil {
+				return err
+			}
+			offsets[idx] = &spillCursor{r: r}
+		}
+	}
+
+	var (
+		peerStart, peerEnd, group int
+		// next is the first row after the peers of the current row, which
+		// was already read from peers
+		next sqltypes.Row
+		// first and lastPeer are the first row of the partition and the
+		// last peer of the current row
+		first, lastPeer sqltypes.Row
+		batch           []sqltypes.Row
+	)
+	for i := 0; i < n; i++ {
+		row, err := current.next()
+		if err != nil {
+			return current.unexpectedEOF(err)
+		}
+
+		if i == peerEnd {
+			// the row starts a new group of peers, which are read ahead
+			if i > 0 {
+				group++
+			}
+			peerStart = i
+			for peerEnd < n {
+				peer := next
+				next = nil
+				if peer == nil {
+					if peer, err = peers.next(); err != nil {
+						return peers.unexpectedEOF(err)
+					}
+				}
+				if peerEnd > i {
+					same, err := ws.w.equalKeys(ws.w.OrderBy, row, peer)
+					if err != nil {
+						return err
+					}
+					if !same {
+						next = peer
+						break
+					}
+				}
+				if peerEnd == 0 {
+					first = peer
+				}
+				for idx, fn := range fns {
+					if aggs[idx] != nil {
+						if err := aggs[idx].add(peer); err != nil {
+							return err
+						}
+					} else if fn.Opcode == WindowNthValue && int64(peerEnd) == fn.N-1 {
+						refs[idx] = peer
+					}
+				}
+				lastPeer = peer
+				peerEnd++
+			}
+			for idx, agg := range aggs {
+				if agg != nil {
+					aggValues[idx] = agg.finish()
+				}
+			}
+		}
+
+		out := make(sqltypes.Row, len(fns), len(fns)+len(row))
+		for idx, fn := range fns {
+			if aggs[idx] != nil {
+				out[idx] = aggValues[idx]
+				continue
+			}
+			switch fn.Opcode {
+			case WindowLag, WindowLead:
+				if j := fn.offsetRow(i); j >= 0 && j < int64(n) {
+					if refs[idx], err = offsets[idx].at(j); err != nil {
+						return err
+					}
+				}
+			case WindowFirstValue:
+				refs[idx] = first
+			case WindowLastValue:
+				refs[idx] = lastPeer
+			}
+			ref := refs[idx]
+			out[idx] = fn.evaluate(func(int64) sqltypes.Row { return ref }, row, n, i, peerStart, peerEnd, group)
+		}
+		batch = append(batch, append(out, row...))
+		if len(batch) == spillBatchSize {
+			if err := emit(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		return emit(batch)
+	}
+	return nil
+}
+
+// spillCursor reads the rows of a spilled partition up to a position.
+type spillCursor struct {
+	r *spillReader
+	// row is the row at position pos-1
+	row sqltypes.Row
+	pos int64
+}
+
+// at returns the row at position j, which can't be lower than the one of
+// the previous call.
+func (c *spillCursor) at(j int64) (sqltypes.Row, error) {
+	for c.pos <= j {
+		row, err := c.r.next()
+		if err != nil {
+			return nil, c.r.unexpectedEOF(err)
+		}
+		c.row = row
+		c.pos++
+	}
+	return c.row, nil
+}
+
+// offsetRow returns the position of the row whose value LAG or LEAD returns
+// for the row at position i.
+func (wf *WindowFunctionParams) offsetRow(i int) int64 {
+	if wf.Opcode == WindowLead {
+		return int64(i) + wf.N
+	}
+	return int64(i) - wf.N
+}
+
+// evaluate returns the value of the function, which is not an aggregation,
+// for the row at position i of a partition of n rows. at returns the row at
+// a given position of the partition, for the positions the function refers
+// to.
+func (wf *WindowFunctionParams) evaluate(at func(j int64) sqltypes.Row, row sqltypes.Row, n, i, peerStart, peerEnd, group int) sqltypes.Value {
+	switch wf.Opcode {
+	case WindowRowNumber:
+		return sqltypes.NewUint64(uint64(i + 1))
+	case WindowRank:
+		return sqltypes.NewUint64(uint64(peerStart + 1))
+	case WindowDenseRank:
+		return sqltypes.NewUint64(uint64(group + 1))
+	case WindowPercentRank:
+		if n == 1 {
+			return sqltypes.NewFloat64(0)
+		}
+		return sqltypes.NewFloat64(float64(peerStart) / float64(n-1))
+	case WindowCumeDist:
+		return sqltypes.NewFloat64(float64(peerEnd) / float64(n))
+	case WindowNtile:
+		// the first n % N buckets have one more row than the others
+		size, rem := int64(n)/wf.N, int64(n)%wf.N
+		pos := int64(i)
+		if pos < rem*(size+1) {
+			return sqltypes.NewUint64(uint64(pos/(size+1) + 1))
+		}
+		return sqltypes.NewUint64(uint64(rem + (pos-rem*(size+1))/size + 1))
+	case WindowLag, WindowLead:
+		if j := wf.offsetRow(i); j >= 0 && j < int64(n) {
+			return at(j)[wf.Col]
+		}
+		if wf.DefaultCol >= 0 {
+			return row[wf.DefaultCol]
+		}
+		return sqltypes.NULL
+	case WindowFirstValue:
+		return at(0)[wf.Col]
+	case WindowLastValue:
+		return at(int64(peerEnd) - 1)[wf.Col]
+	case WindowNthValue:
+		if wf.N <= int64(peerEnd) {
+			return at(wf.N - 1)[wf.Col]
+		}
+		return sqltypes.NULL
+	}
+	panic("BUG: unexpected window function opcode")
+}
+
+// aggregatorAvg computes AVG as the SUM of the values divided by their COUNT
+type aggregatorAvg struct {
+	aggregatorSum
+	n int64
+}
+
+func (a *aggregatorAvg) add(row []sqltypes.Value) error {
+	if row[a.from].IsNull() {
+		return nil
+	}
+	a.n++
+	return a.sum.Add(row[a.from])
+}
+
+func (a *aggregatorAvg) finish() sqltypes.Value {
+	if a.n == 0 {
+		return sqltypes.NULL
+	}
+	sum := a.sum.Result()
+	if sum.Type() == sqltypes.Float64 {
+		f, _ := sum.ToFloat64()
+		return sqltypes.NewFloat64(f / float64(a.n))
+	}
+	dec, err := decimal.NewFromMySQL(sum.Raw())
+	if err != nil {
+		return sqltypes.NULL
+	}
+	// like MySQL, the scale of the average is the scale of the sum plus div_precision_increment
+	scale := max(-dec.Exponent(), 0) + divPrecisionIncrement
+	return sqltypes.NewDecimal(dec.Div(decimal.NewFromInt(a.n), divPrecisionIncrement).StringFixed(scale))
+}
+
+func (a *aggregatorAvg) reset() {
+	a.aggregatorSum.reset()
+	a.n = 0
+}
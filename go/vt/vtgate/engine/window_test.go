@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+)
+
+func windowTestInput() *fakePrimitive {
+	return &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"part|col|val",
+				"int64|int64|int64",
+			),
+			"1|1|10",
+			"1|2|20",
+			"1|2|30",
+			"1|3|null",
+			"2|1|5",
+			"3|7|1",
+			"3|8|2",
+		)},
+	}
+}
+
+func TestWindowRanking(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Functions: []*WindowFunctionParams{
+			{Opcode: WindowRowNumber, Col: -1, DefaultCol: -1, Alias: "rn"},
+			{Opcode: WindowRank, Col: -1, DefaultCol: -1, Alias: "r"},
+			{Opcode: WindowDenseRank, Col: -1, DefaultCol: -1, Alias: "dr"},
+			{Opcode: WindowNtile, Col: -1, N: 2, DefaultCol: -1, Alias: "nt"},
+		},
+		Input:        windowTestInput(),
+		CollationEnv: collations.MySQL8(),
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	want := sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"rn|r|dr|nt|part|col|val",
+		"uint64|uint64|uint64|uint64|int64|int64|int64",
+	),
+		"1|1|1|1|1|1|10",
+		"2|2|2|1|1|2|20",
+		"3|2|2|2|1|2|30",
+		"4|4|3|2|1|3|null",
+		"1|1|1|1|2|1|5",
+		"1|1|1|1|3|7|1",
+		"2|2|2|2|3|8|2",
+	)
+	for _, field := range want.Fields[:4] {
+		field.Charset = collations.CollationBinaryID
+	}
+	utils.MustMatch(t, want, result)
+}
+
+func TestWindowAggregates(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Functions: []*WindowFunctionParams{
+			{Opcode: WindowCount, Col: 2, DefaultCol: -1, Alias: "c"},
+			{Opcode: WindowSum, Col: 2, DefaultCol: -1, Alias: "s"},
+			{Opcode: WindowMax, Col: 2, DefaultCol: -1, Alias: "m"},
+		},
+		Input:        windowTestInput(),
+		CollationEnv: collations.MySQL8(),
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	// the aggregations are cumulative, and peers share the same value
+	want := sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"c|s|m|part|col|val",
+		"int64|decimal|int64|int64|int64|int64",
+	),
+		"1|10|10|1|1|10",
+		"3|60|30|1|2|20",
+		"3|60|30|1|2|30",
+		"3|60|30|1|3|null",
+		"1|5|5|2|1|5",
+		"1|1|1|3|7|1",
+		"2|3|2|3|8|2",
+	)
+	assert.Equal(t, want.Rows, result.Rows)
+	assert.Equal(t, sqltypes.Decimal, result.Fields[1].Type)
+	assert.Equal(t, "m", result.Fields[2].Name)
+}
+
+func TestWindowLagLead(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Functions: []*WindowFunctionParams{
+			{Opcode: WindowLag, Col: 2, N: 1, DefaultCol: -1, Alias: "lg"},
+			{Opcode: WindowLead, Col: 2, N: 2, DefaultCol: 1, Alias: "ld"},
+			{Opcode: WindowFirstValue, Col: 2, DefaultCol: -1, Alias: "fv"},
+		},
+		Input:        windowTestInput(),
+		CollationEnv: collations.MySQL8(),
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	want := sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"lg|ld|fv|part|col|val",
+		"int64|int64|int64|int64|int64|int64",
+	),
+		"null|30|10|1|1|10",
+		"10|null|10|1|2|20",
+		"20|2|10|1|2|30",
+		"30|3|10|1|3|null",
+		"null|1|5|2|1|5",
+		"null|7|1|3|7|1",
+		"1|8|1|3|8|2",
+	)
+	assert.Equal(t, want.Rows, result.Rows)
+}
+
+func TestWindowWithoutPartitions(t *testing.T) {
+	w := &Window{
+		Functions: []*WindowFunctionParams{
+			{Opcode: WindowCountStar, Col: -1, DefaultCol: -1, Alias: "c"},
+			{Opcode: WindowAvg, Col: 2, DefaultCol: -1, Alias: "a"},
+		},
+		Input:        windowTestInput(),
+		CollationEnv: collations.MySQL8(),
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	// without an ORDER BY, all the rows of the partition are peers
+	for _, row := range result.Rows {
+		assert.Equal(t, "7", row[0].ToString())
+		assert.Equal(t, "11.3333", row[1].ToString())
+	}
+}
+
+func TestWindowStreamExecute(t *testing.T) {
+	fp := windowTestInput()
+	fp.allResultsInOneCall = false
+	w := &Window{
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		Functions: []*WindowFunctionParams{
+			{Opcode: WindowCountStar, Col: -1, DefaultCol: -1, Alias: "c"},
+		},
+		Input:        fp,
+		CollationEnv: collations.MySQL8(),
+	}
+
+	var rows []sqltypes.Row
+	err := w.TryStreamExecute(context.Background(), &noopVCursor{}, nil, true, func(qr *sqltypes.Result) error {
+		rows = append(rows, qr.Rows...)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var counts []string
+	for _, row := range rows {
+		counts = append(counts, row[0].ToString())
+	}
+	assert.Equal(t, []string{"4", "4", "4", "4", "1", "2", "2"}, counts)
+}
+
+func TestWindowMaxMemoryRows(t *testing.T) {
+	saveMax := testMaxMemoryRows
+	saveIgnore := testIgnoreMaxMemoryRows
+	testMaxMemoryRows = 3
+	defer func() {
+		testMaxMemoryRows = saveMax
+		testIgnoreMaxMemoryRows = saveIgnore
+	}()
+
+	testCases := []struct {
+		ignoreMaxMemoryRows bool
+		err                 string
+	}{
+		{true, ""},
+		{false, "in-memory row count exceeded allowed limit of 3"},
+	}
+	for _, test := range testCases {
+		w := &Window{
+			PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+			Functions: []*WindowFunctionParams{
+				{Opcode: WindowRowNumber, Col: -1, DefaultCol: -1},
+			},
+			Input:        windowTestInput(),
+			CollationEnv: collations.MySQL8(),
+		}
+
+		testIgnoreMaxMemoryRows = test.ignoreMaxMemoryRows
+		_, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+		if test.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, test.err)
+		}
+	}
+}
+
+func TestWindowSpill(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Functions: []*WindowFunctionParams{
+			{Opcode: WindowRowNumber, Col: -1, DefaultCol: -1, Alias: "rn"},
+			{Opcode: WindowRank, Col: -1, DefaultCol: -1, Alias: "r"},
+			{Opcode: WindowDenseRank, Col: -1, DefaultCol: -1, Alias: "dr"},
+			{Opcode: WindowPercentRank, Col: -1, DefaultCol: -1, Alias: "pr"},
+			{Opcode: WindowCumeDist, Col: -1, DefaultCol: -1, Alias: "cd"},
+			{Opcode: WindowNtile, Col: -1, N: 3, DefaultCol: -1, Alias: "nt"},
+			{Opcode: WindowLag, Col: 2, N: 1, DefaultCol: -1, Alias: "lg"},
+			{Opcode: WindowLead, Col: 2, N: 2, DefaultCol: 1, Alias: "ld"},
+			{Opcode: WindowFirstValue, Col: 2, DefaultCol: -1, Alias: "fv"},
+			{Opcode: WindowLastValue, Col: 2, DefaultCol: -1, Alias: "lv"},
+			{Opcode: WindowNthValue, Col: 2, N: 3, DefaultCol: -1, Alias: "nv"},
+			{Opcode: WindowCountStar, Col: -1, DefaultCol: -1, Alias: "cs"},
+			{Opcode: WindowSum, Col: 2, DefaultCol: -1, Alias: "s"},
+			{Opcode: WindowAvg, Col: 2, DefaultCol: -1, Alias: "a"},
+			{Opcode: WindowMin, Col: 2, DefaultCol: -1, Alias: "m"},
+		},
+		CollationEnv: collations.MySQL8(),
+	}
+	input := func() *fakePrimitive {
+		fp := windowTestInput()
+		fp.results[0].Rows = append(fp.results[0].Rows, sqltypes.MakeTestResult(fp.results[0].Fields,
+			"3|8|3",
+			"3|9|null",
+			"3|9|4",
+			"3|9|5",
+			"3|10|6",
+			"3|12|7",
+			"4|1|8",
+		).Rows...)
+		fp.allResultsInOneCall = false
+		return fp
+	}
+
+	w.Input = input()
+	want, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	// the partitions of more than 2 rows are spilled
+	dir := enableTestSpill(t, 2, 1024)
+	w.Input = input()
+	vc := &loggingVCursor{}
+	got, err := wrapStreamExecute(w, vc, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, want.Rows, got.Rows)
+	require.Equal(t, 2, vc.memoryUsage[w].SpillFiles)
+	require.Equal(t, 3, vc.memoryUsage[w].Rows)
+	requireNoSpillFiles(t, dir)
+
+	// the other executions still fail when a partition exceeds the limit
+	w.Input = input()
+	_, err = w.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.EqualError(t, err, "in-memory row count exceeded allowed limit of 2")
+}
@@ -44,6 +44,7 @@ type typeAggregation struct {
 	datetime  uint16
 
 	geometry uint16
+	vector   uint16
 	blob     uint16
 	total    uint16
 
@@ -173,6 +174,8 @@ func (ta *typeAggregation) add(tt sqltypes.Type, f typeFlag, size, scale int32)
 		ta.timestamp++
 	case sqltypes.Geometry:
 		ta.geometry++
+	case sqltypes.Vector:
+		ta.vector++
 	case sqltypes.Blob, sqltypes.Text:
 		ta.blob++
 	default:
@@ -217,6 +220,7 @@ func (ta *typeAggregation) result() sqltypes.Type {
 			If all temporal types are DATE, TIME, or TIMESTAMP, the result is DATE, TIME, or TIMESTAMP, respectively.
 			Otherwise, for a mix of temporal types, the result is DATETIME.
 		If all types are GEOMETRY, the result is GEOMETRY.
+		If all types are VECTOR, the result is VECTOR.
 		If any type is BLOB, the result is BLOB. This also applies to TEXT.
 		For all other type combinations, the result is VARCHAR.
 		Literal NULL operands are ignored for type aggregation.
@@ -292,6 +296,9 @@ func (ta *typeAggregation) result() sqltypes.Type {
 	if ta.geometry == ta.total {
 		return sqltypes.Geometry
 	}
+	if ta.vector == ta.total {
+		return sqltypes.Vector
+	}
 	if ta.blob > 0 {
 		return sqltypes.Blob
 	}
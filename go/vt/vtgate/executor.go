@@ -19,16 +19,19 @@ package vtgate
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/maps"
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/cache/theine"
@@ -60,6 +63,8 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/queryacl"
+	"vitess.io/vitess/go/vt/vtgate/slowlog"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
@@ -78,6 +83,9 @@ var (
 	queriesRoutedByTable    = stats.NewCountersWithMultiLabels("QueriesRoutedByTable", "Queries routed from vtgate to vttablet by plan type, keyspace and table", []string{"Plan", "Keyspace", "Table"})
 
 	exceedMemoryRowsLogger = logutil.NewThrottledLogger("ExceedMemoryRows", 1*time.Minute)
+
+	preparedPlanCacheHits   = stats.NewCounter("QueryPlanCacheBindVarsHits", "Query plan cache hits for queries with bind variables sent by the client")
+	preparedPlanCacheMisses = stats.NewCounter("QueryPlanCacheBindVarsMisses", "Query plan cache misses for queries with bind variables sent by the client")
 )
 
 const (
@@ -114,6 +122,14 @@ type Executor struct {
 	plans *PlanCache
 	epoch atomic.Uint32
 
+	resultCache *resultCache
+
+	// workloadQuotas limits the queries of the workloads that have quotas.
+	workloadQuotas *workloadQuotas
+
+	// readRetries is the maximum number of times a read that failed on a replica is retried on another one.
+	readRetries int
+
 	normalize       bool
 	warnShardedOnly bool
 
@@ -174,11 +190,18 @@ func NewExecutor(
 		allowScatter:        !noScatter,
 		pv:                  pv,
 		plans:               plans,
+		resultCache:         newResultCache(queryResultCacheMemory, queryResultCacheTTL, queryResultCacheMaxRows, queryResultCacheOptIn, schemaTracker),
+		workloadQuotas:      newWorkloadQuotas(workloadMaxConcurrency, workloadMaxQPS, workloadMaxRows),
+		readRetries:         readRetryMaxAttempts,
 		warmingReadsPercent: warmingReadsPercent,
 		warmingReadsChannel: make(chan bool, warmingReadsConcurrency),
 	}
+	e.txConn.onCommit = func(keyspaces []string) {
+		e.resultCache.invalidateKeyspaces(keyspaces)
+	}
 
 	vschemaacl.Init()
+	queryacl.Init()
 	// we subscribe to update from the VSchemaManager
 	e.vm = &VSchemaManager{
 		subscriber: e.SaveVSchema,
@@ -186,6 +209,8 @@ func NewExecutor(
 		cell:       cell,
 		schema:     e.schemaTracker,
 		parser:     env.Parser(),
+
+		trackedColumnsOverride: schemaTrackedColumnsOverride,
 	}
 	serv.WatchSrvVSchema(ctx, cell, e.vm.VSchemaUpdate)
 
@@ -208,6 +233,18 @@ func NewExecutor(
 		stats.NewCounterFunc("QueryPlanCacheMisses", "Query plan cache misses", func() int64 {
 			return e.plans.Metrics.Hits()
 		})
+		stats.NewGaugeFunc("QueryResultCacheLength", "Query result cache length", func() int64 {
+			if e.resultCache == nil {
+				return 0
+			}
+			return int64(e.resultCache.entries.Len())
+		})
+		stats.NewGaugeFunc("QueryResultCacheSize", "Query result cache size", func() int64 {
+			if e.resultCache == nil {
+				return 0
+			}
+			return int64(e.resultCache.entries.UsedCapacity())
+		})
 		servenv.HTTPHandle(pathQueryPlans, e)
 		servenv.HTTPHandle(pathScatterStats, e)
 		servenv.HTTPHandle(pathVSchema, e)
@@ -223,6 +260,9 @@ func (e *Executor) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars)
+	if slowlog.Enabled() {
+		ctx = logstats.NewContext(ctx, logStats)
+	}
 	stmtType, result, err := e.execute(ctx, mysqlCtx, safeSession, sql, bindVars, logStats)
 	logStats.Error = err
 	if result == nil {
@@ -230,7 +270,7 @@ func (e *Executor) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	} else {
 		saveSessionStats(safeSession, stmtType, result.RowsAffected, result.InsertID, len(result.Rows), err)
 	}
-	if result != nil && len(result.Rows) > warnMemoryRows {
+	if warnMemoryRows := getWarnMemoryRows(); result != nil && len(result.Rows) > warnMemoryRows {
 		warnings.Add("ResultsExceeded", 1)
 		piiSafeSQL, err := e.env.Parser().RedactSQLQuery(sql)
 		if err != nil {
@@ -245,7 +285,7 @@ func (e *Executor) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	}
 
 	logStats.SaveEndTime()
-	e.queryLogger.Send(logStats)
+	e.sendLogStats(logStats)
 	err = vterrors.TruncateError(err, truncateErrorLen)
 	return result, err
 }
@@ -257,6 +297,10 @@ type streaminResultReceiver struct {
 	rowsReturned int
 	insertID     uint64
 	callback     func(*sqltypes.Result) error
+
+	// quotas and workload are set once the query is planned, to enforce the row quota of its workload.
+	quotas   *workloadQuotas
+	workload string
 }
 
 func (s *streaminResultReceiver) storeResultStats(typ sqlparser.StatementType, qr *sqltypes.Result) error {
@@ -264,6 +308,9 @@ func (s *streaminResultReceiver) storeResultStats(typ sqlparser.StatementType, q
 	defer s.mu.Unlock()
 	s.rowsAffected += qr.RowsAffected
 	s.rowsReturned += len(qr.Rows)
+	if err := s.quotas.checkRows(s.workload, len(qr.Rows), s.rowsReturned); err != nil {
+		return err
+	}
 	if qr.InsertID != 0 {
 		s.insertID = qr.InsertID
 	}
@@ -287,10 +334,16 @@ func (e *Executor) StreamExecute(
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars)
+	if slowlog.Enabled() {
+		ctx = logstats.NewContext(ctx, logStats)
+	}
 	srr := &streaminResultReceiver{callback: callback}
 	var err error
 
 	resultHandler := func(ctx context.Context, plan *engine.Plan, vc *vcursorImpl, bindVars map[string]*querypb.BindVariable, execStart time.Time) error {
+		srr.quotas = e.workloadQuotas
+		srr.workload = safeSession.GetOptions().GetWorkloadName()
+
 		var seenResults atomic.Bool
 		var resultMu sync.Mutex
 		result := &sqltypes.Result{}
@@ -334,6 +387,9 @@ func (e *Executor) StreamExecute(
 		err := vc.StreamExecutePrimitive(ctx, plan.Instructions, bindVars, true, func(qr *sqltypes.Result) error {
 			return srr.storeResultStats(plan.Type, qr)
 		})
+		if plan.Type != sqlparser.StmtSelect {
+			e.resultCache.invalidate(plan.TablesUsed)
+		}
 
 		// Check if there was partial DML execution. If so, rollback the effect of the partially executed query.
 		if err != nil {
@@ -369,7 +425,7 @@ func (e *Executor) StreamExecute(
 
 	logStats.Error = err
 	saveSessionStats(safeSession, srr.stmtType, srr.rowsAffected, srr.insertID, srr.rowsReturned, err)
-	if srr.rowsReturned > warnMemoryRows {
+	if warnMemoryRows := getWarnMemoryRows(); srr.rowsReturned > warnMemoryRows {
 		warnings.Add("ResultsExceeded", 1)
 		piiSafeSQL, err := e.env.Parser().RedactSQLQuery(sql)
 		if err != nil {
@@ -384,7 +440,7 @@ func (e *Executor) StreamExecute(
 	}
 
 	logStats.SaveEndTime()
-	e.queryLogger.Send(logStats)
+	e.sendLogStats(logStats)
 	return vterrors.TruncateError(err, truncateErrorLen)
 
 }
@@ -398,6 +454,13 @@ func canReturnRows(stmtType sqlparser.StatementType) bool {
 	}
 }
 
+// sendLogStats sends the stats of a query to the query log, the literals
+// of its SQL being redacted if vtgate redacts the query literals.
+func (e *Executor) sendLogStats(logStats *logstats.LogStats) {
+	logStats.SQL = e.env.Parser().RedactForLog(logStats.SQL)
+	e.queryLogger.Send(logStats)
+}
+
 func saveSessionStats(safeSession *SafeSession, stmtType sqlparser.StatementType, rowsAffected, insertID uint64, rowsReturned int, err error) {
 	safeSession.RowCount = -1
 	if err != nil {
@@ -424,6 +487,9 @@ func (e *Executor) execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	err = e.newExecute(ctx, mysqlCtx, safeSession, sql, bindVars, logStats, func(ctx context.Context, plan *engine.Plan, vc *vcursorImpl, bindVars map[string]*querypb.BindVariable, time time.Time) error {
 		stmtType = plan.Type
 		qr, err = e.executePlan(ctx, safeSession, plan, vc, bindVars, logStats, time)
+		if err == nil && qr != nil {
+			err = e.workloadQuotas.checkRows(safeSession.GetOptions().GetWorkloadName(), len(qr.Rows), len(qr.Rows))
+		}
 		return err
 	}, func(typ sqlparser.StatementType, result *sqltypes.Result) error {
 		stmtType = typ
@@ -494,6 +560,8 @@ func (e *Executor) addNeededBindVars(vcursor *vcursorImpl, bindVarNeeds *sqlpars
 			bindVars[key] = sqltypes.StringBindVariable(session.SessionUUID)
 		case sysvars.SessionEnableSystemSettings.Name:
 			bindVars[key] = sqltypes.BoolBindVariable(session.EnableSystemSettings)
+		case sysvars.ScatterErrorsAsWarnings.Name:
+			bindVars[key] = sqltypes.BoolBindVariable(session.GetScatterErrorsAsWarnings())
 		case sysvars.ReadAfterWriteGTID.Name:
 			var v string
 			ifReadAfterWriteExist(session, func(raw *vtgatepb.ReadAfterWrite) {
@@ -514,12 +582,22 @@ func (e *Executor) addNeededBindVars(vcursor *vcursorImpl, bindVarNeeds *sqlpars
 				}
 			})
 			bindVars[key] = sqltypes.StringBindVariable(v)
+		case sysvars.ReadAfterWriteConsistency.Name:
+			v := "EVENTUAL"
+			ifReadAfterWriteExist(session, func(raw *vtgatepb.ReadAfterWrite) {
+				if raw.ReadYourWrites {
+					v = "SESSION"
+				}
+			})
+			bindVars[key] = sqltypes.StringBindVariable(v)
 		case sysvars.Version.Name:
 			bindVars[key] = sqltypes.StringBindVariable(servenv.AppVersion.MySQLVersion())
 		case sysvars.VersionComment.Name:
 			bindVars[key] = sqltypes.StringBindVariable(servenv.AppVersion.String())
 		case sysvars.Socket.Name:
 			bindVars[key] = sqltypes.StringBindVariable(mysqlSocketPath())
+		case sysvars.SnapshotGTIDs.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.SnapshotGTIDsJSON())
 		default:
 			if value, hasSysVar := session.SystemVariables[sysVar]; hasSysVar {
 				expr, err := e.env.Parser().ParseExpr(value)
@@ -579,6 +657,9 @@ func (e *Executor) handleBegin(ctx context.Context, safeSession *SafeSession, lo
 
 	begin := stmt.(*sqlparser.Begin)
 	err := e.txConn.Begin(ctx, safeSession, begin.TxAccessModes)
+	if err == nil && isConsistentSnapshot(begin.TxAccessModes) {
+		err = e.beginSnapshot(ctx, safeSession)
+	}
 	logStats.ExecuteTime = time.Since(execStart)
 
 	e.updateQueryCounts("Begin", "", "", 0)
@@ -612,7 +693,7 @@ func (e *Executor) handleRollback(ctx context.Context, safeSession *SafeSession,
 	return &sqltypes.Result{}, err
 }
 
-func (e *Executor) handleSavepoint(ctx context.Context, safeSession *SafeSession, sql string, planType string, logStats *logstats.LogStats, nonTxResponse func(query string) (*sqltypes.Result, error), ignoreMaxMemoryRows bool) (*sqltypes.Result, error) {
+func (e *Executor) handleSavepoint(ctx context.Context, safeSession *SafeSession, stmt sqlparser.Statement, sql string, planType string, logStats *logstats.LogStats, nonTxResponse func(query string) (*sqltypes.Result, error), ignoreMaxMemoryRows bool) (*sqltypes.Result, error) {
 	execStart := time.Now()
 	logStats.PlanTime = execStart.Sub(logStats.StartTime)
 	logStats.ShardQueries = uint64(len(safeSession.ShardSessions))
@@ -621,16 +702,24 @@ func (e *Executor) handleSavepoint(ctx context.Context, safeSession *SafeSession
 		logStats.ExecuteTime = time.Since(execStart)
 	}()
 
+	if !safeSession.isTxOpen() && !safeSession.InTransaction() {
+		return nonTxResponse(sql)
+	}
+
+	// The savepoint is validated against the session before reaching any shard,
+	// so that all the shards participating in the transaction stay in the same state.
+	savepoints, err := e.activeSavepoints(safeSession.SavePoints(), stmt)
+	if err != nil {
+		return nil, err
+	}
+
 	// If no transaction exists on any of the shard sessions,
 	// then savepoint does not need to be executed, it will be only stored in the session
 	// and later will be executed when a transaction is started.
 	if !safeSession.isTxOpen() {
-		if safeSession.InTransaction() {
-			// Storing, as this needs to be executed just after starting transaction on the shard.
-			safeSession.StoreSavepoint(sql)
-			return &sqltypes.Result{}, nil
-		}
-		return nonTxResponse(sql)
+		// Storing, as this needs to be executed just after starting transaction on the shard.
+		safeSession.SetSavepoints(savepoints)
+		return &sqltypes.Result{}, nil
 	}
 	orig := safeSession.commitOrder
 	qr, err := e.executeSPInAllSessions(ctx, safeSession, sql, ignoreMaxMemoryRows)
@@ -638,10 +727,73 @@ func (e *Executor) handleSavepoint(ctx context.Context, safeSession *SafeSession
 	if err != nil {
 		return nil, err
 	}
-	safeSession.StoreSavepoint(sql)
+	safeSession.SetSavepoints(savepoints)
 	return qr, nil
 }
 
+// activeSavepoints returns the savepoint statements that the shards joining the transaction
+// have to replay once stmt is applied on top of the given ones. Only the savepoints that are
+// still active are kept: rolling back to a savepoint drops the ones set after it, releasing one
+// also drops the released savepoint. This keeps the shards joining later in the same state as
+// the ones already participating in the transaction, regardless of how many nested
+// transactions were opened and closed before.
+func (e *Executor) activeSavepoints(savepoints []string, stmt sqlparser.Statement) ([]string, error) {
+	var names []sqlparser.IdentifierCI
+	apply := func(stmt sqlparser.Statement) error {
+		switch stmt := stmt.(type) {
+		case *sqlparser.Savepoint:
+			// Setting an existing savepoint again moves it to the end.
+			if idx := savepointIndex(names, stmt.Name); idx >= 0 {
+				names = append(names[:idx], names[idx+1:]...)
+			}
+			names = append(names, stmt.Name)
+		case *sqlparser.SRollback:
+			idx := savepointIndex(names, stmt.Name)
+			if idx < 0 {
+				return vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.SPDoesNotExist, "SAVEPOINT does not exist: %s", sqlparser.String(stmt))
+			}
+			names = names[:idx+1]
+		case *sqlparser.Release:
+			idx := savepointIndex(names, stmt.Name)
+			if idx < 0 {
+				return vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.SPDoesNotExist, "SAVEPOINT does not exist: %s", sqlparser.String(stmt))
+			}
+			names = names[:idx]
+		default:
+			return vterrors.VT13001(fmt.Sprintf("unexpected savepoint statement: %s", sqlparser.String(stmt)))
+		}
+		return nil
+	}
+
+	for _, sql := range savepoints {
+		spStmt, err := e.env.Parser().Parse(sql)
+		if err != nil {
+			return nil, err
+		}
+		if err := apply(spStmt); err != nil {
+			return nil, err
+		}
+	}
+	if err := apply(stmt); err != nil {
+		return nil, err
+	}
+
+	active := make([]string, 0, len(names))
+	for _, name := range names {
+		active = append(active, sqlparser.String(&sqlparser.Savepoint{Name: name}))
+	}
+	return active, nil
+}
+
+func savepointIndex(names []sqlparser.IdentifierCI, name sqlparser.IdentifierCI) int {
+	for idx, n := range names {
+		if n.Equal(name) {
+			return idx
+		}
+	}
+	return -1
+}
+
 // executeSPInAllSessions function executes the savepoint query in all open shard sessions (pre, normal and post)
 // which has non-zero transaction id (i.e. an open transaction on the shard connection).
 func (e *Executor) executeSPInAllSessions(ctx context.Context, safeSession *SafeSession, sql string, ignoreMaxMemoryRows bool) (*sqltypes.Result, error) {
@@ -1046,6 +1198,10 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	defer e.mu.Unlock()
 	if vschema != nil {
 		e.vschema = vschema
+		e.applyVTGateConfig(vschema.VTGateConfig)
+		if gw := e.scatterConn.gateway; gw != nil && gw.buffer != nil {
+			gw.buffer.SetMovedTables(movedTables(vschema))
+		}
 	}
 	e.vschemaStats = stats
 	e.ClearPlans()
@@ -1063,6 +1219,38 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	}
 }
 
+// movedTables returns the tables moved by the MoveTables workflows, per
+// keyspace, i.e. the tables of the routing rules, both in the keyspace they
+// are routed from and in the one they are routed to.
+func movedTables(vschema *vindexes.VSchema) map[string][]string {
+	sets := make(map[string]map[string]bool)
+	add := func(keyspace, table string) {
+		if sets[keyspace] == nil {
+			sets[keyspace] = make(map[string]bool)
+		}
+		sets[keyspace][table] = true
+	}
+	for from, rule := range vschema.RoutingRules {
+		if rule.Error != nil || len(rule.Tables) == 0 {
+			continue
+		}
+		if to := rule.Tables[0]; to.Keyspace != nil {
+			add(to.Keyspace.Name, to.Name.String())
+		}
+		// The rules are keyed by [keyspace.]table[@tablet_type].
+		from, _, _ = strings.Cut(from, "@")
+		if keyspace, table, ok := strings.Cut(from, "."); ok {
+			add(keyspace, table)
+		}
+	}
+
+	tables := make(map[string][]string, len(sets))
+	for keyspace, set := range sets {
+		tables[keyspace] = maps.Keys(set)
+	}
+	return tables
+}
+
 // ParseDestinationTarget parses destination target string and sets default keyspace if possible.
 func (e *Executor) ParseDestinationTarget(targetString string) (string, topodatapb.TabletType, key.Destination, error) {
 	destKeyspace, destTabletType, dest, err := topoproto.ParseDestination(targetString, defaultTabletType)
@@ -1081,7 +1269,9 @@ type iQueryOption interface {
 }
 
 // getPlan computes the plan for the given query. If one is in
-// the cache, it reuses it.
+// the cache, it reuses it. When typeBindVars is set, the plan is built
+// for the types of the bind variables sent by the client, which is only done
+// for the queries received over the MySQL protocol, like prepared statements.
 func (e *Executor) getPlan(
 	ctx context.Context,
 	vcursor *vcursorImpl,
@@ -1091,6 +1281,7 @@ func (e *Executor) getPlan(
 	bindVars map[string]*querypb.BindVariable,
 	reservedVars *sqlparser.ReservedVars,
 	allowParameterization bool,
+	typeBindVars bool,
 	logStats *logstats.LogStats,
 ) (*engine.Plan, error) {
 	if e.VSchema() == nil {
@@ -1106,12 +1297,18 @@ func (e *Executor) getPlan(
 		return nil, err
 	}
 	vcursor.SetPriority(priority)
+	vcursor.cacheResult = e.resultCache.accepts(stmt)
 
 	setVarComment, err := prepareSetVarComment(vcursor, stmt)
 	if err != nil {
 		return nil, err
 	}
 
+	rowPoliciesApplied, err := e.applyRowPolicies(ctx, vcursor, stmt, bindVars)
+	if err != nil {
+		return nil, err
+	}
+
 	// Normalize if possible
 	shouldNormalize := e.canNormalizeStatement(stmt, setVarComment)
 	parameterize := allowParameterization && shouldNormalize
@@ -1133,25 +1330,84 @@ func (e *Executor) getPlan(
 	}
 	stmt = rewriteASTResult.AST
 	bindVarNeeds := rewriteASTResult.BindVarNeeds
-	if shouldNormalize {
+	if shouldNormalize || rowPoliciesApplied {
 		query = sqlparser.String(stmt)
 	}
 
+	// The arguments are typed after the query has been normalized, so that the normalized
+	// query stays the same for all the types of bind variables sent by the client.
+	var bindVarTypes string
+	if shouldNormalize && typeBindVars {
+		bindVarTypes = typeClientArguments(stmt, bindVars)
+	}
+
 	logStats.SQL = comments.Leading + query + comments.Trailing
 	logStats.BindVariables = sqltypes.CopyBindVariables(bindVars)
 
-	return e.cacheAndBuildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds, logStats)
+	return e.cacheAndBuildStatement(ctx, vcursor, query, bindVarTypes, stmt, reservedVars, bindVarNeeds, logStats)
 }
 
-func (e *Executor) hashPlan(ctx context.Context, vcursor *vcursorImpl, query string) PlanCacheKey {
+// hashPlan returns the key of the plan of the query in the plan cache.
+// The key has two levels: the first one is made of the normalized query and of
+// the session settings that impact its plan. The second level is only used for queries
+// sent with bind variables by the client, like prepared statements, and is made of
+// the types of these bind variables, since the plan is built for these types.
+func (e *Executor) hashPlan(ctx context.Context, vcursor *vcursorImpl, query string, bindVarTypes string) PlanCacheKey {
 	hasher := vthash.New256()
 	vcursor.keyForPlan(ctx, query, hasher)
 
 	var planKey PlanCacheKey
 	hasher.Sum(planKey[:0])
+	if bindVarTypes == "" {
+		return planKey
+	}
+
+	hasher = vthash.New256()
+	_, _ = hasher.Write(planKey[:])
+	_, _ = hasher.WriteString("+BindVars:")
+	_, _ = hasher.WriteString(bindVarTypes)
+	hasher.Sum(planKey[:0])
 	return planKey
 }
 
+// typeClientArguments sets the type of the arguments of the query that have a bind variable
+// sent by the client, like the parameters of a prepared statement, so that the plan
+// is built for these types. It returns the names and types of these bind variables.
+func typeClientArguments(stmt sqlparser.Statement, bindVars map[string]*querypb.BindVariable) string {
+	var args []*sqlparser.Argument
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		arg, ok := node.(*sqlparser.Argument)
+		if !ok || arg.Type != sqltypes.Unknown {
+			return true, nil
+		}
+		bv, found := bindVars[arg.Name]
+		if !found || bv.Type == sqltypes.Null || bv.Type == sqltypes.Tuple {
+			return true, nil
+		}
+		arg.Type = bv.Type
+		args = append(args, arg)
+		return true, nil
+	}, stmt)
+	if len(args) == 0 {
+		return ""
+	}
+
+	slices.SortFunc(args, func(a, b *sqlparser.Argument) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	var buf strings.Builder
+	for i, arg := range args {
+		if i > 0 && args[i-1].Name == arg.Name {
+			continue
+		}
+		buf.WriteString(arg.Name)
+		buf.WriteByte(':')
+		buf.WriteString(arg.Type.String())
+		buf.WriteByte(';')
+	}
+	return buf.String()
+}
+
 func (e *Executor) buildStatement(
 	ctx context.Context,
 	vcursor *vcursorImpl,
@@ -1176,6 +1432,7 @@ func (e *Executor) cacheAndBuildStatement(
 	ctx context.Context,
 	vcursor *vcursorImpl,
 	query string,
+	bindVarTypes string,
 	stmt sqlparser.Statement,
 	reservedVars *sqlparser.ReservedVars,
 	bindVarNeeds *sqlparser.BindVarNeeds,
@@ -1183,13 +1440,21 @@ func (e *Executor) cacheAndBuildStatement(
 ) (*engine.Plan, error) {
 	planCachable := sqlparser.CachePlan(stmt) && vcursor.safeSession.cachePlan()
 	if planCachable {
-		planKey := e.hashPlan(ctx, vcursor, query)
+		planKey := e.hashPlan(ctx, vcursor, query, bindVarTypes)
+		logStats.PlanFingerprint = hex.EncodeToString(planKey[:8])
 
 		var plan *engine.Plan
 		var err error
 		plan, logStats.CachedPlan, err = e.plans.GetOrLoad(planKey, e.epoch.Load(), func() (*engine.Plan, error) {
 			return e.buildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds)
 		})
+		if bindVarTypes != "" && err == nil {
+			if logStats.CachedPlan {
+				preparedPlanCacheHits.Add(1)
+			} else {
+				preparedPlanCacheMisses.Add(1)
+			}
+		}
 		return plan, err
 	}
 	return e.buildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds)
@@ -1350,7 +1615,7 @@ func (e *Executor) Prepare(ctx context.Context, method string, safeSession *Safe
 	// it was a no-op record (i.e. didn't issue any queries)
 	if !(logStats.StmtType == "ROLLBACK" && logStats.ShardQueries == 0) {
 		logStats.SaveEndTime()
-		e.queryLogger.Send(logStats)
+		e.sendLogStats(logStats)
 	}
 	return fld, vterrors.TruncateError(err, truncateErrorLen)
 }
@@ -1400,7 +1665,7 @@ func (e *Executor) handlePrepare(ctx context.Context, safeSession *SafeSession,
 		return nil, err
 	}
 
-	plan, err := e.getPlan(ctx, vcursor, sql, stmt, comments, bindVars, reservedVars /* parameterize */, false, logStats)
+	plan, err := e.getPlan(ctx, vcursor, sql, stmt, comments, bindVars, reservedVars, false /* parameterize */, false /* typeBindVars */, logStats)
 	execStart := time.Now()
 	logStats.PlanTime = execStart.Sub(logStats.StartTime)
 
@@ -1504,7 +1769,8 @@ func (e *Executor) startVStream(ctx context.Context, rss []*srvtopo.ResolvedShar
 }
 
 func (e *Executor) checkThatPlanIsValid(stmt sqlparser.Statement, plan *engine.Plan) error {
-	if e.allowScatter || plan.Instructions == nil || sqlparser.AllowScatterDirective(stmt) {
+	noScatter := sqlparser.NoScatterDirective(stmt)
+	if plan.Instructions == nil || (!noScatter && (e.allowScatter || sqlparser.AllowScatterDirective(stmt))) {
 		return nil
 	}
 	// we go over all the primitives in the plan, searching for a route that is of SelectScatter opcode
@@ -1520,6 +1786,9 @@ func (e *Executor) checkThatPlanIsValid(stmt sqlparser.Statement, plan *engine.P
 		return nil
 	}
 
+	if noScatter {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "plan includes scatter, which is disallowed by the NO_SCATTER query directive")
+	}
 	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "plan includes scatter, which is disallowed using the `no_scatter` command line argument")
 }
 
@@ -1585,6 +1854,7 @@ func (e *Executor) planPrepareStmt(ctx context.Context, vcursor *vcursorImpl, qu
 		map[string]*querypb.BindVariable{},
 		reservedVars, /* normalize */
 		false,
+		false, /* typeBindVars */
 		lStats,
 	)
 	if err != nil {
@@ -1601,6 +1871,7 @@ func (e *Executor) Close() {
 	}
 	topo.Close()
 	e.plans.Close()
+	e.resultCache.close()
 }
 
 func (e *Executor) environment() *vtenv.Environment {
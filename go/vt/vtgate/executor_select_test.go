@@ -822,13 +822,14 @@ func TestSelectInitializedVitessAwareVariable(t *testing.T) {
 	defer executor.queryLogger.Unsubscribe(logChan)
 
 	session := &vtgatepb.Session{
-		TargetString:         "@primary",
-		Autocommit:           true,
-		EnableSystemSettings: true,
-		QueryTimeout:         75,
+		TargetString:            "@primary",
+		Autocommit:              true,
+		EnableSystemSettings:    true,
+		QueryTimeout:            75,
+		ScatterErrorsAsWarnings: true,
 	}
 
-	sql := "select @@autocommit, @@enable_system_settings, @@query_timeout"
+	sql := "select @@autocommit, @@enable_system_settings, @@query_timeout, @@scatter_errors_as_warnings"
 
 	result, err := executorExec(ctx, executor, session, sql, nil)
 	wantResult := &sqltypes.Result{
@@ -836,11 +837,13 @@ func TestSelectInitializedVitessAwareVariable(t *testing.T) {
 			{Name: "@@autocommit", Type: sqltypes.Int64, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
 			{Name: "@@enable_system_settings", Type: sqltypes.Int64, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
 			{Name: "@@query_timeout", Type: sqltypes.Int64, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
+			{Name: "@@scatter_errors_as_warnings", Type: sqltypes.Int64, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
 		},
 		Rows: [][]sqltypes.Value{{
 			sqltypes.NewInt64(1),
 			sqltypes.NewInt64(1),
 			sqltypes.NewInt64(75),
+			sqltypes.NewInt64(1),
 		}},
 	}
 	require.NoError(t, err)
@@ -3319,6 +3322,9 @@ func TestSelectScatterFails(t *testing.T) {
 	_, err = executorExecSession(ctx, executor, "select /*vt+ ALLOW_SCATTER */ id from user", nil, sess)
 	require.NoError(t, err)
 
+	_, err = executorExecSession(ctx, executor, "select /*vt+ SCATTER_OK */ id from user", nil, sess)
+	require.NoError(t, err)
+
 	_, err = executorExecSession(ctx, executor, "begin", nil, sess)
 	require.NoError(t, err)
 
@@ -3329,6 +3335,34 @@ func TestSelectScatterFails(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNoScatterDirective(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	_, err := executorExec(ctx, executor, session, "select /*vt+ NO_SCATTER */ id from user", nil)
+	require.ErrorContains(t, err, "plan includes scatter, which is disallowed by the NO_SCATTER query directive")
+
+	// the directive takes precedence over the one allowing scatter
+	_, err = executorExec(ctx, executor, session, "select /*vt+ NO_SCATTER ALLOW_SCATTER */ id from user", nil)
+	require.ErrorContains(t, err, "NO_SCATTER")
+
+	_, err = executorExec(ctx, executor, session, "select /*vt+ NO_SCATTER */ id from user where id = 1", nil)
+	require.NoError(t, err)
+
+	// a forced vindex can avoid the scatter
+	_, err = executorExec(ctx, executor, session, "select /*vt+ NO_SCATTER FORCE_VINDEX=name_user_map */ id from user where name = 'foo' or id > 5", nil)
+	require.ErrorContains(t, err, "NO_SCATTER")
+	_, err = executorExec(ctx, executor, session, "select /*vt+ NO_SCATTER FORCE_VINDEX=name_user_map */ id from user where name = 'foo'", nil)
+	require.NoError(t, err)
+
+	qr, err := executorExec(ctx, executor, session, "vexplain select /*vt+ NO_SCATTER FORCE_VINDEX=name_user_map */ id from user where name = 'foo'", nil)
+	require.NoError(t, err)
+	assert.Contains(t, qr.Rows[0][0].ToString(), `"PlannerHints": [
+		"FORCE_VINDEX=name_user_map",
+		"NO_SCATTER"
+	]`)
+}
+
 func TestGen4SelectStraightJoin(t *testing.T) {
 	executor, sbc1, _, _, _ := createExecutorEnv(t)
 	executor.normalize = true
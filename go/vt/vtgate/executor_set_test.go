@@ -263,6 +263,21 @@ func TestExecutorSet(t *testing.T) {
 	}, {
 		in:  "set @@query_timeout = 50, query_timeout = 75",
 		out: &vtgatepb.Session{Autocommit: true, QueryTimeout: 75},
+	}, {
+		in:  "set @@scatter_errors_as_warnings = on",
+		out: &vtgatepb.Session{Autocommit: true, ScatterErrorsAsWarnings: true},
+	}, {
+		in:  "set scatter_errors_as_warnings = 0",
+		out: &vtgatepb.Session{Autocommit: true},
+	}, {
+		in:  "set @@read_after_write_consistency = 'SESSION'",
+		out: &vtgatepb.Session{Autocommit: true, ReadAfterWrite: &vtgatepb.ReadAfterWrite{ReadYourWrites: true}},
+	}, {
+		in:  "set @@read_after_write_consistency = eventual",
+		out: &vtgatepb.Session{Autocommit: true, ReadAfterWrite: &vtgatepb.ReadAfterWrite{}},
+	}, {
+		in:  "set @@read_after_write_consistency = 'strong'",
+		err: "variable 'read_after_write_consistency' can't be set to the value of 'strong'",
 	}}
 	for i, tcase := range testcases {
 		t.Run(fmt.Sprintf("%d-%s", i, tcase.in), func(t *testing.T) {
@@ -492,6 +507,40 @@ func TestSetUDVFromTabletInput(t *testing.T) {
 	utils.MustMatch(t, want, session.UserDefinedVariables, "")
 }
 
+func TestSetUDVFromSubquery(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+
+	sbc1.SetResults([]*sqltypes.Result{
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1"),
+	})
+	session := &vtgatepb.Session{TargetString: "@primary"}
+	_, err := executorExec(ctx, executor, session, "set @foo = (select id from user where id = 1) + 1", nil)
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]*querypb.BindVariable{"foo": sqltypes.Int64BindVariable(2)}, session.UserDefinedVariables, "")
+	assert.Equal(t, "select id from `user` where id = 1", sbc1.StringQueries()[0])
+	assert.Empty(t, sbc2.Queries)
+
+	// the variable is used to route the next query
+	sbc1.Queries = nil
+	_, err = executorExec(ctx, executor, session, "select id from user where id = @foo - 1", nil)
+	require.NoError(t, err)
+	assert.Len(t, sbc1.Queries, 1)
+	assert.Empty(t, sbc2.Queries)
+}
+
+func TestSetUDVInSelect(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+
+	sbc1.SetResults([]*sqltypes.Result{
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("@foo := `name`|id", "varchar|int64"), "a|1"),
+	})
+	session := &vtgatepb.Session{TargetString: "@primary"}
+	_, err := executorExec(ctx, executor, session, "select @foo := name, id from user where id = 1", nil)
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]*querypb.BindVariable{"foo": sqltypes.StringBindVariable("a")}, session.UserDefinedVariables, "")
+	assert.Equal(t, "select `name` as `@foo := ``name```, id from `user` where id = 1", sbc1.StringQueries()[0])
+}
+
 func createMap(keys []string, values []any) map[string]*querypb.BindVariable {
 	result := make(map[string]*querypb.BindVariable)
 	for i, key := range keys {
@@ -47,9 +47,11 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/queryacl"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -1307,6 +1309,29 @@ func TestExecutorDDLFk(t *testing.T) {
 	}
 }
 
+func TestExecutorQueryACL(t *testing.T) {
+	acl, err := queryacl.Parse([]byte(`{"app": [{"tables": ["TestExecutor.*"], "privileges": ["SELECT"]}]}`))
+	require.NoError(t, err)
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+	queryacl.Set(acl)
+	defer queryacl.Set(nil)
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+
+	ctx = callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("app"))
+	_, err = executorExec(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+
+	_, err = executorExec(ctx, executor, session, "delete from user where id = 1", nil)
+	require.EqualError(t, err, "User 'app' is not authorized to run DML statements on table TestExecutor.user")
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+
+	// The users without grants cannot query anything.
+	ctx = callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("other"))
+	_, err = executorExec(ctx, executor, session, "select id from user where id = 1", nil)
+	require.EqualError(t, err, "User 'other' is not authorized to run SELECT statements on table TestExecutor.user")
+}
+
 func TestExecutorAlterVSchemaKeyspace(t *testing.T) {
 	vschemaacl.AuthorizedDDLUsers = "%"
 	defer func() {
@@ -1594,7 +1619,7 @@ func assertCacheContains(t *testing.T, e *Executor, vc *vcursorImpl, sql string)
 			return true
 		})
 	} else {
-		h := e.hashPlan(context.Background(), vc, sql)
+		h := e.hashPlan(context.Background(), vc, sql, "")
 		plan, _ = e.plans.Get(h, e.epoch.Load())
 	}
 	require.Truef(t, plan != nil, "plan not found for query: %s", sql)
@@ -1610,7 +1635,7 @@ func getPlanCached(t *testing.T, ctx context.Context, e *Executor, vcursor *vcur
 
 	stmt, reservedVars, err := parseAndValidateQuery(sql, sqlparser.NewTestParser())
 	require.NoError(t, err)
-	plan, err := e.getPlan(context.Background(), vcursor, sql, stmt, comments, bindVars, reservedVars /* normalize */, e.normalize, logStats)
+	plan, err := e.getPlan(context.Background(), vcursor, sql, stmt, comments, bindVars, reservedVars /* normalize */, e.normalize, false, logStats)
 	require.NoError(t, err)
 
 	// Wait for cache to settle
@@ -1711,6 +1736,57 @@ func TestGetPlanCacheNormalized(t *testing.T) {
 	})
 }
 
+func TestGetPlanCacheBindVarTypes(t *testing.T) {
+	r, _, _, _, ctx := createExecutorEnv(t)
+	r.normalize = true
+	vc, _ := newVCursorImpl(NewSafeSession(&vtgatepb.Session{TargetString: "@primary"}), makeComments(""), r, nil, r.vm, r.VSchema(), r.resolver.resolver, nil, false, pv)
+
+	getPlan := func(bindVars map[string]*querypb.BindVariable) (*engine.Plan, *logstats.LogStats) {
+		logStats := logstats.NewLogStats(ctx, "Test", "", "", nil)
+		stmt, reservedVars, err := parseAndValidateQuery("select * from music_user_map where id = :v1 and user_id in ::v2", sqlparser.NewTestParser())
+		require.NoError(t, err)
+		plan, err := r.getPlan(ctx, vc, "", stmt, makeComments(""), bindVars, reservedVars, true, true, logStats)
+		require.NoError(t, err)
+		// Wait for cache to settle
+		time.Sleep(100 * time.Millisecond)
+		return plan, logStats
+	}
+	hits, misses := preparedPlanCacheHits.Get(), preparedPlanCacheMisses.Get()
+
+	intVars := map[string]*querypb.BindVariable{
+		"v1": sqltypes.Int64BindVariable(1),
+		"v2": sqltypes.TestBindVariable([]any{1, 2}),
+	}
+	plan1, logStats1 := getPlan(intVars)
+	assert.False(t, logStats1.CachedPlan)
+	assert.Equal(t, "select * from music_user_map where id = :v1 and user_id in ::v2", logStats1.SQL)
+	assertCacheSize(t, r.plans, 1)
+
+	plan2, logStats2 := getPlan(map[string]*querypb.BindVariable{
+		"v1": sqltypes.Int64BindVariable(2),
+		"v2": sqltypes.TestBindVariable([]any{3}),
+	})
+	assert.True(t, logStats2.CachedPlan)
+	assert.Same(t, plan1, plan2)
+	assert.Len(t, logStats1.PlanFingerprint, 16)
+	assert.Equal(t, logStats1.PlanFingerprint, logStats2.PlanFingerprint)
+	assertCacheSize(t, r.plans, 1)
+
+	// the same query with bind variables of other types gets its own plan
+	plan3, logStats3 := getPlan(map[string]*querypb.BindVariable{
+		"v1": sqltypes.StringBindVariable("1"),
+		"v2": sqltypes.TestBindVariable([]any{1, 2}),
+	})
+	assert.False(t, logStats3.CachedPlan)
+	assert.NotSame(t, plan1, plan3)
+	assert.NotEqual(t, logStats1.PlanFingerprint, logStats3.PlanFingerprint)
+	assert.Equal(t, logStats1.SQL, logStats3.SQL)
+	assertCacheSize(t, r.plans, 2)
+
+	assert.EqualValues(t, 1, preparedPlanCacheHits.Get()-hits)
+	assert.EqualValues(t, 2, preparedPlanCacheMisses.Get()-misses)
+}
+
 func TestGetPlanNormalized(t *testing.T) {
 	r, _, _, _, ctx := createExecutorEnv(t)
 
@@ -1780,7 +1856,7 @@ func TestGetPlanPriority(t *testing.T) {
 			assert.NoError(t, err)
 			crticalityFromStatement, _ := sqlparser.GetPriorityFromStatement(stmt)
 
-			_, err = r.getPlan(context.Background(), vCursor, testCase.sql, stmt, makeComments("/* some comment */"), map[string]*querypb.BindVariable{}, nil, true, logStats)
+			_, err = r.getPlan(context.Background(), vCursor, testCase.sql, stmt, makeComments("/* some comment */"), map[string]*querypb.BindVariable{}, nil, true, false, logStats)
 			if testCase.expectedError != nil {
 				assert.ErrorIs(t, err, testCase.expectedError)
 			} else {
@@ -2383,16 +2459,8 @@ func TestExecutorSavepointInTx(t *testing.T) {
 	require.NoError(t, err)
 	_, err = exec(executor, session, "rollback")
 	require.NoError(t, err)
+	// savepoint a is released before any shard joins the transaction, so it is not replayed.
 	sbc1WantQueries := []*querypb.BoundQuery{{
-		Sql:           "savepoint a",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "rollback to a",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "release savepoint a",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
 		Sql:           "select id from `user` where id = 1",
 		BindVariables: map[string]*querypb.BindVariable{},
 	}, {
@@ -2407,24 +2475,6 @@ func TestExecutorSavepointInTx(t *testing.T) {
 	}}
 
 	sbc2WantQueries := []*querypb.BoundQuery{{
-		Sql:           "savepoint a",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "rollback to a",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "release savepoint a",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "savepoint b",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "rollback to b",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
-		Sql:           "release savepoint b",
-		BindVariables: map[string]*querypb.BindVariable{},
-	}, {
 		Sql:           "select id from `user` where id = 3",
 		BindVariables: map[string]*querypb.BindVariable{},
 	}}
@@ -2484,14 +2534,9 @@ func TestExecutorSavepointInTxWithReservedConn(t *testing.T) {
 		Sql: "release savepoint a", BindVariables: emptyBV,
 	}}
 
+	// releasing savepoint a also releases savepoint b, nothing is left to replay.
 	sbc2WantQueries := []*querypb.BoundQuery{{
 		Sql: "set sql_mode = ''", BindVariables: emptyBV,
-	}, {
-		Sql: "savepoint a", BindVariables: emptyBV,
-	}, {
-		Sql: "savepoint b", BindVariables: emptyBV,
-	}, {
-		Sql: "release savepoint a", BindVariables: emptyBV,
 	}, {
 		Sql: "select id from `user` where id = 3", BindVariables: emptyBV,
 	}}
@@ -2552,6 +2597,56 @@ func TestExecutorSavepointWithoutTx(t *testing.T) {
 	testQueryLog(t, executor, logChan, "TestExecute", "SELECT", "select id from `user` where id = 3", 1)
 }
 
+func TestExecutorSavepointAcrossShards(t *testing.T) {
+	executor, sbc1, sbc2, _, _ := createExecutorEnv(t)
+
+	session := NewSafeSession(&vtgatepb.Session{Autocommit: false, TargetString: "@primary"})
+	_, err := exec(executor, session, "savepoint a")
+	require.NoError(t, err)
+	_, err = exec(executor, session, "select id from user where id = 1")
+	require.NoError(t, err)
+	_, err = exec(executor, session, "savepoint b")
+	require.NoError(t, err)
+	_, err = exec(executor, session, "savepoint c")
+	require.NoError(t, err)
+	_, err = exec(executor, session, "rollback to b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"savepoint a", "savepoint b"}, session.Savepoints)
+
+	// sbc2 joins the transaction after the savepoints, it gets the active ones only.
+	_, err = exec(executor, session, "select id from user where id = 3")
+	require.NoError(t, err)
+	_, err = exec(executor, session, "rollback to A")
+	require.NoError(t, err)
+	_, err = exec(executor, session, "rollback to c")
+	require.ErrorContains(t, err, "SAVEPOINT does not exist: rollback to c")
+	_, err = exec(executor, session, "release savepoint a")
+	require.NoError(t, err)
+	assert.Empty(t, session.Savepoints)
+	_, err = exec(executor, session, "rollback")
+	require.NoError(t, err)
+
+	emptyBV := map[string]*querypb.BindVariable{}
+	sbc1WantQueries := []*querypb.BoundQuery{
+		{Sql: "savepoint a", BindVariables: emptyBV},
+		{Sql: "select id from `user` where id = 1", BindVariables: emptyBV},
+		{Sql: "savepoint b", BindVariables: emptyBV},
+		{Sql: "savepoint c", BindVariables: emptyBV},
+		{Sql: "rollback to b", BindVariables: emptyBV},
+		{Sql: "rollback to A", BindVariables: emptyBV},
+		{Sql: "release savepoint a", BindVariables: emptyBV},
+	}
+	sbc2WantQueries := []*querypb.BoundQuery{
+		{Sql: "savepoint a", BindVariables: emptyBV},
+		{Sql: "savepoint b", BindVariables: emptyBV},
+		{Sql: "select id from `user` where id = 3", BindVariables: emptyBV},
+		{Sql: "rollback to A", BindVariables: emptyBV},
+		{Sql: "release savepoint a", BindVariables: emptyBV},
+	}
+	utils.MustMatch(t, sbc1WantQueries, sbc1.Queries, "")
+	utils.MustMatch(t, sbc2WantQueries, sbc2.Queries, "")
+}
+
 func TestExecutorCallProc(t *testing.T) {
 	executor, sbc1, sbc2, sbcUnsharded, _ := createExecutorEnv(t)
 
@@ -2620,15 +2715,16 @@ func TestExecutorCallProc(t *testing.T) {
 func TestExecutorTempTable(t *testing.T) {
 	executor, _, _, sbcUnsharded, ctx := createExecutorEnv(t)
 
-	initialWarningsCount := warnings.Counts()["WarnUnshardedOnly"]
 	executor.warnShardedOnly = true
 	creatQuery := "create temporary table temp_t(id bigint primary key)"
 	session := NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded})
 	_, err := executor.Execute(ctx, nil, "TestExecutorTempTable", session, creatQuery, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, 1, sbcUnsharded.ExecCount.Load())
-	assert.NotEmpty(t, session.Warnings)
-	assert.Equal(t, initialWarningsCount+1, warnings.Counts()["WarnUnshardedOnly"], "warnings count")
+	// temporary tables are supported in sharded keyspaces as well.
+	assert.Empty(t, session.Warnings)
+	// only the temporary tables of sharded keyspaces need to be tracked for routing.
+	assert.Empty(t, session.TempTables)
 
 	before := executor.plans.Len()
 
@@ -2638,6 +2734,49 @@ func TestExecutorTempTable(t *testing.T) {
 	assert.Equal(t, before, executor.plans.Len())
 }
 
+func TestExecutorTempTableSharded(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+
+	session := NewSafeSession(&vtgatepb.Session{TargetString: KsTestSharded})
+	_, err := executor.Execute(ctx, nil, "TestExecutorTempTableSharded", session, "create temporary table temp_t(id bigint primary key)", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{KsTestSharded + ".temp_t"}, session.TempTables)
+	require.Len(t, session.ShardSessions, 1)
+	assert.NotZero(t, session.ShardSessions[0].ReservedId)
+
+	for _, query := range []string{
+		"insert into temp_t(id) values (1)",
+		"select * from temp_t",
+		"update temp_t set id = 2",
+		"select t.id from temp_t as t join user on t.id = user.id where user.id = 1",
+		"delete from temp_t",
+	} {
+		_, err = executor.Execute(ctx, nil, "TestExecutorTempTableSharded", session, query, nil)
+		require.NoError(t, err, query)
+	}
+	assert.Empty(t, sbc2.Queries)
+	require.Len(t, session.ShardSessions, 1)
+
+	_, err = executor.Execute(ctx, nil, "TestExecutorTempTableSharded", session, "drop temporary table temp_t", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.TempTables)
+	assert.Empty(t, sbc2.Queries)
+	assert.Equal(t, []string{
+		"create temporary table temp_t (\n\tid bigint primary key\n)",
+		"insert into temp_t(id) values (1)",
+		"select * from temp_t",
+		"update temp_t set id = 2",
+		"select t.id from temp_t as t",
+		"select 1 from `user` where `user`.id = 1 and `user`.id = :t_id",
+		"delete from temp_t",
+		"drop temporary table temp_t",
+	}, sbc1.StringQueries())
+
+	// The table is not known anymore.
+	_, err = executor.Execute(ctx, nil, "TestExecutorTempTableSharded", session, "select * from temp_t", nil)
+	require.ErrorContains(t, err, "table temp_t not found")
+}
+
 func TestExecutorShowVitessMigrations(t *testing.T) {
 	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
 
@@ -2684,6 +2823,47 @@ func TestExecutorVExplainQueries(t *testing.T) {
 	require.Contains(t, txt, lookupQuery)
 }
 
+func TestExecutorVExplainTrace(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+
+	query := "vexplain trace select count(*) from user"
+	trace := func(qr *sqltypes.Result) map[string]any {
+		require.Len(t, qr.Rows, 1)
+		var plan map[string]any
+		require.NoError(t, json.Unmarshal([]byte(qr.Rows[0][0].ToString()), &plan))
+		return plan
+	}
+	check := func(plan map[string]any) {
+		// the sandbox connections return one row from each of the 8 shards
+		assert.Equal(t, "Aggregate", plan["OperatorType"])
+		assert.EqualValues(t, 1, plan["Calls"])
+		assert.EqualValues(t, 8, plan["RowsIn"])
+		assert.EqualValues(t, 1, plan["RowsOut"])
+		assert.NotEmpty(t, plan["Time"])
+
+		route := plan["Inputs"].([]any)[0].(map[string]any)
+		assert.Equal(t, "Route", route["OperatorType"])
+		assert.EqualValues(t, 8, route["RowsOut"])
+		assert.EqualValues(t, 8, route["ShardsQueried"])
+		assert.EqualValues(t, 8*len("1foo"), route["MemoryUsed"])
+	}
+
+	session := NewAutocommitSession(&vtgatepb.Session{})
+	qr, err := executor.Execute(ctx, nil, "TestExecutorVExplainTrace", session, query, nil)
+	require.NoError(t, err)
+	check(trace(qr))
+
+	// Test the streaming side as well
+	var result *sqltypes.Result
+	session = NewAutocommitSession(&vtgatepb.Session{})
+	err = executor.StreamExecute(ctx, nil, "TestExecutorVExplainTrace", session, query, nil, func(qr *sqltypes.Result) error {
+		result = qr
+		return nil
+	})
+	require.NoError(t, err)
+	check(trace(result))
+}
+
 func TestExecutorStartTxnStmt(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnv(t)
 
@@ -2730,6 +2910,43 @@ func TestExecutorStartTxnStmt(t *testing.T) {
 	}
 }
 
+func TestExecutorStartTxnConsistentSnapshot(t *testing.T) {
+	var conns []*sandboxconn.SandboxConn
+	executor, ctx := createExecutorEnvCallback(t, func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		if ks == KsTestSharded {
+			conn.BeginSessionStateChanges = "uuid:1-" + shard
+			conns = append(conns, conn)
+		}
+	})
+
+	// The snapshots are begun on all the shards of the keyspace of the session.
+	session := NewAutocommitSession(&vtgatepb.Session{TargetString: KsTestSharded})
+	_, err := executor.Execute(ctx, nil, "TestExecutorStartTxnConsistentSnapshot", session, "start transaction with consistent snapshot, read only", nil)
+	require.NoError(t, err)
+	for _, conn := range conns {
+		assert.EqualValues(t, 1, conn.BeginCount.Load())
+	}
+	assert.Len(t, session.ShardSessions, len(conns))
+	assert.Len(t, session.SnapshotGtids, len(conns))
+	assert.Equal(t, "uuid:1--20", session.SnapshotGtids[KsTestSharded+"/-20"])
+
+	qr, err := executor.Execute(ctx, nil, "TestExecutorStartTxnConsistentSnapshot", session, "select @@snapshot_gtids", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Contains(t, qr.Rows[0][0].ToString(), `"TestExecutor/-20":"uuid:1--20"`)
+
+	_, err = executor.Execute(ctx, nil, "TestExecutorStartTxnConsistentSnapshot", session, "rollback", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.SnapshotGtids)
+
+	// Without a keyspace, the snapshots are begun on each shard when it is first read.
+	session = NewAutocommitSession(&vtgatepb.Session{})
+	_, err = executor.Execute(ctx, nil, "TestExecutorStartTxnConsistentSnapshot", session, "start transaction with consistent snapshot, read only", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.ShardSessions)
+	assert.EqualValues(t, 1, conns[0].BeginCount.Load())
+}
+
 func TestExecutorPrepareExecute(t *testing.T) {
 	executor, _, _, _, _ := createExecutorEnv(t)
 
@@ -2937,3 +3154,31 @@ func exec(executor *Executor, session *SafeSession, sql string) (*sqltypes.Resul
 func makeComments(text string) sqlparser.MarginComments {
 	return sqlparser.MarginComments{Trailing: text}
 }
+
+func TestMovedTables(t *testing.T) {
+	srvVSchema := &vschemapb.SrvVSchema{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1", ToTables: []string{"src.t1"}},
+				{FromTable: "src.t1", ToTables: []string{"src.t1"}},
+				{FromTable: "dst.t1", ToTables: []string{"src.t1"}},
+				{FromTable: "dst.t1@replica", ToTables: []string{"src.t1"}},
+				{FromTable: "bad", ToTables: []string{"src.t1", "dst.t1"}},
+			},
+		},
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"src": {Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}}},
+			"dst": {Tables: map[string]*vschemapb.Table{"t1": {}}},
+		},
+	}
+	vschema := vindexes.BuildVSchema(srvVSchema, sqlparser.NewTestParser())
+
+	tables := movedTables(vschema)
+	for _, keyspaceTables := range tables {
+		sort.Strings(keyspaceTables)
+	}
+	assert.Equal(t, map[string][]string{
+		"src": {"t1"},
+		"dst": {"t1"},
+	}, tables)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exportsink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/log"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// DownloadPath is the http path of the files of the download sink.
+const DownloadPath = "/select-into-outfile/"
+
+// downloadChunkSize is the size of the chunks in which a file is sent.
+const downloadChunkSize = 64 * 1024
+
+var (
+	// downloadTTL is the time after which a file that is not downloaded is removed.
+	downloadTTL = time.Hour
+
+	downloads = &downloadSink{files: map[string]*download{}}
+)
+
+// downloadSink keeps the export files in a temporary directory, until they are
+// downloaded from DownloadPath followed by their name. A file is removed as soon
+// as its download starts, so that it can only be downloaded once.
+type downloadSink struct {
+	mu    sync.Mutex
+	dir   string
+	files map[string]*download
+}
+
+// download is a complete file of the download sink.
+type download struct {
+	path    string
+	expires time.Time
+}
+
+// downloadFile is a file of the download sink that is being written.
+type downloadFile struct {
+	*os.File
+	sink *downloadSink
+	name string
+}
+
+// Create implements the Sink interface.
+func (ds *downloadSink) Create(ctx context.Context, name string, overwrite bool) (File, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid name for a download: '%s'", name)
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.removeExpiredLocked()
+	if _, exists := ds.files[name]; exists && !overwrite {
+		return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "download '%s' already exists", name)
+	}
+	if ds.dir == "" {
+		dir, err := os.MkdirTemp("", "select-into-outfile-")
+		if err != nil {
+			return nil, err
+		}
+		ds.dir = dir
+	}
+	f, err := os.CreateTemp(ds.dir, "download-")
+	if err != nil {
+		return nil, err
+	}
+	return &downloadFile{File: f, sink: ds, name: name}, nil
+}
+
+// Close implements the File interface. It makes the file available for download.
+func (f *downloadFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	f.sink.mu.Lock()
+	defer f.sink.mu.Unlock()
+	if old, exists := f.sink.files[f.name]; exists {
+		os.Remove(old.path)
+	}
+	f.sink.files[f.name] = &download{path: f.Name(), expires: time.Now().Add(downloadTTL)}
+	return nil
+}
+
+// Abort implements the File interface.
+func (f *downloadFile) Abort() error {
+	f.File.Close()
+	return os.Remove(f.Name())
+}
+
+func (ds *downloadSink) removeExpiredLocked() {
+	now := time.Now()
+	for name, d := range ds.files {
+		if now.After(d.expires) {
+			os.Remove(d.path)
+			delete(ds.files, name)
+		}
+	}
+}
+
+// serveHTTP sends a file in chunks, without a Content-Length, which makes it a chunked http response.
+func (ds *downloadSink) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, DownloadPath)
+
+	ds.mu.Lock()
+	ds.removeExpiredLocked()
+	d, ok := ds.files[name]
+	delete(ds.files, name)
+	ds.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	defer os.Remove(d.path)
+
+	f, err := os.Open(d.path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				log.Warningf("Failed to send download %s: %v", name, err)
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Warningf("Failed to read download %s: %v", name, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package exportsink implements the destinations of the files written by vtgate
+// for SELECT ... INTO OUTFILE and DUMPFILE on sharded keyspaces.
+//
+// The sink of a file is chosen by the scheme of its name:
+// - no scheme, or file://: a file in --select-into-outfile-dir on the vtgate host
+// - download://: a file that is downloaded once from the vtgate http port
+// - s3:// and gs://: an object in S3 or GCS, when their plugin is linked into vtgate
+//
+// Only the sinks listed in --select-into-outfile-sinks can be used.
+package exportsink
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// File is an export file that is being written.
+type File interface {
+	io.Writer
+
+	// Close completes the file.
+	Close() error
+
+	// Abort discards the file.
+	Abort() error
+}
+
+// Sink creates the export files of one scheme.
+type Sink interface {
+	// Create creates the file with the given name, without the scheme.
+	// An existing file is only replaced when overwrite is set.
+	Create(ctx context.Context, name string, overwrite bool) (File, error)
+}
+
+var (
+	// enabledSinks are the schemes of the sinks that can be used.
+	enabledSinks []string
+
+	mu    sync.Mutex
+	sinks = map[string]Sink{}
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&enabledSinks, "select-into-outfile-sinks", enabledSinks, "Sinks that vtgate can write the result of SELECT ... INTO OUTFILE on sharded keyspaces to: file, download, s3, gs. Such queries fail when it is empty.")
+	fs.StringVar(&exportDir, "select-into-outfile-dir", exportDir, "Directory of the files written by SELECT ... INTO OUTFILE on sharded keyspaces with the file sink.")
+	fs.DurationVar(&downloadTTL, "select-into-outfile-download-ttl", downloadTTL, "Time after which a file written by SELECT ... INTO OUTFILE with the download sink is removed, if it has not been downloaded.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerFlags)
+	servenv.OnParseFor("vtcombo", registerFlags)
+
+	Register("file", &fileSink{})
+	Register("download", downloads)
+	servenv.OnRun(func() {
+		if Enabled("download") {
+			servenv.HTTPHandleFunc(DownloadPath, downloads.serveHTTP)
+		}
+	})
+}
+
+// Register makes a sink available for the file names with the given scheme.
+func Register(scheme string, sink Sink) {
+	mu.Lock()
+	defer mu.Unlock()
+	sinks[scheme] = sink
+}
+
+// Enabled returns true if the sink of the scheme can be used.
+func Enabled(scheme string) bool {
+	return slices.Contains(enabledSinks, scheme)
+}
+
+// Create creates the export file with the given name in the sink of its scheme.
+func Create(ctx context.Context, fileName string, overwrite bool) (File, error) {
+	scheme, name, found := strings.Cut(fileName, "://")
+	if !found {
+		scheme, name = "file", fileName
+	}
+	if !Enabled(scheme) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "SELECT ... INTO OUTFILE to %s is not enabled, see --select-into-outfile-sinks", scheme)
+	}
+
+	mu.Lock()
+	sink, ok := sinks[scheme]
+	mu.Unlock()
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown sink for SELECT ... INTO OUTFILE: %s", scheme)
+	}
+	return sink.Create(ctx, name, overwrite)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exportsink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setFlags(t *testing.T, sinks []string, dir string) {
+	oldSinks, oldDir := enabledSinks, exportDir
+	enabledSinks, exportDir = sinks, dir
+	t.Cleanup(func() {
+		enabledSinks, exportDir = oldSinks, oldDir
+	})
+}
+
+func writeFile(t *testing.T, fileName string, overwrite bool, data string) error {
+	f, err := Create(context.Background(), fileName, overwrite)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write([]byte(data))
+	require.NoError(t, err)
+	return f.Close()
+}
+
+func TestCreateDisabled(t *testing.T) {
+	setFlags(t, []string{"download"}, t.TempDir())
+
+	_, err := Create(context.Background(), "x.txt", false)
+	require.EqualError(t, err, "SELECT ... INTO OUTFILE to file is not enabled, see --select-into-outfile-sinks")
+	_, err = Create(context.Background(), "s3://bucket/x.txt", false)
+	require.EqualError(t, err, "SELECT ... INTO OUTFILE to s3 is not enabled, see --select-into-outfile-sinks")
+
+	setFlags(t, []string{"unknown"}, "")
+	_, err = Create(context.Background(), "unknown://x.txt", false)
+	require.EqualError(t, err, "unknown sink for SELECT ... INTO OUTFILE: unknown")
+}
+
+func TestFileSink(t *testing.T) {
+	dir := t.TempDir()
+	setFlags(t, []string{"file"}, dir)
+
+	require.NoError(t, writeFile(t, "x.txt", false, "1\n"))
+	require.NoError(t, writeFile(t, "file://"+filepath.Join(dir, "y.txt"), false, "2\n"))
+	data, err := os.ReadFile(filepath.Join(dir, "x.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n", string(data))
+	data, err = os.ReadFile(filepath.Join(dir, "y.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "2\n", string(data))
+
+	err = writeFile(t, "x.txt", false, "3\n")
+	require.EqualError(t, err, "file 'x.txt' already exists")
+	require.NoError(t, writeFile(t, "x.txt", true, "3\n"))
+	data, err = os.ReadFile(filepath.Join(dir, "x.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "3\n", string(data))
+
+	for _, name := range []string{"../x.txt", "/etc/passwd", ".", "a/../../x.txt"} {
+		err = writeFile(t, name, false, "")
+		require.EqualError(t, err, "file '"+name+"' is not in --select-into-outfile-dir")
+	}
+
+	f, err := Create(context.Background(), "z.txt", false)
+	require.NoError(t, err)
+	require.NoError(t, f.Abort())
+	_, err = os.Stat(filepath.Join(dir, "z.txt"))
+	require.True(t, os.IsNotExist(err))
+
+	setFlags(t, []string{"file"}, "")
+	err = writeFile(t, "x.txt", false, "")
+	require.EqualError(t, err, "--select-into-outfile-dir is required to write files on the vtgate host")
+}
+
+func TestDownloadSink(t *testing.T) {
+	setFlags(t, []string{"download"}, "")
+	server := httptest.NewServer(http.HandlerFunc(downloads.serveHTTP))
+	defer server.Close()
+
+	get := func(name string) (int, string) {
+		resp, err := http.Get(server.URL + DownloadPath + name)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	require.NoError(t, writeFile(t, "download://x.csv", false, "1,a\n2,b\n"))
+	err := writeFile(t, "download://x.csv", false, "")
+	require.EqualError(t, err, "download 'x.csv' already exists")
+	require.NoError(t, writeFile(t, "download://x.csv", true, "3,c\n"))
+
+	_, err = Create(context.Background(), "download://a/x.csv", false)
+	require.EqualError(t, err, "invalid name for a download: 'a/x.csv'")
+
+	f, err := Create(context.Background(), "download://y.csv", false)
+	require.NoError(t, err)
+	require.NoError(t, f.Abort())
+
+	status, body := get("x.csv")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "3,c\n", body)
+
+	// a file can only be downloaded once
+	status, _ = get("x.csv")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = get("y.csv")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	oldTTL := downloadTTL
+	defer func() { downloadTTL = oldTTL }()
+	downloadTTL = -1
+	require.NoError(t, writeFile(t, "download://z.csv", false, "4,d\n"))
+	status, _ = get("z.csv")
+	assert.Equal(t, http.StatusNotFound, status)
+}
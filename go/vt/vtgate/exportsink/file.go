@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exportsink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// exportDir is the directory of the files of the file sink.
+var exportDir string
+
+// fileSink writes the export files to the local file system, like MySQL does with secure_file_priv.
+// Relative names are in the export directory, and absolute names have to be in it.
+type fileSink struct{}
+
+// localFile is a file of the file sink.
+type localFile struct {
+	*os.File
+}
+
+// Create implements the Sink interface.
+func (fs *fileSink) Create(ctx context.Context, name string, overwrite bool) (File, error) {
+	if exportDir == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "--select-into-outfile-dir is required to write files on the vtgate host")
+	}
+	dir, err := filepath.Abs(exportDir)
+	if err != nil {
+		return nil, err
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if rel, err := filepath.Rel(dir, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "file '%s' is not in --select-into-outfile-dir", name)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o640)
+	if os.IsExist(err) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "file '%s' already exists", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &localFile{File: f}, nil
+}
+
+// Abort implements the File interface.
+func (f *localFile) Abort() error {
+	f.File.Close()
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcsexportsink implements the gs:// export sink of vtgate, which writes the
+// result of SELECT ... INTO OUTFILE to an object in Google Cloud Storage, named gs://bucket/object.
+package gcsexportsink
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"vitess.io/vitess/go/trace"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/exportsink"
+)
+
+func init() {
+	exportsink.Register("gs", &gcsSink{})
+}
+
+// gcsSink uploads the export files to Google Cloud Storage.
+type gcsSink struct {
+	mu      sync.Mutex
+	_client *storage.Client
+}
+
+// gcsFile is an export file that is being uploaded.
+type gcsFile struct {
+	*storage.Writer
+	name   string
+	cancel context.CancelFunc
+}
+
+func (gs *gcsSink) client(ctx context.Context) (*storage.Client, error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs._client == nil {
+		// The client is kept after the query that creates it,
+		// so it gets its own context, that keeps the span information.
+		ctx = trace.CopySpan(context.Background(), ctx)
+		authClient, err := google.DefaultClient(ctx, storage.ScopeReadWrite)
+		if err != nil {
+			return nil, err
+		}
+		client, err := storage.NewClient(ctx, option.WithHTTPClient(authClient))
+		if err != nil {
+			return nil, err
+		}
+		gs._client = client
+	}
+	return gs._client, nil
+}
+
+// Create implements the exportsink.Sink interface.
+func (gs *gcsSink) Create(ctx context.Context, name string, overwrite bool) (exportsink.File, error) {
+	bucket, object, _ := strings.Cut(name, "/")
+	if bucket == "" || object == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid GCS object name 'gs://%s', expected gs://bucket/object", name)
+	}
+	client, err := gs.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	obj := client.Bucket(bucket).Object(object)
+	if !overwrite {
+		obj = obj.If(storage.Conditions{DoesNotExist: true})
+	}
+	// Canceling the context of the writer aborts the upload.
+	ctx, cancel := context.WithCancel(ctx)
+	return &gcsFile{Writer: obj.NewWriter(ctx), name: name, cancel: cancel}, nil
+}
+
+// Close implements the exportsink.File interface. It waits for the upload to complete.
+func (f *gcsFile) Close() error {
+	defer f.cancel()
+	err := f.Writer.Close()
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Code == http.StatusPreconditionFailed {
+		return vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "file 'gs://%s' already exists", f.name)
+	}
+	return err
+}
+
+// Abort implements the exportsink.File interface.
+func (f *gcsFile) Abort() error {
+	f.cancel()
+	f.Writer.Close()
+	return nil
+}
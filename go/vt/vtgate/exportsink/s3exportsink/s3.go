@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3exportsink implements the s3:// export sink of vtgate, which writes the
+// result of SELECT ... INTO OUTFILE to an object in S3, named s3://bucket/key.
+//
+// AWS access credentials are configured via standard AWS means, such as:
+// - AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables
+// - credentials file at ~/.aws/credentials
+// - if running on an EC2 instance, an IAM role
+package s3exportsink
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/spf13/pflag"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/exportsink"
+)
+
+var (
+	// AWS API region
+	region string
+
+	// AWS endpoint, defaults to amazonaws.com but appliances may use a different location
+	endpoint string
+
+	// forcePath is used to ensure that the certificate and path used match the endpoint + region
+	forcePath bool
+
+	errAborted = errors.New("export aborted")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&region, "select-into-outfile-s3-region", "us-east-1", "AWS region of the S3 buckets written by SELECT ... INTO OUTFILE.")
+	fs.StringVar(&endpoint, "select-into-outfile-s3-endpoint", "", "endpoint of the S3 backend written by SELECT ... INTO OUTFILE (region must be provided).")
+	fs.BoolVar(&forcePath, "select-into-outfile-s3-force-path-style", false, "force the s3 path style for SELECT ... INTO OUTFILE.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerFlags)
+	exportsink.Register("s3", &s3Sink{})
+}
+
+// s3Sink uploads the export files to S3.
+type s3Sink struct {
+	mu      sync.Mutex
+	_client *s3.S3
+}
+
+// s3File is an export file that is being uploaded.
+type s3File struct {
+	*io.PipeWriter
+	done chan error
+}
+
+func (ss *s3Sink) client() (*s3.S3, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss._client == nil {
+		session, err := session.NewSession()
+		if err != nil {
+			return nil, err
+		}
+		ss._client = s3.New(session, &aws.Config{
+			Endpoint:         aws.String(endpoint),
+			Region:           aws.String(region),
+			S3ForcePathStyle: aws.Bool(forcePath),
+		})
+	}
+	return ss._client, nil
+}
+
+// Create implements the exportsink.Sink interface.
+func (ss *s3Sink) Create(ctx context.Context, name string, overwrite bool) (exportsink.File, error) {
+	bucket, key, _ := strings.Cut(name, "/")
+	if bucket == "" || key == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid S3 object name 's3://%s', expected s3://bucket/key", name)
+	}
+	client, err := ss.client()
+	if err != nil {
+		return nil, err
+	}
+	if !overwrite {
+		_, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: &bucket, Key: &key})
+		if err == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "file 's3://%s' already exists", name)
+		}
+		var aerr awserr.RequestFailure
+		if !errors.As(err, &aerr) || aerr.StatusCode() != 404 {
+			return nil, err
+		}
+	}
+
+	reader, writer := io.Pipe()
+	file := &s3File{PipeWriter: writer, done: make(chan error, 1)}
+	go func() {
+		uploader := s3manager.NewUploaderWithClient(client)
+		// Using UploadWithContext breaks uploading to Minio and Ceph https://github.com/vitessio/vitess/issues/14188
+		_, err := uploader.Upload(&s3manager.UploadInput{
+			Bucket: &bucket,
+			Key:    &key,
+			Body:   reader,
+		})
+		reader.CloseWithError(err)
+		file.done <- err
+	}()
+	return file, nil
+}
+
+// Close implements the exportsink.File interface. It waits for the upload to complete.
+func (f *s3File) Close() error {
+	f.PipeWriter.Close()
+	return <-f.done
+}
+
+// Abort implements the exportsink.File interface. The upload fails, and its parts are removed.
+func (f *s3File) Abort() error {
+	f.PipeWriter.CloseWithError(errAborted)
+	<-f.done
+	return nil
+}
@@ -182,6 +182,21 @@ func (conn *FakeVTGateConn) CloseSession(ctx context.Context, session *vtgatepb.
 	panic("not implemented")
 }
 
+// OpenCursor please see vtgateconn.Impl.OpenCursor
+func (conn *FakeVTGateConn) OpenCursor(ctx context.Context, session *vtgatepb.Session, query string, bindVars map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	panic("not implemented")
+}
+
+// FetchCursor please see vtgateconn.Impl.FetchCursor
+func (conn *FakeVTGateConn) FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	panic("not implemented")
+}
+
+// CloseCursor please see vtgateconn.Impl.CloseCursor
+func (conn *FakeVTGateConn) CloseCursor(ctx context.Context, cursorID string) error {
+	panic("not implemented")
+}
+
 // ResolveTransaction please see vtgateconn.Impl.ResolveTransaction
 func (conn *FakeVTGateConn) ResolveTransaction(ctx context.Context, dtid string) error {
 	return nil
@@ -194,6 +209,11 @@ func (conn *FakeVTGateConn) VStream(ctx context.Context, tabletType topodatapb.T
 	return nil, fmt.Errorf("NYI")
 }
 
+// VStreamAck please see vtgateconn.Impl.VStreamAck
+func (conn *FakeVTGateConn) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return fmt.Errorf("NYI")
+}
+
 // Close please see vtgateconn.Impl.Close
 func (conn *FakeVTGateConn) Close() {
 }
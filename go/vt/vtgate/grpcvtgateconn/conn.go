@@ -53,6 +53,7 @@ func init() {
 		"vtclient",
 		"vtcombo",
 		"vtctl",
+		"vtstreamer",
 		"vttestserver",
 	} {
 		servenv.OnParseFor(cmd, registerFlags)
@@ -234,6 +235,57 @@ func (conn *vtgateConn) CloseSession(ctx context.Context, session *vtgatepb.Sess
 	return nil
 }
 
+func (conn *vtgateConn) OpenCursor(ctx context.Context, session *vtgatepb.Session, query string, bindVars map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	request := &vtgatepb.OpenCursorRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		Session:  session,
+		Query: &querypb.BoundQuery{
+			Sql:           query,
+			BindVariables: bindVars,
+		},
+		KeysetColumns: keysetColumns,
+	}
+	response, err := conn.c.OpenCursor(ctx, request)
+	if err != nil {
+		return "", vterrors.FromGRPC(err)
+	}
+	if response.Error != nil {
+		return "", vterrors.FromVTRPC(response.Error)
+	}
+	return response.CursorId, nil
+}
+
+func (conn *vtgateConn) FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	request := &vtgatepb.FetchCursorRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		CursorId: cursorID,
+		MaxRows:  int64(maxRows),
+	}
+	response, err := conn.c.FetchCursor(ctx, request)
+	if err != nil {
+		return nil, false, vterrors.FromGRPC(err)
+	}
+	if response.Error != nil {
+		return nil, false, vterrors.FromVTRPC(response.Error)
+	}
+	return sqltypes.Proto3ToResult(response.Result), response.Done, nil
+}
+
+func (conn *vtgateConn) CloseCursor(ctx context.Context, cursorID string) error {
+	request := &vtgatepb.CloseCursorRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		CursorId: cursorID,
+	}
+	response, err := conn.c.CloseCursor(ctx, request)
+	if err != nil {
+		return vterrors.FromGRPC(err)
+	}
+	if response.Error != nil {
+		return vterrors.FromVTRPC(response.Error)
+	}
+	return nil
+}
+
 func (conn *vtgateConn) ResolveTransaction(ctx context.Context, dtid string) error {
 	request := &vtgatepb.ResolveTransactionRequest{
 		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
@@ -274,6 +326,16 @@ func (conn *vtgateConn) VStream(ctx context.Context, tabletType topodatapb.Table
 	}, nil
 }
 
+func (conn *vtgateConn) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	request := &vtgatepb.VStreamAckRequest{
+		CallerId:      callerid.EffectiveCallerIDFromContext(ctx),
+		ConsumerGroup: consumerGroup,
+		Vgtid:         vgtid,
+	}
+	_, err := conn.c.VStreamAck(ctx, request)
+	return vterrors.FromGRPC(err)
+}
+
 func (conn *vtgateConn) Close() {
 	conn.cc.Close()
 }
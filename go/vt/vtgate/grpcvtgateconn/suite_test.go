@@ -231,6 +231,42 @@ func (f *fakeVTGateService) CloseSession(ctx context.Context, session *vtgatepb.
 	panic("unimplemented")
 }
 
+// OpenCursor is part of the VTGateService interface
+func (f *fakeVTGateService) OpenCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string) (string, error) {
+	if f.hasError {
+		return "", errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "OpenCursor")
+	if _, ok := execMap[sql]; !ok {
+		return "", fmt.Errorf("no match for: %s", sql)
+	}
+	return sql, nil
+}
+
+// FetchCursor is part of the VTGateService interface
+func (f *fakeVTGateService) FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error) {
+	if f.hasError {
+		return nil, false, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "FetchCursor")
+	execCase, ok := execMap[cursorID]
+	if !ok {
+		return nil, false, fmt.Errorf("no cursor %s", cursorID)
+	}
+	return execCase.result, true, nil
+}
+
+// CloseCursor is part of the VTGateService interface
+func (f *fakeVTGateService) CloseCursor(ctx context.Context, cursorID string) error {
+	panic("unimplemented")
+}
+
 // ResolveTransaction is part of the VTGateService interface
 func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string) error {
 	if f.hasError {
@@ -250,6 +286,21 @@ func (f *fakeVTGateService) VStream(ctx context.Context, tabletType topodatapb.T
 	panic("unimplemented")
 }
 
+// VStreamAck is part of the VTGateService interface
+func (f *fakeVTGateService) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	if f.hasError {
+		return errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "VStreamAck")
+	if consumerGroup != ackConsumerGroup || !proto.Equal(vgtid, ackVGtid) {
+		return errors.New("VStreamAck: checkpoint mismatch")
+	}
+	return nil
+}
+
 // CreateFakeServer returns the fake server for the tests
 func CreateFakeServer(t *testing.T) vtgateservice.VTGateService {
 	return &fakeVTGateService{
@@ -292,6 +343,8 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testStreamExecute(t, session)
 	testExecuteBatch(t, session)
 	testPrepare(t, session)
+	testCursor(t, session)
+	testVStreamAck(t, conn)
 
 	// force a panic at every call, then test that works
 	fs.panics = true
@@ -509,6 +562,31 @@ func testPrepare(t *testing.T, session *vtgateconn.VTGateSession) {
 	require.EqualError(t, err, "no match for: none")
 }
 
+func testCursor(t *testing.T, session *vtgateconn.VTGateSession) {
+	ctx := newContext()
+	execCase := execMap["request1"]
+	cursor, err := session.OpenCursor(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, nil)
+	require.NoError(t, err)
+	qr, done, err := cursor.Fetch(ctx, 10)
+	require.NoError(t, err)
+	require.True(t, done)
+	if !qr.Equal(execCase.result) {
+		t.Errorf("Unexpected result from Fetch: got\n%#v want\n%#v", qr, execCase.result)
+	}
+
+	_, err = session.OpenCursor(ctx, "none", nil, nil)
+	require.EqualError(t, err, "no match for: none")
+}
+
+func testVStreamAck(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	err := conn.VStreamAck(ctx, ackConsumerGroup, ackVGtid)
+	require.NoError(t, err)
+
+	err = conn.VStreamAck(ctx, "other", ackVGtid)
+	require.ErrorContains(t, err, "VStreamAck: checkpoint mismatch")
+}
+
 func testPrepareError(t *testing.T, session *vtgateconn.VTGateSession, fake *fakeVTGateService) {
 	ctx := newContext()
 	execCase := execMap["errorRequst"]
@@ -600,3 +678,13 @@ var streamResultFields = sqltypes.Result{
 }
 
 var dtid2 = "aa"
+
+var ackConsumerGroup = "group1"
+
+var ackVGtid = &binlogdatapb.VGtid{
+	ShardGtids: []*binlogdatapb.ShardGtid{{
+		Keyspace: "ks",
+		Shard:    "-80",
+		Gtid:     "MySQL56/89f66f98-e4e4-11ec-b8d1-0242ac120002:1-10",
+	}},
+}
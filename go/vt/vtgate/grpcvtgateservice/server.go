@@ -247,6 +247,46 @@ func (vtg *VTGate) CloseSession(ctx context.Context, request *vtgatepb.CloseSess
 	}, nil
 }
 
+// OpenCursor is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) OpenCursor(ctx context.Context, request *vtgatepb.OpenCursorRequest) (response *vtgatepb.OpenCursorResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+
+	session := request.Session
+	if session == nil {
+		session = &vtgatepb.Session{Autocommit: true}
+	}
+	cursorID, vtgErr := vtg.server.OpenCursor(ctx, session, request.Query.Sql, request.Query.BindVariables, request.KeysetColumns)
+	return &vtgatepb.OpenCursorResponse{
+		Error:    vterrors.ToVTRPC(vtgErr),
+		CursorId: cursorID,
+	}, nil
+}
+
+// FetchCursor is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) FetchCursor(ctx context.Context, request *vtgatepb.FetchCursorRequest) (response *vtgatepb.FetchCursorResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+
+	result, done, vtgErr := vtg.server.FetchCursor(ctx, request.CursorId, int(request.MaxRows))
+	return &vtgatepb.FetchCursorResponse{
+		Error:  vterrors.ToVTRPC(vtgErr),
+		Result: sqltypes.ResultToProto3(result),
+		Done:   done,
+	}, nil
+}
+
+// CloseCursor is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) CloseCursor(ctx context.Context, request *vtgatepb.CloseCursorRequest) (response *vtgatepb.CloseCursorResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+
+	err = vtg.server.CloseCursor(ctx, request.CursorId)
+	return &vtgatepb.CloseCursorResponse{
+		Error: vterrors.ToVTRPC(err),
+	}, nil
+}
+
 // ResolveTransaction is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) ResolveTransaction(ctx context.Context, request *vtgatepb.ResolveTransactionRequest) (response *vtgatepb.ResolveTransactionResponse, err error) {
 	defer vtg.server.HandlePanic(&err)
@@ -283,6 +323,17 @@ func (vtg *VTGate) VStream(request *vtgatepb.VStreamRequest, stream vtgateservic
 	return vterrors.ToGRPC(vtgErr)
 }
 
+// VStreamAck is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) VStreamAck(ctx context.Context, request *vtgatepb.VStreamAckRequest) (response *vtgatepb.VStreamAckResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+	vtgErr := vtg.server.VStreamAck(ctx, request.ConsumerGroup, request.Vgtid)
+	if vtgErr == nil {
+		return &vtgatepb.VStreamAckResponse{}, nil
+	}
+	return nil, vterrors.ToGRPC(vtgErr)
+}
+
 func init() {
 	vtgate.RegisterVTGates = append(vtgate.RegisterVTGates, func(vtGate vtgateservice.VTGateService) {
 		if servenv.GRPCCheckServiceMap("vtgateservice") {
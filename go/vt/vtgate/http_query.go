@@ -0,0 +1,408 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/auditlog"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The HTTP query API executes the queries of the clients which can't keep
+// MySQL connections open, like the serverless functions. A request is a POST
+// of a JSON object to /api/query:
+//
+//	{"sql": "select * from t where id = :id", "bind_vars": {"id": 1}, "target": "ks"}
+//
+// The clients authenticate with HTTP basic auth, checked by the auth server of
+// the MySQL protocol, and their queries are executed with the same caller IDs,
+// and so the same table ACLs, and the same timeout as on a MySQL connection.
+// Each request runs in its own autocommit session: a transaction left open by
+// the request is rolled back.
+//
+// The result is a JSON object, or, when the client accepts
+// application/x-ndjson, a stream of JSON lines: the fields, then a line per
+// row, and a last line with the rows affected or the error. The streamed
+// results are executed like the OLAP workload of the MySQL protocol, without
+// the limit on the rows held in memory.
+
+const (
+	httpQueryPath = "/api/query"
+
+	ndjsonContentType = "application/x-ndjson"
+)
+
+var (
+	httpQueryAPI            bool
+	httpQueryMaxRequestSize int64 = 16 * 1024 * 1024
+)
+
+func registerHTTPQueryFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&httpQueryAPI, "http-query-api", httpQueryAPI, "If set, serve the HTTP query API on "+httpQueryPath+", which executes the SQL of the requests authenticated with HTTP basic auth by the MySQL auth server, and returns JSON or streamed ND-JSON results")
+	fs.Int64Var(&httpQueryMaxRequestSize, "http-query-api-max-request-size", httpQueryMaxRequestSize, "Maximum size in bytes of a request of the HTTP query API")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerHTTPQueryFlags)
+	servenv.OnParseFor("vtcombo", registerHTTPQueryFlags)
+}
+
+// httpQueryRequest is the body of a request of the HTTP query API.
+type httpQueryRequest struct {
+	SQL      string         `json:"sql"`
+	BindVars map[string]any `json:"bind_vars"`
+	// Target is the keyspace, optionally with the shard and the tablet type,
+	// like the database of a MySQL connection.
+	Target string `json:"target"`
+}
+
+type httpQueryField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// httpQueryResult is the response of the HTTP query API, or the last line of
+// a streamed response, which has no fields nor rows.
+type httpQueryResult struct {
+	Fields       []httpQueryField `json:"fields,omitempty"`
+	Rows         [][]any          `json:"rows,omitempty"`
+	RowsAffected uint64           `json:"rows_affected"`
+	InsertID     uint64           `json:"insert_id,omitempty"`
+	Error        *httpQueryError  `json:"error,omitempty"`
+}
+
+type httpQueryError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// httpQueryHandler serves the HTTP query API.
+type httpQueryHandler struct {
+	vtg        *VTGate
+	authServer mysql.AuthServer
+}
+
+// initHTTPQueryAPI serves the HTTP query API, if --http-query-api is set.
+func initHTTPQueryAPI(vtg *VTGate) {
+	if !httpQueryAPI {
+		return
+	}
+	h := &httpQueryHandler{vtg: vtg, authServer: mysqlAuthServer()}
+	servenv.HTTPHandleFunc(httpQueryPath, h.ServeHTTP)
+}
+
+func (h *httpQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "the HTTP query API only accepts POST requests", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, user, err := h.authenticate(r)
+	if err != nil {
+		log.Warningf("HTTP query API: error authenticating %v: %v", r.RemoteAddr, err)
+		auditlog.Log(&auditlog.Event{
+			Type:       auditlog.EventAuthFailure,
+			User:       user,
+			RemoteAddr: r.RemoteAddr,
+			Error:      err.Error(),
+		})
+		w.Header().Set("WWW-Authenticate", `Basic realm="vtgate"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var request httpQueryRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, httpQueryMaxRequestSize))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if request.SQL == "" {
+		http.Error(w, "invalid request: no sql", http.StatusBadRequest)
+		return
+	}
+	bindVars, err := httpQueryBindVars(request.BindVars)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if mysqlQueryTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+		defer cancel()
+	}
+
+	u, _ := uuid.NewUUID()
+	session := &vtgatepb.Session{
+		Options: &querypb.ExecuteOptions{
+			IncludedFields: querypb.ExecuteOptions_ALL,
+			Workload:       querypb.ExecuteOptions_OLTP,
+		},
+		Autocommit:           true,
+		TargetString:         request.Target,
+		DDLStrategy:          defaultDDLStrategy,
+		SessionUUID:          u.String(),
+		EnableSystemSettings: sysVarSetEnabled,
+	}
+	if strings.Contains(r.Header.Get("Accept"), ndjsonContentType) {
+		session.Options.Workload = querypb.ExecuteOptions_OLAP
+		session = h.stream(ctx, w, session, request.SQL, bindVars)
+	} else {
+		session = h.execute(ctx, w, session, request.SQL, bindVars)
+	}
+
+	// The session doesn't outlive the request.
+	if session.InTransaction || session.InReservedConn {
+		if err := h.vtg.CloseSession(ctx, session); err != nil {
+			log.Warningf("HTTP query API: error closing the session of %v: %v", user, err)
+		}
+	}
+}
+
+// authenticate checks the basic auth credentials of the request with the
+// MySQL auth server, and returns the context with the caller IDs of the user.
+// The user is also returned when it fails to authenticate.
+func (h *httpQueryHandler) authenticate(r *http.Request) (context.Context, string, error) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, "", vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "missing basic auth credentials")
+	}
+	if r.TLS == nil && !mysqlAllowClearTextWithoutTLS {
+		return nil, user, vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "cannot use clear text authentication over non-TLS connections, see --mysql_allow_clear_text_without_tls")
+	}
+	getter, err := mysql.AuthenticateClearText(h.authServer, user, []byte(password), httpRemoteAddr(r), r.TLS != nil)
+	if err != nil {
+		return nil, user, vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "access denied for user %q", user)
+	}
+
+	// Like on a MySQL connection, the UserData returned by the auth server
+	// is the immediate caller, used for the table ACLs.
+	ef := callerid.NewEffectiveCallerID(
+		user,         /* principal: who */
+		r.RemoteAddr, /* component: running client process */
+		"VTGate HTTP Query API" /* subcomponent: part of the client */)
+	return callerid.NewContext(r.Context(), ef, getter.Get()), user, nil
+}
+
+// execute executes the query and writes its result as a JSON object.
+func (h *httpQueryHandler) execute(ctx context.Context, w http.ResponseWriter, session *vtgatepb.Session, sql string, bindVars map[string]*querypb.BindVariable) *vtgatepb.Session {
+	session, qr, err := h.vtg.Execute(ctx, nil, session, sql, bindVars)
+	w.Header().Set("Content-Type", jsonContentType)
+	if err != nil {
+		w.WriteHeader(httpQueryStatus(err))
+		writeHTTPQueryLine(w, &httpQueryResult{Error: newHTTPQueryError(err)})
+		return session
+	}
+	result := &httpQueryResult{
+		Fields:       httpQueryFields(qr.Fields),
+		RowsAffected: qr.RowsAffected,
+		InsertID:     qr.InsertID,
+	}
+	for _, row := range qr.Rows {
+		result.Rows = append(result.Rows, httpQueryRow(row))
+	}
+	writeHTTPQueryLine(w, result)
+	return session
+}
+
+// stream executes the query and streams its result as JSON lines. Once the
+// first line is sent, an error can only be reported by the last line.
+func (h *httpQueryHandler) stream(ctx context.Context, w http.ResponseWriter, session *vtgatepb.Session, sql string, bindVars map[string]*querypb.BindVariable) *vtgatepb.Session {
+	flusher, _ := w.(http.Flusher)
+	started := false
+	var rowsAffected, insertID uint64
+	session, err := h.vtg.StreamExecute(ctx, nil, session, sql, bindVars, func(qr *sqltypes.Result) error {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", ndjsonContentType)
+		}
+		if len(qr.Fields) > 0 {
+			if err := writeHTTPQueryLine(w, &httpQueryResult{Fields: httpQueryFields(qr.Fields)}); err != nil {
+				return err
+			}
+		}
+		for _, row := range qr.Rows {
+			if err := writeHTTPQueryLine(w, httpQueryRow(row)); err != nil {
+				return err
+			}
+		}
+		rowsAffected += qr.RowsAffected
+		if qr.InsertID != 0 {
+			insertID = qr.InsertID
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.WriteHeader(httpQueryStatus(err))
+		}
+		writeHTTPQueryLine(w, &httpQueryResult{Error: newHTTPQueryError(err)})
+		return session
+	}
+	w.Header().Set("Content-Type", ndjsonContentType)
+	writeHTTPQueryLine(w, &httpQueryResult{RowsAffected: rowsAffected, InsertID: insertID})
+	return session
+}
+
+// writeHTTPQueryLine writes a value as a line of JSON.
+func writeHTTPQueryLine(w http.ResponseWriter, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func httpQueryFields(fields []*querypb.Field) []httpQueryField {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]httpQueryField, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, httpQueryField{Name: field.Name, Type: field.Type.String()})
+	}
+	return result
+}
+
+// httpQueryRow returns the JSON values of a row: the integers and the floats
+// are numbers, NULL is null, and the other values, including the decimals
+// which JSON numbers can't represent exactly, are strings.
+func httpQueryRow(row []sqltypes.Value) []any {
+	values := make([]any, 0, len(row))
+	for _, v := range row {
+		switch {
+		case v.IsNull():
+			values = append(values, nil)
+		case v.IsIntegral() || v.IsFloat():
+			values = append(values, json.Number(v.ToString()))
+		default:
+			values = append(values, v.ToString())
+		}
+	}
+	return values
+}
+
+// httpQueryBindVars converts the bind variables of a request, decoded with
+// json.Number for the numbers.
+func httpQueryBindVars(in map[string]any) (map[string]*querypb.BindVariable, error) {
+	values := make(map[string]any, len(in))
+	for name, v := range in {
+		value, err := httpQueryBindValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("bind variable %s: %v", name, err)
+		}
+		values[name] = value
+	}
+	bindVars, err := sqltypes.BuildBindVariables(values)
+	if err != nil {
+		return nil, err
+	}
+	if bindVars == nil {
+		bindVars = make(map[string]*querypb.BindVariable)
+	}
+	return bindVars, nil
+}
+
+// httpQueryBindValue converts a JSON value to a value accepted by
+// sqltypes.BuildBindVariable: the integers become int64 or uint64, and the
+// other numbers become decimals, so that they are not rounded.
+func httpQueryBindValue(v any) (any, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u, nil
+		}
+		return sqltypes.DecimalString(v), nil
+	case []any:
+		values := make([]any, 0, len(v))
+		for _, elem := range v {
+			value, err := httpQueryBindValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case map[string]any:
+		return nil, fmt.Errorf("objects are not supported")
+	}
+	return v, nil
+}
+
+func newHTTPQueryError(err error) *httpQueryError {
+	return &httpQueryError{Code: vterrors.Code(err).String(), Message: err.Error()}
+}
+
+// httpQueryStatus returns the HTTP status of the response to a failed query.
+func httpQueryStatus(err error) int {
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_INVALID_ARGUMENT, vtrpcpb.Code_NOT_FOUND, vtrpcpb.Code_ALREADY_EXISTS, vtrpcpb.Code_FAILED_PRECONDITION, vtrpcpb.Code_OUT_OF_RANGE:
+		return http.StatusBadRequest
+	case vtrpcpb.Code_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case vtrpcpb.Code_UNAUTHENTICATED:
+		return http.StatusUnauthorized
+	case vtrpcpb.Code_RESOURCE_EXHAUSTED:
+		return http.StatusTooManyRequests
+	case vtrpcpb.Code_DEADLINE_EXCEEDED:
+		return http.StatusGatewayTimeout
+	case vtrpcpb.Code_UNAVAILABLE:
+		return http.StatusServiceUnavailable
+	case vtrpcpb.Code_UNIMPLEMENTED:
+		return http.StatusNotImplemented
+	}
+	return http.StatusInternalServerError
+}
+
+// httpRemoteAddr returns the address of the client of a request, for the
+// auth servers which restrict the hosts of the users.
+func httpRemoteAddr(r *http.Request) net.Addr {
+	addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr)
+	if err != nil {
+		return &net.TCPAddr{}
+	}
+	return addr
+}
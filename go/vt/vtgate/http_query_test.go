@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/auditlog"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestHTTPQueryAPI(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnv(t)
+	authServer := mysql.NewAuthServerStatic("", `{"user1": [{"Password": "password1", "UserData": "userData1"}]}`, 0)
+	h := &httpQueryHandler{
+		vtg:        &VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected},
+		authServer: authServer,
+	}
+
+	oldAllowClearText := mysqlAllowClearTextWithoutTLS
+	mysqlAllowClearTextWithoutTLS = true
+	defer func() { mysqlAllowClearTextWithoutTLS = oldAllowClearText }()
+
+	query := func(body, password, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, httpQueryPath, strings.NewReader(body))
+		r.SetBasicAuth("user1", password)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := query(`{"sql": "select 1 from dual"}`, "bad password", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Basic realm="vtgate"`, w.Header().Get("WWW-Authenticate"))
+
+	w = query(`{"sql": "select id, value from user where id = :id", "bind_vars": {"id": 1}, "target": "TestExecutor"}`, "password1", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, jsonContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"fields": [{"name": "id", "type": "INT32"}, {"name": "value", "type": "VARCHAR"}], "rows": [[1, "foo"]], "rows_affected": 0}`, w.Body.String())
+	require.NotEmpty(t, sbc1.Queries)
+	assert.Equal(t, sqltypes.Int64BindVariable(1), sbc1.Queries[len(sbc1.Queries)-1].BindVariables["id"])
+
+	w = query(`{"sql": "select id, value from user where id = 1", "target": "TestExecutor"}`, "password1", ndjsonContentType)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, ndjsonContentType, w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	require.Len(t, lines, 3, w.Body.String())
+	assert.JSONEq(t, `{"fields": [{"name": "id", "type": "INT32"}, {"name": "value", "type": "VARCHAR"}], "rows_affected": 0}`, lines[0])
+	assert.JSONEq(t, `[1, "foo"]`, lines[1])
+	assert.JSONEq(t, `{"rows_affected": 0}`, lines[2])
+
+	w = query(`{"sql": "select * from unknown_keyspace.t"}`, "password1", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"code":"`)
+
+	w = query(`{"sql": "select :v", "bind_vars": {"v": {"a": 1}}}`, "password1", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	r := httptest.NewRequest(http.MethodGet, httpQueryPath, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+// auditSink records the audit events.
+type auditSink struct {
+	events []*auditlog.Event
+}
+
+func (s *auditSink) Write(event *auditlog.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *auditSink) Close() error {
+	return nil
+}
+
+func TestHTTPQueryAPIAuthenticationFailed(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+	authServer := mysql.NewAuthServerStatic("", `{"user1": [{"Password": "password1", "UserData": "userData1"}]}`, 0)
+	h := &httpQueryHandler{
+		vtg:        &VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected},
+		authServer: authServer,
+	}
+
+	oldAllowClearText := mysqlAllowClearTextWithoutTLS
+	mysqlAllowClearTextWithoutTLS = true
+	defer func() { mysqlAllowClearTextWithoutTLS = oldAllowClearText }()
+
+	sink := &auditSink{}
+	logger := auditlog.NewLogger(map[string]auditlog.Sink{"test": sink}, auditlog.Config{BufferSize: 10}, sqlparser.NewTestParser())
+	defer auditlog.SetLoggerForTests(logger)()
+
+	r := httptest.NewRequest(http.MethodPost, httpQueryPath, strings.NewReader(`{"sql": "select 1 from dual"}`))
+	r.SetBasicAuth("user1", "bad password")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Closing the logger writes the buffered events.
+	logger.Close()
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, auditlog.EventAuthFailure, sink.events[0].Type)
+	assert.Equal(t, "user1", sink.events[0].User)
+	assert.Equal(t, r.RemoteAddr, sink.events[0].RemoteAddr)
+	assert.Contains(t, sink.events[0].Error, `access denied for user "user1"`)
+}
+
+func TestHTTPQueryBindVars(t *testing.T) {
+	bindVars, err := httpQueryBindVars(map[string]any{
+		"i": json.Number("-12"),
+		"u": json.Number("18446744073709551615"),
+		"d": json.Number("1.50"),
+		"s": "text",
+		"n": nil,
+		"l": []any{json.Number("1"), "a"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*querypb.BindVariable{
+		"i": sqltypes.Int64BindVariable(-12),
+		"u": sqltypes.Uint64BindVariable(18446744073709551615),
+		"d": sqltypes.DecimalBindVariable(sqltypes.DecimalString("1.50")),
+		"s": sqltypes.StringBindVariable("text"),
+		"n": sqltypes.NullBindVariable,
+		"l": {Type: querypb.Type_TUPLE, Values: []*querypb.Value{
+			{Type: querypb.Type_INT64, Value: []byte("1")},
+			{Type: querypb.Type_VARCHAR, Value: []byte("a")},
+		}},
+	}, bindVars)
+}
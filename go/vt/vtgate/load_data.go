@@ -0,0 +1,465 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// parseLoadDataLocal returns the parsed statement if query is a
+// LOAD DATA LOCAL INFILE, and nil otherwise.
+func parseLoadDataLocal(parser *sqlparser.Parser, query string) *sqlparser.Load {
+	trimmed := strings.TrimSpace(sqlparser.StripLeadingComments(query))
+	if len(trimmed) < 4 || !strings.EqualFold(trimmed[:4], "load") {
+		return nil
+	}
+	stmt, err := parser.Parse(query)
+	if err != nil {
+		// The regular execution path reports the error.
+		return nil
+	}
+	load, ok := stmt.(*sqlparser.Load)
+	if !ok || !load.Local {
+		return nil
+	}
+	return load
+}
+
+// localInfileLoader executes a LOAD DATA LOCAL INFILE statement. The
+// file is streamed from the client, and its rows are inserted in
+// batches, so that memory use is bounded by the batch size and not by
+// the size of the file. Each batch is executed as a regular INSERT,
+// which routes every row to its shard based on its keyspace_id. The
+// batches are executed in a transaction, so that a failed load does not
+// leave part of the file inserted.
+type localInfileLoader struct {
+	load     *sqlparser.Load
+	parser   *loadDataParser
+	bindVars map[string]*querypb.BindVariable
+	execute  func(query string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error)
+
+	batchSize int
+	maxBytes  int64
+	maxRows   int64
+
+	// buf holds the data received that does not form a complete row yet.
+	buf         []byte
+	batch       [][]sqltypes.Value
+	ignoreLines int
+	bytesRead   int64
+	rows        int64
+	result      *sqltypes.Result
+
+	// ownTransaction is set if the loader began the transaction the
+	// rows are inserted in, and must end it.
+	ownTransaction bool
+}
+
+func newLocalInfileLoader(
+	load *sqlparser.Load,
+	bindVars map[string]*querypb.BindVariable,
+	execute func(query string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error),
+) (*localInfileLoader, error) {
+	parser, err := newLoadDataParser(load)
+	if err != nil {
+		return nil, err
+	}
+	batchSize := mysqlLocalInfileBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	return &localInfileLoader{
+		load:        load,
+		parser:      parser,
+		bindVars:    bindVars,
+		execute:     execute,
+		batchSize:   batchSize,
+		maxBytes:    mysqlLocalInfileMaxBytes,
+		maxRows:     mysqlLocalInfileMaxRows,
+		ignoreLines: load.IgnoreLines,
+		result:      &sqltypes.Result{},
+	}, nil
+}
+
+// begin begins the transaction the rows are inserted in, unless the
+// session is already in a transaction, in which case the rows are part
+// of it.
+func (l *localInfileLoader) begin(inTransaction bool) error {
+	if inTransaction {
+		return nil
+	}
+	if _, err := l.execute("begin", nil); err != nil {
+		return err
+	}
+	l.ownTransaction = true
+	return nil
+}
+
+// abort rolls back the transaction begun by the loader, if any, after
+// the load failed.
+func (l *localInfileLoader) abort() {
+	if !l.ownTransaction {
+		return
+	}
+	l.ownTransaction = false
+	_, _ = l.execute("rollback", nil)
+}
+
+// write is called with every chunk of the file sent by the client.
+func (l *localInfileLoader) write(data []byte) error {
+	l.bytesRead += int64(len(data))
+	if l.maxBytes > 0 && l.bytesRead > l.maxBytes {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "LOAD DATA LOCAL INFILE file exceeds the limit of %d bytes", l.maxBytes)
+	}
+	l.buf = append(l.buf, data...)
+	return l.parse(false)
+}
+
+// finish inserts the rows that are still pending once the whole file
+// has been received, commits the transaction begun by the loader, and
+// returns the aggregated result.
+func (l *localInfileLoader) finish() (*sqltypes.Result, error) {
+	if err := l.parse(true); err != nil {
+		return nil, err
+	}
+	if err := l.flush(); err != nil {
+		return nil, err
+	}
+	if l.ownTransaction {
+		if _, err := l.execute("commit", nil); err != nil {
+			return nil, err
+		}
+		l.ownTransaction = false
+	}
+	return l.result, nil
+}
+
+func (l *localInfileLoader) parse(atEOF bool) error {
+	pos := 0
+	for {
+		row, n := l.parser.next(l.buf[pos:], atEOF)
+		pos += n
+		if row == nil {
+			if n == 0 {
+				break
+			}
+			continue
+		}
+		if err := l.addRow(row); err != nil {
+			return err
+		}
+	}
+	l.buf = l.buf[:copy(l.buf, l.buf[pos:])]
+	return nil
+}
+
+func (l *localInfileLoader) addRow(row []sqltypes.Value) error {
+	if l.ignoreLines > 0 {
+		l.ignoreLines--
+		return nil
+	}
+	l.rows++
+	if l.maxRows > 0 && l.rows > l.maxRows {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "LOAD DATA LOCAL INFILE file exceeds the limit of %d rows", l.maxRows)
+	}
+
+	// All the rows of a batch go into a single INSERT, so they must all
+	// have the same number of values.
+	expected := len(l.load.Columns)
+	if expected == 0 && len(l.batch) > 0 {
+		expected = len(l.batch[0])
+	}
+	if expected > 0 && len(row) != expected {
+		return sqlerror.NewSQLError(sqlerror.ERWrongValueCountOnRow, sqlerror.SSWrongValueCountOnRow, "Column count doesn't match value count at row %d", l.rows)
+	}
+
+	l.batch = append(l.batch, row)
+	if len(l.batch) >= l.batchSize {
+		return l.flush()
+	}
+	return nil
+}
+
+// flush inserts the rows of the current batch.
+func (l *localInfileLoader) flush() error {
+	if len(l.batch) == 0 {
+		return nil
+	}
+
+	ins := &sqlparser.Insert{
+		Action:  sqlparser.InsertAct,
+		Table:   sqlparser.NewAliasedTableExpr(l.load.Table, ""),
+		Columns: l.load.Columns,
+	}
+	switch {
+	case l.load.Replace:
+		ins.Action = sqlparser.ReplaceAct
+	case bool(l.load.Ignore), l.load.Local:
+		// With LOCAL, the server cannot stop the transfer of the file
+		// half way, so MySQL ignores duplicate keys unless REPLACE is used.
+		ins.Ignore = true
+	}
+
+	bindVars := make(map[string]*querypb.BindVariable, len(l.bindVars)+len(l.batch)*len(l.batch[0]))
+	for k, v := range l.bindVars {
+		bindVars[k] = v
+	}
+	rows := make(sqlparser.Values, 0, len(l.batch))
+	for i, row := range l.batch {
+		tuple := make(sqlparser.ValTuple, 0, len(row))
+		for j, val := range row {
+			name := fmt.Sprintf("ld_%d_%d", i, j)
+			tuple = append(tuple, sqlparser.NewArgument(name))
+			bindVars[name] = sqltypes.ValueBindVariable(val)
+		}
+		rows = append(rows, tuple)
+	}
+	ins.Rows = rows
+
+	qr, err := l.execute(sqlparser.String(ins), bindVars)
+	if err != nil {
+		return err
+	}
+	l.result.RowsAffected += qr.RowsAffected
+	if qr.InsertID != 0 && l.result.InsertID == 0 {
+		l.result.InsertID = qr.InsertID
+	}
+	l.batch = l.batch[:0]
+	return nil
+}
+
+// loadDataParser splits the contents of a file into rows and fields,
+// following the FIELDS and LINES options of a LOAD DATA statement.
+type loadDataParser struct {
+	fieldsTerminatedBy []byte
+	enclosedBy         byte
+	escapedBy          byte
+	linesStartingBy    []byte
+	linesTerminatedBy  []byte
+}
+
+func newLoadDataParser(load *sqlparser.Load) (*loadDataParser, error) {
+	// These are the MySQL defaults.
+	p := &loadDataParser{
+		fieldsTerminatedBy: []byte("\t"),
+		escapedBy:          '\\',
+		linesTerminatedBy:  []byte("\n"),
+	}
+	if load.FieldsTerminatedBy != nil {
+		p.fieldsTerminatedBy = []byte(*load.FieldsTerminatedBy)
+	}
+	if load.LinesTerminatedBy != nil {
+		p.linesTerminatedBy = []byte(*load.LinesTerminatedBy)
+	}
+	if load.LinesStartingBy != nil {
+		p.linesStartingBy = []byte(*load.LinesStartingBy)
+	}
+	if len(p.fieldsTerminatedBy) == 0 || len(p.linesTerminatedBy) == 0 {
+		return nil, vterrors.VT12001("LOAD DATA with an empty FIELDS or LINES TERMINATED BY")
+	}
+
+	var err error
+	if p.enclosedBy, err = singleChar(load.FieldsEnclosedBy); err != nil {
+		return nil, err
+	}
+	if load.FieldsEscapedBy != nil {
+		if p.escapedBy, err = singleChar(load.FieldsEscapedBy); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func singleChar(s *string) (byte, error) {
+	if s == nil || len(*s) == 0 {
+		return 0, nil
+	}
+	if len(*s) > 1 {
+		return 0, sqlerror.NewSQLError(sqlerror.ERWrongFieldTerminators, sqlerror.SSUnknownSQLState, "Field separator argument is not what is expected; check the manual")
+	}
+	return (*s)[0], nil
+}
+
+// next parses the next row at the start of data. It returns the row and
+// the number of bytes it used. If data does not hold a complete row yet,
+// it returns a nil row and 0. When atEOF is set, the remaining data is
+// parsed as the last row; data that cannot form a row is consumed and
+// a nil row is returned.
+func (p *loadDataParser) next(data []byte, atEOF bool) ([]sqltypes.Value, int) {
+	if len(data) == 0 {
+		return nil, 0
+	}
+
+	pos := 0
+	if len(p.linesStartingBy) > 0 {
+		// Lines without the prefix are skipped, as are the
+		// contents of a line before it.
+		i := bytes.Index(data, p.linesStartingBy)
+		if i < 0 {
+			if atEOF {
+				return nil, len(data)
+			}
+			return nil, 0
+		}
+		pos = i + len(p.linesStartingBy)
+	}
+
+	var row []sqltypes.Value
+	for {
+		val, end, ok := p.field(data, pos, atEOF)
+		if !ok {
+			return nil, 0
+		}
+		row = append(row, val)
+		pos = end
+
+		switch {
+		case pos == len(data):
+			// field only returns at the end of data when atEOF is set.
+			return row, pos
+		case bytes.HasPrefix(data[pos:], p.linesTerminatedBy):
+			return row, pos + len(p.linesTerminatedBy)
+		default:
+			pos += len(p.fieldsTerminatedBy)
+		}
+	}
+}
+
+// field parses the field that starts at pos. It returns its value and
+// the position of the terminator that follows it, or the end of data
+// if atEOF is set. ok is false if more data is needed.
+func (p *loadDataParser) field(data []byte, pos int, atEOF bool) (val sqltypes.Value, end int, ok bool) {
+	var buf []byte
+	enclosed := p.enclosedBy != 0 && pos < len(data) && data[pos] == p.enclosedBy
+	if enclosed {
+		pos++
+	}
+
+	for pos < len(data) {
+		c := data[pos]
+		if p.escapedBy != 0 && c == p.escapedBy {
+			if pos+1 == len(data) {
+				if !atEOF {
+					return val, 0, false
+				}
+				buf = append(buf, c)
+				pos++
+				continue
+			}
+			if data[pos+1] == 'N' && !enclosed && len(buf) == 0 {
+				term, more := p.atTerminator(data, pos+2, atEOF)
+				if more {
+					return val, 0, false
+				}
+				if term {
+					return sqltypes.NULL, pos + 2, true
+				}
+			}
+			buf = append(buf, unescapeLoadData(data[pos+1]))
+			pos += 2
+			continue
+		}
+
+		if enclosed {
+			if c == p.enclosedBy {
+				if pos+1 == len(data) && !atEOF {
+					return val, 0, false
+				}
+				if pos+1 < len(data) && data[pos+1] == p.enclosedBy {
+					buf = append(buf, c)
+					pos += 2
+					continue
+				}
+				term, more := p.atTerminator(data, pos+1, atEOF)
+				if more {
+					return val, 0, false
+				}
+				if term {
+					return sqltypes.NewVarChar(string(buf)), pos + 1, true
+				}
+			}
+			buf = append(buf, c)
+			pos++
+			continue
+		}
+
+		term, more := p.atTerminator(data, pos, atEOF)
+		if more {
+			return val, 0, false
+		}
+		if term {
+			break
+		}
+		buf = append(buf, c)
+		pos++
+	}
+
+	if pos == len(data) && !atEOF {
+		return val, 0, false
+	}
+	if !enclosed && p.enclosedBy != 0 && string(buf) == "NULL" {
+		return sqltypes.NULL, pos, true
+	}
+	return sqltypes.NewVarChar(string(buf)), pos, true
+}
+
+// atTerminator returns whether a field or line terminator starts at pos.
+// more is set if the data ends with a partial terminator, and more data
+// is needed to tell.
+func (p *loadDataParser) atTerminator(data []byte, pos int, atEOF bool) (term bool, more bool) {
+	rest := data[pos:]
+	if len(rest) == 0 {
+		return atEOF, !atEOF
+	}
+	for _, t := range [][]byte{p.linesTerminatedBy, p.fieldsTerminatedBy} {
+		if bytes.HasPrefix(rest, t) {
+			return true, false
+		}
+		if !atEOF && len(rest) < len(t) && bytes.HasPrefix(t, rest) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func unescapeLoadData(c byte) byte {
+	switch c {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	}
+	return c
+}
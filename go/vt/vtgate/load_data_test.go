@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestParseLoadDataLocal(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+
+	load := parseLoadDataLocal(parser, "/* c */ LOAD DATA LOCAL INFILE '/tmp/a.csv' INTO TABLE t")
+	require.NotNil(t, load)
+	assert.Equal(t, "/tmp/a.csv", load.FileName)
+
+	assert.Nil(t, parseLoadDataLocal(parser, "load data infile '/tmp/a.csv' into table t"))
+	assert.Nil(t, parseLoadDataLocal(parser, "select 1"))
+	assert.Nil(t, parseLoadDataLocal(parser, "load data local infile"))
+}
+
+func TestLoadDataParser(t *testing.T) {
+	testcases := []struct {
+		name    string
+		options string
+		data    string
+		rows    [][]sqltypes.Value
+	}{{
+		name: "defaults",
+		data: "1\ta\n2\tb\\tc\n3\t\\N\n",
+		rows: [][]sqltypes.Value{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar("b\tc")},
+			{sqltypes.NewVarChar("3"), sqltypes.NULL},
+		},
+	}, {
+		name:    "csv",
+		options: `fields terminated by ',' optionally enclosed by '"' lines terminated by '\r\n'`,
+		data:    "1,\"a,\"\"b\"\"\"\r\n2,NULL\r\n3,\"NULL\"",
+		rows: [][]sqltypes.Value{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar(`a,"b"`)},
+			{sqltypes.NewVarChar("2"), sqltypes.NULL},
+			{sqltypes.NewVarChar("3"), sqltypes.NewVarChar("NULL")},
+		},
+	}, {
+		name:    "lines starting by",
+		options: `fields terminated by ',' lines starting by 'xxx'`,
+		data:    "xxx1,a\nskipped\nyyyxxx2,b\n",
+		rows: [][]sqltypes.Value{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar("b")},
+		},
+	}, {
+		name:    "multi byte terminators",
+		options: `fields terminated by '||' lines terminated by '<>'`,
+		data:    "1||a|b<>2||<",
+		rows: [][]sqltypes.Value{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a|b")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar("<")},
+		},
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse("load data local infile 'f' into table t " + tc.options)
+			require.NoError(t, err)
+			p, err := newLoadDataParser(stmt.(*sqlparser.Load))
+			require.NoError(t, err)
+
+			// Feed the data one byte at a time, to check that rows and
+			// terminators split across chunks are handled.
+			var rows [][]sqltypes.Value
+			var buf []byte
+			parse := func(atEOF bool) {
+				for {
+					row, n := p.next(buf, atEOF)
+					buf = buf[n:]
+					if row == nil {
+						if n == 0 {
+							return
+						}
+						continue
+					}
+					rows = append(rows, row)
+				}
+			}
+			for i := range tc.data {
+				buf = append(buf, tc.data[i])
+				parse(false)
+			}
+			parse(true)
+			assert.Equal(t, tc.rows, rows)
+		})
+	}
+}
+
+func TestLoadDataParserErrors(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().Parse("load data local infile 'f' into table t fields enclosed by 'ab'")
+	require.NoError(t, err)
+	_, err = newLoadDataParser(stmt.(*sqlparser.Load))
+	assert.ErrorContains(t, err, "Field separator argument is not what is expected")
+
+	stmt, err = sqlparser.NewTestParser().Parse("load data local infile 'f' into table t lines terminated by ''")
+	require.NoError(t, err)
+	_, err = newLoadDataParser(stmt.(*sqlparser.Load))
+	assert.ErrorContains(t, err, "empty FIELDS or LINES TERMINATED BY")
+}
+
+func TestLocalInfileLoader(t *testing.T) {
+	defer func(batchSize int, maxRows int64) {
+		mysqlLocalInfileBatchSize = batchSize
+		mysqlLocalInfileMaxRows = maxRows
+	}(mysqlLocalInfileBatchSize, mysqlLocalInfileMaxRows)
+	mysqlLocalInfileBatchSize = 2
+
+	stmt, err := sqlparser.NewTestParser().Parse("load data local infile 'f' replace into table t fields terminated by ',' ignore 1 lines (id, name)")
+	require.NoError(t, err)
+
+	var queries []string
+	var bindVars []map[string]*querypb.BindVariable
+	execute := func(query string, bv map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		bindVars = append(bindVars, bv)
+		return &sqltypes.Result{RowsAffected: uint64(len(bv) / 2)}, nil
+	}
+	loader, err := newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+
+	require.NoError(t, loader.write([]byte("id,name\n1,a\n2,")))
+	require.NoError(t, loader.write([]byte("b\n3,c")))
+	qr, err := loader.finish()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, qr.RowsAffected)
+	assert.Equal(t, []string{
+		"replace into t(id, `name`) values (:ld_0_0, :ld_0_1), (:ld_1_0, :ld_1_1)",
+		"replace into t(id, `name`) values (:ld_0_0, :ld_0_1)",
+	}, queries)
+	assert.Equal(t, sqltypes.StringBindVariable("2"), bindVars[0]["ld_1_0"])
+	assert.Equal(t, sqltypes.StringBindVariable("c"), bindVars[1]["ld_0_1"])
+
+	// Without REPLACE, duplicate keys are ignored.
+	stmt, err = sqlparser.NewTestParser().Parse("load data local infile 'f' into table t fields terminated by ','")
+	require.NoError(t, err)
+	queries = nil
+	loader, err = newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+	require.NoError(t, loader.write([]byte("1,a\n")))
+	_, err = loader.finish()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"insert ignore into t values (:ld_0_0, :ld_0_1)"}, queries)
+
+	// Rows must all have the same number of values.
+	loader, err = newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+	assert.ErrorContains(t, loader.write([]byte("1,a\n2\n")), "Column count doesn't match value count at row 2")
+
+	// Row limit.
+	mysqlLocalInfileMaxRows = 1
+	loader, err = newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+	assert.ErrorContains(t, loader.write([]byte("1,a\n2,b\n")), "exceeds the limit of 1 rows")
+}
+
+func TestLocalInfileLoaderTransaction(t *testing.T) {
+	defer func(batchSize int) {
+		mysqlLocalInfileBatchSize = batchSize
+	}(mysqlLocalInfileBatchSize)
+	mysqlLocalInfileBatchSize = 1
+
+	stmt, err := sqlparser.NewTestParser().Parse("load data local infile 'f' into table t fields terminated by ','")
+	require.NoError(t, err)
+
+	var queries []string
+	var failOn string
+	execute := func(query string, bv map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		if query == failOn {
+			return nil, errors.New("insert failed")
+		}
+		return &sqltypes.Result{RowsAffected: 1}, nil
+	}
+
+	// The batches are committed together.
+	loader, err := newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+	require.NoError(t, loader.begin(false))
+	require.NoError(t, loader.write([]byte("1,a\n2,b\n")))
+	_, err = loader.finish()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"begin",
+		"insert ignore into t values (:ld_0_0, :ld_0_1)",
+		"insert ignore into t values (:ld_0_0, :ld_0_1)",
+		"commit",
+	}, queries)
+
+	// A failed batch rolls back the previous ones.
+	queries = nil
+	failOn = "insert ignore into t values (:ld_0_0, :ld_0_1)"
+	loader, err = newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+	require.NoError(t, loader.begin(false))
+	require.ErrorContains(t, loader.write([]byte("1,a\n")), "insert failed")
+	loader.abort()
+	assert.Equal(t, []string{"begin", failOn, "rollback"}, queries)
+
+	// Within a transaction of the session, the rows are part of it.
+	queries = nil
+	failOn = ""
+	loader, err = newLocalInfileLoader(stmt.(*sqlparser.Load), nil, execute)
+	require.NoError(t, err)
+	require.NoError(t, loader.begin(true))
+	require.NoError(t, loader.write([]byte("1,a\n")))
+	_, err = loader.finish()
+	require.NoError(t, err)
+	loader.abort()
+	assert.Equal(t, []string{"insert ignore into t values (:ld_0_0, :ld_0_1)"}, queries)
+}
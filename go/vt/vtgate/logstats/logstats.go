@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/google/safehtml"
@@ -52,6 +53,60 @@ type LogStats struct {
 	SessionUUID    string
 	CachedPlan     bool
 	ActiveKeyspace string // ActiveKeyspace is the selected keyspace `use ks`
+
+	// PlanFingerprint identifies the plan of the query in the plan cache,
+	// it is empty if the plan isn't cached.
+	PlanFingerprint string
+
+	mu     sync.Mutex
+	shards []ShardStats
+}
+
+// ShardStats records the execution of a query on a shard.
+type ShardStats struct {
+	Keyspace   string        `json:"keyspace"`
+	Shard      string        `json:"shard"`
+	TabletType string        `json:"tablet_type"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+type logStatsKey struct{}
+
+// NewContext returns a context carrying the LogStats, for the executions
+// on the shards to be recorded in it.
+func NewContext(ctx context.Context, stats *LogStats) context.Context {
+	return context.WithValue(ctx, logStatsKey{}, stats)
+}
+
+// FromContext returns the LogStats carried by the context, or nil.
+func FromContext(ctx context.Context) *LogStats {
+	stats, _ := ctx.Value(logStatsKey{}).(*LogStats)
+	return stats
+}
+
+// RecordShard records the execution of the query on the shard of the target.
+func (stats *LogStats) RecordShard(target *querypb.Target, duration time.Duration, err error) {
+	shard := ShardStats{
+		Keyspace:   target.GetKeyspace(),
+		Shard:      target.GetShard(),
+		TabletType: target.GetTabletType().String(),
+		Duration:   duration,
+	}
+	if err != nil {
+		shard.Error = err.Error()
+	}
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.shards = append(stats.shards, shard)
+}
+
+// Shards returns the executions of the query on the shards, in the order
+// they finished.
+func (stats *LogStats) Shards() []ShardStats {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return append([]ShardStats(nil), stats.shards...)
 }
 
 // NewLogStats constructs a new LogStats with supplied Method and ctx
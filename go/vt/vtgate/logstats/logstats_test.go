@@ -40,6 +40,7 @@ import (
 	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/callinfo/fakecallinfo"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 func TestMain(m *testing.M) {
@@ -241,3 +242,18 @@ func TestLogStatsRemoteAddrUsername(t *testing.T) {
 		t.Fatalf("expected to get username: %s, but got: %s", username, user)
 	}
 }
+
+func TestLogStatsRecordShard(t *testing.T) {
+	logStats := NewLogStats(context.Background(), "test", "sql1", "", nil)
+	assert.Nil(t, FromContext(context.Background()))
+	ctx := NewContext(context.Background(), logStats)
+	require.Same(t, logStats, FromContext(ctx))
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_REPLICA}
+	FromContext(ctx).RecordShard(target, time.Second, nil)
+	FromContext(ctx).RecordShard(target, 2*time.Second, errors.New("shard error"))
+	assert.Equal(t, []ShardStats{
+		{Keyspace: "ks", Shard: "-80", TabletType: "REPLICA", Duration: time.Second},
+		{Keyspace: "ks", Shard: "-80", TabletType: "REPLICA", Duration: 2 * time.Second, Error: "shard error"},
+	}, logStats.Shards())
+}
@@ -23,11 +23,14 @@ import (
 	"time"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/queryacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -104,7 +107,7 @@ func (e *Executor) newExecute(
 			// based on the buffering configuration. This way we should be able to perform the max retries
 			// within the given window of time for most queries and we should not end up waiting too long
 			// after the traffic switch fails or the buffer window has ended, retrying old queries.
-			timeout := e.resolver.scatterConn.gateway.buffer.GetConfig().MaxFailoverDuration / (MaxBufferingRetries - 1)
+			timeout := e.resolver.scatterConn.gateway.buffer.MaxFailoverDuration() / (MaxBufferingRetries - 1)
 			if waitForNewerVSchema(ctx, e, lastVSchemaCreated, timeout) {
 				vs = e.VSchema()
 				lastVSchemaCreated = vs.GetCreated()
@@ -123,7 +126,7 @@ func (e *Executor) newExecute(
 		// the vtgate to clear the cached plans when processing the new serving vschema.
 		// When buffering ends, many queries might be getting planned at the same time and we then
 		// take full advatange of the cached plan.
-		plan, err = e.getPlan(ctx, vcursor, query, stmt, comments, bindVars, reservedVars, e.normalize, logStats)
+		plan, err = e.getPlan(ctx, vcursor, query, stmt, comments, bindVars, reservedVars, e.normalize, mysqlCtx != nil, logStats)
 		execStart := e.logPlanningFinished(logStats, plan)
 
 		if err != nil {
@@ -149,21 +152,43 @@ func (e *Executor) newExecute(
 		}
 
 		// 4: Prepare for execution.
+		err = queryacl.Authorized(callerid.ImmediateCallerIDFromContext(ctx), plan.Type, plan.TablesUsed)
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
+		err = e.applyQueryRules(ctx, vcursor, plan, safeSession)
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
 		err = e.addNeededBindVars(vcursor, plan.BindVarNeeds, bindVars, safeSession)
 		if err != nil {
 			logStats.Error = err
 			return err
 		}
 
-		// 5: Execute the plan.
+		// 5: Execute the plan, within the quotas of its workload.
+		release, err := e.workloadQuotas.acquire(safeSession.GetOptions().GetWorkloadName())
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
+		execCtx := ctx
+		if gw := e.resolver.scatterConn.gateway; gw != nil && gw.buffer != nil {
+			// Let the buffer know which tables the query uses, so it can limit
+			// buffering to the tables affected by a MoveTables traffic switch.
+			execCtx = buffer.WithTables(ctx, plan.TablesUsed)
+		}
 		if plan.Instructions.NeedsTransaction() {
-			err = e.insideTransaction(ctx, safeSession, logStats,
+			err = e.insideTransaction(execCtx, safeSession, logStats,
 				func() error {
-					return execPlan(ctx, plan, vcursor, bindVars, execStart)
+					return execPlan(execCtx, plan, vcursor, bindVars, execStart)
 				})
 		} else {
-			err = execPlan(ctx, plan, vcursor, bindVars, execStart)
+			err = execPlan(execCtx, plan, vcursor, bindVars, execStart)
 		}
+		release()
 
 		if err == nil || safeSession.InTransaction() {
 			return err
@@ -233,19 +258,19 @@ func (e *Executor) handleTransactions(
 		qr, err := e.handleRollback(ctx, safeSession, logStats)
 		return qr, err
 	case sqlparser.StmtSavepoint:
-		qr, err := e.handleSavepoint(ctx, safeSession, plan.Original, "Savepoint", logStats, func(_ string) (*sqltypes.Result, error) {
+		qr, err := e.handleSavepoint(ctx, safeSession, stmt, plan.Original, "Savepoint", logStats, func(_ string) (*sqltypes.Result, error) {
 			// Safely to ignore as there is no transaction.
 			return &sqltypes.Result{}, nil
 		}, vcursor.ignoreMaxMemoryRows)
 		return qr, err
 	case sqlparser.StmtSRollback:
-		qr, err := e.handleSavepoint(ctx, safeSession, plan.Original, "Rollback Savepoint", logStats, func(query string) (*sqltypes.Result, error) {
+		qr, err := e.handleSavepoint(ctx, safeSession, stmt, plan.Original, "Rollback Savepoint", logStats, func(query string) (*sqltypes.Result, error) {
 			// Error as there is no transaction, so there is no savepoint that exists.
 			return nil, vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.SPDoesNotExist, "SAVEPOINT does not exist: %s", query)
 		}, vcursor.ignoreMaxMemoryRows)
 		return qr, err
 	case sqlparser.StmtRelease:
-		qr, err := e.handleSavepoint(ctx, safeSession, plan.Original, "Release Savepoint", logStats, func(query string) (*sqltypes.Result, error) {
+		qr, err := e.handleSavepoint(ctx, safeSession, stmt, plan.Original, "Release Savepoint", logStats, func(query string) (*sqltypes.Result, error) {
 			// Error as there is no transaction, so there is no savepoint that exists.
 			return nil, vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.SPDoesNotExist, "SAVEPOINT does not exist: %s", query)
 		}, vcursor.ignoreMaxMemoryRows)
@@ -318,7 +343,7 @@ func (e *Executor) executePlan(
 ) (*sqltypes.Result, error) {
 
 	// 4: Execute!
-	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	qr, err := e.executeWithReadRetry(ctx, safeSession, plan, vcursor, bindVars)
 
 	// 5: Log and add statistics
 	e.setLogStats(logStats, plan, vcursor, execStart, err, qr)
@@ -330,6 +355,38 @@ func (e *Executor) executePlan(
 	return qr, nil
 }
 
+// executePrimitive executes the plan, serving its result from the result cache when possible.
+func (e *Executor) executePrimitive(
+	ctx context.Context,
+	safeSession *SafeSession,
+	plan *engine.Plan,
+	vcursor *vcursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+) (*sqltypes.Result, error) {
+	rc := e.resultCache
+	// The results read inside a transaction or a reserved connection may depend on the state of the connection.
+	if !vcursor.cacheResult || plan.Type != sqlparser.StmtSelect || safeSession.InTransaction() || safeSession.InReservedConn() {
+		qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+		if plan.Type != sqlparser.StmtSelect {
+			rc.invalidate(plan.TablesUsed)
+		}
+		return qr, err
+	}
+
+	epoch := e.epoch.Load()
+	key := rc.key(ctx, vcursor, plan, bindVars)
+	if qr, ok := rc.get(key, epoch); ok {
+		return qr, nil
+	}
+	tables := rc.versions(plan.TablesUsed)
+	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	// Partial results, like the ones of the scatter queries that turn shard errors into warnings, are not cached.
+	if err == nil && len(safeSession.GetWarnings()) == 0 {
+		rc.set(key, epoch, qr, tables)
+	}
+	return qr, err
+}
+
 // rollbackExecIfNeeded rollbacks the partial execution if earlier it was detected that it needs partial query execution to be rolled back.
 func (e *Executor) rollbackExecIfNeeded(ctx context.Context, safeSession *SafeSession, bindVars map[string]*querypb.BindVariable, logStats *logstats.LogStats, err error) error {
 	if safeSession.InTransaction() && safeSession.IsRollbackSet() {
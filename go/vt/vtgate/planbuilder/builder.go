@@ -183,6 +183,8 @@ func createInstructionFor(ctx context.Context, query string, stmt sqlparser.Stat
 		return buildShowThrottlerStatusPlan(query, vschema)
 	case *sqlparser.AlterVschema:
 		return buildVSchemaDDLPlan(stmt, vschema)
+	case *sqlparser.CreateSequence:
+		return buildCreateSequencePlan(stmt, vschema, enableDirectDDL)
 	case *sqlparser.Use:
 		return buildUsePlan(stmt)
 	case *sqlparser.ExplainTab:
@@ -196,7 +198,7 @@ func createInstructionFor(ctx context.Context, query string, stmt sqlparser.Stat
 	case *sqlparser.Analyze:
 		return buildRoutePlan(stmt, reservedVars, vschema, buildAnalyzePlan)
 	case *sqlparser.Set:
-		return buildSetPlan(stmt, vschema)
+		return buildSetPlan(stmt, reservedVars, vschema)
 	case *sqlparser.Load:
 		return buildLoadPlan(query, vschema)
 	case sqlparser.DBDDLStatement:
@@ -53,9 +53,10 @@ func buildGeneralDDLPlan(ctx context.Context, sql string, ddlStatement sqlparser
 	}
 
 	if ddlStatement.IsTemporary() {
-		err := vschema.ErrorIfShardedF(normalDDLPlan.Keyspace, "temporary table", "Temporary table not supported in sharded database %s", normalDDLPlan.Keyspace.Name)
-		if err != nil {
-			return nil, err
+		if _, allShards := normalDDLPlan.TargetDestination.(key.DestinationAllShards); allShards && normalDDLPlan.Keyspace.Sharded {
+			// The temporary tables of a sharded keyspace all live on the same shard,
+			// the queries using them are routed there as they are pinned tables.
+			normalDDLPlan.TargetDestination = key.DestinationKeyspaceID(engine.TempTablePin)
 		}
 		onlineDDLPlan = nil // emptying this so it does not accidentally gets used somewhere
 	}
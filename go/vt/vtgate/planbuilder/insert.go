@@ -50,7 +50,7 @@ func gen4InsertStmtPlanner(version querypb.ExecuteOptions_PlannerVersion, insStm
 		return nil, err
 	}
 	if ks != nil {
-		if tables[0].AutoIncrement == nil && !ctx.SemTable.ForeignKeysPresent() {
+		if tables[0].AutoIncrement == nil && !ctx.SemTable.ForeignKeysPresent() && !sqlparser.ContainsNextValue(insStmt.Rows) {
 			plan := insertUnshardedShortcut(insStmt, ks, tables)
 			setCommentDirectivesOnPlan(plan, insStmt)
 			return newPlanResult(plan.Primitive(), operators.QualifiedTables(ks, tables)...), nil
@@ -63,6 +63,10 @@ func transformToLogicalPlan(ctx *plancontext.PlanningContext, op operators.Opera
 		return transformAggregator(ctx, op)
 	case *operators.Distinct:
 		return transformDistinct(ctx, op)
+	case *operators.Window:
+		return transformWindow(ctx, op)
+	case *operators.RecurseCTE:
+		return transformRecurseCTE(ctx, op)
 	case *operators.FkCascade:
 		return transformFkCascade(ctx, op)
 	case *operators.FkVerify:
@@ -169,6 +173,7 @@ func transformInsertionSelection(ctx *plancontext.PlanningContext, op *operators
 			ForceNonStreaming: op.ForceNonStreaming,
 			Generate:          autoIncGenerate(ins.AutoIncrement),
 			ColVindexes:       ins.ColVindexes,
+			Pinned:            ins.VTable.Pinned,
 		},
 		VindexValueOffset: ins.VindexValueOffset,
 	}
@@ -318,6 +323,31 @@ func transformDistinct(ctx *plancontext.PlanningContext, op *operators.Distinct)
 	return newDistinct(src, op.Columns, op.Truncate), nil
 }
 
+func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (logicalPlan, error) {
+	src, err := transformToLogicalPlan(ctx, op.Source)
+	if err != nil {
+		return nil, err
+	}
+	return newWindow(src, &engine.Window{
+		PartitionBy:  op.PartitionBy,
+		OrderBy:      op.OrderBy,
+		Functions:    op.Params,
+		CollationEnv: ctx.VSchema.Environment().CollationEnv(),
+	}), nil
+}
+
+func transformRecurseCTE(ctx *plancontext.PlanningContext, op *operators.RecurseCTE) (logicalPlan, error) {
+	seed, err := transformToLogicalPlan(ctx, op.Seed)
+	if err != nil {
+		return nil, err
+	}
+	term, err := transformToLogicalPlan(ctx, op.Term)
+	if err != nil {
+		return nil, err
+	}
+	return newRecurseCTE(seed, term, op.Vars), nil
+}
+
 func transformOrdering(ctx *plancontext.PlanningContext, op *operators.Ordering) (logicalPlan, error) {
 	plan, err := transformToLogicalPlan(ctx, op.Source)
 	if err != nil {
@@ -565,6 +595,10 @@ func buildRouteLogicalPlan(ctx *plancontext.PlanningContext, op *operators.Route
 	_ = updateSelectedVindexPredicate(op.Routing)
 
 	eroute, err := routeToEngineRoute(ctx, op, hints)
+	if err != nil {
+		return nil, err
+	}
+	eroute.ExpressionIndexes = operators.ExpressionIndexesUsed(ctx, op.Source)
 	for _, order := range op.Ordering {
 		typ, _ := ctx.SemTable.TypeForExpr(order.AST)
 		eroute.OrderBy = append(eroute.OrderBy, evalengine.OrderByParams{
@@ -575,9 +609,6 @@ func buildRouteLogicalPlan(ctx *plancontext.PlanningContext, op *operators.Route
 			CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
 		})
 	}
-	if err != nil {
-		return nil, err
-	}
 	r := &route{
 		eroute: eroute,
 		Select: stmt,
@@ -608,6 +639,11 @@ func buildInsertLogicalPlan(
 		ic.MultiShardAutocommit = hints.multiShardAutocommit
 		ic.QueryTimeout = hints.queryTimeout
 	}
+	if ins.VTable.Pinned != nil {
+		// The rows of a pinned table all go to the same shard, as for an unsharded table.
+		ic.Opcode = engine.InsertUnsharded
+		ic.Pinned = ins.VTable.Pinned
+	}
 
 	eins := &engine.Insert{
 		InsertCommon: ic,
@@ -622,6 +658,11 @@ func buildInsertLogicalPlan(
 		if ins.AST.RowAlias != nil {
 			eins.Alias = sqlparser.String(ins.AST.RowAlias)
 		}
+		if len(ins.AST.Returning) > 0 {
+			buf := sqlparser.NewTrackedBuffer(dmlFormatter)
+			buf.Myprintf(" returning %v", ins.AST.Returning)
+			eins.Returning = buf.String()
+		}
 	}
 
 	eins.Query = generateQuery(stmt)
@@ -719,6 +760,9 @@ func buildUpdateLogicalPlan(
 
 func buildDeleteLogicalPlan(ctx *plancontext.PlanningContext, rb *operators.Route, dmlOp operators.Operator, stmt *sqlparser.Delete, hints *queryHints) (logicalPlan, error) {
 	del := dmlOp.(*operators.Delete)
+	if len(stmt.Returning) > 0 && !rb.IsSingleShard() {
+		return nil, vterrors.VT12001("RETURNING in DELETE that is not routed to a single shard")
+	}
 
 	var vindexes []*vindexes.ColumnVindex
 	vQuery := ""
@@ -830,6 +874,21 @@ func transformUnionPlan(ctx *plancontext.PlanningContext, op *operators.Union) (
 	if len(sources) == 1 {
 		return sources[0], nil
 	}
+
+	if !op.IsUnion() {
+		opcode := engine.Intersect
+		if op.SetOp() == sqlparser.ExceptType {
+			opcode = engine.Except
+		}
+		return &setOperation{
+			left:      sources[0],
+			right:     sources[1],
+			opcode:    opcode,
+			distinct:  op.Distinct(),
+			checkCols: op.CheckCols,
+		}, nil
+	}
+
 	return &concatenate{
 		sources:           sources,
 		noNeedToTypeCheck: nil,
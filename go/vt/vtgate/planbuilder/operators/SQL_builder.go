@@ -85,6 +85,14 @@ func (qb *queryBuilder) addTableExpr(
 	qb.tableNames = append(qb.tableNames, tableName)
 }
 
+func (qb *queryBuilder) addJSONTable(jt *sqlparser.JSONTableExpr) {
+	if qb.stmt == nil {
+		qb.stmt = &sqlparser.Select{}
+	}
+	qb.stmt.(FromStatement).SetFrom(append(qb.stmt.(FromStatement).GetFrom(), jt))
+	qb.tableNames = append(qb.tableNames, jt.Alias.String())
+}
+
 func (qb *queryBuilder) addPredicate(expr sqlparser.Expr) {
 	if qb.ctx.ShouldSkip(expr) {
 		// This is a predicate that was added to the RHS of an ApplyJoin.
@@ -194,10 +202,11 @@ func (qb *queryBuilder) clearProjections() {
 	sel.SelectExprs = nil
 }
 
-func (qb *queryBuilder) unionWith(other *queryBuilder, distinct bool) {
+func (qb *queryBuilder) unionWith(other *queryBuilder, setOp sqlparser.SetOpType, distinct bool) {
 	qb.stmt = &sqlparser.Union{
 		Left:     qb.asSelectStatement(),
 		Right:    other.asSelectStatement(),
+		Type:     setOp,
 		Distinct: distinct,
 	}
 }
@@ -401,8 +410,9 @@ func buildQuery(op Operator, qb *queryBuilder) {
 
 func buildDelete(op *Delete, qb *queryBuilder) {
 	qb.stmt = &sqlparser.Delete{
-		Ignore:  op.Ignore,
-		Targets: sqlparser.TableNames{op.Target.Name},
+		Ignore:    op.Ignore,
+		Targets:   sqlparser.TableNames{op.Target.Name},
+		Returning: op.Returning,
 	}
 	buildQuery(op.Source, qb)
 
@@ -474,12 +484,11 @@ func buildLimit(op *Limit, qb *queryBuilder) {
 }
 
 func buildTable(op *Table, qb *queryBuilder) {
-	dbName := ""
-
-	if op.QTable.IsInfSchema {
-		dbName = op.QTable.Table.Qualifier.String()
+	if op.QTable.JSONTable != nil {
+		qb.addJSONTable(op.QTable.JSONTable)
+	} else {
+		buildTableName(op, qb)
 	}
-	qb.addTable(dbName, op.QTable.Table.Name.String(), op.QTable.Alias.As.String(), TableID(op), op.QTable.Alias.Hints)
 	for _, pred := range op.QTable.Predicates {
 		qb.addPredicate(pred)
 	}
@@ -488,6 +497,15 @@ func buildTable(op *Table, qb *queryBuilder) {
 	}
 }
 
+func buildTableName(op *Table, qb *queryBuilder) {
+	dbName := ""
+
+	if op.QTable.IsInfSchema {
+		dbName = op.QTable.Table.Qualifier.String()
+	}
+	qb.addTable(dbName, op.QTable.Table.Name.String(), op.QTable.Alias.As.String(), TableID(op), op.QTable.Alias.Hints)
+}
+
 func buildProjection(op *Projection, qb *queryBuilder) {
 	buildQuery(op.Source, qb)
 
@@ -548,7 +566,7 @@ func buildUnion(op *Union, qb *queryBuilder) {
 		// now we can go over the remaining inputs and UNION them together
 		qbOther := &queryBuilder{ctx: qb.ctx}
 		buildQuery(src, qbOther)
-		qb.unionWith(qbOther, op.distinct)
+		qb.unionWith(qbOther, op.setOp, op.distinct)
 	}
 }
 
@@ -18,6 +18,7 @@ package operators
 
 import (
 	"fmt"
+	"slices"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -144,10 +145,13 @@ func (jpc *joinPredicateCollector) inspectPredicate(
 }
 
 func createOperatorFromUnion(ctx *plancontext.PlanningContext, node *sqlparser.Union) Operator {
-	_, isRHSUnion := node.Right.(*sqlparser.Union)
-	if isRHSUnion {
+	rhsUnion, isRHSUnion := node.Right.(*sqlparser.Union)
+	if isRHSUnion && node.Type == sqlparser.UnionType && rhsUnion.Type == sqlparser.UnionType {
 		panic(vterrors.VT12001("nesting of UNIONs on the right-hand side"))
 	}
+	if op := createRecurseCTE(ctx, node); op != nil {
+		return op
+	}
 	opLHS := translateQueryToOp(ctx, node.Left)
 	opRHS := translateQueryToOp(ctx, node.Right)
 	lexprs := ctx.SemTable.SelectExprs(node.Left)
@@ -155,6 +159,7 @@ func createOperatorFromUnion(ctx *plancontext.PlanningContext, node *sqlparser.U
 
 	unionCols := ctx.SemTable.SelectExprs(node)
 	union := newUnion([]Operator{opLHS, opRHS}, []sqlparser.SelectExprs{lexprs, rexprs}, unionCols, node.Distinct)
+	union.setOp = node.Type
 	return newHorizon(union, node)
 }
 
@@ -216,6 +221,8 @@ func getOperatorFromTableExpr(ctx *plancontext.PlanningContext, tableExpr sqlpar
 		return getOperatorFromJoinTableExpr(ctx, tableExpr)
 	case *sqlparser.ParenTableExpr:
 		return crossJoin(ctx, tableExpr.Exprs)
+	case *sqlparser.JSONTableExpr:
+		return getOperatorFromJSONTableExpr(ctx, tableExpr)
 	default:
 		panic(vterrors.VT13001(fmt.Sprintf("unable to use: %T table type", tableExpr)))
 	}
@@ -223,8 +230,13 @@ func getOperatorFromTableExpr(ctx *plancontext.PlanningContext, tableExpr sqlpar
 
 func getOperatorFromJoinTableExpr(ctx *plancontext.PlanningContext, tableExpr *sqlparser.JoinTableExpr) Operator {
 	lhs := getOperatorFromTableExpr(ctx, tableExpr.LeftExpr, false)
+	lateralVars := bindLateralReferences(ctx, tableExpr.RightExpr, TableID(lhs))
 	rhs := getOperatorFromTableExpr(ctx, tableExpr.RightExpr, false)
 
+	if len(lateralVars) > 0 {
+		return createLateralJoin(ctx, tableExpr, lhs, rhs, lateralVars)
+	}
+
 	switch tableExpr.Join {
 	case sqlparser.NormalJoinType:
 		return createInnerJoin(ctx, tableExpr, lhs, rhs)
@@ -260,6 +272,14 @@ func getOperatorFromAliasedTableExpr(ctx *plancontext.PlanningContext, tableExpr
 			}
 		}
 		qg := newQueryGraph()
+		if _, isCTE := tableInfo.(*semantics.CTETable); isCTE {
+			// the columns of the recursive CTE are replaced by bind variables,
+			// so the query only needs a single row to evaluate them against
+			dual := sqlparser.NewTableName("dual")
+			qt := &QueryTable{Alias: &sqlparser.AliasedTableExpr{Expr: dual}, Table: dual, ID: tableID}
+			qg.Tables = append(qg.Tables, qt)
+			return qg
+		}
 		isInfSchema := tableInfo.IsInfSchema()
 		qt := &QueryTable{Alias: tableExpr, Table: tbl, ID: tableID, IsInfSchema: isInfSchema}
 		qg.Tables = append(qg.Tables, qt)
@@ -284,19 +304,84 @@ func getOperatorFromAliasedTableExpr(ctx *plancontext.PlanningContext, tableExpr
 	}
 }
 
+// getOperatorFromJSONTableExpr plans JSON_TABLE as a table on dual, so it can be
+// sent to whichever shard the tables it is joined with are sent to
+func getOperatorFromJSONTableExpr(ctx *plancontext.PlanningContext, tableExpr *sqlparser.JSONTableExpr) Operator {
+	for _, tableInfo := range ctx.SemTable.Tables {
+		jt, ok := tableInfo.(*semantics.JSONTable)
+		if !ok || jt.Expr != tableExpr {
+			continue
+		}
+		qg := newQueryGraph()
+		qt := &QueryTable{
+			Alias:     jt.ASTNode,
+			Table:     sqlparser.NewTableName("dual"),
+			ID:        ctx.SemTable.TableSetFor(jt.ASTNode),
+			JSONTable: tableExpr,
+		}
+		qg.Tables = append(qg.Tables, qt)
+		return qg
+	}
+	panic(vterrors.VT13001("could not find the table information for JSON_TABLE"))
+}
+
 func crossJoin(ctx *plancontext.PlanningContext, exprs sqlparser.TableExprs) Operator {
 	var output Operator
 	for _, tableExpr := range exprs {
+		var lateralVars []BindVarExpr
+		if output != nil {
+			lateralVars = bindLateralReferences(ctx, tableExpr, TableID(output))
+		}
 		op := getOperatorFromTableExpr(ctx, tableExpr, len(exprs) == 1)
-		if output == nil {
+		switch {
+		case output == nil:
 			output = op
-		} else {
+		case len(lateralVars) > 0:
+			output = &Join{LHS: output, RHS: op, LHSVars: lateralVars}
+		default:
 			output = createJoin(ctx, output, op)
 		}
 	}
 	return output
 }
 
+// bindLateralReferences replaces the columns that a lateral derived table or a JSON_TABLE uses from the tables
+// before it in the FROM clause with arguments, and returns the expressions that have to be
+// fetched from those tables to fill in the arguments
+func bindLateralReferences(ctx *plancontext.PlanningContext, tableExpr sqlparser.TableExpr, lhsID semantics.TableSet) []BindVarExpr {
+	var lateral sqlparser.SQLNode
+	switch tableExpr := tableExpr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		dt, ok := tableExpr.Expr.(*sqlparser.DerivedTable)
+		if !ok || !dt.Lateral {
+			return nil
+		}
+		lateral = dt.Select
+	case *sqlparser.JSONTableExpr:
+		// JSON_TABLE is implicitly lateral
+		lateral = tableExpr
+	default:
+		return nil
+	}
+
+	var vars []BindVarExpr
+	sqlparser.SafeRewrite(lateral, nil, func(cursor *sqlparser.Cursor) bool {
+		col, ok := cursor.Node().(*sqlparser.ColName)
+		if !ok || !ctx.SemTable.RecursiveDeps(col).IsSolvedBy(lhsID) {
+			return true
+		}
+		bvName := ctx.GetReservedArgumentFor(col)
+		arg := sqlparser.NewArgument(bvName)
+		ctx.SemTable.CopyExprInfo(col, arg)
+		cursor.Replace(arg)
+		if !slices.ContainsFunc(vars, func(bve BindVarExpr) bool { return bve.Name == bvName }) {
+			vars = append(vars, BindVarExpr{Name: bvName, Expr: col})
+		}
+		return true
+	})
+	return vars
+}
+
 func createQueryTableForDML(
 	ctx *plancontext.PlanningContext,
 	tableExpr sqlparser.TableExpr,
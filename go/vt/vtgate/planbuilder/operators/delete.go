@@ -19,6 +19,7 @@ package operators
 import (
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/sysvars"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
@@ -29,6 +30,9 @@ import (
 type Delete struct {
 	*DMLCommon
 
+	// Returning is the RETURNING clause of the delete, if any.
+	Returning sqlparser.SelectExprs
+
 	noColumns
 	noPredicates
 }
@@ -65,6 +69,9 @@ func (d *Delete) ShortDescription() string {
 
 func createOperatorFromDelete(ctx *plancontext.PlanningContext, deleteStmt *sqlparser.Delete) (op Operator) {
 	childFks := ctx.SemTable.GetChildForeignKeysForTargets()
+	if len(deleteStmt.Returning) > 0 && len(childFks) > 0 {
+		panic(vterrors.VT12001("RETURNING in DELETE on a table with foreign keys"))
+	}
 
 	// We check if delete with input plan is required. DML with input planning is generally
 	// slower, because it does a selection and then creates a delete statement wherein we have to
@@ -248,7 +255,7 @@ func createDeleteOperator(ctx *plancontext.PlanningContext, del *sqlparser.Delet
 	}
 
 	var ovq *sqlparser.Select
-	if vTbl.Keyspace.Sharded && vTbl.Type == vindexes.TypeTable {
+	if vTbl.Keyspace.Sharded && vTbl.Type == vindexes.TypeTable && vTbl.Pinned == nil {
 		primaryVindex := getVindexInformation(tblID, vTbl)
 		if len(vTbl.Owned) > 0 {
 			ovq = generateOwnedVindexQuery(del, targetTbl, primaryVindex.Columns)
@@ -262,6 +269,7 @@ func createDeleteOperator(ctx *plancontext.PlanningContext, del *sqlparser.Delet
 			OwnedVindexQuery: ovq,
 			Source:           op,
 		},
+		Returning: del.Returning,
 	}
 
 	if del.Limit != nil {
@@ -384,6 +392,7 @@ func createFkChildForDelete(ctx *plancontext.PlanningContext, fk vindexes.ChildF
 	bvName := ctx.ReservedVars.ReserveVariable(foreignKeyConstraintValues)
 	parsedComments := getParsedCommentsForFkChecks(ctx)
 	var childStmt sqlparser.Statement
+	verifyAllFKs := false
 	switch fk.OnDelete {
 	case sqlparser.Cascade:
 		// We now construct the delete query for the child table.
@@ -418,11 +427,26 @@ func createFkChildForDelete(ctx *plancontext.PlanningContext, fk vindexes.ChildF
 			Where:      &sqlparser.Where{Type: sqlparser.WhereClause, Expr: compExpr},
 		}
 	case sqlparser.SetDefault:
-		panic(vterrors.VT09016())
+		// We now construct the update query for the child table.
+		// The query looks something like this - `UPDATE <child_table> SET <child_column_in_fk> = <default_value> [AND <another_child_column_in_fk> = <default_value>]... WHERE <child_columns_in_fk> IN (<bind variable for the output from SELECT>)`
+		var valTuple sqlparser.ValTuple
+		for _, column := range fk.ChildColumns {
+			valTuple = append(valTuple, sqlparser.NewColName(column.String()))
+		}
+		compExpr := sqlparser.NewComparisonExpr(sqlparser.InOp, valTuple, sqlparser.NewListArg(bvName), nil)
+		// InnoDB doesn't support SET DEFAULT, so MySQL can't check that the default values exist in the parent.
+		// We run the child update with foreign key checks OFF and verify all of its foreign keys on VTGate instead.
+		childStmt = &sqlparser.Update{
+			Exprs:      childUpdateExprsForSetDefault(fk),
+			Comments:   (&sqlparser.ParsedComments{}).SetMySQLSetVarValue(sysvars.ForeignKeyChecks, "OFF").Parsed(),
+			TableExprs: []sqlparser.TableExpr{sqlparser.NewAliasedTableExpr(fk.Table.GetTableName(), "")},
+			Where:      &sqlparser.Where{Type: sqlparser.WhereClause, Expr: compExpr},
+		}
+		verifyAllFKs = true
 	}
 
-	// For the child statement of a DELETE query, we don't need to verify all the FKs on VTgate or ignore any foreign key explicitly.
-	childOp := createOpFromStmt(ctx, childStmt, false /* verifyAllFKs */, "" /* fkToIgnore */)
+	// For the child statement of a DELETE query, we don't need to ignore any foreign key explicitly.
+	childOp := createOpFromStmt(ctx, childStmt, verifyAllFKs, "" /* fkToIgnore */)
 
 	return &FkChild{
 		BVName: bvName,
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"slices"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// ExpressionIndexesUsed returns the expression indexes, as `table.index`, that MySQL can use
+// for the predicates of the operator. Like MySQL, a predicate matches an expression index
+// when one side of it is the exact expression of the first key part of the index.
+func ExpressionIndexesUsed(ctx *plancontext.PlanningContext, op Operator) []string {
+	if ctx.SemTable == nil {
+		return nil
+	}
+	var used []string
+	addUsed := func(expr sqlparser.Expr) {
+		vtbl, idx := expressionIndexFor(ctx, expr)
+		if idx == nil {
+			return
+		}
+		name := vtbl.Name.String() + "." + idx.Name
+		if !slices.Contains(used, name) {
+			used = append(used, name)
+		}
+	}
+	visitPredicates := func(predicates ...sqlparser.Expr) {
+		for _, pred := range predicates {
+			for _, expr := range sqlparser.SplitAndExpression(nil, pred) {
+				switch expr := expr.(type) {
+				case *sqlparser.ComparisonExpr:
+					addUsed(expr.Left)
+					addUsed(expr.Right)
+				case *sqlparser.BetweenExpr:
+					addUsed(expr.Left)
+				case *sqlparser.IsExpr:
+					addUsed(expr.Left)
+				}
+			}
+		}
+	}
+
+	_ = Visit(op, func(op Operator) error {
+		switch op := op.(type) {
+		case *Table:
+			visitPredicates(op.QTable.Predicates...)
+		case *Filter:
+			visitPredicates(op.Predicates...)
+		case *Join:
+			if op.Predicate != nil {
+				visitPredicates(op.Predicate)
+			}
+		}
+		return nil
+	})
+	slices.Sort(used)
+	return used
+}
+
+// expressionIndexFor returns the expression index whose first key part is the given expression
+func expressionIndexFor(ctx *plancontext.PlanningContext, expr sqlparser.Expr) (*vindexes.Table, *vindexes.ExpressionIndex) {
+	if _, isCol := expr.(*sqlparser.ColName); isCol || sqlparser.IsValue(expr) {
+		return nil, nil
+	}
+	vtbl := vindexTableFor(ctx, ctx.SemTable.RecursiveDeps(expr))
+	if vtbl == nil {
+		return nil, nil
+	}
+	for _, idx := range vtbl.ExpressionIndexes {
+		if len(idx.Exprs) > 0 && sameIndexedExpr(expr, idx.Exprs[0]) {
+			return vtbl, idx
+		}
+	}
+	return nil, nil
+}
+
+// generatedColumnFor returns the generated column of the table whose expression is the given expression.
+// MySQL uses the indexes of a generated column for its expression, and so can we use its vindexes.
+func generatedColumnFor(ctx *plancontext.PlanningContext, expr sqlparser.Expr, id semantics.TableSet) *sqlparser.ColName {
+	if _, isCol := expr.(*sqlparser.ColName); isCol || ctx.SemTable.RecursiveDeps(expr) != id {
+		return nil
+	}
+	vtbl := vindexTableFor(ctx, id)
+	if vtbl == nil {
+		return nil
+	}
+	for _, col := range vtbl.Columns {
+		if col.Generated != nil && sameIndexedExpr(expr, col.Generated) {
+			return sqlparser.NewColName(col.Name.String())
+		}
+	}
+	return nil
+}
+
+func vindexTableFor(ctx *plancontext.PlanningContext, id semantics.TableSet) *vindexes.Table {
+	if id.NumberOfTables() != 1 {
+		return nil
+	}
+	ti, err := ctx.SemTable.TableInfoFor(id)
+	if err != nil {
+		return nil
+	}
+	return ti.GetVindexTable()
+}
+
+// sameIndexedExpr compares an expression of the query with an expression of the schema,
+// which is not qualified with the table name
+func sameIndexedExpr(expr, schemaExpr sqlparser.Expr) bool {
+	return sqlparser.Equals.Expr(unqualifiedExpr(expr), unqualifiedExpr(schemaExpr))
+}
+
+func unqualifiedExpr(expr sqlparser.Expr) sqlparser.Expr {
+	return sqlparser.CopyOnRewrite(expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		col, ok := cursor.Node().(*sqlparser.ColName)
+		if !ok {
+			return
+		}
+		cursor.Replace(sqlparser.NewColName(col.Name.Lowered()))
+	}, nil).(sqlparser.Expr)
+}
@@ -100,7 +100,7 @@ func (h *Horizon) AddPredicate(ctx *plancontext.PlanningContext, expr sqlparser.
 	}
 
 	newExpr := semantics.RewriteDerivedTableExpression(expr, tableInfo)
-	if ContainsAggr(ctx, newExpr) {
+	if ContainsAggr(ctx, newExpr) || h.hasWindowFunctions() {
 		return newFilter(h, expr)
 	}
 	h.Source = h.Source.AddPredicate(ctx, newExpr)
@@ -193,6 +193,13 @@ func (h *Horizon) selectStatement() sqlparser.SelectStatement {
 	return h.Query
 }
 
+// hasWindowFunctions returns true if the query computes window functions,
+// which we can't push predicates under
+func (h *Horizon) hasWindowFunctions() bool {
+	sel, isSel := h.Query.(*sqlparser.Select)
+	return isSel && sqlparser.ContainsWindowFunction(sel.SelectExprs)
+}
+
 func (h *Horizon) src() Operator {
 	return h.Source
 }
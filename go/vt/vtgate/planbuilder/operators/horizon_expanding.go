@@ -159,13 +159,21 @@ func createProjectionFromSelect(ctx *plancontext.PlanningContext, horizon *Horiz
 		}
 	}
 
+	src := horizon.src()
+	if sel, isSel := horizon.selectStatement().(*sqlparser.Select); isSel && !windowsArePushable(ctx, sel, src) {
+		if qp.NeedsAggregation() {
+			panic(vterrors.VT12001("window functions with aggregation on a sharded keyspace"))
+		}
+		src = planWindows(ctx, sel, src)
+	}
+
 	if !qp.NeedsAggregation() {
-		projX := createProjectionWithoutAggr(ctx, qp, horizon.src())
+		projX := createProjectionWithoutAggr(ctx, qp, src)
 		projX.DT = dt
 		return projX
 	}
 
-	return createProjectionWithAggr(ctx, qp, dt, horizon.src())
+	return createProjectionWithAggr(ctx, qp, dt, src)
 }
 
 func createProjectionWithAggr(ctx *plancontext.PlanningContext, qp *QueryProjection, dt *DerivedTable, src Operator) Operator {
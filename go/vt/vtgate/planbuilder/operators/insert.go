@@ -25,6 +25,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
@@ -386,6 +387,7 @@ func createInsertOperator(ctx *plancontext.PlanningContext, insStmt *sqlparser.I
 	insOp.Ignore = bool(insStmt.Ignore) || insStmt.OnDup != nil
 
 	insOp.ColVindexes = getColVindexes(insOp)
+	checkInsertReturning(insStmt, vTbl)
 	switch rows := insStmt.Rows.(type) {
 	case sqlparser.Values:
 		op = route
@@ -402,6 +404,22 @@ func createInsertOperator(ctx *plancontext.PlanningContext, insStmt *sqlparser.I
 	return op
 }
 
+// checkInsertReturning errors out if the RETURNING clause of the insert can't be
+// sent to a single shard along with the rows. The sharded inserts are split
+// by rows, so only a single row can be inserted in them.
+func checkInsertReturning(ins *sqlparser.Insert, vTbl *vindexes.Table) {
+	if len(ins.Returning) == 0 {
+		return
+	}
+	rows, isValues := ins.Rows.(sqlparser.Values)
+	if !isValues {
+		panic(vterrors.VT12001("RETURNING in INSERT with a SELECT statement"))
+	}
+	if vTbl.Keyspace.Sharded && len(rows) > 1 {
+		panic(vterrors.VT12001("RETURNING in INSERT of multiple rows into a sharded table"))
+	}
+}
+
 func insertSelectPlan(
 	ctx *plancontext.PlanningContext,
 	insOp *Insert,
@@ -607,17 +625,66 @@ func populateInsertColumnlist(ins *sqlparser.Insert, table *vindexes.Table) *sql
 	return ins
 }
 
+// nextValueAutoInc returns the auto-increment column and sequence of the insert.
+// Rows using NEXT VALUE FOR a sequence are handled as if the column was an auto-increment
+// column of the table using that sequence, and null values are generated from it.
+func nextValueAutoInc(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, vTable *vindexes.Table) *vindexes.AutoIncrement {
+	rows, ok := ins.Rows.(sqlparser.Values)
+	if !ok {
+		return vTable.AutoIncrement
+	}
+	var autoInc *vindexes.AutoIncrement
+	for _, row := range rows {
+		for idx, expr := range row {
+			nextVal, ok := expr.(*sqlparser.NextValueExpr)
+			if !ok {
+				if sqlparser.ContainsNextValue(expr) {
+					panic(vterrors.VT12001("NEXT VALUE FOR outside of the VALUES of an INSERT"))
+				}
+				continue
+			}
+			if idx >= len(ins.Columns) {
+				panic(vterrors.VT03006())
+			}
+			seq, _, _, _, err := ctx.VSchema.FindTable(nextVal.Sequence)
+			if err != nil {
+				panic(err)
+			}
+			if seq.Type != vindexes.TypeSequence {
+				panic(&semantics.NotSequenceTableError{Table: seq.Name.String()})
+			}
+			if autoInc == nil {
+				autoInc = &vindexes.AutoIncrement{Column: ins.Columns[idx], Sequence: seq}
+				continue
+			}
+			if !autoInc.Column.Equal(ins.Columns[idx]) || autoInc.Sequence != seq {
+				panic(vterrors.VT12001("NEXT VALUE FOR more than one column or sequence in an INSERT"))
+			}
+		}
+	}
+	switch {
+	case autoInc == nil:
+		return vTable.AutoIncrement
+	case vTable.AutoIncrement == nil:
+		return autoInc
+	case !vTable.AutoIncrement.Column.Equal(autoInc.Column) || vTable.AutoIncrement.Sequence != autoInc.Sequence:
+		panic(vterrors.VT12001("NEXT VALUE FOR other than the auto-increment column and sequence of the table"))
+	}
+	return vTable.AutoIncrement
+}
+
 // modifyForAutoinc modifies the AST and the plan to generate necessary autoinc values.
 // For row values cases, bind variable names are generated using baseName.
 func modifyForAutoinc(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, vTable *vindexes.Table) *Generate {
-	if vTable.AutoIncrement == nil {
+	autoInc := nextValueAutoInc(ctx, ins, vTable)
+	if autoInc == nil {
 		return nil
 	}
 	gen := &Generate{
-		Keyspace:  vTable.AutoIncrement.Sequence.Keyspace,
-		TableName: sqlparser.TableName{Name: vTable.AutoIncrement.Sequence.Name},
+		Keyspace:  autoInc.Sequence.Keyspace,
+		TableName: sqlparser.TableName{Name: autoInc.Sequence.Name},
 	}
-	colNum, newColAdded := findOrAddColumn(ins, vTable.AutoIncrement.Column)
+	colNum, newColAdded := findOrAddColumn(ins, autoInc.Column)
 	switch rows := ins.Rows.(type) {
 	case sqlparser.SelectStatement:
 		gen.Offset = colNum
@@ -628,8 +695,9 @@ func modifyForAutoinc(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, v
 			if len(ins.Columns) != len(row) {
 				panic(vterrors.VT03006())
 			}
-			// Support the DEFAULT keyword by treating it as null
-			if _, ok := row[colNum].(*sqlparser.Default); ok {
+			// Support the DEFAULT keyword and NEXT VALUE FOR by treating them as null
+			switch row[colNum].(type) {
+			case *sqlparser.Default, *sqlparser.NextValueExpr:
 				row[colNum] = &sqlparser.NullVal{}
 			}
 			autoIncValues = append(autoIncValues, row[colNum])
@@ -17,6 +17,9 @@ limitations under the License.
 package operators
 
 import (
+	"fmt"
+	"slices"
+
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
@@ -30,6 +33,9 @@ type Join struct {
 	// NormalJoinType, StraightJoinType and LeftJoinType.
 	JoinType sqlparser.JoinType
 
+	// LHSVars are the columns of the LHS that a lateral derived table on the RHS uses
+	LHSVars []BindVarExpr
+
 	noColumns
 }
 
@@ -45,6 +51,7 @@ func (j *Join) Clone(inputs []Operator) Operator {
 		RHS:       inputs[1],
 		Predicate: j.Predicate,
 		JoinType:  j.JoinType,
+		LHSVars:   slices.Clone(j.LHSVars),
 	}
 }
 
@@ -67,6 +74,10 @@ func (j *Join) Compact(ctx *plancontext.PlanningContext) (Operator, *ApplyResult
 		// if we can't move tables around, we can't merge these inputs
 		return j, NoRewrite
 	}
+	if len(j.LHSVars) > 0 {
+		// the RHS uses values from the LHS, so it has to stay on the RHS
+		return j, NoRewrite
+	}
 
 	lqg, lok := j.LHS.(*QueryGraph)
 	rqg, rok := j.RHS.(*QueryGraph)
@@ -119,6 +130,25 @@ func createLeftOuterJoin(ctx *plancontext.PlanningContext, join *sqlparser.JoinT
 	return op
 }
 
+// createLateralJoin creates a join where the RHS is a lateral derived table using the given columns of the LHS
+func createLateralJoin(ctx *plancontext.PlanningContext, join *sqlparser.JoinTableExpr, lhs, rhs Operator, vars []BindVarExpr) Operator {
+	switch join.Join {
+	case sqlparser.NormalJoinType, sqlparser.StraightJoinType:
+		joinOp := &Join{LHS: lhs, RHS: rhs, JoinType: join.Join, LHSVars: vars}
+		return addJoinPredicates(ctx, join.Condition.On, joinOp)
+	case sqlparser.LeftJoinType:
+		subq, _ := getSubQuery(join.Condition.On)
+		if subq != nil {
+			panic(vterrors.VT12001("subquery in outer join predicate"))
+		}
+		predicate := join.Condition.On
+		sqlparser.RemoveKeyspaceInCol(predicate)
+		return &Join{LHS: lhs, RHS: rhs, JoinType: join.Join, Predicate: predicate, LHSVars: vars}
+	default:
+		panic(vterrors.VT12001(fmt.Sprintf("%s with a lateral derived table", join.Join.ToString())))
+	}
+}
+
 func createInnerJoin(ctx *plancontext.PlanningContext, tableExpr *sqlparser.JoinTableExpr, lhs, rhs Operator) Operator {
 	op := createJoin(ctx, lhs, rhs)
 	return addJoinPredicates(ctx, tableExpr.Condition.On, op)
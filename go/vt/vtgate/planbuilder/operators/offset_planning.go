@@ -64,7 +64,7 @@ func mustFetchFromInput(ctx *plancontext.PlanningContext, e sqlparser.SQLNode) b
 	case *sqlparser.FuncExpr:
 		return fun.Name.EqualsAnyString(ctx.VSchema.GetAggregateUDFs())
 	default:
-		return false
+		return sqlparser.GetOverClause(e) != nil
 	}
 }
 
@@ -158,7 +158,7 @@ func addColumnsToInput(ctx *plancontext.PlanningContext, root Operator) Operator
 func isolateDistinctFromUnion(_ *plancontext.PlanningContext, root Operator) Operator {
 	visitor := func(in Operator, _ semantics.TableSet, isRoot bool) (Operator, *ApplyResult) {
 		union, ok := in.(*Union)
-		if !ok || !union.distinct {
+		if !ok || !union.distinct || !union.IsUnion() {
 			return in, NoRewrite
 		}
 
@@ -206,7 +206,8 @@ func pushOrExpandHorizon(ctx *plancontext.PlanningContext, in *Horizon) (Operato
 		!needsOrdering &&
 		!qp.NeedsAggregation() &&
 		!in.selectStatement().IsDistinct() &&
-		in.selectStatement().GetLimit() == nil
+		in.selectStatement().GetLimit() == nil &&
+		(!isSel || windowsArePushable(ctx, sel, rb))
 
 	if canPush {
 		return Swap(in, rb, "push horizon into route")
@@ -248,6 +249,9 @@ func setUpperLimit(in *Limit) (Operator, *ApplyResult) {
 		case *Join, *ApplyJoin, *SubQueryContainer, *SubQuery:
 			// we can't push limits down on either side
 			return SkipChildren
+		case *Window:
+			// the window functions need all the rows of their partitions
+			return SkipChildren
 		case *Route:
 			newSrc := &Limit{
 				Source: op.Source,
@@ -457,6 +461,10 @@ func tryPushFilter(ctx *plancontext.PlanningContext, in *Filter) (Operator, *App
 
 func pushFilterUnderProjection(ctx *plancontext.PlanningContext, filter *Filter, projection *Projection) (Operator, *ApplyResult) {
 	for _, p := range filter.Predicates {
+		if projection.DT != nil && sqlparser.ContainsWindowFunction(projection.DT.RewriteExpression(ctx, p)) {
+			// the window functions are computed below the derived table, so the filter has to stay above it
+			return filter, NoRewrite
+		}
 		cantPush := false
 		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
 			if !mustFetchFromInput(ctx, node) {
@@ -505,9 +513,16 @@ func tryPushDistinct(in *Distinct) (Operator, *ApplyResult) {
 		src.PushedPerformance = false
 		return src, Rewrote("remove double distinct")
 	case *Union:
+		if src.setOp == sqlparser.ExceptType && !src.distinct {
+			// removing duplicates from the inputs of an EXCEPT ALL changes its results
+			return in, NoRewrite
+		}
 		for i := range src.Sources {
 			src.Sources[i] = &Distinct{Source: src.Sources[i]}
 		}
+		if !in.Required {
+			return src, Rewrote("push down distinct under union")
+		}
 		in.PushedPerformance = true
 
 		return in, Rewrote("push down distinct under union")
@@ -552,9 +567,12 @@ func tryPushUnion(ctx *plancontext.PlanningContext, op *Union) (Operator, *Apply
 	var sources []Operator
 	var selects []sqlparser.SelectExprs
 
-	if op.distinct {
+	switch {
+	case !op.IsUnion():
+		sources, selects = mergeSetOpInputs(ctx, op)
+	case op.distinct:
 		sources, selects = mergeUnionInputInAnyOrder(ctx, op)
-	} else {
+	default:
 		sources, selects = mergeUnionInputsInOrder(ctx, op)
 	}
 
@@ -59,6 +59,10 @@ type (
 		Table       sqlparser.TableName
 		Predicates  []sqlparser.Expr
 		IsInfSchema bool
+
+		// JSONTable is set when the table is a JSON_TABLE expression,
+		// which is evaluated on top of a dual table
+		JSONTable *sqlparser.JSONTableExpr
 	}
 )
 
@@ -194,6 +198,7 @@ func (qt *QueryTable) Clone() *QueryTable {
 		Table:       sqlparser.CloneTableName(qt.Table),
 		Predicates:  qt.Predicates,
 		IsInfSchema: qt.IsInfSchema,
+		JSONTable:   qt.JSONTable,
 	}
 }
 
@@ -204,7 +204,8 @@ func (qp *QueryProjection) addSelectExpressions(ctx *plancontext.PlanningContext
 func IsAggr(ctx *plancontext.PlanningContext, e sqlparser.SQLNode) bool {
 	switch node := e.(type) {
 	case sqlparser.AggrFunc:
-		return true
+		// with an OVER clause, the aggregation is a window function
+		return sqlparser.GetOverClause(node) == nil
 	case *sqlparser.FuncExpr:
 		return node.Name.EqualsAnyString(ctx.VSchema.GetAggregateUDFs())
 	}
@@ -220,6 +221,10 @@ func ContainsAggr(ctx *plancontext.PlanningContext, e sqlparser.SQLNode) (hasAgg
 			// so we don't need to worry about aggregation in the original
 			return false, nil
 		case sqlparser.AggrFunc:
+			if sqlparser.GetOverClause(node) != nil {
+				// a window function, but its arguments can still be aggregations
+				return true, nil
+			}
 			hasAggr = true
 			return false, io.EOF
 		case *sqlparser.Subquery:
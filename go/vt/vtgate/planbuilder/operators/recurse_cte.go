@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+)
+
+// RecurseCTE is used to evaluate a recursive common table expression at the vtgate.
+// The Seed produces the first rows, and the Term is then evaluated once for every row
+// produced by the previous iteration, with the columns of that row sent as bind variables.
+type RecurseCTE struct {
+	Seed, Term Operator
+
+	// Vars are the bind variables used by the Term, and the offsets
+	// of the columns of the previous rows that they are built from
+	Vars map[string]int
+
+	// names are the names of the columns of the CTE
+	names   []string
+	columns sqlparser.SelectExprs
+}
+
+var _ Operator = (*RecurseCTE)(nil)
+
+func (r *RecurseCTE) Clone(inputs []Operator) Operator {
+	return &RecurseCTE{
+		Seed:    inputs[0],
+		Term:    inputs[1],
+		Vars:    r.Vars,
+		names:   r.names,
+		columns: r.columns,
+	}
+}
+
+func (r *RecurseCTE) Inputs() []Operator {
+	return []Operator{r.Seed, r.Term}
+}
+
+func (r *RecurseCTE) SetInputs(operators []Operator) {
+	r.Seed = operators[0]
+	r.Term = operators[1]
+}
+
+func (r *RecurseCTE) AddPredicate(_ *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	// filtering the rows of an iteration would change the input of the next one
+	return newFilter(r, expr)
+}
+
+func (r *RecurseCTE) AddColumn(ctx *plancontext.PlanningContext, reuse bool, _ bool, expr *sqlparser.AliasedExpr) int {
+	if reuse {
+		if offset := r.FindCol(ctx, expr.Expr, false); offset >= 0 {
+			return offset
+		}
+	}
+	switch e := expr.Expr.(type) {
+	case *sqlparser.ColName:
+		return r.columnOffset(e)
+	case *sqlparser.WeightStringFuncExpr:
+		return r.AddWSColumn(ctx, r.columnOffset(e.Expr), false)
+	default:
+		panic(vterrors.VT12001(fmt.Sprintf("evaluating %s on top of a recursive common table expression", sqlparser.String(expr))))
+	}
+}
+
+// columnOffset returns the offset of the column of the CTE that the expression references
+func (r *RecurseCTE) columnOffset(expr sqlparser.Expr) int {
+	col, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		panic(vterrors.VT12001(fmt.Sprintf("evaluating %s on top of a recursive common table expression", sqlparser.String(expr))))
+	}
+	offset := slices.IndexFunc(r.names, func(name string) bool {
+		return col.Name.EqualString(name)
+	})
+	if offset == -1 {
+		panic(vterrors.VT13001(fmt.Sprintf("could not find the column '%s' on the recursive CTE", sqlparser.String(col))))
+	}
+	return offset
+}
+
+func (r *RecurseCTE) AddWSColumn(ctx *plancontext.PlanningContext, offset int, _ bool) int {
+	seedOffset := r.Seed.AddWSColumn(ctx, offset, false)
+	termOffset := r.Term.AddWSColumn(ctx, offset, false)
+	if seedOffset != termOffset {
+		panic(vterrors.VT12001("weight_string offsets did not line up for the recursive common table expression"))
+	}
+	return seedOffset
+}
+
+func (r *RecurseCTE) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, _ bool) int {
+	for idx, col := range r.GetColumns(ctx) {
+		if ctx.SemTable.EqualsExprWithDeps(expr, col.Expr) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (r *RecurseCTE) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	return slice.Map(r.GetSelectExprs(ctx), func(from sqlparser.SelectExpr) *sqlparser.AliasedExpr {
+		ae, ok := from.(*sqlparser.AliasedExpr)
+		if !ok {
+			panic(vterrors.VT09015())
+		}
+		return ae
+	})
+}
+
+func (r *RecurseCTE) GetSelectExprs(ctx *plancontext.PlanningContext) sqlparser.SelectExprs {
+	// if the inputs have more columns than we expect, we want to show them,
+	// so the results can be truncated to the expected columns
+	for len(r.Seed.GetSelectExprs(ctx)) > len(r.columns) {
+		r.columns = append(r.columns, aeWrap(sqlparser.NewIntLiteral("0")))
+	}
+	return r.columns
+}
+
+func (r *RecurseCTE) ShortDescription() string {
+	if len(r.Vars) == 0 {
+		return ""
+	}
+	var vars []string
+	for k, v := range r.Vars {
+		vars = append(vars, fmt.Sprintf("%s:%d", k, v))
+	}
+	slices.Sort(vars)
+	return strings.Join(vars, " ")
+}
+
+func (r *RecurseCTE) GetOrdering(*plancontext.PlanningContext) []OrderBy {
+	return nil
+}
+
+// createRecurseCTE plans the UNION of a recursive CTE. It returns nil if the
+// UNION is not the body of a recursive CTE
+func createRecurseCTE(ctx *plancontext.PlanningContext, node *sqlparser.Union) Operator {
+	term, ok := node.Right.(*sqlparser.Select)
+	if !ok {
+		return nil
+	}
+	cteTable, cteID := findCTETable(ctx, term)
+	if cteTable == nil {
+		return nil
+	}
+
+	seed := translateQueryToOp(ctx, node.Left)
+
+	// the columns of the CTE that the recursive part uses are turned
+	// into bind variables that are filled in from the previous rows
+	vars := map[string]int{}
+	sqlparser.SafeRewrite(term, nil, func(cursor *sqlparser.Cursor) bool {
+		col, ok := cursor.Node().(*sqlparser.ColName)
+		if !ok || ctx.SemTable.DirectDeps(col) != cteID {
+			return true
+		}
+		offset := slices.IndexFunc(cteTable.ColumnNames(), func(name string) bool {
+			return col.Name.EqualString(name)
+		})
+		if offset == -1 {
+			panic(vterrors.VT13001(fmt.Sprintf("could not find the column '%s' on the recursive CTE", sqlparser.String(col))))
+		}
+		bvName := ctx.GetReservedArgumentFor(col)
+		arg := sqlparser.NewArgument(bvName)
+		ctx.SemTable.CopyExprInfo(col, arg)
+		cursor.Replace(arg)
+		vars[bvName] = offset
+		return true
+	})
+
+	return newHorizon(&RecurseCTE{
+		Seed:    seed,
+		Term:    translateQueryToOp(ctx, term),
+		Vars:    vars,
+		names:   cteTable.ColumnNames(),
+		columns: ctx.SemTable.SelectExprs(node),
+	}, node)
+}
+
+// findCTETable returns the table used by the recursive part of a recursive CTE to reference the CTE itself
+func findCTETable(ctx *plancontext.PlanningContext, term *sqlparser.Select) (*semantics.CTETable, semantics.TableSet) {
+	var cteTable *semantics.CTETable
+	var cteID semantics.TableSet
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.DerivedTable:
+			return false, nil
+		case *sqlparser.AliasedTableExpr:
+			id := ctx.SemTable.TableSetFor(node)
+			tableInfo, err := ctx.SemTable.TableInfoFor(id)
+			if err != nil {
+				return true, nil
+			}
+			if tbl, ok := tableInfo.(*semantics.CTETable); ok {
+				cteTable, cteID = tbl, id
+				return false, nil
+			}
+		}
+		return true, nil
+	}, sqlparser.TableExprs(term.From))
+	return cteTable, cteID
+}
@@ -19,6 +19,8 @@ package operators
 import (
 	"bytes"
 	"io"
+	"slices"
+	"strings"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -53,12 +55,70 @@ func pushDerived(ctx *plancontext.PlanningContext, op *Horizon) (Operator, *Appl
 }
 
 func optimizeJoin(ctx *plancontext.PlanningContext, op *Join) (Operator, *ApplyResult) {
+	if len(op.LHSVars) > 0 {
+		if jt := jsonTableOnDual(op.RHS); jt != nil {
+			if _, isRoute := op.LHS.(*Route); isRoute {
+				// the JSON documents can be expanded on the same shards that the LHS rows come from,
+				// so we put the columns back in place of the arguments and merge the two routes
+				unbindLateralReferences(jt, op.LHSVars)
+				return mergeOrJoin(ctx, op.LHS, op.RHS, sqlparser.SplitAndExpression(nil, op.Predicate), op.JoinType)
+			}
+		}
+		// the RHS is a lateral derived table that needs values from every row of the LHS
+		join := NewApplyJoin(ctx, Clone(op.LHS), Clone(op.RHS), nil, op.JoinType)
+		join.ExtraLHSVars = op.LHSVars
+		newOp := pushJoinPredicates(ctx, sqlparser.SplitAndExpression(nil, op.Predicate), join)
+		return newOp, Rewrote("lateral join to applyJoin")
+	}
 	return mergeOrJoin(ctx, op.LHS, op.RHS, sqlparser.SplitAndExpression(nil, op.Predicate), op.JoinType)
 }
 
+// jsonTableOnDual returns the JSON_TABLE expression if the operator is a route that only evaluates a JSON_TABLE
+func jsonTableOnDual(op Operator) *sqlparser.JSONTableExpr {
+	route, ok := op.(*Route)
+	if !ok {
+		return nil
+	}
+	if _, isDual := route.Routing.(*DualRouting); !isDual {
+		return nil
+	}
+	src := route.Source
+	for {
+		filter, ok := src.(*Filter)
+		if !ok {
+			break
+		}
+		src = filter.Source
+	}
+	tbl, ok := src.(*Table)
+	if !ok {
+		return nil
+	}
+	return tbl.QTable.JSONTable
+}
+
+// unbindLateralReferences is the reverse of bindLateralReferences
+func unbindLateralReferences(node sqlparser.SQLNode, vars []BindVarExpr) {
+	sqlparser.SafeRewrite(node, nil, func(cursor *sqlparser.Cursor) bool {
+		arg, ok := cursor.Node().(*sqlparser.Argument)
+		if !ok {
+			return true
+		}
+		for _, bve := range vars {
+			if bve.Name == arg.Name {
+				cursor.Replace(bve.Expr)
+				break
+			}
+		}
+		return true
+	})
+}
+
 func optimizeQueryGraph(ctx *plancontext.PlanningContext, op *QueryGraph) (result Operator, changed *ApplyResult) {
 
-	switch {
+	switch joinOrder := sqlparser.JoinOrderDirective(ctx.Statement); {
+	case len(joinOrder) > 0:
+		result = joinOrderSolve(ctx, op, joinOrder)
 	case ctx.PlannerVersion == querypb.ExecuteOptions_Gen4Left2Right:
 		result = leftToRightSolve(ctx, op)
 	default:
@@ -153,6 +213,28 @@ func leftToRightSolve(ctx *plancontext.PlanningContext, qg *QueryGraph) Operator
 	return acc
 }
 
+// joinOrderSolve joins the tables in the order given by the JOIN_ORDER directive.
+// The tables that are not listed are joined last, in the order of the query.
+func joinOrderSolve(ctx *plancontext.PlanningContext, qg *QueryGraph, joinOrder []string) Operator {
+	position := func(table *QueryTable) int {
+		name := table.Table.Name.String()
+		if table.Alias != nil && !table.Alias.As.IsEmpty() {
+			name = table.Alias.As.String()
+		}
+		for i, hinted := range joinOrder {
+			if strings.EqualFold(name, hinted) {
+				return i
+			}
+		}
+		return len(joinOrder)
+	}
+	tables := slices.Clone(qg.Tables)
+	slices.SortStableFunc(tables, func(a, b *QueryTable) int {
+		return position(a) - position(b)
+	})
+	return leftToRightSolve(ctx, &QueryGraph{Tables: tables, innerJoins: qg.innerJoins, NoDeps: qg.NoDeps})
+}
+
 // seedOperatorList returns a route for each table in the qg
 func seedOperatorList(ctx *plancontext.PlanningContext, qg *QueryGraph) []Operator {
 	plans := make([]Operator, len(qg.Tables))
@@ -19,6 +19,7 @@ package operators
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/slice"
@@ -89,6 +90,9 @@ func newShardedRouting(ctx *plancontext.PlanningContext, vtable *vindexes.Table,
 	if isRt {
 		vindexHint = rt.GetVindexHint()
 	}
+	if vindexHint == nil {
+		vindexHint = forcedVindexHint(ctx, vtable)
+	}
 	for _, columnVindex := range vtable.ColumnVindexes {
 		if vindexHint != nil {
 			switch vindexHint.Type {
@@ -113,6 +117,24 @@ func newShardedRouting(ctx *plancontext.PlanningContext, vtable *vindexes.Table,
 	return routing
 }
 
+// forcedVindexHint returns the USE VINDEX hint that the FORCE_VINDEX directive of the query
+// amounts to for the table, or nil if the table has none of the vindexes of the directive.
+func forcedVindexHint(ctx *plancontext.PlanningContext, vtable *vindexes.Table) *sqlparser.IndexHint {
+	var indexes []sqlparser.IdentifierCI
+	for _, name := range sqlparser.ForceVindexDirective(ctx.Statement) {
+		for _, columnVindex := range vtable.ColumnVindexes {
+			if strings.EqualFold(columnVindex.Name, name) {
+				indexes = append(indexes, sqlparser.NewIdentifierCI(columnVindex.Name))
+				break
+			}
+		}
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+	return &sqlparser.IndexHint{Type: sqlparser.UseVindexOp, Indexes: indexes}
+}
+
 // indexesContains is a helper function that returns whether a given string is part of the IdentifierCI list.
 func indexesContains(indexes []sqlparser.IdentifierCI, name string) bool {
 	return slices.ContainsFunc(indexes, func(ci sqlparser.IdentifierCI) bool {
@@ -230,6 +252,12 @@ func (tr *ShardedRouting) searchForNewVindexes(ctx *plancontext.PlanningContext,
 	case *sqlparser.IsExpr:
 		found := tr.planIsExpr(ctx, node)
 		newVindexFound = newVindexFound || found
+
+	case *sqlparser.BetweenExpr:
+		if node.IsBetween {
+			found := tr.planRangeOp(ctx, node, node.Left, node.From, node.To)
+			newVindexFound = newVindexFound || found
+		}
 	}
 
 	return nil, newVindexFound
@@ -246,11 +274,74 @@ func (tr *ShardedRouting) planComparison(ctx *plancontext.PlanningContext, cmp *
 	case sqlparser.LikeOp:
 		found := tr.planLikeOp(ctx, cmp)
 		return nil, found
-
+	case sqlparser.LessThanOp, sqlparser.LessEqualOp, sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+		found := tr.planInequalityOp(ctx, cmp)
+		return nil, found
 	}
 	return nil, false
 }
 
+// planInequalityOp turns a comparison of a column with a value into a range of values of the column.
+// The bounds of the range are inclusive, so strict comparisons route to a few more shards than necessary.
+func (tr *ShardedRouting) planInequalityOp(ctx *plancontext.PlanningContext, cmp *sqlparser.ComparisonExpr) bool {
+	column, value := cmp.Left, cmp.Right
+	lowerBound := cmp.Operator == sqlparser.GreaterThanOp || cmp.Operator == sqlparser.GreaterEqualOp
+	if _, ok := column.(*sqlparser.ColName); !ok {
+		// the value is on the left hand side: `value < col` bounds the column from below
+		column, value = cmp.Right, cmp.Left
+		lowerBound = !lowerBound
+	}
+	if lowerBound {
+		return tr.planRangeOp(ctx, cmp, column, value, nil)
+	}
+	return tr.planRangeOp(ctx, cmp, column, nil, value)
+}
+
+// planRangeOp adds a vindex option for the range predicate on the leading column of a RangeMappable vindex.
+// A nil bound leaves that side of the range open.
+func (tr *ShardedRouting) planRangeOp(ctx *plancontext.PlanningContext, node, left, from, to sqlparser.Expr) bool {
+	column, ok := left.(*sqlparser.ColName)
+	if !ok {
+		return false
+	}
+	var values []evalengine.Expr
+	var valueExprs []sqlparser.Expr
+	for _, bound := range []sqlparser.Expr{from, to} {
+		if bound == nil {
+			values = append(values, evalengine.NullExpr)
+			valueExprs = append(valueExprs, &sqlparser.NullVal{})
+			continue
+		}
+		value := makeEvalEngineExpr(ctx, bound)
+		if value == nil {
+			return false
+		}
+		values = append(values, value)
+		valueExprs = append(valueExprs, bound)
+	}
+
+	newVindexFound := false
+	for _, v := range tr.VindexPreds {
+		if !ctx.SemTable.DirectDeps(column).IsSolvedBy(v.TableID) {
+			continue
+		}
+		if _, ok := v.ColVindex.Vindex.(vindexes.RangeMappable); !ok || !column.Name.Equal(v.ColVindex.Columns[0]) {
+			continue
+		}
+		v.Options = append(v.Options, &VindexOption{
+			Values:      values,
+			ValueExprs:  valueExprs,
+			Predicates:  []sqlparser.Expr{node},
+			OpCode:      engine.Range,
+			FoundVindex: v.ColVindex.Vindex,
+			Cost:        costFor(v.ColVindex, engine.Range),
+			Ready:       true,
+		})
+		newVindexFound = true
+	}
+	return newVindexFound
+}
+
 func (tr *ShardedRouting) planIsExpr(ctx *plancontext.PlanningContext, node *sqlparser.IsExpr) bool {
 	// we only handle IS NULL correct. IsExpr can contain other expressions as well
 	if node.Right != sqlparser.IsNullOp {
@@ -276,13 +367,17 @@ func (tr *ShardedRouting) planIsExpr(ctx *plancontext.PlanningContext, node *sql
 }
 
 func (tr *ShardedRouting) planInOp(ctx *plancontext.PlanningContext, cmp *sqlparser.ComparisonExpr) bool {
-	switch left := cmp.Left.(type) {
+	left := cmp.Left
+	if col := tr.generatedColumn(ctx, left); col != nil {
+		left = col
+	}
+	switch left := left.(type) {
 	case *sqlparser.ColName:
 		vdValue := cmp.Right
 
 		valTuple, isTuple := vdValue.(sqlparser.ValTuple)
 		if isTuple && len(valTuple) == 1 {
-			return tr.planEqualOp(ctx, &sqlparser.ComparisonExpr{Left: left, Right: valTuple[0], Operator: sqlparser.EqualOp})
+			return tr.planEqualOp(ctx, &sqlparser.ComparisonExpr{Left: cmp.Left, Right: valTuple[0], Operator: sqlparser.EqualOp})
 		}
 
 		value := makeEvalEngineExpr(ctx, vdValue)
@@ -335,6 +430,8 @@ func (tr *ShardedRouting) Cost() int {
 		return 10
 	case engine.MultiEqual:
 		return 10
+	case engine.Range:
+		return 15
 	case engine.Scatter:
 		return 20
 	default:
@@ -490,11 +587,18 @@ func (tr *ShardedRouting) planEqualOp(ctx *plancontext.PlanningContext, node *sq
 	vdValue := other
 	if !ok {
 		column, ok = node.Right.(*sqlparser.ColName)
-		if !ok {
-			// either the LHS or RHS have to be a column to be useful for the vindex
+		vdValue = node.Left
+	}
+	if !ok {
+		// either the LHS or RHS have to be a column to be useful for the vindex,
+		// or the expression of a generated column
+		if column = tr.generatedColumn(ctx, node.Left); column != nil {
+			vdValue = node.Right
+		} else if column = tr.generatedColumn(ctx, node.Right); column != nil {
+			vdValue = node.Left
+		} else {
 			return false
 		}
-		vdValue = node.Left
 	}
 	val := makeEvalEngineExpr(ctx, vdValue)
 	if val == nil {
@@ -585,6 +689,18 @@ func (tr *ShardedRouting) planCompositeInOpArg(
 	return foundVindex
 }
 
+// generatedColumn returns the generated column that has the given expression,
+// in one of the tables of the route
+func (tr *ShardedRouting) generatedColumn(ctx *plancontext.PlanningContext, expr sqlparser.Expr) *sqlparser.ColName {
+	for _, vp := range tr.VindexPreds {
+		if col := generatedColumnFor(ctx, expr, vp.TableID); col != nil {
+			ctx.SemTable.CopyDependencies(expr, col)
+			return col
+		}
+	}
+	return nil
+}
+
 func (tr *ShardedRouting) hasVindex(column *sqlparser.ColName) bool {
 	for _, v := range tr.VindexPreds {
 		for _, col := range v.ColVindex.Columns {
@@ -661,7 +777,7 @@ func tryMergeJoinShardedRouting(
 		// can merge via join predicates instead.
 		fallthrough
 
-	case engine.Scatter, engine.IN, engine.None:
+	case engine.Scatter, engine.IN, engine.Range, engine.None:
 		if len(joinPredicates) == 0 {
 			// If we are doing two Scatters, we have to make sure that the
 			// joins are on the correct vindex to allow them to be merged
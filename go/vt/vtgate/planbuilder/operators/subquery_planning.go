@@ -64,6 +64,12 @@ func isMergeable(ctx *plancontext.PlanningContext, query sqlparser.SelectStateme
 			return false
 		}
 
+		// the window functions can only be evaluated inside a single shard if all their windows are
+		// partitioned by a unique vindex
+		if fns := windowFunctions(ctx, node); len(fns) > 0 && !windowsPartitionedBy(node, fns, validVindex) {
+			return false
+		}
+
 		return true
 	case *sqlparser.Union:
 		return isMergeable(ctx, node.Left, op) && isMergeable(ctx, node.Right, op)
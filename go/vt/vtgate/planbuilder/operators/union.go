@@ -23,6 +23,7 @@ import (
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 )
 
@@ -33,6 +34,13 @@ type Union struct {
 	Selects  []sqlparser.SelectExprs
 	distinct bool
 
+	// setOp is UNION, INTERSECT or EXCEPT. INTERSECT and EXCEPT always have exactly two sources
+	setOp sqlparser.SetOpType
+
+	// CheckCols are the columns used to compare rows of the two sides of an INTERSECT or EXCEPT.
+	// This is only filled in during offset planning
+	CheckCols []engine.CheckCol
+
 	unionColumns              sqlparser.SelectExprs
 	unionColumnsAsAlisedExprs []*sqlparser.AliasedExpr
 }
@@ -54,6 +62,7 @@ func (u *Union) Clone(inputs []Operator) Operator {
 	newOp := *u
 	newOp.Sources = inputs
 	newOp.Selects = slices.Clone(u.Selects)
+	newOp.CheckCols = slices.Clone(u.CheckCols)
 	return &newOp
 }
 
@@ -266,9 +275,60 @@ func (u *Union) GetSelectExprs(ctx *plancontext.PlanningContext) sqlparser.Selec
 
 func (u *Union) NoLHSTableSet() {}
 
+// IsUnion returns false when this operator is an INTERSECT or an EXCEPT
+func (u *Union) IsUnion() bool {
+	return u.setOp == sqlparser.UnionType
+}
+
+// SetOp returns the set operator this operator evaluates
+func (u *Union) SetOp() sqlparser.SetOpType {
+	return u.setOp
+}
+
+// Distinct returns true if duplicate rows are removed from the output
+func (u *Union) Distinct() bool {
+	return u.distinct
+}
+
+// planOffsets implements the offsettable interface. For INTERSECT and EXCEPT, vtgate has to compare
+// the rows coming from both sides, so we need to know which columns to hash and how
+func (u *Union) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	if u.IsUnion() {
+		return nil
+	}
+	// only the columns of the query are compared - operators above us might have added more columns
+	columns := u.GetColumns(ctx)[:len(u.Selects[0])]
+	for idx, col := range columns {
+		e := col.Expr
+		var wsCol *int
+		if ctx.SemTable.NeedsWeightString(e) {
+			offset := u.AddWSColumn(ctx, idx, false)
+			wsCol = &offset
+		}
+		typ, _ := ctx.SemTable.TypeForExpr(e)
+		u.CheckCols = append(u.CheckCols, engine.CheckCol{
+			Col:          idx,
+			WsCol:        wsCol,
+			Type:         typ,
+			CollationEnv: ctx.VSchema.Environment().CollationEnv(),
+		})
+	}
+	return nil
+}
+
 func (u *Union) ShortDescription() string {
+	var op string
+	switch u.setOp {
+	case sqlparser.IntersectType:
+		op = "INTERSECT "
+	case sqlparser.ExceptType:
+		op = "EXCEPT "
+	}
 	if u.distinct {
-		return "DISTINCT"
+		return op + "DISTINCT"
+	}
+	if op != "" {
+		return op + "ALL"
 	}
 	return ""
 }
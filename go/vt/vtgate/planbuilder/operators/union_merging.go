@@ -98,6 +98,39 @@ func mergeUnionInputsInOrder(ctx *plancontext.PlanningContext, op *Union) ([]Ope
 	return sources, selects
 }
 
+// mergeSetOpInputs tries to merge the two inputs of an INTERSECT or an EXCEPT into a single route.
+// Unlike UNION, these can't be evaluated shard by shard and then concatenated, so we only merge
+// when the merged route is sent to a single shard, or when the query is DISTINCT and the
+// rows of the right-hand side are available on every shard the left-hand side is sent to
+func mergeSetOpInputs(ctx *plancontext.PlanningContext, op *Union) ([]Operator, []sqlparser.SelectExprs) {
+	lhs, rhs := op.Sources[0], op.Sources[1]
+	newPlan, sel := mergeUnionInputs(ctx, lhs, rhs, op.Selects[0], op.Selects[1], op.distinct)
+	if newPlan == nil {
+		return op.Sources, op.Selects
+	}
+
+	route := newPlan.(*Route)
+	if !route.IsSingleShard() && !(op.distinct && availableOnAllShards(rhs)) {
+		return op.Sources, op.Selects
+	}
+
+	route.Source.(*Union).setOp = op.setOp
+	return []Operator{route}, []sqlparser.SelectExprs{sel}
+}
+
+// availableOnAllShards returns true if the operator returns the same rows no matter which shard it is sent to
+func availableOnAllShards(op Operator) bool {
+	route, ok := op.(*Route)
+	if !ok {
+		return false
+	}
+	switch route.Routing.(type) {
+	case *DualRouting, *AnyShardRouting:
+		return true
+	}
+	return false
+}
+
 // mergeUnionInputs checks whether two operators can be merged into a single one.
 // If they can be merged, a new operator with the merged routing is returned
 // If they cannot be merged, nil is returned.
@@ -229,6 +262,10 @@ func createMergedUnion(
 }
 
 func compactUnion(u *Union) *ApplyResult {
+	if !u.IsUnion() {
+		// INTERSECT and EXCEPT can't be flattened
+		return NoRewrite
+	}
 	if u.distinct {
 		// first we remove unnecessary DISTINCTs
 		for idx, source := range u.Sources {
@@ -247,7 +284,7 @@ func compactUnion(u *Union) *ApplyResult {
 	for idx, source := range u.Sources {
 		other, ok := source.(*Union)
 
-		if ok && (u.distinct || !other.distinct) {
+		if ok && other.IsUnion() && (u.distinct || !other.distinct) {
 			newSources = append(newSources, other.Sources...)
 			newSelects = append(newSelects, other.Selects...)
 			merged = true
@@ -365,7 +365,7 @@ func getUpdateVindexInformation(
 	table TargetTable,
 	assignments []SetExpr,
 ) (map[string]*engine.VindexValues, *sqlparser.Select, []string) {
-	if !table.VTable.Keyspace.Sharded {
+	if !table.VTable.Keyspace.Sharded || table.VTable.Pinned != nil {
 		return nil, nil, nil
 	}
 
@@ -606,7 +606,7 @@ func createFkChildForUpdate(ctx *plancontext.PlanningContext, fk vindexes.ChildF
 	case sqlparser.SetNull:
 		childOp = buildChildUpdOpForSetNull(ctx, fk, childWhereExpr, nonLiteralUpdateInfo, updatedTable)
 	case sqlparser.SetDefault:
-		panic(vterrors.VT09016())
+		childOp = buildChildUpdOpForSetDefault(ctx, fk, childWhereExpr, nonLiteralUpdateInfo, updatedTable)
 	}
 
 	return &FkChild{
@@ -709,6 +709,73 @@ func buildChildUpdOpForSetNull(
 	return createOpFromStmt(ctx, childUpdStmt, false, "")
 }
 
+// buildChildUpdOpForSetDefault builds the child update statement operator for the SET DEFAULT type foreign key constraint.
+// The query looks like this -
+//
+//	`UPDATE <child_table> SET <child_columns_in_fk> = <default values of the child columns>
+//	WHERE <child_columns_in_fk> IN (<bind variable for the output from SELECT>)
+//	[AND ({<bind variables in the SET clause of the original update> IS NULL OR}... <child_columns_in_fk> NOT IN (<bind variables in the SET clause of the original update>))]`
+func buildChildUpdOpForSetDefault(
+	ctx *plancontext.PlanningContext,
+	fk vindexes.ChildFKInfo,
+	childWhereExpr sqlparser.Expr,
+	nonLiteralUpdateInfo []engine.NonLiteralUpdateInfo,
+	updatedTable *vindexes.Table,
+) Operator {
+	// Like SET NULL, the child rows are left alone when the parent columns remain unchanged on the update.
+	updateExprs := ctx.SemTable.GetUpdateExpressionsForFk(fk.String(updatedTable))
+	compExpr := nullSafeNotInComparison(ctx,
+		updatedTable,
+		updateExprs, fk, updatedTable.GetTableName(), nonLiteralUpdateInfo, false /* appendQualifier */)
+	if compExpr != nil {
+		childWhereExpr = &sqlparser.AndExpr{
+			Left:  childWhereExpr,
+			Right: compExpr,
+		}
+	}
+	// InnoDB doesn't support SET DEFAULT, so MySQL can't check that the default values exist in the parent.
+	// We run the child update with foreign key checks OFF and verify all of its foreign keys on VTGate instead,
+	// including the one we are cascading.
+	parsedComments := (&sqlparser.ParsedComments{}).SetMySQLSetVarValue(sysvars.ForeignKeyChecks, "OFF").Parsed()
+	childUpdStmt := &sqlparser.Update{
+		Exprs:      childUpdateExprsForSetDefault(fk),
+		Comments:   parsedComments,
+		TableExprs: []sqlparser.TableExpr{sqlparser.NewAliasedTableExpr(fk.Table.GetTableName(), "")},
+		Where:      &sqlparser.Where{Type: sqlparser.WhereClause, Expr: childWhereExpr},
+	}
+	return createOpFromStmt(ctx, childUpdStmt, true, "")
+}
+
+// childUpdateExprsForSetDefault returns the update expressions setting the child columns of the foreign key to their default values.
+// The default values have to be known from the schema, so that they can be verified against the parent table.
+func childUpdateExprsForSetDefault(fk vindexes.ChildFKInfo) sqlparser.UpdateExprs {
+	var updExprs sqlparser.UpdateExprs
+	for _, column := range fk.ChildColumns {
+		updExprs = append(updExprs, &sqlparser.UpdateExpr{
+			Name: sqlparser.NewColName(column.String()),
+			Expr: columnDefault(fk.Table, column),
+		})
+	}
+	return updExprs
+}
+
+// columnDefault returns the default value of the column, which is NULL for a nullable column without a DEFAULT clause.
+func columnDefault(tbl *vindexes.Table, column sqlparser.IdentifierCI) sqlparser.Expr {
+	for _, col := range tbl.Columns {
+		if !col.Name.Equal(column) {
+			continue
+		}
+		if col.Default != nil {
+			return sqlparser.CloneExpr(col.Default)
+		}
+		if col.Nullable {
+			return &sqlparser.NullVal{}
+		}
+		break
+	}
+	panic(vterrors.VT12001(fmt.Sprintf("SET DEFAULT foreign key action on %s without a known default value for column %s", tbl.Name.String(), column.String())))
+}
+
 // getParsedCommentsForFkChecks gets the parsed comments to be set on a child query related to foreign_key_checks session variable.
 // We only use this function if foreign key checks are either unspecified or on.
 // If foreign key checks are explicity turned on, then we should add the set_var parsed comment too
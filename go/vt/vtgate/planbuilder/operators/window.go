@@ -0,0 +1,410 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+)
+
+// Window evaluates the window functions that share a window specification
+// at the vtgate. Its source has to be sorted by the partitioning and then the
+// ordering of the window.
+// The window functions are the first columns of the operator, and the
+// columns of the source follow them, so adding columns to the source does not
+// change the offsets of the columns already handed out.
+type Window struct {
+	Source Operator
+
+	// Spec is the window specification, with any named window resolved
+	Spec *sqlparser.WindowSpecification
+
+	Functions []sqlparser.Expr
+
+	// These are filled in during offset planning
+	PartitionBy []*engine.GroupByParams
+	OrderBy     []*engine.GroupByParams
+	Params      []*engine.WindowFunctionParams
+}
+
+func (w *Window) Clone(inputs []Operator) Operator {
+	return &Window{
+		Source:      inputs[0],
+		Spec:        w.Spec,
+		Functions:   slices.Clone(w.Functions),
+		PartitionBy: slices.Clone(w.PartitionBy),
+		OrderBy:     slices.Clone(w.OrderBy),
+		Params:      slices.Clone(w.Params),
+	}
+}
+
+func (w *Window) Inputs() []Operator {
+	return []Operator{w.Source}
+}
+
+func (w *Window) SetInputs(operators []Operator) {
+	w.Source = operators[0]
+}
+
+func (w *Window) AddPredicate(_ *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	// filtering the input of the window functions would change their values
+	return newFilter(w, expr)
+}
+
+func (w *Window) AddColumn(ctx *plancontext.PlanningContext, reuse bool, gb bool, expr *sqlparser.AliasedExpr) int {
+	if offset := w.findFunction(ctx, expr.Expr); offset >= 0 {
+		return offset
+	}
+	return len(w.Functions) + w.Source.AddColumn(ctx, reuse, gb, expr)
+}
+
+func (w *Window) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
+	if offset < len(w.Functions) {
+		panic(vterrors.VT12001(fmt.Sprintf("weight_string of the window function %s", sqlparser.String(w.Functions[offset]))))
+	}
+	return len(w.Functions) + w.Source.AddWSColumn(ctx, offset-len(w.Functions), underRoute)
+}
+
+func (w *Window) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
+	if offset := w.findFunction(ctx, expr); offset >= 0 {
+		return offset
+	}
+	offset := w.Source.FindCol(ctx, expr, underRoute)
+	if offset < 0 {
+		return offset
+	}
+	return len(w.Functions) + offset
+}
+
+func (w *Window) findFunction(ctx *plancontext.PlanningContext, expr sqlparser.Expr) int {
+	for idx, fn := range w.Functions {
+		if ctx.SemTable.EqualsExprWithDeps(fn, expr) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (w *Window) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	columns := slice.Map(w.Functions, aeWrap)
+	return append(columns, w.Source.GetColumns(ctx)...)
+}
+
+func (w *Window) GetSelectExprs(ctx *plancontext.PlanningContext) sqlparser.SelectExprs {
+	return transformColumnsToSelectExprs(ctx, w)
+}
+
+func (w *Window) ShortDescription() string {
+	return strings.Join(slice.Map(w.Functions, func(fn sqlparser.Expr) string {
+		return sqlparser.String(fn)
+	}), ", ")
+}
+
+func (w *Window) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
+	return w.Source.GetOrdering(ctx)
+}
+
+func (w *Window) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	w.PartitionBy = w.planKeyOffsets(ctx, w.Spec.PartitionClause)
+	w.OrderBy = w.planKeyOffsets(ctx, slice.Map(w.Spec.OrderClause, func(o *sqlparser.Order) sqlparser.Expr {
+		return o.Expr
+	}))
+
+	for _, fn := range w.Functions {
+		code, arg, def, n := windowFunctionInfo(fn)
+		params := &engine.WindowFunctionParams{
+			Opcode:     code,
+			Col:        -1,
+			N:          n,
+			DefaultCol: -1,
+			Alias:      sqlparser.String(fn),
+		}
+		if arg != nil {
+			params.Col = w.Source.AddColumn(ctx, true, false, aeWrap(arg))
+			params.Type, _ = ctx.SemTable.TypeForExpr(arg)
+		}
+		if def != nil {
+			params.DefaultCol = w.Source.AddColumn(ctx, true, false, aeWrap(def))
+		}
+		w.Params = append(w.Params, params)
+	}
+	return nil
+}
+
+func (w *Window) planKeyOffsets(ctx *plancontext.PlanningContext, exprs []sqlparser.Expr) []*engine.GroupByParams {
+	var keys []*engine.GroupByParams
+	for _, expr := range exprs {
+		key := &engine.GroupByParams{
+			KeyCol:          w.Source.AddColumn(ctx, true, false, aeWrap(expr)),
+			WeightStringCol: -1,
+			Expr:            expr,
+			CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
+		}
+		if ctx.SemTable.NeedsWeightString(expr) {
+			wsExpr := &sqlparser.WeightStringFuncExpr{Expr: expr}
+			key.WeightStringCol = w.Source.AddColumn(ctx, true, false, aeWrap(wsExpr))
+		}
+		key.Type, _ = ctx.SemTable.TypeForExpr(expr)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// windowFunctions returns the window functions used in the select expressions and the ORDER BY of the query
+func windowFunctions(ctx *plancontext.PlanningContext, sel *sqlparser.Select) []sqlparser.Expr {
+	var fns []sqlparser.Expr
+	visit := func(node sqlparser.SQLNode) (bool, error) {
+		if _, isSubq := node.(*sqlparser.Subquery); isSubq {
+			return false, nil
+		}
+		if sqlparser.GetOverClause(node) == nil {
+			return true, nil
+		}
+		fn := node.(sqlparser.Expr)
+		for _, existing := range fns {
+			if ctx.SemTable.EqualsExprWithDeps(existing, fn) {
+				return false, nil
+			}
+		}
+		fns = append(fns, fn)
+		return false, nil
+	}
+	_ = sqlparser.Walk(visit, sel.SelectExprs, sel.OrderBy)
+	return fns
+}
+
+// resolveWindowSpec returns the window specification of an OVER clause,
+// resolving the named windows it refers to
+func resolveWindowSpec(sel *sqlparser.Select, over *sqlparser.OverClause) *sqlparser.WindowSpecification {
+	name := over.WindowName
+	spec := &sqlparser.WindowSpecification{}
+	if over.WindowSpec != nil {
+		name = over.WindowSpec.Name
+		spec.PartitionClause = over.WindowSpec.PartitionClause
+		spec.OrderClause = over.WindowSpec.OrderClause
+		spec.FrameClause = over.WindowSpec.FrameClause
+	}
+
+	// a named window can itself refer to another named window
+	definitions := 0
+	for _, namedWindow := range sel.Windows {
+		definitions += len(namedWindow.Windows)
+	}
+	for seen := 0; !name.IsEmpty(); seen++ {
+		base := namedWindowSpec(sel, name)
+		if base == nil || seen >= definitions {
+			panic(vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Window name '%s' is not defined.", name.String()))
+		}
+		spec.PartitionClause = base.PartitionClause
+		if len(spec.OrderClause) == 0 {
+			spec.OrderClause = base.OrderClause
+		}
+		if spec.FrameClause == nil {
+			spec.FrameClause = base.FrameClause
+		}
+		if base.Name.Equal(name) {
+			// the parser names the specification of a window definition after the window itself
+			break
+		}
+		name = base.Name
+	}
+	return spec
+}
+
+func namedWindowSpec(sel *sqlparser.Select, name sqlparser.IdentifierCI) *sqlparser.WindowSpecification {
+	for _, namedWindow := range sel.Windows {
+		for _, def := range namedWindow.Windows {
+			if def.Name.Equal(name) {
+				return def.WindowSpec
+			}
+		}
+	}
+	return nil
+}
+
+// windowsPartitionedBy returns true if every window of the window functions
+// is partitioned by an expression for which isVindex returns true. The rows
+// of these windows are all on the same shard, so the shards can evaluate them.
+func windowsPartitionedBy(sel *sqlparser.Select, fns []sqlparser.Expr, isVindex func(sqlparser.Expr) bool) bool {
+	for _, fn := range fns {
+		spec := resolveWindowSpec(sel, sqlparser.GetOverClause(fn))
+		if !slices.ContainsFunc(spec.PartitionClause, isVindex) {
+			return false
+		}
+	}
+	return true
+}
+
+// windowsArePushable returns true if the window functions of the query, if
+// any, can be evaluated by the shards that src is sent to
+func windowsArePushable(ctx *plancontext.PlanningContext, sel *sqlparser.Select, src Operator) bool {
+	fns := windowFunctions(ctx, sel)
+	if len(fns) == 0 {
+		return true
+	}
+	rb, isRoute := src.(*Route)
+	if !isRoute {
+		return false
+	}
+	if rb.IsSingleShard() {
+		return true
+	}
+	return windowsPartitionedBy(sel, fns, func(expr sqlparser.Expr) bool {
+		return exprHasUniqueVindex(ctx, expr)
+	})
+}
+
+// planWindows places the operators that evaluate the window functions of the
+// query at the vtgate on top of src. The functions sharing a window are
+// evaluated by the same Window operator, under which the rows are sorted by
+// the partitioning and the ordering of the window.
+func planWindows(ctx *plancontext.PlanningContext, sel *sqlparser.Select, src Operator) Operator {
+	var windows []*Window
+outer:
+	for _, fn := range windowFunctions(ctx, sel) {
+		// check that we can evaluate the function before going any further
+		_, _, _, _ = windowFunctionInfo(fn)
+
+		spec := resolveWindowSpec(sel, sqlparser.GetOverClause(fn))
+		if spec.FrameClause != nil {
+			panic(vterrors.VT12001("window frame clause on a sharded keyspace"))
+		}
+		for _, w := range windows {
+			if sqlparser.Equals.RefOfWindowSpecification(w.Spec, spec) {
+				w.Functions = append(w.Functions, fn)
+				continue outer
+			}
+		}
+		windows = append(windows, &Window{Spec: spec, Functions: []sqlparser.Expr{fn}})
+	}
+
+	for _, w := range windows {
+		var order []OrderBy
+		for _, expr := range w.Spec.PartitionClause {
+			order = append(order, OrderBy{
+				Inner:          &sqlparser.Order{Expr: expr, Direction: sqlparser.AscOrder},
+				SimplifiedExpr: expr,
+			})
+		}
+		for _, o := range w.Spec.OrderClause {
+			order = append(order, OrderBy{Inner: o, SimplifiedExpr: o.Expr})
+		}
+		if len(order) > 0 {
+			src = &Ordering{Source: src, Order: order}
+		}
+		w.Source = src
+		src = w
+	}
+	return src
+}
+
+// windowFunctionInfo returns what the vtgate needs to evaluate a window function:
+// the opcode, the argument and default value expressions, if any, and the N of the function.
+func windowFunctionInfo(fn sqlparser.Expr) (code engine.WindowOpcode, arg, def sqlparser.Expr, n int64) {
+	switch fn := fn.(type) {
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch fn.Type {
+		case sqlparser.RowNumberExprType:
+			code = engine.WindowRowNumber
+		case sqlparser.RankExprType:
+			code = engine.WindowRank
+		case sqlparser.DenseRankExprType:
+			code = engine.WindowDenseRank
+		case sqlparser.PercentRankExprType:
+			code = engine.WindowPercentRank
+		case sqlparser.CumeDistExprType:
+			code = engine.WindowCumeDist
+		}
+		return code, nil, nil, 0
+	case *sqlparser.NtileExpr:
+		return engine.WindowNtile, nil, nil, windowFunctionN("ntile", fn.N, 1)
+	case *sqlparser.LagLeadExpr:
+		checkNullTreatment(fn, fn.NullTreatmentClause)
+		code = engine.WindowLag
+		if fn.Type == sqlparser.LeadExprType {
+			code = engine.WindowLead
+		}
+		n = 1
+		if fn.N != nil {
+			n = windowFunctionN(code.String(), fn.N, 0)
+		}
+		return code, fn.Expr, fn.Default, n
+	case *sqlparser.FirstOrLastValueExpr:
+		checkNullTreatment(fn, fn.NullTreatmentClause)
+		code = engine.WindowFirstValue
+		if fn.Type == sqlparser.LastValueExprType {
+			code = engine.WindowLastValue
+		}
+		return code, fn.Expr, nil, 0
+	case *sqlparser.NTHValueExpr:
+		checkNullTreatment(fn, fn.NullTreatmentClause)
+		if fn.FromFirstLastClause != nil && fn.FromFirstLastClause.Type == sqlparser.FromLastType {
+			panic(vterrors.VT12001(fmt.Sprintf("FROM LAST in %s", sqlparser.String(fn))))
+		}
+		return engine.WindowNthValue, fn.Expr, nil, windowFunctionN("nth_value", fn.N, 1)
+	case *sqlparser.CountStar:
+		return engine.WindowCountStar, nil, nil, 0
+	case *sqlparser.Count:
+		if !fn.Distinct && len(fn.Args) == 1 {
+			return engine.WindowCount, fn.Args[0], nil, 0
+		}
+	case *sqlparser.Sum:
+		if !fn.Distinct {
+			return engine.WindowSum, fn.Arg, nil, 0
+		}
+	case *sqlparser.Avg:
+		if !fn.Distinct {
+			return engine.WindowAvg, fn.Arg, nil, 0
+		}
+	case *sqlparser.Min:
+		if !fn.Distinct {
+			return engine.WindowMin, fn.Arg, nil, 0
+		}
+	case *sqlparser.Max:
+		if !fn.Distinct {
+			return engine.WindowMax, fn.Arg, nil, 0
+		}
+	}
+	panic(vterrors.VT12001(fmt.Sprintf("window function %s on a sharded keyspace", sqlparser.String(fn))))
+}
+
+// windowFunctionN returns the N of NTILE, LAG, LEAD or NTH_VALUE, which has to be an integer literal
+func windowFunctionN(name string, expr sqlparser.Expr, minimum int64) int64 {
+	lit, isLit := expr.(*sqlparser.Literal)
+	if isLit && lit.Type == sqlparser.IntVal {
+		n, err := strconv.ParseInt(lit.Val, 10, 64)
+		if err == nil && n >= minimum {
+			return n
+		}
+	}
+	panic(vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect arguments to %s", name))
+}
+
+func checkNullTreatment(fn sqlparser.Expr, clause *sqlparser.NullTreatmentClause) {
+	if clause != nil && clause.Type == sqlparser.IgnoreNullsType {
+		panic(vterrors.VT12001(fmt.Sprintf("IGNORE NULLS in %s", sqlparser.String(fn))))
+	}
+}
@@ -48,7 +48,7 @@ import (
 )
 
 func makeTestOutput(t *testing.T) string {
-	testOutputTempDir := utils.MakeTestOutput(t, "testdata", "plan_test")
+	testOutputTempDir := utils.MakeTestOutput(t, t.TempDir(), "plan_test")
 
 	return testOutputTempDir
 }
@@ -67,6 +67,7 @@ func TestPlan(t *testing.T) {
 	addPKsProvided(t, vschemaWrapper.V, "user", []string{"user_extra"}, []string{"id", "user_id"})
 	addPKsProvided(t, vschemaWrapper.V, "ordering", []string{"order"}, []string{"oid", "region_id"})
 	addPKsProvided(t, vschemaWrapper.V, "ordering", []string{"order_event"}, []string{"oid", "ename"})
+	addExpressionIndexes(t, vschemaWrapper.V)
 
 	// You will notice that some tests expect user.Id instead of user.id.
 	// This is because we now pre-create vindex columns in the symbol
@@ -102,6 +103,7 @@ func TestPlan(t *testing.T) {
 	testFile(t, "vexplain_cases.json", testOutputTempDir, vschemaWrapper, false)
 	testFile(t, "misc_cases.json", testOutputTempDir, vschemaWrapper, false)
 	testFile(t, "cte_cases.json", testOutputTempDir, vschemaWrapper, false)
+	testFile(t, "window_cases.json", testOutputTempDir, vschemaWrapper, false)
 }
 
 // TestForeignKeyPlanning tests the planning of foreign keys in a managed mode by Vitess.
@@ -188,8 +190,12 @@ func setFks(t *testing.T, vschema *vindexes.VSchema) {
 
 		// FK from tbl_auth referencing tbl20 that is shard scoped of CASCADE types.
 		_ = vschema.AddForeignKey("sharded_fk_allow", "tbl_auth", createFkDefinition([]string{"id"}, "tbl20", []string{"col2"}, sqlparser.Cascade, sqlparser.Cascade))
+
+		// FKs from multicol_tbl4 referencing multicol_tbl3 that are not shard scoped of CASCADE and SET-Default types.
+		_ = vschema.AddForeignKey("sharded_fk_allow", "multicol_tbl4", createFkDefinition([]string{"cola", "colb"}, "multicol_tbl3", []string{"cola", "colb"}, sqlparser.Cascade, sqlparser.SetDefault))
+		_ = vschema.AddForeignKey("sharded_fk_allow", "multicol_tbl4", createFkDefinition([]string{"colc", "cold"}, "multicol_tbl3", []string{"colc", "cold"}, sqlparser.SetDefault, sqlparser.Cascade))
 		addPKs(t, vschema, "sharded_fk_allow", []string{"tbl1", "tbl2", "tbl3", "tbl4", "tbl5", "tbl6", "tbl7", "tbl9", "tbl10",
-			"multicol_tbl1", "multicol_tbl2", "tbl_auth", "tblrefDef", "tbl20"})
+			"multicol_tbl1", "multicol_tbl2", "multicol_tbl3", "multicol_tbl4", "tbl_auth", "tblrefDef", "tbl20"})
 	}
 	if vschema.Keyspaces["unsharded_fk_allow"] != nil {
 		// u_tbl2(col2)  -> u_tbl1(col1)  Cascade.
@@ -248,6 +254,25 @@ func addPKsProvided(t *testing.T, vschema *vindexes.VSchema, ks string, tbls []s
 	}
 }
 
+// addExpressionIndexes adds the schema that schema tracking would find for
+// an expression index on user.textcol1 and for a generated column of user_metadata with a vindex.
+func addExpressionIndexes(t *testing.T, vschema *vindexes.VSchema) {
+	parser := sqlparser.NewTestParser()
+	lowerTextcol1, err := parser.ParseExpr("lower(textcol1)")
+	require.NoError(t, err)
+	require.NoError(t,
+		vschema.AddExpressionIndex("user", "user", "idx_lower_textcol1", sqlparser.Exprs{lowerTextcol1, sqlparser.NewColName("intcol")}))
+
+	md5Email, err := parser.ParseExpr("md5(email)")
+	require.NoError(t, err)
+	tbl := vschema.Keyspaces["user"].Tables["user_metadata"]
+	tbl.Columns = append(tbl.Columns, vindexes.Column{
+		Name:      sqlparser.NewIdentifierCI("md5"),
+		Type:      sqltypes.VarBinary,
+		Generated: md5Email,
+	})
+}
+
 func TestSystemTables57(t *testing.T) {
 	// first we move everything to use 5.7 logic
 	env, err := vtenv.New(vtenv.Options{
@@ -293,6 +318,7 @@ func TestOne(t *testing.T) {
 	addPKsProvided(t, lv, "user", []string{"user_extra"}, []string{"id", "user_id"})
 	addPKsProvided(t, lv, "ordering", []string{"order"}, []string{"oid", "region_id"})
 	addPKsProvided(t, lv, "ordering", []string{"order_event"}, []string{"oid", "ename"})
+	addExpressionIndexes(t, lv)
 	vschema := &vschemawrapper.VSchemaWrapper{
 		V:           lv,
 		TestBuilder: TestBuilder,
@@ -18,6 +18,7 @@ package planbuilder
 
 import (
 	"fmt"
+	"strings"
 
 	"vitess.io/vitess/go/vt/key"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -39,6 +40,20 @@ func gen4SelectStmtPlanner(
 ) (*planResult, error) {
 	sel, isSel := stmt.(*sqlparser.Select)
 	if isSel {
+		assign, err := extractUDVAssignments(sel)
+		if err != nil {
+			return nil, err
+		}
+		if assign != nil {
+			plan, err := gen4SelectStmtPlanner(query, plannerVersion, sel, reservedVars, vschema)
+			if err != nil {
+				return nil, err
+			}
+			assign.Input = plan.primitive
+			plan.primitive = assign
+			return plan, nil
+		}
+
 		// handle dual table for processing at vtgate.
 		p, err := handleDualSelects(sel, vschema)
 		if err != nil {
@@ -90,7 +105,11 @@ func gen4SelectStmtPlanner(
 	// All other engine primitives can handle this, so we only need it when
 	// Route is the last (and only) instruction before the user sees a result
 	if isOnlyDual(sel) || (len(sel.GroupBy) == 0 && sel.SelectExprs.AllAggregation()) {
-		switch prim := primitive.(type) {
+		prim := primitive
+		if export, isExport := prim.(*engine.Export); isExport {
+			prim = export.Input
+		}
+		switch prim := prim.(type) {
 		case *engine.Route:
 			prim.NoRoutesSpecialHandling = true
 		case *engine.VindexLookup:
@@ -100,6 +119,65 @@ func gen4SelectStmtPlanner(
 	return newPlanResult(primitive, tablesUsed...), nil
 }
 
+// extractUDVAssignments replaces the `@var := expr` select expressions by their value expression,
+// and returns the primitive that assigns the variables from the results of the query.
+// It returns nil if the query doesn't assign any user defined variable.
+func extractUDVAssignments(sel *sqlparser.Select) (*engine.AssignUDV, error) {
+	var assign *engine.AssignUDV
+	for offset, expr := range sel.SelectExprs {
+		ae, isAliased := expr.(*sqlparser.AliasedExpr)
+		if !isAliased {
+			continue
+		}
+		assignment, isAssignment := ae.Expr.(*sqlparser.AssignmentExpr)
+		if !isAssignment {
+			continue
+		}
+		variable, isVariable := assignment.Left.(*sqlparser.Variable)
+		if !isVariable || variable.Scope != sqlparser.VariableScope {
+			return nil, vterrors.VT12001("assignment to a system variable in a select expression")
+		}
+		if assign == nil {
+			assign = &engine.AssignUDV{}
+		}
+		assign.Names = append(assign.Names, variable.Name.Lowered())
+		assign.Offsets = append(assign.Offsets, offset)
+		if ae.As.IsEmpty() {
+			// the column keeps the name of the assignment, as in MySQL
+			ae.As = sqlparser.NewIdentifierCI(ae.ColumnName())
+		}
+		ae.Expr = assignment.Right
+	}
+	if assign == nil {
+		return nil, nil
+	}
+	for _, expr := range sel.SelectExprs {
+		if _, isStar := expr.(*sqlparser.StarExpr); isStar {
+			return nil, vterrors.VT12001("assignment to a user defined variable in a query with '*' select expressions")
+		}
+	}
+	// the variables are assigned once the query ran, so the values cannot depend on
+	// the variables assigned by the same query, as in `@rownum := @rownum + 1`.
+	var err error
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		arg, isArg := node.(*sqlparser.Argument)
+		if !isArg {
+			return true, nil
+		}
+		for _, name := range assign.Names {
+			if arg.Name == sqlparser.UserDefinedVariableName+name {
+				err = vterrors.VT12001(fmt.Sprintf("reading the user defined variable '%s' in the query assigning it", name))
+				return false, nil
+			}
+		}
+		return true, nil
+	}, sel)
+	if err != nil {
+		return nil, err
+	}
+	return assign, nil
+}
+
 func gen4planSQLCalcFoundRows(vschema plancontext.VSchema, sel *sqlparser.Select, query string, reservedVars *sqlparser.ReservedVars) (*planResult, error) {
 	ksName := ""
 	if ks, _ := vschema.DefaultKeyspace(); ks != nil {
@@ -211,6 +289,13 @@ func newBuildSelectPlan(
 		return nil, nil, ctx.SemTable.NotUnshardedErr
 	}
 
+	// vtgate writes the file of the INTO clause itself, so the shards only return the rows
+	into := selectInto(selStmt)
+	if into != nil {
+		selStmt.SetInto(nil)
+		defer selStmt.SetInto(into)
+	}
+
 	op, err := createSelectOperator(ctx, selStmt, reservedVars)
 	if err != nil {
 		return nil, nil, err
@@ -221,9 +306,50 @@ func newBuildSelectPlan(
 		return nil, nil, err
 	}
 
+	if into != nil {
+		plan, err = buildExportPlan(ctx, plan, into)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return plan, operators.TablesUsed(op), nil
 }
 
+func selectInto(stmt sqlparser.SelectStatement) *sqlparser.SelectInto {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		return stmt.Into
+	case *sqlparser.Union:
+		return stmt.Into
+	}
+	return nil
+}
+
+// buildExportPlan writes the result of the plan to the file of the INTO clause
+func buildExportPlan(ctx *plancontext.PlanningContext, plan logicalPlan, into *sqlparser.SelectInto) (logicalPlan, error) {
+	if strings.TrimSpace(into.Manifest) == "manifest on" {
+		return nil, vterrors.VT12001("MANIFEST ON in SELECT ... INTO OUTFILE S3 on a sharded keyspace")
+	}
+	switch strings.ToLower(into.Charset.Name) {
+	case "", "utf8mb4", "utf8mb3", "utf8", "binary":
+	default:
+		return nil, vterrors.VT12001(fmt.Sprintf("CHARACTER SET %s in SELECT ... INTO OUTFILE on a sharded keyspace", into.Charset.Name))
+	}
+
+	fileName, opts, err := ctx.VSchema.Environment().Parser().ParseSelectInto(into)
+	if err != nil {
+		return nil, err
+	}
+	return &primitiveWrapper{prim: &engine.Export{
+		FileName:  fileName,
+		Overwrite: strings.TrimSpace(into.Overwrite) == "overwrite on",
+		Dumpfile:  into.Type == sqlparser.IntoDumpfile,
+		Options:   opts,
+		Input:     plan.Primitive(),
+	}}, nil
+}
+
 func createSelectOperator(ctx *plancontext.PlanningContext, selStmt sqlparser.SelectStatement, reservedVars *sqlparser.ReservedVars) (operators.Operator, error) {
 	err := queryRewrite(ctx, selStmt)
 	if err != nil {
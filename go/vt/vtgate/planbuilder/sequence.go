@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+const (
+	defaultSequenceStart = "1"
+	defaultSequenceCache = "1000"
+)
+
+// buildCreateSequencePlan maps a CREATE SEQUENCE statement onto a Vitess sequence table.
+// The sequence table is created in the unsharded keyspace, initialized with the start
+// value and cache size of the sequence, and added to the VSchema as a sequence.
+func buildCreateSequencePlan(stmt *sqlparser.CreateSequence, vschema plancontext.VSchema, enableDirectDDL bool) (*planResult, error) {
+	if !enableDirectDDL {
+		return nil, schema.ErrDirectDDLDisabled
+	}
+	_, keyspace, _, err := vschema.TargetDestination(stmt.Name.Qualifier.String())
+	if err != nil {
+		return nil, err
+	}
+	if keyspace.Sharded {
+		return nil, vterrors.VT12001("CREATE SEQUENCE in a sharded keyspace")
+	}
+
+	start, cache := defaultSequenceStart, defaultSequenceCache
+	for _, opt := range stmt.Options {
+		switch opt.Type {
+		case sqlparser.SequenceIncrementType:
+			if opt.Value.Val != "1" {
+				return nil, vterrors.VT12001("INCREMENT other than 1 in CREATE SEQUENCE")
+			}
+		case sqlparser.SequenceMinValueType, sqlparser.SequenceMaxValueType, sqlparser.SequenceCycleType:
+			return nil, vterrors.VT12001(fmt.Sprintf("%s in CREATE SEQUENCE", opt.Type.ToString()))
+		case sqlparser.SequenceStartType:
+			start = opt.Value.Val
+		case sqlparser.SequenceCacheType:
+			cache = opt.Value.Val
+		case sqlparser.SequenceNoCacheType:
+			cache = "1"
+		}
+	}
+
+	name := sqlparser.String(stmt.Name.Name)
+	ifNotExists := ""
+	if stmt.IfNotExists {
+		ifNotExists = "if not exists "
+	}
+	createTable := &engine.Send{
+		Keyspace:          keyspace,
+		TargetDestination: key.DestinationAllShards{},
+		Query:             fmt.Sprintf("create table %s%s (id int, next_id bigint, cache bigint, primary key (id)) comment 'vitess_sequence'", ifNotExists, name),
+	}
+	initRow := &engine.Send{
+		Keyspace:          keyspace,
+		TargetDestination: key.DestinationAllShards{},
+		Query:             fmt.Sprintf("insert ignore into %s(id, next_id, cache) values (0, %s, %s)", name, start, cache),
+		IsDML:             true,
+		SingleShardOnly:   true,
+	}
+
+	// the sequence might already be part of the VSchema, in which case the
+	// creation of the table decides whether the sequence already exists
+	var alterVSchema *engine.AlterVSchema
+	tbl, _, _, _, err := vschema.FindTable(stmt.Name)
+	if err != nil || tbl == nil || tbl.Type != vindexes.TypeSequence {
+		alterVSchema = &engine.AlterVSchema{
+			Keyspace: keyspace,
+			AlterVschemaDDL: &sqlparser.AlterVschema{
+				Action: sqlparser.AddSequenceDDLAction,
+				Table:  sqlparser.NewTableName(stmt.Name.Name.String()),
+			},
+		}
+	}
+
+	return newPlanResult(&engine.CreateSequence{
+		Keyspace: keyspace,
+		Name:     stmt.Name.Name.String(),
+		Table:    createTable,
+		Init:     initRow,
+		VSchema:  alterVSchema,
+	}, singleTable(keyspace.Name, stmt.Name.Name.String())), nil
+}
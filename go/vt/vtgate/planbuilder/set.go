@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sysvars"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 
@@ -33,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 )
 
 type (
@@ -51,8 +53,9 @@ type (
 	}
 )
 
-func buildSetPlan(stmt *sqlparser.Set, vschema plancontext.VSchema) (*planResult, error) {
+func buildSetPlan(stmt *sqlparser.Set, reservedVars *sqlparser.ReservedVars, vschema plancontext.VSchema) (*planResult, error) {
 	var setOps []engine.SetOp
+	var subqueries []*udvSubquery
 	var err error
 
 	ec := &expressionConverter{
@@ -73,7 +76,12 @@ func buildSetPlan(stmt *sqlparser.Set, vschema plancontext.VSchema) (*planResult
 			}
 			setOps = append(setOps, setOp)
 		case sqlparser.VariableScope:
-			evalExpr, err := ec.convert(expr.Expr /*boolean*/, false /*identifierAsString*/, false)
+			varExpr, subqs, err := planUDVSubqueries(expr.Expr, reservedVars, vschema)
+			if err != nil {
+				return nil, err
+			}
+			subqueries = append(subqueries, subqs...)
+			evalExpr, err := ec.convert(varExpr /*boolean*/, false /*identifierAsString*/, false)
 			if err != nil {
 				return nil, err
 			}
@@ -120,10 +128,55 @@ func buildSetPlan(stmt *sqlparser.Set, vschema plancontext.VSchema) (*planResult
 		return nil, err
 	}
 
-	return newPlanResult(&engine.Set{
+	var primitive engine.Primitive = &engine.Set{
 		Ops:   setOps,
 		Input: input,
-	}), nil
+	}
+	var tables []string
+	for _, subq := range subqueries {
+		primitive = &engine.UncorrelatedSubquery{
+			Opcode:         opcode.PulloutValue,
+			SubqueryResult: subq.argName,
+			Subquery:       subq.plan.primitive,
+			Outer:          primitive,
+		}
+		tables = append(tables, subq.plan.tables...)
+	}
+	return newPlanResult(primitive, tables...), nil
+}
+
+type udvSubquery struct {
+	argName string
+	plan    *planResult
+}
+
+// planUDVSubqueries plans the subqueries of the value assigned to a user defined variable,
+// as in `SET @var = (SELECT ...)`, on their own. They are replaced in the returned expression
+// by the arguments their result is bound to.
+func planUDVSubqueries(expr sqlparser.Expr, reservedVars *sqlparser.ReservedVars, vschema plancontext.VSchema) (sqlparser.Expr, []*udvSubquery, error) {
+	var subqueries []*udvSubquery
+	var err error
+	newExpr := sqlparser.CopyOnRewrite(expr, func(node, _ sqlparser.SQLNode) bool {
+		_, isSubq := node.(*sqlparser.Subquery)
+		return !isSubq
+	}, func(cursor *sqlparser.CopyOnWriteCursor) {
+		subq, isSubq := cursor.Node().(*sqlparser.Subquery)
+		if !isSubq || err != nil {
+			return
+		}
+		var plan *planResult
+		plan, err = gen4SelectStmtPlanner(sqlparser.String(subq.Select), querypb.ExecuteOptions_Gen4, subq.Select, reservedVars, vschema)
+		if err != nil {
+			return
+		}
+		argName := reservedVars.ReserveSubQuery()
+		subqueries = append(subqueries, &udvSubquery{argName: argName, plan: plan})
+		cursor.Replace(sqlparser.NewArgument(argName))
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newExpr.(sqlparser.Expr), subqueries, nil
 }
 
 func buildSetOpReadOnly(setting) planFunc {
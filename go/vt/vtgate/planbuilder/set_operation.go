@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"vitess.io/vitess/go/vt/vtgate/engine"
+)
+
+var _ logicalPlan = (*setOperation)(nil)
+
+// setOperation is the logicalPlan for engine.SetOperation.
+type setOperation struct {
+	left, right logicalPlan
+
+	opcode    engine.SetOpcode
+	distinct  bool
+	checkCols []engine.CheckCol
+}
+
+// Primitive implements the logicalPlan interface
+func (s *setOperation) Primitive() engine.Primitive {
+	return &engine.SetOperation{
+		Opcode:    s.opcode,
+		Distinct:  s.distinct,
+		Left:      s.left.Primitive(),
+		Right:     s.right.Primitive(),
+		CheckCols: s.checkCols,
+	}
+}
@@ -33,7 +33,7 @@ import (
 
 func buildVExplainPlan(ctx context.Context, vexplainStmt *sqlparser.VExplainStmt, reservedVars *sqlparser.ReservedVars, vschema plancontext.VSchema, enableOnlineDDL, enableDirectDDL bool) (*planResult, error) {
 	switch vexplainStmt.Type {
-	case sqlparser.QueriesVExplainType, sqlparser.AllVExplainType:
+	case sqlparser.QueriesVExplainType, sqlparser.AllVExplainType, sqlparser.TraceVExplainType:
 		return buildVExplainLoggingPlan(ctx, vexplainStmt, reservedVars, vschema, enableOnlineDDL, enableDirectDDL)
 	case sqlparser.PlanVExplainType:
 		return buildVExplainVtgatePlan(ctx, vexplainStmt.Statement, reservedVars, vschema, enableOnlineDDL, enableDirectDDL)
@@ -74,6 +74,13 @@ func buildVExplainVtgatePlan(ctx context.Context, explainStatement sqlparser.Sta
 		return nil, err
 	}
 	description := engine.PrimitiveToPlanDescription(innerInstruction.primitive)
+	if hints := sqlparser.PlannerHints(explainStatement); len(hints) > 0 {
+		// show the hints that shaped the plan, so the user can tell them apart from the planner's choices
+		if description.Other == nil {
+			description.Other = map[string]any{}
+		}
+		description.Other["PlannerHints"] = hints
+	}
 	output, err := json.MarshalIndent(description, "", "\t")
 	if err != nil {
 		return nil, err
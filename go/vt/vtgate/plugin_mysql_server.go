@@ -18,8 +18,11 @@ package vtgate
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"regexp"
@@ -35,6 +38,7 @@ import (
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 
+	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/trace"
@@ -43,10 +47,12 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/auditlog"
 	"vitess.io/vitess/go/vt/vttls"
 )
 
@@ -58,6 +64,7 @@ var (
 	mysqlAuthServerImpl               = "static"
 	mysqlAllowClearTextWithoutTLS     bool
 	mysqlProxyProtocol                bool
+	mysqlProxyProtocolTrustedCIDRs    []string
 	mysqlServerRequireSecureTransport bool
 	mysqlSslCert                      string
 	mysqlSslKey                       string
@@ -65,6 +72,8 @@ var (
 	mysqlSslCrl                       string
 	mysqlSslServerCA                  string
 	mysqlTLSMinVersion                string
+	mysqlSslSNICerts                  []string
+	mysqlSslWatchCerts                bool
 
 	mysqlKeepAlivePeriod          time.Duration
 	mysqlConnReadTimeout          time.Duration
@@ -72,11 +81,30 @@ var (
 	mysqlQueryTimeout             time.Duration
 	mysqlSlowConnectWarnThreshold time.Duration
 	mysqlConnBufferPooling        bool
+	mysqlQueryAttributes          bool
+	mysqlMultiStatements          = true
+	mysqlSessionTrack             bool
+
+	// mysqlSessionTrackSystemVariables defaults to the MySQL default
+	// of session_track_system_variables.
+	mysqlSessionTrackSystemVariables = []string{"time_zone", "autocommit", "character_set_client", "character_set_results", "character_set_connection"}
+
+	mysqlLocalInfile          bool
+	mysqlLocalInfileMaxBytes  int64 = 256 * 1024 * 1024
+	mysqlLocalInfileMaxRows   int64
+	mysqlLocalInfileBatchSize = 500
+
+	mysqlCursorMaxRows = 300000
 
 	mysqlDefaultWorkloadName = "OLTP"
 	mysqlDefaultWorkload     int32
 
 	mysqlServerFlushDelay = 100 * time.Millisecond
+
+	mysqlServerDrainTimeout time.Duration
+
+	mysqlxServerPort = -1
+	pgwireServerPort = -1
 )
 
 func registerPluginFlags(fs *pflag.FlagSet) {
@@ -87,6 +115,7 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&mysqlAuthServerImpl, "mysql_auth_server_impl", mysqlAuthServerImpl, "Which auth server implementation to use. Options: none, ldap, clientcert, static, vault.")
 	fs.BoolVar(&mysqlAllowClearTextWithoutTLS, "mysql_allow_clear_text_without_tls", mysqlAllowClearTextWithoutTLS, "If set, the server will allow the use of a clear text password over non-SSL connections.")
 	fs.BoolVar(&mysqlProxyProtocol, "proxy_protocol", mysqlProxyProtocol, "Enable HAProxy PROXY protocol on MySQL listener socket")
+	fs.StringSliceVar(&mysqlProxyProtocolTrustedCIDRs, "proxy-protocol-trusted-cidrs", mysqlProxyProtocolTrustedCIDRs, "Comma-separated list of the networks, in CIDR notation, of the load balancers allowed to send a PROXY protocol header with --proxy_protocol. Connections from other addresses that send one are rejected. All addresses are allowed if empty")
 	fs.BoolVar(&mysqlServerRequireSecureTransport, "mysql_server_require_secure_transport", mysqlServerRequireSecureTransport, "Reject insecure connections but only if mysql_server_ssl_cert and mysql_server_ssl_key are provided")
 	fs.StringVar(&mysqlSslCert, "mysql_server_ssl_cert", mysqlSslCert, "Path to the ssl cert for mysql server plugin SSL")
 	fs.StringVar(&mysqlSslKey, "mysql_server_ssl_key", mysqlSslKey, "Path to ssl key for mysql server plugin SSL")
@@ -94,14 +123,28 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&mysqlSslCrl, "mysql_server_ssl_crl", mysqlSslCrl, "Path to ssl CRL for mysql server plugin SSL")
 	fs.StringVar(&mysqlTLSMinVersion, "mysql_server_tls_min_version", mysqlTLSMinVersion, "Configures the minimal TLS version negotiated when SSL is enabled. Defaults to TLSv1.2. Options: TLSv1.0, TLSv1.1, TLSv1.2, TLSv1.3.")
 	fs.StringVar(&mysqlSslServerCA, "mysql_server_ssl_server_ca", mysqlSslServerCA, "path to server CA in PEM format, which will be combine with server cert, return full certificate chain to clients")
+	fs.StringSliceVar(&mysqlSslSNICerts, "mysql-server-ssl-sni-certs", mysqlSslSNICerts, "Comma-separated list of cert_file:key_file pairs of additional certificates for mysql server plugin SSL. A client which requests one of the names of a certificate with SNI is served that certificate, others are served mysql_server_ssl_cert")
+	fs.BoolVar(&mysqlSslWatchCerts, "mysql-server-ssl-watch-certs", mysqlSslWatchCerts, "If set, reload the mysql server plugin SSL certificates when their files change. The new certificates are used for the new connections, existing connections are not affected. The certificates are also reloaded on SIGHUP")
 	fs.DurationVar(&mysqlSlowConnectWarnThreshold, "mysql_slow_connect_warn_threshold", mysqlSlowConnectWarnThreshold, "Warn if it takes more than the given threshold for a mysql connection to establish")
 	fs.DurationVar(&mysqlConnReadTimeout, "mysql_server_read_timeout", mysqlConnReadTimeout, "connection read timeout")
 	fs.DurationVar(&mysqlConnWriteTimeout, "mysql_server_write_timeout", mysqlConnWriteTimeout, "connection write timeout")
 	fs.DurationVar(&mysqlQueryTimeout, "mysql_server_query_timeout", mysqlQueryTimeout, "mysql query timeout")
 	fs.BoolVar(&mysqlConnBufferPooling, "mysql-server-pool-conn-read-buffers", mysqlConnBufferPooling, "If set, the server will pool incoming connection read buffers")
+	fs.BoolVar(&mysqlQueryAttributes, "mysql-server-query-attributes", mysqlQueryAttributes, "If set, the server will accept query attributes (CLIENT_QUERY_ATTRIBUTES) from clients and forward them to vttablet as bind variables")
+	fs.BoolVar(&mysqlMultiStatements, "mysql-server-multi-statements", mysqlMultiStatements, "If set, the server will accept multiple statements in a single query (CLIENT_MULTI_STATEMENTS), execute them in order and return one result per statement")
+	fs.BoolVar(&mysqlSessionTrack, "mysql-server-session-track", mysqlSessionTrack, "If set, the server will send session state changes (CLIENT_SESSION_TRACK) to clients that support them: the tracked system variables that changed, and the GTIDs returned by the tablets when session_track_gtids is OWN_GTID")
+	fs.StringSliceVar(&mysqlSessionTrackSystemVariables, "mysql-server-session-track-system-variables", mysqlSessionTrackSystemVariables, "Comma-separated list of system variables whose changes are sent to clients when session tracking is enabled, or '*' for all of them")
+	fs.BoolVar(&mysqlLocalInfile, "mysql-server-local-infile", mysqlLocalInfile, "If set, the server will accept LOAD DATA LOCAL INFILE from clients, and insert the rows of the file through vtgate")
+	fs.Int64Var(&mysqlLocalInfileMaxBytes, "mysql-server-local-infile-max-bytes", mysqlLocalInfileMaxBytes, "Maximum size of a file sent with LOAD DATA LOCAL INFILE. 0 means no limit")
+	fs.Int64Var(&mysqlLocalInfileMaxRows, "mysql-server-local-infile-max-rows", mysqlLocalInfileMaxRows, "Maximum number of rows of a file sent with LOAD DATA LOCAL INFILE. 0 means no limit")
+	fs.IntVar(&mysqlLocalInfileBatchSize, "mysql-server-local-infile-batch-size", mysqlLocalInfileBatchSize, "Number of rows inserted per statement when executing LOAD DATA LOCAL INFILE")
+	fs.IntVar(&mysqlCursorMaxRows, "mysql-server-cursor-max-rows", mysqlCursorMaxRows, "Maximum number of rows of a read-only cursor of a prepared statement buffered until the client fetches them. 0 means no limit")
 	fs.DurationVar(&mysqlKeepAlivePeriod, "mysql-server-keepalive-period", mysqlKeepAlivePeriod, "TCP period between keep-alives")
 	fs.DurationVar(&mysqlServerFlushDelay, "mysql_server_flush_delay", mysqlServerFlushDelay, "Delay after which buffered response will be flushed to the client.")
+	fs.DurationVar(&mysqlServerDrainTimeout, "mysql-server-drain-timeout", mysqlServerDrainTimeout, "How long a drain of the MySQL server, started on "+mysqlDrainPath+" or at shutdown, waits for the queries and transactions in flight before closing the remaining connections. 0 waits without limit, up to --onterm_timeout at shutdown")
 	fs.StringVar(&mysqlDefaultWorkloadName, "mysql_default_workload", mysqlDefaultWorkloadName, "Default session workload (OLTP, OLAP, DBA)")
+	fs.IntVar(&mysqlxServerPort, "mysqlx-server-port", mysqlxServerPort, "If set, also listen for MySQL X Protocol connections on this port, for the X DevAPI connectors. Uses the bind address, auth server and TLS settings of the MySQL binary protocol")
+	fs.IntVar(&pgwireServerPort, "pgwire-server-port", pgwireServerPort, "Experimental: if set, also listen for PostgreSQL wire protocol connections on this port, to read from the keyspaces with PostgreSQL clients. Uses the bind address, auth server and TLS settings of the MySQL binary protocol")
 }
 
 // vtgateHandler implements the Listener interface.
@@ -114,6 +157,10 @@ type vtgateHandler struct {
 	connections map[uint32]*mysql.Conn
 
 	busyConnections atomic.Int32
+
+	// draining is true once the MySQL server started to drain: the clients
+	// are asked to reconnect to another vtgate at their transaction boundaries.
+	draining atomic.Bool
 }
 
 func newVtgateHandler(vtg *VTGate) *vtgateHandler {
@@ -129,6 +176,28 @@ func (vh *vtgateHandler) NewConnection(c *mysql.Conn) {
 	vh.connections[c.ConnectionID] = c
 }
 
+func (vh *vtgateHandler) ConnectionReady(c *mysql.Conn) {
+	auditlog.Log(&auditlog.Event{
+		Type:         auditlog.EventConnect,
+		ConnectionID: c.ConnectionID,
+		User:         c.User,
+		RemoteAddr:   c.RemoteAddr().String(),
+	})
+}
+
+var _ mysql.AuthenticationFailedHandler = (*vtgateHandler)(nil)
+
+// AuthenticationFailed is part of the mysql.AuthenticationFailedHandler interface.
+func (vh *vtgateHandler) AuthenticationFailed(c *mysql.Conn, user string, err error) {
+	auditlog.Log(&auditlog.Event{
+		Type:         auditlog.EventAuthFailure,
+		ConnectionID: c.ConnectionID,
+		User:         user,
+		RemoteAddr:   c.RemoteAddr().String(),
+		Error:        err.Error(),
+	})
+}
+
 func (vh *vtgateHandler) numConnections() int {
 	vh.mu.Lock()
 	defer vh.mu.Unlock()
@@ -154,6 +223,14 @@ func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 		delete(vh.connections, c.ConnectionID)
 		vh.mu.Unlock()
 	}()
+	if c.User != "" {
+		auditlog.Log(&auditlog.Event{
+			Type:         auditlog.EventDisconnect,
+			ConnectionID: c.ConnectionID,
+			User:         c.User,
+			RemoteAddr:   c.RemoteAddr().String(),
+		})
+	}
 
 	var ctx context.Context
 	var cancel context.CancelFunc
@@ -173,12 +250,23 @@ func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 // Regexp to extract parent span id over the sql query
 var r = regexp.MustCompile(`/\*VT_SPAN_CONTEXT=(.*)\*/`)
 
-// this function is here to make this logic easy to test by decoupling the logic from the `trace.NewSpan` and `trace.NewFromString` functions
-func startSpanTestable(ctx context.Context, query, label string,
+// Regexp to extract the W3C trace context from the sqlcommenter comments of the sql query
+var traceparentRegexp = regexp.MustCompile(`traceparent='([^']+)'`)
+
+// this function is here to make this logic easy to test by decoupling the logic from the `trace.NewSpan` and `trace.NewFromString` functions.
+// The parent span is taken from the VT_SPAN_CONTEXT or traceparent comments of the query, or else from the traceparent
+// connection attribute of the client.
+func startSpanTestable(ctx context.Context, query, connTraceparent, label string,
 	newSpan func(context.Context, string) (trace.Span, context.Context),
 	newSpanFromString func(context.Context, string, string) (trace.Span, context.Context, error)) (trace.Span, context.Context, error) {
 	_, comments := sqlparser.SplitMarginComments(query)
 	match := r.FindStringSubmatch(comments.Leading)
+	if len(match) == 0 {
+		match = traceparentRegexp.FindStringSubmatch(comments.Leading + comments.Trailing)
+	}
+	if len(match) == 0 && connTraceparent != "" {
+		match = []string{"", connTraceparent}
+	}
 	span, ctx := getSpan(ctx, match, newSpan, label, newSpanFromString)
 
 	trace.AnnotateSQL(span, sqlparser.Preview(query))
@@ -200,16 +288,44 @@ func getSpan(ctx context.Context, match []string, newSpan func(context.Context,
 	return span, ctx
 }
 
-func startSpan(ctx context.Context, query, label string) (trace.Span, context.Context, error) {
-	return startSpanTestable(ctx, query, label, trace.NewSpan, trace.NewFromString)
+func startSpan(ctx context.Context, c *mysql.Conn, query, label string) (trace.Span, context.Context, error) {
+	return startSpanTestable(ctx, query, c.ConnAttrs["traceparent"], label, trace.NewSpan, trace.NewFromString)
 }
 
-func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
-	session := vh.session(c)
-	if c.IsShuttingDown() && !session.InTransaction {
+// checkShutdown returns an error, and marks the connection for close, if the
+// MySQL server is draining or shutting down and the connection is at a transaction
+// boundary, for its client to reconnect to another vtgate.
+func (vh *vtgateHandler) checkShutdown(c *mysql.Conn, session *vtgatepb.Session) error {
+	if session.InTransaction {
+		return nil
+	}
+	switch {
+	case vh.draining.Load():
+		c.MarkForClose()
+		return sqlerror.NewSQLError(sqlerror.ERConnectionKilled, sqlerror.SSQueryInterrupted, "Connection was killed: vtgate is draining, reconnect to another vtgate")
+	case c.IsShuttingDown():
 		c.MarkForClose()
 		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
 	}
+	return nil
+}
+
+// warnDraining warns the clients in a transaction while the MySQL server is
+// draining that their connection is closed at the end of the transaction.
+func (vh *vtgateHandler) warnDraining(session *vtgatepb.Session) {
+	if session.InTransaction && vh.draining.Load() {
+		session.Warnings = append(session.Warnings, &querypb.QueryWarning{
+			Code:    uint32(sqlerror.ERConnectionKilled),
+			Message: "vtgate is draining: the connection will be closed at the end of the transaction",
+		})
+	}
+}
+
+func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	session := vh.session(c)
+	if err := vh.checkShutdown(c, session); err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	c.UpdateCancelCtx(cancel)
@@ -219,7 +335,7 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		defer cancel()
 	}
 
-	span, ctx, err := startSpan(ctx, query, "vtgateHandler.ComQuery")
+	span, ctx, err := startSpan(ctx, c, query, "vtgateHandler.ComQuery")
 	if err != nil {
 		return vterrors.Wrap(err, "failed to extract span")
 	}
@@ -248,21 +364,75 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		}
 	}()
 
+	bindVars := queryAttributesBindVars(c)
+	tracker := newSessionStateTracker(c, vh.vtg.executor.env.Parser(), session)
+	if mysqlLocalInfile && c.LocalInfileEnabled() {
+		if load := parseLoadDataLocal(vh.vtg.executor.env.Parser(), query); load != nil {
+			session, result, err := vh.loadDataLocal(ctx, c, session, load, bindVars)
+			if err := sqlerror.NewSQLErrorFromError(err); err != nil {
+				return err
+			}
+			fillInTxStatusFlags(c, session)
+			return callback(tracker.track(result))
+		}
+	}
 	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
-		session, err := vh.vtg.StreamExecute(ctx, vh, session, query, make(map[string]*querypb.BindVariable), callback)
+		session, err := vh.vtg.StreamExecute(ctx, vh, session, query, bindVars, func(qr *sqltypes.Result) error {
+			return callback(tracker.track(qr))
+		})
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
+		vh.warnDraining(session)
 		fillInTxStatusFlags(c, session)
 		return nil
 	}
-	session, result, err := vh.vtg.Execute(ctx, vh, session, query, make(map[string]*querypb.BindVariable))
+	session, result, err := vh.vtg.Execute(ctx, vh, session, query, bindVars)
 
 	if err := sqlerror.NewSQLErrorFromError(err); err != nil {
 		return err
 	}
+	vh.warnDraining(session)
 	fillInTxStatusFlags(c, session)
-	return callback(result)
+	return callback(tracker.track(result))
+}
+
+// loadDataLocal executes a LOAD DATA LOCAL INFILE statement, asking the
+// client for the file and inserting its rows in batches.
+func (vh *vtgateHandler) loadDataLocal(ctx context.Context, c *mysql.Conn, session *vtgatepb.Session, load *sqlparser.Load, bindVars map[string]*querypb.BindVariable) (*vtgatepb.Session, *sqltypes.Result, error) {
+	loader, err := newLocalInfileLoader(load, bindVars, func(query string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+		var qr *sqltypes.Result
+		var err error
+		session, qr, err = vh.vtg.Execute(ctx, vh, session, query, bindVars)
+		return qr, err
+	})
+	if err != nil {
+		return session, nil, err
+	}
+	if err := loader.begin(session.InTransaction); err != nil {
+		return session, nil, err
+	}
+	if err := c.RequestLocalInfile(load.FileName, loader.write); err != nil {
+		loader.abort()
+		return session, nil, err
+	}
+	result, err := loader.finish()
+	if err != nil {
+		loader.abort()
+	}
+	return session, result, err
+}
+
+// queryAttributesBindVars returns the initial bind variables for a
+// query, carrying the query attributes sent by the client so that they
+// are forwarded to vttablet.
+func queryAttributesBindVars(c *mysql.Conn) map[string]*querypb.BindVariable {
+	attributes := c.QueryAttributes()
+	bindVars := make(map[string]*querypb.BindVariable, len(attributes))
+	for _, attr := range attributes {
+		bindVars[sqlparser.QueryAttributeName+attr.Name] = sqltypes.ValueBindVariable(attr.Value)
+	}
+	return bindVars
 }
 
 func fillInTxStatusFlags(c *mysql.Conn, session *vtgatepb.Session) {
@@ -345,6 +515,9 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	ctx = callerid.NewContext(ctx, ef, im)
 
 	session := vh.session(c)
+	if err := vh.checkShutdown(c, session); err != nil {
+		return err
+	}
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -354,11 +527,15 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 		}
 	}()
 
-	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
+	// The rows of a cursor are buffered until they are fetched, so they
+	// are not streamed even for OLAP sessions: this way the result is
+	// subject to the memory row limits.
+	if session.Options.Workload == querypb.ExecuteOptions_OLAP && prepare.CursorType&mysql.CursorTypeReadOnly == 0 {
 		_, err := vh.vtg.StreamExecute(ctx, vh, session, prepare.PrepareStmt, prepare.BindVars, callback)
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
+		vh.warnDraining(session)
 		fillInTxStatusFlags(c, session)
 		return nil
 	}
@@ -366,6 +543,7 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	if err != nil {
 		return sqlerror.NewSQLErrorFromError(err)
 	}
+	vh.warnDraining(session)
 	fillInTxStatusFlags(c, session)
 
 	return callback(qr)
@@ -425,6 +603,10 @@ func (vh *vtgateHandler) Env() *vtenv.Environment {
 	return vh.vtg.executor.env
 }
 
+// workloadNameConnAttr is the connection attribute that sets the workload name of the
+// session. The WORKLOAD_NAME comment directive of a query overrides it.
+const workloadNameConnAttr = "workload_name"
+
 func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 	session, _ := c.ClientData.(*vtgatepb.Session)
 	if session == nil {
@@ -445,6 +627,9 @@ func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 		if c.Capabilities&mysql.CapabilityClientFoundRows != 0 {
 			session.Options.ClientFoundRows = true
 		}
+		if workloadName := c.ConnAttrs[workloadNameConnAttr]; workloadName != "" {
+			session.Options.WorkloadName = workloadName
+		}
 		c.ClientData = session
 	}
 	return session
@@ -453,19 +638,61 @@ func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 type mysqlServer struct {
 	tcpListener  *mysql.Listener
 	unixListener *mysql.Listener
+	xListener    *mysql.Listener
+	pgListener   *mysql.Listener
 	sigChan      chan os.Signal
 	vtgateHandle *vtgateHandler
+
+	drainMu      sync.Mutex
+	drainStarted time.Time
+	drainDone    chan struct{}
 }
 
-// initTLSConfig inits tls config for the given mysql listener
-func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA string, mysqlServerRequireSecureTransport bool, mysqlMinTLSVersion uint16) error {
-	serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlMinTLSVersion)
+// initTLSConfig inits tls config for the given mysql listener. The
+// certificates are reloaded on SIGHUP and, if watchCerts is set, when their
+// files change. sniCerts are the cert_file:key_file pairs of the additional
+// certificates served to the clients which request one of their names.
+func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA string, mysqlServerRequireSecureTransport bool, mysqlMinTLSVersion uint16, sniCerts []string, watchCerts bool) error {
+	certFiles := []vttls.CertificateFiles{{Cert: mysqlSslCert, Key: mysqlSslKey, CA: mysqlSslServerCA}}
+	for _, pair := range sniCerts {
+		cert, key, ok := strings.Cut(pair, ":")
+		if !ok || cert == "" || key == "" {
+			log.Exitf("invalid SNI certificate %q, expected cert_file:key_file", pair)
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid SNI certificate %q, expected cert_file:key_file", pair)
+		}
+		certFiles = append(certFiles, vttls.CertificateFiles{Cert: cert, Key: key})
+	}
+	certs, err := vttls.NewReloadableCertificates(certFiles...)
+	if err != nil {
+		log.Exitf("grpcutils.TLSServerConfig failed: %v", err)
+		return err
+	}
+	serverConfig, err := vttls.ReloadableServerConfig(certs, mysqlSslCa, mysqlSslCrl, mysqlMinTLSVersion)
 	if err != nil {
 		log.Exitf("grpcutils.TLSServerConfig failed: %v", err)
 		return err
 	}
-	srv.tcpListener.TLSConfig.Store(serverConfig)
-	srv.tcpListener.RequireSecureTransport = mysqlServerRequireSecureTransport
+	srv.storeTLSConfig(serverConfig)
+	for _, l := range []*mysql.Listener{srv.tcpListener, srv.xListener, srv.pgListener} {
+		if l != nil {
+			l.RequireSecureTransport = mysqlServerRequireSecureTransport
+		}
+	}
+	if watchCerts {
+		// The listeners keep the same config, which serves the reloaded
+		// certificates to the new connections.
+		err := certs.Watch(ctx, func(err error) {
+			if err != nil {
+				log.Errorf("reloading the mysql server certificates failed: %v", err)
+				return
+			}
+			log.Info("mysql server certificates reloaded")
+		})
+		if err != nil {
+			log.Exitf("watching the mysql server certificates failed: %v", err)
+			return err
+		}
+	}
 	srv.sigChan = make(chan os.Signal, 1)
 	signal.Notify(srv.sigChan, syscall.SIGHUP)
 	go func() {
@@ -474,12 +701,16 @@ func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSsl
 			case <-ctx.Done():
 				return
 			case <-srv.sigChan:
-				serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlMinTLSVersion)
+				if err := certs.Reload(); err != nil {
+					log.Errorf("grpcutils.TLSServerConfig failed: %v", err)
+					continue
+				}
+				serverConfig, err := vttls.ReloadableServerConfig(certs, mysqlSslCa, mysqlSslCrl, mysqlMinTLSVersion)
 				if err != nil {
 					log.Errorf("grpcutils.TLSServerConfig failed: %v", err)
 				} else {
 					log.Info("grpcutils.TLSServerConfig updated")
-					srv.tcpListener.TLSConfig.Store(serverConfig)
+					srv.storeTLSConfig(serverConfig)
 				}
 			}
 		}
@@ -487,11 +718,21 @@ func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSsl
 	return nil
 }
 
+// storeTLSConfig sets the TLS config of the TCP, X Protocol and PostgreSQL
+// protocol listeners.
+func (srv *mysqlServer) storeTLSConfig(serverConfig *tls.Config) {
+	for _, l := range []*mysql.Listener{srv.tcpListener, srv.xListener, srv.pgListener} {
+		if l != nil {
+			l.TLSConfig.Store(serverConfig)
+		}
+	}
+}
+
 // initMySQLProtocol starts the mysql protocol.
 // It should be called only once in a process.
 func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 	// Flag is not set, just return.
-	if mysqlServerPort < 0 && mysqlServerSocketPath == "" {
+	if mysqlServerPort < 0 && mysqlServerSocketPath == "" && mysqlxServerPort < 0 && pgwireServerPort < 0 {
 		return nil
 	}
 
@@ -500,11 +741,7 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		return nil
 	}
 
-	// Initialize registered AuthServer implementations (or other plugins)
-	for _, initFn := range pluginInitializers {
-		initFn()
-	}
-	authServer := mysql.GetAuthServer(mysqlAuthServerImpl)
+	authServer := mysqlAuthServer()
 
 	// Check mysql_default_workload
 	var ok bool
@@ -519,19 +756,25 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		log.Exitf("-mysql_tcp_version must be one of [tcp, tcp4, tcp6]")
 	}
 
+	proxyProtocolTrustedCIDRs, err := mysql.ParseTrustedCIDRs(mysqlProxyProtocolTrustedCIDRs)
+	if err != nil {
+		log.Exitf("--proxy-protocol-trusted-cidrs: %v", err)
+	}
+
 	// Create a Listener.
-	var err error
 	srv := &mysqlServer{}
 	srv.vtgateHandle = newVtgateHandler(vtgate)
 	if mysqlServerPort >= 0 {
-		srv.tcpListener, err = mysql.NewListener(
-			mysqlTCPVersion,
-			net.JoinHostPort(mysqlServerBindAddress, fmt.Sprintf("%v", mysqlServerPort)),
+		listener, err := newMySQLNetListener(mysqlServerPort, proxyProtocolTrustedCIDRs)
+		if err != nil {
+			log.Exitf("mysql.NewListener failed: %v", err)
+		}
+		srv.tcpListener, err = mysql.NewFromListener(
+			listener,
 			authServer,
 			srv.vtgateHandle,
 			mysqlConnReadTimeout,
 			mysqlConnWriteTimeout,
-			mysqlProxyProtocol,
 			mysqlConnBufferPooling,
 			mysqlKeepAlivePeriod,
 			mysqlServerFlushDelay,
@@ -539,23 +782,88 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		if err != nil {
 			log.Exitf("mysql.NewListener failed: %v", err)
 		}
-		if mysqlSslCert != "" && mysqlSslKey != "" {
-			tlsVersion, err := vttls.TLSVersionToNumber(mysqlTLSMinVersion)
-			if err != nil {
-				log.Exitf("mysql.NewListener failed: %v", err)
-			}
-
-			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion)
-		}
 		srv.tcpListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		srv.tcpListener.DisableMultiStatements = !mysqlMultiStatements
+		srv.tcpListener.EnableLocalInfile = mysqlLocalInfile
+		srv.tcpListener.EnableSessionTrack = mysqlSessionTrack
+		srv.tcpListener.EnableQueryAttributes = mysqlQueryAttributes
+		srv.tcpListener.MaxCursorRows = mysqlCursorMaxRows
 		// Check for the connection threshold
 		if mysqlSlowConnectWarnThreshold != 0 {
 			log.Infof("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold)
 			srv.tcpListener.SlowConnectWarnThreshold.Store(mysqlSlowConnectWarnThreshold.Nanoseconds())
 		}
-		// Start listening for tcp
+	}
+
+	if mysqlxServerPort >= 0 {
+		listener, err := newMySQLNetListener(mysqlxServerPort, proxyProtocolTrustedCIDRs)
+		if err != nil {
+			log.Exitf("mysql.NewListenerWithConfig failed for the X Protocol: %v", err)
+		}
+		srv.xListener, err = mysql.NewListenerWithConfig(mysql.ListenerConfig{
+			Listener:            listener,
+			AuthServer:          authServer,
+			Handler:             srv.vtgateHandle,
+			ConnReadTimeout:     mysqlConnReadTimeout,
+			ConnWriteTimeout:    mysqlConnWriteTimeout,
+			ConnBufferPooling:   mysqlConnBufferPooling,
+			ConnKeepAlivePeriod: mysqlKeepAlivePeriod,
+			FlushDelay:          mysqlServerFlushDelay,
+			XProtocol:           true,
+		})
+		if err != nil {
+			log.Exitf("mysql.NewListenerWithConfig failed for the X Protocol: %v", err)
+		}
+		srv.xListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		if mysqlSlowConnectWarnThreshold != 0 {
+			srv.xListener.SlowConnectWarnThreshold.Store(mysqlSlowConnectWarnThreshold.Nanoseconds())
+		}
+	}
+
+	if pgwireServerPort >= 0 {
+		listener, err := newMySQLNetListener(pgwireServerPort, proxyProtocolTrustedCIDRs)
+		if err != nil {
+			log.Exitf("mysql.NewListenerWithConfig failed for the PostgreSQL protocol: %v", err)
+		}
+		srv.pgListener, err = mysql.NewListenerWithConfig(mysql.ListenerConfig{
+			Listener:            listener,
+			AuthServer:          authServer,
+			Handler:             srv.vtgateHandle,
+			ConnReadTimeout:     mysqlConnReadTimeout,
+			ConnWriteTimeout:    mysqlConnWriteTimeout,
+			ConnBufferPooling:   mysqlConnBufferPooling,
+			ConnKeepAlivePeriod: mysqlKeepAlivePeriod,
+			FlushDelay:          mysqlServerFlushDelay,
+			PostgresProtocol:    true,
+		})
+		if err != nil {
+			log.Exitf("mysql.NewListenerWithConfig failed for the PostgreSQL protocol: %v", err)
+		}
+		srv.pgListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		if mysqlSlowConnectWarnThreshold != 0 {
+			srv.pgListener.SlowConnectWarnThreshold.Store(mysqlSlowConnectWarnThreshold.Nanoseconds())
+		}
+	}
+
+	if (srv.tcpListener != nil || srv.xListener != nil || srv.pgListener != nil) && mysqlSslCert != "" && mysqlSslKey != "" {
+		tlsVersion, err := vttls.TLSVersionToNumber(mysqlTLSMinVersion)
+		if err != nil {
+			log.Exitf("mysql.NewListener failed: %v", err)
+		}
+
+		_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion, mysqlSslSNICerts, mysqlSslWatchCerts)
+	}
+
+	// Start listening for tcp
+	if srv.tcpListener != nil {
 		go srv.tcpListener.Accept()
 	}
+	if srv.xListener != nil {
+		go srv.xListener.Accept()
+	}
+	if srv.pgListener != nil {
+		go srv.pgListener.Accept()
+	}
 
 	if mysqlServerSocketPath != "" {
 		err = setupUnixSocket(srv, authServer, mysqlServerSocketPath)
@@ -566,6 +874,20 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 	return srv
 }
 
+// newMySQLNetListener listens on the given port of the bind address. With
+// --proxy_protocol, the client addresses are read from the PROXY protocol
+// headers sent by the load balancers in trustedCIDRs.
+func newMySQLNetListener(port int, trustedCIDRs []*net.IPNet) (net.Listener, error) {
+	listener, err := net.Listen(mysqlTCPVersion, net.JoinHostPort(mysqlServerBindAddress, fmt.Sprintf("%v", port)))
+	if err != nil {
+		return nil, err
+	}
+	if mysqlProxyProtocol {
+		listener = mysql.NewProxyProtocolListener(listener, trustedCIDRs)
+	}
+	return listener, nil
+}
+
 // newMysqlUnixSocket creates a new unix socket mysql listener. If a socket file already exists, attempts
 // to clean it up.
 func newMysqlUnixSocket(address string, authServer mysql.AuthServer, handler mysql.Handler) (*mysql.Listener, error) {
@@ -584,6 +906,11 @@ func newMysqlUnixSocket(address string, authServer mysql.AuthServer, handler mys
 
 	switch err := err.(type) {
 	case nil:
+		listener.DisableMultiStatements = !mysqlMultiStatements
+		listener.EnableLocalInfile = mysqlLocalInfile
+		listener.EnableSessionTrack = mysqlSessionTrack
+		listener.EnableQueryAttributes = mysqlQueryAttributes
+		listener.MaxCursorRows = mysqlCursorMaxRows
 		return listener, nil
 	case *net.OpError:
 		log.Warningf("Found existent socket when trying to create new unix mysql listener: %s, attempting to clean up", address)
@@ -614,6 +941,13 @@ func newMysqlUnixSocket(address string, authServer mysql.AuthServer, handler mys
 			mysqlKeepAlivePeriod,
 			mysqlServerFlushDelay,
 		)
+		if listenerErr == nil {
+			listener.DisableMultiStatements = !mysqlMultiStatements
+			listener.EnableLocalInfile = mysqlLocalInfile
+			listener.EnableSessionTrack = mysqlSessionTrack
+			listener.EnableQueryAttributes = mysqlQueryAttributes
+			listener.MaxCursorRows = mysqlCursorMaxRows
+		}
 		return listener, listenerErr
 	default:
 		return nil, err
@@ -621,13 +955,35 @@ func newMysqlUnixSocket(address string, authServer mysql.AuthServer, handler mys
 }
 
 func (srv *mysqlServer) shutdownMysqlProtocolAndDrain() {
-	if srv.tcpListener != nil {
-		srv.tcpListener.Shutdown()
-		srv.tcpListener = nil
+	<-srv.startDrain()
+}
+
+// startDrain starts to drain the MySQL server, if it isn't already draining,
+// and returns a channel closed once it is drained.
+func (srv *mysqlServer) startDrain() <-chan struct{} {
+	srv.drainMu.Lock()
+	defer srv.drainMu.Unlock()
+	if srv.drainDone == nil {
+		srv.drainStarted = time.Now()
+		srv.drainDone = make(chan struct{})
+		go srv.drain(srv.drainDone)
 	}
-	if srv.unixListener != nil {
-		srv.unixListener.Shutdown()
-		srv.unixListener = nil
+	return srv.drainDone
+}
+
+// drain stops accepting new connections and waits, up to --mysql-server-drain-timeout,
+// for the queries and transactions in flight to finish. Meanwhile, the clients are
+// told with ERROR 1927 to reconnect to another vtgate at their next transaction
+// boundary, and warned while they are in a transaction. The remaining connections
+// are closed once the server is drained, rolling back their transactions.
+func (srv *mysqlServer) drain(done chan struct{}) {
+	defer close(done)
+	srv.vtgateHandle.draining.Store(true)
+
+	for _, l := range []*mysql.Listener{srv.tcpListener, srv.unixListener, srv.xListener, srv.pgListener} {
+		if l != nil {
+			l.Shutdown()
+		}
 	}
 	if srv.sigChan != nil {
 		signal.Stop(srv.sigChan)
@@ -638,6 +994,10 @@ func (srv *mysqlServer) shutdownMysqlProtocolAndDrain() {
 		start := time.Now()
 		reported := start
 		for busy > 0 {
+			if mysqlServerDrainTimeout > 0 && time.Since(start) > mysqlServerDrainTimeout {
+				log.Warningf("Client connections still active after %v (%d active), closing them", mysqlServerDrainTimeout, busy)
+				break
+			}
 			if time.Since(reported) > 2*time.Second {
 				log.Infof("Still waiting for client connections to be idle (%d active)...", busy)
 				reported = time.Now()
@@ -647,6 +1007,76 @@ func (srv *mysqlServer) shutdownMysqlProtocolAndDrain() {
 			busy = srv.vtgateHandle.busyConnections.Load()
 		}
 	}
+	srv.vtgateHandle.closeConnections()
+}
+
+// closeConnections closes all the client connections. If they're waiting for
+// reads, this will cause them to error out, which will automatically rollback
+// open transactions.
+func (vh *vtgateHandler) closeConnections() {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	for id, c := range vh.connections {
+		if c != nil {
+			log.Infof("Rolling back transactions associated with connection ID: %v", id)
+			c.Close()
+		}
+	}
+}
+
+// mysqlDrainPath is the path of the HTTP handler draining the MySQL server.
+const mysqlDrainPath = "/debug/mysql_drain"
+
+// mysqlDrainStatus is the progress of the drain of the MySQL server.
+type mysqlDrainStatus struct {
+	Draining        bool       `json:"draining"`
+	Drained         bool       `json:"drained"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	Connections     int        `json:"connections"`
+	BusyConnections int32      `json:"busy_connections"`
+}
+
+func (srv *mysqlServer) drainStatus() *mysqlDrainStatus {
+	status := &mysqlDrainStatus{
+		Connections:     srv.vtgateHandle.numConnections(),
+		BusyConnections: srv.vtgateHandle.busyConnections.Load(),
+	}
+
+	srv.drainMu.Lock()
+	defer srv.drainMu.Unlock()
+	if srv.drainDone != nil {
+		status.Draining = true
+		status.StartedAt = &srv.drainStarted
+		select {
+		case <-srv.drainDone:
+			status.Drained = true
+		default:
+		}
+	}
+	return status
+}
+
+// handleDrain serves the progress of the drain of the MySQL server, for the
+// orchestration systems to know when vtgate can be stopped. A POST request
+// starts the drain.
+func (srv *mysqlServer) handleDrain(w http.ResponseWriter, r *http.Request) {
+	role := acl.MONITORING
+	if r.Method == http.MethodPost {
+		role = acl.ADMIN
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if r.Method == http.MethodPost {
+		log.Infof("Draining the MySQL server, as requested by %v", r.RemoteAddr)
+		srv.startDrain()
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(srv.drainStatus()); err != nil {
+		log.Errorf("Failed to serve the drain status: %v", err)
+	}
 }
 
 func (srv *mysqlServer) rollbackAtShutdown() {
@@ -656,20 +1086,8 @@ func (srv *mysqlServer) rollbackAtShutdown() {
 		return
 	}
 
-	// Close all open connections. If they're waiting for reads, this will cause
-	// them to error out, which will automatically rollback open transactions.
-	func() {
-		if srv.vtgateHandle != nil {
-			srv.vtgateHandle.mu.Lock()
-			defer srv.vtgateHandle.mu.Unlock()
-			for id, c := range srv.vtgateHandle.connections {
-				if c != nil {
-					log.Infof("Rolling back transactions associated with connection ID: %v", id)
-					c.Close()
-				}
-			}
-		}
-	}()
+	// Close all open connections, if the drain didn't.
+	srv.vtgateHandle.closeConnections()
 
 	// If vtgate is instead busy executing a query, the number of open conns
 	// will be non-zero. Give another second for those queries to finish.
@@ -695,9 +1113,23 @@ func init() {
 	servenv.OnParseFor("vtcombo", registerPluginFlags)
 }
 
-var pluginInitializers []func()
+var (
+	pluginInitializers    []func()
+	pluginInitializerOnce sync.Once
+)
 
 // RegisterPluginInitializer lets plugins register themselves to be init'ed at servenv.OnRun-time
 func RegisterPluginInitializer(initializer func()) {
 	pluginInitializers = append(pluginInitializers, initializer)
 }
+
+// mysqlAuthServer initializes the registered AuthServer implementations (or
+// other plugins), once, and returns the one of --mysql_auth_server_impl.
+func mysqlAuthServer() mysql.AuthServer {
+	pluginInitializerOnce.Do(func() {
+		for _, initFn := range pluginInitializers {
+			initFn()
+		}
+	})
+	return mysql.GetAuthServer(mysqlAuthServerImpl)
+}
@@ -19,7 +19,10 @@ package vtgate
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"strings"
@@ -211,22 +214,22 @@ func newSpanFail(t *testing.T) func(ctx context.Context, label string) (trace.Sp
 }
 
 func TestNoSpanContextPassed(t *testing.T) {
-	_, _, err := startSpanTestable(context.Background(), "sql without comments", "someLabel", newSpanOK, newFromStringFail(t))
+	_, _, err := startSpanTestable(context.Background(), "sql without comments", "", "someLabel", newSpanOK, newFromStringFail(t))
 	assert.NoError(t, err)
 }
 
 func TestSpanContextNoPassedInButExistsInString(t *testing.T) {
-	_, _, err := startSpanTestable(context.Background(), "SELECT * FROM SOMETABLE WHERE COL = \"/*VT_SPAN_CONTEXT=123*/", "someLabel", newSpanOK, newFromStringFail(t))
+	_, _, err := startSpanTestable(context.Background(), "SELECT * FROM SOMETABLE WHERE COL = \"/*VT_SPAN_CONTEXT=123*/", "", "someLabel", newSpanOK, newFromStringFail(t))
 	assert.NoError(t, err)
 }
 
 func TestSpanContextPassedIn(t *testing.T) {
-	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SQL QUERY", "someLabel", newSpanFail(t), newFromStringOK)
+	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SQL QUERY", "", "someLabel", newSpanFail(t), newFromStringOK)
 	assert.NoError(t, err)
 }
 
 func TestSpanContextPassedInEvenAroundOtherComments(t *testing.T) {
-	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SELECT /*vt+ SCATTER_ERRORS_AS_WARNINGS */ col1, col2 FROM TABLE ", "someLabel",
+	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SELECT /*vt+ SCATTER_ERRORS_AS_WARNINGS */ col1, col2 FROM TABLE ", "", "someLabel",
 		newSpanFail(t),
 		newFromStringExpect(t, "123"))
 	assert.NoError(t, err)
@@ -234,7 +237,7 @@ func TestSpanContextPassedInEvenAroundOtherComments(t *testing.T) {
 
 func TestSpanContextNotParsable(t *testing.T) {
 	hasRun := false
-	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SQL QUERY", "someLabel",
+	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SQL QUERY", "", "someLabel",
 		func(c context.Context, s string) (trace.Span, context.Context) {
 			hasRun = true
 			return trace.NoopSpan{}, context.Background()
@@ -244,6 +247,25 @@ func TestSpanContextNotParsable(t *testing.T) {
 	assert.True(t, hasRun, "Should have continued execution despite failure to parse VT_SPAN_CONTEXT")
 }
 
+func TestTraceparentPassedIn(t *testing.T) {
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	_, _, err := startSpanTestable(context.Background(), "select 1 from dual /*traceparent='"+traceparent+"'*/", "", "someLabel",
+		newSpanFail(t),
+		newFromStringExpect(t, traceparent))
+	assert.NoError(t, err)
+
+	// The span context of the query comments takes precedence over the connection attribute.
+	_, _, err = startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/select 1 from dual", traceparent, "someLabel",
+		newSpanFail(t),
+		newFromStringExpect(t, "123"))
+	assert.NoError(t, err)
+
+	_, _, err = startSpanTestable(context.Background(), "select 1 from dual", traceparent, "someLabel",
+		newSpanFail(t),
+		newFromStringExpect(t, traceparent))
+	assert.NoError(t, err)
+}
+
 func newTestAuthServerStatic() *mysql.AuthServerStatic {
 	jsonConfig := "{\"user1\":{\"Password\":\"password1\", \"UserData\":\"userData1\", \"SourceHost\":\"localhost\"}}"
 	return mysql.NewAuthServerStatic("", jsonConfig, 0)
@@ -289,8 +311,8 @@ func testInitTLSConfig(t *testing.T, serverCA bool) {
 		serverCACert = path.Join(root, "ca-cert.pem")
 	}
 
-	srv := &mysqlServer{tcpListener: &mysql.Listener{}}
-	if err := initTLSConfig(ctx, srv, path.Join(root, "server-cert.pem"), path.Join(root, "server-key.pem"), path.Join(root, "ca-cert.pem"), path.Join(root, "ca-crl.pem"), serverCACert, true, tls.VersionTLS12); err != nil {
+	srv := &mysqlServer{tcpListener: &mysql.Listener{}, xListener: &mysql.Listener{}, pgListener: &mysql.Listener{}}
+	if err := initTLSConfig(ctx, srv, path.Join(root, "server-cert.pem"), path.Join(root, "server-key.pem"), path.Join(root, "ca-cert.pem"), path.Join(root, "ca-crl.pem"), serverCACert, true, tls.VersionTLS12, nil, false); err != nil {
 		t.Fatalf("init tls config failure due to: +%v", err)
 	}
 
@@ -298,6 +320,12 @@ func testInitTLSConfig(t *testing.T, serverCA bool) {
 	if serverConfig == nil {
 		t.Fatalf("init tls config shouldn't create nil server config")
 	}
+	if srv.xListener.TLSConfig.Load() != serverConfig {
+		t.Fatalf("init tls config should set the same server config for the X Protocol listener")
+	}
+	if srv.pgListener.TLSConfig.Load() != serverConfig {
+		t.Fatalf("init tls config should set the same server config for the PostgreSQL protocol listener")
+	}
 
 	srv.sigChan <- syscall.SIGHUP
 	time.Sleep(100 * time.Millisecond) // wait for signal handler
@@ -305,6 +333,9 @@ func testInitTLSConfig(t *testing.T, serverCA bool) {
 	if srv.tcpListener.TLSConfig.Load() == serverConfig {
 		t.Fatalf("init tls config should have been recreated after SIGHUP")
 	}
+	if srv.xListener.TLSConfig.Load() != srv.tcpListener.TLSConfig.Load() {
+		t.Fatalf("init tls config should have been recreated after SIGHUP for the X Protocol listener")
+	}
 }
 
 // TestKillMethods test the mysql plugin for kill method calls.
@@ -422,3 +453,110 @@ func TestGracefulShutdownWithTransaction(t *testing.T) {
 
 	require.True(t, mysqlConn.IsMarkedForClose())
 }
+
+func TestDrainWithTransaction(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vh := newVtgateHandler(&VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected})
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer listener.Close()
+	srv := &mysqlServer{tcpListener: listener, vtgateHandle: vh}
+
+	// add a connection
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.UserData = &mysql.StaticUserData{}
+	vh.connections[1] = mysqlConn
+
+	err = vh.ComQuery(mysqlConn, "BEGIN", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &mysqlDrainStatus{Connections: 1, BusyConnections: 1}, srv.drainStatus())
+
+	done := srv.startDrain()
+	require.Eventually(t, mysqlConn.IsShuttingDown, 10*time.Second, 10*time.Millisecond)
+
+	status := srv.drainStatus()
+	assert.True(t, status.Draining)
+	assert.False(t, status.Drained)
+	assert.EqualValues(t, 1, status.BusyConnections)
+
+	// the transaction goes on, with a warning
+	err = vh.ComQuery(mysqlConn, "select 1", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, vh.WarningCount(mysqlConn))
+	assert.False(t, mysqlConn.IsMarkedForClose())
+
+	err = vh.ComQuery(mysqlConn, "COMMIT", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	<-done
+
+	status = srv.drainStatus()
+	assert.True(t, status.Draining)
+	assert.True(t, status.Drained)
+	assert.True(t, mysqlConn.IsClosed())
+
+	err = vh.ComQuery(mysqlConn, "select 1", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.EqualError(t, err, "Connection was killed: vtgate is draining, reconnect to another vtgate (errno 1927) (sqlstate 70100)")
+	require.True(t, mysqlConn.IsMarkedForClose())
+}
+
+func TestDrainTimeout(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vh := newVtgateHandler(&VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected})
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer listener.Close()
+	srv := &mysqlServer{tcpListener: listener, vtgateHandle: vh}
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.UserData = &mysql.StaticUserData{}
+	vh.connections[1] = mysqlConn
+
+	err = vh.ComQuery(mysqlConn, "BEGIN", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	oldTimeout := mysqlServerDrainTimeout
+	defer func() { mysqlServerDrainTimeout = oldTimeout }()
+	mysqlServerDrainTimeout = 100 * time.Millisecond
+
+	// the transaction isn't finished before the timeout, so its connection is closed
+	srv.shutdownMysqlProtocolAndDrain()
+	assert.True(t, srv.drainStatus().Drained)
+	assert.True(t, mysqlConn.IsClosed())
+}
+
+func TestHandleDrain(t *testing.T) {
+	vh := newVtgateHandler(&VTGate{})
+	srv := &mysqlServer{vtgateHandle: vh}
+
+	w := httptest.NewRecorder()
+	srv.handleDrain(w, httptest.NewRequest(http.MethodGet, mysqlDrainPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"draining": false, "drained": false, "connections": 0, "busy_connections": 0}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	srv.handleDrain(w, httptest.NewRequest(http.MethodPost, mysqlDrainPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var status mysqlDrainStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.True(t, status.Draining)
+	assert.NotNil(t, status.StartedAt)
+
+	<-srv.startDrain()
+	assert.True(t, srv.drainStatus().Drained)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var queryRuleMatches = stats.NewCountersWithMultiLabels("VtgateQueryRuleMatches", "Queries matched by the vtgate query rules, by rule and action", []string{"Rule", "Action"})
+
+// applyQueryRules applies the first query rule of the VSchema matching the planned query.
+// It fails the denied queries, adds a warning to the session for the queries to warn about,
+// and sends the redirected reads to the tablet type of their rule.
+func (e *Executor) applyQueryRules(ctx context.Context, vcursor *vcursorImpl, plan *engine.Plan, safeSession *SafeSession) error {
+	rules := vcursor.vschema.QueryRules
+	if len(rules) == 0 {
+		return nil
+	}
+
+	user := callerid.ImmediateCallerIDFromContext(ctx).GetUsername()
+	routeType := ""
+	if plan.Instructions != nil {
+		routeType = plan.Instructions.RouteType()
+	}
+	for _, rule := range rules {
+		if !rule.Matches(plan.Original, user, plan.TablesUsed, plan.Type.String(), routeType) {
+			continue
+		}
+		queryRuleMatches.Add([]string{rule.Name, rule.Action.String()}, 1)
+		switch rule.Action {
+		case vschemapb.QueryRule_DENY:
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: %s", rule.Name)
+		case vschemapb.QueryRule_WARN:
+			safeSession.RecordWarning(&querypb.QueryWarning{
+				Code:    uint32(sqlerror.ERUnknownError),
+				Message: fmt.Sprintf("query matches rule: %s", rule.Name),
+			})
+		case vschemapb.QueryRule_REDIRECT:
+			// The writes, and the reads of the transactions, must stay on the tablets they were sent to.
+			if plan.Type == sqlparser.StmtSelect && !safeSession.InTransaction() {
+				vcursor.tabletType = rule.RedirectTabletType
+			}
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestExecutorQueryRules(t *testing.T) {
+	ctx := context.Background()
+	executor, primary, replica := createExecutorEnvWithPrimaryReplicaConn(t, ctx, 0)
+
+	rules, err := vindexes.BuildQueryRules(&vschemapb.QueryRules{
+		Rules: []*vschemapb.QueryRule{{
+			Name:   "admin",
+			Users:  []string{"admin"},
+			Action: vschemapb.QueryRule_ALLOW,
+		}, {
+			Name:   "no_full_deletes",
+			Query:  "^delete from [a-z0-9_]+$",
+			Action: vschemapb.QueryRule_DENY,
+		}, {
+			Name:      "legacy_updates",
+			Tables:    []string{"legacy"},
+			PlanTypes: []string{"UPDATE"},
+			Action:    vschemapb.QueryRule_WARN,
+		}, {
+			Name:               "reports_on_replicas",
+			Tables:             []string{"reports"},
+			Action:             vschemapb.QueryRule_REDIRECT,
+			RedirectTabletType: "replica",
+		}},
+	})
+	require.NoError(t, err)
+	executor.VSchema().QueryRules = rules
+	denied := queryRuleMatches.Counts()["no_full_deletes.DENY"]
+	redirected := queryRuleMatches.Counts()["reports_on_replicas.REDIRECT"]
+
+	session := &vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true}
+	_, err = executorExec(ctx, executor, session, "delete from t1", nil)
+	require.EqualError(t, err, "disallowed due to rule: no_full_deletes")
+	assert.Zero(t, primary.ExecCount.Load())
+
+	// The first matching rule decides, so the admin can run the denied queries.
+	adminCtx := callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("admin"))
+	_, err = executorExec(adminCtx, executor, session, "delete from t1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.ExecCount.Load())
+
+	_, err = executorExec(ctx, executor, session, "update legacy set a = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, session.Warnings, 1)
+	assert.Equal(t, "query matches rule: legacy_updates", session.Warnings[0].Message)
+
+	_, err = executorExec(ctx, executor, session, "select * from reports", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, primary.ExecCount.Load())
+	assert.EqualValues(t, 1, replica.ExecCount.Load())
+
+	// The reads of the transactions are not redirected.
+	_, err = executorExec(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExec(ctx, executor, session, "select * from reports", nil)
+	require.NoError(t, err)
+	_, err = executorExec(ctx, executor, session, "commit", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, replica.ExecCount.Load())
+
+	assert.EqualValues(t, 1, queryRuleMatches.Counts()["no_full_deletes.DENY"]-denied)
+	assert.EqualValues(t, 2, queryRuleMatches.Counts()["reports_on_replicas.REDIRECT"]-redirected)
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package queryacl restricts the tables each user can query through vtgate,
+// and the types of statements they can run on them.
+//
+// The ACL is a JSON document mapping the users to their grants, the special
+// user '%' applying to the users without grants of their own:
+//
+//	{
+//	  "app": [{"tables": ["commerce.*"], "privileges": ["SELECT", "DML"]}],
+//	  "%": [{"tables": ["*"], "privileges": ["SELECT"]}]
+//	}
+//
+// A table is either '*', 'keyspace.*', 'keyspace.table' or 'table', the latter
+// matching the tables of that name in all the keyspaces. The privileges are
+// SELECT, DML and DDL, and a grant without privileges gives them all.
+package queryacl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// PrivilegeSelect allows the SELECT statements.
+	PrivilegeSelect = "SELECT"
+	// PrivilegeDML allows the INSERT, REPLACE, UPDATE and DELETE statements.
+	PrivilegeDML = "DML"
+	// PrivilegeDDL allows the DDL statements.
+	PrivilegeDDL = "DDL"
+
+	// allUsers is the user whose grants apply to the users without grants of their own.
+	allUsers = "%"
+)
+
+var (
+	// ACLFile is the path of the JSON file of the ACL. The ACL is disabled if it is empty.
+	ACLFile string
+
+	// ReloadInterval is how often the ACL file is reloaded, on top of the reloads on SIGHUP.
+	ReloadInterval time.Duration
+
+	// acl is the ACL in effect, nil if disabled.
+	acl atomic.Pointer[ACL]
+
+	reloadOnce sync.Once
+)
+
+// RegisterQueryACLFlags installs the query ACL flags on the given FlagSet.
+func RegisterQueryACLFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&ACLFile, "query-acl-file", ACLFile, "JSON file restricting the tables each user can query and the types of statements they can run on them. Reloaded on SIGHUP.")
+	fs.DurationVar(&ReloadInterval, "query-acl-reload-interval", ReloadInterval, "Interval at which the --query-acl-file is reloaded, 0 to only reload it on SIGHUP.")
+}
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtgate"} {
+		servenv.OnParseFor(cmd, RegisterQueryACLFlags)
+	}
+}
+
+// Grant gives privileges on tables.
+type Grant struct {
+	Tables     []string `json:"tables"`
+	Privileges []string `json:"privileges,omitempty"`
+}
+
+// ACL maps the users to their grants.
+type ACL map[string][]*Grant
+
+// Parse parses and validates a JSON ACL.
+func Parse(data []byte) (ACL, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var parsed ACL
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid query ACL")
+	}
+	for user, grants := range parsed {
+		for _, grant := range grants {
+			if len(grant.Tables) == 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "grant of user %s must have tables", user)
+			}
+			for i, privilege := range grant.Privileges {
+				privilege = strings.ToUpper(privilege)
+				switch privilege {
+				case PrivilegeSelect, PrivilegeDML, PrivilegeDDL:
+				default:
+					return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid privilege %s of user %s", grant.Privileges[i], user)
+				}
+				grant.Privileges[i] = privilege
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// Init loads the ACL file, and installs its reloading. It fails if the file cannot be loaded.
+func Init() {
+	if ACLFile == "" {
+		acl.Store(nil)
+		return
+	}
+	if err := reload(); err != nil {
+		log.Exitf("Failed to load the query ACL: %v", err)
+	}
+	reloadOnce.Do(installReloading)
+}
+
+// Set replaces the ACL in effect, nil disabling it.
+func Set(newACL ACL) {
+	if newACL == nil {
+		acl.Store(nil)
+		return
+	}
+	acl.Store(&newACL)
+}
+
+func reload() error {
+	data, err := os.ReadFile(ACLFile)
+	if err != nil {
+		return err
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	acl.Store(&parsed)
+	return nil
+}
+
+func installReloading() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	go func() {
+		for range sigChan {
+			// A broken file keeps the previous ACL in effect.
+			if err := reload(); err != nil {
+				log.Errorf("Failed to reload the query ACL: %v", err)
+			}
+		}
+	}()
+
+	if ReloadInterval > 0 {
+		ticker := time.NewTicker(ReloadInterval)
+		go func() {
+			for range ticker.C {
+				sigChan <- syscall.SIGHUP
+			}
+		}()
+	}
+}
+
+// privilegeFor returns the privilege needed to run the statements of the given type,
+// or an empty string if they are not restricted.
+func privilegeFor(stmtType sqlparser.StatementType) string {
+	switch stmtType {
+	case sqlparser.StmtSelect, sqlparser.StmtStream:
+		return PrivilegeSelect
+	case sqlparser.StmtInsert, sqlparser.StmtReplace, sqlparser.StmtUpdate, sqlparser.StmtDelete:
+		return PrivilegeDML
+	case sqlparser.StmtDDL:
+		return PrivilegeDDL
+	}
+	return ""
+}
+
+// Authorized returns an error if the caller is not allowed to run a statement of the given
+// type on the given tables, qualified by their keyspace. A statement without tables needs
+// the privilege on any table.
+func Authorized(caller *querypb.VTGateCallerID, stmtType sqlparser.StatementType, tables []string) error {
+	current := acl.Load()
+	if current == nil {
+		return nil
+	}
+	privilege := privilegeFor(stmtType)
+	if privilege == "" {
+		return nil
+	}
+
+	user := caller.GetUsername()
+	grants, ok := (*current)[user]
+	if !ok {
+		grants = (*current)[allUsers]
+	}
+	if len(tables) == 0 {
+		if !slices.ContainsFunc(grants, func(grant *Grant) bool { return grant.allows(privilege) }) {
+			return vterrors.NewErrorf(vtrpcpb.Code_PERMISSION_DENIED, vterrors.AccessDeniedError, "User '%s' is not authorized to run %s statements", user, privilege)
+		}
+		return nil
+	}
+	for _, table := range tables {
+		if !slices.ContainsFunc(grants, func(grant *Grant) bool { return grant.allows(privilege) && grant.matchesTable(table) }) {
+			return vterrors.NewErrorf(vtrpcpb.Code_PERMISSION_DENIED, vterrors.AccessDeniedError, "User '%s' is not authorized to run %s statements on table %s", user, privilege, table)
+		}
+	}
+	return nil
+}
+
+func (g *Grant) allows(privilege string) bool {
+	return len(g.Privileges) == 0 || slices.Contains(g.Privileges, privilege)
+}
+
+func (g *Grant) matchesTable(table string) bool {
+	keyspace, name, _ := strings.Cut(table, ".")
+	for _, t := range g.Tables {
+		switch {
+		case t == "*" || t == table:
+			return true
+		case strings.HasSuffix(t, ".*"):
+			if strings.TrimSuffix(t, ".*") == keyspace {
+				return true
+			}
+		case !strings.Contains(t, "."):
+			if t == name {
+				return true
+			}
+		}
+	}
+	return false
+}
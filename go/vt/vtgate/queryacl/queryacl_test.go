@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queryacl
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestAuthorized(t *testing.T) {
+	defer Set(nil)
+
+	acl, err := Parse([]byte(`{
+		"app": [{"tables": ["commerce.*"], "privileges": ["select", "DML"]}, {"tables": ["customer.orders"], "privileges": ["SELECT"]}],
+		"admin": [{"tables": ["*"]}],
+		"%": [{"tables": ["product"], "privileges": ["SELECT"]}]
+	}`))
+	require.NoError(t, err)
+
+	app := &querypb.VTGateCallerID{Username: "app"}
+	admin := &querypb.VTGateCallerID{Username: "admin"}
+	other := &querypb.VTGateCallerID{Username: "other"}
+
+	// Everything is allowed until an ACL is set.
+	assert.NoError(t, Authorized(other, sqlparser.StmtDDL, []string{"commerce.product"}))
+	Set(acl)
+
+	tests := []struct {
+		caller   *querypb.VTGateCallerID
+		stmtType sqlparser.StatementType
+		tables   []string
+		err      string
+	}{{
+		caller:   app,
+		stmtType: sqlparser.StmtUpdate,
+		tables:   []string{"commerce.product", "commerce.customer"},
+	}, {
+		caller:   app,
+		stmtType: sqlparser.StmtSelect,
+		tables:   []string{"commerce.product", "customer.orders"},
+	}, {
+		caller:   app,
+		stmtType: sqlparser.StmtDelete,
+		tables:   []string{"customer.orders"},
+		err:      "User 'app' is not authorized to run DML statements on table customer.orders",
+	}, {
+		caller:   app,
+		stmtType: sqlparser.StmtDDL,
+		tables:   []string{"commerce.product"},
+		err:      "User 'app' is not authorized to run DDL statements on table commerce.product",
+	}, {
+		caller:   app,
+		stmtType: sqlparser.StmtSelect,
+	}, {
+		caller:   app,
+		stmtType: sqlparser.StmtDDL,
+		err:      "User 'app' is not authorized to run DDL statements",
+	}, {
+		caller:   app,
+		stmtType: sqlparser.StmtShow,
+		tables:   []string{"customer.orders"},
+	}, {
+		caller:   admin,
+		stmtType: sqlparser.StmtDDL,
+		tables:   []string{"customer.orders"},
+	}, {
+		caller:   other,
+		stmtType: sqlparser.StmtSelect,
+		tables:   []string{"commerce.product", "customer.product"},
+	}, {
+		caller:   other,
+		stmtType: sqlparser.StmtSelect,
+		tables:   []string{"commerce.customer"},
+		err:      "User 'other' is not authorized to run SELECT statements on table commerce.customer",
+	}}
+	for _, tt := range tests {
+		err := Authorized(tt.caller, tt.stmtType, tt.tables)
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse([]byte(`{"app": [{"privileges": ["SELECT"]}]}`))
+	assert.EqualError(t, err, "grant of user app must have tables")
+
+	_, err = Parse([]byte(`{"app": [{"tables": ["*"], "privileges": ["DROP"]}]}`))
+	assert.EqualError(t, err, "invalid privilege DROP of user app")
+
+	_, err = Parse([]byte(`{"app": [{"table": ["*"]}]}`))
+	assert.ErrorContains(t, err, "invalid query ACL")
+}
+
+func TestInit(t *testing.T) {
+	defer func() {
+		ACLFile = ""
+		Init()
+	}()
+
+	ACLFile = path.Join(t.TempDir(), "acl.json")
+	require.NoError(t, os.WriteFile(ACLFile, []byte(`{"app": [{"tables": ["commerce.*"], "privileges": ["SELECT"]}]}`), 0600))
+	Init()
+
+	app := &querypb.VTGateCallerID{Username: "app"}
+	assert.NoError(t, Authorized(app, sqlparser.StmtSelect, []string{"commerce.product"}))
+	assert.Error(t, Authorized(app, sqlparser.StmtInsert, []string{"commerce.product"}))
+
+	// A broken file keeps the previous ACL in effect.
+	require.NoError(t, os.WriteFile(ACLFile, []byte(`{"app": [{"tables": ["commerce.*"], "privileges": ["INSERT"]}]}`), 0600))
+	assert.Error(t, reload())
+	assert.NoError(t, Authorized(app, sqlparser.StmtSelect, []string{"commerce.product"}))
+
+	require.NoError(t, os.WriteFile(ACLFile, []byte(`{"app": [{"tables": ["commerce.*"], "privileges": ["DML"]}]}`), 0600))
+	require.NoError(t, reload())
+	assert.NoError(t, Authorized(app, sqlparser.StmtInsert, []string{"commerce.product"}))
+}
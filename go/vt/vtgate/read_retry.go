@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sync"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// readRetries counts the reads retried on another replica, by outcome.
+var readRetries = stats.NewCountersWithSingleLabel("ReadRetries", "Reads retried on another replica after a tablet failure, by result", "Result")
+
+// failedTablets records the tablets that failed to answer a query, so that
+// its retries are sent to other tablets. It is carried by the context of the
+// query, and used by the tablet gateway.
+type failedTablets struct {
+	mu      sync.Mutex
+	aliases map[string]bool
+	// exhausted is set when a retry found no other healthy tablet to use.
+	exhausted bool
+}
+
+type failedTabletsKey struct{}
+
+func withFailedTablets(ctx context.Context) (context.Context, *failedTablets) {
+	ft := &failedTablets{aliases: make(map[string]bool)}
+	return context.WithValue(ctx, failedTabletsKey{}, ft), ft
+}
+
+// failedTabletsFromContext returns the failed tablets of the query, or nil if
+// its retries are not tracked.
+func failedTabletsFromContext(ctx context.Context) *failedTablets {
+	ft, _ := ctx.Value(failedTabletsKey{}).(*failedTablets)
+	return ft
+}
+
+func (ft *failedTablets) add(alias string) {
+	if ft == nil {
+		return
+	}
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.aliases[alias] = true
+}
+
+func (ft *failedTablets) contains(alias string) bool {
+	if ft == nil {
+		return false
+	}
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.aliases[alias]
+}
+
+func (ft *failedTablets) setExhausted() {
+	if ft == nil {
+		return
+	}
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.exhausted = true
+}
+
+func (ft *failedTablets) state() (failed int, exhausted bool) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return len(ft.aliases), ft.exhausted
+}
+
+// canRetryRead returns whether the plan can be executed again if a replica fails:
+// it must be a read sent to the replicas, outside of a transaction or of any other
+// state kept on the connections of the session.
+func (e *Executor) canRetryRead(safeSession *SafeSession, plan *engine.Plan, vcursor *vcursorImpl) bool {
+	if e.readRetries <= 0 || plan.Type != sqlparser.StmtSelect {
+		return false
+	}
+	switch vcursor.tabletType {
+	case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+	default:
+		return false
+	}
+	return !safeSession.InTransaction() && !safeSession.InReservedConn() && !safeSession.InLockSession()
+}
+
+// isRetryableReadError returns true for the errors caused by a tablet that went
+// away or stopped serving, as opposed to the errors caused by the query itself.
+func isRetryableReadError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_UNAVAILABLE, vtrpcpb.Code_FAILED_PRECONDITION, vtrpcpb.Code_CLUSTER_EVENT, vtrpcpb.Code_ABORTED:
+		return true
+	}
+	return sqlerror.IsConnErr(sqlerror.NewSQLErrorFromError(vterrors.RootCause(err)))
+}
+
+// executeWithReadRetry executes the plan. If it is a read sent to the replicas and one of
+// them fails, the plan is executed again on the other healthy replicas, within the same
+// deadline and up to --read-retry-max-attempts times.
+func (e *Executor) executeWithReadRetry(
+	ctx context.Context,
+	safeSession *SafeSession,
+	plan *engine.Plan,
+	vcursor *vcursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+) (*sqltypes.Result, error) {
+	if !e.canRetryRead(safeSession, plan, vcursor) {
+		return e.executePrimitive(ctx, safeSession, plan, vcursor, bindVars)
+	}
+
+	ctx, failed := withFailedTablets(ctx)
+	qr, err := e.executePrimitive(ctx, safeSession, plan, vcursor, bindVars)
+	for attempt := 0; err != nil && attempt < e.readRetries; attempt++ {
+		if n, _ := failed.state(); n == 0 || !isRetryableReadError(ctx, err) {
+			break
+		}
+		log.V(2).Infof("Retrying read on another replica after: %v", err)
+		retryQR, retryErr := e.executePrimitive(ctx, safeSession, plan, vcursor, bindVars)
+		if _, exhausted := failed.state(); exhausted {
+			// There is no other replica to try: keep the error of the failed one.
+			readRetries.Add("NoReplica", 1)
+			break
+		}
+		qr, err = retryQR, retryErr
+		if err == nil {
+			readRetries.Add("Success", 1)
+		} else {
+			readRetries.Add("Failure", 1)
+		}
+	}
+	return qr, err
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestIsRetryableReadError(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, isRetryableReadError(ctx, nil))
+	assert.True(t, isRetryableReadError(ctx, vterrors.New(vtrpcpb.Code_UNAVAILABLE, "tablet is shutting down")))
+	assert.True(t, isRetryableReadError(ctx, vterrors.New(vtrpcpb.Code_ABORTED, "connection closed")))
+	assert.True(t, isRetryableReadError(ctx, sqlerror.NewSQLError(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "Lost connection to MySQL server during query")))
+	assert.False(t, isRetryableReadError(ctx, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "syntax error")))
+	assert.False(t, isRetryableReadError(ctx, errors.New("unknown error")))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, isRetryableReadError(canceled, vterrors.New(vtrpcpb.Code_UNAVAILABLE, "tablet is shutting down")))
+}
+
+func createExecutorEnvWithReplicas(t *testing.T) (executor *Executor, replicas []*sandboxconn.SandboxConn, ctx context.Context) {
+	ctx = utils.LeakCheckContext(t)
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+
+	createSandbox(KsTestUnsharded).VSchema = unshardedVSchema
+	hc.AddTestTablet(cell, "0", 1, KsTestUnsharded, "0", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	replicas = []*sandboxconn.SandboxConn{
+		hc.AddTestTablet(cell, "1", 1, KsTestUnsharded, "0", topodatapb.TabletType_REPLICA, true, 1, nil),
+		hc.AddTestTablet(cell, "2", 1, KsTestUnsharded, "0", topodatapb.TabletType_REPLICA, true, 1, nil),
+	}
+
+	executor = NewExecutor(ctx, vtenv.NewTestEnv(), serv, cell, resolver, false, false, testBufferSize, DefaultPlanCache(), nil, false, querypb.ExecuteOptions_Gen4, 0)
+	executor.SetQueryLogger(streamlog.New[*logstats.LogStats]("VTGate", queryLogBufferSize))
+	t.Cleanup(executor.Close)
+	return executor, replicas, ctx
+}
+
+func TestExecutorReadRetry(t *testing.T) {
+	executor, replicas, ctx := createExecutorEnvWithReplicas(t)
+	executor.readRetries = 1
+
+	// The gateway retries the unavailable tablets itself, but not the aborted queries.
+	replicas[0].MustFailCodes[vtrpcpb.Code_ABORTED] = 1
+	successes := readRetries.Counts()["Success"]
+	// The read fails on the first replica once the gateway picks it, and is then
+	// retried on the other one.
+	for i := 0; i < 100 && replicas[0].MustFailCodes[vtrpcpb.Code_ABORTED] > 0; i++ {
+		_, err := executorExec(ctx, executor, &vtgatepb.Session{TargetString: "@replica", Autocommit: true}, "select id from main1", nil)
+		require.NoError(t, err)
+	}
+	require.Zero(t, replicas[0].MustFailCodes[vtrpcpb.Code_ABORTED])
+	assert.EqualValues(t, 1, readRetries.Counts()["Success"]-successes)
+
+	// The reads of the transactions are not retried.
+	for _, replica := range replicas {
+		replica.MustFailCodes[vtrpcpb.Code_ABORTED] = 1
+	}
+	session := &vtgatepb.Session{TargetString: "@replica", Autocommit: true}
+	_, err := executorExec(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExec(ctx, executor, session, "select id from main1", nil)
+	require.ErrorContains(t, err, "ABORTED error")
+	_, err = executorExec(ctx, executor, session, "rollback", nil)
+	require.NoError(t, err)
+
+	// The retry fails if the other replica fails too.
+	for _, replica := range replicas {
+		replica.MustFailCodes[vtrpcpb.Code_ABORTED] = 1
+	}
+	failures := readRetries.Counts()["Failure"]
+	_, err = executorExec(ctx, executor, &vtgatepb.Session{TargetString: "@replica", Autocommit: true}, "select id from main1", nil)
+	require.ErrorContains(t, err, "ABORTED error")
+	assert.EqualValues(t, 1, readRetries.Counts()["Failure"]-failures)
+}
+
+func TestExecutorReadRetryDisabled(t *testing.T) {
+	executor, replicas, ctx := createExecutorEnvWithReplicas(t)
+	executor.readRetries = 0
+
+	for _, replica := range replicas {
+		replica.MustFailCodes[vtrpcpb.Code_ABORTED] = 1
+	}
+	_, err := executorExec(ctx, executor, &vtgatepb.Session{TargetString: "@replica", Autocommit: true}, "select id from main1", nil)
+	require.ErrorContains(t, err, "ABORTED error")
+	// Only one of the replicas was tried.
+	assert.EqualValues(t, 1, replicas[0].MustFailCodes[vtrpcpb.Code_ABORTED]+replicas[1].MustFailCodes[vtrpcpb.Code_ABORTED])
+}
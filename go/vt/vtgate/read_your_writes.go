@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// readYourWritesRedirects counts the reads sent to the primary because the replicas
+// hadn't applied the writes of the session yet.
+var readYourWritesRedirects = stats.NewCountersWithSingleLabel("ReadYourWritesRedirects", "Reads of sessions with read-your-writes consistency sent to the primary because the replicas were behind", "Keyspace")
+
+// Read-your-writes consistency: with read_after_write_consistency set to SESSION,
+// the vtgate records the GTIDs that the primaries return for the writes of the
+// session, and only sends the later reads of the session to the replicas of a
+// shard once they all applied these GTIDs. It sends them to the primary otherwise.
+//
+// The primaries only return the GTIDs of the writes if MySQL has session_track_gtids
+// set to OWN_GTID. The commit of an explicit transaction doesn't return them, so only
+// the autocommit writes are tracked.
+
+// recordWrite records the GTIDs returned by the primary of a shard for a write
+// of the session.
+func recordWrite(session *SafeSession, target *querypb.Target, qr *sqltypes.Result) {
+	if qr == nil || qr.SessionStateChanges == "" || target == nil || target.TabletType != topodatapb.TabletType_PRIMARY {
+		return
+	}
+	if !session.GetReadYourWrites() {
+		return
+	}
+	if err := session.RecordShardGTIDs(target.Keyspace, target.Shard, qr.SessionStateChanges); err != nil {
+		log.Warningf("Cannot record the GTIDs %q of a write to %s/%s: %v", qr.SessionStateChanges, target.Keyspace, target.Shard, err)
+	}
+}
+
+// readYourWrites returns the shards to send the queries of the session to: the shards
+// that target a replica that didn't apply the writes of the session yet are sent to the
+// primary instead. The queries of transactions and reserved connections are left alone.
+func (stc *ScatterConn) readYourWrites(rss []*srvtopo.ResolvedShard, session *SafeSession) []*srvtopo.ResolvedShard {
+	if !session.GetReadYourWrites() || session.InTransaction() || session.InReservedConn() {
+		return rss
+	}
+	var redirected []*srvtopo.ResolvedShard
+	for i, rs := range rss {
+		if rs.Target == nil || rs.Target.TabletType == topodatapb.TabletType_PRIMARY {
+			continue
+		}
+		gtids := session.GetShardGTIDs(rs.Target.Keyspace, rs.Target.Shard)
+		if gtids == "" || stc.replicasCaughtUp(rs.Target, gtids) {
+			continue
+		}
+		if redirected == nil {
+			redirected = make([]*srvtopo.ResolvedShard, len(rss))
+			copy(redirected, rss)
+		}
+		target := rs.Target.CloneVT()
+		target.TabletType = topodatapb.TabletType_PRIMARY
+		redirected[i] = &srvtopo.ResolvedShard{Target: target, Gateway: rs.Gateway}
+		readYourWritesRedirects.Add(rs.Target.Keyspace, 1)
+	}
+	if redirected == nil {
+		return rss
+	}
+	return redirected
+}
+
+// replicasCaughtUp returns true if there are healthy tablets for the target and
+// all of them reported a replication position that contains the given GTIDs.
+func (stc *ScatterConn) replicasCaughtUp(target *querypb.Target, gtids string) bool {
+	if stc.gateway == nil || stc.gateway.hc == nil {
+		return false
+	}
+	want, err := replication.ParseMysql56GTIDSet(gtids)
+	if err != nil {
+		return false
+	}
+	tablets := stc.gateway.hc.GetHealthyTabletStats(target)
+	if len(tablets) == 0 {
+		return false
+	}
+	for _, th := range tablets {
+		pos, err := replication.DecodePosition(th.Stats.GetReplicationPosition())
+		if err != nil || pos.IsZero() || !pos.GTIDSet.Contains(want) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/cache/theine"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vthash"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var (
+	resultCacheHits          = stats.NewCounter("QueryResultCacheHits", "Query result cache hits")
+	resultCacheMisses        = stats.NewCounter("QueryResultCacheMisses", "Query result cache misses")
+	resultCacheInvalidations = stats.NewCounter("QueryResultCacheInvalidations", "Query result cache entries dropped because they expired or one of their tables changed")
+)
+
+// tableVersioner gives the version of the schema of the tables, which changes every time
+// the schema of a table changes. It is implemented by the schema tracker.
+type tableVersioner interface {
+	TableVersion(ks string, tbl string) int64
+}
+
+// resultCache caches the results of read-only queries, keyed on their plan, bind variables and
+// caller, since the tablets may enforce table ACLs. An entry is dropped when it expires, or when
+// one of its tables changes: either its schema changed according to the schema tracker, or this
+// vtgate executed a DML on it. Since the changes of a DML executed in a transaction are only seen
+// by the other sessions once it is committed, the entries of all the tables of the keyspaces of a
+// transaction are also dropped when it is committed. Changes made through other vtgates are only
+// seen when the entries expire.
+type resultCache struct {
+	entries *theine.Store[theine.HashKey256, *cachedResult]
+	ttl     time.Duration
+	maxRows int
+	// optIn only caches the queries with the RESULT_CACHE=ON directive.
+	optIn  bool
+	schema tableVersioner
+
+	mu sync.Mutex
+	// dmls are the versions of the tables bumped by the DMLs executed through this vtgate.
+	dmls map[string]int64
+	// commits are the versions of the keyspaces bumped by the transactions committed
+	// through this vtgate.
+	commits map[string]int64
+}
+
+type cachedResult struct {
+	result  *sqltypes.Result
+	expires time.Time
+	tables  []tableVersion
+}
+
+type tableVersion struct {
+	table   string
+	version int64
+}
+
+// CachedSize returns the approximate memory used by the entry.
+func (cr *cachedResult) CachedSize(alloc bool) int64 {
+	size := int64(64 + 24*len(cr.tables))
+	for _, tv := range cr.tables {
+		size += int64(len(tv.table))
+	}
+	return size + cr.result.CachedSize(true)
+}
+
+// newResultCache returns the query result cache, or nil if it is disabled.
+func newResultCache(memory int64, ttl time.Duration, maxRows int, optIn bool, schema SchemaInfo) *resultCache {
+	if memory <= 0 || ttl <= 0 {
+		return nil
+	}
+	rc := &resultCache{
+		// the TTL already bounds the lifetime of the results of one-off queries,
+		// so there is no need for the doorkeeper.
+		entries: theine.NewStore[theine.HashKey256, *cachedResult](memory, false),
+		ttl:     ttl,
+		maxRows: maxRows,
+		optIn:   optIn,
+		dmls:    make(map[string]int64),
+		commits: make(map[string]int64),
+	}
+	if tv, ok := schema.(tableVersioner); ok {
+		rc.schema = tv
+	}
+	return rc
+}
+
+// accepts returns whether the results of the statement can be cached, according
+// to its RESULT_CACHE directive and to whether its results are deterministic.
+func (rc *resultCache) accepts(stmt sqlparser.Statement) bool {
+	if rc == nil {
+		return false
+	}
+	sel, ok := stmt.(sqlparser.SelectStatement)
+	if !ok || sel.GetLock() != sqlparser.NoLock {
+		return false
+	}
+	if s, ok := sel.(*sqlparser.Select); ok && s.SQLCalcFoundRows {
+		return false
+	}
+	hasInto := false
+	deterministic := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.SelectInto:
+			hasInto = true
+		case *sqlparser.CurTimeFuncExpr, *sqlparser.LockingFunc:
+			deterministic = false
+		case *sqlparser.FuncExpr:
+			switch node.Name.Lowered() {
+			case "rand", "uuid", "uuid_short", "connection_id", "sleep", "last_insert_id", "found_rows", "row_count":
+				deterministic = false
+			}
+		}
+		return true, nil
+	}, stmt)
+	if hasInto {
+		return false
+	}
+	if cache := sqlparser.ResultCacheDirective(stmt); cache != nil {
+		return *cache
+	}
+	return !rc.optIn && deterministic
+}
+
+// key returns the key of the result of a plan, which depends on the target of the
+// session, its system variables, the bind variables of the query and the caller.
+func (rc *resultCache) key(ctx context.Context, vc *vcursorImpl, plan *engine.Plan, bindVars map[string]*querypb.BindVariable) theine.HashKey256 {
+	hasher := vthash.New256()
+	vc.keyForPlan(ctx, plan.Original, hasher)
+
+	names := make([]string, 0, len(bindVars))
+	for name := range bindVars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	_, _ = hasher.WriteString("+BindVars:")
+	for _, name := range names {
+		bv := bindVars[name]
+		_, _ = hasher.WriteString(name)
+		_, _ = hasher.WriteString("=")
+		_, _ = hasher.WriteString(bv.Type.String())
+		_, _ = hasher.Write(bv.Value)
+		for _, v := range bv.Values {
+			_, _ = hasher.WriteString(",")
+			_, _ = hasher.WriteString(v.Type.String())
+			_, _ = hasher.Write(v.Value)
+		}
+		_, _ = hasher.WriteString(";")
+	}
+
+	_, _ = hasher.WriteString("+SysVars:")
+	var sysVars []string
+	vc.safeSession.GetSystemVariables(func(k string, v string) {
+		sysVars = append(sysVars, k+"="+v)
+	})
+	slices.Sort(sysVars)
+	_, _ = hasher.WriteString(strings.Join(sysVars, ";"))
+
+	_, _ = hasher.WriteString("+Caller:")
+	if im := callerid.ImmediateCallerIDFromContext(ctx); im != nil {
+		_, _ = hasher.WriteString(im.Username)
+		_, _ = hasher.WriteString("/")
+		_, _ = hasher.WriteString(strings.Join(im.Groups, ","))
+	}
+	_, _ = hasher.WriteString(";")
+	if ef := callerid.EffectiveCallerIDFromContext(ctx); ef != nil {
+		_, _ = hasher.WriteString(ef.Principal)
+		_, _ = hasher.WriteString("/")
+		_, _ = hasher.WriteString(ef.Component)
+		_, _ = hasher.WriteString("/")
+		_, _ = hasher.WriteString(ef.Subcomponent)
+	}
+
+	var key theine.HashKey256
+	hasher.Sum(key[:0])
+	return key
+}
+
+// versions returns the current versions of the given tables.
+func (rc *resultCache) versions(tables []string) []tableVersion {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	tvs := make([]tableVersion, 0, len(tables))
+	for _, table := range tables {
+		version := rc.dmls[table]
+		if ks, tbl, found := strings.Cut(table, "."); found {
+			version += rc.commits[ks]
+			if rc.schema != nil {
+				version += rc.schema.TableVersion(ks, tbl)
+			}
+		}
+		tvs = append(tvs, tableVersion{table: table, version: version})
+	}
+	return tvs
+}
+
+// get returns the cached result of the key, if it is still valid.
+func (rc *resultCache) get(key theine.HashKey256, epoch uint32) (*sqltypes.Result, bool) {
+	cr, ok := rc.entries.Get(key, epoch)
+	if !ok {
+		resultCacheMisses.Add(1)
+		return nil, false
+	}
+	if time.Now().After(cr.expires) || !slices.Equal(cr.tables, rc.versions(tablesOf(cr.tables))) {
+		rc.entries.Delete(key)
+		resultCacheInvalidations.Add(1)
+		resultCacheMisses.Add(1)
+		return nil, false
+	}
+	resultCacheHits.Add(1)
+	return cr.result.ShallowCopy(), true
+}
+
+// set caches the result of the key. The versions of its tables must be read before the
+// query is executed, so that the changes made while it executes invalidate the result.
+func (rc *resultCache) set(key theine.HashKey256, epoch uint32, result *sqltypes.Result, tables []tableVersion) {
+	if rc.maxRows > 0 && len(result.Rows) > rc.maxRows {
+		return
+	}
+	cr := &cachedResult{
+		result:  result.ShallowCopy(),
+		expires: time.Now().Add(rc.ttl),
+		tables:  tables,
+	}
+	rc.entries.Set(key, cr, cr.CachedSize(true), epoch)
+}
+
+// invalidate drops the cached results of the given tables, after a DML changed them.
+func (rc *resultCache) invalidate(tables []string) {
+	if rc == nil || len(tables) == 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, table := range tables {
+		rc.dmls[table]++
+	}
+}
+
+// invalidateKeyspaces drops the cached results of the tables of the given keyspaces, after
+// a transaction on them was committed.
+func (rc *resultCache) invalidateKeyspaces(keyspaces []string) {
+	if rc == nil || len(keyspaces) == 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, ks := range keyspaces {
+		rc.commits[ks]++
+	}
+}
+
+func (rc *resultCache) close() {
+	if rc != nil {
+		rc.entries.Close()
+	}
+}
+
+func tablesOf(tvs []tableVersion) []string {
+	tables := make([]string, 0, len(tvs))
+	for _, tv := range tvs {
+		tables = append(tables, tv.table)
+	}
+	return tables
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+type fakeTableVersions map[string]int64
+
+func (f fakeTableVersions) Tables(string) map[string]*vindexes.TableInfo { return nil }
+
+func (f fakeTableVersions) Views(string) map[string]sqlparser.SelectStatement { return nil }
+
+func (f fakeTableVersions) UDFs(string) []string { return nil }
+
+func (f fakeTableVersions) TableVersion(ks string, tbl string) int64 {
+	return f[ks+"."+tbl]
+}
+
+func TestResultCacheAccepts(t *testing.T) {
+	testCases := []struct {
+		query string
+		optIn bool
+		all   bool
+	}{
+		{query: "select * from user", optIn: false, all: true},
+		{query: "select /*vt+ RESULT_CACHE=ON */ * from user", optIn: true, all: true},
+		{query: "select /*vt+ RESULT_CACHE=OFF */ * from user", optIn: false, all: false},
+		{query: "select * from user union select * from music", optIn: false, all: true},
+		{query: "select now(), id from user", optIn: false, all: false},
+		{query: "select /*vt+ RESULT_CACHE=ON */ rand() from user", optIn: true, all: true},
+		{query: "select /*vt+ RESULT_CACHE=ON */ * from user for update", optIn: false, all: false},
+		{query: "select /*vt+ RESULT_CACHE=ON */ * from user into outfile 'x.txt'", optIn: false, all: false},
+		{query: "select /*vt+ RESULT_CACHE=ON */ sql_calc_found_rows * from user", optIn: false, all: false},
+		{query: "select get_lock('a', 1) from dual", optIn: false, all: false},
+		{query: "update user set a = 1", optIn: false, all: false},
+	}
+
+	optIn := newResultCache(1024, time.Minute, 0, true, nil)
+	defer optIn.close()
+	all := newResultCache(1024, time.Minute, 0, false, nil)
+	defer all.close()
+	parser := sqlparser.NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.optIn, optIn.accepts(stmt), "opt-in")
+			assert.Equal(t, tc.all, all.accepts(stmt), "all")
+		})
+	}
+
+	var disabled *resultCache
+	assert.Nil(t, newResultCache(0, time.Minute, 0, true, nil))
+	assert.False(t, disabled.accepts(&sqlparser.Select{}))
+}
+
+func TestExecutorResultCache(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+	schema := fakeTableVersions{}
+	executor.resultCache = newResultCache(1024*1024, time.Minute, 0, true, schema)
+
+	hits := resultCacheHits.Get()
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	query := "select /*vt+ RESULT_CACHE=ON */ id from user where id = 1"
+	exec := func(sql string, bindVars map[string]*querypb.BindVariable, shardResult string) string {
+		t.Helper()
+		sbc1.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), shardResult)})
+		qr, err := executorExec(ctx, executor, session, sql, bindVars)
+		require.NoError(t, err)
+		require.Len(t, qr.Rows, 1)
+		return qr.Rows[0][0].ToString()
+	}
+
+	assert.Equal(t, "1", exec(query, nil, "1"))
+	assert.Equal(t, "1", exec(query, nil, "2"))
+
+	// The queries without the directive are not cached.
+	assert.Equal(t, "3", exec("select id from user where id = 1", nil, "3"))
+
+	// The results of other bind variables are cached separately.
+	assert.Equal(t, "4", exec(query, map[string]*querypb.BindVariable{"extra": sqltypes.Int64BindVariable(1)}, "4"))
+	assert.Equal(t, "1", exec(query, nil, "5"))
+
+	// A DML executed through the vtgate invalidates the results of its table.
+	_, err := executorExec(ctx, executor, session, "update user set a = 1 where id = 1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "6", exec(query, nil, "6"))
+	assert.Equal(t, "6", exec(query, nil, "7"))
+
+	// So does a change of its schema.
+	schema["TestExecutor.user"] = 1
+	assert.Equal(t, "8", exec(query, nil, "8"))
+
+	// The results read in a transaction are neither served from nor stored in the cache.
+	session.InTransaction = true
+	assert.Equal(t, "9", exec(query, nil, "9"))
+	session.InTransaction = false
+	assert.Equal(t, "8", exec(query, nil, "10"))
+	assert.EqualValues(t, 4, resultCacheHits.Get()-hits)
+
+	// The results of other callers are cached separately, since the tablets may enforce table ACLs.
+	callerCtx := ctx
+	ctx = callerid.NewContext(callerCtx, nil, callerid.NewImmediateCallerID("other"))
+	assert.Equal(t, "11", exec(query, nil, "11"))
+	ctx = callerid.NewContext(callerCtx, callerid.NewEffectiveCallerID("other", "", ""), nil)
+	assert.Equal(t, "12", exec(query, nil, "12"))
+	ctx = callerCtx
+	assert.Equal(t, "8", exec(query, nil, "13"))
+
+	// A DML executed in a transaction invalidates the results again once it is committed,
+	// since the results cached by the other sessions before don't see its changes.
+	txSession := &vtgatepb.Session{TargetString: "@primary"}
+	for _, sql := range []string{"begin", "update user set a = 1 where id = 1"} {
+		_, err = executorExec(ctx, executor, txSession, sql, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "14", exec(query, nil, "14"))
+	assert.Equal(t, "14", exec(query, nil, "15"))
+	_, err = executorExec(ctx, executor, txSession, "commit", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "16", exec(query, nil, "16"))
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// rowPolicies enforces the row policies of the tables used by a statement.
+type rowPolicies struct {
+	ctx      context.Context
+	e        *Executor
+	vcursor  *vcursorImpl
+	bindVars map[string]*querypb.BindVariable
+
+	// rewritten is true if predicates were added to the statement.
+	rewritten bool
+}
+
+// applyRowPolicies enforces the row policies of the tables used by the statement:
+// their predicate is added to the statement where it reads, updates or deletes their rows,
+// and the rows it inserts or the values it sets are checked against them.
+// It returns true if the statement was rewritten.
+func (e *Executor) applyRowPolicies(ctx context.Context, vcursor *vcursorImpl, stmt sqlparser.Statement, bindVars map[string]*querypb.BindVariable) (bool, error) {
+	switch stmt.(type) {
+	case sqlparser.SelectStatement, *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete:
+	default:
+		return false, nil
+	}
+
+	rp := &rowPolicies{ctx: ctx, e: e, vcursor: vcursor, bindVars: bindVars}
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			return true, rp.addPredicates(node.From, node.AddWhere)
+		case *sqlparser.Update:
+			if err := rp.checkUpdate(node); err != nil {
+				return false, err
+			}
+			return true, rp.addPredicates(node.TableExprs, node.AddWhere)
+		case *sqlparser.Delete:
+			return true, rp.addPredicates(node.TableExprs, node.AddWhere)
+		case *sqlparser.Insert:
+			return true, rp.checkInsert(node)
+		}
+		return true, nil
+	}, stmt)
+	return rp.rewritten, err
+}
+
+// findTable returns the table of the given name if it has a row policy, after setting
+// the bind variables of the attributes used by the policy. Any value sent by the client
+// for these bind variables is overwritten.
+func (rp *rowPolicies) findTable(name sqlparser.TableName) (*vindexes.Table, error) {
+	table, err := rp.vcursor.FindRoutedTable(name)
+	if err != nil || table == nil || table.RowPolicy == nil {
+		// The planner reports the unknown tables.
+		return nil, nil
+	}
+	for _, attr := range table.RowPolicy.Attributes {
+		value, ok := callerAttribute(rp.ctx, attr)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the row policy of table %s needs the attribute %s of the caller", table.Name, attr)
+		}
+		if rp.bindVars != nil {
+			rp.bindVars[vindexes.RowPolicyAttributeVar(attr)] = sqltypes.StringBindVariable(value)
+		}
+	}
+	return table, nil
+}
+
+// callerAttribute returns the value of an attribute of the immediate caller, whose identity
+// is set by vtgate once the client is authenticated, and can't be set by the client itself:
+// the username for the attribute user, and the value of the group name=value otherwise.
+func callerAttribute(ctx context.Context, name string) (string, bool) {
+	caller := callerid.ImmediateCallerIDFromContext(ctx)
+	if caller == nil {
+		return "", false
+	}
+	if name == "user" {
+		return caller.Username, caller.Username != ""
+	}
+	for _, group := range caller.Groups {
+		if key, value, ok := strings.Cut(group, "="); ok && strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// addPredicates adds the predicates of the tables with a row policy in exprs, to the
+// conditions of the outer joins of which they are the inner side, and to the where
+// clause through addWhere otherwise.
+func (rp *rowPolicies) addPredicates(exprs []sqlparser.TableExpr, addWhere func(sqlparser.Expr)) error {
+	preds, err := rp.predicates(exprs)
+	if err != nil {
+		return err
+	}
+	for _, pred := range preds {
+		addWhere(pred)
+	}
+	return nil
+}
+
+func (rp *rowPolicies) predicates(exprs []sqlparser.TableExpr) ([]sqlparser.Expr, error) {
+	var preds []sqlparser.Expr
+	for _, expr := range exprs {
+		exprPreds, err := rp.tableExprPredicates(expr)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, exprPreds...)
+	}
+	return preds, nil
+}
+
+func (rp *rowPolicies) tableExprPredicates(expr sqlparser.TableExpr) ([]sqlparser.Expr, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		name, ok := expr.Expr.(sqlparser.TableName)
+		if !ok {
+			return nil, nil
+		}
+		table, err := rp.findTable(name)
+		if err != nil || table == nil {
+			return nil, err
+		}
+		if !expr.As.IsEmpty() {
+			name = sqlparser.TableName{Name: expr.As}
+		}
+		rp.rewritten = true
+		return []sqlparser.Expr{table.RowPolicy.Predicate(name)}, nil
+	case *sqlparser.ParenTableExpr:
+		return rp.predicates(expr.Exprs)
+	case *sqlparser.JoinTableExpr:
+		left, err := rp.tableExprPredicates(expr.LeftExpr)
+		if err != nil {
+			return nil, err
+		}
+		right, err := rp.tableExprPredicates(expr.RightExpr)
+		if err != nil {
+			return nil, err
+		}
+		switch expr.Join {
+		case sqlparser.LeftJoinType, sqlparser.NaturalLeftJoinType:
+			return left, addToJoinCondition(expr, right)
+		case sqlparser.RightJoinType, sqlparser.NaturalRightJoinType:
+			return right, addToJoinCondition(expr, left)
+		}
+		return append(left, right...), nil
+	}
+	return nil, nil
+}
+
+// addToJoinCondition adds the predicates of the tables of the inner side of an outer join to its
+// condition, so that the rows of the outer side are still returned when they have no visible match.
+func addToJoinCondition(join *sqlparser.JoinTableExpr, preds []sqlparser.Expr) error {
+	if len(preds) == 0 {
+		return nil
+	}
+	if join.Condition == nil || join.Condition.On == nil {
+		return vterrors.VT12001("outer join without ON condition on tables with a row policy")
+	}
+	join.Condition.On = sqlparser.AndExpressions(append([]sqlparser.Expr{join.Condition.On}, preds...)...)
+	return nil
+}
+
+// checkInsert checks the inserted rows, and the values set on duplicate keys, against the row policy of the table.
+func (rp *rowPolicies) checkInsert(ins *sqlparser.Insert) error {
+	name, err := ins.Table.TableName()
+	if err != nil {
+		return nil
+	}
+	table, err := rp.findTable(name)
+	if err != nil || table == nil {
+		return err
+	}
+
+	rows, ok := ins.Rows.(sqlparser.Values)
+	if !ok {
+		return vterrors.VT12001(fmt.Sprintf("INSERT with a SELECT into table %s with a row policy", table.Name))
+	}
+	columns := ins.Columns
+	if len(columns) == 0 {
+		if !table.ColumnListAuthoritative {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the INSERT into table %s with a row policy must list its columns", table.Name)
+		}
+		for _, col := range table.Columns {
+			if !col.Invisible {
+				columns = append(columns, col.Name)
+			}
+		}
+	}
+	for _, row := range rows {
+		values := make(map[string]sqlparser.Expr, len(columns))
+		for i, col := range columns {
+			if i < len(row) {
+				values[col.Lowered()] = row[i]
+			}
+		}
+		if err := rp.check(table, values); err != nil {
+			return err
+		}
+	}
+
+	if !ins.Table.As.IsEmpty() {
+		name = sqlparser.TableName{Name: ins.Table.As}
+	}
+	return rp.checkAssignments(table, name, sqlparser.UpdateExprs(ins.OnDup))
+}
+
+// checkUpdate checks the values set by the update against the row policies of the tables.
+func (rp *rowPolicies) checkUpdate(upd *sqlparser.Update) error {
+	return sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr:
+			name, ok := node.Expr.(sqlparser.TableName)
+			if !ok {
+				return false, nil
+			}
+			table, err := rp.findTable(name)
+			if err != nil || table == nil {
+				return false, err
+			}
+			if !node.As.IsEmpty() {
+				name = sqlparser.TableName{Name: node.As}
+			}
+			return false, rp.checkAssignments(table, name, upd.Exprs)
+		case sqlparser.TableExprs, sqlparser.TableExpr:
+			return true, nil
+		}
+		return false, nil
+	}, sqlparser.TableExprs(upd.TableExprs))
+}
+
+// checkAssignments checks the values assigned to the columns used by the row policy of the table.
+func (rp *rowPolicies) checkAssignments(table *vindexes.Table, name sqlparser.TableName, exprs sqlparser.UpdateExprs) error {
+	values := make(map[string]sqlparser.Expr)
+	assigned := false
+	for _, expr := range exprs {
+		if !expr.Name.Qualifier.IsEmpty() && expr.Name.Qualifier.Name != name.Name {
+			continue
+		}
+		if slices.ContainsFunc(table.RowPolicy.Columns, expr.Name.Name.Equal) {
+			assigned = true
+		}
+		values[expr.Name.Name.Lowered()] = expr.Expr
+	}
+	if !assigned {
+		return nil
+	}
+	return rp.check(table, values)
+}
+
+// check evaluates the row policy of the table for a row with the given values.
+func (rp *rowPolicies) check(table *vindexes.Table, values map[string]sqlparser.Expr) error {
+	var missing []string
+	expr := table.RowPolicy.Rewrite(
+		func(col sqlparser.IdentifierCI) sqlparser.Expr {
+			value, ok := values[col.Lowered()]
+			if !ok {
+				missing = append(missing, col.String())
+				return sqlparser.NewColName(col.String())
+			}
+			return value
+		},
+		func(name string) sqlparser.Expr {
+			return sqlparser.NewArgument(vindexes.RowPolicyAttributeVar(name))
+		},
+	)
+	if len(missing) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the row policy of table %s needs the value of column %s", table.Name, missing[0])
+	}
+
+	evalExpr, err := evalengine.Translate(expr, &evalengine.Config{
+		Collation:   rp.vcursor.collation,
+		Environment: rp.e.env,
+		SQLMode:     evalengine.ParseSQLMode(rp.vcursor.SQLMode()),
+	})
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot verify the row policy of table %s", table.Name)
+	}
+	bindVars := maps.Clone(rp.bindVars)
+	if bindVars == nil {
+		bindVars = make(map[string]*querypb.BindVariable)
+	}
+	for _, attr := range table.RowPolicy.Attributes {
+		value, _ := callerAttribute(rp.ctx, attr)
+		bindVars[vindexes.RowPolicyAttributeVar(attr)] = sqltypes.StringBindVariable(value)
+	}
+	result, err := evalengine.NewExpressionEnv(rp.ctx, bindVars, rp.vcursor).Evaluate(evalExpr)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot verify the row policy of table %s", table.Name)
+	}
+	if !result.ToBoolean() {
+		return vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "the row violates the row policy of table %s", table.Name)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestExecutorRowPolicies(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	rowPolicy, err := vindexes.BuildRowPolicy("tenant_id = attribute('tenant')", executor.env.Parser())
+	require.NoError(t, err)
+	executor.VSchema().Keyspaces[KsTestUnsharded].Tables["simple"].RowPolicy = rowPolicy
+
+	session := &vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true}
+	_, err = executorExec(ctx, executor, session, "select id from simple", nil)
+	require.EqualError(t, err, "the row policy of table simple needs the attribute tenant of the caller")
+
+	ctx = callerid.NewContext(ctx, nil, &querypb.VTGateCallerID{Username: "u1", Groups: []string{"readers", "tenant=42"}})
+	tenant := sqltypes.StringBindVariable("42")
+	tests := []struct {
+		query string
+		want  string
+		err   string
+	}{{
+		query: "select id from simple where id = 1",
+		want:  "select id from `simple` where id = 1 and `simple`.tenant_id = :__vtattr_tenant",
+	}, {
+		query: "select s.id from zip_detail as z left join simple as s on z.id = s.id",
+		want:  "select s.id from zip_detail as z left join `simple` as s on z.id = s.id and s.tenant_id = :__vtattr_tenant",
+	}, {
+		query: "select id from zip_detail where id in (select id from simple)",
+		want:  "select id from zip_detail where id in (select id from `simple` where `simple`.tenant_id = :__vtattr_tenant)",
+	}, {
+		query: "update simple set name = 'x' where id = 1",
+		want:  "update `simple` set `name` = 'x' where id = 1 and `simple`.tenant_id = :__vtattr_tenant",
+	}, {
+		query: "delete from simple",
+		want:  "delete from `simple` where `simple`.tenant_id = :__vtattr_tenant",
+	}, {
+		query: "insert into simple(id, tenant_id) values (1, 42), (2, 40 + 2)",
+		want:  "insert into `simple`(id, tenant_id) values (1, 42), (2, 40 + 2)",
+	}, {
+		query: "insert into simple(id, tenant_id) values (1, 42), (2, 43)",
+		err:   "the row violates the row policy of table simple",
+	}, {
+		query: "insert into simple(id) values (1)",
+		err:   "the row policy of table simple needs the value of column tenant_id",
+	}, {
+		query: "insert into simple(id, tenant_id) select id, tenant_id from zip_detail",
+		err:   "VT12001: unsupported: INSERT with a SELECT into table simple with a row policy",
+	}, {
+		query: "update simple set tenant_id = 43",
+		err:   "the row violates the row policy of table simple",
+	}, {
+		query: "update simple set tenant_id = id",
+		err:   "cannot verify the row policy of table simple",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			sbclookup.Queries = nil
+			_, err := executorExec(ctx, executor, session, tt.query, nil)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				assert.Empty(t, sbclookup.Queries)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sbclookup.Queries, 1)
+			assert.Equal(t, tt.want, sbclookup.Queries[0].Sql)
+			if bv, ok := sbclookup.Queries[0].BindVariables["__vtattr_tenant"]; ok {
+				assert.Equal(t, tenant, bv)
+			}
+		})
+	}
+}
+
+func TestExecutorRowPoliciesPerCaller(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	rowPolicy, err := vindexes.BuildRowPolicy("tenant_id = attribute('tenant') or owner = attribute('user')", executor.env.Parser())
+	require.NoError(t, err)
+	executor.VSchema().Keyspaces[KsTestUnsharded].Tables["simple"].RowPolicy = rowPolicy
+
+	// The cached plan reads the attributes of each caller.
+	for _, tenant := range []string{"1", "2"} {
+		ctx := callerid.NewContext(ctx, nil, &querypb.VTGateCallerID{Username: "u" + tenant, Groups: []string{"tenant=" + tenant}})
+		session := &vtgatepb.Session{TargetString: KsTestUnsharded}
+		sbclookup.Queries = nil
+		_, err = executorExec(ctx, executor, session, "select id from simple", nil)
+		require.NoError(t, err)
+		require.Len(t, sbclookup.Queries, 1)
+		assert.Equal(t, "select id from `simple` where `simple`.tenant_id = :__vtattr_tenant or `simple`.owner = :__vtattr_user", sbclookup.Queries[0].Sql)
+		assert.Equal(t, sqltypes.StringBindVariable(tenant), sbclookup.Queries[0].BindVariables["__vtattr_tenant"])
+		assert.Equal(t, sqltypes.StringBindVariable("u"+tenant), sbclookup.Queries[0].BindVariables["__vtattr_user"])
+	}
+}
+
+func TestExecutorRowPoliciesSpoofedAttributes(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	rowPolicy, err := vindexes.BuildRowPolicy("tenant_id = attribute('tenant')", executor.env.Parser())
+	require.NoError(t, err)
+	executor.VSchema().Keyspaces[KsTestUnsharded].Tables["simple"].RowPolicy = rowPolicy
+
+	// The client sets the attribute as a user defined variable, a bind variable and an
+	// effective caller, but is still limited to the rows of its own tenant.
+	ctx = callerid.NewContext(ctx, callerid.NewEffectiveCallerID("u2", "", ""), &querypb.VTGateCallerID{Username: "u1", Groups: []string{"tenant=1"}})
+	session := &vtgatepb.Session{
+		TargetString:         KsTestUnsharded,
+		UserDefinedVariables: map[string]*querypb.BindVariable{"tenant": sqltypes.Int64BindVariable(2)},
+	}
+	bindVars := map[string]*querypb.BindVariable{"__vtattr_tenant": sqltypes.Int64BindVariable(2)}
+	_, err = executorExec(ctx, executor, session, "select id from simple", bindVars)
+	require.NoError(t, err)
+	require.Len(t, sbclookup.Queries, 1)
+	assert.Equal(t, "select id from `simple` where `simple`.tenant_id = :__vtattr_tenant", sbclookup.Queries[0].Sql)
+	assert.Equal(t, sqltypes.StringBindVariable("1"), sbclookup.Queries[0].BindVariables["__vtattr_tenant"])
+
+	_, err = executorExec(ctx, executor, session, "insert into simple(id, tenant_id) values (1, 2)", bindVars)
+	require.ErrorContains(t, err, "the row violates the row policy of table simple")
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package vtgate
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -26,6 +28,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
@@ -65,6 +69,7 @@ type (
 		queryFromVindex bool
 
 		logging *executeLogger
+		tracing *primitiveTracer
 
 		*vtgatepb.Session
 	}
@@ -74,6 +79,13 @@ type (
 		entries []engine.ExecuteEntry
 		lastID  int
 		parser  *sqlparser.Parser
+		memory  map[engine.Primitive]*engine.MemoryUsage
+	}
+
+	// primitiveTracer collects the execution statistics of the primitives for VEXPLAIN TRACE.
+	primitiveTracer struct {
+		mu    sync.Mutex
+		stats map[engine.Primitive]*engine.PrimitiveStats
 	}
 
 	// autocommitState keeps track of whether a single round-trip
@@ -198,6 +210,7 @@ func (session *SafeSession) resetCommonLocked() {
 	session.Session.InTransaction = false
 	session.commitOrder = vtgatepb.CommitOrder_NORMAL
 	session.Savepoints = nil
+	session.SnapshotGtids = nil
 	if session.Options != nil {
 		session.Options.TransactionAccessMode = nil
 	}
@@ -607,6 +620,13 @@ func (session *SafeSession) StoreSavepoint(sql string) {
 	session.Savepoints = append(session.Savepoints, sql)
 }
 
+// SetSavepoints replaces the savepoint queries stored in the session
+func (session *SafeSession) SetSavepoints(savepoints []string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.Savepoints = savepoints
+}
+
 // InReservedConn returns true if the session needs to execute on a dedicated connection
 func (session *SafeSession) InReservedConn() bool {
 	session.mu.Lock()
@@ -772,6 +792,38 @@ func (session *SafeSession) GetSessionEnableSystemSettings() bool {
 	return session.EnableSystemSettings
 }
 
+// SetScatterErrorsAsWarnings sets the ScatterErrorsAsWarnings setting.
+func (session *SafeSession) SetScatterErrorsAsWarnings(enabled bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.ScatterErrorsAsWarnings = enabled
+}
+
+// GetScatterErrorsAsWarnings returns the ScatterErrorsAsWarnings value.
+func (session *SafeSession) GetScatterErrorsAsWarnings() bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ScatterErrorsAsWarnings
+}
+
+// SetTempTable records that the temporary table exists, or not anymore, in the sharded keyspace.
+func (session *SafeSession) SetTempTable(keyspace, table string, exists bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	name := keyspace + "." + table
+	session.TempTables = slices.DeleteFunc(session.TempTables, func(t string) bool { return t == name })
+	if exists {
+		session.TempTables = append(session.TempTables, name)
+	}
+}
+
+// HasTempTable returns true if the session created the temporary table in the sharded keyspace.
+func (session *SafeSession) HasTempTable(keyspace, table string) bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return slices.Contains(session.TempTables, keyspace+"."+table)
+}
+
 // SetReadAfterWriteGTID set the ReadAfterWriteGtid setting.
 func (session *SafeSession) SetReadAfterWriteGTID(vtgtid string) {
 	session.mu.Lock()
@@ -802,6 +854,91 @@ func (session *SafeSession) SetSessionTrackGtids(enable bool) {
 	session.ReadAfterWrite.SessionTrackGtids = enable
 }
 
+// SetReadYourWrites sets the read-your-writes consistency of the session.
+// Disabling it forgets the writes recorded so far.
+func (session *SafeSession) SetReadYourWrites(enable bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.ReadAfterWrite == nil {
+		session.ReadAfterWrite = &vtgatepb.ReadAfterWrite{}
+	}
+	session.ReadAfterWrite.ReadYourWrites = enable
+	if !enable {
+		session.ReadAfterWrite.ShardGtids = nil
+	}
+}
+
+// GetReadYourWrites returns whether the session has read-your-writes consistency.
+func (session *SafeSession) GetReadYourWrites() bool {
+	if session == nil {
+		return false
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.GetReadAfterWrite().GetReadYourWrites()
+}
+
+// RecordShardGTIDs adds the GTIDs of a write of the session to the ones already
+// recorded for its keyspace and shard.
+func (session *SafeSession) RecordShardGTIDs(keyspace, shard string, gtids string) error {
+	added, err := replication.ParseMysql56GTIDSet(gtids)
+	if err != nil {
+		return err
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.ReadAfterWrite == nil {
+		session.ReadAfterWrite = &vtgatepb.ReadAfterWrite{}
+	}
+	if session.ReadAfterWrite.ShardGtids == nil {
+		session.ReadAfterWrite.ShardGtids = make(map[string]string)
+	}
+	key := keyspace + "/" + shard
+	var union replication.GTIDSet = added
+	if recorded, ok := session.ReadAfterWrite.ShardGtids[key]; ok {
+		if set, err := replication.ParseMysql56GTIDSet(recorded); err == nil {
+			union = set.Union(added)
+		}
+	}
+	session.ReadAfterWrite.ShardGtids[key] = union.String()
+	return nil
+}
+
+// RecordSnapshotGTIDs records the GTID set of the snapshot of the transaction of
+// the session in a keyspace and shard.
+func (session *SafeSession) RecordSnapshotGTIDs(keyspace, shard string, gtids string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.SnapshotGtids == nil {
+		session.SnapshotGtids = make(map[string]string)
+	}
+	session.SnapshotGtids[keyspace+"/"+shard] = gtids
+}
+
+// SnapshotGTIDsJSON returns the GTID sets of the snapshots of the transaction of
+// the session as a JSON object by keyspace/shard, or an empty string if there
+// are none.
+func (session *SafeSession) SnapshotGTIDsJSON() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if len(session.SnapshotGtids) == 0 {
+		return ""
+	}
+	// The keys of the maps are sorted by encoding/json.
+	b, err := json.Marshal(session.SnapshotGtids)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// GetShardGTIDs returns the GTIDs of the writes of the session in a keyspace and shard.
+func (session *SafeSession) GetShardGTIDs(keyspace, shard string) string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.GetReadAfterWrite().GetShardGtids()[keyspace+"/"+shard]
+}
+
 func removeShard(tabletAlias *topodatapb.TabletAlias, sessions []*vtgatepb.Session_ShardSession) ([]*vtgatepb.Session_ShardSession, error) {
 	idx := -1
 	for i, session := range sessions {
@@ -951,6 +1088,16 @@ func (session *SafeSession) EnableLogging(parser *sqlparser.Parser) {
 	}
 }
 
+// EnableTracing enables the collection of the execution statistics of the primitives.
+func (session *SafeSession) EnableTracing() {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	session.tracing = &primitiveTracer{
+		stats: make(map[engine.Primitive]*engine.PrimitiveStats),
+	}
+}
+
 // GetUDV returns the bind variable value for the user defined variable.
 func (session *SafeSession) GetUDV(name string) *querypb.BindVariable {
 	session.mu.Lock()
@@ -1030,3 +1177,81 @@ func (l *executeLogger) GetLogs() []engine.ExecuteEntry {
 	copy(result, l.entries)
 	return result
 }
+
+// recordMemoryUsage adds the memory used by an execution of the primitive to its memory usage.
+func (l *executeLogger) recordMemoryUsage(primitive engine.Primitive, usage engine.MemoryUsage) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.memory == nil {
+		l.memory = make(map[engine.Primitive]*engine.MemoryUsage)
+	}
+	mu := l.memory[primitive]
+	if mu == nil {
+		mu = &engine.MemoryUsage{}
+		l.memory[primitive] = mu
+	}
+	mu.Merge(usage)
+}
+
+// GetMemoryUsage returns a copy of the memory usage of the primitives recorded so far.
+func (l *executeLogger) GetMemoryUsage() map[engine.Primitive]*engine.MemoryUsage {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make(map[engine.Primitive]*engine.MemoryUsage, len(l.memory))
+	for primitive, usage := range l.memory {
+		usageCopy := *usage
+		result[primitive] = &usageCopy
+	}
+	return result
+}
+
+// record adds an execution of the primitive, which returned the given number and size of rows, to its statistics.
+func (t *primitiveTracer) record(primitive engine.Primitive, start time.Time, rows int, bytes int64) {
+	if t == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := t.stats[primitive]
+	if stats == nil {
+		stats = &engine.PrimitiveStats{}
+		t.stats[primitive] = stats
+	}
+	stats.Calls++
+	stats.Time += elapsed
+	stats.Rows += rows
+	stats.Bytes += bytes
+}
+
+// GetStats returns a copy of the statistics collected so far.
+func (t *primitiveTracer) GetStats() map[engine.Primitive]*engine.PrimitiveStats {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make(map[engine.Primitive]*engine.PrimitiveStats, len(t.stats))
+	for primitive, stats := range t.stats {
+		statsCopy := *stats
+		result[primitive] = &statsCopy
+	}
+	return result
+}
+
+// rowsSize returns the size of the values of the rows.
+func rowsSize(rows []sqltypes.Row) int64 {
+	var size int64
+	for _, row := range rows {
+		for _, value := range row {
+			size += int64(value.Len())
+		}
+	}
+	return size
+}
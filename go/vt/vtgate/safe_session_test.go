@@ -98,3 +98,23 @@ func TestTimeZone(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordShardGTIDs(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetReadYourWrites(true)
+	assert.True(t, session.GetReadYourWrites())
+
+	require.NoError(t, session.RecordShardGTIDs("ks", "-80", "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"))
+	require.NoError(t, session.RecordShardGTIDs("ks", "-80", "3e11fa47-71ca-11e1-9e33-c80aa9429562:6,8e11fa47-71ca-11e1-9e33-c80aa9429562:1"))
+	require.NoError(t, session.RecordShardGTIDs("ks", "80-", "3e11fa47-71ca-11e1-9e33-c80aa9429562:7"))
+	require.Error(t, session.RecordShardGTIDs("ks", "80-", "not a gtid"))
+
+	assert.Equal(t, "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-6,8e11fa47-71ca-11e1-9e33-c80aa9429562:1", session.GetShardGTIDs("ks", "-80"))
+	assert.Equal(t, "3e11fa47-71ca-11e1-9e33-c80aa9429562:7", session.GetShardGTIDs("ks", "80-"))
+	assert.Empty(t, session.GetShardGTIDs("other", "-80"))
+
+	// Going back to eventual consistency forgets the writes.
+	session.SetReadYourWrites(false)
+	assert.False(t, session.GetReadYourWrites())
+	assert.Empty(t, session.GetShardGTIDs("ks", "-80"))
+}
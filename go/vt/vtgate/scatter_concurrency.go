@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// scatterConcurrency limits the number of shard queries of scatter queries
+// that are in flight at the same time, for each keyspace. The streaming shard
+// queries aren't limited, since they stay open while the rest of the plan runs.
+type scatterConcurrency struct {
+	// max is the maximum concurrency of the shard queries of a keyspace.
+	// It can be changed at runtime by the dynamic configuration of vtgate.
+	max              atomic.Int64
+	adaptive         bool
+	latencyThreshold time.Duration
+
+	limits *stats.GaugesWithSingleLabel
+	waits  *stats.CountersWithSingleLabel
+
+	mu        sync.Mutex
+	keyspaces map[string]*keyspaceConcurrency
+}
+
+// keyspaceConcurrency is the concurrency limit of the scatter queries of a keyspace.
+// When the limit is adaptive, it follows an additive increase, multiplicative decrease
+// scheme: it is halved when a shard query fails because the tablet is overloaded or
+// is slower than the latency threshold, and grows back by one every limit successful
+// shard queries.
+type keyspaceConcurrency struct {
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// released is closed and replaced each time a shard query finishes,
+	// to wake up the shard queries waiting for their turn.
+	released chan struct{}
+}
+
+// newScatterConcurrency returns the concurrency limiter of the scatter queries,
+// or nil if their concurrency isn't limited.
+func newScatterConcurrency(statsName string, max int, adaptive bool, latencyThreshold time.Duration) *scatterConcurrency {
+	if max <= 0 {
+		return nil
+	}
+	limitsStatsName, waitsStatsName := "", ""
+	if statsName != "" {
+		limitsStatsName = statsName + "ScatterConcurrencyLimit"
+		waitsStatsName = statsName + "ScatterConcurrencyWaits"
+	}
+	sc := &scatterConcurrency{
+		adaptive:         adaptive,
+		latencyThreshold: latencyThreshold,
+		limits:           stats.NewGaugesWithSingleLabel(limitsStatsName, "Concurrency limit of the shard queries of scatter queries", "Keyspace"),
+		waits:            stats.NewCountersWithSingleLabel(waitsStatsName, "Number of shard queries of scatter queries that waited for the concurrency limit", "Keyspace"),
+		keyspaces:        make(map[string]*keyspaceConcurrency),
+	}
+	sc.max.Store(int64(max))
+	return sc
+}
+
+// setMax changes the maximum concurrency of the shard queries of the keyspaces.
+// An adaptive limit above the new maximum is lowered to it, and grows back to it
+// otherwise.
+func (sc *scatterConcurrency) setMax(max int) {
+	sc.max.Store(int64(max))
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	for keyspace, kc := range sc.keyspaces {
+		kc.mu.Lock()
+		if !sc.adaptive || kc.limit > float64(max) {
+			kc.limit = float64(max)
+		}
+		// Wake up the shard queries waiting for their turn, as they may
+		// fit in the new limit.
+		close(kc.released)
+		kc.released = make(chan struct{})
+		sc.limits.Set(keyspace, int64(kc.limit))
+		kc.mu.Unlock()
+	}
+}
+
+func (sc *scatterConcurrency) forKeyspace(keyspace string) *keyspaceConcurrency {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	kc, ok := sc.keyspaces[keyspace]
+	if !ok {
+		max := sc.max.Load()
+		kc = &keyspaceConcurrency{
+			limit:    float64(max),
+			released: make(chan struct{}),
+		}
+		sc.keyspaces[keyspace] = kc
+		sc.limits.Set(keyspace, max)
+	}
+	return kc
+}
+
+// acquire waits until a shard query of the keyspace can be sent.
+// The returned function must be called with the outcome of the shard query once it is done.
+func (sc *scatterConcurrency) acquire(ctx context.Context, keyspace string) (func(err error, latency time.Duration), error) {
+	kc := sc.forKeyspace(keyspace)
+	waited := false
+	for {
+		kc.mu.Lock()
+		if kc.inFlight < int(kc.limit) {
+			kc.inFlight++
+			kc.mu.Unlock()
+			break
+		}
+		released := kc.released
+		kc.mu.Unlock()
+
+		if !waited {
+			waited = true
+			sc.waits.Add(keyspace, 1)
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, vterrors.Errorf(vtrpcpb.Code_DEADLINE_EXCEEDED, "waiting for the scatter concurrency limit of keyspace %s: %v", keyspace, ctx.Err())
+		}
+	}
+	return func(err error, latency time.Duration) {
+		sc.release(kc, keyspace, err, latency)
+	}, nil
+}
+
+func (sc *scatterConcurrency) release(kc *keyspaceConcurrency, keyspace string, err error, latency time.Duration) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	kc.inFlight--
+	close(kc.released)
+	kc.released = make(chan struct{})
+
+	if !sc.adaptive {
+		return
+	}
+	if isOverloadError(err) || (sc.latencyThreshold > 0 && latency > sc.latencyThreshold) {
+		kc.limit = max(kc.limit/2, 1)
+	} else if err == nil {
+		kc.limit = min(kc.limit+1/kc.limit, float64(sc.max.Load()))
+	}
+	sc.limits.Set(keyspace, int64(kc.limit))
+}
+
+// isOverloadError returns true for the errors of the shards that are too busy to answer,
+// as opposed to the errors caused by the query itself.
+func isOverloadError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_UNAVAILABLE, vtrpcpb.Code_RESOURCE_EXHAUSTED, vtrpcpb.Code_DEADLINE_EXCEEDED:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+func TestScatterConcurrencyDisabled(t *testing.T) {
+	assert.Nil(t, newScatterConcurrency("", 0, true, time.Second))
+}
+
+func TestScatterConcurrencyLimit(t *testing.T) {
+	sc := newScatterConcurrency("", 2, false, 0)
+	ctx := context.Background()
+
+	release1, err := sc.acquire(ctx, "ks")
+	require.NoError(t, err)
+	release2, err := sc.acquire(ctx, "ks")
+	require.NoError(t, err)
+
+	// The limit is per keyspace.
+	releaseOther, err := sc.acquire(ctx, "other")
+	require.NoError(t, err)
+	releaseOther(nil, 0)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := sc.acquire(ctx, "ks")
+		assert.NoError(t, err)
+		release(nil, 0)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired a third slot with a limit of 2")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release1(nil, 0)
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waiting shard query wasn't woken up")
+	}
+	release2(nil, 0)
+	assert.EqualValues(t, 1, sc.waits.Counts()["ks"])
+}
+
+func TestScatterConcurrencyContextDone(t *testing.T) {
+	sc := newScatterConcurrency("", 1, false, 0)
+	release, err := sc.acquire(context.Background(), "ks")
+	require.NoError(t, err)
+	defer release(nil, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = sc.acquire(ctx, "ks")
+	require.ErrorContains(t, err, "waiting for the scatter concurrency limit of keyspace ks")
+	assert.Equal(t, vtrpcpb.Code_DEADLINE_EXCEEDED, vterrors.Code(err))
+}
+
+func TestScatterConcurrencyAdaptive(t *testing.T) {
+	sc := newScatterConcurrency("", 8, true, time.Second)
+	ctx := context.Background()
+	run := func(err error, latency time.Duration) {
+		release, acquireErr := sc.acquire(ctx, "ks")
+		require.NoError(t, acquireErr)
+		release(err, latency)
+	}
+	limit := func() int64 {
+		return sc.limits.Counts()["ks"]
+	}
+
+	run(nil, 0)
+	assert.EqualValues(t, 8, limit())
+
+	// The errors of the query itself don't lower the limit.
+	run(vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "syntax error"), 0)
+	assert.EqualValues(t, 8, limit())
+
+	run(vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "tablet is overloaded"), 0)
+	assert.EqualValues(t, 4, limit())
+	run(nil, 2*time.Second)
+	assert.EqualValues(t, 2, limit())
+	run(vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "pool full"), 0)
+	assert.EqualValues(t, 1, limit())
+	run(vterrors.Errorf(vtrpcpb.Code_DEADLINE_EXCEEDED, "timeout"), 0)
+	assert.EqualValues(t, 1, limit())
+
+	// The limit grows back by one every limit successful shard queries, up to the maximum.
+	for i := 0; i < 100; i++ {
+		run(nil, time.Millisecond)
+	}
+	assert.EqualValues(t, 8, limit())
+}
+
+func TestScatterConcurrencySetMax(t *testing.T) {
+	sc := newScatterConcurrency("", 1, false, 0)
+	ctx := context.Background()
+
+	release1, err := sc.acquire(ctx, "ks")
+	require.NoError(t, err)
+	defer release1(nil, 0)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := sc.acquire(ctx, "ks")
+		assert.NoError(t, err)
+		release(nil, 0)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired a second slot with a limit of 1")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Raising the maximum wakes up the waiting shard query.
+	sc.setMax(2)
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waiting shard query wasn't woken up")
+	}
+	assert.EqualValues(t, 2, sc.limits.Counts()["ks"])
+
+	// The keyspaces seen afterwards start at the new maximum.
+	release2, err := sc.acquire(ctx, "other")
+	require.NoError(t, err)
+	release2(nil, 0)
+	assert.EqualValues(t, 2, sc.limits.Counts()["other"])
+}
+
+func TestScatterConcurrencySetMaxAdaptive(t *testing.T) {
+	sc := newScatterConcurrency("", 8, true, time.Second)
+	release, err := sc.acquire(context.Background(), "ks")
+	require.NoError(t, err)
+	release(vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "tablet is overloaded"), 0)
+	assert.EqualValues(t, 4, sc.limits.Counts()["ks"])
+
+	// An adaptive limit below the new maximum is kept.
+	sc.setMax(6)
+	assert.EqualValues(t, 4, sc.limits.Counts()["ks"])
+
+	// An adaptive limit above the new maximum is lowered to it.
+	sc.setMax(2)
+	assert.EqualValues(t, 2, sc.limits.Counts()["ks"])
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/sqlparser"
 
 	"google.golang.org/protobuf/proto"
@@ -38,6 +39,7 @@ import (
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -53,6 +55,8 @@ type ScatterConn struct {
 	tabletCallErrorCount *stats.CountersWithMultiLabels
 	txConn               *TxConn
 	gateway              *TabletGateway
+	// concurrency limits the shard queries of the scatter queries, if not nil.
+	concurrency *scatterConcurrency
 }
 
 // shardActionFunc defines the contract for a shard action
@@ -70,7 +74,7 @@ type shardActionFunc func(rs *srvtopo.ResolvedShard, i int) error
 // multiGoTransaction is capable of executing multiple
 // shardActionTransactionFunc actions in parallel and consolidating
 // the results and errors for the caller.
-type shardActionTransactionFunc func(rs *srvtopo.ResolvedShard, i int, shardActionInfo *shardActionInfo) (*shardActionInfo, error)
+type shardActionTransactionFunc func(ctx context.Context, rs *srvtopo.ResolvedShard, i int, shardActionInfo *shardActionInfo) (*shardActionInfo, error)
 
 // NewScatterConn creates a new ScatterConn.
 func NewScatterConn(statsName string, txConn *TxConn, gw *TabletGateway) *ScatterConn {
@@ -88,8 +92,9 @@ func NewScatterConn(statsName string, txConn *TxConn, gw *TabletGateway) *Scatte
 			tabletCallErrorCountStatsName,
 			"Error count from tablet calls in scatter conns",
 			[]string{"Operation", "Keyspace", "ShardName", "DbType"}),
-		txConn:  txConn,
-		gateway: gw,
+		txConn:      txConn,
+		gateway:     gw,
+		concurrency: newScatterConcurrency(statsName, scatterMaxConcurrency, scatterAdaptiveConcurrency, scatterAdaptiveConcurrencyLatency),
 	}
 }
 
@@ -152,6 +157,8 @@ func (stc *ScatterConn) ExecuteMultiShard(
 		return nil, []error{vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] got mismatched number of queries and shards")}
 	}
 
+	rss = stc.readYourWrites(rss, session)
+
 	// mu protects qr
 	var mu sync.Mutex
 	qr = new(sqltypes.Result)
@@ -166,7 +173,7 @@ func (stc *ScatterConn) ExecuteMultiShard(
 		rss,
 		session,
 		autocommit,
-		func(rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
+		func(ctx context.Context, rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
 			var (
 				innerqr *sqltypes.Result
 				err     error
@@ -257,18 +264,19 @@ func (stc *ScatterConn) ExecuteMultiShard(
 			if err != nil {
 				return newInfo, err
 			}
+			recordWrite(session, rs.Target, innerqr)
 			mu.Lock()
 			defer mu.Unlock()
 
 			// Don't append more rows if row count is exceeded.
-			if ignoreMaxMemoryRows || len(qr.Rows) <= maxMemoryRows {
+			if ignoreMaxMemoryRows || len(qr.Rows) <= getMaxMemoryRows() {
 				qr.AppendResult(innerqr)
 			}
 			return newInfo, nil
 		},
 	)
 
-	if !ignoreMaxMemoryRows && len(qr.Rows) > maxMemoryRows {
+	if maxMemoryRows := getMaxMemoryRows(); !ignoreMaxMemoryRows && len(qr.Rows) > maxMemoryRows {
 		return nil, []error{vterrors.NewErrorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.NetPacketTooLarge, "in-memory row count exceeded allowed limit of %d", maxMemoryRows)}
 	}
 
@@ -358,6 +366,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 	if session.InLockSession() && session.TriggerLockHeartBeat() {
 		go stc.runLockQuery(ctx, session)
 	}
+	rss = stc.readYourWrites(rss, session)
 
 	allErrors := stc.multiGoTransaction(
 		ctx,
@@ -365,7 +374,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 		rss,
 		session,
 		autocommit,
-		func(rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
+		func(ctx context.Context, rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
 			var (
 				err   error
 				opts  *querypb.ExecuteOptions
@@ -636,16 +645,45 @@ func (stc *ScatterConn) multiGoTransaction(
 	if numShards == 0 {
 		return allErrors
 	}
+	// The streams aren't limited: their callbacks run the rest of the plan,
+	// such as the inner side of a join, which can send shard queries to the
+	// same keyspace and would wait forever for the slots held by the stream.
+	limitConcurrency := numShards > 1 && stc.concurrency != nil && name != "StreamExecute"
 	oneShard := func(rs *srvtopo.ResolvedShard, i int) {
 		var err error
 		startTime, statsKey := stc.startAction(name, rs.Target)
 		defer stc.endAction(startTime, allErrors, statsKey, &err, session)
 
+		span, ctx := trace.NewSpan(ctx, "ScatterConn."+name)
+		defer span.Finish()
+		span.Annotate("keyspace", rs.Target.Keyspace)
+		span.Annotate("shard", rs.Target.Shard)
+		span.Annotate("tablet_type", rs.Target.TabletType.String())
+
+		if logStats := logstats.FromContext(ctx); logStats != nil {
+			shardStart := time.Now()
+			defer func() {
+				logStats.RecordShard(rs.Target, time.Since(shardStart), err)
+			}()
+		}
+
+		if limitConcurrency {
+			var release func(error, time.Duration)
+			release, err = stc.concurrency.acquire(ctx, rs.Target.Keyspace)
+			if err != nil {
+				return
+			}
+			queryStart := time.Now()
+			defer func() {
+				release(err, time.Since(queryStart))
+			}()
+		}
+
 		shardActionInfo, err := actionInfo(ctx, rs.Target, session, autocommit, stc.txConn.mode)
 		if err != nil {
 			return
 		}
-		updated, err := action(rs, i, shardActionInfo)
+		updated, err := action(ctx, rs, i, shardActionInfo)
 		if updated == nil {
 			return
 		}
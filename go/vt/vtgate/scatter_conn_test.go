@@ -17,8 +17,11 @@ limitations under the License.
 package vtgate
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"vitess.io/vitess/go/vt/log"
 
@@ -40,6 +43,7 @@ import (
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 )
 
 // This file uses the sandbox_test framework.
@@ -187,6 +191,124 @@ func TestExecutePanic(t *testing.T) {
 
 }
 
+func TestExecuteMultiShardScatterConcurrency(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "TestExecuteMultiShardScatterConcurrency"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	sc.concurrency = newScatterConcurrency("", 4, true, 0)
+	sbc0 := hc.AddTestTablet("aa", "0", 1, keyspace, "0", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	sbc1 := hc.AddTestTablet("aa", "1", 1, keyspace, "1", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	sbc1.MustFailCodes[vtrpcpb.Code_UNAVAILABLE] = 1
+
+	var rss []*srvtopo.ResolvedShard
+	var queries []*querypb.BoundQuery
+	for i, sbc := range []*sandboxconn.SandboxConn{sbc0, sbc1} {
+		rss = append(rss, &srvtopo.ResolvedShard{
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: fmt.Sprint(i), TabletType: topodatapb.TabletType_PRIMARY},
+			Gateway: sbc,
+		})
+		queries = append(queries, &querypb.BoundQuery{Sql: "query"})
+	}
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, NewSafeSession(nil), false, false)
+	require.ErrorContains(t, vterrors.Aggregate(errs), "UNAVAILABLE")
+	assert.EqualValues(t, 1, sbc0.ExecCount.Load())
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+	// The overloaded shard halved the concurrency of the keyspace.
+	assert.EqualValues(t, 2, sc.concurrency.limits.Counts()[keyspace])
+}
+
+func TestExecuteMultiShardReadYourWrites(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "TestExecuteMultiShardReadYourWrites"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	primary := hc.AddTestTablet("aa", "0", 1, keyspace, "0", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	replica := hc.AddTestTablet("aa", "1", 1, keyspace, "0", topodatapb.TabletType_REPLICA, true, 1, nil)
+	replicaHealth, err := hc.GetTabletHealthByAlias(replica.Tablet().Alias)
+	require.NoError(t, err)
+
+	session := NewSafeSession(&vtgatepb.Session{Autocommit: true})
+	session.SetReadYourWrites(true)
+	exec := func(tabletType topodatapb.TabletType) {
+		t.Helper()
+		rss := []*srvtopo.ResolvedShard{{
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: "0", TabletType: tabletType},
+			Gateway: sc.gateway,
+		}}
+		_, errs := sc.ExecuteMultiShard(ctx, nil, rss, []*querypb.BoundQuery{{Sql: "query"}}, session, true, false)
+		require.NoError(t, vterrors.Aggregate(errs))
+	}
+
+	// The reads go to the replicas until the session writes.
+	exec(topodatapb.TabletType_REPLICA)
+	assert.EqualValues(t, 1, replica.ExecCount.Load())
+
+	primary.SetResults([]*sqltypes.Result{{RowsAffected: 1, SessionStateChanges: "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"}})
+	exec(topodatapb.TabletType_PRIMARY)
+	assert.Equal(t, "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5", session.GetShardGTIDs(keyspace, "0"))
+
+	// The replica didn't report its position, or is behind.
+	exec(topodatapb.TabletType_REPLICA)
+	replicaHealth.Stats.ReplicationPosition = "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-4"
+	exec(topodatapb.TabletType_REPLICA)
+	assert.EqualValues(t, 3, primary.ExecCount.Load())
+	assert.EqualValues(t, 1, replica.ExecCount.Load())
+
+	// The replica caught up.
+	replicaHealth.Stats.ReplicationPosition = "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-7"
+	exec(topodatapb.TabletType_REPLICA)
+	assert.EqualValues(t, 3, primary.ExecCount.Load())
+	assert.EqualValues(t, 2, replica.ExecCount.Load())
+}
+
+func TestStreamExecuteMultiNestedScatterConcurrency(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	keyspace := "TestStreamExecuteMultiNestedScatterConcurrency"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	sc.concurrency = newScatterConcurrency("", 1, false, 0)
+	sbc0 := hc.AddTestTablet("aa", "0", 1, keyspace, "0", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	sbc1 := hc.AddTestTablet("aa", "1", 1, keyspace, "1", topodatapb.TabletType_PRIMARY, true, 1, nil)
+
+	var rss []*srvtopo.ResolvedShard
+	var queries []*querypb.BoundQuery
+	for i, sbc := range []*sandboxconn.SandboxConn{sbc0, sbc1} {
+		rss = append(rss, &srvtopo.ResolvedShard{
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: fmt.Sprint(i), TabletType: topodatapb.TabletType_PRIMARY},
+			Gateway: sbc,
+		})
+		queries = append(queries, &querypb.BoundQuery{Sql: "inner"})
+	}
+
+	// Like a streaming join, the callback of the outer stream runs the scatter
+	// query of the inner side on the same keyspace while the stream is open.
+	var mu sync.Mutex
+	var innerRows int
+	errs := sc.StreamExecuteMulti(ctx, nil, "outer", rss, []map[string]*querypb.BindVariable{nil, nil}, NewSafeSession(nil), false, func(*sqltypes.Result) error {
+		qr, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, NewSafeSession(nil), false, false)
+		if err := vterrors.Aggregate(errs); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		innerRows += len(qr.Rows)
+		return nil
+	})
+	require.NoError(t, vterrors.Aggregate(errs))
+	// Each of the two outer streams sent one result, for which the inner
+	// scatter query returned one row per shard.
+	assert.Equal(t, 4, innerRows)
+}
+
 func TestReservedOnMultiReplica(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -55,6 +55,18 @@ type (
 		consumeDelay time.Duration
 
 		parser *sqlparser.Parser
+
+		versions tableVersions
+	}
+
+	// tableVersions keeps a version for each table, which changes every time the tracker
+	// sees the schema of the table change. It has its own lock, as it is read on the
+	// query path while the tracker fetches the new schema from the tablets.
+	tableVersions struct {
+		mu        sync.RWMutex
+		last      int64
+		keyspaces map[keyspaceStr]int64
+		tables    map[keyspaceStr]map[tableNameStr]int64
 	}
 )
 
@@ -83,6 +95,8 @@ func NewTracker(ch chan *discovery.TabletHealth, enableViews, enableUDFs bool, p
 
 // LoadKeyspace loads the keyspace schema.
 func (t *Tracker) LoadKeyspace(conn queryservice.QueryService, target *querypb.Target) error {
+	defer t.versions.bumpKeyspace(target.Keyspace)
+
 	err := t.loadTables(conn, target)
 	if err != nil {
 		return err
@@ -269,6 +283,15 @@ func (t *Tracker) GetIndexes(ks string, tbl string) []*sqlparser.IndexDefinition
 	return tblInfo.Indexes
 }
 
+// GetCheckConstraints returns the CHECK constraints for table in the given keyspace.
+func (t *Tracker) GetCheckConstraints(ks string, tbl string) []*sqlparser.ConstraintDefinition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tblInfo := t.tables.get(ks, tbl)
+	return tblInfo.CheckConstraints
+}
+
 // Tables returns a map with the columns for all known tables in the keyspace
 func (t *Tracker) Tables(ks string) map[string]*vindexes.TableInfo {
 	t.mu.Lock()
@@ -332,6 +355,7 @@ func (t *Tracker) updatedTableSchema(th *discovery.TabletHealth) bool {
 	defer t.mu.Unlock()
 
 	tablesUpdated := th.Stats.TableSchemaChanged
+	defer t.versions.bumpTables(th.Target.Keyspace, tablesUpdated)
 
 	// first we empty all prior schema. deleted tables will not show up in the result,
 	// so this is the only chance to delete
@@ -366,7 +390,8 @@ func (t *Tracker) updateTables(keyspace string, res map[string]string) {
 
 		cols := getColumns(ddl.TableSpec)
 		fks := getForeignKeys(ddl.TableSpec)
-		t.tables.set(keyspace, tableName, cols, fks, ddl.TableSpec.Indexes)
+		checks := getCheckConstraints(ddl.TableSpec)
+		t.tables.set(keyspace, tableName, cols, fks, ddl.TableSpec.Indexes, checks)
 	}
 }
 
@@ -389,6 +414,7 @@ func getColumns(tblSpec *sqlparser.TableSpec) []vindexes.Column {
 				Scale:         int32(scale),
 				Nullable:      nullable,
 				Values:        column.Type.EnumValues,
+				Generated:     column.Type.Options.As,
 			})
 	}
 	return cols
@@ -409,6 +435,16 @@ func getForeignKeys(tblSpec *sqlparser.TableSpec) []*sqlparser.ForeignKeyDefinit
 	return fks
 }
 
+func getCheckConstraints(tblSpec *sqlparser.TableSpec) []*sqlparser.ConstraintDefinition {
+	var checks []*sqlparser.ConstraintDefinition
+	for _, constraint := range tblSpec.Constraints {
+		if _, ok := constraint.Details.(*sqlparser.CheckConstraintDefinition); ok {
+			checks = append(checks, constraint)
+		}
+	}
+	return checks
+}
+
 func getTableCollation(tblSpec *sqlparser.TableSpec) string {
 	if tblSpec.Options == nil {
 		return ""
@@ -440,6 +476,7 @@ func (t *Tracker) updatedViewSchema(th *discovery.TabletHealth) bool {
 	defer t.mu.Unlock()
 
 	viewsUpdated := th.Stats.ViewSchemaChanged
+	defer t.versions.bumpTables(th.Target.Keyspace, viewsUpdated)
 
 	// first we empty all prior schema. deleted tables will not show up in the result,
 	// so this is the only chance to delete
@@ -465,6 +502,41 @@ func (t *Tracker) updateViews(keyspace string, res map[string]string) {
 	}
 }
 
+// TableVersion returns the version of the schema of the given table or view. The version
+// changes every time the tracker sees the schema of the table change, or reloads its keyspace.
+func (t *Tracker) TableVersion(ks string, tbl string) int64 {
+	t.versions.mu.RLock()
+	defer t.versions.mu.RUnlock()
+	return max(t.versions.keyspaces[ks], t.versions.tables[ks][tbl])
+}
+
+func (tv *tableVersions) bumpKeyspace(ks string) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+	if tv.keyspaces == nil {
+		tv.keyspaces = make(map[keyspaceStr]int64)
+	}
+	tv.last++
+	tv.keyspaces[ks] = tv.last
+}
+
+func (tv *tableVersions) bumpTables(ks string, tbls []string) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+	if tv.tables == nil {
+		tv.tables = make(map[keyspaceStr]map[tableNameStr]int64)
+	}
+	m := tv.tables[ks]
+	if m == nil {
+		m = make(map[tableNameStr]int64)
+		tv.tables[ks] = m
+	}
+	tv.last++
+	for _, tbl := range tbls {
+		m[tbl] = tv.last
+	}
+}
+
 // RegisterSignalReceiver allows a function to register to be called when new schema is available
 func (t *Tracker) RegisterSignalReceiver(f func()) {
 	t.mu.Lock()
@@ -490,13 +562,13 @@ type tableMap struct {
 	m map[keyspaceStr]map[tableNameStr]*vindexes.TableInfo
 }
 
-func (tm *tableMap) set(ks, tbl string, cols []vindexes.Column, fks []*sqlparser.ForeignKeyDefinition, indexes []*sqlparser.IndexDefinition) {
+func (tm *tableMap) set(ks, tbl string, cols []vindexes.Column, fks []*sqlparser.ForeignKeyDefinition, indexes []*sqlparser.IndexDefinition, checks []*sqlparser.ConstraintDefinition) {
 	m := tm.m[ks]
 	if m == nil {
 		m = make(map[tableNameStr]*vindexes.TableInfo)
 		tm.m[ks] = m
 	}
-	m[tbl] = &vindexes.TableInfo{Columns: cols, ForeignKeys: fks, Indexes: indexes}
+	m[tbl] = &vindexes.TableInfo{Columns: cols, ForeignKeys: fks, Indexes: indexes, CheckConstraints: checks}
 }
 
 func (tm *tableMap) get(ks, tbl string) *vindexes.TableInfo {
@@ -239,6 +239,27 @@ func TestTableTracking(t *testing.T) {
 	testTracker(t, false, schemaResponse, testcases)
 }
 
+// TestTableVersions tests that the version of a table changes when its schema or its keyspace is reloaded.
+func TestTableVersions(t *testing.T) {
+	tracker := NewTracker(nil, false, false, sqlparser.NewTestParser())
+	assert.Zero(t, tracker.TableVersion(keyspace, "t1"))
+
+	tracker.versions.bumpKeyspace(keyspace)
+	v1 := tracker.TableVersion(keyspace, "t1")
+	assert.Positive(t, v1)
+	assert.Equal(t, v1, tracker.TableVersion(keyspace, "t2"))
+	assert.Zero(t, tracker.TableVersion("other", "t1"))
+
+	tracker.versions.bumpTables(keyspace, []string{"t1"})
+	v2 := tracker.TableVersion(keyspace, "t1")
+	assert.Greater(t, v2, v1)
+	assert.Equal(t, v1, tracker.TableVersion(keyspace, "t2"))
+
+	tracker.versions.bumpKeyspace(keyspace)
+	assert.Greater(t, tracker.TableVersion(keyspace, "t1"), v2)
+	assert.Greater(t, tracker.TableVersion(keyspace, "t2"), v2)
+}
+
 // TestViewsTracking tests that the tracker is able to track views.
 func TestViewsTracking(t *testing.T) {
 	schemaDefResult := []sandboxconn.SchemaResult{
@@ -345,6 +366,9 @@ func TestFKInfoRetrieval(t *testing.T) {
 
 // TestIndexInfoRetrieval tests that the tracker is able to retrieve required index information from ddl statement.
 func TestIndexInfoRetrieval(t *testing.T) {
+	md5Email, err := sqlparser.NewTestParser().ParseExpr("md5(email)")
+	require.NoError(t, err)
+
 	schemaDefResult := []sandboxconn.SchemaResult{
 		tables(tbl(
 			"my_tbl", "CREATE TABLE `my_tbl` ("+
@@ -360,9 +384,11 @@ func TestIndexInfoRetrieval(t *testing.T) {
 				"`id` bigint NOT NULL AUTO_INCREMENT,"+
 				"`name` varchar(50) CHARACTER SET latin1 COLLATE latin1_swedish_ci DEFAULT NULL,"+
 				"`email` varbinary(100) DEFAULT NULL,"+
+				"`email_md5` varbinary(32) GENERATED ALWAYS AS (md5(`email`)) VIRTUAL,"+
 				"PRIMARY KEY (`id`),"+
 				"KEY `id` (`id`,`name`), "+
-				"UNIQUE KEY `email` (`email`)) "+
+				"UNIQUE KEY `email` (`email`), "+
+				"KEY `lower_name` ((lower(`name`)))) "+
 				"ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci")),
 	}
 
@@ -389,6 +415,7 @@ func TestIndexInfoRetrieval(t *testing.T) {
 				{Name: sqlparser.NewIdentifierCI("id"), Type: querypb.Type_INT64, CollationName: "binary", Nullable: false},
 				{Name: sqlparser.NewIdentifierCI("name"), Type: querypb.Type_VARCHAR, CollationName: "latin1_swedish_ci", Size: 50, Nullable: true, Default: &sqlparser.NullVal{}},
 				{Name: sqlparser.NewIdentifierCI("email"), Type: querypb.Type_VARBINARY, CollationName: "binary", Size: 100, Nullable: true, Default: &sqlparser.NullVal{}},
+				{Name: sqlparser.NewIdentifierCI("email_md5"), Type: querypb.Type_VARBINARY, CollationName: "binary", Size: 32, Nullable: true, Generated: md5Email},
 			},
 		},
 		expIdx: map[string][]string{
@@ -396,6 +423,50 @@ func TestIndexInfoRetrieval(t *testing.T) {
 				"primary key (id)",
 				"key id (id, `name`)",
 				"unique key email (email)",
+				"key lower_name ((lower(`name`)))",
+			},
+		},
+	}}
+
+	testTracker(t, false, schemaDefResult, testcases)
+}
+
+// TestCheckConstraintRetrieval tests that the tracker is able to retrieve the check constraints from ddl statement.
+func TestCheckConstraintRetrieval(t *testing.T) {
+	schemaDefResult := []sandboxconn.SchemaResult{
+		tables(tbl(
+			"my_tbl", "CREATE TABLE `my_tbl` ("+
+				"`id` bigint NOT NULL AUTO_INCREMENT,"+
+				"`price` int DEFAULT NULL,"+
+				"PRIMARY KEY (`id`),"+
+				"CONSTRAINT `price_chk` CHECK ((`price` > 0))) "+
+				"ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci")),
+		empty(), /*initial load of view*/
+		tables(tbl(
+			"my_tbl", "CREATE TABLE `my_tbl` ("+
+				"`id` bigint NOT NULL AUTO_INCREMENT,"+
+				"`price` int DEFAULT NULL,"+
+				"`discount` int DEFAULT NULL,"+
+				"PRIMARY KEY (`id`),"+
+				"CONSTRAINT `discount_chk` CHECK ((`discount` < `price`)) /*!80016 NOT ENFORCED */,"+
+				"CONSTRAINT `price_chk` CHECK ((`price` > 0))) "+
+				"ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci")),
+	}
+
+	testcases := []testCases{{
+		testName: "initial table load",
+		expChk: map[string][]string{
+			"my_tbl": {
+				"constraint price_chk check (price > 0)",
+			},
+		},
+	}, {
+		testName: "next load",
+		updTbl:   []string{"my_tbl"},
+		expChk: map[string][]string{
+			"my_tbl": {
+				"constraint discount_chk check (discount < price) not enforced",
+				"constraint price_chk check (price > 0)",
 			},
 		},
 	}}
@@ -460,6 +531,7 @@ type testCases struct {
 	expTbl map[string][]vindexes.Column
 	expFk  map[string]string
 	expIdx map[string][]string
+	expChk map[string][]string
 
 	updView []string
 	expView map[string]string
@@ -526,6 +598,14 @@ func testTracker(t *testing.T, enableUDFs bool, schemaDefResult []sandboxconn.Sc
 				}
 			}
 
+			for k, expChecks := range tcase.expChk {
+				checks := tracker.GetCheckConstraints(keyspace, k)
+				require.Equal(t, len(expChecks), len(checks))
+				for i, check := range checks {
+					assert.Equal(t, expChecks[i], sqlparser.String(check), "mismatch check constraint for table: ", k)
+				}
+			}
+
 			for k, v := range tcase.expView {
 				assert.Equal(t, v, sqlparser.String(tracker.GetViews(keyspace, k)), "mismatch for view: ", k)
 			}
@@ -252,6 +252,11 @@ func (a *analyzer) analyzeUp(cursor *sqlparser.Cursor) bool {
 		return true
 	}
 
+	if err := a.checkGeneratedColumnWrites(cursor); err != nil {
+		a.setError(err)
+		return true
+	}
+
 	if err := a.typer.up(cursor); err != nil {
 		a.setError(err)
 		return false
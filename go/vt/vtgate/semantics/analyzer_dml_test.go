@@ -22,7 +22,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 func TestUpdBindingColName(t *testing.T) {
@@ -138,3 +141,69 @@ func TestInsertBindingColNameErrorCases(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratedColumnWrites(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	md5Email, err := parser.ParseExpr("md5(email)")
+	require.NoError(t, err)
+	si := &FakeSI{
+		Tables: map[string]*vindexes.Table{
+			"u": {
+				Name: sqlparser.NewIdentifierCS("u"),
+				Columns: []vindexes.Column{{
+					Name: sqlparser.NewIdentifierCI("id"),
+					Type: querypb.Type_INT64,
+				}, {
+					Name: sqlparser.NewIdentifierCI("email"),
+					Type: querypb.Type_VARCHAR,
+				}, {
+					Name:      sqlparser.NewIdentifierCI("email_md5"),
+					Type:      querypb.Type_VARCHAR,
+					Generated: md5Email,
+				}},
+				ColumnListAuthoritative: true,
+				Keyspace:                ks2,
+			},
+		},
+	}
+
+	tcases := []struct {
+		query  string
+		expErr string
+	}{{
+		query: "insert into u(id, email) values (1, 'foo')",
+	}, {
+		query: "insert into u(id, email, email_md5) values (1, 'foo', default), (2, 'bar', default)",
+	}, {
+		query: "update u set email = 'foo', email_md5 = default",
+	}, {
+		query:  "insert into u(id, email, email_md5) values (1, 'foo', 'bar')",
+		expErr: "The value specified for generated column 'email_md5' in table 'u' is not allowed.",
+	}, {
+		query:  "insert into u(id, email, email_md5) values (1, 'foo', default), (2, 'bar', 'baz')",
+		expErr: "The value specified for generated column 'email_md5' in table 'u' is not allowed.",
+	}, {
+		query:  "insert into u(id, email, email_md5) select id, email, email_md5 from u",
+		expErr: "The value specified for generated column 'email_md5' in table 'u' is not allowed.",
+	}, {
+		query:  "insert into u(id, email) values (1, 'foo') on duplicate key update email_md5 = 'bar'",
+		expErr: "The value specified for generated column 'email_md5' in table 'u' is not allowed.",
+	}, {
+		query:  "update u set EMAIL_MD5 = 'bar' where id = 1",
+		expErr: "The value specified for generated column 'EMAIL_MD5' in table 'u' is not allowed.",
+	}}
+	for _, tc := range tcases {
+		t.Run(tc.query, func(t *testing.T) {
+			parse, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+
+			_, err = Analyze(parse, "d", si)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expErr)
+			require.Equal(t, vterrors.NonDefaultValueForGeneratedColumn, vterrors.ErrState(err))
+		})
+	}
+}
@@ -872,9 +872,6 @@ func TestInvalidQueries(t *testing.T) {
 	}, {
 		sql:  "select is_free_lock('xyz') from user",
 		serr: "is_free_lock('xyz') allowed only with dual",
-	}, {
-		sql: "SELECT * FROM JSON_TABLE('[ {\"c1\": null} ]','$[*]' COLUMNS( c1 INT PATH '$.c1' ERROR ON ERROR )) as jt",
-		err: &JSONTablesError{},
 	}, {
 		sql:             "select does_not_exist from t1",
 		notUnshardedErr: "column 'does_not_exist' not found in table 't1'",
@@ -888,8 +885,23 @@ func TestInvalidQueries(t *testing.T) {
 		sql:  "select 1 from t1 where (id, id) in (select 1, 2, 3)",
 		serr: "Operand should contain 2 column(s)",
 	}, {
-		sql:  "WITH RECURSIVE cte (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM cte WHERE n < 5) SELECT * FROM cte",
-		serr: "VT12001: unsupported: recursive common table expression",
+		sql:  "WITH RECURSIVE cte (n) AS (SELECT n FROM cte) SELECT * FROM cte",
+		serr: "Recursive Common Table Expression 'cte' should contain a UNION",
+	}, {
+		sql:  "WITH RECURSIVE cte (n) AS (SELECT n FROM cte UNION ALL SELECT 1) SELECT * FROM cte",
+		serr: "Recursive Common Table Expression 'cte' should have one or more non-recursive query blocks followed by one or more recursive ones",
+	}, {
+		sql:  "WITH RECURSIVE cte (n) AS (SELECT 1 UNION ALL SELECT count(*) FROM cte) SELECT * FROM cte",
+		serr: "Recursive Common Table Expression 'cte' can contain neither aggregation nor window functions in recursive query block",
+	}, {
+		sql:  "WITH RECURSIVE cte (n) AS (SELECT 1 UNION ALL SELECT c1.n + 1 FROM cte c1, cte c2) SELECT * FROM cte",
+		serr: "In recursive query block of Recursive Common Table Expression 'cte', the recursive table must be referenced only once, and not in any subquery",
+	}, {
+		sql:  "WITH RECURSIVE cte (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM t1 left join cte on t1.a = cte.n) SELECT * FROM cte",
+		serr: "In recursive query block of Recursive Common Table Expression 'cte', the recursive table must neither be in the right argument of a LEFT JOIN, nor be forced to be non-first with join order hints",
+	}, {
+		sql:  "WITH RECURSIVE cte (n) AS (SELECT 1 UNION SELECT n + 1 FROM cte WHERE n < 5) SELECT * FROM cte",
+		serr: "VT12001: unsupported: UNION DISTINCT in a recursive common table expression",
 	}, {
 		sql:  "with x as (select 1), x as (select 1) select * from x",
 		serr: "VT03013: not unique table/alias: 'x'",
@@ -1041,6 +1053,56 @@ func TestScopingWithWITH(t *testing.T) {
 	}
 }
 
+func TestScopingWithJSONTable(t *testing.T) {
+	queries := []struct {
+		query             string
+		errorMessage      string
+		recursive, direct TableSet
+	}{
+		{
+			query:     "select jt.id from json_table('[{\"id\": 1}]', '$[*]' columns(id int path '$.id')) as jt",
+			recursive: TS0,
+			direct:    TS0,
+		}, {
+			query:     "select id from json_table('[{\"id\": 1}]', '$[*]' columns(rowid for ordinality, id int path '$.id')) as jt",
+			recursive: TS0,
+			direct:    TS0,
+		}, {
+			query:     "select jt.name from json_table('[{\"a\": [\"x\"]}]', '$[*]' columns(nested path '$.a[*]' columns(name varchar(10) path '$'))) as jt",
+			recursive: TS0,
+			direct:    TS0,
+		}, {
+			query:     "select jt.id from t2, json_table(t2.textcol, '$[*]' columns(id int path '$.id')) as jt",
+			recursive: TS1,
+			direct:    TS1,
+		}, {
+			query:        "select jt.id from json_table(t2.textcol, '$[*]' columns(id int path '$.id')) as jt, t2",
+			errorMessage: "column 't2.textcol' not found",
+		}, {
+			query:        "select jt.foo from json_table('[]', '$[*]' columns(id int path '$.id')) as jt",
+			errorMessage: "column 'jt.foo' not found",
+		}}
+	for _, query := range queries {
+		t.Run(query.query, func(t *testing.T) {
+			parse, err := sqlparser.NewTestParser().Parse(query.query)
+			require.NoError(t, err)
+			st, err := Analyze(parse, "user", fakeSchemaInfo())
+
+			switch {
+			case query.errorMessage != "" && err != nil:
+				require.EqualError(t, err, query.errorMessage)
+			case query.errorMessage != "":
+				require.EqualError(t, st.NotUnshardedErr, query.errorMessage)
+			default:
+				require.NoError(t, err)
+				sel := parse.(*sqlparser.Select)
+				assert.Equal(t, query.recursive, st.RecursiveDeps(extract(sel, 0)), "RecursiveDeps")
+				assert.Equal(t, query.direct, st.DirectDeps(extract(sel, 0)), "DirectDeps")
+			}
+		})
+	}
+}
+
 func TestJoinPredicateDependencies(t *testing.T) {
 	// create table t(<no column info>)
 	// create table t1(id bigint)
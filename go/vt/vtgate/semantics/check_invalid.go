@@ -28,6 +28,8 @@ func (a *analyzer) checkForInvalidConstructs(cursor *sqlparser.Cursor) error {
 		return a.checkSelect(cursor, node)
 	case *sqlparser.Nextval:
 		return a.checkNextVal()
+	case *sqlparser.NextValueExpr:
+		return a.checkNextValueExpr(cursor)
 	case *sqlparser.AliasedTableExpr:
 		return checkAliasedTableExpr(node)
 	case *sqlparser.JoinTableExpr:
@@ -36,24 +38,14 @@ func (a *analyzer) checkForInvalidConstructs(cursor *sqlparser.Cursor) error {
 		return &LockOnlyWithDualError{Node: node}
 	case *sqlparser.Union:
 		return checkUnion(node)
-	case *sqlparser.JSONTableExpr:
-		return &JSONTablesError{}
-	case *sqlparser.DerivedTable:
-		return checkDerived(node)
 	case *sqlparser.AssignmentExpr:
 		return vterrors.VT12001("Assignment expression")
 	case *sqlparser.Subquery:
 		return a.checkSubqueryColumns(cursor.Parent(), node)
-	case *sqlparser.With:
-		if node.Recursive {
-			return vterrors.VT12001("recursive common table expression")
-		}
 	case *sqlparser.Insert:
 		if node.Action == sqlparser.ReplaceAct {
 			return ShardedError{Inner: &UnsupportedConstruct{errString: "REPLACE INTO with sharded keyspace"}}
 		}
-	case *sqlparser.OverClause:
-		return ShardedError{Inner: &UnsupportedConstruct{errString: "OVER CLAUSE with sharded keyspace"}}
 	}
 
 	return nil
@@ -92,13 +84,6 @@ func (a *analyzer) checkSubqueryColumns(parent sqlparser.SQLNode, subq *sqlparse
 	return nil
 }
 
-func checkDerived(node *sqlparser.DerivedTable) error {
-	if node.Lateral {
-		return vterrors.VT12001("lateral derived tables")
-	}
-	return nil
-}
-
 func checkUnion(node *sqlparser.Union) error {
 	err := sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
 		switch node := node.(type) {
@@ -173,12 +158,77 @@ func (a *analyzer) checkSelect(cursor *sqlparser.Cursor, node *sqlparser.Select)
 	if a.scoper.currentScope().parent != nil {
 		return &CantUseOptionHereError{Msg: errMsg}
 	}
-	if node.Into != nil {
-		return ShardedError{Inner: &UnsupportedConstruct{errString: "INTO on sharded keyspace"}}
+	return nil
+}
+
+// checkNextValueExpr checks that NEXT VALUE FOR is only used as one of the values of an INSERT,
+// where it can be replaced by a value generated from the sequence table
+func (a *analyzer) checkNextValueExpr(cursor *sqlparser.Cursor) error {
+	_, inTuple := cursor.Parent().(sqlparser.ValTuple)
+	_, inInsert := a.scoper.currentScope().stmt.(*sqlparser.Insert)
+	if inTuple && inInsert {
+		return nil
+	}
+	return vterrors.VT12001("NEXT VALUE FOR outside of the VALUES of an INSERT")
+}
+
+// checkGeneratedColumnWrites checks that INSERT and UPDATE statements do not write to generated columns.
+// Like MySQL, the only value allowed for a generated column is DEFAULT.
+func (a *analyzer) checkGeneratedColumnWrites(cursor *sqlparser.Cursor) error {
+	switch node := cursor.Node().(type) {
+	case *sqlparser.UpdateExpr:
+		if _, isDefault := node.Expr.(*sqlparser.Default); isDefault {
+			return nil
+		}
+		return a.checkNotGenerated(a.binder.direct.dependencies(node.Name), node.Name.Name)
+	case *sqlparser.Insert:
+		rows, isValues := node.Rows.(sqlparser.Values)
+		ts := a.tables.tableSetFor(node.Table)
+		for idx, col := range node.Columns {
+			if isValues && allDefaultValues(rows, idx) {
+				continue
+			}
+			if err := a.checkNotGenerated(ts, col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkNotGenerated returns an error if the column of the given table is a generated column
+func (a *analyzer) checkNotGenerated(ts TableSet, col sqlparser.IdentifierCI) error {
+	if ts.NumberOfTables() != 1 {
+		return nil
+	}
+	ti, err := a.tables.tableInfoFor(ts)
+	if err != nil {
+		return nil
+	}
+	vtbl := ti.GetVindexTable()
+	if vtbl == nil {
+		return nil
+	}
+	for _, column := range vtbl.Columns {
+		if column.Generated != nil && column.Name.Equal(col) {
+			return &GeneratedColumnWriteError{Column: col.String(), Table: vtbl.Name.String()}
+		}
 	}
 	return nil
 }
 
+func allDefaultValues(rows sqlparser.Values, idx int) bool {
+	for _, row := range rows {
+		if idx >= len(row) {
+			return false
+		}
+		if _, isDefault := row[idx].(*sqlparser.Default); !isDefault {
+			return false
+		}
+	}
+	return true
+}
+
 // checkAliasedTableExpr checks the validity of AliasedTableExpr.
 func checkAliasedTableExpr(node *sqlparser.AliasedTableExpr) error {
 	if len(node.Hints) == 0 {
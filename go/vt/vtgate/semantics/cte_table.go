@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"strings"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// CTETable is the table that the recursive part of a recursive common table expression
+// uses to reference the rows produced by the previous iteration of the recursion.
+//
+//	WITH RECURSIVE cte(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM cte WHERE n < 5) SELECT n FROM cte
+//
+// Here, the `cte` used in `SELECT n + 1 FROM cte WHERE n < 5` is a CTETable
+type CTETable struct {
+	tableName   string
+	ASTNode     *sqlparser.AliasedTableExpr
+	columnNames []string
+	types       []evalengine.Type
+}
+
+var _ TableInfo = (*CTETable)(nil)
+
+// dependencies implements the TableInfo interface
+func (c *CTETable) dependencies(colName string, org originable) (dependencies, error) {
+	ts := org.tableSetFor(c.ASTNode)
+	for i, name := range c.columnNames {
+		if strings.EqualFold(name, colName) {
+			return createCertain(ts, ts, c.types[i]), nil
+		}
+	}
+	return &nothing{}, nil
+}
+
+// getTableSet implements the TableInfo interface
+func (c *CTETable) getTableSet(org originable) TableSet {
+	return org.tableSetFor(c.ASTNode)
+}
+
+// getExprFor implements the TableInfo interface
+func (c *CTETable) getExprFor(s string) (sqlparser.Expr, error) {
+	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Unknown column '%s' in 'field list'", s)
+}
+
+// IsInfSchema implements the TableInfo interface
+func (c *CTETable) IsInfSchema() bool {
+	return false
+}
+
+// getColumns implements the TableInfo interface
+func (c *CTETable) getColumns(bool) []ColumnInfo {
+	cols := make([]ColumnInfo, 0, len(c.columnNames))
+	for i, name := range c.columnNames {
+		cols = append(cols, ColumnInfo{
+			Name: name,
+			Type: c.types[i],
+		})
+	}
+	return cols
+}
+
+// GetAliasedTableExpr implements the TableInfo interface
+func (c *CTETable) GetAliasedTableExpr() *sqlparser.AliasedTableExpr {
+	return c.ASTNode
+}
+
+// canShortCut implements the TableInfo interface.
+// The CTE has been rewritten into a derived table by now, so the query can't be sent as is
+func (c *CTETable) canShortCut() shortCut {
+	return cannotShortCut
+}
+
+// GetVindexTable implements the TableInfo interface
+func (c *CTETable) GetVindexTable() *vindexes.Table {
+	return nil
+}
+
+// Name implements the TableInfo interface
+func (c *CTETable) Name() (sqlparser.TableName, error) {
+	return sqlparser.NewTableName(c.tableName), nil
+}
+
+// authoritative implements the TableInfo interface
+func (c *CTETable) authoritative() bool {
+	return true
+}
+
+// matches implements the TableInfo interface
+func (c *CTETable) matches(name sqlparser.TableName) bool {
+	return c.tableName == name.Name.String() && name.Qualifier.IsEmpty()
+}
+
+// ColumnNames returns the names of the columns of the CTE, in the order they are produced
+func (c *CTETable) ColumnNames() []string {
+	return c.columnNames
+}
+
+func newCTETable(node *sqlparser.AliasedTableExpr, cte *sqlparser.CommonTableExpr, seed *sqlparser.Select, org originable) (*CTETable, error) {
+	tableName := cte.ID.String()
+	if node.As.NotEmpty() {
+		tableName = node.As.String()
+	}
+	tbl := &CTETable{
+		tableName: tableName,
+		ASTNode:   node,
+	}
+
+	for i, expr := range seed.SelectExprs {
+		ae, ok := expr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, vterrors.VT09015()
+		}
+		name := ae.ColumnName()
+		if len(cte.Columns) > 0 {
+			if len(cte.Columns) != len(seed.SelectExprs) {
+				return nil, vterrors.VT03033()
+			}
+			name = cte.Columns[i].String()
+		}
+		_, _, typ := org.depsForExpr(ae.Expr)
+		tbl.columnNames = append(tbl.columnNames, name)
+		tbl.types = append(tbl.types, typ)
+	}
+	return tbl, nil
+}
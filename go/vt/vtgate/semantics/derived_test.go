@@ -86,6 +86,17 @@ func TestScopingWDerivedTables(t *testing.T) {
 			query:         "select t.col1 from t3 ua join (select t1.id, t1.col1 from t1 join t2) as t",
 			directDeps:    TS3,
 			recursiveDeps: TS1,
+		}, {
+			query:         "select t.id from user u, lateral (select u.id as id) as t",
+			directDeps:    TS2,
+			recursiveDeps: TS0,
+		}, {
+			query:         "select t.id from user u join lateral (select u.id as id) as t on true",
+			directDeps:    TS2,
+			recursiveDeps: TS0,
+		}, {
+			query:        "select t.id from user u, (select u.id as id) as t",
+			errorMessage: "column 'u.id' not found",
 		}, {
 			query:        "select uu.test from (select id from t1) uu",
 			errorMessage: "column 'uu.test' not found",
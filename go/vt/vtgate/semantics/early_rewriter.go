@@ -126,11 +126,24 @@ func (r *earlyRewriter) handleAliasedTable(node *sqlparser.AliasedTableExpr) err
 	if cte == nil {
 		return nil
 	}
+	if _, isRef := r.scoper.cteRefs[node]; isRef {
+		// this is the recursive part of the CTE referencing the CTE itself,
+		// which is handled by the table collector
+		return nil
+	}
+	selStmt := cte.Subquery.Select
+	if _, recursive := r.scoper.recursiveCTEs[cte]; recursive {
+		union, err := r.recursiveCTEUnion(cte)
+		if err != nil {
+			return err
+		}
+		selStmt = union
+	}
 	if node.As.IsEmpty() {
 		node.As = tbl.Name
 	}
 	node.Expr = &sqlparser.DerivedTable{
-		Select: cte.Subquery.Select,
+		Select: selStmt,
 	}
 	if len(cte.Columns) > 0 {
 		node.Columns = cte.Columns
@@ -141,15 +154,112 @@ func (r *earlyRewriter) handleAliasedTable(node *sqlparser.AliasedTableExpr) err
 func (r *earlyRewriter) handleWith(node *sqlparser.With) error {
 	scope := r.scoper.currentScope()
 	for _, cte := range node.CTEs {
-		err := scope.addCTE(cte)
+		recursive := node.Recursive && referencesTable(cte.Subquery.Select, cte.ID.String())
+		err := scope.addCTE(cte, recursive)
 		if err != nil {
 			return err
 		}
+		if recursive {
+			r.scoper.recursiveCTEs[cte] = nil
+		}
 	}
 	node.CTEs = nil
+	node.Recursive = false
 	return nil
 }
 
+// recursiveCTEUnion checks that the recursive CTE is something we can evaluate, and returns a copy of
+// its UNION for the derived table replacing a use of the CTE. The reference that the recursive
+// part makes to the CTE is recorded, so the table collector can turn it into a CTETable
+func (r *earlyRewriter) recursiveCTEUnion(cte *sqlparser.CommonTableExpr) (*sqlparser.Union, error) {
+	name := cte.ID.String()
+	union, ok := sqlparser.CloneSelectStatement(cte.Subquery.Select).(*sqlparser.Union)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Recursive Common Table Expression '%s' should contain a UNION", name)
+	}
+	seed := sqlparser.GetFirstSelect(union.Left)
+	if referencesTable(seed, name) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones", name)
+	}
+	if referencesTable(union.Left, name) {
+		return nil, vterrors.VT12001("more than one recursive query block in a common table expression")
+	}
+	if union.Distinct {
+		return nil, vterrors.VT12001("UNION DISTINCT in a recursive common table expression")
+	}
+	if len(union.OrderBy) > 0 || union.Limit != nil {
+		return nil, vterrors.VT12001("ORDER BY or LIMIT in a recursive common table expression")
+	}
+
+	term, ok := union.Right.(*sqlparser.Select)
+	if !ok {
+		return nil, vterrors.VT13001(fmt.Sprintf("unexpected recursive query block: %T", union.Right))
+	}
+	if len(term.GroupBy) > 0 || sqlparser.ContainsAggregation(term.SelectExprs) || sqlparser.ContainsWindowFunction(term.SelectExprs) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Recursive Common Table Expression '%s' can contain neither aggregation nor window functions in recursive query block", name)
+	}
+	if term.Distinct || len(term.OrderBy) > 0 || term.Limit != nil {
+		return nil, vterrors.VT12001("DISTINCT, ORDER BY or LIMIT in the recursive query block of a common table expression")
+	}
+
+	var refs []*sqlparser.AliasedTableExpr
+	for _, expr := range term.From {
+		var err error
+		refs, err = findCTERefs(expr, name, refs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(refs) != 1 || referencesTable(term.SelectExprs, name) || (term.Where != nil && referencesTable(term.Where, name)) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In recursive query block of Recursive Common Table Expression '%s', the recursive table must be referenced only once, and not in any subquery", name)
+	}
+
+	r.scoper.cteRefs[refs[0]] = &cteRef{cte: cte, seed: seed}
+	return union, nil
+}
+
+// findCTERefs returns the tables of the FROM clause of the recursive part of a CTE that reference the CTE
+func findCTERefs(expr sqlparser.TableExpr, name string, refs []*sqlparser.AliasedTableExpr) ([]*sqlparser.AliasedTableExpr, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		tbl, ok := expr.Expr.(sqlparser.TableName)
+		if ok && tbl.Qualifier.IsEmpty() && tbl.Name.String() == name {
+			return append(refs, expr), nil
+		}
+		if referencesTable(expr, name) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In recursive query block of Recursive Common Table Expression '%s', the recursive table must be referenced only once, and not in any subquery", name)
+		}
+	case *sqlparser.JoinTableExpr:
+		before := len(refs)
+		var err error
+		refs, err = findCTERefs(expr.LeftExpr, name, refs)
+		if err != nil {
+			return nil, err
+		}
+		inLeft := len(refs) > before
+		refs, err = findCTERefs(expr.RightExpr, name, refs)
+		if err != nil {
+			return nil, err
+		}
+		inRight := len(refs) > before && !inLeft
+		if (expr.Join == sqlparser.LeftJoinType && inRight) || (expr.Join == sqlparser.RightJoinType && inLeft) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In recursive query block of Recursive Common Table Expression '%s', the recursive table must neither be in the right argument of a LEFT JOIN, nor be forced to be non-first with join order hints", name)
+		}
+		if expr.Condition != nil && referencesTable(expr.Condition.On, name) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In recursive query block of Recursive Common Table Expression '%s', the recursive table must be referenced only once, and not in any subquery", name)
+		}
+	case *sqlparser.ParenTableExpr:
+		for _, inner := range expr.Exprs {
+			var err error
+			refs, err = findCTERefs(inner, name, refs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return refs, nil
+}
+
 func rewriteNotExpr(cursor *sqlparser.Cursor, node *sqlparser.NotExpr) {
 	cmp, ok := node.Expr.(*sqlparser.ComparisonExpr)
 	if !ok {
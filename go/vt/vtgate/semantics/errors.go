@@ -44,7 +44,6 @@ type (
 	NotSequenceTableError          struct{ Table string }
 	NextWithMultipleTablesError    struct{ CountTables int }
 	LockOnlyWithDualError          struct{ Node *sqlparser.LockingFunc }
-	JSONTablesError                struct{ Table string }
 	QualifiedOrderInUnionError     struct{ Table string }
 	BuggyError                     struct{ Msg string }
 	UnsupportedConstruct           struct{ errString string }
@@ -76,6 +75,10 @@ type (
 		Column string
 		Clause string
 	}
+	GeneratedColumnWriteError struct {
+		Column string
+		Table  string
+	}
 )
 
 func eprintf(e error, format string, args ...any) string {
@@ -208,13 +211,6 @@ func (e *QualifiedOrderInUnionError) Error() string {
 	return eprintf(e, "Table `%s` from one of the SELECTs cannot be used in global ORDER clause", e.Table)
 }
 
-// JSONTablesError
-func (e *JSONTablesError) Error() string {
-	return eprintf(e, "json_table expressions")
-}
-
-func (e *JSONTablesError) unsupported() {}
-
 // BuggyError is used for checking conditions that should never occur
 func (e *BuggyError) Error() string {
 	return eprintf(e, e.Msg)
@@ -336,3 +332,16 @@ func (*ColumnNotFoundClauseError) ErrorCode() vtrpcpb.Code {
 func (e *ColumnNotFoundClauseError) ErrorState() vterrors.State {
 	return vterrors.BadFieldError
 }
+
+// GeneratedColumnWriteError
+func (e *GeneratedColumnWriteError) Error() string {
+	return fmt.Sprintf("The value specified for generated column '%s' in table '%s' is not allowed.", e.Column, e.Table)
+}
+
+func (*GeneratedColumnWriteError) ErrorCode() vtrpcpb.Code {
+	return vtrpcpb.Code_INVALID_ARGUMENT
+}
+
+func (*GeneratedColumnWriteError) ErrorState() vterrors.State {
+	return vterrors.NonDefaultValueForGeneratedColumn
+}
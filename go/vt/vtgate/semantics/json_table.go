@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// JSONTable is the table produced by a JSON_TABLE expression in the FROM clause.
+//
+//	SELECT jt.id FROM JSON_TABLE('[{"id": 1}]', '$[*]' COLUMNS(id INT PATH '$.id')) AS jt
+//
+// JSON_TABLE is not an AliasedTableExpr, so the analyzer creates one on `dual` to
+// identify the table with, which is never part of the AST of the query.
+type JSONTable struct {
+	tableName   string
+	ASTNode     *sqlparser.AliasedTableExpr
+	Expr        *sqlparser.JSONTableExpr
+	columnNames []string
+	types       []evalengine.Type
+}
+
+var _ TableInfo = (*JSONTable)(nil)
+
+// dependencies implements the TableInfo interface
+func (j *JSONTable) dependencies(colName string, org originable) (dependencies, error) {
+	ts := org.tableSetFor(j.ASTNode)
+	for i, name := range j.columnNames {
+		if strings.EqualFold(name, colName) {
+			return createCertain(ts, ts, j.types[i]), nil
+		}
+	}
+	return &nothing{}, nil
+}
+
+// getTableSet implements the TableInfo interface
+func (j *JSONTable) getTableSet(org originable) TableSet {
+	return org.tableSetFor(j.ASTNode)
+}
+
+// getExprFor implements the TableInfo interface
+func (j *JSONTable) getExprFor(s string) (sqlparser.Expr, error) {
+	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Unknown column '%s' in 'field list'", s)
+}
+
+// IsInfSchema implements the TableInfo interface
+func (j *JSONTable) IsInfSchema() bool {
+	return false
+}
+
+// getColumns implements the TableInfo interface
+func (j *JSONTable) getColumns(bool) []ColumnInfo {
+	cols := make([]ColumnInfo, 0, len(j.columnNames))
+	for i, name := range j.columnNames {
+		cols = append(cols, ColumnInfo{
+			Name: name,
+			Type: j.types[i],
+		})
+	}
+	return cols
+}
+
+// GetAliasedTableExpr implements the TableInfo interface
+func (j *JSONTable) GetAliasedTableExpr() *sqlparser.AliasedTableExpr {
+	return j.ASTNode
+}
+
+// canShortCut implements the TableInfo interface.
+// JSON_TABLE does not read from any keyspace, so it does not stop the shortcut
+func (j *JSONTable) canShortCut() shortCut {
+	return canShortCut
+}
+
+// GetVindexTable implements the TableInfo interface
+func (j *JSONTable) GetVindexTable() *vindexes.Table {
+	return nil
+}
+
+// Name implements the TableInfo interface
+func (j *JSONTable) Name() (sqlparser.TableName, error) {
+	return sqlparser.NewTableName(j.tableName), nil
+}
+
+// authoritative implements the TableInfo interface
+func (j *JSONTable) authoritative() bool {
+	return true
+}
+
+// matches implements the TableInfo interface
+func (j *JSONTable) matches(name sqlparser.TableName) bool {
+	return j.tableName == name.Name.String() && name.Qualifier.IsEmpty()
+}
+
+func newJSONTable(node *sqlparser.JSONTableExpr) *JSONTable {
+	tbl := &JSONTable{
+		tableName: node.Alias.String(),
+		ASTNode:   sqlparser.NewAliasedTableExpr(sqlparser.NewTableName("dual"), node.Alias.String()),
+		Expr:      node,
+	}
+	tbl.addColumns(node.Columns)
+	return tbl
+}
+
+// addColumns adds the columns of the JSON_TABLE, including the ones defined under NESTED PATH
+func (j *JSONTable) addColumns(columns []*sqlparser.JtColumnDefinition) {
+	for _, col := range columns {
+		switch {
+		case col.JtOrdinal != nil:
+			j.columnNames = append(j.columnNames, col.JtOrdinal.Name.String())
+			j.types = append(j.types, evalengine.NewTypeEx(sqltypes.Uint32, collations.CollationBinaryID, false, 0, 0, nil))
+		case col.JtPath != nil:
+			j.columnNames = append(j.columnNames, col.JtPath.Name.String())
+			j.types = append(j.types, jsonTableColumnType(col.JtPath))
+		case col.JtNestedPath != nil:
+			j.addColumns(col.JtNestedPath.Columns)
+		}
+	}
+}
+
+func jsonTableColumnType(col *sqlparser.JtPathColDef) evalengine.Type {
+	if col.JtColExists {
+		return evalengine.NewType(sqltypes.Int32, collations.CollationBinaryID)
+	}
+	typ := col.Type.SQLType()
+	coll := collations.ID(collations.CollationBinaryID)
+	if sqltypes.IsText(typ) {
+		coll = collations.Unknown
+	}
+	return evalengine.NewType(typ, coll)
+}
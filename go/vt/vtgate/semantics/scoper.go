@@ -18,6 +18,7 @@ package semantics
 
 import (
 	"reflect"
+	"slices"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -38,6 +39,17 @@ type (
 		specialExprScopes map[*sqlparser.Literal]*scope
 		statementIDs      map[sqlparser.Statement]TableSet
 		si                SchemaInformation
+
+		// recursiveCTEs contains the common table expressions of WITH RECURSIVE clauses that reference themselves
+		recursiveCTEs map[*sqlparser.CommonTableExpr]any
+		// cteRefs maps the reference that the recursive part of a CTE makes to the CTE itself, to the CTE
+		cteRefs map[*sqlparser.AliasedTableExpr]*cteRef
+	}
+
+	// cteRef is the reference that the recursive part of a recursive CTE makes to the CTE itself
+	cteRef struct {
+		cte  *sqlparser.CommonTableExpr
+		seed *sqlparser.Select
 	}
 
 	scope struct {
@@ -61,6 +73,8 @@ func newScoper(si SchemaInformation) *scoper {
 		specialExprScopes: map[*sqlparser.Literal]*scope{},
 		statementIDs:      map[sqlparser.Statement]TableSet{},
 		si:                si,
+		recursiveCTEs:     map[*sqlparser.CommonTableExpr]any{},
+		cteRefs:           map[*sqlparser.AliasedTableExpr]*cteRef{},
 	}
 }
 
@@ -169,6 +183,10 @@ func (s *scoper) enterJoinScope(cursor *sqlparser.Cursor) {
 		// To create this special context, we will find the parent scope of the select statement involved.
 		currScope := s.currentScope()
 		stmtScope := currScope.findParentScopeOfStatement()
+		if containsLateral(cursor.Node().(sqlparser.TableExpr)) {
+			// a lateral derived table can see the tables that come before it in the FROM clause
+			stmtScope = currScope
+		}
 		nScope := newScope(stmtScope)
 		if stmtScope == nil {
 			// TODO: this feels hacky. revisit with a better plan
@@ -179,6 +197,23 @@ func (s *scoper) enterJoinScope(cursor *sqlparser.Cursor) {
 	}
 }
 
+// containsLateral returns true if the table expression contains a lateral derived table
+func containsLateral(expr sqlparser.TableExpr) bool {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		dt, ok := expr.Expr.(*sqlparser.DerivedTable)
+		return ok && dt.Lateral
+	case *sqlparser.JoinTableExpr:
+		return containsLateral(expr.LeftExpr) || containsLateral(expr.RightExpr)
+	case *sqlparser.JSONTableExpr:
+		// JSON_TABLE can always use the columns of the tables that come before it
+		return true
+	case *sqlparser.ParenTableExpr:
+		return slices.ContainsFunc(expr.Exprs, containsLateral)
+	}
+	return false
+}
+
 func (s *scoper) pushSelectScope(node *sqlparser.Select) {
 	currScope := newScope(s.currentScope())
 	currScope.stmtScope = true
@@ -339,14 +374,16 @@ func newScope(parent *scope) *scope {
 	}
 }
 
-func (s *scope) addCTE(cte *sqlparser.CommonTableExpr) error {
+func (s *scope) addCTE(cte *sqlparser.CommonTableExpr, recursive bool) error {
 	name := cte.ID.String()
 	_, exists := s.ctes[name]
 	if exists {
 		return vterrors.VT03013(name)
 	}
-	if err := checkForInvalidAliasUse(cte, name); err != nil {
-		return err
+	if !recursive {
+		if err := checkForInvalidAliasUse(cte, name); err != nil {
+			return err
+		}
 	}
 	s.ctes[name] = cte
 	return nil
@@ -354,18 +391,41 @@ func (s *scope) addCTE(cte *sqlparser.CommonTableExpr) error {
 
 func checkForInvalidAliasUse(cte *sqlparser.CommonTableExpr, name string) (err error) {
 	// TODO I'm sure there is a better. way, but we need to do this to stop infinite loops from occurring
+	if usesTableName(cte.Subquery.Select, name) {
+		return vterrors.VT12001("do not support CTE that use the CTE alias inside the CTE query")
+	}
+	return nil
+}
+
+// usesTableName returns true if the unqualified table name is used anywhere in the node
+func usesTableName(node sqlparser.SQLNode, name string) (found bool) {
 	down := func(node sqlparser.SQLNode, parent sqlparser.SQLNode) bool {
 		tbl, ok := node.(sqlparser.TableName)
-		if !ok || tbl.Qualifier.NotEmpty() {
-			return err == nil
+		if ok && tbl.Qualifier.IsEmpty() && tbl.Name.String() == name {
+			found = true
 		}
-		if tbl.Name.String() == name {
-			err = vterrors.VT12001("do not support CTE that use the CTE alias inside the CTE query")
+		return !found
+	}
+	_ = sqlparser.CopyOnRewrite(node, down, nil, nil)
+	return found
+}
+
+// referencesTable returns true if the unqualified table name is used as a table anywhere in the node.
+// Unlike usesTableName, columns qualified with the name are not counted
+func referencesTable(node sqlparser.SQLNode, name string) (found bool) {
+	down := func(node sqlparser.SQLNode, parent sqlparser.SQLNode) bool {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			return false
+		case sqlparser.TableName:
+			if node.Qualifier.IsEmpty() && node.Name.String() == name {
+				found = true
+			}
 		}
-		return err == nil
+		return !found
 	}
-	_ = sqlparser.CopyOnRewrite(cte.Subquery.Select, down, nil, nil)
-	return err
+	_ = sqlparser.CopyOnRewrite(node, down, nil, nil)
+	return found
 }
 
 func (s *scope) addTable(info TableInfo) error {
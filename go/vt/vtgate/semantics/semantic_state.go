@@ -595,6 +595,8 @@ func (st *SemTable) ReplaceTableSetFor(id TableSet, t *sqlparser.AliasedTableExp
 		tbl.ASTNode = t
 	case *DerivedTable:
 		tbl.ASTNode = t
+	case *CTETable:
+		tbl.ASTNode = t
 	}
 }
 
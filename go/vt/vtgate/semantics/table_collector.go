@@ -113,6 +113,8 @@ func (tc *tableCollector) up(cursor *sqlparser.Cursor) error {
 	switch node := cursor.Node().(type) {
 	case *sqlparser.AliasedTableExpr:
 		return tc.visitAliasedTableExpr(node)
+	case *sqlparser.JSONTableExpr:
+		return tc.visitJSONTableExpr(node)
 	case *sqlparser.Union:
 		return tc.visitUnion(node)
 	case *sqlparser.RowAlias:
@@ -136,6 +138,13 @@ func (tc *tableCollector) visitAliasedTableExpr(node *sqlparser.AliasedTableExpr
 	return nil
 }
 
+func (tc *tableCollector) visitJSONTableExpr(node *sqlparser.JSONTableExpr) error {
+	tableInfo := newJSONTable(node)
+	tc.Tables = append(tc.Tables, tableInfo)
+	scope := tc.scoper.currentScope()
+	return scope.addTable(tableInfo)
+}
+
 func (tc *tableCollector) visitUnion(union *sqlparser.Union) error {
 	firstSelect := sqlparser.GetFirstSelect(union)
 	expanded, selectExprs := getColumnNames(firstSelect.SelectExprs)
@@ -306,7 +315,11 @@ func (tc *tableCollector) handleTableName(node *sqlparser.AliasedTableExpr, t sq
 
 	tableInfo, found = tc.done[node]
 	if !found {
-		tableInfo, err = getTableInfo(node, t, tc.si, tc.currentDb)
+		if ref, isCTE := tc.scoper.cteRefs[node]; isCTE {
+			tableInfo, err = newCTETable(node, ref.cte, ref.seed, tc.org)
+		} else {
+			tableInfo, err = getTableInfo(node, t, tc.si, tc.currentDb)
+		}
 		if err != nil {
 			return err
 		}
@@ -18,6 +18,7 @@ package semantics
 
 import (
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -61,10 +62,33 @@ func (t *typer) up(cursor *sqlparser.Cursor) error {
 			}
 		}
 		t.m[node] = code.ResolveType(inputType, t.collationEnv)
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch node.Type {
+		case sqlparser.PercentRankExprType, sqlparser.CumeDistExprType:
+			t.m[node] = evalengine.NewType(sqltypes.Float64, collations.CollationBinaryID)
+		default:
+			t.m[node] = evalengine.NewType(sqltypes.Uint64, collations.CollationBinaryID)
+		}
+	case *sqlparser.NtileExpr:
+		t.m[node] = evalengine.NewType(sqltypes.Uint64, collations.CollationBinaryID)
+	case *sqlparser.FirstOrLastValueExpr:
+		t.setNullableTypeFrom(node, node.Expr)
+	case *sqlparser.NTHValueExpr:
+		t.setNullableTypeFrom(node, node.Expr)
+	case *sqlparser.LagLeadExpr:
+		t.setNullableTypeFrom(node, node.Expr)
 	}
 	return nil
 }
 
+// setNullableTypeFrom gives a window function the type of its argument. The
+// result is nullable, since the row it takes the value from might not exist.
+func (t *typer) setNullableTypeFrom(node, arg sqlparser.Expr) {
+	if tt, ok := t.m[arg]; ok {
+		t.m[node] = evalengine.NewTypeEx(tt.Type(), tt.Collation(), true, tt.Size(), tt.Scale(), tt.Values())
+	}
+}
+
 func (t *typer) setTypeFor(node *sqlparser.ColName, typ evalengine.Type) {
 	t.m[node] = typ
 }
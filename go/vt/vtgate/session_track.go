@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/maps"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/sysvars"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// sessionStateTracker reports the session state changes made by a
+// statement to clients that enabled session tracking
+// (CLIENT_SESSION_TRACK): the tracked system variables that changed,
+// and the GTIDs returned by the tablets if the session has
+// session_track_gtids set to OWN_GTID.
+type sessionStateTracker struct {
+	c       *mysql.Conn
+	parser  *sqlparser.Parser
+	session *vtgatepb.Session
+	before  map[string]string
+	done    bool
+}
+
+// newSessionStateTracker returns a tracker for the statement about to
+// be executed, or nil if the client did not enable session tracking.
+func newSessionStateTracker(c *mysql.Conn, parser *sqlparser.Parser, session *vtgatepb.Session) *sessionStateTracker {
+	if !c.SessionTrackEnabled() {
+		return nil
+	}
+	t := &sessionStateTracker{
+		c:       c,
+		parser:  parser,
+		session: session,
+	}
+	t.before = t.trackedVariables()
+	return t
+}
+
+// track records the session state changes on the connection, so they
+// are sent along with the OK packet of the statement. It returns the
+// result to send to the client.
+func (t *sessionStateTracker) track(qr *sqltypes.Result) *sqltypes.Result {
+	if t == nil {
+		return qr
+	}
+	if !t.done {
+		t.done = true
+		after := t.trackedVariables()
+		names := maps.Keys(after)
+		slices.Sort(names)
+		for _, name := range names {
+			if after[name] != t.before[name] {
+				t.c.TrackSystemVariable(name, after[name])
+			}
+		}
+	}
+	if qr != nil && qr.SessionStateChanges != "" && !t.session.GetReadAfterWrite().GetSessionTrackGtids() {
+		qr = qr.ShallowCopy()
+		qr.SessionStateChanges = ""
+	}
+	return qr
+}
+
+// trackedVariables returns the current values of the tracked system
+// variables that are set in the session.
+func (t *sessionStateTracker) trackedVariables() map[string]string {
+	values := make(map[string]string)
+	trackAll := false
+	for _, name := range mysqlSessionTrackSystemVariables {
+		if name == "*" {
+			trackAll = true
+			continue
+		}
+		if value, ok := t.sessionValue(strings.ToLower(name)); ok {
+			values[strings.ToLower(name)] = value
+		}
+	}
+	if trackAll {
+		for name := range t.session.SystemVariables {
+			if value, ok := t.sessionValue(name); ok {
+				values[name] = value
+			}
+		}
+		values[sysvars.Autocommit.Name], _ = t.sessionValue(sysvars.Autocommit.Name)
+		values[sysvars.SessionTrackGTIDs.Name], _ = t.sessionValue(sysvars.SessionTrackGTIDs.Name)
+	}
+	return values
+}
+
+func (t *sessionStateTracker) sessionValue(name string) (string, bool) {
+	switch name {
+	case sysvars.Autocommit.Name:
+		if t.session.Autocommit {
+			return "ON", true
+		}
+		return "OFF", true
+	case sysvars.SessionTrackGTIDs.Name:
+		if t.session.GetReadAfterWrite().GetSessionTrackGtids() {
+			return "OWN_GTID", true
+		}
+		return "OFF", true
+	}
+
+	expr, ok := t.session.SystemVariables[name]
+	if !ok {
+		return "", false
+	}
+	// System variables are stored as SQL expressions.
+	if parsed, err := t.parser.ParseExpr(expr); err == nil {
+		if lit, ok := parsed.(*sqlparser.Literal); ok {
+			return lit.Val, true
+		}
+	}
+	return expr, true
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestSessionStateTracker(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	session := &vtgatepb.Session{
+		SystemVariables: map[string]string{"time_zone": "'+00:00'", "sql_mode": "''"},
+	}
+
+	// Session tracking was not negotiated.
+	c := mysql.GetTestConn()
+	tracker := newSessionStateTracker(c, parser, session)
+	require.Nil(t, tracker)
+	qr := &sqltypes.Result{SessionStateChanges: "uuid:1"}
+	assert.Equal(t, qr, tracker.track(qr))
+
+	c.Capabilities |= mysql.CapabilityClientSessionTrack
+	tracker = newSessionStateTracker(c, parser, session)
+	require.NotNil(t, tracker)
+	assert.Equal(t, map[string]string{"time_zone": "+00:00", "autocommit": "OFF"}, tracker.before)
+
+	session.SystemVariables["time_zone"] = "'+01:00'"
+	session.Autocommit = true
+	assert.Equal(t, map[string]string{"time_zone": "+01:00", "autocommit": "ON"}, tracker.trackedVariables())
+
+	// GTIDs are only sent if the session tracks them.
+	assert.Empty(t, tracker.track(qr).SessionStateChanges)
+	assert.Equal(t, "uuid:1", qr.SessionStateChanges)
+	session.ReadAfterWrite = &vtgatepb.ReadAfterWrite{SessionTrackGtids: true}
+	assert.Equal(t, "uuid:1", tracker.track(qr).SessionStateChanges)
+}
+
+func TestSessionStateTrackerAllVariables(t *testing.T) {
+	defer func(vars []string) {
+		mysqlSessionTrackSystemVariables = vars
+	}(mysqlSessionTrackSystemVariables)
+	mysqlSessionTrackSystemVariables = []string{"*"}
+
+	c := mysql.GetTestConn()
+	c.Capabilities |= mysql.CapabilityClientSessionTrack
+	session := &vtgatepb.Session{
+		SystemVariables: map[string]string{"sql_mode": "'ANSI'"},
+		ReadAfterWrite:  &vtgatepb.ReadAfterWrite{SessionTrackGtids: true},
+	}
+	tracker := newSessionStateTracker(c, sqlparser.NewTestParser(), session)
+	assert.Equal(t, map[string]string{
+		"sql_mode":            "ANSI",
+		"autocommit":          "OFF",
+		"session_track_gtids": "OWN_GTID",
+	}, tracker.before)
+}
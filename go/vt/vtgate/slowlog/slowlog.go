@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package slowlog records the queries of vtgate slower than
+// --slow-query-log-threshold, with their normalized SQL, the fingerprint of
+// their plan and the latencies of their executions on the shards.
+//
+// The slow queries are written as JSON lines to --slow-query-log-file if it
+// is set, and the most recent ones are served as JSON on /debug/slow_queries,
+// where vtadmin fetches them.
+package slowlog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+// DebugPath is the path of the HTTP handler serving the recent slow queries.
+const DebugPath = "/debug/slow_queries"
+
+var (
+	threshold  time.Duration
+	filePath   string
+	recentSize = 100
+
+	slowQueryCount      = stats.NewCounter("SlowQueries", "Queries slower than the slow query log threshold")
+	slowQueryErrorCount = stats.NewCounter("SlowQueryLogErrors", "Errors writing the slow query log file")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&threshold, "slow-query-log-threshold", threshold, "Duration above which the queries are recorded in the slow query log. The slow query log is disabled if 0.")
+	fs.StringVar(&filePath, "slow-query-log-file", filePath, "File the slow queries are appended to as JSON lines. The slow queries are only served on "+DebugPath+" if empty.")
+	fs.IntVar(&recentSize, "slow-query-log-recent-size", recentSize, "Number of the most recent slow queries served on "+DebugPath+".")
+}
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtgate"} {
+		servenv.OnParseFor(cmd, registerFlags)
+	}
+}
+
+// Enabled returns true if the slow query log of vtgate is enabled, in which
+// case the executions of the queries on the shards are recorded.
+func Enabled() bool {
+	return threshold > 0
+}
+
+// Entry is a slow query.
+type Entry struct {
+	Time            time.Time             `json:"time"`
+	SessionUUID     string                `json:"session_uuid,omitempty"`
+	User            string                `json:"user,omitempty"`
+	Keyspace        string                `json:"keyspace,omitempty"`
+	StatementType   string                `json:"statement_type,omitempty"`
+	SQL             string                `json:"sql"`
+	PlanFingerprint string                `json:"plan_fingerprint,omitempty"`
+	Tables          []string              `json:"tables,omitempty"`
+	ShardQueries    uint64                `json:"shard_queries"`
+	Shards          []logstats.ShardStats `json:"shards,omitempty"`
+	RowsAffected    uint64                `json:"rows_affected"`
+	RowsReturned    uint64                `json:"rows_returned"`
+	PlanTime        time.Duration         `json:"plan_time"`
+	ExecuteTime     time.Duration         `json:"execute_time"`
+	CommitTime      time.Duration         `json:"commit_time"`
+	Duration        time.Duration         `json:"duration"`
+	Error           string                `json:"error,omitempty"`
+}
+
+func newEntry(stats *logstats.LogStats) *Entry {
+	return &Entry{
+		Time:            stats.EndTime,
+		SessionUUID:     stats.SessionUUID,
+		User:            stats.ImmediateCaller(),
+		Keyspace:        stats.ActiveKeyspace,
+		StatementType:   stats.StmtType,
+		SQL:             stats.SQL,
+		PlanFingerprint: stats.PlanFingerprint,
+		Tables:          stats.TablesUsed,
+		ShardQueries:    stats.ShardQueries,
+		Shards:          stats.Shards(),
+		RowsAffected:    stats.RowsAffected,
+		RowsReturned:    stats.RowsReturned,
+		PlanTime:        stats.PlanTime,
+		ExecuteTime:     stats.ExecuteTime,
+		CommitTime:      stats.CommitTime,
+		Duration:        stats.TotalTime(),
+		Error:           stats.ErrorStr(),
+	}
+}
+
+// Log records the queries slower than its threshold.
+type Log struct {
+	threshold time.Duration
+
+	mu     sync.Mutex
+	w      io.Writer
+	recent []*Entry
+	next   int
+}
+
+// New returns a Log recording the queries slower than the threshold, keeping
+// the given number of the most recent ones and writing them all to w if it
+// isn't nil.
+func New(threshold time.Duration, recentSize int, w io.Writer) *Log {
+	return &Log{
+		threshold: threshold,
+		w:         w,
+		recent:    make([]*Entry, 0, recentSize),
+	}
+}
+
+// Record records the query if it is slower than the threshold.
+func (l *Log) Record(stats *logstats.LogStats) {
+	if stats.TotalTime() < l.threshold {
+		return
+	}
+	slowQueryCount.Add(1)
+	entry := newEntry(stats)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cap(l.recent) > 0 {
+		if len(l.recent) < cap(l.recent) {
+			l.recent = append(l.recent, entry)
+		} else {
+			l.recent[l.next] = entry
+		}
+		l.next = (l.next + 1) % cap(l.recent)
+	}
+	if l.w != nil {
+		data, err := json.Marshal(entry)
+		if err == nil {
+			_, err = l.w.Write(append(data, '\n'))
+		}
+		if err != nil {
+			slowQueryErrorCount.Add(1)
+			log.Errorf("Failed to write the slow query log: %v", err)
+		}
+	}
+}
+
+// Recent returns the most recent slow queries, from the most recent one.
+func (l *Log) Recent() []*Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]*Entry, 0, len(l.recent))
+	for i := 1; i <= len(l.recent); i++ {
+		entries = append(entries, l.recent[(l.next-i+len(l.recent))%len(l.recent)])
+	}
+	return entries
+}
+
+// ServeHTTP serves the most recent slow queries as JSON, limited to
+// the number given by the optional limit parameter.
+func (l *Log) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	entries := l.Recent()
+	if limit := r.FormValue("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid limit "+limit, http.StatusBadRequest)
+			return
+		}
+		entries = entries[:min(n, len(entries))]
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		log.Errorf("Failed to serve the slow queries: %v", err)
+	}
+}
+
+// subscribe records the queries logged by the query logger.
+func (l *Log) subscribe(queryLogger *streamlog.StreamLogger[*logstats.LogStats]) {
+	ch := queryLogger.Subscribe("SlowQueryLog")
+	go func() {
+		for stats := range ch {
+			l.Record(stats)
+		}
+	}()
+}
+
+// Init creates the slow query log of vtgate from the flags, subscribing it to
+// the queries of the query logger. The slow query log is disabled if its
+// threshold is 0.
+func Init(queryLogger *streamlog.StreamLogger[*logstats.LogStats]) error {
+	if !Enabled() {
+		return nil
+	}
+	var w io.Writer
+	if filePath != "" {
+		file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+		servenv.OnTerm(func() { file.Close() })
+		w = file
+	}
+	slowLog := New(threshold, recentSize, w)
+	slowLog.subscribe(queryLogger)
+	servenv.HTTPHandle(DebugPath, slowLog)
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func newLogStats(sql string, duration time.Duration) *logstats.LogStats {
+	stats := logstats.NewLogStats(context.Background(), "Execute", sql, "", nil)
+	stats.EndTime = stats.StartTime.Add(duration)
+	return stats
+}
+
+func TestRecordThreshold(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(time.Second, 10, &buf)
+
+	l.Record(newLogStats("select 1 from dual", 500*time.Millisecond))
+	assert.Empty(t, l.Recent())
+	assert.Empty(t, buf.String())
+
+	stats := newLogStats("select a from t where id = :id", 2*time.Second)
+	stats.StmtType = "SELECT"
+	stats.PlanFingerprint = "0123456789abcdef"
+	stats.RowsReturned = 3
+	stats.RecordShard(&querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}, time.Second, nil)
+	stats.RecordShard(&querypb.Target{Keyspace: "ks", Shard: "80-", TabletType: topodatapb.TabletType_PRIMARY}, 2*time.Second, errors.New("timeout"))
+	l.Record(stats)
+
+	entries := l.Recent()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "select a from t where id = :id", entries[0].SQL)
+	assert.Equal(t, "0123456789abcdef", entries[0].PlanFingerprint)
+	assert.Equal(t, 2*time.Second, entries[0].Duration)
+	assert.Equal(t, []logstats.ShardStats{
+		{Keyspace: "ks", Shard: "-80", TabletType: "PRIMARY", Duration: time.Second},
+		{Keyspace: "ks", Shard: "80-", TabletType: "PRIMARY", Duration: 2 * time.Second, Error: "timeout"},
+	}, entries[0].Shards)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 1)
+	var written Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &written))
+	assert.Equal(t, entries[0].SQL, written.SQL)
+	assert.Equal(t, entries[0].Shards, written.Shards)
+	assert.EqualValues(t, 3, written.RowsReturned)
+}
+
+func TestRecentSize(t *testing.T) {
+	l := New(time.Second, 2, nil)
+	for _, sql := range []string{"select 1", "select 2", "select 3"} {
+		l.Record(newLogStats(sql, time.Second))
+	}
+
+	var sqls []string
+	for _, entry := range l.Recent() {
+		sqls = append(sqls, entry.SQL)
+	}
+	assert.Equal(t, []string{"select 3", "select 2"}, sqls)
+}
+
+func TestServeHTTP(t *testing.T) {
+	l := New(time.Second, 10, nil)
+	for _, sql := range []string{"select 1", "select 2", "select 3"} {
+		l.Record(newLogStats(sql, time.Second))
+	}
+
+	tcases := []struct {
+		query string
+		code  int
+		sqls  []string
+	}{{
+		query: "",
+		code:  http.StatusOK,
+		sqls:  []string{"select 3", "select 2", "select 1"},
+	}, {
+		query: "?limit=1",
+		code:  http.StatusOK,
+		sqls:  []string{"select 3"},
+	}, {
+		query: "?limit=5",
+		code:  http.StatusOK,
+		sqls:  []string{"select 3", "select 2", "select 1"},
+	}, {
+		query: "?limit=x",
+		code:  http.StatusBadRequest,
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			l.ServeHTTP(w, httptest.NewRequest("GET", DebugPath+tcase.query, nil))
+			require.Equal(t, tcase.code, w.Code)
+			if tcase.code != http.StatusOK {
+				return
+			}
+
+			var entries []*Entry
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+			var sqls []string
+			for _, entry := range entries {
+				sqls = append(sqls, entry.SQL)
+			}
+			assert.Equal(t, tcase.sqls, sqls)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Consistent snapshots: a transaction started WITH CONSISTENT SNAPSHOT, READ ONLY
+// in a session which targets a keyspace begins its snapshots on all the shards of
+// the keyspace when it starts, rather than on each shard when it is first read.
+// The begins are sent to the shards in parallel, so the snapshots are taken at
+// about the same time, and all the reads of the transaction see the data of these
+// snapshots. Each vttablet returns the GTID set of its snapshot, which the session
+// exposes in @@snapshot_gtids. Without a keyspace, the snapshots are begun on each
+// shard when it is first read, as before.
+//
+// The snapshots aren't synchronized by a lock across the shards: a transaction
+// committed on several shards while the snapshots are taken can be seen on some
+// of them only, like with any cross-shard read outside of a 2PC transaction.
+
+// consistentSnapshotShards counts the shards on which consistent snapshots were begun.
+var consistentSnapshotShards = stats.NewCountersWithSingleLabel("ConsistentSnapshotShards", "Shards on which the consistent snapshot transactions of the sessions were begun", "Keyspace")
+
+// isConsistentSnapshot returns true if the transaction characteristics of a
+// begin ask for a read only consistent snapshot.
+func isConsistentSnapshot(txAccessModes []sqlparser.TxAccessMode) bool {
+	return slices.Contains(txAccessModes, sqlparser.WithConsistentSnapshot) && slices.Contains(txAccessModes, sqlparser.ReadOnly)
+}
+
+// beginSnapshot begins the consistent snapshots of the transaction of the session
+// on all the shards of the keyspace it targets.
+func (e *Executor) beginSnapshot(ctx context.Context, safeSession *SafeSession) error {
+	if safeSession.InReservedConn() {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot start a transaction with consistent snapshot, read only on a session with reserved connections")
+	}
+	keyspace, tabletType, dest, err := e.ParseDestinationTarget(safeSession.TargetString)
+	if err != nil {
+		return err
+	}
+	if keyspace == "" {
+		return nil
+	}
+	if dest == nil {
+		dest = key.DestinationAllShards{}
+	}
+	rss, err := e.resolver.resolver.ResolveDestination(ctx, keyspace, tabletType, dest)
+	if err != nil {
+		return err
+	}
+	return e.txConn.BeginSnapshot(ctx, safeSession, rss)
+}
+
+// BeginSnapshot begins consistent snapshot transactions on the shards in
+// parallel, and records them and their GTID sets in the session. All the
+// snapshots are rolled back if one of them fails.
+func (txc *TxConn) BeginSnapshot(ctx context.Context, session *SafeSession, rss []*srvtopo.ResolvedShard) error {
+	options := session.GetOrCreateOptions().CloneVT()
+	options.TransactionIsolation = querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY
+
+	var (
+		wg  sync.WaitGroup
+		rec concurrency.AllErrorRecorder
+	)
+	for _, rs := range rss {
+		wg.Add(1)
+		go func(rs *srvtopo.ResolvedShard) {
+			defer wg.Done()
+			state, err := rs.Gateway.Begin(ctx, rs.Target, options)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			err = session.AppendOrUpdate(&vtgatepb.Session_ShardSession{
+				Target:        rs.Target,
+				TransactionId: state.TransactionID,
+				TabletAlias:   state.TabletAlias,
+			}, txc.mode)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			if state.SessionStateChanges != "" {
+				session.RecordSnapshotGTIDs(rs.Target.Keyspace, rs.Target.Shard, state.SessionStateChanges)
+			}
+			consistentSnapshotShards.Add(rs.Target.Keyspace, 1)
+		}(rs)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		_ = txc.Rollback(ctx, session)
+		return rec.AggrError(vterrors.Aggregate)
+	}
+	return nil
+}
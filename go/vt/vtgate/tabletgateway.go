@@ -238,6 +238,8 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 	var tabletLastUsed *topodatapb.Tablet
 	var err error
 	invalidTablets := make(map[string]bool)
+	// the tablets that failed the previous attempts of the query, when the executor retries it.
+	failed := failedTabletsFromContext(ctx)
 
 	if len(discovery.AllowedTabletTypes) > 0 {
 		var match bool
@@ -309,14 +311,23 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		gw.shuffleTablets(gw.localCell, tablets)
 
 		var th *discovery.TabletHealth
+		skippedFailed := false
 		// skip tablets we tried before
 		for _, t := range tablets {
-			if _, ok := invalidTablets[topoproto.TabletAliasString(t.Tablet.Alias)]; !ok {
+			alias := topoproto.TabletAliasString(t.Tablet.Alias)
+			if failed.contains(alias) {
+				skippedFailed = true
+				continue
+			}
+			if _, ok := invalidTablets[alias]; !ok {
 				th = t
 				break
 			}
 		}
 		if th == nil {
+			if skippedFailed {
+				failed.setExhausted()
+			}
 			// do not override error from last attempt.
 			if err == nil {
 				err = vterrors.VT14002()
@@ -338,6 +349,9 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		var canRetry bool
 		canRetry, err = inner(ctx, target, th.Conn)
 		gw.updateStats(target, startTime, err)
+		if err != nil {
+			failed.add(topoproto.TabletAliasString(tabletLastUsed.Alias))
+		}
 		if canRetry {
 			invalidTablets[topoproto.TabletAliasString(tabletLastUsed.Alias)] = true
 			continue
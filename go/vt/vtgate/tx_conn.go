@@ -19,6 +19,7 @@ package vtgate
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -44,6 +45,9 @@ const nonAtomicCommitWarnMaxShards = 16
 type TxConn struct {
 	tabletGateway *TabletGateway
 	mode          vtgatepb.TransactionMode
+
+	// onCommit is called with the keyspaces of a transaction after it is committed.
+	onCommit func(keyspaces []string)
 }
 
 // NewTxConn builds a new TxConn.
@@ -89,6 +93,10 @@ func (txc *TxConn) Commit(ctx context.Context, session *SafeSession) error {
 	if !session.InTransaction() {
 		return nil
 	}
+	if txc.onCommit != nil {
+		// Some shards may have committed even if the commit fails.
+		defer txc.onCommit(sessionKeyspaces(session))
+	}
 
 	twopc := false
 	switch session.TransactionMode {
@@ -104,6 +112,19 @@ func (txc *TxConn) Commit(ctx context.Context, session *SafeSession) error {
 	return txc.commitNormal(ctx, session)
 }
 
+// sessionKeyspaces returns the keyspaces of the shard sessions of the session.
+func sessionKeyspaces(session *SafeSession) []string {
+	var keyspaces []string
+	for _, shardSessions := range [][]*vtgatepb.Session_ShardSession{session.PreSessions, session.ShardSessions, session.PostSessions} {
+		for _, s := range shardSessions {
+			if !slices.Contains(keyspaces, s.Target.Keyspace) {
+				keyspaces = append(keyspaces, s.Target.Keyspace)
+			}
+		}
+	}
+	return keyspaces
+}
+
 func (txc *TxConn) queryService(ctx context.Context, alias *topodatapb.TabletAlias) (queryservice.QueryService, error) {
 	if alias == nil {
 		return txc.tabletGateway, nil
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"reflect"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -31,6 +32,7 @@ import (
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/key"
@@ -50,6 +52,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/exportsink"
 	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
@@ -118,6 +121,8 @@ type vcursorImpl struct {
 	vm                  VSchemaOperator
 	semTable            *semantics.SemTable
 	warnShardedOnly     bool // when using sharded only features, a warning will be warnings field
+	// cacheResult is set when the result of the query can be served from and stored in the result cache.
+	cacheResult bool
 
 	warnings []*querypb.QueryWarning // any warnings that are accumulated during the planning phase are stored here
 	pv       plancontext.PlannerVersion
@@ -224,15 +229,41 @@ func (vc *vcursorImpl) SQLMode() string {
 	return config.DefaultSQLMode
 }
 
-// MaxMemoryRows returns the maxMemoryRows flag value.
+// MaxMemoryRows returns the maxMemoryRows flag value, or its override by the
+// dynamic vtgate config.
 func (vc *vcursorImpl) MaxMemoryRows() int {
-	return maxMemoryRows
+	return getMaxMemoryRows()
 }
 
 // ExceedsMaxMemoryRows returns a boolean indicating whether the maxMemoryRows value has been exceeded.
 // Returns false if the max memory rows override directive is set to true.
 func (vc *vcursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
-	return !vc.ignoreMaxMemoryRows && numRows > maxMemoryRows
+	return !vc.ignoreMaxMemoryRows && numRows > getMaxMemoryRows()
+}
+
+// CTEMaxRecursionDepth returns the cteMaxRecursionDepth flag value.
+func (vc *vcursorImpl) CTEMaxRecursionDepth() int {
+	return cteMaxRecursionDepth
+}
+
+// ForeignKeyCascadeBatchSize returns the foreignKeyCascadeBatchSize flag value.
+func (vc *vcursorImpl) ForeignKeyCascadeBatchSize() int {
+	return foreignKeyCascadeBatchSize
+}
+
+// ExportLimits returns the selectIntoOutfileMaxRows and selectIntoOutfileMaxBytes flag values.
+func (vc *vcursorImpl) ExportLimits() (int64, int64) {
+	return selectIntoOutfileMaxRows, selectIntoOutfileMaxBytes
+}
+
+// SpillLimits returns the spillDir and spillMaxBytes flag values.
+func (vc *vcursorImpl) SpillLimits() (string, int64) {
+	return spillDir, spillMaxBytes
+}
+
+// CreateExportFile is part of the engine.VCursor interface.
+func (vc *vcursorImpl) CreateExportFile(ctx context.Context, fileName string, overwrite bool) (exportsink.File, error) {
+	return exportsink.Create(ctx, fileName, overwrite)
 }
 
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
@@ -260,6 +291,9 @@ func (vc *vcursorImpl) FindTable(name sqlparser.TableName) (*vindexes.Table, str
 	if destKeyspace == "" {
 		destKeyspace = vc.keyspace
 	}
+	if table := vc.findTempTable(destKeyspace, name.Name.String()); table != nil {
+		return table, destKeyspace, destTabletType, dest, nil
+	}
 	table, err := vc.vschema.FindTable(destKeyspace, name.Name.String())
 	if err != nil {
 		return nil, "", destTabletType, nil, err
@@ -267,6 +301,23 @@ func (vc *vcursorImpl) FindTable(name sqlparser.TableName) (*vindexes.Table, str
 	return table, destKeyspace, destTabletType, dest, err
 }
 
+// findTempTable returns the temporary table created by the session in the sharded keyspace,
+// as a table pinned to the shard it lives on. Like in MySQL, it shadows the table with the same name.
+func (vc *vcursorImpl) findTempTable(keyspace, name string) *vindexes.Table {
+	if !vc.safeSession.HasTempTable(keyspace, name) {
+		return nil
+	}
+	ks, ok := vc.vschema.Keyspaces[keyspace]
+	if !ok || !ks.Keyspace.Sharded {
+		return nil
+	}
+	return &vindexes.Table{
+		Name:     sqlparser.NewIdentifierCS(name),
+		Keyspace: ks.Keyspace,
+		Pinned:   engine.TempTablePin,
+	}
+}
+
 func (vc *vcursorImpl) FindView(name sqlparser.TableName) sqlparser.SelectStatement {
 	ks, _, _, err := vc.executor.ParseDestinationTarget(name.Qualifier.String())
 	if err != nil {
@@ -310,6 +361,9 @@ func (vc *vcursorImpl) FindTableOrVindex(name sqlparser.TableName) (*vindexes.Ta
 	if destKeyspace == "" {
 		destKeyspace = vc.getActualKeyspace()
 	}
+	if table := vc.findTempTable(destKeyspace, name.Name.String()); table != nil {
+		return table, nil, destKeyspace, destTabletType, dest, nil
+	}
 	table, vindex, err := vc.vschema.FindTableOrVindex(destKeyspace, name.Name.String(), vc.tabletType)
 	if err != nil {
 		return nil, nil, "", destTabletType, nil, err
@@ -478,30 +532,42 @@ func (vc *vcursorImpl) TargetString() string {
 const MaxBufferingRetries = 3
 
 func (vc *vcursorImpl) ExecutePrimitive(ctx context.Context, primitive engine.Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	span, ctx := startPrimitiveSpan(ctx, primitive)
+	defer span.Finish()
+	start := time.Now()
 	for try := 0; try < MaxBufferingRetries; try++ {
 		res, err := primitive.TryExecute(ctx, vc, bindVars, wantfields)
 		if err != nil && vterrors.RootCause(err) == buffer.ShardMissingError {
 			continue
 		}
+		vc.tracePrimitive(primitive, start, res)
 		return res, err
 	}
 	return nil, vterrors.New(vtrpcpb.Code_UNAVAILABLE, "upstream shards are not available")
 }
 
 func (vc *vcursorImpl) ExecutePrimitiveStandalone(ctx context.Context, primitive engine.Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	span, ctx := startPrimitiveSpan(ctx, primitive)
+	defer span.Finish()
 	// clone the vcursorImpl with a new session.
 	newVC := vc.cloneWithAutocommitSession()
+	start := time.Now()
 	for try := 0; try < MaxBufferingRetries; try++ {
 		res, err := primitive.TryExecute(ctx, newVC, bindVars, wantfields)
 		if err != nil && vterrors.RootCause(err) == buffer.ShardMissingError {
 			continue
 		}
+		vc.tracePrimitive(primitive, start, res)
 		return res, err
 	}
 	return nil, vterrors.New(vtrpcpb.Code_UNAVAILABLE, "upstream shards are not available")
 }
 
 func (vc *vcursorImpl) StreamExecutePrimitive(ctx context.Context, primitive engine.Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	span, ctx := startPrimitiveSpan(ctx, primitive)
+	defer span.Finish()
+	callback, done := vc.traceStreamPrimitive(primitive, callback)
+	defer done()
 	for try := 0; try < MaxBufferingRetries; try++ {
 		err := primitive.TryStreamExecute(ctx, vc, bindVars, wantfields, callback)
 		if err != nil && vterrors.RootCause(err) == buffer.ShardMissingError {
@@ -513,8 +579,12 @@ func (vc *vcursorImpl) StreamExecutePrimitive(ctx context.Context, primitive eng
 }
 
 func (vc *vcursorImpl) StreamExecutePrimitiveStandalone(ctx context.Context, primitive engine.Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(result *sqltypes.Result) error) error {
+	span, ctx := startPrimitiveSpan(ctx, primitive)
+	defer span.Finish()
 	// clone the vcursorImpl with a new session.
 	newVC := vc.cloneWithAutocommitSession()
+	callback, done := vc.traceStreamPrimitive(primitive, callback)
+	defer done()
 	for try := 0; try < MaxBufferingRetries; try++ {
 		err := primitive.TryStreamExecute(ctx, newVC, bindVars, wantfields, callback)
 		if err != nil && vterrors.RootCause(err) == buffer.ShardMissingError {
@@ -525,6 +595,47 @@ func (vc *vcursorImpl) StreamExecutePrimitiveStandalone(ctx context.Context, pri
 	return vterrors.New(vtrpcpb.Code_UNAVAILABLE, "upstream shards are not available")
 }
 
+// startPrimitiveSpan starts the span of an execution of the primitive, named after its type.
+func startPrimitiveSpan(ctx context.Context, primitive engine.Primitive) (trace.Span, context.Context) {
+	typ := reflect.TypeOf(primitive)
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	span, ctx := trace.NewSpan(ctx, "engine."+typ.Name())
+	span.Annotate("route_type", primitive.RouteType())
+	return span, ctx
+}
+
+// tracePrimitive records the execution of the primitive for VEXPLAIN TRACE, if it is enabled.
+func (vc *vcursorImpl) tracePrimitive(primitive engine.Primitive, start time.Time, res *sqltypes.Result) {
+	if vc.safeSession.tracing == nil {
+		return
+	}
+	var rows int
+	var bytes int64
+	if res != nil {
+		rows, bytes = len(res.Rows), rowsSize(res.Rows)
+	}
+	vc.safeSession.tracing.record(primitive, start, rows, bytes)
+}
+
+// traceStreamPrimitive wraps the callback of a streaming execution of the primitive to
+// record it for VEXPLAIN TRACE, if it is enabled. The returned func ends the recording.
+func (vc *vcursorImpl) traceStreamPrimitive(primitive engine.Primitive, callback func(*sqltypes.Result) error) (func(*sqltypes.Result) error, func()) {
+	if vc.safeSession.tracing == nil {
+		return callback, func() {}
+	}
+	start := time.Now()
+	var rows, bytes atomic.Int64
+	return func(result *sqltypes.Result) error {
+			rows.Add(int64(len(result.Rows)))
+			bytes.Add(rowsSize(result.Rows))
+			return callback(result)
+		}, func() {
+			vc.safeSession.tracing.record(primitive, start, int(rows.Load()), bytes.Load())
+		}
+}
+
 // Execute is part of the engine.VCursor interface.
 func (vc *vcursorImpl) Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error) {
 	session := vc.safeSession
@@ -857,7 +968,7 @@ func (vc *vcursorImpl) GetQueryTimeout(queryTimeoutFromComments int) int {
 	if sessionQueryTimeout != 0 {
 		return sessionQueryTimeout
 	}
-	return queryTimeout
+	return getQueryTimeout()
 }
 
 // SetClientFoundRows implements the SessionActions interface
@@ -955,6 +1066,17 @@ func (vc *vcursorImpl) SetSessionEnableSystemSettings(_ context.Context, allow b
 	return nil
 }
 
+// SetScatterErrorsAsWarnings implements the SessionActions interface
+func (vc *vcursorImpl) SetScatterErrorsAsWarnings(_ context.Context, enabled bool) error {
+	vc.safeSession.SetScatterErrorsAsWarnings(enabled)
+	return nil
+}
+
+// GetScatterErrorsAsWarnings implements the SessionActions interface
+func (vc *vcursorImpl) GetScatterErrorsAsWarnings() bool {
+	return vc.safeSession.GetScatterErrorsAsWarnings()
+}
+
 // GetSessionEnableSystemSettings implements the SessionActions interface
 func (vc *vcursorImpl) GetSessionEnableSystemSettings() bool {
 	return vc.safeSession.GetSessionEnableSystemSettings()
@@ -975,11 +1097,21 @@ func (vc *vcursorImpl) SetSessionTrackGTIDs(enable bool) {
 	vc.safeSession.SetSessionTrackGtids(enable)
 }
 
+// SetReadYourWrites implements the SessionActions interface
+func (vc *vcursorImpl) SetReadYourWrites(enable bool) {
+	vc.safeSession.SetReadYourWrites(enable)
+}
+
 // HasCreatedTempTable implements the SessionActions interface
 func (vc *vcursorImpl) HasCreatedTempTable() {
 	vc.safeSession.GetOrCreateOptions().HasCreatedTempTables = true
 }
 
+// SetTempTable implements the SessionActions interface
+func (vc *vcursorImpl) SetTempTable(keyspace, table string, exists bool) {
+	vc.safeSession.SetTempTable(keyspace, table, exists)
+}
+
 // GetWarnings implements the SessionActions interface
 func (vc *vcursorImpl) GetWarnings() []*querypb.QueryWarning {
 	return vc.safeSession.GetWarnings()
@@ -1263,6 +1395,7 @@ func (vc *vcursorImpl) ReleaseLock(ctx context.Context) error {
 func (vc *vcursorImpl) cloneWithAutocommitSession() *vcursorImpl {
 	safeSession := NewAutocommitSession(vc.safeSession.Session)
 	safeSession.logging = vc.safeSession.logging
+	safeSession.tracing = vc.safeSession.tracing
 	return &vcursorImpl{
 		safeSession:     safeSession,
 		keyspace:        vc.keyspace,
@@ -1288,6 +1421,23 @@ func (vc *vcursorImpl) VExplainLogging() {
 func (vc *vcursorImpl) GetVExplainLogs() []engine.ExecuteEntry {
 	return vc.safeSession.logging.GetLogs()
 }
+
+func (vc *vcursorImpl) RecordMemoryUsage(primitive engine.Primitive, usage engine.MemoryUsage) {
+	vc.safeSession.logging.recordMemoryUsage(primitive, usage)
+}
+
+func (vc *vcursorImpl) GetVExplainMemoryUsage() map[engine.Primitive]*engine.MemoryUsage {
+	return vc.safeSession.logging.GetMemoryUsage()
+}
+
+func (vc *vcursorImpl) VExplainTracing() {
+	vc.safeSession.EnableTracing()
+}
+
+func (vc *vcursorImpl) GetVExplainTrace() map[engine.Primitive]*engine.PrimitiveStats {
+	return vc.safeSession.tracing.GetStats()
+}
+
 func (vc *vcursorImpl) FindRoutedShard(keyspace, shard string) (keyspaceName string, err error) {
 	return vc.vschema.FindRoutedShard(keyspace, shard)
 }
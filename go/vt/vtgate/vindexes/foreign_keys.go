@@ -174,3 +174,17 @@ func (vschema *VSchema) AddUniqueKey(ksname, tblName string, exprs sqlparser.Exp
 	tbl.UniqueKeys = append(tbl.UniqueKeys, exprs)
 	return nil
 }
+
+// AddExpressionIndex is for testing only.
+func (vschema *VSchema) AddExpressionIndex(ksname, tblName, idxName string, exprs sqlparser.Exprs) error {
+	ks, ok := vschema.Keyspaces[ksname]
+	if !ok {
+		return fmt.Errorf("keyspace %s not found in vschema", ksname)
+	}
+	tbl, ok := ks.Tables[tblName]
+	if !ok {
+		return fmt.Errorf("table %s not found in keyspace %s", tblName, ksname)
+	}
+	tbl.ExpressionIndexes = append(tbl.ExpressionIndexes, &ExpressionIndex{Name: idxName, Exprs: exprs})
+	return nil
+}
@@ -206,6 +206,7 @@ func (ln *LookupNonUnique) UnknownParams() []string {
 // The following fields are optional:
 //
 //	autocommit: setting this to "true" will cause inserts to upsert and deletes to be ignored.
+//	async: in this mode, the lookup table is maintained asynchronously by the VReplication workflow of the vindex.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 //	no_verify: in this mode, Verify will always succeed.
 func newLookup(name string, m map[string]string) (Vindex, error) {
@@ -233,6 +234,7 @@ func newLookup(name string, m map[string]string) (Vindex, error) {
 	if err := lookup.lkp.Init(m, cc.autocommit, upsert, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lookup.lkp.Async = cc.async
 	return lookup, nil
 }
 
@@ -279,6 +281,7 @@ func (lu *LookupUnique) AutoCommitEnabled() bool {
 // The following fields are optional:
 //
 //	autocommit: setting this to "true" will cause deletes to be ignored.
+//	async: in this mode, the lookup table is maintained asynchronously by the VReplication workflow of the vindex.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 func newLookupUnique(name string, m map[string]string) (Vindex, error) {
 	lu := &LookupUnique{
@@ -304,6 +307,7 @@ func newLookupUnique(name string, m map[string]string) (Vindex, error) {
 	if err := lu.lkp.Init(m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lu.lkp.Async = cc.async
 	return lu, nil
 }
 
@@ -76,6 +76,7 @@ type LookupHash struct {
 // The following fields are optional:
 //
 //	autocommit: setting this to "true" will cause inserts to upsert and deletes to be ignored.
+//	async: in this mode, the lookup table is maintained asynchronously by the VReplication workflow of the vindex.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 func newLookupHash(name string, m map[string]string) (Vindex, error) {
 	lh := &LookupHash{
@@ -97,6 +98,7 @@ func newLookupHash(name string, m map[string]string) (Vindex, error) {
 	if err := lh.lkp.Init(m, cc.autocommit, upsert, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lh.lkp.Async = cc.async
 	return lh, nil
 }
 
@@ -286,6 +288,7 @@ var _ LookupPlanable = (*LookupHashUnique)(nil)
 // The following fields are optional:
 //
 //	autocommit: setting this to "true" will cause deletes to be ignored.
+//	async: in this mode, the lookup table is maintained asynchronously by the VReplication workflow of the vindex.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 func newLookupHashUnique(name string, m map[string]string) (Vindex, error) {
 	lhu := &LookupHashUnique{
@@ -306,6 +309,7 @@ func newLookupHashUnique(name string, m map[string]string) (Vindex, error) {
 	if err := lhu.lkp.Init(m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lhu.lkp.Async = cc.async
 	return lhu, nil
 }
 
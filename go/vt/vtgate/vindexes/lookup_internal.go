@@ -39,6 +39,7 @@ const (
 
 	lookupCommonParamAutocommit           = "autocommit"
 	lookupCommonParamMultiShardAutocommit = "multi_shard_autocommit"
+	lookupCommonParamAsync                = "async"
 
 	lookupInternalParamTable       = "table"
 	lookupInternalParamFrom        = "from"
@@ -60,6 +61,7 @@ var (
 		append(make([]string, 0), lookupInternalParams...),
 		lookupCommonParamAutocommit,
 		lookupCommonParamMultiShardAutocommit,
+		lookupCommonParamAsync,
 	)
 
 	// lookupInternalParams are used by both lookup_* vindexes and the newer
@@ -85,6 +87,7 @@ type lookupInternal struct {
 	IgnoreNulls             bool     `json:"ignore_nulls,omitempty"`
 	BatchLookup             bool     `json:"batch_lookup,omitempty"`
 	ReadLock                string   `json:"read_lock,omitempty"`
+	Async                   bool     `json:"async,omitempty"`
 	sel, selTxDml, ver, del string   // sel: map query, ver: verify query, del: delete query
 }
 
@@ -213,6 +216,14 @@ func (lkp *lookupInternal) Verify(ctx context.Context, vcursor VCursor, ids, val
 }
 
 func (lkp *lookupInternal) VerifyCustom(ctx context.Context, vcursor VCursor, ids, values []sqltypes.Value, co vtgatepb.CommitOrder) ([]bool, error) {
+	if lkp.Async {
+		// The lookup table may not have caught up with the owner table yet.
+		out := make([]bool, len(ids))
+		for i := range ids {
+			out[i] = true
+		}
+		return out, nil
+	}
 	out := make([]bool, len(ids))
 	for i, id := range ids {
 		bindVars := map[string]*querypb.BindVariable{
@@ -273,6 +284,10 @@ func (v *sorter) Swap(i, j int) {
 // Create(vcursor, [[value_a0, value_b0,], [value_a1, value_b1]], [binary(value_c0), binary(value_c1)])
 // Notice that toValues contains the computed binary value of the keyspace_id.
 func (lkp *lookupInternal) Create(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, toValues []sqltypes.Value, ignoreMode bool) error {
+	// In async mode, the lookup table is maintained by the VReplication workflow of the vindex.
+	if lkp.Async {
+		return nil
+	}
 	if lkp.Autocommit {
 		return lkp.createCustom(ctx, vcursor, rowsColValues, toValues, ignoreMode, vtgatepb.CommitOrder_AUTOCOMMIT)
 	}
@@ -369,7 +384,8 @@ nextRow:
 // Delete(vcursor, [[valuea, valueb]], 52CB7B1B31B2222E)
 func (lkp *lookupInternal) Delete(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value, value sqltypes.Value, co vtgatepb.CommitOrder) error {
 	// In autocommit mode, it's not safe to delete. So, it's a no-op.
-	if lkp.Autocommit {
+	// In async mode, the lookup table is maintained by the VReplication workflow of the vindex.
+	if lkp.Autocommit || lkp.Async {
 		return nil
 	}
 	if len(rowsColValues) == 0 {
@@ -423,6 +439,7 @@ func (lkp *lookupInternal) query() (selQuery string, arguments []string) {
 type commonConfig struct {
 	autocommit           bool
 	multiShardAutocommit bool
+	async                bool
 }
 
 func parseCommonConfig(m map[string]string) (*commonConfig, error) {
@@ -434,6 +451,9 @@ func parseCommonConfig(m map[string]string) (*commonConfig, error) {
 	if c.multiShardAutocommit, err = boolFromMap(m, lookupCommonParamMultiShardAutocommit); err != nil {
 		return nil, err
 	}
+	if c.async, err = boolFromMap(m, lookupCommonParamAsync); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
 
@@ -407,7 +407,7 @@ func TestLookupNonUniqueMap(t *testing.T) {
 
 	// Test query fail.
 	vc.mustFail = true
-	_, err = lnu.Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	_, err = lnu.(SingleColumn).Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
 	require.EqualError(t, err, "lookup.Map: execute failed")
 }
 
@@ -511,7 +511,7 @@ func TestLookupNonUniqueVerify(t *testing.T) {
 	lnu = createLookup(t, "lookup", true)
 	vc.queries = nil
 
-	got, err := lnu.Verify(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}, [][]byte{[]byte(""), []byte("")})
+	got, err := lnu.(SingleColumn).Verify(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}, [][]byte{[]byte(""), []byte("")})
 	require.NoError(t, err)
 	assert.Empty(t, vc.queries, "lookup verify queries")
 	utils.MustMatch(t, []bool{true, true}, got)
@@ -685,6 +685,47 @@ func TestLookupNonUniqueCreateAutocommit(t *testing.T) {
 	assert.Equal(t, 1, vc.autocommits, "autocommits")
 }
 
+func TestLookupNonUniqueAsync(t *testing.T) {
+	lnu, err := CreateVindex("lookup", "lookup", map[string]string{
+		"table": "t",
+		"from":  "from1,from2",
+		"to":    "toc",
+		"async": "true",
+	})
+	require.NoError(t, err)
+	require.Empty(t, lnu.(ParamValidating).UnknownParams())
+	vc := &vcursor{numRows: 1}
+
+	// the lookup table is not written by the vindex.
+	rows := [][]sqltypes.Value{{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}}
+	err = lnu.(Lookup).Create(context.Background(), vc, rows, [][]byte{[]byte("test1")}, false /* ignoreMode */)
+	require.NoError(t, err)
+	err = lnu.(Lookup).Update(context.Background(), vc, rows[0], []byte("test1"), []sqltypes.Value{sqltypes.NewInt64(3), sqltypes.NewInt64(4)})
+	require.NoError(t, err)
+	err = lnu.(Lookup).Delete(context.Background(), vc, rows, []byte("test1"))
+	require.NoError(t, err)
+	assert.Empty(t, vc.queries)
+
+	// the rows may be missing from the lookup table.
+	got, err := lnu.(SingleColumn).Verify(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)}, [][]byte{[]byte("test1")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true}, got)
+	assert.Empty(t, vc.queries)
+
+	// but it is read.
+	_, err = lnu.(SingleColumn).Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 1)
+
+	_, err = CreateVindex("lookup", "lookup", map[string]string{
+		"table": "t",
+		"from":  "from1,from2",
+		"to":    "toc",
+		"async": "sometimes",
+	})
+	require.EqualError(t, err, "async value must be 'true' or 'false': 'sometimes'")
+}
+
 func TestLookupNonUniqueDelete(t *testing.T) {
 	lnu := createLookup(t, "lookup", false /* writeOnly */)
 	vc := &vcursor{}
@@ -74,6 +74,7 @@ type LookupUnicodeLooseMD5Hash struct {
 // The following fields are optional:
 //
 //	autocommit: setting this to "true" will cause inserts to upsert and deletes to be ignored.
+//	async: in this mode, the lookup table is maintained asynchronously by the VReplication workflow of the vindex.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 func newLookupUnicodeLooseMD5Hash(name string, m map[string]string) (Vindex, error) {
 	lh := &LookupUnicodeLooseMD5Hash{
@@ -94,6 +95,7 @@ func newLookupUnicodeLooseMD5Hash(name string, m map[string]string) (Vindex, err
 	if err := lh.lkp.Init(m, cc.autocommit, cc.autocommit || cc.multiShardAutocommit, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lh.lkp.Async = cc.async
 	return lh, nil
 }
 
@@ -264,6 +266,7 @@ type LookupUnicodeLooseMD5HashUnique struct {
 // The following fields are optional:
 //
 //	autocommit: setting this to "true" will cause deletes to be ignored.
+//	async: in this mode, the lookup table is maintained asynchronously by the VReplication workflow of the vindex.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 func newLookupUnicodeLooseMD5HashUnique(name string, m map[string]string) (Vindex, error) {
 	lhu := &LookupUnicodeLooseMD5HashUnique{
@@ -284,6 +287,7 @@ func newLookupUnicodeLooseMD5HashUnique(name string, m map[string]string) (Vinde
 	if err := lhu.lkp.Init(m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lhu.lkp.Async = cc.async
 	return lhu, nil
 }
 
@@ -25,12 +25,14 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
 )
 
 var (
-	_ MultiColumn = (*MultiCol)(nil)
+	_ MultiColumn   = (*MultiCol)(nil)
+	_ RangeMappable = (*MultiCol)(nil)
 )
 
 type MultiCol struct {
@@ -121,6 +123,74 @@ func (m *MultiCol) PartialVindex() bool {
 	return true
 }
 
+// MapRange implements the RangeMappable interface. The range of the leading column maps
+// to a keyspace range only when its vindex preserves the order of the values: 'numeric'
+// for non-negative integers and 'binary' for binary strings. Other ranges are sent to
+// all the shards.
+func (m *MultiCol) MapRange(ctx context.Context, vcursor VCursor, start, end sqltypes.Value) (key.Destination, error) {
+	colVdx := m.columnVdx[0]
+	if m.columnBytes[0] == 0 || !preservesOrder(colVdx, start) || !preservesOrder(colVdx, end) {
+		return key.DestinationAllShards{}, nil
+	}
+	var begin, stop []byte
+	if !start.IsNull() {
+		hash, err := colVdx.Hash(start)
+		if err != nil {
+			return key.DestinationNone{}, nil
+		}
+		begin = m.leadingPrefix(hash)
+	}
+	if !end.IsNull() {
+		hash, err := colVdx.Hash(end)
+		if err != nil {
+			return key.DestinationNone{}, nil
+		}
+		// the end of the range is exclusive, and an overflow leaves it open.
+		stop = addOne(m.leadingPrefix(hash))
+	}
+	if begin != nil && stop != nil && bytes.Compare(begin, stop) >= 0 {
+		return key.DestinationNone{}, nil
+	}
+	return key.DestinationKeyRange{
+		KeyRange: &topodatapb.KeyRange{
+			Start: begin,
+			End:   stop,
+		},
+	}, nil
+}
+
+// leadingPrefix returns a copy of the bytes of the hash that the leading column
+// contributes to the keyspace id.
+func (m *MultiCol) leadingPrefix(hash []byte) []byte {
+	prefix := hash
+	if len(prefix) > m.columnBytes[0] {
+		prefix = prefix[:m.columnBytes[0]]
+	}
+	return append([]byte(nil), prefix...)
+}
+
+// preservesOrder returns true if the hash of the vindex is in the same order as
+// the value, so that it can be used to map a range of values.
+func preservesOrder(vdx Hashing, value sqltypes.Value) bool {
+	if value.IsNull() {
+		return true
+	}
+	switch vdx.(type) {
+	case *Numeric:
+		if value.IsUnsigned() {
+			return true
+		}
+		if !value.IsSigned() {
+			return false
+		}
+		num, err := value.ToInt64()
+		return err == nil && num >= 0
+	case *Binary:
+		return value.IsBinary()
+	}
+	return false
+}
+
 func (m *MultiCol) mapKsid(colValues []sqltypes.Value) (bool, []byte, error) {
 	if m.noOfCols < len(colValues) {
 		// wrong number of column values were passed
@@ -218,17 +288,22 @@ func getColumnBytes(m map[string]string, colCount int) (map[int]int, error) {
 		if err != nil {
 			return nil, err
 		}
+		if colByte < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column bytes count cannot be negative in the parameter '%s'", paramColumnBytes)
+		}
 		bytesUsed = bytesUsed + colByte
 		columnBytes[idx] = colByte
 	}
-	pendingCol := colCount - len(columnBytes)
-	remainingBytes := 8 - bytesUsed
-	if pendingCol > remainingBytes {
+	if bytesUsed > 8 {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column bytes count exceeds the keyspace id length (total bytes count cannot exceed 8 bytes) in the parameter '%s'", paramColumnBytes)
 	}
+	pendingCol := colCount - len(columnBytes)
+	remainingBytes := 8 - bytesUsed
 	if pendingCol <= 0 {
 		return columnBytes, nil
 	}
+	// the columns are given a share of the remaining bytes in order. Once they are all
+	// used, the next columns are given none: they don't take part in the keyspace id.
 	for idx := 0; idx < colCount; idx++ {
 		if _, defined := columnBytes[idx]; defined {
 			continue
@@ -250,8 +325,8 @@ func getColumnCount(m map[string]string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	if colCount > 8 || colCount < 1 {
-		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "number of columns should be between 1 and 8 in the parameter '%s'", paramColumnCount)
+	if colCount < 1 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "number of columns should be at least 1 in the parameter '%s'", paramColumnCount)
 	}
 	return colCount, nil
 }
@@ -61,7 +61,7 @@ func TestMulticolCreateVindex(t *testing.T) {
 				"column_count": "0",
 			},
 			0,
-			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "number of columns should be between 1 and 8 in the parameter 'column_count'"),
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "number of columns should be at least 1 in the parameter 'column_count'"),
 			nil,
 		),
 		multicolCreateVindexTestCase(
@@ -74,12 +74,12 @@ func TestMulticolCreateVindex(t *testing.T) {
 			nil,
 		),
 		multicolCreateVindexTestCase(
-			"column count 9 invalid",
+			"column count 10 ok",
 			map[string]string{
-				"column_count": "9",
+				"column_count": "10",
 			},
-			0,
-			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "number of columns should be between 1 and 8 in the parameter 'column_count'"),
+			10,
+			nil,
 			nil,
 		),
 		multicolCreateVindexTestCase(
@@ -112,6 +112,26 @@ func TestMulticolCreateVindex(t *testing.T) {
 			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column bytes count exceeds the keyspace id length (total bytes count cannot exceed 8 bytes) in the parameter 'column_bytes'"),
 			nil,
 		),
+		multicolCreateVindexTestCase(
+			"column bytes negative invalid",
+			map[string]string{
+				"column_count": "3",
+				"column_bytes": "4,-1",
+			},
+			0,
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "column bytes count cannot be negative in the parameter 'column_bytes'"),
+			nil,
+		),
+		multicolCreateVindexTestCase(
+			"column bytes with columns left out of the keyspace id ok",
+			map[string]string{
+				"column_count": "4",
+				"column_bytes": "4,4",
+			},
+			4,
+			nil,
+			nil,
+		),
 		multicolCreateVindexTestCase(
 			"column vindex ok",
 			map[string]string{
@@ -253,3 +273,94 @@ func TestMultiColMap(t *testing.T) {
 	}
 	assert.Equal(t, want, got)
 }
+
+func TestMultiColMapManyColumns(t *testing.T) {
+	vindex, err := CreateVindex("multicol", "multicol_many", map[string]string{
+		"column_count":  "10",
+		"column_vindex": "numeric,numeric,numeric,numeric,numeric,numeric,numeric,numeric,numeric,numeric",
+	})
+	require.NoError(t, err)
+	mutiCol := vindex.(MultiColumn)
+
+	row := make([]sqltypes.Value, 0, 10)
+	for i := 1; i <= 10; i++ {
+		row = append(row, sqltypes.NewInt64(int64(i)))
+	}
+	got, err := mutiCol.Map(context.Background(), nil, [][]sqltypes.Value{row, row[:9], row[:2]})
+	require.NoError(t, err)
+
+	// the last two columns are given no bytes of the keyspace id.
+	want := []key.Destination{
+		key.DestinationKeyspaceID("\x00\x00\x00\x00\x00\x00\x00\x00"),
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00\x00\x00\x00\x00\x00\x00\x00"), End: []byte("\x00\x00\x00\x00\x00\x00\x00\x01")}},
+		key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00\x00"), End: []byte("\x00\x01")}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestMultiColMapRange(t *testing.T) {
+	vindex, err := CreateVindex("multicol", "multicol_range", map[string]string{
+		"column_count":  "2",
+		"column_bytes":  "1,7",
+		"column_vindex": "numeric,hash",
+	})
+	require.NoError(t, err)
+	rangeMappable := vindex.(RangeMappable)
+
+	tcases := []struct {
+		name       string
+		start, end sqltypes.Value
+		want       key.Destination
+	}{{
+		name:  "closed range",
+		start: sqltypes.NewInt64(16),
+		end:   sqltypes.NewInt64(32),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x00"), End: []byte("\x01")}},
+	}, {
+		name:  "open start",
+		start: sqltypes.NULL,
+		end:   sqltypes.NewUint64(0x0300000000000000),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{End: []byte("\x04")}},
+	}, {
+		name:  "open end",
+		start: sqltypes.NewUint64(0x8000000000000000),
+		end:   sqltypes.NULL,
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\x80")}},
+	}, {
+		name:  "end overflows",
+		start: sqltypes.NewUint64(0xf000000000000000),
+		end:   sqltypes.NewUint64(0xffffffffffffffff),
+		want:  key.DestinationKeyRange{KeyRange: &topodatapb.KeyRange{Start: []byte("\xf0")}},
+	}, {
+		name:  "empty range",
+		start: sqltypes.NewUint64(0x0300000000000000),
+		end:   sqltypes.NewUint64(0x0100000000000000),
+		want:  key.DestinationNone{},
+	}, {
+		name:  "negative value",
+		start: sqltypes.NewInt64(-1),
+		end:   sqltypes.NewInt64(10),
+		want:  key.DestinationAllShards{},
+	}, {
+		name:  "non integral value",
+		start: sqltypes.NewVarChar("1"),
+		end:   sqltypes.NewInt64(10),
+		want:  key.DestinationAllShards{},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			got, err := rangeMappable.MapRange(context.Background(), nil, tcase.start, tcase.end)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.want, got)
+		})
+	}
+
+	// the hash of the default vindex does not preserve the order of the values.
+	vindex, err = CreateVindex("multicol", "multicol_range_hash", map[string]string{
+		"column_count": "2",
+	})
+	require.NoError(t, err)
+	got, err := vindex.(RangeMappable).MapRange(context.Background(), nil, sqltypes.NewInt64(1), sqltypes.NewInt64(10))
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationAllShards{}, got)
+}
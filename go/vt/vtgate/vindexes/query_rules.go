@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// QueryRule is a query rule of the SrvVSchema, ready to be matched against the queries.
+type QueryRule struct {
+	Name               string
+	Description        string
+	Action             vschemapb.QueryRule_Action
+	RedirectTabletType topodatapb.TabletType
+
+	query      *regexp.Regexp
+	users      []string
+	tables     []string
+	planTypes  []string
+	routeTypes []string
+
+	source *vschemapb.QueryRule
+	Error  error
+}
+
+// MarshalJSON returns a JSON representation of QueryRule.
+func (qr *QueryRule) MarshalJSON() ([]byte, error) {
+	if qr.Error != nil {
+		return json.Marshal(qr.Error.Error())
+	}
+	return json2.MarshalPB(qr.source)
+}
+
+// BuildQueryRule validates a query rule and prepares it to be matched against the queries.
+func BuildQueryRule(source *vschemapb.QueryRule) (*QueryRule, error) {
+	if source.Name == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query rule must have a name")
+	}
+	qr := &QueryRule{
+		Name:        source.Name,
+		Description: source.Description,
+		Action:      source.Action,
+		users:       source.Users,
+		tables:      source.Tables,
+		source:      source,
+	}
+	if source.Query != "" {
+		query, err := regexp.Compile(source.Query)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid query of query rule %s", source.Name)
+		}
+		qr.query = query
+	}
+	for _, planType := range source.PlanTypes {
+		qr.planTypes = append(qr.planTypes, strings.ToUpper(planType))
+	}
+	for _, routeType := range source.RouteTypes {
+		qr.routeTypes = append(qr.routeTypes, strings.ToLower(routeType))
+	}
+	switch source.Action {
+	case vschemapb.QueryRule_REDIRECT:
+		tabletType, err := topoproto.ParseTabletType(source.RedirectTabletType)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid redirect tablet type of query rule %s", source.Name)
+		}
+		qr.RedirectTabletType = tabletType
+	default:
+		if source.RedirectTabletType != "" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query rule %s has a redirect tablet type but its action is %s", source.Name, source.Action)
+		}
+	}
+	return qr, nil
+}
+
+// BuildQueryRules validates the query rules and prepares them to be matched against the queries.
+func BuildQueryRules(source *vschemapb.QueryRules) ([]*QueryRule, error) {
+	var rules []*QueryRule
+	names := make(map[string]bool)
+	for _, rule := range source.GetRules() {
+		if names[rule.Name] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "duplicate query rule %s", rule.Name)
+		}
+		names[rule.Name] = true
+		qr, err := BuildQueryRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, qr)
+	}
+	return rules, nil
+}
+
+func buildQueryRules(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	vschema.QueryRules = nil
+	for _, rule := range source.GetQueryRules().GetRules() {
+		qr, err := BuildQueryRule(rule)
+		if err != nil {
+			qr = &QueryRule{Name: rule.Name, Error: err}
+		}
+		vschema.QueryRules = append(vschema.QueryRules, qr)
+	}
+}
+
+// Matches returns true if the query meets all the conditions of the rule.
+// The query is the normalized query, the user is the immediate caller, the tables
+// are the tables used by the query as keyspace.table, the plan type is the
+// statement type of the query and the route type is the route type of its plan.
+func (qr *QueryRule) Matches(query, user string, tables []string, planType, routeType string) bool {
+	if qr.Error != nil {
+		return false
+	}
+	if qr.query != nil && !qr.query.MatchString(query) {
+		return false
+	}
+	if len(qr.users) > 0 && !slices.Contains(qr.users, user) {
+		return false
+	}
+	if len(qr.tables) > 0 && !slices.ContainsFunc(tables, qr.matchesTable) {
+		return false
+	}
+	if len(qr.planTypes) > 0 && !slices.Contains(qr.planTypes, strings.ToUpper(planType)) {
+		return false
+	}
+	if len(qr.routeTypes) > 0 && !slices.Contains(qr.routeTypes, strings.ToLower(routeType)) {
+		return false
+	}
+	return true
+}
+
+// matchesTable returns true if the rule applies to the table, a table of the rule
+// without keyspace applying to the tables of that name in all the keyspaces.
+func (qr *QueryRule) matchesTable(table string) bool {
+	for _, t := range qr.tables {
+		if t == table {
+			return true
+		}
+		if !strings.Contains(t, ".") {
+			if _, name, ok := strings.Cut(table, "."); ok && name == t {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestQueryRuleMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      *vschemapb.QueryRule
+		query     string
+		user      string
+		tables    []string
+		planType  string
+		routeType string
+		matches   bool
+	}{{
+		name:    "no conditions",
+		rule:    &vschemapb.QueryRule{Name: "r"},
+		query:   "select * from t",
+		matches: true,
+	}, {
+		name:    "query",
+		rule:    &vschemapb.QueryRule{Name: "r", Query: "(?i)^select .* for update$"},
+		query:   "select * from t where id = :id for update",
+		matches: true,
+	}, {
+		name:  "query mismatch",
+		rule:  &vschemapb.QueryRule{Name: "r", Query: "(?i)^select .* for update$"},
+		query: "select * from t where id = :id",
+	}, {
+		name:    "user",
+		rule:    &vschemapb.QueryRule{Name: "r", Users: []string{"batch", "etl"}},
+		user:    "etl",
+		matches: true,
+	}, {
+		name: "user mismatch",
+		rule: &vschemapb.QueryRule{Name: "r", Users: []string{"batch", "etl"}},
+		user: "app",
+	}, {
+		name:    "qualified table",
+		rule:    &vschemapb.QueryRule{Name: "r", Tables: []string{"ks.t"}},
+		tables:  []string{"ks.u", "ks.t"},
+		matches: true,
+	}, {
+		name:   "qualified table in another keyspace",
+		rule:   &vschemapb.QueryRule{Name: "r", Tables: []string{"ks.t"}},
+		tables: []string{"other.t"},
+	}, {
+		name:    "unqualified table",
+		rule:    &vschemapb.QueryRule{Name: "r", Tables: []string{"t"}},
+		tables:  []string{"other.t"},
+		matches: true,
+	}, {
+		name:      "plan and route types",
+		rule:      &vschemapb.QueryRule{Name: "r", PlanTypes: []string{"delete"}, RouteTypes: []string{"Scatter"}},
+		planType:  "DELETE",
+		routeType: "Scatter",
+		matches:   true,
+	}, {
+		name:      "route type mismatch",
+		rule:      &vschemapb.QueryRule{Name: "r", PlanTypes: []string{"delete"}, RouteTypes: []string{"Scatter"}},
+		planType:  "DELETE",
+		routeType: "EqualUnique",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := BuildQueryRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, rule.Matches(tt.query, tt.user, tt.tables, tt.planType, tt.routeType))
+		})
+	}
+}
+
+func TestBuildQueryRulesErrors(t *testing.T) {
+	tests := []struct {
+		rules *vschemapb.QueryRules
+		err   string
+	}{{
+		rules: &vschemapb.QueryRules{Rules: []*vschemapb.QueryRule{{Action: vschemapb.QueryRule_DENY}}},
+		err:   "query rule must have a name",
+	}, {
+		rules: &vschemapb.QueryRules{Rules: []*vschemapb.QueryRule{{Name: "r"}, {Name: "r"}}},
+		err:   "duplicate query rule r",
+	}, {
+		rules: &vschemapb.QueryRules{Rules: []*vschemapb.QueryRule{{Name: "r", Query: "("}}},
+		err:   "invalid query of query rule r",
+	}, {
+		rules: &vschemapb.QueryRules{Rules: []*vschemapb.QueryRule{{Name: "r", Action: vschemapb.QueryRule_REDIRECT}}},
+		err:   "invalid redirect tablet type of query rule r",
+	}, {
+		rules: &vschemapb.QueryRules{Rules: []*vschemapb.QueryRule{{Name: "r", Action: vschemapb.QueryRule_WARN, RedirectTabletType: "replica"}}},
+		err:   "query rule r has a redirect tablet type but its action is WARN",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			_, err := BuildQueryRules(tt.rules)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestBuildVSchemaQueryRules(t *testing.T) {
+	srvVSchema := &vschemapb.SrvVSchema{
+		QueryRules: &vschemapb.QueryRules{
+			Rules: []*vschemapb.QueryRule{
+				{Name: "bad", Query: "("},
+				{Name: "reports", Tables: []string{"reports"}, Action: vschemapb.QueryRule_REDIRECT, RedirectTabletType: "rdonly"},
+			},
+		},
+	}
+	vschema := BuildVSchema(srvVSchema, sqlparser.NewTestParser())
+	require.Len(t, vschema.QueryRules, 2)
+
+	// The invalid rules never match.
+	assert.ErrorContains(t, vschema.QueryRules[0].Error, "invalid query of query rule bad")
+	assert.False(t, vschema.QueryRules[0].Matches("select 1", "", nil, "SELECT", ""))
+	assert.Equal(t, topodatapb.TabletType_RDONLY, vschema.QueryRules[1].RedirectTabletType)
+
+	out, err := json.Marshal(vschema.QueryRules)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["invalid query of query rule bad: error parsing regexp: missing closing ): `+"`(`"+`", {"name": "reports", "tables": ["reports"], "action": "REDIRECT", "redirectTabletType": "rdonly"}]`, string(out))
+}
@@ -22,8 +22,14 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
 	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
@@ -31,8 +37,9 @@ import (
 )
 
 const (
-	regionJSONParamRegionBytes = "region_bytes"
-	regionJSONParamRegionMap   = "region_map"
+	regionJSONParamRegionBytes    = "region_bytes"
+	regionJSONParamRegionMap      = "region_map"
+	regionJSONParamRegionMapWatch = "region_map_watch"
 )
 
 var (
@@ -41,7 +48,16 @@ var (
 	regionJSONParams = []string{
 		regionJSONParamRegionBytes,
 		regionJSONParamRegionMap,
+		regionJSONParamRegionMapWatch,
 	}
+
+	// regionMapFiles holds the region maps loaded by the region_json vindexes, by path.
+	// They are shared by the vindexes, which are created again on every change of the
+	// vschema, so that a file is only watched once.
+	regionMapFiles = struct {
+		mu    sync.Mutex
+		files map[string]*regionMapFile
+	}{files: make(map[string]*regionMapFile)}
 )
 
 func init() {
@@ -51,14 +67,101 @@ func init() {
 // RegionMap is used to store mapping of country to region
 type RegionMap map[string]uint64
 
+// regionMapFile is a region map loaded from a json file, which can be
+// reloaded when the file changes.
+type regionMapFile struct {
+	path      string
+	regionMap atomic.Pointer[RegionMap]
+	watchOnce sync.Once
+}
+
+// getRegionMapFile returns the region map of the file, after (re)loading it.
+func getRegionMapFile(path string) (*regionMapFile, error) {
+	regionMapFiles.mu.Lock()
+	defer regionMapFiles.mu.Unlock()
+
+	rmf, ok := regionMapFiles.files[path]
+	if !ok {
+		rmf = &regionMapFile{path: path}
+	}
+	if err := rmf.load(); err != nil {
+		return nil, err
+	}
+	regionMapFiles.files[path] = rmf
+	return rmf, nil
+}
+
+func (rmf *regionMapFile) load() error {
+	data, err := os.ReadFile(rmf.path)
+	if err != nil {
+		return err
+	}
+	rmap := make(RegionMap)
+	if err := json.Unmarshal(data, &rmap); err != nil {
+		return err
+	}
+	for country, region := range rmap {
+		if region > math.MaxUint16 {
+			return fmt.Errorf("region of %s does not fit in 2 bytes: %d", country, region)
+		}
+	}
+	rmf.regionMap.Store(&rmap)
+	log.Infof("Loaded Region map from: %s", rmf.path)
+	return nil
+}
+
+// watch reloads the region map when its file changes, for the lifetime of the process.
+// The directory of the file is watched, so that a file replaced by a rename is still
+// watched afterwards. The previous region map is kept if the file cannot be loaded.
+func (rmf *regionMapFile) watch() error {
+	var err error
+	rmf.watchOnce.Do(func() {
+		var watcher *fsnotify.Watcher
+		watcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			return
+		}
+		if err = watcher.Add(filepath.Dir(rmf.path)); err != nil {
+			watcher.Close()
+			return
+		}
+		fileName := filepath.Base(rmf.path)
+		go func() {
+			defer watcher.Close()
+			for {
+				select {
+				case evt, ok := <-watcher.Events:
+					if !ok {
+						return
+					}
+					if filepath.Base(evt.Name) != fileName || !evt.Has(fsnotify.Write|fsnotify.Create) {
+						continue
+					}
+					if err := rmf.load(); err != nil {
+						log.Errorf("Failed to reload the Region map from %s: %v", rmf.path, err)
+					}
+				case err, ok := <-watcher.Errors:
+					if !ok {
+						return
+					}
+					log.Errorf("Error watching the Region map %s: %v", rmf.path, err)
+				}
+			}
+		}()
+	})
+	return err
+}
+
 // RegionJSON is a multi-column unique vindex
 // The first column is used to lookup the prefix part of the keyspace id, the second column is hashed,
 // and the two values are combined to produce the keyspace id.
 // RegionJson can be used for geo-partitioning because the first column can denote a region,
 // and it will dictate the shard range for that region.
+// The id is hashed into the rest of the keyspace id like the hash vindex does, so that the rows
+// can still be looked up by id with a lookup vindex on the id column.
 type RegionJSON struct {
 	name          string
-	regionMap     RegionMap
+	regionMap     *regionMapFile
 	regionBytes   int
 	unknownParams []string
 }
@@ -66,19 +169,9 @@ type RegionJSON struct {
 // newRegionJSON creates a RegionJson vindex.
 // The supplied map requires all the fields of "RegionExperimental".
 // Additionally, it requires a region_map argument representing the path to a json file
-// containing a map of country to region.
+// containing a map of country to region. If region_map_watch is true, the file is reloaded
+// whenever it changes.
 func newRegionJSON(name string, m map[string]string) (Vindex, error) {
-	rmPath := m[regionJSONParamRegionMap]
-	rmap := make(map[string]uint64)
-	data, err := os.ReadFile(rmPath)
-	if err != nil {
-		return nil, err
-	}
-	log.Infof("Loaded Region map from: %s", rmPath)
-	err = json.Unmarshal(data, &rmap)
-	if err != nil {
-		return nil, err
-	}
 	rb, err := strconv.Atoi(m[regionJSONParamRegionBytes])
 	if err != nil {
 		return nil, err
@@ -88,10 +181,25 @@ func newRegionJSON(name string, m map[string]string) (Vindex, error) {
 	default:
 		return nil, fmt.Errorf("region_bytes must be 1 or 2: %v", rb)
 	}
+	rmf, err := getRegionMapFile(m[regionJSONParamRegionMap])
+	if err != nil {
+		return nil, err
+	}
+	if watch := m[regionJSONParamRegionMapWatch]; watch != "" {
+		shouldWatch, err := strconv.ParseBool(watch)
+		if err != nil {
+			return nil, fmt.Errorf("region_map_watch must be a boolean: %v", watch)
+		}
+		if shouldWatch {
+			if err := rmf.watch(); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	return &RegionJSON{
 		name:          name,
-		regionMap:     rmap,
+		regionMap:     rmf,
 		regionBytes:   rb,
 		unknownParams: FindUnknownParams(m, regionJSONParams),
 	}, nil
@@ -119,6 +227,7 @@ func (rv *RegionJSON) NeedsVCursor() bool {
 
 // Map satisfies MultiColumn.
 func (rv *RegionJSON) Map(ctx context.Context, vcursor VCursor, rowsColValues [][]sqltypes.Value) ([]key.Destination, error) {
+	regionMap := *rv.regionMap.regionMap.Load()
 	destinations := make([]key.Destination, 0, len(rowsColValues))
 	for _, row := range rowsColValues {
 		if len(row) != 2 {
@@ -133,8 +242,8 @@ func (rv *RegionJSON) Map(ctx context.Context, vcursor VCursor, rowsColValues []
 		}
 		h := vhash(hn)
 
-		rn, ok := rv.regionMap[row[1].ToString()]
-		if !ok {
+		rn, ok := regionMap[row[1].ToString()]
+		if !ok || (rv.regionBytes == 1 && rn > math.MaxUint8) {
 			destinations = append(destinations, key.DestinationNone{})
 			continue
 		}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+)
+
+func writeRegionMap(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestRegionJSONCreateVindex(t *testing.T) {
+	regionMap := filepath.Join(t.TempDir(), "region_map.json")
+	writeRegionMap(t, regionMap, `{"US": 1, "DE": 2, "FR": 300}`)
+
+	_, err := CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "3", "region_map": regionMap})
+	assert.EqualError(t, err, "region_bytes must be 1 or 2: 3")
+
+	_, err = CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "1", "region_map": filepath.Join(t.TempDir(), "missing.json")})
+	assert.ErrorContains(t, err, "no such file or directory")
+
+	_, err = CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "1", "region_map": regionMap, "region_map_watch": "sometimes"})
+	assert.EqualError(t, err, "region_map_watch must be a boolean: sometimes")
+
+	tooLarge := filepath.Join(t.TempDir(), "region_map.json")
+	writeRegionMap(t, tooLarge, `{"US": 65536}`)
+	_, err = CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "2", "region_map": tooLarge})
+	assert.EqualError(t, err, "region of US does not fit in 2 bytes: 65536")
+
+	vindex, err := CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "1", "region_map": regionMap, "hello": "world"})
+	require.NoError(t, err)
+	assert.Equal(t, "region_json", vindex.String())
+	assert.Equal(t, 1, vindex.Cost())
+	assert.True(t, vindex.IsUnique())
+	assert.False(t, vindex.NeedsVCursor())
+	assert.Equal(t, []string{"hello"}, vindex.(ParamValidating).UnknownParams())
+}
+
+func TestRegionJSONMap(t *testing.T) {
+	regionMap := filepath.Join(t.TempDir(), "region_map.json")
+	writeRegionMap(t, regionMap, `{"US": 1, "DE": 2, "FR": 300}`)
+	vindex, err := CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "1", "region_map": regionMap})
+	require.NoError(t, err)
+
+	got, err := vindex.(MultiColumn).Map(context.Background(), nil, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("US")},
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("DE")},
+		// the region of FR does not fit in 1 byte.
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("FR")},
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("XX")},
+		{sqltypes.NewInt64(1)},
+	})
+	require.NoError(t, err)
+	want := []key.Destination{
+		key.DestinationKeyspaceID("\x01\x16\x6b\x40\xb4\x4a\xba\x4b\xd6"),
+		key.DestinationKeyspaceID("\x02\x16\x6b\x40\xb4\x4a\xba\x4b\xd6"),
+		key.DestinationNone{},
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}
+	assert.Equal(t, want, got)
+
+	verified, err := vindex.(MultiColumn).Verify(context.Background(), nil, [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("US")},
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("DE")},
+	}, [][]byte{[]byte("\x01\x16\x6b\x40\xb4\x4a\xba\x4b\xd6"), []byte("\x01\x16\x6b\x40\xb4\x4a\xba\x4b\xd6")})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, verified)
+}
+
+func TestRegionJSONReload(t *testing.T) {
+	regionMap := filepath.Join(t.TempDir(), "region_map.json")
+	writeRegionMap(t, regionMap, `{"US": 1}`)
+	vindex, err := CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "2", "region_map": regionMap, "region_map_watch": "true"})
+	require.NoError(t, err)
+
+	row := [][]sqltypes.Value{{sqltypes.NewInt64(1), sqltypes.NewVarChar("US")}}
+	got, err := vindex.(MultiColumn).Map(context.Background(), nil, row)
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationKeyspaceID("\x00\x01\x16\x6b\x40\xb4\x4a\xba\x4b\xd6"), got[0])
+
+	// the vindex picks up the changes of the file.
+	writeRegionMap(t, regionMap, `{"US": 258}`)
+	assert.Eventually(t, func() bool {
+		got, err := vindex.(MultiColumn).Map(context.Background(), nil, row)
+		return err == nil && assert.ObjectsAreEqual(key.DestinationKeyspaceID("\x01\x02\x16\x6b\x40\xb4\x4a\xba\x4b\xd6"), got[0])
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid file is ignored, and the previous region map is kept.
+	writeRegionMap(t, regionMap, `{"US": `)
+	time.Sleep(100 * time.Millisecond)
+	got, err = vindex.(MultiColumn).Map(context.Background(), nil, row)
+	require.NoError(t, err)
+	assert.Equal(t, key.DestinationKeyspaceID("\x01\x02\x16\x6b\x40\xb4\x4a\xba\x4b\xd6"), got[0])
+
+	// a vindex created again from the same file shares its region map.
+	writeRegionMap(t, regionMap, `{"US": 3}`)
+	other, err := CreateVindex("region_json", "region_json", map[string]string{"region_bytes": "2", "region_map": regionMap})
+	require.NoError(t, err)
+	assert.Same(t, vindex.(*RegionJSON).regionMap, other.(*RegionJSON).regionMap)
+}
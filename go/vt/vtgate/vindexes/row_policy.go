@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// rowPolicyAttribute is the function of the row policies returning the value of an attribute of the caller.
+	rowPolicyAttribute = "attribute"
+	// rowPolicyAttributeVar is the prefix of the bind variables holding the values of the attributes.
+	rowPolicyAttributeVar = "__vtattr_"
+)
+
+// validAttribute matches the valid names of attributes.
+var validAttribute = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RowPolicy is the predicate that the rows of a table must satisfy to be read or written through vtgate.
+type RowPolicy struct {
+	// Expr is the predicate, on the unqualified columns of the table.
+	Expr sqlparser.Expr
+	// Columns are the columns used by the predicate.
+	Columns []sqlparser.IdentifierCI
+	// Attributes are the names of the attributes used by the predicate.
+	Attributes []string
+}
+
+// MarshalJSON returns a JSON representation of RowPolicy.
+func (rp *RowPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sqlparser.String(rp.Expr))
+}
+
+// BuildRowPolicy parses and validates the row policy of a table.
+func BuildRowPolicy(policy string, parser *sqlparser.Parser) (*RowPolicy, error) {
+	expr, err := parser.ParseExpr(policy)
+	if err != nil {
+		return nil, err
+	}
+	if sqlparser.ContainsAggregation(expr) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "row policy cannot use aggregations")
+	}
+
+	rp := &RowPolicy{Expr: expr}
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			return false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "row policy cannot use subqueries")
+		case *sqlparser.ColName:
+			if !node.Qualifier.IsEmpty() {
+				return false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "row policy cannot use the qualified column %s", sqlparser.String(node))
+			}
+			if !slices.ContainsFunc(rp.Columns, node.Name.Equal) {
+				rp.Columns = append(rp.Columns, node.Name)
+			}
+		case *sqlparser.FuncExpr:
+			if !node.Name.EqualString(rowPolicyAttribute) {
+				return true, nil
+			}
+			name, ok := attributeName(node)
+			if !ok {
+				return false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the argument of %s must be the name of an attribute", sqlparser.String(node))
+			}
+			if !slices.Contains(rp.Attributes, name) {
+				rp.Attributes = append(rp.Attributes, name)
+			}
+			return false, nil
+		}
+		return true, nil
+	}, expr)
+	if err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// attributeName returns the name of the attribute returned by a call to the attribute function.
+func attributeName(node *sqlparser.FuncExpr) (string, bool) {
+	if len(node.Exprs) != 1 {
+		return "", false
+	}
+	lit, ok := node.Exprs[0].(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.StrVal || lit.Val == "" {
+		return "", false
+	}
+	name := sqlparser.NewIdentifierCI(lit.Val).Lowered()
+	return name, validAttribute.MatchString(name)
+}
+
+// RowPolicyAttributeVar returns the name of the bind variable holding the value of an attribute.
+func RowPolicyAttributeVar(name string) string {
+	return rowPolicyAttributeVar + name
+}
+
+// Rewrite returns a copy of the predicate, with its columns replaced by the expressions
+// returned by col, and its attributes by the expressions returned by attr.
+func (rp *RowPolicy) Rewrite(col func(sqlparser.IdentifierCI) sqlparser.Expr, attr func(string) sqlparser.Expr) sqlparser.Expr {
+	return sqlparser.CopyOnRewrite(rp.Expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		switch node := cursor.Node().(type) {
+		case *sqlparser.ColName:
+			cursor.Replace(col(node.Name))
+		case *sqlparser.FuncExpr:
+			if name, ok := attributeName(node); ok && node.Name.EqualString(rowPolicyAttribute) {
+				cursor.Replace(attr(name))
+			}
+		}
+	}, nil).(sqlparser.Expr)
+}
+
+// Predicate returns the predicate for the table of the given name or alias, its attributes
+// being read from the bind variables named by RowPolicyAttributeVar.
+func (rp *RowPolicy) Predicate(table sqlparser.TableName) sqlparser.Expr {
+	return rp.Rewrite(
+		func(col sqlparser.IdentifierCI) sqlparser.Expr {
+			return sqlparser.NewColNameWithQualifier(col.String(), table)
+		},
+		func(name string) sqlparser.Expr {
+			return sqlparser.NewArgument(RowPolicyAttributeVar(name))
+		},
+	)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestBuildRowPolicy(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	rp, err := BuildRowPolicy("tenant_id = attribute('Tenant') and (region = attribute('region') or region is null)", parser)
+	require.NoError(t, err)
+	assert.Equal(t, []sqlparser.IdentifierCI{sqlparser.NewIdentifierCI("tenant_id"), sqlparser.NewIdentifierCI("region")}, rp.Columns)
+	assert.Equal(t, []string{"tenant", "region"}, rp.Attributes)
+
+	pred := rp.Predicate(sqlparser.TableName{Name: sqlparser.NewIdentifierCS("t")})
+	assert.Equal(t, "t.tenant_id = :__vtattr_tenant and (t.region = :__vtattr_region or t.region is null)", sqlparser.String(pred))
+	// The policy itself is left untouched.
+	assert.Equal(t, "tenant_id = attribute('Tenant') and (region = attribute('region') or region is null)", sqlparser.String(rp.Expr))
+
+	tests := []struct {
+		policy string
+		err    string
+	}{{
+		policy: "tenant_id = (select 1)",
+		err:    "row policy cannot use subqueries",
+	}, {
+		policy: "t.tenant_id = 1",
+		err:    "row policy cannot use the qualified column t.tenant_id",
+	}, {
+		policy: "count(*) > 0",
+		err:    "row policy cannot use aggregations",
+	}, {
+		policy: "tenant_id = attribute(tenant)",
+		err:    "the argument of attribute(tenant) must be the name of an attribute",
+	}, {
+		policy: "tenant_id = attribute('tenant id')",
+		err:    "the argument of attribute('tenant id') must be the name of an attribute",
+	}, {
+		policy: "tenant_id =",
+		err:    "syntax error",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			_, err := BuildRowPolicy(tt.policy, parser)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestBuildVSchemaRowPolicy(t *testing.T) {
+	srvVSchema := &vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {RowPolicy: "tenant_id = attribute('tenant')"},
+				},
+			},
+			"bad": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {RowPolicy: "t1.tenant_id = attribute('tenant')"},
+				},
+			},
+		},
+	}
+	vschema := BuildVSchema(srvVSchema, sqlparser.NewTestParser())
+	require.NoError(t, vschema.Keyspaces["ks"].Error)
+	require.EqualError(t, vschema.Keyspaces["bad"].Error, "invalid row policy for table t1: row policy cannot use the qualified column t1.tenant_id")
+
+	table := vschema.Keyspaces["ks"].Tables["t1"]
+	require.NotNil(t, table.RowPolicy)
+	out, err := json.Marshal(table)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "t1", "row_policy": "tenant_id = attribute('tenant')"}`, string(out))
+}
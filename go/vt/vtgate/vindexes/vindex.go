@@ -138,6 +138,14 @@ type (
 		PrefixVindex() SingleColumn
 	}
 
+	// A RangeMappable vindex is one that can map a range of values of its
+	// leading column to a destination. It's being used to reduce the fan out
+	// for range predicates ('<', '>', 'BETWEEN') on that column. Both bounds
+	// are inclusive, and a NULL bound leaves that side of the range open.
+	RangeMappable interface {
+		MapRange(ctx context.Context, vcursor VCursor, start, end sqltypes.Value) (key.Destination, error)
+	}
+
 	// A Lookup vindex is one that needs to lookup
 	// a previously stored map to compute the keyspace
 	// id from an id. This means that the creation of
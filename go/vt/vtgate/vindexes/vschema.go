@@ -75,6 +75,8 @@ type VSchema struct {
 	Keyspaces            map[string]*KeyspaceSchema `json:"keyspaces"`
 	ShardRoutingRules    map[string]string          `json:"shard_routing_rules"`
 	KeyspaceRoutingRules map[string]string          `json:"keyspace_routing_rules"`
+	QueryRules           []*QueryRule               `json:"query_rules,omitempty"`
+	VTGateConfig         *vschemapb.VTGateConfig    `json:"vtgate_config,omitempty"`
 	// created is the time when the VSchema object was created. Used to detect if a cached
 	// copy of the vschema is stale.
 	created time.Time
@@ -129,6 +131,42 @@ type Table struct {
 	// MySQL error message: ERROR 3756 (HY000): The primary key cannot be a functional index
 	PrimaryKey sqlparser.Columns `json:"primary_key,omitempty"`
 	UniqueKeys []sqlparser.Exprs `json:"unique_keys,omitempty"`
+
+	// ExpressionIndexes are the indexes that have at least one functional key part.
+	ExpressionIndexes []*ExpressionIndex `json:"expression_indexes,omitempty"`
+
+	// CheckConstraints are the CHECK constraints of the table.
+	CheckConstraints []*CheckConstraint `json:"check_constraints,omitempty"`
+
+	// RowPolicy is the predicate that the rows of the table must satisfy to be read or written.
+	RowPolicy *RowPolicy `json:"row_policy,omitempty"`
+}
+
+// ExpressionIndex is an index of a table that has at least one functional key part,
+// like `KEY idx ((lower(name)))`. Key parts that are plain columns are stored as column names.
+type ExpressionIndex struct {
+	Name  string          `json:"name"`
+	Exprs sqlparser.Exprs `json:"exprs"`
+}
+
+// CheckConstraint is a CHECK constraint of a table.
+type CheckConstraint struct {
+	Name     string
+	Expr     sqlparser.Expr
+	Enforced bool
+}
+
+// MarshalJSON returns a JSON representation of CheckConstraint.
+func (cc *CheckConstraint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name     string `json:"name,omitempty"`
+		Expr     string `json:"expr"`
+		Enforced bool   `json:"enforced"`
+	}{
+		Name:     cc.Name,
+		Expr:     sqlparser.String(cc.Expr),
+		Enforced: cc.Enforced,
+	})
 }
 
 // GetTableName gets the sqlparser.TableName for the vindex Table.
@@ -157,9 +195,10 @@ type ColumnVindex struct {
 
 // TableInfo contains column and foreign key info for a table.
 type TableInfo struct {
-	Columns     []Column
-	ForeignKeys []*sqlparser.ForeignKeyDefinition
-	Indexes     []*sqlparser.IndexDefinition
+	Columns          []Column
+	ForeignKeys      []*sqlparser.ForeignKeyDefinition
+	Indexes          []*sqlparser.IndexDefinition
+	CheckConstraints []*sqlparser.ConstraintDefinition
 }
 
 // IsUnique is used to tell whether the ColumnVindex
@@ -199,6 +238,8 @@ type Column struct {
 	Nullable  bool  `json:"nullable,omitempty"`
 	// Values contains the list of values for enum and set types.
 	Values []string `json:"values,omitempty"`
+	// Generated is the expression of a generated column.
+	Generated sqlparser.Expr `json:"generated,omitempty"`
 }
 
 // MarshalJSON returns a JSON representation of Column.
@@ -212,6 +253,7 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 		Scale     int32    `json:"scale,omitempty"`
 		Nullable  bool     `json:"nullable,omitempty"`
 		Values    []string `json:"values,omitempty"`
+		Generated string   `json:"generated,omitempty"`
 	}{
 		Name:      col.Name.String(),
 		Type:      querypb.Type_name[int32(col.Type)],
@@ -224,6 +266,9 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 	if col.Default != nil {
 		cj.Default = sqlparser.String(col.Default)
 	}
+	if col.Generated != nil {
+		cj.Generated = sqlparser.String(col.Generated)
+	}
 	return json.Marshal(cj)
 }
 
@@ -339,6 +384,8 @@ func BuildVSchema(source *vschemapb.SrvVSchema, parser *sqlparser.Parser) (vsche
 	buildRoutingRule(source, vschema, parser)
 	buildShardRoutingRule(source, vschema)
 	buildKeyspaceRoutingRule(source, vschema)
+	buildQueryRules(source, vschema)
+	buildVTGateConfig(source, vschema)
 	// Resolve auto-increments after routing rules are built since sequence tables also obey routing rules.
 	resolveAutoIncrement(source, vschema, parser)
 	return vschema
@@ -655,6 +702,13 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			}
 			t.Pinned = decoded
 		}
+		if table.RowPolicy != "" {
+			rowPolicy, err := BuildRowPolicy(table.RowPolicy, parser)
+			if err != nil {
+				return vterrors.Wrapf(err, "invalid row policy for table %s", tname)
+			}
+			t.RowPolicy = rowPolicy
+		}
 
 		// If keyspace is sharded, then any table that's not a reference or pinned must have vindexes.
 		if keyspace.Sharded && t.Type != TypeReference && table.Pinned == "" && len(table.ColumnVindexes) == 0 {
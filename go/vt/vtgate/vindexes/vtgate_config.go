@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// ValidateVTGateConfig validates the dynamic configuration of the vtgates.
+// The values of 0 keep the values of the flags of the vtgates, so the
+// buffering window can only be checked against the maximum failover duration
+// if both are set.
+func ValidateVTGateConfig(config *vschemapb.VTGateConfig) error {
+	if config == nil {
+		return nil
+	}
+	for _, field := range []struct {
+		name  string
+		value int64
+	}{
+		{"query_timeout_ms", config.QueryTimeoutMs},
+		{"max_memory_rows", config.MaxMemoryRows},
+		{"warn_memory_rows", config.WarnMemoryRows},
+		{"buffer_window_ms", config.BufferWindowMs},
+		{"buffer_max_failover_duration_ms", config.BufferMaxFailoverDurationMs},
+		{"scatter_max_concurrency", config.ScatterMaxConcurrency},
+	} {
+		if field.value < 0 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s must be >= 0 (specified value: %d)", field.name, field.value)
+		}
+	}
+	if config.BufferWindowMs != 0 && config.BufferWindowMs < time.Second.Milliseconds() {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "buffer_window_ms must be >= 1000 (specified value: %d)", config.BufferWindowMs)
+	}
+	if config.BufferWindowMs != 0 && config.BufferMaxFailoverDurationMs != 0 && config.BufferWindowMs > config.BufferMaxFailoverDurationMs {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "buffer_window_ms must be <= buffer_max_failover_duration_ms: %d vs. %d", config.BufferWindowMs, config.BufferMaxFailoverDurationMs)
+	}
+	return nil
+}
+
+func buildVTGateConfig(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	config := source.GetVtgateConfig()
+	if err := ValidateVTGateConfig(config); err != nil {
+		log.Errorf("Ignoring the version %d of the vtgate config: %v", config.Version, err)
+		return
+	}
+	vschema.VTGateConfig = config
+}
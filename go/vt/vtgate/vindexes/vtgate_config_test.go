@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestValidateVTGateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *vschemapb.VTGateConfig
+		err    string
+	}{{
+		name: "nil",
+	}, {
+		name:   "empty",
+		config: &vschemapb.VTGateConfig{},
+	}, {
+		name: "valid",
+		config: &vschemapb.VTGateConfig{
+			QueryTimeoutMs:              1000,
+			MaxMemoryRows:               1000,
+			WarnMemoryRows:              100,
+			BufferWindowMs:              5000,
+			BufferMaxFailoverDurationMs: 10000,
+			ScatterMaxConcurrency:       8,
+		},
+	}, {
+		name:   "negative",
+		config: &vschemapb.VTGateConfig{MaxMemoryRows: -1},
+		err:    "max_memory_rows must be >= 0 (specified value: -1)",
+	}, {
+		name:   "short buffer window",
+		config: &vschemapb.VTGateConfig{BufferWindowMs: 500},
+		err:    "buffer_window_ms must be >= 1000 (specified value: 500)",
+	}, {
+		name:   "buffer window longer than the max failover duration",
+		config: &vschemapb.VTGateConfig{BufferWindowMs: 5000, BufferMaxFailoverDurationMs: 2000},
+		err:    "buffer_window_ms must be <= buffer_max_failover_duration_ms: 5000 vs. 2000",
+	}, {
+		name:   "buffer window without max failover duration",
+		config: &vschemapb.VTGateConfig{BufferWindowMs: 50000},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVTGateConfig(tt.config)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBuildVTGateConfig(t *testing.T) {
+	config := &vschemapb.VTGateConfig{Version: 3, QueryTimeoutMs: 1000}
+	vschema := BuildVSchema(&vschemapb.SrvVSchema{VtgateConfig: config}, sqlparser.NewTestParser())
+	assert.Equal(t, config, vschema.VTGateConfig)
+
+	vschema = BuildVSchema(&vschemapb.SrvVSchema{VtgateConfig: &vschemapb.VTGateConfig{Version: 4, QueryTimeoutMs: -1}}, sqlparser.NewTestParser())
+	assert.Nil(t, vschema.VTGateConfig)
+}
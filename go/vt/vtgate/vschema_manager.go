@@ -18,6 +18,7 @@ package vtgate
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"vitess.io/vitess/go/vt/graph"
@@ -46,6 +47,10 @@ type VSchemaManager struct {
 	subscriber        func(vschema *vindexes.VSchema, stats *VSchemaStats)
 	schema            SchemaInfo
 	parser            *sqlparser.Parser
+
+	// trackedColumnsOverride makes the columns of the schema tracker replace the
+	// columns of the tables that have an authoritative column list in the VSchema.
+	trackedColumnsOverride bool
 }
 
 // SchemaInfo is an interface to schema tracker.
@@ -223,7 +228,7 @@ func (vm *VSchemaManager) updateTableInfo(vschema *vindexes.VSchema, ks *vindexe
 	// are created in the Vschema, so that later when we try to find the routed tables, we don't end up
 	// getting dummy tables.
 	for tblName, tblInfo := range m {
-		setColumns(ks, tblName, tblInfo.Columns)
+		setColumns(ks, tblName, tblInfo.Columns, vm.trackedColumnsOverride)
 	}
 
 	// Now that we have ensured that all the tables are created, we can start populating the foreign keys
@@ -264,8 +269,41 @@ func (vm *VSchemaManager) updateTableInfo(vschema *vindexes.VSchema, ks *vindexe
 				}
 				rTbl.UniqueKeys = append(rTbl.UniqueKeys, uniqueKey)
 			}
+			if exprIdx := expressionIndex(idxDef); exprIdx != nil {
+				rTbl.ExpressionIndexes = append(rTbl.ExpressionIndexes, exprIdx)
+			}
+		}
+		for _, constraint := range tblInfo.CheckConstraints {
+			check, ok := constraint.Details.(*sqlparser.CheckConstraintDefinition)
+			if !ok {
+				continue
+			}
+			rTbl.CheckConstraints = append(rTbl.CheckConstraints, &vindexes.CheckConstraint{
+				Name:     constraint.Name.String(),
+				Expr:     check.Expr,
+				Enforced: check.Enforced,
+			})
+		}
+	}
+}
+
+// expressionIndex returns the index as an ExpressionIndex if it has at least one functional key part.
+func expressionIndex(idxDef *sqlparser.IndexDefinition) *vindexes.ExpressionIndex {
+	hasExpr := slices.ContainsFunc(idxDef.Columns, func(idxCol *sqlparser.IndexColumn) bool {
+		return idxCol.Expression != nil
+	})
+	if !hasExpr {
+		return nil
+	}
+	exprIdx := &vindexes.ExpressionIndex{Name: idxDef.Info.Name.String()}
+	for _, idxCol := range idxDef.Columns {
+		if idxCol.Expression == nil {
+			exprIdx.Exprs = append(exprIdx.Exprs, sqlparser.NewColName(idxCol.Column.String()))
+		} else {
+			exprIdx.Exprs = append(exprIdx.Exprs, idxCol.Expression)
 		}
 	}
+	return exprIdx
 }
 
 // updateUDFsInfo updates the aggregate UDFs in the Vschema.
@@ -331,7 +369,7 @@ func addCrossEdges(g *graph.Graph[string], from []string, to []string) {
 	}
 }
 
-func setColumns(ks *vindexes.KeyspaceSchema, tblName string, columns []vindexes.Column) *vindexes.Table {
+func setColumns(ks *vindexes.KeyspaceSchema, tblName string, columns []vindexes.Column, override bool) *vindexes.Table {
 	vTbl := ks.Tables[tblName]
 	if vTbl == nil {
 		// a table that is unknown by the vschema. we add it as a normal table
@@ -343,8 +381,9 @@ func setColumns(ks *vindexes.KeyspaceSchema, tblName string, columns []vindexes.
 		}
 		return ks.Tables[tblName]
 	}
-	// if we found the matching table and the vschema view of it is not authoritative, then we just update the columns of the table
-	if !vTbl.ColumnListAuthoritative {
+	// if we found the matching table and the vschema view of it is not authoritative, then we just update the columns of the table.
+	// when the tracked columns override the vschema, we do it even if the vschema view is authoritative.
+	if !vTbl.ColumnListAuthoritative || (override && len(columns) > 0) {
 		vTbl.Columns = columns
 		vTbl.ColumnListAuthoritative = true
 	}
@@ -103,6 +103,29 @@ func TestVSchemaUpdate(t *testing.T) {
 			{&sqlparser.BinaryExpr{Operator: sqlparser.DivOp, Left: sqlparser.NewColName("b"), Right: sqlparser.NewIntLiteral("2")}},
 			{sqlparser.NewColName("c"), &sqlparser.BinaryExpr{Operator: sqlparser.PlusOp, Left: sqlparser.NewColName("d"), Right: sqlparser.NewColName("e")}},
 		},
+		ExpressionIndexes: []*vindexes.ExpressionIndex{{
+			Name:  "b_idx",
+			Exprs: sqlparser.Exprs{&sqlparser.BinaryExpr{Operator: sqlparser.DivOp, Left: sqlparser.NewColName("b"), Right: sqlparser.NewIntLiteral("2")}},
+		}, {
+			Name:  "xy_idx",
+			Exprs: sqlparser.Exprs{&sqlparser.BinaryExpr{Operator: sqlparser.PlusOp, Left: sqlparser.NewColName("x"), Right: sqlparser.NewColName("y")}},
+		}, {
+			Name:  "cde_idx",
+			Exprs: sqlparser.Exprs{sqlparser.NewColName("c"), &sqlparser.BinaryExpr{Operator: sqlparser.PlusOp, Left: sqlparser.NewColName("d"), Right: sqlparser.NewColName("e")}},
+		}},
+	}
+	chkTbl := &vindexes.Table{
+		Name:                    sqlparser.NewIdentifierCS("chkTbl"),
+		Keyspace:                ks,
+		ColumnListAuthoritative: true,
+		CheckConstraints: []*vindexes.CheckConstraint{{
+			Name:     "a_chk",
+			Expr:     &sqlparser.ComparisonExpr{Operator: sqlparser.GreaterThanOp, Left: sqlparser.NewColName("a"), Right: sqlparser.NewIntLiteral("0")},
+			Enforced: true,
+		}, {
+			Name: "b_chk",
+			Expr: &sqlparser.ComparisonExpr{Operator: sqlparser.LessThanOp, Left: sqlparser.NewColName("b"), Right: sqlparser.NewColName("a")},
+		}},
 	}
 
 	tcases := []struct {
@@ -292,17 +315,17 @@ func TestVSchemaUpdate(t *testing.T) {
 						{Column: sqlparser.NewIdentifierCI("a")},
 					},
 				}, {
-					Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeUnique},
+					Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeUnique, Name: sqlparser.NewIdentifierCI("b_idx")},
 					Columns: []*sqlparser.IndexColumn{
 						{Expression: &sqlparser.BinaryExpr{Operator: sqlparser.DivOp, Left: sqlparser.NewColName("b"), Right: sqlparser.NewIntLiteral("2")}},
 					},
 				}, {
-					Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeDefault},
+					Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeDefault, Name: sqlparser.NewIdentifierCI("xy_idx")},
 					Columns: []*sqlparser.IndexColumn{
 						{Expression: &sqlparser.BinaryExpr{Operator: sqlparser.PlusOp, Left: sqlparser.NewColName("x"), Right: sqlparser.NewColName("y")}},
 					},
 				}, {
-					Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeUnique},
+					Info: &sqlparser.IndexInfo{Type: sqlparser.IndexTypeUnique, Name: sqlparser.NewIdentifierCI("cde_idx")},
 					Columns: []*sqlparser.IndexColumn{
 						{Column: sqlparser.NewIdentifierCI("c")},
 						{Expression: &sqlparser.BinaryExpr{Operator: sqlparser.PlusOp, Left: sqlparser.NewColName("d"), Right: sqlparser.NewColName("e")}},
@@ -312,6 +335,27 @@ func TestVSchemaUpdate(t *testing.T) {
 		},
 		srvVschema: makeTestSrvVSchema("ks", false, nil),
 		expected:   makeTestVSchema("ks", false, map[string]*vindexes.Table{"idxTbl2": idxTbl2}),
+	}, {
+		name:           "check constraints in schema",
+		currentVSchema: &vindexes.VSchema{},
+		schema: map[string]*vindexes.TableInfo{
+			"chkTbl": {
+				CheckConstraints: []*sqlparser.ConstraintDefinition{{
+					Name: sqlparser.NewIdentifierCI("a_chk"),
+					Details: &sqlparser.CheckConstraintDefinition{
+						Expr:     &sqlparser.ComparisonExpr{Operator: sqlparser.GreaterThanOp, Left: sqlparser.NewColName("a"), Right: sqlparser.NewIntLiteral("0")},
+						Enforced: true,
+					},
+				}, {
+					Name: sqlparser.NewIdentifierCI("b_chk"),
+					Details: &sqlparser.CheckConstraintDefinition{
+						Expr: &sqlparser.ComparisonExpr{Operator: sqlparser.LessThanOp, Left: sqlparser.NewColName("b"), Right: sqlparser.NewColName("a")},
+					},
+				}},
+			},
+		},
+		srvVschema: makeTestSrvVSchema("ks", false, nil),
+		expected:   makeTestVSchema("ks", false, map[string]*vindexes.Table{"chkTbl": chkTbl}),
 	}}
 
 	vm := &VSchemaManager{}
@@ -360,6 +404,7 @@ func TestRebuildVSchema(t *testing.T) {
 		name       string
 		srvVschema *vschemapb.SrvVSchema
 		schema     map[string]*vindexes.TableInfo
+		override   bool
 		expected   *vindexes.VSchema
 	}{{
 		name: "0 Schematracking- 1 srvVSchema",
@@ -411,6 +456,29 @@ func TestRebuildVSchema(t *testing.T) {
 		schema: map[string]*vindexes.TableInfo{"tbl": {Columns: cols1}},
 		// schema tracker will be ignored for authoritative tables.
 		expected: makeTestVSchema("ks", false, map[string]*vindexes.Table{"tbl": tblCol2}),
+	}, {
+		name: "1 Schematracking - 1 srvVSchema (have columns) authoritative, tracked columns override",
+		srvVschema: makeTestSrvVSchema("ks", false, map[string]*vschemapb.Table{
+			"tbl": {
+				Columns:                 []*vschemapb.Column{{Name: "uid", Type: querypb.Type_INT64}, {Name: "name", Type: querypb.Type_VARCHAR}},
+				ColumnListAuthoritative: true,
+			},
+		}),
+		schema:   map[string]*vindexes.TableInfo{"tbl": {Columns: cols1}},
+		override: true,
+		// schema will override what srvSchema has, even for authoritative tables.
+		expected: makeTestVSchema("ks", false, map[string]*vindexes.Table{"tbl": tblCol1}),
+	}, {
+		name: "0 Schematracking - 1 srvVSchema (have columns) authoritative, tracked columns override",
+		srvVschema: makeTestSrvVSchema("ks", false, map[string]*vschemapb.Table{
+			"tbl": {
+				Columns:                 []*vschemapb.Column{{Name: "uid", Type: querypb.Type_INT64}, {Name: "name", Type: querypb.Type_VARCHAR}},
+				ColumnListAuthoritative: true,
+			},
+		}),
+		override: true,
+		// untracked tables keep the columns of the srvSchema.
+		expected: makeTestVSchema("ks", false, map[string]*vindexes.Table{"tbl": tblCol2}),
 	}, {
 		name:   "srvVschema received as nil",
 		schema: map[string]*vindexes.TableInfo{"tbl": {Columns: cols1}},
@@ -428,6 +496,7 @@ func TestRebuildVSchema(t *testing.T) {
 			vm.schema = &fakeSchema{t: tcase.schema}
 			vm.currentSrvVschema = tcase.srvVschema
 			vm.currentVschema = nil
+			vm.trackedColumnsOverride = tcase.override
 			vm.Rebuild()
 
 			utils.MustMatchFn(".globalTables", ".uniqueVindexes")(t, tcase.expected, vs)
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sidecardb"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The consumers of VStream can leave the storage of their positions to
+// vtgate: a stream whose flags name a consumer group, and which doesn't
+// specify a vgtid, resumes from the last checkpoint acknowledged by the group
+// with VStreamAck. A consumer acknowledges a vgtid once it has processed the
+// events up to it, so the events which follow it are sent again if the
+// consumer restarts: they are delivered at least once.
+//
+// The checkpoints are stored in the vstream_checkpoints sidecar table of the
+// primary of the unsharded keyspace set by --vstream-checkpoint-keyspace.
+
+var vstreamCheckpointKeyspace string
+
+func registerVStreamCheckpointFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&vstreamCheckpointKeyspace, "vstream-checkpoint-keyspace", vstreamCheckpointKeyspace, "Unsharded keyspace in whose sidecar database the checkpoints of the VStream consumer groups are stored. The consumer groups are disabled if it's not set.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerVStreamCheckpointFlags)
+	servenv.OnParseFor("vtcombo", registerVStreamCheckpointFlags)
+}
+
+const (
+	sqlSelectVStreamCheckpoint = "select vgtid from %s.vstream_checkpoints where consumer_group = %a"
+	sqlUpsertVStreamCheckpoint = "insert into %s.vstream_checkpoints(consumer_group, vgtid) values (%a, %a) on duplicate key update vgtid = values(vgtid)"
+)
+
+// vstreamCheckpoints stores the checkpoints of the VStream consumer groups.
+type vstreamCheckpoints struct {
+	resolver *srvtopo.Resolver
+	keyspace string
+}
+
+// shard returns the primary of the checkpoint keyspace, and the sidecar
+// database of the keyspace.
+func (vc *vstreamCheckpoints) shard(ctx context.Context) (*srvtopo.ResolvedShard, string, error) {
+	if vc.keyspace == "" {
+		return nil, "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the VStream consumer groups require --vstream-checkpoint-keyspace to be set")
+	}
+	rss, err := vc.resolver.ResolveDestination(ctx, vc.keyspace, topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(rss) != 1 {
+		return nil, "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the VStream checkpoint keyspace %s must be unsharded, it has %d shards", vc.keyspace, len(rss))
+	}
+	sidecarDB, err := sidecardb.GetIdentifierForKeyspace(vc.keyspace)
+	if err != nil {
+		return nil, "", err
+	}
+	return rss[0], sidecarDB, nil
+}
+
+// load returns the last checkpoint of the consumer group, or nil if it has
+// none.
+func (vc *vstreamCheckpoints) load(ctx context.Context, consumerGroup string) (*binlogdatapb.VGtid, error) {
+	rs, sidecarDB, err := vc.shard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query, err := sqlparser.BuildParsedQuery(sqlSelectVStreamCheckpoint, sidecarDB, ":consumer_group").GenerateQuery(
+		map[string]*querypb.BindVariable{"consumer_group": sqltypes.StringBindVariable(consumerGroup)}, nil)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := rs.Gateway.Execute(ctx, rs.Target, query, nil, 0, 0, nil)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to load the checkpoint of VStream consumer group %s", consumerGroup)
+	}
+	if len(qr.Rows) == 0 {
+		return nil, nil
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	if err := prototext.Unmarshal(qr.Rows[0][0].Raw(), vgtid); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid checkpoint of VStream consumer group %s", consumerGroup)
+	}
+	return vgtid, nil
+}
+
+// save stores the vgtid as the checkpoint of the consumer group.
+func (vc *vstreamCheckpoints) save(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	if consumerGroup == "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the consumer group must be specified")
+	}
+	if len(vgtid.GetShardGtids()) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "vgtid must have at least one value with a position")
+	}
+	rs, sidecarDB, err := vc.shard(ctx)
+	if err != nil {
+		return err
+	}
+	vgtidbuf, err := prototext.Marshal(vgtid)
+	if err != nil {
+		return err
+	}
+	query, err := sqlparser.BuildParsedQuery(sqlUpsertVStreamCheckpoint, sidecarDB, ":consumer_group", ":vgtid").GenerateQuery(
+		map[string]*querypb.BindVariable{
+			"consumer_group": sqltypes.StringBindVariable(consumerGroup),
+			"vgtid":          sqltypes.BytesBindVariable(vgtidbuf),
+		}, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := rs.Gateway.Execute(ctx, rs.Target, query, nil, 0, 0, nil); err != nil {
+		return vterrors.Wrapf(err, "failed to save the checkpoint of VStream consumer group %s", consumerGroup)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/sidecardb"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestVStreamCheckpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cell := "aa"
+	ks := "TestVStreamCheckpoints"
+	sand := createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-"})
+	vsm := newTestVStreamManager(ctx, hc, st, cell)
+	sbc := hc.AddTestTablet(cell, "1.1.1.1", 1001, ks, "-", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-", sbc.Tablet())
+
+	if sdbc, _ := sidecardb.GetIdentifierCache(); sdbc != nil {
+		sdbc.Destroy()
+	}
+	_, created := sidecardb.NewIdentifierCache(func(ctx context.Context, keyspace string) (string, error) {
+		return sidecar.DefaultName, nil
+	})
+	require.True(t, created)
+
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-",
+			Gtid:     "pos",
+		}},
+	}
+	vgtidbuf, err := prototext.Marshal(vgtid)
+	require.NoError(t, err)
+	flags := &vtgatepb.VStreamFlags{ConsumerGroup: "group1"}
+
+	err = vsm.Ack(ctx, "group1", vgtid)
+	require.EqualError(t, err, "the VStream consumer groups require --vstream-checkpoint-keyspace to be set")
+
+	vsm.checkpoints.keyspace = ks
+	err = vsm.Ack(ctx, "group1", vgtid)
+	require.EqualError(t, err, "the VStream checkpoint keyspace TestVStreamCheckpoints must be unsharded, it has 8 shards")
+	sand.ShardSpec = "-"
+
+	err = vsm.Ack(ctx, "", vgtid)
+	require.EqualError(t, err, "the consumer group must be specified")
+	err = vsm.Ack(ctx, "group1", &binlogdatapb.VGtid{})
+	require.EqualError(t, err, "vgtid must have at least one value with a position")
+
+	err = vsm.Ack(ctx, "group1", vgtid)
+	require.NoError(t, err)
+	require.Len(t, sbc.Queries, 1)
+	require.Equal(t, fmt.Sprintf("insert into _vt.vstream_checkpoints(consumer_group, vgtid) values ('group1', %s) on duplicate key update vgtid = values(vgtid)",
+		sqltypes.EncodeStringSQL(string(vgtidbuf))), sbc.Queries[0].Sql)
+
+	// A stream of a group without a checkpoint must specify a vgtid.
+	sbc.SetResults([]*sqltypes.Result{{Fields: sqltypes.MakeTestFields("vgtid", "varbinary")}})
+	err = vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, nil, nil, flags, func(events []*binlogdatapb.VEvent) error {
+		return nil
+	})
+	require.EqualError(t, err, "VStream consumer group group1 has no checkpoint, the vgtid must be specified")
+	require.Equal(t, "select vgtid from _vt.vstream_checkpoints where consumer_group = 'group1'", sbc.Queries[1].Sql)
+
+	// The stream resumes from the checkpoint.
+	sbc.SetResults([]*sqltypes.Result{{
+		Fields: sqltypes.MakeTestFields("vgtid", "varbinary"),
+		Rows:   [][]sqltypes.Value{{sqltypes.NewVarBinary(string(vgtidbuf))}},
+	}})
+	sbc.StartPos = "pos"
+	sbc.AddVStreamEvents([]*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid01"},
+		{Type: binlogdatapb.VEventType_DDL},
+	}, nil)
+	ch := startVStream(ctx, t, vsm, nil, flags)
+	verifyEvents(t, ch, &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{
+				Keyspace: ks,
+				Shard:    "-",
+				Gtid:     "gtid01",
+			}},
+		}},
+		{Type: binlogdatapb.VEventType_DDL},
+	}})
+}
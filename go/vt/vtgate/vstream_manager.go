@@ -52,6 +52,8 @@ type vstreamManager struct {
 
 	vstreamsCreated *stats.CountersWithMultiLabels
 	vstreamsLag     *stats.GaugesWithMultiLabels
+
+	checkpoints *vstreamCheckpoints
 }
 
 // maxSkewTimeoutSeconds is the maximum allowed skew between two streams when the MinimizeSkew flag is set
@@ -115,6 +117,11 @@ type vstream struct {
 	// the shard map tracking the copy completion, keyed by streamId. streamId is of the form <keyspace>.<shard>
 	copyCompletedShard map[string]struct{}
 
+	// mutex used to synchronize access to the shard lags
+	lagMu sync.Mutex
+	// the state of the stream of each shard, sent with the heartbeats, keyed by streamId. streamId is of the form <keyspace>/<shard>
+	shardLags map[string]*binlogdatapb.ShardLag
+
 	vsm *vstreamManager
 
 	eventCh           chan []*binlogdatapb.VEvent
@@ -145,11 +152,25 @@ func newVStreamManager(resolver *srvtopo.Resolver, serv srvtopo.Server, cell str
 			"VStreamsLag",
 			"Difference between event current time and the binlog event timestamp",
 			[]string{"Keyspace", "ShardName", "TabletType"}),
+		checkpoints: &vstreamCheckpoints{
+			resolver: resolver,
+			keyspace: vstreamCheckpointKeyspace,
+		},
 	}
 }
 
 func (vsm *vstreamManager) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
 	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func(events []*binlogdatapb.VEvent) error) error {
+	if flags.GetConsumerGroup() != "" && len(vgtid.GetShardGtids()) == 0 {
+		checkpoint, err := vsm.checkpoints.load(ctx, flags.ConsumerGroup)
+		if err != nil {
+			return err
+		}
+		if checkpoint == nil {
+			return vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "VStream consumer group %s has no checkpoint, the vgtid must be specified", flags.ConsumerGroup)
+		}
+		vgtid = checkpoint
+	}
 	vgtid, filter, flags, err := vsm.resolveParams(ctx, tabletType, vgtid, filter, flags)
 	if err != nil {
 		return err
@@ -179,6 +200,7 @@ func (vsm *vstreamManager) VStream(ctx context.Context, tabletType topodatapb.Ta
 		heartbeatInterval:  flags.GetHeartbeatInterval(),
 		ts:                 ts,
 		copyCompletedShard: make(map[string]struct{}),
+		shardLags:          make(map[string]*binlogdatapb.ShardLag),
 		tabletPickerOptions: discovery.TabletPickerOptions{
 			CellPreference: flags.GetCellPreference(),
 			TabletOrder:    flags.GetTabletOrder(),
@@ -187,6 +209,12 @@ func (vsm *vstreamManager) VStream(ctx context.Context, tabletType topodatapb.Ta
 	return vs.stream(ctx)
 }
 
+// Ack stores the vgtid as the checkpoint of the consumer group, from which
+// its streams resume when they don't specify a vgtid.
+func (vsm *vstreamManager) Ack(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return vsm.checkpoints.save(ctx, consumerGroup, vgtid)
+}
+
 // resolveParams provides defaults for the inputs if they're not specified.
 func (vsm *vstreamManager) resolveParams(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
 	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (*binlogdatapb.VGtid, *binlogdatapb.Filter, *vtgatepb.VStreamFlags, error) {
@@ -286,7 +314,7 @@ func (vs *vstream) stream(ctx context.Context) error {
 	ctx, vs.cancel = context.WithCancel(ctx)
 	defer vs.cancel()
 
-	go vs.sendEvents(ctx)
+	go vs.sendEvents(ctx, vs.vgtid.CloneVT())
 
 	// Make a copy first, because the ShardGtids list can change once streaming starts.
 	copylist := append(([]*binlogdatapb.ShardGtid)(nil), vs.vgtid.ShardGtids...)
@@ -298,7 +326,11 @@ func (vs *vstream) stream(ctx context.Context) error {
 	return vs.getError()
 }
 
-func (vs *vstream) sendEvents(ctx context.Context) {
+// sendEvents sends the events of the shard streams to the client, and a
+// heartbeat if the stream is idle for the heartbeat interval. A heartbeat
+// carries the last vgtid sent, starting with the vgtid of the stream, and
+// the state of the stream of each of its shards.
+func (vs *vstream) sendEvents(ctx context.Context, vgtid *binlogdatapb.VGtid) {
 	var heartbeat <-chan time.Time
 	var resetHeartbeat func()
 
@@ -331,6 +363,11 @@ func (vs *vstream) sendEvents(ctx context.Context) {
 			})
 			return
 		case evs := <-vs.eventCh:
+			for _, ev := range evs {
+				if ev.Type == binlogdatapb.VEventType_VGTID {
+					vgtid = ev.Vgtid
+				}
+			}
 			if err := send(evs); err != nil {
 				vs.once.Do(func() {
 					vs.setError(err)
@@ -344,6 +381,8 @@ func (vs *vstream) sendEvents(ctx context.Context) {
 				Type:        binlogdatapb.VEventType_HEARTBEAT,
 				Timestamp:   now / 1e9,
 				CurrentTime: now,
+				Vgtid:       vgtid.CloneVT(),
+				ShardLags:   vs.getShardLags(vgtid),
 			}}
 			if err := send(evs); err != nil {
 				vs.once.Do(func() {
@@ -663,7 +702,14 @@ func (vs *vstream) streamFromTablet(ctx context.Context, sgtid *binlogdatapb.Sha
 					journal := event.Journal
 					// Journal events are not sent to clients by default, but only when StopOnReshard is set
 					if vs.stopOnReshard && journal.MigrationType == binlogdatapb.MigrationType_SHARDS {
-						sendevents = append(sendevents, event)
+						// Tell the client which target shards replace each participant.
+						ev := event.CloneVT()
+						shardMappings, err := getShardMappings(journal)
+						if err != nil {
+							return err
+						}
+						ev.Journal.ShardMappings = shardMappings
+						sendevents = append(sendevents, ev)
 						eventss = append(eventss, sendevents)
 						if err := vs.sendAll(ctx, sgtid, eventss); err != nil {
 							return err
@@ -690,7 +736,7 @@ func (vs *vstream) streamFromTablet(ctx context.Context, sgtid *binlogdatapb.Sha
 				}
 				lag := event.CurrentTime/1e9 - event.Timestamp
 				vs.vsm.vstreamsLag.Set(labels, lag)
-
+				vs.setShardLag(sgtid.Keyspace, sgtid.Shard, lag)
 			}
 			if len(sendevents) != 0 {
 				eventss = append(eventss, sendevents)
@@ -831,6 +877,68 @@ func (vs *vstream) isCopyFullyCompleted(ctx context.Context, sgtid *binlogdatapb
 	}
 }
 
+// setShardLag records the lag of the last event received from a shard.
+func (vs *vstream) setShardLag(keyspace, shard string, lag int64) {
+	vs.lagMu.Lock()
+	defer vs.lagMu.Unlock()
+
+	streamID := fmt.Sprintf("%s/%s", keyspace, shard)
+	shardLag, ok := vs.shardLags[streamID]
+	if !ok {
+		shardLag = &binlogdatapb.ShardLag{Keyspace: keyspace, Shard: shard}
+		vs.shardLags[streamID] = shardLag
+	}
+	shardLag.LagSeconds = lag
+	shardLag.LastEventTime = time.Now().Unix()
+}
+
+// getShardLags returns the state of the stream of each shard of the vgtid.
+// The shards from which no event has been received yet have an empty state.
+func (vs *vstream) getShardLags(vgtid *binlogdatapb.VGtid) []*binlogdatapb.ShardLag {
+	vs.lagMu.Lock()
+	defer vs.lagMu.Unlock()
+
+	shardLags := make([]*binlogdatapb.ShardLag, 0, len(vgtid.GetShardGtids()))
+	for _, sgtid := range vgtid.GetShardGtids() {
+		if shardLag, ok := vs.shardLags[fmt.Sprintf("%s/%s", sgtid.Keyspace, sgtid.Shard)]; ok {
+			shardLags = append(shardLags, shardLag.CloneVT())
+		} else {
+			shardLags = append(shardLags, &binlogdatapb.ShardLag{Keyspace: sgtid.Keyspace, Shard: sgtid.Shard})
+		}
+	}
+	return shardLags
+}
+
+// getShardMappings maps each participant of the journal of a reshard to the
+// target shards whose key ranges overlap its key range.
+func getShardMappings(journal *binlogdatapb.Journal) ([]*binlogdatapb.ShardMapping, error) {
+	shardMappings := make([]*binlogdatapb.ShardMapping, 0, len(journal.Participants))
+	for _, participant := range journal.Participants {
+		_, sourceRange, err := topo.ValidateShardName(participant.Shard)
+		if err != nil {
+			return nil, err
+		}
+		shardMapping := &binlogdatapb.ShardMapping{
+			Keyspace:    participant.Keyspace,
+			SourceShard: participant.Shard,
+		}
+		for _, sgtid := range journal.ShardGtids {
+			if sgtid.Keyspace != participant.Keyspace {
+				continue
+			}
+			_, targetRange, err := topo.ValidateShardName(sgtid.Shard)
+			if err != nil {
+				return nil, err
+			}
+			if key.KeyRangeIntersect(sourceRange, targetRange) {
+				shardMapping.TargetShards = append(shardMapping.TargetShards, sgtid.Shard)
+			}
+		}
+		shardMappings = append(shardMappings, shardMapping)
+	}
+	return shardMappings, nil
+}
+
 func (vs *vstream) getError() error {
 	vs.errMu.Lock()
 	defer vs.errMu.Unlock()
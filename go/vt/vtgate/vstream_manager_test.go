@@ -1281,6 +1281,72 @@ func TestVStreamIdleHeartbeat(t *testing.T) {
 	}
 }
 
+func TestVStreamHeartbeatShardLags(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	cell := "aa"
+	ks := "TestVStream"
+	_ = createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-20", "20-40"})
+	vsm := newTestVStreamManager(ctx, hc, st, cell)
+	sbc0 := hc.AddTestTablet("aa", "1.1.1.1", 1001, ks, "-20", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-20", sbc0.Tablet())
+	sbc1 := hc.AddTestTablet("aa", "1.1.1.1", 1002, ks, "20-40", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "20-40", sbc1.Tablet())
+
+	now := time.Now().Unix()
+	sbc0.AddVStreamEvents([]*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid01", Timestamp: now - 5, CurrentTime: now * 1e9},
+		{Type: binlogdatapb.VEventType_COMMIT, Timestamp: now - 5, CurrentTime: now * 1e9},
+	}, nil)
+
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "pos",
+		}, {
+			Keyspace: ks,
+			Shard:    "20-40",
+			Gtid:     "pos2040",
+		}},
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ch := startVStream(ctx, t, vsm, vgtid, &vtgatepb.VStreamFlags{HeartbeatInterval: 1})
+	<-ch
+
+	var heartbeat *binlogdatapb.VEvent
+	for heartbeat == nil {
+		for _, ev := range (<-ch).Events {
+			if ev.Type == binlogdatapb.VEventType_HEARTBEAT {
+				heartbeat = ev
+			}
+		}
+	}
+	// The heartbeat carries the last vgtid sent.
+	wantVgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "gtid01",
+		}, {
+			Keyspace: ks,
+			Shard:    "20-40",
+			Gtid:     "pos2040",
+		}},
+	}
+	require.True(t, proto.Equal(wantVgtid, heartbeat.Vgtid), "got vgtid %v, want %v", heartbeat.Vgtid, wantVgtid)
+	require.Len(t, heartbeat.ShardLags, 2)
+	require.Equal(t, ks, heartbeat.ShardLags[0].Keyspace)
+	require.Equal(t, "-20", heartbeat.ShardLags[0].Shard)
+	require.EqualValues(t, 5, heartbeat.ShardLags[0].LagSeconds)
+	require.GreaterOrEqual(t, heartbeat.ShardLags[0].LastEventTime, now)
+	// No event has been received from the second shard yet.
+	require.True(t, proto.Equal(&binlogdatapb.ShardLag{Keyspace: ks, Shard: "20-40"}, heartbeat.ShardLags[1]))
+}
+
 func TestKeyspaceHasBeenSharded(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -1684,6 +1750,86 @@ func TestVStreamManagerHealthCheckResponseHandling(t *testing.T) {
 	}
 }
 
+func TestVStreamJournalStopOnReshard(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cell := "aa"
+	ks := "TestVStream"
+	_ = createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-20", "-10", "10-20"})
+	vsm := newTestVStreamManager(ctx, hc, st, "aa")
+	sbc0 := hc.AddTestTablet(cell, "1.1.1.1", 1001, ks, "-20", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-20", sbc0.Tablet())
+
+	journal := &binlogdatapb.Journal{
+		Id:            1,
+		MigrationType: binlogdatapb.MigrationType_SHARDS,
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-10",
+			Gtid:     "pos10",
+		}, {
+			Keyspace: ks,
+			Shard:    "10-20",
+			Gtid:     "pos1020",
+		}},
+		Participants: []*binlogdatapb.KeyspaceShard{{
+			Keyspace: ks,
+			Shard:    "-20",
+		}},
+	}
+	sbc0.AddVStreamEvents([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_JOURNAL, Journal: journal}}, nil)
+
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: ks,
+			Shard:    "-20",
+			Gtid:     "pos",
+		}},
+	}
+	ch := startVStream(ctx, t, vsm, vgtid, &vtgatepb.VStreamFlags{StopOnReshard: true})
+	wantJournal := journal.CloneVT()
+	wantJournal.ShardMappings = []*binlogdatapb.ShardMapping{{
+		Keyspace:     ks,
+		SourceShard:  "-20",
+		TargetShards: []string{"-10", "10-20"},
+	}}
+	verifyEvents(t, ch, &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_JOURNAL, Journal: wantJournal},
+	}})
+}
+
+func TestGetShardMappings(t *testing.T) {
+	journal := &binlogdatapb.Journal{
+		MigrationType: binlogdatapb.MigrationType_SHARDS,
+		ShardGtids: []*binlogdatapb.ShardGtid{
+			{Keyspace: "ks", Shard: "-40"},
+			{Keyspace: "ks", Shard: "40-c0"},
+			{Keyspace: "ks", Shard: "c0-"},
+		},
+		Participants: []*binlogdatapb.KeyspaceShard{
+			{Keyspace: "ks", Shard: "-80"},
+			{Keyspace: "ks", Shard: "80-"},
+		},
+	}
+	shardMappings, err := getShardMappings(journal)
+	require.NoError(t, err)
+	require.Equal(t, []*binlogdatapb.ShardMapping{
+		{Keyspace: "ks", SourceShard: "-80", TargetShards: []string{"-40", "40-c0"}},
+		{Keyspace: "ks", SourceShard: "80-", TargetShards: []string{"40-c0", "c0-"}},
+	}, shardMappings)
+
+	// Merging the shards of the keyspace into one.
+	journal.ShardGtids = []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "0"}}
+	shardMappings, err = getShardMappings(journal)
+	require.NoError(t, err)
+	require.Equal(t, []*binlogdatapb.ShardMapping{
+		{Keyspace: "ks", SourceShard: "-80", TargetShards: []string{"0"}},
+		{Keyspace: "ks", SourceShard: "80-", TargetShards: []string{"0"}},
+	}, shardMappings)
+}
+
 func newTestVStreamManager(ctx context.Context, hc discovery.HealthCheck, serv srvtopo.Server, cell string) *vstreamManager {
 	gw := NewTabletGateway(ctx, hc, serv, cell)
 	srvResolver := srvtopo.NewResolver(serv, gw, cell)
@@ -52,8 +52,10 @@ import (
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/auditlog"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	vtschema "vitess.io/vitess/go/vt/vtgate/schema"
+	"vitess.io/vitess/go/vt/vtgate/slowlog"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 )
 
@@ -68,11 +70,37 @@ var (
 	// plan cache related flag
 	queryPlanCacheMemory int64 = 32 * 1024 * 1024 // 32mb
 
+	// result cache related flags
+	queryResultCacheMemory  int64
+	queryResultCacheTTL     = 10 * time.Second
+	queryResultCacheMaxRows = 10000
+	queryResultCacheOptIn   = true
+
+	readRetryMaxAttempts = 1
+
 	maxMemoryRows   = 300000
 	warnMemoryRows  = 30000
 	maxPayloadSize  int
 	warnPayloadSize int
 
+	cteMaxRecursionDepth = 1000
+
+	foreignKeyCascadeBatchSize = 1000
+
+	scatterMaxConcurrency             int
+	scatterAdaptiveConcurrency        bool
+	scatterAdaptiveConcurrencyLatency = time.Second
+
+	workloadMaxConcurrency map[string]int
+	workloadMaxQPS         map[string]int
+	workloadMaxRows        map[string]int
+
+	selectIntoOutfileMaxRows  int64 = 1000000
+	selectIntoOutfileMaxBytes int64 = 1024 * 1024 * 1024 // 1gb
+
+	spillDir      string
+	spillMaxBytes int64
+
 	noScatter          bool
 	enableShardRouting bool
 
@@ -100,6 +128,9 @@ var (
 	enableSchemaChangeSignal = true
 	enableViews              bool
 	enableUdfs               bool
+	// schemaTrackedColumnsOverride makes the columns found by the schema tracker replace
+	// the column lists declared in the VSchema, even the authoritative ones
+	schemaTrackedColumnsOverride bool
 
 	// vtgate views flags
 	queryTimeout int
@@ -126,7 +157,24 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&truncateErrorLen, "truncate-error-len", truncateErrorLen, "truncate errors sent to client if they are longer than this value (0 means do not truncate)")
 	fs.IntVar(&streamBufferSize, "stream_buffer_size", streamBufferSize, "the number of bytes sent from vtgate for each stream call. It's recommended to keep this value in sync with vttablet's query-server-config-stream-buffer-size.")
 	fs.Int64Var(&queryPlanCacheMemory, "gate_query_cache_memory", queryPlanCacheMemory, "gate server query cache size in bytes, maximum amount of memory to be cached. vtgate analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
+	fs.Int64Var(&queryResultCacheMemory, "query-result-cache-memory", queryResultCacheMemory, "Size in bytes of the cache of the results of read-only queries (0 disables the cache). The results are invalidated by the schema changes seen by the schema tracker, by the DMLs executed through this vtgate on their tables and the commits of the transactions on their keyspaces, and after --query-result-cache-ttl.")
+	fs.DurationVar(&queryResultCacheTTL, "query-result-cache-ttl", queryResultCacheTTL, "Maximum time a result stays in the query result cache, which bounds the staleness of the results changed through other vtgates.")
+	fs.IntVar(&queryResultCacheMaxRows, "query-result-cache-max-rows", queryResultCacheMaxRows, "Maximum number of rows of a result kept in the query result cache (0 means no limit).")
+	fs.BoolVar(&queryResultCacheOptIn, "query-result-cache-opt-in", queryResultCacheOptIn, "Only cache the results of the queries with the RESULT_CACHE=ON comment directive. Otherwise, the results of all the deterministic read-only queries are cached, unless they have the RESULT_CACHE=OFF directive.")
+	fs.IntVar(&readRetryMaxAttempts, "read-retry-max-attempts", readRetryMaxAttempts, "Maximum number of times a read sent to the replicas outside of a transaction is retried on other healthy replicas, within the same deadline, when a replica fails to answer it (0 disables the retries).")
 	fs.IntVar(&maxMemoryRows, "max_memory_rows", maxMemoryRows, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
+	fs.IntVar(&cteMaxRecursionDepth, "cte-max-recursion-depth", cteMaxRecursionDepth, "Maximum number of iterations of the recursive part of a recursive common table expression evaluated by vtgate.")
+	fs.IntVar(&foreignKeyCascadeBatchSize, "foreign-key-cascade-batch-size", foreignKeyCascadeBatchSize, "Maximum number of parent rows whose values are cascaded to a child table in a single query, when vtgate manages the foreign keys (0 means no limit).")
+	fs.IntVar(&scatterMaxConcurrency, "scatter-max-concurrency", scatterMaxConcurrency, "Maximum number of shard queries of scatter queries that vtgate sends at the same time to the shards of a keyspace (0 means no limit).")
+	fs.BoolVar(&scatterAdaptiveConcurrency, "scatter-adaptive-concurrency", scatterAdaptiveConcurrency, "Adapt the concurrency of the scatter queries of each keyspace between 1 and --scatter-max-concurrency: it is lowered when the shard queries fail because the tablets are overloaded or are slower than --scatter-adaptive-concurrency-latency, and raised back when they succeed.")
+	fs.DurationVar(&scatterAdaptiveConcurrencyLatency, "scatter-adaptive-concurrency-latency", scatterAdaptiveConcurrencyLatency, "Latency of a shard query above which the adaptive scatter concurrency of its keyspace is lowered (0 means the latency is not used).")
+	fs.StringToIntVar(&workloadMaxConcurrency, "workload-max-concurrency", workloadMaxConcurrency, "Maximum number of queries of a workload that vtgate executes at the same time, as a list of workload=limit pairs. The workload of a query is set by the workload_name connection attribute or by the WORKLOAD_NAME comment directive.")
+	fs.StringToIntVar(&workloadMaxQPS, "workload-max-qps", workloadMaxQPS, "Maximum number of queries per second of a workload that vtgate executes, as a list of workload=limit pairs.")
+	fs.StringToIntVar(&workloadMaxRows, "workload-max-rows", workloadMaxRows, "Maximum number of rows a query of a workload can return, as a list of workload=limit pairs.")
+	fs.Int64Var(&selectIntoOutfileMaxRows, "select-into-outfile-max-rows", selectIntoOutfileMaxRows, "Maximum number of rows of the file written by SELECT ... INTO OUTFILE on sharded keyspaces (0 means no limit).")
+	fs.Int64Var(&selectIntoOutfileMaxBytes, "select-into-outfile-max-bytes", selectIntoOutfileMaxBytes, "Maximum size in bytes of the file written by SELECT ... INTO OUTFILE on sharded keyspaces (0 means no limit).")
+	fs.StringVar(&spillDir, "spill-dir", spillDir, "Directory of the temporary files to which the sorts, hash joins and window functions of streaming queries spill the rows exceeding --max_memory_rows. The default directory for temporary files if empty.")
+	fs.Int64Var(&spillMaxBytes, "spill-max-bytes", spillMaxBytes, "Maximum size in bytes of the temporary files to which a sort, a hash join or a window function of a streaming query spills the rows exceeding --max_memory_rows (0 disables the spilling, and these queries fail instead).")
 	fs.IntVar(&warnMemoryRows, "warn_memory_rows", warnMemoryRows, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
 	fs.StringVar(&defaultDDLStrategy, "ddl_strategy", defaultDDLStrategy, "Set default strategy for DDL statements. Override with @@ddl_strategy session variable")
 	fs.StringVar(&dbDDLPlugin, "dbddl_plugin", dbDDLPlugin, "controls how to handle CREATE/DROP DATABASE. use it if you are using your own database provisioning service")
@@ -150,6 +198,7 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&messageStreamGracePeriod, "message_stream_grace_period", messageStreamGracePeriod, "the amount of time to give for a vttablet to resume if it ends a message stream, usually because of a reparent.")
 	fs.BoolVar(&enableViews, "enable-views", enableViews, "Enable views support in vtgate.")
 	fs.BoolVar(&enableUdfs, "track-udfs", enableUdfs, "Track UDFs in vtgate.")
+	fs.BoolVar(&schemaTrackedColumnsOverride, "schema-tracking-authoritative-columns", schemaTrackedColumnsOverride, "Use the columns found by the schema tracker as the authoritative column lists of the tracked tables, replacing the column lists declared in the VSchema even when they are marked authoritative.")
 	fs.BoolVar(&allowKillStmt, "allow-kill-statement", allowKillStmt, "Allows the execution of kill statement")
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
@@ -221,6 +270,7 @@ type VTGate struct {
 	vsm      *vstreamManager
 	txConn   *TxConn
 	gw       *TabletGateway
+	cursors  *cursorManager
 
 	// stats objects.
 	// TODO(sougou): This needs to be cleaned up. There
@@ -329,6 +379,12 @@ func Init(
 	if err := executor.defaultQueryLogger(); err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
 	}
+	if err := auditlog.Init(executor.queryLogger, env.Parser()); err != nil {
+		log.Fatalf("error initializing audit log: %v", err)
+	}
+	if err := slowlog.Init(executor.queryLogger); err != nil {
+		log.Fatalf("error initializing slow query log: %v", err)
+	}
 
 	// connect the schema tracker with the vschema manager
 	if enableSchemaChangeSignal {
@@ -357,8 +413,10 @@ func Init(
 		srv := initMySQLProtocol(vtgateInst)
 		if srv != nil {
 			servenv.OnTermSync(srv.shutdownMysqlProtocolAndDrain)
+			servenv.HTTPHandleFunc(mysqlDrainPath, srv.handleDrain)
 			servenv.OnClose(srv.rollbackAtShutdown)
 		}
+		initHTTPQueryAPI(vtgateInst)
 	})
 	servenv.OnTerm(func() {
 		if st != nil && enableSchemaChangeSignal {
@@ -582,6 +640,11 @@ func (vtg *VTGate) VStream(ctx context.Context, tabletType topodatapb.TabletType
 	return vtg.vsm.VStream(ctx, tabletType, vgtid, filter, flags, send)
 }
 
+// VStreamAck stores the checkpoint of a VStream consumer group.
+func (vtg *VTGate) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return vtg.vsm.Ack(ctx, consumerGroup, vgtid)
+}
+
 // GetGatewayCacheStatus returns a displayable version of the Gateway cache.
 func (vtg *VTGate) GetGatewayCacheStatus() TabletCacheStatusList {
 	return vtg.gw.CacheStatus()
@@ -594,7 +657,7 @@ func (vtg *VTGate) VSchemaStats() *VSchemaStats {
 
 func truncateErrorStrings(data map[string]any, parser *sqlparser.Parser) map[string]any {
 	ret := map[string]any{}
-	if terseErrors {
+	if terseErrors || parser.RedactsQueryLiterals() {
 		// request might have PII information. Return an empty map
 		return ret
 	}
@@ -619,7 +682,7 @@ func recordAndAnnotateError(err error, statsKey []string, request map[string]any
 		ec.String(),
 	}
 
-	if terseErrors {
+	if terseErrors || parser.RedactsQueryLiterals() {
 		regexpBv := regexp.MustCompile(`BindVars: \{.*\}`)
 		str := regexpBv.ReplaceAllString(err.Error(), "BindVars: {REDACTED}")
 		err = errors.New(str)
@@ -674,6 +737,7 @@ func newVTGate(executor *Executor, resolver *Resolver, vsm *vstreamManager, tc *
 		vsm:          vsm,
 		txConn:       tc,
 		gw:           gw,
+		cursors:      newCursorManager(),
 		timings:      timings,
 		rowsReturned: rowsReturned,
 		rowsAffected: rowsAffected,
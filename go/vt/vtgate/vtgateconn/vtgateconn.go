@@ -43,6 +43,7 @@ func registerFlags(fs *pflag.FlagSet) {
 func init() {
 	servenv.OnParseFor("vttablet", registerFlags)
 	servenv.OnParseFor("vtclient", registerFlags)
+	servenv.OnParseFor("vtstreamer", registerFlags)
 }
 
 // GetVTGateProtocol returns the protocol used to connect to vtgate as provided in the flag.
@@ -111,6 +112,13 @@ func (conn *VTGateConn) VStream(ctx context.Context, tabletType topodatapb.Table
 	return conn.impl.VStream(ctx, tabletType, vgtid, filter, flags)
 }
 
+// VStreamAck stores the vgtid as the checkpoint of the consumer group. It
+// must be called once the events up to the vgtid have been processed, as
+// the streams of the group which don't specify a vgtid resume from it.
+func (conn *VTGateConn) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	return conn.impl.VStreamAck(ctx, consumerGroup, vgtid)
+}
+
 // VTGateSession exposes the Vitess Execution API to the clients.
 // The object maintains client-side state and is comparable to a native MySQL connection.
 // For example, if you enable autocommit on a Session object, all subsequent calls will respect this.
@@ -156,6 +164,41 @@ func (sn *VTGateSession) Prepare(ctx context.Context, query string, bindVars map
 	return fields, err
 }
 
+// OpenCursor opens a cursor on the rows of a single table SELECT, which are
+// read by pages with Fetch. The rows are paginated by the keyset columns, or
+// by the primary key of the table in the vschema if there are none.
+func (sn *VTGateSession) OpenCursor(ctx context.Context, query string, bindVars map[string]*querypb.BindVariable, keysetColumns []string) (*Cursor, error) {
+	id, err := sn.impl.OpenCursor(ctx, sn.session, query, bindVars, keysetColumns)
+	if err != nil {
+		return nil, err
+	}
+	return &Cursor{id: id, impl: sn.impl}, nil
+}
+
+// Cursor is a cursor opened by VTGateSession.OpenCursor. No stream is held
+// open between its fetches, which can be spread over a long time, as long
+// as they come before the idle timeout of the cursor in vtgate.
+type Cursor struct {
+	id   string
+	impl Impl
+}
+
+// ID returns the ID of the cursor in vtgate.
+func (c *Cursor) ID() string {
+	return c.id
+}
+
+// Fetch returns the next rows of the cursor, at most maxRows. done is set
+// when the cursor has no more rows, and vtgate has closed it.
+func (c *Cursor) Fetch(ctx context.Context, maxRows int) (qr *sqltypes.Result, done bool, err error) {
+	return c.impl.FetchCursor(ctx, c.id, maxRows)
+}
+
+// Close closes the cursor before all its rows are fetched.
+func (c *Cursor) Close(ctx context.Context) error {
+	return c.impl.CloseCursor(ctx, c.id)
+}
+
 //
 // The rest of this file is for the protocol implementations.
 //
@@ -178,12 +221,24 @@ type Impl interface {
 	// CloseSession closes the session provided by rolling back any active transaction.
 	CloseSession(ctx context.Context, session *vtgatepb.Session) error
 
+	// OpenCursor opens a cursor on the rows of a single table SELECT, and returns its ID.
+	OpenCursor(ctx context.Context, session *vtgatepb.Session, query string, bindVars map[string]*querypb.BindVariable, keysetColumns []string) (string, error)
+
+	// FetchCursor returns the next rows of a cursor, and whether it has no more rows.
+	FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error)
+
+	// CloseCursor closes a cursor.
+	CloseCursor(ctx context.Context, cursorID string) error
+
 	// ResolveTransaction resolves the specified 2pc transaction.
 	ResolveTransaction(ctx context.Context, dtid string) error
 
 	// VStream streams binlogevents
 	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (VStreamReader, error)
 
+	// VStreamAck stores the checkpoint of a VStream consumer group.
+	VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error
+
 	// Close must be called for releasing resources.
 	Close()
 }
@@ -42,11 +42,18 @@ type VTGateService interface {
 	// but does not affect the query statistics.
 	CloseSession(ctx context.Context, session *vtgatepb.Session) error
 
+	// Cursor support: OpenCursor returns the ID of a cursor on the rows of
+	// a single table SELECT, which are read by pages with FetchCursor.
+	OpenCursor(ctx context.Context, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, keysetColumns []string) (string, error)
+	FetchCursor(ctx context.Context, cursorID string, maxRows int) (*sqltypes.Result, bool, error)
+	CloseCursor(ctx context.Context, cursorID string) error
+
 	// 2PC support
 	ResolveTransaction(ctx context.Context, dtid string) error
 
 	// Update Stream methods
 	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error
+	VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error
 
 	// HandlePanic should be called with defer at the beginning of each
 	// RPC implementation method, before calling any of the previous methods
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	quotaConcurrency = "Concurrency"
+	quotaQPS         = "QPS"
+	quotaRows        = "Rows"
+)
+
+var (
+	workloadQueries         = stats.NewCountersWithSingleLabel("VtgateWorkloadQueries", "Queries executed by workload", "Workload")
+	workloadRowsReturned    = stats.NewCountersWithSingleLabel("VtgateWorkloadRowsReturned", "Rows returned by workload", "Workload")
+	workloadQueriesInFlight = stats.NewGaugesWithSingleLabel("VtgateWorkloadQueriesInFlight", "Queries being executed by workload", "Workload")
+	workloadQuotaRejections = stats.NewCountersWithMultiLabels("VtgateWorkloadQuotaRejections", "Queries rejected because their workload exceeded a quota", []string{"Workload", "Quota"})
+)
+
+// workloadQuotas enforces the quotas of the workloads, which are identified by the
+// workload name of the session, or of the query with the WORKLOAD_NAME comment directive.
+// A workload can be limited in the number of queries it runs at the same time,
+// in the number of queries it runs per second, and in the number of rows a query returns.
+// The queries over a quota fail with a RESOURCE_EXHAUSTED error.
+// The queries of all the workloads are counted, whether they have quotas or not.
+type workloadQuotas struct {
+	maxConcurrency map[string]int
+	maxQPS         map[string]int
+	maxRows        map[string]int
+
+	now func() time.Time
+
+	mu sync.Mutex
+	// workloads holds the state of the workloads with a concurrency or a QPS quota.
+	workloads map[string]*workloadState
+}
+
+type workloadState struct {
+	inFlight int
+
+	// tokens is the number of queries the workload can still run in the current second,
+	// which grows back continuously up to its QPS quota.
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newWorkloadQuotas(maxConcurrency, maxQPS, maxRows map[string]int) *workloadQuotas {
+	return &workloadQuotas{
+		maxConcurrency: maxConcurrency,
+		maxQPS:         maxQPS,
+		maxRows:        maxRows,
+		now:            time.Now,
+		workloads:      make(map[string]*workloadState),
+	}
+}
+
+// acquire checks the concurrency and QPS quotas of the workload before one of its queries is executed.
+// The returned function must be called once the query is done.
+func (wq *workloadQuotas) acquire(workload string) (func(), error) {
+	if wq == nil || workload == "" {
+		return func() {}, nil
+	}
+
+	if wq.maxConcurrency[workload] > 0 || wq.maxQPS[workload] > 0 {
+		if err := wq.reserve(workload); err != nil {
+			return nil, err
+		}
+	}
+
+	workloadQueries.Add(workload, 1)
+	workloadQueriesInFlight.Add(workload, 1)
+	return func() {
+		workloadQueriesInFlight.Add(workload, -1)
+		if wq.maxConcurrency[workload] > 0 || wq.maxQPS[workload] > 0 {
+			wq.mu.Lock()
+			defer wq.mu.Unlock()
+			wq.workloads[workload].inFlight--
+		}
+	}, nil
+}
+
+// reserve takes a slot of the concurrency quota and a token of the QPS quota of the workload.
+func (wq *workloadQuotas) reserve(workload string) error {
+	wq.mu.Lock()
+	defer wq.mu.Unlock()
+	ws, ok := wq.workloads[workload]
+	if !ok {
+		ws = &workloadState{tokens: float64(wq.maxQPS[workload]), lastRefill: wq.now()}
+		wq.workloads[workload] = ws
+	}
+
+	if limit := wq.maxConcurrency[workload]; limit > 0 && ws.inFlight >= limit {
+		workloadQuotaRejections.Add([]string{workload, quotaConcurrency}, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "workload %s exceeded its quota of %d concurrent queries", workload, limit)
+	}
+	if qps := wq.maxQPS[workload]; qps > 0 {
+		now := wq.now()
+		ws.tokens = min(ws.tokens+now.Sub(ws.lastRefill).Seconds()*float64(qps), float64(qps))
+		ws.lastRefill = now
+		if ws.tokens < 1 {
+			workloadQuotaRejections.Add([]string{workload, quotaQPS}, 1)
+			return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "workload %s exceeded its quota of %d queries per second", workload, qps)
+		}
+		ws.tokens--
+	}
+	ws.inFlight++
+	return nil
+}
+
+// checkRows records the rows just returned by a query of the workload, and fails when
+// the total rows returned by the query exceed the row quota of the workload.
+func (wq *workloadQuotas) checkRows(workload string, rows, total int) error {
+	if wq == nil || workload == "" {
+		return nil
+	}
+	workloadRowsReturned.Add(workload, int64(rows))
+	if limit := wq.maxRows[workload]; limit > 0 && total > limit {
+		workloadQuotaRejections.Add([]string{workload, quotaRows}, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "workload %s exceeded its quota of %d rows per query", workload, limit)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestWorkloadQuotasConcurrency(t *testing.T) {
+	wq := newWorkloadQuotas(map[string]int{"batch": 2}, nil, nil)
+	rejections := workloadQuotaRejections.Counts()["batch.Concurrency"]
+
+	release1, err := wq.acquire("batch")
+	require.NoError(t, err)
+	release2, err := wq.acquire("batch")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, workloadQueriesInFlight.Counts()["batch"])
+
+	_, err = wq.acquire("batch")
+	require.EqualError(t, err, "workload batch exceeded its quota of 2 concurrent queries")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.EqualValues(t, rejections+1, workloadQuotaRejections.Counts()["batch.Concurrency"])
+
+	// The workloads without quotas and the queries without workload are not limited.
+	for range 3 {
+		release, err := wq.acquire("oltp")
+		require.NoError(t, err)
+		defer release()
+		release, err = wq.acquire("")
+		require.NoError(t, err)
+		defer release()
+	}
+
+	release1()
+	release3, err := wq.acquire("batch")
+	require.NoError(t, err)
+	release2()
+	release3()
+	assert.EqualValues(t, 0, workloadQueriesInFlight.Counts()["batch"])
+}
+
+func TestWorkloadQuotasQPS(t *testing.T) {
+	wq := newWorkloadQuotas(nil, map[string]int{"batch": 2}, nil)
+	now := time.Now()
+	wq.now = func() time.Time { return now }
+
+	for range 2 {
+		release, err := wq.acquire("batch")
+		require.NoError(t, err)
+		release()
+	}
+	_, err := wq.acquire("batch")
+	require.EqualError(t, err, "workload batch exceeded its quota of 2 queries per second")
+
+	// Half a second later, a single query can be executed again.
+	now = now.Add(500 * time.Millisecond)
+	release, err := wq.acquire("batch")
+	require.NoError(t, err)
+	release()
+	_, err = wq.acquire("batch")
+	require.Error(t, err)
+
+	// The unused quota does not accumulate over more than a second.
+	now = now.Add(time.Minute)
+	for range 2 {
+		release, err := wq.acquire("batch")
+		require.NoError(t, err)
+		release()
+	}
+	_, err = wq.acquire("batch")
+	require.Error(t, err)
+}
+
+func TestWorkloadQuotasRows(t *testing.T) {
+	wq := newWorkloadQuotas(nil, nil, map[string]int{"batch": 10})
+	rows := workloadRowsReturned.Counts()["batch"]
+
+	require.NoError(t, wq.checkRows("batch", 6, 6))
+	require.NoError(t, wq.checkRows("batch", 4, 10))
+	err := wq.checkRows("batch", 1, 11)
+	require.EqualError(t, err, "workload batch exceeded its quota of 10 rows per query")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.EqualValues(t, rows+11, workloadRowsReturned.Counts()["batch"])
+
+	require.NoError(t, wq.checkRows("oltp", 100, 100))
+}
+
+func TestExecutorWorkloadRowsQuota(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	executor.workloadQuotas = newWorkloadQuotas(nil, nil, map[string]int{"batch": 1})
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2")
+
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	_, err := executorExec(ctx, executor, &vtgatepb.Session{}, "select id from main1", nil)
+	require.NoError(t, err)
+
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	_, err = executorExec(ctx, executor, &vtgatepb.Session{}, "select /*vt+ WORKLOAD_NAME=batch */ id from main1", nil)
+	require.EqualError(t, err, "workload batch exceeded its quota of 1 rows per query")
+
+	// The workload can also be set on the session, like the vtgate MySQL server does with the workload_name connection attribute.
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	session := &vtgatepb.Session{Options: &querypb.ExecuteOptions{WorkloadName: "batch"}}
+	_, err = executorExec(ctx, executor, session, "select id from main1", nil)
+	require.EqualError(t, err, "workload batch exceeded its quota of 1 rows per query")
+
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	_, err = executorStream(ctx, executor, "select /*vt+ WORKLOAD_NAME=batch */ id from main1")
+	require.ErrorContains(t, err, "workload batch exceeded its quota of 1 rows per query")
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtstreamer
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// The change events are published in a canonical JSON form, which doesn't
+// depend on the broker:
+//
+//	{
+//	  "op": "u",
+//	  "source": {"keyspace": "customer", "shard": "-80", "table": "corder", "timestamp": 1712345678},
+//	  "before": {"order_id": 1, "customer_id": 1, "sku": "SKU-1001", "price": 100},
+//	  "after": {"order_id": 1, "customer_id": 1, "sku": "SKU-1001", "price": 120}
+//	}
+//
+// The op is c for an insert, u for an update and d for a delete. The before
+// image of an insert and the after image of a delete are null. The columns of
+// the images are in the order of the table, the numbers are JSON numbers, the
+// JSON columns are embedded as is, the binary columns are base64 encoded and
+// the other columns are strings.
+//
+// The key of the message is a JSON object with the key columns of the row,
+// like {"order_id": 1}.
+
+// Operations of the change events.
+const (
+	OpCreate = "c"
+	OpUpdate = "u"
+	OpDelete = "d"
+)
+
+// ChangeEvent is the change of a row.
+type ChangeEvent struct {
+	Op     string      `json:"op"`
+	Source EventSource `json:"source"`
+	Before *Row        `json:"before"`
+	After  *Row        `json:"after"`
+}
+
+// EventSource is the origin of a ChangeEvent.
+type EventSource struct {
+	Keyspace string `json:"keyspace"`
+	Shard    string `json:"shard"`
+	Table    string `json:"table"`
+	// Timestamp is the time of the binlog event in seconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Row is an image of a row, marshaled as a JSON object whose keys are its
+// column names.
+type Row struct {
+	Fields []*querypb.Field
+	Values []sqltypes.Value
+}
+
+// MarshalJSON marshals the row with its columns in order.
+func (r *Row) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range r.Fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		val, err := marshalValue(r.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalValue returns the JSON form of a column value.
+func marshalValue(v sqltypes.Value) ([]byte, error) {
+	switch {
+	case v.IsNull():
+		return []byte("null"), nil
+	case v.Type() == sqltypes.TypeJSON && json.Valid(v.Raw()):
+		return v.Raw(), nil
+	case sqltypes.IsBinary(v.Type()) || v.Type() == sqltypes.Bit || v.Type() == sqltypes.Geometry:
+		return json.Marshal(v.Raw())
+	case sqltypes.IsNumber(v.Type()):
+		return v.Raw(), nil
+	}
+	return json.Marshal(v.ToString())
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtstreamer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestRowMarshalJSON(t *testing.T) {
+	row := &Row{
+		Fields: sqltypes.MakeTestFields("i|u|f|d|s|b|j|n", "int64|uint64|float64|decimal|varchar|varbinary|json|int64"),
+		Values: []sqltypes.Value{
+			sqltypes.NewInt64(-1),
+			sqltypes.NewUint64(2),
+			sqltypes.NewFloat64(1.5),
+			sqltypes.NewDecimal("10.25"),
+			sqltypes.NewVarChar(`a"b`),
+			sqltypes.NewVarBinary("\x00\x01"),
+			sqltypes.TestValue(sqltypes.TypeJSON, `{"a": [1, 2]}`),
+			sqltypes.NULL,
+		},
+	}
+	got, err := row.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `{"i":-1,"u":2,"f":1.5,"d":10.25,"s":"a\"b","b":"AAE=","j":{"a": [1, 2]},"n":null}`, string(got))
+}
+
+func TestTopicMapper(t *testing.T) {
+	_, err := NewTopicMapper("", nil)
+	require.EqualError(t, err, "the topic template must not be empty")
+	_, err = NewTopicMapper(DefaultTopicTemplate, map[string]string{"t1": ""})
+	require.EqualError(t, err, "the topic of table t1 must not be empty")
+
+	tm, err := NewTopicMapper("cdc-{keyspace}-{table}", map[string]string{"t1": "orders", "ks2.t1": "orders2"})
+	require.NoError(t, err)
+	require.Equal(t, "orders", tm.Topic("ks1", "t1"))
+	require.Equal(t, "orders2", tm.Topic("ks2", "t1"))
+	require.Equal(t, "cdc-ks1-t2", tm.Topic("ks1", "t2"))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtstreamer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"vitess.io/vitess/go/vt/log"
+)
+
+// Message is a change event published to a topic.
+type Message struct {
+	Topic string
+	// Key is the key of the row, which the brokers use to assign the messages
+	// of a topic to its partitions. It's nil if the table has no key columns.
+	Key   []byte
+	Value []byte
+}
+
+// Publisher publishes the change events to a message broker.
+type Publisher interface {
+	// Publish publishes the messages in order. It must only return once the
+	// broker has acknowledged them, as the position of the stream is
+	// checkpointed after them.
+	Publish(ctx context.Context, msgs []*Message) error
+	// Close flushes and closes the publisher.
+	Close() error
+}
+
+// PublisherFactory creates a Publisher.
+type PublisherFactory func() (Publisher, error)
+
+var (
+	publishersMu sync.Mutex
+	publishers   = make(map[string]PublisherFactory)
+)
+
+// RegisterPublisher registers a publisher under the name selected with
+// --publisher. The publishers which depend on the client library of a broker
+// are registered by a plugin of the binary.
+func RegisterPublisher(name string, factory PublisherFactory) {
+	publishersMu.Lock()
+	defer publishersMu.Unlock()
+
+	if _, ok := publishers[name]; ok {
+		log.Warningf("Publisher %s already exists, overwriting it", name)
+	}
+	publishers[name] = factory
+}
+
+// NewPublisher creates the publisher registered under the name.
+func NewPublisher(name string) (Publisher, error) {
+	publishersMu.Lock()
+	factory, ok := publishers[name]
+	publishersMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no publisher registered as %s, the registered publishers are %v", name, PublisherNames())
+	}
+	return factory()
+}
+
+// PublisherNames returns the sorted names of the registered publishers.
+func PublisherNames() []string {
+	publishersMu.Lock()
+	defer publishersMu.Unlock()
+
+	names := make([]string, 0, len(publishers))
+	for name := range publishers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterPublisher("stdout", func() (Publisher, error) {
+		return newWriterPublisher(os.Stdout), nil
+	})
+}
+
+// writerPublisher writes the messages to a writer as JSON lines. It's meant
+// for testing and for piping the change events to another program.
+type writerPublisher struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newWriterPublisher(w io.Writer) *writerPublisher {
+	return &writerPublisher{enc: json.NewEncoder(w)}
+}
+
+type writerMessage struct {
+	Topic string          `json:"topic"`
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Publish is part of the Publisher interface.
+func (wp *writerPublisher) Publish(ctx context.Context, msgs []*Message) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	for _, msg := range msgs {
+		wm := &writerMessage{Topic: msg.Topic, Key: msg.Key, Value: msg.Value}
+		if wm.Key == nil {
+			wm.Key = json.RawMessage("null")
+		}
+		if err := wp.enc.Encode(wm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is part of the Publisher interface.
+func (wp *writerPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vtstreamer publishes the change events of the VStream API of vtgate
+// to a message broker.
+//
+// The position of the stream is checkpointed in vtgate, as a VStream consumer
+// group: the events of each transaction are published, and then the vgtid
+// which follows them is acknowledged with VStreamAck. When the streamer is
+// restarted, it resumes from the last checkpoint of its group, so the events
+// published after it are published again: they are delivered at least once.
+package vtstreamer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// VStreamClient is the part of the vtgate API used by a Streamer. It's
+// implemented by *vtgateconn.VTGateConn.
+type VStreamClient interface {
+	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+		filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error)
+	VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error
+}
+
+// Config is the configuration of a Streamer.
+type Config struct {
+	// Keyspace is the keyspace whose tables are streamed.
+	Keyspace string
+	// Tables are the tables which are streamed, all the tables of the
+	// keyspace if empty.
+	Tables     []string
+	TabletType topodatapb.TabletType
+	// ConsumerGroup is the VStream consumer group which stores the
+	// checkpoints of the streamer.
+	ConsumerGroup string
+	// Copy, if the consumer group has no checkpoint yet, publishes the rows
+	// which are already in the tables before their changes. Otherwise the
+	// streamer starts from the current position.
+	Copy   bool
+	Topics *TopicMapper
+	// KeyColumns are the key columns of the messages, by table. The key
+	// columns of the tables which aren't in it are the columns flagged as part
+	// of the primary key by the stream, if any.
+	KeyColumns map[string][]string
+	// CheckpointInterval is the minimum interval between two checkpoints.
+	CheckpointInterval time.Duration
+}
+
+// Streamer publishes the change events of a keyspace.
+type Streamer struct {
+	cfg       *Config
+	client    VStreamClient
+	publisher Publisher
+
+	fields  map[string][]*querypb.Field // keyed by the table name of the events
+	pending []*Message
+
+	// position is the vgtid up to which the events are published, and
+	// checkpoint is the last vgtid acknowledged.
+	position       *binlogdatapb.VGtid
+	checkpoint     *binlogdatapb.VGtid
+	lastCheckpoint time.Time
+}
+
+// NewStreamer creates a Streamer.
+func NewStreamer(cfg *Config, client VStreamClient, publisher Publisher) (*Streamer, error) {
+	if cfg.Keyspace == "" {
+		return nil, fmt.Errorf("the keyspace must be specified")
+	}
+	if cfg.ConsumerGroup == "" {
+		return nil, fmt.Errorf("the consumer group must be specified")
+	}
+	if cfg.Topics == nil {
+		return nil, fmt.Errorf("the topics must be specified")
+	}
+	return &Streamer{
+		cfg:       cfg,
+		client:    client,
+		publisher: publisher,
+		fields:    make(map[string][]*querypb.Field),
+	}, nil
+}
+
+func (s *Streamer) filter() *binlogdatapb.Filter {
+	if len(s.cfg.Tables) == 0 {
+		return &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "/.*"}}}
+	}
+	filter := &binlogdatapb.Filter{}
+	for _, table := range s.cfg.Tables {
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{
+			Match:  table,
+			Filter: "select * from " + sqlparser.String(sqlparser.NewIdentifierCS(table)),
+		})
+	}
+	return filter
+}
+
+// Run streams and publishes the change events until the context is canceled
+// or the stream fails. The last position published is checkpointed before it
+// returns.
+func (s *Streamer) Run(ctx context.Context) error {
+	defer s.finalCheckpoint()
+
+	filter := s.filter()
+	flags := &vtgatepb.VStreamFlags{ConsumerGroup: s.cfg.ConsumerGroup}
+	err := s.stream(ctx, nil, filter, flags)
+	if vterrors.Code(err) != vtrpcpb.Code_NOT_FOUND || s.position != nil {
+		return err
+	}
+
+	// The consumer group has no checkpoint yet.
+	vgtid := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{
+		Keyspace: s.cfg.Keyspace,
+		Gtid:     "current",
+	}}}
+	if s.cfg.Copy {
+		vgtid.ShardGtids[0].Gtid = ""
+	}
+	log.Infof("Consumer group %s has no checkpoint, starting from position %q", s.cfg.ConsumerGroup, vgtid.ShardGtids[0].Gtid)
+	return s.stream(ctx, vgtid, filter, flags)
+}
+
+func (s *Streamer) stream(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) error {
+	reader, err := s.client.VStream(ctx, s.cfg.TabletType, vgtid, filter, flags)
+	if err != nil {
+		return err
+	}
+	for {
+		events, err := reader.Recv()
+		if err != nil {
+			return err
+		}
+		if err := s.processEvents(ctx, events); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Streamer) processEvents(ctx context.Context, events []*binlogdatapb.VEvent) error {
+	for _, ev := range events {
+		switch ev.Type {
+		case binlogdatapb.VEventType_FIELD:
+			s.fields[ev.FieldEvent.TableName] = ev.FieldEvent.Fields
+		case binlogdatapb.VEventType_ROW:
+			msgs, err := s.rowMessages(ev)
+			if err != nil {
+				return err
+			}
+			s.pending = append(s.pending, msgs...)
+		case binlogdatapb.VEventType_VGTID:
+			if err := s.publish(ctx, ev.Vgtid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// publish publishes the pending messages, which precede the vgtid, and
+// checkpoints the vgtid if the checkpoint interval has elapsed.
+func (s *Streamer) publish(ctx context.Context, vgtid *binlogdatapb.VGtid) error {
+	if len(s.pending) != 0 {
+		if err := s.publisher.Publish(ctx, s.pending); err != nil {
+			return vterrors.Wrapf(err, "failed to publish %d change events", len(s.pending))
+		}
+		s.pending = nil
+	}
+	s.position = vgtid
+	if time.Since(s.lastCheckpoint) < s.cfg.CheckpointInterval {
+		return nil
+	}
+	return s.saveCheckpoint(ctx)
+}
+
+func (s *Streamer) saveCheckpoint(ctx context.Context) error {
+	if s.position == nil || s.position == s.checkpoint {
+		return nil
+	}
+	if err := s.client.VStreamAck(ctx, s.cfg.ConsumerGroup, s.position); err != nil {
+		return vterrors.Wrapf(err, "failed to checkpoint consumer group %s", s.cfg.ConsumerGroup)
+	}
+	s.checkpoint = s.position
+	s.lastCheckpoint = time.Now()
+	return nil
+}
+
+// finalCheckpoint checkpoints the last position published when the stream
+// ends, which can be because its context is canceled.
+func (s *Streamer) finalCheckpoint() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.saveCheckpoint(ctx); err != nil {
+		log.Errorf("Failed to save the final checkpoint: %v", err)
+	}
+}
+
+// rowMessages returns the messages of the changes of a row event.
+func (s *Streamer) rowMessages(ev *binlogdatapb.VEvent) ([]*Message, error) {
+	re := ev.RowEvent
+	fields, ok := s.fields[re.TableName]
+	if !ok {
+		return nil, fmt.Errorf("no fields for table %s", re.TableName)
+	}
+	// The table names of the events are qualified with their keyspace.
+	table := strings.TrimPrefix(re.TableName, re.Keyspace+".")
+	keyCols, err := s.keyColumns(table, fields)
+	if err != nil {
+		return nil, err
+	}
+	topic := s.cfg.Topics.Topic(re.Keyspace, table)
+
+	msgs := make([]*Message, 0, len(re.RowChanges))
+	for _, change := range re.RowChanges {
+		ce := &ChangeEvent{
+			Source: EventSource{
+				Keyspace:  re.Keyspace,
+				Shard:     re.Shard,
+				Table:     table,
+				Timestamp: ev.Timestamp,
+			},
+		}
+		if change.Before != nil {
+			ce.Before = &Row{Fields: fields, Values: sqltypes.MakeRowTrusted(fields, change.Before)}
+		}
+		if change.After != nil {
+			ce.After = &Row{Fields: fields, Values: sqltypes.MakeRowTrusted(fields, change.After)}
+		}
+		keyRow := ce.After
+		switch {
+		case ce.Before == nil:
+			ce.Op = OpCreate
+		case ce.After == nil:
+			ce.Op = OpDelete
+			keyRow = ce.Before
+		default:
+			ce.Op = OpUpdate
+		}
+
+		value, err := json.Marshal(ce)
+		if err != nil {
+			return nil, err
+		}
+		msg := &Message{Topic: topic, Value: value}
+		if len(keyCols) != 0 {
+			key := &Row{}
+			for _, col := range keyCols {
+				key.Fields = append(key.Fields, fields[col])
+				key.Values = append(key.Values, keyRow.Values[col])
+			}
+			if msg.Key, err = key.MarshalJSON(); err != nil {
+				return nil, err
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// keyColumns returns the indexes of the key columns of a table.
+func (s *Streamer) keyColumns(table string, fields []*querypb.Field) ([]int, error) {
+	var cols []int
+	if names, ok := s.cfg.KeyColumns[table]; ok {
+		for _, name := range names {
+			col := -1
+			for i, field := range fields {
+				if strings.EqualFold(field.Name, name) {
+					col = i
+					break
+				}
+			}
+			if col == -1 {
+				return nil, fmt.Errorf("key column %s is not a column of table %s", name, table)
+			}
+			cols = append(cols, col)
+		}
+		return cols, nil
+	}
+	for i, field := range fields {
+		if field.Flags&uint32(querypb.MySqlFlag_PRI_KEY_FLAG) != 0 {
+			cols = append(cols, i)
+		}
+	}
+	return cols, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtstreamer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+type fakeReader struct {
+	batches [][]*binlogdatapb.VEvent
+	err     error
+}
+
+func (r *fakeReader) Recv() ([]*binlogdatapb.VEvent, error) {
+	if len(r.batches) == 0 {
+		return nil, r.err
+	}
+	events := r.batches[0]
+	r.batches = r.batches[1:]
+	return events, nil
+}
+
+type fakeClient struct {
+	// readers are returned by the successive calls to VStream.
+	readers []*fakeReader
+	vgtids  []*binlogdatapb.VGtid
+	filter  *binlogdatapb.Filter
+	acks    []*binlogdatapb.VGtid
+}
+
+func (c *fakeClient) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
+	filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (vtgateconn.VStreamReader, error) {
+	if flags.ConsumerGroup != "group1" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected consumer group %s", flags.ConsumerGroup)
+	}
+	c.vgtids = append(c.vgtids, vgtid)
+	c.filter = filter
+	reader := c.readers[0]
+	c.readers = c.readers[1:]
+	return reader, nil
+}
+
+func (c *fakeClient) VStreamAck(ctx context.Context, consumerGroup string, vgtid *binlogdatapb.VGtid) error {
+	c.acks = append(c.acks, vgtid)
+	return nil
+}
+
+func newTestVGtid(gtid string) *binlogdatapb.VGtid {
+	return &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "-80", Gtid: gtid}}}
+}
+
+func TestStreamer(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	fields[0].Flags = uint32(querypb.MySqlFlag_PRI_KEY_FLAG)
+	row := func(id int64, name string) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(id), sqltypes.NewVarChar(name)})
+	}
+	events := [][]*binlogdatapb.VEvent{{
+		{Type: binlogdatapb.VEventType_FIELD, FieldEvent: &binlogdatapb.FieldEvent{TableName: "ks.t1", Fields: fields, Keyspace: "ks", Shard: "-80"}},
+		{Type: binlogdatapb.VEventType_ROW, Timestamp: 1, RowEvent: &binlogdatapb.RowEvent{
+			TableName: "ks.t1",
+			Keyspace:  "ks",
+			Shard:     "-80",
+			RowChanges: []*binlogdatapb.RowChange{
+				{After: row(1, "a")},
+				{Before: row(1, "a"), After: row(1, "b")},
+			},
+		}},
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: newTestVGtid("pos1")},
+	}, {
+		{Type: binlogdatapb.VEventType_ROW, Timestamp: 2, RowEvent: &binlogdatapb.RowEvent{
+			TableName:  "ks.t1",
+			Keyspace:   "ks",
+			Shard:      "-80",
+			RowChanges: []*binlogdatapb.RowChange{{Before: row(1, "b")}},
+		}},
+		{Type: binlogdatapb.VEventType_VGTID, Vgtid: newTestVGtid("pos2")},
+		// The rows of an unfinished transaction aren't published.
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{
+			TableName:  "ks.t1",
+			Keyspace:   "ks",
+			Shard:      "-80",
+			RowChanges: []*binlogdatapb.RowChange{{After: row(2, "c")}},
+		}},
+	}}
+	wantOutput := `{"topic":"cdc.ks.t1","key":{"id":1},"value":{"op":"c","source":{"keyspace":"ks","shard":"-80","table":"t1","timestamp":1},"before":null,"after":{"id":1,"name":"a"}}}
+{"topic":"cdc.ks.t1","key":{"id":1},"value":{"op":"u","source":{"keyspace":"ks","shard":"-80","table":"t1","timestamp":1},"before":{"id":1,"name":"a"},"after":{"id":1,"name":"b"}}}
+{"topic":"cdc.ks.t1","key":{"id":1},"value":{"op":"d","source":{"keyspace":"ks","shard":"-80","table":"t1","timestamp":2},"before":{"id":1,"name":"b"},"after":null}}
+`
+
+	newStreamer := func(t *testing.T, client *fakeClient, copy bool) (*Streamer, *bytes.Buffer) {
+		topics, err := NewTopicMapper("cdc.{keyspace}.{table}", nil)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		s, err := NewStreamer(&Config{
+			Keyspace:      "ks",
+			Tables:        []string{"t1"},
+			ConsumerGroup: "group1",
+			Copy:          copy,
+			Topics:        topics,
+		}, client, newWriterPublisher(&buf))
+		require.NoError(t, err)
+		return s, &buf
+	}
+
+	t.Run("resume from checkpoint", func(t *testing.T) {
+		client := &fakeClient{readers: []*fakeReader{{batches: events, err: io.EOF}}}
+		s, buf := newStreamer(t, client, false)
+		require.ErrorIs(t, s.Run(context.Background()), io.EOF)
+		require.Equal(t, []*binlogdatapb.VGtid{nil}, client.vgtids)
+		require.Equal(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: "select * from t1"}}}, client.filter)
+		require.Equal(t, wantOutput, buf.String())
+		require.Equal(t, []*binlogdatapb.VGtid{newTestVGtid("pos1"), newTestVGtid("pos2")}, client.acks)
+	})
+
+	t.Run("no checkpoint", func(t *testing.T) {
+		noCheckpoint := vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "VStream consumer group group1 has no checkpoint, the vgtid must be specified")
+		for _, copy := range []bool{false, true} {
+			client := &fakeClient{readers: []*fakeReader{{err: noCheckpoint}, {batches: events[:1], err: io.EOF}}}
+			s, _ := newStreamer(t, client, copy)
+			require.ErrorIs(t, s.Run(context.Background()), io.EOF)
+			wantGtid := "current"
+			if copy {
+				wantGtid = ""
+			}
+			require.Equal(t, []*binlogdatapb.VGtid{nil, {ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Gtid: wantGtid}}}}, client.vgtids)
+			require.Equal(t, []*binlogdatapb.VGtid{newTestVGtid("pos1")}, client.acks)
+		}
+	})
+
+	t.Run("checkpoint interval", func(t *testing.T) {
+		client := &fakeClient{readers: []*fakeReader{{batches: events, err: io.EOF}}}
+		s, _ := newStreamer(t, client, false)
+		s.cfg.CheckpointInterval = time.Hour
+		require.ErrorIs(t, s.Run(context.Background()), io.EOF)
+		// The first position is checkpointed at once, the last one when the
+		// stream ends.
+		require.Equal(t, []*binlogdatapb.VGtid{newTestVGtid("pos1"), newTestVGtid("pos2")}, client.acks)
+	})
+
+	t.Run("key columns", func(t *testing.T) {
+		client := &fakeClient{readers: []*fakeReader{{batches: events[:1], err: io.EOF}}}
+		s, buf := newStreamer(t, client, false)
+		s.cfg.KeyColumns = map[string][]string{"t1": {"name", "id"}}
+		require.ErrorIs(t, s.Run(context.Background()), io.EOF)
+		require.Contains(t, buf.String(), `"key":{"name":"a","id":1}`)
+
+		client = &fakeClient{readers: []*fakeReader{{batches: events[:1], err: io.EOF}}}
+		s, _ = newStreamer(t, client, false)
+		s.cfg.KeyColumns = map[string][]string{"t1": {"c1"}}
+		require.EqualError(t, s.Run(context.Background()), "key column c1 is not a column of table t1")
+		require.Empty(t, client.acks)
+	})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtstreamer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTopicTemplate is the topic of the tables which aren't mapped.
+const DefaultTopicTemplate = "{keyspace}.{table}"
+
+// TopicMapper maps the tables to the topics their change events are published
+// to. A table is either mapped explicitly, by its name or by its name
+// qualified with its keyspace, or its topic is the template with {keyspace}
+// and {table} replaced.
+type TopicMapper struct {
+	template string
+	topics   map[string]string
+}
+
+// NewTopicMapper creates a TopicMapper.
+func NewTopicMapper(template string, topics map[string]string) (*TopicMapper, error) {
+	if template == "" {
+		return nil, fmt.Errorf("the topic template must not be empty")
+	}
+	for table, topic := range topics {
+		if topic == "" {
+			return nil, fmt.Errorf("the topic of table %s must not be empty", table)
+		}
+	}
+	return &TopicMapper{template: template, topics: topics}, nil
+}
+
+// Topic returns the topic of the table.
+func (tm *TopicMapper) Topic(keyspace, table string) string {
+	if topic, ok := tm.topics[keyspace+"."+table]; ok {
+		return topic
+	}
+	if topic, ok := tm.topics[table]; ok {
+		return topic
+	}
+	return strings.NewReplacer("{keyspace}", keyspace, "{table}", table).Replace(tm.template)
+}
@@ -366,6 +366,23 @@ func (client *FakeTabletManagerClient) CheckThrottler(ctx context.Context, table
 	return &tabletmanagerdatapb.CheckThrottlerResponse{}, nil
 }
 
+// Distributed transaction related methods
+
+// GetUnresolvedTransactions is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, abandonAge int64) ([]*querypb.TransactionMetadata, error) {
+	return nil, nil
+}
+
+// ReadTransaction is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ReadTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string) (*querypb.TransactionMetadata, error) {
+	return &querypb.TransactionMetadata{}, nil
+}
+
+// ConcludeTransaction is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ConcludeTransactionRequest) error {
+	return nil
+}
+
 //
 // Management related methods
 //
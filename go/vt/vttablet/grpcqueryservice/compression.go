@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcqueryservice
+
+import (
+	"context"
+	"slices"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var (
+	// resultCompression is the gRPC compressor of the large query results
+	// sent to the clients supporting it. Empty disables the compression.
+	resultCompression string
+	// resultCompressionThreshold is the size, in bytes, from which a query
+	// result is compressed. For streams, the size of their first result
+	// decides whether the whole stream is compressed.
+	resultCompressionThreshold = 64 * 1024
+
+	compressedResults = stats.NewCountersWithSingleLabel("QueryResultsCompressed", "Number of query responses compressed by the gRPC query service", "Compressor")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&resultCompression, "grpc-result-compression", resultCompression, "gRPC compressor of the query results at least --grpc-result-compression-threshold large, for the clients supporting it. Default: nothing. Supported: snappy, zstd")
+	fs.IntVar(&resultCompressionThreshold, "grpc-result-compression-threshold", resultCompressionThreshold, "Size in bytes from which the query results are compressed with --grpc-result-compression. For streams, the size of their first result applies.")
+}
+
+func init() {
+	servenv.OnParseFor("vttablet", registerFlags)
+}
+
+// compressResult compresses the response of the call of ctx if the result
+// it sends is large enough and the client accepts the result compressor.
+// It must be called before the response headers are sent.
+func compressResult(ctx context.Context, result *querypb.QueryResult) {
+	if resultCompression == "" || result.SizeVT() < resultCompressionThreshold {
+		return
+	}
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(supported, resultCompression) {
+		return
+	}
+	if err := grpc.SetSendCompressor(ctx, resultCompression); err != nil {
+		log.Warningf("Failed to compress query result with %s: %v", resultCompression, err)
+		return
+	}
+	compressedResults.Add(resultCompression, 1)
+}
+
+// streamResultCompressor returns a function compressing the stream of ctx
+// according to the first result it is called with.
+func streamResultCompressor(ctx context.Context) func(*querypb.QueryResult) {
+	first := true
+	return func(result *querypb.QueryResult) {
+		if first {
+			first = false
+			compressResult(ctx, result)
+		}
+	}
+}
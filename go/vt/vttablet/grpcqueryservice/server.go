@@ -52,8 +52,10 @@ func (q *query) Execute(ctx context.Context, request *querypb.ExecuteRequest) (r
 	if err != nil {
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	compressResult(ctx, qr)
 	return &querypb.ExecuteResponse{
-		Result: sqltypes.ResultToProto3(result),
+		Result: qr,
 	}, nil
 }
 
@@ -64,9 +66,12 @@ func (q *query) StreamExecute(request *querypb.StreamExecuteRequest, stream quer
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	compress := streamResultCompressor(stream.Context())
 	err = q.server.StreamExecute(ctx, request.Target, request.Query.Sql, request.Query.BindVariables, request.TransactionId, request.ReservedId, request.Options, func(reply *sqltypes.Result) error {
+		qr := sqltypes.ResultToProto3(reply)
+		compress(qr)
 		return stream.Send(&querypb.StreamExecuteResponse{
-			Result: sqltypes.ResultToProto3(reply),
+			Result: qr,
 		})
 	})
 	return vterrors.ToGRPC(err)
@@ -252,8 +257,10 @@ func (q *query) BeginExecute(ctx context.Context, request *querypb.BeginExecuteR
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	compressResult(ctx, qr)
 	return &querypb.BeginExecuteResponse{
-		Result:              sqltypes.ResultToProto3(result),
+		Result:              qr,
 		TransactionId:       state.TransactionID,
 		TabletAlias:         state.TabletAlias,
 		SessionStateChanges: state.SessionStateChanges,
@@ -267,9 +274,12 @@ func (q *query) BeginStreamExecute(request *querypb.BeginStreamExecuteRequest, s
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	compress := streamResultCompressor(stream.Context())
 	state, err := q.server.BeginStreamExecute(ctx, request.Target, request.PreQueries, request.Query.Sql, request.Query.BindVariables, request.ReservedId, request.Options, func(reply *sqltypes.Result) error {
+		qr := sqltypes.ResultToProto3(reply)
+		compress(qr)
 		return stream.Send(&querypb.BeginStreamExecuteResponse{
-			Result: sqltypes.ResultToProto3(reply),
+			Result: qr,
 		})
 	})
 
@@ -392,8 +402,10 @@ func (q *query) ReserveExecute(ctx context.Context, request *querypb.ReserveExec
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	compressResult(ctx, qr)
 	return &querypb.ReserveExecuteResponse{
-		Result:      sqltypes.ResultToProto3(result),
+		Result:      qr,
 		ReservedId:  state.ReservedID,
 		TabletAlias: state.TabletAlias,
 	}, nil
@@ -406,9 +418,12 @@ func (q *query) ReserveStreamExecute(request *querypb.ReserveStreamExecuteReques
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	compress := streamResultCompressor(stream.Context())
 	state, err := q.server.ReserveStreamExecute(ctx, request.Target, request.PreQueries, request.Query.Sql, request.Query.BindVariables, request.TransactionId, request.Options, func(reply *sqltypes.Result) error {
+		qr := sqltypes.ResultToProto3(reply)
+		compress(qr)
 		return stream.Send(&querypb.ReserveStreamExecuteResponse{
-			Result: sqltypes.ResultToProto3(reply),
+			Result: qr,
 		})
 	})
 	if err != nil && state.ReservedID == 0 {
@@ -444,8 +459,10 @@ func (q *query) ReserveBeginExecute(ctx context.Context, request *querypb.Reserv
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	compressResult(ctx, qr)
 	return &querypb.ReserveBeginExecuteResponse{
-		Result:              sqltypes.ResultToProto3(result),
+		Result:              qr,
 		TransactionId:       state.TransactionID,
 		ReservedId:          state.ReservedID,
 		TabletAlias:         state.TabletAlias,
@@ -460,9 +477,12 @@ func (q *query) ReserveBeginStreamExecute(request *querypb.ReserveBeginStreamExe
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	compress := streamResultCompressor(stream.Context())
 	state, err := q.server.ReserveBeginStreamExecute(ctx, request.Target, request.PreQueries, request.PostBeginQueries, request.Query.Sql, request.Query.BindVariables, request.Options, func(reply *sqltypes.Result) error {
+		qr := sqltypes.ResultToProto3(reply)
+		compress(qr)
 		return stream.Send(&querypb.ReserveBeginStreamExecuteResponse{
-			Result: sqltypes.ResultToProto3(reply),
+			Result: qr,
 		})
 	})
 	if err != nil && state.ReservedID == 0 && state.TransactionID == 0 {
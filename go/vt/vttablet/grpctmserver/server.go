@@ -585,6 +585,30 @@ func (s *server) CheckThrottler(ctx context.Context, request *tabletmanagerdatap
 	return response, err
 }
 
+func (s *server) GetUnresolvedTransactions(ctx context.Context, request *tabletmanagerdatapb.GetUnresolvedTransactionsRequest) (response *tabletmanagerdatapb.GetUnresolvedTransactionsResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetUnresolvedTransactions", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	response = &tabletmanagerdatapb.GetUnresolvedTransactionsResponse{}
+	response.Transactions, err = s.tm.GetUnresolvedTransactions(ctx, request.AbandonAge)
+	return response, err
+}
+
+func (s *server) ReadTransaction(ctx context.Context, request *tabletmanagerdatapb.ReadTransactionRequest) (response *tabletmanagerdatapb.ReadTransactionResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "ReadTransaction", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	response = &tabletmanagerdatapb.ReadTransactionResponse{}
+	response.Transaction, err = s.tm.ReadTransaction(ctx, request)
+	return response, err
+}
+
+func (s *server) ConcludeTransaction(ctx context.Context, request *tabletmanagerdatapb.ConcludeTransactionRequest) (response *tabletmanagerdatapb.ConcludeTransactionResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "ConcludeTransaction", request, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	response = &tabletmanagerdatapb.ConcludeTransactionResponse{}
+	err = s.tm.ConcludeTransaction(ctx, request)
+	return response, err
+}
+
 // registration glue
 
 func init() {
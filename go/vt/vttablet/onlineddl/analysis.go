@@ -31,8 +31,9 @@ import (
 type specialAlterOperation string
 
 const (
-	instantDDLSpecialOperation     specialAlterOperation = "instant-ddl"
-	rangePartitionSpecialOperation specialAlterOperation = "range-partition"
+	instantDDLSpecialOperation          specialAlterOperation = "instant-ddl"
+	rangePartitionSpecialOperation      specialAlterOperation = "range-partition"
+	partitionManagementSpecialOperation specialAlterOperation = "partition-management"
 )
 
 type SpecialAlterPlan struct {
@@ -99,6 +100,31 @@ func analyzeInstantDDL(alterTable *sqlparser.AlterTable, createTable *sqlparser.
 	return op, nil
 }
 
+// isPartitionManagementDiff returns true when the given diff, along with its subsequent diffs, only consists
+// of ALTER TABLE statements which ADD, DROP or REORGANIZE partitions. MySQL applies these to the affected
+// partitions only, without rebuilding the table.
+func isPartitionManagementDiff(diff schemadiff.EntityDiff) bool {
+	diffs := schemadiff.AllSubsequent(diff)
+	if len(diffs) == 0 {
+		return false
+	}
+	for _, d := range diffs {
+		alterTable, ok := d.Statement().(*sqlparser.AlterTable)
+		if !ok {
+			return false
+		}
+		if alterTable.PartitionSpec == nil || alterTable.PartitionOption != nil || len(alterTable.AlterOptions) > 0 {
+			return false
+		}
+		switch alterTable.PartitionSpec.Action {
+		case sqlparser.AddAction, sqlparser.DropAction, sqlparser.ReorganizeAction:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // analyzeSpecialAlterPlan checks if the given ALTER onlineDDL, and for the current state of affected table,
 // can be executed in a special way. If so, it returns with a "special plan"
 func (e *Executor) analyzeSpecialAlterPlan(ctx context.Context, onlineDDL *schema.OnlineDDL, capableOf capabilities.CapableOf) (*SpecialAlterPlan, error) {
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/sqlparser"
 )
 
@@ -207,6 +208,51 @@ func TestAnalyzeInstantDDL(t *testing.T) {
 			alter:   "alter table t modify column c1 set('a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i')",
 			instant: false,
 		},
+		// MariaDB
+		{
+			version: "10.2.44-MariaDB",
+			create:  "create table t(id int, i1 int not null, primary key(id))",
+			alter:   "alter table t add column i2 int not null",
+			instant: false,
+		},
+		{
+			version: "10.3.7-MariaDB",
+			create:  "create table t(id int, i1 int not null, primary key(id))",
+			alter:   "alter table t add column i2 int not null",
+			instant: true,
+		},
+		{
+			// fail add mid column in 10.3
+			version: "10.3.39-MariaDB",
+			create:  "create table t(id int, i1 int not null, primary key(id))",
+			alter:   "alter table t add column i2 int not null after id",
+			instant: false,
+		},
+		{
+			version: "10.3.39-MariaDB",
+			create:  "create table t(id int, i1 int not null, primary key(id))",
+			alter:   "alter table t drop column i1",
+			instant: false,
+		},
+		{
+			version: "5.5.5-10.4.31-MariaDB",
+			create:  "create table t(id int, i1 int not null, primary key(id))",
+			alter:   "alter table t add column i2 int not null after id, drop column i1",
+			instant: true,
+		},
+		{
+			// fail due to FULLTEXT index
+			version: "10.11.6-MariaDB",
+			create:  "create table t(id int, i1 int not null, t1 text, primary key(id), fulltext key (t1))",
+			alter:   "alter table t add column i2 int not null",
+			instant: false,
+		},
+		{
+			version: "10.3.39-MariaDB",
+			create:  "create table t(id int, c1 enum('a', 'b', 'c') default 'a', primary key(id))",
+			alter:   "alter table t modify column c1 enum('a', 'b', 'c', 'd') default 'd'",
+			instant: true,
+		},
 	}
 	parser := sqlparser.NewTestParser()
 	for _, tc := range tt {
@@ -238,3 +284,49 @@ func TestAnalyzeInstantDDL(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPartitionManagementDiff(t *testing.T) {
+	tt := []struct {
+		name   string
+		from   string
+		to     string
+		expect bool
+	}{
+		{
+			name: "no diff",
+			from: "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10))",
+			to:   "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10))",
+		},
+		{
+			name:   "rotate",
+			from:   "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20))",
+			to:     "create table t(id int, primary key(id)) partition by range (id) (partition p2 values less than (20), partition p3 values less than (30))",
+			expect: true,
+		},
+		{
+			name:   "reorganize",
+			from:   "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10), partition pmax values less than maxvalue)",
+			to:     "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20), partition pmax values less than maxvalue)",
+			expect: true,
+		},
+		{
+			name: "mixed with other changes",
+			from: "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10), partition p2 values less than (20))",
+			to:   "create table t(id int, i int, primary key(id)) partition by range (id) (partition p2 values less than (20), partition p3 values less than (30))",
+		},
+		{
+			name: "repartition",
+			from: "create table t(id int, primary key(id)) partition by range (id) (partition p1 values less than (10))",
+			to:   "create table t(id int, primary key(id)) partition by hash (id) partitions 4",
+		},
+	}
+	env := schemadiff.NewTestEnv()
+	hints := &schemadiff.DiffHints{PartitionChangeStrategy: schemadiff.PartitionChangeDistinctStatements}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := schemadiff.DiffCreateTablesQueries(env, tc.from, tc.to, hints)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, isPartitionManagementDiff(diff))
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -49,6 +50,7 @@ import (
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/dbconnpool"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/maintenancewindow"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -96,6 +98,8 @@ var (
 	defaultCutOverThreshold = 10 * time.Second
 	maxConcurrentOnlineDDLs = 256
 
+	migrationSchedulerParallelism = 1
+
 	migrationNextCheckIntervals = []time.Duration{1 * time.Second, 5 * time.Second, 10 * time.Second, 20 * time.Second}
 	maxConstraintNameLength     = 64
 	cutoverIntervals            = []time.Duration{0, 1 * time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
@@ -112,6 +116,7 @@ func registerOnlineDDLFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&migrationCheckInterval, "migration_check_interval", migrationCheckInterval, "Interval between migration checks")
 	fs.DurationVar(&retainOnlineDDLTables, "retain_online_ddl_tables", retainOnlineDDLTables, "How long should vttablet keep an old migrated table before purging it")
 	fs.IntVar(&maxConcurrentOnlineDDLs, "max_concurrent_online_ddl", maxConcurrentOnlineDDLs, "Maximum number of online DDL changes that may run concurrently")
+	fs.IntVar(&migrationSchedulerParallelism, "migration-scheduler-parallelism", migrationSchedulerParallelism, "Number of Online DDL migrations which may run concurrently without --allow-concurrent, as long as they do not touch the same tables, including tables related via foreign keys. Conflicting migrations run in submission order. 1 runs one such migration at a time")
 }
 
 const (
@@ -179,6 +184,7 @@ type Executor struct {
 	lagThrottler          *throttle.Throttler
 	toggleBufferTableFunc func(cancelCtx context.Context, tableName string, timeout time.Duration, bufferQueries bool)
 	requestGCChecksFunc   func()
+	gcTableRetentionFunc  func() time.Duration
 	tabletAlias           *topodatapb.TabletAlias
 
 	keyspace string
@@ -218,9 +224,20 @@ func newCancellableMigration(uuid string, message string) *cancellableMigration
 	return &cancellableMigration{uuid: uuid, message: message}
 }
 
+// gcTableRetention returns how long GC tables and migration artifacts are to be retained: the keyspace's
+// table GC retention, if configured, or else --retain_online_ddl_tables
+func (e *Executor) gcTableRetention() time.Duration {
+	if e.gcTableRetentionFunc != nil {
+		if retention := e.gcTableRetentionFunc(); retention > 0 {
+			return retention
+		}
+	}
+	return retainOnlineDDLTables
+}
+
 // newGCTableRetainTime returns the time until which a new GC table is to be retained
-func newGCTableRetainTime() time.Time {
-	return time.Now().UTC().Add(retainOnlineDDLTables)
+func (e *Executor) newGCTableRetainTime() time.Time {
+	return time.Now().UTC().Add(e.gcTableRetention())
 }
 
 // getMigrationCutOverThreshold returns the cut-over threshold for the given migration. The migration's
@@ -238,6 +255,7 @@ func NewExecutor(env tabletenv.Env, tabletAlias *topodatapb.TabletAlias, ts *top
 	tabletTypeFunc func() topodatapb.TabletType,
 	toggleBufferTableFunc func(cancelCtx context.Context, tableName string, timeout time.Duration, bufferQueries bool),
 	requestGCChecksFunc func(),
+	gcTableRetentionFunc func() time.Duration,
 ) *Executor {
 	// sanitize flags
 	if maxConcurrentOnlineDDLs < 1 {
@@ -256,6 +274,7 @@ func NewExecutor(env tabletenv.Env, tabletAlias *topodatapb.TabletAlias, ts *top
 		lagThrottler:          lagThrottler,
 		toggleBufferTableFunc: toggleBufferTableFunc,
 		requestGCChecksFunc:   requestGCChecksFunc,
+		gcTableRetentionFunc:  gcTableRetentionFunc,
 		ticks:                 timer.NewTimer(migrationCheckInterval),
 		// Gracefully return an error if any caller tries to execute
 		// a query before the executor has been fully opened.
@@ -710,13 +729,36 @@ func (e *Executor) tableParticipatesInForeignKeyRelationship(ctx context.Context
 	return false, nil
 }
 
-func (e *Executor) validateTableForAlterAction(ctx context.Context, onlineDDL *schema.OnlineDDL) (err error) {
+// readFKChildTables returns the tables which have a foreign key constraint referencing the given table
+func (e *Executor) readFKChildTables(ctx context.Context, schema string, table string) (childTables []string, err error) {
+	query, err := sqlparser.ParseAndBind(sqlSelectFKChildTables,
+		sqltypes.StringBindVariable(schema),
+		sqltypes.StringBindVariable(table),
+		sqltypes.StringBindVariable(schema),
+		sqltypes.StringBindVariable(table),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.execQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range r.Named().Rows {
+		childTables = append(childTables, row.AsString("table_name", ""))
+	}
+	return childTables, nil
+}
+
+// validateTableForAlterAction validates that the table may be migrated. allowForeignKeys indicates whether
+// the migration may run on a table participating in a foreign key constraint.
+func (e *Executor) validateTableForAlterAction(ctx context.Context, onlineDDL *schema.OnlineDDL, allowForeignKeys bool) (err error) {
 	participatesInFK, err := e.tableParticipatesInForeignKeyRelationship(ctx, onlineDDL.Schema, onlineDDL.Table)
 	if err != nil {
 		return vterrors.Wrapf(err, "error while attempting to validate whether table %s participates in FOREIGN KEY constraint", onlineDDL.Table)
 	}
 	if participatesInFK {
-		if !onlineDDL.StrategySetting().IsAllowForeignKeysFlag() {
+		if !allowForeignKeys {
 			// FK migrations not allowed
 			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "table %s participates in a FOREIGN KEY constraint and FOREIGN KEY constraints are not supported in Online DDL unless the *experimental and unsafe* --unsafe-allow-foreign-keys strategy flag is specified", onlineDDL.Table)
 		}
@@ -860,6 +902,15 @@ func (e *Executor) killTableLockHoldersAndAccessors(ctx context.Context, tableNa
 	return nil
 }
 
+// lockTablesWriteQuery returns a LOCK TABLES query which write-locks the given tables
+func lockTablesWriteQuery(tableNames ...string) string {
+	locks := make([]string, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		locks = append(locks, sqlescape.EscapeID(tableName)+" WRITE")
+	}
+	return "LOCK TABLES " + strings.Join(locks, ", ")
+}
+
 // cutOverVReplMigration stops vreplication, then removes the _vt.vreplication entry for the given migration
 func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, shouldForceCutOver bool) error {
 	if err := e.incrementCutoverAttempts(ctx, s.workflow); err != nil {
@@ -893,6 +944,14 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 
 	migrationCutOverThreshold := getMigrationCutOverThreshold(onlineDDL)
 
+	// The tables referencing the migrated table via foreign keys (only possible with --unsafe-allow-foreign-keys)
+	// take part in the cut-over: their queries are buffered and they are locked along with the migrated table,
+	// so that no child row is written while the parent table is swapped.
+	childTables, err := e.readFKChildTables(ctx, onlineDDL.Schema, onlineDDL.Table)
+	if err != nil {
+		return err
+	}
+
 	waitForPos := func(s *VReplStream, pos replication.Position) error {
 		ctx, cancel := context.WithTimeout(ctx, migrationCutOverThreshold)
 		defer cancel()
@@ -910,7 +969,7 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 		// and no harm done.
 		// Later on, when traffic is blocked and tables renamed, that's a more dangerous place to be in; we want as little logic
 		// in that place as possible.
-		sentryTableName, err = schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+		sentryTableName, err = schema.GenerateGCTableName(schema.HoldTableGCState, e.newGCTableRetainTime())
 		if err != nil {
 			return nil
 		}
@@ -1032,7 +1091,9 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 		log.Infof("toggling buffering: %t in migration %v", bufferQueries, onlineDDL.UUID)
 		timeout := migrationCutOverThreshold + qrBufferExtraTimeout
 
-		e.toggleBufferTableFunc(bufferingCtx, onlineDDL.Table, timeout, bufferQueries)
+		for _, tableName := range append([]string{onlineDDL.Table}, childTables...) {
+			e.toggleBufferTableFunc(bufferingCtx, tableName, timeout, bufferQueries)
+		}
 		if !bufferQueries {
 			grpcCtx, cancel := context.WithTimeout(ctx, grpcTimeout)
 			defer cancel()
@@ -1071,8 +1132,10 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 	time.Sleep(100 * time.Millisecond)
 
 	if shouldForceCutOver {
-		if err := e.killTableLockHoldersAndAccessors(ctx, onlineDDL.Table); err != nil {
-			return err
+		for _, tableName := range append([]string{onlineDDL.Table}, childTables...) {
+			if err := e.killTableLockHoldersAndAccessors(ctx, tableName); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -1093,8 +1156,8 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 		e.updateMigrationStage(ctx, onlineDDL.UUID, "locking tables")
 		lockCtx, cancel := context.WithTimeout(ctx, migrationCutOverThreshold)
 		defer cancel()
-		lockTableQuery := sqlparser.BuildParsedQuery(sqlLockTwoTablesWrite, sentryTableName, onlineDDL.Table)
-		if _, err := lockConn.Conn.Exec(lockCtx, lockTableQuery.Query, 1, false); err != nil {
+		lockTableQuery := lockTablesWriteQuery(append([]string{sentryTableName, onlineDDL.Table}, childTables...)...)
+		if _, err := lockConn.Conn.Exec(lockCtx, lockTableQuery, 1, false); err != nil {
 			return err
 		}
 
@@ -1606,12 +1669,20 @@ func (e *Executor) ExecuteWithVReplication(ctx context.Context, onlineDDL *schem
 	}
 	if revertMigration == nil {
 		// Original ALTER TABLE request for vreplication
-		if err := e.validateTableForAlterAction(ctx, onlineDDL); err != nil {
+		if err := e.validateTableForAlterAction(ctx, onlineDDL, onlineDDL.StrategySetting().IsAllowForeignKeysFlag()); err != nil {
 			return err
 		}
 		if err := e.postInitVreplicationOriginalMigration(ctx, onlineDDL, v, conn); err != nil {
 			return err
 		}
+	} else {
+		// A revert is allowed on a foreign key table if the reverted migration was, so that it serves
+		// as a safety net for the migration without repeating its strategy flags. The children of the
+		// table are then locked along with it at cut-over time.
+		allowForeignKeys := onlineDDL.StrategySetting().IsAllowForeignKeysFlag() || revertMigration.StrategySetting().IsAllowForeignKeysFlag()
+		if err := e.validateTableForAlterAction(ctx, onlineDDL, allowForeignKeys); err != nil {
+			return err
+		}
 	}
 
 	{
@@ -2594,7 +2665,12 @@ func (e *Executor) validateMigrationRevertible(ctx context.Context, revertMigrat
 		return fmt.Errorf("can only revert a migration in a '%s' state. Migration %s is in '%s' state", schema.OnlineDDLStatusComplete, revertMigration.UUID, revertMigration.Status)
 	}
 	{
-		// Validation: see if there's a pending migration on this table:
+		// Validation: see if there's a pending migration on this table, or on any of its foreign key children,
+		// which are locked along with it during the revert's cut-over:
+		childTables, err := e.readFKChildTables(ctx, revertMigration.Schema, revertMigration.Table)
+		if err != nil {
+			return err
+		}
 		r, err := e.execQuery(ctx, sqlSelectPendingMigrations)
 		if err != nil {
 			return err
@@ -2613,6 +2689,9 @@ func (e *Executor) validateMigrationRevertible(ctx context.Context, revertMigrat
 			if keyspace == e.keyspace && table == revertMigration.Table {
 				return fmt.Errorf("can not revert migration %s on table %s because migration %s is in %s status. May only revert if all migrations on this table are completed or failed", revertMigration.UUID, revertMigration.Table, pendingUUID, status)
 			}
+			if keyspace == e.keyspace && slices.Contains(childTables, table) {
+				return fmt.Errorf("can not revert migration %s on table %s because migration %s on its foreign key child table %s is in %s status. May only revert if all migrations on its child tables are completed or failed", revertMigration.UUID, revertMigration.Table, pendingUUID, table, status)
+			}
 		}
 		{
 			// Validation: see that we're reverting the last successful migration on this table:
@@ -2762,7 +2841,7 @@ func (e *Executor) evaluateDeclarativeDiff(ctx context.Context, onlineDDL *schem
 		return nil, err
 	}
 	// Is this CREATE TABLE or CREATE VIEW?
-	comparisonTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+	comparisonTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, e.newGCTableRetainTime())
 	if err != nil {
 		return nil, err
 	}
@@ -2813,11 +2892,18 @@ func (e *Executor) evaluateDeclarativeDiff(ctx context.Context, onlineDDL *schem
 	}
 	senv := schemadiff.NewEnv(e.env.Environment(), e.env.Environment().CollationEnv().DefaultConnectionCharset())
 	hints := &schemadiff.DiffHints{
-		AutoIncrementStrategy: schemadiff.AutoIncrementApplyHigher,
+		AutoIncrementStrategy:   schemadiff.AutoIncrementApplyHigher,
+		PartitionChangeStrategy: schemadiff.PartitionChangeDistinctStatements,
 	}
 	switch ddlStmt.(type) {
 	case *sqlparser.CreateTable:
 		diff, err = schemadiff.DiffCreateTablesQueries(senv, existingShowCreateTable, newShowCreateTable, hints)
+		if err == nil && len(schemadiff.AllSubsequent(diff)) > 1 && !isPartitionManagementDiff(diff) {
+			// Partition management statements are mixed with other changes, which we run as a single
+			// Online DDL ALTER TABLE, along with a complete re-partitioning.
+			hints.PartitionChangeStrategy = schemadiff.PartitionChangeFullSpec
+			diff, err = schemadiff.DiffCreateTablesQueries(senv, existingShowCreateTable, newShowCreateTable, hints)
+		}
 	case *sqlparser.CreateView:
 		diff, err = schemadiff.DiffCreateViewsQueries(senv, existingShowCreateTable, newShowCreateTable, hints)
 	default:
@@ -2936,7 +3022,7 @@ func (e *Executor) executeDropDDLActionMigration(ctx context.Context, onlineDDL
 	}
 
 	var toTableName string
-	onlineDDL.SQL, toTableName, err = schema.GenerateRenameStatementWithUUID(onlineDDL.Table, schema.HoldTableGCState, onlineDDL.GetGCUUID(), newGCTableRetainTime())
+	onlineDDL.SQL, toTableName, err = schema.GenerateRenameStatementWithUUID(onlineDDL.Table, schema.HoldTableGCState, onlineDDL.GetGCUUID(), e.newGCTableRetainTime())
 	if err != nil {
 		return failMigration(err)
 	}
@@ -3003,7 +3089,7 @@ func (e *Executor) executeCreateDDLActionMigration(ctx context.Context, onlineDD
 
 	// from now on, whether a VIEW or a TABLE, they get the same treatment
 
-	sentryArtifactTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+	sentryArtifactTableName, err := schema.GenerateGCTableName(schema.HoldTableGCState, e.newGCTableRetainTime())
 	if err != nil {
 		return failMigration(err)
 	}
@@ -3039,7 +3125,7 @@ func (e *Executor) executeCreateDDLActionMigration(ctx context.Context, onlineDD
 // of temporary third table. It returns the name of generated third table, though normally
 // that table should not exist before & after operation, only _during_ operation time.
 func (e *Executor) generateSwapTablesStatement(ctx context.Context, tableName1, tableName2 string) (query string, swapTableName string, err error) {
-	swapTableName, err = schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+	swapTableName, err = schema.GenerateGCTableName(schema.HoldTableGCState, e.newGCTableRetainTime())
 	if err != nil {
 		return "", swapTableName, err
 	}
@@ -3052,7 +3138,7 @@ func (e *Executor) generateSwapTablesStatement(ctx context.Context, tableName1,
 }
 
 func (e *Executor) executeAlterViewOnline(ctx context.Context, onlineDDL *schema.OnlineDDL) (err error) {
-	artifactViewName, err := schema.GenerateGCTableName(schema.HoldTableGCState, newGCTableRetainTime())
+	artifactViewName, err := schema.GenerateGCTableName(schema.HoldTableGCState, e.newGCTableRetainTime())
 	if err != nil {
 		return err
 	}
@@ -3344,6 +3430,22 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 				if err := e.updateDDLAction(ctx, onlineDDL.UUID, sqlparser.AlterStr); err != nil {
 					return failMigration(err)
 				}
+				if isPartitionManagementDiff(diff) {
+					// Partitions were added, dropped or reorganized. Rather than rebuild the table, we run the
+					// partition management statements directly, one at a time.
+					var statements []string
+					for _, d := range schemadiff.AllSubsequent(diff) {
+						onlineDDL.SQL = d.CanonicalStatementString()
+						if _, err := e.executeDirectly(ctx, onlineDDL); err != nil {
+							return failMigration(err)
+						}
+						statements = append(statements, onlineDDL.SQL)
+					}
+					specialPlan := NewSpecialAlterOperation(partitionManagementSpecialOperation, nil, nil)
+					_ = e.updateMigrationSpecialPlan(ctx, onlineDDL.UUID, specialPlan.String())
+					_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, strings.Join(statements, "; "))
+					return nil
+				}
 				if createViewStmt, isCreateView := ddlStmt.(*sqlparser.CreateView); isCreateView {
 					// Rewrite as CREATE OR REPLACE
 					// this will be handled later on.
@@ -3409,11 +3511,19 @@ func (e *Executor) runNextMigration(ctx context.Context) error {
 	// Conflicts are:
 	// - a migration is 'ready' but is not set to run _concurrently_, and there's a running migration that is also non-concurrent
 	// - a migration is 'ready' but there's another migration 'running' on the exact same table
+	// With --migration-scheduler-parallelism above 1, a migration which does not depend on any earlier pending
+	// migration (see scheduler.go) is not considered conflicting, whether it is set to run concurrently or not.
 	getNonConflictingMigration := func() (*schema.OnlineDDL, error) {
 		pendingMigrationsUUIDs, err := e.readPendingMigrationsUUIDs(ctx)
 		if err != nil {
 			return nil, err
 		}
+		runnableMigrations := map[string]bool{}
+		if migrationSchedulerParallelism > 1 {
+			if runnableMigrations, err = e.readRunnableMigrations(ctx); err != nil {
+				return nil, err
+			}
+		}
 		r, err := e.execQuery(ctx, sqlSelectReadyMigrations)
 		if err != nil {
 			return nil, err
@@ -3426,8 +3536,11 @@ func (e *Executor) runNextMigration(ctx context.Context) error {
 			}
 			isImmediateOperation := migrationRow.AsBool("is_immediate_operation", false)
 
-			if conflictFound, _ := e.isAnyConflictingMigrationRunning(onlineDDL); conflictFound {
-				continue // this migration conflicts with a running one
+			if !runnableMigrations[onlineDDL.UUID] {
+				// The dependency-aware scheduler did not find this migration to be independent of all pending migrations
+				if conflictFound, _ := e.isAnyConflictingMigrationRunning(onlineDDL); conflictFound {
+					continue // this migration conflicts with a running one
+				}
 			}
 			if e.countOwnedRunningMigrations() >= maxConcurrentOnlineDDLs {
 				continue // too many running migrations
@@ -3821,6 +3934,18 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 						return nil
 					}
 				}
+				if !shouldForceCutOver {
+					// Cut-overs only run during the maintenance windows of the cluster, unless forced.
+					isOpen, err := maintenancewindow.Check(ctx, e.ts)
+					if err != nil {
+						_ = e.updateMigrationMessage(ctx, uuid, err.Error())
+						return err
+					}
+					if !isOpen {
+						_ = e.updateMigrationStage(ctx, uuid, "waiting for a maintenance window to cut-over")
+						return nil
+					}
+				}
 				shouldCutOver, shouldForceCutOver := shouldCutOverAccordingToBackoff(
 					shouldForceCutOver, forceCutOverAfter, sinceReadyToComplete, sinceLastCutoverAttempt, cutoverAttempts,
 				)
@@ -4059,7 +4184,7 @@ func (e *Executor) gcArtifacts(ctx context.Context) error {
 	}
 
 	query, err := sqlparser.ParseAndBind(sqlSelectUncollectedArtifacts,
-		sqltypes.Int64BindVariable(int64((retainOnlineDDLTables).Seconds())),
+		sqltypes.Int64BindVariable(int64(e.gcTableRetention().Seconds())),
 	)
 	if err != nil {
 		return err
@@ -4995,7 +5120,7 @@ func (e *Executor) SubmitMigration(
 	log.Infof("SubmitMigration: request to submit migration %s; action=%s, table=%s", onlineDDL.UUID, actionStr, onlineDDL.Table)
 
 	revertedUUID, _ := onlineDDL.GetRevertUUID(e.env.Environment().Parser()) // Empty value if the migration is not actually a REVERT. Safe to ignore error.
-	retainArtifactsSeconds := int64((retainOnlineDDLTables).Seconds())
+	retainArtifactsSeconds := int64(e.gcTableRetention().Seconds())
 	if retainArtifacts, _ := onlineDDL.StrategySetting().RetainArtifactsDuration(); retainArtifacts != 0 {
 		// Explicit retention indicated by `--retain-artifact` DDL strategy flag for this migration. Override!
 		retainArtifactsSeconds = int64((retainArtifacts).Seconds())
@@ -491,3 +491,8 @@ func TestShouldCutOverAccordingToBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestLockTablesWriteQuery(t *testing.T) {
+	assert.Equal(t, "LOCK TABLES `sentry` WRITE, `t` WRITE", lockTablesWriteQuery("sentry", "t"))
+	assert.Equal(t, "LOCK TABLES `sentry` WRITE, `parent` WRITE, `child1` WRITE, `child2` WRITE", lockTablesWriteQuery("sentry", "parent", "child1", "child2"))
+}
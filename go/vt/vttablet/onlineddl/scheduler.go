@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// scheduledMigration is a pending migration, as seen by the dependency-aware scheduler. The
+// tables of a migration are its own table and the tables related to it via foreign keys.
+// A migration with no tables, such as a REVERT which is not reviewed yet, is unknown territory,
+// and conflicts with any other migration.
+type scheduledMigration struct {
+	uuid    string
+	tables  []string
+	running bool
+}
+
+// conflicts returns true when the two migrations may not run concurrently, because they touch
+// a common table.
+func (m *scheduledMigration) conflicts(other *scheduledMigration) bool {
+	if len(m.tables) == 0 || len(other.tables) == 0 {
+		return true
+	}
+	for _, table := range m.tables {
+		for _, otherTable := range other.tables {
+			if table == otherTable {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// migrationDependencies returns the dependency graph of the given pending migrations, which are
+// expected in submission order: a migration depends on all earlier migrations it conflicts with.
+// The graph maps a migration's UUID to the UUIDs of the migrations it depends on.
+func migrationDependencies(migrations []*scheduledMigration) map[string][]string {
+	dependencies := make(map[string][]string, len(migrations))
+	for i, migration := range migrations {
+		dependencies[migration.uuid] = nil
+		for _, earlier := range migrations[:i] {
+			if migration.conflicts(earlier) {
+				dependencies[migration.uuid] = append(dependencies[migration.uuid], earlier.uuid)
+			}
+		}
+	}
+	return dependencies
+}
+
+// runnableMigrations returns the UUIDs of the given pending migrations which may start running now:
+// those which are not running, and which do not depend on any other pending migration. At most
+// parallelism migrations run at once, counting the already running ones.
+func runnableMigrations(migrations []*scheduledMigration, parallelism int) map[string]bool {
+	runnable := map[string]bool{}
+	slots := parallelism
+	for _, migration := range migrations {
+		if migration.running {
+			slots--
+		}
+	}
+	dependencies := migrationDependencies(migrations)
+	for _, migration := range migrations {
+		if slots <= 0 {
+			break
+		}
+		if migration.running || len(dependencies[migration.uuid]) > 0 {
+			continue
+		}
+		runnable[migration.uuid] = true
+		slots--
+	}
+	return runnable
+}
+
+// readFKRelatedTables returns the tables related to the given table via foreign keys: its parents and its children.
+func (e *Executor) readFKRelatedTables(ctx context.Context, schema string, table string) (relatedTables []string, err error) {
+	query, err := sqlparser.ParseAndBind(sqlSelectFKParentTables,
+		sqltypes.StringBindVariable(schema),
+		sqltypes.StringBindVariable(table),
+		sqltypes.StringBindVariable(schema),
+		sqltypes.StringBindVariable(table),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.execQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range r.Named().Rows {
+		relatedTables = append(relatedTables, row.AsString("table_name", ""))
+	}
+	childTables, err := e.readFKChildTables(ctx, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	return append(relatedTables, childTables...), nil
+}
+
+// readRunnableMigrations reads the pending migrations and returns those which the dependency-aware
+// scheduler allows to start running now, given --migration-scheduler-parallelism.
+func (e *Executor) readRunnableMigrations(ctx context.Context) (map[string]bool, error) {
+	r, err := e.execQuery(ctx, sqlSelectPendingMigrations)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []*scheduledMigration
+	for _, row := range r.Named().Rows {
+		migration := &scheduledMigration{
+			uuid:    row["migration_uuid"].ToString(),
+			running: schema.OnlineDDLStatus(row["migration_status"].ToString()) == schema.OnlineDDLStatusRunning,
+		}
+		if table := row["mysql_table"].ToString(); table != "" {
+			relatedTables, err := e.readFKRelatedTables(ctx, row["mysql_schema"].ToString(), table)
+			if err != nil {
+				return nil, err
+			}
+			migration.tables = append([]string{table}, relatedTables...)
+		}
+		migrations = append(migrations, migration)
+	}
+	return runnableMigrations(migrations, migrationSchedulerParallelism), nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationDependencies(t *testing.T) {
+	migrations := []*scheduledMigration{
+		{uuid: "m1", tables: []string{"t1"}},
+		{uuid: "m2", tables: []string{"t2"}},
+		{uuid: "m3", tables: []string{"t1"}},
+		// t3 is a child of t2
+		{uuid: "m4", tables: []string{"t3", "t2"}},
+		{uuid: "m5", tables: []string{"t4"}},
+		// a revert, not yet reviewed
+		{uuid: "m6"},
+	}
+	expected := map[string][]string{
+		"m1": nil,
+		"m2": nil,
+		"m3": {"m1"},
+		"m4": {"m2"},
+		"m5": nil,
+		"m6": {"m1", "m2", "m3", "m4", "m5"},
+	}
+	assert.Equal(t, expected, migrationDependencies(migrations))
+}
+
+func TestRunnableMigrations(t *testing.T) {
+	tcases := []struct {
+		name        string
+		migrations  []*scheduledMigration
+		parallelism int
+		expected    map[string]bool
+	}{
+		{
+			name:        "none",
+			parallelism: 4,
+			expected:    map[string]bool{},
+		},
+		{
+			name: "independent",
+			migrations: []*scheduledMigration{
+				{uuid: "m1", tables: []string{"t1"}},
+				{uuid: "m2", tables: []string{"t2"}},
+				{uuid: "m3", tables: []string{"t3"}},
+			},
+			parallelism: 4,
+			expected:    map[string]bool{"m1": true, "m2": true, "m3": true},
+		},
+		{
+			name: "parallelism",
+			migrations: []*scheduledMigration{
+				{uuid: "m1", tables: []string{"t1"}, running: true},
+				{uuid: "m2", tables: []string{"t2"}},
+				{uuid: "m3", tables: []string{"t3"}},
+			},
+			parallelism: 2,
+			expected:    map[string]bool{"m2": true},
+		},
+		{
+			name: "same table",
+			migrations: []*scheduledMigration{
+				{uuid: "m1", tables: []string{"t1"}, running: true},
+				{uuid: "m2", tables: []string{"t1"}},
+				{uuid: "m3", tables: []string{"t2"}},
+			},
+			parallelism: 4,
+			expected:    map[string]bool{"m3": true},
+		},
+		{
+			name: "foreign key",
+			migrations: []*scheduledMigration{
+				{uuid: "m1", tables: []string{"parent", "child"}},
+				{uuid: "m2", tables: []string{"child", "parent"}},
+				{uuid: "m3", tables: []string{"t3"}},
+			},
+			parallelism: 4,
+			expected:    map[string]bool{"m1": true, "m3": true},
+		},
+		{
+			name: "unknown tables",
+			migrations: []*scheduledMigration{
+				{uuid: "m1"},
+				{uuid: "m2", tables: []string{"t2"}},
+			},
+			parallelism: 4,
+			expected:    map[string]bool{"m1": true},
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			assert.Equal(t, tcase.expected, runnableMigrations(tcase.migrations, tcase.parallelism))
+		})
+	}
+}
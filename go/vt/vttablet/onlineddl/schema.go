@@ -338,6 +338,7 @@ const (
 			migration_uuid,
 			migration_context,
 			keyspace,
+			mysql_schema,
 			mysql_table,
 			migration_status
 		FROM _vt.schema_migrations
@@ -465,6 +466,22 @@ const (
 			TABLE_SCHEMA=%a AND TABLE_NAME=%a
 			AND REFERENCED_TABLE_NAME IS NOT NULL
 		`
+	sqlSelectFKParentTables = `
+		SELECT
+			DISTINCT REFERENCED_TABLE_NAME as table_name
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+		WHERE
+			TABLE_SCHEMA=%a AND TABLE_NAME=%a
+			AND REFERENCED_TABLE_SCHEMA=%a AND REFERENCED_TABLE_NAME!=%a
+		`
+	sqlSelectFKChildTables = `
+		SELECT
+			DISTINCT TABLE_NAME as table_name
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE
+		WHERE
+			REFERENCED_TABLE_SCHEMA=%a AND REFERENCED_TABLE_NAME=%a
+			AND TABLE_SCHEMA=%a AND TABLE_NAME!=%a
+		`
 	sqlSelectUniqueKeys = `
 	SELECT
 		COLUMNS.TABLE_SCHEMA as table_schema,
@@ -582,7 +599,6 @@ const (
 		`
 	sqlSwapTables              = "RENAME TABLE `%a` TO `%a`, `%a` TO `%a`, `%a` TO `%a`"
 	sqlRenameTable             = "RENAME TABLE `%a` TO `%a`"
-	sqlLockTwoTablesWrite      = "LOCK TABLES `%a` WRITE, `%a` WRITE"
 	sqlUnlockTables            = "UNLOCK TABLES"
 	sqlCreateSentryTable       = "CREATE TABLE IF NOT EXISTS `%a` (id INT PRIMARY KEY)"
 	sqlFindProcess             = "SELECT id, Info as info FROM information_schema.processlist WHERE id=%a AND Info LIKE %a"
@@ -110,6 +110,10 @@ type SandboxConn struct {
 	VStreamErrors []error
 	VStreamCh     chan *binlogdatapb.VEvent
 
+	// BeginSessionStateChanges is returned by the begins, like the GTID set
+	// of a consistent snapshot.
+	BeginSessionStateChanges string
+
 	// transaction id generator
 	TransactionID atomic.Int64
 
@@ -311,7 +315,7 @@ func (sbc *SandboxConn) begin(ctx context.Context, target *querypb.Target, preQu
 			return queryservice.TransactionState{}, err
 		}
 	}
-	return queryservice.TransactionState{TransactionID: transactionID, TabletAlias: sbc.tablet.Alias}, nil
+	return queryservice.TransactionState{TransactionID: transactionID, TabletAlias: sbc.tablet.Alias, SessionStateChanges: sbc.BeginSessionStateChanges}, nil
 }
 
 // Commit is part of the QueryService interface.
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// GetUnresolvedTransactions returns the unresolved distributed transactions
+// for which this tablet is the metadata manager.
+func (tm *TabletManager) GetUnresolvedTransactions(ctx context.Context, abandonAgeSeconds int64) ([]*querypb.TransactionMetadata, error) {
+	return tm.QueryServiceControl.UnresolvedTransactions(ctx, tm.target(), abandonAgeSeconds)
+}
+
+// ReadTransaction returns the metadata of a distributed transaction,
+// as recorded by its metadata manager.
+func (tm *TabletManager) ReadTransaction(ctx context.Context, req *tabletmanagerdatapb.ReadTransactionRequest) (*querypb.TransactionMetadata, error) {
+	return tm.QueryServiceControl.QueryService().ReadTransaction(ctx, tm.target(), req.Dtid)
+}
+
+// ConcludeTransaction resolves a distributed transaction on this tablet. On a
+// participant, it commits or rolls back the prepared transaction. On the metadata
+// manager, it either records the decision to roll back the transaction, or
+// deletes its metadata.
+func (tm *TabletManager) ConcludeTransaction(ctx context.Context, req *tabletmanagerdatapb.ConcludeTransactionRequest) error {
+	qs := tm.QueryServiceControl.QueryService()
+	target := tm.target()
+	switch {
+	case req.SetRollback:
+		return qs.SetRollback(ctx, target, req.Dtid, 0)
+	case req.Mm:
+		return qs.ConcludeTransaction(ctx, target, req.Dtid)
+	case req.Commit:
+		return qs.CommitPrepared(ctx, target, req.Dtid)
+	default:
+		return qs.RollbackPrepared(ctx, target, req.Dtid, 0)
+	}
+}
+
+func (tm *TabletManager) target() *querypb.Target {
+	tablet := tm.Tablet()
+	return &querypb.Target{Keyspace: tablet.Keyspace, Shard: tablet.Shard, TabletType: tablet.Type}
+}
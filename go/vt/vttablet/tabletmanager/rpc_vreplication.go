@@ -18,6 +18,7 @@ package tabletmanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -53,9 +54,9 @@ const (
 	// Delete VReplication records for the given workflow.
 	sqlDeleteVReplicationWorkflow = "delete from %s.vreplication where workflow = %a and db_name = %a"
 	// Retrieve the current configuration values for a workflow's vreplication stream(s).
-	sqlSelectVReplicationWorkflowConfig = "select id, source, cell, tablet_types, state, message from %s.vreplication where workflow = %a"
+	sqlSelectVReplicationWorkflowConfig = "select id, source, cell, tablet_types, state, message, options from %s.vreplication where workflow = %a"
 	// Update the configuration values for a workflow's vreplication stream.
-	sqlUpdateVReplicationWorkflowStreamConfig = "update %s.vreplication set state = %a, source = %a, cell = %a, tablet_types = %a where id = %a"
+	sqlUpdateVReplicationWorkflowStreamConfig = "update %s.vreplication set state = %a, source = %a, cell = %a, tablet_types = %a, options = %a where id = %a"
 	// Update field values for multiple workflows. The final format specifier is
 	// used to optionally add any additional predicates to the query.
 	sqlUpdateVReplicationWorkflows = "update /*vt+ ALLOW_UNSAFE_VREPLICATION_WRITE */ %s.vreplication set%s where db_name = '%s'%s"
@@ -457,14 +458,19 @@ func (tm *TabletManager) UpdateVReplicationWorkflow(ctx context.Context, req *ta
 		if !textutil.ValueIsSimulatedNull(req.State) {
 			state = binlogdatapb.VReplicationWorkflowState_name[int32(req.State)]
 		}
+		options, err := updateCopySettings(row.AsString("options", ""), req.CopySettings)
+		if err != nil {
+			return nil, err
+		}
 		bindVars = map[string]*querypb.BindVariable{
 			"st": sqltypes.StringBindVariable(state),
 			"sc": sqltypes.StringBindVariable(string(source)),
 			"cl": sqltypes.StringBindVariable(strings.Join(cells, ",")),
 			"tt": sqltypes.StringBindVariable(tabletTypesStr),
+			"op": sqltypes.StringBindVariable(options),
 			"id": sqltypes.Int64BindVariable(id),
 		}
-		parsed = sqlparser.BuildParsedQuery(sqlUpdateVReplicationWorkflowStreamConfig, sidecar.GetIdentifier(), ":st", ":sc", ":cl", ":tt", ":id")
+		parsed = sqlparser.BuildParsedQuery(sqlUpdateVReplicationWorkflowStreamConfig, sidecar.GetIdentifier(), ":st", ":sc", ":cl", ":tt", ":op", ":id")
 		stmt, err = parsed.GenerateQuery(bindVars, nil)
 		if err != nil {
 			return nil, err
@@ -483,6 +489,53 @@ func (tm *TabletManager) UpdateVReplicationWorkflow(ctx context.Context, req *ta
 	}, nil
 }
 
+// updateCopySettings returns the options of a vreplication stream with the
+// copy settings updated with the ones provided, if any. The other options are
+// kept as they are.
+func updateCopySettings(options string, settings *tabletmanagerdatapb.VReplicationCopySettings) (string, error) {
+	if settings == nil {
+		return options, nil
+	}
+	opts := make(map[string]json.RawMessage)
+	if options != "" {
+		if err := json.Unmarshal([]byte(options), &opts); err != nil {
+			return "", vterrors.Wrapf(err, "failed to parse the workflow options %q", options)
+		}
+	}
+	current := &tabletmanagerdatapb.VReplicationCopySettings{}
+	if val, ok := opts["copy_settings"]; ok {
+		if err := json.Unmarshal(val, current); err != nil {
+			return "", vterrors.Wrapf(err, "failed to parse the copy settings %q", val)
+		}
+	}
+	// We use the simulated NULL value of -1 to keep the existing value.
+	if !textutil.ValueIsSimulatedNull(settings.ParallelInsertWorkers) {
+		current.ParallelInsertWorkers = settings.ParallelInsertWorkers
+	}
+	if !textutil.ValueIsSimulatedNull(settings.BatchRows) {
+		current.BatchRows = settings.BatchRows
+	}
+	if !textutil.ValueIsSimulatedNull(settings.BatchBytes) {
+		current.BatchBytes = settings.BatchBytes
+	}
+	if !textutil.ValueIsSimulatedNull(settings.MaxRowsPerSecond) {
+		current.MaxRowsPerSecond = settings.MaxRowsPerSecond
+	}
+	if !textutil.ValueIsSimulatedNull(settings.MaxBytesPerSecond) {
+		current.MaxBytesPerSecond = settings.MaxBytesPerSecond
+	}
+	val, err := json.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+	opts["copy_settings"] = val
+	res, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
 // UpdateVReplicationWorkflows operates in much the same way that
 // UpdateVReplicationWorkflow does, but it allows you to update the
 // metadata/flow control fields -- state, message, and stop_pos -- for
@@ -91,9 +91,9 @@ func (pe *primitiveExecutor) next() ([]sqltypes.Value, error) {
 	return row, nil
 }
 
-// drain fastforward's a shard to process (and ignore) everything from its results stream and return a count of the
-// discarded rows.
-func (pe *primitiveExecutor) drain(ctx context.Context) (int64, error) {
+// drain fastforward's a shard to process everything from its results stream, passing each row to onRow, and return
+// a count of the drained rows.
+func (pe *primitiveExecutor) drain(ctx context.Context, onRow func([]sqltypes.Value) error) (int64, error) {
 	var count int64
 	for {
 		row, err := pe.next()
@@ -103,6 +103,9 @@ func (pe *primitiveExecutor) drain(ctx context.Context) (int64, error) {
 		if row == nil {
 			return count, nil
 		}
+		if err := onRow(row); err != nil {
+			return 0, err
+		}
 		count++
 	}
 }
@@ -44,6 +44,11 @@ type DiffReport struct {
 	ExtraRowsSourceDiffs []*RowDiff      `json:"ExtraRowsSourceSample,omitempty"`
 	ExtraRowsTargetDiffs []*RowDiff      `json:"ExtraRowsTargetSample,omitempty"`
 	MismatchedRowsDiffs  []*DiffMismatch `json:"MismatchedRowsSample,omitempty"`
+
+	// repair of the rows which are different, if requested
+	RowsToRepair     int64    `json:",omitempty"`
+	RepairedRows     int64    `json:",omitempty"`
+	RepairStatements []string `json:"RepairStatementsSample,omitempty"`
 }
 
 type ProgressReport struct {
@@ -95,6 +95,9 @@ type tableDiffer struct {
 	wgShardStreamers   sync.WaitGroup
 	shardStreamsCtx    context.Context
 	shardStreamsCancel context.CancelFunc
+
+	// repairer repairs the rows found to be different, if requested.
+	repairer *tableRepairer
 }
 
 func newTableDiffer(wd *workflowDiffer, table *tabletmanagerdatapb.TableDefinition, sourceQuery string) *tableDiffer {
@@ -134,6 +137,11 @@ func (td *tableDiffer) initialize(ctx context.Context) error {
 		return err
 	}
 	defer func() {
+		if td.repairer != nil {
+			// The target streams are kept stopped at the snapshot position until
+			// the rows of the table are repaired.
+			return
+		}
 		// We use a new context as we want to reset the state even
 		// when the parent context has timed out or been canceled.
 		log.Infof("Restarting the %q VReplication workflow on target tablets in keyspace %q",
@@ -592,9 +600,12 @@ func (td *tableDiffer) diff(ctx context.Context, rowsToCompare int64, debug, onl
 				return nil, vterrors.Wrap(err, "unexpected error generating diff")
 			}
 			dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
+			if err := td.repairer.addTargetRow(targetRow); err != nil {
+				return nil, err
+			}
 
 			// Drain target, update count.
-			count, err := targetExecutor.drain(ctx)
+			count, err := targetExecutor.drain(ctx, td.repairer.addTargetRow)
 			if err != nil {
 				return nil, err
 			}
@@ -610,7 +621,10 @@ func (td *tableDiffer) diff(ctx context.Context, rowsToCompare int64, debug, onl
 				return nil, vterrors.Wrap(err, "unexpected error generating diff")
 			}
 			dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
-			count, err := sourceExecutor.drain(ctx)
+			if err := td.repairer.addSourceRow(sourceRow); err != nil {
+				return nil, err
+			}
+			count, err := sourceExecutor.drain(ctx, td.repairer.addSourceRow)
 			if err != nil {
 				return nil, err
 			}
@@ -634,6 +648,9 @@ func (td *tableDiffer) diff(ctx context.Context, rowsToCompare int64, debug, onl
 				}
 				dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
 			}
+			if err := td.repairer.addSourceRow(sourceRow); err != nil {
+				return nil, err
+			}
 			dr.ExtraRowsSource++
 			advanceTarget = false
 			continue
@@ -645,6 +662,9 @@ func (td *tableDiffer) diff(ctx context.Context, rowsToCompare int64, debug, onl
 				}
 				dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
 			}
+			if err := td.repairer.addTargetRow(targetRow); err != nil {
+				return nil, err
+			}
 			dr.ExtraRowsTarget++
 			advanceSource = false
 			continue
@@ -669,6 +689,9 @@ func (td *tableDiffer) diff(ctx context.Context, rowsToCompare int64, debug, onl
 				}
 				dr.MismatchedRowsDiffs = append(dr.MismatchedRowsDiffs, &DiffMismatch{Source: sourceDiffRow, Target: targetDiffRow})
 			}
+			if err := td.repairer.addSourceRow(sourceRow); err != nil {
+				return nil, err
+			}
 			dr.MismatchedRows++
 		default:
 			dr.MatchingRows++
@@ -678,6 +701,11 @@ func (td *tableDiffer) diff(ctx context.Context, rowsToCompare int64, debug, onl
 		// approximate progress information but without too much overhead for when it's not
 		// needed or even desired.
 		if dr.ProcessedRows%1e4 == 0 {
+			// The rows to repair are flushed first, as the diff resumes after
+			// the last PK if it's restarted.
+			if err := td.repairer.flush(); err != nil {
+				return nil, err
+			}
 			if err := td.updateTableProgress(dbClient, dr, sourceRow); err != nil {
 				return nil, err
 			}
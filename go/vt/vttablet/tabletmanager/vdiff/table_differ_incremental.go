@@ -0,0 +1,485 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// An incremental diff only compares the rows which were modified on the
+// sources since a given GTID position, rather than the whole table. The
+// modified rows are collected from the row events of the binlogs of the
+// sources, up to the position of the target streams, which are stopped for
+// the duration of the diff. Their latest image on the source is then
+// compared with the row on the target. This requires binlog_row_image=full
+// on the sources, as the images of the rows are compared.
+
+const (
+	streamingChanges = tableDiffPhase("streaming_source_changes")
+
+	// incrementalDiffBatchSize is the number of rows fetched from the target
+	// in one query.
+	incrementalDiffBatchSize = 1000
+)
+
+// incrementalRow is the latest image of a modified row on the source.
+type incrementalRow struct {
+	pk  []sqltypes.Value
+	row []sqltypes.Value // nil if the row was deleted
+}
+
+// incrementalChanges collects the latest images of the rows which were
+// modified on the sources, by primary key.
+type incrementalChanges struct {
+	tp *tablePlan
+
+	mu   sync.Mutex
+	rows map[string]*incrementalRow
+	keys []string // the keys of rows, in the order they were first modified
+}
+
+func newIncrementalChanges(tp *tablePlan) *incrementalChanges {
+	return &incrementalChanges{
+		tp:   tp,
+		rows: make(map[string]*incrementalRow),
+	}
+}
+
+// pkKey returns the key of a row of the diff by its primary key values.
+func (ic *incrementalChanges) pkKey(row []sqltypes.Value) ([]sqltypes.Value, string) {
+	pk := make([]sqltypes.Value, len(ic.tp.pkCols))
+	var key strings.Builder
+	for i, colIndex := range ic.tp.pkCols {
+		pk[i] = row[colIndex]
+		if i > 0 {
+			key.WriteByte(',')
+		}
+		pk[i].EncodeSQL(&key)
+	}
+	return pk, key.String()
+}
+
+// set records the latest image of a row, which is nil if the row was deleted.
+func (ic *incrementalChanges) set(pk []sqltypes.Value, key string, row []sqltypes.Value) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	if _, ok := ic.rows[key]; !ok {
+		ic.keys = append(ic.keys, key)
+	}
+	ic.rows[key] = &incrementalRow{pk: pk, row: row}
+}
+
+// columnMap maps the fields of the row events streamed from a source to the
+// columns of the diff, by name. The filter of the workflow can be a subset of
+// the columns of the table, or list them in a different order.
+func (ic *incrementalChanges) columnMap(fields []*querypb.Field) ([]int, error) {
+	colMap := make([]int, len(fields))
+	found := make([]bool, len(ic.tp.compareCols))
+	for i, field := range fields {
+		colMap[i] = -1
+		for j, col := range ic.tp.compareCols {
+			if strings.EqualFold(field.Name, col.colName) {
+				colMap[i] = col.colIndex
+				found[j] = true
+				break
+			}
+		}
+	}
+	for j, ok := range found {
+		if !ok {
+			return nil, fmt.Errorf("column %s of table %s is not in the row events of the source", ic.tp.compareCols[j].colName, ic.tp.table.Name)
+		}
+	}
+	return colMap, nil
+}
+
+// addRowChange records the images of a row change, given the column map of
+// its fields. If the primary key of the row was updated, the row with the old
+// primary key is recorded as deleted.
+func (ic *incrementalChanges) addRowChange(fields []*querypb.Field, colMap []int, change *binlogdatapb.RowChange) error {
+	if isPartialImage(change) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+			"partial row image found for table %s: an incremental vdiff requires binlog_row_image=full on the source", ic.tp.table.Name)
+	}
+	toRow := func(image *querypb.Row) []sqltypes.Value {
+		row := make([]sqltypes.Value, len(ic.tp.compareCols))
+		for i, val := range sqltypes.MakeRowTrusted(fields, image) {
+			if colMap[i] >= 0 {
+				row[colMap[i]] = val
+			}
+		}
+		return row
+	}
+	if change.Before != nil {
+		pk, key := ic.pkKey(toRow(change.Before))
+		ic.set(pk, key, nil)
+	}
+	if change.After != nil {
+		row := toRow(change.After)
+		pk, key := ic.pkKey(row)
+		ic.set(pk, key, row)
+	}
+	return nil
+}
+
+// isPartialImage returns true if the images of a row change do not contain
+// the full values of all of the columns of the row.
+func isPartialImage(change *binlogdatapb.RowChange) bool {
+	if change.JsonPartialValues != nil && change.JsonPartialValues.Count > 0 {
+		for _, b := range change.JsonPartialValues.Cols {
+			if b != 0 {
+				return true
+			}
+		}
+	}
+	if change.DataColumns == nil || change.DataColumns.Count == 0 {
+		return false
+	}
+	for i := 0; i < int(change.DataColumns.Count); i++ {
+		if change.DataColumns.Cols[i/8]&byte(1<<(uint(i)&0x7)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// incrementalDiff diffs the rows of the table which were modified on the
+// sources between the incremental from position of the vdiff and the position
+// of the target streams.
+func (td *tableDiffer) incrementalDiff(ctx context.Context, dbClient binlogplayer.DBClient) (*DiffReport, error) {
+	defer td.wd.ct.TableDiffPhaseTimings.Record(fmt.Sprintf("%s.%s", td.table.Name, diffingTable), time.Now())
+	if len(td.tablePlan.aggregates) != 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+			"an incremental vdiff is not supported for table %s, as its filter has aggregates", td.table.Name)
+	}
+	fromPos, err := replication.DecodePosition(td.wd.opts.CoreOptions.IncrementalFromPosition)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid incremental from position %q", td.wd.opts.CoreOptions.IncrementalFromPosition)
+	}
+
+	// The target streams are stopped until the modified rows are compared, so
+	// that the target doesn't move past the position the sources are streamed to.
+	vdiffEngine := td.wd.ct.vde
+	vdiffEngine.snapshotMu.Lock()
+	defer vdiffEngine.snapshotMu.Unlock()
+
+	targetKeyspace := td.wd.ct.vde.thisTablet.Keyspace
+	log.Infof("Locking target keyspace %s", targetKeyspace)
+	ctx, unlock, lockErr := td.wd.ct.ts.LockKeyspace(ctx, targetKeyspace, "vdiff")
+	if lockErr != nil {
+		log.Errorf("LockKeyspace failed: %v", lockErr)
+		return nil, lockErr
+	}
+	defer func() {
+		var unlockErr error
+		unlock(&unlockErr)
+		if unlockErr != nil {
+			log.Errorf("UnlockKeyspace %s failed: %v", targetKeyspace, unlockErr)
+		}
+	}()
+
+	if err := td.stopTargetVReplicationStreams(ctx, dbClient); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// We use a new context as we want to reset the state even
+		// when the parent context has timed out or been canceled.
+		log.Infof("Restarting the %q VReplication workflow on target tablets in keyspace %q",
+			td.wd.ct.workflow, targetKeyspace)
+		restartCtx, restartCancel := context.WithTimeout(context.Background(), BackgroundOperationTimeout)
+		defer restartCancel()
+		if err := td.restartTargetVReplicationStreams(restartCtx); err != nil {
+			log.Errorf("error restarting target streams: %v", err)
+		}
+	}()
+
+	if err := td.selectTablets(ctx); err != nil {
+		return nil, err
+	}
+	if err := td.syncSourceStreams(ctx); err != nil {
+		return nil, err
+	}
+
+	changes := newIncrementalChanges(td.tablePlan)
+	if err := td.forEachSource(func(source *migrationSource) error {
+		return td.streamIncrementalChanges(ctx, source, fromPos, changes)
+	}); err != nil {
+		return nil, err
+	}
+	log.Infof("Found %d modified rows in table %s for incremental vdiff %s", len(changes.keys), td.table.Name, td.wd.ct.uuid)
+	return td.compareIncrementalChanges(ctx, dbClient, changes)
+}
+
+// streamIncrementalChanges streams the row events of the table from a source,
+// from the from position up to the position of its target stream.
+func (td *tableDiffer) streamIncrementalChanges(ctx context.Context, source *migrationSource, fromPos replication.Position, changes *incrementalChanges) error {
+	defer td.wd.ct.TableDiffPhaseTimings.Record(fmt.Sprintf("%s.%s", td.table.Name, streamingChanges), time.Now())
+	stopPos := source.position
+	if fromPos.AtLeast(stopPos) {
+		log.Infof("Source shard %s has no changes to diff for table %s: from position %s is at least the stream position %s",
+			source.shard, td.table.Name, replication.EncodePosition(fromPos), replication.EncodePosition(stopPos))
+		return nil
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	conn, err := tabletconn.GetDialer()(streamCtx, source.tablet, false)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(streamCtx)
+
+	req := &binlogdatapb.VStreamRequest{
+		Target: &querypb.Target{
+			Keyspace:   source.tablet.Keyspace,
+			Shard:      source.shard,
+			TabletType: source.tablet.Type,
+		},
+		Position: replication.EncodePosition(fromPos),
+		Filter: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  td.table.Name,
+				Filter: td.sourceQuery,
+			}},
+		},
+	}
+	var (
+		fields  []*querypb.Field
+		colMap  []int
+		reached bool
+	)
+	err = conn.VStream(streamCtx, req, func(events []*binlogdatapb.VEvent) error {
+		for _, event := range events {
+			switch event.Type {
+			case binlogdatapb.VEventType_FIELD:
+				fields = event.FieldEvent.Fields
+				if colMap, err = changes.columnMap(fields); err != nil {
+					return err
+				}
+			case binlogdatapb.VEventType_ROW:
+				if fields == nil {
+					return fmt.Errorf("received a row event for table %s without its fields", td.table.Name)
+				}
+				for _, change := range event.RowEvent.RowChanges {
+					if err := changes.addRowChange(fields, colMap, change); err != nil {
+						return err
+					}
+				}
+			case binlogdatapb.VEventType_GTID:
+				pos, err := replication.DecodePosition(event.Gtid)
+				if err != nil {
+					return err
+				}
+				if pos.AtLeast(stopPos) {
+					// Returning io.EOF ends the stream without an error.
+					reached = true
+					return io.EOF
+				}
+			}
+		}
+		select {
+		case <-td.wd.ct.done:
+			return ErrVDiffStoppedByUser
+		default:
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return vterrors.Wrapf(err, "VStream for tablet %v", source.tablet.Alias)
+	}
+	if !reached {
+		return fmt.Errorf("vstream of table %s on tablet %v ended before reaching position %s",
+			td.table.Name, source.tablet.Alias, replication.EncodePosition(stopPos))
+	}
+	return nil
+}
+
+// incrementalTargetQuery returns the query selecting the rows of a batch of
+// primary keys from the target table in the local database.
+func (td *tableDiffer) incrementalTargetQuery(rows []*incrementalRow) (string, error) {
+	statement, err := td.wd.ct.vde.parser.Parse(td.tablePlan.targetQuery)
+	if err != nil {
+		return "", err
+	}
+	sel, ok := statement.(*sqlparser.Select)
+	if !ok {
+		return "", fmt.Errorf("unexpected: %v", sqlparser.String(statement))
+	}
+	if tableExpr, ok := sel.From[0].(*sqlparser.AliasedTableExpr); ok {
+		if tableName, ok := tableExpr.Expr.(sqlparser.TableName); ok {
+			tableName.Qualifier = sqlparser.NewIdentifierCS(td.tablePlan.dbName)
+			tableExpr.Expr = tableName
+		}
+	}
+
+	var buf strings.Builder
+	pkCols := make([]string, len(td.tablePlan.comparePKs))
+	for i, pk := range td.tablePlan.comparePKs {
+		pkCols[i] = sqlparser.String(sqlparser.NewIdentifierCI(pk.colName))
+	}
+	if len(pkCols) == 1 {
+		buf.WriteString(pkCols[0])
+	} else {
+		fmt.Fprintf(&buf, "(%s)", strings.Join(pkCols, ", "))
+	}
+	buf.WriteString(" in (")
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if len(row.pk) > 1 {
+			buf.WriteByte('(')
+		}
+		for j, val := range row.pk {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			val.EncodeSQL(&buf)
+		}
+		if len(row.pk) > 1 {
+			buf.WriteByte(')')
+		}
+	}
+	buf.WriteByte(')')
+	where, err := td.wd.ct.vde.parser.ParseExpr(buf.String())
+	if err != nil {
+		return "", err
+	}
+	sel.AddWhere(where)
+	return sqlparser.String(sel), nil
+}
+
+// compareIncrementalChanges compares the latest images of the modified rows
+// on the sources with the rows on the target.
+func (td *tableDiffer) compareIncrementalChanges(ctx context.Context, dbClient binlogplayer.DBClient, changes *incrementalChanges) (*DiffReport, error) {
+	var (
+		debug                 = td.wd.opts.ReportOptions.DebugQuery
+		onlyPks               = td.wd.opts.ReportOptions.OnlyPks
+		maxExtraRowsToCompare = td.wd.opts.CoreOptions.MaxExtraRowsToCompare
+		maxReportSampleRows   = td.wd.opts.ReportOptions.MaxSampleRows
+		rowsToCompare         = td.wd.opts.CoreOptions.MaxRows
+	)
+	dr := &DiffReport{TableName: td.table.Name}
+	defer func() {
+		globalStats.RowsDiffedCount.Add(dr.ProcessedRows)
+	}()
+
+	keys := changes.keys
+	if rowsToCompare > 0 && int64(len(keys)) > rowsToCompare {
+		log.Infof("Stopping vdiff, specified row limit reached")
+		keys = keys[:rowsToCompare]
+	}
+	for start := 0; start < len(keys); start += incrementalDiffBatchSize {
+		select {
+		case <-ctx.Done():
+			return nil, vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
+		case <-td.wd.ct.done:
+			return nil, ErrVDiffStoppedByUser
+		default:
+		}
+
+		batchKeys := keys[start:min(start+incrementalDiffBatchSize, len(keys))]
+		batch := make([]*incrementalRow, len(batchKeys))
+		for i, key := range batchKeys {
+			batch[i] = changes.rows[key]
+		}
+		query, err := td.incrementalTargetQuery(batch)
+		if err != nil {
+			return nil, err
+		}
+		qr, err := dbClient.ExecuteFetch(query, -1)
+		if err != nil {
+			return nil, err
+		}
+		targetRows := make(map[string][]sqltypes.Value, len(qr.Rows))
+		for _, row := range qr.Rows {
+			_, key := changes.pkKey(row)
+			targetRows[key] = row
+		}
+
+		for i, key := range batchKeys {
+			sourceRow, targetRow := batch[i].row, targetRows[key]
+			dr.ProcessedRows++
+			switch {
+			case sourceRow == nil && targetRow == nil:
+				// The row was deleted on both sides.
+				dr.MatchingRows++
+			case sourceRow == nil:
+				if dr.ExtraRowsTarget < maxExtraRowsToCompare {
+					diffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, debug, onlyPks)
+					if err != nil {
+						return nil, vterrors.Wrap(err, "unexpected error generating diff")
+					}
+					dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
+				}
+				dr.ExtraRowsTarget++
+			case targetRow == nil:
+				if dr.ExtraRowsSource < maxExtraRowsToCompare {
+					diffRow, err := td.genRowDiff(td.tablePlan.sourceQuery, sourceRow, debug, onlyPks)
+					if err != nil {
+						return nil, vterrors.Wrap(err, "unexpected error generating diff")
+					}
+					dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
+				}
+				dr.ExtraRowsSource++
+			default:
+				c, err := td.compare(sourceRow, targetRow, td.tablePlan.compareCols, true)
+				if err != nil {
+					return nil, err
+				}
+				if c == 0 {
+					dr.MatchingRows++
+					continue
+				}
+				if maxReportSampleRows == 0 || dr.MismatchedRows < maxReportSampleRows {
+					sourceDiffRow, err := td.genRowDiff(td.tablePlan.targetQuery, sourceRow, debug, onlyPks)
+					if err != nil {
+						return nil, vterrors.Wrap(err, "unexpected error generating diff")
+					}
+					targetDiffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, debug, onlyPks)
+					if err != nil {
+						return nil, vterrors.Wrap(err, "unexpected error generating diff")
+					}
+					dr.MismatchedRowsDiffs = append(dr.MismatchedRowsDiffs, &DiffMismatch{Source: sourceDiffRow, Target: targetDiffRow})
+				}
+				dr.MismatchedRows++
+			}
+		}
+		if err := td.updateTableProgress(dbClient, dr, nil); err != nil {
+			return nil, err
+		}
+	}
+	return dr, nil
+}
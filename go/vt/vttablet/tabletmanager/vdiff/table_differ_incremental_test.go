@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func newIncrementalTestPlan(pks ...string) *tablePlan {
+	tp := &tablePlan{
+		targetQuery: "select c1, c2, c3 from t1 order by c1 asc",
+		dbName:      "vt_customer",
+		table:       &tabletmanagerdatapb.TableDefinition{Name: "t1"},
+		compareCols: []compareColInfo{
+			{colIndex: 0, colName: "c1"},
+			{colIndex: 1, colName: "c2"},
+			{colIndex: 2, colName: "c3"},
+		},
+	}
+	for _, pk := range pks {
+		for i := range tp.compareCols {
+			if tp.compareCols[i].colName == pk {
+				tp.compareCols[i].isPK = true
+				tp.comparePKs = append(tp.comparePKs, tp.compareCols[i])
+				tp.pkCols = append(tp.pkCols, i)
+			}
+		}
+	}
+	return tp
+}
+
+func TestIncrementalChanges(t *testing.T) {
+	tp := newIncrementalTestPlan("c1")
+	ic := newIncrementalChanges(tp)
+
+	// The fields of the filter can be in a different order than the diff.
+	fields := sqltypes.MakeTestFields("c3|c1|c2", "varchar|int64|varchar")
+	colMap, err := ic.columnMap(fields)
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 0, 1}, colMap)
+
+	_, err = ic.columnMap(sqltypes.MakeTestFields("c1|c2", "int64|varchar"))
+	require.ErrorContains(t, err, "column c3 of table t1 is not in the row events of the source")
+
+	row := func(c3 string, c1 int64, c2 string) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewVarChar(c3), sqltypes.NewInt64(c1), sqltypes.NewVarChar(c2)})
+	}
+	changes := []*binlogdatapb.RowChange{
+		{After: row("a", 1, "x")},
+		{After: row("b", 2, "y")},
+		{Before: row("a", 1, "x"), After: row("c", 1, "z")},
+		{Before: row("b", 2, "y")},
+		{Before: row("c", 1, "z"), After: row("c", 3, "z")},
+	}
+	for _, change := range changes {
+		require.NoError(t, ic.addRowChange(fields, colMap, change))
+	}
+	require.Equal(t, []string{"1", "2", "3"}, ic.keys)
+	require.Nil(t, ic.rows["1"].row, "the old primary key of an updated row is deleted")
+	require.Nil(t, ic.rows["2"].row)
+	require.Equal(t, []sqltypes.Value{sqltypes.NewInt64(3), sqltypes.NewVarChar("z"), sqltypes.NewVarChar("c")}, ic.rows["3"].row)
+	require.Equal(t, []sqltypes.Value{sqltypes.NewInt64(3)}, ic.rows["3"].pk)
+
+	partial := &binlogdatapb.RowChange{
+		After:       row("a", 4, "x"),
+		DataColumns: &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0x03}},
+	}
+	require.ErrorContains(t, ic.addRowChange(fields, colMap, partial), "binlog_row_image=full")
+}
+
+func TestIsPartialImage(t *testing.T) {
+	tcases := []struct {
+		name   string
+		change *binlogdatapb.RowChange
+		want   bool
+	}{{
+		name:   "no bitmaps",
+		change: &binlogdatapb.RowChange{},
+	}, {
+		name:   "all columns",
+		change: &binlogdatapb.RowChange{DataColumns: &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0x07}}},
+	}, {
+		name:   "missing column",
+		change: &binlogdatapb.RowChange{DataColumns: &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0x05}}},
+		want:   true,
+	}, {
+		name:   "partial json",
+		change: &binlogdatapb.RowChange{JsonPartialValues: &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0x04}}},
+		want:   true,
+	}, {
+		name:   "no partial json",
+		change: &binlogdatapb.RowChange{JsonPartialValues: &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0x00}}},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			require.Equal(t, tcase.want, isPartialImage(tcase.change))
+		})
+	}
+}
+
+func TestIncrementalTargetQuery(t *testing.T) {
+	td := &tableDiffer{
+		wd: &workflowDiffer{ct: &controller{vde: &Engine{parser: sqlparser.NewTestParser()}}},
+	}
+	pkRow := func(vals ...int64) *incrementalRow {
+		r := &incrementalRow{}
+		for _, val := range vals {
+			r.pk = append(r.pk, sqltypes.NewInt64(val))
+		}
+		return r
+	}
+
+	td.tablePlan = newIncrementalTestPlan("c1")
+	query, err := td.incrementalTargetQuery([]*incrementalRow{pkRow(1), pkRow(2)})
+	require.NoError(t, err)
+	require.Equal(t, "select c1, c2, c3 from vt_customer.t1 where c1 in (1, 2) order by c1 asc", query)
+
+	td.tablePlan = newIncrementalTestPlan("c1", "c2")
+	query, err = td.incrementalTargetQuery([]*incrementalRow{pkRow(1, 2), pkRow(3, 4)})
+	require.NoError(t, err)
+	require.Equal(t, "select c1, c2, c3 from vt_customer.t1 where (c1, c2) in ((1, 2), (3, 4)) order by c1 asc", query)
+}
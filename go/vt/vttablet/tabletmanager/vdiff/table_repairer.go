@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// When a vdiff repairs the rows of a table, the streams of the workflow are
+// kept stopped on the target at the position of the snapshot of the sources
+// while the table is diffed. The rows found to be different are collected by
+// primary key and, in batches, confirmed against the rows of the target
+// primary at that same position. Then the statements which repair them are
+// generated, and applied unless it's a dry run: the extra rows are deleted,
+// and the missing and mismatched rows are inserted and updated with the
+// values of the source.
+
+// repairBatchSize is the number of rows which are confirmed and repaired at once.
+const repairBatchSize = 1000
+
+// tableRepairer repairs the rows of a table on the target which are different
+// from the source.
+type tableRepairer struct {
+	td       *tableDiffer
+	dbClient binlogplayer.DBClient
+	dryRun   bool
+
+	// candidates are the rows found to be different by the diff, with the
+	// image of the source, or nil if the row is extra on the target.
+	candidates *incrementalChanges
+
+	rowsToRepair int64
+	repairedRows int64
+	statements   []string // a sample of the statements for the report
+}
+
+func (td *tableDiffer) newTableRepairer(dbClient binlogplayer.DBClient) (*tableRepairer, error) {
+	if td.wd.ct.sourceTimeZone != "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+			"repair is not supported for table %s, as the workflow converts the time zone of its rows", td.table.Name)
+	}
+	if len(td.tablePlan.aggregates) != 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+			"repair is not supported for table %s, as its filter has aggregates", td.table.Name)
+	}
+	return &tableRepairer{
+		td:         td,
+		dbClient:   dbClient,
+		dryRun:     !td.wd.opts.CoreOptions.Repair,
+		candidates: newIncrementalChanges(td.tablePlan),
+	}, nil
+}
+
+// addSourceRow records a row of the source which is missing or mismatched on
+// the target.
+func (tr *tableRepairer) addSourceRow(row []sqltypes.Value) error {
+	if tr == nil {
+		return nil
+	}
+	pk, key := tr.candidates.pkKey(row)
+	tr.candidates.set(pk, key, row)
+	return tr.flushIfFull()
+}
+
+// addTargetRow records a row of the target which is extra.
+func (tr *tableRepairer) addTargetRow(row []sqltypes.Value) error {
+	if tr == nil {
+		return nil
+	}
+	pk, key := tr.candidates.pkKey(row)
+	tr.candidates.set(pk, key, nil)
+	return tr.flushIfFull()
+}
+
+func (tr *tableRepairer) flushIfFull() error {
+	if len(tr.candidates.keys) < repairBatchSize {
+		return nil
+	}
+	return tr.flush()
+}
+
+// flush confirms the rows collected so far against the target, and repairs
+// the ones which are still different.
+func (tr *tableRepairer) flush() error {
+	if tr == nil || len(tr.candidates.keys) == 0 {
+		return nil
+	}
+	td := tr.td
+	candidates := tr.candidates
+	tr.candidates = newIncrementalChanges(td.tablePlan)
+
+	batch := make([]*incrementalRow, len(candidates.keys))
+	for i, key := range candidates.keys {
+		batch[i] = candidates.rows[key]
+	}
+	query, err := td.incrementalTargetQuery(batch)
+	if err != nil {
+		return err
+	}
+	qr, err := tr.dbClient.ExecuteFetch(query, -1)
+	if err != nil {
+		return err
+	}
+	targetRows := make(map[string][]sqltypes.Value, len(qr.Rows))
+	for _, row := range qr.Rows {
+		_, key := candidates.pkKey(row)
+		targetRows[key] = row
+	}
+
+	// The extra rows are deleted first, as they can have the same primary key
+	// as a missing row if the source and target don't sort them the same way.
+	var deletes, inserts, updates []string
+	for i, key := range candidates.keys {
+		sourceRow, targetRow := batch[i].row, targetRows[key]
+		switch {
+		case sourceRow == nil && targetRow == nil:
+		case sourceRow == nil:
+			deletes = append(deletes, tr.deleteQuery(batch[i].pk))
+		case targetRow == nil:
+			inserts = append(inserts, tr.insertQuery(sourceRow))
+		default:
+			c, err := td.compare(sourceRow, targetRow, td.tablePlan.compareCols, true)
+			if err != nil {
+				return err
+			}
+			if c != 0 {
+				updates = append(updates, tr.updateQuery(batch[i].pk, sourceRow))
+			}
+		}
+	}
+	statements := append(append(deletes, inserts...), updates...)
+	if len(statements) == 0 {
+		return nil
+	}
+	tr.rowsToRepair += int64(len(statements))
+	maxReportSampleRows := td.wd.opts.ReportOptions.MaxSampleRows
+	for _, statement := range statements {
+		if maxReportSampleRows > 0 && int64(len(tr.statements)) >= maxReportSampleRows {
+			break
+		}
+		tr.statements = append(tr.statements, statement)
+	}
+	if tr.dryRun {
+		return nil
+	}
+
+	if err := tr.dbClient.Begin(); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := tr.dbClient.ExecuteFetch(statement, 1); err != nil {
+			if rbErr := tr.dbClient.Rollback(); rbErr != nil {
+				log.Errorf("Failed to rollback the repair of table %s for vdiff %s: %v", td.table.Name, td.wd.ct.uuid, rbErr)
+			}
+			return vterrors.Wrapf(err, "failed to repair table %s", td.table.Name)
+		}
+	}
+	if err := tr.dbClient.Commit(); err != nil {
+		return err
+	}
+	tr.repairedRows += int64(len(statements))
+	log.Infof("Repaired %d rows of table %s for vdiff %s", len(statements), td.table.Name, td.wd.ct.uuid)
+	return nil
+}
+
+// updateReport adds the rows repaired, and the sample of the statements, to
+// the report of the table.
+func (tr *tableRepairer) updateReport(dr *DiffReport) {
+	if tr == nil || dr == nil {
+		return
+	}
+	dr.RowsToRepair += tr.rowsToRepair
+	dr.RepairedRows += tr.repairedRows
+	dr.RepairStatements = append(dr.RepairStatements, tr.statements...)
+}
+
+func (tr *tableRepairer) tableName() string {
+	return sqlparser.String(sqlparser.TableName{
+		Name:      sqlparser.NewIdentifierCS(tr.td.tablePlan.table.Name),
+		Qualifier: sqlparser.NewIdentifierCS(tr.td.tablePlan.dbName),
+	})
+}
+
+func (tr *tableRepairer) writePKCondition(buf *strings.Builder, pk []sqltypes.Value) {
+	for i, col := range tr.td.tablePlan.comparePKs {
+		if i > 0 {
+			buf.WriteString(" and ")
+		}
+		buf.WriteString(sqlparser.String(sqlparser.NewIdentifierCI(col.colName)))
+		buf.WriteString(" = ")
+		pk[i].EncodeSQL(buf)
+	}
+}
+
+// deleteQuery returns the statement which deletes an extra row of the target.
+func (tr *tableRepairer) deleteQuery(pk []sqltypes.Value) string {
+	var buf strings.Builder
+	buf.WriteString("delete from ")
+	buf.WriteString(tr.tableName())
+	buf.WriteString(" where ")
+	tr.writePKCondition(&buf, pk)
+	return buf.String()
+}
+
+// insertQuery returns the statement which inserts a missing row of the target.
+func (tr *tableRepairer) insertQuery(row []sqltypes.Value) string {
+	var buf strings.Builder
+	buf.WriteString("insert into ")
+	buf.WriteString(tr.tableName())
+	buf.WriteString(" (")
+	for i, col := range tr.td.tablePlan.compareCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(sqlparser.String(sqlparser.NewIdentifierCI(col.colName)))
+	}
+	buf.WriteString(") values (")
+	for i, col := range tr.td.tablePlan.compareCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		row[col.colIndex].EncodeSQL(&buf)
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// updateQuery returns the statement which updates a mismatched row of the
+// target with the values of the source.
+func (tr *tableRepairer) updateQuery(pk, row []sqltypes.Value) string {
+	var buf strings.Builder
+	buf.WriteString("update ")
+	buf.WriteString(tr.tableName())
+	buf.WriteString(" set ")
+	first := true
+	for _, col := range tr.td.tablePlan.compareCols {
+		if col.isPK {
+			continue
+		}
+		if !first {
+			buf.WriteString(", ")
+		}
+		first = false
+		buf.WriteString(sqlparser.String(sqlparser.NewIdentifierCI(col.colName)))
+		buf.WriteString(" = ")
+		row[col.colIndex].EncodeSQL(&buf)
+	}
+	buf.WriteString(" where ")
+	tr.writePKCondition(&buf, pk)
+	return buf.String()
+}
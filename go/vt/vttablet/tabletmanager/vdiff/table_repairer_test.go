@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func newRepairTestDiffer(repair bool) *tableDiffer {
+	return &tableDiffer{
+		wd: &workflowDiffer{
+			ct: &controller{vde: &Engine{parser: sqlparser.NewTestParser()}},
+			opts: &tabletmanagerdatapb.VDiffOptions{
+				CoreOptions:   &tabletmanagerdatapb.VDiffCoreOptions{Repair: repair, RepairDryRun: !repair},
+				ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{MaxSampleRows: 2},
+			},
+			collationEnv: collations.MySQL8(),
+		},
+		table:     &tabletmanagerdatapb.TableDefinition{Name: "t1"},
+		tablePlan: newIncrementalTestPlan("c1"),
+	}
+}
+
+func TestTableRepairerStatements(t *testing.T) {
+	td := newRepairTestDiffer(true)
+	tr, err := td.newTableRepairer(nil)
+	require.NoError(t, err)
+
+	pk := []sqltypes.Value{sqltypes.NewInt64(1)}
+	row := []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a'b"), sqltypes.NULL}
+	require.Equal(t, "delete from vt_customer.t1 where c1 = 1", tr.deleteQuery(pk))
+	require.Equal(t, "insert into vt_customer.t1 (c1, c2, c3) values (1, 'a\\'b', null)", tr.insertQuery(row))
+	require.Equal(t, "update vt_customer.t1 set c2 = 'a\\'b', c3 = null where c1 = 1", tr.updateQuery(pk, row))
+
+	td.wd.ct.sourceTimeZone = "US/Pacific"
+	_, err = td.newTableRepairer(nil)
+	require.ErrorContains(t, err, "repair is not supported for table t1")
+}
+
+func TestTableRepairerFlush(t *testing.T) {
+	row := func(c1 int64, c2, c3 string) []sqltypes.Value {
+		return []sqltypes.Value{sqltypes.NewInt64(c1), sqltypes.NewVarChar(c2), sqltypes.NewVarChar(c3)}
+	}
+	targetRows := sqltypes.MakeTestResult(sqltypes.MakeTestFields("c1|c2|c3", "int64|varchar|varchar"),
+		"2|b|b",
+		"3|c|old",
+		"4|d|d",
+	)
+	selectQuery := "select c1, c2, c3 from vt_customer.t1 where c1 in (1, 2, 3, 4, 5) order by c1 asc"
+
+	addCandidates := func(t *testing.T, tr *tableRepairer) {
+		require.NoError(t, tr.addSourceRow(row(1, "a", "a"))) // missing on the target
+		require.NoError(t, tr.addTargetRow(row(2, "b", "b"))) // extra on the target
+		require.NoError(t, tr.addSourceRow(row(3, "c", "c"))) // mismatched
+		require.NoError(t, tr.addSourceRow(row(4, "d", "d"))) // already repaired
+		require.NoError(t, tr.addTargetRow(row(5, "e", "e"))) // already deleted
+	}
+	wantStatements := []string{
+		"delete from vt_customer.t1 where c1 = 2",
+		"insert into vt_customer.t1 (c1, c2, c3) values (1, 'a', 'a')",
+		"update vt_customer.t1 set c2 = 'c', c3 = 'c' where c1 = 3",
+	}
+
+	t.Run("repair", func(t *testing.T) {
+		dbc := binlogplayer.NewMockDBClient(t)
+		tr, err := newRepairTestDiffer(true).newTableRepairer(dbc)
+		require.NoError(t, err)
+		addCandidates(t, tr)
+
+		dbc.ExpectRequest(selectQuery, targetRows, nil)
+		dbc.ExpectRequest("begin", nil, nil)
+		for _, statement := range wantStatements {
+			dbc.ExpectRequest(statement, &sqltypes.Result{RowsAffected: 1}, nil)
+		}
+		dbc.ExpectRequest("commit", nil, nil)
+		require.NoError(t, tr.flush())
+		dbc.Wait()
+		require.NoError(t, tr.flush(), "nothing left to flush")
+
+		dr := &DiffReport{}
+		tr.updateReport(dr)
+		require.Equal(t, int64(3), dr.RowsToRepair)
+		require.Equal(t, int64(3), dr.RepairedRows)
+		require.Equal(t, wantStatements[:2], dr.RepairStatements)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		dbc := binlogplayer.NewMockDBClient(t)
+		tr, err := newRepairTestDiffer(false).newTableRepairer(dbc)
+		require.NoError(t, err)
+		addCandidates(t, tr)
+
+		dbc.ExpectRequest(selectQuery, targetRows, nil)
+		require.NoError(t, tr.flush())
+		dbc.Wait()
+
+		dr := &DiffReport{}
+		tr.updateReport(dr)
+		require.Equal(t, int64(3), dr.RowsToRepair)
+		require.Zero(t, dr.RepairedRows)
+		require.Equal(t, wantStatements[:2], dr.RepairStatements)
+	})
+
+	t.Run("not repairing", func(t *testing.T) {
+		var tr *tableRepairer
+		require.NoError(t, tr.addSourceRow(row(1, "a", "a")))
+		require.NoError(t, tr.flush())
+		dr := &DiffReport{}
+		tr.updateReport(dr)
+		require.Zero(t, dr.RowsToRepair)
+	})
+}
@@ -178,7 +178,33 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		return err
 	}
 
-	for {
+	if wd.opts.CoreOptions.Repair || wd.opts.CoreOptions.RepairDryRun {
+		var err error
+		if td.repairer, err = td.newTableRepairer(dbClient); err != nil {
+			return err
+		}
+		defer func() {
+			td.repairer = nil
+			// The target streams were kept stopped by td.initialize.
+			log.Infof("Restarting the %q VReplication workflow on target tablets in keyspace %q after the repair of table %s",
+				wd.ct.workflow, wd.ct.vde.thisTablet.Keyspace, td.table.Name)
+			restartCtx, restartCancel := context.WithTimeout(context.Background(), BackgroundOperationTimeout)
+			defer restartCancel()
+			if err := td.restartTargetVReplicationStreams(restartCtx); err != nil {
+				log.Errorf("error restarting target streams: %v", err)
+			}
+		}()
+	}
+
+	if wd.opts.CoreOptions.IncrementalFromPosition != "" {
+		// Only the rows modified since the position are diffed, from the binlogs
+		// of the sources, so there's no snapshot to restart the diff from.
+		if diffReport, diffErr = td.incrementalDiff(ctx, dbClient); diffErr != nil {
+			log.Errorf("Encountered an error diffing table %s incrementally for vdiff %s: %v", td.table.Name, wd.ct.uuid, diffErr)
+			return diffErr
+		}
+	}
+	for diffReport == nil {
 		select {
 		case <-ctx.Done():
 			return vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
@@ -206,6 +232,11 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		log.Infof("Table initialization done on table %s for vdiff %s", td.table.Name, wd.ct.uuid)
 		diffTimer = time.NewTimer(maxDiffRuntime)
 		diffReport, diffErr = td.diff(ctx, wd.opts.CoreOptions.MaxRows, wd.opts.ReportOptions.DebugQuery, wd.opts.ReportOptions.OnlyPks, wd.opts.CoreOptions.MaxExtraRowsToCompare, wd.opts.ReportOptions.MaxSampleRows, diffTimer.C)
+		// The rows found to be different so far are repaired even if the diff is
+		// restarted, as it then resumes after them.
+		if err := td.repairer.flush(); err != nil {
+			return err
+		}
 		if diffErr == nil { // We finished the diff successfully
 			break
 		}
@@ -223,6 +254,8 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		}
 	}
 
+	td.repairer.updateReport(diffReport)
+
 	if diffReport.MismatchedRows > 0 || diffReport.ExtraRowsTarget > 0 || diffReport.ExtraRowsSource > 0 {
 		if err := updateTableMismatch(dbClient, wd.ct.id, td.table.Name); err != nil {
 			return err
@@ -292,6 +325,12 @@ func (wd *workflowDiffer) diff(ctx context.Context) (err error) {
 			return fmt.Errorf("no vdiff table found for %s on tablet %v",
 				td.table.Name, wd.ct.vde.thisTablet.Alias)
 		}
+		// A table which was already diffed before the vdiff was restarted, e.g.
+		// by a restart of the tablet, is not diffed again.
+		if qr.Named().Row().AsString("state", "") == string(CompletedState) {
+			log.Infof("Skipping diff of table %s for vdiff %s as it has already completed", td.table.Name, wd.ct.uuid)
+			continue
+		}
 
 		log.Infof("Starting diff of table %s for vdiff %s", td.table.Name, wd.ct.uuid)
 		if err := wd.diffTable(ctx, dbClient, td); err != nil {
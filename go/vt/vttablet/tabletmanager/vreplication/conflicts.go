@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/textutil"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// Reverse workflows replicate the writes of the target keyspace back to the
+// original source keyspace after traffic was switched. A row event which does
+// not apply cleanly on the original source, because the source was written to
+// after the switch, is a conflict. Conflicts are recorded in the
+// vreplication_conflicts sidecar table, along with the image of the row, the
+// GTID of the transaction and the error, so that they can be reconciled.
+const (
+	// ConflictDuplicateKey is the type of a conflict where an insert failed
+	// because the row already exists.
+	ConflictDuplicateKey = "duplicate_key"
+	// ConflictMissingRow is the type of a conflict where an update or delete
+	// did not match any row.
+	ConflictMissingRow = "missing_row"
+
+	reverseWorkflowSuffix           = "_reverse"
+	maxVReplicationConflictErrorLen = 10000
+)
+
+// isReverseWorkflow returns true if the workflow is the reverse workflow of a
+// MoveTables or Reshard, whose conflicts are captured.
+func isReverseWorkflow(workflow string) bool {
+	return strings.HasSuffix(workflow, reverseWorkflowSuffix)
+}
+
+// conflict is a row event which conflicted with the data on the target.
+type conflict struct {
+	table    string
+	typ      string
+	rowImage string
+	gtid     string
+	err      string
+}
+
+// newConflict returns the conflict of a row change of a table plan.
+func newConflict(tplan *TablePlan, typ string, change *binlogdatapb.RowChange, pos replication.Position, err error) *conflict {
+	c := &conflict{
+		table:    tplan.TargetName,
+		typ:      typ,
+		rowImage: conflictRowImage(tplan, change),
+		gtid:     replication.EncodePosition(pos),
+	}
+	if err != nil {
+		c.err = err.Error()
+	}
+	return c
+}
+
+// conflictRowImage returns the before and after images of a row change as a
+// JSON document, e.g. {"before":{"id":"1","val":"a"},"after":null}.
+func conflictRowImage(tplan *TablePlan, change *binlogdatapb.RowChange) string {
+	toImage := func(row *querypb.Row) map[string]any {
+		if row == nil {
+			return nil
+		}
+		image := make(map[string]any, len(tplan.Fields))
+		for i, val := range sqltypes.MakeRowTrusted(tplan.Fields, row) {
+			if val.IsNull() {
+				image[tplan.Fields[i].Name] = nil
+				continue
+			}
+			image[tplan.Fields[i].Name] = val.ToString()
+		}
+		return image
+	}
+	image, err := json.Marshal(map[string]map[string]any{
+		"before": toImage(change.Before),
+		"after":  toImage(change.After),
+	})
+	if err != nil {
+		return ""
+	}
+	return string(image)
+}
+
+// isDuplicateKeyError returns true if the error is a duplicate entry error.
+func isDuplicateKeyError(err error) bool {
+	sqlErr, ok := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+	return ok && sqlErr.Num == sqlerror.ERDupEntry
+}
+
+// rowsMatched returns the number of rows matched by an UPDATE. MySQL reports it
+// in the info of the result, as "Rows matched: 1  Changed: 0  Warnings: 0".
+func rowsMatched(qr *sqltypes.Result) (int64, bool) {
+	fields := strings.Fields(qr.Info)
+	if len(fields) < 3 || fields[0] != "Rows" || fields[1] != "matched:" {
+		return 0, false
+	}
+	matched, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return matched, true
+}
+
+// isMissingRow returns true if the update or delete of a row change did not
+// match any row.
+func isMissingRow(change *binlogdatapb.RowChange, qr *sqltypes.Result) bool {
+	if qr == nil || change.Before == nil || qr.RowsAffected > 0 {
+		return false
+	}
+	if change.After == nil {
+		return true
+	}
+	// An update which matches a row with the same values doesn't affect it.
+	matched, ok := rowsMatched(qr)
+	return ok && matched == 0
+}
+
+// getLastConflict returns the last conflict of a stream.
+func getLastConflict(dbClient *vdbClient, vreplID int32) (id int64, table, typ, rowImage string, err error) {
+	var qr *sqltypes.Result
+	query := "select id, table_name, type, row_image from %s.vreplication_conflicts where vrepl_id = %d order by id desc limit 1"
+	query = sqlparser.BuildParsedQuery(query, sidecar.GetIdentifier(), vreplID).Query
+	if qr, err = dbClient.ExecuteFetch(query, 1); err != nil {
+		return 0, "", "", "", err
+	}
+	if len(qr.Rows) != 1 {
+		return 0, "", "", "", nil
+	}
+	row := qr.Rows[0]
+	id, _ = row[0].ToCastInt64()
+	return id, row[1].ToString(), row[2].ToString(), row[3].ToString(), nil
+}
+
+// insertConflict records a conflict of a stream. Like insertLog, if it's the
+// same as the last conflict of the stream, e.g. when the stream retries a
+// transaction which keeps failing with the same duplicate key, the count of
+// the last conflict is incremented instead.
+func insertConflict(dbClient *vdbClient, vreplID int32, c *conflict) {
+	id, lastTable, lastType, lastRowImage, err := getLastConflict(dbClient, vreplID)
+	if err != nil {
+		log.Errorf("Could not insert vreplication_conflicts record because we failed to get the last conflict record: %v", err)
+		return
+	}
+	var query string
+	if id > 0 && c.table == lastTable && c.typ == lastType && c.rowImage == lastRowImage {
+		query = sqlparser.BuildParsedQuery("update %s.vreplication_conflicts set count = count + 1 where id = %d", sidecar.GetIdentifier(), id).Query
+	} else {
+		message := c.err
+		if len(message) > maxVReplicationConflictErrorLen {
+			message, err = textutil.TruncateText(message, maxVReplicationConflictErrorLen, binlogplayer.TruncationLocation, binlogplayer.TruncationIndicator)
+			if err != nil {
+				log.Errorf("Could not insert vreplication_conflicts record because we failed to truncate the error: %v", err)
+				return
+			}
+		}
+		buf := sqlparser.NewTrackedBuffer(nil)
+		buf.Myprintf("insert into %s.vreplication_conflicts(vrepl_id, table_name, type, row_image, gtid, error) values(%s, %s, %s, %s, %s, %s)",
+			sidecar.GetIdentifier(), strconv.Itoa(int(vreplID)), encodeString(c.table), encodeString(c.typ),
+			encodeString(c.rowImage), encodeString(c.gtid), encodeString(message))
+		query = buf.ParsedQuery().Query
+	}
+	if _, err = dbClient.ExecuteFetch(query, 1); err != nil {
+		log.Errorf("Could not insert into vreplication_conflicts table: %v: %v", query, err)
+	}
+}
+
+// captureConflict checks the result of applying a row change of a reverse
+// workflow for a conflict. A missing row is recorded right away, as part of
+// the transaction. A duplicate key fails the transaction, so it's recorded by
+// the vreplicator once the transaction is rolled back.
+func (vp *vplayer) captureConflict(tplan *TablePlan, change *binlogdatapb.RowChange, qr *sqltypes.Result, err error) {
+	var c *conflict
+	switch {
+	case err != nil:
+		if !isDuplicateKeyError(err) {
+			return
+		}
+		c = newConflict(tplan, ConflictDuplicateKey, change, vp.pos, err)
+		vp.vr.pendingConflict = c
+	case isMissingRow(change, qr):
+		c = newConflict(tplan, ConflictMissingRow, change, vp.pos, nil)
+		insertConflict(vp.vr.dbClient, vp.vr.id, c)
+	default:
+		return
+	}
+	vp.vr.stats.ConflictCounts.Add([]string{c.table, c.typ}, 1)
+}
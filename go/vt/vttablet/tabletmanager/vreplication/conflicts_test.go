@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestIsReverseWorkflow(t *testing.T) {
+	assert.True(t, isReverseWorkflow("wf1_reverse"))
+	assert.False(t, isReverseWorkflow("wf1"))
+	assert.False(t, isReverseWorkflow("reverse_wf1"))
+}
+
+func TestIsDuplicateKeyError(t *testing.T) {
+	assert.True(t, isDuplicateKeyError(sqlerror.NewSQLError(sqlerror.ERDupEntry, sqlerror.SSConstraintViolation, "Duplicate entry '1' for key 't1.PRIMARY'")))
+	assert.False(t, isDuplicateKeyError(sqlerror.NewSQLError(sqlerror.ERNoSuchTable, sqlerror.SSUnknownSQLState, "Table 't1' doesn't exist")))
+	assert.False(t, isDuplicateKeyError(fmt.Errorf("some error")))
+}
+
+func TestIsMissingRow(t *testing.T) {
+	row := sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1)})
+	tcases := []struct {
+		name   string
+		change *binlogdatapb.RowChange
+		qr     *sqltypes.Result
+		want   bool
+	}{{
+		name:   "skipped",
+		change: &binlogdatapb.RowChange{Before: row},
+	}, {
+		name:   "insert",
+		change: &binlogdatapb.RowChange{After: row},
+		qr:     &sqltypes.Result{},
+	}, {
+		name:   "delete",
+		change: &binlogdatapb.RowChange{Before: row},
+		qr:     &sqltypes.Result{RowsAffected: 1},
+	}, {
+		name:   "missing delete",
+		change: &binlogdatapb.RowChange{Before: row},
+		qr:     &sqltypes.Result{},
+		want:   true,
+	}, {
+		name:   "update",
+		change: &binlogdatapb.RowChange{Before: row, After: row},
+		qr:     &sqltypes.Result{RowsAffected: 1, Info: "Rows matched: 1  Changed: 1  Warnings: 0"},
+	}, {
+		name:   "unchanged update",
+		change: &binlogdatapb.RowChange{Before: row, After: row},
+		qr:     &sqltypes.Result{Info: "Rows matched: 1  Changed: 0  Warnings: 0"},
+	}, {
+		name:   "missing update",
+		change: &binlogdatapb.RowChange{Before: row, After: row},
+		qr:     &sqltypes.Result{Info: "Rows matched: 0  Changed: 0  Warnings: 0"},
+		want:   true,
+	}, {
+		name:   "update without info",
+		change: &binlogdatapb.RowChange{Before: row, After: row},
+		qr:     &sqltypes.Result{},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			assert.Equal(t, tcase.want, isMissingRow(tcase.change, tcase.qr))
+		})
+	}
+}
+
+func TestConflictRowImage(t *testing.T) {
+	tplan := &TablePlan{
+		TargetName: "t1",
+		Fields: []*querypb.Field{
+			{Name: "id", Type: querypb.Type_INT64},
+			{Name: "val", Type: querypb.Type_VARBINARY},
+		},
+	}
+	change := &binlogdatapb.RowChange{
+		Before: sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarBinary("a")}),
+		After:  sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NULL}),
+	}
+	assert.JSONEq(t, `{"before":{"id":"1","val":"a"},"after":{"id":"1","val":null}}`, conflictRowImage(tplan, change))
+
+	pos, err := replication.DecodePosition("MySQL56/00000000-0000-0000-0000-000000000001:1-10")
+	require.NoError(t, err)
+	c := newConflict(tplan, ConflictMissingRow, &binlogdatapb.RowChange{Before: change.Before}, pos, nil)
+	require.Equal(t, "t1", c.table)
+	assert.Equal(t, ConflictMissingRow, c.typ)
+	assert.JSONEq(t, `{"before":{"id":"1","val":"a"},"after":null}`, c.rowImage)
+	assert.Equal(t, "MySQL56/00000000-0000-0000-0000-000000000001:1-10", c.gtid)
+	assert.Empty(t, c.err)
+}
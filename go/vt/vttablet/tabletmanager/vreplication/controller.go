@@ -31,6 +31,7 @@ import (
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/vterrors"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/tb"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/log"
@@ -38,7 +39,9 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 const (
@@ -62,6 +65,7 @@ type controller struct {
 	source       *binlogdatapb.BinlogSource
 	stopPos      string
 	tabletPicker *discovery.TabletPicker
+	copySettings *tabletmanagerdatapb.VReplicationCopySettings
 
 	cancel context.CancelFunc
 	done   chan struct{}
@@ -113,6 +117,11 @@ func newController(ctx context.Context, params map[string]string, dbClientFactor
 	}
 
 	ct.stopPos = params["stop_pos"]
+	if ct.copySettings, err = parseCopySettings(params["options"]); err != nil {
+		// The copy settings are only used to tune the copy phase, so we don't
+		// fail the stream because of them.
+		log.Warningf("Ignoring the copy settings of VReplication stream %d, as its options %q are invalid: %v", ct.id, params["options"], err)
+	}
 
 	if ct.source.GetExternalMysql() == "" {
 		if v := params["cell"]; v != "" {
@@ -235,8 +244,7 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 		if _, err := dbClient.ExecuteFetch(fmt.Sprintf("set @@session.net_write_timeout = %v", vttablet.VReplicationNetWriteTimeout), 10000); err != nil {
 			return err
 		}
-		// We must apply AUTO_INCREMENT values precisely as we got them. This include the 0 value, which is not recommended in AUTO_INCREMENT, and yet is valid.
-		if _, err := dbClient.ExecuteFetch("set @@session.sql_mode = CONCAT(@@session.sql_mode, ',NO_AUTO_VALUE_ON_ZERO')", 10000); err != nil {
+		if err := setSessionSQLMode(dbClient); err != nil {
 			return err
 		}
 
@@ -256,6 +264,7 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 		defer vsClient.Close(ctx)
 
 		vr := newVReplicator(ct.id, ct.source, vsClient, ct.blpStats, dbClient, ct.mysqld, ct.vre)
+		vr.copySettings = ct.copySettings
 		err = vr.Replicate(ctx)
 		ct.lastWorkflowError.Record(err)
 
@@ -326,3 +335,27 @@ func (ct *controller) Stop() {
 	ct.blpStats.Stop()
 	<-ct.done
 }
+
+// setSessionSQLMode adds NO_AUTO_VALUE_ON_ZERO to the sql_mode of the session:
+// we must apply AUTO_INCREMENT values precisely as we got them. This include the 0
+// value, which is not recommended in AUTO_INCREMENT, and yet is valid.
+// The queries we build quote strings with single quotes and escape them with
+// backslashes, so it also removes ANSI_QUOTES and NO_BACKSLASH_ESCAPES if the
+// server has them on by default.
+func setSessionSQLMode(dbClient binlogplayer.DBClient) error {
+	qr, err := dbClient.ExecuteFetch("select @@session.sql_mode", 1)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for select @@session.sql_mode: %v", qr.Rows)
+	}
+	sqlMode := mysql.SQLMode(qr.Rows[0][0].ToString()).WithDefaultQuoting()
+	if sqlMode == "" {
+		sqlMode = "NO_AUTO_VALUE_ON_ZERO"
+	} else {
+		sqlMode += ",NO_AUTO_VALUE_ON_ZERO"
+	}
+	_, err = dbClient.ExecuteFetch(fmt.Sprintf("set @@session.sql_mode = %s", encodeString(string(sqlMode))), 10000)
+	return err
+}
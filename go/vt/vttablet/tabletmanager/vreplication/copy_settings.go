@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+
+	"golang.org/x/time/rate"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// The copy phase of a workflow can be tuned with the copy settings stored in
+// the options of its streams, which take precedence over the flags of the
+// tablet. They're updated with a WorkflowUpdate, which restarts the streams,
+// so the new settings apply to the next batch of rows copied.
+
+// parseCopySettings returns the copy settings in the options of a stream, if
+// any.
+func parseCopySettings(options string) (*tabletmanagerdatapb.VReplicationCopySettings, error) {
+	if options == "" {
+		return nil, nil
+	}
+	var opts struct {
+		CopySettings *tabletmanagerdatapb.VReplicationCopySettings `json:"copy_settings,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(options), &opts); err != nil {
+		return nil, err
+	}
+	return opts.CopySettings, nil
+}
+
+// getInsertParallelism returns the number of parallel workers to use for
+// inserting batches during the copy phase.
+func (vc *vcopier) getInsertParallelism() int {
+	if workers := vc.vr.copySettings.GetParallelInsertWorkers(); workers > 0 {
+		return int(workers)
+	}
+	return getInsertParallelism()
+}
+
+// copyBatcher combines the rows streamed from the source into batches of at
+// least the configured number of rows, or bytes, before they're copied.
+type copyBatcher struct {
+	batchRows  int
+	batchBytes int
+
+	rows   []*querypb.Row
+	lastpk *querypb.Row
+	bytes  int
+}
+
+// newCopyBatcher returns a copyBatcher, or nil if the rows are copied as they
+// are streamed.
+func newCopyBatcher(settings *tabletmanagerdatapb.VReplicationCopySettings) *copyBatcher {
+	if settings.GetBatchRows() <= 0 && settings.GetBatchBytes() <= 0 {
+		return nil
+	}
+	return &copyBatcher{
+		batchRows:  int(settings.GetBatchRows()),
+		batchBytes: int(settings.GetBatchBytes()),
+	}
+}
+
+// add adds the rows to the current batch, and returns the batch if it's full.
+// The rows must not be modified by the caller afterwards.
+func (cb *copyBatcher) add(rows []*querypb.Row, lastpk *querypb.Row) ([]*querypb.Row, *querypb.Row) {
+	cb.rows = append(cb.rows, rows...)
+	cb.lastpk = lastpk
+	cb.bytes += rowsSize(rows)
+	if (cb.batchRows > 0 && len(cb.rows) >= cb.batchRows) || (cb.batchBytes > 0 && cb.bytes >= cb.batchBytes) {
+		return cb.flush()
+	}
+	return nil, nil
+}
+
+// flush returns the current batch, if any, and starts a new one.
+func (cb *copyBatcher) flush() ([]*querypb.Row, *querypb.Row) {
+	rows, lastpk := cb.rows, cb.lastpk
+	cb.rows, cb.lastpk, cb.bytes = nil, nil, 0
+	return rows, lastpk
+}
+
+// copyRateLimiter limits the rate at which rows are copied.
+type copyRateLimiter struct {
+	rows  *rate.Limiter
+	bytes *rate.Limiter
+}
+
+// newCopyRateLimiter returns a copyRateLimiter, or nil if the rate isn't
+// limited.
+func newCopyRateLimiter(settings *tabletmanagerdatapb.VReplicationCopySettings) *copyRateLimiter {
+	if settings.GetMaxRowsPerSecond() <= 0 && settings.GetMaxBytesPerSecond() <= 0 {
+		return nil
+	}
+	newLimiter := func(perSecond int64) *rate.Limiter {
+		if perSecond <= 0 {
+			return nil
+		}
+		// Allow bursts of up to one second worth of the max rate.
+		return rate.NewLimiter(rate.Limit(perSecond), int(min(perSecond, math.MaxInt32)))
+	}
+	return &copyRateLimiter{
+		rows:  newLimiter(settings.GetMaxRowsPerSecond()),
+		bytes: newLimiter(settings.GetMaxBytesPerSecond()),
+	}
+}
+
+// wait blocks until the rows can be copied without exceeding the max rate.
+func (rl *copyRateLimiter) wait(ctx context.Context, rows []*querypb.Row) error {
+	if rl == nil {
+		return nil
+	}
+	if err := waitN(ctx, rl.rows, len(rows)); err != nil {
+		return err
+	}
+	return waitN(ctx, rl.bytes, rowsSize(rows))
+}
+
+// waitN waits for n tokens of the limiter, which can be more than its burst.
+func waitN(ctx context.Context, limiter *rate.Limiter, n int) error {
+	if limiter == nil {
+		return nil
+	}
+	for n > 0 {
+		tokens := min(n, limiter.Burst())
+		if err := limiter.WaitN(ctx, tokens); err != nil {
+			return err
+		}
+		n -= tokens
+	}
+	return nil
+}
+
+func rowsSize(rows []*querypb.Row) int {
+	size := 0
+	for _, row := range rows {
+		size += len(row.Values)
+	}
+	return size
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestParseCopySettings(t *testing.T) {
+	settings, err := parseCopySettings("")
+	require.NoError(t, err)
+	require.Nil(t, settings)
+
+	settings, err = parseCopySettings(`{"tenant_id":"t1"}`)
+	require.NoError(t, err)
+	require.Nil(t, settings)
+
+	settings, err = parseCopySettings(`{"tenant_id":"t1","copy_settings":{"parallel_insert_workers":4,"max_rows_per_second":1000}}`)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), settings.ParallelInsertWorkers)
+	require.Equal(t, int64(1000), settings.MaxRowsPerSecond)
+
+	_, err = parseCopySettings(`{"copy_settings":`)
+	require.Error(t, err)
+}
+
+func TestCopyInsertParallelism(t *testing.T) {
+	oldVreplicationParallelInsertWorkers := vreplicationParallelInsertWorkers
+	defer func() {
+		vreplicationParallelInsertWorkers = oldVreplicationParallelInsertWorkers
+	}()
+	vreplicationParallelInsertWorkers = 2
+
+	vc := &vcopier{vr: &vreplicator{}}
+	require.Equal(t, 2, vc.getInsertParallelism())
+	vc.vr.copySettings = &tabletmanagerdatapb.VReplicationCopySettings{ParallelInsertWorkers: 8}
+	require.Equal(t, 8, vc.getInsertParallelism())
+}
+
+func TestCopyBatcher(t *testing.T) {
+	row := func(val string) *querypb.Row {
+		return &querypb.Row{Lengths: []int64{int64(len(val))}, Values: []byte(val)}
+	}
+	pk := func(val string) *querypb.Row {
+		return &querypb.Row{Lengths: []int64{1}, Values: []byte(val)}
+	}
+
+	require.Nil(t, newCopyBatcher(nil))
+	require.Nil(t, newCopyBatcher(&tabletmanagerdatapb.VReplicationCopySettings{MaxRowsPerSecond: 10}))
+
+	t.Run("rows", func(t *testing.T) {
+		cb := newCopyBatcher(&tabletmanagerdatapb.VReplicationCopySettings{BatchRows: 3})
+		rows, lastpk := cb.add([]*querypb.Row{row("a"), row("b")}, pk("2"))
+		require.Nil(t, rows)
+		require.Nil(t, lastpk)
+		rows, lastpk = cb.add([]*querypb.Row{row("c"), row("d")}, pk("4"))
+		require.Equal(t, []*querypb.Row{row("a"), row("b"), row("c"), row("d")}, rows)
+		require.Equal(t, pk("4"), lastpk)
+		rows, _ = cb.add([]*querypb.Row{row("e")}, pk("5"))
+		require.Nil(t, rows)
+		rows, lastpk = cb.flush()
+		require.Equal(t, []*querypb.Row{row("e")}, rows)
+		require.Equal(t, pk("5"), lastpk)
+		rows, _ = cb.flush()
+		require.Empty(t, rows)
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		cb := newCopyBatcher(&tabletmanagerdatapb.VReplicationCopySettings{BatchRows: 100, BatchBytes: 5})
+		rows, _ := cb.add([]*querypb.Row{row("abc")}, pk("1"))
+		require.Nil(t, rows)
+		rows, lastpk := cb.add([]*querypb.Row{row("de")}, pk("2"))
+		require.Equal(t, []*querypb.Row{row("abc"), row("de")}, rows)
+		require.Equal(t, pk("2"), lastpk)
+	})
+}
+
+func TestCopyRateLimiter(t *testing.T) {
+	rows := make([]*querypb.Row, 150)
+	for i := range rows {
+		rows[i] = &querypb.Row{Lengths: []int64{10}, Values: []byte("0123456789")}
+	}
+
+	var rl *copyRateLimiter
+	require.NoError(t, rl.wait(context.Background(), rows))
+	require.Nil(t, newCopyRateLimiter(&tabletmanagerdatapb.VReplicationCopySettings{BatchRows: 10}))
+
+	// The first second worth of rows is copied right away, and the rest at the
+	// max rate, even when there are more rows than that in a batch.
+	rl = newCopyRateLimiter(&tabletmanagerdatapb.VReplicationCopySettings{MaxRowsPerSecond: 100})
+	start := time.Now()
+	require.NoError(t, rl.wait(context.Background(), rows))
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	rl = newCopyRateLimiter(&tabletmanagerdatapb.VReplicationCopySettings{MaxBytesPerSecond: 1000})
+	start = time.Now()
+	require.NoError(t, rl.wait(context.Background(), rows[:100]))
+	require.Less(t, time.Since(start), 400*time.Millisecond)
+
+	// The rows can't be copied before the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.Error(t, rl.wait(ctx, rows))
+}
@@ -779,12 +779,12 @@ func (vre *Engine) WaitForPos(ctx context.Context, id int32, pos string) error {
 		switch {
 		case err != nil:
 			// We have high contention on the _vt.vreplication row, so retry if our read gets
-			// killed off by the deadlock detector and should be re-tried.
-			// The full error we get back from MySQL in that case is:
+			// killed off by the deadlock detector, or times out waiting for a lock, and should
+			// be re-tried. The full error we get back from MySQL in the deadlock case is:
 			// Deadlock found when trying to get lock; try restarting transaction (errno 1213) (sqlstate 40001)
 			// Docs: https://dev.mysql.com/doc/mysql-errors/en/server-error-reference.html#error_er_lock_deadlock
-			if sqlErr, ok := err.(*sqlerror.SQLError); ok && sqlErr.Number() == sqlerror.ERLockDeadlock {
-				log.Infof("Deadlock detected waiting for pos %s: %v; will retry", pos, err)
+			if sqlerror.ClassifyError(err) == sqlerror.ErrorClassConflict {
+				log.Infof("Transaction conflict detected waiting for pos %s: %v; will retry", pos, err)
 			} else {
 				return err
 			}
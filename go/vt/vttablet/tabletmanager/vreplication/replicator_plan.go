@@ -219,6 +219,12 @@ type TablePlan struct {
 	PartialInserts map[string]*sqlparser.ParsedQuery
 	// PartialUpdates are same as PartialInserts, but for update statements
 	PartialUpdates map[string]*sqlparser.ParsedQuery
+	// MinMaxRecalculations recalculate the min and max columns of a group
+	// when their values are removed from it.
+	MinMaxRecalculations []*minMaxRecalculation
+	// sourceRows streams the rows of the source to recalculate the min and
+	// max columns. It's set by the vplayer.
+	sourceRows sourceRowsFunc
 
 	CollationEnv *collations.Environment
 }
@@ -258,6 +264,14 @@ func (tp *TablePlan) applyBulkInsert(sqlbuffer *bytes2.Buffer, rows []*querypb.R
 		if i > 0 {
 			sqlbuffer.WriteString(", ")
 		}
+		if tp.hasTransformations() {
+			values := &strings.Builder{}
+			if err := tp.appendTransformedRow(values, row); err != nil {
+				return nil, err
+			}
+			sqlbuffer.WriteString(values.String())
+			continue
+		}
 		if err := appendFromRow(tp.BulkInsertValues, sqlbuffer, tp.Fields, row, tp.FieldsToSkip); err != nil {
 			return nil, err
 		}
@@ -389,9 +403,12 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 			var newVal *sqltypes.Value
 			var err error
 			if field.Type == querypb.Type_JSON {
-				if vals[i].IsNull() { // An SQL NULL and not an actual JSON value
+				switch {
+				case vals[i].IsNull(): // An SQL NULL and not an actual JSON value
 					newVal = &sqltypes.NULL
-				} else { // A JSON value (which may be a JSON null literal value)
+				case isPartialJSONValue(rowChange, i): // A partial JSON update of the current value
+					newVal = partialJSONValue(&vals[i], tp.partialJSONColumn(field))
+				default: // A JSON value (which may be a JSON null literal value)
 					newVal, err = vjson.MarshalSQLValue(vals[i].Raw())
 					if err != nil {
 						return nil, err
@@ -406,6 +423,9 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 			}
 			bindvars["a_"+field.Name] = bindVar
 		}
+		if err := tp.bindTransformations(bindvars, vals); err != nil {
+			return nil, err
+		}
 	}
 	switch {
 	case !before && after:
@@ -427,7 +447,14 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 		if tp.Delete == nil {
 			return nil, nil
 		}
-		return execParsedQuery(tp.Delete, bindvars, executor)
+		qr, err := execParsedQuery(tp.Delete, bindvars, executor)
+		if err != nil {
+			return nil, err
+		}
+		if err := tp.recalculateMinMax(bindvars, false, executor); err != nil {
+			return nil, err
+		}
+		return qr, nil
 	case before && after:
 		if !tp.pkChanged(bindvars) && !tp.HasExtraSourcePkColumns {
 			if tp.isPartial(rowChange) {
@@ -437,18 +464,32 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 				}
 				tp.Stats.PartialQueryCount.Add([]string{"update"}, 1)
 				return execParsedQuery(upd, bindvars, executor)
-			} else {
-				return execParsedQuery(tp.Update, bindvars, executor)
 			}
+			qr, err := execParsedQuery(tp.Update, bindvars, executor)
+			if err != nil {
+				return nil, err
+			}
+			if err := tp.recalculateMinMax(bindvars, true, executor); err != nil {
+				return nil, err
+			}
+			return qr, nil
 		}
 		if tp.Delete != nil {
 			if _, err := execParsedQuery(tp.Delete, bindvars, executor); err != nil {
 				return nil, err
 			}
+			if err := tp.recalculateMinMax(bindvars, false, executor); err != nil {
+				return nil, err
+			}
 		}
 		if tp.isOutsidePKRange(bindvars, before, after, "insert") {
 			return nil, nil
 		}
+		// The row is inserted anew, so partial JSON updates can't apply to
+		// the current value of the columns.
+		if err := tp.bindPartialJSONValuesToBefore(rowChange, bindvars); err != nil {
+			return nil, err
+		}
 		return execParsedQuery(tp.Insert, bindvars, executor)
 	}
 	// Unreachable.
@@ -555,6 +596,9 @@ func (tp *TablePlan) applyBulkInsertChanges(rowInserts []*binlogdatapb.RowChange
 			}
 			bindvars["a_"+field.Name] = bindVar
 		}
+		if err := tp.bindTransformations(bindvars, vals); err != nil {
+			return nil, err
+		}
 		if err := tp.BulkInsertValues.Append(rowValues, bindvars, nil); err != nil {
 			return nil, err
 		}
@@ -24,12 +24,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/bytes2"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
 type TestReplicatorPlan struct {
@@ -271,6 +273,58 @@ func TestBuildPlayerPlan(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		// min and max
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, min(c2) as mn, max(c3) as mx from t2 group by c1",
+			}},
+		},
+		plan: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t2",
+					Filter: "select c1, c2, c3 from t2",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t2": {
+					TargetName:   "t1",
+					SendRule:     "t2",
+					PKReferences: []string{"c1"},
+					InsertFront:  "insert into t1(c1,mn,mx)",
+					InsertValues: "(:a_c1,:a_c2,:a_c3)",
+					InsertOnDup:  " on duplicate key update mn=least(ifnull(mn, values(mn)), ifnull(values(mn), mn)), mx=greatest(ifnull(mx, values(mx)), ifnull(values(mx), mx))",
+					Insert:       "insert into t1(c1,mn,mx) values (:a_c1,:a_c2,:a_c3) on duplicate key update mn=least(ifnull(mn, values(mn)), ifnull(values(mn), mn)), mx=greatest(ifnull(mx, values(mx)), ifnull(values(mx), mx))",
+					Update:       "update t1 set mn=least(ifnull(mn, :a_c2), ifnull(:a_c2, mn)), mx=greatest(ifnull(mx, :a_c3), ifnull(:a_c3, mx)) where c1=:b_c1",
+					Delete:       "update t1 set mn=mn, mx=mx where c1=:b_c1",
+				},
+			},
+		},
+		planpk: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t2",
+					Filter: "select c1, c2, c3, pk1, pk2 from t2",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t2": {
+					TargetName:   "t1",
+					SendRule:     "t2",
+					PKReferences: []string{"c1", "pk1", "pk2"},
+					InsertFront:  "insert into t1(c1,mn,mx)",
+					InsertValues: "(:a_c1,:a_c2,:a_c3)",
+					InsertOnDup:  " on duplicate key update mn=least(ifnull(mn, values(mn)), ifnull(values(mn), mn)), mx=greatest(ifnull(mx, values(mx)), ifnull(values(mx), mx))",
+					Insert:       "insert into t1(c1,mn,mx) select :a_c1, :a_c2, :a_c3 from dual where (:a_pk1,:a_pk2) <= (1,'aaa') on duplicate key update mn=least(ifnull(mn, values(mn)), ifnull(values(mn), mn)), mx=greatest(ifnull(mx, values(mx)), ifnull(values(mx), mx))",
+					Update:       "update t1 set mn=least(ifnull(mn, :a_c2), ifnull(:a_c2, mn)), mx=greatest(ifnull(mx, :a_c3), ifnull(:a_c3, mx)) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+					Delete:       "update t1 set mn=mn, mx=mx where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+				},
+			},
+		},
 	}, {
 		// full group by
 		input: &binlogdatapb.Filter{
@@ -466,6 +520,56 @@ func TestBuildPlayerPlan(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		// column transformations are evaluated by vreplication.
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, concat(a, '-', b) as c2, c from t1",
+			}},
+		},
+		plan: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: "select c1, a, b, c from t1",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t1": {
+					TargetName:   "t1",
+					SendRule:     "t1",
+					PKReferences: []string{"c1"},
+					InsertFront:  "insert into t1(c1,c2,c)",
+					InsertValues: "(:a_c1,:e_c2,:a_c)",
+					Insert:       "insert into t1(c1,c2,c) values (:a_c1,:e_c2,:a_c)",
+					Update:       "update t1 set c2=:e_c2, c=:a_c where c1=:b_c1",
+					Delete:       "delete from t1 where c1=:b_c1",
+				},
+			},
+		},
+		planpk: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: "select c1, a, b, c, pk1, pk2 from t1",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t1": {
+					TargetName:   "t1",
+					SendRule:     "t1",
+					PKReferences: []string{"c1", "pk1", "pk2"},
+					InsertFront:  "insert into t1(c1,c2,c)",
+					InsertValues: "(:a_c1,:e_c2,:a_c)",
+					Insert:       "insert into t1(c1,c2,c) select :a_c1, :e_c2, :a_c from dual where (:a_pk1,:a_pk2) <= (1,'aaa')",
+					Update:       "update t1 set c2=:e_c2, c=:a_c where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+					Delete:       "delete from t1 where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+				},
+			},
+		},
 	}, {
 		// Keywords as names.
 		input: &binlogdatapb.Filter{
@@ -692,6 +796,33 @@ func TestBuildPlayerPlan(t *testing.T) {
 			}},
 		},
 		err: "unsupported non-column name in sum clause: sum(a + b) in query: select sum(a + b) as c from t1",
+	}, {
+		// no complex expr in min
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, min(a + b) as c from t1 group by c1",
+			}},
+		},
+		err: "unsupported non-column name in min clause: min(a + b) in query: select c1, min(a + b) as c from t1 group by c1",
+	}, {
+		// min and max need a group by
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select max(a) as c from t1",
+			}},
+		},
+		err: "min and max expressions require a group by clause: c in query: select max(a) as c from t1",
+	}, {
+		// min and max need a group by of columns
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select concat(a, b) as c1, max(c) as c from t1 group by c1",
+			}},
+		},
+		err: "group by expression of min and max expressions must be a column: c1 in query: select concat(a, b) as c1, max(c) as c from t1 group by c1",
 	}, {
 		// no complex expr in group by
 		input: &binlogdatapb.Filter{
@@ -719,6 +850,15 @@ func TestBuildPlayerPlan(t *testing.T) {
 			}},
 		},
 		err: "group by expression is not allowed to reference an aggregate expression: a in query: select count(*) as a from t1 group by a",
+	}, {
+		// primary key can't be a column transformation
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select concat(a, b) as c1 from t1",
+			}},
+		},
+		err: "primary key column c1 is not allowed to reference a transformation expression",
 	}}
 
 	PrimaryKeyInfos := map[string][]*ColumnInfo{
@@ -736,7 +876,7 @@ func TestBuildPlayerPlan(t *testing.T) {
 	}
 
 	for _, tcase := range testcases {
-		plan, err := buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+		plan, err := buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
 		gotErr := ""
 		if err != nil {
 			gotErr = err.Error()
@@ -746,7 +886,7 @@ func TestBuildPlayerPlan(t *testing.T) {
 		wantPlan, _ := json.Marshal(tcase.plan)
 		require.Equal(t, string(wantPlan), string(gotPlan), "Filter(%v):\n%s, want\n%s", tcase.input, gotPlan, wantPlan)
 
-		plan, err = buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, copyState, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+		plan, err = buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, copyState, binlogplayer.NewStats(), vtenv.NewTestEnv())
 		if err != nil {
 			continue
 		}
@@ -774,7 +914,7 @@ func TestBuildPlayerPlanNoDup(t *testing.T) {
 			Filter: "select * from t",
 		}},
 	}
-	_, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	_, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
 	want := "more than one target for source table t"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("buildReplicatorPlan err: %v, must contain: %v", err, want)
@@ -795,7 +935,7 @@ func TestBuildPlayerPlanExclude(t *testing.T) {
 			Filter: "",
 		}},
 	}
-	plan, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	plan, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
 	assert.NoError(t, err)
 
 	want := &TestReplicatorPlan{
@@ -818,3 +958,312 @@ func TestBuildPlayerPlanExclude(t *testing.T) {
 	wantPlan, _ := json.Marshal(want)
 	assert.Equal(t, string(gotPlan), string(wantPlan))
 }
+
+func TestApplyChangePartialJSON(t *testing.T) {
+	PrimaryKeyInfos := map[string][]*ColumnInfo{
+		"t1": {&ColumnInfo{Name: "id", IsPK: true}},
+	}
+	input := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select * from t1",
+		}},
+	}
+	plan, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
+	require.NoError(t, err)
+	tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{
+		TableName: "t1",
+		Fields: []*querypb.Field{
+			{Name: "id", Type: querypb.Type_INT64},
+			{Name: "j", Type: querypb.Type_JSON},
+		},
+	})
+	require.NoError(t, err)
+
+	rowChange := func(id string) *binlogdatapb.RowChange {
+		return &binlogdatapb.RowChange{
+			Before: sqltypes.RowToProto3([]sqltypes.Value{
+				sqltypes.NewInt64(1),
+				sqltypes.MakeTrusted(querypb.Type_JSON, []byte(`{"a": 1, "b": 2}`)),
+			}),
+			After: sqltypes.RowToProto3([]sqltypes.Value{
+				sqltypes.MakeTrusted(querypb.Type_INT64, []byte(id)),
+				sqltypes.MakeTrusted(sqltypes.Expression, []byte(`JSON_REMOVE(%s, _utf8mb4'$.a')`)),
+			}),
+			JsonPartialValues: &binlogdatapb.RowChange_Bitmap{
+				Count: 2,
+				Cols:  []byte{0x02},
+			},
+		}
+	}
+	var queries []string
+	executor := func(query string) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		return &sqltypes.Result{}, nil
+	}
+
+	// The partial update applies to the current value of the column.
+	_, err = tp.applyChange(rowChange("1"), executor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"update t1 set j=JSON_REMOVE(j, _utf8mb4'$.a') where id=1",
+	}, queries)
+
+	// The row moves, so the partial update applies to the before image.
+	queries = nil
+	_, err = tp.applyChange(rowChange("2"), executor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"delete from t1 where id=1",
+		"insert into t1(id,j) values (2,JSON_REMOVE(JSON_OBJECT(_utf8mb4'a', 1, _utf8mb4'b', 2), _utf8mb4'$.a'))",
+	}, queries)
+}
+
+func TestApplyChangeMinMax(t *testing.T) {
+	PrimaryKeyInfos := map[string][]*ColumnInfo{
+		"t1": {&ColumnInfo{Name: "g", IsPK: true}},
+	}
+	input := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select g, min(v) as mn, max(w) as mx, count(*) as cnt from t1 where in_keyrange(g, 'hash', '-80') group by g",
+		}},
+	}
+	plan, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
+	require.NoError(t, err)
+	fields := sqltypes.MakeTestFields("g|v|w", "int64|int64|int64")
+	tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{TableName: "t1", Fields: fields})
+	require.NoError(t, err)
+
+	// The current values of the group in the target table, and the values of
+	// the group in the source.
+	targetValues := map[string]string{}
+	var sourceValues []string
+	var queries []string
+	executor := func(query string) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		if value, ok := targetValues[query]; ok {
+			return sqltypes.MakeTestResult(sqltypes.MakeTestFields("c", "int64"), value), nil
+		}
+		return &sqltypes.Result{}, nil
+	}
+	tp.sourceRows = func(query string, send func(*binlogdatapb.VStreamRowsResponse) error) error {
+		queries = append(queries, "source: "+query)
+		result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("c", "int64"), sourceValues...)
+		return send(&binlogdatapb.VStreamRowsResponse{
+			Fields: result.Fields,
+			Rows:   sqltypes.RowsToProto3(result.Rows),
+		})
+	}
+	row := func(g, v, w int64) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(g), sqltypes.NewInt64(v), sqltypes.NewInt64(w)})
+	}
+
+	// The deleted value of v is the min of the group, so it's recalculated,
+	// but the deleted value of w isn't the max.
+	targetValues["select mn from t1 where g=1"] = "5"
+	targetValues["select mx from t1 where g=1"] = "10"
+	sourceValues = []string{"7", "null", "6"}
+	_, err = tp.applyChange(&binlogdatapb.RowChange{Before: row(1, 5, 9)}, executor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"update t1 set mn=mn, mx=mx, cnt=cnt-1 where g=1",
+		"select mn from t1 where g=1",
+		"source: select v from t1 where in_keyrange(g, 'hash', '-80') and g = 1",
+		"update t1 set mn=6 where g=1",
+		"select mx from t1 where g=1",
+	}, queries)
+
+	// The new value of v replaces the min, but the max is decreased, so it's
+	// recalculated.
+	queries = nil
+	targetValues["select mn from t1 where g=1"] = "6"
+	sourceValues = []string{"8", "3"}
+	_, err = tp.applyChange(&binlogdatapb.RowChange{Before: row(1, 6, 10), After: row(1, 4, 8)}, executor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"update t1 set mn=least(ifnull(mn, 4), ifnull(4, mn)), mx=greatest(ifnull(mx, 8), ifnull(8, mx)), cnt=cnt where g=1",
+		"select mx from t1 where g=1",
+		"source: select w from t1 where in_keyrange(g, 'hash', '-80') and g = 1",
+		"update t1 set mx=8 where g=1",
+	}, queries)
+	assert.EqualValues(t, 2, tp.Stats.MinMaxRecalculationCount.Counts()["t1"])
+}
+
+func TestApplyChangeTransformations(t *testing.T) {
+	PrimaryKeyInfos := map[string][]*ColumnInfo{
+		"t1": {&ColumnInfo{Name: "id", IsPK: true}},
+	}
+	input := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select id, concat_ws(' ', first, last) as name, json_unquote(json_extract(j, '$.color')) as color, case when n > 1 then 'many' else 'one' end as n, cast(s as signed) as s from t1",
+		}},
+	}
+	plan, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
+	require.NoError(t, err)
+	tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{
+		TableName: "t1",
+		Fields: []*querypb.Field{
+			{Name: "id", Type: querypb.Type_INT64},
+			{Name: "first", Type: querypb.Type_VARCHAR},
+			{Name: "last", Type: querypb.Type_VARCHAR},
+			{Name: "j", Type: querypb.Type_JSON},
+			{Name: "n", Type: querypb.Type_INT64},
+			{Name: "s", Type: querypb.Type_VARCHAR},
+		},
+	})
+	require.NoError(t, err)
+
+	row := func(id, last, n string) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{
+			sqltypes.MakeTrusted(querypb.Type_INT64, []byte(id)),
+			sqltypes.NewVarChar("John"),
+			sqltypes.NewVarChar(last),
+			sqltypes.MakeTrusted(querypb.Type_JSON, []byte(`{"color": "red"}`)),
+			sqltypes.MakeTrusted(querypb.Type_INT64, []byte(n)),
+			sqltypes.NewVarChar("42"),
+		})
+	}
+	var queries []string
+	executor := func(query string) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		return &sqltypes.Result{}, nil
+	}
+
+	_, err = tp.applyChange(&binlogdatapb.RowChange{After: row("1", "Doe", "1")}, executor)
+	require.NoError(t, err)
+	_, err = tp.applyChange(&binlogdatapb.RowChange{Before: row("1", "Doe", "1"), After: row("1", "Smith", "2")}, executor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"insert into t1(id,`name`,color,n,s) values (1,'John Doe','red','one',42)",
+		"update t1 set `name`='John Smith', color='red', n='many', s=42 where id=1",
+	}, queries)
+
+	// The copy phase binds the transformations by name, too.
+	queries = nil
+	_, err = tp.applyBulkInsert(&bytes2.Buffer{}, []*querypb.Row{row("1", "Doe", "1"), row("2", "Smith", "2")}, executor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"insert into t1(id,`name`,color,n,s) values (1,'John Doe','red','one',42), (2,'John Smith','red','many',42)",
+	}, queries)
+}
+
+func TestValidateFilterTransformations(t *testing.T) {
+	testcases := []struct {
+		filter string
+		err    string
+	}{{
+		filter: "select id, concat(a, b) as ab, case a when 1 then 'one' end as c, cast(a as char) as d from t1",
+	}, {
+		// not a transformation: evaluated by MySQL.
+		filter: "select id, concat(substr(a, 1, 1), b) as ab, a + 1 as c from t1",
+	}, {
+		filter: "select id, concat(a, b) from t1",
+		err:    "expression needs an alias: concat(a, b)",
+	}}
+	env := vtenv.NewTestEnv()
+	for _, tcase := range testcases {
+		t.Run(tcase.filter, func(t *testing.T) {
+			stmt, err := env.Parser().Parse(tcase.filter)
+			require.NoError(t, err)
+			err = ValidateFilterTransformations(env, stmt.(*sqlparser.Select))
+			if tcase.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tcase.err)
+		})
+	}
+}
+
+func TestApplyChangeMinMaxGroupTypes(t *testing.T) {
+	PrimaryKeyInfos := map[string][]*ColumnInfo{
+		"t1": {&ColumnInfo{Name: "g", IsPK: true}},
+	}
+	input := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select g, min(v) as mn from t1 group by g",
+		}},
+	}
+
+	tests := []struct {
+		typ   string
+		group sqltypes.Value
+		other sqltypes.Value
+		// source is the query streaming the rows of the group from the source.
+		source string
+	}{{
+		typ:    "varchar",
+		group:  sqltypes.NewVarChar("a"),
+		source: "select v from t1 where g = 'a'",
+	}, {
+		// The values which can't be filtered by the vstreamer are compared
+		// to the rows it sends.
+		typ:    "decimal",
+		group:  sqltypes.NewDecimal("1.50"),
+		other:  sqltypes.NewDecimal("1.51"),
+		source: "select v, g from t1",
+	}, {
+		typ:    "float64",
+		group:  sqltypes.NewFloat64(0.1),
+		other:  sqltypes.NewFloat64(0.2),
+		source: "select v, g from t1",
+	}, {
+		typ:    "varbinary",
+		group:  sqltypes.NewVarBinary("\x00\xff"),
+		other:  sqltypes.NewVarBinary("\x00\xfe"),
+		source: "select v, g from t1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			plan, err := buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
+			require.NoError(t, err)
+			fields := sqltypes.MakeTestFields("g|v", tt.typ+"|int64")
+			tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{TableName: "t1", Fields: fields})
+			require.NoError(t, err)
+
+			var updates []string
+			executor := func(query string) (*sqltypes.Result, error) {
+				if strings.HasPrefix(query, "select mn from t1") {
+					return sqltypes.MakeTestResult(sqltypes.MakeTestFields("mn", "int64"), "5"), nil
+				}
+				if strings.HasPrefix(query, "update t1 set mn=") && !strings.Contains(query, "mn=mn") {
+					updates = append(updates, query)
+				}
+				return &sqltypes.Result{}, nil
+			}
+			var sourceQueries []string
+			var rows [][]sqltypes.Value
+			tp.sourceRows = func(query string, send func(*binlogdatapb.VStreamRowsResponse) error) error {
+				sourceQueries = append(sourceQueries, query)
+				// The rows of the other groups are filtered out by the vstreamer,
+				// unless it sends the values of the group.
+				rowFields := []*querypb.Field{{Name: "v", Type: sqltypes.Int64}}
+				rows = [][]sqltypes.Value{{sqltypes.NewInt64(8)}, {sqltypes.NewInt64(7)}}
+				if tt.other.Type() != sqltypes.Null {
+					rowFields = append(rowFields, &querypb.Field{Name: "g", Type: tt.group.Type(), Charset: fields[0].Charset})
+					rows = [][]sqltypes.Value{
+						{sqltypes.NewInt64(8), tt.group},
+						{sqltypes.NewInt64(6), tt.other},
+						{sqltypes.NewInt64(7), tt.group},
+					}
+				}
+				return send(&binlogdatapb.VStreamRowsResponse{
+					Fields: rowFields,
+					Rows:   sqltypes.RowsToProto3(rows),
+				})
+			}
+
+			before := sqltypes.RowToProto3([]sqltypes.Value{tt.group, sqltypes.NewInt64(5)})
+			_, err = tp.applyChange(&binlogdatapb.RowChange{Before: before}, executor)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.source}, sourceQueries)
+			require.Len(t, updates, 1)
+			assert.True(t, strings.HasPrefix(updates[0], "update t1 set mn=7 where g="), updates[0])
+			assert.EqualValues(t, 1, tp.Stats.MinMaxRecalculationCount.Counts()["t1"])
+			assert.EqualValues(t, len(rows), tp.Stats.MinMaxRecalculationRows.Counts()["t1"])
+		})
+	}
+}
@@ -488,6 +488,36 @@ func (st *vrStats) register() {
 			}
 			return result
 		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationMinMaxRecalculationCount",
+		"count of recalculations of min and max aggregates from the source per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "table"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for table, count := range ct.blpStats.MinMaxRecalculationCount.Counts() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+fmt.Sprintf("%v", ct.id)+"."+table] = count
+				}
+			}
+			return result
+		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationMinMaxRecalculationRows",
+		"count of the source rows streamed to the recalculations of min and max aggregates per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "table"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for table, count := range ct.blpStats.MinMaxRecalculationRows.Counts() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+fmt.Sprintf("%v", ct.id)+"."+table] = count
+				}
+			}
+			return result
+		})
 	stats.NewCountersFuncWithMultiLabels(
 		"VReplicationPartialQueryCacheSize",
 		"cache size for partial queries per stream",
@@ -528,6 +558,22 @@ func (st *vrStats) register() {
 			return result
 		})
 
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationConflictCounts",
+		"The number of conflicts detected by reverse workflows by workflow, id, table and conflict type",
+		[]string{"workflow", "id", "table", "type"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64)
+			for _, ct := range st.controllers {
+				for key, val := range ct.blpStats.ConflictCounts.Counts() {
+					result[fmt.Sprintf("%s.%d.%s", ct.workflow, ct.id, key)] = val
+				}
+			}
+			return result
+		})
+
 	stats.NewCountersFuncWithMultiLabels(
 		"VReplicationDDLActions",
 		"vreplication DDL processing actions per stream",
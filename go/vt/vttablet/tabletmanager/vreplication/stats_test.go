@@ -194,6 +194,11 @@ func TestVReplicationStats(t *testing.T) {
 	require.Equal(t, int64(10), testStats.controllers[1].blpStats.ThrottledCounts.Counts()["tablet.vcopier"])
 	require.Equal(t, int64(80), testStats.controllers[1].blpStats.ThrottledCounts.Counts()["tablet.vplayer"])
 
+	blpStats.ConflictCounts.Add([]string{"t1", ConflictDuplicateKey}, 2)
+	blpStats.ConflictCounts.Add([]string{"t1", ConflictMissingRow}, 5)
+	require.Equal(t, int64(2), testStats.controllers[1].blpStats.ConflictCounts.Counts()["t1.duplicate_key"])
+	require.Equal(t, int64(5), testStats.controllers[1].blpStats.ConflictCounts.Counts()["t1.missing_row"])
+
 	blpStats.DDLEventActions.Add(binlogdatapb.OnDDLAction_IGNORE.String(), 4)
 	blpStats.DDLEventActions.Add(binlogdatapb.OnDDLAction_EXEC.String(), 3)
 	blpStats.DDLEventActions.Add(binlogdatapb.OnDDLAction_EXEC_IGNORE.String(), 2)
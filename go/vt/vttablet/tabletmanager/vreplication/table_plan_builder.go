@@ -29,7 +29,9 @@ import (
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vttablet"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -55,6 +57,7 @@ type tablePlanBuilder struct {
 	colExprs          []*colExpr
 	onInsert          insertType
 	pkCols            []*colExpr
+	groupCols         []*colExpr
 	extraSourcePkCols []*colExpr
 	lastpk            *sqltypes.Result
 	colInfos          []*ColumnInfo
@@ -63,6 +66,7 @@ type tablePlanBuilder struct {
 	pkIndices         []bool
 
 	collationEnv *collations.Environment
+	env          *vtenv.Environment
 }
 
 // colExpr describes the processing to be performed to
@@ -73,12 +77,17 @@ type colExpr struct {
 	// operation==opExpr: full expression is set
 	// operation==opCount: nothing is set.
 	// operation==opSum: for 'sum(a)', expr is set to 'a'.
+	// operation==opMin, opMax: for 'min(a)', expr is set to 'a'.
 	operation operation
 	// expr stores the expected field name from vstreamer and dictates
 	// the generated bindvar names, like a_col or b_col.
 	expr sqlparser.Expr
 	// references contains all the column names referenced in the expression.
 	references map[string]bool
+	// evalExpr is set for column transformations, which are evaluated by the
+	// evalengine rather than by MySQL. expr is then the bind variable the
+	// evaluated value is bound to.
+	evalExpr evalengine.Expr
 
 	isGrouped  bool
 	isPK       bool
@@ -94,6 +103,8 @@ const (
 	opExpr = operation(iota)
 	opCount
 	opSum
+	opMin
+	opMax
 )
 
 // insertType describes the type of insert statement to generate.
@@ -132,7 +143,7 @@ const (
 // The TablePlan built is a partial plan. The full plan for a table is built
 // when we receive field information from events or rows sent by the source.
 // buildExecutionPlan is the function that builds the full plan.
-func buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[string][]*ColumnInfo, copyState map[string]*sqltypes.Result, stats *binlogplayer.Stats, collationEnv *collations.Environment, parser *sqlparser.Parser) (*ReplicatorPlan, error) {
+func buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[string][]*ColumnInfo, copyState map[string]*sqltypes.Result, stats *binlogplayer.Stats, env *vtenv.Environment) (*ReplicatorPlan, error) {
 	filter := source.Filter
 	plan := &ReplicatorPlan{
 		VStreamFilter: &binlogdatapb.Filter{FieldEventMode: filter.FieldEventMode},
@@ -141,7 +152,7 @@ func buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[strin
 		ColInfoMap:    colInfoMap,
 		stats:         stats,
 		Source:        source,
-		collationEnv:  collationEnv,
+		collationEnv:  env.CollationEnv(),
 	}
 	for tableName := range colInfoMap {
 		lastpk, ok := copyState[tableName]
@@ -160,7 +171,7 @@ func buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[strin
 		if !ok {
 			return nil, fmt.Errorf("table %s not found in schema", tableName)
 		}
-		tablePlan, err := buildTablePlan(tableName, rule, colInfos, lastpk, stats, source, collationEnv, parser)
+		tablePlan, err := buildTablePlan(tableName, rule, colInfos, lastpk, stats, source, env)
 		if err != nil {
 			return nil, err
 		}
@@ -200,7 +211,7 @@ func MatchTable(tableName string, filter *binlogdatapb.Filter) (*binlogdatapb.Ru
 }
 
 func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*ColumnInfo, lastpk *sqltypes.Result,
-	stats *binlogplayer.Stats, source *binlogdatapb.BinlogSource, collationEnv *collations.Environment, parser *sqlparser.Parser) (*TablePlan, error) {
+	stats *binlogplayer.Stats, source *binlogdatapb.BinlogSource, env *vtenv.Environment) (*TablePlan, error) {
 
 	planError := func(err error, query string) error {
 		// Use the error string here to ensure things are uniform across
@@ -223,7 +234,7 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 	case filter == ExcludeStr:
 		return nil, nil
 	}
-	sel, fromTable, err := analyzeSelectFrom(query, parser)
+	sel, fromTable, err := analyzeSelectFrom(query, env.Parser())
 	if err != nil {
 		return nil, planError(err, query)
 	}
@@ -255,7 +266,7 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 			EnumValuesMap:    enumValuesMap,
 			ConvertCharset:   rule.ConvertCharset,
 			ConvertIntToEnum: rule.ConvertIntToEnum,
-			CollationEnv:     collationEnv,
+			CollationEnv:     env.CollationEnv(),
 		}
 
 		return tablePlan, nil
@@ -271,7 +282,8 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 		colInfos:     colInfos,
 		stats:        stats,
 		source:       source,
-		collationEnv: collationEnv,
+		collationEnv: env.CollationEnv(),
+		env:          env,
 	}
 
 	if err := tpb.analyzeExprs(sel.SelectExprs); err != nil {
@@ -386,6 +398,7 @@ func (tpb *tablePlanBuilder) generate() *TablePlan {
 		TablePlanBuilder:        tpb,
 		PartialInserts:          make(map[string]*sqlparser.ParsedQuery, 0),
 		PartialUpdates:          make(map[string]*sqlparser.ParsedQuery, 0),
+		MinMaxRecalculations:    tpb.generateMinMaxRecalculations(),
 		CollationEnv:            tpb.collationEnv,
 	}
 }
@@ -498,18 +511,25 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 			}
 			cexpr.operation = opCount
 			return cexpr, nil
-		case "sum":
+		case "sum", "min", "max":
 			if len(expr.GetArgs()) != 1 {
-				return nil, fmt.Errorf("unsupported multiple columns in sum clause: %v", sqlparser.String(expr))
+				return nil, fmt.Errorf("unsupported multiple columns in %s clause: %v", fname, sqlparser.String(expr))
 			}
 			innerCol, ok := expr.GetArg().(*sqlparser.ColName)
 			if !ok {
-				return nil, fmt.Errorf("unsupported non-column name in sum clause: %v", sqlparser.String(expr))
+				return nil, fmt.Errorf("unsupported non-column name in %s clause: %v", fname, sqlparser.String(expr))
 			}
 			if !innerCol.Qualifier.IsEmpty() {
 				return nil, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(innerCol))
 			}
-			cexpr.operation = opSum
+			switch fname {
+			case "sum":
+				cexpr.operation = opSum
+			case "min":
+				cexpr.operation = opMin
+			case "max":
+				cexpr.operation = opMax
+			}
 			cexpr.expr = innerCol
 			tpb.addCol(innerCol.Name)
 			cexpr.references[innerCol.Name.String()] = true
@@ -535,6 +555,14 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 		return nil, err
 	}
 	cexpr.expr = aliased.Expr
+	if isTransformation(aliased.Expr) {
+		evalExpr, err := translateTransformation(tpb.env, aliased.Expr)
+		if err != nil {
+			return nil, err
+		}
+		cexpr.evalExpr = evalExpr
+		cexpr.expr = sqlparser.NewArgument(transformationBindVar(as))
+	}
 	return cexpr, nil
 }
 
@@ -549,6 +577,9 @@ func (tpb *tablePlanBuilder) addCol(ident sqlparser.IdentifierCI) {
 func (tpb *tablePlanBuilder) analyzeGroupBy(groupBy sqlparser.GroupBy) error {
 	if groupBy == nil {
 		// If there's no grouping, the it's an insertNormal.
+		if cexpr := tpb.findMinMax(); cexpr != nil {
+			return fmt.Errorf("min and max expressions require a group by clause: %v", cexpr.colName)
+		}
 		return nil
 	}
 	for _, expr := range groupBy {
@@ -564,6 +595,16 @@ func (tpb *tablePlanBuilder) analyzeGroupBy(groupBy sqlparser.GroupBy) error {
 			return fmt.Errorf("group by expression is not allowed to reference an aggregate expression: %v", sqlparser.String(expr))
 		}
 		cexpr.isGrouped = true
+		tpb.groupCols = append(tpb.groupCols, cexpr)
+	}
+	if tpb.findMinMax() != nil {
+		// The rows of a group are selected from the source by the values of
+		// its columns when its min or max value is recalculated.
+		for _, cexpr := range tpb.groupCols {
+			if _, ok := cexpr.expr.(*sqlparser.ColName); !ok || cexpr.evalExpr != nil {
+				return fmt.Errorf("group by expression of min and max expressions must be a column: %v", cexpr.colName)
+			}
+		}
 	}
 	// If all colExprs are grouped, then it's an insertIgnore.
 	tpb.onInsert = insertIgnore
@@ -612,6 +653,9 @@ func (tpb *tablePlanBuilder) analyzePK(cols []*ColumnInfo) error {
 		if cexpr.operation != opExpr {
 			return fmt.Errorf("primary key column %v is not allowed to reference an aggregate expression", col)
 		}
+		if cexpr.evalExpr != nil {
+			return fmt.Errorf("primary key column %v is not allowed to reference a transformation expression", col.Name)
+		}
 		cexpr.isPK = true
 		cexpr.dataType = col.DataType
 		cexpr.columnType = col.ColumnType
@@ -671,6 +715,16 @@ func (tpb *tablePlanBuilder) findCol(name sqlparser.IdentifierCI) *colExpr {
 	return findCol(name, tpb.colExprs)
 }
 
+// findMinMax returns the first min or max expression, if any.
+func (tpb *tablePlanBuilder) findMinMax() *colExpr {
+	for _, cexpr := range tpb.colExprs {
+		if cexpr.operation == opMin || cexpr.operation == opMax {
+			return cexpr
+		}
+	}
+	return nil
+}
+
 func (tpb *tablePlanBuilder) generateInsertStatement() *sqlparser.ParsedQuery {
 	bvf := &bindvarFormatter{}
 	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
@@ -738,6 +792,8 @@ func (tpb *tablePlanBuilder) generateValuesPart(buf *sqlparser.TrackedBuffer, bv
 		case opSum:
 			// NULL values must be treated as 0 for SUM.
 			buf.Myprintf("ifnull(%v, 0)", cexpr.expr)
+		case opMin, opMax:
+			buf.Myprintf("%v", cexpr.expr)
 		}
 	}
 	buf.Myprintf(")")
@@ -761,6 +817,8 @@ func (tpb *tablePlanBuilder) generateSelectPart(buf *sqlparser.TrackedBuffer, bv
 			buf.WriteString("1")
 		case opSum:
 			buf.Myprintf("ifnull(%v, 0)", cexpr.expr)
+		case opMin, opMax:
+			buf.Myprintf("%v", cexpr.expr)
 		}
 	}
 	buf.WriteString(" from dual where ")
@@ -798,6 +856,10 @@ func (tpb *tablePlanBuilder) generateOnDupPart(buf *sqlparser.TrackedBuffer) *sq
 		case opSum:
 			buf.Myprintf("%v", cexpr.colName)
 			buf.Myprintf("+ifnull(values(%v), 0)", cexpr.colName)
+		case opMin, opMax:
+			// NULL values are ignored by MIN and MAX.
+			buf.Myprintf("%s(ifnull(%v, values(%v)), ifnull(values(%v), %v))",
+				minMaxFunc(cexpr.operation), cexpr.colName, cexpr.colName, cexpr.colName, cexpr.colName)
 		}
 	}
 	return buf.ParsedQuery()
@@ -849,6 +911,13 @@ func (tpb *tablePlanBuilder) generateUpdateStatement() *sqlparser.ParsedQuery {
 			buf.Myprintf("-ifnull(%v, 0)", cexpr.expr)
 			bvf.mode = bvAfter
 			buf.Myprintf("+ifnull(%v, 0)", cexpr.expr)
+		case opMin, opMax:
+			// The new value is applied here. If the old value was the min or
+			// max value of the group, it's recalculated after the update.
+			bvf.mode = bvAfter
+			buf.Myprintf("%s(ifnull(%v, ", minMaxFunc(cexpr.operation), cexpr.colName)
+			buf.Myprintf("%v), ifnull(", cexpr.expr)
+			buf.Myprintf("%v, %v))", cexpr.expr, cexpr.colName)
 		}
 	}
 	tpb.generateWhere(buf, bvf)
@@ -879,6 +948,10 @@ func (tpb *tablePlanBuilder) generateDeleteStatement() *sqlparser.ParsedQuery {
 				buf.Myprintf("%v-1", cexpr.colName)
 			case opSum:
 				buf.Myprintf("%v-ifnull(%v, 0)", cexpr.colName, cexpr.expr)
+			case opMin, opMax:
+				// If the deleted value was the min or max value of the group,
+				// it's recalculated after the delete.
+				buf.Myprintf("%v", cexpr.colName)
 			}
 		}
 		tpb.generateWhere(buf, bvf)
@@ -890,7 +963,7 @@ func (tpb *tablePlanBuilder) generateDeleteStatement() *sqlparser.ParsedQuery {
 
 func (tpb *tablePlanBuilder) generateMultiDeleteStatement() *sqlparser.ParsedQuery {
 	if vttablet.VReplicationExperimentalFlags&vttablet.VReplicationExperimentalFlagVPlayerBatching == 0 ||
-		(len(tpb.pkCols)+len(tpb.extraSourcePkCols)) != 1 {
+		(len(tpb.pkCols)+len(tpb.extraSourcePkCols)) != 1 || tpb.onInsert != insertNormal {
 		return nil
 	}
 	return sqlparser.BuildParsedQuery("delete from %s where %s in %a",
@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The MIN and MAX aggregates of a rollup, e.g. "select g, min(a) as mn, max(a) as mx from t group by g",
+// are maintained incrementally: the value of a new or updated row is applied to its group
+// with LEAST or GREATEST. But when the current min or max value of a group is removed from
+// it, by a delete or an update of a row, the new value can't be derived from the target
+// table, so it's recalculated from the rows of the group on the source, which are streamed
+// with VStreamRows. The rows are streamed at the current position of the source rather than
+// at the position of the change, so the recalculated value can already include the changes
+// which follow it. That's harmless: applying a value to a min or max again doesn't change it,
+// and a value which is removed again is recalculated again.
+//
+// VStreamRows can't use an index to select the rows of the group: each recalculation reads
+// all the rows of the source table, and the vstreamer only sends the ones of the group. So
+// the rollups with MIN or MAX fit the tables whose rows are seldom deleted, or whose min and
+// max values are seldom removed from their groups. The recalculations, and the rows streamed
+// to them, are counted in the VReplicationMinMaxRecalculationCount and
+// VReplicationMinMaxRecalculationRows metrics.
+
+// recalculatedBindVar is the bind variable of a recalculated min or max value.
+const recalculatedBindVar = "recalculated"
+
+// sourceRowsFunc streams the rows of a query from the source of the stream.
+type sourceRowsFunc func(query string, send func(*binlogdatapb.VStreamRowsResponse) error) error
+
+// minMaxRecalculation is the plan to recalculate a min or max column of a group.
+type minMaxRecalculation struct {
+	cexpr *colExpr
+	// Select selects the current value of the column, in the group of the
+	// before image of a row.
+	Select *sqlparser.ParsedQuery
+	// Update sets the recalculated value of the column, in the group of the
+	// before image of a row.
+	Update *sqlparser.ParsedQuery
+	// sourceSelect selects the values of the column on the source. The
+	// conditions which select the rows of the group are added to it.
+	sourceSelect *sqlparser.Select
+}
+
+// minMaxFunc returns the function which applies a value to a min or max.
+func minMaxFunc(op operation) string {
+	if op == opMin {
+		return "least"
+	}
+	return "greatest"
+}
+
+// generateMinMaxRecalculations generates the recalculation plans of the min
+// and max columns.
+func (tpb *tablePlanBuilder) generateMinMaxRecalculations() []*minMaxRecalculation {
+	var recalcs []*minMaxRecalculation
+	for _, cexpr := range tpb.colExprs {
+		if cexpr.operation != opMin && cexpr.operation != opMax {
+			continue
+		}
+		bvf := &bindvarFormatter{}
+		buf := sqlparser.NewTrackedBuffer(bvf.formatter)
+		buf.Myprintf("select %v from %v", cexpr.colName, tpb.name)
+		tpb.generateWhere(buf, bvf)
+		sel := buf.ParsedQuery()
+
+		bvf = &bindvarFormatter{}
+		buf = sqlparser.NewTrackedBuffer(bvf.formatter)
+		buf.Myprintf("update %v set %v=%v", tpb.name, cexpr.colName, sqlparser.NewArgument(recalculatedBindVar))
+		tpb.generateWhere(buf, bvf)
+
+		recalcs = append(recalcs, &minMaxRecalculation{
+			cexpr:  cexpr,
+			Select: sel,
+			Update: buf.ParsedQuery(),
+			sourceSelect: &sqlparser.Select{
+				SelectExprs: sqlparser.SelectExprs{&sqlparser.AliasedExpr{Expr: cexpr.expr}},
+				From:        tpb.sendSelect.From,
+				Where:       tpb.sendSelect.Where,
+			},
+		})
+	}
+	return recalcs
+}
+
+// recalculateMinMax recalculates the min and max columns of the group of the
+// before image of a row, if its values were the min or max values of the group.
+// bindvars are the bind variables of the row. If updated is true, the row was
+// updated within its group, and the values of its after image were applied.
+func (tp *TablePlan) recalculateMinMax(bindvars map[string]*querypb.BindVariable, updated bool, executor func(string) (*sqltypes.Result, error)) error {
+	for _, recalc := range tp.MinMaxRecalculations {
+		colName := recalc.cexpr.expr.(*sqlparser.ColName).Name.String()
+		collation := tp.fieldCollation(colName)
+		before, err := sqltypes.BindVariableToValue(bindvars["b_"+colName])
+		if err != nil {
+			return err
+		}
+		if before.IsNull() {
+			continue
+		}
+		if updated {
+			after, err := sqltypes.BindVariableToValue(bindvars["a_"+colName])
+			if err != nil {
+				return err
+			}
+			if !after.IsNull() {
+				cmp, err := evalengine.NullsafeCompare(after, before, tp.CollationEnv, collation, nil)
+				if err != nil {
+					return err
+				}
+				// The new value of the row replaced its old value, if it was the
+				// min or max of the group.
+				if minMaxPrecedes(recalc.cexpr.operation, cmp) || cmp == 0 {
+					continue
+				}
+			}
+		}
+		qr, err := execParsedQuery(recalc.Select, bindvars, executor)
+		if err != nil {
+			return err
+		}
+		// The group may not have been copied yet.
+		if len(qr.Rows) != 1 || qr.Rows[0][0].IsNull() {
+			continue
+		}
+		cmp, err := evalengine.NullsafeCompare(qr.Rows[0][0], before, tp.CollationEnv, collation, nil)
+		if err != nil {
+			return err
+		}
+		if cmp != 0 {
+			continue
+		}
+		value, err := tp.recalculate(recalc, bindvars)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to recalculate column %v of table %s", recalc.cexpr.colName, tp.TargetName)
+		}
+		bindvars[recalculatedBindVar] = sqltypes.ValueBindVariable(value)
+		if _, err := execParsedQuery(recalc.Update, bindvars, executor); err != nil {
+			return err
+		}
+		tp.Stats.MinMaxRecalculationCount.Add(tp.TargetName, 1)
+	}
+	return nil
+}
+
+// recalculate returns the min or max value of the rows of the group of the
+// before image of a row on the source.
+func (tp *TablePlan) recalculate(recalc *minMaxRecalculation, bindvars map[string]*querypb.BindVariable) (sqltypes.Value, error) {
+	if tp.sourceRows == nil {
+		return sqltypes.NULL, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the rows of the source can't be streamed")
+	}
+	sel := sqlparser.CloneRefOfSelect(recalc.sourceSelect)
+	// The vstreamer only supports integer and string literals in filters, and
+	// compares them to the values of the rows as such. The other values of the
+	// group, like decimals, floats or binary strings, are selected instead, and
+	// compared to the values of the rows sent by the vstreamer.
+	var matches []sqltypes.Value
+	for _, cexpr := range tp.TablePlanBuilder.groupCols {
+		col := cexpr.expr.(*sqlparser.ColName)
+		val, err := sqltypes.BindVariableToValue(bindvars["b_"+col.Name.String()])
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		switch {
+		case val.IsNull():
+			sel.AddWhere(&sqlparser.IsExpr{Left: col, Right: sqlparser.IsNullOp})
+		case val.IsIntegral():
+			sel.AddWhere(&sqlparser.ComparisonExpr{Operator: sqlparser.EqualOp, Left: col, Right: sqlparser.NewIntLiteral(val.ToString())})
+		case val.IsText():
+			sel.AddWhere(&sqlparser.ComparisonExpr{Operator: sqlparser.EqualOp, Left: col, Right: sqlparser.NewStrLiteral(val.ToString())})
+		default:
+			sel.SelectExprs = append(sel.SelectExprs, &sqlparser.AliasedExpr{Expr: col})
+			matches = append(matches, val)
+		}
+	}
+
+	op := recalc.cexpr.operation
+	result := sqltypes.NULL
+	var fields []*querypb.Field
+	err := tp.sourceRows(sqlparser.String(sel), func(resp *binlogdatapb.VStreamRowsResponse) error {
+		if len(resp.Fields) != 0 {
+			fields = resp.Fields
+		}
+		tp.Stats.MinMaxRecalculationRows.Add(tp.TargetName, int64(len(resp.Rows)))
+	rows:
+		for _, row := range resp.Rows {
+			values := sqltypes.MakeRowTrusted(fields, row)
+			for i, match := range matches {
+				cmp, err := evalengine.NullsafeCompare(values[i+1], match, tp.CollationEnv, collations.ID(fields[i+1].Charset), nil)
+				if err != nil {
+					return err
+				}
+				if cmp != 0 {
+					continue rows
+				}
+			}
+			val := values[0]
+			if val.IsNull() {
+				continue
+			}
+			if result.IsNull() {
+				result = val
+				continue
+			}
+			cmp, err := evalengine.NullsafeCompare(val, result, tp.CollationEnv, collations.ID(fields[0].Charset), nil)
+			if err != nil {
+				return err
+			}
+			if minMaxPrecedes(op, cmp) {
+				result = val
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// minMaxPrecedes returns true if a value compared to another one, with the
+// result cmp, precedes it in a min or max.
+func minMaxPrecedes(op operation, cmp int) bool {
+	if op == opMin {
+		return cmp < 0
+	}
+	return cmp > 0
+}
+
+// fieldCollation returns the collation of a field of the source.
+func (tp *TablePlan) fieldCollation(name string) collations.ID {
+	for _, field := range tp.Fields {
+		if field.Name == name {
+			return collations.ID(field.Charset)
+		}
+	}
+	return collations.Unknown
+}
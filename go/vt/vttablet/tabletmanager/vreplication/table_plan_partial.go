@@ -18,6 +18,11 @@ package vreplication
 
 import (
 	"fmt"
+	"strings"
+
+	mysqlbinlog "vitess.io/vitess/go/mysql/binlog"
+	vjson "vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -207,3 +212,61 @@ func (tp *TablePlan) getPartialUpdateQuery(dataColumns *binlogdatapb.RowChange_B
 	tp.Stats.PartialQueryCacheSize.Add([]string{"update"}, 1)
 	return upd, nil
 }
+
+// isPartialJSONValue returns true if the value at index in the after image is
+// a partial JSON update rather than a full JSON document. This is the case
+// when the source uses binlog_row_value_options=PARTIAL_JSON.
+func isPartialJSONValue(rowChange *binlogdatapb.RowChange, index int) bool {
+	jsonPartialValues := rowChange.JsonPartialValues
+	return jsonPartialValues != nil && int64(index) < jsonPartialValues.Count && isBitSet(jsonPartialValues.Cols, index)
+}
+
+// partialJSONValue returns the new value of a JSON column as the expression
+// that applies the partial JSON update to the value of base.
+func partialJSONValue(update *sqltypes.Value, base string) *sqltypes.Value {
+	expr := strings.Replace(update.RawStr(), mysqlbinlog.JSONDiffPlaceholder, base, 1)
+	val := sqltypes.MakeTrusted(querypb.Type_JSON, []byte(expr))
+	return &val
+}
+
+// partialJSONColumn returns the column of the target table that a partial
+// JSON update of the field applies to.
+func (tp *TablePlan) partialJSONColumn(field *querypb.Field) string {
+	if tp.TablePlanBuilder != nil {
+		for _, cexpr := range tp.TablePlanBuilder.colExprs {
+			if col, ok := cexpr.expr.(*sqlparser.ColName); ok && col.Name.EqualString(field.Name) {
+				return sqlparser.String(cexpr.colName)
+			}
+		}
+	}
+	return sqlparser.String(sqlparser.NewIdentifierCI(field.Name))
+}
+
+// bindPartialJSONValuesToBefore binds the partial JSON updates of the after
+// image as expressions that apply them to the values of the before image.
+func (tp *TablePlan) bindPartialJSONValuesToBefore(rowChange *binlogdatapb.RowChange, bindvars map[string]*querypb.BindVariable) error {
+	if rowChange.JsonPartialValues == nil {
+		return nil
+	}
+	before := sqltypes.MakeRowTrusted(tp.Fields, rowChange.Before)
+	after := sqltypes.MakeRowTrusted(tp.Fields, rowChange.After)
+	for i, field := range tp.Fields {
+		if field.Type != querypb.Type_JSON || after[i].IsNull() || !isPartialJSONValue(rowChange, i) {
+			continue
+		}
+		base := sqltypes.NullStr
+		if !before[i].IsNull() {
+			val, err := vjson.MarshalSQLValue(before[i].Raw())
+			if err != nil {
+				return err
+			}
+			base = val.RawStr()
+		}
+		bindVar, err := tp.bindFieldVal(field, partialJSONValue(&after[i], base))
+		if err != nil {
+			return err
+		}
+		bindvars["a_"+field.Name] = bindVar
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	vjson "vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Column transformations are filter expressions which vreplication evaluates
+// with the evalengine on the target, e.g. "select id, concat(first, ' ', last) as name from t".
+// Only a vetted subset of expressions are transformations: CONCAT, CONCAT_WS, CASE,
+// JSON_EXTRACT, JSON_UNQUOTE and type casts, made of columns, literals and other
+// vetted expressions. Any other expression is evaluated by MySQL as part of the DML.
+
+// isTransformationFunc returns true if the function is vetted for transformations.
+func isTransformationFunc(funcExpr *sqlparser.FuncExpr) bool {
+	if !funcExpr.Qualifier.IsEmpty() {
+		return false
+	}
+	switch funcExpr.Name.Lowered() {
+	case "concat", "concat_ws":
+		return true
+	}
+	return false
+}
+
+// isTransformation returns true if the filter expression is a column transformation.
+func isTransformation(expr sqlparser.Expr) bool {
+	switch expr := expr.(type) {
+	case *sqlparser.FuncExpr:
+		if !isTransformationFunc(expr) {
+			return false
+		}
+	case *sqlparser.CaseExpr, *sqlparser.JSONExtractExpr, *sqlparser.JSONUnquoteExpr, *sqlparser.CastExpr, *sqlparser.ConvertExpr:
+	default:
+		return false
+	}
+	vetted := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName, *sqlparser.Literal, *sqlparser.NullVal, sqlparser.BoolVal,
+			*sqlparser.CaseExpr, *sqlparser.JSONExtractExpr, *sqlparser.JSONUnquoteExpr, *sqlparser.CastExpr, *sqlparser.ConvertExpr,
+			*sqlparser.ComparisonExpr, *sqlparser.AndExpr, *sqlparser.OrExpr, *sqlparser.NotExpr, *sqlparser.IsExpr:
+		case *sqlparser.FuncExpr:
+			if !isTransformationFunc(node) {
+				vetted = false
+			}
+		case sqlparser.Expr:
+			vetted = false
+		}
+		return vetted, nil
+	}, expr)
+	return vetted
+}
+
+// transformationBindVar returns the name of the bind variable the evaluated
+// value of a transformation is bound to.
+func transformationBindVar(colName sqlparser.IdentifierCI) string {
+	return "e_" + colName.String()
+}
+
+// translateTransformation translates a column transformation for the evalengine.
+// The columns it references are replaced by the bind variables of the after
+// image of the row, e.g. c1 by :a_c1.
+func translateTransformation(env *vtenv.Environment, expr sqlparser.Expr) (evalengine.Expr, error) {
+	boundExpr := sqlparser.CopyOnRewrite(expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		if col, ok := cursor.Node().(*sqlparser.ColName); ok {
+			cursor.Replace(sqlparser.NewArgument("a_" + col.Name.String()))
+		}
+	}, nil).(sqlparser.Expr)
+	evalExpr, err := evalengine.Translate(boundExpr, &evalengine.Config{
+		Collation:   env.CollationEnv().DefaultConnectionCharset(),
+		Environment: env,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unsupported transformation expression: %v: %v", sqlparser.String(expr), err)
+	}
+	return evalExpr, nil
+}
+
+// ValidateFilterTransformations validates the column transformations of a
+// filter query. It's used when creating a workflow, so that a transformation
+// the evalengine can't evaluate fails the workflow creation rather than the
+// streams on the target.
+func ValidateFilterTransformations(env *vtenv.Environment, sel *sqlparser.Select) error {
+	for _, selExpr := range sel.SelectExprs {
+		aliased, ok := selExpr.(*sqlparser.AliasedExpr)
+		if !ok || !isTransformation(aliased.Expr) {
+			continue
+		}
+		if aliased.As.IsEmpty() {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "expression needs an alias: %v", sqlparser.String(aliased))
+		}
+		if _, err := translateTransformation(env, aliased.Expr); err != nil {
+			return vterrors.Wrapf(err, "invalid filter %v", sqlparser.String(sel))
+		}
+	}
+	return nil
+}
+
+// hasTransformations returns true if the plan has column transformations.
+func (tp *TablePlan) hasTransformations() bool {
+	if tp.TablePlanBuilder == nil {
+		return false
+	}
+	for _, cexpr := range tp.TablePlanBuilder.colExprs {
+		if cexpr.evalExpr != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// bindTransformations evaluates the column transformations of the plan on the
+// after image of a row, and binds their values. bindvars are the bind variables
+// of the row and vals are its values.
+func (tp *TablePlan) bindTransformations(bindvars map[string]*querypb.BindVariable, vals []sqltypes.Value) error {
+	if !tp.hasTransformations() {
+		return nil
+	}
+	evalBindVars := make(map[string]*querypb.BindVariable, len(tp.Fields))
+	for i, field := range tp.Fields {
+		if field.Type == querypb.Type_JSON {
+			// The bind variable of a JSON value is its SQL representation, but
+			// the transformation is evaluated on the JSON document itself.
+			evalBindVars["a_"+field.Name] = sqltypes.ValueBindVariable(vals[i])
+			continue
+		}
+		evalBindVars["a_"+field.Name] = bindvars["a_"+field.Name]
+	}
+	tpb := tp.TablePlanBuilder
+	env := evalengine.NewExpressionEnv(context.Background(), evalBindVars, evalengine.NewEmptyVCursor(tpb.env, time.Local))
+	collation := tpb.env.CollationEnv().DefaultConnectionCharset()
+	for _, cexpr := range tpb.colExprs {
+		if cexpr.evalExpr == nil {
+			continue
+		}
+		result, err := env.Evaluate(cexpr.evalExpr)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to evaluate the transformation of column %v", cexpr.colName)
+		}
+		bindvars[transformationBindVar(cexpr.colName)] = sqltypes.ValueBindVariable(result.Value(collation))
+	}
+	return nil
+}
+
+// appendTransformedRow appends the values of a row of the copy phase to a bulk
+// insert, evaluating the column transformations of the plan. Unlike appendFromRow,
+// it binds the values by name, as transformations don't map to the fields of the
+// row by position.
+func (tp *TablePlan) appendTransformedRow(buf *strings.Builder, row *querypb.Row) error {
+	vals := sqltypes.MakeRowTrusted(tp.Fields, row)
+	bindvars := make(map[string]*querypb.BindVariable, len(tp.Fields))
+	for i, field := range tp.Fields {
+		val := &vals[i]
+		if field.Type == querypb.Type_JSON && !val.IsNull() {
+			jsonVal, err := vjson.MarshalSQLValue(val.Raw())
+			if err != nil {
+				return err
+			}
+			val = jsonVal
+		}
+		bindVar, err := tp.bindFieldVal(field, val)
+		if err != nil {
+			return err
+		}
+		bindvars["a_"+field.Name] = bindVar
+	}
+	if err := tp.bindTransformations(bindvars, vals); err != nil {
+		return err
+	}
+	return tp.BulkInsertValues.Append(buf, bindvars, nil)
+}
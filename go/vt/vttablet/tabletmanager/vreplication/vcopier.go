@@ -221,7 +221,7 @@ func newVCopierCopyWorker(
 func (vc *vcopier) initTablesForCopy(ctx context.Context) error {
 	defer vc.vr.dbClient.Rollback()
 
-	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env.CollationEnv(), vc.vr.vre.env.Parser())
+	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env)
 	if err != nil {
 		return err
 	}
@@ -382,7 +382,7 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 
 	log.Infof("Copying table %s, lastpk: %v", tableName, copyState[tableName])
 
-	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env.CollationEnv(), vc.vr.vre.env.Parser())
+	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env)
 	if err != nil {
 		return err
 	}
@@ -405,10 +405,12 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	copyStateGCTicker := time.NewTicker(copyStateGCInterval)
 	defer copyStateGCTicker.Stop()
 
-	parallelism := getInsertParallelism()
+	parallelism := vc.getInsertParallelism()
 	copyWorkerFactory := vc.newCopyWorkerFactory(parallelism)
 	copyWorkQueue := vc.newCopyWorkQueue(parallelism, copyWorkerFactory)
 	defer copyWorkQueue.close()
+	batcher := newCopyBatcher(vc.vr.copySettings)
+	rateLimiter := newCopyRateLimiter(vc.vr.copySettings)
 
 	// Allocate a result channel to collect results from tasks.
 	resultCh := make(chan *vcopierCopyTaskResult, parallelism*4)
@@ -420,6 +422,102 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	// Use this for task sequencing.
 	var prevCh <-chan *vcopierCopyTaskResult
 
+	// copyRows copies a batch of rows with the next task, in the order of the
+	// batches.
+	copyRows := func(batch []*querypb.Row, batchLastpk *querypb.Row) error {
+		if err := rateLimiter.wait(ctx, batch); err != nil {
+			// The rows can't be copied before the copy phase duration elapses.
+			<-ctx.Done()
+			return io.EOF
+		}
+
+		// Code below is copied from vcopier.go. It was implemented to facilitate
+		// parallel bulk inserts in https://github.com/vitessio/vitess/pull/10828.
+		// We can probably extract this into a common package and use it for both
+		// flavors of the vcopier. But cut/pasting it for now, so as to not change
+		// vcopier at the moment to avoid any regressions.
+
+		// Prepare a vcopierCopyTask for the current batch of work.
+		// TODO(maxeng) see if using a pre-allocated pool will speed things up.
+		currCh := make(chan *vcopierCopyTaskResult, 1)
+		currT := newVCopierCopyTask(newVCopierCopyTaskArgs(batch, batchLastpk))
+
+		// Send result to the global resultCh and currCh. resultCh is used by
+		// the loop to return results to VStreamRows. currCh will be used to
+		// sequence the start of the nextT.
+		currT.lifecycle.onResult().sendTo(currCh)
+		currT.lifecycle.onResult().sendTo(resultCh)
+
+		// Use prevCh to Sequence the prevT with the currT so that:
+		// * The prevT is completed before we begin updating
+		//   _vt.copy_state for currT.
+		// * If prevT fails or is canceled, the current task is
+		//   canceled.
+		// prevCh is nil only for the first task in the vcopier run.
+		if prevCh != nil {
+			// prevT publishes to prevCh, and currT is the only thing that can
+			// consume from prevCh. If prevT is already done, then prevCh will
+			// have a value in it. If prevT isn't yet done, then prevCh will
+			// have a value later. Either way, AwaitCompletion should
+			// eventually get a value, unless there is a context expiry.
+			currT.lifecycle.before(vcopierCopyTaskInsertCopyState).awaitCompletion(prevCh)
+		}
+
+		// Store currCh in prevCh. The nextT will use this for sequencing.
+		prevCh = currCh
+
+		// Update stats after task is done.
+		currT.lifecycle.onResult().do(func(_ context.Context, result *vcopierCopyTaskResult) {
+			if result.state == vcopierCopyTaskFail {
+				vc.vr.stats.ErrorCounts.Add([]string{"Copy"}, 1)
+			}
+			if result.state == vcopierCopyTaskComplete {
+				vc.vr.stats.CopyRowCount.Add(int64(len(result.args.rows)))
+				vc.vr.stats.QueryCount.Add("copy", 1)
+				vc.vr.stats.TableCopyRowCounts.Add(tableName, int64(len(result.args.rows)))
+				vc.vr.stats.TableCopyTimings.Add(tableName, time.Since(result.startedAt))
+			}
+		})
+
+		if err := copyWorkQueue.enqueue(ctx, currT); err != nil {
+			log.Warningf("failed to enqueue task in workflow %s: %s", vc.vr.WorkflowName, err.Error())
+			return err
+		}
+
+		// When async execution is not enabled, a done task will be available
+		// in the resultCh after each Enqueue, unless there was a queue state
+		// error (e.g. couldn't obtain a worker from pool).
+		//
+		// When async execution is enabled, results will show up in the channel
+		// eventually, possibly in a subsequent VStreamRows loop. It's still
+		// a good idea to check this channel on every pass so that:
+		//
+		// * resultCh doesn't fill up. If it does fill up then tasks won't be
+		//   able to add their results to the channel, and progress in this
+		//   goroutine will be blocked.
+		// * We keep lastpk up-to-date.
+		select {
+		case result := <-resultCh:
+			if result != nil {
+				switch result.state {
+				case vcopierCopyTaskCancel:
+					log.Warningf("task was canceled in workflow %s: %v", vc.vr.WorkflowName, result.err)
+					return io.EOF
+				case vcopierCopyTaskComplete:
+					// Collect lastpk. Needed for logging at the end.
+					lastpk = result.args.lastpk
+				case vcopierCopyTaskFail:
+					return vterrors.Wrapf(result.err, "task error")
+				}
+			} else {
+				return io.EOF
+			}
+		default:
+		}
+
+		return nil
+	}
+
 	serr := vc.vr.sourceVStreamer.VStreamRows(ctx, initialPlan.SendRule.Filter, lastpkpb, func(rows *binlogdatapb.VStreamRowsResponse) error {
 		for {
 			select {
@@ -503,97 +601,26 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 		}
 
 		// Clone rows, since pointer values will change while async work is
-		// happening. Can skip this when there's no parallelism.
-		if parallelism > 1 {
+		// happening, or while they're batched. Can skip this when there's no
+		// parallelism nor batching.
+		if parallelism > 1 || batcher != nil {
 			rows = rows.CloneVT()
 		}
-
-		// Code below is copied from vcopier.go. It was implemented to facilitate
-		// parallel bulk inserts in https://github.com/vitessio/vitess/pull/10828.
-		// We can probably extract this into a common package and use it for both
-		// flavors of the vcopier. But cut/pasting it for now, so as to not change
-		// vcopier at the moment to avoid any regressions.
-
-		// Prepare a vcopierCopyTask for the current batch of work.
-		// TODO(maxeng) see if using a pre-allocated pool will speed things up.
-		currCh := make(chan *vcopierCopyTaskResult, 1)
-		currT := newVCopierCopyTask(newVCopierCopyTaskArgs(rows.Rows, rows.Lastpk))
-
-		// Send result to the global resultCh and currCh. resultCh is used by
-		// the loop to return results to VStreamRows. currCh will be used to
-		// sequence the start of the nextT.
-		currT.lifecycle.onResult().sendTo(currCh)
-		currT.lifecycle.onResult().sendTo(resultCh)
-
-		// Use prevCh to Sequence the prevT with the currT so that:
-		// * The prevT is completed before we begin updating
-		//   _vt.copy_state for currT.
-		// * If prevT fails or is canceled, the current task is
-		//   canceled.
-		// prevCh is nil only for the first task in the vcopier run.
-		if prevCh != nil {
-			// prevT publishes to prevCh, and currT is the only thing that can
-			// consume from prevCh. If prevT is already done, then prevCh will
-			// have a value in it. If prevT isn't yet done, then prevCh will
-			// have a value later. Either way, AwaitCompletion should
-			// eventually get a value, unless there is a context expiry.
-			currT.lifecycle.before(vcopierCopyTaskInsertCopyState).awaitCompletion(prevCh)
-		}
-
-		// Store currCh in prevCh. The nextT will use this for sequencing.
-		prevCh = currCh
-
-		// Update stats after task is done.
-		currT.lifecycle.onResult().do(func(_ context.Context, result *vcopierCopyTaskResult) {
-			if result.state == vcopierCopyTaskFail {
-				vc.vr.stats.ErrorCounts.Add([]string{"Copy"}, 1)
-			}
-			if result.state == vcopierCopyTaskComplete {
-				vc.vr.stats.CopyRowCount.Add(int64(len(result.args.rows)))
-				vc.vr.stats.QueryCount.Add("copy", 1)
-				vc.vr.stats.TableCopyRowCounts.Add(tableName, int64(len(result.args.rows)))
-				vc.vr.stats.TableCopyTimings.Add(tableName, time.Since(result.startedAt))
+		batch, batchLastpk := rows.Rows, rows.Lastpk
+		if batcher != nil {
+			if batch, batchLastpk = batcher.add(rows.Rows, rows.Lastpk); len(batch) == 0 {
+				return nil
 			}
-		})
-
-		if err := copyWorkQueue.enqueue(ctx, currT); err != nil {
-			log.Warningf("failed to enqueue task in workflow %s: %s", vc.vr.WorkflowName, err.Error())
-			return err
 		}
+		return copyRows(batch, batchLastpk)
+	})
 
-		// When async execution is not enabled, a done task will be available
-		// in the resultCh after each Enqueue, unless there was a queue state
-		// error (e.g. couldn't obtain a worker from pool).
-		//
-		// When async execution is enabled, results will show up in the channel
-		// eventually, possibly in a subsequent VStreamRows loop. It's still
-		// a good idea to check this channel on every pass so that:
-		//
-		// * resultCh doesn't fill up. If it does fill up then tasks won't be
-		//   able to add their results to the channel, and progress in this
-		//   goroutine will be blocked.
-		// * We keep lastpk up-to-date.
-		select {
-		case result := <-resultCh:
-			if result != nil {
-				switch result.state {
-				case vcopierCopyTaskCancel:
-					log.Warningf("task was canceled in workflow %s: %v", vc.vr.WorkflowName, result.err)
-					return io.EOF
-				case vcopierCopyTaskComplete:
-					// Collect lastpk. Needed for logging at the end.
-					lastpk = result.args.lastpk
-				case vcopierCopyTaskFail:
-					return vterrors.Wrapf(result.err, "task error")
-				}
-			} else {
-				return io.EOF
-			}
-		default:
+	// Copy the rows which are still batched once all of them were streamed.
+	if serr == nil && ctx.Err() == nil && batcher != nil {
+		if batch, batchLastpk := batcher.flush(); len(batch) > 0 {
+			serr = copyRows(batch, batchLastpk)
 		}
-
-		return nil
-	})
+	}
 
 	// Close the work queue. This will prevent new tasks from being enqueued,
 	// and will wait until all workers are returned to the worker pool.
@@ -54,7 +54,7 @@ func newCopyAllState(vc *vcopier) (*copyAllState, error) {
 	state := &copyAllState{
 		vc: vc,
 	}
-	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env.CollationEnv(), vc.vr.vre.env.Parser())
+	plan, err := buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env)
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +85,10 @@ func (vc *vcopier) copyAll(ctx context.Context, settings binlogplayer.VRSettings
 	rowsCopiedTicker := time.NewTicker(rowsCopiedUpdateInterval)
 	defer rowsCopiedTicker.Stop()
 
-	parallelism := getInsertParallelism()
+	parallelism := vc.getInsertParallelism()
 	copyWorkerFactory := vc.newCopyWorkerFactory(parallelism)
 	var copyWorkQueue *vcopierCopyWorkQueue
+	rateLimiter := newCopyRateLimiter(vc.vr.copySettings)
 
 	// Allocate a result channel to collect results from tasks. To not block fast workers, we allocate a buffer of
 	// MaxResultsInFlight results per worker.
@@ -181,6 +182,11 @@ func (vc *vcopier) copyAll(ctx context.Context, settings binlogplayer.VRSettings
 		if len(resp.Rows) == 0 {
 			return nil
 		}
+		if err := rateLimiter.wait(ctx, resp.Rows); err != nil {
+			// The rows can't be copied before the copy phase duration elapses.
+			<-ctx.Done()
+			return io.EOF
+		}
 		// Get the last committed pk into a loggable form.
 		lastpkbuf, merr := prototext.Marshal(&querypb.QueryResult{
 			Fields: pkfields,
@@ -120,7 +120,10 @@ func newVPlayer(vr *vreplicator, settings binlogplayer.VRSettings, copyState map
 		return vr.dbClient.Commit()
 	}
 	batchMode := false
-	if vttablet.VReplicationExperimentalFlags&vttablet.VReplicationExperimentalFlagVPlayerBatching != 0 {
+	// Conflicts of reverse workflows are detected from the result of each
+	// row change, which isn't known until the commit when batching.
+	if vttablet.VReplicationExperimentalFlags&vttablet.VReplicationExperimentalFlagVPlayerBatching != 0 &&
+		!isReverseWorkflow(vr.WorkflowName) {
 		batchMode = true
 	}
 	if batchMode {
@@ -180,7 +183,7 @@ func (vp *vplayer) play(ctx context.Context) error {
 		return nil
 	}
 
-	plan, err := buildReplicatorPlan(vp.vr.source, vp.vr.colInfoMap, vp.copyState, vp.vr.stats, vp.vr.vre.env.CollationEnv(), vp.vr.vre.env.Parser())
+	plan, err := buildReplicatorPlan(vp.vr.source, vp.vr.colInfoMap, vp.copyState, vp.vr.stats, vp.vr.vre.env)
 	if err != nil {
 		vp.vr.stats.ErrorCounts.Add([]string{"Plan"}, 1)
 		return err
@@ -358,8 +361,13 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 		}
 	}
 
+	captureConflicts := isReverseWorkflow(vp.vr.WorkflowName)
 	for _, change := range rowEvent.RowChanges {
-		if _, err := tplan.applyChange(change, applyFunc); err != nil {
+		qr, err := tplan.applyChange(change, applyFunc)
+		if captureConflicts {
+			vp.captureConflict(tplan, change, qr, err)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -605,6 +613,11 @@ func (vp *vplayer) applyEvent(ctx context.Context, event *binlogdatapb.VEvent, m
 		if err != nil {
 			return err
 		}
+		if len(tplan.MinMaxRecalculations) != 0 {
+			tplan.sourceRows = func(query string, send func(*binlogdatapb.VStreamRowsResponse) error) error {
+				return vp.vr.sourceVStreamer.VStreamRows(ctx, query, nil, send)
+			}
+		}
 		vp.tablePlans[event.FieldEvent.TableName] = tplan
 		stats.Send(fmt.Sprintf("%v", event.FieldEvent))
 
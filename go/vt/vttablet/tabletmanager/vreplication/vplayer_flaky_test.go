@@ -1132,7 +1132,7 @@ func TestPlayerKeywordNames(t *testing.T) {
 		input: "insert into `commit` values(1, 'aaa')",
 		output: qh.Expect(
 			"begin",
-			"insert into `commit`(`primary`,`column`) values (1 + 1,concat('aaa', 'a'))",
+			"insert into `commit`(`primary`,`column`) values (1 + 1,'aaaa')",
 			"/update _vt.vreplication set pos=",
 			"commit",
 		),
@@ -1144,7 +1144,7 @@ func TestPlayerKeywordNames(t *testing.T) {
 		input: "update `commit` set `column`='bbb' where `primary`=1",
 		output: qh.Expect(
 			"begin",
-			"update `commit` set `column`=concat('bbb', 'a') where `primary`=(1 + 1)",
+			"update `commit` set `column`='bbba' where `primary`=(1 + 1)",
 			"/update _vt.vreplication set pos=",
 			"commit",
 		),
@@ -1157,7 +1157,7 @@ func TestPlayerKeywordNames(t *testing.T) {
 		output: qh.Expect(
 			"begin",
 			"delete from `commit` where `primary`=(1 + 1)",
-			"insert into `commit`(`primary`,`column`) values (2 + 1,concat('bbb', 'a'))",
+			"insert into `commit`(`primary`,`column`) values (2 + 1,'bbba')",
 			"/update _vt.vreplication set pos=",
 			"commit",
 		),
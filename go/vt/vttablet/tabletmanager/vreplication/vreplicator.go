@@ -32,6 +32,7 @@ import (
 	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/maintenancewindow"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -52,6 +53,10 @@ var (
 
 	dbLockRetryDelay = 1 * time.Second
 
+	// maintenanceWindowCheckInterval is how often a copy phase waiting for a
+	// maintenance window checks whether one is open.
+	maintenanceWindowCheckInterval = 1 * time.Minute
+
 	// vreplicationMinimumHeartbeatUpdateInterval overrides vreplicationHeartbeatUpdateInterval if the latter is higher than this
 	// to ensure that it satisfies liveness criteria implicitly expected by internal processes like Online DDL
 	vreplicationMinimumHeartbeatUpdateInterval = 60
@@ -110,7 +115,19 @@ type vreplicator struct {
 	WorkflowSubType int32
 	WorkflowName    string
 
+	// copySettings are the settings of the copy phase of the workflow, if any.
+	copySettings *tabletmanagerdatapb.VReplicationCopySettings
+
 	throttleUpdatesRateLimiter *timer.RateLimiter
+
+	// waitingForMaintenanceWindow is set while the copy phase waits for a
+	// maintenance window of the cluster.
+	waitingForMaintenanceWindow bool
+
+	// pendingConflict is the duplicate key conflict of a reverse workflow
+	// which failed the current transaction. It's recorded once the
+	// transaction is rolled back.
+	pendingConflict *conflict
 }
 
 // newVReplicator creates a new vreplicator. The valid fields from the source are:
@@ -180,7 +197,11 @@ func (vr *vreplicator) Replicate(ctx context.Context) error {
 		if err := vr.setMessage(err.Error()); err != nil {
 			binlogplayer.LogError("Failed to set error state", err)
 		}
+		if vr.pendingConflict != nil {
+			insertConflict(vr.dbClient, vr.id, vr.pendingConflict)
+		}
 	}
+	vr.pendingConflict = nil
 	return err
 }
 
@@ -268,6 +289,13 @@ func (vr *vreplicator) replicate(ctx context.Context) error {
 		}
 		switch {
 		case numTablesToCopy != 0:
+			isOpen, err := vr.waitForMaintenanceWindow(ctx)
+			if err != nil {
+				return err
+			}
+			if !isOpen {
+				continue
+			}
 			if err := vr.clearFKCheck(vr.dbClient); err != nil {
 				log.Warningf("Unable to clear FK check %v", err)
 				return err
@@ -466,6 +494,36 @@ func (vr *vreplicator) setMessage(message string) (err error) {
 	return nil
 }
 
+// waitForMaintenanceWindow returns whether one of the maintenance windows of
+// the cluster is open, as the copy phase only runs during them. When none is,
+// it waits before returning, for the caller to check again.
+func (vr *vreplicator) waitForMaintenanceWindow(ctx context.Context) (bool, error) {
+	isOpen, err := maintenancewindow.Check(ctx, vr.vre.ts)
+	if err != nil {
+		return false, err
+	}
+	if isOpen {
+		if vr.waitingForMaintenanceWindow {
+			vr.waitingForMaintenanceWindow = false
+			if err := vr.setMessage(""); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	if !vr.waitingForMaintenanceWindow {
+		vr.waitingForMaintenanceWindow = true
+		if err := vr.setMessage("waiting for a maintenance window to copy"); err != nil {
+			return false, err
+		}
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(maintenanceWindowCheckInterval):
+	}
+	return false, nil
+}
+
 func (vr *vreplicator) insertLog(typ, message string) {
 	insertLog(vr.dbClient, typ, vr.id, vr.state.String(), message)
 }
@@ -566,10 +624,16 @@ func (vr *vreplicator) setSQLMode(ctx context.Context, dbClient *vdbClient) (fun
 //     the workflow by either /throttler/throttle-app?app=vreplication and/or /throttler/throttle-app?app=online-ddl
 //     This is useful when we want to throttle all migrations. We throttle "online-ddl" and that applies to both vreplication
 //     migrations as well as gh-ost migrations.
+//   - "vreplication:lookup-vindex" for the flows which backfill lookup vindexes.
+//     Similarly, all the lookup vindex backfills can be throttled together with
+//     /throttler/throttle-app?app=lookup-vindex.
 func (vr *vreplicator) throttlerAppName() string {
 	names := []string{vr.WorkflowName, throttlerapp.VReplicationName.String()}
-	if vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_OnlineDDL) {
+	switch binlogdatapb.VReplicationWorkflowType(vr.WorkflowType) {
+	case binlogdatapb.VReplicationWorkflowType_OnlineDDL:
 		names = append(names, throttlerapp.OnlineDDLName.String())
+	case binlogdatapb.VReplicationWorkflowType_CreateLookupIndex:
+		names = append(names, throttlerapp.LookupVindexName.String())
 	}
 	return throttlerapp.Concatenate(names...)
 }
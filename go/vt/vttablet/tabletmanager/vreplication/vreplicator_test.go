@@ -87,6 +87,23 @@ func TestRecalculatePKColsInfoByColumnNames(t *testing.T) {
 	}
 }
 
+func TestThrottlerAppName(t *testing.T) {
+	tests := []struct {
+		workflowType binlogdatapb.VReplicationWorkflowType
+		want         string
+	}{
+		{binlogdatapb.VReplicationWorkflowType_MoveTables, "wf:vreplication"},
+		{binlogdatapb.VReplicationWorkflowType_OnlineDDL, "wf:vreplication:online-ddl"},
+		{binlogdatapb.VReplicationWorkflowType_CreateLookupIndex, "wf:vreplication:lookup-vindex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.workflowType.String(), func(t *testing.T) {
+			vr := &vreplicator{WorkflowName: "wf", WorkflowType: int32(tt.workflowType)}
+			assert.Equal(t, tt.want, vr.throttlerAppName())
+		})
+	}
+}
+
 func TestPrimaryKeyEquivalentColumns(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
@@ -25,6 +25,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/pools/smartconnpool"
 	"vitess.io/vitess/go/sqltypes"
@@ -43,6 +44,9 @@ import (
 
 const defaultKillTimeout = 5 * time.Second
 
+// trackOwnGTIDsQuery makes MySQL return the GTID of each committed transaction.
+const trackOwnGTIDsQuery = "set @@session.session_track_gtids = OWN_GTID"
+
 // Conn is a db connection for tabletserver.
 // It performs automatic reconnects as needed.
 // Its Execute function has a timeout that can kill
@@ -57,6 +61,10 @@ type Conn struct {
 	stats   *tabletenv.Stats
 	current atomic.Pointer[string]
 
+	// queryAttributes are sent to MySQL with the next query, including
+	// its retry after a reconnect.
+	queryAttributes []mysql.QueryAttribute
+
 	// err will be set if a query is killed through a Kill.
 	errmu sync.Mutex
 	err   error
@@ -75,6 +83,13 @@ func newPooledConn(ctx context.Context, pool *Pool, appParams dbconfigs.Connecto
 		pool.env.CheckMySQL()
 		return nil, err
 	}
+	// MariaDB doesn't support session_track_gtids.
+	if pool.trackGTIDs && !c.IsMariaDB() {
+		if _, err := c.ExecuteFetch(trackOwnGTIDsQuery, 1, false); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
 	db := &Conn{
 		conn:        c,
 		env:         pool.env,
@@ -123,6 +138,7 @@ func (dbc *Conn) Err() error {
 func (dbc *Conn) Exec(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
 	span, ctx := trace.NewSpan(ctx, "DBConn.Exec")
 	defer span.Finish()
+	defer dbc.clearQueryAttributes()
 
 	for attempt := 1; attempt <= 2; attempt++ {
 		r, err := dbc.execOnce(ctx, query, maxrows, wantfields, false)
@@ -159,6 +175,17 @@ func (dbc *Conn) Exec(ctx context.Context, query string, maxrows int, wantfields
 	panic("unreachable")
 }
 
+// SetQueryAttributes sets the query attributes to send to MySQL along
+// with the next query executed on this connection. They are dropped if
+// the connection was not established with CLIENT_QUERY_ATTRIBUTES.
+func (dbc *Conn) SetQueryAttributes(attributes []mysql.QueryAttribute) {
+	dbc.queryAttributes = attributes
+}
+
+func (dbc *Conn) clearQueryAttributes() {
+	dbc.queryAttributes = nil
+}
+
 func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfields bool, insideTxn bool) (*sqltypes.Result, error) {
 	dbc.current.Store(&query)
 	defer dbc.current.Store(nil)
@@ -178,6 +205,7 @@ func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfi
 	}
 
 	ch := make(chan execResult)
+	dbc.conn.SetQueryAttributes(dbc.queryAttributes)
 	go func() {
 		result, err := dbc.conn.ExecuteFetch(query, maxrows, wantfields)
 		ch <- execResult{result, err}
@@ -221,6 +249,7 @@ func (dbc *Conn) terminate(ctx context.Context, insideTxn bool, now time.Time) {
 
 // ExecOnce executes the specified query, but does not retry on connection errors.
 func (dbc *Conn) ExecOnce(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	defer dbc.clearQueryAttributes()
 	return dbc.execOnce(ctx, query, maxrows, wantfields, true /* Once means we are in a txn*/)
 }
 
@@ -244,6 +273,7 @@ func (dbc *Conn) Stream(ctx context.Context, query string, callback func(*sqltyp
 	span, ctx := trace.NewSpan(ctx, "DBConn.Stream")
 	trace.AnnotateSQL(span, sqlparser.Preview(query))
 	defer span.Finish()
+	defer dbc.clearQueryAttributes()
 
 	resultSent := false
 	for attempt := 1; attempt <= 2; attempt++ {
@@ -307,6 +337,7 @@ func (dbc *Conn) streamOnce(
 	defer dbc.stats.MySQLTimings.Record("ExecStream", now)
 
 	ch := make(chan error)
+	dbc.conn.SetQueryAttributes(dbc.queryAttributes)
 	go func() {
 		ch <- dbc.conn.ExecuteStreamFetch(query, callback, alloc, streamBufferSize)
 		close(ch)
@@ -337,6 +368,7 @@ func (dbc *Conn) StreamOnce(
 	streamBufferSize int,
 	includedFields querypb.ExecuteOptions_IncludedFields,
 ) error {
+	defer dbc.clearQueryAttributes()
 	resultSent := false
 	return dbc.streamOnce(
 		ctx,
@@ -62,6 +62,8 @@ type Pool struct {
 
 	appDebugParams dbconfigs.Connector
 	getConnTime    *servenv.TimingsWrapper
+
+	trackGTIDs bool
 }
 
 // NewPool creates a new Pool. The name is used
@@ -95,6 +97,13 @@ func NewPool(env tabletenv.Env, name string, cfg tabletenv.ConnPoolConfig) *Pool
 	return cp
 }
 
+// TrackGTIDs makes MySQL return the GTIDs of the transactions committed by the
+// connections of the pool in the session state changes of their results. It must
+// be called before Open.
+func (cp *Pool) TrackGTIDs() {
+	cp.trackGTIDs = true
+}
+
 // Open must be called before starting to use the pool.
 func (cp *Pool) Open(appParams, dbaParams, appDebugParams dbconfigs.Connector) {
 	cp.appDebugParams = appDebugParams
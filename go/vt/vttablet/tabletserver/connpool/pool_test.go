@@ -341,3 +341,19 @@ func newPoolWithCapacity(capacity int) *Pool {
 		IdleTimeout: 10 * time.Second,
 	})
 }
+
+func TestConnPoolTrackGTIDs(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	connPool := newPool()
+	connPool.TrackGTIDs()
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+
+	db.ResetQueryLog()
+	dbConn, err := connPool.Get(context.Background(), nil)
+	require.NoError(t, err)
+	defer dbConn.Recycle()
+	assert.Contains(t, db.QueryLog(), "set @@session.session_track_gtids = own_gtid")
+}
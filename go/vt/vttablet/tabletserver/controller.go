@@ -92,6 +92,10 @@ type Controller interface {
 
 	// CheckThrottler
 	CheckThrottler(ctx context.Context, appName string, flags *throttle.CheckFlags) *throttle.CheckResult
+
+	// UnresolvedTransactions returns the unresolved distributed transactions
+	// for which this tablet is the metadata manager.
+	UnresolvedTransactions(ctx context.Context, target *querypb.Target, abandonAgeSeconds int64) ([]*querypb.TransactionMetadata, error)
 }
 
 // Ensure TabletServer satisfies Controller interface.
@@ -22,6 +22,7 @@ import (
 	"html"
 	"net/http"
 	"strconv"
+	"strings"
 	"text/template"
 	"time"
 
@@ -133,6 +134,16 @@ func debugEnvHandler(tsv *TabletServer, w http.ResponseWriter, r *http.Request)
 		case "Consolidator":
 			tsv.SetConsolidatorMode(value)
 			msg = fmt.Sprintf("Setting %v to: %v", varname, value)
+		case "ConsolidatorDisabledPlans":
+			var plans []string
+			if value != "" {
+				plans = strings.Split(value, ",")
+			}
+			if err := tsv.SetConsolidatorDisabledPlans(plans); err != nil {
+				msg = fmt.Sprintf("Failed setting value for %v: %v", varname, err)
+				break
+			}
+			msg = fmt.Sprintf("Setting %v to: %v", varname, value)
 		}
 	}
 
@@ -151,6 +162,10 @@ func debugEnvHandler(tsv *TabletServer, w http.ResponseWriter, r *http.Request)
 		Name:  "Consolidator",
 		Value: tsv.ConsolidatorMode(),
 	})
+	vars = append(vars, envValue{
+		Name:  "ConsolidatorDisabledPlans",
+		Value: strings.Join(tsv.ConsolidatorDisabledPlans(), ","),
+	})
 
 	format := r.FormValue("format")
 	if format == "json" {
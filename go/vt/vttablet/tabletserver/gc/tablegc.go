@@ -18,6 +18,7 @@ package gc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
@@ -29,12 +30,17 @@ import (
 	"vitess.io/vitess/go/mysql/capabilities"
 	"vitess.io/vitess/go/mysql/sqlerror"
 
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/dbconnpool"
 	"vitess.io/vitess/go/vt/log"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
@@ -71,10 +77,16 @@ func registerGCFlags(fs *pflag.FlagSet) {
 }
 
 var (
-	sqlPurgeTable   = `delete from %a limit 50`
-	sqlShowVtTables = `show full tables like '\_vt\_%'`
-	sqlDropTable    = "drop table if exists `%a`"
-	sqlDropView     = "drop view if exists `%a`"
+	sqlPurgeTable        = `delete from %a limit 50`
+	sqlShowVtTables      = `show full tables like '\_vt\_%'`
+	sqlDropTable         = "drop table if exists `%a`"
+	sqlDropView          = "drop view if exists `%a`"
+	sqlTruncatePartition = "alter table `%a` truncate partition %a"
+	sqlSelectPartitions  = `select distinct partition_name, partition_ordinal_position
+		from information_schema.partitions
+		where table_schema=database() and table_name=%a and partition_name is not null
+		order by partition_ordinal_position
+	`
 )
 
 type gcTable struct {
@@ -102,6 +114,7 @@ type TableGC struct {
 	keyspace string
 	shard    string
 	dbName   string
+	cell     string
 
 	isOpen          int64
 	cancelOperation context.CancelFunc
@@ -112,12 +125,22 @@ type TableGC struct {
 
 	throttlerClient *throttle.Client
 
-	env  tabletenv.Env
-	pool *connpool.Pool
-	ts   *topo.Server
+	env           tabletenv.Env
+	pool          *connpool.Pool
+	ts            *topo.Server
+	srvTopoServer srvtopo.Server
 
-	stateMutex sync.Mutex
-	purgeMutex sync.Mutex
+	stateMutex  sync.Mutex
+	purgeMutex  sync.Mutex
+	configMutex sync.Mutex
+
+	// tableGCConfig is the keyspace's table GC configuration, as read from SrvKeyspace
+	tableGCConfig *topodatapb.TableGCConfig
+	// purgeRequestedAt is the time of the latest immediate purge request seen by this collector
+	purgeRequestedAt time.Time
+	// purgeNowRequested is set when an immediate purge was requested, and cleared once
+	// the collector has transitioned all GC tables to DROP state
+	purgeNowRequested atomic.Bool
 
 	purgingTables map[string]bool
 	// lifecycleStates indicates what states a GC table goes through. The user can set
@@ -136,13 +159,15 @@ type Status struct {
 }
 
 // NewTableGC creates a table collector
-func NewTableGC(env tabletenv.Env, ts *topo.Server, lagThrottler *throttle.Throttler) *TableGC {
+func NewTableGC(env tabletenv.Env, srvTopoServer srvtopo.Server, ts *topo.Server, cell string, lagThrottler *throttle.Throttler) *TableGC {
 	collector := &TableGC{
 		throttlerClient: throttle.NewBackgroundClient(lagThrottler, throttlerapp.TableGCName, throttle.ThrottleCheckPrimaryWrite),
 		isOpen:          0,
 
-		env: env,
-		ts:  ts,
+		env:           env,
+		ts:            ts,
+		srvTopoServer: srvTopoServer,
+		cell:          cell,
 		pool: connpool.NewPool(env, "TableGCPool", tabletenv.ConnPoolConfig{
 			Size:        2,
 			IdleTimeout: env.Config().OltpReadPool.IdleTimeout,
@@ -207,9 +232,76 @@ func (collector *TableGC) Open() (err error) {
 	ctx, collector.cancelOperation = context.WithCancel(ctx)
 	go collector.operate(ctx)
 
+	if collector.srvTopoServer != nil {
+		// We watch using ctx, which is cancelled when the collector is Close()d.
+		go collector.srvTopoServer.WatchSrvKeyspace(ctx, collector.cell, collector.keyspace, func(srvks *topodatapb.SrvKeyspace, err error) bool {
+			return collector.watchSrvKeyspaceCallback(ctx, srvks, err)
+		})
+	}
+
 	return nil
 }
 
+// watchSrvKeyspaceCallback is called upon SrvKeyspace changes, and applies the keyspace's table GC configuration.
+func (collector *TableGC) watchSrvKeyspaceCallback(ctx context.Context, srvks *topodatapb.SrvKeyspace, err error) bool {
+	if ctx.Err() != nil {
+		// Collector is closed. Stop watching.
+		return false
+	}
+	if err != nil {
+		if !topo.IsErrType(err, topo.Interrupted) && !errors.Is(err, context.Canceled) {
+			log.Errorf("TableGC: SrvKeyspace watch error: %v", err)
+		}
+		// Keep watching: the SrvKeyspace may not have been created yet.
+		return true
+	}
+	collector.applyTableGCConfig(srvks.GetTableGcConfig())
+	return true
+}
+
+// applyTableGCConfig applies a table GC configuration as read from SrvKeyspace. An immediate purge request
+// is honored once, and only when it is newer than any request seen before. When the collector opens, it
+// thus ignores requests that predate it.
+func (collector *TableGC) applyTableGCConfig(tableGCConfig *topodatapb.TableGCConfig) {
+	collector.configMutex.Lock()
+	defer collector.configMutex.Unlock()
+
+	isInitialConfig := (collector.tableGCConfig == nil)
+	collector.tableGCConfig = tableGCConfig
+	if tableGCConfig == nil {
+		collector.tableGCConfig = &topodatapb.TableGCConfig{}
+	}
+	purgeRequestedAt := protoutil.TimeFromProto(tableGCConfig.GetPurgeRequestedAt()).UTC()
+	if purgeRequestedAt.IsZero() || !purgeRequestedAt.After(collector.purgeRequestedAt) {
+		return
+	}
+	collector.purgeRequestedAt = purgeRequestedAt
+	if isInitialConfig {
+		return
+	}
+	log.Infof("TableGC: immediate purge requested at %v", purgeRequestedAt)
+	collector.purgeNowRequested.Store(true)
+	collector.RequestChecks()
+}
+
+// Retention returns the table retention configured for the keyspace, or zero if none is configured.
+func (collector *TableGC) Retention() time.Duration {
+	collector.configMutex.Lock()
+	defer collector.configMutex.Unlock()
+
+	retention, _, _ := protoutil.DurationFromProto(collector.tableGCConfig.GetRetention())
+	return retention
+}
+
+// purgePartitions returns true when the keyspace is configured to purge partitioned tables by
+// truncating their partitions.
+func (collector *TableGC) purgePartitions() bool {
+	collector.configMutex.Lock()
+	defer collector.configMutex.Unlock()
+
+	return collector.tableGCConfig.GetPurgePartitions()
+}
+
 // Close frees resources
 func (collector *TableGC) Close() {
 	log.Infof("TableGC - started execution of Close. Acquiring initMutex lock")
@@ -437,8 +529,13 @@ func (collector *TableGC) readTables(ctx context.Context) (gcTables []*gcTable,
 // It lists _vt_% tables, then filters through those which are due-date.
 // It then applies the necessary operation per table.
 func (collector *TableGC) checkTables(ctx context.Context, gcTables []*gcTable, dropTablesChan chan<- *gcTable, transitionRequestsChan chan<- *transitionRequest) error {
+	// When an immediate purge is requested, all GC tables skip the rest of their lifecycle and go straight to DROP state.
+	purgeNow := collector.purgeNowRequested.CompareAndSwap(true, false)
 	for i := range gcTables {
 		table := gcTables[i] // we capture as local variable as we will later use this in a goroutine
+		if purgeNow && collector.transitionToDrop(ctx, table, transitionRequestsChan) {
+			continue
+		}
 		shouldTransition, state, uuid, err := collector.shouldTransitionTable(table.tableName)
 
 		if err != nil {
@@ -482,6 +579,54 @@ func (collector *TableGC) checkTables(ctx context.Context, gcTables []*gcTable,
 	return nil
 }
 
+// transitionToDrop submits a transition of the given table straight into DROP state, skipping any
+// remaining lifecycle states. It returns false when this is not a GC table, or when it is already in DROP state.
+func (collector *TableGC) transitionToDrop(ctx context.Context, table *gcTable, transitionRequestsChan chan<- *transitionRequest) bool {
+	isGCTable, state, uuid, _, err := schema.AnalyzeGCTableName(table.tableName)
+	if err != nil || !isGCTable || state == schema.DropTableGCState {
+		return false
+	}
+	log.Infof("TableGC: immediate purge: transitioning table %s to %s state", table.tableName, schema.DropTableGCState)
+	collector.removePurgingTable(table.tableName)
+	go func() {
+		transitionRequestsChan <- &transitionRequest{
+			fromTableName: table.tableName,
+			isBaseTable:   table.isBaseTable,
+			toGCState:     schema.DropTableGCState,
+			uuid:          uuid,
+		}
+	}()
+	return true
+}
+
+// truncatePartitions truncates the partitions of the given table, one by one, subject to throttling.
+// This is a fast purge for partitioned tables, as opposed to deleting the rows in small chunks.
+// Non-partitioned tables are unaffected.
+func (collector *TableGC) truncatePartitions(ctx context.Context, conn *dbconnpool.DBConnection, tableName string) error {
+	query, err := sqlparser.ParseAndBind(sqlSelectPartitions, sqltypes.StringBindVariable(tableName))
+	if err != nil {
+		return err
+	}
+	res, err := conn.ExecuteFetch(query, -1, false)
+	if err != nil {
+		return err
+	}
+	for _, row := range res.Rows {
+		partitionName := row[0].ToString()
+		for !collector.throttlerClient.ThrottleCheckOKOrWait(ctx) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		parsed := sqlparser.BuildParsedQuery(sqlTruncatePartition, tableName, sqlescape.EscapeID(partitionName))
+		if _, err := conn.ExecuteFetch(parsed.Query, 1, false); err != nil {
+			return err
+		}
+		log.Infof("TableGC: truncated partition %s of %s", partitionName, tableName)
+	}
+	return nil
+}
+
 // purge continuously purges rows from a table.
 // This function is non-reentrant: there's only one instance of this function running at any given time.
 // A timer keeps calling this function, so if it bails out (e.g. on error) it will later resume work
@@ -545,6 +690,11 @@ func (collector *TableGC) purge(ctx context.Context) (tableName string, err erro
 	}()
 
 	log.Infof("TableGC: purge begin for %s", tableName)
+	if collector.purgePartitions() {
+		if err := collector.truncatePartitions(ctx, conn, tableName); err != nil {
+			return tableName, err
+		}
+	}
 	for {
 		if ctx.Err() != nil {
 			// cancelled
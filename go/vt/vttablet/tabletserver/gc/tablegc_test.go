@@ -21,6 +21,8 @@ import (
 	"testing"
 	"time"
 
+	"vitess.io/vitess/go/protoutil"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/schema"
 
 	"github.com/stretchr/testify/assert"
@@ -500,3 +502,118 @@ func TestCheckTables(t *testing.T) {
 	assert.ElementsMatch(t, expectDropTables, foundDropTables)
 	assert.ElementsMatch(t, expectTransitionRequests, foundTransitionRequests)
 }
+
+func TestCheckTablesPurgeNow(t *testing.T) {
+	collector := &TableGC{
+		isOpen:           0,
+		purgingTables:    map[string]bool{},
+		checkRequestChan: make(chan bool),
+	}
+	var err error
+	collector.lifecycleStates, err = schema.ParseGCLifecycle("hold,purge,evac,drop")
+	require.NoError(t, err)
+	collector.purgeNowRequested.Store(true)
+
+	gcTables := []*gcTable{
+		{
+			tableName:   "_vt_something_that_isnt_a_gc_table",
+			isBaseTable: true,
+		},
+		{
+			tableName:   "_vt_hld_11111111111111111111111111111111_20990920093324_", // 2099 is in the far future
+			isBaseTable: true,
+		},
+		{
+			tableName:   "_vt_prg_22222222222222222222222222222222_20200920093324_",
+			isBaseTable: true,
+		},
+		{
+			tableName:   "_vt_drp_33333333333333333333333333333333_20200919083451_",
+			isBaseTable: false,
+		},
+	}
+	expectDropTables := []*gcTable{
+		{
+			tableName:   "_vt_drp_33333333333333333333333333333333_20200919083451_",
+			isBaseTable: false,
+		},
+	}
+	expectTransitionRequests := []*transitionRequest{
+		{
+			fromTableName: "_vt_hld_11111111111111111111111111111111_20990920093324_",
+			isBaseTable:   true,
+			toGCState:     schema.DropTableGCState,
+			uuid:          "11111111111111111111111111111111",
+		},
+		{
+			fromTableName: "_vt_prg_22222222222222222222222222222222_20200920093324_",
+			isBaseTable:   true,
+			toGCState:     schema.DropTableGCState,
+			uuid:          "22222222222222222222222222222222",
+		},
+	}
+	expectResponses := len(expectDropTables) + len(expectTransitionRequests)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	dropTablesChan := make(chan *gcTable)
+	transitionRequestsChan := make(chan *transitionRequest)
+
+	err = collector.checkTables(ctx, gcTables, dropTablesChan, transitionRequestsChan)
+	assert.NoError(t, err)
+	// The request is consumed
+	assert.False(t, collector.purgeNowRequested.Load())
+	assert.Empty(t, collector.purgingTables)
+
+	var foundDropTables []*gcTable
+	var foundTransitionRequests []*transitionRequest
+	for responses := 0; responses < expectResponses; responses++ {
+		select {
+		case <-ctx.Done():
+			assert.FailNow(t, "timeout")
+			return
+		case gcTable := <-dropTablesChan:
+			foundDropTables = append(foundDropTables, gcTable)
+		case request := <-transitionRequestsChan:
+			foundTransitionRequests = append(foundTransitionRequests, request)
+		}
+	}
+	assert.ElementsMatch(t, expectDropTables, foundDropTables)
+	assert.ElementsMatch(t, expectTransitionRequests, foundTransitionRequests)
+}
+
+func TestApplyTableGCConfig(t *testing.T) {
+	collector := &TableGC{
+		checkRequestChan: make(chan bool, len(NextChecksIntervals)*2),
+	}
+	assert.Zero(t, collector.Retention())
+	assert.False(t, collector.purgePartitions())
+
+	// A purge request which predates the collector's first config is ignored
+	requestedAt := time.Now().Add(-time.Hour)
+	collector.applyTableGCConfig(&topodatapb.TableGCConfig{
+		Retention:        protoutil.DurationToProto(2 * time.Hour),
+		PurgePartitions:  true,
+		PurgeRequestedAt: protoutil.TimeToProto(requestedAt),
+	})
+	assert.Equal(t, 2*time.Hour, collector.Retention())
+	assert.True(t, collector.purgePartitions())
+	assert.False(t, collector.purgeNowRequested.Load())
+
+	// The same request, seen again, is ignored
+	collector.applyTableGCConfig(&topodatapb.TableGCConfig{
+		PurgeRequestedAt: protoutil.TimeToProto(requestedAt),
+	})
+	assert.Zero(t, collector.Retention())
+	assert.False(t, collector.purgePartitions())
+	assert.False(t, collector.purgeNowRequested.Load())
+
+	// A new request is honored
+	collector.applyTableGCConfig(&topodatapb.TableGCConfig{
+		PurgeRequestedAt: protoutil.TimeToProto(time.Now()),
+	})
+	assert.True(t, collector.purgeNowRequested.Load())
+
+	collector.applyTableGCConfig(nil)
+	assert.Zero(t, collector.Retention())
+}
@@ -204,7 +204,7 @@ func (hs *healthStreamer) unregister(ch chan *querypb.StreamHealthResponse) {
 	delete(hs.clients, ch)
 }
 
-func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, ptsTimestamp time.Time, lag time.Duration, err error, serving bool) {
+func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, ptsTimestamp time.Time, lag time.Duration, position string, err error, serving bool) {
 	hs.mu.Lock()
 	defer hs.mu.Unlock()
 
@@ -220,6 +220,7 @@ func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, ptsTimes
 		hs.state.RealtimeStats.HealthError = ""
 	}
 	hs.state.RealtimeStats.ReplicationLagSeconds = uint32(lag.Seconds())
+	hs.state.RealtimeStats.ReplicationPosition = position
 	hs.state.Serving = serving
 
 	hs.state.RealtimeStats.FilteredReplicationLagSeconds, hs.state.RealtimeStats.BinlogPlayersCount = blpFunc()
@@ -79,6 +79,7 @@ func analyzeUpdate(upd *sqlparser.Update, tables map[string]*schema.Table) (plan
 		buf := sqlparser.NewTrackedBuffer(nil)
 		buf.Myprintf("%v", upd.Where)
 		plan.WhereClause = buf.ParsedQuery()
+		plan.WhereExpr = upd.Where.Expr
 	}
 
 	// Situations when we pass-through:
@@ -108,6 +109,7 @@ func analyzeDelete(del *sqlparser.Delete, tables map[string]*schema.Table) (plan
 		buf := sqlparser.NewTrackedBuffer(nil)
 		buf.Myprintf("%v", del.Where)
 		plan.WhereClause = buf.ParsedQuery()
+		plan.WhereExpr = del.Where.Expr
 	}
 
 	if PassthroughDMLs || plan.Table == nil || del.Limit != nil {
@@ -173,6 +173,10 @@ type Plan struct {
 	// to serialize e.g. UPDATEs going to the same row.
 	WhereClause *sqlparser.ParsedQuery
 
+	// WhereExpr is the expression of the WHERE clause of DMLs. It is used by
+	// the hot row protection to find the values of the key expression columns.
+	WhereExpr sqlparser.Expr
+
 	// FullStmt can be used when the query does not operate on tables
 	FullStmt sqlparser.Statement
 
@@ -168,6 +168,8 @@ type QueryEngine struct {
 	// that we start more than one transaction per hot row (range).
 	// For implementation details, please see BeginExecute() in tabletserver.go.
 	txSerializer *txserializer.TxSerializer
+	// queryKiller kills the running queries exceeding its resource thresholds.
+	queryKiller *queryKiller
 
 	// Vars
 	maxResultSize    atomic.Int64
@@ -184,6 +186,9 @@ type QueryEngine struct {
 	strictTransTables bool
 
 	consolidatorMode atomic.Value
+	// consolidatorDisabledPlans are the plan types whose queries are not
+	// consolidated, unless their execute options require it.
+	consolidatorDisabledPlans atomic.Pointer[[planbuilder.NumPlans]bool]
 
 	// stats
 	// Note: queryErrorCountsWithCode is similar to queryErrorCounts except it contains error code as an additional dimension
@@ -228,6 +233,10 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	qe.conns = connpool.NewPool(env, "ConnPool", config.OltpReadPool)
 	qe.streamConns = connpool.NewPool(env, "StreamConnPool", config.OlapReadPool)
 	qe.consolidatorMode.Store(config.Consolidator)
+	if err := qe.setConsolidatorDisabledPlans(config.ConsolidatorDisabledPlans); err != nil {
+		log.Warningf("Ignoring --consolidator-disabled-plans: %v", err)
+		_ = qe.setConsolidatorDisabledPlans(nil)
+	}
 	qe.consolidator = sync2.NewConsolidator()
 	if config.ConsolidatorStreamTotalSize > 0 && config.ConsolidatorStreamQuerySize > 0 {
 		log.Infof("Stream consolidator is enabled with query size set to %d and total size set to %d.",
@@ -237,6 +246,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 		log.Info("Stream consolidator is not enabled.")
 	}
 	qe.txSerializer = txserializer.New(env)
+	qe.queryKiller = newQueryKiller(env)
 
 	qe.strictTableACL = config.StrictTableACL
 	qe.enableTableACLDryRun = config.EnableTableACLDryRun
@@ -341,6 +351,7 @@ func (qe *QueryEngine) Open() error {
 	qe.se.RegisterNotifier("qe", qe.schemaChanged, true)
 	qe.plans.EnsureOpen()
 	qe.settings.EnsureOpen()
+	qe.queryKiller.Open()
 	qe.isOpen.Store(true)
 	return nil
 }
@@ -353,6 +364,7 @@ func (qe *QueryEngine) Close() {
 		return
 	}
 	// Close in reverse order of Open.
+	qe.queryKiller.Close()
 	qe.se.UnregisterNotifier("qe")
 
 	qe.plans.Close()
@@ -689,6 +701,33 @@ func (qe *QueryEngine) handleHTTPAclJSON(response http.ResponseWriter, request *
 }
 
 // ServeHTTP lists the most recent, cached queries and their count.
+// setConsolidatorDisabledPlans sets the plan types whose queries are not
+// consolidated, by their name.
+func (qe *QueryEngine) setConsolidatorDisabledPlans(names []string) error {
+	var disabled [planbuilder.NumPlans]bool
+	for _, name := range names {
+		pt, ok := planbuilder.PlanByName(strings.TrimSpace(name))
+		if !ok {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown plan type: %s", name)
+		}
+		disabled[pt] = true
+	}
+	qe.consolidatorDisabledPlans.Store(&disabled)
+	return nil
+}
+
+// consolidatorDisabledPlanNames returns the names of the plan types whose
+// queries are not consolidated.
+func (qe *QueryEngine) consolidatorDisabledPlanNames() []string {
+	var names []string
+	for pt, disabled := range qe.consolidatorDisabledPlans.Load() {
+		if disabled {
+			names = append(names, planbuilder.PlanType(pt).String())
+		}
+	}
+	return names
+}
+
 func (qe *QueryEngine) handleHTTPConsolidations(response http.ResponseWriter, request *http.Request) {
 	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
 		acl.SendError(response, err)
@@ -405,6 +405,26 @@ func runConsolidatedQuery(t *testing.T, sql string) *QueryEngine {
 	return qe
 }
 
+func TestConsolidatorDisabledPlans(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	qe := newTestQueryEngine(10*time.Second, true, newDBConfigs(db))
+	assert.Empty(t, qe.consolidatorDisabledPlanNames())
+
+	require.NoError(t, qe.setConsolidatorDisabledPlans([]string{"Show", " SelectStream"}))
+	assert.Equal(t, []string{"SelectStream", "Show"}, qe.consolidatorDisabledPlanNames())
+	assert.True(t, qe.consolidatorDisabledPlans.Load()[planbuilder.PlanShow])
+	assert.False(t, qe.consolidatorDisabledPlans.Load()[planbuilder.PlanSelect])
+
+	// Unknown plan types are rejected without changing the disabled plans.
+	err := qe.setConsolidatorDisabledPlans([]string{"Select", "Bogus"})
+	assert.ErrorContains(t, err, "unknown plan type: Bogus")
+	assert.Equal(t, []string{"SelectStream", "Show"}, qe.consolidatorDisabledPlanNames())
+
+	require.NoError(t, qe.setConsolidatorDisabledPlans(nil))
+	assert.Empty(t, qe.consolidatorDisabledPlanNames())
+}
+
 func TestConsolidationsUIRedaction(t *testing.T) {
 	// Reset to default redaction state.
 	defer func() {
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -67,6 +68,8 @@ type QueryExecutor struct {
 	// The target type we requested might be different from tsv's tablet type, if we had a change to the tablet type recently.
 	targetTabletType topodatapb.TabletType
 	setting          *smartconnpool.Setting
+	// limits are set on the query by the LIMIT query rules it matches.
+	limits rules.Limits
 }
 
 const (
@@ -113,6 +116,9 @@ func (qre *QueryExecutor) shouldConsolidate() bool {
 	case querypb.ExecuteOptions_CONSOLIDATOR_ENABLED_REPLICAS:
 		return qre.targetTabletType != topodatapb.TabletType_PRIMARY
 	default:
+		if qre.tsv.qe.consolidatorDisabledPlans.Load()[qre.plan.PlanID] {
+			return false
+		}
 		cm := qre.tsv.qe.consolidatorMode.Load().(string)
 		return cm == tabletenv.Enable || (cm == tabletenv.NotOnPrimary && qre.targetTabletType != topodatapb.TabletType_PRIMARY)
 	}
@@ -154,6 +160,11 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	if err = qre.checkPermissions(); err != nil {
 		return nil, err
 	}
+	if qre.limits.QueryTimeout > 0 {
+		var cancel context.CancelFunc
+		qre.ctx, cancel = context.WithTimeout(qre.ctx, qre.limits.QueryTimeout)
+		defer cancel()
+	}
 
 	if qre.plan.PlanID == p.PlanNextval {
 		return qre.execNextval()
@@ -189,6 +200,9 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
+		if err := qre.verifyResultMemory(qr.CachedSize(true)); err != nil {
+			return nil, err
+		}
 		return qr, nil
 	case p.PlanOtherRead, p.PlanOtherAdmin, p.PlanFlush, p.PlanSavepoint, p.PlanRelease, p.PlanSRollback:
 		return qre.execOther()
@@ -230,7 +244,7 @@ func (qre *QueryExecutor) execAutocommit(f func(conn *StatefulConnection) (*sqlt
 	}
 	qre.options.TransactionIsolation = querypb.ExecuteOptions_AUTOCOMMIT
 
-	if qre.tsv.txThrottler.Throttle(qre.tsv.getPriorityFromOptions(qre.options), qre.options.GetWorkloadName()) {
+	if qre.tsv.txThrottler.Throttle(qre.tsv.getPriorityFromOptions(qre.options), qre.options.GetWorkloadName()) || qre.tsv.txLagThrottler.throttle(qre.ctx, qre.options) {
 		return nil, errTxThrottled
 	}
 
@@ -245,7 +259,7 @@ func (qre *QueryExecutor) execAutocommit(f func(conn *StatefulConnection) (*sqlt
 }
 
 func (qre *QueryExecutor) execAsTransaction(f func(conn *StatefulConnection) (*sqltypes.Result, error)) (*sqltypes.Result, error) {
-	if qre.tsv.txThrottler.Throttle(qre.tsv.getPriorityFromOptions(qre.options), qre.options.GetWorkloadName()) {
+	if qre.tsv.txThrottler.Throttle(qre.tsv.getPriorityFromOptions(qre.options), qre.options.GetWorkloadName()) || qre.tsv.txLagThrottler.throttle(qre.ctx, qre.options) {
 		return nil, errTxThrottled
 	}
 	conn, beginSQL, _, err := qre.tsv.te.txPool.Begin(qre.ctx, qre.options, false, 0, nil, qre.setting)
@@ -301,6 +315,9 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
+		if err := qre.verifyResultMemory(qr.CachedSize(true)); err != nil {
+			return nil, err
+		}
 		return qr, nil
 	case p.PlanDDL:
 		return qre.execDDL(conn)
@@ -325,6 +342,14 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 	if err := qre.checkPermissions(); err != nil {
 		return err
 	}
+	if qre.limits.QueryTimeout > 0 {
+		var cancel context.CancelFunc
+		qre.ctx, cancel = context.WithTimeout(qre.ctx, qre.limits.QueryTimeout)
+		defer cancel()
+	}
+	if qre.limits.MaxRows > 0 || qre.limits.MaxMemory > 0 {
+		callback = qre.limitStream(callback)
+	}
 
 	switch qre.plan.PlanID {
 	case p.PlanSelectStream:
@@ -449,6 +474,7 @@ func (qre *QueryExecutor) checkPermissions() error {
 	}
 
 	action, ruleCancelCtx, timeout, desc := qre.plan.Rules.GetAction(remoteAddr, username, qre.bindVars, qre.marginComments)
+	qre.limits = qre.plan.Rules.GetLimits(remoteAddr, username, qre.bindVars, qre.marginComments)
 
 	bufferingTimeoutCtx, cancel := context.WithTimeout(qre.ctx, timeout) // aborts buffering at given timeout
 	defer cancel()
@@ -764,6 +790,32 @@ func (qre *QueryExecutor) verifyRowCount(count, maxrows int64) error {
 	return nil
 }
 
+// verifyResultMemory returns an error if the size of the result exceeds the
+// MaxMemory limit of the query rules.
+func (qre *QueryExecutor) verifyResultMemory(size int64) error {
+	if maxMemory := qre.limits.MaxMemory; maxMemory > 0 && size > maxMemory {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "result size exceeded %d bytes due to rule: %s", maxMemory, qre.limits.Description)
+	}
+	return nil
+}
+
+// limitStream returns a callback which fails the stream once its rows or their
+// size exceed the MaxRows or MaxMemory limits of the query rules.
+func (qre *QueryExecutor) limitStream(callback StreamCallback) StreamCallback {
+	var rows, size int64
+	return func(result *sqltypes.Result) error {
+		rows += int64(len(result.Rows))
+		if maxRows := qre.limits.MaxRows; maxRows > 0 && rows > maxRows {
+			return vterrors.Errorf(vtrpcpb.Code_ABORTED, "row count exceeded %d due to rule: %s", maxRows, qre.limits.Description)
+		}
+		size += result.CachedSize(true)
+		if err := qre.verifyResultMemory(size); err != nil {
+			return err
+		}
+		return callback(result)
+	}
+}
+
 func (qre *QueryExecutor) execOther() (*sqltypes.Result, error) {
 	conn, err := qre.getConn()
 	if err != nil {
@@ -1075,7 +1127,11 @@ func (qre *QueryExecutor) drainResultSetOnConn(conn *connpool.Conn) error {
 }
 
 func (qre *QueryExecutor) getSelectLimit() int64 {
-	return qre.tsv.qe.maxResultSize.Load()
+	maxrows := qre.tsv.qe.maxResultSize.Load()
+	if qre.limits.MaxRows > 0 && qre.limits.MaxRows < maxrows {
+		return qre.limits.MaxRows
+	}
+	return maxrows
 }
 
 func (qre *QueryExecutor) execDBConn(conn *connpool.Conn, sql string, wantfields bool) (*sqltypes.Result, error) {
@@ -1085,12 +1141,14 @@ func (qre *QueryExecutor) execDBConn(conn *connpool.Conn, sql string, wantfields
 	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
 
 	qd := NewQueryDetail(qre.logStats.Ctx, conn)
+	qd.planType = qre.plan.PlanID
 	err := qre.tsv.statelessql.Add(qd)
 	if err != nil {
 		return nil, err
 	}
 	defer qre.tsv.statelessql.Remove(qd)
 
+	conn.SetQueryAttributes(qre.queryAttributes())
 	return conn.Exec(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
 }
 
@@ -1101,15 +1159,44 @@ func (qre *QueryExecutor) execStatefulConn(conn *StatefulConnection, sql string,
 	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
 
 	qd := NewQueryDetail(qre.logStats.Ctx, conn)
+	qd.planType = qre.plan.PlanID
 	err := qre.tsv.statefulql.Add(qd)
 	if err != nil {
 		return nil, err
 	}
 	defer qre.tsv.statefulql.Remove(qd)
 
+	conn.UnderlyingDBConn().Conn.SetQueryAttributes(qre.queryAttributes())
 	return conn.Exec(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
 }
 
+// queryAttributes returns the query attributes that were forwarded by
+// vtgate as bind variables, so they can be passed on to MySQL, along with
+// the traceparent of the span of the query if the tracer propagates it.
+func (qre *QueryExecutor) queryAttributes() []mysql.QueryAttribute {
+	var attributes []mysql.QueryAttribute
+	if traceparent := trace.Traceparent(qre.ctx); traceparent != "" {
+		if _, ok := qre.bindVars[sqlparser.QueryAttributeName+"traceparent"]; !ok {
+			attributes = append(attributes, mysql.QueryAttribute{Name: "traceparent", Value: sqltypes.NewVarChar(traceparent)})
+		}
+	}
+	for name, bv := range qre.bindVars {
+		attrName, ok := strings.CutPrefix(name, sqlparser.QueryAttributeName)
+		if !ok {
+			continue
+		}
+		val, err := sqltypes.BindVariableToValue(bv)
+		if err != nil {
+			continue
+		}
+		attributes = append(attributes, mysql.QueryAttribute{Name: attrName, Value: val})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+	return attributes
+}
+
 func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction bool, sql string, callback func(*sqltypes.Result) error) error {
 	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.execStreamSQL")
 	trace.AnnotateSQL(span, sqlparser.Preview(sql))
@@ -1127,6 +1214,8 @@ func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction
 	// This change will ensure that long-running streaming stateful queries get gracefully shutdown during ServingTypeChange
 	// once their grace period is over.
 	qd := NewQueryDetail(qre.logStats.Ctx, conn.Conn)
+	qd.planType = qre.plan.PlanID
+	conn.Conn.SetQueryAttributes(qre.queryAttributes())
 	if isTransaction {
 		err := qre.tsv.statefulql.Add(qd)
 		if err != nil {
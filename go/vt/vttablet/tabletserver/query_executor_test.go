@@ -1242,6 +1242,74 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorQRLimit(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table"
+	// The MaxRows of the rule lowers the limit of the query.
+	db.AddQuery("select * from test_table limit 3", &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt32(1), sqltypes.NewInt32(1), sqltypes.NewInt32(1)},
+			{sqltypes.NewInt32(2), sqltypes.NewInt32(2), sqltypes.NewInt32(2)},
+			{sqltypes.NewInt32(3), sqltypes.NewInt32(3), sqltypes.NewInt32(3)},
+		},
+	})
+
+	limitRule := rules.NewQueryRule("bound test_table", "bound test_table", rules.QRLimit)
+	limitRule.AddTableCond("test_table")
+	limitRule.SetLimits(rules.Limits{QueryTimeout: time.Minute, MaxRows: 2})
+
+	rulesName := "limitRulesQRLimit"
+	qrs := rules.New()
+	qrs.Add(limitRule)
+
+	ctx := callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID("analyst"))
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, qrs))
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err := qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_ABORTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "caller id: analyst: row count exceeded 2")
+	assert.Equal(t, rules.Limits{QueryTimeout: time.Minute, MaxRows: 2, Description: "bound test_table"}, qre.limits)
+
+	// The rows of a stream are counted across its results.
+	db.AddQuery(query, &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt32(1), sqltypes.NewInt32(1), sqltypes.NewInt32(1)},
+			{sqltypes.NewInt32(2), sqltypes.NewInt32(2), sqltypes.NewInt32(2)},
+			{sqltypes.NewInt32(3), sqltypes.NewInt32(3), sqltypes.NewInt32(3)},
+		},
+	})
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	err = qre.Stream(func(*sqltypes.Result) error { return nil })
+	assert.ErrorContains(t, err, "row count exceeded 2 due to rule: bound test_table")
+
+	// The MaxMemory of a rule bounds the size of the results.
+	limitRule = rules.NewQueryRule("bound test_table", "bound test_table", rules.QRLimit)
+	limitRule.AddTableCond("test_table")
+	limitRule.SetLimits(rules.Limits{MaxMemory: 1})
+	qrs = rules.New()
+	qrs.Add(limitRule)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, qrs))
+	db.AddQuery("select * from test_table limit 10001", &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt32(1), sqltypes.NewInt32(1), sqltypes.NewInt32(1)},
+		},
+	})
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err = qre.Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "result size exceeded 1 bytes due to rule: bound test_table")
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -1306,6 +1374,8 @@ func TestQueryExecutorShouldConsolidate(t *testing.T) {
 		consolidatorEnabledByDefault bool
 		// query-specific consolidator override, unspecified by default
 		consolidatorExecuteOption querypb.ExecuteOptions_Consolidator
+		// plan types the consolidator is disabled for on the tablet
+		consolidatorDisabledPlans []string
 		// whether or not the consolidator is waiting on the results of an
 		// identical running query
 		consolidatorHasIdenticalQuery bool
@@ -1364,10 +1434,37 @@ func TestQueryExecutorShouldConsolidate(t *testing.T) {
 			expectExec:                    false,
 			input:                         "select * from t limit 10001",
 		},
+		{
+			consolidatorEnabledByDefault:  true,
+			consolidatorExecuteOption:     querypb.ExecuteOptions_CONSOLIDATOR_UNSPECIFIED,
+			consolidatorDisabledPlans:     []string{"Select"},
+			consolidatorHasIdenticalQuery: true,
+			expectConsolidate:             false,
+			expectExec:                    true,
+			input:                         "select * from t limit 10001",
+		},
+		{
+			consolidatorEnabledByDefault:  true,
+			consolidatorExecuteOption:     querypb.ExecuteOptions_CONSOLIDATOR_UNSPECIFIED,
+			consolidatorDisabledPlans:     []string{"SelectStream", "Show"},
+			consolidatorHasIdenticalQuery: true,
+			expectConsolidate:             true,
+			expectExec:                    false,
+			input:                         "select * from t limit 10001",
+		},
+		{
+			consolidatorEnabledByDefault:  true,
+			consolidatorExecuteOption:     querypb.ExecuteOptions_CONSOLIDATOR_ENABLED,
+			consolidatorDisabledPlans:     []string{"Select"},
+			consolidatorHasIdenticalQuery: true,
+			expectConsolidate:             true,
+			expectExec:                    false,
+			input:                         "select * from t limit 10001",
+		},
 	}
 	for _, tcase := range testCases {
-		name := fmt.Sprintf("table-consolidator:%t;query-consolidator:%v;identical-query:%t",
-			tcase.consolidatorEnabledByDefault, tcase.consolidatorExecuteOption, tcase.consolidatorHasIdenticalQuery)
+		name := fmt.Sprintf("table-consolidator:%t;query-consolidator:%v;disabled-plans:%v;identical-query:%t",
+			tcase.consolidatorEnabledByDefault, tcase.consolidatorExecuteOption, tcase.consolidatorDisabledPlans, tcase.consolidatorHasIdenticalQuery)
 		t.Run(name, func(t *testing.T) {
 			// Set up fake db, tablet server (with fake consolidator), and executor.
 
@@ -1382,6 +1479,7 @@ func TestQueryExecutorShouldConsolidate(t *testing.T) {
 
 			tsv := newTestTabletServer(ctx, flags, db)
 			defer tsv.StopService()
+			require.NoError(t, tsv.SetConsolidatorDisabledPlans(tcase.consolidatorDisabledPlans))
 
 			fakeConsolidator := sync2.NewFakeConsolidator()
 			tsv.qe.consolidator = fakeConsolidator
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/dbconnpool"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+const (
+	// sqlTmpDiskUsage returns the size of the active session temporary
+	// tablespaces of the given connections, when it exceeds the threshold.
+	sqlTmpDiskUsage = "select ID, cast(sum(SIZE) as signed) from information_schema.INNODB_SESSION_TEMP_TABLESPACES " +
+		"where STATE = 'ACTIVE' and ID in (%s) group by ID having sum(SIZE) > %d"
+	// sqlRowsExamined returns the rows examined by the running statements of
+	// the given connections, when they exceed the threshold.
+	sqlRowsExamined = "select t.PROCESSLIST_ID, s.ROWS_EXAMINED from performance_schema.events_statements_current s " +
+		"join performance_schema.threads t on t.THREAD_ID = s.THREAD_ID " +
+		"where s.END_EVENT_ID is null and t.PROCESSLIST_ID in (%s) and s.ROWS_EXAMINED > %d"
+)
+
+// queryKiller is a watchdog killing the running queries of the tablet which
+// exceed the thresholds of its config: the max execution time of their plan
+// type, the size of their on-disk temporary tables, or the number of rows
+// they examined. It protects the tablets from the pathological queries which
+// their timeouts don't bound. In dry-run mode, it only logs the queries it
+// would have killed.
+type queryKiller struct {
+	env        tabletenv.Env
+	queryLists []*QueryList
+
+	enabled           bool
+	dryRun            bool
+	maxExecutionTimes [planbuilder.NumPlans]time.Duration
+	maxTmpDiskUsage   int64
+	maxRowsExamined   int64
+
+	ticks *timer.Timer
+	kills *stats.CountersWithMultiLabels
+
+	// killed are the queries already killed, or reported in dry-run mode,
+	// so that they are only killed once.
+	killed map[*QueryDetail]bool
+}
+
+func newQueryKiller(env tabletenv.Env) *queryKiller {
+	config := env.Config().QueryKiller
+	qk := &queryKiller{
+		env:             env,
+		enabled:         config.Mode == tabletenv.Enable || config.Mode == tabletenv.Dryrun,
+		dryRun:          config.Mode == tabletenv.Dryrun,
+		maxTmpDiskUsage: config.MaxTmpDiskUsage,
+		maxRowsExamined: config.MaxRowsExamined,
+		ticks:           timer.NewTimer(config.Interval),
+		kills:           env.Exporter().NewCountersWithMultiLabels("QueryKillerKills", "Queries killed by the query killer, or which would have been in dry-run mode", []string{"Reason", "DryRun"}),
+		killed:          make(map[*QueryDetail]bool),
+	}
+	for plan, maxExecutionTime := range config.MaxExecutionTimes {
+		pt, ok := planbuilder.PlanByName(plan)
+		if !ok {
+			log.Warningf("Query killer: ignoring the max execution time of unknown plan type %s", plan)
+			continue
+		}
+		qk.maxExecutionTimes[pt] = maxExecutionTime
+	}
+	return qk
+}
+
+// watch sets the lists of the running queries the query killer checks.
+func (qk *queryKiller) watch(queryLists ...*QueryList) {
+	qk.queryLists = queryLists
+}
+
+// Open starts the periodic checks of the running queries.
+func (qk *queryKiller) Open() {
+	if !qk.enabled {
+		return
+	}
+	qk.ticks.Start(qk.check)
+}
+
+// Close stops the periodic checks of the running queries.
+func (qk *queryKiller) Close() {
+	qk.ticks.Stop()
+}
+
+// check kills the running queries exceeding the thresholds.
+func (qk *queryKiller) check() {
+	defer qk.env.LogError()
+
+	var queries []*QueryDetail
+	for _, ql := range qk.queryLists {
+		queries = append(queries, ql.runningQueries()...)
+	}
+	running := make(map[*QueryDetail]bool, len(queries))
+	for _, qd := range queries {
+		running[qd] = true
+	}
+	for qd := range qk.killed {
+		if !running[qd] {
+			delete(qk.killed, qd)
+		}
+	}
+
+	byConnID := make(map[int64][]*QueryDetail)
+	for _, qd := range queries {
+		if qk.killed[qd] {
+			continue
+		}
+		elapsed := time.Since(qd.start)
+		if maxExecutionTime := qk.maxExecutionTimes[qd.planType]; maxExecutionTime > 0 && elapsed > maxExecutionTime {
+			qk.kill(qd, "ExecutionTime", fmt.Sprintf("execution time exceeded %v", maxExecutionTime))
+			continue
+		}
+		byConnID[qd.connID] = append(byConnID[qd.connID], qd)
+	}
+	if len(byConnID) == 0 || (qk.maxTmpDiskUsage == 0 && qk.maxRowsExamined == 0) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), qk.env.Config().QueryKiller.Interval)
+	defer cancel()
+	conn, err := dbconnpool.NewDBConnection(ctx, qk.env.Config().DB.DbaWithDB())
+	if err != nil {
+		log.Warningf("Query killer: failed to connect to MySQL: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ids := make([]string, 0, len(byConnID))
+	for connID := range byConnID {
+		ids = append(ids, strconv.FormatInt(connID, 10))
+	}
+	connIDs := strings.Join(ids, ", ")
+	if qk.maxTmpDiskUsage > 0 {
+		qk.killExceeding(conn, byConnID, fmt.Sprintf(sqlTmpDiskUsage, connIDs, qk.maxTmpDiskUsage), "TmpDiskUsage", "on-disk temporary tables size", qk.maxTmpDiskUsage)
+	}
+	if qk.maxRowsExamined > 0 {
+		qk.killExceeding(conn, byConnID, fmt.Sprintf(sqlRowsExamined, connIDs, qk.maxRowsExamined), "RowsExamined", "number of examined rows", qk.maxRowsExamined)
+	}
+}
+
+// killExceeding kills the queries of the connections returned by the query,
+// which returns connection IDs along with their value of the resource
+// exceeding the threshold.
+func (qk *queryKiller) killExceeding(conn *dbconnpool.DBConnection, byConnID map[int64][]*QueryDetail, query, reason, resource string, threshold int64) {
+	qr, err := conn.ExecuteFetch(query, len(byConnID), false)
+	if err != nil {
+		log.Warningf("Query killer: failed to check the %s of the running queries: %v", resource, err)
+		return
+	}
+	for _, row := range qr.Rows {
+		connID, err := row[0].ToCastInt64()
+		if err != nil {
+			continue
+		}
+		value, _ := row[1].ToCastInt64()
+		for _, qd := range byConnID[connID] {
+			if !qk.killed[qd] {
+				qk.kill(qd, reason, fmt.Sprintf("%s %d exceeded %d", resource, value, threshold))
+			}
+		}
+	}
+}
+
+// kill kills a query, or only logs it in dry-run mode. Its log lines are
+// the audit log of the query killer.
+func (qk *queryKiller) kill(qd *QueryDetail, reason, detail string) {
+	qk.killed[qd] = true
+	qk.kills.Add([]string{reason, strconv.FormatBool(qk.dryRun)}, 1)
+
+	elapsed := time.Since(qd.start)
+	query := qk.env.Environment().Parser().TruncateForLog(qd.conn.Current())
+	if qk.env.Config().SanitizeLogMessages {
+		query, _ = qk.env.Environment().Parser().RedactSQLQuery(query)
+	}
+	caller := callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(qd.ctx))
+	if qk.dryRun {
+		log.Infof("Query killer (dry run): would have killed the %s query of %q on connection ID %d after %v, as its %s: %s", qd.planType, caller, qd.connID, elapsed, detail, query)
+		return
+	}
+	log.Warningf("Query killer: killing the %s query of %q on connection ID %d after %v, as its %s: %s", qd.planType, caller, qd.connID, elapsed, detail, query)
+	if err := qd.conn.Kill("query killer: "+detail, elapsed); err != nil {
+		log.Warningf("Query killer: error killing query on connection ID %d: %v", qd.connID, err)
+	}
+}
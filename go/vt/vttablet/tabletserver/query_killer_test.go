@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+func newTestQueryKiller(t *testing.T, mode string) (*queryKiller, *QueryList) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.QueryKiller.Mode = mode
+	cfg.QueryKiller.MaxExecutionTimes = map[string]time.Duration{
+		"Select":  time.Minute,
+		"Unknown": time.Minute,
+	}
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name())
+	qk := newQueryKiller(env)
+	ql := NewQueryList("test", sqlparser.NewTestParser())
+	qk.watch(ql)
+	return qk, ql
+}
+
+func addTestQuery(t *testing.T, ql *QueryList, connID int64, planType planbuilder.PlanType, elapsed time.Duration) *testConn {
+	conn := &testConn{id: connID, query: "select * from t"}
+	qd := NewQueryDetail(context.Background(), conn)
+	qd.planType = planType
+	qd.start = time.Now().Add(-elapsed)
+	require.NoError(t, ql.Add(qd))
+	return conn
+}
+
+func TestQueryKillerExecutionTime(t *testing.T) {
+	qk, ql := newTestQueryKiller(t, tabletenv.Enable)
+	assert.True(t, qk.enabled)
+	assert.False(t, qk.dryRun)
+
+	slowSelect := addTestQuery(t, ql, 1, planbuilder.PlanSelect, 2*time.Minute)
+	fastSelect := addTestQuery(t, ql, 2, planbuilder.PlanSelect, time.Second)
+	slowInsert := addTestQuery(t, ql, 3, planbuilder.PlanInsert, 2*time.Minute)
+
+	qk.check()
+	assert.True(t, slowSelect.killed)
+	assert.False(t, fastSelect.killed)
+	assert.False(t, slowInsert.killed, "no max execution time for inserts")
+	assert.EqualValues(t, 1, qk.kills.Counts()["ExecutionTime.false"])
+
+	// A query is only killed once.
+	qk.check()
+	assert.EqualValues(t, 1, qk.kills.Counts()["ExecutionTime.false"])
+	assert.Len(t, qk.killed, 1)
+
+	// The killed queries are forgotten once they stop running.
+	for _, qd := range ql.runningQueries() {
+		ql.Remove(qd)
+	}
+	qk.check()
+	assert.Empty(t, qk.killed)
+}
+
+func TestQueryKillerDryRun(t *testing.T) {
+	qk, ql := newTestQueryKiller(t, tabletenv.Dryrun)
+	assert.True(t, qk.enabled)
+	assert.True(t, qk.dryRun)
+
+	slowSelect := addTestQuery(t, ql, 1, planbuilder.PlanSelect, 2*time.Minute)
+
+	qk.check()
+	assert.False(t, slowSelect.killed)
+	assert.EqualValues(t, 1, qk.kills.Counts()["ExecutionTime.true"])
+}
+
+func TestQueryKillerDisabled(t *testing.T) {
+	qk, _ := newTestQueryKiller(t, tabletenv.Disable)
+	assert.False(t, qk.enabled)
+
+	qk.Open()
+	assert.False(t, qk.ticks.Running())
+	qk.Close()
+}
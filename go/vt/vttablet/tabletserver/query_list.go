@@ -30,6 +30,7 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 )
 
 // QueryDetail is a simple wrapper for Query, Context and a killable conn.
@@ -38,6 +39,8 @@ type QueryDetail struct {
 	conn   killable
 	connID int64
 	start  time.Time
+	// planType is the plan type of the query, for the query killer.
+	planType planbuilder.PlanType
 }
 
 type killable interface {
@@ -131,6 +134,17 @@ func (ql *QueryList) Remove(qd *QueryDetail) {
 	}
 }
 
+// runningQueries returns the QueryDetails of the running queries.
+func (ql *QueryList) runningQueries() []*QueryDetail {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+	var qds []*QueryDetail
+	for _, connQDs := range ql.queryDetails {
+		qds = append(qds, connQDs...)
+	}
+	return qds
+}
+
 // Terminate updates the query status and kills the connection
 func (ql *QueryList) Terminate(connID int64) bool {
 	ql.mu.Lock()
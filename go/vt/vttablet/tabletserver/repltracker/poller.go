@@ -21,6 +21,7 @@ import (
 	"sync"
 	"time"
 
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -36,6 +37,9 @@ type poller struct {
 	mu           sync.Mutex
 	lag          time.Duration
 	timeRecorded time.Time
+	// estimator smooths the lag reported by mysqld, which drops to 0
+	// whenever the replica catches up with its relay log.
+	estimator replication.LagEstimator
 }
 
 func (p *poller) InitDBConfig(mysqld mysqlctl.MysqlDaemon) {
@@ -65,8 +69,10 @@ func (p *poller) Status() (time.Duration, error) {
 		return time.Since(p.timeRecorded) + p.lag, nil
 	}
 
-	p.lag = time.Duration(status.ReplicationLagSeconds) * time.Second
-	p.timeRecorded = time.Now()
+	now := time.Now()
+	p.estimator.RecordStatus(status, now)
+	p.lag = p.estimator.Lag(now)
+	p.timeRecorded = now
 	replicationLagSeconds.Set(int64(p.lag.Seconds()))
 	return p.lag, nil
 }
@@ -52,3 +52,35 @@ func TestPoller(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Less(t, int64(1*time.Second), int64(lag))
 }
+
+func TestPollerLagEstimation(t *testing.T) {
+	poller := &poller{}
+	poller.estimator.GracePeriod = time.Hour
+	mysqld := mysqlctl.NewFakeMysqlDaemon(nil)
+	poller.InitDBConfig(mysqld)
+	mysqld.Replicating = true
+
+	mysqld.ReplicationLagSeconds = 10
+	lag, err := poller.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, lag)
+
+	// The replica caught up with its relay log, but it may still be behind.
+	mysqld.ReplicationLagSeconds = 0
+	lag, err = poller.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, lag)
+
+	poller.estimator.GracePeriod = 0
+	lag, err = poller.Status()
+	assert.NoError(t, err)
+	assert.Zero(t, lag)
+
+	// The lag is measured from the last heartbeat applied by the replica,
+	// rather than rounded to the second.
+	mysqld.ReplicationLagSeconds = 3
+	mysqld.HeartbeatTime = time.Now().Add(-3500 * time.Millisecond)
+	lag, err = poller.Status()
+	assert.NoError(t, err)
+	assert.InDelta(t, 3500*time.Millisecond, lag, float64(100*time.Millisecond))
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package repltracker
 
 import (
+	"context"
 	"sync"
 	"time"
 
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/mysqlctl"
@@ -61,6 +63,7 @@ type ReplTracker struct {
 	hw     *heartbeatWriter
 	hr     *heartbeatReader
 	poller *poller
+	mysqld mysqlctl.MysqlDaemon
 }
 
 // NewReplTracker creates a new ReplTracker.
@@ -70,7 +73,11 @@ func NewReplTracker(env tabletenv.Env, alias *topodatapb.TabletAlias) *ReplTrack
 		forceHeartbeat: env.Config().ReplicationTracker.HeartbeatOnDemand > 0,
 		hw:             newHeartbeatWriter(env, alias),
 		hr:             newHeartbeatReader(env),
-		poller:         &poller{},
+		poller: &poller{
+			// The lag is only considered gone once the replica was seen
+			// caught up by two health checks in a row.
+			estimator: replication.LagEstimator{GracePeriod: env.Config().Healthcheck.Interval},
+		},
 	}
 }
 
@@ -84,6 +91,7 @@ func (rt *ReplTracker) InitDBConfig(target *querypb.Target, mysqld mysqlctl.Mysq
 	rt.hw.InitDBConfig(target)
 	rt.hr.InitDBConfig(target)
 	rt.poller.InitDBConfig(mysqld)
+	rt.mysqld = mysqld
 }
 
 // MakePrimary must be called if the tablet type becomes PRIMARY.
@@ -144,6 +152,25 @@ func (rt *ReplTracker) Status() (time.Duration, error) {
 	return rt.poller.Status()
 }
 
+// Position reports the replication position of a replica, which is the set
+// of the GTIDs it executed. It is empty for the primary.
+func (rt *ReplTracker) Position() (string, error) {
+	rt.mu.Lock()
+	isPrimary := rt.isPrimary
+	rt.mu.Unlock()
+	if isPrimary || rt.mysqld == nil {
+		return "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pos, err := rt.mysqld.PrimaryPosition(ctx)
+	if err != nil {
+		return "", err
+	}
+	return replication.EncodePosition(pos), nil
+}
+
 // EnableHeartbeat enables or disables writes of heartbeat. This functionality
 // is only used by tests.
 func (rt *ReplTracker) EnableHeartbeat(enable bool) {
@@ -39,13 +39,25 @@ func (cached *BindVarCond) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *Limits) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Description string
+	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
+	return size
+}
 func (cached *Rule) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(320)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -79,6 +91,8 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field limits vitess.io/vitess/go/vt/vttablet/tabletserver/rules.Limits
+	size += cached.limits.CachedSize(false)
 	return size
 }
 func (cached *Rules) CachedSize(alloc bool) int64 {
@@ -170,6 +170,7 @@ func (qrs *Rules) FilterByPlan(query string, planid planbuilder.PlanType, tableN
 }
 
 // GetAction runs the input against the rules engine and returns the action to be performed.
+// QRLimit rules don't stop the evaluation: their limits are returned by GetLimits.
 func (qrs *Rules) GetAction(
 	ip,
 	user string,
@@ -181,13 +182,44 @@ func (qrs *Rules) GetAction(
 	timeout time.Duration,
 	desc string) {
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
+		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue && act != QRLimit {
 			return act, qr.cancelCtx, qr.timeout, qr.Description
 		}
 	}
 	return QRContinue, nil, 0, ""
 }
 
+// GetLimits runs the input against the rules engine and returns the limits
+// of all the QRLimit rules it matches. When several rules set the same limit,
+// the smallest one applies.
+func (qrs *Rules) GetLimits(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) (limits Limits) {
+	for _, qr := range qrs.rules {
+		if qr.act != QRLimit || qr.GetAction(ip, user, bindVars, marginComments) != QRLimit {
+			continue
+		}
+		limits.QueryTimeout = minLimit(limits.QueryTimeout, qr.limits.QueryTimeout)
+		limits.MaxRows = minLimit(limits.MaxRows, qr.limits.MaxRows)
+		limits.MaxMemory = minLimit(limits.MaxMemory, qr.limits.MaxMemory)
+		if limits.Description == "" {
+			limits.Description = qr.Description
+		}
+	}
+	return limits
+}
+
+// minLimit returns the smallest of two limits, zero meaning no limit.
+func minLimit[T time.Duration | int64](a, b T) T {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
 // -----------------------------------------------
 
 // Rule represents one rule (conditions-action).
@@ -224,6 +256,29 @@ type Rule struct {
 
 	// a rule can timeout.
 	timeout time.Duration
+
+	// limits set by a QRLimit rule on the queries it matches.
+	limits Limits
+}
+
+// Limits are the limits QRLimit rules set on the queries they match.
+// Zero values don't limit the queries.
+type Limits struct {
+	// QueryTimeout is the timeout of the queries, if shorter than the
+	// timeout they otherwise have.
+	QueryTimeout time.Duration
+	// MaxRows is the maximum number of rows the queries can return, if
+	// lower than the max result size of the tablet.
+	MaxRows int64
+	// MaxMemory is the maximum size of the results of the queries, in bytes.
+	MaxMemory int64
+	// Description is the description of the first rule setting the limits.
+	Description string
+}
+
+// IsZero returns true if the limits don't limit the queries.
+func (l Limits) IsZero() bool {
+	return l.QueryTimeout == 0 && l.MaxRows == 0 && l.MaxMemory == 0
 }
 
 type namedRegexp struct {
@@ -250,6 +305,12 @@ func NewQueryRule(description, name string, act Action) (qr *Rule) {
 	return &Rule{Description: description, Name: name, act: act}
 }
 
+// SetLimits sets the limits of a QRLimit rule.
+func (qr *Rule) SetLimits(limits Limits) {
+	limits.Description = ""
+	qr.limits = limits
+}
+
 // NewBufferedTableQueryRule creates a new buffer Rule.
 func NewBufferedTableQueryRule(cancelCtx context.Context, tableName string, bufferTimeout time.Duration, description string) (qr *Rule) {
 	// We ignore act because there's only one action right now
@@ -269,6 +330,7 @@ func (qr *Rule) Equal(other *Rule) bool {
 		qr.leadingComment.Equal(other.leadingComment) &&
 		qr.trailingComment.Equal(other.trailingComment) &&
 		qr.timeout == other.timeout &&
+		qr.limits == other.limits &&
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
@@ -288,6 +350,7 @@ func (qr *Rule) Copy() (newqr *Rule) {
 		act:             qr.act,
 		cancelCtx:       qr.cancelCtx,
 		timeout:         qr.timeout,
+		limits:          qr.limits,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -339,6 +402,15 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.limits.QueryTimeout != 0 {
+		safeEncode(b, `,"QueryTimeout":`, qr.limits.QueryTimeout.String())
+	}
+	if qr.limits.MaxRows != 0 {
+		safeEncode(b, `,"MaxRows":`, qr.limits.MaxRows)
+	}
+	if qr.limits.MaxMemory != 0 {
+		safeEncode(b, `,"MaxMemory":`, qr.limits.MaxMemory)
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
@@ -457,6 +529,14 @@ Error:
 	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid operator %v for type %T (%v)", op, value, value)
 }
 
+// AddQueryAttributeCond adds a query attribute restriction to the Rule.
+// Query attributes are forwarded by vtgate as bind variables, so this
+// is equivalent to AddBindVarCond on the bind variable that carries
+// the attribute, and follows the same value & operator rules.
+func (qr *Rule) AddQueryAttributeCond(name string, onAbsent, onMismatch bool, op Operator, value any) error {
+	return qr.AddBindVarCond(sqlparser.QueryAttributeName+name, onAbsent, onMismatch, op, value)
+}
+
 // FilterByPlan returns a new Rule if the query and planid match.
 // The new Rule will contain all the original constraints other
 // than the plan and query. If the plan and query don't match the Rule,
@@ -573,6 +653,7 @@ const (
 	QRFail
 	QRFailRetry
 	QRBuffer
+	QRLimit
 )
 
 // MarshalJSON marshals to JSON.
@@ -586,6 +667,8 @@ func (act Action) MarshalJSON() ([]byte, error) {
 		str = "FAIL_RETRY"
 	case QRBuffer:
 		str = "BUFFER"
+	case QRLimit:
+		str = "LIMIT"
 	default:
 		str = "INVALID"
 	}
@@ -876,16 +959,30 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 		var lv []any
 		var ok bool
 		switch k {
-		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment":
+		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment", "QueryTimeout":
 			sv, ok = v.(string)
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for %s", k)
 			}
-		case "Plans", "BindVarConds", "TableNames":
+		case "Plans", "BindVarConds", "QueryAttributeConds", "TableNames":
 			lv, ok = v.([]any)
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "MaxRows", "MaxMemory":
+			nv, ok := v.(json.Number)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want number for %s", k)
+			}
+			iv, err := nv.Int64()
+			if err != nil || iv <= 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive integer for %s: %v", k, nv)
+			}
+			if k == "MaxRows" {
+				qr.limits.MaxRows = iv
+			} else {
+				qr.limits.MaxMemory = iv
+			}
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -950,6 +1047,17 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 					return nil, err
 				}
 			}
+		case "QueryAttributeConds":
+			for _, qac := range lv {
+				name, onAbsent, onMismatch, op, value, err := buildBindVarCondition(qac)
+				if err != nil {
+					return nil, err
+				}
+				err = qr.AddQueryAttributeCond(name, onAbsent, onMismatch, op, value)
+				if err != nil {
+					return nil, err
+				}
+			}
 		case "Action":
 			switch sv {
 			case "FAIL":
@@ -958,11 +1066,25 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				qr.act = QRFailRetry
 			case "BUFFER":
 				qr.act = QRBuffer
+			case "LIMIT":
+				qr.act = QRLimit
 			default:
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
+		case "QueryTimeout":
+			timeout, err := time.ParseDuration(sv)
+			if err != nil || timeout <= 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive duration for QueryTimeout: %v", sv)
+			}
+			qr.limits.QueryTimeout = timeout
 		}
 	}
+	if qr.act == QRLimit && qr.limits.IsZero() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "LIMIT Action needs QueryTimeout, MaxRows or MaxMemory")
+	}
+	if qr.act != QRLimit && !qr.limits.IsZero() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "QueryTimeout, MaxRows and MaxMemory need the LIMIT Action")
+	}
 	return qr, nil
 }
 
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -586,6 +587,73 @@ func TestAction(t *testing.T) {
 	assert.Equalf(t, desc, "rule 5", "want rule 5, got %s", desc)
 }
 
+func TestLimits(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[
+		{"Description": "bound reports", "TableNames": ["reports"], "Action": "LIMIT", "QueryTimeout": "30s", "MaxRows": 1000},
+		{"Description": "bound analysts", "User": "analyst", "Action": "LIMIT", "QueryTimeout": "10s", "MaxMemory": 1048576},
+		{"Description": "deny intruders", "User": "intruder", "Action": "FAIL"}
+	]`))
+	require.NoError(t, err)
+
+	data, err := qrs.MarshalJSON()
+	require.NoError(t, err)
+	other := New()
+	require.NoError(t, other.UnmarshalJSON(data))
+	assert.True(t, qrs.Equal(other), "round-trip of %s", data)
+
+	reports := qrs.FilterByPlan("select * from reports", planbuilder.PlanSelect, "reports")
+	limits := reports.GetLimits("", "user", nil, sqlparser.MarginComments{})
+	assert.Equal(t, Limits{QueryTimeout: 30 * time.Second, MaxRows: 1000, Description: "bound reports"}, limits)
+
+	// The smallest of each limit applies.
+	limits = reports.GetLimits("", "analyst", nil, sqlparser.MarginComments{})
+	assert.Equal(t, Limits{QueryTimeout: 10 * time.Second, MaxRows: 1000, MaxMemory: 1048576, Description: "bound reports"}, limits)
+
+	// LIMIT rules don't end the evaluation of the other actions.
+	action, _, _, desc := reports.GetAction("", "analyst", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRContinue, action)
+	assert.Empty(t, desc)
+
+	others := qrs.FilterByPlan("select * from t", planbuilder.PlanSelect, "t")
+	assert.True(t, others.GetLimits("", "user", nil, sqlparser.MarginComments{}).IsZero())
+}
+
+func TestQueryAttributeConds(t *testing.T) {
+	qrs := New()
+	jsondata := `[{
+		"Name": "r1",
+		"Description": "rule 1",
+		"QueryAttributeConds": [{
+			"Name": "priority",
+			"OnAbsent": false,
+			"OnMismatch": false,
+			"Operator": "==",
+			"Value": "low"
+		}],
+		"Action": "FAIL"
+	}]`
+	err := qrs.UnmarshalJSON([]byte(jsondata))
+	require.NoError(t, err)
+
+	qr := qrs.Find("r1")
+	require.NotNil(t, qr)
+	assert.Equal(t, sqlparser.QueryAttributeName+"priority", qr.bindVarConds[0].name)
+
+	bv := make(map[string]*querypb.BindVariable)
+	action, _, _, _ := qrs.GetAction("123", "user", bv, sqlparser.MarginComments{})
+	assert.Equal(t, QRContinue, action)
+
+	bv[sqlparser.QueryAttributeName+"priority"] = sqltypes.StringBindVariable("high")
+	action, _, _, _ = qrs.GetAction("123", "user", bv, sqlparser.MarginComments{})
+	assert.Equal(t, QRContinue, action)
+
+	bv[sqlparser.QueryAttributeName+"priority"] = sqltypes.StringBindVariable("low")
+	action, _, _, desc := qrs.GetAction("123", "user", bv, sqlparser.MarginComments{})
+	assert.Equal(t, QRFail, action)
+	assert.Equal(t, "rule 1", desc)
+}
+
 func TestImport(t *testing.T) {
 	var qrs = New()
 	jsondata := `[{
@@ -734,6 +802,12 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"QueryTimeout": 1 }]`, "want string for QueryTimeout"},
+	{`[{"Action": "LIMIT", "QueryTimeout": "1" }]`, "want positive duration for QueryTimeout: 1"},
+	{`[{"Action": "LIMIT", "MaxRows": "1" }]`, "want number for MaxRows"},
+	{`[{"Action": "LIMIT", "MaxMemory": -1 }]`, "want positive integer for MaxMemory: -1"},
+	{`[{"Action": "LIMIT" }]`, "LIMIT Action needs QueryTimeout, MaxRows or MaxMemory"},
+	{`[{"Action": "FAIL", "MaxRows": 10 }]`, "QueryTimeout, MaxRows and MaxMemory need the LIMIT Action"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -152,6 +152,7 @@ type (
 		MakeNonPrimary()
 		Close()
 		Status() (time.Duration, error)
+		Position() (string, error)
 	}
 
 	queryEngine interface {
@@ -704,11 +705,34 @@ func (sm *stateManager) handleTransitionGracePeriod(tabletType topodatapb.Tablet
 // Broadcast fetches the replication status and broadcasts
 // the state to all subscribed.
 func (sm *stateManager) Broadcast() {
+	// The replication position is read from MySQL, so it is read before
+	// taking the lock, which would otherwise block the state changes.
+	pos := sm.replicationPosition()
+
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
+	if sm.target.TabletType == topodatapb.TabletType_PRIMARY {
+		// The tablet was promoted in the meantime.
+		pos = ""
+	}
 	lag, err := sm.refreshReplHealthLocked()
-	sm.hs.ChangeState(sm.target.TabletType, sm.ptsTimestamp, lag, err, sm.isServingLocked())
+	sm.hs.ChangeState(sm.target.TabletType, sm.ptsTimestamp, lag, pos, err, sm.isServingLocked())
+}
+
+// replicationPosition returns the replication position of a replica, which
+// lets the vtgates route the reads that must see some writes to the replicas that
+// already applied them. It is empty for the primary, or if it can't be read.
+func (sm *stateManager) replicationPosition() string {
+	if sm.Target().TabletType == topodatapb.TabletType_PRIMARY {
+		return ""
+	}
+	pos, err := sm.rt.Position()
+	if err != nil {
+		log.Warningf("Failed to read the replication position: %v", err)
+		return ""
+	}
+	return pos
 }
 
 func (sm *stateManager) refreshReplHealthLocked() (time.Duration, error) {
@@ -668,6 +668,31 @@ func TestStateManagerNotify(t *testing.T) {
 	sm.StopService()
 }
 
+func TestStateManagerBroadcastPosition(t *testing.T) {
+	sm := newTestStateManager(t)
+	defer sm.StopService()
+	rt := sm.rt.(*testReplTracker)
+	rt.position = "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"
+	// The position is read from MySQL, so it must be read without the lock.
+	rt.positionHook = func() {
+		locked := sm.mu.TryLock()
+		assert.True(t, locked, "the replication position was read while holding the lock")
+		if locked {
+			sm.mu.Unlock()
+		}
+	}
+
+	err := sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, "")
+	require.NoError(t, err)
+	sm.Broadcast()
+	assert.Equal(t, rt.position, sm.hs.state.RealtimeStats.ReplicationPosition)
+
+	err = sm.SetServingType(topodatapb.TabletType_PRIMARY, testNow, StateServing, "")
+	require.NoError(t, err)
+	sm.Broadcast()
+	assert.Empty(t, sm.hs.state.RealtimeStats.ReplicationPosition)
+}
+
 func TestRefreshReplHealthLocked(t *testing.T) {
 	sm := newTestStateManager(t)
 	defer sm.StopService()
@@ -838,8 +863,11 @@ func (te *testSchemaEngine) Close() {
 
 type testReplTracker struct {
 	testOrderState
-	lag time.Duration
-	err error
+	lag      time.Duration
+	err      error
+	position string
+	// positionHook is called when the position is read, if not nil.
+	positionHook func()
 }
 
 func (te *testReplTracker) MakePrimary() {
@@ -861,6 +889,13 @@ func (te *testReplTracker) Status() (time.Duration, error) {
 	return te.lag, te.err
 }
 
+func (te *testReplTracker) Position() (string, error) {
+	if te.positionHook != nil {
+		te.positionHook()
+	}
+	return te.position, nil
+}
+
 type testQueryEngine struct {
 	testOrderState
 
@@ -69,6 +69,9 @@ func NewStatefulConnPool(env tabletenv.Env) *StatefulConnectionPool {
 		foundRowsPool: connpool.NewPool(env, "FoundRowsPool", config.TxPool),
 		active:        pools.NewNumbered(),
 	}
+	// The GTIDs of the transactions are returned to vtgate for read-your-writes.
+	scp.conns.TrackGTIDs()
+	scp.foundRowsPool.TrackGTIDs()
 	scp.lastID.Store(time.Now().UnixNano())
 	return scp
 }
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/topo"
+
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+)
+
+// sleepDuringTableACLTopoFailure is how long to sleep before watching the
+// table ACL again in case of error.
+// (it's a var not a const so the test can change the value).
+var sleepDuringTableACLTopoFailure = 30 * time.Second
+
+// topoTableACL watches the table ACL of the cluster in the topo and applies
+// its changes to the tablet. An invalid or deleted table ACL is ignored,
+// keeping the last valid one.
+type topoTableACL struct {
+	ts *topo.Server
+
+	// config is the table ACL which was last applied.
+	config *tableaclpb.Config
+
+	// mu protects the following variables.
+	mu sync.Mutex
+
+	// cancel is the function to call to cancel the current watch, if any.
+	cancel func()
+
+	// stopped is set when stop() is called.
+	stopped bool
+}
+
+// InitTopoACL loads the table ACL from the topo, then reloads it whenever it
+// changes. If enforceTableACLConfig is set, the tablet exits when there's no
+// valid initial table ACL.
+func (tsv *TabletServer) InitTopoACL(enforceTableACLConfig bool) {
+	tt := tsv.initTopoACL(enforceTableACLConfig)
+	servenv.OnTerm(tt.stop)
+}
+
+func (tsv *TabletServer) initTopoACL(enforceTableACLConfig bool) *topoTableACL {
+	// Init sets the callback clearing the plans, without loading any file.
+	_ = tableacl.Init("", func() {
+		tsv.ClearQueryPlanCache()
+	})
+
+	tt := &topoTableACL{ts: tsv.topoServer}
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	config, err := tt.ts.GetTableACL(ctx)
+	cancel()
+	if err == nil && config == nil {
+		err = errors.New("no table ACL in the topo")
+	}
+	if err == nil {
+		err = tt.apply(config)
+	}
+	if err != nil {
+		log.Errorf("Fail to initialize Table ACL from topo: %v", err)
+		if enforceTableACLConfig {
+			log.Exit("Need a valid initial Table ACL when enforce-tableacl-config is set, exiting.")
+		}
+	}
+	tt.start()
+	return tt
+}
+
+func (tt *topoTableACL) start() {
+	go func() {
+		for {
+			if err := tt.oneWatch(); err != nil && !topo.IsErrType(err, topo.Interrupted) {
+				log.Warningf("Background watch of table ACL failed: %v", err)
+			}
+
+			tt.mu.Lock()
+			stopped := tt.stopped
+			tt.mu.Unlock()
+			if stopped {
+				return
+			}
+
+			time.Sleep(sleepDuringTableACLTopoFailure)
+		}
+	}()
+}
+
+func (tt *topoTableACL) stop() {
+	tt.mu.Lock()
+	if tt.cancel != nil {
+		tt.cancel()
+	}
+	tt.stopped = true
+	tt.mu.Unlock()
+}
+
+// apply applies the table ACL if it changed since the last one applied.
+func (tt *topoTableACL) apply(config *tableaclpb.Config) error {
+	if proto.Equal(tt.config, config) {
+		return nil
+	}
+	if err := tableacl.InitFromProto(config); err != nil {
+		return err
+	}
+	tt.config = config
+	log.Infof("Table ACL fetched from topo and applied to vttablet")
+	return nil
+}
+
+func (tt *topoTableACL) oneWatch() error {
+	defer func() {
+		// Whatever happens, cancel() won't be valid after this function exits.
+		tt.mu.Lock()
+		tt.cancel = nil
+		tt.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	current, changes, err := tt.ts.WatchTableACL(ctx)
+	if err != nil {
+		return err
+	}
+
+	tt.mu.Lock()
+	if tt.stopped {
+		// We're not interested in the result any more.
+		tt.mu.Unlock()
+		cancel()
+		for range changes {
+		}
+		return topo.NewError(topo.Interrupted, "watch")
+	}
+	tt.cancel = cancel
+	tt.mu.Unlock()
+
+	if err := tt.apply(current.Value); err != nil {
+		log.Errorf("Ignoring invalid table ACL from topo: %v", err)
+	}
+	for change := range changes {
+		if change.Err != nil {
+			// Last error value, we're done.
+			// changes will be closed right after
+			// this, no need to do anything.
+			return change.Err
+		}
+		if err := tt.apply(change.Value); err != nil {
+			log.Errorf("Ignoring invalid table ACL from topo: %v", err)
+		}
+	}
+	return errors.New("watch terminated with no error")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestTopoACL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int64())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	srvTopoCounts := stats.NewCountersWithSingleLabel("", "Resilient srvtopo server operations", "type")
+	tsv := NewTabletServer(ctx, vtenv.NewTestEnv(), "TabletServerTest", tabletenv.NewDefaultConfig(), ts, &topodatapb.TabletAlias{}, srvTopoCounts)
+
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "group01",
+			TableNamesOrPrefixes: []string{"test_table"},
+			Readers:              []string{"group:readers"},
+		}},
+		UserGroups: []*tableaclpb.UserGroupSpec{{
+			Name:  "readers",
+			Users: []string{"u1"},
+		}},
+	}
+	require.NoError(t, ts.SaveTableACL(ctx, config))
+
+	tt := tsv.initTopoACL(true)
+	defer tt.stop()
+	assert.Equal(t, "group01", tableacl.GetCurrentConfig().TableGroups[0].Name)
+	assert.True(t, tableacl.Authorized("test_table", tableacl.READER).IsMember(&querypb.VTGateCallerID{Username: "u1"}))
+
+	// Changes of the table ACL are applied.
+	config.UserGroups[0].Users = []string{"u2"}
+	require.NoError(t, ts.SaveTableACL(ctx, config))
+	assert.Eventually(t, func() bool {
+		return tableacl.Authorized("test_table", tableacl.READER).IsMember(&querypb.VTGateCallerID{Username: "u2"})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// An invalid table ACL is ignored.
+	config.UserGroups = nil
+	require.NoError(t, ts.SaveTableACL(ctx, config))
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, tableacl.GetCurrentConfig().UserGroups, 1)
+	assert.True(t, tableacl.Authorized("test_table", tableacl.READER).IsMember(&querypb.VTGateCallerID{Username: "u2"}))
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -69,6 +70,12 @@ var (
 	// The following vars are used for custom initialization of Tabletconfig.
 	enableHotRowProtection       bool
 	enableHotRowProtectionDryRun bool
+	hotRowProtectionKeyExprs     []string
+	enableQueryKiller            bool
+	enableQueryKillerDryRun      bool
+	queryKillerMaxExecTimes      []string
+	enableTxLagThrottler         bool
+	enableTxLagThrottlerDryRun   bool
 	enableConsolidator           bool
 	enableConsolidatorReplicas   bool
 	enableHeartbeat              bool
@@ -181,6 +188,19 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&currentConfig.HotRowProtection.MaxQueueSize, "hot_row_protection_max_queue_size", defaultConfig.HotRowProtection.MaxQueueSize, "Maximum number of BeginExecute RPCs which will be queued for the same row (range).")
 	fs.IntVar(&currentConfig.HotRowProtection.MaxGlobalQueueSize, "hot_row_protection_max_global_queue_size", defaultConfig.HotRowProtection.MaxGlobalQueueSize, "Global queue limit across all row (ranges). Useful to prevent that the queue can grow unbounded.")
 	fs.IntVar(&currentConfig.HotRowProtection.MaxConcurrency, "hot_row_protection_concurrent_transactions", defaultConfig.HotRowProtection.MaxConcurrency, "Number of concurrent transactions let through to the txpool/MySQL for the same hot row. Should be > 1 to have enough 'ready' transactions in MySQL and benefit from a pipelining effect.")
+	fs.StringArrayVar(&hotRowProtectionKeyExprs, "hot_row_protection_key_expression", nil, "A table:expression pair, e.g. 'product:left(sku, 4)'. The UPDATEs and DELETEs of the table whose WHERE clause sets all the columns of the expression with equalities are serialized on the value of the expression, instead of on their WHERE clause. Can be repeated.")
+
+	fs.BoolVar(&enableQueryKiller, "enable-query-killer", false, "If true, the queries of the tablet exceeding the --query-killer-* thresholds are killed.")
+	fs.BoolVar(&enableQueryKillerDryRun, "enable-query-killer-dry-run", false, "If true, the query killer does not kill the queries exceeding its thresholds but logs that they would have been killed.")
+	fs.DurationVar(&currentConfig.QueryKiller.Interval, "query-killer-interval", defaultConfig.QueryKiller.Interval, "Interval between the checks of the running queries by the query killer.")
+	fs.StringArrayVar(&queryKillerMaxExecTimes, "query-killer-max-execution-time", nil, "A plan:duration pair, e.g. 'Select:30s'. The queries of the plan type running for longer than the duration are killed. Can be repeated.")
+	fs.Int64Var(&currentConfig.QueryKiller.MaxTmpDiskUsage, "query-killer-max-tmp-disk-usage", defaultConfig.QueryKiller.MaxTmpDiskUsage, "The queries whose on-disk temporary tables are larger than this many bytes are killed. 0 disables the check.")
+	fs.Int64Var(&currentConfig.QueryKiller.MaxRowsExamined, "query-killer-max-rows-examined", defaultConfig.QueryKiller.MaxRowsExamined, "The queries which examined more rows than this, according to performance_schema, are killed. 0 disables the check.")
+
+	fs.BoolVar(&enableTxLagThrottler, "enable-tx-lag-throttler", false, "If true, the transactions are rejected while the tablet throttler reports a replication lag above its threshold.")
+	fs.BoolVar(&enableTxLagThrottlerDryRun, "enable-tx-lag-throttler-dry-run", false, "If true, the transaction lag throttler does not reject the transactions but only records that they would have been throttled.")
+	fs.StringVar(&currentConfig.TxLagThrottler.Scope, "tx-lag-throttler-scope", defaultConfig.TxLagThrottler.Scope, "The lag checked by the transaction lag throttler: 'shard' for the lag of the replicas of the shard, 'self' for the lag of the tablet.")
+	fs.StringSliceVar(&currentConfig.TxLagThrottler.ExemptCallers, "tx-lag-throttler-exempt-callers", defaultConfig.TxLagThrottler.ExemptCallers, "A comma-separated list of caller IDs whose transactions are never throttled by the transaction lag throttler.")
 
 	fs.BoolVar(&currentConfig.EnableTransactionLimit, "enable_transaction_limit", defaultConfig.EnableTransactionLimit, "If true, limit on number of transactions open at the same time will be enforced for all users. User trying to open a new transaction after exhausting their limit will receive an error immediately, regardless of whether there are available slots or not.")
 	fs.BoolVar(&currentConfig.EnableTransactionLimitDryRun, "enable_transaction_limit_dry_run", defaultConfig.EnableTransactionLimitDryRun, "If true, limit on number of transactions open at the same time will be tracked for all users, but not enforced.")
@@ -199,6 +219,7 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	flagutil.DualFormatBoolVar(fs, &enableConsolidatorReplicas, "enable_consolidator_replicas", false, "This option enables the query consolidator only on replicas.")
 	fs.Int64Var(&currentConfig.ConsolidatorStreamQuerySize, "consolidator-stream-query-size", defaultConfig.ConsolidatorStreamQuerySize, "Configure the stream consolidator query size in bytes. Setting to 0 disables the stream consolidator.")
 	fs.Int64Var(&currentConfig.ConsolidatorStreamTotalSize, "consolidator-stream-total-size", defaultConfig.ConsolidatorStreamTotalSize, "Configure the stream consolidator total size in bytes. Setting to 0 disables the stream consolidator.")
+	fs.StringSliceVar(&currentConfig.ConsolidatorDisabledPlans, "consolidator-disabled-plans", defaultConfig.ConsolidatorDisabledPlans, "Comma separated list of plan types (e.g. Show,SelectStream) whose queries are not consolidated, unless their execute options require it.")
 
 	fs.DurationVar(&healthCheckInterval, "health_check_interval", defaultConfig.Healthcheck.Interval, "Interval between health checks")
 	fs.DurationVar(&degradedThreshold, "degraded_threshold", defaultConfig.Healthcheck.DegradedThreshold, "replication lag after which a replica is considered degraded")
@@ -243,6 +264,47 @@ func Init() {
 	} else {
 		currentConfig.HotRowProtection.Mode = Disable
 	}
+	if len(hotRowProtectionKeyExprs) > 0 {
+		currentConfig.HotRowProtection.KeyExpressions = make(map[string]string, len(hotRowProtectionKeyExprs))
+		for _, keyExpr := range hotRowProtectionKeyExprs {
+			table, expr, _ := strings.Cut(keyExpr, ":")
+			currentConfig.HotRowProtection.KeyExpressions[strings.TrimSpace(table)] = strings.TrimSpace(expr)
+		}
+	}
+
+	if enableQueryKiller {
+		if enableQueryKillerDryRun {
+			currentConfig.QueryKiller.Mode = Dryrun
+		} else {
+			currentConfig.QueryKiller.Mode = Enable
+		}
+	} else {
+		currentConfig.QueryKiller.Mode = Disable
+	}
+	if enableTxLagThrottler {
+		if enableTxLagThrottlerDryRun {
+			currentConfig.TxLagThrottler.Mode = Dryrun
+		} else {
+			currentConfig.TxLagThrottler.Mode = Enable
+		}
+	} else {
+		currentConfig.TxLagThrottler.Mode = Disable
+	}
+	if len(queryKillerMaxExecTimes) > 0 {
+		currentConfig.QueryKiller.MaxExecutionTimes = make(map[string]time.Duration, len(queryKillerMaxExecTimes))
+		for _, maxExecTime := range queryKillerMaxExecTimes {
+			plan, duration, _ := strings.Cut(maxExecTime, ":")
+			// Invalid durations are left at 0 for Verify to reject them.
+			d, _ := time.ParseDuration(strings.TrimSpace(duration))
+			currentConfig.QueryKiller.MaxExecutionTimes[strings.TrimSpace(plan)] = d
+		}
+	}
+
+	// Redacting the query literals implies terse errors and sanitized log messages.
+	if servenv.RedactQueryLiterals {
+		currentConfig.TerseErrors = true
+		currentConfig.SanitizeLogMessages = true
+	}
 
 	switch {
 	case enableConsolidatorReplicas:
@@ -324,6 +386,7 @@ type TabletConfig struct {
 	StreamBufferSize                 int           `json:"streamBufferSize,omitempty"`
 	ConsolidatorStreamTotalSize      int64         `json:"consolidatorStreamTotalSize,omitempty"`
 	ConsolidatorStreamQuerySize      int64         `json:"consolidatorStreamQuerySize,omitempty"`
+	ConsolidatorDisabledPlans        []string      `json:"consolidatorDisabledPlans,omitempty"`
 	QueryCacheMemory                 int64         `json:"queryCacheMemory,omitempty"`
 	QueryCacheDoorkeeper             bool          `json:"queryCacheDoorkeeper,omitempty"`
 	SchemaReloadInterval             time.Duration `json:"schemaReloadIntervalSeconds,omitempty"`
@@ -366,6 +429,10 @@ type TabletConfig struct {
 
 	RowStreamer RowStreamerConfig `json:"rowStreamer,omitempty"`
 
+	QueryKiller QueryKillerConfig `json:"-"`
+
+	TxLagThrottler TxLagThrottlerConfig `json:"-"`
+
 	EnableViews bool `json:"-"`
 
 	EnablePerWorkloadTableMetrics bool `json:"-"`
@@ -628,8 +695,47 @@ type HotRowProtectionConfig struct {
 	MaxQueueSize       int    `json:"maxQueueSize,omitempty"`
 	MaxGlobalQueueSize int    `json:"maxGlobalQueueSize,omitempty"`
 	MaxConcurrency     int    `json:"maxConcurrency,omitempty"`
+	// KeyExpressions are the expressions on the columns of the tables, by table,
+	// whose values are the keys on which their DMLs are serialized.
+	KeyExpressions map[string]string `json:"keyExpressions,omitempty"`
 }
 
+// QueryKillerConfig contains the config for the query killer, which kills the
+// queries exceeding its thresholds.
+type QueryKillerConfig struct {
+	// Mode can be disable, dryRun or enable. Default is disable.
+	Mode     string
+	Interval time.Duration
+	// MaxExecutionTimes are the execution times, by plan type, after which
+	// the queries are killed.
+	MaxExecutionTimes map[string]time.Duration
+	// MaxTmpDiskUsage is the size in bytes of the on-disk temporary tables
+	// of a query after which it is killed. 0 disables the check.
+	MaxTmpDiskUsage int64
+	// MaxRowsExamined is the number of examined rows after which a query
+	// is killed. 0 disables the check.
+	MaxRowsExamined int64
+}
+
+// TxLagThrottlerConfig contains the config for the transaction lag throttler,
+// which rejects the transactions while the tablet throttler reports a
+// replication lag above its threshold.
+type TxLagThrottlerConfig struct {
+	// Mode can be disable, dryRun or enable. Default is disable.
+	Mode string
+	// Scope is the lag which is checked: TxLagThrottlerScopeShard or
+	// TxLagThrottlerScopeSelf.
+	Scope string
+	// ExemptCallers are the caller IDs whose transactions are never throttled.
+	ExemptCallers []string
+}
+
+// The scopes of the lag checked by the transaction lag throttler.
+const (
+	TxLagThrottlerScopeShard = "shard"
+	TxLagThrottlerScopeSelf  = "self"
+)
+
 // HealthcheckConfig contains the config for healthcheck.
 type HealthcheckConfig struct {
 	Interval           time.Duration
@@ -889,6 +995,50 @@ func (c *TabletConfig) Verify() error {
 	if v := c.HotRowProtection.MaxConcurrency; v <= 0 {
 		return fmt.Errorf("--hot_row_protection_concurrent_transactions must be > 0 (specified value: %v)", v)
 	}
+	for table, expr := range c.HotRowProtection.KeyExpressions {
+		if table == "" || expr == "" {
+			return fmt.Errorf("--hot_row_protection_key_expression must be a table:expression pair (specified value: %v:%v)", table, expr)
+		}
+	}
+	if err := c.verifyQueryKillerConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyTxLagThrottlerConfig(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyTxLagThrottlerConfig checks transaction lag throttler related config for sanity
+func (c *TabletConfig) verifyTxLagThrottlerConfig() error {
+	if c.TxLagThrottler.Mode == Disable {
+		return nil
+	}
+	if v := c.TxLagThrottler.Scope; v != TxLagThrottlerScopeShard && v != TxLagThrottlerScopeSelf {
+		return fmt.Errorf("--tx-lag-throttler-scope must be %s or %s (specified value: %v)", TxLagThrottlerScopeShard, TxLagThrottlerScopeSelf, v)
+	}
+	return nil
+}
+
+// verifyQueryKillerConfig checks query killer related config for sanity
+func (c *TabletConfig) verifyQueryKillerConfig() error {
+	if c.QueryKiller.Mode == Disable {
+		return nil
+	}
+	if v := c.QueryKiller.Interval; v <= 0 {
+		return fmt.Errorf("--query-killer-interval must be > 0 (specified value: %v)", v)
+	}
+	for plan, d := range c.QueryKiller.MaxExecutionTimes {
+		if plan == "" || d <= 0 {
+			return fmt.Errorf("--query-killer-max-execution-time must be a plan:duration pair with a positive duration (specified value: %v:%v)", plan, d)
+		}
+	}
+	if v := c.QueryKiller.MaxTmpDiskUsage; v < 0 {
+		return fmt.Errorf("--query-killer-max-tmp-disk-usage must be >= 0 (specified value: %v)", v)
+	}
+	if v := c.QueryKiller.MaxRowsExamined; v < 0 {
+		return fmt.Errorf("--query-killer-max-rows-examined must be >= 0 (specified value: %v)", v)
+	}
 	return nil
 }
 
@@ -1041,6 +1191,14 @@ var defaultConfig = TabletConfig{
 		// of them ready in MySQL and profit from a pipelining effect.
 		MaxConcurrency: 5,
 	},
+	QueryKiller: QueryKillerConfig{
+		Mode:     Disable,
+		Interval: time.Second,
+	},
+	TxLagThrottler: TxLagThrottlerConfig{
+		Mode:  Disable,
+		Scope: TxLagThrottlerScopeShard,
+	},
 	Consolidator:                Enable,
 	ConsolidatorStreamTotalSize: 128 * 1024 * 1024,
 	ConsolidatorStreamQuerySize: 2 * 1024 * 1024,
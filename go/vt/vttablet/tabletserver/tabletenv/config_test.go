@@ -238,6 +238,51 @@ func TestFlags(t *testing.T) {
 	want.HotRowProtection.Mode = Disable
 	assert.Equal(t, want, currentConfig)
 
+	hotRowProtectionKeyExprs = []string{"product:left(sku, 4)", "user: email"}
+	Init()
+	want.HotRowProtection.KeyExpressions = map[string]string{"product": "left(sku, 4)", "user": "email"}
+	assert.Equal(t, want, currentConfig)
+	hotRowProtectionKeyExprs = nil
+	currentConfig.HotRowProtection.KeyExpressions = nil
+	want.HotRowProtection.KeyExpressions = nil
+
+	enableQueryKiller = true
+	enableQueryKillerDryRun = true
+	queryKillerMaxExecTimes = []string{"Select:30s", "SelectStream: 5m"}
+	Init()
+	want.QueryKiller.Mode = Dryrun
+	want.QueryKiller.MaxExecutionTimes = map[string]time.Duration{"Select": 30 * time.Second, "SelectStream": 5 * time.Minute}
+	assert.Equal(t, want, currentConfig)
+
+	enableQueryKillerDryRun = false
+	Init()
+	want.QueryKiller.Mode = Enable
+	assert.Equal(t, want, currentConfig)
+
+	enableQueryKiller = false
+	queryKillerMaxExecTimes = nil
+	Init()
+	currentConfig.QueryKiller.MaxExecutionTimes = nil
+	want.QueryKiller.Mode = Disable
+	want.QueryKiller.MaxExecutionTimes = nil
+	assert.Equal(t, want, currentConfig)
+
+	enableTxLagThrottler = true
+	enableTxLagThrottlerDryRun = true
+	Init()
+	want.TxLagThrottler.Mode = Dryrun
+	assert.Equal(t, want, currentConfig)
+
+	enableTxLagThrottlerDryRun = false
+	Init()
+	want.TxLagThrottler.Mode = Enable
+	assert.Equal(t, want, currentConfig)
+
+	enableTxLagThrottler = false
+	Init()
+	want.TxLagThrottler.Mode = Disable
+	assert.Equal(t, want, currentConfig)
+
 	enableConsolidator = true
 	enableConsolidatorReplicas = true
 	Init()
@@ -481,3 +526,38 @@ func TestVerifyUnmanagedTabletConfig(t *testing.T) {
 	err = config.verifyUnmanagedTabletConfig()
 	assert.Nil(t, err)
 }
+
+func TestVerifyQueryKillerConfig(t *testing.T) {
+	config := defaultConfig
+
+	// The thresholds are not checked while the query killer is disabled.
+	config.QueryKiller.MaxRowsExamined = -1
+	assert.NoError(t, config.verifyQueryKillerConfig())
+
+	config.QueryKiller.Mode = Dryrun
+	assert.EqualError(t, config.verifyQueryKillerConfig(), "--query-killer-max-rows-examined must be >= 0 (specified value: -1)")
+
+	config.QueryKiller.MaxRowsExamined = 1000000
+	config.QueryKiller.MaxExecutionTimes = map[string]time.Duration{"Select": 0}
+	assert.EqualError(t, config.verifyQueryKillerConfig(), "--query-killer-max-execution-time must be a plan:duration pair with a positive duration (specified value: Select:0s)")
+
+	config.QueryKiller.MaxExecutionTimes = map[string]time.Duration{"Select": time.Minute}
+	assert.NoError(t, config.verifyQueryKillerConfig())
+
+	config.QueryKiller.Interval = 0
+	assert.EqualError(t, config.verifyQueryKillerConfig(), "--query-killer-interval must be > 0 (specified value: 0s)")
+}
+
+func TestVerifyTxLagThrottlerConfig(t *testing.T) {
+	config := defaultConfig
+
+	// The scope is not checked while the transaction lag throttler is disabled.
+	config.TxLagThrottler.Scope = "keyspace"
+	assert.NoError(t, config.verifyTxLagThrottlerConfig())
+
+	config.TxLagThrottler.Mode = Enable
+	assert.EqualError(t, config.verifyTxLagThrottlerConfig(), "--tx-lag-throttler-scope must be shard or self (specified value: keyspace)")
+
+	config.TxLagThrottler.Scope = TxLagThrottlerScopeSelf
+	assert.NoError(t, config.verifyTxLagThrottlerConfig())
+}
@@ -118,6 +118,9 @@ type TabletServer struct {
 	lagThrottler *throttle.Throttler
 	tableGC      *gc.TableGC
 
+	// txLagThrottler throttles the transactions with lagThrottler.
+	txLagThrottler *txLagThrottler
+
 	// sm manages state transitions.
 	sm                *stateManager
 	onlineDDLExecutor *onlineddl.Executor
@@ -176,16 +179,18 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	tsv.hs = newHealthStreamer(tsv, alias, tsv.se)
 	tsv.rt = repltracker.NewReplTracker(tsv, alias)
 	tsv.lagThrottler = throttle.NewThrottler(tsv, srvTopoServer, topoServer, alias.Cell, tsv.rt.HeartbeatWriter(), tabletTypeFunc)
+	tsv.txLagThrottler = newTxLagThrottler(tsv, tsv.lagThrottler)
 	tsv.vstreamer = vstreamer.NewEngine(tsv, srvTopoServer, tsv.se, tsv.lagThrottler, alias.Cell)
 	tsv.tracker = schema.NewTracker(tsv, tsv.vstreamer, tsv.se)
 	tsv.watcher = NewBinlogWatcher(tsv, tsv.vstreamer, tsv.config)
 	tsv.qe = NewQueryEngine(tsv, tsv.se)
+	tsv.qe.queryKiller.watch(tsv.statelessql, tsv.statefulql, tsv.olapql)
 	tsv.txThrottler = txthrottler.NewTxThrottler(tsv, topoServer)
 	tsv.te = NewTxEngine(tsv)
 	tsv.messager = messager.NewEngine(tsv, tsv.se, tsv.vstreamer)
 
-	tsv.tableGC = gc.NewTableGC(tsv, topoServer, tsv.lagThrottler)
-	tsv.onlineDDLExecutor = onlineddl.NewExecutor(tsv, alias, topoServer, tsv.lagThrottler, tabletTypeFunc, tsv.onlineDDLExecutorToggleTableBuffer, tsv.tableGC.RequestChecks)
+	tsv.tableGC = gc.NewTableGC(tsv, srvTopoServer, topoServer, alias.Cell, tsv.lagThrottler)
+	tsv.onlineDDLExecutor = onlineddl.NewExecutor(tsv, alias, topoServer, tsv.lagThrottler, tabletTypeFunc, tsv.onlineDDLExecutorToggleTableBuffer, tsv.tableGC.RequestChecks, tsv.tableGC.Retention)
 
 	tsv.sm = &stateManager{
 		statelessql: tsv.statelessql,
@@ -498,7 +503,7 @@ func (tsv *TabletServer) begin(ctx context.Context, target *querypb.Target, save
 		target, options, false, /* allowOnShutdown */
 		func(ctx context.Context, logStats *tabletenv.LogStats) error {
 			startTime := time.Now()
-			if tsv.txThrottler.Throttle(tsv.getPriorityFromOptions(options), options.GetWorkloadName()) {
+			if tsv.txThrottler.Throttle(tsv.getPriorityFromOptions(options), options.GetWorkloadName()) || tsv.txLagThrottler.throttle(ctx, options) {
 				return errTxThrottled
 			}
 			var connSetting *smartconnpool.Setting
@@ -779,6 +784,26 @@ func (tsv *TabletServer) ReadTransaction(ctx context.Context, target *querypb.Ta
 	return metadata, err
 }
 
+// UnresolvedTransactions returns the distributed transactions older than abandonAgeSeconds
+// for which this tablet is the metadata manager. Zero returns all of them.
+func (tsv *TabletServer) UnresolvedTransactions(ctx context.Context, target *querypb.Target, abandonAgeSeconds int64) (transactions []*querypb.TransactionMetadata, err error) {
+	err = tsv.execRequest(
+		ctx, tsv.loadQueryTimeout(),
+		"UnresolvedTransactions", "unresolved_transactions", nil,
+		target, nil, true, /* allowOnShutdown */
+		func(ctx context.Context, logStats *tabletenv.LogStats) error {
+			txe := &TxExecutor{
+				ctx:      ctx,
+				logStats: logStats,
+				te:       tsv.te,
+			}
+			transactions, err = txe.UnresolvedTransactions(time.Duration(abandonAgeSeconds) * time.Second)
+			return err
+		},
+	)
+	return transactions, err
+}
+
 // Execute executes the query and returns the result as response.
 func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (result *sqltypes.Result, err error) {
 	span, ctx := trace.NewSpan(ctx, "TabletServer.Execute")
@@ -1043,7 +1068,8 @@ func (tsv *TabletServer) beginWaitForSameRangeTransactions(ctx context.Context,
 }
 
 // computeTxSerializerKey returns a unique string ("key") used to determine
-// whether two queries would update the same row (range).
+// whether two queries would update the same row (range), or rows with the same
+// value of the key expression of the table.
 // Additionally, it returns the table name (needed for updating stats vars).
 // It returns an empty string as key if the row (range) cannot be parsed from
 // the query and bind variables or the table name is empty.
@@ -1070,6 +1096,11 @@ func (tsv *TabletServer) computeTxSerializerKey(ctx context.Context, logStats *t
 		return "", ""
 	}
 
+	// Example: product where left(sku, 4) = 'ABCD'
+	if key, ok := tsv.qe.txSerializer.KeyFromExpression(tableName.String(), plan.WhereExpr, bindVariables); ok {
+		return key, tableName.String()
+	}
+
 	where, err := plan.WhereClause.GenerateQuery(bindVariables, nil)
 	if err != nil {
 		logComputeRowSerializerKey.Errorf("failed to substitute bind vars in where clause: %v query: %v bind vars: %v", err, sql, bindVariables)
@@ -1593,6 +1624,7 @@ func (tsv *TabletServer) handlePanicAndSendLogStats(
 	// - beginWaitForSameRangeTransactions() (Method == "")
 	// - Begin / Commit in autocommit mode
 	if logStats != nil && logStats.Method != "" {
+		logStats.OriginalSQL = tsv.env.Parser().RedactForLog(logStats.OriginalSQL)
 		logStats.Send()
 	}
 }
@@ -2092,6 +2124,17 @@ func (tsv *TabletServer) ConsolidatorMode() string {
 	return tsv.qe.consolidatorMode.Load().(string)
 }
 
+// SetConsolidatorDisabledPlans sets the plan types, by name, whose queries
+// are not consolidated unless their execute options require it.
+func (tsv *TabletServer) SetConsolidatorDisabledPlans(plans []string) error {
+	return tsv.qe.setConsolidatorDisabledPlans(plans)
+}
+
+// ConsolidatorDisabledPlans returns the plan types whose queries are not consolidated.
+func (tsv *TabletServer) ConsolidatorDisabledPlans() []string {
+	return tsv.qe.consolidatorDisabledPlanNames()
+}
+
 // queryAsString returns a readable normalized version of the query.
 // If sanitize is false it also includes the bind variables.
 // If truncateForLog is true, it truncates the sql query and the
@@ -2129,9 +2172,12 @@ func queryAsString(sql string, bindVariables map[string]*querypb.BindVariable, s
 		}
 	}
 
-	// Truncate the sql query if necessary
+	// Truncate the sql query if necessary, TruncateForLog redacting its literals
+	// like RedactForLog.
 	if truncateForLog {
 		sql = parser.TruncateForLog(sql)
+	} else {
+		sql = parser.RedactForLog(sql)
 	}
 
 	// sql is the normalized query without the bind vars
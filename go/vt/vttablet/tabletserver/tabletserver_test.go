@@ -1079,6 +1079,29 @@ func TestDMLQueryWithoutWhereClause(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestComputeTxSerializerKeyExpression(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.Mode = tabletenv.Enable
+	cfg.HotRowProtection.KeyExpressions = map[string]string{"test_table": "lower(`name`)"}
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	logStats := tabletenv.NewLogStats(ctx, "TestComputeTxSerializerKeyExpression")
+	bv := map[string]*querypb.BindVariable{"name": sqltypes.StringBindVariable("Foo")}
+
+	// The rows with the same value of the key expression are serialized together.
+	key, table := tsv.computeTxSerializerKey(ctx, logStats, "update test_table set name_string = 'x' where pk = 1 and `name` = :name", bv)
+	assert.Equal(t, "test_table where lower(`name`) = 'foo'", key)
+	assert.Equal(t, "test_table", table)
+
+	// Without the columns of the key expression, the WHERE clause is the key.
+	key, _ = tsv.computeTxSerializerKey(ctx, logStats, "delete from test_table where pk = 1", nil)
+	assert.Equal(t, "test_table where pk = 1", key)
+}
+
 func TestSerializeTransactionsSameRow_ConcurrentTransactions(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1842,6 +1865,35 @@ func TestSanitizeLogMessages(t *testing.T) {
 	}
 }
 
+func TestRedactQueryLiterals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env, err := vtenv.New(vtenv.Options{RedactQueryLiterals: true})
+	require.NoError(t, err)
+	// Redacting the query literals implies terse errors and sanitized log messages.
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.TerseErrors = true
+	cfg.SanitizeLogMessages = true
+	srvTopoCounts := stats.NewCountersWithSingleLabel("", "Resilient srvtopo server operations", "type")
+	tsv := NewTabletServer(ctx, env, "TabletServerTest", cfg, memorytopo.NewServer(ctx, ""), &topodatapb.TabletAlias{}, srvTopoCounts)
+	tl := newTestLogger()
+	defer tl.Close()
+
+	sql := "select * from test_table where xyz = 'secret' and abc = :vtg1"
+	sqlErr := sqlerror.NewSQLError(10, "HY000", "sensitive message 'secret'")
+	err = tsv.convertAndLogError(
+		ctx,
+		sql,
+		map[string]*querypb.BindVariable{"vtg1": sqltypes.StringBindVariable("secret")},
+		sqlErr,
+		nil,
+	)
+
+	want := "(errno 10) (sqlstate HY000): Sql: \"select * from test_table where xyz = :xyz /* VARCHAR */ and abc = :vtg1\", BindVars: {[REDACTED]}"
+	require.EqualError(t, err, want)
+	require.Equal(t, want, tl.getLog(0))
+}
+
 func TestTerseErrorsNonSQLError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
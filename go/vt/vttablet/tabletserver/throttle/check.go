@@ -147,6 +147,12 @@ func (check *ThrottlerCheck) Check(ctx context.Context, appName string, storeTyp
 				return check.throttler.getMySQLClusterMetrics(ctx, storeName)
 			}
 		}
+	case customStoreType:
+		{
+			metricResultFunc = func() (metricResult base.MetricResult, threshold float64) {
+				return check.throttler.getSelfMetric(storeName)
+			}
+		}
 	}
 	if metricResultFunc == nil {
 		return NoSuchMetricCheckResult
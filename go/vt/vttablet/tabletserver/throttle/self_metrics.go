@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/patrickmn/go-cache"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
+)
+
+// customStoreType is the store type of the self metrics, whose aggregated
+// metrics are named "custom/<metric name>".
+const customStoreType = "custom"
+
+// SelfMetricQueryFunc runs a query on the MySQL server of the tablet.
+type SelfMetricQueryFunc func(ctx context.Context, query string) (*sqltypes.Result, error)
+
+// SelfMetric is a metric of the tablet, which the throttler checks in addition
+// to the replication lag once enabled with --throttle-metrics. Plugins can
+// register their own metrics with RegisterSelfMetric.
+type SelfMetric interface {
+	// Name is the name of the metric in --throttle-metrics.
+	Name() string
+	// DefaultThreshold is the threshold of the metric when --throttle-metrics
+	// doesn't set one. The checks are throttled while the metric exceeds it.
+	DefaultThreshold() float64
+	// Read reads the current value of the metric.
+	Read(ctx context.Context, query SelfMetricQueryFunc) (float64, error)
+}
+
+var (
+	selfMetricsMu sync.Mutex
+	selfMetrics   = map[string]SelfMetric{}
+)
+
+// RegisterSelfMetric registers a self metric, which can then be enabled with
+// --throttle-metrics. It must be called before the throttler is created.
+func RegisterSelfMetric(metric SelfMetric) {
+	selfMetricsMu.Lock()
+	defer selfMetricsMu.Unlock()
+	if _, ok := selfMetrics[metric.Name()]; ok {
+		panic(fmt.Sprintf("self metric %s is already registered", metric.Name()))
+	}
+	selfMetrics[metric.Name()] = metric
+}
+
+func getSelfMetric(name string) SelfMetric {
+	selfMetricsMu.Lock()
+	defer selfMetricsMu.Unlock()
+	return selfMetrics[name]
+}
+
+func init() {
+	RegisterSelfMetric(&querySelfMetric{
+		name:             "threads_running",
+		query:            "show global status like 'Threads_running'",
+		defaultThreshold: 100,
+	})
+	RegisterSelfMetric(&querySelfMetric{
+		name:             "history_list_length",
+		query:            "select `count` from information_schema.innodb_metrics where name = 'trx_rseg_history_len'",
+		defaultThreshold: 1000000,
+	})
+}
+
+// querySelfMetric is a metric read from the last column of the first row of
+// a query.
+type querySelfMetric struct {
+	name             string
+	query            string
+	defaultThreshold float64
+}
+
+// Name is part of the SelfMetric interface.
+func (m *querySelfMetric) Name() string {
+	return m.name
+}
+
+// DefaultThreshold is part of the SelfMetric interface.
+func (m *querySelfMetric) DefaultThreshold() float64 {
+	return m.defaultThreshold
+}
+
+// Read is part of the SelfMetric interface.
+func (m *querySelfMetric) Read(ctx context.Context, query SelfMetricQueryFunc) (float64, error) {
+	qr, err := query(ctx, m.query)
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) == 0 || len(qr.Rows[0]) == 0 {
+		return 0, fmt.Errorf("no results for metric %s", m.name)
+	}
+	row := qr.Rows[0]
+	return strconv.ParseFloat(row[len(row)-1].ToString(), 64)
+}
+
+// execSelfMetric is a metric read from the standard output of a command, as
+// set with --throttle-metrics-exec.
+type execSelfMetric struct {
+	name    string
+	command string
+}
+
+// Name is part of the SelfMetric interface.
+func (m *execSelfMetric) Name() string {
+	return m.name
+}
+
+// DefaultThreshold is part of the SelfMetric interface. The exec metrics
+// have no default threshold.
+func (m *execSelfMetric) DefaultThreshold() float64 {
+	return 0
+}
+
+// Read is part of the SelfMetric interface.
+func (m *execSelfMetric) Read(ctx context.Context, query SelfMetricQueryFunc) (float64, error) {
+	out, err := exec.CommandContext(ctx, m.command).Output()
+	if err != nil {
+		return 0, fmt.Errorf("error running %s for metric %s: %w", m.command, m.name, err)
+	}
+	return strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+}
+
+// selfMetricResult is the result of reading a self metric.
+type selfMetricResult struct {
+	Value float64
+	Err   error `json:"-"`
+}
+
+// Get implements MetricResult
+func (metricResult *selfMetricResult) Get() (float64, error) {
+	return metricResult.Value, metricResult.Err
+}
+
+// enabledSelfMetric is a self metric enabled with --throttle-metrics.
+type enabledSelfMetric struct {
+	metric    SelfMetric
+	threshold float64
+	// readInProgress avoids reading the metric again while it's being read.
+	readInProgress atomic.Bool
+}
+
+// parseSelfMetrics returns the self metrics enabled by the --throttle-metrics
+// and --throttle-metrics-exec flag values.
+func parseSelfMetrics(metrics []string, execMetrics []string) ([]*enabledSelfMetric, error) {
+	commands := make(map[string]string, len(execMetrics))
+	for _, execMetric := range execMetrics {
+		name, command, ok := strings.Cut(execMetric, "=")
+		if !ok || name == "" || strings.Contains(name, "/") || command == "" {
+			return nil, fmt.Errorf("--throttle-metrics-exec must be name=command pairs (specified value: %s)", execMetric)
+		}
+		commands[name] = command
+	}
+
+	var enabled []*enabledSelfMetric
+	for _, m := range metrics {
+		name, thresholdValue, hasThreshold := strings.Cut(m, "=")
+		var metric SelfMetric
+		if command, ok := commands[name]; ok {
+			metric = &execSelfMetric{name: name, command: command}
+		} else if metric = getSelfMetric(name); metric == nil {
+			return nil, fmt.Errorf("unknown throttler metric: %s", name)
+		}
+		threshold := metric.DefaultThreshold()
+		if hasThreshold {
+			var err error
+			if threshold, err = strconv.ParseFloat(thresholdValue, 64); err != nil {
+				return nil, fmt.Errorf("invalid threshold for throttler metric %s: %w", name, err)
+			}
+		}
+		if threshold <= 0 {
+			return nil, fmt.Errorf("throttler metric %s needs a positive threshold", name)
+		}
+		enabled = append(enabled, &enabledSelfMetric{metric: metric, threshold: threshold})
+	}
+	return enabled, nil
+}
+
+// initSelfMetrics enables the self metrics of the flags.
+func (throttler *Throttler) initSelfMetrics() {
+	selfMetrics, err := parseSelfMetrics(throttleMetrics, throttleMetricsExec)
+	if err != nil {
+		log.Errorf("Throttler: ignoring the throttler metrics: %v", err)
+		return
+	}
+	throttler.selfMetrics = selfMetrics
+}
+
+// querySelfMetric runs a query reading a self metric on the MySQL server.
+func (throttler *Throttler) querySelfMetric(ctx context.Context, query string) (*sqltypes.Result, error) {
+	conn, err := throttler.pool.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	return conn.Conn.Exec(ctx, query, 1, true)
+}
+
+// collectSelfMetrics reads the self metrics into the aggregated metrics.
+func (throttler *Throttler) collectSelfMetrics(ctx context.Context) {
+	for _, m := range throttler.selfMetrics {
+		if !m.readInProgress.CompareAndSwap(false, true) {
+			// The previous read of the metric is still running.
+			continue
+		}
+		go func(m *enabledSelfMetric) {
+			defer m.readInProgress.Store(false)
+
+			ctx, cancel := context.WithTimeout(ctx, 4*mysqlCollectInterval)
+			defer cancel()
+			value, err := m.metric.Read(ctx, throttler.querySelfMetric)
+			metricName := fmt.Sprintf("%s/%s", customStoreType, m.metric.Name())
+			throttler.aggregatedMetrics.Set(metricName, &selfMetricResult{Value: value, Err: err}, cache.DefaultExpiration)
+		}(m)
+	}
+}
+
+// getSelfMetric returns the last value read of a self metric, and its threshold.
+func (throttler *Throttler) getSelfMetric(name string) (base.MetricResult, float64) {
+	for _, m := range throttler.selfMetrics {
+		if m.metric.Name() == name {
+			return throttler.getNamedMetric(fmt.Sprintf("%s/%s", customStoreType, name)), m.threshold
+		}
+	}
+	return base.NoSuchMetric, 0
+}
+
+// checkSelfMetrics checks that every self metric is below its threshold,
+// returning the check result of the first which isn't.
+func (throttler *Throttler) checkSelfMetrics(ctx context.Context, appName string, flags *CheckFlags) *CheckResult {
+	// The overridden threshold is the one of the replication lag.
+	selfMetricFlags := *flags
+	selfMetricFlags.OverrideThreshold = 0
+	for _, m := range throttler.selfMetrics {
+		name := m.metric.Name()
+		checkResult := throttler.check.checkAppMetricResult(ctx, appName, customStoreType, name, func() (base.MetricResult, float64) {
+			return throttler.getSelfMetric(name)
+		}, &selfMetricFlags)
+		if checkResult.StatusCode != okMetricCheckResult.StatusCode {
+			checkResult.Message = fmt.Sprintf("%s: %s", name, checkResult.Message)
+			return checkResult
+		}
+	}
+	return nil
+}
+
+// selfMetricsThresholds returns the thresholds of the self metrics.
+func (throttler *Throttler) selfMetricsThresholds() map[string]float64 {
+	thresholds := make(map[string]float64, len(throttler.selfMetrics))
+	for _, m := range throttler.selfMetrics {
+		thresholds[fmt.Sprintf("%s/%s", customStoreType, m.metric.Name())] = m.threshold
+	}
+	return thresholds
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestParseSelfMetrics(t *testing.T) {
+	tcases := []struct {
+		name        string
+		metrics     []string
+		execMetrics []string
+		thresholds  map[string]float64
+		expectErr   string
+	}{
+		{
+			name:       "none",
+			thresholds: map[string]float64{},
+		},
+		{
+			name:       "default thresholds",
+			metrics:    []string{"threads_running", "history_list_length"},
+			thresholds: map[string]float64{"threads_running": 100, "history_list_length": 1000000},
+		},
+		{
+			name:       "explicit threshold",
+			metrics:    []string{"threads_running=50"},
+			thresholds: map[string]float64{"threads_running": 50},
+		},
+		{
+			name:        "exec metric",
+			metrics:     []string{"disk_util=80"},
+			execMetrics: []string{"disk_util=/usr/local/bin/disk_util"},
+			thresholds:  map[string]float64{"disk_util": 80},
+		},
+		{
+			name:      "unknown metric",
+			metrics:   []string{"disk_util=80"},
+			expectErr: "unknown throttler metric: disk_util",
+		},
+		{
+			name:        "exec metric without threshold",
+			metrics:     []string{"disk_util"},
+			execMetrics: []string{"disk_util=/usr/local/bin/disk_util"},
+			expectErr:   "throttler metric disk_util needs a positive threshold",
+		},
+		{
+			name:      "invalid threshold",
+			metrics:   []string{"threads_running=many"},
+			expectErr: "invalid threshold for throttler metric threads_running",
+		},
+		{
+			name:        "invalid exec metric",
+			execMetrics: []string{"disk_util"},
+			expectErr:   "--throttle-metrics-exec must be name=command pairs",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			enabled, err := parseSelfMetrics(tcase.metrics, tcase.execMetrics)
+			if tcase.expectErr != "" {
+				assert.ErrorContains(t, err, tcase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			thresholds := map[string]float64{}
+			for _, m := range enabled {
+				thresholds[m.metric.Name()] = m.threshold
+			}
+			assert.Equal(t, tcase.thresholds, thresholds)
+		})
+	}
+}
+
+func TestReadSelfMetrics(t *testing.T) {
+	ctx := context.Background()
+	t.Run("query", func(t *testing.T) {
+		metric := getSelfMetric("threads_running")
+		require.NotNil(t, metric)
+		value, err := metric.Read(ctx, func(ctx context.Context, query string) (*sqltypes.Result, error) {
+			assert.Equal(t, "show global status like 'Threads_running'", query)
+			return sqltypes.MakeTestResult(sqltypes.MakeTestFields("Variable_name|Value", "varchar|varchar"), "Threads_running|17"), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 17.0, value)
+
+		_, err = metric.Read(ctx, func(ctx context.Context, query string) (*sqltypes.Result, error) {
+			return &sqltypes.Result{}, nil
+		})
+		assert.ErrorContains(t, err, "no results for metric threads_running")
+	})
+	t.Run("exec", func(t *testing.T) {
+		command := filepath.Join(t.TempDir(), "disk_util")
+		require.NoError(t, os.WriteFile(command, []byte("#!/bin/sh\necho 42.5\n"), 0o755))
+		metric := &execSelfMetric{name: "disk_util", command: command}
+		value, err := metric.Read(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 42.5, value)
+
+		metric = &execSelfMetric{name: "disk_util", command: filepath.Join(t.TempDir(), "missing")}
+		_, err = metric.Read(ctx, nil)
+		assert.ErrorContains(t, err, "for metric disk_util")
+	})
+}
+
+func TestCheckSelfMetrics(t *testing.T) {
+	ctx := context.Background()
+	throttler := newTestThrottler()
+	throttler.aggregatedMetrics = cache.New(cache.NoExpiration, 0)
+	selfMetrics, err := parseSelfMetrics([]string{"threads_running=50", "history_list_length"}, nil)
+	require.NoError(t, err)
+	throttler.selfMetrics = selfMetrics
+	assert.Equal(t, map[string]float64{"custom/threads_running": 50, "custom/history_list_length": 1000000}, throttler.selfMetricsThresholds())
+
+	flags := &CheckFlags{OverrideThreshold: 7}
+
+	// The metrics are not collected yet.
+	checkResult := throttler.checkSelfMetrics(ctx, "test", flags)
+	require.NotNil(t, checkResult)
+	assert.Equal(t, http.StatusNotFound, checkResult.StatusCode)
+	assert.Contains(t, checkResult.Message, "threads_running: ")
+
+	throttler.aggregatedMetrics.SetDefault("custom/threads_running", &selfMetricResult{Value: 10})
+	throttler.aggregatedMetrics.SetDefault("custom/history_list_length", &selfMetricResult{Value: 1000})
+	assert.Nil(t, throttler.checkSelfMetrics(ctx, "test", flags))
+
+	throttler.aggregatedMetrics.SetDefault("custom/history_list_length", &selfMetricResult{Value: 2000000})
+	checkResult = throttler.checkSelfMetrics(ctx, "test", flags)
+	require.NotNil(t, checkResult)
+	assert.Equal(t, http.StatusTooManyRequests, checkResult.StatusCode)
+	assert.Equal(t, 2000000.0, checkResult.Value)
+	assert.Equal(t, 1000000.0, checkResult.Threshold)
+	assert.Contains(t, checkResult.Message, "history_list_length: ")
+
+	// The self metrics can also be checked on their own.
+	checkResult = throttler.check.Check(ctx, "test", customStoreType, "threads_running", "", &CheckFlags{})
+	assert.Equal(t, http.StatusOK, checkResult.StatusCode)
+	assert.Equal(t, 10.0, checkResult.Value)
+	checkResult = throttler.check.Check(ctx, "test", customStoreType, "disk_util", "", &CheckFlags{})
+	assert.Equal(t, http.StatusNotFound, checkResult.StatusCode)
+}
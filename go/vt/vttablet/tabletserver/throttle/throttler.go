@@ -109,6 +109,8 @@ var (
 	// flag vars
 	defaultThrottleLagThreshold = 5 * time.Second
 	throttleTabletTypes         = "replica"
+	throttleMetrics             []string
+	throttleMetricsExec         []string
 )
 
 var (
@@ -124,6 +126,8 @@ func init() {
 
 func registerThrottlerFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&throttleTabletTypes, "throttle_tablet_types", throttleTabletTypes, "Comma separated VTTablet types to be considered by the throttler. default: 'replica'. example: 'replica,rdonly'. 'replica' always implicitly included")
+	fs.StringSliceVar(&throttleMetrics, "throttle-metrics", throttleMetrics, "Comma separated metrics of the tablet checked by the throttler in addition to the replication lag, as name or name=threshold. Builtin metrics: threads_running, history_list_length.")
+	fs.StringSliceVar(&throttleMetricsExec, "throttle-metrics-exec", throttleMetricsExec, "Comma separated name=command pairs defining metrics read from the standard output of a command, to enable with --throttle-metrics.")
 }
 
 var (
@@ -207,6 +211,9 @@ type Throttler struct {
 
 	readSelfThrottleMetric func(context.Context, *mysql.Probe) *mysql.MySQLThrottleMetric // overwritten by unit test
 
+	// selfMetrics are the metrics of the tablet checked in addition to the replication lag.
+	selfMetrics []*enabledSelfMetric
+
 	nonLowPriorityAppRequestsThrottled *cache.Cache
 	httpClient                         *http.Client
 }
@@ -226,6 +233,12 @@ type ThrottlerStatus struct {
 
 	AggregatedMetrics map[string]base.MetricResult
 	MetricsHealth     base.MetricHealthMap
+
+	// MetricsThresholds are the thresholds of the self metrics.
+	MetricsThresholds map[string]float64
+	// Check is the aggregated decision of the replication lag and self
+	// metrics checks of the tablet.
+	Check *CheckResult
 }
 
 // NewThrottler creates a Throttler
@@ -258,6 +271,7 @@ func NewThrottler(env tabletenv.Env, srvTopoServer srvtopo.Server, ts *topo.Serv
 
 	throttler.httpClient = base.SetupHTTPClient(2 * mysqlCollectInterval)
 	throttler.initThrottleTabletTypes()
+	throttler.initSelfMetrics()
 	throttler.check = NewThrottlerCheck(throttler)
 
 	throttler.leaderCheckInterval = leaderCheckInterval
@@ -769,6 +783,7 @@ func (throttler *Throttler) Operate(ctx context.Context, wg *sync.WaitGroup) {
 					throttler.collectMySQLMetrics(ctx, tmClient, func(clusterName string) bool {
 						return clusterName == selfStoreName
 					})
+					throttler.collectSelfMetrics(ctx)
 					if !throttler.isDormant() {
 						throttler.collectMySQLMetrics(ctx, tmClient, func(clusterName string) bool {
 							return clusterName != selfStoreName
@@ -1244,6 +1259,13 @@ func (throttler *Throttler) checkStore(ctx context.Context, appName string, stor
 	}
 
 	checkResult = throttler.check.Check(ctx, appName, "mysql", storeName, remoteAddr, flags)
+	if checkResult.StatusCode == http.StatusOK && !throttlerapp.VitessName.Equals(appName) {
+		// The "vitess" app checks the replication lag only, as the primary
+		// does when probing the replicas.
+		if selfMetricsCheckResult := throttler.checkSelfMetrics(ctx, appName, flags); selfMetricsCheckResult != nil {
+			checkResult = selfMetricsCheckResult
+		}
+	}
 
 	shouldRequestHeartbeats := !flags.SkipRequestHeartbeats
 	if throttlerapp.VitessName.Equals(appName) {
@@ -1309,5 +1331,25 @@ func (throttler *Throttler) Status() *ThrottlerStatus {
 
 		AggregatedMetrics: throttler.aggregatedMetricsSnapshot(),
 		MetricsHealth:     throttler.metricsHealthSnapshot(),
+
+		MetricsThresholds: throttler.selfMetricsThresholds(),
+		Check:             throttler.aggregatedCheck(context.Background()),
 	}
 }
+
+// aggregatedCheck checks the replication lag and the self metrics of the
+// tablet, without the side effects of the checks of the apps.
+func (throttler *Throttler) aggregatedCheck(ctx context.Context) *CheckResult {
+	checkType := ThrottleCheckSelf
+	if throttler.isLeader.Load() {
+		checkType = ThrottleCheckPrimaryWrite
+	}
+	flags := &CheckFlags{ReadCheck: true, SkipRequestHeartbeats: true}
+	checkResult := throttler.CheckByType(ctx, throttlerapp.VitessName.String(), "", flags, checkType)
+	if checkResult.StatusCode == http.StatusOK && throttler.IsRunning() {
+		if selfMetricsCheckResult := throttler.checkSelfMetrics(ctx, throttlerapp.VitessName.String(), flags); selfMetricsCheckResult != nil {
+			return selfMetricsCheckResult
+		}
+	}
+	return checkResult
+}
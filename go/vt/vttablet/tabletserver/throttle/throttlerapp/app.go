@@ -52,6 +52,7 @@ const (
 	PTOSCName     Name = "pt-osc"
 
 	VReplicationName      Name = "vreplication"
+	LookupVindexName      Name = "lookup-vindex"
 	VStreamerName         Name = "vstreamer"
 	VPlayerName           Name = "vplayer"
 	VCopierName           Name = "vcopier"
@@ -60,6 +61,8 @@ const (
 	ExternalConnectorName Name = "external-connector"
 	ReplicaConnectorName  Name = "replica-connector"
 
+	TransactionName Name = "transaction"
+
 	BinlogWatcherName Name = "binlog-watcher"
 	MessagerName      Name = "messager"
 	SchemaTrackerName Name = "schema-tracker"
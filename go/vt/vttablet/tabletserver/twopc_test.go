@@ -23,11 +23,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 
 	"vitess.io/vitess/go/sqltypes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 func TestReadAllRedo(t *testing.T) {
@@ -392,6 +396,69 @@ func TestReadAllTransactions(t *testing.T) {
 	}
 }
 
+func TestUnresolvedTransactions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	txe, tsv, db := newTestTxExecutor(t, ctx)
+	defer db.Close()
+	defer tsv.StopService()
+
+	db.AddQueryPattern(`select t\.dtid, t\.state, t\.time_created, p\.keyspace, p\.shard.*where t\.time_created < \d+.*`, &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Type: sqltypes.VarChar},
+			{Type: sqltypes.Int64},
+			{Type: sqltypes.Int64},
+			{Type: sqltypes.VarChar},
+			{Type: sqltypes.VarChar},
+		},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewVarBinary("dtid0"),
+			sqltypes.NewInt64(int64(DTStatePrepare)),
+			sqltypes.NewInt64(1),
+			sqltypes.NewVarBinary("ks01"),
+			sqltypes.NewVarBinary("shard01"),
+		}, {
+			sqltypes.NewVarBinary("dtid0"),
+			sqltypes.NewInt64(int64(DTStatePrepare)),
+			sqltypes.NewInt64(1),
+			sqltypes.NewVarBinary("ks02"),
+			sqltypes.NewVarBinary("shard02"),
+		}, {
+			sqltypes.NewVarBinary("dtid1"),
+			sqltypes.NewInt64(int64(DTStateCommit)),
+			sqltypes.NewInt64(2),
+			sqltypes.NewVarBinary("ks11"),
+			sqltypes.NewVarBinary("shard11"),
+		}},
+	})
+	got, err := txe.UnresolvedTransactions(time.Minute)
+	require.NoError(t, err)
+	want := []*querypb.TransactionMetadata{{
+		Dtid:        "dtid0",
+		State:       querypb.TransactionState_PREPARE,
+		TimeCreated: 1,
+		Participants: []*querypb.Target{{
+			Keyspace:   "ks01",
+			Shard:      "shard01",
+			TabletType: topodatapb.TabletType_PRIMARY,
+		}, {
+			Keyspace:   "ks02",
+			Shard:      "shard02",
+			TabletType: topodatapb.TabletType_PRIMARY,
+		}},
+	}, {
+		Dtid:        "dtid1",
+		State:       querypb.TransactionState_COMMIT,
+		TimeCreated: 2,
+		Participants: []*querypb.Target{{
+			Keyspace:   "ks11",
+			Shard:      "shard11",
+			TabletType: topodatapb.TabletType_PRIMARY,
+		}},
+	}}
+	utils.MustMatch(t, want, got)
+}
+
 func jsonStr(v any) string {
 	out, _ := json.Marshal(v)
 	return string(out)
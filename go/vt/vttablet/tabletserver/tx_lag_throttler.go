@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// lagChecker checks the lag metrics of the tablet throttler.
+type lagChecker interface {
+	CheckByType(ctx context.Context, appName string, remoteAddr string, flags *throttle.CheckFlags, checkType throttle.ThrottleCheckType) *throttle.CheckResult
+}
+
+// txLagThrottler rejects the transactions while the tablet throttler reports
+// a replication lag above its threshold, so that bursty writers can't drive
+// the replicas to an unbounded lag. The transactions check the throttler as
+// the "transaction:<caller ID>" app, so that the callers can also be
+// throttled or exempted at runtime with the throttler app rules.
+type txLagThrottler struct {
+	checker       lagChecker
+	enabled       bool
+	dryRun        bool
+	checkType     throttle.ThrottleCheckType
+	exemptCallers map[string]bool
+
+	requests  *stats.CountersWithSingleLabel
+	throttled *stats.CountersWithMultiLabels
+}
+
+func newTxLagThrottler(env tabletenv.Env, checker lagChecker) *txLagThrottler {
+	config := env.Config().TxLagThrottler
+	t := &txLagThrottler{
+		checker:       checker,
+		enabled:       config.Mode == tabletenv.Enable || config.Mode == tabletenv.Dryrun,
+		dryRun:        config.Mode == tabletenv.Dryrun,
+		checkType:     throttle.ThrottleCheckPrimaryWrite,
+		exemptCallers: make(map[string]bool, len(config.ExemptCallers)),
+		requests:      env.Exporter().NewCountersWithSingleLabel("TxLagThrottlerRequests", "Transactions checked by the transaction lag throttler", "Workload"),
+		throttled:     env.Exporter().NewCountersWithMultiLabels("TxLagThrottlerThrottled", "Transactions throttled by the transaction lag throttler, or which would have been in dry-run mode", []string{"Workload", "DryRun"}),
+	}
+	if config.Scope == tabletenv.TxLagThrottlerScopeSelf {
+		t.checkType = throttle.ThrottleCheckSelf
+	}
+	for _, caller := range config.ExemptCallers {
+		t.exemptCallers[caller] = true
+	}
+	return t
+}
+
+// throttle returns whether the transaction must be rejected.
+func (t *txLagThrottler) throttle(ctx context.Context, options *querypb.ExecuteOptions) bool {
+	if !t.enabled {
+		return false
+	}
+	caller := txLagThrottlerCaller(ctx)
+	if t.exemptCallers[caller] {
+		return false
+	}
+
+	appName := throttlerapp.TransactionName.String()
+	if caller != "" {
+		appName = throttlerapp.TransactionName.ConcatenateString(caller)
+	}
+	workload := options.GetWorkloadName()
+	t.requests.Add(workload, 1)
+	checkResult := t.checker.CheckByType(ctx, appName, "", &throttle.CheckFlags{}, t.checkType)
+	if checkResult.StatusCode == http.StatusOK {
+		return false
+	}
+	t.throttled.Add([]string{workload, strconv.FormatBool(t.dryRun)}, 1)
+	return !t.dryRun
+}
+
+// txLagThrottlerCaller returns the caller ID of a transaction: the principal
+// of its effective caller ID, or else the username of its immediate caller ID.
+func txLagThrottlerCaller(ctx context.Context) string {
+	if principal := callerid.EffectiveCallerIDFromContext(ctx).GetPrincipal(); principal != "" {
+		return principal
+	}
+	return callerid.ImmediateCallerIDFromContext(ctx).GetUsername()
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+type fakeLagChecker struct {
+	statusCode int
+	appNames   []string
+	checkTypes []throttle.ThrottleCheckType
+}
+
+func (c *fakeLagChecker) CheckByType(ctx context.Context, appName string, remoteAddr string, flags *throttle.CheckFlags, checkType throttle.ThrottleCheckType) *throttle.CheckResult {
+	c.appNames = append(c.appNames, appName)
+	c.checkTypes = append(c.checkTypes, checkType)
+	return &throttle.CheckResult{StatusCode: c.statusCode}
+}
+
+func newTestTxLagThrottler(t *testing.T, mode string, scope string, statusCode int) (*txLagThrottler, *fakeLagChecker) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.TxLagThrottler.Mode = mode
+	cfg.TxLagThrottler.Scope = scope
+	cfg.TxLagThrottler.ExemptCallers = []string{"admin"}
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name())
+	checker := &fakeLagChecker{statusCode: statusCode}
+	return newTxLagThrottler(env, checker), checker
+}
+
+func TestTxLagThrottler(t *testing.T) {
+	tlt, checker := newTestTxLagThrottler(t, tabletenv.Enable, tabletenv.TxLagThrottlerScopeShard, http.StatusTooManyRequests)
+	options := &querypb.ExecuteOptions{WorkloadName: "batch"}
+
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("writer", "", ""), nil)
+	assert.True(t, tlt.throttle(ctx, options))
+	assert.Equal(t, []string{"transaction:writer"}, checker.appNames)
+	assert.Equal(t, []throttle.ThrottleCheckType{throttle.ThrottleCheckPrimaryWrite}, checker.checkTypes)
+	assert.EqualValues(t, 1, tlt.requests.Counts()["batch"])
+	assert.EqualValues(t, 1, tlt.throttled.Counts()["batch.false"])
+
+	// Without effective caller ID, the immediate caller ID is used.
+	ctx = callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID("reporter"))
+	assert.True(t, tlt.throttle(ctx, options))
+	assert.Equal(t, "transaction:reporter", checker.appNames[1])
+
+	// The exempted callers don't check the throttler.
+	ctx = callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("admin", "", ""), nil)
+	assert.False(t, tlt.throttle(ctx, options))
+	assert.Len(t, checker.appNames, 2)
+
+	checker.statusCode = http.StatusOK
+	assert.False(t, tlt.throttle(context.Background(), options))
+	assert.Equal(t, "transaction", checker.appNames[2])
+	assert.EqualValues(t, 2, tlt.throttled.Counts()["batch.false"])
+}
+
+func TestTxLagThrottlerSelf(t *testing.T) {
+	tlt, checker := newTestTxLagThrottler(t, tabletenv.Enable, tabletenv.TxLagThrottlerScopeSelf, http.StatusTooManyRequests)
+	assert.True(t, tlt.throttle(context.Background(), nil))
+	assert.Equal(t, []throttle.ThrottleCheckType{throttle.ThrottleCheckSelf}, checker.checkTypes)
+}
+
+func TestTxLagThrottlerDryRun(t *testing.T) {
+	tlt, _ := newTestTxLagThrottler(t, tabletenv.Dryrun, tabletenv.TxLagThrottlerScopeShard, http.StatusTooManyRequests)
+	assert.False(t, tlt.throttle(context.Background(), nil))
+	assert.EqualValues(t, 1, tlt.throttled.Counts()[".true"])
+}
+
+func TestTxLagThrottlerDisabled(t *testing.T) {
+	tlt, checker := newTestTxLagThrottler(t, tabletenv.Disable, tabletenv.TxLagThrottlerScopeShard, http.StatusTooManyRequests)
+	assert.False(t, tlt.throttle(context.Background(), nil))
+	assert.Empty(t, checker.appNames)
+}
@@ -43,6 +43,9 @@ const (
 	txLogInterval  = 1 * time.Minute
 	beginWithCSRO  = "start transaction with consistent snapshot, read only"
 	trackGtidQuery = "set session session_track_gtids = START_GTID"
+
+	gtidExecutedQuery    = "select @@global.gtid_executed"
+	snapshotGTIDAttempts = 3
 )
 
 var txIsolations = map[querypb.ExecuteOptions_TransactionIsolation]string{
@@ -399,14 +402,12 @@ func createStartTxStmt(options *querypb.ExecuteOptions, readOnly bool) (string,
 
 func handleConsistentSnapshotCase(ctx context.Context, conn *StatefulConnection) (beginSQL string, sessionStateChanges string, err error) {
 	_, err = conn.execWithRetry(ctx, trackGtidQuery, 1, false)
-	// We allow this to fail since this is a custom MySQL extension, but we return
-	// then if this query was executed or not.
-	//
-	// Callers also can know because the sessionStateChanges will be empty for a snapshot
-	// transaction and get GTID information in another (less efficient) way.
-	if err == nil {
-		beginSQL = trackGtidQuery + "; "
+	// We allow this to fail since this is a custom MySQL extension: without it,
+	// the GTID set of the snapshot is read around the start of the transaction.
+	if err != nil {
+		return startSnapshotAtGTID(ctx, conn)
 	}
+	beginSQL = trackGtidQuery + "; "
 
 	isolationLevel := txIsolations[querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY]
 
@@ -424,6 +425,51 @@ func handleConsistentSnapshotCase(ctx context.Context, conn *StatefulConnection)
 	return
 }
 
+// startSnapshotAtGTID starts a consistent snapshot transaction when MySQL can't
+// track its GTID, and returns the GTID set of the snapshot as the session state
+// changes. The executed GTID set is read before and after the snapshot is taken:
+// when both are equal, no transaction committed in between, and the snapshot is
+// at this GTID set. Otherwise the snapshot is taken again, and after
+// snapshotGTIDAttempts attempts, the last snapshot is kept without its GTID set,
+// like when the executed GTID set can't be read.
+func startSnapshotAtGTID(ctx context.Context, conn *StatefulConnection) (beginSQL string, gtids string, err error) {
+	isolationLevel := txIsolations[querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY]
+	for attempt := 1; ; attempt++ {
+		before, gtidErr := conn.Exec(ctx, gtidExecutedQuery, 1, false)
+		if gtidErr == nil {
+			beginSQL += gtidExecutedQuery + "; "
+		}
+		execSQL, err := setIsolationLevel(ctx, conn, isolationLevel)
+		if err != nil {
+			return "", "", err
+		}
+		beginSQL += execSQL
+		execSQL, _, err = startTransaction(ctx, conn, beginWithCSRO)
+		if err != nil {
+			return "", "", err
+		}
+		beginSQL += execSQL
+		if gtidErr != nil {
+			return beginSQL, "", nil
+		}
+		after, gtidErr := conn.Exec(ctx, gtidExecutedQuery, 1, false)
+		if gtidErr != nil {
+			return beginSQL, "", nil
+		}
+		beginSQL += "; " + gtidExecutedQuery
+		if len(before.Rows) == 1 && len(after.Rows) == 1 && before.Rows[0][0].ToString() == after.Rows[0][0].ToString() {
+			return beginSQL, strings.ReplaceAll(after.Rows[0][0].ToString(), "\n", ""), nil
+		}
+		if attempt == snapshotGTIDAttempts {
+			return beginSQL, "", nil
+		}
+		if _, err := conn.execWithRetry(ctx, "rollback", 1, false); err != nil {
+			return "", "", err
+		}
+		beginSQL += "; rollback; "
+	}
+}
+
 func startTransaction(ctx context.Context, conn *StatefulConnection, transaction string) (string, string, error) {
 	sessionStateChanges, err := conn.execWithRetry(ctx, transaction, 1, false)
 	if err != nil {
@@ -860,6 +860,35 @@ func (fl *fakeLimiter) Actions() []fakeLimiterEntry {
 	return result
 }
 
+func TestTxPoolConsistentSnapshotGTID(t *testing.T) {
+	db, txPool, _, closer := setup(t)
+	defer closer()
+
+	// Without the START_GTID tracking, the snapshot is taken again until no
+	// transaction commits while it starts.
+	db.AddRejectedQuery(trackGtidQuery, errRejected)
+	gtidExecuted := func(gtids string) {
+		db.AddQuery(gtidExecutedQuery, sqltypes.MakeTestResult(sqltypes.MakeTestFields("@@global.gtid_executed", "varchar"), gtids))
+	}
+	gtidExecuted("16b1039f-22b6-11ed-b765-0a43f95f28a3:1-5")
+	committed := false
+	db.AddQuery(beginWithCSRO, &sqltypes.Result{})
+	db.SetBeforeFunc(beginWithCSRO, func() {
+		if !committed {
+			committed = true
+			gtidExecuted("16b1039f-22b6-11ed-b765-0a43f95f28a3:1-6,\n86b1039f-22b6-11ed-b765-0a43f95f28a3:1-2")
+		}
+	})
+
+	options := &querypb.ExecuteOptions{TransactionIsolation: querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY}
+	conn, beginSQL, sessionStateChanges, err := txPool.Begin(context.Background(), options, false, 0, nil, nil)
+	require.NoError(t, err)
+	defer conn.Release(tx.ConnRelease)
+	require.Equal(t, "select @@global.gtid_executed; set transaction isolation level repeatable read; start transaction with consistent snapshot, read only; select @@global.gtid_executed; rollback; "+
+		"select @@global.gtid_executed; set transaction isolation level repeatable read; start transaction with consistent snapshot, read only; select @@global.gtid_executed", beginSQL)
+	require.Equal(t, "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-6,86b1039f-22b6-11ed-b765-0a43f95f28a3:1-2", sessionStateChanges)
+}
+
 func setup(t *testing.T) (*fakesqldb.DB, *TxPool, *fakeLimiter, func()) {
 	db := fakesqldb.New(t)
 	db.AddQueryPattern(".*", &sqltypes.Result{})
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txserializer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// keyExpression is the expression on the columns of a table whose value is the
+// key on which the DMLs of the table are serialized, instead of their WHERE
+// clause. E.g. with "left(sku, 4)", all the UPDATEs of the products of the same
+// family are serialized, whatever the other conditions of their WHERE clauses.
+type keyExpression struct {
+	expr sqlparser.Expr
+	// text is the canonical form of expr, used in the keys.
+	text string
+	// columns are the lowered names of the columns of expr.
+	columns []string
+}
+
+// parseKeyExpressions parses the key expressions of the config. The invalid
+// ones are logged and ignored: the DMLs of their tables are then serialized on
+// their WHERE clause.
+func parseKeyExpressions(env *vtenv.Environment, exprs map[string]string) map[string]*keyExpression {
+	keyExprs := make(map[string]*keyExpression, len(exprs))
+	for table, text := range exprs {
+		expr, err := env.Parser().ParseExpr(text)
+		if err != nil {
+			log.Errorf("Ignoring the hot row protection key expression of table %v: %v", table, err)
+			continue
+		}
+		ke := &keyExpression{expr: expr, text: sqlparser.String(expr)}
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			if col, ok := node.(*sqlparser.ColName); ok {
+				ke.columns = append(ke.columns, col.Name.Lowered())
+			}
+			return true, nil
+		}, expr)
+		if len(ke.columns) == 0 {
+			log.Errorf("Ignoring the hot row protection key expression of table %v: %v has no column", table, text)
+			continue
+		}
+		keyExprs[table] = ke
+	}
+	return keyExprs
+}
+
+// KeyFromExpression returns the key of a DML on the table from the key
+// expression of the table, evaluated on the values the WHERE clause of the DML
+// sets on its columns, e.g. "product where left(sku, 4) = 'ABCD'".
+// It returns false if the table has no key expression or if the WHERE clause
+// doesn't set all its columns with equalities to literals or bind variables.
+func (txs *TxSerializer) KeyFromExpression(table string, where sqlparser.Expr, bindVariables map[string]*querypb.BindVariable) (string, bool) {
+	ke, ok := txs.keyExprs[table]
+	if !ok || where == nil {
+		return "", false
+	}
+
+	values := make(map[string]sqlparser.Expr, len(ke.columns))
+	for _, pred := range sqlparser.SplitAndExpression(nil, where) {
+		cmp, ok := pred.(*sqlparser.ComparisonExpr)
+		if !ok || cmp.Operator != sqlparser.EqualOp {
+			continue
+		}
+		col, val := cmp.Left, cmp.Right
+		if _, ok := col.(*sqlparser.ColName); !ok {
+			col, val = val, col
+		}
+		colName, ok := col.(*sqlparser.ColName)
+		if !ok {
+			continue
+		}
+		switch val.(type) {
+		case *sqlparser.Literal, *sqlparser.Argument:
+			values[colName.Name.Lowered()] = val
+		}
+	}
+	for _, col := range ke.columns {
+		if _, ok := values[col]; !ok {
+			return "", false
+		}
+	}
+
+	// Replace the columns of the key expression by their values, and evaluate it.
+	expr := sqlparser.CopyOnRewrite(ke.expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		if col, ok := cursor.Node().(*sqlparser.ColName); ok {
+			cursor.Replace(values[col.Name.Lowered()])
+		}
+	}, nil).(sqlparser.Expr)
+
+	env := txs.env.Environment()
+	collation := env.CollationEnv().DefaultConnectionCharset()
+	evalExpr, err := evalengine.Translate(expr, &evalengine.Config{
+		Collation:   collation,
+		Environment: env,
+	})
+	if err != nil {
+		txs.log.Errorf("failed to translate the key expression %v of table %v: %v", ke.text, table, err)
+		return "", false
+	}
+	result, err := evalengine.NewExpressionEnv(context.Background(), bindVariables, evalengine.NewEmptyVCursor(env, time.Local)).Evaluate(evalExpr)
+	if err != nil {
+		txs.log.Errorf("failed to evaluate the key expression %v of table %v: %v", ke.text, table, err)
+		return "", false
+	}
+
+	var key strings.Builder
+	key.WriteString(table)
+	key.WriteString(" where ")
+	key.WriteString(ke.text)
+	key.WriteString(" = ")
+	result.Value(collation).EncodeSQLStringBuilder(&key)
+	return key.String(), true
+}
@@ -32,6 +32,7 @@ import (
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 
@@ -39,7 +40,8 @@ import (
 )
 
 // TxSerializer serializes incoming transactions which target the same row range
-// i.e. table name and WHERE clause are identical.
+// i.e. table name and WHERE clause are identical, or which have the same value
+// of the key expression of their table.
 // Additional transactions are queued and woken up in arrival order.
 //
 // This implementation has some parallels to the sync2.Consolidator class.
@@ -62,6 +64,7 @@ type TxSerializer struct {
 	maxQueueSize           int
 	maxGlobalQueueSize     int
 	concurrentTransactions int
+	keyExprs               map[string]*keyExpression
 
 	// waits stores how many times a transaction was queued because another
 	// transaction was already in flight for the same row (range).
@@ -81,6 +84,14 @@ type TxSerializer struct {
 	waits, waitsDryRun, queueExceeded, queueExceededDryRun *stats.CountersWithSingleLabel
 	globalQueueExceeded, globalQueueExceededDryRun         *stats.Counter
 
+	// queueDepth is the number of transactions per table which are queued
+	// behind another transaction for the same row (range), i.e. waiting for a
+	// slot or holding one of the additional concurrent slots.
+	//
+	// waitTimings records per table how long the transactions waited for a slot.
+	queueDepth  *stats.GaugesWithSingleLabel
+	waitTimings *servenv.TimingsWrapper
+
 	log                          *logutil.ThrottledLogger
 	logDryRun                    *logutil.ThrottledLogger
 	logWaitsDryRun               *logutil.ThrottledLogger
@@ -102,6 +113,7 @@ func New(env tabletenv.Env) *TxSerializer {
 		maxQueueSize:           config.HotRowProtection.MaxQueueSize,
 		maxGlobalQueueSize:     config.HotRowProtection.MaxGlobalQueueSize,
 		concurrentTransactions: config.HotRowProtection.MaxConcurrency,
+		keyExprs:               parseKeyExpressions(env.Environment(), config.HotRowProtection.KeyExpressions),
 		waits: env.Exporter().NewCountersWithSingleLabel(
 			"TxSerializerWaits",
 			"Number of times a transaction was queued because another transaction was already in flight for the same row range",
@@ -124,6 +136,14 @@ func New(env tabletenv.Env) *TxSerializer {
 		globalQueueExceededDryRun: env.Exporter().NewCounter(
 			"TxSerializerGlobalQueueExceededDryRun",
 			"Dry-run stats for TxSerializerGlobalQueueExceeded"),
+		queueDepth: env.Exporter().NewGaugesWithSingleLabel(
+			"TxSerializerQueueDepth",
+			"Number of transactions which are queued behind another transaction for the same row range",
+			"table_name"),
+		waitTimings: env.Exporter().NewTimings(
+			"TxSerializerWaitTime",
+			"Time the transactions waited for another transaction for the same row range",
+			"table_name"),
 		log:                          logutil.NewThrottledLogger("HotRowProtection", 5*time.Second),
 		logDryRun:                    logutil.NewThrottledLogger("HotRowProtection DryRun", 5*time.Second),
 		logWaitsDryRun:               logutil.NewThrottledLogger("HotRowProtection Waits DryRun", 5*time.Second),
@@ -166,7 +186,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	q, ok := txs.queues[key]
 	if !ok {
 		// First transaction in the queue i.e. we don't wait and return immediately.
-		txs.queues[key] = newQueueForFirstTransaction(txs.concurrentTransactions)
+		txs.queues[key] = newQueueForFirstTransaction(table, txs.concurrentTransactions)
 		txs.globalSize++
 		return false, nil
 	}
@@ -217,6 +237,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	txs.globalSize++
 	q.size++
 	q.count++
+	txs.queueDepth.Add(table, 1)
 	if q.size > q.max {
 		q.max = q.size
 	}
@@ -248,6 +269,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 
 	// Blocking wait for the next available slot.
 	txs.waits.Add(table, 1)
+	defer txs.waitTimings.Record(table, time.Now())
 	select {
 	case q.availableSlots <- struct{}{}:
 		return true, nil
@@ -268,6 +290,10 @@ func (txs *TxSerializer) unlockLocked(key string, returnSlot bool) {
 	q.size--
 	txs.globalSize--
 
+	if q.size > 0 {
+		txs.queueDepth.Add(q.table, -1)
+	}
+
 	if q.size == 0 {
 		// This is the last transaction in flight.
 		delete(txs.queues, key)
@@ -366,6 +392,9 @@ func (txs *TxSerializer) ServeHTTP(response http.ResponseWriter, request *http.R
 // transactions which can access the tx pool). All queued transactions are
 // competing for these slots and try to add themselves to the channel.
 type queue struct {
+	// table is the table name of the queued transactions.
+	table string
+
 	// NOTE: The following fields are guarded by TxSerializer.mu.
 	// size counts how many transactions are currently queued/in flight (includes
 	// the transactions which are not waiting.)
@@ -386,8 +415,9 @@ type queue struct {
 	availableSlots chan struct{}
 }
 
-func newQueueForFirstTransaction(concurrentTransactions int) *queue {
+func newQueueForFirstTransaction(table string, concurrentTransactions int) *queue {
 	return &queue{
+		table: table,
 		size:  1,
 		count: 1,
 		max:   1,
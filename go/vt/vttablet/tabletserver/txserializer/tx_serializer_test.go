@@ -26,11 +26,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -41,6 +46,8 @@ func resetVariables(txs *TxSerializer) {
 	txs.queueExceededDryRun.ResetAll()
 	txs.globalQueueExceeded.Reset()
 	txs.globalQueueExceededDryRun.Reset()
+	txs.queueDepth.ResetAll()
+	txs.waitTimings.Reset()
 }
 
 func TestTxSerializer_NoHotRow(t *testing.T) {
@@ -161,6 +168,9 @@ func TestTxSerializer(t *testing.T) {
 	if err := waitForPending(txs, "t1 where1", 2); err != nil {
 		t.Error(err)
 	}
+	if got, want := txs.queueDepth.Counts()["t1"], int64(1); got != want {
+		t.Errorf("wrong QueueDepth variable: got = %v, want = %v", got, want)
+	}
 
 	// tx3 (gets rejected because it would exceed the local queue).
 	_, _, err3 := txs.Wait(context.Background(), "t1 where1", "t1")
@@ -191,6 +201,70 @@ func TestTxSerializer(t *testing.T) {
 	if got, want := txs.queueExceeded.Counts()["t1"], int64(1); got != want {
 		t.Errorf("variable not incremented: got = %v, want = %v", got, want)
 	}
+	// The queue is empty again, and the wait of tx2 was timed.
+	if got, want := txs.queueDepth.Counts()["t1"], int64(0); got != want {
+		t.Errorf("wrong QueueDepth variable: got = %v, want = %v", got, want)
+	}
+	if got, want := txs.waitTimings.Counts()["TxSerializerTest.t1"], int64(1); got != want {
+		t.Errorf("wrong WaitTime variable: got = %v, want = %v", got, want)
+	}
+}
+
+func TestTxSerializerKeyFromExpression(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.KeyExpressions = map[string]string{
+		"product": "left(sku, 4)",
+		"orders":  "concat(region, '-', store_id)",
+		"invalid": "left(sku,",
+	}
+	env := vtenv.NewTestEnv()
+	txs := New(tabletenv.NewEnv(env, cfg, "TxSerializerTest"))
+
+	testcases := []struct {
+		table    string
+		where    string
+		bindVars map[string]*querypb.BindVariable
+		key      string
+	}{{
+		table: "product",
+		where: "sku = 'ABCD-123' and price > 10",
+		key:   "product where left(sku, 4) = 'ABCD'",
+	}, {
+		table:    "product",
+		where:    "id = 1 and :sku = sku",
+		bindVars: map[string]*querypb.BindVariable{"sku": sqltypes.StringBindVariable("ABCD-456")},
+		key:      "product where left(sku, 4) = 'ABCD'",
+	}, {
+		table: "orders",
+		where: "store_id = 12 and region = 'eu'",
+		key:   "orders where concat(region, '-', store_id) = 'eu-12'",
+	}, {
+		// Not all the columns of the expression are set.
+		table: "orders",
+		where: "store_id = 12",
+	}, {
+		// The columns must be set with equalities.
+		table: "product",
+		where: "sku in ('ABCD-123', 'ABCD-456')",
+	}, {
+		table: "product",
+		where: "sku = 'ABCD-123' or id = 1",
+	}, {
+		table: "invalid",
+		where: "sku = 'ABCD-123'",
+	}, {
+		table: "customer",
+		where: "sku = 'ABCD-123'",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.table+" "+tc.where, func(t *testing.T) {
+			where, err := env.Parser().ParseExpr(tc.where)
+			require.NoError(t, err)
+			key, ok := txs.KeyFromExpression(tc.table, where, tc.bindVars)
+			assert.Equal(t, tc.key != "", ok)
+			assert.Equal(t, tc.key, key)
+		})
+	}
 }
 
 func TestTxSerializer_ConcurrentTransactions(t *testing.T) {
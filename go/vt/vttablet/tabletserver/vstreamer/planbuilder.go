@@ -81,6 +81,12 @@ const (
 	NotEqual
 	// IsNotNull is used to filter a column if it is NULL
 	IsNotNull
+	// IsNull is used to filter a column if it is not NULL
+	IsNull
+	// In is used to filter a comparable column if not in a list of values
+	In
+	// NotIn is used to filter a comparable column if in a list of values
+	NotIn
 )
 
 // Filter contains opcodes for filtering.
@@ -88,6 +94,8 @@ type Filter struct {
 	Opcode Opcode
 	ColNum int
 	Value  sqltypes.Value
+	// Values is the list of values for In and NotIn.
+	Values []sqltypes.Value
 
 	// Parameters for VindexMatch.
 	// Vindex, VindexColumns and KeyRange, if set, will be used
@@ -208,6 +216,25 @@ func compare(comparison Opcode, columnValue, filterValue sqltypes.Value, collati
 	return false, nil
 }
 
+// compareIn returns true if the value of the column is in the list of values
+// of the Filter for In, or not in it for NotIn.
+func compareIn(comparison Opcode, columnValue sqltypes.Value, filterValues []sqltypes.Value, collationEnv *collations.Environment, charset collations.ID) (bool, error) {
+	// use null semantics: return false if the value is null
+	if columnValue.IsNull() {
+		return false, nil
+	}
+	for _, filterValue := range filterValues {
+		match, err := compare(Equal, columnValue, filterValue, collationEnv, charset)
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return comparison == In, nil
+		}
+	}
+	return comparison == NotIn, nil
+}
+
 // filter filters the row against the plan. It returns false if the row did not match.
 // The output of the filtering operation is stored in the 'result' argument because
 // filtering cannot be performed in-place. The result argument must be a slice of
@@ -230,6 +257,18 @@ func (plan *Plan) filter(values, result []sqltypes.Value, charsets []collations.
 			if values[filter.ColNum].IsNull() {
 				return false, nil
 			}
+		case IsNull:
+			if !values[filter.ColNum].IsNull() {
+				return false, nil
+			}
+		case In, NotIn:
+			match, err := compareIn(filter.Opcode, values[filter.ColNum], filter.Values, plan.env.CollationEnv(), charsets[filter.ColNum])
+			if err != nil {
+				return false, err
+			}
+			if !match {
+				return false, nil
+			}
 		default:
 			match, err := compare(filter.Opcode, values[filter.ColNum], filter.Value, plan.env.CollationEnv(), charsets[filter.ColNum])
 			if err != nil {
@@ -514,46 +553,49 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 	for _, expr := range exprs {
 		switch expr := expr.(type) {
 		case *sqlparser.ComparisonExpr:
+			if expr.Operator == sqlparser.InOp || expr.Operator == sqlparser.NotInOp {
+				if err := plan.analyzeInFilter(expr); err != nil {
+					return err
+				}
+				continue
+			}
 			opcode, err := getOpcode(expr)
 			if err != nil {
 				return err
 			}
-			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
-			}
-			if !qualifiedName.Qualifier.IsEmpty() {
-				return fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
+			colnum, err := plan.filterColumn(expr.Left, expr)
+			if err != nil {
+				return err
 			}
-			colnum, err := findColumn(plan.Table, qualifiedName.Name)
+			val, err := plan.filterValue(expr.Right, expr)
 			if err != nil {
 				return err
 			}
-			val, ok := expr.Right.(*sqlparser.Literal)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			plan.Filters = append(plan.Filters, Filter{
+				Opcode: opcode,
+				ColNum: colnum,
+				Value:  val,
+			})
+		case *sqlparser.BetweenExpr:
+			if !expr.IsBetween {
+				return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
 			}
-			// StrVal is varbinary, we do not support varchar since we would have to implement all collation types
-			if val.Type != sqlparser.IntVal && val.Type != sqlparser.StrVal {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			colnum, err := plan.filterColumn(expr.Left, expr)
+			if err != nil {
+				return err
 			}
-			pv, err := evalengine.Translate(val, &evalengine.Config{
-				Collation:   plan.env.CollationEnv().DefaultConnectionCharset(),
-				Environment: plan.env,
-			})
+			from, err := plan.filterValue(expr.From, expr)
 			if err != nil {
 				return err
 			}
-			env := evalengine.EmptyExpressionEnv(plan.env)
-			resolved, err := env.Evaluate(pv)
+			to, err := plan.filterValue(expr.To, expr)
 			if err != nil {
 				return err
 			}
-			plan.Filters = append(plan.Filters, Filter{
-				Opcode: opcode,
-				ColNum: colnum,
-				Value:  resolved.Value(plan.env.CollationEnv().DefaultConnectionCharset()),
-			})
+			plan.Filters = append(plan.Filters,
+				Filter{Opcode: GreaterThanEqual, ColNum: colnum, Value: from},
+				Filter{Opcode: LessThanEqual, ColNum: colnum, Value: to},
+			)
 		case *sqlparser.FuncExpr:
 			if !expr.Name.EqualString("in_keyrange") {
 				return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
@@ -562,22 +604,21 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 				return err
 			}
 		case *sqlparser.IsExpr: // Needed for CreateLookupVindex with ignore_nulls
-			if expr.Right != sqlparser.IsNotNullOp {
+			var opcode Opcode
+			switch expr.Right {
+			case sqlparser.IsNotNullOp:
+				opcode = IsNotNull
+			case sqlparser.IsNullOp:
+				opcode = IsNull
+			default:
 				return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
 			}
-			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
-			}
-			if !qualifiedName.Qualifier.IsEmpty() {
-				return fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
-			}
-			colnum, err := findColumn(plan.Table, qualifiedName.Name)
+			colnum, err := plan.filterColumn(expr.Left, expr)
 			if err != nil {
 				return err
 			}
 			plan.Filters = append(plan.Filters, Filter{
-				Opcode: IsNotNull,
+				Opcode: opcode,
 				ColNum: colnum,
 			})
 		default:
@@ -587,6 +628,72 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 	return nil
 }
 
+// analyzeInFilter analyzes a "col in (...)" or "col not in (...)" filter.
+func (plan *Plan) analyzeInFilter(expr *sqlparser.ComparisonExpr) error {
+	colnum, err := plan.filterColumn(expr.Left, expr)
+	if err != nil {
+		return err
+	}
+	tuple, ok := expr.Right.(sqlparser.ValTuple)
+	if !ok {
+		return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+	}
+	filter := Filter{
+		Opcode: In,
+		ColNum: colnum,
+		Values: make([]sqltypes.Value, 0, len(tuple)),
+	}
+	if expr.Operator == sqlparser.NotInOp {
+		filter.Opcode = NotIn
+	}
+	for _, valExpr := range tuple {
+		val, err := plan.filterValue(valExpr, expr)
+		if err != nil {
+			return err
+		}
+		filter.Values = append(filter.Values, val)
+	}
+	plan.Filters = append(plan.Filters, filter)
+	return nil
+}
+
+// filterColumn returns the column number of the column of a filter.
+func (plan *Plan) filterColumn(colExpr, filter sqlparser.Expr) (int, error) {
+	qualifiedName, ok := colExpr.(*sqlparser.ColName)
+	if !ok {
+		return 0, fmt.Errorf("unexpected: %v", sqlparser.String(filter))
+	}
+	if !qualifiedName.Qualifier.IsEmpty() {
+		return 0, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
+	}
+	return findColumn(plan.Table, qualifiedName.Name)
+}
+
+// filterValue returns the value of a literal of a filter.
+func (plan *Plan) filterValue(valExpr, filter sqlparser.Expr) (sqltypes.Value, error) {
+	val, ok := valExpr.(*sqlparser.Literal)
+	if !ok {
+		return sqltypes.Value{}, fmt.Errorf("unexpected: %v", sqlparser.String(filter))
+	}
+	// StrVal is varbinary, we do not support varchar since we would have to implement all collation types
+	if val.Type != sqlparser.IntVal && val.Type != sqlparser.StrVal {
+		return sqltypes.Value{}, fmt.Errorf("unexpected: %v", sqlparser.String(filter))
+	}
+	pv, err := evalengine.Translate(val, &evalengine.Config{
+		Collation:   plan.env.CollationEnv().DefaultConnectionCharset(),
+		Environment: plan.env,
+	})
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	env := evalengine.EmptyExpressionEnv(plan.env)
+	resolved, err := env.Evaluate(pv)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	return resolved.Value(plan.env.CollationEnv().DefaultConnectionCharset()), nil
+}
+
 // splitAndExpression breaks up the Expr into AND-separated conditions
 // and appends them to filters, which can be shuffled and recombined
 // as needed.
@@ -728,6 +728,41 @@ func TestPlanBuilderFilterComparison(t *testing.T) {
 			{Opcode: Equal, ColNum: 0, Value: sqltypes.NewInt64(2)},
 			{Opcode: NotEqual, ColNum: 1, Value: sqltypes.NewVarChar("xyz")},
 		},
+	}, {
+		name:       "in",
+		inFilter:   "select * from t1 where id in (1, 2)",
+		outFilters: []Filter{{Opcode: In, ColNum: 0, Values: []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}}},
+	}, {
+		name:       "not-in",
+		inFilter:   "select * from t1 where val not in ('abc', 'xyz')",
+		outFilters: []Filter{{Opcode: NotIn, ColNum: 1, Values: []sqltypes.Value{sqltypes.NewVarChar("abc"), sqltypes.NewVarChar("xyz")}}},
+	}, {
+		name:     "in-with-column",
+		inFilter: "select * from t1 where id in (1, val)",
+		outErr:   "unexpected: id in (1, val)",
+	}, {
+		name:       "is-null",
+		inFilter:   "select * from t1 where val is null",
+		outFilters: []Filter{{Opcode: IsNull, ColNum: 1}},
+	}, {
+		name:       "is-not-null",
+		inFilter:   "select * from t1 where val is not null",
+		outFilters: []Filter{{Opcode: IsNotNull, ColNum: 1}},
+	}, {
+		name:     "is-true",
+		inFilter: "select * from t1 where val is true",
+		outErr:   "unsupported constraint: val is true",
+	}, {
+		name:     "between",
+		inFilter: "select * from t1 where id between 1 and 10",
+		outFilters: []Filter{
+			{Opcode: GreaterThanEqual, ColNum: 0, Value: sqltypes.NewInt64(1)},
+			{Opcode: LessThanEqual, ColNum: 0, Value: sqltypes.NewInt64(10)},
+		},
+	}, {
+		name:     "not-between",
+		inFilter: "select * from t1 where id not between 1 and 10",
+		outErr:   "unsupported constraint: id not between 1 and 10",
 	}}
 
 	for _, tcase := range testcases {
@@ -782,3 +817,29 @@ func TestCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareIn(t *testing.T) {
+	int1 := sqltypes.NewInt32(1)
+	int2 := sqltypes.NewInt32(2)
+	int3 := sqltypes.NewInt32(3)
+	testcases := []struct {
+		opcode       Opcode
+		columnValue  sqltypes.Value
+		filterValues []sqltypes.Value
+		want         bool
+	}{
+		{opcode: In, columnValue: int1, filterValues: []sqltypes.Value{int1, int2}, want: true},
+		{opcode: In, columnValue: int3, filterValues: []sqltypes.Value{int1, int2}, want: false},
+		{opcode: In, columnValue: sqltypes.NULL, filterValues: []sqltypes.Value{int1, int2}, want: false},
+		{opcode: NotIn, columnValue: int1, filterValues: []sqltypes.Value{int1, int2}, want: false},
+		{opcode: NotIn, columnValue: int3, filterValues: []sqltypes.Value{int1, int2}, want: true},
+		{opcode: NotIn, columnValue: sqltypes.NULL, filterValues: []sqltypes.Value{int1, int2}, want: false},
+	}
+	for _, tc := range testcases {
+		t.Run("", func(t *testing.T) {
+			got, err := compareIn(tc.opcode, tc.columnValue, tc.filterValues, collations.MySQL8(), collations.CollationUtf8mb4ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
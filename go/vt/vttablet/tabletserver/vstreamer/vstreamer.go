@@ -69,6 +69,7 @@ type vstreamer struct {
 	se           *schema.Engine
 	startPos     string
 	filter       *binlogdatapb.Filter
+	sourceFilter *binlog.SourceFilter
 	send         func([]*binlogdatapb.VEvent) error
 	throttlerApp throttlerapp.Name
 
@@ -82,6 +83,9 @@ type vstreamer struct {
 	format  mysql.BinlogFormat
 	pos     replication.Position
 	stopPos string
+	// skipRows is set by parseEvent if the current transaction doesn't
+	// match the source filter, in which case its row events aren't sent.
+	skipRows bool
 
 	phase string
 	vse   *Engine
@@ -111,14 +115,27 @@ type streamerPlan struct {
 //	"select * from t where in_keyrange('-80')", same as "-80",
 //	"select * from t where in_keyrange(col1, 'hash', '-80')",
 //	"select col1, col2 from t where...",
-//	"select col1, keyspace_id() from t where...".
+//	"select col1, keyspace_id() from t where...",
+//	"select col1, col2 from t where col1 in (1, 2) and col2 is not null",
+//	"select col1, col2 from t where col1 between 1 and 10".
 //	Only "in_keyrange" and limited comparison operators (see enum Opcode in planbuilder.go) are supported in the where clause.
+//	The operands of the comparisons must be a column of the table and int or string literals.
 //	Other constructs like joins, group by, etc. are not supported.
 //
+//	The SourceServerIds and SourceDomains of the filter restrict the row events to the
+//	ones of the transactions that originated on these servers or MariaDB GTID domains.
+//
 // vschema: the current vschema. This value can later be changed through the SetVSchema method.
 // send: callback function to send events.
 func newVStreamer(ctx context.Context, cp dbconfigs.Connector, se *schema.Engine, startPos string, stopPos string, filter *binlogdatapb.Filter, vschema *localVSchema, throttlerApp throttlerapp.Name, send func([]*binlogdatapb.VEvent) error, phase string, vse *Engine) *vstreamer {
 	ctx, cancel := context.WithCancel(ctx)
+	var sourceFilter *binlog.SourceFilter
+	if len(filter.GetSourceServerIds()) > 0 || len(filter.GetSourceDomains()) > 0 {
+		sourceFilter = &binlog.SourceFilter{
+			ServerIDs: filter.SourceServerIds,
+			Domains:   filter.SourceDomains,
+		}
+	}
 	return &vstreamer{
 		ctx:          ctx,
 		cancel:       cancel,
@@ -128,6 +145,7 @@ func newVStreamer(ctx context.Context, cp dbconfigs.Connector, se *schema.Engine
 		stopPos:      stopPos,
 		throttlerApp: throttlerApp,
 		filter:       filter,
+		sourceFilter: sourceFilter,
 		send:         send,
 		vevents:      make(chan *localVSchema, 1),
 		vschema:      vschema,
@@ -433,7 +451,11 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent) ([]*binlogdatapb.VEvent, e
 		return nil, fmt.Errorf("got a real event before FORMAT_DESCRIPTION_EVENT: %#v", ev)
 	}
 
-	// Strip the checksum, if any. We don't actually verify the checksum, so discard it.
+	// Verify and strip the checksum, if any.
+	if err := mysql.VerifyBinlogEventChecksum(vs.format, ev); err != nil {
+		vs.vse.vstreamerChecksumErrors.Add(1)
+		return nil, fmt.Errorf("invalid binlog event: %v, event data: %#v", err, ev)
+	}
 	ev, _, err := ev.StripChecksum(vs.format)
 	if err != nil {
 		return nil, fmt.Errorf("can't strip checksum from binlog event: %v, event data: %#v", err, ev)
@@ -452,6 +474,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent) ([]*binlogdatapb.VEvent, e
 			})
 		}
 		vs.pos = replication.AppendGTID(vs.pos, gtid)
+		vs.skipRows = !vs.sourceFilter.Matches(ev.ServerID(), gtid)
 	case ev.IsXID():
 		vevents = append(vevents, &binlogdatapb.VEvent{
 			Type: binlogdatapb.VEventType_GTID,
@@ -630,7 +653,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent) ([]*binlogdatapb.VEvent, e
 			}
 			vevents = append(vevents, vevent)
 
-		} else {
+		} else if !vs.skipRows {
 			vevents, err = vs.processRowEvent(vevents, plan, rows)
 		}
 		if err != nil {
@@ -641,15 +664,23 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent) ([]*binlogdatapb.VEvent, e
 			return nil, fmt.Errorf("compressed transaction payload events are not supported with database flavor %s",
 				vs.vse.env.Config().DB.Flavor)
 		}
-		tpevents, err := ev.TransactionPayload(vs.format)
+		tp, err := ev.TransactionPayload(vs.format)
 		if err != nil {
 			return nil, err
 		}
+		defer tp.Close()
 		// Events inside the payload don't have their own checksum.
 		ogca := vs.format.ChecksumAlgorithm
 		defer func() { vs.format.ChecksumAlgorithm = ogca }()
 		vs.format.ChecksumAlgorithm = mysql.BinlogChecksumAlgOff
-		for _, tpevent := range tpevents {
+		for {
+			tpevent, err := tp.GetNextEvent()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
 			tpvevents, err := vs.parseEvent(tpevent)
 			if err != nil {
 				return nil, vterrors.Wrap(err, "failed to parse transaction payload's internal event")
@@ -767,9 +798,21 @@ func (vs *vstreamer) buildTablePlan(id uint64, tm *mysql.TableMap) (*binlogdatap
 
 func (vs *vstreamer) buildTableColumns(tm *mysql.TableMap) ([]*querypb.Field, error) {
 	var fields []*querypb.Field
-	var txtFieldIdx int
 	for i, typ := range tm.Types {
-		t, err := sqltypes.MySQLToType(typ, 0)
+		// The optional metadata tells the signedness of numeric columns, and
+		// with binlog_row_metadata=FULL which columns are ENUM and SET.
+		var flags int64
+		if tm.IsUnsigned(i) {
+			flags |= int64(querypb.MySqlFlag_UNSIGNED_FLAG)
+		}
+		if tm.EnumSetValues != nil && tm.EnumSetValues[i] != nil {
+			if tm.Metadata[i]>>8 == uint16(mysqlbinlog.TypeSet) {
+				flags |= int64(querypb.MySqlFlag_SET_FLAG)
+			} else {
+				flags |= int64(querypb.MySqlFlag_ENUM_FLAG)
+			}
+		}
+		t, err := sqltypes.MySQLToType(typ, flags)
 		if err != nil {
 			return nil, fmt.Errorf("unsupported type: %d, position: %d", typ, i)
 		}
@@ -777,25 +820,34 @@ func (vs *vstreamer) buildTableColumns(tm *mysql.TableMap) ([]*querypb.Field, er
 		// column if one was provided in the event's optional metadata (MySQL only
 		// provides this for text based columns).
 		var coll collations.ID
-		switch {
-		case sqltypes.IsText(t) && len(tm.ColumnCollationIDs) > txtFieldIdx:
-			coll = tm.ColumnCollationIDs[txtFieldIdx]
-			txtFieldIdx++
-		case t == sqltypes.TypeJSON:
+		if id, ok := tm.ColumnCollationID(i); ok {
+			coll = id
+		} else if t == sqltypes.TypeJSON {
 			// JSON is a blob at this (storage) layer -- vs the connection/query serving
 			// layer which CollationForType seems primarily concerned about and JSON at
 			// the response layer should be using utf-8 as that's the standard -- so we
 			// should NOT use utf8mb4 as the collation in MySQL for a JSON column is
 			// NULL, meaning there is not one (same as for int) and we should use binary.
 			coll = collations.CollationBinaryID
-		default: // Use the server defined default for the column's type
+		} else { // Use the server defined default for the column's type
 			coll = collations.CollationForType(t, vs.se.Environment().CollationEnv().DefaultConnectionCharset())
 		}
+		// With binlog_row_metadata=FULL the event is self-describing, so
+		// the fields are usable even if the table is not in the schema.
+		name := fmt.Sprintf("@%d", i+1)
+		if tm.ColumnNames != nil {
+			name = tm.ColumnNames[i]
+		}
+		var columnType string
+		if tm.EnumSetValues != nil && tm.EnumSetValues[i] != nil {
+			columnType = enumSetColumnType(t, tm.EnumSetValues[i])
+		}
 		fields = append(fields, &querypb.Field{
-			Name:    fmt.Sprintf("@%d", i+1),
-			Type:    t,
-			Charset: uint32(coll),
-			Flags:   mysql.FlagsForColumn(t, coll),
+			Name:       name,
+			Type:       t,
+			Charset:    uint32(coll),
+			Flags:      mysql.FlagsForColumn(t, coll),
+			ColumnType: columnType,
 		})
 	}
 	st, err := vs.se.GetTableForPos(sqlparser.NewIdentifierCS(tm.Name), replication.EncodePosition(vs.pos))
@@ -840,6 +892,25 @@ func (vs *vstreamer) buildTableColumns(tm *mysql.TableMap) ([]*querypb.Field, er
 	return fieldsCopy, nil
 }
 
+// enumSetColumnType returns the column type of an ENUM or SET column, like
+// enum('a','b'), from its string values.
+func enumSetColumnType(t querypb.Type, values []string) string {
+	buf := bytes.NewBuffer(nil)
+	if t == sqltypes.Set {
+		buf.WriteString("set(")
+	} else {
+		buf.WriteString("enum(")
+	}
+	for i, value := range values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		sqltypes.NewVarChar(value).EncodeSQL(buf)
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
 func getExtColInfos(ctx context.Context, cp dbconfigs.Connector, se *schema.Engine, table, database string) (map[string]*extColInfo, error) {
 	extColInfos := make(map[string]*extColInfo)
 	conn, err := cp.Connect(ctx)
@@ -910,7 +981,7 @@ func (vs *vstreamer) processJournalEvent(vevents []*binlogdatapb.VEvent, plan *s
 	}
 nextrow:
 	for _, row := range rows.Rows {
-		afterOK, afterValues, _, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns)
+		afterOK, afterValues, _, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -948,11 +1019,12 @@ nextrow:
 func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *streamerPlan, rows mysql.Rows) ([]*binlogdatapb.VEvent, error) {
 	rowChanges := make([]*binlogdatapb.RowChange, 0, len(rows.Rows))
 	for _, row := range rows.Rows {
-		beforeOK, beforeValues, _, err := vs.extractRowAndFilter(plan, row.Identify, rows.IdentifyColumns, row.NullIdentifyColumns)
+		beforeOK, beforeValues, _, err := vs.extractRowAndFilter(plan, row.Identify, rows.IdentifyColumns, row.NullIdentifyColumns, nil)
 		if err != nil {
 			return nil, err
 		}
-		afterOK, afterValues, partial, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns)
+		partialJSON := partialJSONColumns(plan, rows.DataColumns, row.JSONPartialValues)
+		afterOK, afterValues, partial, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns, partialJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -973,6 +1045,7 @@ func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *strea
 					Cols:  rows.DataColumns.Bits(),
 				}
 			}
+			rowChange.JsonPartialValues = jsonPartialValues(plan, partialJSON)
 		}
 		rowChanges = append(rowChanges, rowChange)
 	}
@@ -1017,7 +1090,9 @@ func (vs *vstreamer) rebuildPlans() error {
 //   - true, if row needs to be skipped because of workflow filter rules
 //   - data values, array of one value per column
 //   - true, if the row image was partial (i.e. binlog_row_image=noblob and dml doesn't update one or more blob/text columns)
-func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataColumns, nullColumns mysql.Bitmap) (bool, []sqltypes.Value, bool, error) {
+//
+// The columns set in partialJSON hold a partial JSON update, which is returned as an SQL expression.
+func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataColumns, nullColumns mysql.Bitmap, partialJSON []bool) (bool, []sqltypes.Value, bool, error) {
 	if len(data) == 0 {
 		return false, nil, false, nil
 	}
@@ -1039,7 +1114,14 @@ func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataCo
 			valueIndex++
 			continue
 		}
-		value, l, err := mysqlbinlog.CellValue(data, pos, plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum], plan.Table.Fields[colNum])
+		var value sqltypes.Value
+		var l int
+		var err error
+		if partialJSON != nil && partialJSON[colNum] {
+			value, l, err = mysqlbinlog.JSONDiffCellValue(data, pos, plan.TableMap.Metadata[colNum])
+		} else {
+			value, l, err = mysqlbinlog.CellValue(data, pos, plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum], plan.Table.Fields[colNum])
+		}
 		if err != nil {
 			log.Errorf("extractRowAndFilter: %s, table: %s, colNum: %d, fields: %+v, current values: %+v",
 				err, plan.Table.Name, colNum, plan.Table.Fields, values)
@@ -1056,6 +1138,43 @@ func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataCo
 	return ok, filtered, partial, err
 }
 
+// partialJSONColumns returns which columns of the table hold a partial JSON
+// update in the after image of a row, or nil if none do. The bits of
+// jsonPartialValues are indexed by the JSON columns present in the image.
+func partialJSONColumns(plan *streamerPlan, dataColumns, jsonPartialValues mysql.Bitmap) []bool {
+	if jsonPartialValues.BitCount() == 0 {
+		return nil
+	}
+	partialJSON := make([]bool, dataColumns.Count())
+	jsonIndex := 0
+	for colNum := 0; colNum < dataColumns.Count(); colNum++ {
+		if !dataColumns.Bit(colNum) || plan.TableMap.Types[colNum] != mysqlbinlog.TypeJSON {
+			continue
+		}
+		partialJSON[colNum] = jsonPartialValues.Bit(jsonIndex)
+		jsonIndex++
+	}
+	return partialJSON
+}
+
+// jsonPartialValues returns the bitmap of the values sent for the plan that
+// hold a partial JSON update, or nil if none do.
+func jsonPartialValues(plan *streamerPlan, partialJSON []bool) *binlogdatapb.RowChange_Bitmap {
+	if partialJSON == nil {
+		return nil
+	}
+	bitmap := mysql.NewServerBitmap(len(plan.ColExprs))
+	for i, colExpr := range plan.ColExprs {
+		if colExpr.ColNum >= 0 && colExpr.Vindex == nil && partialJSON[colExpr.ColNum] {
+			bitmap.Set(i, true)
+		}
+	}
+	return &binlogdatapb.RowChange_Bitmap{
+		Count: int64(bitmap.Count()),
+		Cols:  bitmap.Bits(),
+	}
+}
+
 func wrapError(err error, stopPos replication.Position, vse *Engine) error {
 	if err != nil {
 		vse.vstreamersEndedWithErrors.Add(1)
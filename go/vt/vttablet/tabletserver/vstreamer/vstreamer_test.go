@@ -957,6 +957,72 @@ func TestRegexp(t *testing.T) {
 	ts.Run()
 }
 
+// TestSourceFilter tests that the row events of the transactions that didn't
+// originate on the servers of the source filter aren't sent.
+func TestSourceFilter(t *testing.T) {
+	qr, err := env.Mysqld.FetchSuperQuery(context.Background(), "select @@server_id")
+	require.NoError(t, err)
+	serverID, err := qr.Rows[0][0].ToUint32()
+	require.NoError(t, err)
+	newFilter := func(serverIDs ...uint32) *binlogdatapb.Filter {
+		return &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match: "/.*/",
+			}},
+			SourceServerIds: serverIDs,
+		}
+	}
+
+	t.Run("match", func(t *testing.T) {
+		ts := &TestSpec{
+			t: t,
+			ddls: []string{
+				"create table stream1(id int, val varbinary(128), primary key(id))",
+			},
+			options: &TestSpecOptions{
+				filter: newFilter(serverID+1, serverID),
+			},
+		}
+		defer ts.Close()
+		ts.Init()
+		ts.tests = [][]*TestQuery{{
+			{"begin", nil},
+			{"insert into stream1 values (1, 'aaa')", nil},
+			{"update stream1 set val='bbb' where id = 1", nil},
+			{"commit", nil},
+		}}
+		ts.Run()
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		ts := &TestSpec{
+			t: t,
+			ddls: []string{
+				"create table stream1(id int, val varbinary(128), primary key(id))",
+			},
+			options: &TestSpecOptions{
+				filter: newFilter(serverID + 1),
+			},
+		}
+		defer ts.Close()
+		ts.Init()
+		// The transactions are still sent, so that the position advances.
+		ts.tests = [][]*TestQuery{{
+			{"begin", nil},
+			{"insert into stream1 values (1, 'aaa')", []TestRowEvent{
+				{event: ts.fieldEvents["stream1"].String()},
+			}},
+			{"update stream1 set val='bbb' where id = 1", noEvents},
+			{"commit", nil},
+		}, {
+			{"begin", nil},
+			{"delete from stream1 where id = 1", noEvents},
+			{"commit", nil},
+		}}
+		ts.Run()
+	})
+}
+
 func TestREKeyRange(t *testing.T) {
 	filter := &binlogdatapb.Filter{
 		Rules: []*binlogdatapb.Rule{{
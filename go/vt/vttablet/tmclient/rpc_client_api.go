@@ -258,6 +258,21 @@ type TabletManagerClient interface {
 	// Throttler
 	CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error)
 
+	//
+	// Distributed transaction related methods
+	//
+
+	// GetUnresolvedTransactions returns the distributed transactions older than
+	// abandonAge seconds for which the tablet is the metadata manager.
+	GetUnresolvedTransactions(ctx context.Context, tablet *topodatapb.Tablet, abandonAge int64) ([]*querypb.TransactionMetadata, error)
+
+	// ReadTransaction returns the metadata of a distributed transaction from
+	// its metadata manager.
+	ReadTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string) (*querypb.TransactionMetadata, error)
+
+	// ConcludeTransaction resolves a distributed transaction on the tablet.
+	ConcludeTransaction(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ConcludeTransactionRequest) error
+
 	//
 	// Management methods
 	//
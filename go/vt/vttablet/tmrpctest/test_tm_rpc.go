@@ -1354,6 +1354,80 @@ func tmRPCTestCheckThrottler(ctx context.Context, t *testing.T, client tmclient.
 	expectHandleRPCPanic(t, "CheckThrottler", false /*verbose*/, err)
 }
 
+//
+// Distributed transaction related methods
+//
+
+var testDtid = "aa:123"
+var testTransactionMetadata = &querypb.TransactionMetadata{
+	Dtid:        testDtid,
+	State:       querypb.TransactionState_COMMIT,
+	TimeCreated: 1234,
+	Participants: []*querypb.Target{{
+		Keyspace:   "ks",
+		Shard:      "80-",
+		TabletType: topodatapb.TabletType_PRIMARY,
+	}},
+}
+
+func (fra *fakeRPCTM) GetUnresolvedTransactions(ctx context.Context, abandonAgeSeconds int64) ([]*querypb.TransactionMetadata, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "GetUnresolvedTransactions abandonAge", abandonAgeSeconds, int64(60))
+	return []*querypb.TransactionMetadata{testTransactionMetadata}, nil
+}
+
+func (fra *fakeRPCTM) ReadTransaction(ctx context.Context, req *tabletmanagerdatapb.ReadTransactionRequest) (*querypb.TransactionMetadata, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "ReadTransaction dtid", req.Dtid, testDtid)
+	return testTransactionMetadata, nil
+}
+
+var testConcludeTransactionCalled = false
+
+func (fra *fakeRPCTM) ConcludeTransaction(ctx context.Context, req *tabletmanagerdatapb.ConcludeTransactionRequest) error {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "ConcludeTransaction dtid", req.Dtid, testDtid)
+	compareBool(fra.t, "ConcludeTransaction commit", req.Commit)
+	testConcludeTransactionCalled = true
+	return nil
+}
+
+func tmRPCTestGetUnresolvedTransactions(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	transactions, err := client.GetUnresolvedTransactions(ctx, tablet, 60)
+	compareError(t, "GetUnresolvedTransactions", err, transactions, []*querypb.TransactionMetadata{testTransactionMetadata})
+}
+
+func tmRPCTestGetUnresolvedTransactionsPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.GetUnresolvedTransactions(ctx, tablet, 60)
+	expectHandleRPCPanic(t, "GetUnresolvedTransactions", false /*verbose*/, err)
+}
+
+func tmRPCTestReadTransaction(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	metadata, err := client.ReadTransaction(ctx, tablet, testDtid)
+	compareError(t, "ReadTransaction", err, metadata, testTransactionMetadata)
+}
+
+func tmRPCTestReadTransactionPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.ReadTransaction(ctx, tablet, testDtid)
+	expectHandleRPCPanic(t, "ReadTransaction", false /*verbose*/, err)
+}
+
+func tmRPCTestConcludeTransaction(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	err := client.ConcludeTransaction(ctx, tablet, &tabletmanagerdatapb.ConcludeTransactionRequest{Dtid: testDtid, Commit: true})
+	compareError(t, "ConcludeTransaction", err, true, testConcludeTransactionCalled)
+}
+
+func tmRPCTestConcludeTransactionPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	err := client.ConcludeTransaction(ctx, tablet, &tabletmanagerdatapb.ConcludeTransactionRequest{Dtid: testDtid, Commit: true})
+	expectHandleRPCPanic(t, "ConcludeTransaction", true /*verbose*/, err)
+}
+
 //
 // RPC helpers
 //
@@ -1441,6 +1515,11 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	// Throttler related methods
 	tmRPCTestCheckThrottler(ctx, t, client, tablet, checkThrottlerRequest)
 
+	// Distributed transaction related methods
+	tmRPCTestGetUnresolvedTransactions(ctx, t, client, tablet)
+	tmRPCTestReadTransaction(ctx, t, client, tablet)
+	tmRPCTestConcludeTransaction(ctx, t, client, tablet)
+
 	//
 	// Tests panic handling everywhere now
 	//
@@ -1494,5 +1573,10 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestBackupPanic(ctx, t, client, tablet)
 	tmRPCTestRestoreFromBackupPanic(ctx, t, client, tablet, restoreFromBackupRequest)
 
+	// Distributed transaction related methods
+	tmRPCTestGetUnresolvedTransactionsPanic(ctx, t, client, tablet)
+	tmRPCTestReadTransactionPanic(ctx, t, client, tablet)
+	tmRPCTestConcludeTransactionPanic(ctx, t, client, tablet)
+
 	client.Close()
 }
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vttls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// reloadDelay is how long Watch waits for the changes of the files to
+// settle before reloading, so that a certificate and its key which are
+// replaced one after the other are loaded together.
+var reloadDelay = 100 * time.Millisecond
+
+// CertificateFiles are the files of a server certificate and its key.
+type CertificateFiles struct {
+	Cert string
+	Key  string
+	// CA, if set, is the file of the CA certificates which are sent to
+	// the clients after the certificate, to complete its chain.
+	CA string
+}
+
+// ReloadableCertificates are server certificates which are loaded from
+// their files again by Reload, for example when they are rotated, while
+// the server keeps running. The certificate of a connection is the first
+// one which is valid for the server name requested by the client with
+// SNI, or the first one if none is.
+type ReloadableCertificates struct {
+	files []CertificateFiles
+	certs atomic.Pointer[[]tls.Certificate]
+}
+
+// NewReloadableCertificates loads the certificates of files.
+func NewReloadableCertificates(files ...CertificateFiles) (*ReloadableCertificates, error) {
+	if len(files) == 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "no server certificate")
+	}
+	rc := &ReloadableCertificates{files: files}
+	if err := rc.Reload(); err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
+// Reload loads the certificates from their files again. The previous
+// certificates are kept if any of them fails to load.
+func (rc *ReloadableCertificates) Reload() error {
+	certs := make([]tls.Certificate, 0, len(rc.files))
+	for _, files := range rc.files {
+		cert, err := loadCertificateFiles(files)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	rc.certs.Store(&certs)
+	return nil
+}
+
+// GetCertificate returns the certificate for the client hello. It is
+// meant to be the GetCertificate of a tls.Config.
+func (rc *ReloadableCertificates) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certs := *rc.certs.Load()
+	if hello.ServerName != "" {
+		for i := range certs {
+			if hello.SupportsCertificate(&certs[i]) == nil {
+				return &certs[i], nil
+			}
+		}
+	}
+	return &certs[0], nil
+}
+
+// Watch reloads the certificates when their files change, until ctx is
+// done, and calls onReload with the result of each reload. The
+// directories of the files are watched, rather than the files, so that
+// the files which are replaced by a rename, like the mounted Kubernetes
+// secrets, are still watched afterwards.
+func (rc *ReloadableCertificates) Watch(ctx context.Context, onReload func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return vterrors.Wrapf(err, "failed to watch the certificate files")
+	}
+	dirs := make(map[string]bool)
+	for _, files := range rc.files {
+		for _, name := range []string{files.Cert, files.Key, files.CA} {
+			if name == "" {
+				continue
+			}
+			dir := filepath.Dir(name)
+			if dirs[dir] {
+				continue
+			}
+			dirs[dir] = true
+			if err := watcher.Add(dir); err != nil {
+				watcher.Close()
+				return vterrors.Wrapf(err, "failed to watch the certificate directory %s", dir)
+			}
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				reload = time.After(reloadDelay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onReload(err)
+			case <-reload:
+				reload = nil
+				onReload(rc.Reload())
+			}
+		}
+	}()
+	return nil
+}
+
+// loadCertificateFiles loads a certificate from its files, bypassing the
+// caches of ServerConfig so that the files are read again.
+func loadCertificateFiles(files CertificateFiles) (tls.Certificate, error) {
+	certB, err := os.ReadFile(files.Cert)
+	if err != nil {
+		return tls.Certificate{}, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "failed to read server cert file: %s", files.Cert)
+	}
+	keyB, err := os.ReadFile(files.Key)
+	if err != nil {
+		return tls.Certificate{}, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "failed to read key file: %s", files.Key)
+	}
+	if files.CA != "" {
+		caB, err := os.ReadFile(files.CA)
+		if err != nil {
+			return tls.Certificate{}, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "failed to read ca file: %s", files.CA)
+		}
+		certB = append(certB, caB...)
+	}
+
+	cert, err := tls.X509KeyPair(certB, keyB)
+	if err != nil {
+		return tls.Certificate{}, vterrors.Errorf(vtrpc.Code_NOT_FOUND, "failed to load tls certificate, cert %s, key: %s", files.Cert, files.Key)
+	}
+	// Parse the leaf once, instead of in every handshake to match the
+	// server name.
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, vterrors.Wrapf(err, "failed to parse tls certificate %s", files.Cert)
+	}
+	return cert, nil
+}
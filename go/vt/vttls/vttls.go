@@ -199,13 +199,37 @@ func ServerConfig(cert, key, ca, crl, serverCA string, minTLSVersion uint16) (*t
 	}
 	config.Certificates = *certificates
 
+	if err := setClientVerification(config, ca, crl); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ReloadableServerConfig returns the TLS config to use for a server to
+// accept client connections, which serves the certificates of certs.
+// The certificates can be reloaded without creating a new config.
+func ReloadableServerConfig(certs *ReloadableCertificates, ca, crl string, minTLSVersion uint16) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:     minTLSVersion,
+		GetCertificate: certs.GetCertificate,
+	}
+
+	if err := setClientVerification(config, ca, crl); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// setClientVerification sets up the server config to verify the
+// certificates of the clients against ca and crl, if specified.
+func setClientVerification(config *tls.Config, ca, crl string) error {
 	// if specified, load ca to validate client,
 	// and enforce clients present valid certs.
 	if ca != "" {
 		certificatePool, err := loadx509CertPool(ca)
 
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		config.ClientCAs = certificatePool
@@ -215,12 +239,12 @@ func ServerConfig(cert, key, ca, crl, serverCA string, minTLSVersion uint16) (*t
 	if crl != "" {
 		crlFunc, err := verifyPeerCertificateAgainstCRL(crl)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		config.VerifyPeerCertificate = crlFunc
 	}
 
-	return config, nil
+	return nil
 }
 
 var certPools = sync.Map{}
@@ -840,3 +840,58 @@ export const validateVersionShard = async (params: ValidateVersionShardParams) =
 
     return vtctldata.ValidateVersionShardResponse.create(result);
 };
+
+export interface FetchUnresolvedTransactionsParams {
+    clusterID: string;
+    keyspace: string;
+    // abandonAge is the age, in seconds, after which an unresolved
+    // transaction is returned.
+    abandonAge?: number;
+}
+
+export const fetchUnresolvedTransactions = async (params: FetchUnresolvedTransactionsParams) => {
+    const req = new URLSearchParams();
+    if (params.abandonAge) {
+        req.append('abandon_age', params.abandonAge.toString());
+    }
+
+    const { result } = await vtfetch(`/api/transactions/${params.clusterID}/${params.keyspace}?${req.toString()}`);
+
+    const err = vtctldata.GetUnresolvedTransactionsResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.GetUnresolvedTransactionsResponse.create(result);
+};
+
+export interface FetchTransactionInfoParams {
+    clusterID: string;
+    dtid: string;
+}
+
+export const fetchTransactionInfo = async (params: FetchTransactionInfoParams) => {
+    const { result } = await vtfetch(`/api/transaction/${params.clusterID}/${encodeURIComponent(params.dtid)}`);
+
+    const err = vtctldata.GetTransactionInfoResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.GetTransactionInfoResponse.create(result);
+};
+
+export interface ConcludeTransactionParams {
+    clusterID: string;
+    dtid: string;
+}
+
+export const concludeTransaction = async (params: ConcludeTransactionParams) => {
+    const { result } = await vtfetch(
+        `/api/transaction/${params.clusterID}/${encodeURIComponent(params.dtid)}/conclude`,
+        {
+            method: 'put',
+        }
+    );
+
+    const err = vtctldata.ConcludeTransactionResponse.verify(result);
+    if (err) throw Error(err);
+
+    return vtctldata.ConcludeTransactionResponse.create(result);
+};
@@ -78,6 +78,12 @@ import {
     GetFullStatusParams,
     validateVersionShard,
     ValidateVersionShardParams,
+    fetchUnresolvedTransactions,
+    FetchUnresolvedTransactionsParams,
+    fetchTransactionInfo,
+    FetchTransactionInfoParams,
+    concludeTransaction,
+    ConcludeTransactionParams,
 } from '../api/http';
 import { vtadmin as pb, vtctldata } from '../proto/vtadmin';
 import { formatAlias } from '../util/tablets';
@@ -619,3 +625,35 @@ export const useValidateVersionShard = (
         return validateVersionShard(params);
     }, options);
 };
+
+/**
+ * useUnresolvedTransactions is a query hook that fetches the unresolved distributed transactions of a keyspace.
+ */
+export const useUnresolvedTransactions = (
+    params: FetchUnresolvedTransactionsParams,
+    options?: UseQueryOptions<vtctldata.GetUnresolvedTransactionsResponse, Error> | undefined
+) => {
+    return useQuery(['transactions', params], () => fetchUnresolvedTransactions(params), { ...options });
+};
+
+/**
+ * useTransactionInfo is a query hook that fetches the metadata of a distributed transaction.
+ */
+export const useTransactionInfo = (
+    params: FetchTransactionInfoParams,
+    options?: UseQueryOptions<vtctldata.GetTransactionInfoResponse, Error> | undefined
+) => {
+    return useQuery(['transaction', params], () => fetchTransactionInfo(params), { ...options });
+};
+
+/**
+ * useConcludeTransaction is a mutate hook that force-resolves an unresolved distributed transaction.
+ */
+export const useConcludeTransaction = (
+    params: Parameters<typeof concludeTransaction>[0],
+    options?: UseMutationOptions<Awaited<ReturnType<typeof concludeTransaction>>, Error, ConcludeTransactionParams>
+) => {
+    return useMutation<Awaited<ReturnType<typeof concludeTransaction>>, Error, ConcludeTransactionParams>(() => {
+        return concludeTransaction(params);
+    }, options);
+};